@@ -0,0 +1,67 @@
+// Command migrate-org importa numa nova organização o ZIP da exportação completa de outra instância
+// (GET /api/v1/organization-exports/:exportId/download), para mover um cliente entre ambientes
+// auto-hospedados. O banco de dados de destino já deve estar migrado (inicie a API ao menos uma vez).
+//
+// Uso:
+//
+//	migrate-org -file organization_export_20261016_120000.zip [-name "Nova Organização"] [-dry-run]
+//
+// O resultado (ID da nova organização, registros importados e avisos) é impresso em JSON.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"phoenixgrc/backend/internal/bootstrap"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/orgimport"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	file := flag.String("file", "", "ZIP da exportação completa da organização")
+	name := flag.String("name", "", "Nome da nova organização (padrão: o nome da organização exportada)")
+	dryRun := flag.Bool("dry-run", false, "Valida e simula a importação sem gravar")
+	flag.Parse()
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	phxlog.Init(config.Cfg.LogLevel, config.Cfg.Environment)
+	if err := config.Validate(); err != nil {
+		phxlog.L.Fatal("Configuração inválida.", zap.Error(err))
+	}
+	if err := bootstrap.ConnectDatabase(); err != nil {
+		phxlog.L.Fatal("Falha ao conectar ao banco de dados.", zap.Error(err))
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		phxlog.L.Fatal("Falha ao abrir o arquivo da exportação.", zap.Error(err))
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		phxlog.L.Fatal("Falha ao ler o arquivo da exportação.", zap.Error(err))
+	}
+
+	result, err := orgimport.Import(context.Background(), database.GetDB(), f, info.Size(), orgimport.Options{OrganizationName: *name, DryRun: *dryRun})
+	if err != nil {
+		phxlog.L.Fatal("Falha na importação da organização.", zap.Error(err))
+	}
+	out, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(out))
+	if *dryRun {
+		phxlog.L.Info("Simulação concluída; nada foi gravado.")
+		return
+	}
+	phxlog.L.Info("Organização importada.", zap.String("organizationID", result.OrganizationID.String()))
+}
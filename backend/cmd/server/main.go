@@ -1,127 +1,136 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"phoenixgrc/backend/internal/apiusage"
 	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/avscan"
+	"phoenixgrc/backend/internal/bootstrap"
 	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/events"
+	"phoenixgrc/backend/internal/featureflags"
 	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/health"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/models"
 	"phoenixgrc/backend/internal/notifications"
 	"phoenixgrc/backend/internal/oauth2auth"
 	"phoenixgrc/backend/internal/router"
 	"phoenixgrc/backend/internal/samlauth"
+	"phoenixgrc/backend/internal/tracing"
+	"phoenixgrc/backend/pkg/config"
+	"phoenixgrc/backend/pkg/features"
 	phxlog "phoenixgrc/backend/pkg/log"
 
-	"crypto/rand"
-	"encoding/hex"
-
 	"go.uber.org/zap"
 )
 
-// checkAndGenerateKeys verifica as chaves de segurança essenciais.
-// Se elas estiverem ausentes ou com os valores padrão, gera novas chaves
-// e encerra a aplicação com instruções para o usuário.
-func checkAndGenerateKeys() {
-	log := phxlog.L.Named("KeyCheck")
-	jwtKey := os.Getenv("JWT_SECRET_KEY")
-	encryptionKey := os.Getenv("ENCRYPTION_KEY_HEX")
-
-	jwtDefault := "mudar_esta_chave_em_producao_com_um_valor_aleatorio_longo"
-	encryptionKeyDefault := "mudar_para_64_caracteres_hexadecimais_em_producao"
-
-	if jwtKey == "" || jwtKey == jwtDefault || encryptionKey == "" || encryptionKey == encryptionKeyDefault {
-		log.Warn("Chaves de segurança ausentes ou padrão detectadas. Gerando novas chaves.")
-
-		// Gerar nova JWT_SECRET_KEY (64 bytes, codificado em hex)
-		jwtBytes := make([]byte, 64)
-		if _, err := rand.Read(jwtBytes); err != nil {
-			log.Fatal("Falha ao gerar a chave JWT.", zap.Error(err))
-		}
-		newJwtKey := hex.EncodeToString(jwtBytes)
-
-		// Gerar nova ENCRYPTION_KEY_HEX (32 bytes, 64 caracteres hex)
-		encBytes := make([]byte, 32)
-		if _, err := rand.Read(encBytes); err != nil {
-			log.Fatal("Falha ao gerar a chave de criptografia.", zap.Error(err))
-		}
-		newEncKey := hex.EncodeToString(encBytes)
-
-		fmt.Println("------------------------------------------------------------------")
-		fmt.Println("ATENÇÃO: As chaves de segurança não foram configuradas.")
-		fmt.Println("Por favor, adicione as seguintes linhas ao seu arquivo .env:")
-		fmt.Println("------------------------------------------------------------------")
-		fmt.Printf("JWT_SECRET_KEY=%s\n", newJwtKey)
-		fmt.Printf("ENCRYPTION_KEY_HEX=%s\n", newEncKey)
-		fmt.Println("------------------------------------------------------------------")
-		fmt.Println("A aplicação será encerrada. Após atualizar o arquivo .env, inicie-a novamente.")
-		os.Exit(1)
-	}
-}
+// Estado usado no encerramento, preenchido por initializeServices.
+var (
+	jobPool         *jobs.Pool // nil com JOBS_WORKER_CONCURRENCY=0
+	shutdownTracing = func(context.Context) error { return nil }
+)
 
-// initializeServices coordena a inicialização de todos os serviços principais.
+// initializeServices coordena a inicialização de todos os serviços principais. As tarefas em segundo plano
+// (workers, agendadores, novas tentativas) param quando ctx é cancelado.
 // Retorna um erro se qualquer inicialização crítica falhar.
-func initializeServices() error {
-	phxlog.Init(os.Getenv("LOG_LEVEL"), os.Getenv("APP_ENV"))
+func initializeServices(ctx context.Context) error {
+	phxlog.Init(config.Cfg.LogLevel, config.Cfg.Environment)
 	log := phxlog.L.Named("Initialization")
 
 	// 1. Configuração e Chaves (Crítico)
-	checkAndGenerateKeys()
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	bootstrap.CheckKeys()
 	log.Info("Verificação de chaves de segurança concluída.")
 
+	// Rastreamento (não crítico): sem o exportador, a API sobe sem traces
+	if shutdown, err := tracing.Init(ctx, "phoenixgrc-api"); err != nil {
+		log.Error("Falha ao inicializar o rastreamento OpenTelemetry.", zap.Error(err))
+	} else {
+		shutdownTracing = shutdown
+		if tracing.Enabled() {
+			log.Info("Rastreamento OpenTelemetry inicializado.")
+		}
+	}
+
 	// 2. JWT (Crítico)
 	if err := auth.InitializeJWT(); err != nil {
 		return fmt.Errorf("falha ao inicializar JWT: %w", err)
 	}
+	health.Default.SetHealthy("jwt", true)
 	log.Info("JWT inicializado com sucesso.")
 
 	// 3. Banco de Dados (Crítico)
-	dbHost := os.Getenv("POSTGRES_HOST")
-	dbPort := os.Getenv("POSTGRES_PORT")
-	dbUser := os.Getenv("POSTGRES_USER")
-	dbPassword := os.Getenv("POSTGRES_PASSWORD")
-	dbName := os.Getenv("POSTGRES_DB")
-	dbSSLMode := os.Getenv("POSTGRES_SSLMODE")
-
-	if dbHost == "" {
-		dbHost = "db"
+	if err := bootstrap.ConnectDatabase(); err != nil {
+		return fmt.Errorf("falha ao conectar ao banco de dados: %w", err)
 	}
-	if dbPort == "" {
-		dbPort = "5432"
+	log.Info("Conexão com o banco de dados estabelecida com sucesso.")
+
+	// Tokens de um gateway de autenticação externo (JWT_GATEWAY_*) identificam o usuário pelo e-mail
+	auth.GatewayUserResolver = func(email string) (*models.User, error) {
+		var user models.User
+		if err := database.GetDB().Where("LOWER(email) = LOWER(?) AND is_active = ?", email, true).First(&user).Error; err != nil {
+			return nil, err
+		}
+		return &user, nil
 	}
-	if dbSSLMode == "" {
-		dbSSLMode = "disable"
+
+	features.Init(featureflags.Source{}, time.Duration(config.Cfg.FeatureFlagCacheTTLSeconds)*time.Second)
+	log.Info("Avaliação de feature flags inicializada.")
+
+	// 4. Serviços Opcionais/Não-Críticos: uma falha não impede a API de subir; o subsistema fica indisponível
+	// no /readyz e a inicialização é repetida em segundo plano
+	optionalServices := []struct {
+		name string
+		init func() error
+	}{
+		{"saml", samlauth.InitializeSAMLSPGlobalConfig},
+		{"oauth2", oauth2auth.InitializeOAuth2GlobalConfig},
+		{"file_storage", filestorage.InitFileStorage},
+		{"antivirus", avscan.InitScanner},
 	}
-	if dbUser == "" || dbPassword == "" || dbName == "" {
-		return fmt.Errorf("credenciais do banco de dados (POSTGRES_USER, POSTGRES_PASSWORD, POSTGRES_DB) devem ser definidas")
+	for _, service := range optionalServices {
+		if err := health.InitOptional(ctx, service.name, service.init); err == nil {
+			log.Info("Serviço opcional inicializado.", zap.String("service", service.name))
+		}
 	}
 
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
-		dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode)
+	notifications.InitEmailService()
+	log.Info("Serviço de e-mail inicializado.")
 
-	if err := database.ConnectDB(dsn); err != nil {
-		return fmt.Errorf("falha ao conectar ao banco de dados: %w", err)
-	}
-	log.Info("Conexão com o banco de dados estabelecida com sucesso.")
+	notifications.InitChannels()
 
-	// 4. Serviços Opcionais/Não-Críticos (registram avisos em caso de falha)
-	if err := samlauth.InitializeSAMLSPGlobalConfig(); err != nil {
-		return fmt.Errorf("falha ao inicializar a configuração global do SAML SP: %w", err)
-	}
-	log.Info("Configuração global do SAML SP inicializada.")
+	// E-mail, feature flags e limites de requisição são reaplicados na recarga da configuração (SIGHUP ou
+	// POST /api/v1/admin/config/reload)
+	bootstrap.RegisterReloadHooks()
 
-	if err := oauth2auth.InitializeOAuth2GlobalConfig(); err != nil {
-		return fmt.Errorf("falha ao inicializar a configuração global do OAuth2: %w", err)
+	bootstrap.RegisterJobHandlers()
+	if config.Cfg.JobsWorkerConcurrency > 0 {
+		jobPool = jobs.NewPool(database.GetDB(), config.Cfg.JobsWorkerConcurrency, config.Cfg.JobsPollInterval)
+		jobPool.Start(ctx)
+		log.Info("Workers da fila de jobs iniciados.", zap.Int("concurrency", config.Cfg.JobsWorkerConcurrency))
+	} else {
+		log.Info("Workers da fila de jobs desativados neste processo (JOBS_WORKER_CONCURRENCY=0).")
 	}
-	log.Info("Configuração global do OAuth2 inicializada.")
 
-	if err := filestorage.InitFileStorage(); err != nil {
-		return fmt.Errorf("inicialização do armazenamento de arquivos falhou: %w", err)
+	if config.Cfg.SchedulersEnabled {
+		bootstrap.StartSchedulers(ctx)
+	} else {
+		log.Info("Agendadores periódicos desativados neste processo (SCHEDULERS_ENABLED=false).")
 	}
-	log.Info("Armazenamento de arquivos inicializado.")
 
-	notifications.InitEmailService()
-	log.Info("Serviço de e-mail inicializado.")
+	apiusage.StartFlusher(ctx, 30*time.Second, config.Cfg.APIUsageHourlyLimit, config.Cfg.APIUsageAlertPercent)
+	log.Info("Gravação periódica de métricas de uso da API iniciada.")
 
 	return nil
 }
@@ -131,22 +140,84 @@ func main() {
 	// A chamada explícita não é mais necessária aqui.
 	// _ = config.LoadConfig()
 
+	// SIGINT/SIGTERM (ex: rolling deploy) iniciam o encerramento gracioso
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Inicializa todos os serviços. A aplicação encerra se um serviço crítico falhar.
-	if err := initializeServices(); err != nil {
+	if err := initializeServices(ctx); err != nil {
 		phxlog.L.Fatal("Falha na inicialização de serviço crítico.", zap.Error(err))
 	}
 
 	// Configura o roteador
 	appRouter := router.SetupRouter(phxlog.L)
+	bootstrap.HandleReloadSignal(ctx)
+
+	server := &http.Server{
+		Addr:              ":" + config.Cfg.Port,
+		Handler:           appRouter,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// Os streams SSE ficam abertos indefinidamente; são encerrados para não segurar o desligamento
+	server.RegisterOnShutdown(events.DefaultHub.Close)
+
+	go func() {
+		phxlog.L.Info("Iniciando o servidor", zap.String("port", config.Cfg.Port))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			phxlog.L.Fatal("Falha ao iniciar o servidor", zap.Error(err))
+		}
+	}()
+
+	<-ctx.Done()
+	stop() // Um segundo sinal encerra imediatamente
+	shutdown(server, config.Cfg.ShutdownDrainDelay, config.Cfg.ShutdownTimeout)
+}
+
+// shutdown encerra o servidor em ordem: retira a instância do /readyz e espera drainDelay, para o balanceador
+// e as sondas pararem de enviar requisições novas enquanto ela ainda as atende; depois, dentro de timeout,
+// para de aceitar conexões e espera as requisições em andamento, espera os jobs em andamento (os agendadores e
+// workers já pararam com o cancelamento do contexto), grava as métricas de uso pendentes e envia os traces, e
+// por fim fecha o pool de conexões com o banco.
+func shutdown(server *http.Server, drainDelay, timeout time.Duration) {
+	log := phxlog.L.Named("Shutdown")
+	health.Default.SetUnhealthy("server", true, errors.New("shutting down"))
+	if drainDelay > 0 {
+		log.Info("Instância fora do /readyz; aguardando o balanceador antes de encerrar.", zap.Duration("drainDelay", drainDelay))
+		time.Sleep(drainDelay)
+	}
+	log.Info("Encerrando o servidor; aguardando as requisições e os jobs em andamento.", zap.Duration("timeout", timeout))
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	// Inicia o servidor
-	serverPort := os.Getenv("SERVER_PORT")
-	if serverPort == "" {
-		serverPort = "8080"
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Warn("Requisições em andamento não terminaram a tempo; conexões encerradas.", zap.Error(err))
+		_ = server.Close()
 	}
 
-	phxlog.L.Info("Iniciando o servidor", zap.String("port", serverPort))
-	if err := appRouter.Run(":" + serverPort); err != nil {
-		phxlog.L.Fatal("Falha ao iniciar o servidor", zap.Error(err))
+	if jobPool != nil {
+		done := make(chan struct{})
+		go func() {
+			jobPool.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-shutdownCtx.Done():
+			log.Warn("Jobs em andamento não terminaram a tempo; serão retomados após o fim da reserva.")
+		}
+	}
+
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFlush()
+	if err := apiusage.Default.Flush(flushCtx, database.GetDB(), config.Cfg.APIUsageHourlyLimit, config.Cfg.APIUsageAlertPercent); err != nil {
+		log.Error("Falha ao gravar as métricas de uso da API pendentes.", zap.Error(err))
+	}
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Error("Falha ao enviar os traces pendentes.", zap.Error(err))
+	}
+	if err := database.Close(); err != nil {
+		log.Error("Falha ao fechar o pool de conexões com o banco de dados.", zap.Error(err))
 	}
+	log.Info("Servidor encerrado.")
 }
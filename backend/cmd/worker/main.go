@@ -0,0 +1,166 @@
+// Command worker executa a fila de jobs em segundo plano (notificações, relatórios agendados, exportações) e
+// os agendadores periódicos fora do processo da API, para que tarefas pesadas não afetem a latência das
+// requisições e os workers possam ser escalados separadamente. Use com JOBS_WORKER_CONCURRENCY=0 e
+// SCHEDULERS_ENABLED=false no servidor da API.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"phoenixgrc/backend/internal/bootstrap"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/featureflags"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/health"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/tracing"
+	"phoenixgrc/backend/pkg/config"
+	"phoenixgrc/backend/pkg/features"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// defaultConcurrency é o número de workers quando JOBS_WORKER_CONCURRENCY é 0 (valor usado no servidor da
+// API para deixar a fila para este processo).
+const defaultConcurrency = 4
+
+// shutdownTimeout é o tempo máximo de espera pelos jobs em andamento no encerramento.
+const shutdownTimeout = jobs.JobTimeout + 30*time.Second
+
+// shutdownTracing envia os spans pendentes no encerramento (ver tracing.Init).
+var shutdownTracing = func(context.Context) error { return nil }
+
+// initializeServices inicializa o que os jobs usam: banco de dados, feature flags, armazenamento de
+// arquivos, e-mail e canais de notificação.
+func initializeServices() error {
+	phxlog.Init(config.Cfg.LogLevel, config.Cfg.Environment)
+	log := phxlog.L.Named("Initialization")
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	bootstrap.CheckKeys()
+
+	shutdown, err := tracing.Init(context.Background(), "phoenixgrc-worker")
+	if err != nil {
+		log.Error("Falha ao inicializar o rastreamento OpenTelemetry.", zap.Error(err))
+	} else {
+		shutdownTracing = shutdown
+	}
+
+	if err := bootstrap.ConnectDatabase(); err != nil {
+		return err
+	}
+	log.Info("Conexão com o banco de dados estabelecida com sucesso.")
+
+	features.Init(featureflags.Source{}, time.Duration(config.Cfg.FeatureFlagCacheTTLSeconds)*time.Second)
+
+	// Como no servidor, o armazenamento indisponível não impede o worker de subir; os jobs que dependem dele
+	// falham e são repetidos
+	if err := health.InitOptional(context.Background(), "file_storage", filestorage.InitFileStorage); err == nil {
+		log.Info("Serviço opcional inicializado.", zap.String("service", "file_storage"))
+	}
+
+	notifications.InitEmailService()
+	notifications.InitChannels()
+	bootstrap.RegisterReloadHooks()
+	bootstrap.RegisterJobHandlers()
+	return nil
+}
+
+// healthHandler responde 200 se o banco de dados responder, para o healthcheck do contêiner.
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	sqlDB, err := database.DB.DB()
+	if err == nil {
+		err = sqlDB.PingContext(r.Context())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"error","message":"database ping failed"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"status":"ok","database":"connected"}`))
+}
+
+// livenessHandler responde 200 enquanto o processo atende requisições, sem verificar dependências.
+func livenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": health.StatusOK})
+}
+
+// readinessHandler responde a situação de cada componente usado pelos jobs, como o /readyz da API.
+func readinessHandler(w http.ResponseWriter, r *http.Request) {
+	report := health.Default.Evaluate(r.Context(), health.ServiceChecks(), health.DefaultCheckTimeout)
+	writeJSON(w, report.HTTPStatus(), report)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func main() {
+	if err := initializeServices(); err != nil {
+		phxlog.L.Fatal("Falha na inicialização do worker.", zap.Error(err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	concurrency := config.Cfg.JobsWorkerConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	pool := jobs.NewPool(database.GetDB(), concurrency, config.Cfg.JobsPollInterval)
+	pool.Start(ctx)
+	phxlog.L.Info("Workers da fila de jobs iniciados.",
+		zap.Int("concurrency", concurrency),
+		zap.String("workerID", pool.WorkerID),
+		zap.Strings("jobTypes", jobs.RegisteredTypes()))
+
+	bootstrap.StartSchedulers(ctx)
+	bootstrap.HandleReloadSignal(ctx)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", healthHandler)
+	mux.HandleFunc("/healthz", livenessHandler)
+	mux.HandleFunc("/readyz", readinessHandler)
+	mux.Handle("/metrics", promhttp.Handler())
+	server := &http.Server{Addr: ":" + config.Cfg.WorkerHTTPPort, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			phxlog.L.Error("Falha no servidor de health/métricas do worker", zap.Error(err))
+		}
+	}()
+	phxlog.L.Info("Worker em execução.", zap.String("httpPort", config.Cfg.WorkerHTTPPort))
+
+	<-ctx.Done()
+	phxlog.L.Info("Encerrando o worker; aguardando os jobs em andamento.")
+	done := make(chan struct{})
+	go func() {
+		pool.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(shutdownTimeout):
+		phxlog.L.Warn("Jobs em andamento não terminaram a tempo; serão retomados após o fim da reserva.")
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = server.Shutdown(shutdownCtx)
+	_ = shutdownTracing(shutdownCtx)
+	_ = database.Close()
+	phxlog.L.Info("Worker encerrado.")
+}
@@ -0,0 +1,209 @@
+// Package apiusage agrega o uso da API por organização, credencial e endpoint e
+// alerta os administradores quando uma organização se aproxima do limite horário.
+package apiusage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventTypeThresholdReached é o tipo de evento enviado às regras de roteamento de notificações
+// quando uma organização atinge o percentual de alerta do limite horário.
+const EventTypeThresholdReached = "api_usage.threshold"
+
+// CredentialContextKey é a chave do contexto do Gin onde a autenticação por chave de API
+// deve gravar o ID da chave. Sem ela, a chamada é contabilizada como sessão.
+const CredentialContextKey = "apiCredentialID"
+
+type bucketKey struct {
+	OrganizationID uuid.UUID
+	Credential     string
+	Method         string
+	Route          string
+	WindowStart    time.Time
+}
+
+type bucketDelta struct {
+	Requests  int64
+	Errors    int64
+	LatencyMs int64
+}
+
+// Recorder acumula as chamadas em memória até o próximo Flush, evitando uma escrita no banco por requisição.
+type Recorder struct {
+	mu      sync.Mutex
+	pending map[bucketKey]*bucketDelta
+	// alerted guarda a janela em que cada organização já recebeu alerta, para enviar no máximo um por hora.
+	alerted map[uuid.UUID]time.Time
+}
+
+// NewRecorder cria um Recorder vazio.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		pending: make(map[bucketKey]*bucketDelta),
+		alerted: make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Default é o Recorder usado pelo middleware HTTP.
+var Default = NewRecorder()
+
+// WindowStart retorna o início da janela horária (UTC) que contém t.
+func WindowStart(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+// Record contabiliza uma chamada concluída.
+func (r *Recorder) Record(orgID uuid.UUID, credential, method, route string, status int, latency time.Duration, at time.Time) {
+	if credential == "" {
+		credential = models.APIUsageSessionCredential
+	}
+	key := bucketKey{OrganizationID: orgID, Credential: credential, Method: method, Route: route, WindowStart: WindowStart(at)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delta, ok := r.pending[key]
+	if !ok {
+		delta = &bucketDelta{}
+		r.pending[key] = delta
+	}
+	delta.Requests++
+	if status >= 400 {
+		delta.Errors++
+	}
+	delta.LatencyMs += latency.Milliseconds()
+}
+
+// drain retira os contadores pendentes, deixando o Recorder pronto para novas chamadas.
+func (r *Recorder) drain() []models.APIUsageCounter {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[bucketKey]*bucketDelta)
+	r.mu.Unlock()
+
+	counters := make([]models.APIUsageCounter, 0, len(pending))
+	for key, delta := range pending {
+		counters = append(counters, models.APIUsageCounter{
+			OrganizationID: key.OrganizationID,
+			Credential:     key.Credential,
+			Method:         key.Method,
+			Route:          key.Route,
+			WindowStart:    key.WindowStart,
+			RequestCount:   delta.Requests,
+			ErrorCount:     delta.Errors,
+			TotalLatencyMs: delta.LatencyMs,
+		})
+	}
+	return counters
+}
+
+// Flush grava os contadores pendentes somando-os aos já persistidos e, se houver limite
+// configurado, verifica o alerta das organizações que tiveram chamadas na janela atual.
+func (r *Recorder) Flush(ctx context.Context, db *gorm.DB, hourlyLimit int64, thresholdPercent int) error {
+	counters := r.drain()
+	if len(counters) == 0 {
+		return nil
+	}
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "organization_id"}, {Name: "credential"}, {Name: "method"}, {Name: "route"}, {Name: "window_start"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"request_count":    gorm.Expr("api_usage_counters.request_count + excluded.request_count"),
+			"error_count":      gorm.Expr("api_usage_counters.error_count + excluded.error_count"),
+			"total_latency_ms": gorm.Expr("api_usage_counters.total_latency_ms + excluded.total_latency_ms"),
+			"updated_at":       gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(&counters).Error
+	if err != nil {
+		return fmt.Errorf("failed to persist api usage counters: %w", err)
+	}
+	if hourlyLimit <= 0 {
+		return nil
+	}
+
+	window := WindowStart(time.Now())
+	touched := make(map[uuid.UUID]bool)
+	for _, counter := range counters {
+		if counter.WindowStart.Equal(window) {
+			touched[counter.OrganizationID] = true
+		}
+	}
+	for orgID := range touched {
+		var used int64
+		err := db.WithContext(ctx).Model(&models.APIUsageCounter{}).
+			Where("organization_id = ? AND window_start = ?", orgID, window).
+			Select("COALESCE(SUM(request_count), 0)").Scan(&used).Error
+		if err != nil {
+			phxlog.L.Error("Failed to compute hourly api usage", zap.String("organizationID", orgID.String()), zap.Error(err))
+			continue
+		}
+		if r.shouldAlert(orgID, window, used, hourlyLimit, thresholdPercent) {
+			sendThresholdAlert(ctx, orgID, window, used, hourlyLimit)
+		}
+	}
+	return nil
+}
+
+// ThresholdReached indica se o uso atingiu thresholdPercent do limite horário.
+func ThresholdReached(used, hourlyLimit int64, thresholdPercent int) bool {
+	if hourlyLimit <= 0 {
+		return false
+	}
+	return used*100 >= hourlyLimit*int64(thresholdPercent)
+}
+
+// shouldAlert aplica o limiar e garante no máximo um alerta por organização por janela.
+func (r *Recorder) shouldAlert(orgID uuid.UUID, window time.Time, used, hourlyLimit int64, thresholdPercent int) bool {
+	if !ThresholdReached(used, hourlyLimit, thresholdPercent) {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if last, ok := r.alerted[orgID]; ok && last.Equal(window) {
+		return false
+	}
+	r.alerted[orgID] = window
+	return true
+}
+
+func sendThresholdAlert(ctx context.Context, orgID uuid.UUID, window time.Time, used, hourlyLimit int64) {
+	phxlog.L.Warn("Organization is approaching its hourly API limit",
+		zap.String("organizationID", orgID.String()),
+		zap.Int64("used", used),
+		zap.Int64("limit", hourlyLimit))
+	notifications.Dispatch(ctx, notifications.Message{
+		EventType:      EventTypeThresholdReached,
+		OrganizationID: orgID,
+		Subject:        "Uso da API próximo do limite",
+		Body: fmt.Sprintf("A organização fez %d de %d chamadas permitidas na janela iniciada em %s (UTC). Verifique integrações com volume anormal em Uso da API.",
+			used, hourlyLimit, window.Format("2006-01-02 15:04")),
+	})
+}
+
+// StartFlusher grava periodicamente os contadores do Recorder padrão até o contexto ser cancelado.
+func StartFlusher(ctx context.Context, every time.Duration, hourlyLimit int64, thresholdPercent int) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := Default.Flush(ctx, database.GetDB(), hourlyLimit, thresholdPercent); err != nil {
+					phxlog.L.Error("API usage flush failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
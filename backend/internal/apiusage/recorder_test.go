@@ -0,0 +1,51 @@
+package apiusage
+
+import (
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAggregatesByBucket(t *testing.T) {
+	r := NewRecorder()
+	orgID := uuid.New()
+	at := time.Date(2026, 3, 10, 14, 5, 0, 0, time.UTC)
+
+	r.Record(orgID, "", "GET", "/api/v1/risks", 200, 20*time.Millisecond, at)
+	r.Record(orgID, "", "GET", "/api/v1/risks", 500, 40*time.Millisecond, at.Add(30*time.Minute))
+	r.Record(orgID, "key-1", "GET", "/api/v1/risks", 200, 10*time.Millisecond, at)
+	r.Record(orgID, "", "GET", "/api/v1/risks", 200, 10*time.Millisecond, at.Add(time.Hour))
+
+	counters := r.drain()
+	assert.Len(t, counters, 3)
+	for _, counter := range counters {
+		if counter.Credential == models.APIUsageSessionCredential && counter.WindowStart.Equal(WindowStart(at)) {
+			assert.Equal(t, int64(2), counter.RequestCount)
+			assert.Equal(t, int64(1), counter.ErrorCount)
+			assert.Equal(t, int64(60), counter.TotalLatencyMs)
+		}
+	}
+	assert.Empty(t, r.drain(), "drain must reset pending counters")
+}
+
+func TestThresholdReached(t *testing.T) {
+	assert.False(t, ThresholdReached(1000, 0, 80), "no limit disables alerts")
+	assert.False(t, ThresholdReached(799, 1000, 80))
+	assert.True(t, ThresholdReached(800, 1000, 80))
+	assert.True(t, ThresholdReached(1200, 1000, 80))
+}
+
+func TestShouldAlertOncePerWindow(t *testing.T) {
+	r := NewRecorder()
+	orgID := uuid.New()
+	window := WindowStart(time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC))
+
+	assert.False(t, r.shouldAlert(orgID, window, 100, 1000, 80))
+	assert.True(t, r.shouldAlert(orgID, window, 850, 1000, 80))
+	assert.False(t, r.shouldAlert(orgID, window, 950, 1000, 80))
+	assert.True(t, r.shouldAlert(orgID, window.Add(time.Hour), 900, 1000, 80))
+}
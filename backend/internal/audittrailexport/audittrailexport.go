@@ -0,0 +1,203 @@
+// Package audittrailexport gera a exportação da trilha de auditoria da organização para reguladores: o
+// período é dividido em partes CSV de até PartSize registros, em ordem cronológica, e um manifesto de
+// integridade lista cada parte com o SHA-256 do arquivo. O manifesto é assinado (HMAC-SHA256) com a chave
+// do servidor; a cadeia de hashes (chain_sha256) liga cada parte à anterior, de modo que remover, reordenar
+// ou alterar uma parte invalida todas as seguintes.
+package audittrailexport
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SchemaVersion é a versão do formato das partes e do manifesto.
+const SchemaVersion = 1
+
+// PartSize é o número máximo de registros por parte.
+const PartSize = 10000
+
+// SignatureAlgorithm identifica o algoritmo da assinatura do manifesto.
+const SignatureAlgorithm = "HMAC-SHA256"
+
+// Header são as colunas das partes CSV.
+var Header = []string{"id", "created_at", "organization_id", "actor_id", "action", "entity_type", "entity_id", "summary", "details"}
+
+// ErrInvalidSignature indica que o manifesto foi alterado ou não foi assinado com a chave informada.
+var ErrInvalidSignature = errors.New("invalid manifest signature")
+
+// Part descreve um arquivo CSV da exportação.
+type Part struct {
+	Number       int       `json:"number"` // A partir de 1
+	FileName     string    `json:"file_name"`
+	Entries      int       `json:"entries"`
+	SizeBytes    int       `json:"size_bytes"`
+	SHA256       string    `json:"sha256"`       // Hash do arquivo
+	ChainSHA256  string    `json:"chain_sha256"` // SHA-256(chain_sha256 da parte anterior + sha256 desta parte)
+	FirstEntryID uuid.UUID `json:"first_entry_id"`
+	LastEntryID  uuid.UUID `json:"last_entry_id"`
+	FirstEntryAt time.Time `json:"first_entry_at"`
+	LastEntryAt  time.Time `json:"last_entry_at"`
+}
+
+// Manifest é o manifesto de integridade da exportação. Signature cobre todos os demais campos.
+type Manifest struct {
+	SchemaVersion      int       `json:"schema_version"`
+	ExportID           uuid.UUID `json:"export_id"`
+	OrganizationID     uuid.UUID `json:"organization_id"`
+	From               time.Time `json:"from"` // Inclusivo
+	To                 time.Time `json:"to"`   // Exclusivo
+	GeneratedAt        time.Time `json:"generated_at"`
+	TotalEntries       int       `json:"total_entries"`
+	Parts              []Part    `json:"parts"`
+	SignatureAlgorithm string    `json:"signature_algorithm"`
+	Signature          string    `json:"signature"`
+}
+
+// NewManifest cria o manifesto vazio de uma exportação.
+func NewManifest(exportID, organizationID uuid.UUID, from, to time.Time) *Manifest {
+	return &Manifest{
+		SchemaVersion:      SchemaVersion,
+		ExportID:           exportID,
+		OrganizationID:     organizationID,
+		From:               from.UTC(),
+		To:                 to.UTC(),
+		Parts:              []Part{},
+		SignatureAlgorithm: SignatureAlgorithm,
+	}
+}
+
+// PartFileName é o nome do arquivo da parte, ex: audit_trail_part_0001.csv.
+func PartFileName(number int) string {
+	return fmt.Sprintf("audit_trail_part_%04d.csv", number)
+}
+
+// WritePart grava os registros em CSV, com cabeçalho, na ordem recebida.
+func WritePart(w io.Writer, entries []models.AuditTrailEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Header); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		details := ""
+		if entry.Details != nil {
+			details = *entry.Details
+		}
+		if err := cw.Write([]string{
+			entry.ID.String(),
+			entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+			entry.OrganizationID.String(),
+			uuidString(entry.ActorID),
+			string(entry.Action),
+			entry.EntityType,
+			uuidString(entry.EntityID),
+			entry.Summary,
+			details,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// AddPart gera a próxima parte com os registros (não vazios, em ordem cronológica), registra-a no
+// manifesto e retorna o conteúdo do arquivo.
+func (m *Manifest) AddPart(entries []models.AuditTrailEntry) ([]byte, Part, error) {
+	if len(entries) == 0 {
+		return nil, Part{}, errors.New("a part needs at least one entry")
+	}
+	var buf bytes.Buffer
+	if err := WritePart(&buf, entries); err != nil {
+		return nil, Part{}, err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	previousChain := ""
+	if len(m.Parts) > 0 {
+		previousChain = m.Parts[len(m.Parts)-1].ChainSHA256
+	}
+	chain := sha256.Sum256([]byte(previousChain + hex.EncodeToString(sum[:])))
+	first, last := entries[0], entries[len(entries)-1]
+	part := Part{
+		Number:       len(m.Parts) + 1,
+		FileName:     PartFileName(len(m.Parts) + 1),
+		Entries:      len(entries),
+		SizeBytes:    buf.Len(),
+		SHA256:       hex.EncodeToString(sum[:]),
+		ChainSHA256:  hex.EncodeToString(chain[:]),
+		FirstEntryID: first.ID,
+		LastEntryID:  last.ID,
+		FirstEntryAt: first.CreatedAt.UTC(),
+		LastEntryAt:  last.CreatedAt.UTC(),
+	}
+	m.Parts = append(m.Parts, part)
+	m.TotalEntries += len(entries)
+	return buf.Bytes(), part, nil
+}
+
+// Sign assina o manifesto com a chave do servidor.
+func (m *Manifest) Sign(key []byte, generatedAt time.Time) error {
+	m.GeneratedAt = generatedAt.UTC()
+	signature, err := m.signature(key)
+	if err != nil {
+		return err
+	}
+	m.Signature = signature
+	return nil
+}
+
+// Verify confere a assinatura do manifesto.
+func (m *Manifest) Verify(key []byte) error {
+	expected, err := m.signature(key)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(expected), []byte(m.Signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyPart confere o conteúdo de um arquivo com a parte do manifesto.
+func (m *Manifest) VerifyPart(number int, content []byte) error {
+	if number < 1 || number > len(m.Parts) {
+		return fmt.Errorf("part %d is not in the manifest", number)
+	}
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != m.Parts[number-1].SHA256 {
+		return fmt.Errorf("part %d does not match the manifest hash", number)
+	}
+	return nil
+}
+
+// signature é o HMAC do JSON do manifesto sem a assinatura.
+func (m *Manifest) signature(key []byte) (string, error) {
+	unsigned := *m
+	unsigned.Signature = ""
+	payload, err := json.Marshal(unsigned)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("audit-trail-export\n"))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,102 @@
+package audittrailexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("test-signing-key")
+
+func sampleEntries(orgID uuid.UUID, n int, start time.Time) []models.AuditTrailEntry {
+	actorID := uuid.New()
+	entries := make([]models.AuditTrailEntry, n)
+	for i := range entries {
+		details := `{"field":"status"}`
+		entries[i] = models.AuditTrailEntry{
+			ID:             uuid.New(),
+			OrganizationID: orgID,
+			ActorID:        &actorID,
+			Action:         models.AuditTrailAdminActionExecuted,
+			EntityType:     "risk",
+			Summary:        "Risco atualizado, \"status\" alterado",
+			Details:        &details,
+			CreatedAt:      start.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return entries
+}
+
+func TestWritePartEscapesAndOrdersColumns(t *testing.T) {
+	orgID := uuid.New()
+	entries := sampleEntries(orgID, 2, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	entries[1].ActorID = nil
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePart(&buf, entries))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, Header, rows[0])
+	assert.Equal(t, entries[0].ID.String(), rows[1][0])
+	assert.Equal(t, "2026-01-02T03:04:05Z", rows[1][1])
+	assert.Equal(t, orgID.String(), rows[1][2])
+	assert.Equal(t, entries[0].Summary, rows[1][7])
+	assert.Equal(t, `{"field":"status"}`, rows[1][8])
+	assert.Equal(t, "", rows[2][3], "actor vazio para ações do sistema")
+	assert.Equal(t, "", rows[2][6])
+}
+
+func TestManifestChainsPartsAndVerifiesSignature(t *testing.T) {
+	orgID := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewManifest(uuid.New(), orgID, from, from.AddDate(0, 1, 0))
+
+	first, part1, err := m.AddPart(sampleEntries(orgID, 3, from))
+	require.NoError(t, err)
+	second, part2, err := m.AddPart(sampleEntries(orgID, 2, from.Add(time.Hour)))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, part1.Number)
+	assert.Equal(t, "audit_trail_part_0002.csv", part2.FileName)
+	assert.Equal(t, 5, m.TotalEntries)
+	assert.NotEqual(t, part1.ChainSHA256, part2.ChainSHA256)
+	require.NoError(t, m.VerifyPart(1, first))
+	require.NoError(t, m.VerifyPart(2, second))
+	assert.Error(t, m.VerifyPart(1, second))
+	assert.Error(t, m.VerifyPart(3, second))
+
+	require.NoError(t, m.Sign(testKey, time.Now()))
+	require.NoError(t, m.Verify(testKey))
+	assert.ErrorIs(t, m.Verify([]byte("other-key")), ErrInvalidSignature)
+
+	// O manifesto continua válido após ida e volta em JSON
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	var decoded Manifest
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, decoded.Verify(testKey))
+
+	// Qualquer alteração invalida a assinatura
+	decoded.TotalEntries = 4
+	assert.ErrorIs(t, decoded.Verify(testKey), ErrInvalidSignature)
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	decoded.Parts = decoded.Parts[1:]
+	assert.ErrorIs(t, decoded.Verify(testKey), ErrInvalidSignature)
+}
+
+func TestAddPartRejectsEmptyPart(t *testing.T) {
+	m := NewManifest(uuid.New(), uuid.New(), time.Now(), time.Now())
+	_, _, err := m.AddPart(nil)
+	assert.Error(t, err)
+	assert.Empty(t, m.Parts)
+}
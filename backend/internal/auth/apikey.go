@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/apiusage"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// APIKeyScheme é o esquema do cabeçalho Authorization das chaves de API: "Authorization: ApiKey phx_...".
+	APIKeyScheme = "apikey"
+	// APIKeyTokenPrefix identifica os tokens de chaves de API (ex: em varreduras de segredos vazados).
+	APIKeyTokenPrefix = "phx_"
+	apiKeySecretBytes = 32
+	// apiKeyDisplayLength é o início do token guardado para identificar a chave nas listagens.
+	apiKeyDisplayLength = len(APIKeyTokenPrefix) + 8
+	// apiKeyLastUsedInterval limita a gravação de last_used_at a uma por intervalo.
+	apiKeyLastUsedInterval = time.Minute
+)
+
+// ErrInvalidAPIKey indica uma chave de API desconhecida, revogada, expirada ou de um usuário inativo.
+var ErrInvalidAPIKey = errors.New("invalid, revoked or expired API key")
+
+// apiKeyScopeRoutes associa os prefixos de rota aos escopos de leitura (GET, HEAD) e escrita. As chaves de API
+// só acessam essas rotas e GET /api/v1/me.
+var apiKeyScopeRoutes = []struct {
+	prefix      string
+	read, write models.APIKeyScope
+}{
+	{"/api/v1/risks", models.ScopeRisksRead, models.ScopeRisksWrite},
+	{"/api/v1/audit", models.ScopeAuditRead, models.ScopeAuditWrite},
+	{"/api/v1/vulnerabilities", models.ScopeVulnerabilitiesRead, models.ScopeVulnerabilitiesWrite},
+	{"/api/v1/assets", models.ScopeAssetsRead, models.ScopeAssetsWrite},
+}
+
+// GenerateAPIKeyToken gera o token de uma nova chave de API, o seu hash (o único valor armazenado) e o prefixo
+// de identificação.
+func GenerateAPIKeyToken() (token, hash, prefix string, err error) {
+	buf := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", fmt.Errorf("error generating API key: %w", err)
+	}
+	token = APIKeyTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashToken(token), token[:apiKeyDisplayLength], nil
+}
+
+// ValidateAPIKey carrega a chave ativa do token e o usuário dono, que precisa estar ativo.
+func ValidateAPIKey(db *gorm.DB, token string) (*models.APIKey, *models.User, error) {
+	if !strings.HasPrefix(token, APIKeyTokenPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	var key models.APIKey
+	if err := db.Where("secret_hash = ?", hashToken(token)).Take(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		return nil, nil, fmt.Errorf("error loading API key: %w", err)
+	}
+	if !key.Active(time.Now()) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	var user models.User
+	if err := db.Take(&user, "id = ?", key.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		return nil, nil, fmt.Errorf("error loading API key owner: %w", err)
+	}
+	if !user.IsActive || !user.OrganizationID.Valid || user.OrganizationID.UUID != key.OrganizationID {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	return &key, &user, nil
+}
+
+// APIKeyAllows indica se os escopos permitem o método na rota (caminho da requisição).
+func APIKeyAllows(scopes []models.APIKeyScope, method, path string) bool {
+	if path == "/api/v1/me" {
+		return method == http.MethodGet
+	}
+	for _, route := range apiKeyScopeRoutes {
+		if path != route.prefix && !strings.HasPrefix(path, route.prefix+"/") {
+			continue
+		}
+		for _, scope := range scopes {
+			if scope == route.write || ((method == http.MethodGet || method == http.MethodHead) && scope == route.read) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// authenticateAPIKey autentica a requisição pela chave de API e define no contexto os mesmos dados do JWT,
+// com o papel atual do dono, além de apiKeyID (e do ID da credencial para a contabilização de uso).
+func authenticateAPIKey(c *gin.Context, db *gorm.DB, token string) {
+	if db == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify API key"})
+		return
+	}
+	key, user, err := ValidateAPIKey(db, token)
+	if errors.Is(err, ErrInvalidAPIKey) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid, revoked or expired API key"})
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify API key"})
+		return
+	}
+	if !APIKeyAllows(key.ScopeList(), c.Request.Method, c.Request.URL.Path) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key scopes do not allow this request"})
+		return
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedInterval {
+		db.Model(&models.APIKey{}).Where("id = ?", key.ID).
+			UpdateColumns(map[string]interface{}{"last_used_at": now, "last_used_ip": truncate(c.ClientIP(), 45)})
+	}
+
+	c.Set("userID", user.ID)
+	c.Set("organizationID", key.OrganizationID)
+	c.Set("userEmail", user.Email)
+	c.Set("userRole", user.Role)
+	c.Set("apiKeyID", key.ID)
+	c.Set(apiusage.CredentialContextKey, key.ID.String())
+	c.Next()
+}
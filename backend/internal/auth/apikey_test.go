@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyAllows(t *testing.T) {
+	scopes := []models.APIKeyScope{models.ScopeRisksWrite, models.ScopeAuditRead}
+	cases := []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodPost, "/api/v1/risks", true},
+		{http.MethodGet, "/api/v1/risks/123", true}, // Escrita inclui leitura
+		{http.MethodGet, "/api/v1/audit/frameworks", true},
+		{http.MethodPost, "/api/v1/audit/assessments", false},
+		{http.MethodGet, "/api/v1/risks-export", false},
+		{http.MethodGet, "/api/v1/vulnerabilities", false},
+		{http.MethodPost, "/api/v1/api-keys", false},
+		{http.MethodGet, "/api/v1/me", true},
+		{http.MethodDelete, "/api/v1/me", false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, APIKeyAllows(scopes, tc.method, tc.path), "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestGenerateAPIKeyToken(t *testing.T) {
+	token, hash, prefix, err := GenerateAPIKeyToken()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, APIKeyTokenPrefix))
+	assert.True(t, strings.HasPrefix(token, prefix))
+	assert.Len(t, prefix, apiKeyDisplayLength)
+	assert.Equal(t, hashToken(token), hash)
+	assert.NotContains(t, hash, token)
+}
+
+func TestAuthMiddlewareWithAPIKey(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	originalDB := database.DB
+	database.DB = db
+	defer func() { database.DB = originalDB }()
+
+	token, hash, _, err := GenerateAPIKeyToken()
+	require.NoError(t, err)
+	keyID, userID, orgID := uuid.New(), uuid.New(), uuid.New()
+	expectKey := func() {
+		mock.ExpectQuery(`SELECT \* FROM "api_keys" WHERE secret_hash = \$1`).
+			WithArgs(hash, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "user_id", "scopes", "expires_at", "last_used_at"}).
+				AddRow(keyID, orgID, userID, `["risks:read"]`, time.Now().Add(time.Hour), time.Now()))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE id = \$1`).
+			WithArgs(userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role", "organization_id", "is_active"}).
+				AddRow(userID, "ci@example.com", models.RoleManager, orgID, true))
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(AuthMiddleware())
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"role": c.Value("userRole"), "api_key_id": c.Value("apiKeyID")})
+	}
+	router.GET("/api/v1/risks", handler)
+	router.POST("/api/v1/risks", handler)
+	do := func(method, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/risks", nil)
+		req.Header.Set("Authorization", authorization)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	expectKey()
+	rec := do(http.MethodGet, "ApiKey "+token)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), keyID.String())
+	assert.Contains(t, rec.Body.String(), `"role":"manager"`)
+
+	expectKey()
+	rec = do(http.MethodPost, "ApiKey "+token)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "risks:read does not allow writes")
+
+	mock.ExpectQuery(`SELECT \* FROM "api_keys" WHERE secret_hash = \$1`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rec = do(http.MethodGet, "ApiKey phx_unknown")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"crypto"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
 	"strings"
 	"time"
 
@@ -13,76 +17,211 @@ import (
 	"github.com/google/uuid"
 )
 
-var jwtKey []byte
+// DefaultIssuer é o issuer (iss) dos tokens emitidos pelo Phoenix quando JWT_ISSUER não é definido.
+const DefaultIssuer = "phoenix-grc"
+
+var (
+	jwtKey              []byte
+	jwtIssuer           = DefaultIssuer
+	jwtAudience         string           // Se definido, é gravado no aud dos tokens emitidos e exigido na validação
+	jwtClockSkew        time.Duration    // Tolerância de relógio na validação de exp, nbf e iat
+	accessTokenLifespan = 24 * time.Hour // Validade dos tokens de acesso (JWT_TOKEN_LIFESPAN_HOURS)
+	gateway             *gatewayValidator
+)
 
 // Claims struct to be encoded to JWT
 type Claims struct {
-	UserID         uuid.UUID      `json:"user_id"`
-	OrganizationID uuid.NullUUID  `json:"org_id"`
-	Email          string         `json:"email"`
+	UserID         uuid.UUID       `json:"user_id"`
+	OrganizationID uuid.UUID       `json:"org_id"`
+	Email          string          `json:"email"`
 	Role           models.UserRole `json:"role"`
+	SessionID      *uuid.UUID      `json:"sid,omitempty"` // Sessão de login (AuthSession); tokens sem sid não são revogáveis
+	// Escopo da sessão; models.SessionScopeMFAEnrollment restringe o token às rotas de cadastro de MFA
+	Scope string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// InitializeJWT loads the JWT secret key from environment variables.
+// gatewayValidator valida tokens emitidos por um gateway de autenticação externo (ex: um proxy de
+// autenticação na borda). O usuário é identificado pelo e-mail do token e resolvido por GatewayUserResolver.
+type gatewayValidator struct {
+	issuer     string
+	audience   string
+	emailClaim string
+	key        interface{} // []byte (HMAC) ou chave pública (RSA, ECDSA, Ed25519)
+	methods    []string
+}
+
+// GatewayUserResolver carrega o usuário ativo com o e-mail de um token do gateway. Definido na
+// inicialização do servidor; sem ele os tokens do gateway são recusados.
+var GatewayUserResolver func(email string) (*models.User, error)
+
+// InitializeJWT loads the JWT secret key, the token lifespans and the claim validation settings from the
+// startup configuration (config.Cfg). They only change when the process restarts.
 func InitializeJWT() error {
-	secret := os.Getenv("JWT_SECRET_KEY")
-	if secret == "" {
+	cfg := config.Cfg
+	if cfg.JWTSecret == "" {
 		return fmt.Errorf("JWT_SECRET_KEY environment variable not set")
 	}
-	jwtKey = []byte(secret)
-	return nil
+	jwtKey = []byte(cfg.JWTSecret)
+
+	jwtIssuer = DefaultIssuer
+	if cfg.JWTIssuer != "" {
+		jwtIssuer = cfg.JWTIssuer
+	}
+	jwtAudience = cfg.JWTAudience
+	if cfg.JWTClockSkew < 0 {
+		return fmt.Errorf("invalid JWT_CLOCK_SKEW_SECONDS: must be a non-negative number of seconds")
+	}
+	jwtClockSkew = cfg.JWTClockSkew
+	if cfg.JWTRefreshTokenLifespan <= 0 {
+		return fmt.Errorf("invalid JWT_REFRESH_TOKEN_LIFESPAN_HOURS: must be a positive number of hours")
+	}
+	refreshTokenLifespan = cfg.JWTRefreshTokenLifespan
+	accessTokenLifespan = cfg.JWTTokenLifespan
+
+	var err error
+	gateway, err = loadGatewayValidator(cfg)
+	return err
 }
 
-// GenerateToken generates a new JWT token for a given user.
-func GenerateToken(user *models.User, organizationID uuid.NullUUID) (string, error) {
-	if len(jwtKey) == 0 {
-		return "", fmt.Errorf("JWT secret key not initialized. Call InitializeJWT() first")
+// loadGatewayValidator lê a configuração do gateway externo (JWT_GATEWAY_*). Retorna nil se
+// JWT_GATEWAY_ISSUER não estiver definido.
+func loadGatewayValidator(cfg config.AppConfig) (*gatewayValidator, error) {
+	issuer := cfg.JWTGatewayIssuer
+	if issuer == "" {
+		return nil, nil
+	}
+	if issuer == jwtIssuer {
+		return nil, fmt.Errorf("JWT_GATEWAY_ISSUER must differ from the Phoenix issuer %q", jwtIssuer)
+	}
+	g := &gatewayValidator{
+		issuer:     issuer,
+		audience:   cfg.JWTGatewayAudience,
+		emailClaim: cfg.JWTGatewayEmailClaim,
+	}
+	if g.emailClaim == "" {
+		g.emailClaim = "email"
 	}
 
-	expirationTime := time.Now().Add(24 * time.Hour) // Token valid for 24 hours
-	// Potentially make lifespan configurable via env var
-	tokenLifespanStr := os.Getenv("JWT_TOKEN_LIFESPAN_HOURS")
-	if tokenLifespanHours, err := time.ParseDuration(tokenLifespanStr + "h"); err == nil {
-		expirationTime = time.Now().Add(tokenLifespanHours)
+	pemData := cfg.JWTGatewayPublicKey
+	if path := cfg.JWTGatewayPublicKeyFile; pemData == "" && path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JWT_GATEWAY_PUBLIC_KEY_FILE: %w", err)
+		}
+		pemData = string(data)
 	}
+	switch {
+	case pemData != "":
+		key, methods, err := parseGatewayPublicKey([]byte(pemData))
+		if err != nil {
+			return nil, err
+		}
+		g.key, g.methods = key, methods
+	case cfg.JWTGatewaySecret != "":
+		g.key = []byte(cfg.JWTGatewaySecret)
+		g.methods = []string{"HS256", "HS384", "HS512"}
+	default:
+		return nil, fmt.Errorf("JWT_GATEWAY_ISSUER requires JWT_GATEWAY_PUBLIC_KEY, JWT_GATEWAY_PUBLIC_KEY_FILE or JWT_GATEWAY_SECRET")
+	}
+	return g, nil
+}
+
+// parseGatewayPublicKey lê uma chave pública PEM (RSA, ECDSA ou Ed25519) e retorna os algoritmos aceitos para ela.
+func parseGatewayPublicKey(pemData []byte) (crypto.PublicKey, []string, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pemData); err == nil {
+		return key, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(pemData); err == nil {
+		return key, []string{"ES256", "ES384", "ES512"}, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(pemData); err == nil {
+		return key, []string{"EdDSA"}, nil
+	}
+	return nil, nil, errors.New("invalid gateway public key: expected a PEM encoded RSA, ECDSA or Ed25519 public key")
+}
+
+// GenerateToken generates a new JWT token for a given user.
+// O token não pertence a uma sessão: logins interativos usam StartSession, que emite também o refresh token.
+func GenerateToken(user *models.User, organizationID uuid.UUID) (string, error) {
+	token, _, err := signAccessToken(user, organizationID, nil, "")
+	return token, err
+}
 
+// signAccessToken assina um token de acesso do usuário, vinculado à sessão sessionID (se não for nil) e com o
+// escopo da sessão. Tokens restritos ao cadastro de MFA valem no máximo enrollmentSessionLifespan.
+func signAccessToken(user *models.User, organizationID uuid.UUID, sessionID *uuid.UUID, scope string) (string, time.Time, error) {
+	if len(jwtKey) == 0 {
+		return "", time.Time{}, fmt.Errorf("JWT secret key not initialized. Call InitializeJWT() first")
+	}
 
+	now := time.Now()
+	lifespan := accessTokenLifespan
+	if scope == models.SessionScopeMFAEnrollment && lifespan > enrollmentSessionLifespan {
+		lifespan = enrollmentSessionLifespan
+	}
+	expirationTime := now.Add(lifespan)
 	claims := &Claims{
 		UserID:         user.ID,
 		OrganizationID: organizationID,
 		Email:          user.Email,
 		Role:           user.Role,
+		SessionID:      sessionID,
+		Scope:          scope,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "phoenix-grc", // Optional: identify the issuer
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    jwtIssuer,
 		},
 	}
+	if jwtAudience != "" {
+		claims.Audience = jwt.ClaimStrings{jwtAudience}
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(jwtKey)
 	if err != nil {
-		return "", fmt.Errorf("error signing token: %w", err)
+		return "", time.Time{}, fmt.Errorf("error signing token: %w", err)
 	}
 
-	return tokenString, nil
+	return tokenString, expirationTime, nil
 }
 
 // ValidateToken validates a JWT token string.
+// Tokens do gateway externo (iss igual a JWT_GATEWAY_ISSUER, se configurado) são validados com a chave do
+// gateway e convertidos nas claims do usuário correspondente; os demais devem ter sido emitidos pelo Phoenix.
 // Returns the claims if the token is valid, otherwise returns an error.
 func ValidateToken(tokenString string) (*Claims, error) {
 	if len(jwtKey) == 0 {
 		return nil, fmt.Errorf("JWT secret key not initialized")
 	}
 
+	if gateway != nil {
+		// O iss ainda não verificado só escolhe a chave; a assinatura e o iss são validados em seguida
+		unverified := jwt.MapClaims{}
+		if _, _, err := jwt.NewParser().ParseUnverified(tokenString, unverified); err == nil {
+			if issuer, _ := unverified.GetIssuer(); issuer == gateway.issuer {
+				return gateway.validate(tokenString)
+			}
+		}
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(jwtIssuer),
+		jwt.WithLeeway(jwtClockSkew),
+	}
+	if jwtAudience != "" {
+		options = append(options, jwt.WithAudience(jwtAudience))
+	}
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return jwtKey, nil
-	})
+	}, options...)
 
 	if err != nil {
 		return nil, fmt.Errorf("error parsing token: %w", err)
@@ -95,6 +234,54 @@ func ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// validate valida um token do gateway e retorna as claims do usuário identificado pelo e-mail.
+func (g *gatewayValidator) validate(tokenString string) (*Claims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(g.methods),
+		jwt.WithIssuer(g.issuer),
+		jwt.WithLeeway(jwtClockSkew),
+		jwt.WithExpirationRequired(),
+	}
+	if g.audience != "" {
+		options = append(options, jwt.WithAudience(g.audience))
+	}
+	gatewayClaims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, gatewayClaims, func(token *jwt.Token) (interface{}, error) {
+		return g.key, nil
+	}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing gateway token: %w", err)
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid gateway token")
+	}
+
+	email, _ := gatewayClaims[g.emailClaim].(string)
+	if strings.TrimSpace(email) == "" {
+		return nil, fmt.Errorf("gateway token has no %q claim", g.emailClaim)
+	}
+	if GatewayUserResolver == nil {
+		return nil, fmt.Errorf("gateway tokens are not enabled")
+	}
+	user, err := GatewayUserResolver(strings.TrimSpace(email))
+	if err != nil {
+		return nil, fmt.Errorf("gateway user not found: %w", err)
+	}
+
+	registered := jwt.RegisteredClaims{Issuer: g.issuer}
+	registered.Subject, _ = gatewayClaims.GetSubject()
+	registered.Audience, _ = gatewayClaims.GetAudience()
+	registered.ExpiresAt, _ = gatewayClaims.GetExpirationTime()
+	registered.IssuedAt, _ = gatewayClaims.GetIssuedAt()
+	return &Claims{
+		UserID:           user.ID,
+		OrganizationID:   user.OrganizationID.UUID,
+		Email:            user.Email,
+		Role:             user.Role,
+		RegisteredClaims: registered,
+	}, nil
+}
+
 // AuthMiddleware creates a Gin middleware for JWT authentication.
 // It checks for a valid JWT in the Authorization header (Bearer token).
 // If valid, it sets the user's claims in the Gin context.
@@ -107,6 +294,11 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		parts := strings.Split(authHeader, " ")
+		// Integrações autenticam com uma chave de API: "Authorization: ApiKey phx_..."
+		if len(parts) == 2 && strings.ToLower(parts[0]) == APIKeyScheme {
+			authenticateAPIKey(c, database.GetDB(), parts[1])
+			return
+		}
 		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
 			return
@@ -118,18 +310,62 @@ func AuthMiddleware() gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token: " + err.Error()})
 			return
 		}
+		// Tokens de uma sessão deixam de valer quando ela é revogada (logout, troca de senha, reuso do refresh token)
+		if claims.SessionID != nil {
+			active, err := sessionIsActive(database.GetDB(), *claims.SessionID)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify session"})
+				return
+			}
+			if !active {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+				return
+			}
+			c.Set("sessionID", *claims.SessionID)
+		}
+		// Quem ainda precisa cadastrar o segundo fator exigido pela organização só acessa as rotas de cadastro.
+		// Escopos desconhecidos são recusados em vez de tratados como acesso completo.
+		switch claims.Scope {
+		case "":
+		case models.SessionScopeMFAEnrollment:
+			if !enrollmentRouteAllowed(c.FullPath()) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error":                   "Your organization requires two-factor authentication; set it up before continuing",
+					"mfa_enrollment_required": true,
+				})
+				return
+			}
+		default:
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token: unknown scope"})
+			return
+		}
 
 		// Store claims in context for use by handlers
 		c.Set("userID", claims.UserID)
 		c.Set("organizationID", claims.OrganizationID)
 		c.Set("userEmail", claims.Email)
 		c.Set("userRole", claims.Role)
+		c.Set("tokenScope", claims.Scope)
 		c.Set("claims", claims) // Or set the whole claims struct
 
 		c.Next()
 	}
 }
 
+// enrollmentRoutes são as rotas aceitas com um token restrito ao cadastro de MFA, além das que começam com
+// enrollmentRoutePrefix (ver setupV1Routes).
+var enrollmentRoutes = map[string]bool{
+	"/api/v1/me":              true,
+	"/api/v1/auth/logout":     true,
+	"/api/v1/auth/logout-all": true,
+}
+
+const enrollmentRoutePrefix = "/api/v1/users/me/2fa/"
+
+func enrollmentRouteAllowed(route string) bool {
+	return enrollmentRoutes[route] || strings.HasPrefix(route, enrollmentRoutePrefix)
+}
+
 // RoleAuthMiddleware cria um middleware para verificar se o usuário tem a role necessária.
 // Ele assume que o AuthMiddleware já foi executado.
 func RoleAuthMiddleware(requiredRole models.UserRole) gin.HandlerFunc {
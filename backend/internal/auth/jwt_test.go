@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
 	"testing"
 	"time"
 	"errors" // Adicionado para errors.Is
@@ -17,16 +18,13 @@ import (
 
 func TestMain(m *testing.M) {
 	// Setup: Initialize JWT with a test key before running tests
-	os.Setenv("JWT_SECRET_KEY", "testsecretkeyforjwtauthentication")
-	os.Setenv("JWT_TOKEN_LIFESPAN_HOURS", "1")
+	config.Cfg.JWTSecret = "testsecretkeyforjwtauthentication"
+	config.Cfg.JWTTokenLifespan = time.Hour
 	if err := InitializeJWT(); err != nil {
 		panic("Failed to initialize JWT for testing: " + err.Error())
 	}
 	// Run tests
 	exitVal := m.Run()
-	// Teardown: Clean up environment variables if necessary
-	os.Unsetenv("JWT_SECRET_KEY")
-	os.Unsetenv("JWT_TOKEN_LIFESPAN_HOURS")
 	os.Exit(exitVal)
 }
 
@@ -79,7 +77,7 @@ func TestValidateToken_InvalidSignature(t *testing.T) {
 	userID := uuid.New()
 	orgID := uuid.New()
 	user := &models.User{ID: userID, Email: "test@example.com", Role: models.RoleUser, OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true}}
-	tokenString, _ := GenerateToken(user, user.OrganizationID)
+	tokenString, _ := GenerateToken(user, user.OrganizationID.UUID)
 
 	// Tamper with the token or try to validate with a different key (simulated by re-initializing with wrong key)
 	// For simplicity, we'll just check against a known invalid token structure.
@@ -103,8 +101,7 @@ func TestValidateToken_InvalidSignature(t *testing.T) {
 
 
 func TestValidateToken_Expired(t *testing.T) {
-	os.Setenv("JWT_TOKEN_LIFESPAN_HOURS", "-1") // Set lifespan to negative 1 hour
-	InitializeJWT() // Re-initialize to pick up new lifespan
+	withJWTConfig(t, func(cfg *config.AppConfig) { cfg.JWTTokenLifespan = -time.Hour }) // Tokens já vencidos
 
 	userID := uuid.New()
 	orgID := uuid.New()
@@ -129,9 +126,6 @@ func TestValidateToken_Expired(t *testing.T) {
 		// Se errors.Is(err, jwt.ErrTokenExpired) for verdadeiro, o teste está correto.
 		assert.True(t, true, "Correctly identified jwt.ErrTokenExpired")
 	}
-
-	os.Setenv("JWT_TOKEN_LIFESPAN_HOURS", "1") // Reset lifespan for other tests
-	InitializeJWT()
 }
 
 func TestAuthMiddleware(t *testing.T) {
@@ -174,7 +168,7 @@ func TestAuthMiddleware(t *testing.T) {
 	userID := uuid.New()
 	orgID := uuid.New()
 	user := &models.User{ID: userID, Email: "authmiddleware@example.com", Role: models.RoleManager, OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true}}
-	validToken, _ := GenerateToken(user, user.OrganizationID)
+	validToken, _ := GenerateToken(user, user.OrganizationID.UUID)
 
 	reqValid, _ := http.NewRequest(http.MethodGet, "/testauth", nil)
 	reqValid.Header.Set("Authorization", "Bearer "+validToken)
@@ -182,3 +176,143 @@ func TestAuthMiddleware(t *testing.T) {
 	router.ServeHTTP(rrValid, reqValid)
 	assert.Equal(t, http.StatusOK, rrValid.Code)
 }
+
+// withJWTConfig altera a configuração e reinicializa o JWT; ao final do teste, a configuração original é restaurada.
+func withJWTConfig(t *testing.T, change func(cfg *config.AppConfig)) {
+	previous := config.Cfg
+	t.Cleanup(func() {
+		config.Cfg = previous
+		if err := InitializeJWT(); err != nil {
+			t.Fatalf("failed to restore JWT config: %v", err)
+		}
+	})
+	change(&config.Cfg)
+	if err := InitializeJWT(); err != nil {
+		t.Fatalf("InitializeJWT: %v", err)
+	}
+}
+
+func TestValidateToken_IssuerAndAudience(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "aud@example.com", Role: models.RoleUser}
+	withJWTConfig(t, func(cfg *config.AppConfig) { cfg.JWTIssuer, cfg.JWTAudience = "https://grc.example.com", "phoenix-api" })
+
+	tokenString, err := GenerateToken(user, uuid.New())
+	assert.NoError(t, err)
+	claims, err := ValidateToken(tokenString)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://grc.example.com", claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"phoenix-api"}, claims.Audience)
+
+	// Token com outro issuer
+	other := &Claims{UserID: user.ID, RegisteredClaims: jwt.RegisteredClaims{
+		Issuer: DefaultIssuer, Audience: jwt.ClaimStrings{"phoenix-api"}, ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}}
+	otherToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, other).SignedString(jwtKey)
+	_, err = ValidateToken(otherToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+
+	// Token sem a audiência exigida
+	other.Issuer = "https://grc.example.com"
+	other.Audience = jwt.ClaimStrings{"another-api"}
+	otherToken, _ = jwt.NewWithClaims(jwt.SigningMethodHS256, other).SignedString(jwtKey)
+	_, err = ValidateToken(otherToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+}
+
+func TestValidateToken_ClockSkew(t *testing.T) {
+	withJWTConfig(t, func(cfg *config.AppConfig) { cfg.JWTClockSkew = time.Minute })
+	claims := &Claims{UserID: uuid.New(), RegisteredClaims: jwt.RegisteredClaims{
+		Issuer: DefaultIssuer, ExpiresAt: jwt.NewNumericDate(time.Now().Add(-30 * time.Second)),
+	}}
+	tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+	_, err := ValidateToken(tokenString)
+	assert.NoError(t, err, "token expired within the accepted clock skew should be valid")
+
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Minute))
+	tokenString, _ = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+	_, err = ValidateToken(tokenString)
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+}
+
+func TestInitializeJWT_InvalidSettings(t *testing.T) {
+	previous := config.Cfg
+	t.Cleanup(func() {
+		config.Cfg = previous
+		InitializeJWT()
+	})
+
+	config.Cfg.JWTClockSkew = -time.Second
+	assert.Error(t, InitializeJWT())
+	config.Cfg.JWTClockSkew = 0
+
+	config.Cfg.JWTGatewayIssuer = "https://edge.example.com"
+	assert.Error(t, InitializeJWT(), "gateway without key must be rejected")
+	config.Cfg.JWTGatewayPublicKey = "not a pem"
+	assert.Error(t, InitializeJWT())
+}
+
+func TestValidateToken_Gateway(t *testing.T) {
+	gatewaySecret := []byte("gateway-shared-secret")
+	withJWTConfig(t, func(cfg *config.AppConfig) {
+		cfg.JWTGatewayIssuer = "https://edge.example.com"
+		cfg.JWTGatewayAudience = "phoenix"
+		cfg.JWTGatewaySecret = string(gatewaySecret)
+		cfg.JWTGatewayEmailClaim = "upn"
+	})
+	orgID := uuid.New()
+	user := &models.User{ID: uuid.New(), Email: "edge@example.com", Role: models.RoleManager, OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true}}
+	originalResolver := GatewayUserResolver
+	t.Cleanup(func() { GatewayUserResolver = originalResolver })
+	GatewayUserResolver = func(email string) (*models.User, error) {
+		if email != user.Email {
+			return nil, errors.New("not found")
+		}
+		return user, nil
+	}
+	gatewayToken := func(claims jwt.MapClaims, key []byte) string {
+		s, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+		return s
+	}
+	valid := jwt.MapClaims{"iss": "https://edge.example.com", "aud": "phoenix", "upn": user.Email, "exp": time.Now().Add(time.Hour).Unix()}
+
+	claims, err := ValidateToken(gatewayToken(valid, gatewaySecret))
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+	assert.Equal(t, orgID, claims.OrganizationID)
+	assert.Equal(t, models.RoleManager, claims.Role)
+
+	// Assinado com a chave do Phoenix, não a do gateway
+	_, err = ValidateToken(gatewayToken(valid, jwtKey))
+	assert.ErrorIs(t, err, jwt.ErrSignatureInvalid)
+
+	wrongAudience := jwt.MapClaims{"iss": "https://edge.example.com", "aud": "other", "upn": user.Email, "exp": time.Now().Add(time.Hour).Unix()}
+	_, err = ValidateToken(gatewayToken(wrongAudience, gatewaySecret))
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+
+	noExpiration := jwt.MapClaims{"iss": "https://edge.example.com", "aud": "phoenix", "upn": user.Email}
+	_, err = ValidateToken(gatewayToken(noExpiration, gatewaySecret))
+	assert.Error(t, err)
+
+	unknownUser := jwt.MapClaims{"iss": "https://edge.example.com", "aud": "phoenix", "upn": "nobody@example.com", "exp": time.Now().Add(time.Hour).Unix()}
+	_, err = ValidateToken(gatewayToken(unknownUser, gatewaySecret))
+	assert.Error(t, err)
+
+	// Tokens do Phoenix continuam válidos
+	phoenixToken, _ := GenerateToken(user, orgID)
+	_, err = ValidateToken(phoenixToken)
+	assert.NoError(t, err)
+}
+
+func TestAccessTokenLifespanIsReadAtInitialization(t *testing.T) {
+	previous := config.Cfg
+	t.Cleanup(func() { config.Cfg = previous })
+	// Sem reiniciar (InitializeJWT), uma alteração da configuração não muda a validade dos tokens
+	config.Cfg.JWTTokenLifespan = 48 * time.Hour
+
+	user := &models.User{ID: uuid.New(), Email: "lifespan@example.com", Role: models.RoleUser}
+	tokenString, err := GenerateToken(user, uuid.New())
+	assert.NoError(t, err)
+	claims, err := ValidateToken(tokenString)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, time.Minute)
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LockoutPolicy define o bloqueio temporário do login após falhas seguidas.
+type LockoutPolicy struct {
+	Threshold int           // Falhas que bloqueiam a conta; 0 desativa o bloqueio
+	Duration  time.Duration // Duração do bloqueio; falhas mais antigas que isso não contam
+}
+
+// CurrentLockoutPolicy retorna a política configurada em LOGIN_LOCKOUT_THRESHOLD e LOGIN_LOCKOUT_MINUTES.
+func CurrentLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{Threshold: config.Cfg.LoginLockoutThreshold, Duration: config.Cfg.LoginLockoutDuration}
+}
+
+// Enabled indica se o bloqueio está ativo.
+func (p LockoutPolicy) Enabled() bool {
+	return p.Threshold > 0 && p.Duration > 0
+}
+
+// LoginFailure é o resultado do registro de uma falha de login.
+type LoginFailure struct {
+	Attempts    int        // Falhas seguidas na janela, contando esta
+	LockedUntil *time.Time // Preenchido quando esta falha bloqueou a conta
+}
+
+// RecordLoginFailure conta uma falha de login (senha ou segundo fator) do usuário e bloqueia a conta ao
+// atingir o limite da política. O contador é atualizado no banco, então tentativas simultâneas não escapam da
+// contagem; só a falha que efetivamente bloqueou a conta retorna LockedUntil.
+func RecordLoginFailure(db *gorm.DB, userID uuid.UUID, policy LockoutPolicy, now time.Time) (LoginFailure, error) {
+	var failure LoginFailure
+	if !policy.Enabled() {
+		return failure, nil
+	}
+	// Falhas fora da janela recomeçam a contagem
+	err := db.Raw(`UPDATE users SET
+			failed_login_attempts = CASE WHEN last_failed_login_at IS NULL OR last_failed_login_at < ? THEN 1 ELSE failed_login_attempts + 1 END,
+			last_failed_login_at = ?
+		WHERE id = ? RETURNING failed_login_attempts`, now.Add(-policy.Duration), now, userID).
+		Scan(&failure.Attempts).Error
+	if err != nil || failure.Attempts < policy.Threshold {
+		return failure, err
+	}
+
+	lockedUntil := now.Add(policy.Duration)
+	result := db.Model(&models.User{}).
+		Where("id = ? AND failed_login_attempts >= ?", userID, policy.Threshold).
+		Updates(map[string]interface{}{"locked_until": lockedUntil, "failed_login_attempts": 0})
+	if result.Error != nil {
+		return failure, result.Error
+	}
+	if result.RowsAffected > 0 {
+		failure.LockedUntil = &lockedUntil
+	}
+	return failure, nil
+}
+
+// ResetLoginFailures zera a contagem de falhas após um login completo.
+func ResetLoginFailures(db *gorm.DB, user *models.User) error {
+	if user.FailedLoginAttempts == 0 && user.LastFailedLoginAt == nil && user.LockedUntil == nil {
+		return nil
+	}
+	user.FailedLoginAttempts, user.LastFailedLoginAt, user.LockedUntil = 0, nil, nil
+	return db.Model(&models.User{}).Where("id = ?", user.ID).
+		Updates(map[string]interface{}{"failed_login_attempts": 0, "last_failed_login_at": nil, "locked_until": nil}).Error
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordLoginFailureBelowThreshold(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	userID := uuid.New()
+	now := time.Now()
+	policy := LockoutPolicy{Threshold: 5, Duration: 15 * time.Minute}
+	mock.ExpectQuery(`UPDATE users SET .* WHERE id = \$3 RETURNING failed_login_attempts`).
+		WithArgs(now.Add(-policy.Duration), now, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"failed_login_attempts"}).AddRow(2))
+
+	failure, err := RecordLoginFailure(db, userID, policy, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, failure.Attempts)
+	assert.Nil(t, failure.LockedUntil)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordLoginFailureLocksAtThreshold(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	userID := uuid.New()
+	now := time.Now()
+	policy := LockoutPolicy{Threshold: 5, Duration: 15 * time.Minute}
+	mock.ExpectQuery(`UPDATE users SET`).WillReturnRows(sqlmock.NewRows([]string{"failed_login_attempts"}).AddRow(5))
+	mock.ExpectExec(`UPDATE "users" SET .* WHERE id = \$\d+ AND failed_login_attempts >= \$\d+`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	failure, err := RecordLoginFailure(db, userID, policy, now)
+	require.NoError(t, err)
+	require.NotNil(t, failure.LockedUntil)
+	assert.Equal(t, now.Add(policy.Duration), *failure.LockedUntil)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordLoginFailureConcurrentLock(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	policy := LockoutPolicy{Threshold: 3, Duration: time.Minute}
+	// Outra tentativa simultânea já bloqueou a conta e zerou o contador
+	mock.ExpectQuery(`UPDATE users SET`).WillReturnRows(sqlmock.NewRows([]string{"failed_login_attempts"}).AddRow(3))
+	mock.ExpectExec(`UPDATE "users" SET`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	failure, err := RecordLoginFailure(db, uuid.New(), policy, time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, failure.LockedUntil, "only the attempt that locked the account reports it")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordLoginFailureDisabled(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	failure, err := RecordLoginFailure(db, uuid.New(), LockoutPolicy{Threshold: 0, Duration: time.Minute}, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, failure.Attempts)
+	assert.NoError(t, mock.ExpectationsWereMet(), "no queries when lockout is disabled")
+}
+
+func TestResetLoginFailures(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	user := &models.User{ID: uuid.New()}
+	require.NoError(t, ResetLoginFailures(db, user), "nothing to reset")
+
+	lockedUntil := time.Now().Add(time.Minute)
+	user.FailedLoginAttempts, user.LockedUntil = 2, &lockedUntil
+	assert.True(t, user.IsLocked(time.Now()))
+	mock.ExpectExec(`UPDATE "users" SET .*"failed_login_attempts"=\$\d+.* WHERE id = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, ResetLoginFailures(db, user))
+	assert.False(t, user.IsLocked(time.Now()))
+	assert.Zero(t, user.FailedLoginAttempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultRefreshTokenLifespan é a validade do refresh token sem JWT_REFRESH_TOKEN_LIFESPAN_HOURS. Cada
+	// renovação a estende: a sessão expira após esse tempo sem uso.
+	defaultRefreshTokenLifespan = 30 * 24 * time.Hour
+	// sessionCacheTTL é por quanto tempo uma sessão verificada como ativa não é consultada de novo no banco.
+	// Revogações feitas por outra instância da API levam até esse tempo para valer nela.
+	sessionCacheTTL   = 30 * time.Second
+	refreshTokenBytes = 32
+	// enrollmentSessionLifespan é a validade das sessões restritas ao cadastro de MFA e dos seus tokens: o
+	// suficiente para cadastrar o fator, depois do que o usuário entra de novo com ele.
+	enrollmentSessionLifespan = 30 * time.Minute
+)
+
+var refreshTokenLifespan = defaultRefreshTokenLifespan
+
+var (
+	// ErrInvalidRefreshToken indica um refresh token desconhecido, expirado ou de uma sessão revogada.
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrRefreshTokenReused indica o uso de um refresh token já rotacionado: a sessão foi revogada.
+	ErrRefreshTokenReused = errors.New("refresh token has already been used; the session was revoked")
+)
+
+// TokenPair é o par de tokens de uma sessão: o token de acesso (JWT com a claim sid) e o refresh token opaco.
+type TokenPair struct {
+	AccessToken           string    `json:"token"`
+	AccessTokenExpiresAt  time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	SessionID             uuid.UUID `json:"session_id"`
+	Scope                 string    `json:"scope,omitempty"` // Escopo da sessão (models.SessionScopeMFAEnrollment)
+}
+
+// activeSessions guarda as sessões verificadas recentemente como ativas (ID -> validade da verificação).
+var activeSessions sync.Map
+
+// StartSession cria uma sessão de login para o usuário e emite o primeiro par de tokens.
+func StartSession(db *gorm.DB, user *models.User, organizationID uuid.UUID, userAgent, ipAddress string) (*TokenPair, error) {
+	return startSession(db, user, organizationID, userAgent, ipAddress, "")
+}
+
+// StartEnrollmentSession cria uma sessão restrita ao cadastro de MFA (models.SessionScopeMFAEnrollment), para o
+// usuário que ainda não tem o segundo fator exigido pela organização. Ela expira em enrollmentSessionLifespan e
+// continua restrita nas renovações: depois de cadastrar o fator, o usuário entra de novo com ele.
+func StartEnrollmentSession(db *gorm.DB, user *models.User, organizationID uuid.UUID, userAgent, ipAddress string) (*TokenPair, error) {
+	return startSession(db, user, organizationID, userAgent, ipAddress, models.SessionScopeMFAEnrollment)
+}
+
+func startSession(db *gorm.DB, user *models.User, organizationID uuid.UUID, userAgent, ipAddress, scope string) (*TokenPair, error) {
+	refreshToken, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	session := models.AuthSession{
+		UserID:           user.ID,
+		RefreshTokenHash: hash,
+		ExpiresAt:        time.Now().Add(sessionLifespan(scope)),
+		Scope:            scope,
+		UserAgent:        truncate(userAgent, 255),
+		IPAddress:        truncate(ipAddress, 45),
+	}
+	if organizationID != uuid.Nil {
+		session.OrganizationID = &organizationID
+	}
+	if err := db.Create(&session).Error; err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
+	}
+	return issueTokenPair(user, organizationID, &session, refreshToken)
+}
+
+// RefreshSession troca um refresh token por um novo par de tokens, rotacionando o refresh token. Os dados do
+// usuário (papel, organização) são relidos do banco. Um refresh token já rotacionado revoga a sessão
+// (ErrRefreshTokenReused): ele só volta a ser usado se tiver sido copiado.
+func RefreshSession(db *gorm.DB, refreshToken string) (*TokenPair, *models.User, error) {
+	hash := hashToken(refreshToken)
+	now := time.Now()
+
+	var session models.AuthSession
+	err := db.Where("refresh_token_hash = ?", hash).Take(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		var previous models.AuthSession
+		if db.Where("previous_refresh_token_hash = ? AND revoked_at IS NULL", hash).Take(&previous).Error == nil {
+			if err := RevokeSession(db, previous.ID, models.SessionRevokedRefreshReuse); err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, ErrRefreshTokenReused
+		}
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading session: %w", err)
+	}
+	if !session.Active(now) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	var user models.User
+	if err := db.Take(&user, "id = ?", session.UserID).Error; err != nil {
+		return nil, nil, fmt.Errorf("error loading session user: %w", err)
+	}
+	if !user.IsActive {
+		if err := RevokeSession(db, session.ID, models.SessionRevokedUserDeactivated); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	newToken, newHash, err := newRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	expiresAt := now.Add(sessionLifespan(session.Scope))
+	// A condição no hash atual garante que duas renovações simultâneas com o mesmo token não emitam dois pares
+	result := db.Model(&models.AuthSession{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, hash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":          newHash,
+			"previous_refresh_token_hash": hash,
+			"expires_at":                  expiresAt,
+			"last_refreshed_at":           now,
+			"organization_id":             nullableUUID(user.OrganizationID),
+		})
+	if result.Error != nil {
+		return nil, nil, fmt.Errorf("error rotating refresh token: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	session.ExpiresAt = expiresAt
+	pair, err := issueTokenPair(&user, user.OrganizationID.UUID, &session, newToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, &user, nil
+}
+
+// RevokeSession revoga uma sessão: os seus tokens de acesso e o refresh token deixam de ser aceitos.
+func RevokeSession(db *gorm.DB, sessionID uuid.UUID, reason string) error {
+	err := db.Model(&models.AuthSession{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error
+	if err != nil {
+		return fmt.Errorf("error revoking session: %w", err)
+	}
+	activeSessions.Delete(sessionID)
+	return nil
+}
+
+// RevokeUserSessions revoga as sessões ativas do usuário, exceto a informada em except (se não for nil), e
+// retorna quantas foram revogadas.
+func RevokeUserSessions(db *gorm.DB, userID uuid.UUID, reason string, except *uuid.UUID) (int64, error) {
+	var ids []uuid.UUID
+	query := db.Model(&models.AuthSession{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if except != nil {
+		query = query.Where("id <> ?", *except)
+	}
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("error listing sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := db.Model(&models.AuthSession{}).
+		Where("id IN ? AND revoked_at IS NULL", ids).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	if result.Error != nil {
+		return 0, fmt.Errorf("error revoking sessions: %w", result.Error)
+	}
+	for _, id := range ids {
+		activeSessions.Delete(id)
+	}
+	return result.RowsAffected, nil
+}
+
+// sessionIsActive indica se a sessão de um token de acesso não foi revogada. O token de acesso tem a própria
+// validade, então a expiração do refresh token não é considerada aqui.
+func sessionIsActive(db *gorm.DB, sessionID uuid.UUID) (bool, error) {
+	if until, ok := activeSessions.Load(sessionID); ok && time.Now().Before(until.(time.Time)) {
+		return true, nil
+	}
+	if db == nil {
+		return false, errors.New("database not initialized")
+	}
+	var count int64
+	if err := db.Model(&models.AuthSession{}).Where("id = ? AND revoked_at IS NULL", sessionID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count == 0 {
+		activeSessions.Delete(sessionID)
+		return false, nil
+	}
+	activeSessions.Store(sessionID, time.Now().Add(sessionCacheTTL))
+	return true, nil
+}
+
+func issueTokenPair(user *models.User, organizationID uuid.UUID, session *models.AuthSession, refreshToken string) (*TokenPair, error) {
+	accessToken, accessExpiresAt, err := signAccessToken(user, organizationID, &session.ID, session.Scope)
+	if err != nil {
+		return nil, err
+	}
+	activeSessions.Store(session.ID, time.Now().Add(sessionCacheTTL))
+	return &TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+		SessionID:             session.ID,
+		Scope:                 session.Scope,
+	}, nil
+}
+
+// sessionLifespan é a validade do refresh token de uma sessão com o escopo informado.
+func sessionLifespan(scope string) time.Duration {
+	if scope == models.SessionScopeMFAEnrollment {
+		return enrollmentSessionLifespan
+	}
+	return refreshTokenLifespan
+}
+
+// newRefreshToken gera um refresh token aleatório e o seu hash, que é o único valor armazenado.
+func newRefreshToken() (token, hash string, err error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("error generating refresh token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashToken(token), nil
+}
+
+// hashToken é o SHA-256, em hexadecimal, de um token opaco (refresh tokens e chaves de API).
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func nullableUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
+
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
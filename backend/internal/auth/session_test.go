@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newSessionMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestStartSessionIssuesSessionBoundTokens(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	orgID := uuid.New()
+	user := &models.User{ID: uuid.New(), Email: "session@example.com", Role: models.RoleManager}
+	mock.ExpectExec(`INSERT INTO "auth_sessions"`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	pair, err := StartSession(db, user, orgID, "test-agent", "10.0.0.1")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.NotEmpty(t, pair.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(refreshTokenLifespan), pair.RefreshTokenExpiresAt, 5*time.Second)
+
+	claims, err := ValidateToken(pair.AccessToken)
+	require.NoError(t, err)
+	require.NotNil(t, claims.SessionID)
+	assert.Equal(t, pair.SessionID, *claims.SessionID)
+	assert.Equal(t, orgID, claims.OrganizationID)
+	assert.NotEmpty(t, claims.ID, "access tokens have a jti")
+}
+
+func TestRefreshSessionRotatesTheRefreshToken(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	sessionID, userID, orgID := uuid.New(), uuid.New(), uuid.New()
+	oldToken := "old-refresh-token"
+
+	mock.ExpectQuery(`SELECT \* FROM "auth_sessions" WHERE refresh_token_hash = \$1`).
+		WithArgs(hashToken(oldToken), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "refresh_token_hash", "expires_at"}).
+			AddRow(sessionID, userID, hashToken(oldToken), time.Now().Add(time.Hour)))
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE id = \$1`).
+		WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role", "organization_id", "is_active"}).
+			AddRow(userID, "session@example.com", models.RoleAdmin, orgID, true))
+	mock.ExpectExec(`UPDATE "auth_sessions" SET .* WHERE id = \$\d+ AND refresh_token_hash = \$\d+ AND revoked_at IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	pair, user, err := RefreshSession(db, oldToken)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.NotEqual(t, oldToken, pair.RefreshToken)
+	assert.Equal(t, sessionID, pair.SessionID)
+	assert.Equal(t, models.RoleAdmin, user.Role, "role is reloaded from the database")
+
+	claims, err := ValidateToken(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID, *claims.SessionID)
+	assert.Equal(t, orgID, claims.OrganizationID)
+}
+
+func TestRefreshSessionRevokesOnReuse(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	sessionID := uuid.New()
+	rotated := "already-rotated-token"
+
+	mock.ExpectQuery(`SELECT \* FROM "auth_sessions" WHERE refresh_token_hash = \$1`).
+		WithArgs(hashToken(rotated), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "auth_sessions" WHERE previous_refresh_token_hash = \$1 AND revoked_at IS NULL`).
+		WithArgs(hashToken(rotated), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(sessionID))
+	mock.ExpectExec(`UPDATE "auth_sessions" SET .*"revoked_reason"=\$\d+.* WHERE id = \$\d+ AND revoked_at IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, _, err := RefreshSession(db, rotated)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshSessionUnknownToken(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	mock.ExpectQuery(`SELECT \* FROM "auth_sessions" WHERE refresh_token_hash`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "auth_sessions" WHERE previous_refresh_token_hash`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, _, err := RefreshSession(db, "unknown")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthMiddlewareRejectsRevokedSession(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	originalDB := database.DB
+	database.DB = db
+	defer func() { database.DB = originalDB }()
+
+	sessionID := uuid.New()
+	user := &models.User{ID: uuid.New(), Email: "revoked@example.com", Role: models.RoleUser}
+	token, _, err := signAccessToken(user, uuid.New(), &sessionID, "")
+	require.NoError(t, err)
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "auth_sessions" WHERE id = \$1 AND revoked_at IS NULL`).
+		WithArgs(sessionID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/protected", AuthMiddleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Session has been revoked")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStartEnrollmentSessionIssuesRestrictedTokens(t *testing.T) {
+	db, mock := newSessionMockDB(t)
+	user := &models.User{ID: uuid.New(), Email: "enroll@example.com", Role: models.RoleUser}
+	mock.ExpectExec(`INSERT INTO "auth_sessions"`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	pair, err := StartEnrollmentSession(db, user, uuid.New(), "test-agent", "10.0.0.1")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, models.SessionScopeMFAEnrollment, pair.Scope)
+	assert.WithinDuration(t, time.Now().Add(enrollmentSessionLifespan), pair.RefreshTokenExpiresAt, 5*time.Second)
+	assert.WithinDuration(t, time.Now().Add(enrollmentSessionLifespan), pair.AccessTokenExpiresAt, 5*time.Second)
+
+	claims, err := ValidateToken(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, models.SessionScopeMFAEnrollment, claims.Scope)
+}
+
+func TestAuthMiddlewareRestrictsEnrollmentTokens(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "enroll@example.com", Role: models.RoleUser}
+	restricted, _, err := signAccessToken(user, uuid.New(), nil, models.SessionScopeMFAEnrollment)
+	require.NoError(t, err)
+	unknown, _, err := signAccessToken(user, uuid.New(), nil, "something_else")
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1", AuthMiddleware())
+	api.POST("/users/me/2fa/totp/setup", func(c *gin.Context) { c.Status(http.StatusOK) })
+	api.POST("/auth/logout", func(c *gin.Context) { c.Status(http.StatusOK) })
+	api.GET("/risks", func(c *gin.Context) { c.Status(http.StatusOK) })
+	call := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, call(http.MethodPost, "/api/v1/users/me/2fa/totp/setup", restricted).Code)
+	assert.Equal(t, http.StatusOK, call(http.MethodPost, "/api/v1/auth/logout", restricted).Code)
+	rec := call(http.MethodGet, "/api/v1/risks", restricted)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"mfa_enrollment_required":true`)
+	assert.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/api/v1/risks", unknown).Code)
+}
@@ -0,0 +1,90 @@
+package avscan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize é o tamanho dos blocos enviados ao clamd no comando INSTREAM.
+const clamdChunkSize = 64 * 1024
+
+// ClamAVScanner verifica arquivos via clamd (protocolo TCP, comando INSTREAM).
+type ClamAVScanner struct {
+	Address string // host:port do clamd
+	Timeout time.Duration
+}
+
+// Scan envia o conteúdo ao clamd e interpreta a resposta ("stream: OK" ou "stream: <assinatura> FOUND").
+func (s *ClamAVScanner) Scan(ctx context.Context, filename string, content io.Reader) (Result, error) {
+	dialer := net.Dialer{Timeout: s.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.Address)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to clamd at %s: %w", s.Address, err)
+	}
+	defer conn.Close()
+	if s.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.Timeout))
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("failed to send INSTREAM to clamd: %w", err)
+	}
+
+	buf := make([]byte, clamdChunkSize)
+	sizeHeader := make([]byte, 4)
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(sizeHeader, uint32(n))
+			if _, err := conn.Write(sizeHeader); err != nil {
+				return Result{}, fmt.Errorf("failed to stream data to clamd: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return Result{}, fmt.Errorf("failed to stream data to clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return Result{}, fmt.Errorf("failed to read file for scanning: %w", readErr)
+		}
+	}
+	// Bloco de tamanho zero sinaliza o fim do stream.
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return Result{}, fmt.Errorf("failed to finish INSTREAM: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil && err != io.EOF {
+		return Result{}, fmt.Errorf("failed to read clamd response: %w", err)
+	}
+	return parseClamdReply(reply)
+}
+
+// parseClamdReply converte a resposta do clamd em um Result.
+func parseClamdReply(reply string) (Result, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	result := Result{Engine: "clamav", ScannedAt: time.Now()}
+	switch {
+	case strings.HasSuffix(reply, " OK"):
+		result.Verdict = VerdictClean
+		return result, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		result.Verdict = VerdictInfected
+		signature := strings.TrimSuffix(reply, " FOUND")
+		if idx := strings.Index(signature, ": "); idx >= 0 {
+			signature = signature[idx+2:]
+		}
+		result.Signature = signature
+		return result, nil
+	default:
+		return Result{}, fmt.Errorf("unexpected clamd response: %q", reply)
+	}
+}
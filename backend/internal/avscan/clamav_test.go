@@ -0,0 +1,80 @@
+package avscan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd aceita uma conexão, consome o INSTREAM e responde com reply.
+func fakeClamd(t *testing.T, reply string) (addr string, received chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	received = make(chan string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		cmd, _ := r.ReadString('\x00')
+		if cmd != "zINSTREAM\x00" {
+			return
+		}
+		var data strings.Builder
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(r, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			chunk := make([]byte, n)
+			if _, err := io.ReadFull(r, chunk); err != nil {
+				return
+			}
+			data.Write(chunk)
+		}
+		received <- data.String()
+		conn.Write([]byte(reply + "\x00"))
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestClamAVScanner_Clean(t *testing.T) {
+	addr, received := fakeClamd(t, "stream: OK")
+	scanner := &ClamAVScanner{Address: addr, Timeout: 5 * time.Second}
+
+	result, err := scanner.Scan(context.Background(), "evidence.pdf", strings.NewReader("harmless content"))
+	require.NoError(t, err)
+	assert.Equal(t, VerdictClean, result.Verdict)
+	assert.Equal(t, "harmless content", <-received)
+}
+
+func TestClamAVScanner_Infected(t *testing.T) {
+	addr, _ := fakeClamd(t, "stream: Eicar-Test-Signature FOUND")
+	scanner := &ClamAVScanner{Address: addr, Timeout: 5 * time.Second}
+
+	result, err := scanner.Scan(context.Background(), "eicar.txt", strings.NewReader("X5O!P%@AP"))
+	require.NoError(t, err)
+	assert.Equal(t, VerdictInfected, result.Verdict)
+	assert.Equal(t, "Eicar-Test-Signature", result.Signature)
+}
+
+func TestParseClamdReply_Unexpected(t *testing.T) {
+	_, err := parseClamdReply("INSTREAM size limit exceeded. ERROR")
+	assert.Error(t, err)
+}
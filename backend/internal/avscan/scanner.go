@@ -0,0 +1,93 @@
+package avscan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// Verdict é o resultado de uma verificação antivírus, persistido junto ao registro que referencia o arquivo.
+type Verdict string
+
+const (
+	VerdictClean      Verdict = "clean"
+	VerdictInfected   Verdict = "infected"
+	VerdictError      Verdict = "scan_error"  // Scanner indisponível e AV_SCAN_FAIL_OPEN=true
+	VerdictNotScanned Verdict = "not_scanned" // Nenhum scanner configurado
+)
+
+// Result descreve o resultado de uma verificação.
+type Result struct {
+	Verdict   Verdict
+	Signature string // Nome da ameaça detectada, se houver
+	Engine    string // "clamav", "webhook"
+	ScannedAt time.Time
+}
+
+// Scanner verifica o conteúdo de um arquivo antes de ele ser persistido.
+type Scanner interface {
+	Scan(ctx context.Context, filename string, content io.Reader) (Result, error)
+}
+
+// DefaultScanner é o scanner configurado na inicialização. Nil significa que nenhuma verificação é feita.
+var DefaultScanner Scanner
+
+// InitScanner inicializa o scanner padrão com base em AV_SCANNER ("clamav", "webhook" ou vazio).
+func InitScanner() error {
+	log := phxlog.L.Named("AVScanner")
+	switch strings.ToLower(config.Cfg.AVScanner) {
+	case "":
+		log.Info("Antivirus scanning is disabled (AV_SCANNER not set).")
+		DefaultScanner = nil
+	case "clamav":
+		if config.Cfg.ClamAVAddress == "" {
+			return fmt.Errorf("AV_SCANNER=clamav requires CLAMAV_ADDRESS (host:port)")
+		}
+		DefaultScanner = &ClamAVScanner{Address: config.Cfg.ClamAVAddress, Timeout: 60 * time.Second}
+		log.Info("ClamAV scanner configured", zap.String("address", config.Cfg.ClamAVAddress))
+	case "webhook":
+		if config.Cfg.AVWebhookURL == "" {
+			return fmt.Errorf("AV_SCANNER=webhook requires AV_WEBHOOK_URL")
+		}
+		DefaultScanner = NewWebhookScanner(config.Cfg.AVWebhookURL, config.Cfg.AVWebhookToken)
+		log.Info("Webhook scanner configured", zap.String("url", config.Cfg.AVWebhookURL))
+	default:
+		return fmt.Errorf("unsupported AV_SCANNER '%s' (expected clamav or webhook)", config.Cfg.AVScanner)
+	}
+	return nil
+}
+
+// ScanUpload verifica um upload com o DefaultScanner e reposiciona o leitor no início,
+// para que o mesmo arquivo possa ser enviado ao armazenamento em seguida.
+// Se o scanner falhar e AV_SCAN_FAIL_OPEN estiver ativo, retorna VerdictError sem erro.
+func ScanUpload(ctx context.Context, filename string, file io.ReadSeeker) (Result, error) {
+	if DefaultScanner == nil {
+		return Result{Verdict: VerdictNotScanned}, nil
+	}
+
+	result, err := DefaultScanner.Scan(ctx, filename, file)
+	if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil {
+		return Result{}, fmt.Errorf("failed to reset file pointer after scan: %w", seekErr)
+	}
+	if err != nil {
+		phxlog.L.Error("Antivirus scan failed", zap.String("filename", filename), zap.Error(err))
+		if config.Cfg.AVScanFailOpen {
+			return Result{Verdict: VerdictError, ScannedAt: time.Now()}, nil
+		}
+		return Result{}, err
+	}
+	if result.Verdict == VerdictInfected {
+		phxlog.L.Warn("Infected upload rejected",
+			zap.String("filename", filename),
+			zap.String("signature", result.Signature),
+			zap.String("engine", result.Engine))
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package avscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WebhookScanner envia o arquivo para um serviço HTTP externo de verificação.
+// O serviço recebe o conteúdo bruto via POST (query param "filename") e deve responder
+// com JSON: {"infected": bool, "signature": "string"}.
+type WebhookScanner struct {
+	URL    string
+	Token  string // Enviado como "Authorization: Bearer <token>", se definido
+	client *http.Client
+}
+
+type webhookScanResponse struct {
+	Infected  bool   `json:"infected"`
+	Signature string `json:"signature"`
+}
+
+// NewWebhookScanner cria um WebhookScanner com timeout padrão.
+func NewWebhookScanner(scanURL, token string) *WebhookScanner {
+	return &WebhookScanner{URL: scanURL, Token: token, client: &http.Client{Timeout: 60 * time.Second}}
+}
+
+// Scan envia o conteúdo ao webhook e interpreta o veredito.
+func (s *WebhookScanner) Scan(ctx context.Context, filename string, content io.Reader) (Result, error) {
+	target, err := url.Parse(s.URL)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid scanner webhook URL: %w", err)
+	}
+	q := target.Query()
+	q.Set("filename", filename)
+	target.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), content)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create scanner request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("scanner webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("scanner webhook returned status %d", resp.StatusCode)
+	}
+
+	var body webhookScanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Result{}, fmt.Errorf("failed to decode scanner webhook response: %w", err)
+	}
+
+	result := Result{Engine: "webhook", ScannedAt: time.Now(), Verdict: VerdictClean}
+	if body.Infected {
+		result.Verdict = VerdictInfected
+		result.Signature = body.Signature
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+// Package bootstrap reúne a inicialização compartilhada pelos processos do backend: o servidor da API
+// (cmd/server) e o worker dedicado da fila de jobs (cmd/worker).
+package bootstrap
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/idempotency"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/orgexport"
+	"phoenixgrc/backend/internal/reports"
+	"phoenixgrc/backend/internal/retention"
+	"phoenixgrc/backend/internal/trash"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// CheckKeys verifica as chaves de segurança essenciais.
+// Se elas estiverem ausentes ou com os valores padrão, gera novas chaves
+// e encerra a aplicação com instruções para o usuário.
+func CheckKeys() {
+	log := phxlog.L.Named("KeyCheck")
+	jwtKey := config.Cfg.JWTSecret
+	encryptionKey := config.Cfg.EncryptionKeyHex
+
+	jwtDefault := "mudar_esta_chave_em_producao_com_um_valor_aleatorio_longo"
+	encryptionKeyDefault := "mudar_para_64_caracteres_hexadecimais_em_producao"
+
+	if jwtKey == "" || jwtKey == jwtDefault || encryptionKey == "" || encryptionKey == encryptionKeyDefault {
+		log.Warn("Chaves de segurança ausentes ou padrão detectadas. Gerando novas chaves.")
+
+		// Gerar nova JWT_SECRET_KEY (64 bytes, codificado em hex)
+		jwtBytes := make([]byte, 64)
+		if _, err := rand.Read(jwtBytes); err != nil {
+			log.Fatal("Falha ao gerar a chave JWT.", zap.Error(err))
+		}
+		newJwtKey := hex.EncodeToString(jwtBytes)
+
+		// Gerar nova ENCRYPTION_KEY_HEX (32 bytes, 64 caracteres hex)
+		encBytes := make([]byte, 32)
+		if _, err := rand.Read(encBytes); err != nil {
+			log.Fatal("Falha ao gerar a chave de criptografia.", zap.Error(err))
+		}
+		newEncKey := hex.EncodeToString(encBytes)
+
+		fmt.Println("------------------------------------------------------------------")
+		fmt.Println("ATENÇÃO: As chaves de segurança não foram configuradas.")
+		fmt.Println("Por favor, adicione as seguintes linhas ao seu arquivo .env:")
+		fmt.Println("------------------------------------------------------------------")
+		fmt.Printf("JWT_SECRET_KEY=%s\n", newJwtKey)
+		fmt.Printf("ENCRYPTION_KEY_HEX=%s\n", newEncKey)
+		fmt.Println("------------------------------------------------------------------")
+		fmt.Println("A aplicação será encerrada. Após atualizar o arquivo .env, inicie-a novamente.")
+		os.Exit(1)
+	}
+}
+
+// ConnectDatabase conecta ao PostgreSQL configurado pelas variáveis POSTGRES_*.
+func ConnectDatabase() error {
+	cfg := config.Cfg
+	dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode := cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBSSLMode
+	if dbUser == "" || dbPassword == "" || dbName == "" {
+		return fmt.Errorf("credenciais do banco de dados (POSTGRES_USER, POSTGRES_PASSWORD, POSTGRES_DB) devem ser definidas")
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
+		dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode)
+	return database.ConnectDB(dsn)
+}
+
+// RegisterJobHandlers registra os handlers de todos os tipos de job. Deve ser chamado pelos processos que
+// executam a fila de jobs.
+func RegisterJobHandlers() {
+	notifications.RegisterJobHandlers()
+	reports.RegisterJobHandlers()
+	handlers.RegisterJobHandlers()
+}
+
+// StartSchedulers inicia os agendadores periódicos (lembretes, vencimentos, snapshots, relatórios e limpezas) até o
+// contexto ser cancelado. Os e-mails e relatórios que eles disparam são executados pela fila de jobs.
+func StartSchedulers(ctx context.Context) {
+	log := phxlog.L.Named("Schedulers")
+
+	notifications.StartPolicyAckReminderScheduler(ctx, time.Hour)
+	log.Info("Agendador de lembretes de aceite de políticas iniciado.")
+
+	notifications.StartSecretRotationReminderScheduler(ctx, time.Hour)
+	log.Info("Agendador de lembretes de rotação de credenciais iniciado.")
+
+	notifications.StartAssessmentDueReminderScheduler(ctx, time.Hour)
+	log.Info("Agendador de lembretes de prazo de avaliações iniciado.")
+
+	notifications.StartControlWaiverExpiryScheduler(ctx, time.Hour)
+	log.Info("Agendador de vencimento de exceções de controles iniciado.")
+
+	compliancescore.StartSnapshotScheduler(ctx, time.Hour)
+	log.Info("Agendador de snapshots diários do score de conformidade iniciado.")
+
+	reports.StartScheduler(ctx, 5*time.Minute)
+	log.Info("Agendador de relatórios por e-mail iniciado.")
+
+	idempotency.StartPurgeScheduler(ctx, time.Hour)
+	log.Info("Agendador de limpeza das chaves de idempotência iniciado.")
+
+	trash.StartPurgeScheduler(ctx, time.Hour)
+	log.Info("Agendador de expurgo da lixeira iniciado.")
+
+	retention.StartScheduler(ctx, time.Hour)
+	log.Info("Agendador das regras de retenção de dados iniciado.")
+
+	orgexport.StartPurgeScheduler(ctx, time.Hour)
+	log.Info("Agendador de limpeza das exportações da organização iniciado.")
+}
@@ -0,0 +1,82 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/ratelimit"
+	"phoenixgrc/backend/pkg/config"
+	"phoenixgrc/backend/pkg/features"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// RegisterReloadHooks registra o que é reaplicado quando config.Reload altera uma configuração recarregável:
+// o cliente de e-mail, o cache das feature flags e as políticas de limitação de requisições.
+func RegisterReloadHooks() {
+	config.OnReload(func(result *config.ReloadResult) error {
+		if result.Affects("AWS_REGION", "AWS_SES_EMAIL_SENDER") {
+			notifications.InitEmailService()
+		}
+		return nil
+	})
+	config.OnReload(func(result *config.ReloadResult) error {
+		if result.Affects("FLAGS_CACHE_TTL_SECONDS") {
+			features.SetCacheTTL(time.Duration(config.Get().FeatureFlagCacheTTLSeconds) * time.Second)
+		}
+		return nil
+	})
+	config.OnReload(func(result *config.ReloadResult) error {
+		if !result.Affects("RATE_LIMIT_ENABLED", "RATE_LIMIT_POLICY_*") {
+			return nil
+		}
+		// O Store (RATE_LIMIT_STORE, REDIS_URL) só muda ao reiniciar; uma política inválida mantém as atuais
+		cfg := config.Get()
+		return ratelimit.Configure(ratelimit.Options{Enabled: cfg.RateLimitEnabled, Policies: cfg.RateLimitPolicies})
+	})
+}
+
+// HandleReloadSignal recarrega a configuração a cada SIGHUP (ex: `kill -HUP` após atualizar o .env ou o
+// ConfigMap) até o contexto ser cancelado.
+func HandleReloadSignal(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				ReloadConfig("signal")
+			}
+		}
+	}()
+}
+
+// ReloadConfig executa config.Reload e registra o resultado no log. source identifica a origem da recarga
+// (sinal ou endpoint).
+func ReloadConfig(source string) (*config.ReloadResult, error) {
+	log := phxlog.L.Named("ConfigReload")
+	result, err := config.Reload()
+	if err != nil {
+		log.Error("Configuração inválida; nenhuma alteração aplicada.", zap.String("source", source), zap.Error(err))
+		return nil, err
+	}
+	fields := []zap.Field{
+		zap.String("source", source),
+		zap.Strings("changed", result.Changed),
+		zap.Strings("restartRequired", result.RestartRequired),
+	}
+	if len(result.Errors) > 0 {
+		log.Error("Configuração recarregada com falhas.", append(fields, zap.Strings("errors", result.Errors))...)
+	} else {
+		log.Info("Configuração recarregada.", fields...)
+	}
+	return result, nil
+}
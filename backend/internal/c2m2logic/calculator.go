@@ -29,32 +29,12 @@ func CalculateAndUpdateMaturityLevel(assessmentID uuid.UUID, db *gorm.DB) (int,
 		return 0, fmt.Errorf("failed to fetch C2M2 practice evaluations for assessment %s: %w", assessmentID, err)
 	}
 
-	// Mapear avaliações por ID da prática para fácil acesso
-	evaluationsMap := make(map[uuid.UUID]models.PracticeStatus)
-	for _, e := range evaluations {
-		evaluationsMap[e.PracticeID] = e.Status
-	}
-
 	// 3. Calcular o MIL alcançado
-	achievedMIL := 0
-	for mil := 1; mil <= 3; mil++ {
-		allPracticesForMILMet := true
-		for _, p := range allPractices {
-			if p.TargetMIL == mil {
-				status, exists := evaluationsMap[p.ID]
-				if !exists || status != models.PracticeStatusFullyImplemented {
-					allPracticesForMILMet = false
-					break
-				}
-			}
-		}
-
-		if allPracticesForMILMet {
-			achievedMIL = mil
-		} else {
-			break // Não pode alcançar um MIL maior se o atual não foi alcançado
-		}
+	practiceMIL := make(map[uuid.UUID]int, len(allPractices))
+	for _, p := range allPractices {
+		practiceMIL[p.ID] = p.TargetMIL
 	}
+	achievedMIL := AchievedMIL(evaluations, practiceMIL)
 
 	// 4. Atualizar o assessment no banco de dados
 	err := db.Model(&models.AuditAssessment{}).
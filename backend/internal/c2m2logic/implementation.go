@@ -0,0 +1,75 @@
+package c2m2logic
+
+import (
+	"math"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// DomainImplementation agrega as avaliações de práticas C2M2 de um domínio.
+type DomainImplementation struct {
+	DomainID             uuid.UUID
+	PracticesEvaluated   int // Práticas distintas com ao menos uma avaliação
+	NotImplemented       int
+	PartiallyImplemented int
+	FullyImplemented     int
+}
+
+// Evaluations é o total de avaliações de práticas consideradas.
+func (d DomainImplementation) Evaluations() int {
+	return d.NotImplemented + d.PartiallyImplemented + d.FullyImplemented
+}
+
+// ImplementationPercentage é o percentual de implementação (0-100, uma casa decimal): práticas
+// totalmente implementadas contam 1 e parcialmente implementadas 0,5. Sem avaliações retorna 0.
+func (d DomainImplementation) ImplementationPercentage() float64 {
+	total := d.Evaluations()
+	if total == 0 {
+		return 0
+	}
+	score := float64(d.FullyImplemented) + 0.5*float64(d.PartiallyImplemented)
+	return math.Round(score/float64(total)*1000) / 10
+}
+
+// Add soma as contagens de outro domínio (usado no consolidado do framework).
+func (d *DomainImplementation) Add(other DomainImplementation) {
+	d.PracticesEvaluated += other.PracticesEvaluated
+	d.NotImplemented += other.NotImplemented
+	d.PartiallyImplemented += other.PartiallyImplemented
+	d.FullyImplemented += other.FullyImplemented
+}
+
+// SummarizeByDomain agrega as avaliações de práticas por domínio, na ordem de domainIDs. Uma prática
+// avaliada em várias avaliações de controle conta uma vez por avaliação; avaliações de práticas fora de
+// practiceDomain ou com status desconhecido são ignoradas.
+func SummarizeByDomain(domainIDs []uuid.UUID, practiceDomain map[uuid.UUID]uuid.UUID, evaluations []models.C2M2PracticeEvaluation) []DomainImplementation {
+	summaries := make([]DomainImplementation, len(domainIDs))
+	byDomain := make(map[uuid.UUID]*DomainImplementation, len(domainIDs))
+	for i, id := range domainIDs {
+		summaries[i].DomainID = id
+		byDomain[id] = &summaries[i]
+	}
+	evaluated := make(map[uuid.UUID]bool)
+	for _, e := range evaluations {
+		summary, ok := byDomain[practiceDomain[e.PracticeID]]
+		if !ok {
+			continue
+		}
+		switch e.Status {
+		case models.PracticeStatusNotImplemented:
+			summary.NotImplemented++
+		case models.PracticeStatusPartiallyImplemented:
+			summary.PartiallyImplemented++
+		case models.PracticeStatusFullyImplemented:
+			summary.FullyImplemented++
+		default:
+			continue
+		}
+		if !evaluated[e.PracticeID] {
+			evaluated[e.PracticeID] = true
+			summary.PracticesEvaluated++
+		}
+	}
+	return summaries
+}
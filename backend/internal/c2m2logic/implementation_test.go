@@ -0,0 +1,52 @@
+package c2m2logic
+
+import (
+	"phoenixgrc/backend/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeByDomain(t *testing.T) {
+	riskDomain, assetDomain, emptyDomain := uuid.New(), uuid.New(), uuid.New()
+	rm1, rm2, am1 := uuid.New(), uuid.New(), uuid.New()
+	practiceDomain := map[uuid.UUID]uuid.UUID{rm1: riskDomain, rm2: riskDomain, am1: assetDomain}
+	eval := func(id uuid.UUID, status models.PracticeStatus) models.C2M2PracticeEvaluation {
+		return models.C2M2PracticeEvaluation{PracticeID: id, Status: status}
+	}
+
+	summaries := SummarizeByDomain([]uuid.UUID{riskDomain, assetDomain, emptyDomain}, practiceDomain, []models.C2M2PracticeEvaluation{
+		eval(rm1, models.PracticeStatusFullyImplemented),
+		eval(rm1, models.PracticeStatusPartiallyImplemented), // mesma prática em outro controle
+		eval(rm2, models.PracticeStatusNotImplemented),
+		eval(am1, models.PracticeStatusPartiallyImplemented),
+		eval(am1, "unknown"),
+		eval(uuid.New(), models.PracticeStatusFullyImplemented),
+	})
+	assert.Len(t, summaries, 3)
+
+	risk := summaries[0]
+	assert.Equal(t, riskDomain, risk.DomainID)
+	assert.Equal(t, 2, risk.PracticesEvaluated)
+	assert.Equal(t, 3, risk.Evaluations())
+	assert.Equal(t, 50.0, risk.ImplementationPercentage())
+
+	asset := summaries[1]
+	assert.Equal(t, 1, asset.Evaluations())
+	assert.Equal(t, 50.0, asset.ImplementationPercentage())
+
+	empty := summaries[2]
+	assert.Equal(t, DomainImplementation{DomainID: emptyDomain}, empty)
+	assert.Equal(t, 0.0, empty.ImplementationPercentage())
+
+	var overall DomainImplementation
+	for _, s := range summaries {
+		overall.Add(s)
+	}
+	assert.Equal(t, 4, overall.Evaluations())
+	assert.Equal(t, 50.0, overall.ImplementationPercentage())
+
+	twoThirds := DomainImplementation{FullyImplemented: 2, NotImplemented: 1}
+	assert.Equal(t, 66.7, twoThirds.ImplementationPercentage())
+}
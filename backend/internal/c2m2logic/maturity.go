@@ -0,0 +1,116 @@
+package c2m2logic
+
+import (
+	"phoenixgrc/backend/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// NISTFunctions são as funções do NIST CSF usadas no sumário de maturidade, na ordem de apresentação.
+var NISTFunctions = []string{"Identify", "Protect", "Detect", "Respond", "Recover", "Govern"}
+
+// nistFunctionByCode mapeia o prefixo do identificador do NIST CSF (ex.: "ID" em "ID.AM-1") para a função.
+var nistFunctionByCode = map[string]string{
+	"ID": "Identify",
+	"PR": "Protect",
+	"DE": "Detect",
+	"RS": "Respond",
+	"RC": "Recover",
+	"GV": "Govern",
+}
+
+// NISTFunction identifica a função NIST do controle pelo prefixo do control_id (ex.: "PR.AA-1"), pelo código
+// da família entre parênteses (ex.: "Gestão de Ativos (ID.AM)") ou pelo nome da família (ex.: "Identify").
+// Retorna "" quando o controle não pertence a uma função NIST.
+func NISTFunction(controlID, family string) string {
+	if code, _, ok := strings.Cut(controlID, "."); ok {
+		if fn, found := nistFunctionByCode[strings.ToUpper(strings.TrimSpace(code))]; found {
+			return fn
+		}
+	}
+	name, rest, _ := strings.Cut(family, " (")
+	if code, _, ok := strings.Cut(rest, "."); ok {
+		if fn, found := nistFunctionByCode[strings.ToUpper(strings.TrimSpace(code))]; found {
+			return fn
+		}
+	}
+	name = strings.TrimSpace(name)
+	for _, fn := range NISTFunctions {
+		if strings.EqualFold(name, fn) {
+			return fn
+		}
+	}
+	return ""
+}
+
+// AchievedMIL deriva o MIL de uma avaliação a partir das práticas avaliadas: o maior nível L tal que todas as
+// práticas com MIL alvo de 1 a L estão totalmente implementadas. Práticas sem avaliação contam como não
+// implementadas. practiceMIL é o MIL alvo de cada prática do catálogo; avaliações de práticas desconhecidas
+// são ignoradas. É a regra de CalculateAndUpdateMaturityLevel.
+func AchievedMIL(evaluations []models.C2M2PracticeEvaluation, practiceMIL map[uuid.UUID]int) int {
+	status := make(map[uuid.UUID]models.PracticeStatus, len(evaluations))
+	for _, e := range evaluations {
+		status[e.PracticeID] = e.Status
+	}
+	achieved := 0
+	for mil := 1; mil <= 3; mil++ {
+		for id, target := range practiceMIL {
+			if target == mil && status[id] != models.PracticeStatusFullyImplemented {
+				return achieved // Não pode alcançar um MIL maior se o atual não foi alcançado
+			}
+		}
+		achieved = mil
+	}
+	return achieved
+}
+
+// ControlMaturity é a maturidade de um controle do framework para o sumário.
+type ControlMaturity struct {
+	ControlID string
+	Family    string
+	Evaluated bool // A avaliação do controle tem práticas C2M2 avaliadas
+	MIL       int
+}
+
+// FunctionSummary agrega a maturidade dos controles de uma função NIST.
+type FunctionSummary struct {
+	Function          string
+	AchievedMIL       int // MIL mais frequente entre os controles avaliados (empate: o maior)
+	EvaluatedControls int
+	TotalControls     int
+	Distribution      [4]int // Controles avaliados por MIL (0-3)
+}
+
+// SummarizeByFunction agrega os controles por função NIST. Todas as funções são retornadas, na ordem de
+// NISTFunctions, mesmo sem controles; controles fora das funções NIST são ignorados.
+func SummarizeByFunction(controls []ControlMaturity) []FunctionSummary {
+	byFunction := make(map[string]*FunctionSummary, len(NISTFunctions))
+	summaries := make([]FunctionSummary, len(NISTFunctions))
+	for i, fn := range NISTFunctions {
+		summaries[i].Function = fn
+		byFunction[fn] = &summaries[i]
+	}
+	for _, ctrl := range controls {
+		summary, ok := byFunction[NISTFunction(ctrl.ControlID, ctrl.Family)]
+		if !ok {
+			continue
+		}
+		summary.TotalControls++
+		if !ctrl.Evaluated || ctrl.MIL < 0 || ctrl.MIL > 3 {
+			continue
+		}
+		summary.EvaluatedControls++
+		summary.Distribution[ctrl.MIL]++
+	}
+	for i := range summaries {
+		maxCount := 0
+		for mil, count := range summaries[i].Distribution {
+			if count > 0 && count >= maxCount {
+				maxCount = count
+				summaries[i].AchievedMIL = mil
+			}
+		}
+	}
+	return summaries
+}
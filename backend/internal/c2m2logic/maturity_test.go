@@ -0,0 +1,74 @@
+package c2m2logic
+
+import (
+	"phoenixgrc/backend/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNISTFunction(t *testing.T) {
+	assert.Equal(t, "Identify", NISTFunction("ID.AM-1", "Gestão de Ativos (ID.AM)"))
+	assert.Equal(t, "Govern", NISTFunction("GV.OC-1", ""))
+	assert.Equal(t, "Recover", NISTFunction("CUSTOM-1", "Planejamento de Recuperação (RC.RP)"))
+	assert.Equal(t, "Protect", NISTFunction("X-1", "Protect (PR)"))
+	assert.Equal(t, "", NISTFunction("A.5.1", "Controles Organizacionais"))
+}
+
+func TestAchievedMIL(t *testing.T) {
+	mil1a, mil1b, mil2, mil3 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	practiceMIL := map[uuid.UUID]int{mil1a: 1, mil1b: 1, mil2: 2, mil3: 3}
+	eval := func(id uuid.UUID, status models.PracticeStatus) models.C2M2PracticeEvaluation {
+		return models.C2M2PracticeEvaluation{PracticeID: id, Status: status}
+	}
+	full, partial := models.PracticeStatusFullyImplemented, models.PracticeStatusPartiallyImplemented
+
+	testCases := []struct {
+		name        string
+		evaluations []models.C2M2PracticeEvaluation
+		expected    int
+	}{
+		{"no evaluations", nil, 0},
+		{"MIL 1 practice partial", []models.C2M2PracticeEvaluation{eval(mil1a, full), eval(mil1b, partial)}, 0},
+		{"MIL 1 practice not evaluated", []models.C2M2PracticeEvaluation{eval(mil1a, full), eval(mil2, full), eval(mil3, full)}, 0},
+		{"all MIL 1 practices full", []models.C2M2PracticeEvaluation{eval(mil1a, full), eval(mil1b, full), eval(mil2, partial)}, 1},
+		{"MIL 1 and 2 full", []models.C2M2PracticeEvaluation{eval(mil1a, full), eval(mil1b, full), eval(mil2, full)}, 2},
+		{"all levels full", []models.C2M2PracticeEvaluation{eval(mil1a, full), eval(mil1b, full), eval(mil2, full), eval(mil3, full)}, 3},
+		{"MIL 2 not evaluated stops at 1", []models.C2M2PracticeEvaluation{eval(mil1a, full), eval(mil1b, full), eval(mil3, full)}, 1},
+		{"unknown practice ignored", []models.C2M2PracticeEvaluation{eval(mil1a, full), eval(mil1b, full), eval(uuid.New(), partial)}, 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, AchievedMIL(tc.evaluations, practiceMIL))
+		})
+	}
+	// Níveis sem práticas no catálogo contam como atingidos, como em CalculateAndUpdateMaturityLevel
+	assert.Equal(t, 3, AchievedMIL([]models.C2M2PracticeEvaluation{eval(mil2, full)}, map[uuid.UUID]int{mil2: 2}))
+}
+
+func TestSummarizeByFunction(t *testing.T) {
+	summaries := SummarizeByFunction([]ControlMaturity{
+		{ControlID: "ID.AM-1", Evaluated: true, MIL: 2},
+		{ControlID: "ID.AM-2", Evaluated: true, MIL: 1},
+		{ControlID: "ID.RA-1", Evaluated: true, MIL: 2},
+		{ControlID: "ID.RA-2"},
+		{ControlID: "PR.AA-1", Evaluated: true, MIL: 1},
+		{ControlID: "PR.AA-2", Evaluated: true, MIL: 3},
+		{ControlID: "A.5.1", Evaluated: true, MIL: 3},
+	})
+	assert.Len(t, summaries, len(NISTFunctions))
+
+	identify := summaries[0]
+	assert.Equal(t, "Identify", identify.Function)
+	assert.Equal(t, 4, identify.TotalControls)
+	assert.Equal(t, 3, identify.EvaluatedControls)
+	assert.Equal(t, [4]int{0, 1, 2, 0}, identify.Distribution)
+	assert.Equal(t, 2, identify.AchievedMIL, "most frequent MIL")
+
+	protect := summaries[1]
+	assert.Equal(t, 3, protect.AchievedMIL, "ties resolve to the higher MIL")
+
+	detect := summaries[2]
+	assert.Equal(t, FunctionSummary{Function: "Detect"}, detect)
+}
@@ -0,0 +1,141 @@
+package compliancescore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertSnapshots grava os snapshots de score, substituindo o snapshot existente da mesma organização,
+// framework e data.
+func UpsertSnapshots(db *gorm.DB, snapshots []models.ComplianceScoreSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "organization_id"}, {Name: "framework_id"}, {Name: "snapshot_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"compliance_score", "total_controls", "evaluated_controls",
+			"conformant_controls", "partially_conformant_controls", "non_conformant_controls", "source", "updated_at"}),
+	}).CreateInBatches(&snapshots, 500).Error
+}
+
+// SnapshotFromTally monta o snapshot do agregado corrente na data informada.
+func SnapshotFromTally(tally models.ComplianceScoreTally, totalControls int, date time.Time, source models.ComplianceSnapshotSource) models.ComplianceScoreSnapshot {
+	c := FromTally(tally)
+	return models.ComplianceScoreSnapshot{
+		OrganizationID:              tally.OrganizationID,
+		FrameworkID:                 tally.FrameworkID,
+		SnapshotDate:                date,
+		ComplianceScore:             c.Score(),
+		TotalControls:               totalControls,
+		EvaluatedControls:           c.Evaluated,
+		ConformantControls:          c.Conformant,
+		PartiallyConformantControls: c.PartiallyConformant,
+		NonConformantControls:       c.NonConformant,
+		Source:                      source,
+	}
+}
+
+// TakeDailySnapshots grava o snapshot do dia (UTC) de cada organização em cada framework com avaliações.
+// Executado mais de uma vez no mesmo dia, substitui o snapshot do dia: o último da data é o estado no fim
+// do dia. Falhas em um framework não interrompem os demais e são retornadas junto com a quantidade gravada.
+func TakeDailySnapshots(ctx context.Context, db *gorm.DB, now time.Time) (int, error) {
+	db = db.WithContext(ctx)
+	var pairs []struct {
+		OrganizationID uuid.UUID
+		FrameworkID    uuid.UUID
+	}
+	if err := db.Raw(`SELECT DISTINCT audit_assessments.organization_id, audit_controls.framework_id
+		FROM audit_assessments
+		JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id
+		WHERE audit_assessments.audit_campaign_id IS NULL AND audit_assessments.status <> ''`).
+		Scan(&pairs).Error; err != nil {
+		return 0, err
+	}
+	if len(pairs) == 0 {
+		return 0, nil
+	}
+
+	frameworkIDs := make([]uuid.UUID, 0, len(pairs))
+	for _, p := range pairs {
+		frameworkIDs = append(frameworkIDs, p.FrameworkID)
+	}
+	var counts []struct {
+		FrameworkID uuid.UUID
+		Total       int
+	}
+	if err := db.Model(&models.AuditControl{}).Select("framework_id, COUNT(*) AS total").
+		Where("framework_id IN ?", frameworkIDs).Group("framework_id").Scan(&counts).Error; err != nil {
+		return 0, err
+	}
+	totalControls := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		totalControls[count.FrameworkID] = count.Total
+	}
+
+	y, m, d := now.UTC().Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	snapshots := make([]models.ComplianceScoreSnapshot, 0, len(pairs))
+	var errs []error
+	for _, p := range pairs {
+		tally, err := Load(db, p.OrganizationID, p.FrameworkID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		snapshots = append(snapshots, SnapshotFromTally(tally, totalControls[p.FrameworkID], date, models.ComplianceSnapshotSourceNightly))
+	}
+	if err := UpsertSnapshots(db, snapshots); err != nil {
+		return 0, errors.Join(append(errs, err)...)
+	}
+	return len(snapshots), errors.Join(errs...)
+}
+
+// StartSnapshotScheduler grava os snapshots do dia periodicamente até o contexto ser cancelado.
+func StartSnapshotScheduler(ctx context.Context, every time.Duration) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				count, err := TakeDailySnapshots(ctx, database.GetDB(), now)
+				if err != nil {
+					phxlog.L.Error("Compliance score snapshot failed", zap.Error(err))
+				}
+				if count > 0 {
+					phxlog.L.Debug("Compliance score snapshots recorded", zap.Int("count", count))
+				}
+			}
+		}
+	}()
+}
+
+// Weekly reduz uma série diária (ordenada por data) a um ponto por semana ISO (segunda a domingo): o
+// último snapshot de cada semana, que é o estado no fim da semana.
+func Weekly(snapshots []models.ComplianceScoreSnapshot) []models.ComplianceScoreSnapshot {
+	weekly := make([]models.ComplianceScoreSnapshot, 0, len(snapshots)/7+1)
+	for _, s := range snapshots {
+		year, week := s.SnapshotDate.ISOWeek()
+		if n := len(weekly); n > 0 {
+			lastYear, lastWeek := weekly[n-1].SnapshotDate.ISOWeek()
+			if lastYear == year && lastWeek == week {
+				weekly[n-1] = s
+				continue
+			}
+		}
+		weekly = append(weekly, s)
+	}
+	return weekly
+}
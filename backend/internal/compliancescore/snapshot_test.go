@@ -0,0 +1,50 @@
+package compliancescore
+
+import (
+	"phoenixgrc/backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotFromTally(t *testing.T) {
+	tally := models.ComplianceScoreTally{
+		OrganizationID:     uuid.New(),
+		FrameworkID:        uuid.New(),
+		ScoreSum:           250,
+		EvaluatedControls:  3,
+		ConformantControls: 2,
+	}
+	date := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	snapshot := SnapshotFromTally(tally, 10, date, models.ComplianceSnapshotSourceNightly)
+	assert.Equal(t, tally.OrganizationID, snapshot.OrganizationID)
+	assert.Equal(t, tally.FrameworkID, snapshot.FrameworkID)
+	assert.Equal(t, date, snapshot.SnapshotDate)
+	assert.InDelta(t, 83.33, snapshot.ComplianceScore, 0.01)
+	assert.Equal(t, 10, snapshot.TotalControls)
+	assert.Equal(t, 3, snapshot.EvaluatedControls)
+	assert.Equal(t, 2, snapshot.ConformantControls)
+	assert.Equal(t, models.ComplianceSnapshotSourceNightly, snapshot.Source)
+}
+
+func TestWeeklyKeepsLastSnapshotOfEachISOWeek(t *testing.T) {
+	day := func(y int, m time.Month, d int, score float64) models.ComplianceScoreSnapshot {
+		return models.ComplianceScoreSnapshot{SnapshotDate: time.Date(y, m, d, 0, 0, 0, 0, time.UTC), ComplianceScore: score}
+	}
+	daily := []models.ComplianceScoreSnapshot{
+		day(2024, 12, 23, 10), // segunda
+		day(2024, 12, 29, 20), // domingo: fim da semana 52
+		day(2024, 12, 30, 30), // segunda: semana 1 de 2025
+		day(2025, 1, 2, 40),
+		day(2025, 1, 13, 50), // semana sem snapshots antes desta
+	}
+	weekly := Weekly(daily)
+	if assert.Len(t, weekly, 3) {
+		assert.Equal(t, 20.0, weekly[0].ComplianceScore)
+		assert.Equal(t, 40.0, weekly[1].ComplianceScore)
+		assert.Equal(t, 50.0, weekly[2].ComplianceScore)
+	}
+	assert.Empty(t, Weekly(nil))
+}
@@ -0,0 +1,152 @@
+package compliancescore
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lock serializa, até o fim da transação, as escritas e recálculos do agregado da organização no framework.
+func lock(tx *gorm.DB, orgID, frameworkID uuid.UUID) error {
+	key := fmt.Sprintf("compliance_score:%s:%s", orgID, frameworkID)
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error
+}
+
+// findAssessment retorna a avaliação atual do controle na organização, ou nil se não houver.
+func findAssessment(tx *gorm.DB, orgID, controlID uuid.UUID) (*models.AuditAssessment, error) {
+	var assessment models.AuditAssessment
+	err := tx.Scopes(models.OrgWideAssessments).Where("organization_id = ? AND audit_control_id = ?", orgID, controlID).Take(&assessment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &assessment, nil
+}
+
+// rebuild recalcula o agregado a partir das avaliações e o grava. Deve ser chamado com o lock obtido.
+func rebuild(tx *gorm.DB, orgID, frameworkID uuid.UUID) (models.ComplianceScoreTally, error) {
+	tally := models.ComplianceScoreTally{OrganizationID: orgID, FrameworkID: frameworkID}
+	err := tx.Raw(`SELECT
+			COALESCE(SUM(audit_assessments.score) FILTER (WHERE audit_assessments.status <> ''), 0) AS score_sum,
+			COUNT(*) FILTER (WHERE audit_assessments.status <> '') AS evaluated_controls,
+			COUNT(*) FILTER (WHERE audit_assessments.status = ?) AS conformant_controls,
+			COUNT(*) FILTER (WHERE audit_assessments.status = ?) AS partially_conformant_controls,
+			COUNT(*) FILTER (WHERE audit_assessments.status = ?) AS non_conformant_controls
+		FROM audit_assessments
+		JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id
+		WHERE audit_assessments.organization_id = ? AND audit_controls.framework_id = ?
+			AND audit_assessments.audit_campaign_id IS NULL`,
+		models.ControlStatusConformant, models.ControlStatusPartiallyConformant, models.ControlStatusNonConformant,
+		orgID, frameworkID).Scan(&tally).Error
+	if err != nil {
+		return tally, err
+	}
+	tally.OrganizationID, tally.FrameworkID = orgID, frameworkID
+	tally.RebuiltAt = time.Now()
+	err = tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}, {Name: "framework_id"}},
+		UpdateAll: true,
+	}).Create(&tally).Error
+	return tally, err
+}
+
+// Load retorna o agregado da organização no framework. Na primeira leitura (ou após Invalidate) o agregado
+// é recalculado por completo e gravado; a partir daí é mantido por RecordAssessmentWrite.
+func Load(db *gorm.DB, orgID, frameworkID uuid.UUID) (models.ComplianceScoreTally, error) {
+	var tally models.ComplianceScoreTally
+	err := db.Where("organization_id = ? AND framework_id = ?", orgID, frameworkID).Take(&tally).Error
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return tally, err
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := lock(tx, orgID, frameworkID); err != nil {
+			return err
+		}
+		var errRebuild error
+		tally, errRebuild = rebuild(tx, orgID, frameworkID)
+		return errRebuild
+	})
+	return tally, err
+}
+
+// RecordAssessmentWrite executa write (a criação ou atualização da avaliação do controle) e aplica ao
+// agregado do framework a diferença entre a avaliação anterior e a resultante, na mesma transação.
+// Retorna o agregado atualizado, ou nil se o controle não existir.
+func RecordAssessmentWrite(db *gorm.DB, orgID, controlID uuid.UUID, write func(tx *gorm.DB) error) (*models.ComplianceScoreTally, error) {
+	var result *models.ComplianceScoreTally
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var control models.AuditControl
+		if err := tx.Select("id", "framework_id").Take(&control, "id = ?", controlID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return write(tx)
+			}
+			return err
+		}
+		if err := lock(tx, orgID, control.FrameworkID); err != nil {
+			return err
+		}
+		before, err := findAssessment(tx, orgID, controlID)
+		if err != nil {
+			return err
+		}
+		if err := write(tx); err != nil {
+			return err
+		}
+		after, err := findAssessment(tx, orgID, controlID)
+		if err != nil {
+			return err
+		}
+
+		var tally models.ComplianceScoreTally
+		err = tx.Where("organization_id = ? AND framework_id = ?", orgID, control.FrameworkID).Take(&tally).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Ainda sem agregado: o recálculo já enxerga a avaliação recém-gravada.
+			tally, err = rebuild(tx, orgID, control.FrameworkID)
+			result = &tally
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		result = &tally
+		delta := Delta(before, after)
+		if delta.IsZero() {
+			return nil
+		}
+		err = tx.Model(&models.ComplianceScoreTally{}).
+			Where("organization_id = ? AND framework_id = ?", orgID, control.FrameworkID).
+			Updates(map[string]interface{}{
+				"score_sum":                     gorm.Expr("score_sum + ?", delta.ScoreSum),
+				"evaluated_controls":            gorm.Expr("evaluated_controls + ?", delta.Evaluated),
+				"conformant_controls":           gorm.Expr("conformant_controls + ?", delta.Conformant),
+				"partially_conformant_controls": gorm.Expr("partially_conformant_controls + ?", delta.PartiallyConformant),
+				"non_conformant_controls":       gorm.Expr("non_conformant_controls + ?", delta.NonConformant),
+				"updated_at":                    time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("organization_id = ? AND framework_id = ?", orgID, control.FrameworkID).Take(result).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Invalidate descarta os agregados do framework (ex: ao excluir um controle, cujas avaliações são removidas
+// em cascata). O próximo Load recalcula. orgID é a organização dona do framework, cujo lock é obtido.
+func Invalidate(tx *gorm.DB, orgID, frameworkID uuid.UUID) error {
+	if err := lock(tx, orgID, frameworkID); err != nil {
+		return err
+	}
+	return tx.Where("framework_id = ?", frameworkID).Delete(&models.ComplianceScoreTally{}).Error
+}
@@ -0,0 +1,128 @@
+// Package compliancescore mantém o score de conformidade de cada organização por framework de forma
+// incremental: cada escrita de avaliação aplica apenas a diferença entre a avaliação anterior e a nova
+// ao agregado persistido (models.ComplianceScoreTally), em vez de varrer todos os controles do framework.
+//
+// As escritas de uma mesma organização e framework são serializadas por um advisory lock transacional do
+// PostgreSQL, então atualizações concorrentes do mesmo controle nunca contam a mesma avaliação duas vezes.
+package compliancescore
+
+import (
+	"math"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Contribution é a contribuição de um conjunto de avaliações para o score de conformidade.
+type Contribution struct {
+	ScoreSum            int64
+	Evaluated           int
+	Conformant          int
+	PartiallyConformant int
+	NonConformant       int
+}
+
+// ContributionOf é a contribuição de uma avaliação. Toda avaliação com status conta como controle
+// avaliado; sem score, entra com 0 na soma. Avaliação inexistente (nil) ou ainda sem status (apenas
+// atribuída a um avaliador ou com evidência anexada) não contribui.
+func ContributionOf(assessment *models.AuditAssessment) Contribution {
+	if assessment == nil || assessment.Status == "" {
+		return Contribution{}
+	}
+	c := Contribution{Evaluated: 1}
+	if assessment.Score != nil {
+		c.ScoreSum = int64(*assessment.Score)
+	}
+	switch assessment.Status {
+	case models.ControlStatusConformant:
+		c.Conformant = 1
+	case models.ControlStatusPartiallyConformant:
+		c.PartiallyConformant = 1
+	case models.ControlStatusNonConformant:
+		c.NonConformant = 1
+	}
+	return c
+}
+
+// Delta é a variação do agregado quando a avaliação de um controle passa de before para after.
+func Delta(before, after *models.AuditAssessment) Contribution {
+	return ContributionOf(after).Sub(ContributionOf(before))
+}
+
+// Recompute soma a contribuição de todas as avaliações (recálculo completo).
+func Recompute(assessments []models.AuditAssessment) Contribution {
+	var total Contribution
+	for i := range assessments {
+		total = total.Add(ContributionOf(&assessments[i]))
+	}
+	return total
+}
+
+// Add retorna a soma das contribuições.
+func (c Contribution) Add(other Contribution) Contribution {
+	return Contribution{
+		ScoreSum:            c.ScoreSum + other.ScoreSum,
+		Evaluated:           c.Evaluated + other.Evaluated,
+		Conformant:          c.Conformant + other.Conformant,
+		PartiallyConformant: c.PartiallyConformant + other.PartiallyConformant,
+		NonConformant:       c.NonConformant + other.NonConformant,
+	}
+}
+
+// Sub retorna a diferença entre as contribuições.
+func (c Contribution) Sub(other Contribution) Contribution {
+	return Contribution{
+		ScoreSum:            c.ScoreSum - other.ScoreSum,
+		Evaluated:           c.Evaluated - other.Evaluated,
+		Conformant:          c.Conformant - other.Conformant,
+		PartiallyConformant: c.PartiallyConformant - other.PartiallyConformant,
+		NonConformant:       c.NonConformant - other.NonConformant,
+	}
+}
+
+// IsZero indica que a contribuição não altera o agregado (ex: só a evidência mudou).
+func (c Contribution) IsZero() bool {
+	return c == Contribution{}
+}
+
+// Score é a média dos scores dos controles avaliados (0 quando nenhum foi avaliado).
+func (c Contribution) Score() float64 {
+	if c.Evaluated <= 0 {
+		return 0
+	}
+	return float64(c.ScoreSum) / float64(c.Evaluated)
+}
+
+// FromTally converte o agregado persistido em contribuição.
+func FromTally(t models.ComplianceScoreTally) Contribution {
+	return Contribution{
+		ScoreSum:            t.ScoreSum,
+		Evaluated:           t.EvaluatedControls,
+		Conformant:          t.ConformantControls,
+		PartiallyConformant: t.PartiallyConformantControls,
+		NonConformant:       t.NonConformantControls,
+	}
+}
+
+// Summary é o score de uma organização em um framework, publicado no evento compliance_score.updated.
+type Summary struct {
+	FrameworkID                 uuid.UUID `json:"framework_id"`
+	ComplianceScore             float64   `json:"compliance_score"`
+	EvaluatedControls           int       `json:"evaluated_controls"`
+	ConformantControls          int       `json:"conformant_controls"`
+	PartiallyConformantControls int       `json:"partially_conformant_controls"`
+	NonConformantControls       int       `json:"non_conformant_controls"`
+}
+
+// Summarize monta o resumo do agregado, com o score arredondado em duas casas.
+func Summarize(t models.ComplianceScoreTally) Summary {
+	c := FromTally(t)
+	return Summary{
+		FrameworkID:                 t.FrameworkID,
+		ComplianceScore:             math.Round(c.Score()*100) / 100,
+		EvaluatedControls:           c.Evaluated,
+		ConformantControls:          c.Conformant,
+		PartiallyConformantControls: c.PartiallyConformant,
+		NonConformantControls:       c.NonConformant,
+	}
+}
@@ -0,0 +1,103 @@
+package compliancescore
+
+import (
+	"math/rand"
+	"phoenixgrc/backend/internal/models"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestContributionOf(t *testing.T) {
+	assert.True(t, ContributionOf(nil).IsZero())
+
+	conformant := &models.AuditAssessment{Status: models.ControlStatusConformant, Score: intPtr(100)}
+	assert.Equal(t, Contribution{ScoreSum: 100, Evaluated: 1, Conformant: 1}, ContributionOf(conformant))
+
+	assignedOnly := &models.AuditAssessment{Score: intPtr(80)}
+	assert.True(t, ContributionOf(assignedOnly).IsZero(), "assessments without status are not evaluated yet")
+
+	notApplicable := &models.AuditAssessment{Status: models.ControlStatusNotApplicable}
+	assert.Equal(t, Contribution{Evaluated: 1}, ContributionOf(notApplicable), "evaluated without score or status count")
+
+	partial := &models.AuditAssessment{Status: models.ControlStatusPartiallyConformant, Score: intPtr(50)}
+	assert.Equal(t, Contribution{ScoreSum: 50, Conformant: 1, PartiallyConformant: -1}, Delta(partial, conformant))
+	assert.True(t, Delta(conformant, conformant).IsZero(), "evidence-only update leaves the tally untouched")
+
+	total := Recompute([]models.AuditAssessment{*conformant, *partial, *notApplicable})
+	assert.Equal(t, 50.0, total.Score())
+	assert.Equal(t, 0.0, Contribution{}.Score())
+}
+
+func TestSummarizeRoundsScore(t *testing.T) {
+	frameworkID := uuid.New()
+	summary := Summarize(models.ComplianceScoreTally{FrameworkID: frameworkID, ScoreSum: 200, EvaluatedControls: 3, ConformantControls: 2})
+	assert.Equal(t, frameworkID, summary.FrameworkID)
+	assert.Equal(t, 66.67, summary.ComplianceScore)
+	assert.Equal(t, 2, summary.ConformantControls)
+}
+
+// TestConcurrentDeltasMatchFullRecompute simula muitas escritas concorrentes nos mesmos controles, cada uma
+// aplicando Delta sob o lock do framework (como RecordAssessmentWrite), e compara o agregado resultante
+// com um recálculo completo.
+func TestConcurrentDeltasMatchFullRecompute(t *testing.T) {
+	const (
+		controls = 2000
+		writers  = 16
+		writes   = 2500
+	)
+	statuses := []models.AuditControlStatus{
+		models.ControlStatusConformant,
+		models.ControlStatusPartiallyConformant,
+		models.ControlStatusNonConformant,
+		models.ControlStatusNotApplicable,
+	}
+
+	var (
+		mu          sync.Mutex // Papel do advisory lock por organização e framework
+		assessments = make(map[int]*models.AuditAssessment)
+		tally       Contribution
+		wg          sync.WaitGroup
+	)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for i := 0; i < writes; i++ {
+				control := rng.Intn(controls)
+				var after *models.AuditAssessment
+				if rng.Intn(10) > 0 { // ~10% das escritas removem a avaliação
+					after = &models.AuditAssessment{Status: statuses[rng.Intn(len(statuses))]}
+					if rng.Intn(10) == 0 { // apenas atribuída
+						after.Status = ""
+					}
+					if rng.Intn(4) > 0 {
+						after.Score = intPtr(rng.Intn(101))
+					}
+				}
+
+				mu.Lock()
+				before := assessments[control]
+				if after == nil {
+					delete(assessments, control)
+				} else {
+					assessments[control] = after
+				}
+				tally = tally.Add(Delta(before, after))
+				mu.Unlock()
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+
+	current := make([]models.AuditAssessment, 0, len(assessments))
+	for _, assessment := range assessments {
+		current = append(current, *assessment)
+	}
+	assert.Equal(t, Recompute(current), tally)
+}
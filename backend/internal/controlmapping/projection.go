@@ -0,0 +1,47 @@
+// Package controlmapping projeta avaliações de um framework sobre outro a partir dos mapeamentos
+// entre controles ("avalie uma vez, atenda vários").
+package controlmapping
+
+import "phoenixgrc/backend/internal/models"
+
+// Source é um controle do framework avaliado, mapeado para o controle projetado.
+type Source struct {
+	Relationship models.ControlMappingRelationship
+	Status       models.AuditControlStatus // Vazio quando o controle não foi avaliado
+}
+
+// statusRank ordena os status projetáveis; nao_aplicavel e vazio não projetam.
+var statusRank = map[models.AuditControlStatus]int{
+	models.ControlStatusNonConformant:       1,
+	models.ControlStatusPartiallyConformant: 2,
+	models.ControlStatusConformant:          3,
+}
+
+// ProjectStatus estima o status de um controle a partir dos controles mapeados. Um mapeamento parcial
+// limita o resultado a parcialmente_conforme; vale o melhor resultado entre as fontes.
+// Retorna false quando nenhuma fonte foi avaliada.
+func ProjectStatus(sources []Source) (models.AuditControlStatus, bool) {
+	var best models.AuditControlStatus
+	for _, s := range sources {
+		status := s.Status
+		if statusRank[status] == 0 {
+			continue
+		}
+		if s.Relationship == models.ControlMappingPartial && status == models.ControlStatusConformant {
+			status = models.ControlStatusPartiallyConformant
+		}
+		if statusRank[status] > statusRank[best] {
+			best = status
+		}
+	}
+	return best, best != ""
+}
+
+// EstimatedCoverage é o percentual estimado de cobertura: controles conformes contam inteiros e
+// parcialmente conformes contam pela metade.
+func EstimatedCoverage(conformant, partial, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return (float64(conformant) + float64(partial)/2) * 100 / float64(total)
+}
@@ -0,0 +1,37 @@
+package controlmapping
+
+import (
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectStatusTakesBestSource(t *testing.T) {
+	status, ok := ProjectStatus([]Source{
+		{Relationship: models.ControlMappingEquivalent, Status: models.ControlStatusNonConformant},
+		{Relationship: models.ControlMappingEquivalent, Status: models.ControlStatusConformant},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, models.ControlStatusConformant, status)
+}
+
+func TestProjectStatusPartialMappingCapsResult(t *testing.T) {
+	status, ok := ProjectStatus([]Source{{Relationship: models.ControlMappingPartial, Status: models.ControlStatusConformant}})
+	assert.True(t, ok)
+	assert.Equal(t, models.ControlStatusPartiallyConformant, status)
+}
+
+func TestProjectStatusIgnoresUnassessedAndNotApplicable(t *testing.T) {
+	_, ok := ProjectStatus([]Source{
+		{Relationship: models.ControlMappingEquivalent},
+		{Relationship: models.ControlMappingEquivalent, Status: models.ControlStatusNotApplicable},
+	})
+	assert.False(t, ok)
+}
+
+func TestEstimatedCoverage(t *testing.T) {
+	assert.Equal(t, 0.0, EstimatedCoverage(0, 0, 0))
+	assert.Equal(t, 50.0, EstimatedCoverage(1, 2, 4))
+}
@@ -2,7 +2,8 @@ package database
 
 import (
 	"fmt"
-	"os"
+	"phoenixgrc/backend/internal/tracing"
+	"phoenixgrc/backend/pkg/config"
 	phxlog "phoenixgrc/backend/pkg/log" // Importar o logger zap
 
 	"github.com/golang-migrate/migrate/v4"
@@ -20,12 +21,7 @@ var DB *gorm.DB
 func ConnectDB(dsn string) error {
 	var err error
 	gormLogLevel := logger.Silent
-	appEnv := os.Getenv("APP_ENV")
-	if appEnv == "" { // Fallback para GIN_MODE se APP_ENV não estiver setado
-		appEnv = os.Getenv("GIN_MODE")
-	}
-
-	if appEnv == "development" || appEnv == "debug" {
+	if config.Cfg.Environment == "development" {
 		gormLogLevel = logger.Info
 	}
 
@@ -38,6 +34,19 @@ func ConnectDB(dsn string) error {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if threshold := config.Cfg.DBSlowQueryThreshold; threshold > 0 {
+		if err := DB.Use(&SlowQueryPlugin{Threshold: threshold}); err != nil {
+			return fmt.Errorf("failed to register slow query plugin: %w", err)
+		}
+		phxlog.L.Info("Slow query logging enabled.", zap.Duration("threshold", threshold))
+	}
+
+	if tracing.Enabled() {
+		if err := DB.Use(&TracingPlugin{}); err != nil {
+			return fmt.Errorf("failed to register tracing plugin: %w", err)
+		}
+	}
+
 	phxlog.L.Info("Database connection established.")
 	return nil
 }
@@ -118,3 +127,15 @@ func MigrateDB(dbURL string) error {
 func GetDB() *gorm.DB {
 	return DB
 }
+
+// Close fecha o pool de conexões com o banco de dados, no encerramento do processo.
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// migrationDirs são os diretórios das migrações procurados por RunPhoenixMigrations, na mesma ordem.
+var migrationDirs = []string{"internal/database/migrations", "../internal/database/migrations"}
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_.*\.up\.sql$`)
+
+var (
+	latestMigrationOnce    sync.Once
+	latestMigrationVersion uint
+)
+
+// MigrationState é a situação das migrações no banco de dados.
+type MigrationState struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+	Latest  uint `json:"latest,omitempty"` // Última migração disponível; 0 se os arquivos não estiverem acessíveis
+}
+
+// LatestMigrationVersion retorna a versão da última migração disponível nos arquivos, ou 0 se o diretório
+// das migrações não for encontrado (ex: imagem sem o código-fonte).
+func LatestMigrationVersion() uint {
+	latestMigrationOnce.Do(func() {
+		for _, dir := range migrationDirs {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				match := migrationFilePattern.FindStringSubmatch(entry.Name())
+				if match == nil {
+					continue
+				}
+				if version, err := strconv.ParseUint(match[1], 10, 64); err == nil && uint(version) > latestMigrationVersion {
+					latestMigrationVersion = uint(version)
+				}
+			}
+			return
+		}
+	})
+	return latestMigrationVersion
+}
+
+// CheckMigrations lê a versão aplicada na tabela schema_migrations (golang-migrate). Retorna erro se nenhuma
+// migração foi aplicada, se a última foi interrompida (dirty) ou se há migrações pendentes.
+func CheckMigrations(ctx context.Context, db *gorm.DB) (MigrationState, error) {
+	state := MigrationState{Latest: LatestMigrationVersion()}
+	if !db.WithContext(ctx).Migrator().HasTable("schema_migrations") {
+		return state, errors.New("no migrations applied")
+	}
+	var row struct {
+		Version int64
+		Dirty   bool
+	}
+	result := db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row)
+	if result.Error != nil {
+		return state, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return state, errors.New("no migrations applied")
+	}
+	state.Version, state.Dirty = uint(row.Version), row.Dirty
+	if state.Dirty {
+		return state, fmt.Errorf("migration %d is dirty (interrupted); fix it and force the version", state.Version)
+	}
+	if state.Latest > state.Version {
+		return state, fmt.Errorf("pending migrations: database at version %d, latest is %d", state.Version, state.Latest)
+	}
+	return state, nil
+}
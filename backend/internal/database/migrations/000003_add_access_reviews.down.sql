@@ -0,0 +1,4 @@
+-- Reversão das tabelas de revisão de acessos
+
+DROP TABLE IF EXISTS access_review_items;
+DROP TABLE IF EXISTS access_review_campaigns;
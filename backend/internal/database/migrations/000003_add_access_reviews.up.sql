@@ -0,0 +1,37 @@
+-- Revisões de acesso: campanhas periódicas e itens (contas/papéis) a revisar
+
+CREATE TABLE IF NOT EXISTS access_review_campaigns (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'open', -- open, closed
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_access_review_campaigns_organization_id ON access_review_campaigns(organization_id);
+CREATE INDEX IF NOT EXISTS idx_access_review_campaigns_status ON access_review_campaigns(status);
+
+CREATE TABLE IF NOT EXISTS access_review_items (
+    id UUID PRIMARY KEY,
+    campaign_id UUID NOT NULL REFERENCES access_review_campaigns(id) ON DELETE CASCADE,
+    source_system VARCHAR(100) NOT NULL DEFAULT 'phoenix',
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Nulo para contas importadas de outros sistemas
+    account_identifier VARCHAR(255) NOT NULL,
+    account_name VARCHAR(255),
+    entitlement VARCHAR(255),
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    decision VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, keep, revoke
+    justification TEXT,
+    decided_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_access_review_items_campaign_id ON access_review_items(campaign_id);
+CREATE INDEX IF NOT EXISTS idx_access_review_items_reviewer_id ON access_review_items(reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_access_review_items_decision ON access_review_items(decision);
@@ -0,0 +1,5 @@
+-- Reversão das colunas de verificação antivírus
+
+ALTER TABLE audit_assessments DROP COLUMN IF EXISTS evidence_scanned_at;
+ALTER TABLE audit_assessments DROP COLUMN IF EXISTS evidence_scan_signature;
+ALTER TABLE audit_assessments DROP COLUMN IF EXISTS evidence_scan_status;
@@ -0,0 +1,5 @@
+-- Resultado da verificação antivírus das evidências de auditoria
+
+ALTER TABLE audit_assessments ADD COLUMN IF NOT EXISTS evidence_scan_status VARCHAR(20); -- clean, infected, scan_error, not_scanned
+ALTER TABLE audit_assessments ADD COLUMN IF NOT EXISTS evidence_scan_signature VARCHAR(255);
+ALTER TABLE audit_assessments ADD COLUMN IF NOT EXISTS evidence_scanned_at TIMESTAMP WITH TIME ZONE;
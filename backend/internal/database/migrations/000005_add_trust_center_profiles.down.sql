@@ -0,0 +1,3 @@
+-- Reversão da tabela do trust center
+
+DROP TABLE IF EXISTS trust_center_profiles;
@@ -0,0 +1,17 @@
+-- Trust center: fatos de conformidade expostos publicamente, mediante aprovação da organização
+
+CREATE TABLE IF NOT EXISTS trust_center_profiles (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL UNIQUE REFERENCES organizations(id) ON DELETE CASCADE,
+    slug VARCHAR(63) NOT NULL UNIQUE,
+    headline VARCHAR(255),
+    description TEXT,
+    certifications_json JSONB,
+    framework_ids TEXT, -- UUIDs de audit_frameworks separados por vírgula
+    show_last_audit_date BOOLEAN NOT NULL DEFAULT TRUE,
+    is_published BOOLEAN NOT NULL DEFAULT FALSE,
+    approved_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    approved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,3 @@
+-- Reversão dos locks de edição
+
+DROP TABLE IF EXISTS edit_locks;
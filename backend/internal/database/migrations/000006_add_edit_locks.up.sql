@@ -0,0 +1,17 @@
+-- Locks de edição (leases) para evitar que dois usuários sobrescrevam o mesmo risco/avaliação
+
+CREATE TABLE IF NOT EXISTS edit_locks (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    resource_type VARCHAR(30) NOT NULL, -- 'risk' ou 'assessment' (ID do audit_control)
+    resource_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    user_name VARCHAR(255),
+    acquired_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_edit_lock_resource ON edit_locks (organization_id, resource_type, resource_id);
+CREATE INDEX IF NOT EXISTS idx_edit_locks_expires_at ON edit_locks (expires_at);
@@ -0,0 +1,4 @@
+-- Reversão do histórico de avaliações e snapshots de score
+
+DROP TABLE IF EXISTS compliance_score_snapshots;
+DROP TABLE IF EXISTS audit_assessment_histories;
@@ -0,0 +1,35 @@
+-- Histórico de avaliações (importações de auditorias anteriores) e snapshots de score de conformidade
+
+CREATE TABLE IF NOT EXISTS audit_assessment_histories (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    status VARCHAR(30) NOT NULL,
+    score INTEGER,
+    assessment_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    comments TEXT,
+    import_batch_id UUID,
+    imported_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessment_history_org_control ON audit_assessment_histories (organization_id, audit_control_id);
+CREATE INDEX IF NOT EXISTS idx_audit_assessment_histories_import_batch_id ON audit_assessment_histories (import_batch_id);
+
+CREATE TABLE IF NOT EXISTS compliance_score_snapshots (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    framework_id UUID NOT NULL,
+    snapshot_date DATE NOT NULL,
+    compliance_score DOUBLE PRECISION,
+    total_controls INTEGER,
+    evaluated_controls INTEGER,
+    conformant_controls INTEGER,
+    partially_conformant_controls INTEGER,
+    non_conformant_controls INTEGER,
+    source VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_compliance_snapshot_org_fw_date ON compliance_score_snapshots (organization_id, framework_id, snapshot_date);
@@ -0,0 +1,9 @@
+-- Reversão do módulo de políticas
+
+DROP INDEX IF EXISTS idx_audit_assessments_policy_id;
+ALTER TABLE audit_assessments DROP COLUMN IF EXISTS policy_id;
+
+DROP TABLE IF EXISTS policy_acknowledgments;
+DROP TABLE IF EXISTS policy_control_mappings;
+DROP TABLE IF EXISTS policy_versions;
+DROP TABLE IF EXISTS policies;
@@ -0,0 +1,62 @@
+-- Módulo de políticas: documentos versionados, aprovação, mapeamento para controles e aceite por usuário
+
+CREATE TABLE IF NOT EXISTS policies (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    owner_id UUID NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft', -- draft, approved, retired
+    review_cadence_months INTEGER NOT NULL DEFAULT 12,
+    next_review_date TIMESTAMP WITH TIME ZONE,
+    current_version_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_policies_organization_id ON policies (organization_id);
+CREATE INDEX IF NOT EXISTS idx_policies_owner_id ON policies (owner_id);
+CREATE INDEX IF NOT EXISTS idx_policies_status ON policies (status);
+
+CREATE TABLE IF NOT EXISTS policy_versions (
+    id UUID PRIMARY KEY,
+    policy_id UUID NOT NULL REFERENCES policies(id) ON DELETE CASCADE,
+    version_number INTEGER NOT NULL,
+    file_url VARCHAR(1024) NOT NULL, -- objectName no provedor de armazenamento
+    file_name VARCHAR(255),
+    changelog TEXT,
+    uploaded_by_id UUID,
+    approval_status VARCHAR(20), -- pendente, aprovado, rejeitado (vazio até ser submetida)
+    requester_id UUID,
+    approver_id UUID,
+    approval_comments TEXT,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policy_version_number ON policy_versions (policy_id, version_number);
+
+CREATE TABLE IF NOT EXISTS policy_control_mappings (
+    id UUID PRIMARY KEY,
+    policy_id UUID NOT NULL REFERENCES policies(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policy_control ON policy_control_mappings (policy_id, audit_control_id);
+CREATE INDEX IF NOT EXISTS idx_policy_control_mappings_audit_control_id ON policy_control_mappings (audit_control_id);
+
+CREATE TABLE IF NOT EXISTS policy_acknowledgments (
+    id UUID PRIMARY KEY,
+    policy_id UUID NOT NULL REFERENCES policies(id) ON DELETE CASCADE,
+    policy_version_id UUID NOT NULL REFERENCES policy_versions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    acknowledged_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_policy_acknowledgments_policy_id ON policy_acknowledgments (policy_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policy_ack_version_user ON policy_acknowledgments (policy_version_id, user_id);
+
+ALTER TABLE audit_assessments ADD COLUMN IF NOT EXISTS policy_id UUID REFERENCES policies(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_assessments_policy_id ON audit_assessments (policy_id);
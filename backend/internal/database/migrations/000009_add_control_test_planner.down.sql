@@ -0,0 +1,4 @@
+-- Reversão do planejador de testes de controle
+
+DROP TABLE IF EXISTS control_test_capacities;
+DROP TABLE IF EXISTS control_test_plans;
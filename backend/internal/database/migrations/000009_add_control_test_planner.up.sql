@@ -0,0 +1,29 @@
+-- Planejador de testes de controle: testes recorrentes e capacidade mensal por responsável
+
+CREATE TABLE IF NOT EXISTS control_test_plans (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id),
+    frequency VARCHAR(20) NOT NULL, -- monthly, quarterly, semiannual, annual
+    estimated_hours DOUBLE PRECISION NOT NULL,
+    notes TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_test_plans_organization_id ON control_test_plans (organization_id);
+CREATE INDEX IF NOT EXISTS idx_control_test_plans_audit_control_id ON control_test_plans (audit_control_id);
+CREATE INDEX IF NOT EXISTS idx_control_test_plans_owner_id ON control_test_plans (owner_id);
+
+CREATE TABLE IF NOT EXISTS control_test_capacities (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    monthly_hours DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_control_test_capacity_org_user ON control_test_capacities (organization_id, user_id);
@@ -0,0 +1,4 @@
+-- Reversão das campanhas de aceite de políticas
+
+DROP TABLE IF EXISTS policy_ack_campaign_targets;
+DROP TABLE IF EXISTS policy_ack_campaigns;
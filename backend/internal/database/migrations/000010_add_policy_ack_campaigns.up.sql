@@ -0,0 +1,31 @@
+-- Campanhas de aceite de políticas com prazo e lembretes
+
+CREATE TABLE IF NOT EXISTS policy_ack_campaigns (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    policy_id UUID NOT NULL REFERENCES policies(id) ON DELETE CASCADE,
+    policy_version_id UUID NOT NULL REFERENCES policy_versions(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    message TEXT,
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open', -- open, closed
+    all_users BOOLEAN NOT NULL DEFAULT FALSE,
+    reminder_interval_days INTEGER NOT NULL DEFAULT 3,
+    last_reminder_at TIMESTAMP WITH TIME ZONE,
+    created_by_id UUID,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_policy_ack_campaigns_organization_id ON policy_ack_campaigns (organization_id);
+CREATE INDEX IF NOT EXISTS idx_policy_ack_campaigns_policy_id ON policy_ack_campaigns (policy_id);
+CREATE INDEX IF NOT EXISTS idx_policy_ack_campaigns_status ON policy_ack_campaigns (status);
+
+CREATE TABLE IF NOT EXISTS policy_ack_campaign_targets (
+    id UUID PRIMARY KEY,
+    campaign_id UUID NOT NULL REFERENCES policy_ack_campaigns(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policy_ack_target ON policy_ack_campaign_targets (campaign_id, user_id);
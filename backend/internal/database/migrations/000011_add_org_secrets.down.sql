@@ -0,0 +1,3 @@
+-- Reversão do cofre de credenciais de integração
+
+DROP TABLE IF EXISTS org_secrets;
@@ -0,0 +1,24 @@
+-- Cofre de credenciais de integração por organização (valores criptografados com AES-GCM)
+
+CREATE TABLE IF NOT EXISTS org_secrets (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(50) NOT NULL, -- jira_token, slack_webhook, teams_webhook, aws_role, api_key, generic
+    description VARCHAR(255),
+    encrypted_value TEXT NOT NULL,
+    value_hint VARCHAR(20),
+    rotation_interval_days INTEGER NOT NULL DEFAULT 0,
+    last_rotated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    rotation_due_at TIMESTAMP WITH TIME ZONE,
+    rotation_reminder_sent_at TIMESTAMP WITH TIME ZONE,
+    usage_count BIGINT NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_by VARCHAR(100),
+    created_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_org_secret_name ON org_secrets (organization_id, name);
+CREATE INDEX IF NOT EXISTS idx_org_secrets_rotation_due_at ON org_secrets (rotation_due_at);
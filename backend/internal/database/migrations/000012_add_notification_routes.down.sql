@@ -0,0 +1,3 @@
+-- Reversão das regras de roteamento de notificações
+
+DROP TABLE IF EXISTS notification_routes;
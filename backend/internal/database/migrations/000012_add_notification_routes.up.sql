@@ -0,0 +1,17 @@
+-- Regras de roteamento de notificações por organização (evento -> canal -> destino)
+
+CREATE TABLE IF NOT EXISTS notification_routes (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    event_type VARCHAR(100) NOT NULL, -- ex: risk_created, risk_status_changed, * (todos)
+    channel VARCHAR(30) NOT NULL, -- email, slack, teams, webhook, sms
+    target VARCHAR(2048),
+    target_secret_name VARCHAR(100), -- nome do segredo em org_secrets
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_routes_organization_id ON notification_routes (organization_id);
+CREATE INDEX IF NOT EXISTS idx_notification_routes_event_type ON notification_routes (event_type);
@@ -0,0 +1,8 @@
+-- Reversão dos fornecedores e questionários de segurança
+
+DROP TABLE IF EXISTS questionnaire_answers;
+DROP TABLE IF EXISTS questionnaire_invitations;
+DROP TABLE IF EXISTS questionnaire_questions;
+DROP TABLE IF EXISTS questionnaire_sections;
+DROP TABLE IF EXISTS questionnaires;
+DROP TABLE IF EXISTS vendors;
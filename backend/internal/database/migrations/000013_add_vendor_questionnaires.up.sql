@@ -0,0 +1,94 @@
+-- Fornecedores e questionários de segurança com portal externo de respostas
+
+CREATE TABLE IF NOT EXISTS vendors (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    website VARCHAR(255),
+    contact_name VARCHAR(255),
+    contact_email VARCHAR(255),
+    criticality VARCHAR(20) NOT NULL DEFAULT 'medium', -- low, medium, high, critical
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    notes TEXT,
+    last_questionnaire_score NUMERIC,
+    last_assessed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendors_organization_id ON vendors (organization_id);
+
+CREATE TABLE IF NOT EXISTS questionnaires (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft', -- draft, published, archived
+    created_by_id UUID,
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_questionnaires_organization_id ON questionnaires (organization_id);
+CREATE INDEX IF NOT EXISTS idx_questionnaires_status ON questionnaires (status);
+
+CREATE TABLE IF NOT EXISTS questionnaire_sections (
+    id UUID PRIMARY KEY,
+    questionnaire_id UUID NOT NULL REFERENCES questionnaires(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    position INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_questionnaire_sections_questionnaire_id ON questionnaire_sections (questionnaire_id);
+
+CREATE TABLE IF NOT EXISTS questionnaire_questions (
+    id UUID PRIMARY KEY,
+    section_id UUID NOT NULL REFERENCES questionnaire_sections(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    type VARCHAR(30) NOT NULL, -- yes_no, single_choice, multiple_choice, text
+    weight NUMERIC NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL,
+    options_json JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_questionnaire_questions_section_id ON questionnaire_questions (section_id);
+
+CREATE TABLE IF NOT EXISTS questionnaire_invitations (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    questionnaire_id UUID NOT NULL REFERENCES questionnaires(id) ON DELETE CASCADE,
+    vendor_id UUID NOT NULL REFERENCES vendors(id) ON DELETE CASCADE,
+    recipient_name VARCHAR(255),
+    recipient_email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL, -- SHA-256 do token do link
+    status VARCHAR(20) NOT NULL DEFAULT 'sent', -- sent, in_progress, submitted, revoked
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    score NUMERIC NOT NULL DEFAULT 0,
+    max_score NUMERIC NOT NULL DEFAULT 0,
+    score_percent NUMERIC,
+    created_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_questionnaire_invitations_token_hash ON questionnaire_invitations (token_hash);
+CREATE INDEX IF NOT EXISTS idx_questionnaire_invitations_organization_id ON questionnaire_invitations (organization_id);
+CREATE INDEX IF NOT EXISTS idx_questionnaire_invitations_questionnaire_id ON questionnaire_invitations (questionnaire_id);
+CREATE INDEX IF NOT EXISTS idx_questionnaire_invitations_vendor_id ON questionnaire_invitations (vendor_id);
+CREATE INDEX IF NOT EXISTS idx_questionnaire_invitations_status ON questionnaire_invitations (status);
+
+CREATE TABLE IF NOT EXISTS questionnaire_answers (
+    id UUID PRIMARY KEY,
+    invitation_id UUID NOT NULL REFERENCES questionnaire_invitations(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL REFERENCES questionnaire_questions(id) ON DELETE CASCADE,
+    value TEXT,
+    comment TEXT,
+    score NUMERIC NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_questionnaire_answer ON questionnaire_answers (invitation_id, question_id);
@@ -0,0 +1,5 @@
+-- Reversão do inventário de ativos
+
+DROP TABLE IF EXISTS asset_controls;
+DROP TABLE IF EXISTS asset_risks;
+DROP TABLE IF EXISTS assets;
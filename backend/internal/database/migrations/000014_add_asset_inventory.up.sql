@@ -0,0 +1,40 @@
+-- Inventário de ativos com vínculos a riscos e controles de auditoria
+
+CREATE TABLE IF NOT EXISTS assets (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(30) NOT NULL, -- hardware, software, information, service, cloud, facility, people, other
+    description TEXT,
+    owner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    criticality VARCHAR(20) NOT NULL DEFAULT 'medium', -- low, medium, high, critical
+    data_classification VARCHAR(20) NOT NULL DEFAULT 'internal', -- public, internal, confidential, restricted
+    location VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_asset_org_name ON assets (organization_id, name);
+CREATE INDEX IF NOT EXISTS idx_assets_type ON assets (type);
+CREATE INDEX IF NOT EXISTS idx_assets_owner_id ON assets (owner_id);
+CREATE INDEX IF NOT EXISTS idx_assets_criticality ON assets (criticality);
+
+CREATE TABLE IF NOT EXISTS asset_risks (
+    id UUID PRIMARY KEY,
+    asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    risk_id UUID NOT NULL REFERENCES risks(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_asset_risk ON asset_risks (asset_id, risk_id);
+CREATE INDEX IF NOT EXISTS idx_asset_risks_risk_id ON asset_risks (risk_id);
+
+CREATE TABLE IF NOT EXISTS asset_controls (
+    id UUID PRIMARY KEY,
+    asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_asset_control ON asset_controls (asset_id, audit_control_id);
+CREATE INDEX IF NOT EXISTS idx_asset_controls_audit_control_id ON asset_controls (audit_control_id);
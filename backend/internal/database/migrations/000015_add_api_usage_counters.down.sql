@@ -0,0 +1,3 @@
+-- Reversão dos contadores de uso da API
+
+DROP TABLE IF EXISTS api_usage_counters;
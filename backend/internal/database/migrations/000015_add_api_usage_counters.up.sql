@@ -0,0 +1,18 @@
+-- Contadores horários de uso da API por organização, credencial e endpoint
+
+CREATE TABLE IF NOT EXISTS api_usage_counters (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    credential VARCHAR(64) NOT NULL, -- "session" ou o ID da chave de API
+    method VARCHAR(10) NOT NULL,
+    route VARCHAR(255) NOT NULL,
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    error_count BIGINT NOT NULL DEFAULT 0,
+    total_latency_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_usage_bucket ON api_usage_counters (organization_id, credential, method, route, window_start);
+CREATE INDEX IF NOT EXISTS idx_api_usage_counters_window_start ON api_usage_counters (window_start);
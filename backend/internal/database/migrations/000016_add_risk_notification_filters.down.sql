@@ -0,0 +1,3 @@
+-- Reversão dos filtros de notificação de risco
+
+DROP TABLE IF EXISTS risk_notification_filters;
@@ -0,0 +1,13 @@
+-- Filtros por organização avaliados antes do envio das notificações de risco
+
+CREATE TABLE IF NOT EXISTS risk_notification_filters (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    criteria_json JSONB, -- {"event_types": [], "risk_levels": [], "impacts": [], "categories": []}
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_notification_filters_organization_id ON risk_notification_filters (organization_id);
@@ -0,0 +1,7 @@
+-- Reversão dos campos de importação de scanners
+
+DROP INDEX IF EXISTS idx_vulnerabilities_risk_id;
+ALTER TABLE vulnerabilities DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE vulnerabilities DROP COLUMN IF EXISTS risk_id;
+ALTER TABLE vulnerabilities DROP COLUMN IF EXISTS source;
+ALTER TABLE vulnerabilities DROP COLUMN IF EXISTS cvss_score;
@@ -0,0 +1,8 @@
+-- Campos preenchidos pela importação de exportações de scanners (Nessus, Qualys, SARIF)
+
+ALTER TABLE vulnerabilities ADD COLUMN IF NOT EXISTS cvss_score NUMERIC(3,1);
+ALTER TABLE vulnerabilities ADD COLUMN IF NOT EXISTS source VARCHAR(50);
+ALTER TABLE vulnerabilities ADD COLUMN IF NOT EXISTS risk_id UUID REFERENCES risks(id) ON DELETE SET NULL;
+ALTER TABLE vulnerabilities ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_vulnerabilities_risk_id ON vulnerabilities (risk_id);
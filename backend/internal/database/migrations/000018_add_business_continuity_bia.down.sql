@@ -0,0 +1,6 @@
+-- Reversão da continuidade de negócios / BIA
+
+DROP TABLE IF EXISTS business_process_vendors;
+DROP TABLE IF EXISTS business_process_assets;
+DROP TABLE IF EXISTS business_processes;
+DROP TABLE IF EXISTS vendor_risks;
@@ -0,0 +1,51 @@
+-- Continuidade de negócios / BIA: processos de negócio com RTO/RPO e dependências de ativos e fornecedores
+
+CREATE TABLE IF NOT EXISTS vendor_risks (
+    id UUID PRIMARY KEY,
+    vendor_id UUID NOT NULL REFERENCES vendors(id) ON DELETE CASCADE,
+    risk_id UUID NOT NULL REFERENCES risks(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vendor_risk ON vendor_risks (vendor_id, risk_id);
+CREATE INDEX IF NOT EXISTS idx_vendor_risks_risk_id ON vendor_risks (risk_id);
+
+CREATE TABLE IF NOT EXISTS business_processes (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    owner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    criticality VARCHAR(20) NOT NULL DEFAULT 'medium', -- low, medium, high, critical
+    rto_hours INTEGER NOT NULL,
+    rpo_hours INTEGER NOT NULL,
+    mtpd_hours INTEGER,
+    impact_notes TEXT,
+    last_reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_business_process_org_name ON business_processes (organization_id, name);
+CREATE INDEX IF NOT EXISTS idx_business_processes_owner_id ON business_processes (owner_id);
+CREATE INDEX IF NOT EXISTS idx_business_processes_criticality ON business_processes (criticality);
+
+CREATE TABLE IF NOT EXISTS business_process_assets (
+    id UUID PRIMARY KEY,
+    business_process_id UUID NOT NULL REFERENCES business_processes(id) ON DELETE CASCADE,
+    asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_business_process_asset ON business_process_assets (business_process_id, asset_id);
+CREATE INDEX IF NOT EXISTS idx_business_process_assets_asset_id ON business_process_assets (asset_id);
+
+CREATE TABLE IF NOT EXISTS business_process_vendors (
+    id UUID PRIMARY KEY,
+    business_process_id UUID NOT NULL REFERENCES business_processes(id) ON DELETE CASCADE,
+    vendor_id UUID NOT NULL REFERENCES vendors(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_business_process_vendor ON business_process_vendors (business_process_id, vendor_id);
+CREATE INDEX IF NOT EXISTS idx_business_process_vendors_vendor_id ON business_process_vendors (vendor_id);
@@ -0,0 +1,5 @@
+-- Reversão do portal de solicitações de evidência
+
+DROP TABLE IF EXISTS evidence_portal_links;
+DROP TABLE IF EXISTS evidence_submissions;
+DROP TABLE IF EXISTS evidence_requests;
@@ -0,0 +1,56 @@
+-- Portal de solicitações de evidência para colaboradores (acesso por token de link)
+
+CREATE TABLE IF NOT EXISTS evidence_requests (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    instructions TEXT,
+    audit_control_id UUID REFERENCES audit_controls(id) ON DELETE SET NULL,
+    assignee_email VARCHAR(255) NOT NULL,
+    assignee_name VARCHAR(255),
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, submitted, accepted, rejected
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    reviewed_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_requests_organization_id ON evidence_requests (organization_id);
+CREATE INDEX IF NOT EXISTS idx_evidence_requests_audit_control_id ON evidence_requests (audit_control_id);
+CREATE INDEX IF NOT EXISTS idx_evidence_requests_assignee_email ON evidence_requests (assignee_email);
+CREATE INDEX IF NOT EXISTS idx_evidence_requests_due_date ON evidence_requests (due_date);
+CREATE INDEX IF NOT EXISTS idx_evidence_requests_status ON evidence_requests (status);
+
+CREATE TABLE IF NOT EXISTS evidence_submissions (
+    id UUID PRIMARY KEY,
+    evidence_request_id UUID NOT NULL REFERENCES evidence_requests(id) ON DELETE CASCADE,
+    object_name VARCHAR(512) NOT NULL,
+    file_name VARCHAR(255),
+    size_bytes BIGINT,
+    scan_status VARCHAR(20),
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_submissions_evidence_request_id ON evidence_submissions (evidence_request_id);
+
+CREATE TABLE IF NOT EXISTS evidence_portal_links (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(255),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_portal_links_organization_id ON evidence_portal_links (organization_id);
+CREATE INDEX IF NOT EXISTS idx_evidence_portal_links_email ON evidence_portal_links (email);
@@ -0,0 +1,3 @@
+-- Reversão do mapeamento entre riscos e controles
+
+DROP TABLE IF EXISTS risk_controls;
@@ -0,0 +1,15 @@
+-- Mapeamento muitos-para-muitos entre riscos e controles de auditoria (controles mitigadores)
+
+CREATE TABLE IF NOT EXISTS risk_controls (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    risk_id UUID NOT NULL REFERENCES risks(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    notes TEXT,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_risk_control ON risk_controls (risk_id, audit_control_id);
+CREATE INDEX IF NOT EXISTS idx_risk_controls_organization_id ON risk_controls (organization_id);
+CREATE INDEX IF NOT EXISTS idx_risk_controls_audit_control_id ON risk_controls (audit_control_id);
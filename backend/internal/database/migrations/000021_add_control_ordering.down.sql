@@ -0,0 +1,8 @@
+-- Reversão da ordenação dos controles
+
+DROP TABLE IF EXISTS org_control_orders;
+
+DROP INDEX IF EXISTS idx_audit_controls_sort_key;
+DROP INDEX IF EXISTS idx_audit_controls_position;
+ALTER TABLE audit_controls DROP COLUMN IF EXISTS sort_key;
+ALTER TABLE audit_controls DROP COLUMN IF EXISTS position;
@@ -0,0 +1,18 @@
+-- Ordenação dos controles: sequência oficial, chave natural do control_id e ordem personalizada por organização
+
+ALTER TABLE audit_controls ADD COLUMN IF NOT EXISTS position INTEGER;
+ALTER TABLE audit_controls ADD COLUMN IF NOT EXISTS sort_key VARCHAR(255);
+CREATE INDEX IF NOT EXISTS idx_audit_controls_position ON audit_controls (position);
+CREATE INDEX IF NOT EXISTS idx_audit_controls_sort_key ON audit_controls (sort_key);
+
+CREATE TABLE IF NOT EXISTS org_control_orders (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    framework_id UUID NOT NULL REFERENCES audit_frameworks(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_org_control_order ON org_control_orders (organization_id, audit_control_id);
+CREATE INDEX IF NOT EXISTS idx_org_control_orders_framework_id ON org_control_orders (framework_id);
@@ -0,0 +1,3 @@
+-- Reversão do mapeamento de controles entre frameworks
+
+DROP TABLE IF EXISTS control_mappings;
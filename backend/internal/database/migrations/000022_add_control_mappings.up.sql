@@ -0,0 +1,16 @@
+-- Mapeamento de controles equivalentes entre frameworks (organization_id nulo = mapeamento padrão)
+
+CREATE TABLE IF NOT EXISTS control_mappings (
+    id UUID PRIMARY KEY,
+    organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE,
+    source_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    target_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    relationship VARCHAR(20) NOT NULL,
+    notes TEXT,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_mappings_organization_id ON control_mappings (organization_id);
+CREATE INDEX IF NOT EXISTS idx_control_mappings_source_control_id ON control_mappings (source_control_id);
+CREATE INDEX IF NOT EXISTS idx_control_mappings_target_control_id ON control_mappings (target_control_id);
@@ -0,0 +1,3 @@
+-- Reversão das rubricas de score de avaliação
+
+DROP TABLE IF EXISTS assessment_score_rubrics;
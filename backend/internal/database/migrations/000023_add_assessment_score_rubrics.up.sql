@@ -0,0 +1,18 @@
+-- Scores padrão por status de avaliação, por organização (framework_id nulo) ou por framework
+
+CREATE TABLE IF NOT EXISTS assessment_score_rubrics (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    framework_id UUID REFERENCES audit_frameworks(id) ON DELETE CASCADE,
+    conformant_score INTEGER NOT NULL CHECK (conformant_score BETWEEN 0 AND 100),
+    partially_conformant_score INTEGER NOT NULL CHECK (partially_conformant_score BETWEEN 0 AND 100),
+    non_conformant_score INTEGER NOT NULL CHECK (non_conformant_score BETWEEN 0 AND 100),
+    not_applicable_score INTEGER NOT NULL CHECK (not_applicable_score BETWEEN 0 AND 100),
+    updated_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Uma rubrica padrão por organização e uma por framework
+CREATE UNIQUE INDEX IF NOT EXISTS idx_score_rubric_org_default ON assessment_score_rubrics (organization_id) WHERE framework_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_score_rubric_org_framework ON assessment_score_rubrics (organization_id, framework_id) WHERE framework_id IS NOT NULL;
@@ -0,0 +1,8 @@
+-- Reversão dos frameworks personalizados (remove os frameworks das organizações e seus controles)
+
+DELETE FROM audit_frameworks WHERE organization_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_audit_frameworks_org_name;
+DROP INDEX IF EXISTS idx_audit_frameworks_seeded_name;
+DROP INDEX IF EXISTS idx_audit_frameworks_organization_id;
+ALTER TABLE audit_frameworks DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE audit_frameworks ADD CONSTRAINT audit_frameworks_name_key UNIQUE (name);
@@ -0,0 +1,10 @@
+-- Frameworks personalizados por organização (organization_id nulo = framework semeado, somente leitura)
+
+ALTER TABLE audit_frameworks ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_audit_frameworks_organization_id ON audit_frameworks (organization_id);
+
+-- O nome passa a ser único entre os semeados e dentro de cada organização
+ALTER TABLE audit_frameworks DROP CONSTRAINT IF EXISTS audit_frameworks_name_key;
+DROP INDEX IF EXISTS idx_audit_frameworks_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_frameworks_seeded_name ON audit_frameworks (name) WHERE organization_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_frameworks_org_name ON audit_frameworks (organization_id, name) WHERE organization_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Reversão do histórico de alterações de configuração
+
+DROP TABLE IF EXISTS config_changes;
@@ -0,0 +1,18 @@
+-- Histórico versionado das entidades de configuração da organização (IdPs, rotas de notificação,
+-- filtros de notificação de riscos, webhooks, rubricas de score), com o registro completo antes/depois
+
+CREATE TABLE IF NOT EXISTS config_changes (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    before JSONB,
+    after JSONB,
+    changed_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    rolled_back_from_id UUID REFERENCES config_changes(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_config_change_entity ON config_changes (organization_id, entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_config_changes_created_at ON config_changes (created_at);
@@ -0,0 +1,3 @@
+-- Reversão do registro de operações em lote
+
+DROP TABLE IF EXISTS bulk_operation_logs;
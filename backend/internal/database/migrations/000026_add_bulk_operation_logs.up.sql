@@ -0,0 +1,14 @@
+-- Registro das operações em lote executadas (importações, campanhas), base da cota diária de notificações
+
+CREATE TABLE IF NOT EXISTS bulk_operation_logs (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    operation VARCHAR(50) NOT NULL,
+    affected_records INTEGER NOT NULL DEFAULT 0,
+    notifications INTEGER NOT NULL DEFAULT 0,
+    confirmed BOOLEAN NOT NULL DEFAULT FALSE,
+    performed_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_bulk_operation_org_created ON bulk_operation_logs (organization_id, created_at);
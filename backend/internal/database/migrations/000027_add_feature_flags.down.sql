@@ -0,0 +1,4 @@
+-- Reversão das feature flags e de suas regras de segmentação
+
+DROP TABLE IF EXISTS feature_flag_rules;
+DROP TABLE IF EXISTS feature_flags;
@@ -0,0 +1,26 @@
+-- Feature flags da plataforma com regras de segmentação por organização/papel e rollout percentual
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+    id UUID PRIMARY KEY,
+    key VARCHAR(100) NOT NULL,
+    description TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    rollout_percentage INTEGER NOT NULL DEFAULT 0 CHECK (rollout_percentage BETWEEN 0 AND 100),
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feature_flags_key ON feature_flags (key);
+
+CREATE TABLE IF NOT EXISTS feature_flag_rules (
+    id UUID PRIMARY KEY,
+    feature_flag_id UUID NOT NULL REFERENCES feature_flags(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE,
+    role VARCHAR(50),
+    enabled BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_feature_flag_rules_feature_flag_id ON feature_flag_rules (feature_flag_id);
+CREATE INDEX IF NOT EXISTS idx_feature_flag_rules_organization_id ON feature_flag_rules (organization_id);
@@ -0,0 +1,3 @@
+-- Reversão do agregado incremental do score de conformidade
+
+DROP TABLE IF EXISTS compliance_score_tallies;
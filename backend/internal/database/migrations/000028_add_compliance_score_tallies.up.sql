@@ -0,0 +1,14 @@
+-- Agregado incremental do score de conformidade por organização e framework
+
+CREATE TABLE IF NOT EXISTS compliance_score_tallies (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    framework_id UUID NOT NULL REFERENCES audit_frameworks(id) ON DELETE CASCADE,
+    score_sum BIGINT NOT NULL DEFAULT 0,
+    evaluated_controls INTEGER NOT NULL DEFAULT 0,
+    conformant_controls INTEGER NOT NULL DEFAULT 0,
+    partially_conformant_controls INTEGER NOT NULL DEFAULT 0,
+    non_conformant_controls INTEGER NOT NULL DEFAULT 0,
+    rebuilt_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (organization_id, framework_id)
+);
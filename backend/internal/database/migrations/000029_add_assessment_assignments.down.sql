@@ -0,0 +1,14 @@
+-- Reversão da atribuição de controles a avaliadores
+
+DROP INDEX IF EXISTS idx_audit_assessments_due_date;
+DROP INDEX IF EXISTS idx_audit_assessments_assigned_to_id;
+
+ALTER TABLE audit_assessments
+    DROP COLUMN IF EXISTS last_due_reminder_at,
+    DROP COLUMN IF EXISTS assignment_completed_at,
+    DROP COLUMN IF EXISTS due_date,
+    DROP COLUMN IF EXISTS assigned_at,
+    DROP COLUMN IF EXISTS assigned_by_id,
+    DROP COLUMN IF EXISTS assigned_to_id;
+
+DELETE FROM compliance_score_tallies;
@@ -0,0 +1,15 @@
+-- Atribuição de controles a avaliadores, com prazo e lembretes
+
+ALTER TABLE audit_assessments
+    ADD COLUMN IF NOT EXISTS assigned_to_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS assigned_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS due_date DATE,
+    ADD COLUMN IF NOT EXISTS assignment_completed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS last_due_reminder_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_audit_assessments_assigned_to_id ON audit_assessments(assigned_to_id);
+CREATE INDEX IF NOT EXISTS idx_audit_assessments_due_date ON audit_assessments(due_date);
+
+-- Avaliações sem status deixam de contar como avaliadas: os agregados são recalculados na próxima leitura
+DELETE FROM compliance_score_tallies;
@@ -0,0 +1,5 @@
+-- Reversão dos comentários e da narrativa de implementação das avaliações
+
+ALTER TABLE audit_assessments
+    DROP COLUMN IF EXISTS implementation_narrative,
+    DROP COLUMN IF EXISTS comments;
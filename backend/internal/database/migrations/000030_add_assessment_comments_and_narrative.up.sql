@@ -0,0 +1,5 @@
+-- Comentários e narrativa de implementação das avaliações (usados no dossiê de evidências do controle)
+
+ALTER TABLE audit_assessments
+    ADD COLUMN IF NOT EXISTS comments TEXT,
+    ADD COLUMN IF NOT EXISTS implementation_narrative TEXT;
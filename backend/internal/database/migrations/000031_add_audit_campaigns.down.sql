@@ -0,0 +1,15 @@
+-- Reversão das auditorias (campanhas de avaliação)
+
+-- As avaliações de auditorias são descartadas para restaurar a unicidade (organização, controle)
+DELETE FROM audit_assessments WHERE audit_campaign_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_audit_assessments_campaign_control;
+DROP INDEX IF EXISTS idx_audit_assessments_org_control;
+ALTER TABLE audit_assessments
+    ADD CONSTRAINT audit_assessments_organization_id_audit_control_id_key UNIQUE (organization_id, audit_control_id);
+DROP INDEX IF EXISTS idx_audit_assessments_audit_campaign_id;
+ALTER TABLE audit_assessments DROP COLUMN IF EXISTS audit_campaign_id;
+
+DROP TABLE IF EXISTS audit_campaign_participants;
+DROP TABLE IF EXISTS audit_campaign_scope_assets;
+DROP TABLE IF EXISTS audit_campaign_scope_families;
+DROP TABLE IF EXISTS audit_campaigns;
@@ -0,0 +1,53 @@
+-- Auditorias (campanhas de avaliação) com escopo, janela e participantes.
+-- As avaliações de uma auditoria ficam separadas da avaliação contínua da organização.
+
+CREATE TABLE IF NOT EXISTS audit_campaigns (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    framework_id UUID NOT NULL REFERENCES audit_frameworks(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'planned',
+    starts_at DATE NOT NULL,
+    ends_at DATE NOT NULL,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_audit_campaigns_organization_id ON audit_campaigns(organization_id);
+CREATE INDEX IF NOT EXISTS idx_audit_campaigns_framework_id ON audit_campaigns(framework_id);
+CREATE INDEX IF NOT EXISTS idx_audit_campaigns_status ON audit_campaigns(status);
+
+CREATE TABLE IF NOT EXISTS audit_campaign_scope_families (
+    campaign_id UUID NOT NULL REFERENCES audit_campaigns(id) ON DELETE CASCADE,
+    family VARCHAR(255) NOT NULL,
+    PRIMARY KEY (campaign_id, family)
+);
+
+CREATE TABLE IF NOT EXISTS audit_campaign_scope_assets (
+    campaign_id UUID NOT NULL REFERENCES audit_campaigns(id) ON DELETE CASCADE,
+    asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    PRIMARY KEY (campaign_id, asset_id)
+);
+CREATE INDEX IF NOT EXISTS idx_audit_campaign_scope_assets_asset_id ON audit_campaign_scope_assets(asset_id);
+
+CREATE TABLE IF NOT EXISTS audit_campaign_participants (
+    campaign_id UUID NOT NULL REFERENCES audit_campaigns(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (campaign_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_audit_campaign_participants_user_id ON audit_campaign_participants(user_id);
+
+-- Uma avaliação contínua por controle e organização, e uma por controle em cada auditoria
+ALTER TABLE audit_assessments
+    ADD COLUMN IF NOT EXISTS audit_campaign_id UUID REFERENCES audit_campaigns(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_audit_assessments_audit_campaign_id ON audit_assessments(audit_campaign_id);
+
+ALTER TABLE audit_assessments DROP CONSTRAINT IF EXISTS audit_assessments_organization_id_audit_control_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_assessments_org_control
+    ON audit_assessments(organization_id, audit_control_id) WHERE audit_campaign_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_assessments_campaign_control
+    ON audit_assessments(organization_id, audit_control_id, audit_campaign_id) WHERE audit_campaign_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Reversão da configuração da tela de login por organização
+
+DROP TABLE IF EXISTS organization_login_pages;
@@ -0,0 +1,17 @@
+-- Configuração da tela de login por organização (marca, métodos de login e avisos legais por slug/domínio)
+
+CREATE TABLE IF NOT EXISTS organization_login_pages (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    slug VARCHAR(63) NOT NULL,
+    custom_domain VARCHAR(255),
+    welcome_message TEXT,
+    disable_password_login BOOLEAN NOT NULL DEFAULT FALSE,
+    terms_of_service_url VARCHAR(500),
+    privacy_policy_url VARCHAR(500),
+    legal_notice TEXT,
+    updated_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_login_pages_slug ON organization_login_pages(slug);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_login_pages_custom_domain ON organization_login_pages(custom_domain);
@@ -0,0 +1,3 @@
+-- Reversão da trilha de auditoria da organização
+
+DROP TABLE IF EXISTS audit_trail_entries;
@@ -0,0 +1,17 @@
+-- Trilha de auditoria da organização (ações administrativas com o relatório da alteração)
+
+CREATE TABLE IF NOT EXISTS audit_trail_entries (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50),
+    entity_id UUID,
+    summary VARCHAR(500),
+    details JSONB,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_trail_org_created ON audit_trail_entries (organization_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_trail_entries_actor_id ON audit_trail_entries (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_trail_entries_action ON audit_trail_entries (action);
@@ -0,0 +1,6 @@
+-- Reversão das exceções (waivers) de controles
+
+DROP INDEX IF EXISTS idx_approval_workflows_control_waiver_id;
+ALTER TABLE approval_workflows DROP COLUMN IF EXISTS control_waiver_id;
+DROP TABLE IF EXISTS control_waiver_compensating_controls;
+DROP TABLE IF EXISTS control_waivers;
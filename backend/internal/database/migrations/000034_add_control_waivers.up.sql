@@ -0,0 +1,37 @@
+-- Exceções (waivers) de controles: dispensa temporária com justificativa, controles compensatórios,
+-- vencimento e aprovação via approval_workflows
+
+CREATE TABLE IF NOT EXISTS control_waivers (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    justification TEXT NOT NULL,
+    compensating_measures TEXT,
+    expires_at DATE NOT NULL, -- Último dia de validade
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, active, rejected, expired, revoked
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requested_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approver_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    previous_assessment_status VARCHAR(50),
+    approved_at TIMESTAMP WITH TIME ZONE,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_waivers_organization_id ON control_waivers (organization_id);
+CREATE INDEX IF NOT EXISTS idx_control_waivers_audit_control_id ON control_waivers (audit_control_id);
+CREATE INDEX IF NOT EXISTS idx_control_waivers_status ON control_waivers (status);
+CREATE INDEX IF NOT EXISTS idx_control_waivers_expires_at ON control_waivers (expires_at);
+
+CREATE TABLE IF NOT EXISTS control_waiver_compensating_controls (
+    waiver_id UUID NOT NULL REFERENCES control_waivers(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    PRIMARY KEY (waiver_id, audit_control_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_waiver_compensating_controls_audit_control_id ON control_waiver_compensating_controls (audit_control_id);
+
+-- O workflow de aprovação passa a servir também às exceções de controles
+ALTER TABLE approval_workflows ADD COLUMN IF NOT EXISTS control_waiver_id UUID REFERENCES control_waivers(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_approval_workflows_control_waiver_id ON approval_workflows (control_waiver_id);
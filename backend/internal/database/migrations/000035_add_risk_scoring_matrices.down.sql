@@ -0,0 +1,4 @@
+-- Reversão da matriz de pontuação de riscos por organização
+
+DROP INDEX IF EXISTS idx_risk_scoring_matrices_organization_id;
+DROP TABLE IF EXISTS risk_scoring_matrices;
@@ -0,0 +1,15 @@
+-- Matriz de pontuação de riscos (apetite a risco) por organização: dimensões, nível de cada célula e
+-- limiar de aceitação obrigatória via workflow de aprovação
+
+CREATE TABLE IF NOT EXISTS risk_scoring_matrices (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    size INTEGER NOT NULL,
+    cells JSONB NOT NULL, -- [][]string: linha = probabilidade, coluna = impacto
+    acceptance_threshold VARCHAR(20),
+    updated_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_risk_scoring_matrices_organization_id ON risk_scoring_matrices (organization_id);
@@ -0,0 +1,5 @@
+-- Reversão da regra das duas pessoas
+
+DROP INDEX IF EXISTS idx_approval_workflows_admin_action_request_id;
+ALTER TABLE approval_workflows DROP COLUMN IF EXISTS admin_action_request_id;
+DROP TABLE IF EXISTS admin_action_requests;
@@ -0,0 +1,27 @@
+-- Regra das duas pessoas: ações administrativas destrutivas só são executadas após a aprovação de um
+-- segundo administrador, via approval_workflows
+
+CREATE TABLE IF NOT EXISTS admin_action_requests (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL, -- framework.delete, org_secret.rotate, org_secret.delete
+    target_id UUID NOT NULL,
+    target_label VARCHAR(255),
+    payload TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, executed, failed, rejected, cancelled
+    requested_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approver_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    executed_at TIMESTAMP WITH TIME ZONE,
+    failure_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_action_requests_organization_id ON admin_action_requests (organization_id);
+CREATE INDEX IF NOT EXISTS idx_admin_action_requests_target_id ON admin_action_requests (target_id);
+CREATE INDEX IF NOT EXISTS idx_admin_action_requests_status ON admin_action_requests (status);
+CREATE INDEX IF NOT EXISTS idx_admin_action_requests_approver_id ON admin_action_requests (approver_id);
+
+ALTER TABLE approval_workflows ADD COLUMN IF NOT EXISTS admin_action_request_id UUID REFERENCES admin_action_requests(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_approval_workflows_admin_action_request_id ON approval_workflows (admin_action_request_id);
@@ -0,0 +1,3 @@
+-- Reversão da taxonomia de categorias de risco
+
+DROP TABLE IF EXISTS risk_category_definitions;
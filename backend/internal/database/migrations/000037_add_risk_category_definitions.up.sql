@@ -0,0 +1,27 @@
+-- Taxonomia de categorias de risco por organização. risks.category guarda a key da categoria.
+
+CREATE TABLE IF NOT EXISTS risk_category_definitions (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_risk_category_org_key ON risk_category_definitions (organization_id, key);
+
+-- Categorias padrão (valores do antigo enum) para as organizações existentes
+INSERT INTO risk_category_definitions (id, organization_id, key, name, is_active, created_at, updated_at)
+SELECT gen_random_uuid(), o.id, d.key, d.name, TRUE, NOW(), NOW()
+FROM organizations o
+CROSS JOIN (VALUES ('tecnologico', 'Tecnológico'), ('operacional', 'Operacional'), ('legal', 'Legal')) AS d(key, name)
+ON CONFLICT (organization_id, key) DO NOTHING;
+
+-- Categorias já usadas em riscos que não estejam entre as padrão
+INSERT INTO risk_category_definitions (id, organization_id, key, name, is_active, created_at, updated_at)
+SELECT gen_random_uuid(), r.organization_id, r.category, r.category, TRUE, NOW(), NOW()
+FROM (SELECT DISTINCT organization_id, category FROM risks WHERE category IS NOT NULL AND category <> '') r
+ON CONFLICT (organization_id, key) DO NOTHING;
@@ -0,0 +1,4 @@
+-- Reversão das respostas aos lembretes de prazo das avaliações
+
+DROP TABLE IF EXISTS assessment_reminder_acks;
+ALTER TABLE audit_assessments DROP COLUMN IF EXISTS reminder_target_date;
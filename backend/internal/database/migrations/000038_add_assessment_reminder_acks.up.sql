@@ -0,0 +1,19 @@
+-- Resposta do avaliador aos lembretes de prazo: nova data prevista com justificativa (histórico) e a data
+-- considerada pelos lembretes na avaliação
+
+ALTER TABLE audit_assessments ADD COLUMN IF NOT EXISTS reminder_target_date DATE;
+
+CREATE TABLE IF NOT EXISTS assessment_reminder_acks (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    audit_assessment_id UUID NOT NULL REFERENCES audit_assessments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    due_date DATE,
+    previous_target_date DATE,
+    target_date DATE NOT NULL,
+    justification TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessment_reminder_acks_organization_id ON assessment_reminder_acks (organization_id);
+CREATE INDEX IF NOT EXISTS idx_assessment_reminder_acks_audit_assessment_id ON assessment_reminder_acks (audit_assessment_id);
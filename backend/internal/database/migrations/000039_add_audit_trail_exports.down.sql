@@ -0,0 +1,3 @@
+-- Reversão das exportações da trilha de auditoria
+
+DROP TABLE IF EXISTS audit_trail_exports;
@@ -0,0 +1,21 @@
+-- Exportações da trilha de auditoria (partes CSV + manifesto de integridade assinado), geradas em segundo plano
+
+CREATE TABLE IF NOT EXISTS audit_trail_exports (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    requested_by_id UUID NOT NULL,
+    from_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    to_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total_entries INTEGER DEFAULT 0,
+    part_count INTEGER DEFAULT 0,
+    manifest JSONB,
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_trail_exports_organization_id ON audit_trail_exports (organization_id);
+CREATE INDEX IF NOT EXISTS idx_audit_trail_exports_status ON audit_trail_exports (status);
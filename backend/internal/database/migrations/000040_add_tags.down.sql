@@ -0,0 +1,4 @@
+-- Reversão das tags
+
+DROP TABLE IF EXISTS entity_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,27 @@
+-- Tags da organização e associação polimórfica com riscos, controles e fornecedores
+
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7),
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tag_org_name ON tags (organization_id, name);
+
+CREATE TABLE IF NOT EXISTS entity_tags (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    created_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_entity_tag_unique ON entity_tags (tag_id, entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_entity_tag_entity ON entity_tags (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_entity_tags_organization_id ON entity_tags (organization_id);
@@ -0,0 +1,7 @@
+-- Reversão dos índices do full-text search
+
+DROP INDEX IF EXISTS idx_risks_search;
+DROP INDEX IF EXISTS idx_audit_controls_search;
+DROP INDEX IF EXISTS idx_audit_assessments_search;
+DROP INDEX IF EXISTS idx_policies_search;
+DROP INDEX IF EXISTS idx_vendors_search;
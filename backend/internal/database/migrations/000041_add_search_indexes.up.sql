@@ -0,0 +1,8 @@
+-- Índices do full-text search (GET /organizations/:orgId/search). As expressões devem ser iguais às de
+-- search.Sources (pacote internal/search) para que os índices sejam usados.
+
+CREATE INDEX IF NOT EXISTS idx_risks_search ON risks USING GIN (to_tsvector('portuguese', coalesce(title, '') || ' ' || coalesce(description, '')));
+CREATE INDEX IF NOT EXISTS idx_audit_controls_search ON audit_controls USING GIN (to_tsvector('portuguese', coalesce(control_id, '') || ' ' || coalesce(family, '') || ' ' || coalesce(description, '')));
+CREATE INDEX IF NOT EXISTS idx_audit_assessments_search ON audit_assessments USING GIN (to_tsvector('portuguese', coalesce(comments, '') || ' ' || coalesce(c2m2_comments, '')));
+CREATE INDEX IF NOT EXISTS idx_policies_search ON policies USING GIN (to_tsvector('portuguese', coalesce(title, '') || ' ' || coalesce(description, '')));
+CREATE INDEX IF NOT EXISTS idx_vendors_search ON vendors USING GIN (to_tsvector('portuguese', coalesce(name, '') || ' ' || coalesce(contact_name, '') || ' ' || coalesce(notes, '')));
@@ -0,0 +1,4 @@
+-- Reversão da política de higiene de credenciais por organização
+
+DROP INDEX IF EXISTS idx_org_secret_policies_organization_id;
+DROP TABLE IF EXISTS org_secret_policies;
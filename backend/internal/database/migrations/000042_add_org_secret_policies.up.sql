@@ -0,0 +1,14 @@
+-- Política de higiene de credenciais por organização: idade máxima das credenciais do cofre desde a última
+-- rotação e o modo de aplicação (warn apenas sinaliza; block impede que as integrações leiam o valor)
+
+CREATE TABLE IF NOT EXISTS org_secret_policies (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    max_age_days INTEGER NOT NULL,
+    enforcement VARCHAR(20) NOT NULL DEFAULT 'warn',
+    updated_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_org_secret_policies_organization_id ON org_secret_policies (organization_id);
@@ -0,0 +1,5 @@
+-- Reversão das visões salvas
+
+DROP INDEX IF EXISTS idx_saved_views_owner_id;
+DROP INDEX IF EXISTS idx_saved_view_org_entity;
+DROP TABLE IF EXISTS saved_views;
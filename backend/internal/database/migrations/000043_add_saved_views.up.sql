@@ -0,0 +1,17 @@
+-- Visões salvas: filtros nomeados das listagens de riscos e avaliações, pessoais ou compartilhadas com a organização
+
+CREATE TABLE IF NOT EXISTS saved_views (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    entity_type VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255),
+    filters JSONB NOT NULL, -- map[string][]string com os parâmetros da listagem
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_view_org_entity ON saved_views (organization_id, entity_type);
+CREATE INDEX IF NOT EXISTS idx_saved_views_owner_id ON saved_views (owner_id);
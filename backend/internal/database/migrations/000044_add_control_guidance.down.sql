@@ -0,0 +1,3 @@
+-- Reversão da orientação ao avaliador por controle
+
+ALTER TABLE audit_controls DROP COLUMN IF EXISTS guidance;
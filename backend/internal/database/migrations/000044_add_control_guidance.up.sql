@@ -0,0 +1,4 @@
+-- Orientação ao avaliador por controle: texto, exemplos de evidências e referências (JSON), semeada para os
+-- frameworks padrão e editável nos frameworks personalizados
+
+ALTER TABLE audit_controls ADD COLUMN IF NOT EXISTS guidance JSONB;
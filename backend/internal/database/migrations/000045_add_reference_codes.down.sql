@@ -0,0 +1,12 @@
+-- Reversão dos códigos de referência sequenciais por organização
+
+DROP INDEX IF EXISTS idx_risks_org_reference_code;
+ALTER TABLE risks DROP COLUMN IF EXISTS reference_code;
+
+DROP INDEX IF EXISTS idx_vulnerabilities_org_reference_code;
+ALTER TABLE vulnerabilities DROP COLUMN IF EXISTS reference_code;
+
+DROP INDEX IF EXISTS idx_policies_org_reference_code;
+ALTER TABLE policies DROP COLUMN IF EXISTS reference_code;
+
+DROP TABLE IF EXISTS org_sequences;
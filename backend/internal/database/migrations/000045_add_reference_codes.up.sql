@@ -0,0 +1,55 @@
+-- Códigos de referência sequenciais por organização (RISK-0042, FND-0007, POL-0003), usados ao lado dos UUIDs.
+-- org_sequences guarda o último número por tipo; os registros existentes são numerados pela data de criação.
+
+CREATE TABLE IF NOT EXISTS org_sequences (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    entity_type VARCHAR(30) NOT NULL,
+    last_value BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (organization_id, entity_type)
+);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS reference_code VARCHAR(20);
+
+UPDATE risks SET reference_code = numbered.code
+FROM (
+    SELECT id, 'RISK-' || lpad(n::text, greatest(4, length(n::text)), '0') AS code
+    FROM (SELECT id, row_number() OVER (PARTITION BY organization_id ORDER BY created_at, id) AS n FROM risks) AS ordered
+) AS numbered
+WHERE risks.id = numbered.id AND risks.reference_code IS NULL;
+
+INSERT INTO org_sequences (organization_id, entity_type, last_value, updated_at)
+SELECT organization_id, 'risk', COUNT(*), NOW() FROM risks GROUP BY organization_id
+ON CONFLICT (organization_id, entity_type) DO UPDATE SET last_value = GREATEST(org_sequences.last_value, EXCLUDED.last_value);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_risks_org_reference_code ON risks (organization_id, reference_code);
+
+ALTER TABLE vulnerabilities ADD COLUMN IF NOT EXISTS reference_code VARCHAR(20);
+
+UPDATE vulnerabilities SET reference_code = numbered.code
+FROM (
+    SELECT id, 'FND-' || lpad(n::text, greatest(4, length(n::text)), '0') AS code
+    FROM (SELECT id, row_number() OVER (PARTITION BY organization_id ORDER BY created_at, id) AS n FROM vulnerabilities) AS ordered
+) AS numbered
+WHERE vulnerabilities.id = numbered.id AND vulnerabilities.reference_code IS NULL;
+
+INSERT INTO org_sequences (organization_id, entity_type, last_value, updated_at)
+SELECT organization_id, 'vulnerability', COUNT(*), NOW() FROM vulnerabilities GROUP BY organization_id
+ON CONFLICT (organization_id, entity_type) DO UPDATE SET last_value = GREATEST(org_sequences.last_value, EXCLUDED.last_value);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vulnerabilities_org_reference_code ON vulnerabilities (organization_id, reference_code);
+
+ALTER TABLE policies ADD COLUMN IF NOT EXISTS reference_code VARCHAR(20);
+
+UPDATE policies SET reference_code = numbered.code
+FROM (
+    SELECT id, 'POL-' || lpad(n::text, greatest(4, length(n::text)), '0') AS code
+    FROM (SELECT id, row_number() OVER (PARTITION BY organization_id ORDER BY created_at, id) AS n FROM policies) AS ordered
+) AS numbered
+WHERE policies.id = numbered.id AND policies.reference_code IS NULL;
+
+INSERT INTO org_sequences (organization_id, entity_type, last_value, updated_at)
+SELECT organization_id, 'policy', COUNT(*), NOW() FROM policies GROUP BY organization_id
+ON CONFLICT (organization_id, entity_type) DO UPDATE SET last_value = GREATEST(org_sequences.last_value, EXCLUDED.last_value);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policies_org_reference_code ON policies (organization_id, reference_code);
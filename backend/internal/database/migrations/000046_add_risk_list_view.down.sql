@@ -0,0 +1,5 @@
+-- Reversão do modelo de leitura da listagem de riscos
+
+DROP INDEX IF EXISTS idx_approval_workflows_risk_created_at;
+DROP INDEX IF EXISTS idx_risks_org_created_at;
+DROP VIEW IF EXISTS risk_list_items;
@@ -0,0 +1,54 @@
+-- Modelo de leitura da listagem de riscos: cada linha traz o risco com o responsável, a situação do aceite
+-- mais recente, o número de stakeholders e o andamento do tratamento (controles mitigadores avaliados).
+-- Os agregados são subconsultas LATERAL por risco, calculadas apenas para as linhas da página quando a
+-- consulta ordena por created_at com LIMIT. Colunas novas em risks precisam ser incluídas aqui (DROP e CREATE).
+
+CREATE OR REPLACE VIEW risk_list_items AS
+SELECT
+    risks.id,
+    risks.organization_id,
+    risks.reference_code,
+    risks.title,
+    risks.description,
+    risks.category,
+    risks.impact,
+    risks.probability,
+    risks.risk_level,
+    risks.status,
+    risks.owner_id,
+    risks.created_at,
+    risks.updated_at,
+    owners.name AS owner_name,
+    owners.email AS owner_email,
+    approval.status AS approval_status,
+    approval.updated_at AS approval_updated_at,
+    stakeholders.stakeholder_count,
+    controls.control_count,
+    controls.treated_control_count,
+    CASE WHEN controls.control_count = 0 THEN NULL
+         ELSE (controls.treated_control_count * 100 / controls.control_count)::int END AS treatment_progress
+FROM risks
+LEFT JOIN users AS owners ON owners.id = risks.owner_id
+LEFT JOIN LATERAL (
+    SELECT approval_workflows.status, approval_workflows.updated_at
+    FROM approval_workflows
+    WHERE approval_workflows.risk_id = risks.id
+    ORDER BY approval_workflows.created_at DESC
+    LIMIT 1
+) AS approval ON TRUE
+LEFT JOIN LATERAL (
+    SELECT COUNT(*)::int AS stakeholder_count FROM risk_stakeholders WHERE risk_stakeholders.risk_id = risks.id
+) AS stakeholders ON TRUE
+LEFT JOIN LATERAL (
+    SELECT COUNT(*)::int AS control_count,
+           (COUNT(*) FILTER (WHERE audit_assessments.status IN ('conforme', 'nao_aplicavel', 'dispensado')))::int AS treated_control_count
+    FROM risk_controls
+    LEFT JOIN audit_assessments ON audit_assessments.audit_control_id = risk_controls.audit_control_id
+        AND audit_assessments.organization_id = risk_controls.organization_id
+        AND audit_assessments.audit_campaign_id IS NULL
+    WHERE risk_controls.risk_id = risks.id
+) AS controls ON TRUE;
+
+-- Índices da página (organização ordenada pela criação) e da solicitação de aceite mais recente.
+CREATE INDEX IF NOT EXISTS idx_risks_org_created_at ON risks (organization_id, created_at DESC, id);
+CREATE INDEX IF NOT EXISTS idx_approval_workflows_risk_created_at ON approval_workflows (risk_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Reversão dos relatórios agendados
+
+DROP TABLE IF EXISTS report_schedules;
@@ -0,0 +1,26 @@
+-- Relatórios agendados: resumo do registro de riscos, variação de conformidade e ações vencidas, gerados em
+-- PDF/XLSX semanal ou mensalmente e enviados por e-mail a uma lista de distribuição (horários em UTC)
+
+CREATE TABLE IF NOT EXISTS report_schedules (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    report_type VARCHAR(30) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    frequency VARCHAR(10) NOT NULL,
+    day_of_week INTEGER NOT NULL DEFAULT 1,
+    day_of_month INTEGER NOT NULL DEFAULT 1,
+    hour INTEGER NOT NULL DEFAULT 8,
+    recipients JSONB NOT NULL, -- []string de e-mails
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_status VARCHAR(20),
+    last_error TEXT,
+    created_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_schedules_organization_id ON report_schedules (organization_id);
+CREATE INDEX IF NOT EXISTS idx_report_schedules_next_run_at ON report_schedules (next_run_at);
@@ -0,0 +1,3 @@
+-- Reversão do modelo do relatório executivo
+
+DROP TABLE IF EXISTS executive_report_templates;
@@ -0,0 +1,14 @@
+-- Modelo do relatório executivo (PDF para o conselho) por organização: título, subtítulo, rodapé, logo e cor.
+-- Campos vazios usam o padrão e a identidade visual da organização
+
+CREATE TABLE IF NOT EXISTS executive_report_templates (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    title VARCHAR(150),
+    subtitle VARCHAR(255),
+    footer_text VARCHAR(255),
+    logo_url VARCHAR(255),
+    primary_color VARCHAR(7),
+    updated_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,3 @@
+-- Reversão da política de acesso às evidências
+
+DROP TABLE IF EXISTS evidence_access_policies;
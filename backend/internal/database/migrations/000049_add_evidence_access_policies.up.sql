@@ -0,0 +1,17 @@
+-- Política de acesso às evidências por organização: redes (CIDR) e horário comercial permitidos para downloads,
+-- com break-glass opcional (justificativa registrada na trilha de auditoria)
+
+CREATE TABLE IF NOT EXISTS evidence_access_policies (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL UNIQUE REFERENCES organizations(id) ON DELETE CASCADE,
+    allowed_networks JSONB NOT NULL DEFAULT '[]',
+    business_hours_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    weekdays JSONB NOT NULL DEFAULT '[]',
+    start_time VARCHAR(5),
+    end_time VARCHAR(5),
+    allow_break_glass BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
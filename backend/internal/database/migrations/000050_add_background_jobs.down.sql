@@ -0,0 +1,3 @@
+-- Reversão da fila de jobs em segundo plano
+
+DROP TABLE IF EXISTS background_jobs;
@@ -0,0 +1,23 @@
+-- Fila durável de jobs em segundo plano (notificações e demais tarefas assíncronas), reservados pelos
+-- workers com FOR UPDATE SKIP LOCKED
+
+CREATE TABLE IF NOT EXISTS background_jobs (
+    id UUID PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    organization_id UUID,
+    status VARCHAR(20) NOT NULL,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    last_error TEXT,
+    locked_by VARCHAR(255),
+    locked_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_background_jobs_status_run_at ON background_jobs (status, run_at);
+CREATE INDEX IF NOT EXISTS idx_background_jobs_type ON background_jobs (type);
+CREATE INDEX IF NOT EXISTS idx_background_jobs_organization_id ON background_jobs (organization_id);
@@ -0,0 +1,5 @@
+-- Reversão dos coordenadores de framework, rascunhos de evidência e comentários de avaliação
+
+DROP TABLE IF EXISTS assessment_comments;
+DROP TABLE IF EXISTS assessment_evidence_drafts;
+DROP TABLE IF EXISTS framework_coordinators;
@@ -0,0 +1,45 @@
+-- Coordenadores de framework (quem registra as avaliações contínuas), rascunhos de evidência e comentários
+-- das avaliações enviados pelos demais usuários
+
+CREATE TABLE IF NOT EXISTS framework_coordinators (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    framework_id UUID NOT NULL REFERENCES audit_frameworks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_framework_coordinator ON framework_coordinators (organization_id, framework_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_framework_coordinators_user_id ON framework_coordinators (user_id);
+
+CREATE TABLE IF NOT EXISTS assessment_evidence_drafts (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    uploaded_by_id UUID NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    object_name VARCHAR(1024) NOT NULL,
+    size_bytes BIGINT,
+    scan_status VARCHAR(20),
+    note TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    accepted_by_id UUID,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_draft_org_control ON assessment_evidence_drafts (organization_id, audit_control_id);
+
+CREATE TABLE IF NOT EXISTS assessment_comments (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    audit_control_id UUID NOT NULL REFERENCES audit_controls(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessment_comment_org_control ON assessment_comments (organization_id, audit_control_id);
@@ -0,0 +1,77 @@
+-- Status de riscos e avaliações definidos pelo administrador: cada status refina um status nativo, que
+-- continua gravado em status; o código definido fica em status_code (nulo para os nativos).
+
+CREATE TABLE IF NOT EXISTS status_definitions (
+    id UUID PRIMARY KEY,
+    entity VARCHAR(20) NOT NULL,
+    code VARCHAR(30) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    description TEXT,
+    base_status VARCHAR(30) NOT NULL,
+    deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+    replaced_by VARCHAR(30),
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_status_definition_entity_code ON status_definitions (entity, code);
+
+CREATE TABLE IF NOT EXISTS status_transitions (
+    id UUID PRIMARY KEY,
+    entity VARCHAR(20) NOT NULL,
+    from_code VARCHAR(30) NOT NULL,
+    to_code VARCHAR(30) NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_status_transition ON status_transitions (entity, from_code, to_code);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS status_code VARCHAR(30);
+ALTER TABLE audit_assessments ADD COLUMN IF NOT EXISTS status_code VARCHAR(30);
+
+-- A view da listagem passa a expor status_code (colunas novas entram no fim).
+CREATE OR REPLACE VIEW risk_list_items AS
+SELECT
+    risks.id,
+    risks.organization_id,
+    risks.reference_code,
+    risks.title,
+    risks.description,
+    risks.category,
+    risks.impact,
+    risks.probability,
+    risks.risk_level,
+    risks.status,
+    risks.owner_id,
+    risks.created_at,
+    risks.updated_at,
+    owners.name AS owner_name,
+    owners.email AS owner_email,
+    approval.status AS approval_status,
+    approval.updated_at AS approval_updated_at,
+    stakeholders.stakeholder_count,
+    controls.control_count,
+    controls.treated_control_count,
+    CASE WHEN controls.control_count = 0 THEN NULL
+         ELSE (controls.treated_control_count * 100 / controls.control_count)::int END AS treatment_progress,
+    risks.status_code
+FROM risks
+LEFT JOIN users AS owners ON owners.id = risks.owner_id
+LEFT JOIN LATERAL (
+    SELECT approval_workflows.status, approval_workflows.updated_at
+    FROM approval_workflows
+    WHERE approval_workflows.risk_id = risks.id
+    ORDER BY approval_workflows.created_at DESC
+    LIMIT 1
+) AS approval ON TRUE
+LEFT JOIN LATERAL (
+    SELECT COUNT(*)::int AS stakeholder_count FROM risk_stakeholders WHERE risk_stakeholders.risk_id = risks.id
+) AS stakeholders ON TRUE
+LEFT JOIN LATERAL (
+    SELECT COUNT(*)::int AS control_count,
+           (COUNT(*) FILTER (WHERE audit_assessments.status IN ('conforme', 'nao_aplicavel', 'dispensado')))::int AS treated_control_count
+    FROM risk_controls
+    LEFT JOIN audit_assessments ON audit_assessments.audit_control_id = risk_controls.audit_control_id
+        AND audit_assessments.organization_id = risk_controls.organization_id
+        AND audit_assessments.audit_campaign_id IS NULL
+    WHERE risk_controls.risk_id = risks.id
+) AS controls ON TRUE;
@@ -0,0 +1,3 @@
+-- Reversão da identidade visual dos e-mails
+
+DROP TABLE IF EXISTS email_brandings;
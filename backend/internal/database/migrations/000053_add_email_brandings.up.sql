@@ -0,0 +1,15 @@
+-- Identidade visual dos e-mails de notificação por organização: remetente, logo, cores, rodapé e idioma.
+-- Campos vazios usam o branding da organização e o padrão da aplicação
+
+CREATE TABLE IF NOT EXISTS email_brandings (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    from_name VARCHAR(100),
+    logo_url VARCHAR(500),
+    primary_color VARCHAR(7),
+    secondary_color VARCHAR(7),
+    footer_text VARCHAR(500),
+    locale VARCHAR(10),
+    updated_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,3 @@
+-- Reversão da central de notificações in-app
+
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,18 @@
+-- Central de notificações in-app: uma linha por notificação direcionada a um usuário, registrada junto com o
+-- e-mail. read_at fica nulo até o usuário marcar como lida
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id UUID,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT,
+    link VARCHAR(500),
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_organization_id ON notifications (organization_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_read_at ON notifications (read_at);
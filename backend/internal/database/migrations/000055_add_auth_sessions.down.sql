@@ -0,0 +1,3 @@
+-- Reversão das sessões de login
+
+DROP TABLE IF EXISTS auth_sessions;
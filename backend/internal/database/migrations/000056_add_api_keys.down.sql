@@ -0,0 +1,3 @@
+-- Reversão das chaves de API
+
+DROP TABLE IF EXISTS api_keys;
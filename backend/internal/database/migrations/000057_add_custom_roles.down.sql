@@ -0,0 +1,4 @@
+-- Reversão dos papéis personalizados
+
+ALTER TABLE users DROP COLUMN IF EXISTS custom_role_id;
+DROP TABLE IF EXISTS custom_roles;
@@ -0,0 +1,18 @@
+-- Papéis personalizados por organização (permissões recurso:ação somadas às do papel base do usuário).
+-- Os papéis existentes (admin, manager, user) continuam em users.role e mantêm as mesmas permissões de antes.
+
+CREATE TABLE IF NOT EXISTS custom_roles (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255),
+    permissions JSONB NOT NULL,
+    created_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_custom_roles_org_name ON custom_roles (organization_id, name);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS custom_role_id UUID REFERENCES custom_roles(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_users_custom_role_id ON users (custom_role_id);
@@ -0,0 +1,81 @@
+-- Equipes da organização: grupos de usuários que podem ser donos de riscos, avaliações de controles e testes de
+-- controle, com as notificações enviadas a todos os membros.
+
+CREATE TABLE IF NOT EXISTS teams (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255),
+    created_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teams_org_name ON teams (organization_id, name);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_user_id ON team_members (user_id);
+
+ALTER TABLE risks ADD COLUMN IF NOT EXISTS owner_team_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_risks_owner_team_id ON risks (owner_team_id);
+ALTER TABLE audit_assessments ADD COLUMN IF NOT EXISTS assigned_team_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_assessments_assigned_team_id ON audit_assessments (assigned_team_id);
+ALTER TABLE control_test_plans ADD COLUMN IF NOT EXISTS owner_team_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_control_test_plans_owner_team_id ON control_test_plans (owner_team_id);
+
+-- A view da listagem passa a expor a equipe dona do risco (colunas novas entram no fim).
+CREATE OR REPLACE VIEW risk_list_items AS
+SELECT
+    risks.id,
+    risks.organization_id,
+    risks.reference_code,
+    risks.title,
+    risks.description,
+    risks.category,
+    risks.impact,
+    risks.probability,
+    risks.risk_level,
+    risks.status,
+    risks.owner_id,
+    risks.created_at,
+    risks.updated_at,
+    owners.name AS owner_name,
+    owners.email AS owner_email,
+    approval.status AS approval_status,
+    approval.updated_at AS approval_updated_at,
+    stakeholders.stakeholder_count,
+    controls.control_count,
+    controls.treated_control_count,
+    CASE WHEN controls.control_count = 0 THEN NULL
+         ELSE (controls.treated_control_count * 100 / controls.control_count)::int END AS treatment_progress,
+    risks.status_code,
+    risks.owner_team_id,
+    owner_teams.name AS owner_team_name
+FROM risks
+LEFT JOIN users AS owners ON owners.id = risks.owner_id
+LEFT JOIN teams AS owner_teams ON owner_teams.id = risks.owner_team_id
+LEFT JOIN LATERAL (
+    SELECT approval_workflows.status, approval_workflows.updated_at
+    FROM approval_workflows
+    WHERE approval_workflows.risk_id = risks.id
+    ORDER BY approval_workflows.created_at DESC
+    LIMIT 1
+) AS approval ON TRUE
+LEFT JOIN LATERAL (
+    SELECT COUNT(*)::int AS stakeholder_count FROM risk_stakeholders WHERE risk_stakeholders.risk_id = risks.id
+) AS stakeholders ON TRUE
+LEFT JOIN LATERAL (
+    SELECT COUNT(*)::int AS control_count,
+           (COUNT(*) FILTER (WHERE audit_assessments.status IN ('conforme', 'nao_aplicavel', 'dispensado')))::int AS treated_control_count
+    FROM risk_controls
+    LEFT JOIN audit_assessments ON audit_assessments.audit_control_id = risk_controls.audit_control_id
+        AND audit_assessments.organization_id = risk_controls.organization_id
+        AND audit_assessments.audit_campaign_id IS NULL
+    WHERE risk_controls.risk_id = risks.id
+) AS controls ON TRUE;
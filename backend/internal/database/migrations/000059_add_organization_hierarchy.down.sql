@@ -0,0 +1,4 @@
+-- Reversão da hierarquia de organizações
+
+DROP INDEX IF EXISTS idx_organizations_parent_id;
+ALTER TABLE organizations DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,5 @@
+-- Hierarquia de organizações (unidades de negócio): cada organização pode ter uma organização pai, cujos
+-- usuários veem os relatórios consolidados das filhas. Os demais endpoints continuam isolados por organização.
+
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_organizations_parent_id ON organizations (parent_id);
@@ -0,0 +1,3 @@
+-- Reversão dos convites de usuários
+
+DROP TABLE IF EXISTS user_invitations;
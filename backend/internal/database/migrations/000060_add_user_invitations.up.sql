@@ -0,0 +1,22 @@
+-- Convites de usuários: o link enviado por e-mail contém um token aleatório, do qual só o hash (SHA-256) é guardado.
+
+CREATE TABLE IF NOT EXISTS user_invitations (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(255),
+    role VARCHAR(20) NOT NULL,
+    custom_role_id UUID REFERENCES custom_roles(id) ON DELETE SET NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    invited_by_id UUID NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    accepted_user_id UUID,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_invitations_token_hash ON user_invitations (token_hash);
+CREATE INDEX IF NOT EXISTS idx_user_invitations_organization_id ON user_invitations (organization_id);
+CREATE INDEX IF NOT EXISTS idx_user_invitations_email ON user_invitations (email);
@@ -0,0 +1,3 @@
+-- Reversão dos tokens de redefinição de senha com hash
+
+DROP TABLE IF EXISTS password_reset_tokens;
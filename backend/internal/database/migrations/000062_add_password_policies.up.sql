@@ -0,0 +1,17 @@
+-- Política de senhas por organização e data da última troca de senha de cada usuário (validade da senha).
+
+CREATE TABLE IF NOT EXISTS password_policies (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    min_length INTEGER NOT NULL DEFAULT 8,
+    require_uppercase BOOLEAN NOT NULL DEFAULT FALSE,
+    require_lowercase BOOLEAN NOT NULL DEFAULT FALSE,
+    require_digit BOOLEAN NOT NULL DEFAULT FALSE,
+    require_symbol BOOLEAN NOT NULL DEFAULT FALSE,
+    check_breached BOOLEAN NOT NULL DEFAULT FALSE,
+    max_age_days INTEGER NOT NULL DEFAULT 0,
+    updated_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP WITH TIME ZONE;
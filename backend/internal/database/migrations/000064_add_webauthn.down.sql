@@ -0,0 +1,5 @@
+-- Reversão das passkeys (WebAuthn) e da política de MFA da organização
+
+DROP TABLE IF EXISTS mfa_policies;
+DROP TABLE IF EXISTS web_authn_challenges;
+DROP TABLE IF EXISTS web_authn_credentials;
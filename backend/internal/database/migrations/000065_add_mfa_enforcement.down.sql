@@ -0,0 +1,6 @@
+-- Reversão da exigência de segundo fator pela organização e do escopo das sessões
+
+ALTER TABLE auth_sessions DROP COLUMN IF EXISTS scope;
+
+ALTER TABLE mfa_policies DROP COLUMN IF EXISTS require_mfa_roles;
+ALTER TABLE mfa_policies DROP COLUMN IF EXISTS require_mfa_for_all;
@@ -0,0 +1,7 @@
+-- Exigência de segundo fator pela organização (todos os usuários ou papéis) e escopo das sessões de login:
+-- quem ainda não cadastrou o fator exigido recebe uma sessão restrita ao cadastro de MFA
+
+ALTER TABLE mfa_policies ADD COLUMN IF NOT EXISTS require_mfa_for_all BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE mfa_policies ADD COLUMN IF NOT EXISTS require_mfa_roles JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE auth_sessions ADD COLUMN IF NOT EXISTS scope VARCHAR(30) NOT NULL DEFAULT '';
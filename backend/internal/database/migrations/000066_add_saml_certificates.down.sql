@@ -0,0 +1,3 @@
+-- Reversão dos certificados do Service Provider SAML por IdP
+
+DROP TABLE IF EXISTS saml_certificates;
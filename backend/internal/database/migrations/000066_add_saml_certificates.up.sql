@@ -0,0 +1,22 @@
+-- Certificados do Service Provider SAML por IdP, para a rotação sem interrupção: o ativo assina e os de reserva
+-- são publicados nos metadados junto com ele
+
+CREATE TABLE IF NOT EXISTS saml_certificates (
+    id UUID PRIMARY KEY,
+    identity_provider_id UUID NOT NULL REFERENCES identity_providers(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    certificate_pem TEXT NOT NULL,
+    private_key_encrypted TEXT NOT NULL,
+    fingerprint_sha256 VARCHAR(64) NOT NULL,
+    subject VARCHAR(255),
+    not_before TIMESTAMP WITH TIME ZONE NOT NULL,
+    not_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    activated_at TIMESTAMP WITH TIME ZONE,
+    created_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_saml_certificates_identity_provider_id ON saml_certificates (identity_provider_id);
+-- Um único certificado ativo por IdP
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saml_certificates_active ON saml_certificates (identity_provider_id) WHERE status = 'active';
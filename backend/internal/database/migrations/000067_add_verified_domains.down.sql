@@ -0,0 +1,3 @@
+-- Reversão dos domínios verificados para descoberta do provedor de identidade
+
+DROP TABLE IF EXISTS verified_domains;
@@ -0,0 +1,19 @@
+-- Domínios de email verificados por DNS, usados para descobrir o provedor de identidade da organização no login
+
+CREATE TABLE IF NOT EXISTS verified_domains (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    domain VARCHAR(255) NOT NULL,
+    identity_provider_id UUID REFERENCES identity_providers(id) ON DELETE SET NULL,
+    verification_token VARCHAR(64) NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    last_checked_at TIMESTAMP WITH TIME ZONE,
+    created_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_verified_domains_org_domain ON verified_domains (organization_id, domain);
+CREATE INDEX IF NOT EXISTS idx_verified_domains_identity_provider_id ON verified_domains (identity_provider_id);
+-- Um domínio verificado pertence a uma única organização; pendentes podem ser reivindicados por várias
+CREATE UNIQUE INDEX IF NOT EXISTS idx_verified_domains_verified ON verified_domains (domain) WHERE verified_at IS NOT NULL;
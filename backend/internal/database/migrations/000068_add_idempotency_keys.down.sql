@@ -0,0 +1,3 @@
+-- Reversão das chaves de idempotência
+
+DROP TABLE IF EXISTS idempotency_keys;
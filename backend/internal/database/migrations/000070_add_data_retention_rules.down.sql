@@ -0,0 +1,4 @@
+-- Reversão das regras de retenção de dados
+
+ALTER TABLE users DROP COLUMN IF EXISTS anonymized_at;
+DROP TABLE IF EXISTS data_retention_rules;
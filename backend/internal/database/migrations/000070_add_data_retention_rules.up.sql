@@ -0,0 +1,16 @@
+-- Regras de retenção de dados por organização (limitação da conservação, LGPD/GDPR) e marcação dos usuários
+-- anonimizados
+
+CREATE TABLE IF NOT EXISTS data_retention_rules (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    category VARCHAR(50) NOT NULL,
+    retention_days INTEGER NOT NULL,
+    updated_by_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_retention_rules_org_category ON data_retention_rules (organization_id, category);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP WITH TIME ZONE;
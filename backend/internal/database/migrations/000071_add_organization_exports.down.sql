@@ -0,0 +1,3 @@
+-- Reversão das exportações completas da organização
+
+DROP TABLE IF EXISTS organization_exports;
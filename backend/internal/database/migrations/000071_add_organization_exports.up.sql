@@ -0,0 +1,23 @@
+-- Exportações completas dos dados da organização (backup e offboarding), geradas em segundo plano como ZIP no
+-- armazenamento de arquivos
+
+CREATE TABLE IF NOT EXISTS organization_exports (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    requested_by_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    object_name VARCHAR(512),
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    sha256 VARCHAR(64),
+    manifest JSONB,
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_exports_organization_id ON organization_exports (organization_id);
+CREATE INDEX IF NOT EXISTS idx_organization_exports_status ON organization_exports (status);
+CREATE INDEX IF NOT EXISTS idx_organization_exports_expires_at ON organization_exports (expires_at);
@@ -0,0 +1,6 @@
+-- Reversão do adiamento e do descarte de notificações
+
+DROP INDEX IF EXISTS idx_notifications_dismissed_at;
+DROP INDEX IF EXISTS idx_notifications_snoozed_until;
+ALTER TABLE notifications DROP COLUMN IF EXISTS dismissed_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS snoozed_until;
@@ -0,0 +1,7 @@
+-- Adiar e descartar notificações da central: snoozed_until esconde a notificação (e a tira do contador de não
+-- lidas) até a data; dismissed_at a remove da central do usuário
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS dismissed_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_notifications_snoozed_until ON notifications (snoozed_until);
+CREATE INDEX IF NOT EXISTS idx_notifications_dismissed_at ON notifications (dismissed_at);
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// slowQueryMaxSQLLength limita o SQL registrado (ex: IN com milhares de placeholders).
+const slowQueryMaxSQLLength = 2000
+
+const slowQueryStartKey = "slowquery:start"
+
+// SlowQueries conta as consultas acima do limite, por rota, arquivo de origem e tabela.
+var SlowQueries = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "phoenixgrc_db_slow_queries_total",
+		Help: "Total number of database queries slower than the configured threshold.",
+	},
+	[]string{"route", "caller", "table"},
+)
+
+type requestTagsKey struct{}
+
+// RequestTags identifica a requisição HTTP que originou uma consulta.
+type RequestTags struct {
+	RequestID string
+	Route     string // Rota do Gin (ex: "GET /api/v1/risks/:riskId")
+}
+
+// WithRequestTags anexa ao contexto as tags da requisição, registradas junto às consultas lentas feitas com
+// db.WithContext(ctx).
+func WithRequestTags(ctx context.Context, tags RequestTags) context.Context {
+	return context.WithValue(ctx, requestTagsKey{}, tags)
+}
+
+// RequestTagsFrom retorna as tags da requisição anexadas ao contexto, se houver.
+func RequestTagsFrom(ctx context.Context) (RequestTags, bool) {
+	if ctx == nil {
+		return RequestTags{}, false
+	}
+	tags, ok := ctx.Value(requestTagsKey{}).(RequestTags)
+	return tags, ok
+}
+
+// SlowQueryPlugin é um plugin do GORM que registra as consultas que levam Threshold ou mais. O SQL é
+// registrado com os placeholders ($1, $2...), sem os valores dos parâmetros, que podem conter dados
+// pessoais ou segredos.
+type SlowQueryPlugin struct {
+	Threshold time.Duration
+	Logger    *zap.Logger // Opcional; padrão: phxlog.L
+}
+
+// Name implementa gorm.Plugin.
+func (p *SlowQueryPlugin) Name() string {
+	return "phoenixgrc:slow_query"
+}
+
+// Initialize implementa gorm.Plugin, registrando a medição em torno de cada tipo de operação.
+func (p *SlowQueryPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("slowquery:before_create", p.start),
+		cb.Create().After("gorm:create").Register("slowquery:after_create", p.finish),
+		cb.Query().Before("gorm:query").Register("slowquery:before_query", p.start),
+		cb.Query().After("gorm:query").Register("slowquery:after_query", p.finish),
+		cb.Update().Before("gorm:update").Register("slowquery:before_update", p.start),
+		cb.Update().After("gorm:update").Register("slowquery:after_update", p.finish),
+		cb.Delete().Before("gorm:delete").Register("slowquery:before_delete", p.start),
+		cb.Delete().After("gorm:delete").Register("slowquery:after_delete", p.finish),
+		cb.Row().Before("gorm:row").Register("slowquery:before_row", p.start),
+		cb.Row().After("gorm:row").Register("slowquery:after_row", p.finish),
+		cb.Raw().Before("gorm:raw").Register("slowquery:before_raw", p.start),
+		cb.Raw().After("gorm:raw").Register("slowquery:after_raw", p.finish),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *SlowQueryPlugin) start(db *gorm.DB) {
+	db.InstanceSet(slowQueryStartKey, time.Now())
+}
+
+func (p *SlowQueryPlugin) finish(db *gorm.DB) {
+	value, ok := db.InstanceGet(slowQueryStartKey)
+	if !ok || p.Threshold <= 0 {
+		return
+	}
+	elapsed := time.Since(value.(time.Time))
+	if elapsed < p.Threshold {
+		return
+	}
+
+	stmt := db.Statement
+	sql := stmt.SQL.String()
+	if len(sql) > slowQueryMaxSQLLength {
+		sql = sql[:slowQueryMaxSQLLength] + "..."
+	}
+	caller := slowQueryCaller()
+	tags, _ := RequestTagsFrom(stmt.Context)
+
+	SlowQueries.WithLabelValues(tags.Route, filepath.Base(strings.SplitN(caller, ":", 2)[0]), stmt.Table).Inc()
+
+	logger := p.Logger
+	if logger == nil {
+		logger = phxlog.L
+	}
+	fields := []zap.Field{
+		zap.Duration("duration", elapsed),
+		zap.Duration("threshold", p.Threshold),
+		zap.String("sql", sql),
+		zap.Int("params", len(stmt.Vars)),
+		zap.String("table", stmt.Table),
+		zap.Int64("rows", db.RowsAffected),
+		zap.String("caller", caller),
+	}
+	if tags.RequestID != "" {
+		fields = append(fields, zap.String("request_id", tags.RequestID))
+	}
+	if tags.Route != "" {
+		fields = append(fields, zap.String("route", tags.Route))
+	}
+	if db.Error != nil {
+		fields = append(fields, zap.Error(db.Error))
+	}
+	logger.Warn("Slow database query", fields...)
+}
+
+// slowQueryCaller retorna arquivo:linha do primeiro chamador fora do GORM e deste pacote, ex: o handler
+// que fez a consulta.
+func slowQueryCaller() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "gorm.io/") &&
+			!strings.HasPrefix(frame.Function, "phoenixgrc/backend/internal/database.") &&
+			!strings.HasPrefix(frame.Function, "runtime.") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package database_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type slowQueryUser struct {
+	ID    string
+	Email string
+}
+
+func counterValue(t *testing.T, counter interface{ Write(*dto.Metric) error }) float64 {
+	var m dto.Metric
+	require.NoError(t, counter.Write(&m))
+	return m.GetCounter().GetValue()
+}
+
+func newSlowQueryDB(t *testing.T, threshold time.Duration) (*gorm.DB, sqlmock.Sqlmock, *observer.ObservedLogs) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	core, logs := observer.New(zapcore.WarnLevel)
+	require.NoError(t, db.Use(&database.SlowQueryPlugin{Threshold: threshold, Logger: zap.New(core)}))
+	return db, mock, logs
+}
+
+func TestSlowQueryPluginLogsRedactedQueryWithRequestTags(t *testing.T) {
+	db, mock, logs := newSlowQueryDB(t, 20*time.Millisecond)
+	mock.ExpectQuery(`SELECT \* FROM "slow_query_users"`).
+		WillDelayFor(30 * time.Millisecond).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow("1", "secret@example.com"))
+
+	counter := database.SlowQueries.WithLabelValues("GET /api/v1/users", "slowquery_test.go", "slow_query_users")
+	before := counterValue(t, counter)
+
+	ctx := database.WithRequestTags(context.Background(), database.RequestTags{RequestID: "req-123", Route: "GET /api/v1/users"})
+	var users []slowQueryUser
+	require.NoError(t, db.WithContext(ctx).Where("email = ?", "secret@example.com").Find(&users).Error)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	sql := fields["sql"].(string)
+	assert.Contains(t, sql, "$1")
+	assert.NotContains(t, sql, "secret@example.com")
+	assert.Equal(t, int64(1), fields["params"])
+	assert.Equal(t, "req-123", fields["request_id"])
+	assert.Equal(t, "GET /api/v1/users", fields["route"])
+	assert.True(t, strings.Contains(fields["caller"].(string), "slowquery_test.go:"), fields["caller"])
+	assert.Equal(t, before+1, counterValue(t, counter))
+}
+
+func TestSlowQueryPluginIgnoresFastQueries(t *testing.T) {
+	db, mock, logs := newSlowQueryDB(t, time.Second)
+	mock.ExpectQuery(`SELECT \* FROM "slow_query_users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}))
+
+	var users []slowQueryUser
+	require.NoError(t, db.Find(&users).Error)
+	assert.Zero(t, logs.Len())
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"errors"
+
+	"phoenixgrc/backend/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const tracingSpanKey = "tracing:span"
+
+// TracingPlugin é um plugin do GORM que cria um span para cada consulta, filho do span do contexto da
+// consulta (db.WithContext(ctx)). Como no log de consultas lentas, o SQL vai com os placeholders, sem os
+// valores dos parâmetros.
+type TracingPlugin struct{}
+
+// Name implementa gorm.Plugin.
+func (p *TracingPlugin) Name() string {
+	return "phoenixgrc:tracing"
+}
+
+// Initialize implementa gorm.Plugin, abrindo e fechando um span em torno de cada tipo de operação.
+func (p *TracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("tracing:before_create", p.start("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", p.finish),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", p.start("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", p.finish),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", p.start("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", p.finish),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", p.start("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", p.finish),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", p.start("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", p.finish),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", p.start("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", p.finish),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *TracingPlugin) start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+		_, span := tracing.Tracer().Start(db.Statement.Context, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("db.system", "postgresql"), attribute.String("db.operation", operation)))
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func (p *TracingPlugin) finish(db *gorm.DB) {
+	value, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+
+	stmt := db.Statement
+	sql := stmt.SQL.String()
+	if len(sql) > slowQueryMaxSQLLength {
+		sql = sql[:slowQueryMaxSQLLength] + "..."
+	}
+	span.SetAttributes(
+		attribute.String("db.statement", sql),
+		attribute.String("db.sql.table", stmt.Table),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if tags, ok := RequestTagsFrom(stmt.Context); ok && tags.Route != "" {
+		span.SetAttributes(attribute.String("http.route", tags.Route))
+	}
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,64 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/tracing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingPluginCreatesChildSpanPerQuery(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(&database.TracingPlugin{}))
+
+	mock.ExpectQuery(`SELECT \* FROM "slow_query_users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow("1", "secret@example.com"))
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "GET /api/v1/users")
+	ctx = database.WithRequestTags(ctx, database.RequestTags{RequestID: "req-123", Route: "GET /api/v1/users"})
+	var users []slowQueryUser
+	require.NoError(t, db.WithContext(ctx).Where("email = ?", "secret@example.com").Find(&users).Error)
+	parent.End()
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	query := spans[0]
+	assert.Equal(t, "db.query", query.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	attrs := spanAttributes(query)
+	assert.Equal(t, "slow_query_users", attrs["db.sql.table"].AsString())
+	assert.Contains(t, attrs["db.statement"].AsString(), "$1")
+	assert.NotContains(t, attrs["db.statement"].AsString(), "secret@example.com")
+	assert.Equal(t, int64(1), attrs["db.rows_affected"].AsInt64())
+	assert.Equal(t, "GET /api/v1/users", attrs["http.route"].AsString())
+}
@@ -0,0 +1,136 @@
+// Package events distribui eventos de domínio, escopados por organização, para assinantes
+// em tempo real (ex: o stream SSE consumido pelos dashboards).
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType identifica o tipo de evento publicado no stream.
+type EventType string
+
+const (
+	EventRiskCreated         EventType = "risk.created"
+	EventRiskApprovalDecided EventType = "risk.approval_decided"
+	EventAssessmentSubmitted EventType = "assessment.submitted"
+	// Score de conformidade de um framework recalculado após uma avaliação (recurso: o framework)
+	EventComplianceScoreUpdated EventType = "compliance_score.updated"
+)
+
+// subscriberBufferSize é quantos eventos podem ficar pendentes por assinante antes de serem descartados.
+const subscriberBufferSize = 32
+
+// Event é a mensagem entregue aos assinantes de uma organização.
+type Event struct {
+	ID             string      `json:"id"`
+	Type           EventType   `json:"type"`
+	OrganizationID uuid.UUID   `json:"organization_id"`
+	ResourceID     uuid.UUID   `json:"resource_id"`
+	Data           interface{} `json:"data,omitempty"`
+	OccurredAt     time.Time   `json:"occurred_at"`
+}
+
+// Hub mantém os assinantes ativos por organização. É seguro para uso concorrente.
+// A entrega é best-effort e em memória: com múltiplas réplicas da API, cada instância
+// só entrega eventos gerados nela mesma.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[chan Event]struct{}
+	closed      bool
+}
+
+// NewHub cria um Hub vazio.
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[uuid.UUID]map[chan Event]struct{})}
+}
+
+// DefaultHub é o hub usado pelos handlers da API.
+var DefaultHub = NewHub()
+
+// Subscribe registra um assinante para os eventos da organização.
+// A função retornada cancela a assinatura e fecha o canal; deve ser chamada quando o cliente desconectar.
+// Depois de Close, o canal retornado já vem fechado.
+func (h *Hub) Subscribe(orgID uuid.UUID) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBufferSize)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subscribers[orgID] == nil {
+		h.subscribers[orgID] = make(map[chan Event]struct{})
+	}
+	h.subscribers[orgID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[orgID][ch]; !ok {
+			return // Já cancelada ou encerrada por Close
+		}
+		delete(h.subscribers[orgID], ch)
+		if len(h.subscribers[orgID]) == 0 {
+			delete(h.subscribers, orgID)
+		}
+		close(ch)
+	}
+	return ch, unsubscribe
+}
+
+// Close fecha os canais de todos os assinantes, encerrando os streams abertos, e recusa novas assinaturas.
+// Usado no encerramento do servidor, para que as conexões SSE não segurem o desligamento; os clientes
+// reconectam em outra instância.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for orgID, channels := range h.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+		delete(h.subscribers, orgID)
+	}
+}
+
+// Publish entrega o evento a todos os assinantes da organização sem bloquear.
+// Assinantes lentos cujo buffer está cheio perdem o evento (o dashboard pode recarregar a lista).
+func (h *Hub) Publish(event Event) {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.subscribers[event.OrganizationID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// SubscriberCount retorna o número de assinantes ativos da organização.
+func (h *Hub) SubscriberCount(orgID uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[orgID])
+}
+
+// Publish publica um evento no DefaultHub.
+func Publish(orgID uuid.UUID, eventType EventType, resourceID uuid.UUID, data interface{}) {
+	DefaultHub.Publish(Event{
+		Type:           eventType,
+		OrganizationID: orgID,
+		ResourceID:     resourceID,
+		Data:           data,
+	})
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubDeliversOnlyToSameOrganization(t *testing.T) {
+	hub := NewHub()
+	orgA, orgB := uuid.New(), uuid.New()
+
+	chA, unsubA := hub.Subscribe(orgA)
+	defer unsubA()
+	chB, unsubB := hub.Subscribe(orgB)
+	defer unsubB()
+
+	resourceID := uuid.New()
+	hub.Publish(Event{Type: EventRiskCreated, OrganizationID: orgA, ResourceID: resourceID})
+
+	select {
+	case ev := <-chA:
+		assert.Equal(t, EventRiskCreated, ev.Type)
+		assert.Equal(t, resourceID, ev.ResourceID)
+		assert.NotEmpty(t, ev.ID)
+		assert.False(t, ev.OccurredAt.IsZero())
+	case <-time.After(time.Second):
+		t.Fatal("expected event for org A")
+	}
+
+	select {
+	case ev := <-chB:
+		t.Fatalf("org B should not receive org A events, got %+v", ev)
+	default:
+	}
+}
+
+func TestHubUnsubscribeClosesChannel(t *testing.T) {
+	hub := NewHub()
+	orgID := uuid.New()
+
+	ch, unsubscribe := hub.Subscribe(orgID)
+	require.Equal(t, 1, hub.SubscriberCount(orgID))
+
+	unsubscribe()
+	unsubscribe() // idempotente
+	assert.Equal(t, 0, hub.SubscriberCount(orgID))
+
+	_, open := <-ch
+	assert.False(t, open)
+
+	// Publicar sem assinantes não deve entrar em pânico.
+	hub.Publish(Event{Type: EventAssessmentSubmitted, OrganizationID: orgID})
+}
+
+func TestHubDropsEventsForSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+	orgID := uuid.New()
+
+	ch, unsubscribe := hub.Subscribe(orgID)
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBufferSize+10; i++ {
+		hub.Publish(Event{Type: EventRiskCreated, OrganizationID: orgID})
+	}
+	assert.Len(t, ch, subscriberBufferSize)
+}
+
+func TestHubCloseEndsStreams(t *testing.T) {
+	hub := NewHub()
+	orgID := uuid.New()
+
+	ch, unsubscribe := hub.Subscribe(orgID)
+	hub.Close()
+	_, open := <-ch
+	assert.False(t, open)
+	assert.Equal(t, 0, hub.SubscriberCount(orgID))
+	unsubscribe() // Cancelar após Close não fecha o canal de novo
+
+	late, _ := hub.Subscribe(orgID)
+	_, open = <-late
+	assert.False(t, open, "novas assinaturas vêm fechadas após Close")
+	hub.Publish(Event{Type: EventRiskCreated, OrganizationID: orgID})
+}
@@ -0,0 +1,150 @@
+// Package evidenceaccess avalia a política de acesso às evidências da organização: de quais redes e em que
+// horário os arquivos de evidência podem ser baixados.
+package evidenceaccess
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BreakGlassHeader é o cabeçalho com a justificativa de um download fora da política.
+const BreakGlassHeader = "X-Break-Glass-Reason"
+
+// MinBreakGlassReasonLength é o tamanho mínimo da justificativa de break-glass.
+const MinBreakGlassReasonLength = 10
+
+// Motivos de bloqueio.
+const (
+	ViolationNetwork = "outside_allowed_networks"
+	ViolationHours   = "outside_business_hours"
+)
+
+// Decision é o resultado da avaliação de um download.
+type Decision struct {
+	Allowed   bool
+	Violation string // Vazio quando permitido
+	Message   string
+}
+
+// LoadPolicy retorna a política de acesso às evidências da organização, ou nil se ela não configurou uma.
+func LoadPolicy(db *gorm.DB, orgID uuid.UUID) (*models.EvidenceAccessPolicy, error) {
+	var policy models.EvidenceAccessPolicy
+	if err := db.Where("organization_id = ?", orgID).Take(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// ParseNetworks valida e normaliza as redes permitidas. Um IP sem prefixo vale como /32 (ou /128 no IPv6).
+func ParseNetworks(networks []string) ([]string, error) {
+	seen := make(map[string]bool, len(networks))
+	normalized := make([]string, 0, len(networks))
+	for _, raw := range networks {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if !strings.Contains(raw, "/") {
+			ip := net.ParseIP(raw)
+			if ip == nil {
+				return nil, fmt.Errorf("'%s' is not a valid IP address or CIDR", raw)
+			}
+			if ip.To4() != nil {
+				raw += "/32"
+			} else {
+				raw += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid IP address or CIDR", raw)
+		}
+		cidr := network.String()
+		if !seen[cidr] {
+			seen[cidr] = true
+			normalized = append(normalized, cidr)
+		}
+	}
+	return normalized, nil
+}
+
+// ParseClock converte HH:MM em minutos desde a meia-noite.
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a valid time (use HH:MM)", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// InNetworks indica se o IP pertence a uma das redes; nenhuma rede configurada permite qualquer IP.
+func InNetworks(networks []string, ip net.IP) bool {
+	if len(networks) == 0 {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range networks {
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// InBusinessHours indica se now está no horário comercial da política.
+func InBusinessHours(policy *models.EvidenceAccessPolicy, now time.Time) bool {
+	if !policy.BusinessHoursEnabled {
+		return true
+	}
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	dayAllowed := false
+	for _, day := range policy.WeekdayList() {
+		if time.Weekday(day) == local.Weekday() {
+			dayAllowed = true
+			break
+		}
+	}
+	start, errStart := ParseClock(policy.StartTime)
+	end, errEnd := ParseClock(policy.EndTime)
+	if !dayAllowed || errStart != nil || errEnd != nil {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	return minute >= start && minute < end
+}
+
+// Evaluate avalia um download feito a partir de ip no instante now. policy pode ser nil (sem restrição).
+func Evaluate(policy *models.EvidenceAccessPolicy, ip net.IP, now time.Time) Decision {
+	if policy == nil {
+		return Decision{Allowed: true}
+	}
+	if !InNetworks(policy.NetworkList(), ip) {
+		address := "an unknown address"
+		if ip != nil {
+			address = ip.String()
+		}
+		return Decision{Violation: ViolationNetwork, Message: "downloads are not allowed from " + address}
+	}
+	if !InBusinessHours(policy, now) {
+		return Decision{Violation: ViolationHours, Message: fmt.Sprintf("downloads are only allowed during business hours (%s-%s %s)",
+			policy.StartTime, policy.EndTime, policy.Timezone)}
+	}
+	return Decision{Allowed: true}
+}
@@ -0,0 +1,71 @@
+package evidenceaccess
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := ParseNetworks([]string{" 10.0.0.0/8 ", "192.168.1.77/24", "203.0.113.5", "2001:db8::1", "", "10.1.2.3/8"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.0/24", "203.0.113.5/32", "2001:db8::1/128"}, networks)
+
+	_, err = ParseNetworks([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+	_, err = ParseNetworks([]string{"intranet"})
+	assert.Error(t, err)
+}
+
+func TestEvaluateNetworks(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	assert.True(t, Evaluate(nil, nil, now).Allowed, "sem política")
+
+	policy := &models.EvidenceAccessPolicy{}
+	policy.SetNetworks([]string{"10.0.0.0/8", "2001:db8::/32"})
+	assert.True(t, Evaluate(policy, net.ParseIP("10.20.30.40"), now).Allowed)
+	assert.True(t, Evaluate(policy, net.ParseIP("2001:db8::5"), now).Allowed)
+
+	d := Evaluate(policy, net.ParseIP("198.51.100.7"), now)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, ViolationNetwork, d.Violation)
+	assert.Contains(t, d.Message, "198.51.100.7")
+	assert.False(t, Evaluate(policy, nil, now).Allowed)
+
+	policy.SetNetworks(nil)
+	assert.True(t, Evaluate(policy, net.ParseIP("198.51.100.7"), now).Allowed, "sem redes = qualquer rede")
+}
+
+func TestEvaluateBusinessHours(t *testing.T) {
+	policy := &models.EvidenceAccessPolicy{BusinessHoursEnabled: true, Timezone: "America/Sao_Paulo", StartTime: "08:00", EndTime: "18:30"}
+	policy.SetNetworks(nil)
+	policy.SetWeekdays([]int{1, 2, 3, 4, 5})
+	ip := net.ParseIP("10.0.0.1")
+
+	// Quarta-feira, 14/10/2026: 11:00 UTC = 08:00 em São Paulo (UTC-3)
+	assert.True(t, Evaluate(policy, ip, time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC)).Allowed)
+	assert.False(t, Evaluate(policy, ip, time.Date(2026, 10, 14, 10, 59, 0, 0, time.UTC)).Allowed)
+	assert.True(t, Evaluate(policy, ip, time.Date(2026, 10, 14, 21, 29, 0, 0, time.UTC)).Allowed)
+	d := Evaluate(policy, ip, time.Date(2026, 10, 14, 21, 30, 0, 0, time.UTC))
+	assert.Equal(t, ViolationHours, d.Violation, "fim da faixa é exclusivo")
+	assert.Contains(t, d.Message, "08:00-18:30 America/Sao_Paulo")
+
+	// Sábado no horário: bloqueado
+	assert.False(t, Evaluate(policy, ip, time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)).Allowed)
+
+	policy.BusinessHoursEnabled = false
+	assert.True(t, Evaluate(policy, ip, time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)).Allowed)
+}
+
+func TestParseClock(t *testing.T) {
+	minutes, err := ParseClock("18:30")
+	require.NoError(t, err)
+	assert.Equal(t, 18*60+30, minutes)
+	_, err = ParseClock("25:00")
+	assert.Error(t, err)
+}
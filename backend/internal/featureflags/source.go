@@ -0,0 +1,49 @@
+// Package featureflags carrega do banco as feature flags avaliadas por pkg/features.
+package featureflags
+
+import (
+	"context"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/features"
+
+	"gorm.io/gorm"
+)
+
+// Source lê as flags e suas regras (ordenadas pela posição) das tabelas feature_flags e feature_flag_rules.
+type Source struct{}
+
+// LoadFlags implementa features.Source.
+func (Source) LoadFlags(ctx context.Context) ([]features.Flag, error) {
+	var rows []models.FeatureFlag
+	err := database.GetDB().WithContext(ctx).
+		Preload("Rules", func(db *gorm.DB) *gorm.DB { return db.Order("position asc, created_at asc") }).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	flags := make([]features.Flag, 0, len(rows))
+	for _, row := range rows {
+		flags = append(flags, ToFlag(row))
+	}
+	return flags, nil
+}
+
+// ToFlag converte o registro do banco na flag avaliada por pkg/features.
+func ToFlag(row models.FeatureFlag) features.Flag {
+	flag := features.Flag{
+		Key:               row.Key,
+		Enabled:           row.Enabled,
+		RolloutPercentage: row.RolloutPercentage,
+		Rules:             make([]features.Rule, 0, len(row.Rules)),
+	}
+	for _, r := range row.Rules {
+		rule := features.Rule{OrganizationID: r.OrganizationID, Enabled: r.Enabled}
+		if r.Role != nil {
+			rule.Role = string(*r.Role)
+		}
+		flag.Rules = append(flag.Rules, rule)
+	}
+	return flag
+}
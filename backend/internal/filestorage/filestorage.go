@@ -3,6 +3,7 @@ package filestorage
 import (
 	"context"
 	"io"
+	"phoenixgrc/backend/internal/tracing"
 	"phoenixgrc/backend/pkg/config"
 	phxlog "phoenixgrc/backend/pkg/log" // Importar o logger zap
 	"go.uber.org/zap"                 // Importar zap
@@ -26,14 +27,17 @@ func InitFileStorage() error {
 
 	var err error
 	switch providerType {
-	case "s3":
-		DefaultFileStorageProvider, err = InitializeS3Provider()
+	case "s3", "minio": // MinIO e outros serviços S3-compatíveis usam o mesmo provider com S3_ENDPOINT
+		var s3Provider *S3StorageProvider
+		s3Provider, err = InitializeS3Provider()
 		if err != nil {
 			phxlog.L.Error("Failed to initialize S3 storage provider. File uploads via S3 may be disabled.", zap.Error(err))
-			// Se err != nil, DefaultFileStorageProvider já será nil por InitializeS3Provider
 		}
-		if DefaultFileStorageProvider == nil && err == nil { // S3 especificamente não configurado (ex: bucket ausente)
-			phxlog.L.Warn("S3 provider not configured (e.g., missing bucket/region). File uploads via S3 disabled.")
+		// Atribuir somente ponteiros não-nil: um *S3StorageProvider nil dentro da interface não é == nil.
+		if s3Provider != nil {
+			DefaultFileStorageProvider = s3Provider
+		} else if err == nil { // S3 especificamente não configurado (ex: bucket ausente)
+			phxlog.L.Warn("S3 provider not configured (e.g., missing bucket/region/endpoint). File uploads via S3 disabled.")
 		}
 	case "gcs":
 		DefaultFileStorageProvider, err = InitializeGCSProvider()
@@ -43,15 +47,30 @@ func InitFileStorage() error {
 		if DefaultFileStorageProvider == nil && err == nil { // GCS especificamente não configurado
 			phxlog.L.Warn("GCS provider not configured (e.g., missing project/bucket). File uploads via GCS disabled.")
 		}
+	case "local":
+		var localProvider *LocalStorageProvider
+		localProvider, err = InitializeLocalProvider()
+		if err != nil {
+			phxlog.L.Error("Failed to initialize local storage provider. File uploads may be disabled.", zap.Error(err))
+		}
+		if localProvider != nil {
+			DefaultFileStorageProvider = localProvider
+		}
 	default:
-		phxlog.L.Warn("Unsupported FILE_STORAGE_PROVIDER. File uploads will be disabled.", zap.String("provider_type", providerType))
+		phxlog.L.Warn("Unsupported FILE_STORAGE_BACKEND/FILE_STORAGE_PROVIDER. File uploads will be disabled.", zap.String("provider_type", providerType))
 		// DefaultFileStorageProvider will remain nil
 	}
 
+	if DefaultFileStorageProvider != nil && tracing.Enabled() {
+		DefaultFileStorageProvider = &tracedProvider{inner: DefaultFileStorageProvider, provider: providerType}
+	}
 	if DefaultFileStorageProvider != nil {
 		phxlog.L.Info("File storage provider initialized successfully.", zap.String("provider_type", providerType))
 	} else {
 		phxlog.L.Warn("No file storage provider initialized. File uploads will be disabled.")
 	}
-	return nil // Always return nil to not block app startup if storage isn't critical for all ops
+	// Falha de um provider configurado é informada ao chamador, que marca o armazenamento como
+	// indisponível e repete a inicialização em segundo plano (ver health.InitOptional); a ausência de
+	// provider configurado não é erro.
+	return err
 }
@@ -151,3 +151,9 @@ func (g *GCSStorageProvider) GetSignedURL(ctx context.Context, objectName string
 
 
 // Note: DefaultFileStorageProvider and InitFileStorage are now in filestorage.go
+
+// Ping verifica o acesso ao bucket lendo seus atributos, sem ler nem gravar objetos.
+func (g *GCSStorageProvider) Ping(ctx context.Context) error {
+	_, err := g.client.Bucket(g.bucketName).Attrs(ctx)
+	return err
+}
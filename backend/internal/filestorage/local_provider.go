@@ -0,0 +1,221 @@
+package filestorage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// LocalFileDownloadPath é a rota pública que serve arquivos do LocalStorageProvider
+// a partir de URLs assinadas (ver GetSignedURL e VerifySignedURL).
+const LocalFileDownloadPath = "/api/public/files/local"
+
+// ErrInvalidObjectName é retornado quando o objectName tenta escapar do diretório raiz.
+var ErrInvalidObjectName = errors.New("invalid object name")
+
+// ErrInvalidSignature é retornado quando a assinatura de uma URL local é inválida ou expirou.
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// LocalStorageProvider implements FileStorageProvider using the local filesystem.
+// Cada organização tem seu próprio subdiretório sob rootPath.
+type LocalStorageProvider struct {
+	rootPath   string
+	baseURL    string
+	signingKey []byte
+}
+
+// InitializeLocalProvider initializes the local filesystem provider, creating the root directory if needed.
+// Retorna nil, nil se LOCAL_STORAGE_PATH estiver vazio.
+func InitializeLocalProvider() (*LocalStorageProvider, error) {
+	rootPath := config.Cfg.LocalStoragePath
+	if rootPath == "" {
+		phxlog.L.Warn("LOCAL_STORAGE_PATH not set. File upload to local storage will be disabled.")
+		return nil, nil
+	}
+
+	absRoot, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local storage path '%s': %w", rootPath, err)
+	}
+	if err := os.MkdirAll(absRoot, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory '%s': %w", absRoot, err)
+	}
+
+	// As URLs assinadas usam uma chave própria, nunca a do JWT. Sem LOCAL_STORAGE_SIGNING_KEY, a chave é
+	// aleatória: as URLs deixam de valer ao reiniciar e não valem em outras instâncias
+	signingKey := []byte(config.Cfg.LocalStorageSigningKey)
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, fmt.Errorf("failed to generate local storage signing key: %w", err)
+		}
+		phxlog.L.Warn("LOCAL_STORAGE_SIGNING_KEY not set; using a random key. Signed download URLs will not survive restarts or work across instances.")
+	}
+
+	phxlog.L.Info("Local filesystem storage provider initialized", zap.String("root_path", absRoot))
+
+	return &LocalStorageProvider{
+		rootPath:   absRoot,
+		baseURL:    strings.TrimRight(config.Cfg.AppRootURL, "/"),
+		signingKey: signingKey,
+	}, nil
+}
+
+// resolvePath converte um objectName em um caminho absoluto dentro de rootPath,
+// rejeitando caminhos absolutos e tentativas de path traversal.
+func (l *LocalStorageProvider) resolvePath(objectName string) (string, error) {
+	if objectName == "" {
+		return "", ErrInvalidObjectName
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(objectName))
+	if filepath.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", ErrInvalidObjectName
+	}
+	return filepath.Join(l.rootPath, cleaned), nil
+}
+
+// organizationObjectName normaliza objectName (barras, "." e "..") e garante que o primeiro segmento seja
+// organizationID, prefixando-o quando necessário. A verificação é feita sobre o caminho já normalizado, para
+// "org-1/../org-2/x" não escapar para o diretório de outra organização.
+func organizationObjectName(organizationID, objectName string) (string, error) {
+	if organizationID == "" || strings.ContainsAny(organizationID, `/\`) || organizationID == "." || organizationID == ".." {
+		return "", ErrInvalidObjectName
+	}
+	cleaned := path.Clean(strings.ReplaceAll(objectName, `\`, "/"))
+	if objectName == "" || path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidObjectName
+	}
+	if first, _, _ := strings.Cut(cleaned, "/"); first != organizationID || cleaned == organizationID {
+		cleaned = organizationID + "/" + cleaned
+	}
+	return cleaned, nil
+}
+
+// UploadFile grava o arquivo em disco sob o diretório da organização.
+// Se objectName não começar com organizationID, ele é prefixado para garantir o isolamento por organização.
+func (l *LocalStorageProvider) UploadFile(ctx context.Context, organizationID string, objectName string, fileContent io.Reader) (string, error) {
+	if organizationID == "" {
+		return "", fmt.Errorf("organization ID is required for local storage uploads")
+	}
+	objectName, err := organizationObjectName(organizationID, objectName)
+	if err != nil {
+		return "", err
+	}
+
+	fullPath, err := l.resolvePath(objectName)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create directory for '%s': %w", objectName, err)
+	}
+
+	// Gravar em arquivo temporário e renomear, para nunca expor um arquivo parcialmente escrito.
+	tmpFile, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file for '%s': %w", objectName, err)
+	}
+	tmpName := tmpFile.Name()
+	if _, err := io.Copy(tmpFile, fileContent); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpName)
+		return "", fmt.Errorf("failed to write file '%s': %w", objectName, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpName)
+		return "", fmt.Errorf("failed to finalize file '%s': %w", objectName, err)
+	}
+	if err := os.Rename(tmpName, fullPath); err != nil {
+		os.Remove(tmpName)
+		return "", fmt.Errorf("failed to move file into place '%s': %w", objectName, err)
+	}
+
+	phxlog.L.Info("File uploaded successfully to local storage", zap.String("objectName", objectName))
+	return objectName, nil
+}
+
+// DeleteFile remove um arquivo do disco. Arquivos inexistentes são tratados como sucesso (idempotência).
+func (l *LocalStorageProvider) DeleteFile(ctx context.Context, objectName string) error {
+	fullPath, err := l.resolvePath(objectName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil {
+		if os.IsNotExist(err) {
+			phxlog.L.Info("Local DeleteFile: object not found (considered successful for idempotency)", zap.String("objectName", objectName))
+			return nil
+		}
+		return fmt.Errorf("failed to delete local object '%s': %w", objectName, err)
+	}
+	phxlog.L.Info("File deleted successfully from local storage", zap.String("objectName", objectName))
+	return nil
+}
+
+// Ping verifica se o diretório raiz existe e é um diretório.
+func (l *LocalStorageProvider) Ping(ctx context.Context) error {
+	info, err := os.Stat(l.rootPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("local storage path '%s' is not a directory", l.rootPath)
+	}
+	return nil
+}
+
+// OpenFile abre o arquivo para streaming. O chamador deve fechar o ReadCloser retornado.
+func (l *LocalStorageProvider) OpenFile(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	fullPath, err := l.resolvePath(objectName)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(fullPath)
+}
+
+// GetSignedURL gera uma URL com assinatura HMAC e expiração, servida por LocalFileDownloadPath.
+func (l *LocalStorageProvider) GetSignedURL(ctx context.Context, objectName string, durationMinutes int) (string, error) {
+	if _, err := l.resolvePath(objectName); err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(time.Duration(durationMinutes) * time.Minute).Unix()
+	q := url.Values{}
+	q.Set("key", objectName)
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("sig", l.sign(objectName, expires))
+	return fmt.Sprintf("%s%s?%s", l.baseURL, LocalFileDownloadPath, q.Encode()), nil
+}
+
+// VerifySignedURL valida a assinatura e a expiração de uma URL gerada por GetSignedURL.
+func (l *LocalStorageProvider) VerifySignedURL(objectName, expiresStr, signature string) error {
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(l.sign(objectName, expires)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (l *LocalStorageProvider) sign(objectName string, expires int64) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	mac.Write([]byte(objectName + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,86 @@
+package filestorage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestLocalProvider(t *testing.T) *LocalStorageProvider {
+	phxlog.L = zap.NewNop()
+	return &LocalStorageProvider{
+		rootPath:   t.TempDir(),
+		baseURL:    "http://localhost:8080",
+		signingKey: []byte("test-key"),
+	}
+}
+
+func TestLocalStorageProvider_UploadOpenDelete(t *testing.T) {
+	p := newTestLocalProvider(t)
+	ctx := context.Background()
+
+	stored, err := p.UploadFile(ctx, "org-1", "audit_evidences/c1/file.txt", strings.NewReader("evidence"))
+	require.NoError(t, err)
+	assert.Equal(t, "org-1/audit_evidences/c1/file.txt", stored, "object name should be scoped to the organization")
+
+	rc, err := p.OpenFile(ctx, stored)
+	require.NoError(t, err)
+	content, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "evidence", string(content))
+
+	require.NoError(t, p.DeleteFile(ctx, stored))
+	assert.NoError(t, p.DeleteFile(ctx, stored), "deleting a missing file should be idempotent")
+}
+
+func TestLocalStorageProvider_RejectsPathTraversal(t *testing.T) {
+	p := newTestLocalProvider(t)
+
+	_, err := p.UploadFile(context.Background(), "org-1", "org-1/../../etc/passwd", strings.NewReader("x"))
+	assert.ErrorIs(t, err, ErrInvalidObjectName)
+
+	_, err = p.OpenFile(context.Background(), "../secret")
+	assert.ErrorIs(t, err, ErrInvalidObjectName)
+}
+
+func TestLocalStorageProvider_UploadStaysInOrganizationDirectory(t *testing.T) {
+	p := newTestLocalProvider(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"org-1/../org-2/x.txt", `org-1\..\org-2\x.txt`, "./org-2/x.txt"} {
+		stored, err := p.UploadFile(ctx, "org-1", name, strings.NewReader("x"))
+		require.NoError(t, err, name)
+		assert.Equal(t, "org-1/org-2/x.txt", stored, "%s must not escape into another organization's directory", name)
+	}
+	_, err := os.Stat(filepath.Join(p.rootPath, "org-2"))
+	assert.True(t, os.IsNotExist(err), "nothing is written outside org-1")
+
+	_, err = p.UploadFile(ctx, "org-1", "../org-2/x.txt", strings.NewReader("x"))
+	assert.ErrorIs(t, err, ErrInvalidObjectName)
+	_, err = p.UploadFile(ctx, "../org-2", "x.txt", strings.NewReader("x"))
+	assert.ErrorIs(t, err, ErrInvalidObjectName)
+}
+
+func TestLocalStorageProvider_SignedURL(t *testing.T) {
+	p := newTestLocalProvider(t)
+
+	signed, err := p.GetSignedURL(context.Background(), "org-1/file.txt", 5)
+	require.NoError(t, err)
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	q := parsed.Query()
+
+	assert.NoError(t, p.VerifySignedURL(q.Get("key"), q.Get("expires"), q.Get("sig")))
+	assert.ErrorIs(t, p.VerifySignedURL("org-2/file.txt", q.Get("expires"), q.Get("sig")), ErrInvalidSignature)
+	assert.ErrorIs(t, p.VerifySignedURL(q.Get("key"), "0", q.Get("sig")), ErrInvalidSignature)
+}
@@ -0,0 +1,26 @@
+package filestorage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotConfigured indica que nenhum provider de armazenamento foi inicializado.
+var ErrNotConfigured = errors.New("file storage provider is not configured")
+
+// pinger é implementado pelos providers que verificam o acesso ao armazenamento sem gravar objetos.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping verifica se o armazenamento padrão está configurado e acessível (usado pelo /readyz).
+func Ping(ctx context.Context) error {
+	provider := DefaultFileStorageProvider
+	if provider == nil {
+		return ErrNotConfigured
+	}
+	if p, ok := Unwrap(provider).(pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package filestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/tracing"
+)
+
+// ErrObjectTooLarge indica que o objeto excede o limite de leitura informado.
+var ErrObjectTooLarge = errors.New("object exceeds the read limit")
+
+// fileOpener é implementado pelos providers que leem o objeto diretamente (ex: armazenamento local).
+type fileOpener interface {
+	OpenFile(ctx context.Context, objectName string) (io.ReadCloser, error)
+}
+
+// readClient baixa objetos pelas URLs assinadas dos providers remotos.
+var readClient = tracing.HTTPClient(&http.Client{Timeout: 30 * time.Second})
+
+// ReadObject lê o conteúdo do objeto, até maxBytes. Usa leitura direta quando o provider permite; nos
+// demais, baixa o objeto por uma URL assinada de curta duração.
+func ReadObject(ctx context.Context, provider FileStorageProvider, objectName string, maxBytes int64) ([]byte, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("file storage provider is not configured")
+	}
+	var body io.ReadCloser
+	if opener, ok := Unwrap(provider).(fileOpener); ok {
+		file, err := opener.OpenFile(ctx, objectName)
+		if err != nil {
+			return nil, err
+		}
+		body = file
+	} else {
+		signedURL, err := provider.GetSignedURL(ctx, objectName, 5)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, signedURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := readClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to download object: HTTP %d", resp.StatusCode)
+		}
+		body = resp.Body
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, ErrObjectTooLarge
+	}
+	return data, nil
+}
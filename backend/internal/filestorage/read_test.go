@@ -0,0 +1,57 @@
+package filestorage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedURLProvider simula um provider remoto, lido apenas por URL assinada.
+type signedURLProvider struct{ url string }
+
+func (p signedURLProvider) UploadFile(ctx context.Context, organizationID, objectName string, fileContent io.Reader) (string, error) {
+	return objectName, nil
+}
+func (p signedURLProvider) DeleteFile(ctx context.Context, objectName string) error { return nil }
+func (p signedURLProvider) GetSignedURL(ctx context.Context, objectName string, durationMinutes int) (string, error) {
+	return p.url + "/" + objectName, nil
+}
+
+func TestReadObject(t *testing.T) {
+	ctx := context.Background()
+	local := newTestLocalProvider(t)
+	stored, err := local.UploadFile(ctx, "org-1", "audit_evidences/c1/print.png", strings.NewReader("image-bytes"))
+	require.NoError(t, err)
+
+	data, err := ReadObject(ctx, local, stored, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "image-bytes", string(data))
+
+	_, err = ReadObject(ctx, local, stored, 5)
+	assert.ErrorIs(t, err, ErrObjectTooLarge)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org-1/report.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("remote-bytes"))
+	}))
+	defer server.Close()
+
+	data, err = ReadObject(ctx, signedURLProvider{url: server.URL}, "org-1/report.jpg", 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "remote-bytes", string(data))
+
+	_, err = ReadObject(ctx, signedURLProvider{url: server.URL}, "org-1/missing.jpg", 1024)
+	assert.Error(t, err)
+
+	_, err = ReadObject(ctx, nil, stored, 1024)
+	assert.Error(t, err)
+}
@@ -12,17 +12,24 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsGoConfig "github.com/aws/aws-sdk-go-v2/config" // Alias para evitar conflito com pkg/config
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager" // Para S3 Upload Manager
 	// "github.com/aws/smithy-go" // Para error handling mais específico, se necessário
 )
 
-// S3StorageProvider implements FileStorageProvider using Amazon S3.
+// defaultS3CompatibleRegion é usada quando um endpoint customizado (ex: MinIO) é configurado sem região.
+// MinIO aceita qualquer região, mas o SDK exige uma para assinar as requisições.
+const defaultS3CompatibleRegion = "us-east-1"
+
+// S3StorageProvider implements FileStorageProvider using Amazon S3 or any
+// S3-compatible object store (MinIO, Ceph RGW, etc.).
 type S3StorageProvider struct {
 	client     *s3.Client
 	uploader   *manager.Uploader // S3 Upload Manager para multipart uploads
 	bucketName string
 	region     string // Região do bucket S3
+	endpoint   string // Endpoint customizado; vazio para AWS
 }
 
 // InitializeS3Provider initializes the S3 client and configuration.
@@ -30,34 +37,55 @@ type S3StorageProvider struct {
 func InitializeS3Provider() (*S3StorageProvider, error) {
 	bucket := config.Cfg.AWSS3Bucket
 	region := config.Cfg.AWSRegion // Reutiliza a região configurada para SES, mas pode ser específica para S3
+	endpoint := config.Cfg.S3Endpoint
 
 	if bucket == "" {
 		phxlog.L.Warn("AWS_S3_BUCKET not set. File upload to S3 will be disabled.")
 		return nil, nil
 	}
 	if region == "" {
-		phxlog.L.Warn("AWS_REGION (for S3) not set. File upload to S3 will be disabled.")
-		return nil, nil
+		if endpoint == "" {
+			phxlog.L.Warn("AWS_REGION (for S3) not set. File upload to S3 will be disabled.")
+			return nil, nil
+		}
+		region = defaultS3CompatibleRegion
 	}
 
 	// Carregar configuração AWS SDK (usa credenciais do ambiente: variáveis ou IAM role)
-	sdkConfig, err := awsGoConfig.LoadDefaultConfig(context.TODO(), awsGoConfig.WithRegion(region))
+	loadOpts := []func(*awsGoConfig.LoadOptions) error{awsGoConfig.WithRegion(region)}
+	if config.Cfg.S3AccessKeyID != "" && config.Cfg.S3SecretAccessKey != "" {
+		// Credenciais estáticas, típicas de instalações on-prem (MinIO) sem IAM.
+		loadOpts = append(loadOpts, awsGoConfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(config.Cfg.S3AccessKeyID, config.Cfg.S3SecretAccessKey, ""),
+		))
+	}
+	sdkConfig, err := awsGoConfig.LoadDefaultConfig(context.TODO(), loadOpts...)
 	if err != nil {
 		phxlog.L.Error("Failed to load AWS SDK config for S3. Ensure AWS credentials and region are configured.", zap.Error(err))
 		return nil, fmt.Errorf("failed to load AWS SDK config for S3: %w", err)
 	}
 	phxlog.L.Info("AWS SDK config loaded successfully for S3", zap.String("region", region))
 
-	s3Client := s3.NewFromConfig(sdkConfig)
+	s3Client := s3.NewFromConfig(sdkConfig, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = config.Cfg.S3UsePathStyle
+	})
 	uploader := manager.NewUploader(s3Client)
 
-	phxlog.L.Info("Amazon S3 storage provider initialized", zap.String("bucket", bucket), zap.String("region", region))
+	phxlog.L.Info("S3 storage provider initialized",
+		zap.String("bucket", bucket),
+		zap.String("region", region),
+		zap.String("endpoint", endpoint),
+		zap.Bool("path_style", config.Cfg.S3UsePathStyle))
 
 	return &S3StorageProvider{
 		client:     s3Client,
 		uploader:   uploader,
 		bucketName: bucket,
 		region: region,
+		endpoint:   endpoint,
 	}, nil
 }
 
@@ -166,3 +194,9 @@ func (s *S3StorageProvider) GetSignedURL(ctx context.Context, objectName string,
 
 
 // Ensure newline at end of file
+
+// Ping verifica o acesso ao bucket (HeadBucket), sem ler nem gravar objetos.
+func (s *S3StorageProvider) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucketName)})
+	return err
+}
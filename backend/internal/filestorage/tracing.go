@@ -0,0 +1,62 @@
+package filestorage
+
+import (
+	"context"
+	"io"
+
+	"phoenixgrc/backend/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedProvider registra um span para cada operação do provider (ver InitFileStorage com TRACING_ENABLED).
+type tracedProvider struct {
+	inner    FileStorageProvider
+	provider string
+}
+
+// Unwrap retorna o provider concreto, sem a instrumentação de rastreamento, para as verificações de tipo
+// (ex: armazenamento local).
+func Unwrap(provider FileStorageProvider) FileStorageProvider {
+	if traced, ok := provider.(*tracedProvider); ok {
+		return traced.inner
+	}
+	return provider
+}
+
+func (t *tracedProvider) start(ctx context.Context, operation, objectName string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "storage."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("storage.provider", t.provider), attribute.String("storage.object", objectName)))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *tracedProvider) UploadFile(ctx context.Context, organizationID string, objectName string, fileContent io.Reader) (string, error) {
+	ctx, span := t.start(ctx, "upload", objectName)
+	stored, err := t.inner.UploadFile(ctx, organizationID, objectName, fileContent)
+	endSpan(span, err)
+	return stored, err
+}
+
+func (t *tracedProvider) DeleteFile(ctx context.Context, objectName string) error {
+	ctx, span := t.start(ctx, "delete", objectName)
+	err := t.inner.DeleteFile(ctx, objectName)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedProvider) GetSignedURL(ctx context.Context, objectName string, durationMinutes int) (string, error) {
+	ctx, span := t.start(ctx, "signed_url", objectName)
+	url, err := t.inner.GetSignedURL(ctx, objectName, durationMinutes)
+	endSpan(span, err)
+	return url, err
+}
@@ -0,0 +1,127 @@
+// Package frameworkimport converte arquivos de framework (JSON do Phoenix GRC, CSV e catálogo OSCAL)
+// em um framework normalizado, com os controles na ordem do arquivo.
+package frameworkimport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format é o formato do arquivo de framework.
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatCSV   Format = "csv"
+	FormatOSCAL Format = "oscal"
+)
+
+// Control é um controle do framework importado.
+type Control struct {
+	ControlID   string `json:"control_id"`
+	Description string `json:"description"`
+	Family      string `json:"family"`
+}
+
+// Framework é o framework importado; Controls está na ordem oficial (a do arquivo).
+type Framework struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Version     string    `json:"version"`
+	Controls    []Control `json:"controls"`
+}
+
+// Parse lê o arquivo no formato informado e valida os controles (ControlID e descrição obrigatórios, sem duplicatas).
+func Parse(format Format, r io.Reader) (Framework, error) {
+	var framework Framework
+	var err error
+	switch format {
+	case FormatJSON:
+		err = json.NewDecoder(r).Decode(&framework)
+	case FormatCSV:
+		framework.Controls, err = parseCSV(r)
+	case FormatOSCAL:
+		framework, err = parseOSCALCatalog(r)
+	default:
+		return Framework{}, fmt.Errorf("unsupported format %q (expected json, csv or oscal)", format)
+	}
+	if err != nil {
+		return Framework{}, err
+	}
+	return framework, Validate(framework.Controls)
+}
+
+// Validate confere que cada controle tem ControlID e descrição e que não há ControlIDs repetidos.
+func Validate(controls []Control) error {
+	if len(controls) == 0 {
+		return fmt.Errorf("the file has no controls")
+	}
+	var problems []string
+	seen := make(map[string]int, len(controls))
+	for i, ctrl := range controls {
+		position := i + 1
+		id := strings.ToUpper(strings.TrimSpace(ctrl.ControlID))
+		switch {
+		case id == "":
+			problems = append(problems, fmt.Sprintf("control #%d: control_id is required", position))
+		case len(id) > 100:
+			problems = append(problems, fmt.Sprintf("control #%d: control_id exceeds 100 characters", position))
+		case seen[id] > 0:
+			problems = append(problems, fmt.Sprintf("control #%d: duplicate control_id %q (first seen at #%d)", position, ctrl.ControlID, seen[id]))
+		default:
+			seen[id] = position
+		}
+		if strings.TrimSpace(ctrl.Description) == "" {
+			problems = append(problems, fmt.Sprintf("control #%d: description is required", position))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid controls: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// parseCSV lê um CSV com as colunas control_id, description e family (opcional).
+func parseCSV(r io.Reader) ([]Control, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, required := range []string{"control_id", "description"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header must contain %q", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var controls []Control
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		controls = append(controls, Control{
+			ControlID:   field(record, "control_id"),
+			Description: field(record, "description"),
+			Family:      field(record, "family"),
+		})
+	}
+	return controls, nil
+}
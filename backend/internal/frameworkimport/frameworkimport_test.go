@@ -0,0 +1,61 @@
+package frameworkimport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSON(t *testing.T) {
+	input := `{"name": "Política Interna", "version": "1.0", "controls": [
+		{"control_id": "PI-1", "description": "Inventário", "family": "Ativos"},
+		{"control_id": "PI-2", "description": "Backups", "family": "Continuidade"}]}`
+	framework, err := Parse(FormatJSON, strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, "Política Interna", framework.Name)
+	assert.Equal(t, "1.0", framework.Version)
+	require.Len(t, framework.Controls, 2)
+	assert.Equal(t, Control{ControlID: "PI-2", Description: "Backups", Family: "Continuidade"}, framework.Controls[1])
+}
+
+func TestParseCSVKeepsFileOrder(t *testing.T) {
+	input := "\ufeffcontrol_id,description,family\nA.10,Controle dez,A\nA.2,Controle dois,A\n"
+	framework, err := Parse(FormatCSV, strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, framework.Controls, 2)
+	assert.Equal(t, "A.10", framework.Controls[0].ControlID)
+	assert.Equal(t, "A.2", framework.Controls[1].ControlID)
+}
+
+func TestParseRejectsDuplicateAndIncompleteControls(t *testing.T) {
+	input := "control_id,description\nX-1,Um\nx-1,Repetido\n,Sem código\nX-3,\n"
+	_, err := Parse(FormatCSV, strings.NewReader(input))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate control_id "x-1"`)
+	assert.Contains(t, err.Error(), "control #3: control_id is required")
+	assert.Contains(t, err.Error(), "control #4: description is required")
+}
+
+func TestParseOSCALCatalog(t *testing.T) {
+	input := `{"catalog": {"metadata": {"title": "Mini Catalog", "version": "5.1"}, "groups": [
+		{"id": "ac", "title": "Access Control", "controls": [
+			{"id": "ac-1", "title": "Policy and Procedures", "props": [{"name": "label", "value": "AC-1"}],
+			 "parts": [{"name": "statement", "parts": [{"name": "item", "prose": "Develop an access control policy."}]}],
+			 "controls": [{"id": "ac-1.1", "title": "Automated Management"}]}]},
+		{"id": "au", "title": "Audit", "groups": [{"id": "au-x", "title": "Audit Review", "controls": [{"id": "au-6", "title": "Audit Record Review"}]}]}]}}`
+	framework, err := Parse(FormatOSCAL, strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, "Mini Catalog", framework.Name)
+	assert.Equal(t, "5.1", framework.Version)
+	require.Len(t, framework.Controls, 3)
+	assert.Equal(t, Control{ControlID: "AC-1", Description: "Policy and Procedures: Develop an access control policy.", Family: "Access Control"}, framework.Controls[0])
+	assert.Equal(t, Control{ControlID: "AC-1.1", Description: "Automated Management", Family: "Access Control"}, framework.Controls[1])
+	assert.Equal(t, Control{ControlID: "AU-6", Description: "Audit Record Review", Family: "Audit Review"}, framework.Controls[2])
+}
+
+func TestParseOSCALRejectsOtherModels(t *testing.T) {
+	_, err := Parse(FormatOSCAL, strings.NewReader(`{"assessment-results": {}}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package frameworkimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"phoenixgrc/backend/internal/oscal"
+)
+
+// parseOSCALCatalog converte um catálogo OSCAL (JSON): cada grupo vira uma família e cada controle
+// (incluindo melhorias) vira um controle, identificado pelo rótulo ("label") ou, sem ele, pelo id.
+func parseOSCALCatalog(r io.Reader) (Framework, error) {
+	var doc oscal.CatalogDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return Framework{}, fmt.Errorf("failed to parse OSCAL JSON: %w", err)
+	}
+	if doc.Catalog == nil {
+		return Framework{}, fmt.Errorf("the file is not an OSCAL catalog (missing 'catalog')")
+	}
+	framework := Framework{
+		Name:        doc.Catalog.Metadata.Title,
+		Description: doc.Catalog.Metadata.Remarks,
+		Version:     doc.Catalog.Metadata.Version,
+	}
+	appendOSCALControls(&framework.Controls, doc.Catalog.Controls, "")
+	for _, group := range doc.Catalog.Groups {
+		appendOSCALGroup(&framework.Controls, group)
+	}
+	return framework, nil
+}
+
+func appendOSCALGroup(controls *[]Control, group oscal.Group) {
+	appendOSCALControls(controls, group.Controls, group.Title)
+	for _, sub := range group.Groups {
+		appendOSCALGroup(controls, sub)
+	}
+}
+
+func appendOSCALControls(controls *[]Control, oscalControls []oscal.Control, family string) {
+	for _, oc := range oscalControls {
+		*controls = append(*controls, Control{
+			ControlID:   oscalControlLabel(oc),
+			Description: oscalControlDescription(oc),
+			Family:      family,
+		})
+		appendOSCALControls(controls, oc.Controls, family)
+	}
+}
+
+func oscalControlLabel(oc oscal.Control) string {
+	for _, prop := range oc.Props {
+		if prop.Name == "label" && strings.TrimSpace(prop.Value) != "" {
+			return strings.TrimSpace(prop.Value)
+		}
+	}
+	return strings.ToUpper(oc.ID)
+}
+
+// oscalControlDescription é o título do controle seguido do texto do enunciado ("statement"), quando houver.
+func oscalControlDescription(oc oscal.Control) string {
+	var statement []string
+	for _, part := range oc.Parts {
+		if part.Name == "statement" {
+			collectOSCALProse(part, &statement)
+		}
+	}
+	if len(statement) == 0 {
+		return oc.Title
+	}
+	if oc.Title == "" {
+		return strings.Join(statement, "\n")
+	}
+	return oc.Title + ": " + strings.Join(statement, "\n")
+}
+
+func collectOSCALProse(part oscal.Part, prose *[]string) {
+	if text := strings.TrimSpace(part.Prose); text != "" {
+		*prose = append(*prose, text)
+	}
+	for _, sub := range part.Parts {
+		collectOSCALProse(sub, prose)
+	}
+}
@@ -0,0 +1,649 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AccessReviewCampaignPayload defines the structure for creating an access review campaign.
+type AccessReviewCampaignPayload struct {
+	Name                string `json:"name" binding:"required,min=3,max=255"`
+	Description         string `json:"description"`
+	StartsAt            string `json:"starts_at"`                   // YYYY-MM-DD, default hoje
+	DueDate             string `json:"due_date" binding:"required"` // YYYY-MM-DD
+	IncludePhoenixUsers *bool  `json:"include_phoenix_users"`       // default true
+	DefaultReviewerID   string `json:"default_reviewer_id"`
+}
+
+// AssignAccessReviewersPayload defines the structure for assigning a reviewer to campaign items.
+type AssignAccessReviewersPayload struct {
+	ItemIDs    []string `json:"item_ids" binding:"required,min=1"`
+	ReviewerID string   `json:"reviewer_id" binding:"required"`
+}
+
+// AccessReviewDecisionPayload defines the structure for recording a keep/revoke decision.
+type AccessReviewDecisionPayload struct {
+	Decision      models.AccessReviewDecision `json:"decision" binding:"required,oneof=keep revoke"`
+	Justification string                      `json:"justification" binding:"required,min=3"`
+}
+
+// AttachAccessReviewEvidencePayload defines the audit control that receives the campaign export as evidence.
+type AttachAccessReviewEvidencePayload struct {
+	AuditControlID string `json:"audit_control_id" binding:"required"`
+}
+
+// findOrgAccessReviewCampaign carrega a campanha garantindo que pertence à organização do token.
+// Em caso de erro, já escreve a resposta e retorna false.
+func findOrgAccessReviewCampaign(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, preloadItems bool) (*models.AccessReviewCampaign, bool) {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID format"})
+		return nil, false
+	}
+	query := db
+	if preloadItems {
+		query = query.Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("source_system asc, account_identifier asc")
+		})
+	}
+	var campaign models.AccessReviewCampaign
+	if err := query.Where("id = ? AND organization_id = ?", campaignID, organizationID).First(&campaign).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Access review campaign not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch access review campaign: " + err.Error()})
+		return nil, false
+	}
+	return &campaign, true
+}
+
+// accessReviewCampaignIsActive indica se a campanha ainda aceita alterações (aberta e dentro do prazo).
+func accessReviewCampaignIsActive(campaign *models.AccessReviewCampaign) bool {
+	return campaign.Status == models.AccessReviewStatusOpen && !time.Now().After(campaign.DueDate)
+}
+
+// CreateAccessReviewCampaignHandler creates a campaign and snapshots the organization's users and roles.
+func CreateAccessReviewCampaignHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	if !checkOrgPermission(c, organizationID, rbac.AccessReviewsManage) {
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	var payload AccessReviewCampaignPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	startsAt := time.Now()
+	if payload.StartsAt != "" {
+		parsed, err := time.Parse("2006-01-02", payload.StartsAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid starts_at format, use YYYY-MM-DD"})
+			return
+		}
+		startsAt = parsed
+	}
+	dueDate, err := time.Parse("2006-01-02", payload.DueDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_date format, use YYYY-MM-DD"})
+		return
+	}
+	// O prazo vale até o fim do dia informado.
+	dueDate = dueDate.Add(24*time.Hour - time.Second)
+	if !dueDate.After(startsAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "due_date must be after starts_at"})
+		return
+	}
+
+	db := database.GetDB()
+
+	var defaultReviewerID *uuid.UUID
+	if payload.DefaultReviewerID != "" {
+		reviewerID, err := uuid.Parse(payload.DefaultReviewerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid default_reviewer_id format"})
+			return
+		}
+		var count int64
+		db.Model(&models.User{}).Where("id = ? AND organization_id = ?", reviewerID, organizationID).Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Default reviewer not found in your organization"})
+			return
+		}
+		defaultReviewerID = &reviewerID
+	}
+
+	campaign := models.AccessReviewCampaign{
+		OrganizationID: organizationID,
+		Name:           payload.Name,
+		Description:    payload.Description,
+		Status:         models.AccessReviewStatusOpen,
+		StartsAt:       startsAt,
+		DueDate:        dueDate,
+		CreatedByID:    userID.(uuid.UUID),
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&campaign).Error; err != nil {
+			return err
+		}
+		if payload.IncludePhoenixUsers != nil && !*payload.IncludePhoenixUsers {
+			return nil
+		}
+		var users []models.User
+		if err := tx.Where("organization_id = ? AND is_active = ?", organizationID, true).Order("email asc").Find(&users).Error; err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return nil
+		}
+		items := make([]models.AccessReviewItem, 0, len(users))
+		for _, u := range users {
+			uid := u.ID
+			items = append(items, models.AccessReviewItem{
+				CampaignID:        campaign.ID,
+				SourceSystem:      models.AccessReviewSourcePhoenix,
+				UserID:            &uid,
+				AccountIdentifier: u.Email,
+				AccountName:       u.Name,
+				Entitlement:       string(u.Role),
+				ReviewerID:        defaultReviewerID,
+				Decision:          models.AccessReviewDecisionPending,
+			})
+		}
+		return tx.Create(&items).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create access review campaign: " + err.Error()})
+		return
+	}
+
+	var created models.AccessReviewCampaign
+	db.Preload("Items").First(&created, campaign.ID)
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListAccessReviewCampaignsHandler lists the organization's access review campaigns with pagination.
+func ListAccessReviewCampaignsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	page, pageSize := GetPaginationParams(c)
+	db := database.GetDB()
+
+	query := db.Model(&models.AccessReviewCampaign{}).Where("organization_id = ?", organizationID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count access review campaigns: " + err.Error()})
+		return
+	}
+	var campaigns []models.AccessReviewCampaign
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at desc").Find(&campaigns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list access review campaigns: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      campaigns,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// GetAccessReviewCampaignHandler returns a campaign with its items and decision progress.
+func GetAccessReviewCampaignHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	campaign, ok := findOrgAccessReviewCampaign(c, db, orgID.(uuid.UUID), true)
+	if !ok {
+		return
+	}
+
+	progress := map[models.AccessReviewDecision]int{
+		models.AccessReviewDecisionPending: 0,
+		models.AccessReviewDecisionKeep:    0,
+		models.AccessReviewDecisionRevoke:  0,
+	}
+	for _, item := range campaign.Items {
+		progress[item.Decision]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign":  campaign,
+		"progress":  progress,
+		"is_active": accessReviewCampaignIsActive(campaign),
+	})
+}
+
+// ImportAccessReviewItemsCSVHandler adds accounts from other systems to a campaign.
+// CSV headers: source_system, account_identifier (obrigatórios), account_name, entitlement, reviewer_email (opcionais).
+func ImportAccessReviewItemsCSVHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	if !checkOrgPermission(c, organizationID, rbac.AccessReviewsManage) {
+		return
+	}
+	db := database.GetDB()
+	campaign, ok := findOrgAccessReviewCampaign(c, db, organizationID, false)
+	if !ok {
+		return
+	}
+	if !accessReviewCampaignIsActive(campaign) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Access review campaign is closed or past its due date"})
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV file"})
+		return
+	}
+	if len(records) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file must have a header and at least one data row"})
+		return
+	}
+
+	headerMap := make(map[string]int)
+	for i, h := range records[0] {
+		headerMap[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, rh := range []string{"source_system", "account_identifier"} {
+		if _, ok := headerMap[rh]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Missing required CSV header: %s", rh)})
+			return
+		}
+	}
+
+	reviewerCache := make(map[string]*uuid.UUID)
+	var items []models.AccessReviewItem
+	var importErrors []string
+	for i, record := range records[1:] {
+		lineNumber := i + 2
+		sourceSystem := strings.TrimSpace(getCSVField(record, headerMap, "source_system"))
+		accountIdentifier := strings.TrimSpace(getCSVField(record, headerMap, "account_identifier"))
+		if sourceSystem == "" || accountIdentifier == "" {
+			importErrors = append(importErrors, fmt.Sprintf("line %d: source_system and account_identifier are required", lineNumber))
+			continue
+		}
+		item := models.AccessReviewItem{
+			CampaignID:        campaign.ID,
+			SourceSystem:      sourceSystem,
+			AccountIdentifier: accountIdentifier,
+			AccountName:       strings.TrimSpace(getCSVField(record, headerMap, "account_name")),
+			Entitlement:       strings.TrimSpace(getCSVField(record, headerMap, "entitlement")),
+			Decision:          models.AccessReviewDecisionPending,
+		}
+		if reviewerEmail := strings.ToLower(strings.TrimSpace(getCSVField(record, headerMap, "reviewer_email"))); reviewerEmail != "" {
+			reviewerID, cached := reviewerCache[reviewerEmail]
+			if !cached {
+				var reviewer models.User
+				if err := db.Where("email = ? AND organization_id = ?", reviewerEmail, organizationID).First(&reviewer).Error; err == nil {
+					reviewerID = &reviewer.ID
+				}
+				reviewerCache[reviewerEmail] = reviewerID
+			}
+			if reviewerID == nil {
+				importErrors = append(importErrors, fmt.Sprintf("line %d: reviewer '%s' not found in your organization", lineNumber, reviewerEmail))
+				continue
+			}
+			item.ReviewerID = reviewerID
+		}
+		items = append(items, item)
+	}
+
+	if len(items) > 0 {
+		if err := db.Create(&items).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import access review items: " + err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Access review items imported",
+		"imported_count": len(items),
+		"errors":         importErrors,
+	})
+}
+
+// AssignAccessReviewersHandler assigns a reviewer to a set of campaign items.
+func AssignAccessReviewersHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	if !checkOrgPermission(c, organizationID, rbac.AccessReviewsManage) {
+		return
+	}
+	var payload AssignAccessReviewersPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	reviewerID, err := uuid.Parse(payload.ReviewerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reviewer_id format"})
+		return
+	}
+	itemIDs := make([]uuid.UUID, 0, len(payload.ItemIDs))
+	for _, idStr := range payload.ItemIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID format: " + idStr})
+			return
+		}
+		itemIDs = append(itemIDs, id)
+	}
+
+	db := database.GetDB()
+	campaign, ok := findOrgAccessReviewCampaign(c, db, organizationID, false)
+	if !ok {
+		return
+	}
+	if !accessReviewCampaignIsActive(campaign) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Access review campaign is closed or past its due date"})
+		return
+	}
+
+	var reviewerCount int64
+	db.Model(&models.User{}).Where("id = ? AND organization_id = ? AND is_active = ?", reviewerID, organizationID, true).Count(&reviewerCount)
+	if reviewerCount == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reviewer not found in your organization"})
+		return
+	}
+
+	result := db.Model(&models.AccessReviewItem{}).
+		Where("campaign_id = ? AND id IN ?", campaign.ID, itemIDs).
+		Update("reviewer_id", reviewerID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign reviewer: " + result.Error.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Reviewer assigned", "updated_count": result.RowsAffected})
+}
+
+// DecideAccessReviewItemHandler records a keep/revoke decision with justification.
+// Permitido ao revisor atribuído ou a quem tem access_reviews:manage, somente enquanto a campanha estiver ativa.
+func DecideAccessReviewItemHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	currentUserID := userID.(uuid.UUID)
+
+	itemID, err := uuid.Parse(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID format"})
+		return
+	}
+	var payload AccessReviewDecisionPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	db := database.GetDB()
+	campaign, ok := findOrgAccessReviewCampaign(c, db, organizationID, false)
+	if !ok {
+		return
+	}
+	if !accessReviewCampaignIsActive(campaign) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Access review campaign is closed or past its due date"})
+		return
+	}
+
+	var item models.AccessReviewItem
+	if err := db.Where("id = ? AND campaign_id = ?", itemID, campaign.ID).First(&item).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Access review item not found in this campaign"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch access review item: " + err.Error()})
+		return
+	}
+
+	isReviewer := item.ReviewerID != nil && *item.ReviewerID == currentUserID
+	if !isReviewer && !rbac.Can(c, rbac.AccessReviewsManage) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not the assigned reviewer for this item"})
+		return
+	}
+
+	now := time.Now()
+	item.Decision = payload.Decision
+	item.Justification = payload.Justification
+	item.DecidedByID = &currentUserID
+	item.DecidedAt = &now
+	if err := db.Save(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record decision: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// CloseAccessReviewCampaignHandler closes a campaign, freezing its decisions.
+func CloseAccessReviewCampaignHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	if !checkOrgPermission(c, organizationID, rbac.AccessReviewsManage) {
+		return
+	}
+	db := database.GetDB()
+	campaign, ok := findOrgAccessReviewCampaign(c, db, organizationID, false)
+	if !ok {
+		return
+	}
+	if campaign.Status == models.AccessReviewStatusClosed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Access review campaign is already closed"})
+		return
+	}
+
+	var pendingCount int64
+	db.Model(&models.AccessReviewItem{}).Where("campaign_id = ? AND decision = ?", campaign.ID, models.AccessReviewDecisionPending).Count(&pendingCount)
+
+	now := time.Now()
+	campaign.Status = models.AccessReviewStatusClosed
+	campaign.ClosedAt = &now
+	if err := db.Save(campaign).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to close access review campaign: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"campaign": campaign, "pending_items": pendingCount})
+}
+
+// writeAccessReviewCSV escreve a campanha em CSV no formato usado como evidência de auditoria.
+func writeAccessReviewCSV(w io.Writer, db *gorm.DB, campaign *models.AccessReviewCampaign) error {
+	// Resolver nomes de revisores/decisores para tornar a evidência legível sem o sistema.
+	userIDs := make(map[uuid.UUID]struct{})
+	for _, item := range campaign.Items {
+		if item.ReviewerID != nil {
+			userIDs[*item.ReviewerID] = struct{}{}
+		}
+		if item.DecidedByID != nil {
+			userIDs[*item.DecidedByID] = struct{}{}
+		}
+	}
+	userEmails := make(map[uuid.UUID]string)
+	if len(userIDs) > 0 {
+		ids := make([]uuid.UUID, 0, len(userIDs))
+		for id := range userIDs {
+			ids = append(ids, id)
+		}
+		var users []models.User
+		if err := db.Select("id", "email").Where("id IN ?", ids).Find(&users).Error; err != nil {
+			return err
+		}
+		for _, u := range users {
+			userEmails[u.ID] = u.Email
+		}
+	}
+	emailOf := func(id *uuid.UUID) string {
+		if id == nil {
+			return ""
+		}
+		return userEmails[*id]
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	writer := csv.NewWriter(w)
+	header := []string{"campaign", "campaign_status", "due_date", "source_system", "account_identifier", "account_name",
+		"entitlement", "reviewer", "decision", "justification", "decided_by", "decided_at"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, item := range campaign.Items {
+		if err := writer.Write([]string{
+			campaign.Name,
+			string(campaign.Status),
+			campaign.DueDate.UTC().Format("2006-01-02"),
+			item.SourceSystem,
+			item.AccountIdentifier,
+			item.AccountName,
+			item.Entitlement,
+			emailOf(item.ReviewerID),
+			string(item.Decision),
+			item.Justification,
+			emailOf(item.DecidedByID),
+			formatTime(item.DecidedAt),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportAccessReviewCampaignHandler exports the campaign as CSV (default) or JSON.
+// Query param: ?format=csv|json
+func ExportAccessReviewCampaignHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	campaign, ok := findOrgAccessReviewCampaign(c, db, orgID.(uuid.UUID), true)
+	if !ok {
+		return
+	}
+
+	if strings.ToLower(c.DefaultQuery("format", "csv")) == "json" {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"access_review_%s.json\"", campaign.ID))
+		c.JSON(http.StatusOK, campaign)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeAccessReviewCSV(&buf, db, campaign); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export access review campaign: " + err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"access_review_%s.csv\"", campaign.ID))
+	c.Data(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+// AttachAccessReviewEvidenceHandler uploads the campaign CSV export and links it as evidence
+// of the organization's assessment for the given audit control (ex: revisão periódica de acessos).
+func AttachAccessReviewEvidenceHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	if !checkOrgPermission(c, organizationID, rbac.AccessReviewsManage) {
+		return
+	}
+	var payload AttachAccessReviewEvidencePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	auditControlID, err := uuid.Parse(payload.AuditControlID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit_control_id format"})
+		return
+	}
+
+	db := database.GetDB()
+	campaign, ok := findOrgAccessReviewCampaign(c, db, organizationID, true)
+	if !ok {
+		return
+	}
+	var control models.AuditControl
+	if err := db.First(&control, "id = ?", auditControlID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audit control not found"})
+		return
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeAccessReviewCSV(&buf, db, campaign); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export access review campaign: " + err.Error()})
+		return
+	}
+
+	objectPath := fmt.Sprintf("%s/audit_evidences/%s/%s_access_review_%s.csv",
+		organizationID.String(), auditControlID.String(), uuid.New().String(), campaign.ID.String())
+	objectName, err := filestorage.DefaultFileStorageProvider.UploadFile(c.Request.Context(), organizationID.String(), objectPath, &buf)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload evidence file: " + err.Error()})
+		return
+	}
+
+	now := time.Now()
+	assessment := models.AuditAssessment{
+		OrganizationID: organizationID,
+		AuditControlID: auditControlID,
+		EvidenceURL:    objectName,
+		AssessmentDate: &now,
+	}
+	tally, err := compliancescore.RecordAssessmentWrite(db, organizationID, auditControlID, func(tx *gorm.DB) error {
+		return tx.Clauses(models.OrgWideAssessmentConflict(
+			clause.AssignmentColumns([]string{"evidence_url", "updated_at"}),
+		)).Create(&assessment).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link evidence to assessment: " + err.Error()})
+		return
+	}
+	publishComplianceScore(organizationID, tally)
+
+	phxlog.L.Info("Access review campaign attached as audit evidence",
+		zap.String("campaignID", campaign.ID.String()),
+		zap.String("auditControlID", auditControlID.String()),
+		zap.String("objectName", objectName))
+	c.JSON(http.StatusOK, gin.H{"message": "Access review evidence attached", "evidence_url": objectName})
+}
@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/utils"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// errAdminActionTargetGone indica que o alvo da ação foi removido antes da aprovação.
+var errAdminActionTargetGone = errors.New("the target of this action no longer exists")
+
+// adminActionDefinition descreve uma ação sujeita à regra das duas pessoas: o tipo de entidade registrado
+// na trilha de auditoria e a execução, feita na transação da aprovação.
+type adminActionDefinition struct {
+	entityType  string
+	description string // Usada nos e-mails, ex: "excluir o framework"
+	execute     func(tx *gorm.DB, req *models.AdminActionRequest) error
+}
+
+var adminActions = map[models.AdminActionType]adminActionDefinition{
+	models.AdminActionFrameworkDelete: {
+		entityType:  "audit_framework",
+		description: "excluir o framework (com avaliações)",
+		execute: func(tx *gorm.DB, req *models.AdminActionRequest) error {
+			var count int64
+			if err := tx.Model(&models.AuditFramework{}).Where("id = ? AND organization_id = ?", req.TargetID, req.OrganizationID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return errAdminActionTargetGone
+			}
+			return deleteFramework(tx, req.TargetID)
+		},
+	},
+	models.AdminActionOrgSecretRotate: {
+		entityType:  "org_secret",
+		description: "rotacionar o segredo",
+		execute: func(tx *gorm.DB, req *models.AdminActionRequest) error {
+			var secret models.OrgSecret
+			if err := tx.Where("id = ? AND organization_id = ?", req.TargetID, req.OrganizationID).First(&secret).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return errAdminActionTargetGone
+				}
+				return err
+			}
+			if req.Payload == nil {
+				return errors.New("the new secret value is missing")
+			}
+			value, err := utils.Decrypt(*req.Payload)
+			if err != nil {
+				return err
+			}
+			if err := secret.SetValue(value); err != nil {
+				return err
+			}
+			return tx.Save(&secret).Error
+		},
+	},
+	models.AdminActionOrgSecretDelete: {
+		entityType:  "org_secret",
+		description: "excluir o segredo",
+		execute: func(tx *gorm.DB, req *models.AdminActionRequest) error {
+			result := tx.Where("id = ? AND organization_id = ?", req.TargetID, req.OrganizationID).Delete(&models.OrgSecret{})
+			if result.Error == nil && result.RowsAffected == 0 {
+				return errAdminActionTargetGone
+			}
+			return result.Error
+		},
+	},
+	models.AdminActionOrgSecretBulkRevoke: {
+		entityType:  "org_secret",
+		description: "revogar em lote",
+		execute:     revokeOrgSecrets,
+	},
+}
+
+// AdminActionRequestResponse é uma solicitação de ação administrativa com o workflow de aprovação.
+type AdminActionRequestResponse struct {
+	models.AdminActionRequest
+	ApprovalWorkflowID *uuid.UUID `json:"approval_workflow_id,omitempty"`
+}
+
+func newAdminActionRequestResponse(db *gorm.DB, req models.AdminActionRequest) AdminActionRequestResponse {
+	resp := AdminActionRequestResponse{AdminActionRequest: req}
+	var workflow models.ApprovalWorkflow
+	if err := db.Select("id").Where("admin_action_request_id = ?", req.ID).Take(&workflow).Error; err == nil {
+		resp.ApprovalWorkflowID = &workflow.ID
+	}
+	return resp
+}
+
+// requestSecondAdminApproval registra a ação destrutiva como pendente da aprovação de um segundo
+// administrador (?approver_id=) e responde 202 com a solicitação. payload guarda os dados da execução.
+// Sempre escreve a resposta: o handler que chamou deve apenas retornar.
+func requestSecondAdminApproval(c *gin.Context, db *gorm.DB, action models.AdminActionType, targetID uuid.UUID, targetLabel string, payload *string) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	requesterID := userID.(uuid.UUID)
+
+	approverIDStr := c.Query("approver_id")
+	if approverIDStr == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{
+			"error":  "This action requires a second admin's approval: provide approver_id",
+			"action": action,
+		})
+		return
+	}
+	approverID, err := uuid.Parse(approverIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approver_id format"})
+		return
+	}
+	if approverID == requesterID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The approver must be a different admin than the requester"})
+		return
+	}
+	var approver models.User
+	if err := db.Where("id = ? AND organization_id = ? AND is_active = ?", approverID, organizationID, true).
+		First(&approver).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The approver must be an active admin of the organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch approver: " + err.Error()})
+		return
+	}
+	canApprove, err := rbac.UserCan(db, approver, rbac.AdminActionsApprove)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check approver permissions: " + err.Error()})
+		return
+	}
+	if !canApprove {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The approver must be an active admin of the organization"})
+		return
+	}
+
+	var pending models.AdminActionRequest
+	err = db.Where("organization_id = ? AND action = ? AND target_id = ? AND status = ?", organizationID, action, targetID, models.AdminActionPending).
+		First(&pending).Error
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "An approval request for this action is already pending",
+			"request": newAdminActionRequestResponse(db, pending),
+		})
+		return
+	}
+	if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check pending approval requests: " + err.Error()})
+		return
+	}
+
+	definition := adminActions[action]
+	req := models.AdminActionRequest{
+		OrganizationID: organizationID,
+		Action:         action,
+		TargetID:       targetID,
+		TargetLabel:    targetLabel,
+		Payload:        payload,
+		Status:         models.AdminActionPending,
+		RequestedByID:  requesterID,
+		ApproverID:     approverID,
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&req).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.ApprovalWorkflow{
+			OrganizationID:       organizationID,
+			AdminActionRequestID: &req.ID,
+			RequesterID:          requesterID,
+			ApproverID:           approverID,
+			Status:               models.ApprovalPending,
+		}).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, organizationID, models.AuditTrailAdminActionRequested, definition.entityType, &targetID,
+			fmt.Sprintf("Requested %s on %q, pending approval", action, targetLabel), gin.H{"request_id": req.ID, "approver_id": approverID})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create approval request: " + err.Error()})
+		return
+	}
+
+	subject := fmt.Sprintf("Aprovação Necessária: %s", targetLabel)
+	body := fmt.Sprintf("Olá %s,\n\nUm administrador solicitou %s \"%s\". Pela regra das duas pessoas, a ação só será executada após a sua aprovação.\n\nAcesse o Phoenix GRC para aprovar ou rejeitar a solicitação.",
+		approver.Name, definition.description, targetLabel)
+	notifications.NotifyUserByEmail(c.Request.Context(), approverID, subject, body)
+
+	c.JSON(http.StatusAccepted, newAdminActionRequestResponse(db, req))
+}
+
+// findOrgAdminActionRequest carrega a solicitação de :requestId na organização do usuário.
+func findOrgAdminActionRequest(c *gin.Context, db *gorm.DB) (*models.AdminActionRequest, bool) {
+	requestID, err := uuid.Parse(c.Param("requestId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var req models.AdminActionRequest
+	if err := db.Where("id = ? AND organization_id = ?", requestID, orgID).First(&req).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Admin action request not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch admin action request: " + err.Error()})
+		return nil, false
+	}
+	return &req, true
+}
+
+// ListAdminActionRequestsHandler lists the organization's admin action requests (admin_actions:view), optionally by ?status=.
+func ListAdminActionRequestsHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AdminActionsView) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	page, pageSize := GetPaginationParams(c)
+	db := database.GetDB()
+	query := db.Model(&models.AdminActionRequest{}).Where("organization_id = ?", orgID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count admin action requests: " + err.Error()})
+		return
+	}
+	requests := []models.AdminActionRequest{}
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at desc").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list admin action requests: " + err.Error()})
+		return
+	}
+	items := make([]AdminActionRequestResponse, 0, len(requests))
+	for _, req := range requests {
+		items = append(items, newAdminActionRequestResponse(db, req))
+	}
+	totalPages := total / int64(pageSize)
+	if total%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: items, TotalItems: total, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
+
+// GetAdminActionRequestHandler returns one admin action request (admin_actions:view).
+func GetAdminActionRequestHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AdminActionsView) {
+		return
+	}
+	db := database.GetDB()
+	req, ok := findOrgAdminActionRequest(c, db)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, newAdminActionRequestResponse(db, *req))
+}
+
+// DecideAdminActionRequestHandler approves or rejects a pending admin action request. Only the designated
+// approver (an admin other than the requester) can decide; an approved action is executed immediately.
+func DecideAdminActionRequestHandler(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	req, ok := findOrgAdminActionRequest(c, db)
+	if !ok {
+		return
+	}
+	var payload DecisionPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	deciderID := userID.(uuid.UUID)
+	if req.ApproverID != deciderID || req.RequestedByID == deciderID || !rbac.Can(c, rbac.AdminActionsApprove) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the designated approver, an admin other than the requester, can decide this request"})
+		return
+	}
+	if req.Status != models.AdminActionPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "This request has already been decided"})
+		return
+	}
+	definition, known := adminActions[req.Action]
+	if !known {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This action can no longer be executed"})
+		return
+	}
+
+	now := time.Now()
+	status := models.AdminActionRejected
+	auditAction := models.AuditTrailAdminActionRejected
+	if payload.Decision == models.ApprovalApproved {
+		status = models.AdminActionExecuted
+		auditAction = models.AuditTrailAdminActionExecuted
+	}
+	// decide marca a solicitação (se ainda pendente) e o workflow com a decisão
+	decide := func(tx *gorm.DB, status models.AdminActionStatus, failureReason string) error {
+		updates := map[string]interface{}{"status": status, "decided_at": now, "failure_reason": failureReason}
+		if status == models.AdminActionExecuted {
+			updates["executed_at"] = now
+		}
+		result := tx.Model(&models.AdminActionRequest{}).Where("id = ? AND status = ?", req.ID, models.AdminActionPending).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errAlreadyDecided
+		}
+		return tx.Model(&models.ApprovalWorkflow{}).Where("admin_action_request_id = ?", req.ID).
+			Updates(map[string]interface{}{"status": payload.Decision, "comments": payload.Comments}).Error
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := decide(tx, status, ""); err != nil {
+			return err
+		}
+		if status == models.AdminActionExecuted {
+			if err := definition.execute(tx, req); err != nil {
+				return &adminActionExecutionError{err: err}
+			}
+		}
+		_, err := recordAuditTrail(c, tx, req.OrganizationID, auditAction, definition.entityType, &req.TargetID,
+			fmt.Sprintf("%s %s on %q", status, req.Action, req.TargetLabel), gin.H{"request_id": req.ID, "requested_by_id": req.RequestedByID, "comments": payload.Comments})
+		return err
+	})
+
+	var execErr *adminActionExecutionError
+	switch {
+	case errors.Is(err, errAlreadyDecided):
+		c.JSON(http.StatusConflict, gin.H{"error": "This request has already been decided"})
+		return
+	case errors.As(err, &execErr):
+		// A aprovação fica registrada como falha; a ação não foi executada
+		status = models.AdminActionFailed
+		failErr := db.Transaction(func(tx *gorm.DB) error {
+			if err := decide(tx, status, execErr.err.Error()); err != nil {
+				return err
+			}
+			_, err := recordAuditTrail(c, tx, req.OrganizationID, models.AuditTrailAdminActionFailed, definition.entityType, &req.TargetID,
+				fmt.Sprintf("Approved %s on %q failed", req.Action, req.TargetLabel), gin.H{"request_id": req.ID, "error": execErr.err.Error()})
+			return err
+		})
+		if failErr != nil {
+			phxlog.L.Error("Failed to record admin action failure", zap.String("requestID", req.ID.String()), zap.Error(failErr))
+		}
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decide admin action request: " + err.Error()})
+		return
+	}
+
+	db.First(req, "id = ?", req.ID)
+	subject := fmt.Sprintf("Solicitação %s: %s", req.Status, req.TargetLabel)
+	body := fmt.Sprintf("A solicitação para %s \"%s\" foi decidida: %s.\n\nComentários: %s\n\nAcesse o Phoenix GRC para mais detalhes.",
+		definition.description, req.TargetLabel, req.Status, payload.Comments)
+	notifications.NotifyUserByEmail(c.Request.Context(), req.RequestedByID, subject, body)
+
+	if execErr != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(execErr.err, errAdminActionTargetGone) {
+			code = http.StatusConflict
+		}
+		c.JSON(code, gin.H{"error": "The action was approved but failed: " + execErr.err.Error(), "request": newAdminActionRequestResponse(db, *req)})
+		return
+	}
+	c.JSON(http.StatusOK, newAdminActionRequestResponse(db, *req))
+}
+
+// CancelAdminActionRequestHandler cancels a pending admin action request; only the requester can cancel.
+func CancelAdminActionRequestHandler(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	req, ok := findOrgAdminActionRequest(c, db)
+	if !ok {
+		return
+	}
+	if req.RequestedByID != userID.(uuid.UUID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the requester can cancel this request"})
+		return
+	}
+	now := time.Now()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AdminActionRequest{}).Where("id = ? AND status = ?", req.ID, models.AdminActionPending).
+			Updates(map[string]interface{}{"status": models.AdminActionCancelled, "decided_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errAlreadyDecided
+		}
+		if err := tx.Model(&models.ApprovalWorkflow{}).Where("admin_action_request_id = ?", req.ID).
+			Updates(map[string]interface{}{"status": models.ApprovalRejected, "comments": "Cancelada pelo solicitante"}).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, req.OrganizationID, models.AuditTrailAdminActionCancelled, adminActions[req.Action].entityType, &req.TargetID,
+			fmt.Sprintf("Cancelled %s on %q", req.Action, req.TargetLabel), gin.H{"request_id": req.ID})
+		return err
+	})
+	if errors.Is(err, errAlreadyDecided) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This request has already been decided"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel admin action request: " + err.Error()})
+		return
+	}
+	db.First(req, "id = ?", req.ID)
+	c.JSON(http.StatusOK, newAdminActionRequestResponse(db, *req))
+}
+
+// errAlreadyDecided indica que a solicitação deixou de estar pendente (decisão concorrente).
+var errAlreadyDecided = errors.New("admin action request already decided")
+
+// adminActionExecutionError é a falha na execução de uma ação aprovada.
+type adminActionExecutionError struct {
+	err error
+}
+
+func (e *adminActionExecutionError) Error() string {
+	return e.err.Error()
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultAPIKeyExpiryDays é a validade de uma chave de API criada sem expires_in_days.
+	defaultAPIKeyExpiryDays = 90
+	// maxActiveAPIKeysPerUser limita as chaves ativas de cada usuário.
+	maxActiveAPIKeysPerUser = 25
+)
+
+// APIKeyPayload cria uma chave de API para o usuário autenticado.
+type APIKeyPayload struct {
+	Name          string               `json:"name" binding:"required,min=2,max=100"`
+	Scopes        []models.APIKeyScope `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int                  `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+}
+
+// APIKeyView é a chave de API com os escopos decodificados (o segredo nunca é retornado).
+type APIKeyView struct {
+	models.APIKey
+	Scopes []models.APIKeyScope `json:"scopes"`
+	Active bool                 `json:"active"`
+}
+
+// CreatedAPIKeyResponse inclui o token da chave, exibido apenas na criação.
+type CreatedAPIKeyResponse struct {
+	APIKeyView
+	Token string `json:"token"`
+}
+
+func newAPIKeyView(key models.APIKey) APIKeyView {
+	return APIKeyView{APIKey: key, Scopes: key.ScopeList(), Active: key.Active(time.Now())}
+}
+
+// ListAPIKeyScopesHandler lists the scopes that can be granted to API keys.
+func ListAPIKeyScopesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"scopes": models.APIKeyScopes})
+}
+
+// CreateAPIKeyHandler creates an API key (personal access token) for the authenticated user. The key acts as
+// the user, with the user's current role, only on the routes of its scopes. The token is returned only once.
+func CreateAPIKeyHandler(c *gin.Context) {
+	var payload APIKeyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	scopes := []models.APIKeyScope{}
+	seen := map[models.APIKeyScope]bool{}
+	for _, scope := range payload.Scopes {
+		if !models.ValidAPIKeyScope(scope) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key scope: " + string(scope)})
+			return
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if payload.ExpiresInDays == 0 {
+		payload.ExpiresInDays = defaultAPIKeyExpiryDays
+	}
+
+	userID, _ := c.Get("userID")
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	var active int64
+	if err := db.Model(&models.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Count(&active).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count API keys: " + err.Error()})
+		return
+	}
+	if active >= maxActiveAPIKeysPerUser {
+		c.JSON(http.StatusConflict, gin.H{"error": "Too many active API keys; revoke unused keys first"})
+		return
+	}
+
+	token, hash, prefix, err := auth.GenerateAPIKeyToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate API key: " + err.Error()})
+		return
+	}
+	key := models.APIKey{
+		OrganizationID: orgID.(uuid.UUID),
+		UserID:         userID.(uuid.UUID),
+		Name:           strings.TrimSpace(payload.Name),
+		Prefix:         prefix,
+		SecretHash:     hash,
+		ExpiresAt:      time.Now().AddDate(0, 0, payload.ExpiresInDays),
+	}
+	key.SetScopes(scopes)
+	if err := db.Create(&key).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, CreatedAPIKeyResponse{APIKeyView: newAPIKeyView(key), Token: token})
+}
+
+// ListAPIKeysHandler lists the authenticated user's API keys, newest first. With api_keys:manage, all=true
+// lists the keys of all users. Revoked and expired keys are included only with include_inactive=true.
+func ListAPIKeysHandler(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Where("organization_id = ?", orgID)
+	if c.Query("all") == "true" {
+		if !rbac.Require(c, rbac.APIKeysManage) {
+			return
+		}
+	} else {
+		query = query.Where("user_id = ?", userID)
+	}
+	if c.Query("include_inactive") != "true" {
+		query = query.Where("revoked_at IS NULL AND expires_at > ?", time.Now())
+	}
+	var keys []models.APIKey
+	if err := query.Order("created_at desc").Find(&keys).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list API keys: " + err.Error()})
+		return
+	}
+	items := make([]APIKeyView, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, newAPIKeyView(key))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// RevokeAPIKeyHandler revokes an API key. Users revoke their own keys; api_keys:manage revokes any key of
+// the organization (e.g. of a user who left).
+func RevokeAPIKeyHandler(c *gin.Context) {
+	keyID, err := uuid.Parse(c.Param("keyId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID format"})
+		return
+	}
+	userID, _ := c.Get("userID")
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	var key models.APIKey
+	if err := db.Where("id = ? AND organization_id = ?", keyID, orgID).Take(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load API key: " + err.Error()})
+		return
+	}
+	if key.UserID != userID.(uuid.UUID) && !rbac.Can(c, rbac.APIKeysManage) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+	if key.RevokedAt == nil {
+		now := time.Now()
+		revokedByID := userID.(uuid.UUID)
+		key.RevokedAt = &now
+		key.RevokedByID = &revokedByID
+		if err := db.Model(&key).Updates(map[string]interface{}{"revoked_at": now, "revoked_by_id": revokedByID}).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key: " + err.Error()})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, newAPIKeyView(key))
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/apiusage"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/pkg/config"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// APIUsageRow é uma linha agregada do relatório de uso da API.
+type APIUsageRow struct {
+	Method       string     `json:"method,omitempty"`
+	Route        string     `json:"route,omitempty"`
+	Credential   string     `json:"credential,omitempty"`
+	WindowStart  *time.Time `json:"window_start,omitempty"`
+	Requests     int64      `json:"requests"`
+	Errors       int64      `json:"errors"`
+	AvgLatencyMs float64    `json:"avg_latency_ms"`
+}
+
+// APIUsageCurrentWindow mostra o consumo da janela horária corrente frente ao limite configurado.
+type APIUsageCurrentWindow struct {
+	WindowStart      time.Time `json:"window_start"`
+	Requests         int64     `json:"requests"`
+	HourlyLimit      int64     `json:"hourly_limit,omitempty"` // 0/omitido: sem limite configurado
+	PercentUsed      float64   `json:"percent_used,omitempty"`
+	ThresholdPercent int       `json:"alert_threshold_percent,omitempty"`
+}
+
+// APIUsageResponse é o relatório de uso da API da organização.
+type APIUsageResponse struct {
+	From          time.Time             `json:"from"`
+	To            time.Time             `json:"to"`
+	GroupBy       string                `json:"group_by"`
+	TotalRequests int64                 `json:"total_requests"`
+	TotalErrors   int64                 `json:"total_errors"`
+	CurrentWindow APIUsageCurrentWindow `json:"current_window"`
+	Items         []APIUsageRow         `json:"items"`
+}
+
+var apiUsageGroupColumns = map[string]string{
+	"endpoint":   "method, route",
+	"credential": "credential",
+	"hour":       "window_start",
+}
+
+// GetAPIUsageHandler reports the organization's API usage grouped by endpoint, credential or hour.
+// Query: from, to (RFC3339; default últimas 24h), group_by (endpoint|credential|hour), credential, route, limit (default 50).
+func GetAPIUsageHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.APIUsageView) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+
+	to := time.Now().UTC()
+	from := to.Add(-24 * time.Hour)
+	if v := c.Query("from"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from format, expected RFC3339"})
+			return
+		}
+		from = parsed
+	}
+	if v := c.Query("to"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to format, expected RFC3339"})
+			return
+		}
+		to = parsed
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+	groupBy := c.DefaultQuery("group_by", "endpoint")
+	groupColumns, ok := apiUsageGroupColumns[groupBy]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group_by, expected endpoint, credential or hour"})
+		return
+	}
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit, expected 1-500"})
+			return
+		}
+		limit = parsed
+	}
+
+	db := database.GetDB()
+	// As janelas são horárias: inclui a janela que contém "from".
+	query := db.Model(&models.APIUsageCounter{}).
+		Where("organization_id = ? AND window_start >= ? AND window_start < ?", orgID, apiusage.WindowStart(from), to)
+	if credential := c.Query("credential"); credential != "" {
+		query = query.Where("credential = ?", credential)
+	}
+	if route := c.Query("route"); route != "" {
+		query = query.Where("route = ?", route)
+	}
+
+	response := APIUsageResponse{From: from, To: to, GroupBy: groupBy, Items: []APIUsageRow{}}
+	var totals struct {
+		Requests int64
+		Errors   int64
+	}
+	if err := query.Session(&gorm.Session{}).Select("COALESCE(SUM(request_count), 0) AS requests, COALESCE(SUM(error_count), 0) AS errors").Scan(&totals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute API usage totals: " + err.Error()})
+		return
+	}
+	response.TotalRequests = totals.Requests
+	response.TotalErrors = totals.Errors
+
+	order := "requests DESC"
+	if groupBy == "hour" {
+		order = "window_start ASC"
+	}
+	err := query.Session(&gorm.Session{}).
+		Select(groupColumns + ", SUM(request_count) AS requests, SUM(error_count) AS errors, SUM(total_latency_ms)::float / NULLIF(SUM(request_count), 0) AS avg_latency_ms").
+		Group(groupColumns).Order(order).Limit(limit).
+		Scan(&response.Items).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to aggregate API usage: " + err.Error()})
+		return
+	}
+
+	window := apiusage.WindowStart(time.Now())
+	response.CurrentWindow.WindowStart = window
+	db.Model(&models.APIUsageCounter{}).
+		Where("organization_id = ? AND window_start = ?", orgID, window).
+		Select("COALESCE(SUM(request_count), 0)").Scan(&response.CurrentWindow.Requests)
+	if hourlyLimit := config.Cfg.APIUsageHourlyLimit; hourlyLimit > 0 {
+		response.CurrentWindow.HourlyLimit = hourlyLimit
+		response.CurrentWindow.PercentUsed = float64(response.CurrentWindow.Requests) * 100 / float64(hourlyLimit)
+		response.CurrentWindow.ThresholdPercent = config.Cfg.APIUsageAlertPercent
+	}
+
+	c.JSON(http.StatusOK, response)
+}
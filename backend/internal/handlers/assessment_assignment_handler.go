@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/internal/rbac"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AssessmentAssignmentPayload atribui controles (até 500 por requisição) a um avaliador, com prazo e equipe
+// responsável opcionais. assigned_to_id nulo remove a atribuição dos controles.
+type AssessmentAssignmentPayload struct {
+	ControlIDs     []string `json:"control_ids" binding:"required,min=1,max=500,dive,uuid"`
+	AssignedToID   *string  `json:"assigned_to_id" binding:"omitempty,uuid"`
+	AssignedTeamID *string  `json:"assigned_team_id" binding:"omitempty,uuid"`
+	DueDate        *string  `json:"due_date" binding:"omitempty,datetime=2006-01-02"` // YYYY-MM-DD
+}
+
+// AssignAssessmentsHandler assigns controls to an assessor in bulk (audit:assign), creating the
+// pending assessments that do not exist yet. Reassigning resets the completion and reminders. With
+// assigned_team_id the members of the team are notified and also see the assessments.
+func AssignAssessmentsHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditAssign) {
+		return
+	}
+	var payload AssessmentAssignmentPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+
+	controlIDs := make([]uuid.UUID, 0, len(payload.ControlIDs))
+	seen := make(map[uuid.UUID]bool, len(payload.ControlIDs))
+	for _, raw := range payload.ControlIDs {
+		id := uuid.MustParse(raw) // Validado pelo binding
+		if !seen[id] {
+			seen[id] = true
+			controlIDs = append(controlIDs, id)
+		}
+	}
+
+	var found []uuid.UUID
+	if err := db.Model(&models.AuditControl{}).
+		Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Scopes(visibleFrameworksScope(organizationID)).
+		Where("audit_controls.id IN ?", controlIDs).
+		Pluck("audit_controls.id", &found).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load audit controls: " + err.Error()})
+		return
+	}
+	if len(found) != len(controlIDs) {
+		foundSet := make(map[uuid.UUID]bool, len(found))
+		for _, id := range found {
+			foundSet[id] = true
+		}
+		var missing []string
+		for _, id := range controlIDs {
+			if !foundSet[id] {
+				missing = append(missing, id.String())
+			}
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audit controls not found: " + strings.Join(missing, ", ")})
+		return
+	}
+
+	if payload.AssignedToID == nil {
+		if payload.DueDate != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "due_date requires assigned_to_id"})
+			return
+		}
+		if payload.AssignedTeamID != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "assigned_team_id requires assigned_to_id"})
+			return
+		}
+		result := db.Model(&models.AuditAssessment{}).Scopes(models.OrgWideAssessments).
+			Where("organization_id = ? AND audit_control_id IN ?", organizationID, controlIDs).
+			Updates(map[string]interface{}{
+				"assigned_to_id":          nil,
+				"assigned_team_id":        nil,
+				"assigned_by_id":          nil,
+				"assigned_at":             nil,
+				"due_date":                nil,
+				"assignment_completed_at": nil,
+				"last_due_reminder_at":    nil,
+				"reminder_target_date":    nil,
+			})
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unassign controls: " + result.Error.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Controls unassigned", "unassigned": result.RowsAffected})
+		return
+	}
+
+	assigneeID := uuid.MustParse(*payload.AssignedToID)
+	var assignee models.User
+	if err := db.Select("id", "name", "email").
+		Where("id = ? AND organization_id = ? AND is_active = ?", assigneeID, organizationID, true).
+		First(&assignee).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Assignee not found or inactive in your organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load assignee: " + err.Error()})
+		return
+	}
+
+	var assignedTeamID *uuid.UUID
+	if payload.AssignedTeamID != nil {
+		teamID, err := parseOrgTeamID(db, organizationID, *payload.AssignedTeamID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assigned_team_id: " + err.Error()})
+			return
+		}
+		assignedTeamID = &teamID
+	}
+
+	var dueDate *time.Time
+	if payload.DueDate != nil {
+		parsed, _ := time.Parse("2006-01-02", *payload.DueDate)
+		dueDate = &parsed
+	}
+
+	now := time.Now()
+	assignedByID := userID.(uuid.UUID)
+	assessments := make([]models.AuditAssessment, len(controlIDs))
+	for i, controlID := range controlIDs {
+		assessments[i] = models.AuditAssessment{
+			OrganizationID: organizationID,
+			AuditControlID: controlID,
+			AssignedToID:   &assigneeID,
+			AssignedTeamID: assignedTeamID,
+			AssignedByID:   &assignedByID,
+			AssignedAt:     &now,
+			DueDate:        dueDate,
+		}
+	}
+	// Avaliações existentes mantêm status, score e evidência; só a atribuição é substituída
+	err := db.Clauses(models.OrgWideAssessmentConflict(clause.AssignmentColumns([]string{
+		"assigned_to_id", "assigned_team_id", "assigned_by_id", "assigned_at", "due_date",
+		"assignment_completed_at", "last_due_reminder_at", "reminder_target_date", "updated_at",
+	}))).Create(&assessments).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign controls: " + err.Error()})
+		return
+	}
+
+	if assignedTeamID != nil {
+		body := fmt.Sprintf("%d control(s) were assigned to your team for assessment.", len(controlIDs))
+		if payload.DueDate != nil {
+			body += " Due date: " + *payload.DueDate + "."
+		}
+		notifications.NotifyTeamWithTemplate(c.Request.Context(), *assignedTeamID, templates.NameNotification, templates.Notification{
+			Subject: "Controls assigned to your team", Body: body, Link: notifications.FrontendLink("/audit/my-assessments"),
+		}, assignedByID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":          "Controls assigned",
+		"assigned":         len(controlIDs),
+		"assigned_to_id":   assigneeID,
+		"assigned_team_id": assignedTeamID,
+		"due_date":         payload.DueDate,
+	})
+}
+
+// ListMyAssignedAssessmentsHandler lists the assessments assigned to the authenticated user, ordered by due date.
+// status: open (default, not submitted yet), overdue, completed or all. include_teams=true also lists the
+// assessments assigned to the user's teams.
+func ListMyAssignedAssessmentsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	page, pageSize := GetPaginationParams(c)
+
+	db := database.GetDB()
+	base := db.Model(&models.AuditAssessment{}).Scopes(models.OrgWideAssessments).
+		Where("organization_id = ?", orgID.(uuid.UUID))
+	if c.Query("include_teams") == "true" {
+		base = base.Where("(assigned_to_id = ? OR assigned_team_id IN (?))", userID.(uuid.UUID), models.UserTeamIDs(db, userID.(uuid.UUID)))
+	} else {
+		base = base.Where("assigned_to_id = ?", userID.(uuid.UUID))
+	}
+	switch c.DefaultQuery("status", "open") {
+	case "open":
+		base = base.Where("assignment_completed_at IS NULL")
+	case "overdue":
+		base = base.Where("assignment_completed_at IS NULL AND due_date < ?", time.Now().Format("2006-01-02"))
+	case "completed":
+		base = base.Where("assignment_completed_at IS NOT NULL")
+	case "all":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status filter. Valid are: open, overdue, completed, all"})
+		return
+	}
+
+	var totalItems int64
+	if err := base.Session(&gorm.Session{}).Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count assigned assessments: " + err.Error()})
+		return
+	}
+
+	var assessments []models.AuditAssessment
+	if err := base.Session(&gorm.Session{}).Preload("AuditControl").
+		Order("due_date ASC NULLS LAST, assigned_at ASC").
+		Scopes(PaginateScope(page, pageSize)).
+		Find(&assessments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assigned assessments: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: assessments, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
+
+// maxReminderTargetDays limita a nova data prevista informada ao responder um lembrete.
+const maxReminderTargetDays = 180
+
+// AssessmentReminderAckPayload é a resposta do avaliador a um lembrete de prazo.
+type AssessmentReminderAckPayload struct {
+	TargetDate    string `json:"target_date" binding:"required,datetime=2006-01-02"` // YYYY-MM-DD
+	Justification string `json:"justification" binding:"required,min=10,max=2000"`
+}
+
+// findOrgAssessment carrega a avaliação :assessmentId da organização do usuário.
+func findOrgAssessment(c *gin.Context, db *gorm.DB) (*models.AuditAssessment, bool) {
+	assessmentID, err := uuid.Parse(c.Param("assessmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assessment ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var assessment models.AuditAssessment
+	if err := db.Preload("AuditControl").Where("id = ? AND organization_id = ?", assessmentID, orgID).First(&assessment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Assessment not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch assessment: " + err.Error()})
+		return nil, false
+	}
+	return &assessment, true
+}
+
+// AcknowledgeAssessmentReminderHandler lets the assigned assessor answer the due date reminders with a new
+// target date and a justification. Reminders pause until the new date approaches; the answer is kept in history.
+func AcknowledgeAssessmentReminderHandler(c *gin.Context) {
+	var payload AssessmentReminderAckPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	userID, _ := c.Get("userID")
+	currentUserID := userID.(uuid.UUID)
+	db := database.GetDB()
+	assessment, ok := findOrgAssessment(c, db)
+	if !ok {
+		return
+	}
+	if assessment.AssignedToID == nil || *assessment.AssignedToID != currentUserID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the assessor assigned to this assessment can acknowledge its reminders"})
+		return
+	}
+	if assessment.AssignmentCompletedAt != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "The assessment has already been submitted"})
+		return
+	}
+	if assessment.DueDate == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The assessment has no due date"})
+		return
+	}
+	targetDate, _ := time.Parse("2006-01-02", payload.TargetDate) // Validado pelo binding
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if targetDate.Before(today) || targetDate.After(today.AddDate(0, 0, maxReminderTargetDays)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "target_date must be between today and 180 days ahead"})
+		return
+	}
+
+	ack := models.AssessmentReminderAck{
+		OrganizationID:     assessment.OrganizationID,
+		AuditAssessmentID:  assessment.ID,
+		UserID:             currentUserID,
+		DueDate:            assessment.DueDate,
+		PreviousTargetDate: assessment.ReminderTargetDate,
+		TargetDate:         targetDate,
+		Justification:      strings.TrimSpace(payload.Justification),
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&ack).Error; err != nil {
+			return err
+		}
+		return tx.Model(assessment).Update("reminder_target_date", targetDate).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to acknowledge reminder: " + err.Error()})
+		return
+	}
+
+	// Quem atribuiu a avaliação é informado do novo prazo previsto
+	if assessment.AssignedByID != nil && *assessment.AssignedByID != currentUserID {
+		subject := fmt.Sprintf("Nova data prevista para a avaliação do controle %s", assessment.AuditControl.ControlID)
+		body := fmt.Sprintf("O avaliador informou uma nova data prevista para a avaliação do controle %s.\n\nPrazo da atribuição: %s\nNova data prevista: %s\nJustificativa: %s\n\nAcesse o Phoenix GRC para mais detalhes.",
+			assessment.AuditControl.ControlID, assessment.DueDate.Format("02/01/2006"), targetDate.Format("02/01/2006"), ack.Justification)
+		notifications.NotifyUserByEmail(c.Request.Context(), *assessment.AssignedByID, subject, body)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assessment": assessment, "acknowledgment": ack})
+}
+
+// ListAssessmentReminderAcksHandler returns the history of reminder acknowledgments of an assessment, newest
+// first (assigned assessor or a member of the assigned team, admin or manager).
+func ListAssessmentReminderAcksHandler(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	assessment, ok := findOrgAssessment(c, db)
+	if !ok {
+		return
+	}
+	isAssignee := assessment.AssignedToID != nil && *assessment.AssignedToID == userID.(uuid.UUID)
+	if !isAssignee && assessment.AssignedTeamID != nil {
+		member, err := models.IsTeamMember(db, *assessment.AssignedTeamID, userID.(uuid.UUID))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership: " + err.Error()})
+			return
+		}
+		isAssignee = member
+	}
+	if !isAssignee && !rbac.Can(c, rbac.AuditAssign) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the assigned assessor, admins or managers can view the reminder history"})
+		return
+	}
+	acks := []models.AssessmentReminderAck{}
+	if err := db.Where("audit_assessment_id = ?", assessment.ID).Order("created_at desc").Find(&acks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list reminder acknowledgments: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, acks)
+}
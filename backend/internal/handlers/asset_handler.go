@@ -0,0 +1,584 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AssetPayload defines the structure for creating or updating an asset.
+type AssetPayload struct {
+	Name               string                    `json:"name" binding:"required,min=2,max=255"`
+	Type               models.AssetType          `json:"type" binding:"required,oneof=hardware software information service cloud facility people other"`
+	Description        string                    `json:"description"`
+	OwnerID            string                    `json:"owner_id"`
+	Criticality        models.AssetCriticality   `json:"criticality" binding:"omitempty,oneof=low medium high critical"`
+	DataClassification models.DataClassification `json:"data_classification" binding:"omitempty,oneof=public internal confidential restricted"`
+	Location           string                    `json:"location" binding:"max=255"`
+}
+
+// AssetRisksPayload substitui os riscos vinculados a um ativo.
+type AssetRisksPayload struct {
+	RiskIDs []string `json:"risk_ids"`
+}
+
+// AssetControlsPayload substitui os controles vinculados a um ativo.
+type AssetControlsPayload struct {
+	AuditControlIDs []string `json:"audit_control_ids"`
+}
+
+// AssetRiskSummary é um risco vinculado a um ativo.
+type AssetRiskSummary struct {
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	RiskLevel string    `json:"risk_level"`
+	Status    string    `json:"status"`
+}
+
+// AssetControlSummary é um controle vinculado a um ativo.
+type AssetControlSummary struct {
+	ID            uuid.UUID `json:"id"`
+	ControlID     string    `json:"control_id"`
+	Description   string    `json:"description"`
+	FrameworkName string    `json:"framework_name"`
+}
+
+// AssetDetailResponse é o ativo com seus riscos e controles vinculados.
+type AssetDetailResponse struct {
+	models.Asset
+	Risks    []AssetRiskSummary    `json:"risks"`
+	Controls []AssetControlSummary `json:"controls"`
+}
+
+// ImportAssetsResponse resume o resultado da importação CSV de ativos.
+type ImportAssetsResponse struct {
+	Created    int                     `json:"created"`
+	Updated    int                     `json:"updated"`
+	FailedRows []BulkUploadErrorDetail `json:"failed_rows,omitempty"`
+}
+
+// applyAssetPayload copia o payload para o ativo, validando o responsável.
+func applyAssetPayload(db *gorm.DB, asset *models.Asset, payload AssetPayload) error {
+	asset.Name = strings.TrimSpace(payload.Name)
+	asset.Type = payload.Type
+	asset.Description = payload.Description
+	asset.Location = payload.Location
+	if payload.Criticality != "" {
+		asset.Criticality = payload.Criticality
+	}
+	if payload.DataClassification != "" {
+		asset.DataClassification = payload.DataClassification
+	}
+	asset.OwnerID = nil
+	if payload.OwnerID != "" {
+		ownerID, err := parseOrgUserID(db, asset.OrganizationID, payload.OwnerID)
+		if err != nil {
+			return fmt.Errorf("invalid owner_id: %w", err)
+		}
+		asset.OwnerID = &ownerID
+	}
+	return nil
+}
+
+// assetNameTaken verifica se outro ativo da organização já usa o nome.
+func assetNameTaken(db *gorm.DB, asset *models.Asset) bool {
+	var count int64
+	db.Model(&models.Asset{}).Where("organization_id = ? AND name = ? AND id <> ?", asset.OrganizationID, asset.Name, asset.ID).Count(&count)
+	return count > 0
+}
+
+// findOrgAsset carrega o ativo de :assetId na organização do usuário.
+func findOrgAsset(c *gin.Context, db *gorm.DB) (*models.Asset, bool) {
+	assetID, err := uuid.Parse(c.Param("assetId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var asset models.Asset
+	if err := db.Where("id = ? AND organization_id = ?", assetID, orgID).First(&asset).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Asset not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch asset: " + err.Error()})
+		return nil, false
+	}
+	return &asset, true
+}
+
+// parseUniqueUUIDs converte uma lista de IDs, removendo duplicados.
+func parseUniqueUUIDs(ids []string, field string) ([]uuid.UUID, error) {
+	result := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, idStr := range ids {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s format: %s", field, idStr)
+		}
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// CreateAssetHandler adds an asset to the organization's inventory.
+func CreateAssetHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AssetsManage) {
+		return
+	}
+	var payload AssetPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	asset := models.Asset{
+		OrganizationID:     orgID.(uuid.UUID),
+		Criticality:        models.AssetCriticalityMedium,
+		DataClassification: models.DataClassificationInternal,
+	}
+	if err := applyAssetPayload(db, &asset, payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if assetNameTaken(db, &asset) {
+		c.JSON(http.StatusConflict, gin.H{"error": "An asset with this name already exists in your organization"})
+		return
+	}
+	if err := db.Create(&asset).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create asset: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, asset)
+}
+
+// ListAssetsHandler lists the organization's assets with pagination.
+// Filtros: type, criticality, data_classification, owner_id, search (nome), risk_id, audit_control_id.
+func ListAssetsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	page, pageSize := GetPaginationParams(c)
+
+	query := db.Model(&models.Asset{}).Where("assets.organization_id = ?", orgID)
+	for _, filter := range []string{"type", "criticality", "data_classification", "owner_id"} {
+		if value := c.Query(filter); value != "" {
+			query = query.Where("assets."+filter+" = ?", value)
+		}
+	}
+	if search := c.Query("search"); search != "" {
+		query = query.Where("assets.name ILIKE ?", "%"+search+"%")
+	}
+	if riskID := c.Query("risk_id"); riskID != "" {
+		query = query.Where("assets.id IN (?)", db.Model(&models.AssetRisk{}).Select("asset_id").Where("risk_id = ?", riskID))
+	}
+	if controlID := c.Query("audit_control_id"); controlID != "" {
+		query = query.Where("assets.id IN (?)", db.Model(&models.AssetControl{}).Select("asset_id").Where("audit_control_id = ?", controlID))
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count assets: " + err.Error()})
+		return
+	}
+	var assets []models.Asset
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("assets.name asc").Find(&assets).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assets: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      assets,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// loadAssetDetail carrega os riscos e controles vinculados ao ativo.
+func loadAssetDetail(db *gorm.DB, asset models.Asset) (AssetDetailResponse, error) {
+	detail := AssetDetailResponse{Asset: asset, Risks: []AssetRiskSummary{}, Controls: []AssetControlSummary{}}
+	err := db.Table("risks").
+		Select("risks.id, risks.title, risks.risk_level, risks.status").
+		Joins("JOIN asset_risks ON asset_risks.risk_id = risks.id").
+		Where("asset_risks.asset_id = ? AND risks.deleted_at IS NULL", asset.ID).
+		Order("risks.title asc").
+		Scan(&detail.Risks).Error
+	if err != nil {
+		return detail, err
+	}
+	err = db.Table("audit_controls").
+		Select("audit_controls.id, audit_controls.control_id, audit_controls.description, audit_frameworks.name AS framework_name").
+		Joins("JOIN asset_controls ON asset_controls.audit_control_id = audit_controls.id").
+		Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Where("asset_controls.asset_id = ?", asset.ID).
+		Order("audit_frameworks.name asc, " + models.ControlOrderClause).
+		Scan(&detail.Controls).Error
+	return detail, err
+}
+
+// GetAssetHandler returns an asset with its linked risks and controls.
+func GetAssetHandler(c *gin.Context) {
+	db := database.GetDB()
+	asset, ok := findOrgAsset(c, db)
+	if !ok {
+		return
+	}
+	detail, err := loadAssetDetail(db, *asset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load asset links: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// UpdateAssetHandler updates an asset.
+func UpdateAssetHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AssetsManage) {
+		return
+	}
+	var payload AssetPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	asset, ok := findOrgAsset(c, db)
+	if !ok {
+		return
+	}
+	if err := applyAssetPayload(db, asset, payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if assetNameTaken(db, asset) {
+		c.JSON(http.StatusConflict, gin.H{"error": "An asset with this name already exists in your organization"})
+		return
+	}
+	if err := db.Save(asset).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update asset: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, asset)
+}
+
+// DeleteAssetHandler removes an asset and its links.
+func DeleteAssetHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AssetsManage) {
+		return
+	}
+	db := database.GetDB()
+	asset, ok := findOrgAsset(c, db)
+	if !ok {
+		return
+	}
+	if err := db.Delete(asset).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete asset: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Asset deleted successfully"})
+}
+
+// SetAssetRisksHandler replaces the risks linked to an asset.
+func SetAssetRisksHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AssetsManage) {
+		return
+	}
+	var payload AssetRisksPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	asset, ok := findOrgAsset(c, db)
+	if !ok {
+		return
+	}
+	riskIDs, err := parseUniqueUUIDs(payload.RiskIDs, "risk_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(riskIDs) > 0 {
+		var count int64
+		db.Model(&models.Risk{}).Where("id IN ? AND organization_id = ?", riskIDs, asset.OrganizationID).Count(&count)
+		if int(count) != len(riskIDs) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more risks were not found in your organization"})
+			return
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("asset_id = ?", asset.ID).Delete(&models.AssetRisk{}).Error; err != nil {
+			return err
+		}
+		if len(riskIDs) == 0 {
+			return nil
+		}
+		links := make([]models.AssetRisk, 0, len(riskIDs))
+		for _, id := range riskIDs {
+			links = append(links, models.AssetRisk{AssetID: asset.ID, RiskID: id})
+		}
+		return tx.Create(&links).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update asset risks: " + err.Error()})
+		return
+	}
+	detail, err := loadAssetDetail(db, *asset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load asset links: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// SetAssetControlsHandler replaces the audit controls whose scope includes the asset.
+func SetAssetControlsHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AssetsManage) {
+		return
+	}
+	var payload AssetControlsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	asset, ok := findOrgAsset(c, db)
+	if !ok {
+		return
+	}
+	controlIDs, err := parseUniqueUUIDs(payload.AuditControlIDs, "audit_control_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(controlIDs) > 0 {
+		var count int64
+		db.Model(&models.AuditControl{}).Where("id IN ?", controlIDs).Count(&count)
+		if int(count) != len(controlIDs) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more audit controls were not found"})
+			return
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("asset_id = ?", asset.ID).Delete(&models.AssetControl{}).Error; err != nil {
+			return err
+		}
+		if len(controlIDs) == 0 {
+			return nil
+		}
+		links := make([]models.AssetControl, 0, len(controlIDs))
+		for _, id := range controlIDs {
+			links = append(links, models.AssetControl{AssetID: asset.ID, AuditControlID: id})
+		}
+		return tx.Create(&links).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update asset controls: " + err.Error()})
+		return
+	}
+	detail, err := loadAssetDetail(db, *asset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load asset links: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// ListRiskAssetsHandler answers "which assets does this risk affect?".
+func ListRiskAssetsHandler(c *gin.Context) {
+	riskID, err := uuid.Parse(c.Param("riskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid risk ID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	var assets []models.Asset
+	err = db.Joins("JOIN asset_risks ON asset_risks.asset_id = assets.id").
+		Where("asset_risks.risk_id = ? AND assets.organization_id = ?", riskID, orgID).
+		Order("assets.name asc").
+		Find(&assets).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list risk assets: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, assets)
+}
+
+// ImportAssetsCSVHandler creates or updates assets from a CSV file (chave: name).
+// Colunas: name, type (obrigatórias); description, owner_email, criticality, data_classification, location.
+func ImportAssetsCSVHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AssetsManage) {
+		return
+	}
+	orgIDValue, _ := c.Get("organizationID")
+	organizationID := orgIDValue.(uuid.UUID)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file not provided in 'file' field"})
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open uploaded file"})
+		return
+	}
+	defer src.Close()
+
+	reader := csv.NewReader(src)
+	headers, err := reader.Read()
+	if err == io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file is empty"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV headers: " + err.Error()})
+		return
+	}
+	headerMap := make(map[string]int)
+	for i, h := range headers {
+		headerMap[normalizeHeader(h)] = i
+	}
+	for _, required := range []string{"name", "type"} {
+		if _, ok := headerMap[required]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Missing required CSV header: %s", required)})
+			return
+		}
+	}
+
+	db := database.GetDB()
+	var orgUsers []models.User
+	db.Select("id", "email").Where("organization_id = ? AND is_active = ?", organizationID, true).Find(&orgUsers)
+	usersByEmail := make(map[string]uuid.UUID, len(orgUsers))
+	for _, u := range orgUsers {
+		usersByEmail[strings.ToLower(u.Email)] = u.ID
+	}
+	var existing []models.Asset
+	db.Where("organization_id = ?", organizationID).Find(&existing)
+	existingByName := make(map[string]models.Asset, len(existing))
+	for _, a := range existing {
+		existingByName[strings.ToLower(a.Name)] = a
+	}
+
+	validTypes := map[string]bool{"hardware": true, "software": true, "information": true, "service": true, "cloud": true, "facility": true, "people": true, "other": true}
+	validCriticality := map[string]bool{"low": true, "medium": true, "high": true, "critical": true}
+	validClassification := map[string]bool{"public": true, "internal": true, "confidential": true, "restricted": true}
+
+	var toCreate, toUpdate []models.Asset
+	var failedRows []BulkUploadErrorDetail
+	seenNames := make(map[string]int)
+	lineNumber := 1
+	for {
+		lineNumber++
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			failedRows = append(failedRows, BulkUploadErrorDetail{LineNumber: lineNumber, Errors: []string{"Failed to parse CSV row: " + err.Error()}})
+			continue
+		}
+
+		var rowErrors []string
+		name := strings.TrimSpace(getCSVField(record, headerMap, "name"))
+		if len(name) < 2 || len(name) > 255 {
+			rowErrors = append(rowErrors, "name must be between 2 and 255 characters")
+		} else if prev, dup := seenNames[strings.ToLower(name)]; dup {
+			rowErrors = append(rowErrors, fmt.Sprintf("duplicate name (already on line %d)", prev))
+		}
+		assetType := strings.ToLower(strings.TrimSpace(getCSVField(record, headerMap, "type")))
+		if !validTypes[assetType] {
+			rowErrors = append(rowErrors, fmt.Sprintf("invalid type: '%s'", assetType))
+		}
+		criticality := strings.ToLower(strings.TrimSpace(getCSVField(record, headerMap, "criticality")))
+		if criticality == "" {
+			criticality = string(models.AssetCriticalityMedium)
+		} else if !validCriticality[criticality] {
+			rowErrors = append(rowErrors, fmt.Sprintf("invalid criticality: '%s'", criticality))
+		}
+		classification := strings.ToLower(strings.TrimSpace(getCSVField(record, headerMap, "data_classification")))
+		if classification == "" {
+			classification = string(models.DataClassificationInternal)
+		} else if !validClassification[classification] {
+			rowErrors = append(rowErrors, fmt.Sprintf("invalid data_classification: '%s'", classification))
+		}
+		var ownerID *uuid.UUID
+		if ownerEmail := strings.ToLower(strings.TrimSpace(getCSVField(record, headerMap, "owner_email"))); ownerEmail != "" {
+			if id, ok := usersByEmail[ownerEmail]; ok {
+				ownerID = &id
+			} else {
+				rowErrors = append(rowErrors, fmt.Sprintf("owner_email '%s' is not an active user of your organization", ownerEmail))
+			}
+		}
+		if len(rowErrors) > 0 {
+			failedRows = append(failedRows, BulkUploadErrorDetail{LineNumber: lineNumber, Errors: rowErrors})
+			continue
+		}
+		seenNames[strings.ToLower(name)] = lineNumber
+
+		asset, exists := existingByName[strings.ToLower(name)]
+		if !exists {
+			asset = models.Asset{OrganizationID: organizationID, Name: name}
+		}
+		asset.Type = models.AssetType(assetType)
+		asset.Description = strings.TrimSpace(getCSVField(record, headerMap, "description"))
+		asset.OwnerID = ownerID
+		asset.Criticality = models.AssetCriticality(criticality)
+		asset.DataClassification = models.DataClassification(classification)
+		asset.Location = strings.TrimSpace(getCSVField(record, headerMap, "location"))
+		if exists {
+			toUpdate = append(toUpdate, asset)
+		} else {
+			toCreate = append(toCreate, asset)
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if len(toCreate) > 0 {
+			if err := tx.Create(&toCreate).Error; err != nil {
+				return err
+			}
+		}
+		for i := range toUpdate {
+			if err := tx.Save(&toUpdate[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error during asset import: " + err.Error()})
+		return
+	}
+
+	response := ImportAssetsResponse{Created: len(toCreate), Updated: len(toUpdate), FailedRows: failedRows}
+	imported := len(toCreate) + len(toUpdate)
+	if len(failedRows) > 0 && imported > 0 {
+		c.JSON(http.StatusMultiStatus, response)
+	} else if len(failedRows) > 0 {
+		c.JSON(http.StatusBadRequest, response)
+	} else {
+		c.JSON(http.StatusOK, response)
+	}
+}
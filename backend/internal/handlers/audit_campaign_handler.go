@@ -0,0 +1,597 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditCampaignParticipantPayload defines a participant of an audit campaign.
+type AuditCampaignParticipantPayload struct {
+	UserID string                   `json:"user_id" binding:"required,uuid"`
+	Role   models.AuditCampaignRole `json:"role" binding:"required,oneof=lead assessor reviewer auditor"`
+}
+
+// AuditCampaignPayload defines the structure for creating an audit campaign.
+// Sem famílias nem ativos, o escopo é o framework inteiro.
+type AuditCampaignPayload struct {
+	FrameworkID   string                            `json:"framework_id" binding:"required,uuid"`
+	Name          string                            `json:"name" binding:"required,min=3,max=255"`
+	Description   string                            `json:"description"`
+	StartsAt      string                            `json:"starts_at" binding:"required"` // YYYY-MM-DD
+	EndsAt        string                            `json:"ends_at" binding:"required"`   // YYYY-MM-DD
+	ScopeFamilies []string                          `json:"scope_families"`
+	ScopeAssetIDs []string                          `json:"scope_asset_ids"`
+	Participants  []AuditCampaignParticipantPayload `json:"participants" binding:"dive"`
+}
+
+// UpdateAuditCampaignPayload defines the fields of an audit campaign that can be changed.
+// Campos omitidos são mantidos; listas informadas substituem as atuais.
+type UpdateAuditCampaignPayload struct {
+	Name          *string                            `json:"name" binding:"omitempty,min=3,max=255"`
+	Description   *string                            `json:"description"`
+	Status        *models.AuditCampaignStatus        `json:"status" binding:"omitempty,oneof=planned in_progress closed"`
+	StartsAt      *string                            `json:"starts_at"`
+	EndsAt        *string                            `json:"ends_at"`
+	ScopeFamilies *[]string                          `json:"scope_families"`
+	ScopeAssetIDs *[]string                          `json:"scope_asset_ids"`
+	Participants  *[]AuditCampaignParticipantPayload `json:"participants" binding:"omitempty,dive"`
+}
+
+// auditCampaignMembers são o escopo e os participantes validados de uma auditoria, ainda sem CampaignID.
+type auditCampaignMembers struct {
+	families     []models.AuditCampaignScopeFamily
+	assets       []models.AuditCampaignScopeAsset
+	participants []models.AuditCampaignParticipant
+}
+
+// campaignScopeControls restringe audit_controls ao escopo da auditoria: controles das famílias selecionadas
+// ou vinculados aos ativos selecionados. Sem famílias nem ativos, todos os controles do framework.
+func campaignScopeControls(campaignID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`(NOT EXISTS (SELECT 1 FROM audit_campaign_scope_families WHERE campaign_id = @campaign)
+			AND NOT EXISTS (SELECT 1 FROM audit_campaign_scope_assets WHERE campaign_id = @campaign))
+			OR audit_controls.family IN (SELECT family FROM audit_campaign_scope_families WHERE campaign_id = @campaign)
+			OR audit_controls.id IN (
+				SELECT asset_controls.audit_control_id FROM asset_controls
+				JOIN audit_campaign_scope_assets ON audit_campaign_scope_assets.asset_id = asset_controls.asset_id
+				WHERE audit_campaign_scope_assets.campaign_id = @campaign)`, sql.Named("campaign", campaignID))
+	}
+}
+
+// parseCampaignWindow valida a janela da auditoria (datas YYYY-MM-DD, fim não anterior ao início).
+// Em caso de erro, já escreve a resposta e retorna false.
+func parseCampaignWindow(c *gin.Context, startsAtStr, endsAtStr string) (time.Time, time.Time, bool) {
+	startsAt, err := time.Parse("2006-01-02", startsAtStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid starts_at format, use YYYY-MM-DD"})
+		return time.Time{}, time.Time{}, false
+	}
+	endsAt, err := time.Parse("2006-01-02", endsAtStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ends_at format, use YYYY-MM-DD"})
+		return time.Time{}, time.Time{}, false
+	}
+	if endsAt.Before(startsAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ends_at must not be before starts_at"})
+		return time.Time{}, time.Time{}, false
+	}
+	return startsAt, endsAt, true
+}
+
+// resolveCampaignMembers valida as famílias (existentes no framework), os ativos (da organização) e os
+// participantes (usuários ativos da organização). Em caso de erro, já escreve a resposta e retorna false.
+func resolveCampaignMembers(c *gin.Context, db *gorm.DB, organizationID, frameworkID uuid.UUID, families, assetIDs []string, participants []AuditCampaignParticipantPayload) (*auditCampaignMembers, bool) {
+	members := &auditCampaignMembers{}
+
+	familySet := make(map[string]bool, len(families))
+	var wantedFamilies []string
+	for _, family := range families {
+		family = strings.TrimSpace(family)
+		if family != "" && !familySet[family] {
+			familySet[family] = true
+			wantedFamilies = append(wantedFamilies, family)
+		}
+	}
+	if len(wantedFamilies) > 0 {
+		var found []string
+		if err := db.Model(&models.AuditControl{}).Distinct().
+			Where("framework_id = ? AND family IN ?", frameworkID, wantedFamilies).
+			Pluck("family", &found).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate control families: " + err.Error()})
+			return nil, false
+		}
+		if len(found) != len(wantedFamilies) {
+			for _, family := range found {
+				delete(familySet, family)
+			}
+			missing := make([]string, 0, len(familySet))
+			for family := range familySet {
+				missing = append(missing, family)
+			}
+			sort.Strings(missing)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Control families not found in framework: " + strings.Join(missing, ", ")})
+			return nil, false
+		}
+		for _, family := range wantedFamilies {
+			members.families = append(members.families, models.AuditCampaignScopeFamily{Family: family})
+		}
+	}
+
+	assetSet := make(map[uuid.UUID]bool, len(assetIDs))
+	var wantedAssets []uuid.UUID
+	for _, idStr := range assetIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID format: " + idStr})
+			return nil, false
+		}
+		if !assetSet[id] {
+			assetSet[id] = true
+			wantedAssets = append(wantedAssets, id)
+		}
+	}
+	if len(wantedAssets) > 0 {
+		var count int64
+		if err := db.Model(&models.Asset{}).Where("organization_id = ? AND id IN ?", organizationID, wantedAssets).Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate assets: " + err.Error()})
+			return nil, false
+		}
+		if int(count) != len(wantedAssets) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more assets not found in your organization"})
+			return nil, false
+		}
+		for _, id := range wantedAssets {
+			members.assets = append(members.assets, models.AuditCampaignScopeAsset{AssetID: id})
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(participants))
+	userIDs := make([]uuid.UUID, 0, len(participants))
+	for _, participant := range participants {
+		userID := uuid.MustParse(participant.UserID)
+		if seen[userID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Duplicate participant: " + participant.UserID})
+			return nil, false
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+		members.participants = append(members.participants, models.AuditCampaignParticipant{UserID: userID, Role: participant.Role})
+	}
+	if len(userIDs) > 0 {
+		var count int64
+		if err := db.Model(&models.User{}).
+			Where("organization_id = ? AND is_active = ? AND id IN ?", organizationID, true, userIDs).
+			Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate participants: " + err.Error()})
+			return nil, false
+		}
+		if int(count) != len(userIDs) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more participants not found or inactive in your organization"})
+			return nil, false
+		}
+	}
+	return members, true
+}
+
+// replaceCampaignScope substitui as famílias e os ativos do escopo da auditoria.
+func replaceCampaignScope(tx *gorm.DB, campaignID uuid.UUID, members *auditCampaignMembers) error {
+	if err := tx.Where("campaign_id = ?", campaignID).Delete(&models.AuditCampaignScopeFamily{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("campaign_id = ?", campaignID).Delete(&models.AuditCampaignScopeAsset{}).Error; err != nil {
+		return err
+	}
+	for i := range members.families {
+		members.families[i].CampaignID = campaignID
+	}
+	for i := range members.assets {
+		members.assets[i].CampaignID = campaignID
+	}
+	if len(members.families) > 0 {
+		if err := tx.Create(&members.families).Error; err != nil {
+			return err
+		}
+	}
+	if len(members.assets) > 0 {
+		return tx.Create(&members.assets).Error
+	}
+	return nil
+}
+
+// replaceCampaignParticipants substitui os participantes da auditoria.
+func replaceCampaignParticipants(tx *gorm.DB, campaignID uuid.UUID, members *auditCampaignMembers) error {
+	if err := tx.Where("campaign_id = ?", campaignID).Delete(&models.AuditCampaignParticipant{}).Error; err != nil {
+		return err
+	}
+	if len(members.participants) == 0 {
+		return nil
+	}
+	for i := range members.participants {
+		members.participants[i].CampaignID = campaignID
+	}
+	return tx.Create(&members.participants).Error
+}
+
+// findOrgAuditCampaign carrega a auditoria de :campaignId garantindo que pertence à organização do token.
+// Em caso de erro, já escreve a resposta e retorna false.
+func findOrgAuditCampaign(c *gin.Context, db *gorm.DB, organizationID uuid.UUID) (*models.AuditCampaign, bool) {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID format"})
+		return nil, false
+	}
+	var campaign models.AuditCampaign
+	if err := db.Where("id = ? AND organization_id = ?", campaignID, organizationID).First(&campaign).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Audit campaign not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit campaign: " + err.Error()})
+		return nil, false
+	}
+	return &campaign, true
+}
+
+// findCampaignForAssessment valida o registro de uma avaliação na auditoria: auditoria da organização e do
+// framework do controle, não encerrada, dentro da janela, controle no escopo e usuário com audit:manage_campaigns ou
+// participante que avalia (lead/assessor). Em caso de erro, já escreve a resposta e retorna false.
+func findCampaignForAssessment(c *gin.Context, db *gorm.DB, organizationID, userID uuid.UUID, campaignIDStr string, control models.AuditControl) (*models.AuditCampaign, bool) {
+	campaignID, err := uuid.Parse(campaignIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit_campaign_id format"})
+		return nil, false
+	}
+	var campaign models.AuditCampaign
+	if err := db.Where("id = ? AND organization_id = ?", campaignID, organizationID).First(&campaign).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Audit campaign not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit campaign: " + err.Error()})
+		return nil, false
+	}
+	if campaign.FrameworkID != control.FrameworkID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audit control does not belong to the campaign's framework"})
+		return nil, false
+	}
+	if campaign.Status == models.AuditCampaignStatusClosed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Audit campaign is closed"})
+		return nil, false
+	}
+	today := time.Now().Format("2006-01-02")
+	if today < campaign.StartsAt.Format("2006-01-02") || today > campaign.EndsAt.Format("2006-01-02") {
+		c.JSON(http.StatusConflict, gin.H{"error": "Audit campaign is outside its time window"})
+		return nil, false
+	}
+
+	if !rbac.Can(c, rbac.AuditManageCampaigns) {
+		var participant models.AuditCampaignParticipant
+		err := db.Where("campaign_id = ? AND user_id = ?", campaign.ID, userID).First(&participant).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check campaign participation: " + err.Error()})
+			return nil, false
+		}
+		if err == gorm.ErrRecordNotFound || !participant.Role.CanAssess() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the campaign's leads and assessors can record assessments in it"})
+			return nil, false
+		}
+	}
+
+	var inScope int64
+	if err := db.Model(&models.AuditControl{}).Scopes(campaignScopeControls(campaign.ID)).
+		Where("audit_controls.id = ?", control.ID).Count(&inScope).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check campaign scope: " + err.Error()})
+		return nil, false
+	}
+	if inScope == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audit control is outside the campaign's scope"})
+		return nil, false
+	}
+	return &campaign, true
+}
+
+// CreateAuditCampaignHandler creates an audit campaign over a framework with its scope, time window and participants.
+func CreateAuditCampaignHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageCampaigns) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+
+	var payload AuditCampaignPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	startsAt, endsAt, ok := parseCampaignWindow(c, payload.StartsAt, payload.EndsAt)
+	if !ok {
+		return
+	}
+
+	db := database.GetDB()
+	frameworkID := uuid.MustParse(payload.FrameworkID)
+	var frameworkCount int64
+	db.Model(&models.AuditFramework{}).Scopes(visibleFrameworksScope(organizationID)).Where("id = ?", frameworkID).Count(&frameworkCount)
+	if frameworkCount == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Framework not found"})
+		return
+	}
+	members, ok := resolveCampaignMembers(c, db, organizationID, frameworkID, payload.ScopeFamilies, payload.ScopeAssetIDs, payload.Participants)
+	if !ok {
+		return
+	}
+
+	campaign := models.AuditCampaign{
+		OrganizationID: organizationID,
+		FrameworkID:    frameworkID,
+		Name:           payload.Name,
+		Description:    payload.Description,
+		Status:         models.AuditCampaignStatusPlanned,
+		StartsAt:       startsAt,
+		EndsAt:         endsAt,
+		CreatedByID:    userID.(uuid.UUID),
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&campaign).Error; err != nil {
+			return err
+		}
+		if err := replaceCampaignScope(tx, campaign.ID, members); err != nil {
+			return err
+		}
+		return replaceCampaignParticipants(tx, campaign.ID, members)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create audit campaign: " + err.Error()})
+		return
+	}
+
+	var created models.AuditCampaign
+	db.Preload("ScopeFamilies").Preload("ScopeAssets").Preload("Participants.User").First(&created, "id = ?", campaign.ID)
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListAuditCampaignsHandler lists the organization's audit campaigns with pagination.
+// Optional filters: framework_id and status.
+func ListAuditCampaignsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	page, pageSize := GetPaginationParams(c)
+	db := database.GetDB()
+
+	query := db.Model(&models.AuditCampaign{}).Where("organization_id = ?", orgID.(uuid.UUID))
+	if frameworkIDStr := c.Query("framework_id"); frameworkIDStr != "" {
+		frameworkID, err := uuid.Parse(frameworkIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid framework ID format"})
+			return
+		}
+		query = query.Where("framework_id = ?", frameworkID)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count audit campaigns: " + err.Error()})
+		return
+	}
+	var campaigns []models.AuditCampaign
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("starts_at desc, created_at desc").Find(&campaigns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit campaigns: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      campaigns,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// GetAuditCampaignHandler returns an audit campaign with its scope, participants and assessment progress.
+func GetAuditCampaignHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	campaign, ok := findOrgAuditCampaign(c, db, orgID.(uuid.UUID))
+	if !ok {
+		return
+	}
+	if err := db.Preload("ScopeFamilies").Preload("ScopeAssets").Preload("Participants.User").First(campaign, "id = ?", campaign.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit campaign: " + err.Error()})
+		return
+	}
+
+	var inScope int64
+	if err := db.Model(&models.AuditControl{}).Scopes(campaignScopeControls(campaign.ID)).
+		Where("audit_controls.framework_id = ?", campaign.FrameworkID).Count(&inScope).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count controls in scope: " + err.Error()})
+		return
+	}
+	var rows []struct {
+		Status models.AuditControlStatus
+		Total  int64
+	}
+	if err := db.Model(&models.AuditAssessment{}).Select("status, COUNT(*) AS total").
+		Where("audit_campaign_id = ? AND status <> ''", campaign.ID).
+		Group("status").Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to summarize campaign assessments: " + err.Error()})
+		return
+	}
+	byStatus := make(map[models.AuditControlStatus]int64, len(rows))
+	var assessed int64
+	for _, row := range rows {
+		byStatus[row.Status] = row.Total
+		assessed += row.Total
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign": campaign,
+		"progress": gin.H{
+			"controls_in_scope":  inScope,
+			"assessed_controls":  assessed,
+			"pending_controls":   inScope - assessed,
+			"assessed_by_status": byStatus,
+		},
+	})
+}
+
+// UpdateAuditCampaignHandler updates an audit campaign's details, status, time window, scope or participants.
+func UpdateAuditCampaignHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageCampaigns) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+	campaign, ok := findOrgAuditCampaign(c, db, organizationID)
+	if !ok {
+		return
+	}
+
+	var payload UpdateAuditCampaignPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if payload.Name != nil {
+		updates["name"] = *payload.Name
+	}
+	if payload.Description != nil {
+		updates["description"] = *payload.Description
+	}
+	if payload.StartsAt != nil || payload.EndsAt != nil {
+		startsAt, endsAt := campaign.StartsAt.Format("2006-01-02"), campaign.EndsAt.Format("2006-01-02")
+		if payload.StartsAt != nil {
+			startsAt = *payload.StartsAt
+		}
+		if payload.EndsAt != nil {
+			endsAt = *payload.EndsAt
+		}
+		parsedStart, parsedEnd, ok := parseCampaignWindow(c, startsAt, endsAt)
+		if !ok {
+			return
+		}
+		updates["starts_at"] = parsedStart
+		updates["ends_at"] = parsedEnd
+	}
+	if payload.Status != nil && *payload.Status != campaign.Status {
+		updates["status"] = *payload.Status
+		if *payload.Status == models.AuditCampaignStatusClosed {
+			updates["closed_at"] = time.Now()
+		} else {
+			updates["closed_at"] = nil
+		}
+	}
+
+	var families, assetIDs []string
+	var participants []AuditCampaignParticipantPayload
+	if payload.ScopeFamilies != nil {
+		families = *payload.ScopeFamilies
+	}
+	if payload.ScopeAssetIDs != nil {
+		assetIDs = *payload.ScopeAssetIDs
+	}
+	if payload.Participants != nil {
+		participants = *payload.Participants
+	}
+	members, ok := resolveCampaignMembers(c, db, organizationID, campaign.FrameworkID, families, assetIDs, participants)
+	if !ok {
+		return
+	}
+	// Sem lista informada, o escopo atual é mantido
+	if payload.ScopeFamilies == nil && payload.ScopeAssetIDs != nil {
+		db.Where("campaign_id = ?", campaign.ID).Find(&members.families)
+	}
+	if payload.ScopeAssetIDs == nil && payload.ScopeFamilies != nil {
+		db.Where("campaign_id = ?", campaign.ID).Find(&members.assets)
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if len(updates) > 0 {
+			if err := tx.Model(campaign).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		if payload.ScopeFamilies != nil || payload.ScopeAssetIDs != nil {
+			if err := replaceCampaignScope(tx, campaign.ID, members); err != nil {
+				return err
+			}
+		}
+		if payload.Participants != nil {
+			return replaceCampaignParticipants(tx, campaign.ID, members)
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update audit campaign: " + err.Error()})
+		return
+	}
+
+	var updated models.AuditCampaign
+	db.Preload("ScopeFamilies").Preload("ScopeAssets").Preload("Participants.User").First(&updated, "id = ?", campaign.ID)
+	c.JSON(http.StatusOK, updated)
+}
+
+// ListAuditCampaignControlsHandler lists the controls in the campaign's scope, in the organization's control
+// order, each with the assessment recorded in the campaign (if any).
+func ListAuditCampaignControlsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+	campaign, ok := findOrgAuditCampaign(c, db, organizationID)
+	if !ok {
+		return
+	}
+
+	var controls []models.AuditControl
+	if err := db.Select("audit_controls.*").
+		Scopes(orderedControlsScope(organizationID), campaignScopeControls(campaign.ID)).
+		Where("audit_controls.framework_id = ?", campaign.FrameworkID).
+		Find(&controls).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list controls in scope: " + err.Error()})
+		return
+	}
+
+	var assessments []models.AuditAssessment
+	if err := db.Where("organization_id = ? AND audit_campaign_id = ?", organizationID, campaign.ID).Find(&assessments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list campaign assessments: " + err.Error()})
+		return
+	}
+	assessmentMap := make(map[uuid.UUID]models.AuditAssessment, len(assessments))
+	for _, assessment := range assessments {
+		assessmentMap[assessment.AuditControlID] = assessment
+	}
+
+	type campaignControlResponse struct {
+		models.AuditControl
+		Assessment *models.AuditAssessment `json:"assessment,omitempty"`
+	}
+	response := make([]campaignControlResponse, 0, len(controls))
+	for _, control := range controls {
+		item := campaignControlResponse{AuditControl: control}
+		if assessment, found := assessmentMap[control.ID]; found {
+			item.Assessment = &assessment
+		}
+		response = append(response, item)
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"path/filepath"
+	"phoenixgrc/backend/internal/avscan"
+	"phoenixgrc/backend/internal/c2m2logic"
+	"phoenixgrc/backend/internal/compliancescore"
 	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/events"
 	"phoenixgrc/backend/internal/filestorage"
 	"phoenixgrc/backend/internal/models"
 	phxlog "phoenixgrc/backend/pkg/log"
@@ -26,8 +31,9 @@ import (
 // ListFrameworksHandler lists all available audit frameworks.
 func ListFrameworksHandler(c *gin.Context) {
 	db := database.GetDB()
+	orgID, _ := c.Get("organizationID")
 	var frameworks []models.AuditFramework
-	if err := db.Find(&frameworks).Error; err != nil {
+	if err := db.Scopes(visibleFrameworksScope(orgID.(uuid.UUID))).Order("organization_id nulls first, name asc").Find(&frameworks).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit frameworks: " + err.Error()})
 		return
 	}
@@ -44,6 +50,13 @@ func GetControlFamiliesForFrameworkHandler(c *gin.Context) {
 	}
 
 	db := database.GetDB()
+	orgID, _ := c.Get("organizationID")
+	var frameworkCount int64
+	db.Model(&models.AuditFramework{}).Scopes(visibleFrameworksScope(orgID.(uuid.UUID))).Where("id = ?", frameworkID).Count(&frameworkCount)
+	if frameworkCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found"})
+		return
+	}
 	var families []string
 	// Usar Distinct para pegar apenas famílias únicas e não nulas/vazias
 	if err := db.Model(&models.AuditControl{}).
@@ -71,33 +84,47 @@ func GetFrameworkControlsHandler(c *gin.Context) {
 		return
 	}
 
-	db := database.GetDB()
-	var controls []models.AuditControl
-	if err := db.Where("framework_id = ?", frameworkID).Order("control_id asc").Find(&controls).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list controls for framework: " + err.Error()})
+	orgID, orgOk := c.Get("organizationID")
+	if !orgOk {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Organization ID not found in token for fetching assessments"})
 		return
 	}
+	organizationID := orgID.(uuid.UUID)
 
-	if len(controls) == 0 {
-		// Check if framework itself exists to give a better error
-		var framework models.AuditFramework
-		if errFramework := db.First(&framework, "id = ?", frameworkID).Error; errFramework == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found"})
-			return
-		}
+	db := database.GetDB()
+	// Frameworks personalizados de outras organizações não são visíveis
+	var framework models.AuditFramework
+	if errFramework := db.Scopes(visibleFrameworksScope(organizationID)).First(&framework, "id = ?", frameworkID).Error; errFramework == gorm.ErrRecordNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found"})
+		return
 	}
 
-	// Para cada controle, buscar a avaliação da organização do usuário (se existir)
-	orgID, orgOk := c.Get("organizationID")
-	if !orgOk {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Organization ID not found in token for fetching assessments"})
+	controls, err := loadOrderedFrameworkControls(db, organizationID, frameworkID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list controls for framework: " + err.Error()})
 		return
 	}
-	organizationID := orgID.(uuid.UUID)
+	tagged, err := taggedEntityIDs(c, db, organizationID, models.TagEntityControl)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to filter controls by tag: " + err.Error()})
+		return
+	}
+	if tagged != nil {
+		filtered := controls[:0]
+		for _, ctrl := range controls {
+			if tagged[ctrl.ID] {
+				filtered = append(filtered, ctrl)
+			}
+		}
+		controls = filtered
+	}
 
+	// Para cada controle, buscar a avaliação da organização do usuário (se existir)
 	type AuditControlWithAssessmentResponse struct {
 		models.AuditControl
-		Assessment *models.AuditAssessment `json:"assessment,omitempty"`
+		Assessment  *models.AuditAssessment `json:"assessment,omitempty"`
+		MappedRisks []AssetRiskSummary      `json:"mapped_risks"` // Riscos da organização mitigados pelo controle
+		Guidance    *models.ControlGuidance `json:"guidance,omitempty"`     // Orientação ao avaliador
 	}
 
 	responseControls := make([]AuditControlWithAssessmentResponse, 0, len(controls))
@@ -108,7 +135,7 @@ func GetFrameworkControlsHandler(c *gin.Context) {
 
 	var assessments []models.AuditAssessment
 	if len(controlIDs) > 0 {
-		db.Where("organization_id = ? AND audit_control_id IN (?)", organizationID, controlIDs).Find(&assessments)
+		db.Scopes(models.OrgWideAssessments).Where("organization_id = ? AND audit_control_id IN (?)", organizationID, controlIDs).Find(&assessments)
 	}
 
 	assessmentMap := make(map[uuid.UUID]models.AuditAssessment)
@@ -116,10 +143,26 @@ func GetFrameworkControlsHandler(c *gin.Context) {
 		assessmentMap[assess.AuditControlID] = assess
 	}
 
+	risksByControl, err := loadMappedRisksByControl(db, organizationID, controlIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load mapped risks: " + err.Error()})
+		return
+	}
+
 	for _, ctrl := range controls {
 		respCtrl := AuditControlWithAssessmentResponse{
 			AuditControl: ctrl,
+			MappedRisks:  risksByControl[ctrl.ID],
+		}
+		if respCtrl.MappedRisks == nil {
+			respCtrl.MappedRisks = []AssetRiskSummary{}
+		}
+		guidance, err := ctrl.GuidanceDetails()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read control guidance: " + err.Error()})
+			return
 		}
+		respCtrl.Guidance = guidance
 		if assessment, found := assessmentMap[ctrl.ID]; found {
 			respCtrl.Assessment = &assessment
 		}
@@ -134,11 +177,17 @@ func GetFrameworkControlsHandler(c *gin.Context) {
 // AssessmentPayload defines the structure for creating or updating an assessment.
 type AssessmentPayload struct {
 	AuditControlID string                    `json:"audit_control_id" binding:"required"` // UUID of the AuditControl
-	Status         models.AuditControlStatus `json:"status" binding:"required,oneof=conforme nao_conforme parcialmente_conforme nao_aplicavel"` // Adicionado nao_aplicavel
+	Status         models.AuditControlStatus `json:"status" binding:"required,max=30"` // Status nativo ou cadastrado (ver StatusDefinition)
 	EvidenceURL    string                    `json:"evidence_url" binding:"omitempty,url"`
 	Score          *int                      `json:"score" binding:"omitempty,min=0,max=100"`      // Pointer for optional score
 	AssessmentDate string                    `json:"assessment_date" binding:"omitempty,datetime=2006-01-02"` // YYYY-MM-DD
 	Comments       *string                   `json:"comments,omitempty"`                               // Comentários da avaliação principal
+	ImplementationNarrative *string          `json:"implementation_narrative,omitempty"`               // Como a organização implementa o controle
+	PolicyID       string                    `json:"policy_id,omitempty"`                              // UUID da Policy que atende o controle
+	// Auditoria (campanha) na qual a avaliação é registrada; vazio para a avaliação contínua da organização
+	AuditCampaignID string `json:"audit_campaign_id,omitempty"`
+	// Rascunho de evidência (pendente) usado como evidência da avaliação contínua, no lugar de evidence_file
+	EvidenceDraftID string `json:"evidence_draft_id,omitempty"`
 
 	// Campos C2M2
 	C2M2AssessmentDate *string `json:"c2m2_assessment_date,omitempty" binding:"omitempty,datetime=2006-01-02"` // YYYY-MM-DD
@@ -163,7 +212,19 @@ var allowedMimeTypes = map[string]bool{
 	"text/plain":                                                       true, // .txt
 }
 
+// assessmentUpsertColumns são as colunas atualizadas quando a avaliação do controle já existe.
+var assessmentUpsertColumns = []string{
+	"status", "status_code", "evidence_url", "score", "assessment_date",
+	"evidence_scan_status", "evidence_scan_signature", "evidence_scanned_at",
+	"policy_id", "comments", "implementation_narrative",
+	"c2m2_assessment_date", "c2m2_comments",
+	"updated_at",
+}
+
 // CreateOrUpdateAssessmentHandler creates a new assessment or updates an existing one.
+// With audit_campaign_id the assessment is recorded in that audit, separate from the organization's
+// continuous assessment of the control. Com If-Match (o ETag da avaliação), responde 412 se ela mudou desde a
+// leitura do cliente (ou ainda não existe) e 409 se mudar durante a gravação.
 func CreateOrUpdateAssessmentHandler(c *gin.Context) {
 	if err := c.Request.ParseMultipartForm(10 << 20); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form: " + err.Error()})
@@ -195,6 +256,55 @@ func CreateOrUpdateAssessmentHandler(c *gin.Context) {
 		return
 	}
 
+	var control models.AuditControl
+	if err := database.GetDB().Select("audit_controls.id", "audit_controls.framework_id").
+		Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Scopes(visibleFrameworksScope(organizationID)).
+		First(&control, "audit_controls.id = ?", auditControlUUID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audit control not found"})
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	var campaign *models.AuditCampaign
+	if payload.AuditCampaignID != "" {
+		var ok bool
+		campaign, ok = findCampaignForAssessment(c, database.GetDB(), organizationID, userID.(uuid.UUID), payload.AuditCampaignID, control)
+		if !ok {
+			return
+		}
+	} else if !ensureCanSubmitAssessment(c, database.GetDB(), organizationID, control.FrameworkID) {
+		return
+	} else if !ensureNotLockedByOther(c, database.GetDB(), organizationID, models.EditLockResourceAssessment, auditControlUUID, userID.(uuid.UUID)) {
+		return
+	}
+
+	// O status deve estar definido e a mudança a partir do status atual da avaliação, permitida
+	var currentStatus string
+	var current models.AuditAssessment
+	var currentCampaignID *uuid.UUID
+	if campaign != nil {
+		currentCampaignID = &campaign.ID
+	}
+	currentExists := false
+	if err := database.GetDB().Scopes(models.AssessmentsOf(currentCampaignID)).Select("status", "status_code", "updated_at").
+		Where("organization_id = ? AND audit_control_id = ?", organizationID, auditControlUUID).Take(&current).Error; err == nil {
+		currentStatus = current.EffectiveStatus()
+		currentExists = true
+	}
+	if !checkIfMatch(c, current.UpdatedAt, currentExists) {
+		return
+	}
+	// Com If-Match, o upsert só altera a avaliação se ela ainda estiver na versão conferida acima
+	upsertWhere := clause.Where{}
+	if ifMatchSent(c) {
+		upsertWhere.Exprs = []clause.Expression{clause.Expr{SQL: "audit_assessments.updated_at = ?", Vars: []interface{}{current.UpdatedAt}}}
+	}
+	statusDef, ok := resolveStatus(c, database.GetDB(), models.StatusEntityAssessment, currentStatus, string(payload.Status))
+	if !ok {
+		return
+	}
+
 	if payload.AssessmentDate != "" {
 		if _, err := time.Parse("2006-01-02", payload.AssessmentDate); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assessment_date format, use YYYY-MM-DD: " + err.Error()})
@@ -206,9 +316,26 @@ func CreateOrUpdateAssessmentHandler(c *gin.Context) {
 	assessmentModel := models.AuditAssessment{
 		OrganizationID: organizationID,
 		AuditControlID: auditControlUUID,
-		Status:         payload.Status,
+		Comments:       payload.Comments,
+		ImplementationNarrative: payload.ImplementationNarrative,
 		C2M2Comments:      payload.C2M2Comments,
 	}
+	assessmentModel.SetStatus(models.AuditControlStatus(statusDef.BaseStatus), statusDef.Code)
+
+	if payload.PolicyID != "" {
+		policyUUID, err := uuid.Parse(payload.PolicyID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy_id format"})
+			return
+		}
+		var policyCount int64
+		database.GetDB().Model(&models.Policy{}).Where("id = ? AND organization_id = ?", policyUUID, organizationID).Count(&policyCount)
+		if policyCount == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Policy not found or not part of your organization"})
+			return
+		}
+		assessmentModel.PolicyID = &policyUUID
+	}
 
 	if payload.AssessmentDate != "" {
 		parsedDate, _ := time.Parse("2006-01-02", payload.AssessmentDate)
@@ -230,19 +357,35 @@ func CreateOrUpdateAssessmentHandler(c *gin.Context) {
 	if payload.Score != nil {
 		assessmentModel.Score = payload.Score
 	} else {
-		var defaultScore int
-		switch payload.Status {
-		case models.ControlStatusConformant:
-			defaultScore = 100
-		case models.ControlStatusPartiallyConformant:
-			defaultScore = 50
-		case models.ControlStatusNonConformant, models.ControlStatusNotApplicable:
-			defaultScore = 0
+		// Score padrão conforme a rubrica da organização/framework (100/50/0 se não configurada)
+		rubric, _, err := models.ResolveScoreRubric(database.GetDB(), organizationID, control.FrameworkID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load score rubric: " + err.Error()})
+			return
 		}
+		defaultScore := rubric.ScoreFor(assessmentModel.Status)
 		assessmentModel.Score = &defaultScore
 	}
 
 	file, header, errFile := c.Request.FormFile("evidence_file")
+	var evidenceDraft *models.AssessmentEvidenceDraft
+	if payload.EvidenceDraftID != "" {
+		if errFile == nil {
+			file.Close()
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Provide either evidence_file or evidence_draft_id, not both"})
+			return
+		}
+		if campaign != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Evidence drafts can only be used in the continuous assessment"})
+			return
+		}
+		var ok bool
+		if evidenceDraft, ok = findPendingEvidenceDraft(c, database.GetDB(), organizationID, auditControlUUID, payload.EvidenceDraftID); !ok {
+			return
+		}
+		assessmentEvidenceIdentifier = evidenceDraft.ObjectName
+		assessmentModel.EvidenceScanStatus = evidenceDraft.ScanStatus
+	}
 	if errFile == nil {
 		defer file.Close()
 
@@ -281,6 +424,21 @@ func CreateOrUpdateAssessmentHandler(c *gin.Context) {
 			}
 		}
 
+		scanResult, errScan := avscan.ScanUpload(c.Request.Context(), header.Filename, file)
+		if errScan != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to scan evidence file for malware: " + errScan.Error()})
+			return
+		}
+		if scanResult.Verdict == avscan.VerdictInfected {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Evidence file rejected: malware detected", "signature": scanResult.Signature})
+			return
+		}
+		assessmentModel.EvidenceScanStatus = string(scanResult.Verdict)
+		assessmentModel.EvidenceScanSignature = scanResult.Signature
+		if !scanResult.ScannedAt.IsZero() {
+			assessmentModel.EvidenceScannedAt = &scanResult.ScannedAt
+		}
+
 		if filestorage.DefaultFileStorageProvider == nil {
 			log.Println("Attempted file upload, but no file storage provider is configured.")
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
@@ -306,22 +464,57 @@ func CreateOrUpdateAssessmentHandler(c *gin.Context) {
 
 	db := database.GetDB()
 
-	err = db.Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "organization_id"}, {Name: "audit_control_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"status", "evidence_url", "score", "assessment_date",
-			"c2m2_assessment_date", "c2m2_comments",
-			"updated_at",
-		}),
-	}).Create(&assessmentModel).Error
+	var tally *models.ComplianceScoreTally
+	var campaignID *uuid.UUID
+	if campaign == nil {
+		tally, err = compliancescore.RecordAssessmentWrite(db, organizationID, auditControlUUID, func(tx *gorm.DB) error {
+			conflict := models.OrgWideAssessmentConflict(clause.AssignmentColumns(assessmentUpsertColumns))
+			conflict.Where = upsertWhere
+			if result := tx.Clauses(conflict).Create(&assessmentModel); result.Error != nil {
+				return result.Error
+			} else if result.RowsAffected == 0 {
+				return errVersionConflict
+			}
+			if evidenceDraft != nil {
+				if err := markEvidenceDraftAccepted(tx, evidenceDraft, userID.(uuid.UUID)); err != nil {
+					return err
+				}
+			}
+			// Conclui a atribuição pendente do controle, se houver
+			return tx.Model(&models.AuditAssessment{}).Scopes(models.OrgWideAssessments).
+				Where("organization_id = ? AND audit_control_id = ? AND assigned_to_id IS NOT NULL AND assignment_completed_at IS NULL", organizationID, auditControlUUID).
+				Update("assignment_completed_at", time.Now()).Error
+		})
+	} else {
+		// Avaliações de auditoria não alteram a avaliação contínua nem o score de conformidade
+		campaignID = &campaign.ID
+		assessmentModel.AuditCampaignID = campaignID
+		err = db.Transaction(func(tx *gorm.DB) error {
+			conflict := models.CampaignAssessmentConflict(clause.AssignmentColumns(assessmentUpsertColumns))
+			conflict.Where = upsertWhere
+			if result := tx.Clauses(conflict).Create(&assessmentModel); result.Error != nil {
+				return result.Error
+			} else if result.RowsAffected == 0 {
+				return errVersionConflict
+			}
+			if campaign.Status == models.AuditCampaignStatusPlanned {
+				return tx.Model(campaign).Update("status", models.AuditCampaignStatusInProgress).Error
+			}
+			return nil
+		})
+	}
 
+	if errors.Is(err, errVersionConflict) {
+		respondVersionConflict(c)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create or update assessment: " + err.Error()})
 		return
 	}
 
 	var resultAssessment models.AuditAssessment
-	if err := db.Where("organization_id = ? AND audit_control_id = ?", organizationID, auditControlUUID).First(&resultAssessment).Error; err != nil {
+	if err := db.Scopes(models.AssessmentsOf(campaignID)).Where("organization_id = ? AND audit_control_id = ?", organizationID, auditControlUUID).First(&resultAssessment).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve created/updated assessment: " + err.Error()})
         return
 	}
@@ -370,6 +563,9 @@ func CreateOrUpdateAssessmentHandler(c *gin.Context) {
 		phxlog.L.Warn("Failed to re-fetch assessment with practice evaluations", zap.String("assessmentID", resultAssessment.ID.String()), zap.Error(err))
 	}
 
+	events.Publish(organizationID, events.EventAssessmentSubmitted, resultAssessment.ID, resultAssessment)
+	publishComplianceScore(organizationID, tally)
+	setVersionETag(c, resultAssessment.UpdatedAt)
 	c.JSON(http.StatusOK, resultAssessment)
 }
 
@@ -391,7 +587,7 @@ func GetAssessmentForControlHandler(c *gin.Context) {
 
 	db := database.GetDB()
 	var assessment models.AuditAssessment
-	err = db.Where("organization_id = ? AND audit_control_id = ?", organizationID, controlUUID).
+	err = db.Scopes(models.OrgWideAssessments).Where("organization_id = ? AND audit_control_id = ?", organizationID, controlUUID).
 		Preload("AuditControl").
 		First(&assessment).Error
 
@@ -403,11 +599,16 @@ func GetAssessmentForControlHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch assessment: " + err.Error()})
 		return
 	}
+	setVersionETag(c, assessment.UpdatedAt)
 	c.JSON(http.StatusOK, assessment)
 }
 
 // ListOrgAssessmentsByFrameworkHandler lists all assessments for a given organization and framework.
+// Filtros: status, assigned_to_id (ID ou "me"), assigned_team_id, overdue=true; ?view_id= aplica uma visão salva.
 func ListOrgAssessmentsByFrameworkHandler(c *gin.Context) {
+	if !applySavedView(c, models.SavedViewAssessment) {
+		return
+	}
 	orgIDStr := c.Param("orgId")
 	targetOrgID, err := uuid.Parse(orgIDStr)
 	if err != nil {
@@ -455,8 +656,33 @@ func ListOrgAssessmentsByFrameworkHandler(c *gin.Context) {
 	page, pageSize := GetPaginationParams(c)
 	var totalItems int64
 
-	query := db.Model(&models.AuditAssessment{}).
+	query := db.Model(&models.AuditAssessment{}).Scopes(models.OrgWideAssessments).
 		Where("organization_id = ? AND audit_control_id IN (?)", targetOrgID, controlIDs)
+	if statuses := c.QueryArray("status"); len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if assignee := c.Query("assigned_to_id"); assignee != "" {
+		if assignee == savedViewMe {
+			userID, _ := c.Get("userID")
+			query = query.Where("assigned_to_id = ?", userID.(uuid.UUID))
+		} else if assigneeID, err := uuid.Parse(assignee); err == nil {
+			query = query.Where("assigned_to_id = ?", assigneeID)
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assigned_to_id: use a user ID or 'me'"})
+			return
+		}
+	}
+	if team := c.Query("assigned_team_id"); team != "" {
+		teamID, err := uuid.Parse(team)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assigned_team_id: use a team ID"})
+			return
+		}
+		query = query.Where("assigned_team_id = ?", teamID)
+	}
+	if c.Query("overdue") == "true" {
+		query = query.Where("assignment_completed_at IS NULL AND due_date < ?", time.Now().Format("2006-01-02"))
+	}
 
 	if err := query.Count(&totalItems).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count assessments for framework: " + err.Error()})
@@ -530,10 +756,10 @@ func GetComplianceScoreHandler(c *gin.Context) {
 		return
 	}
 
-	db := database.GetDB()
+	db := database.GetDB().WithContext(c.Request.Context())
 
 	var framework models.AuditFramework
-	if err := db.First(&framework, "id = ?", frameworkID).Error; err != nil {
+	if err := db.Scopes(visibleFrameworksScope(targetOrgID)).First(&framework, "id = ?", frameworkID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found"})
 			return
@@ -542,13 +768,12 @@ func GetComplianceScoreHandler(c *gin.Context) {
 		return
 	}
 
-	var controls []models.AuditControl
-	if err := db.Where("framework_id = ?", frameworkID).Find(&controls).Error; err != nil {
+	var totalControls int64
+	if err := db.Model(&models.AuditControl{}).Where("framework_id = ?", frameworkID).Count(&totalControls).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve controls for framework: " + err.Error()})
 		return
 	}
 
-	totalControls := len(controls)
 	if totalControls == 0 {
 		resp := ComplianceScoreResponse{
 			FrameworkID:    frameworkID,
@@ -559,71 +784,37 @@ func GetComplianceScoreHandler(c *gin.Context) {
 		return
 	}
 
-	var controlIDs []uuid.UUID
-	for _, ctrl := range controls {
-		controlIDs = append(controlIDs, ctrl.ID)
-	}
-
-	var assessments []models.AuditAssessment
-	if err := db.Where("organization_id = ? AND audit_control_id IN (?)", targetOrgID, controlIDs).Find(&assessments).Error; err != nil {
+	// Agregado mantido incrementalmente a cada avaliação (recalculado por completo só na primeira leitura)
+	tally, err := compliancescore.Load(db, targetOrgID, frameworkID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assessments for score calculation: " + err.Error()})
 		return
 	}
-
-	evaluatedControls := 0
-	conformantControls := 0
-	partiallyConformantControls := 0
-	nonConformantControls := 0
-	var totalScoreSum int
-
-	assessmentMap := make(map[uuid.UUID]models.AuditAssessment)
-	for _, assess := range assessments {
-		assessmentMap[assess.AuditControlID] = assess
-	}
-
-	for _, ctrl := range controls {
-		if assessment, found := assessmentMap[ctrl.ID]; found {
-			evaluatedControls++
-			if assessment.Score != nil {
-				totalScoreSum += *assessment.Score
-			}
-			switch assessment.Status {
-			case models.ControlStatusConformant:
-				conformantControls++
-			case models.ControlStatusPartiallyConformant:
-				partiallyConformantControls++
-			case models.ControlStatusNonConformant:
-				nonConformantControls++
-			}
-		}
-	}
-
-	var complianceScore float64
-	if evaluatedControls > 0 {
-		complianceScore = float64(totalScoreSum) / float64(evaluatedControls)
-	} else {
-		complianceScore = 0.0
-	}
-
-	if evaluatedControls == 0 && totalScoreSum != 0 {
-		complianceScore = 0.0
-	}
+	score := compliancescore.FromTally(tally)
 
 	resp := ComplianceScoreResponse{
 		FrameworkID:                 frameworkID,
 		FrameworkName:               framework.Name,
 		OrganizationID:            targetOrgID,
-		ComplianceScore:             complianceScore,
-		TotalControls:               totalControls,
-		EvaluatedControls:           evaluatedControls,
-		ConformantControls:          conformantControls,
-		PartiallyConformantControls: partiallyConformantControls,
-		NonConformantControls:       nonConformantControls,
+		ComplianceScore:             score.Score(),
+		TotalControls:               int(totalControls),
+		EvaluatedControls:           score.Evaluated,
+		ConformantControls:          score.Conformant,
+		PartiallyConformantControls: score.PartiallyConformant,
+		NonConformantControls:       score.NonConformant,
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
+// publishComplianceScore notifica os dashboards do novo score do framework após uma avaliação.
+func publishComplianceScore(orgID uuid.UUID, tally *models.ComplianceScoreTally) {
+	if tally == nil {
+		return
+	}
+	events.Publish(orgID, events.EventComplianceScoreUpdated, tally.FrameworkID, compliancescore.Summarize(*tally))
+}
+
 // DeleteAssessmentEvidenceHandler remove a evidência de uma avaliação específica.
 func DeleteAssessmentEvidenceHandler(c *gin.Context) {
 	assessmentIDStr := c.Param("assessmentId")
@@ -651,6 +842,18 @@ func DeleteAssessmentEvidenceHandler(c *gin.Context) {
 		return
 	}
 
+	if assessment.AuditCampaignID == nil {
+		var frameworkID uuid.UUID
+		if err := db.Model(&models.AuditControl{}).Where("id = ?", assessment.AuditControlID).
+			Pluck("framework_id", &frameworkID).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch control: " + err.Error()})
+			return
+		}
+		if !ensureCanSubmitAssessment(c, db, organizationID, frameworkID) {
+			return
+		}
+	}
+
 	if assessment.EvidenceURL == "" {
 		c.JSON(http.StatusOK, gin.H{"message": "No evidence to delete for this assessment."})
 		return
@@ -704,6 +907,8 @@ type C2M2MaturityFrameworkSummaryResponse struct {
 	SummaryByFunction []C2M2NISTComponentSummary `json:"summary_by_function"`
 }
 
+// GetC2M2MaturitySummaryHandler aggregates the C2M2 maturity of the framework's controls by NIST function.
+// O MIL de cada controle é derivado das práticas C2M2 avaliadas na sua avaliação (ver c2m2logic.AchievedMIL).
 func GetC2M2MaturitySummaryHandler(c *gin.Context) {
 	orgIDStr := c.Param("orgId")
 	targetOrgID, err := uuid.Parse(orgIDStr)
@@ -728,7 +933,7 @@ func GetC2M2MaturitySummaryHandler(c *gin.Context) {
 	db := database.GetDB()
 
 	var framework models.AuditFramework
-	if err := db.First(&framework, "id = ?", frameworkID).Error; err != nil {
+	if err := db.Scopes(visibleFrameworksScope(targetOrgID)).First(&framework, "id = ?", frameworkID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found"})
 			return
@@ -737,8 +942,8 @@ func GetC2M2MaturitySummaryHandler(c *gin.Context) {
 		return
 	}
 
-	var controls []models.AuditControl
-	if err := db.Where("framework_id = ?", frameworkID).Order("control_id asc").Find(&controls).Error; err != nil {
+	controls, err := loadOrderedFrameworkControls(db, targetOrgID, frameworkID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list controls for framework: " + err.Error()})
 		return
 	}
@@ -762,107 +967,60 @@ func GetC2M2MaturitySummaryHandler(c *gin.Context) {
 	// Updated query to fetch assessments and their related C2M2 practice evaluations
 	if err := db.Model(&models.AuditAssessment{}).
 		Preload("C2M2PracticeEvaluations").
+		Scopes(models.OrgWideAssessments).
 		Where("organization_id = ? AND audit_control_id IN (?)", targetOrgID, controlIDs).
 		Find(&assessments).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch C2M2 assessments and evaluations: " + err.Error()})
 		return
 	}
 
-	response := C2M2MaturityFrameworkSummaryResponse{
-		FrameworkID:     frameworkID,
-		FrameworkName:   framework.Name,
-		OrganizationID:  targetOrgID,
-		SummaryByFunction: []C2M2NISTComponentSummary{}, // Retornar vazio
+	var practices []models.C2M2Practice
+	if err := db.Select("id", "target_mil").Find(&practices).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch C2M2 practices: " + err.Error()})
+		return
 	}
-	c.JSON(http.StatusOK, response)
-	return // Retornar aqui para pular a lógica antiga e quebrada.
-
-	// A lógica antiga foi removida.
-
-	summaryByFunction := make(map[string][]int)
-	controlsInFunction := make(map[string]int)
-	evaluatedInFunction := make(map[string]int)
-	milDistInFunction := make(map[string]*C2M2MaturityDistribution)
-
-	nistFunctions := []string{"Identify", "Protect", "Detect", "Respond", "Recover", "Govern"}
-	funcMap := make(map[string]bool)
-	for _, fn := range nistFunctions {
-		funcMap[fn] = true
-		milDistInFunction[fn] = &C2M2MaturityDistribution{}
+	practiceMIL := make(map[uuid.UUID]int, len(practices))
+	for _, p := range practices {
+		practiceMIL[p.ID] = p.TargetMIL
 	}
 
+	// MIL de cada controle derivado das práticas avaliadas na sua avaliação
+	assessmentMap := make(map[uuid.UUID]models.AuditAssessment, len(assessments))
+	for _, a := range assessments {
+		assessmentMap[a.AuditControlID] = a
+	}
+	controlMaturity := make([]c2m2logic.ControlMaturity, 0, len(controls))
 	for _, ctrl := range controls {
-		var nistFunction string
-		familyNameParts := strings.SplitN(ctrl.Family, " (", 2)
-		if len(familyNameParts) > 0 {
-			potentialFunction := strings.TrimSpace(familyNameParts[0])
-			if funcMap[potentialFunction] {
-				nistFunction = potentialFunction
-			} else {
-				nistFunction = potentialFunction
-			}
-		}
-		if nistFunction == "" {
-			continue
-		}
-
-		controlsInFunction[nistFunction]++
-		if assessment, found := assessmentMap[ctrl.ID]; found {
-			// A lógica de cálculo de MIL agora deve ser baseada nas C2M2PracticeEvaluations
-			// dentro de cada assessment. Isso é mais complexo e pode exigir o c2m2logic.Calculator.
-			// Para este patch de compilação, vamos apenas contar os controles avaliados.
-			// A lógica de MIL real precisa ser reimplementada.
-			if len(assessment.C2M2PracticeEvaluations) > 0 {
-				evaluatedInFunction[nistFunction]++
-				// A distribuição de MIL e o achievedMIL não podem ser calculados aqui
-				// sem a lógica completa do calculator. Deixaremos como 0 por enquanto.
-			}
+		maturity := c2m2logic.ControlMaturity{ControlID: ctrl.ControlID, Family: ctrl.Family}
+		if assessment, found := assessmentMap[ctrl.ID]; found && len(assessment.C2M2PracticeEvaluations) > 0 {
+			maturity.Evaluated = true
+			maturity.MIL = c2m2logic.AchievedMIL(assessment.C2M2PracticeEvaluations, practiceMIL)
 		}
+		controlMaturity = append(controlMaturity, maturity)
 	}
 
-	var resultSummaries []C2M2NISTComponentSummary
-	for _, nistFunction := range nistFunctions {
-		if _, exists := controlsInFunction[nistFunction]; !exists && !funcMap[nistFunction] {
-			continue
-		}
-
-		achievedMIL := 0
-		mils := summaryByFunction[nistFunction]
-		if len(mils) > 0 {
-			counts := make(map[int]int)
-			maxCount := 0
-			for _, mil := range mils {
-				counts[mil]++
-				if counts[mil] > maxCount {
-					maxCount = counts[mil]
-					achievedMIL = mil
-				} else if counts[mil] == maxCount && mil > achievedMIL {
-					achievedMIL = mil
-				}
-			}
-		}
-
-		dist := milDistInFunction[nistFunction]
-		if dist == nil {
-			dist = &C2M2MaturityDistribution{}
-		}
-
+	summaries := c2m2logic.SummarizeByFunction(controlMaturity)
+	resultSummaries := make([]C2M2NISTComponentSummary, 0, len(summaries))
+	for _, summary := range summaries {
 		resultSummaries = append(resultSummaries, C2M2NISTComponentSummary{
 			NISTComponentType: "Function",
-			NISTComponentName: nistFunction,
-			AchievedMIL:       achievedMIL,
-			EvaluatedControls: evaluatedInFunction[nistFunction],
-			TotalControls:     controlsInFunction[nistFunction],
-			MILDistribution:   *dist,
+			NISTComponentName: summary.Function,
+			AchievedMIL:       summary.AchievedMIL,
+			EvaluatedControls: summary.EvaluatedControls,
+			TotalControls:     summary.TotalControls,
+			MILDistribution: C2M2MaturityDistribution{
+				MIL0: summary.Distribution[0],
+				MIL1: summary.Distribution[1],
+				MIL2: summary.Distribution[2],
+				MIL3: summary.Distribution[3],
+			},
 		})
 	}
 
-	finalResponse := C2M2MaturityFrameworkSummaryResponse{
-		FrameworkID:     frameworkID,
-		FrameworkName:   framework.Name,
-		OrganizationID:  targetOrgID,
+	c.JSON(http.StatusOK, C2M2MaturityFrameworkSummaryResponse{
+		FrameworkID:       frameworkID,
+		FrameworkName:     framework.Name,
+		OrganizationID:    targetOrgID,
 		SummaryByFunction: resultSummaries,
-	}
-
-	c.JSON(http.StatusOK, finalResponse)
+	})
 }
@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"phoenixgrc/backend/internal/audittrailexport"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// auditTrailExportStaleAfter é o tempo sem progresso após o qual uma exportação em andamento é considerada abandonada.
+const auditTrailExportStaleAfter = time.Hour
+
+// AuditTrailExportPayload é o período [from, to) da exportação; to no futuro é limitado ao momento do pedido.
+type AuditTrailExportPayload struct {
+	From time.Time `json:"from" binding:"required"`
+	To   time.Time `json:"to" binding:"required"`
+}
+
+// AuditTrailExportResponse é a exportação com as partes do manifesto, quando concluída.
+type AuditTrailExportResponse struct {
+	models.AuditTrailExport
+	Parts []audittrailexport.Part `json:"parts,omitempty"`
+}
+
+// auditTrailExportObject é o caminho de um arquivo da exportação no armazenamento.
+func auditTrailExportObject(export *models.AuditTrailExport, fileName string) string {
+	return fmt.Sprintf("%s/audit_trail_exports/%s/%s", export.OrganizationID.String(), export.ID.String(), fileName)
+}
+
+// auditTrailExportSigningKey é a chave da assinatura dos manifestos.
+func auditTrailExportSigningKey() []byte {
+	return []byte(config.Cfg.JWTSecret)
+}
+
+func decodeAuditTrailExportManifest(export *models.AuditTrailExport) (*audittrailexport.Manifest, error) {
+	if export.Manifest == nil {
+		return nil, nil
+	}
+	var manifest audittrailexport.Manifest
+	if err := json.Unmarshal([]byte(*export.Manifest), &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// auditTrailExportJob é o payload de JobAuditTrailExport.
+type auditTrailExportJob struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// runAuditTrailExportJob executa a exportação de um JobAuditTrailExport. Exportações já concluídas ou com
+// falha (ex: job repetido pelo administrador) não são refeitas.
+func runAuditTrailExportJob(ctx context.Context, job *models.BackgroundJob) error {
+	var p auditTrailExportJob
+	if err := jobs.DecodePayload(job, &p); err != nil {
+		return err
+	}
+	provider := filestorage.DefaultFileStorageProvider
+	if provider == nil {
+		return errors.New("file storage service is not configured")
+	}
+	db := database.GetDB()
+	var export models.AuditTrailExport
+	if err := db.WithContext(ctx).First(&export, "id = ?", p.ExportID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if export.Status != models.AuditTrailExportPending && export.Status != models.AuditTrailExportRunning {
+		return nil
+	}
+	runAuditTrailExport(ctx, db, provider, export)
+	return nil
+}
+
+// runAuditTrailExport gera as partes da exportação em ordem cronológica (created_at, id), grava o manifesto
+// assinado e marca a exportação como concluída; em caso de erro, marca-a como falha.
+func runAuditTrailExport(ctx context.Context, db *gorm.DB, provider filestorage.FileStorageProvider, export models.AuditTrailExport) {
+	startedAt := time.Now()
+	db.Model(&export).Updates(map[string]interface{}{"status": models.AuditTrailExportRunning, "started_at": startedAt})
+
+	manifest, err := writeAuditTrailExportParts(ctx, db, provider, &export)
+	if err == nil {
+		err = manifest.Sign(auditTrailExportSigningKey(), time.Now())
+	}
+	var raw []byte
+	if err == nil {
+		raw, err = json.MarshalIndent(manifest, "", "  ")
+	}
+	if err == nil {
+		_, err = provider.UploadFile(ctx, export.OrganizationID.String(), auditTrailExportObject(&export, "manifest.json"), bytes.NewReader(raw))
+	}
+	if err != nil {
+		phxlog.L.Error("Audit trail export failed",
+			zap.String("exportID", export.ID.String()),
+			zap.Error(err))
+		db.Model(&export).Updates(map[string]interface{}{"status": models.AuditTrailExportFailed, "error": err.Error(), "completed_at": time.Now()})
+		return
+	}
+	manifestJSON := string(raw)
+	db.Model(&export).Updates(map[string]interface{}{
+		"status":        models.AuditTrailExportCompleted,
+		"total_entries": manifest.TotalEntries,
+		"part_count":    len(manifest.Parts),
+		"manifest":      manifestJSON,
+		"completed_at":  time.Now(),
+	})
+	phxlog.L.Info("Audit trail export completed",
+		zap.String("exportID", export.ID.String()),
+		zap.Int("entries", manifest.TotalEntries),
+		zap.Int("parts", len(manifest.Parts)),
+		zap.Duration("duration", time.Since(startedAt)))
+}
+
+// writeAuditTrailExportParts lê a trilha por keyset (created_at, id) em blocos de PartSize registros e envia
+// cada bloco como uma parte.
+func writeAuditTrailExportParts(ctx context.Context, db *gorm.DB, provider filestorage.FileStorageProvider, export *models.AuditTrailExport) (*audittrailexport.Manifest, error) {
+	manifest := audittrailexport.NewManifest(export.ID, export.OrganizationID, export.From, export.To)
+	var last *models.AuditTrailEntry
+	for {
+		query := db.Where("organization_id = ? AND created_at >= ? AND created_at < ?", export.OrganizationID, export.From, export.To)
+		if last != nil {
+			query = query.Where("(created_at, id) > (?, ?)", last.CreatedAt, last.ID)
+		}
+		var entries []models.AuditTrailEntry
+		if err := query.Order("created_at asc, id asc").Limit(audittrailexport.PartSize).Find(&entries).Error; err != nil {
+			return nil, fmt.Errorf("failed to read audit trail: %w", err)
+		}
+		if len(entries) == 0 {
+			return manifest, nil
+		}
+		content, part, err := manifest.AddPart(entries)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := provider.UploadFile(ctx, export.OrganizationID.String(), auditTrailExportObject(export, part.FileName), bytes.NewReader(content)); err != nil {
+			return nil, fmt.Errorf("failed to upload %s: %w", part.FileName, err)
+		}
+		db.Model(export).Updates(map[string]interface{}{"total_entries": manifest.TotalEntries, "part_count": len(manifest.Parts)})
+		if len(entries) < audittrailexport.PartSize {
+			return manifest, nil
+		}
+		last = &entries[len(entries)-1]
+	}
+}
+
+// findOrgAuditTrailExport carrega a exportação :exportId da organização do token.
+func findOrgAuditTrailExport(c *gin.Context, db *gorm.DB) (*models.AuditTrailExport, bool) {
+	exportID, err := uuid.Parse(c.Param("exportId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var export models.AuditTrailExport
+	if err := db.Where("id = ? AND organization_id = ?", exportID, orgID).First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Audit trail export not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit trail export: " + err.Error()})
+		return nil, false
+	}
+	return &export, true
+}
+
+// CreateAuditTrailExportHandler starts the background generation of a signed CSV export of the organization's audit trail for [from, to).
+func CreateAuditTrailExportHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditTrailExport) {
+		return
+	}
+	var payload AuditTrailExportPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	now := time.Now()
+	if payload.To.After(now) {
+		payload.To = now
+	}
+	if !payload.From.Before(payload.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to and in the past"})
+		return
+	}
+	provider := filestorage.DefaultFileStorageProvider
+	if provider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+	orgID := c.MustGet("organizationID").(uuid.UUID)
+	userID := c.MustGet("userID").(uuid.UUID)
+	db := database.GetDB()
+
+	// Exportações sem progresso há mais de auditTrailExportStaleAfter (ex: servidor reiniciado) não bloqueiam novas
+	var running int64
+	db.Model(&models.AuditTrailExport{}).
+		Where("organization_id = ? AND status IN ? AND updated_at > ?", orgID,
+			[]models.AuditTrailExportStatus{models.AuditTrailExportPending, models.AuditTrailExportRunning}, now.Add(-auditTrailExportStaleAfter)).
+		Count(&running)
+	if running > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "An audit trail export is already in progress for this organization"})
+		return
+	}
+
+	export := models.AuditTrailExport{
+		OrganizationID: orgID,
+		RequestedByID:  userID,
+		From:           payload.From.UTC(),
+		To:             payload.To.UTC(),
+		Status:         models.AuditTrailExportPending,
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&export).Error; err != nil {
+			return err
+		}
+		// O pedido de exportação também entra na trilha (fica fora do período exportado se to for o momento atual)
+		_, err := recordAuditTrail(c, tx, orgID, models.AuditTrailExportRequested, "audit_trail_export", &export.ID,
+			fmt.Sprintf("Exportação da trilha de auditoria de %s a %s solicitada", export.From.Format(time.RFC3339), export.To.Format(time.RFC3339)),
+			gin.H{"from": export.From, "to": export.To})
+		if err != nil {
+			return err
+		}
+		_, err = jobs.Enqueue(c.Request.Context(), tx, JobAuditTrailExport, auditTrailExportJob{ExportID: export.ID}, jobs.WithOrganization(orgID))
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create audit trail export: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+// ListAuditTrailExportsHandler lists the organization's audit trail exports, newest first.
+func ListAuditTrailExportsHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditTrailExport) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Model(&models.AuditTrailExport{}).Where("organization_id = ?", orgID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	page, pageSize := GetPaginationParams(c)
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count audit trail exports: " + err.Error()})
+		return
+	}
+	var exports []models.AuditTrailExport
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at desc").Find(&exports).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit trail exports: " + err.Error()})
+		return
+	}
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: exports, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
+
+// GetAuditTrailExportHandler returns an audit trail export and, once completed, its parts.
+func GetAuditTrailExportHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditTrailExport) {
+		return
+	}
+	export, ok := findOrgAuditTrailExport(c, database.GetDB())
+	if !ok {
+		return
+	}
+	manifest, err := decodeAuditTrailExportManifest(export)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decode export manifest: " + err.Error()})
+		return
+	}
+	resp := AuditTrailExportResponse{AuditTrailExport: *export}
+	if manifest != nil {
+		resp.Parts = manifest.Parts
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetAuditTrailExportManifestHandler returns the signed integrity manifest of a completed export.
+func GetAuditTrailExportManifestHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditTrailExport) {
+		return
+	}
+	export, ok := findOrgAuditTrailExport(c, database.GetDB())
+	if !ok {
+		return
+	}
+	if export.Status != models.AuditTrailExportCompleted || export.Manifest == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Audit trail export is not completed", "status": export.Status})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=audit_trail_export_%s_manifest.json", export.ID.String()))
+	c.Data(http.StatusOK, "application/json", []byte(*export.Manifest))
+}
+
+// GetAuditTrailExportPartHandler returns a short-lived signed URL to download one CSV part of a completed export.
+func GetAuditTrailExportPartHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditTrailExport) {
+		return
+	}
+	export, ok := findOrgAuditTrailExport(c, database.GetDB())
+	if !ok {
+		return
+	}
+	if export.Status != models.AuditTrailExportCompleted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Audit trail export is not completed", "status": export.Status})
+		return
+	}
+	number, err := strconv.Atoi(c.Param("part"))
+	if err != nil || number < 1 || number > export.PartCount {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audit trail export part not found"})
+		return
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+	fileName := audittrailexport.PartFileName(number)
+	signedURL, err := filestorage.DefaultFileStorageProvider.GetSignedURL(c.Request.Context(), auditTrailExportObject(export, fileName), 15)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate download URL: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": signedURL, "file_name": fileName, "expires_in_minutes": 15})
+}
+
+// VerifyAuditTrailExportManifestHandler checks the signature of a manifest issued by this server, e.g. one handed to an examiner.
+func VerifyAuditTrailExportManifestHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditTrailView) {
+		return
+	}
+	var manifest audittrailexport.Manifest
+	if err := c.ShouldBindJSON(&manifest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID := c.MustGet("organizationID").(uuid.UUID)
+	if manifest.OrganizationID != orgID {
+		c.JSON(http.StatusOK, gin.H{"valid": false, "error": "Manifest belongs to another organization"})
+		return
+	}
+	if err := manifest.Verify(auditTrailExportSigningKey()); err != nil {
+		c.JSON(http.StatusOK, gin.H{"valid": false, "error": "Invalid manifest signature"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"valid": true, "export_id": manifest.ExportID, "total_entries": manifest.TotalEntries, "parts": len(manifest.Parts)})
+}
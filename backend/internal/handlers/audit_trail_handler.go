@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditTrailEntryResponse é um registro da trilha de auditoria com o relatório em JSON.
+type AuditTrailEntryResponse struct {
+	models.AuditTrailEntry
+	Details json.RawMessage `json:"details"`
+}
+
+func newAuditTrailEntryResponse(entry models.AuditTrailEntry) AuditTrailEntryResponse {
+	resp := AuditTrailEntryResponse{AuditTrailEntry: entry, Details: json.RawMessage("null")}
+	if entry.Details != nil {
+		resp.Details = json.RawMessage(*entry.Details)
+	}
+	return resp
+}
+
+// recordAuditTrail grava um registro na trilha de auditoria com o usuário autenticado como autor. Deve ser
+// chamado na mesma transação da alteração: sem o registro, a alteração não é aplicada.
+func recordAuditTrail(c *gin.Context, tx *gorm.DB, organizationID uuid.UUID, action models.AuditTrailAction, entityType string, entityID *uuid.UUID, summary string, details interface{}) (*models.AuditTrailEntry, error) {
+	entry := models.AuditTrailEntry{
+		OrganizationID: organizationID,
+		Action:         action,
+		EntityType:     entityType,
+		EntityID:       entityID,
+		Summary:        summary,
+	}
+	if userID, ok := c.Get("userID"); ok {
+		if id, ok := userID.(uuid.UUID); ok {
+			entry.ActorID = &id
+		}
+	}
+	if details != nil {
+		raw, err := json.Marshal(details)
+		if err != nil {
+			return nil, err
+		}
+		detailsJSON := string(raw)
+		entry.Details = &detailsJSON
+	}
+	if err := tx.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListAuditTrailHandler lists the organization's audit trail, newest first.
+// Filtros opcionais: action, actor_id, entity_id, from e to (RFC3339).
+func ListAuditTrailHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditTrailView) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Model(&models.AuditTrailEntry{}).Where("organization_id = ?", orgID)
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	for _, param := range []string{"actor_id", "entity_id"} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " format"})
+			return
+		}
+		query = query.Where(param+" = ?", id)
+	}
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from format, expected RFC3339"})
+			return
+		}
+		query = query.Where("created_at >= ?", from)
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to format, expected RFC3339"})
+			return
+		}
+		query = query.Where("created_at < ?", to)
+	}
+
+	page, pageSize := GetPaginationParams(c)
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count audit trail entries: " + err.Error()})
+		return
+	}
+	var entries []models.AuditTrailEntry
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at desc").Find(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit trail entries: " + err.Error()})
+		return
+	}
+	items := make([]AuditTrailEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, newAuditTrailEntryResponse(entry))
+	}
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: items, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
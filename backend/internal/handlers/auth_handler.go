@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json" // Added for backup codes
 	"net/http"
+	"time"
 	"phoenixgrc/backend/internal/auth"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
@@ -14,6 +15,7 @@ import (
 	"github.com/google/uuid" // Added for parsing UserID
 	"github.com/pquerna/otp/totp" // Added for TOTP validation
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type LoginPayload struct {
@@ -22,12 +24,55 @@ type LoginPayload struct {
 }
 
 type LoginResponse struct {
-	Token          string          `json:"token"`
-	UserID         string          `json:"user_id"`
-	Email          string          `json:"email"`
-	Name           string          `json:"name"`
-	Role           models.UserRole `json:"role"`
-	OrganizationID string          `json:"organization_id"`
+	Token                 string          `json:"token"`
+	ExpiresAt             time.Time       `json:"expires_at"`
+	RefreshToken          string          `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time       `json:"refresh_token_expires_at"`
+	SessionID             string          `json:"session_id"`
+	UserID                string          `json:"user_id"`
+	Email                 string          `json:"email"`
+	Name                  string          `json:"name"`
+	Role                  models.UserRole `json:"role"`
+	OrganizationID        string          `json:"organization_id"`
+	// The tokens only work on the 2FA setup routes until the user enrolls the factor the organization requires
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty"`
+}
+
+// respondWithNewSession inicia uma sessão de login para o usuário e responde com os tokens. Se faltar ao usuário
+// o segundo fator exigido pela política de MFA da organização, a sessão fica restrita ao cadastro dele.
+func respondWithNewSession(c *gin.Context, db *gorm.DB, user *models.User) {
+	enrollment, err := mfaEnrollmentRequired(db, user)
+	if err != nil {
+		phxlog.L.Error("Failed to check MFA policy", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return
+	}
+	startSession := auth.StartSession
+	if enrollment {
+		startSession = auth.StartEnrollmentSession
+	}
+	pair, err := startSession(db, user, user.OrganizationID.UUID, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newLoginResponse(pair, user))
+}
+
+func newLoginResponse(pair *auth.TokenPair, user *models.User) LoginResponse {
+	return LoginResponse{
+		Token:                 pair.AccessToken,
+		ExpiresAt:             pair.AccessTokenExpiresAt,
+		RefreshToken:          pair.RefreshToken,
+		RefreshTokenExpiresAt: pair.RefreshTokenExpiresAt,
+		SessionID:             pair.SessionID.String(),
+		UserID:                user.ID.String(),
+		Email:                 user.Email,
+		Name:                  user.Name,
+		Role:                  user.Role,
+		OrganizationID:        nullUUIDToString(user.OrganizationID),
+		MFAEnrollmentRequired: pair.Scope == models.SessionScopeMFAEnrollment,
+	}
 }
 
 // LoginHandler lida com o login do usuário.
@@ -49,10 +94,13 @@ func LoginHandler(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User account is inactive"})
 		return
 	}
+	if respondIfLoginLocked(c, &user) {
+		return
+	}
 
 	err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(payload.Password))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		handleLoginFailure(c, database.DB, &user, "Invalid email or password")
 		return
 	}
 
@@ -62,39 +110,36 @@ func LoginHandler(c *gin.Context) {
 		return
 	}
 
-	tokenString, err := auth.GenerateToken(&user, user.OrganizationID.UUID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token: " + err.Error()})
+	// A organização pode exigir SSO (configuração da tela de login)
+	if disabled, err := passwordLoginDisabled(database.DB, &user); err != nil {
+		phxlog.L.Error("Failed to check login page settings", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return
+	} else if disabled {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Password login is disabled for your organization; use single sign-on"})
+		return
+	}
+
+	// Senha vencida pela política da organização: a troca é feita em /auth/change-password
+	if expired, err := passwordExpired(database.DB, &user); err != nil {
+		phxlog.L.Error("Failed to check password expiration", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return
+	} else if expired {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Password has expired and must be changed", "password_expired": true})
 		return
 	}
 
-	// Check if 2FA/TOTP is enabled for the user
-	if user.IsTOTPEnabled {
-		// Do not issue the full JWT token yet.
-		// Issue a temporary token or indicate that 2FA is required.
-		// For simplicity, we'll return a specific response.
-		// The frontend will then prompt for the TOTP code and make a new request.
-		c.JSON(http.StatusOK, gin.H{ // Could be a different status code like 202 Accepted or a custom one if needed
-			"2fa_required": true,
-			"user_id":      user.ID.String(),
-			"message":      "Password verified. Please provide TOTP token.",
-		})
+	// Segundo fator: TOTP, códigos de backup ou passkeys (a política de MFA pode exigir passkey dos administradores).
+	// O frontend pede o fator escolhido e chama a rota de verificação correspondente. Sem nenhum fator, a política
+	// pode restringir a sessão ao cadastro de MFA (ver respondWithNewSession).
+	if respondWithSecondFactorChallenge(c, database.DB, &user) {
 		return
 	}
 
 	// If 2FA is not enabled, proceed with normal login and token issuance
-	var orgIDStr string
-	if user.OrganizationID.Valid {
-		orgIDStr = user.OrganizationID.UUID.String()
-	}
-	c.JSON(http.StatusOK, LoginResponse{
-		Token:          tokenString,
-		UserID:         user.ID.String(),
-		Email:          user.Email,
-		Name:           user.Name,
-		Role:           user.Role,
-		OrganizationID: nullUUIDToString(user.OrganizationID),
-	})
+	loginSucceeded(database.DB, &user)
+	respondWithNewSession(c, database.DB, &user)
 }
 
 type LoginVerifyBackupCodePayload struct {
@@ -128,6 +173,14 @@ func LoginVerifyBackupCodeHandler(c *gin.Context) {
 		return
 	}
 
+	if respondIfLoginLocked(c, &user) {
+		return
+	}
+
+	if respondIfWebAuthnRequired(c, db, &user) {
+		return
+	}
+
 	if !user.IsTOTPEnabled || user.TOTPBackupCodes == "" {
 		c.JSON(http.StatusForbidden, gin.H{"error": "2FA / Backup codes not enabled or not generated for this user."})
 		return
@@ -153,7 +206,7 @@ func LoginVerifyBackupCodeHandler(c *gin.Context) {
 	}
 
 	if !validCodeFound {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid backup code."})
+		handleLoginFailure(c, db, &user, "Invalid backup code.")
 		return
 	}
 
@@ -177,24 +230,8 @@ func LoginVerifyBackupCodeHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "User is not associated with an organization"})
 		return
 	}
-	tokenString, err := auth.GenerateToken(&user, user.OrganizationID.UUID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token: " + err.Error()})
-		return
-	}
-
-	var orgIDStr string
-	if user.OrganizationID.Valid {
-		orgIDStr = user.OrganizationID.UUID.String()
-	}
-	c.JSON(http.StatusOK, LoginResponse{
-		Token:          tokenString,
-		UserID:         user.ID.String(),
-		Email:          user.Email,
-		Name:           user.Name,
-		Role:           user.Role,
-		OrganizationID: nullUUIDToString(user.OrganizationID),
-	})
+	loginSucceeded(db, &user)
+	respondWithNewSession(c, db, &user)
 }
 
 // nullUUIDToString converts a uuid.NullUUID to a string.
@@ -240,6 +277,14 @@ func LoginVerifyTOTPHandler(c *gin.Context) {
 		return
 	}
 
+	if respondIfLoginLocked(c, &user) {
+		return
+	}
+
+	if respondIfWebAuthnRequired(c, db, &user) {
+		return
+	}
+
 	if !user.IsTOTPEnabled || user.TOTPSecret == "" {
 		// This state should ideally not be reachable if LoginHandler directed here,
 		// but good to double-check.
@@ -256,7 +301,7 @@ func LoginVerifyTOTPHandler(c *gin.Context) {
 
 	valid := totp.Validate(payload.Token, decryptedSecret)
 	if !valid {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid TOTP token"})
+		handleLoginFailure(c, db, &user, "Invalid TOTP token")
 		return
 	}
 
@@ -266,22 +311,6 @@ func LoginVerifyTOTPHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "User is not associated with an organization"})
 		return
 	}
-	tokenString, err := auth.GenerateToken(&user, user.OrganizationID.UUID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token: " + err.Error()})
-		return
-	}
-
-	var orgIDStr string
-	if user.OrganizationID.Valid {
-		orgIDStr = user.OrganizationID.UUID.String()
-	}
-	c.JSON(http.StatusOK, LoginResponse{
-		Token:          tokenString,
-		UserID:         user.ID.String(),
-		Email:          user.Email,
-		Name:           user.Name,
-		Role:           user.Role,
-		OrganizationID: nullUUIDToString(user.OrganizationID),
-	})
+	loginSucceeded(db, &user)
+	respondWithNewSession(c, db, &user)
 }
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// BackgroundJobStatsResponse resume a fila de jobs.
+type BackgroundJobStatsResponse struct {
+	Counts          []jobs.Stat `json:"counts"`
+	OldestDueAt     *time.Time  `json:"oldest_due_at"`
+	LagSeconds      int64       `json:"lag_seconds"`      // Atraso do job vencido mais antigo
+	RegisteredTypes []string    `json:"registered_types"` // Tipos com handler registrado nesta instância
+}
+
+// ListBackgroundJobsHandler lists background jobs, newest first. Filtros opcionais: status, type, organization_id.
+func ListBackgroundJobsHandler(c *gin.Context) {
+	query := database.GetDB().Model(&models.BackgroundJob{})
+	if status := c.Query("status"); status != "" {
+		switch models.BackgroundJobStatus(status) {
+		case models.JobQueued, models.JobRunning, models.JobSucceeded, models.JobDead, models.JobCanceled:
+			query = query.Where("status = ?", status)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status filter"})
+			return
+		}
+	}
+	if jobType := c.Query("type"); jobType != "" {
+		query = query.Where("type = ?", jobType)
+	}
+	if raw := c.Query("organization_id"); raw != "" {
+		orgID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization_id format"})
+			return
+		}
+		query = query.Where("organization_id = ?", orgID)
+	}
+
+	page, pageSize := GetPaginationParams(c)
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count background jobs: " + err.Error()})
+		return
+	}
+	backgroundJobs := []models.BackgroundJob{}
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at desc").Find(&backgroundJobs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list background jobs: " + err.Error()})
+		return
+	}
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: backgroundJobs, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
+
+// GetBackgroundJobStatsHandler returns the number of jobs by type and status and the queue lag.
+func GetBackgroundJobStatsHandler(c *gin.Context) {
+	db := database.GetDB()
+	stats, err := jobs.Stats(c.Request.Context(), db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count background jobs: " + err.Error()})
+		return
+	}
+	now := time.Now()
+	oldest, err := jobs.OldestDue(c.Request.Context(), db, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute background job queue lag: " + err.Error()})
+		return
+	}
+	resp := BackgroundJobStatsResponse{Counts: stats, OldestDueAt: oldest, RegisteredTypes: jobs.RegisteredTypes()}
+	if oldest != nil {
+		resp.LagSeconds = int64(now.Sub(*oldest).Seconds())
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetBackgroundJobHandler returns one background job with its payload and last error.
+func GetBackgroundJobHandler(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("jobId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job UUID format"})
+		return
+	}
+	var job models.BackgroundJob
+	if err := database.GetDB().First(&job, "id = ?", jobID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Background job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch background job: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// RetryBackgroundJobHandler puts a dead or canceled job back in the queue with its attempts reset.
+func RetryBackgroundJobHandler(c *gin.Context) {
+	changeBackgroundJob(c, "retry", jobs.Retry)
+}
+
+// CancelBackgroundJobHandler cancels a queued job (including one waiting for a retry).
+func CancelBackgroundJobHandler(c *gin.Context) {
+	changeBackgroundJob(c, "cancel", jobs.Cancel)
+}
+
+func changeBackgroundJob(c *gin.Context, operation string, change func(ctx context.Context, db *gorm.DB, id uuid.UUID, now time.Time) (*models.BackgroundJob, error)) {
+	jobID, err := uuid.Parse(c.Param("jobId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job UUID format"})
+		return
+	}
+	job, err := change(c.Request.Context(), database.GetDB(), jobID, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, jobs.ErrJobNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Background job not found"})
+		case errors.Is(err, jobs.ErrInvalidJobState):
+			c.JSON(http.StatusConflict, gin.H{"error": "Background job cannot be changed in its current status: " + string(job.Status)})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update background job: " + err.Error()})
+		}
+		return
+	}
+	userID, _ := c.Get("userID")
+	phxlog.L.Info("Background job changed by admin",
+		zap.String("operation", operation),
+		zap.String("jobID", job.ID.String()),
+		zap.String("type", job.Type),
+		zap.Any("userID", userID))
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/preflight"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Operações em lote com verificação prévia (preflight) e token de confirmação.
+const (
+	bulkOperationRiskCSVUpload       = "risk_csv_upload"
+	bulkOperationVulnerabilityImport = "vulnerability_import"
+	bulkOperationPolicyAckCampaign   = "policy_ack_campaign"
+	bulkOperationPolicyAckReminders  = "policy_ack_reminders"
+	bulkOperationUserRoleAssignment  = "user_role_assignment"
+	bulkOperationRiskBulkUpdate      = "risk_bulk_operation"
+)
+
+// confirmTokenHeader carrega o token de confirmação emitido pelo endpoint de preflight.
+const confirmTokenHeader = "X-Confirm-Token"
+
+// bulkOperationScope vincula o token à organização e ao usuário autenticados.
+func bulkOperationScope(c *gin.Context, operation, fingerprint string) preflight.Scope {
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	return preflight.Scope{
+		OrganizationID: orgID.(uuid.UUID),
+		UserID:         userID.(uuid.UUID),
+		Operation:      operation,
+		Fingerprint:    fingerprint,
+	}
+}
+
+// evaluateBulkOperation aplica o limite de confirmação e a cota diária de notificações da organização.
+func evaluateBulkOperation(db *gorm.DB, organizationID uuid.UUID, estimate *preflight.Estimate) {
+	var quota *preflight.QuotaImpact
+	if config.Cfg.NotificationDailyQuota > 0 {
+		var used int64
+		err := db.Model(&models.BulkOperationLog{}).
+			Where("organization_id = ? AND created_at >= ?", organizationID, time.Now().Add(-24*time.Hour)).
+			Select("COALESCE(SUM(notifications), 0)").Scan(&used).Error
+		if err != nil {
+			phxlog.L.Warn("Failed to compute notification quota usage", zap.String("organizationID", organizationID.String()), zap.Error(err))
+		}
+		quota = preflight.NewQuotaImpact(config.Cfg.NotificationDailyQuota, used, int64(estimate.Notifications))
+	}
+	estimate.Evaluate(config.Cfg.BulkConfirmThreshold, quota)
+}
+
+// evaluateBulkPreflight avalia a estimativa; quando a operação exige confirmação, inclui o token
+// vinculado ao usuário e ao conteúdo exato da requisição.
+func evaluateBulkPreflight(c *gin.Context, db *gorm.DB, estimate *preflight.Estimate, fingerprint string) {
+	scope := bulkOperationScope(c, estimate.Operation, fingerprint)
+	evaluateBulkOperation(db, scope.OrganizationID, estimate)
+	if estimate.RequiresConfirmation {
+		token, expiresAt := preflight.IssueToken([]byte(config.Cfg.JWTSecret), scope, time.Now())
+		estimate.ConfirmToken = token
+		estimate.ConfirmTokenExpiresAt = &expiresAt
+	}
+}
+
+// respondBulkPreflight responde a estimativa avaliada por evaluateBulkPreflight.
+func respondBulkPreflight(c *gin.Context, db *gorm.DB, estimate preflight.Estimate, fingerprint string) {
+	evaluateBulkPreflight(c, db, &estimate, fingerprint)
+	c.JSON(http.StatusOK, estimate)
+}
+
+// confirmBulkOperation exige o token de confirmação quando a operação está acima do limite.
+// Sem token válido responde 428 com a estimativa. Retorna se a operação foi confirmada e se pode prosseguir.
+func confirmBulkOperation(c *gin.Context, db *gorm.DB, estimate *preflight.Estimate, fingerprint string) (bool, bool) {
+	scope := bulkOperationScope(c, estimate.Operation, fingerprint)
+	evaluateBulkOperation(db, scope.OrganizationID, estimate)
+	if !estimate.RequiresConfirmation {
+		return false, true
+	}
+	if err := preflight.VerifyToken([]byte(config.Cfg.JWTSecret), scope, c.GetHeader(confirmTokenHeader), time.Now()); err != nil {
+		c.JSON(http.StatusPreconditionRequired, gin.H{
+			"error":     "This bulk operation requires confirmation (run the preflight check and send its token in " + confirmTokenHeader + "): " + err.Error(),
+			"preflight": estimate,
+		})
+		return false, false
+	}
+	return true, true
+}
+
+// recordBulkOperation registra a operação executada; falhas são apenas registradas em log.
+func recordBulkOperation(c *gin.Context, db *gorm.DB, operation string, affectedRecords, notifications int, confirmed bool) {
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	entry := models.BulkOperationLog{
+		OrganizationID:  orgID.(uuid.UUID),
+		Operation:       operation,
+		AffectedRecords: affectedRecords,
+		Notifications:   notifications,
+		Confirmed:       confirmed,
+		PerformedByID:   userID.(uuid.UUID),
+	}
+	if err := db.Create(&entry).Error; err != nil {
+		phxlog.L.Error("Failed to record bulk operation", zap.String("operation", operation), zap.Error(err))
+	}
+}
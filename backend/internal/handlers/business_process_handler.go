@@ -0,0 +1,573 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BusinessProcessPayload defines the structure for creating or updating a business process.
+// asset_ids/vendor_ids substituem as dependências quando enviados; omitidos, as dependências são mantidas.
+type BusinessProcessPayload struct {
+	Name           string                  `json:"name" binding:"required,min=2,max=255"`
+	Description    string                  `json:"description"`
+	OwnerID        string                  `json:"owner_id"`
+	Criticality    models.AssetCriticality `json:"criticality" binding:"omitempty,oneof=low medium high critical"`
+	RTOHours       *int                    `json:"rto_hours" binding:"required,min=0"`
+	RPOHours       *int                    `json:"rpo_hours" binding:"required,min=0"`
+	MTPDHours      *int                    `json:"mtpd_hours" binding:"omitempty,min=0"`
+	ImpactNotes    string                  `json:"impact_notes"`
+	LastReviewedAt *time.Time              `json:"last_reviewed_at"`
+	AssetIDs       []string                `json:"asset_ids"`
+	VendorIDs      []string                `json:"vendor_ids"`
+}
+
+// BusinessProcessAssetSummary é um ativo do qual o processo depende.
+type BusinessProcessAssetSummary struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Criticality string    `json:"criticality"`
+}
+
+// BusinessProcessVendorSummary é um fornecedor do qual o processo depende.
+type BusinessProcessVendorSummary struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Criticality string    `json:"criticality"`
+}
+
+// BusinessProcessDetailResponse é o processo com suas dependências.
+type BusinessProcessDetailResponse struct {
+	models.BusinessProcess
+	Assets  []BusinessProcessAssetSummary  `json:"assets"`
+	Vendors []BusinessProcessVendorSummary `json:"vendors"`
+}
+
+// BIADependencyRisk é um risco aberto em um ativo ou fornecedor do qual o processo depende.
+type BIADependencyRisk struct {
+	ProcessID      uuid.UUID `json:"-"`
+	RiskID         uuid.UUID `json:"risk_id"`
+	Title          string    `json:"title"`
+	RiskLevel      string    `json:"risk_level"`
+	Impact         string    `json:"impact"`
+	Status         string    `json:"status"`
+	DependencyType string    `json:"dependency_type"` // asset ou vendor
+	DependencyID   uuid.UUID `json:"dependency_id"`
+	DependencyName string    `json:"dependency_name"`
+}
+
+// BIAProcessEntry é a linha do relatório BIA para um processo.
+type BIAProcessEntry struct {
+	ID          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	Criticality string              `json:"criticality"`
+	OwnerID     *uuid.UUID          `json:"owner_id,omitempty"`
+	RTOHours    int                 `json:"rto_hours"`
+	RPOHours    int                 `json:"rpo_hours"`
+	MTPDHours   *int                `json:"mtpd_hours,omitempty"`
+	AssetCount  int                 `json:"asset_count"`
+	VendorCount int                 `json:"vendor_count"`
+	AtRisk      bool                `json:"at_risk"`
+	OpenRisks   []BIADependencyRisk `json:"open_risks"`
+}
+
+// BIAReportSummary totaliza o relatório BIA.
+type BIAReportSummary struct {
+	TotalProcesses      int `json:"total_processes"`
+	AtRiskProcesses     int `json:"at_risk_processes"`
+	WithoutDependencies int `json:"without_dependencies"`
+	DistinctRisks       int `json:"distinct_risks"`
+}
+
+// BIAReportResponse é o relatório de análise de impacto no negócio.
+type BIAReportResponse struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	RiskLevels  []string          `json:"risk_levels"`
+	Summary     BIAReportSummary  `json:"summary"`
+	Processes   []BIAProcessEntry `json:"processes"`
+}
+
+// biaOpenRiskStatuses são os status considerados "abertos" no relatório BIA.
+var biaOpenRiskStatuses = []models.RiskStatus{models.StatusOpen, models.StatusInProgress}
+
+// biaRiskLevelsFrom retorna os níveis de risco a partir do mínimo informado (ex: Alto -> Alto, Extremo).
+func biaRiskLevelsFrom(minLevel string) ([]string, error) {
+	levels := []string{models.RiskLevelLow, models.RiskLevelModerate, models.RiskLevelHigh, models.RiskLevelExtreme}
+	for i, level := range levels {
+		if strings.EqualFold(level, minLevel) {
+			return levels[i:], nil
+		}
+	}
+	return nil, fmt.Errorf("invalid min_risk_level '%s' (expected Baixo, Moderado, Alto or Extremo)", minLevel)
+}
+
+// criticalityRank ordena a criticidade do mais para o menos crítico.
+func criticalityRank(criticality models.AssetCriticality) int {
+	switch criticality {
+	case models.AssetCriticalityCritical:
+		return 0
+	case models.AssetCriticalityHigh:
+		return 1
+	case models.AssetCriticalityMedium:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// applyBusinessProcessPayload copia o payload para o processo, validando responsável e MTPD.
+func applyBusinessProcessPayload(db *gorm.DB, process *models.BusinessProcess, payload BusinessProcessPayload) error {
+	process.Name = strings.TrimSpace(payload.Name)
+	process.Description = payload.Description
+	process.ImpactNotes = payload.ImpactNotes
+	process.RTOHours = *payload.RTOHours
+	process.RPOHours = *payload.RPOHours
+	process.MTPDHours = payload.MTPDHours
+	process.LastReviewedAt = payload.LastReviewedAt
+	if payload.Criticality != "" {
+		process.Criticality = payload.Criticality
+	}
+	if process.MTPDHours != nil && *process.MTPDHours < process.RTOHours {
+		return fmt.Errorf("mtpd_hours must be greater than or equal to rto_hours")
+	}
+	process.OwnerID = nil
+	if payload.OwnerID != "" {
+		ownerID, err := parseOrgUserID(db, process.OrganizationID, payload.OwnerID)
+		if err != nil {
+			return fmt.Errorf("invalid owner_id: %w", err)
+		}
+		process.OwnerID = &ownerID
+	}
+	return nil
+}
+
+// parseBusinessProcessDependencies valida que os ativos e fornecedores pertencem à organização.
+func parseBusinessProcessDependencies(db *gorm.DB, orgID uuid.UUID, payload BusinessProcessPayload) (assetIDs, vendorIDs []uuid.UUID, err error) {
+	if payload.AssetIDs != nil {
+		if assetIDs, err = parseUniqueUUIDs(payload.AssetIDs, "asset_id"); err != nil {
+			return nil, nil, err
+		}
+		if len(assetIDs) > 0 {
+			var count int64
+			db.Model(&models.Asset{}).Where("id IN ? AND organization_id = ?", assetIDs, orgID).Count(&count)
+			if int(count) != len(assetIDs) {
+				return nil, nil, fmt.Errorf("one or more assets were not found in your organization")
+			}
+		}
+	}
+	if payload.VendorIDs != nil {
+		if vendorIDs, err = parseUniqueUUIDs(payload.VendorIDs, "vendor_id"); err != nil {
+			return nil, nil, err
+		}
+		if len(vendorIDs) > 0 {
+			var count int64
+			db.Model(&models.Vendor{}).Where("id IN ? AND organization_id = ?", vendorIDs, orgID).Count(&count)
+			if int(count) != len(vendorIDs) {
+				return nil, nil, fmt.Errorf("one or more vendors were not found in your organization")
+			}
+		}
+	}
+	return assetIDs, vendorIDs, nil
+}
+
+// saveBusinessProcess grava o processo e, quando enviadas no payload, substitui as dependências.
+func saveBusinessProcess(db *gorm.DB, process *models.BusinessProcess, payload BusinessProcessPayload, assetIDs, vendorIDs []uuid.UUID, create bool) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if create {
+			if err := tx.Create(process).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Save(process).Error; err != nil {
+			return err
+		}
+		if payload.AssetIDs != nil {
+			if err := tx.Where("business_process_id = ?", process.ID).Delete(&models.BusinessProcessAsset{}).Error; err != nil {
+				return err
+			}
+			if len(assetIDs) > 0 {
+				links := make([]models.BusinessProcessAsset, 0, len(assetIDs))
+				for _, id := range assetIDs {
+					links = append(links, models.BusinessProcessAsset{BusinessProcessID: process.ID, AssetID: id})
+				}
+				if err := tx.Create(&links).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if payload.VendorIDs != nil {
+			if err := tx.Where("business_process_id = ?", process.ID).Delete(&models.BusinessProcessVendor{}).Error; err != nil {
+				return err
+			}
+			if len(vendorIDs) > 0 {
+				links := make([]models.BusinessProcessVendor, 0, len(vendorIDs))
+				for _, id := range vendorIDs {
+					links = append(links, models.BusinessProcessVendor{BusinessProcessID: process.ID, VendorID: id})
+				}
+				if err := tx.Create(&links).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// businessProcessNameTaken verifica se outro processo da organização já usa o nome.
+func businessProcessNameTaken(db *gorm.DB, process *models.BusinessProcess) bool {
+	var count int64
+	db.Model(&models.BusinessProcess{}).Where("organization_id = ? AND name = ? AND id <> ?", process.OrganizationID, process.Name, process.ID).Count(&count)
+	return count > 0
+}
+
+// findOrgBusinessProcess carrega o processo de :processId na organização do usuário.
+func findOrgBusinessProcess(c *gin.Context, db *gorm.DB) (*models.BusinessProcess, bool) {
+	processID, err := uuid.Parse(c.Param("processId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid business process ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var process models.BusinessProcess
+	if err := db.Where("id = ? AND organization_id = ?", processID, orgID).First(&process).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Business process not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch business process: " + err.Error()})
+		return nil, false
+	}
+	return &process, true
+}
+
+// loadBusinessProcessDetail carrega os ativos e fornecedores dos quais o processo depende.
+func loadBusinessProcessDetail(db *gorm.DB, process models.BusinessProcess) (BusinessProcessDetailResponse, error) {
+	detail := BusinessProcessDetailResponse{
+		BusinessProcess: process,
+		Assets:          []BusinessProcessAssetSummary{},
+		Vendors:         []BusinessProcessVendorSummary{},
+	}
+	err := db.Table("assets").
+		Select("assets.id, assets.name, assets.type, assets.criticality").
+		Joins("JOIN business_process_assets ON business_process_assets.asset_id = assets.id").
+		Where("business_process_assets.business_process_id = ?", process.ID).
+		Order("assets.name asc").
+		Scan(&detail.Assets).Error
+	if err != nil {
+		return detail, err
+	}
+	err = db.Table("vendors").
+		Select("vendors.id, vendors.name, vendors.criticality").
+		Joins("JOIN business_process_vendors ON business_process_vendors.vendor_id = vendors.id").
+		Where("business_process_vendors.business_process_id = ?", process.ID).
+		Order("vendors.name asc").
+		Scan(&detail.Vendors).Error
+	return detail, err
+}
+
+// respondBusinessProcessDetail responde com o processo e suas dependências.
+func respondBusinessProcessDetail(c *gin.Context, db *gorm.DB, status int, process models.BusinessProcess) {
+	detail, err := loadBusinessProcessDetail(db, process)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load business process dependencies: " + err.Error()})
+		return
+	}
+	c.JSON(status, detail)
+}
+
+// CreateBusinessProcessHandler registers a business process with its RTO/RPO and dependencies.
+func CreateBusinessProcessHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.BusinessProcessesManage) {
+		return
+	}
+	var payload BusinessProcessPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	process := models.BusinessProcess{OrganizationID: orgID.(uuid.UUID), Criticality: models.AssetCriticalityMedium}
+	if err := applyBusinessProcessPayload(db, &process, payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if businessProcessNameTaken(db, &process) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A business process with this name already exists in your organization"})
+		return
+	}
+	assetIDs, vendorIDs, err := parseBusinessProcessDependencies(db, process.OrganizationID, payload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := saveBusinessProcess(db, &process, payload, assetIDs, vendorIDs, true); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create business process: " + err.Error()})
+		return
+	}
+	respondBusinessProcessDetail(c, db, http.StatusCreated, process)
+}
+
+// ListBusinessProcessesHandler lists the organization's business processes with pagination.
+// Filtros: criticality, owner_id, search (nome), asset_id, vendor_id.
+func ListBusinessProcessesHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	page, pageSize := GetPaginationParams(c)
+
+	query := db.Model(&models.BusinessProcess{}).Where("business_processes.organization_id = ?", orgID)
+	for _, filter := range []string{"criticality", "owner_id"} {
+		if value := c.Query(filter); value != "" {
+			query = query.Where("business_processes."+filter+" = ?", value)
+		}
+	}
+	if search := c.Query("search"); search != "" {
+		query = query.Where("business_processes.name ILIKE ?", "%"+search+"%")
+	}
+	if assetID := c.Query("asset_id"); assetID != "" {
+		query = query.Where("business_processes.id IN (?)", db.Model(&models.BusinessProcessAsset{}).Select("business_process_id").Where("asset_id = ?", assetID))
+	}
+	if vendorID := c.Query("vendor_id"); vendorID != "" {
+		query = query.Where("business_processes.id IN (?)", db.Model(&models.BusinessProcessVendor{}).Select("business_process_id").Where("vendor_id = ?", vendorID))
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count business processes: " + err.Error()})
+		return
+	}
+	var processes []models.BusinessProcess
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("business_processes.name asc").Find(&processes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list business processes: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      processes,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// GetBusinessProcessHandler returns a business process with its asset and vendor dependencies.
+func GetBusinessProcessHandler(c *gin.Context) {
+	db := database.GetDB()
+	process, ok := findOrgBusinessProcess(c, db)
+	if !ok {
+		return
+	}
+	respondBusinessProcessDetail(c, db, http.StatusOK, *process)
+}
+
+// UpdateBusinessProcessHandler updates a business process.
+func UpdateBusinessProcessHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.BusinessProcessesManage) {
+		return
+	}
+	var payload BusinessProcessPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	process, ok := findOrgBusinessProcess(c, db)
+	if !ok {
+		return
+	}
+	if err := applyBusinessProcessPayload(db, process, payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if businessProcessNameTaken(db, process) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A business process with this name already exists in your organization"})
+		return
+	}
+	assetIDs, vendorIDs, err := parseBusinessProcessDependencies(db, process.OrganizationID, payload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := saveBusinessProcess(db, process, payload, assetIDs, vendorIDs, false); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update business process: " + err.Error()})
+		return
+	}
+	respondBusinessProcessDetail(c, db, http.StatusOK, *process)
+}
+
+// DeleteBusinessProcessHandler deletes a business process and its dependency links.
+func DeleteBusinessProcessHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.BusinessProcessesManage) {
+		return
+	}
+	db := database.GetDB()
+	process, ok := findOrgBusinessProcess(c, db)
+	if !ok {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("business_process_id = ?", process.ID).Delete(&models.BusinessProcessAsset{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("business_process_id = ?", process.ID).Delete(&models.BusinessProcessVendor{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(process).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete business process: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Business process deleted successfully"})
+}
+
+// sortBIAEntries ordena o relatório: processos em risco primeiro, depois por criticidade, menor RTO e nome.
+func sortBIAEntries(entries []BIAProcessEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.AtRisk != b.AtRisk {
+			return a.AtRisk
+		}
+		if ra, rb := criticalityRank(models.AssetCriticality(a.Criticality)), criticalityRank(models.AssetCriticality(b.Criticality)); ra != rb {
+			return ra < rb
+		}
+		if a.RTOHours != b.RTOHours {
+			return a.RTOHours < b.RTOHours
+		}
+		return a.Name < b.Name
+	})
+}
+
+// GetBIAReportHandler builds the business impact analysis report: every process with its RTO/RPO and the
+// open risks (status aberto/em_andamento, nível >= min_risk_level, padrão Alto) carried by the assets and
+// vendors it depends on. Filtros: min_risk_level, criticality, at_risk_only=true.
+func GetBIAReportHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	riskLevels, err := biaRiskLevelsFrom(c.DefaultQuery("min_risk_level", models.RiskLevelHigh))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := db.Where("organization_id = ?", orgID)
+	if criticality := c.Query("criticality"); criticality != "" {
+		query = query.Where("criticality = ?", criticality)
+	}
+	var processes []models.BusinessProcess
+	if err := query.Find(&processes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list business processes: " + err.Error()})
+		return
+	}
+	report := BIAReportResponse{GeneratedAt: time.Now(), RiskLevels: riskLevels, Processes: []BIAProcessEntry{}}
+	if len(processes) == 0 {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	processIDs := make([]uuid.UUID, 0, len(processes))
+	for _, p := range processes {
+		processIDs = append(processIDs, p.ID)
+	}
+
+	type dependencyCount struct {
+		BusinessProcessID uuid.UUID
+		Total             int
+	}
+	var assetCounts, vendorCounts []dependencyCount
+	if err := db.Model(&models.BusinessProcessAsset{}).Select("business_process_id, COUNT(*) AS total").
+		Where("business_process_id IN ?", processIDs).Group("business_process_id").Scan(&assetCounts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count process assets: " + err.Error()})
+		return
+	}
+	if err := db.Model(&models.BusinessProcessVendor{}).Select("business_process_id, COUNT(*) AS total").
+		Where("business_process_id IN ?", processIDs).Group("business_process_id").Scan(&vendorCounts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count process vendors: " + err.Error()})
+		return
+	}
+
+	var assetRisks, vendorRisks []BIADependencyRisk
+	err = db.Table("business_process_assets").
+		Select("business_process_assets.business_process_id AS process_id, risks.id AS risk_id, risks.title, risks.risk_level, risks.impact, risks.status, 'asset' AS dependency_type, assets.id AS dependency_id, assets.name AS dependency_name").
+		Joins("JOIN assets ON assets.id = business_process_assets.asset_id").
+		Joins("JOIN asset_risks ON asset_risks.asset_id = assets.id").
+		Joins("JOIN risks ON risks.id = asset_risks.risk_id AND risks.deleted_at IS NULL").
+		Where("business_process_assets.business_process_id IN ? AND risks.status IN ? AND risks.risk_level IN ?", processIDs, biaOpenRiskStatuses, riskLevels).
+		Order("risks.title asc").
+		Scan(&assetRisks).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load asset risks: " + err.Error()})
+		return
+	}
+	err = db.Table("business_process_vendors").
+		Select("business_process_vendors.business_process_id AS process_id, risks.id AS risk_id, risks.title, risks.risk_level, risks.impact, risks.status, 'vendor' AS dependency_type, vendors.id AS dependency_id, vendors.name AS dependency_name").
+		Joins("JOIN vendors ON vendors.id = business_process_vendors.vendor_id").
+		Joins("JOIN vendor_risks ON vendor_risks.vendor_id = vendors.id").
+		Joins("JOIN risks ON risks.id = vendor_risks.risk_id AND risks.deleted_at IS NULL").
+		Where("business_process_vendors.business_process_id IN ? AND risks.status IN ? AND risks.risk_level IN ?", processIDs, biaOpenRiskStatuses, riskLevels).
+		Order("risks.title asc").
+		Scan(&vendorRisks).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load vendor risks: " + err.Error()})
+		return
+	}
+
+	entries := make(map[uuid.UUID]*BIAProcessEntry, len(processes))
+	for _, p := range processes {
+		entries[p.ID] = &BIAProcessEntry{
+			ID:          p.ID,
+			Name:        p.Name,
+			Criticality: string(p.Criticality),
+			OwnerID:     p.OwnerID,
+			RTOHours:    p.RTOHours,
+			RPOHours:    p.RPOHours,
+			MTPDHours:   p.MTPDHours,
+			OpenRisks:   []BIADependencyRisk{},
+		}
+	}
+	for _, count := range assetCounts {
+		entries[count.BusinessProcessID].AssetCount = count.Total
+	}
+	for _, count := range vendorCounts {
+		entries[count.BusinessProcessID].VendorCount = count.Total
+	}
+	distinctRisks := make(map[uuid.UUID]bool)
+	for _, risk := range append(assetRisks, vendorRisks...) {
+		entry := entries[risk.ProcessID]
+		entry.OpenRisks = append(entry.OpenRisks, risk)
+		entry.AtRisk = true
+		distinctRisks[risk.RiskID] = true
+	}
+
+	atRiskOnly := c.Query("at_risk_only") == "true"
+	for _, p := range processes {
+		entry := entries[p.ID]
+		report.Summary.TotalProcesses++
+		if entry.AtRisk {
+			report.Summary.AtRiskProcesses++
+		}
+		if entry.AssetCount == 0 && entry.VendorCount == 0 {
+			report.Summary.WithoutDependencies++
+		}
+		if atRiskOnly && !entry.AtRisk {
+			continue
+		}
+		report.Processes = append(report.Processes, *entry)
+	}
+	report.Summary.DistinctRisks = len(distinctRisks)
+	sortBIAEntries(report.Processes)
+	c.JSON(http.StatusOK, report)
+}
@@ -2,11 +2,14 @@ package handlers
 
 import (
 	"net/http"
+	"phoenixgrc/backend/internal/c2m2logic"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // ListC2M2DomainsHandler lista todos os domínios C2M2.
@@ -48,3 +51,177 @@ func ListC2M2PracticesByDomainHandler(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, practices)
 }
+
+// C2M2PracticeEvaluationResponse é uma prática C2M2 avaliada na avaliação de um controle.
+type C2M2PracticeEvaluationResponse struct {
+	ID          uuid.UUID             `json:"id"`
+	PracticeID  uuid.UUID             `json:"practice_id"`
+	Code        string                `json:"code"`
+	Description string                `json:"description"`
+	TargetMIL   int                   `json:"target_mil"`
+	DomainID    uuid.UUID             `json:"domain_id"`
+	DomainCode  string                `json:"domain_code"`
+	DomainName  string                `json:"domain_name"`
+	Status      models.PracticeStatus `json:"status"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// C2M2DomainImplementationSummary é o percentual de implementação das práticas de um domínio C2M2.
+type C2M2DomainImplementationSummary struct {
+	DomainID                 uuid.UUID `json:"domain_id,omitempty"`
+	DomainCode               string    `json:"domain_code,omitempty"`
+	DomainName               string    `json:"domain_name,omitempty"`
+	TotalPractices           int       `json:"total_practices"`
+	PracticesEvaluated       int       `json:"practices_evaluated"`
+	Evaluations              int       `json:"evaluations"`
+	NotImplemented           int       `json:"not_implemented"`
+	PartiallyImplemented     int       `json:"partially_implemented"`
+	FullyImplemented         int       `json:"fully_implemented"`
+	ImplementationPercentage float64   `json:"implementation_percentage"`
+}
+
+// C2M2PracticeRollupResponse consolida as avaliações de práticas C2M2 dos controles de um framework.
+type C2M2PracticeRollupResponse struct {
+	FrameworkID      uuid.UUID                         `json:"framework_id"`
+	FrameworkName    string                            `json:"framework_name"`
+	OrganizationID   uuid.UUID                         `json:"organization_id"`
+	AssessedControls int                               `json:"assessed_controls"` // Controles com práticas avaliadas
+	Overall          C2M2DomainImplementationSummary   `json:"overall"`
+	Domains          []C2M2DomainImplementationSummary `json:"domains"`
+}
+
+func newC2M2DomainImplementationSummary(d c2m2logic.DomainImplementation, totalPractices int) C2M2DomainImplementationSummary {
+	return C2M2DomainImplementationSummary{
+		DomainID:                 d.DomainID,
+		TotalPractices:           totalPractices,
+		PracticesEvaluated:       d.PracticesEvaluated,
+		Evaluations:              d.Evaluations(),
+		NotImplemented:           d.NotImplemented,
+		PartiallyImplemented:     d.PartiallyImplemented,
+		FullyImplemented:         d.FullyImplemented,
+		ImplementationPercentage: d.ImplementationPercentage(),
+	}
+}
+
+// ListAssessmentC2M2PracticesHandler lists the C2M2 practice evaluations recorded on an assessment,
+// ordered by domain and practice code.
+func ListAssessmentC2M2PracticesHandler(c *gin.Context) {
+	assessmentID, err := uuid.Parse(c.Param("assessmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assessment ID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+
+	db := database.GetDB()
+	var assessment models.AuditAssessment
+	if err := db.Select("id").Where("id = ? AND organization_id = ?", assessmentID, orgID).First(&assessment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Assessment not found or not part of your organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch assessment: " + err.Error()})
+		return
+	}
+
+	evaluations := []C2M2PracticeEvaluationResponse{}
+	err = db.Table("c2m2_practice_evaluations").
+		Select("c2m2_practice_evaluations.id, c2m2_practice_evaluations.practice_id, c2m2_practices.code, c2m2_practices.description, c2m2_practices.target_mil, c2m2_domains.id AS domain_id, c2m2_domains.code AS domain_code, c2m2_domains.name AS domain_name, c2m2_practice_evaluations.status, c2m2_practice_evaluations.updated_at").
+		Joins("JOIN c2m2_practices ON c2m2_practices.id = c2m2_practice_evaluations.practice_id").
+		Joins("JOIN c2m2_domains ON c2m2_domains.id = c2m2_practices.domain_id").
+		Where("c2m2_practice_evaluations.audit_assessment_id = ?", assessment.ID).
+		Order("c2m2_domains.code asc, c2m2_practices.code asc").
+		Scan(&evaluations).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list C2M2 practice evaluations: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, evaluations)
+}
+
+// GetC2M2PracticeRollupHandler aggregates the C2M2 practice evaluations of the framework's assessed controls
+// into per-domain implementation percentages (fully implemented = 1, partially = 0.5).
+func GetC2M2PracticeRollupHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	frameworkID, err := uuid.Parse(c.Param("frameworkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid framework ID format"})
+		return
+	}
+	tokenAuthOrgID, tokenAuthOrgExists := c.Get("organizationID")
+	if !tokenAuthOrgExists || tokenAuthOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization's C2M2 practice summary"})
+		return
+	}
+
+	db := database.GetDB()
+	var framework models.AuditFramework
+	if err := db.Scopes(visibleFrameworksScope(targetOrgID)).First(&framework, "id = ?", frameworkID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch framework: " + err.Error()})
+		return
+	}
+
+	var domains []models.C2M2Domain
+	if err := db.Order("code asc").Find(&domains).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list C2M2 domains: " + err.Error()})
+		return
+	}
+	var practices []models.C2M2Practice
+	if err := db.Select("id", "domain_id").Find(&practices).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch C2M2 practices: " + err.Error()})
+		return
+	}
+	practiceDomain := make(map[uuid.UUID]uuid.UUID, len(practices))
+	practicesPerDomain := make(map[uuid.UUID]int, len(domains))
+	for _, p := range practices {
+		practiceDomain[p.ID] = p.DomainID
+		practicesPerDomain[p.DomainID]++
+	}
+
+	var evaluations []models.C2M2PracticeEvaluation
+	err = db.Table("c2m2_practice_evaluations").
+		Select("c2m2_practice_evaluations.*").
+		Joins("JOIN audit_assessments ON audit_assessments.id = c2m2_practice_evaluations.audit_assessment_id").
+		Joins("JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id").
+		Where("audit_assessments.organization_id = ? AND audit_controls.framework_id = ?", targetOrgID, frameworkID).
+		Scopes(models.OrgWideAssessments).
+		Find(&evaluations).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch C2M2 practice evaluations: " + err.Error()})
+		return
+	}
+	assessed := make(map[uuid.UUID]bool)
+	for _, e := range evaluations {
+		assessed[e.AuditAssessmentID] = true
+	}
+
+	domainIDs := make([]uuid.UUID, len(domains))
+	for i, d := range domains {
+		domainIDs[i] = d.ID
+	}
+	response := C2M2PracticeRollupResponse{
+		FrameworkID:      framework.ID,
+		FrameworkName:    framework.Name,
+		OrganizationID:   targetOrgID,
+		AssessedControls: len(assessed),
+		Domains:          make([]C2M2DomainImplementationSummary, 0, len(domains)),
+	}
+	var overall c2m2logic.DomainImplementation
+	for i, summary := range c2m2logic.SummarizeByDomain(domainIDs, practiceDomain, evaluations) {
+		overall.Add(summary)
+		domainSummary := newC2M2DomainImplementationSummary(summary, practicesPerDomain[summary.DomainID])
+		domainSummary.DomainCode = domains[i].Code
+		domainSummary.DomainName = domains[i].Name
+		response.Domains = append(response.Domains, domainSummary)
+	}
+	response.Overall = newC2M2DomainImplementationSummary(overall, len(practices))
+	c.JSON(http.StatusOK, response)
+}
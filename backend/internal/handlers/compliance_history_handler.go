@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportHistoricalAssessmentsResponse resume o resultado de uma importação de avaliações históricas.
+type ImportHistoricalAssessmentsResponse struct {
+	ImportBatchID        uuid.UUID               `json:"import_batch_id"`
+	SuccessfullyImported int                     `json:"successfully_imported"`
+	SnapshotsGenerated   int                     `json:"snapshots_generated"`
+	FailedRows           []BulkUploadErrorDetail `json:"failed_rows,omitempty"`
+}
+
+// tallyComplianceScore calcula o score de conformidade da mesma forma que GetComplianceScoreHandler:
+// média dos scores dos controles avaliados.
+func tallyComplianceScore(totalControls int, results map[uuid.UUID]models.AuditAssessmentHistory) models.ComplianceScoreSnapshot {
+	snapshot := models.ComplianceScoreSnapshot{TotalControls: totalControls}
+	totalScoreSum := 0
+	for _, result := range results {
+		snapshot.EvaluatedControls++
+		if result.Score != nil {
+			totalScoreSum += *result.Score
+		}
+		switch result.Status {
+		case models.ControlStatusConformant:
+			snapshot.ConformantControls++
+		case models.ControlStatusPartiallyConformant:
+			snapshot.PartiallyConformantControls++
+		case models.ControlStatusNonConformant:
+			snapshot.NonConformantControls++
+		}
+	}
+	if snapshot.EvaluatedControls > 0 {
+		snapshot.ComplianceScore = float64(totalScoreSum) / float64(snapshot.EvaluatedControls)
+	}
+	return snapshot
+}
+
+// generateHistoricalSnapshots recalcula, para cada data informada, o score do framework considerando o resultado
+// histórico mais recente de cada controle até aquela data, e grava os snapshots (upsert por organização/framework/data).
+func generateHistoricalSnapshots(db *gorm.DB, organizationID, frameworkID uuid.UUID, controlIDs []uuid.UUID, dates []time.Time) (int, error) {
+	var history []models.AuditAssessmentHistory
+	if err := db.Where("organization_id = ? AND audit_control_id IN (?)", organizationID, controlIDs).
+		Order("assessment_date asc").Find(&history).Error; err != nil {
+		return 0, err
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var snapshots []models.ComplianceScoreSnapshot
+	latestByControl := make(map[uuid.UUID]models.AuditAssessmentHistory)
+	next := 0
+	for _, date := range dates {
+		endOfDay := date.AddDate(0, 0, 1)
+		for next < len(history) && history[next].AssessmentDate.Before(endOfDay) {
+			latestByControl[history[next].AuditControlID] = history[next]
+			next++
+		}
+		snapshot := tallyComplianceScore(len(controlIDs), latestByControl)
+		snapshot.OrganizationID = organizationID
+		snapshot.FrameworkID = frameworkID
+		snapshot.SnapshotDate = date
+		snapshot.Source = models.ComplianceSnapshotSourceHistoricalImport
+		snapshots = append(snapshots, snapshot)
+	}
+	if len(snapshots) == 0 {
+		return 0, nil
+	}
+
+	err := compliancescore.UpsertSnapshots(db, snapshots)
+	return len(snapshots), err
+}
+
+// ImportHistoricalAssessmentsHandler imports prior assessment results (CSV) with their original dates
+// and generates retroactive compliance-score snapshots for each imported date.
+// Não altera as avaliações correntes da organização.
+func ImportHistoricalAssessmentsHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.AuditImportHistory) {
+		return
+	}
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	// Só frameworks semeados ou personalizados da própria organização
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	frameworkID := framework.ID
+
+	var controls []models.AuditControl
+	if err := db.Where("framework_id = ?", frameworkID).Find(&controls).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve controls for framework: " + err.Error()})
+		return
+	}
+	if len(controls) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found or has no controls"})
+		return
+	}
+	controlsByCode := make(map[string]uuid.UUID, len(controls))
+	controlIDs := make([]uuid.UUID, 0, len(controls))
+	for _, ctrl := range controls {
+		controlsByCode[strings.ToUpper(strings.TrimSpace(ctrl.ControlID))] = ctrl.ID
+		controlIDs = append(controlIDs, ctrl.ID)
+	}
+	rubric, _, err := models.ResolveScoreRubric(db, targetOrgID, frameworkID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load score rubric: " + err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file not provided in 'file' field: " + err.Error()})
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open uploaded file: " + err.Error()})
+		return
+	}
+	defer src.Close()
+
+	reader := csv.NewReader(src)
+	headers, err := reader.Read()
+	if err == io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file is empty"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV headers: " + err.Error()})
+		return
+	}
+	headerMap := make(map[string]int)
+	for i, h := range headers {
+		headerMap[normalizeHeader(h)] = i
+	}
+	for _, reqHeader := range []string{"control_id", "status", "assessment_date"} {
+		if _, ok := headerMap[reqHeader]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Missing required CSV header: %s", reqHeader)})
+			return
+		}
+	}
+
+	validStatuses := map[models.AuditControlStatus]bool{
+		models.ControlStatusConformant:          true,
+		models.ControlStatusNonConformant:       true,
+		models.ControlStatusPartiallyConformant: true,
+		models.ControlStatusNotApplicable:       true,
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	batchID := uuid.New()
+
+	var entries []models.AuditAssessmentHistory
+	var failedRows []BulkUploadErrorDetail
+	importedDates := make(map[time.Time]bool)
+	lineNumber := 1
+	for {
+		lineNumber++
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			failedRows = append(failedRows, BulkUploadErrorDetail{LineNumber: lineNumber, Errors: []string{"Failed to parse CSV row: " + err.Error()}})
+			continue
+		}
+
+		var rowErrors []string
+		entry := models.AuditAssessmentHistory{
+			OrganizationID: targetOrgID,
+			ImportBatchID:  batchID,
+			ImportedByID:   userID.(uuid.UUID),
+			Comments:       strings.TrimSpace(getCSVField(record, headerMap, "comments")),
+		}
+
+		controlCode := strings.ToUpper(strings.TrimSpace(getCSVField(record, headerMap, "control_id")))
+		if controlID, ok := controlsByCode[controlCode]; ok {
+			entry.AuditControlID = controlID
+		} else {
+			rowErrors = append(rowErrors, fmt.Sprintf("control_id '%s' not found in framework", controlCode))
+		}
+
+		entry.Status = models.AuditControlStatus(strings.TrimSpace(getCSVField(record, headerMap, "status")))
+		if !validStatuses[entry.Status] {
+			rowErrors = append(rowErrors, fmt.Sprintf("invalid status '%s'. Valid are: conforme, nao_conforme, parcialmente_conforme, nao_aplicavel", entry.Status))
+		}
+
+		assessmentDate, err := time.Parse("2006-01-02", strings.TrimSpace(getCSVField(record, headerMap, "assessment_date")))
+		if err != nil {
+			rowErrors = append(rowErrors, "invalid assessment_date, use YYYY-MM-DD")
+		} else if assessmentDate.After(today) {
+			rowErrors = append(rowErrors, "assessment_date cannot be in the future")
+		}
+		entry.AssessmentDate = assessmentDate
+
+		if scoreStr := strings.TrimSpace(getCSVField(record, headerMap, "score")); scoreStr != "" {
+			score, err := strconv.Atoi(scoreStr)
+			if err != nil || score < 0 || score > 100 {
+				rowErrors = append(rowErrors, "score must be an integer between 0 and 100")
+			}
+			entry.Score = &score
+		} else {
+			score := rubric.ScoreFor(entry.Status)
+			entry.Score = &score
+		}
+
+		if len(rowErrors) > 0 {
+			failedRows = append(failedRows, BulkUploadErrorDetail{LineNumber: lineNumber, Errors: rowErrors})
+			continue
+		}
+		entries = append(entries, entry)
+		importedDates[assessmentDate] = true
+	}
+
+	if len(entries) == 0 {
+		c.JSON(http.StatusBadRequest, ImportHistoricalAssessmentsResponse{ImportBatchID: batchID, FailedRows: failedRows})
+		return
+	}
+
+	dates := make([]time.Time, 0, len(importedDates))
+	for d := range importedDates {
+		dates = append(dates, d)
+	}
+
+	response := ImportHistoricalAssessmentsResponse{ImportBatchID: batchID, SuccessfullyImported: len(entries), FailedRows: failedRows}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(&entries, 500).Error; err != nil {
+			return err
+		}
+		generated, err := generateHistoricalSnapshots(tx, targetOrgID, frameworkID, controlIDs, dates)
+		response.SnapshotsGenerated = generated
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import historical assessments: " + err.Error()})
+		return
+	}
+
+	if len(failedRows) > 0 {
+		c.JSON(http.StatusMultiStatus, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ListComplianceScoreHistoryHandler returns the compliance-score time series of a framework, oldest first.
+// Query params opcionais: from, to (YYYY-MM-DD) e granularity (daily, padrão, ou weekly: o último
+// snapshot de cada semana).
+func ListComplianceScoreHistoryHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	frameworkID, err := uuid.Parse(c.Param("frameworkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid framework ID format"})
+		return
+	}
+	tokenOrgID, _ := c.Get("organizationID")
+	if tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization's compliance history"})
+		return
+	}
+	granularity := c.DefaultQuery("granularity", "daily")
+	if granularity != "daily" && granularity != "weekly" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid granularity, use daily or weekly"})
+		return
+	}
+
+	query := database.GetDB().WithContext(c.Request.Context()).Where("organization_id = ? AND framework_id = ?", targetOrgID, frameworkID)
+	for param, condition := range map[string]string{"from": "snapshot_date >= ?", "to": "snapshot_date <= ?"} {
+		if value := c.Query(param); value != "" {
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid '%s' date, use YYYY-MM-DD", param)})
+				return
+			}
+			query = query.Where(condition, date)
+		}
+	}
+
+	var snapshots []models.ComplianceScoreSnapshot
+	if err := query.Order("snapshot_date asc").Find(&snapshots).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list compliance score history: " + err.Error()})
+		return
+	}
+	if granularity == "weekly" {
+		snapshots = compliancescore.Weekly(snapshots)
+	}
+	c.JSON(http.StatusOK, snapshots)
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// configEntityTables são as tabelas das entidades de configuração versionadas; todas têm id e organization_id.
+var configEntityTables = map[models.ConfigEntityType]string{
+	models.ConfigEntityIdentityProvider:       "identity_providers",
+	models.ConfigEntityNotificationRoute:      "notification_routes",
+	models.ConfigEntityRiskNotificationFilter: "risk_notification_filters",
+	models.ConfigEntityWebhook:                "webhook_configurations",
+	models.ConfigEntityScoreRubric:            "assessment_score_rubrics",
+	models.ConfigEntityRiskScoringMatrix:      "risk_scoring_matrices",
+}
+
+// ConfigChangeResponse é uma versão de configuração com os registros antes/depois em JSON.
+type ConfigChangeResponse struct {
+	models.ConfigChange
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+func newConfigChangeResponse(change models.ConfigChange) ConfigChangeResponse {
+	resp := ConfigChangeResponse{ConfigChange: change, Before: json.RawMessage("null"), After: json.RawMessage("null")}
+	if change.Before != nil {
+		resp.Before = json.RawMessage(*change.Before)
+	}
+	if change.After != nil {
+		resp.After = json.RawMessage(*change.After)
+	}
+	return resp
+}
+
+// quoteIdentifier cita um identificador SQL (tabela ou coluna).
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// snapshotConfigEntity retorna o registro completo da entidade (todas as colunas, via row_to_json),
+// ou nil se ela não existir. Falhas são registradas em log e tratadas como ausência de snapshot.
+func snapshotConfigEntity(db *gorm.DB, entityType models.ConfigEntityType, organizationID, entityID uuid.UUID) *string {
+	table := configEntityTables[entityType]
+	var rows []string
+	err := db.Raw(fmt.Sprintf("SELECT row_to_json(t)::text FROM %s t WHERE t.id = ? AND t.organization_id = ?", quoteIdentifier(table)),
+		entityID, organizationID).Scan(&rows).Error
+	if err != nil {
+		phxlog.L.Warn("Failed to snapshot configuration entity", zap.String("entityType", string(entityType)), zap.String("entityID", entityID.String()), zap.Error(err))
+		return nil
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return &rows[0]
+}
+
+// recordConfigChange grava a versão da entidade após a alteração. before é o snapshot anterior (nil na criação).
+// O histórico não bloqueia a alteração: falhas são apenas registradas em log.
+func recordConfigChange(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, entityType models.ConfigEntityType, entityID uuid.UUID, action models.ConfigChangeAction, before *string) {
+	change := models.ConfigChange{
+		OrganizationID: organizationID,
+		EntityType:     entityType,
+		EntityID:       entityID,
+		Action:         action,
+		Before:         before,
+		After:          snapshotConfigEntity(db, entityType, organizationID, entityID),
+	}
+	if userID, ok := c.Get("userID"); ok {
+		if id, ok := userID.(uuid.UUID); ok {
+			change.ChangedByID = &id
+		}
+	}
+	if err := db.Create(&change).Error; err != nil {
+		phxlog.L.Error("Failed to record configuration change", zap.String("entityType", string(entityType)), zap.String("entityID", entityID.String()), zap.Error(err))
+	}
+}
+
+// restoreConfigEntity grava o snapshot na tabela da entidade (UPDATE ou, se ela foi removida, INSERT).
+// As colunas vêm das chaves do próprio snapshot, que foi gerado a partir da tabela.
+func restoreConfigEntity(tx *gorm.DB, entityType models.ConfigEntityType, entityID uuid.UUID, snapshot string) error {
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(snapshot), &record); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+	columns := make([]string, 0, len(record))
+	for column := range record {
+		columns = append(columns, quoteIdentifier(column))
+	}
+	sort.Strings(columns)
+	table := quoteIdentifier(configEntityTables[entityType])
+	columnList := strings.Join(columns, ", ")
+
+	result := tx.Exec(fmt.Sprintf("UPDATE %s SET (%s) = (SELECT %s FROM json_populate_record(NULL::%s, ?::json)) WHERE id = ?",
+		table, columnList, columnList, table), snapshot, entityID)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	return tx.Exec(fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM json_populate_record(NULL::%s, ?::json)",
+		table, columnList, columnList, table), snapshot).Error
+}
+
+// findOrgConfigChange carrega a versão :changeId da organização.
+func findOrgConfigChange(c *gin.Context, db *gorm.DB) (*models.ConfigChange, bool) {
+	changeID, err := uuid.Parse(c.Param("changeId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid configuration change ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var change models.ConfigChange
+	if err := db.Where("id = ? AND organization_id = ?", changeID, orgID).First(&change).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Configuration change not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch configuration change: " + err.Error()})
+		return nil, false
+	}
+	return &change, true
+}
+
+// ListConfigChangesHandler lists the organization's configuration history, newest first.
+// Filtros opcionais: entity_type, entity_id.
+func ListConfigChangesHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ConfigChangesView) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Model(&models.ConfigChange{}).Where("organization_id = ?", orgID)
+	if entityType := c.Query("entity_type"); entityType != "" {
+		if _, ok := configEntityTables[models.ConfigEntityType(entityType)]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity_type"})
+			return
+		}
+		query = query.Where("entity_type = ?", entityType)
+	}
+	if raw := c.Query("entity_id"); raw != "" {
+		entityID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity_id format"})
+			return
+		}
+		query = query.Where("entity_id = ?", entityID)
+	}
+
+	page, pageSize := GetPaginationParams(c)
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count configuration changes: " + err.Error()})
+		return
+	}
+	var changes []models.ConfigChange
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at desc").Find(&changes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list configuration changes: " + err.Error()})
+		return
+	}
+	items := make([]ConfigChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		items = append(items, newConfigChangeResponse(change))
+	}
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: items, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
+
+// GetConfigChangeHandler returns one configuration version with its before/after records.
+func GetConfigChangeHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ConfigChangesView) {
+		return
+	}
+	change, ok := findOrgConfigChange(c, database.GetDB())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, newConfigChangeResponse(*change))
+}
+
+// RollbackConfigChangeHandler restores the entity to its state before the given change: a creation is
+// undone by removing the entity, an update or removal by writing back the previous record.
+// O rollback também é registrado no histórico (e pode ser desfeito).
+func RollbackConfigChangeHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ConfigChangesRollback) {
+		return
+	}
+	db := database.GetDB()
+	change, ok := findOrgConfigChange(c, db)
+	if !ok {
+		return
+	}
+	if _, ok := configEntityTables[change.EntityType]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This configuration entity can no longer be rolled back"})
+		return
+	}
+
+	current := snapshotConfigEntity(db, change.EntityType, change.OrganizationID, change.EntityID)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if change.Before == nil {
+			table := quoteIdentifier(configEntityTables[change.EntityType])
+			err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ? AND organization_id = ?", table), change.EntityID, change.OrganizationID).Error
+		} else {
+			err = restoreConfigEntity(tx, change.EntityType, change.EntityID, *change.Before)
+		}
+		if err != nil || change.EntityType != models.ConfigEntityRiskScoringMatrix {
+			return err
+		}
+		// A matriz restaurada vale também para os riscos já cadastrados
+		matrix, _, err := loadRiskScoringMatrix(tx, change.OrganizationID)
+		if err != nil {
+			return err
+		}
+		_, err = recalculateRiskLevels(tx, change.OrganizationID, matrix)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to roll back configuration: " + err.Error()})
+		return
+	}
+
+	rollback := models.ConfigChange{
+		OrganizationID:   change.OrganizationID,
+		EntityType:       change.EntityType,
+		EntityID:         change.EntityID,
+		Action:           models.ConfigChangeRollback,
+		Before:           current,
+		After:            snapshotConfigEntity(db, change.EntityType, change.OrganizationID, change.EntityID),
+		RolledBackFromID: &change.ID,
+	}
+	userID, _ := c.Get("userID")
+	changedBy := userID.(uuid.UUID)
+	rollback.ChangedByID = &changedBy
+	if err := db.Create(&rollback).Error; err != nil {
+		phxlog.L.Error("Failed to record configuration rollback", zap.String("changeID", change.ID.String()), zap.Error(err))
+	}
+	c.JSON(http.StatusOK, newConfigChangeResponse(rollback))
+}
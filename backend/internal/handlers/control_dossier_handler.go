@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // Decodificador para miniaturas de evidências GIF
+	_ "image/jpeg" // Decodificador para miniaturas de evidências JPEG
+	_ "image/png"  // Decodificador para miniaturas de evidências PNG
+	"net/http"
+	"path"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/pdfdoc"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Limites das miniaturas de evidências no dossiê.
+const (
+	dossierMaxThumbnails     = 12
+	dossierMaxThumbnailBytes = 5 * 1024 * 1024
+	dossierMaxImagePixels    = 40_000_000 // Evita descompactar imagens gigantes
+	dossierHistoryLimit      = 50
+)
+
+// dossierStatusLabels traduz o status da avaliação para o dossiê.
+var dossierStatusLabels = map[models.AuditControlStatus]string{
+	models.ControlStatusConformant:          "Conforme",
+	models.ControlStatusPartiallyConformant: "Parcialmente conforme",
+	models.ControlStatusNonConformant:       "Não conforme",
+	models.ControlStatusNotApplicable:       "Não aplicável",
+	models.ControlStatusWaived:              "Dispensado (exceção aprovada)",
+}
+
+func dossierStatusLabel(status models.AuditControlStatus) string {
+	if status == "" {
+		return "Não avaliado"
+	}
+	if label, ok := dossierStatusLabels[status]; ok {
+		return label
+	}
+	return string(status)
+}
+
+func dossierDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("02/01/2006")
+}
+
+func dossierText(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// dossierEvidence é um arquivo de evidência listado no dossiê.
+type dossierEvidence struct {
+	Source     string // Avaliação ou solicitação de evidência
+	Reference  string // Chave no armazenamento ou URL externa
+	FileName   string
+	ScanStatus string
+	Date       *time.Time
+	Note       string
+}
+
+// isStoredImage indica se a evidência é uma imagem no armazenamento da aplicação (e não um link externo).
+func isStoredImage(reference string) bool {
+	if strings.HasPrefix(reference, "http://") || strings.HasPrefix(reference, "https://") {
+		return false
+	}
+	switch strings.ToLower(path.Ext(reference)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return true
+	}
+	return false
+}
+
+// loadEvidenceThumbnail lê e decodifica a imagem da evidência. Falhas são registradas e a evidência
+// aparece no dossiê sem miniatura.
+func loadEvidenceThumbnail(ctx context.Context, objectName string) (image.Image, bool) {
+	data, err := filestorage.ReadObject(ctx, filestorage.DefaultFileStorageProvider, objectName, dossierMaxThumbnailBytes)
+	if err != nil {
+		phxlog.L.Warn("Failed to read evidence for dossier thumbnail", zap.String("objectName", objectName), zap.Error(err))
+		return nil, false
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > dossierMaxImagePixels {
+		return nil, false
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	return img, true
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// GetControlDossierHandler produces a printable PDF dossier for one control, as handed to auditors when they
+// sample it: control description, organization narrative, latest assessment, evidence list with image
+// thumbnails, comments and assessment history.
+func GetControlDossierHandler(c *gin.Context) {
+	controlID, err := uuid.Parse(c.Param("controlId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid control UUID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+
+	var control models.AuditControl
+	if err := db.Select("audit_controls.*").
+		Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Scopes(visibleFrameworksScope(organizationID)).
+		First(&control, "audit_controls.id = ?", controlID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Audit control not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch control: " + err.Error()})
+		return
+	}
+	if !enforceEvidenceAccessPolicy(c, "control dossier "+control.ControlID) {
+		return
+	}
+	var framework models.AuditFramework
+	var organization models.Organization
+	if err := db.Select("id", "name", "version").First(&framework, "id = ?", control.FrameworkID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch framework details: " + err.Error()})
+		return
+	}
+	if err := db.Select("id", "name").First(&organization, "id = ?", organizationID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organization: " + err.Error()})
+		return
+	}
+
+	var assessment *models.AuditAssessment
+	var current models.AuditAssessment
+	err = db.Scopes(models.OrgWideAssessments).Where("organization_id = ? AND audit_control_id = ?", organizationID, controlID).Take(&current).Error
+	if err == nil {
+		assessment = &current
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch assessment: " + err.Error()})
+		return
+	}
+
+	var requests []models.EvidenceRequest
+	if err := db.Preload("Submissions", func(tx *gorm.DB) *gorm.DB { return tx.Order("created_at desc") }).
+		Where("organization_id = ? AND audit_control_id = ?", organizationID, controlID).
+		Order("created_at desc").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list evidence requests: " + err.Error()})
+		return
+	}
+
+	var history []models.AuditAssessmentHistory
+	if err := db.Where("organization_id = ? AND audit_control_id = ?", organizationID, controlID).
+		Order("assessment_date desc").Limit(dossierHistoryLimit).Find(&history).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assessment history: " + err.Error()})
+		return
+	}
+
+	doc := pdfdoc.New(fmt.Sprintf("Dossiê do controle %s", control.ControlID))
+	doc.Footer = fmt.Sprintf("%s - %s %s - gerado em %s", organization.Name, framework.Name, control.ControlID, time.Now().Format("02/01/2006 15:04"))
+
+	doc.Heading(fmt.Sprintf("Dossiê de Evidências - %s", control.ControlID))
+	doc.Field("Organização", organization.Name)
+	doc.Field("Framework", strings.TrimSpace(framework.Name+" "+framework.Version))
+	doc.Field("Família", control.Family)
+
+	doc.Section("Descrição do Controle")
+	doc.Paragraph(control.Description)
+
+	doc.Section("Narrativa de Implementação")
+	if assessment != nil && dossierText(assessment.ImplementationNarrative) != "" {
+		doc.Paragraph(*assessment.ImplementationNarrative)
+	} else {
+		doc.Paragraph("Nenhuma narrativa de implementação registrada.")
+	}
+
+	var evidences []dossierEvidence
+	doc.Section("Avaliação Mais Recente")
+	if assessment == nil {
+		doc.Paragraph("O controle ainda não foi avaliado.")
+	} else {
+		doc.Field("Status", dossierStatusLabel(assessment.Status))
+		score := ""
+		if assessment.Score != nil {
+			score = strconv.Itoa(*assessment.Score)
+		}
+		doc.Field("Score", score)
+		doc.Field("Data da avaliação", dossierDate(assessment.AssessmentDate))
+		if assessment.PolicyID != nil {
+			var policy models.Policy
+			if err := db.Select("id", "title").First(&policy, "id = ?", *assessment.PolicyID).Error; err == nil {
+				doc.Field("Política", policy.Title)
+			}
+		}
+		if assessment.AssignedToID != nil {
+			var assessor models.User
+			if err := db.Select("id", "name").First(&assessor, "id = ?", *assessment.AssignedToID).Error; err == nil {
+				doc.Field("Avaliador", assessor.Name)
+			}
+			doc.Field("Prazo", dossierDate(assessment.DueDate))
+		}
+		doc.Field("Atualizada em", assessment.UpdatedAt.Format("02/01/2006 15:04"))
+		if assessment.EvidenceURL != "" {
+			evidences = append(evidences, dossierEvidence{
+				Source:     "Avaliação",
+				Reference:  assessment.EvidenceURL,
+				FileName:   path.Base(assessment.EvidenceURL),
+				ScanStatus: assessment.EvidenceScanStatus,
+				Date:       assessment.AssessmentDate,
+			})
+		}
+	}
+	for _, request := range requests {
+		for _, submission := range request.Submissions {
+			createdAt := submission.CreatedAt
+			evidences = append(evidences, dossierEvidence{
+				Source:     fmt.Sprintf("Solicitação \"%s\" (%s)", request.Title, request.Status),
+				Reference:  submission.ObjectName,
+				FileName:   submission.FileName,
+				ScanStatus: submission.ScanStatus,
+				Date:       &createdAt,
+				Note:       submission.Note,
+			})
+		}
+	}
+
+	doc.Section("Evidências")
+	if len(evidences) == 0 {
+		doc.Paragraph("Nenhuma evidência anexada.")
+	}
+	thumbnails := 0
+	for _, evidence := range evidences {
+		description := fmt.Sprintf("%s\nOrigem: %s\nData: %s", evidence.FileName, evidence.Source, dossierDate(evidence.Date))
+		if evidence.ScanStatus != "" {
+			description += "\nVerificação antivírus: " + evidence.ScanStatus
+		}
+		if evidence.Note != "" {
+			description += "\nNota: " + evidence.Note
+		}
+		if thumbnails < dossierMaxThumbnails && isStoredImage(evidence.Reference) {
+			if img, ok := loadEvidenceThumbnail(c.Request.Context(), evidence.Reference); ok {
+				if err := doc.Image(img, 0, 120, 90, description); err == nil {
+					thumbnails++
+					continue
+				}
+			}
+		}
+		doc.Paragraph(description)
+	}
+
+	doc.Section("Comentários")
+	var comments []string
+	if assessment != nil {
+		if text := dossierText(assessment.Comments); text != "" {
+			comments = append(comments, "Avaliação: "+text)
+		}
+		if text := dossierText(assessment.C2M2Comments); text != "" {
+			comments = append(comments, "Maturidade C2M2: "+text)
+		}
+	}
+	for _, request := range requests {
+		if request.ReviewNote != "" {
+			comments = append(comments, fmt.Sprintf("Revisão da solicitação \"%s\" (%s): %s", request.Title, dossierDate(request.ReviewedAt), request.ReviewNote))
+		}
+	}
+	if len(comments) == 0 {
+		doc.Paragraph("Nenhum comentário registrado.")
+	}
+	for _, comment := range comments {
+		doc.Paragraph(comment)
+	}
+
+	doc.Section("Histórico de Avaliações")
+	if len(history) == 0 {
+		doc.Paragraph("Nenhuma avaliação anterior registrada.")
+	}
+	for _, entry := range history {
+		line := fmt.Sprintf("%s - %s", entry.AssessmentDate.Format("02/01/2006"), dossierStatusLabel(entry.Status))
+		if entry.Score != nil {
+			line += fmt.Sprintf(" (score %d)", *entry.Score)
+		}
+		if entry.Comments != "" {
+			line += ": " + entry.Comments
+		}
+		doc.Paragraph(line)
+	}
+
+	pdf, err := doc.Bytes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate dossier: " + err.Error()})
+		return
+	}
+	fileName := unsafeFileNameChars.ReplaceAllString(control.ControlID, "_")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"dossier_%s.pdf\"", fileName))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/controlmapping"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ControlMappingPayload cria um mapeamento entre controles de frameworks diferentes para a organização.
+type ControlMappingPayload struct {
+	SourceControlID string                            `json:"source_control_id" binding:"required,uuid"`
+	TargetControlID string                            `json:"target_control_id" binding:"required,uuid"`
+	Relationship    models.ControlMappingRelationship `json:"relationship" binding:"required,oneof=equivalent partial"`
+	Notes           string                            `json:"notes"`
+}
+
+// MappedControlRef identifica um controle em um mapeamento.
+type MappedControlRef struct {
+	ID            uuid.UUID `json:"id"`
+	ControlID     string    `json:"control_id"`
+	FrameworkID   uuid.UUID `json:"framework_id"`
+	FrameworkName string    `json:"framework_name"`
+}
+
+// ControlMappingResponse é um mapeamento com os dois controles identificados.
+type ControlMappingResponse struct {
+	ID           uuid.UUID                         `json:"id"`
+	Source       MappedControlRef                  `json:"source"`
+	Target       MappedControlRef                  `json:"target"`
+	Relationship models.ControlMappingRelationship `json:"relationship"`
+	Notes        string                            `json:"notes,omitempty"`
+	IsDefault    bool                              `json:"is_default"` // Mapeamento padrão (semeado), comum a todas as organizações
+}
+
+// ProjectedSourceControl é um controle do framework de origem que sustenta a projeção.
+type ProjectedSourceControl struct {
+	ID               uuid.UUID                         `json:"id"`
+	ControlID        string                            `json:"control_id"`
+	Relationship     models.ControlMappingRelationship `json:"relationship"`
+	AssessmentStatus models.AuditControlStatus         `json:"assessment_status,omitempty"`
+}
+
+// ProjectedControl é a estimativa de um controle do framework alvo.
+type ProjectedControl struct {
+	ID              uuid.UUID                 `json:"id"`
+	ControlID       string                    `json:"control_id"`
+	Description     string                    `json:"description"`
+	Mapped          bool                      `json:"mapped"`
+	ProjectedStatus models.AuditControlStatus `json:"projected_status,omitempty"` // Vazio quando nenhuma fonte foi avaliada
+	CurrentStatus   models.AuditControlStatus `json:"current_status,omitempty"`   // Avaliação própria do controle, se houver
+	Sources         []ProjectedSourceControl  `json:"sources"`
+}
+
+// CoverageProjectionResponse estima a cobertura do framework alvo a partir das avaliações do framework de origem.
+type CoverageProjectionResponse struct {
+	SourceFrameworkID        uuid.UUID          `json:"source_framework_id"`
+	TargetFrameworkID        uuid.UUID          `json:"target_framework_id"`
+	TargetFrameworkName      string             `json:"target_framework_name"`
+	TotalControls            int                `json:"total_controls"`
+	MappedControls           int                `json:"mapped_controls"`
+	ProjectedConformant      int                `json:"projected_conformant"`
+	ProjectedPartial         int                `json:"projected_partially_conformant"`
+	ProjectedNonConformant   int                `json:"projected_non_conformant"`
+	EstimatedCoveragePercent float64            `json:"estimated_coverage_percent"`
+	Controls                 []ProjectedControl `json:"controls"`
+}
+
+// controlMappingsQuery retorna os mapeamentos visíveis para a organização (padrão + próprios) com os dois controles.
+func controlMappingsQuery(db *gorm.DB, organizationID uuid.UUID) *gorm.DB {
+	return db.Table("control_mappings").
+		Select("control_mappings.id, control_mappings.relationship, control_mappings.notes, control_mappings.organization_id IS NULL AS is_default, "+
+			"sc.id AS source_id, sc.control_id AS source_control_id, sc.framework_id AS source_framework_id, sf.name AS source_framework_name, "+
+			"tc.id AS target_id, tc.control_id AS target_control_id, tc.framework_id AS target_framework_id, tf.name AS target_framework_name").
+		Joins("JOIN audit_controls sc ON sc.id = control_mappings.source_control_id").
+		Joins("JOIN audit_frameworks sf ON sf.id = sc.framework_id").
+		Joins("JOIN audit_controls tc ON tc.id = control_mappings.target_control_id").
+		Joins("JOIN audit_frameworks tf ON tf.id = tc.framework_id").
+		Where("control_mappings.organization_id IS NULL OR control_mappings.organization_id = ?", organizationID)
+}
+
+type controlMappingRow struct {
+	ID                  uuid.UUID
+	Relationship        models.ControlMappingRelationship
+	Notes               string
+	IsDefault           bool
+	SourceID            uuid.UUID
+	SourceControlID     string
+	SourceFrameworkID   uuid.UUID
+	SourceFrameworkName string
+	TargetID            uuid.UUID
+	TargetControlID     string
+	TargetFrameworkID   uuid.UUID
+	TargetFrameworkName string
+}
+
+func (r controlMappingRow) response() ControlMappingResponse {
+	return ControlMappingResponse{
+		ID:           r.ID,
+		Source:       MappedControlRef{ID: r.SourceID, ControlID: r.SourceControlID, FrameworkID: r.SourceFrameworkID, FrameworkName: r.SourceFrameworkName},
+		Target:       MappedControlRef{ID: r.TargetID, ControlID: r.TargetControlID, FrameworkID: r.TargetFrameworkID, FrameworkName: r.TargetFrameworkName},
+		Relationship: r.Relationship,
+		Notes:        r.Notes,
+		IsDefault:    r.IsDefault,
+	}
+}
+
+// ListControlMappingsHandler lists the control mappings visible to the organization (default + custom).
+// Optional filters: framework_id and control_id match either side of the mapping.
+func ListControlMappingsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	query := controlMappingsQuery(database.GetDB(), orgID.(uuid.UUID))
+	if raw := c.Query("framework_id"); raw != "" {
+		frameworkID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid framework_id format"})
+			return
+		}
+		query = query.Where("sc.framework_id = ? OR tc.framework_id = ?", frameworkID, frameworkID)
+	}
+	if raw := c.Query("control_id"); raw != "" {
+		controlID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid control_id format"})
+			return
+		}
+		query = query.Where("sc.id = ? OR tc.id = ?", controlID, controlID)
+	}
+	var rows []controlMappingRow
+	if err := query.Order("sf.name asc, sc.sort_key asc, tf.name asc, tc.sort_key asc").Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list control mappings: " + err.Error()})
+		return
+	}
+	mappings := make([]ControlMappingResponse, 0, len(rows))
+	for _, row := range rows {
+		mappings = append(mappings, row.response())
+	}
+	c.JSON(http.StatusOK, mappings)
+}
+
+// CreateControlMappingHandler adds a custom mapping between controls of two different frameworks for the organization.
+func CreateControlMappingHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	var payload ControlMappingPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	sourceID, _ := uuid.Parse(payload.SourceControlID)
+	targetID, _ := uuid.Parse(payload.TargetControlID)
+
+	db := database.GetDB()
+	var controls []models.AuditControl
+	if err := db.Where("id IN ?", []uuid.UUID{sourceID, targetID}).Find(&controls).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch controls: " + err.Error()})
+		return
+	}
+	if len(controls) != 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Source and target must be two existing controls"})
+		return
+	}
+	if controls[0].FrameworkID == controls[1].FrameworkID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Source and target controls must belong to different frameworks"})
+		return
+	}
+
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	var count int64
+	db.Model(&models.ControlMapping{}).
+		Where("(organization_id IS NULL OR organization_id = ?) AND ((source_control_id = ? AND target_control_id = ?) OR (source_control_id = ? AND target_control_id = ?))",
+			organizationID, sourceID, targetID, targetID, sourceID).
+		Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "These controls are already mapped"})
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	createdBy := userID.(uuid.UUID)
+	mapping := models.ControlMapping{
+		OrganizationID:  &organizationID,
+		SourceControlID: sourceID,
+		TargetControlID: targetID,
+		Relationship:    payload.Relationship,
+		Notes:           payload.Notes,
+		CreatedByID:     &createdBy,
+	}
+	if err := db.Create(&mapping).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create control mapping: " + err.Error()})
+		return
+	}
+	var row controlMappingRow
+	if err := controlMappingsQuery(db, organizationID).Where("control_mappings.id = ?", mapping.ID).Scan(&row).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load control mapping: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, row.response())
+}
+
+// DeleteControlMappingHandler removes one of the organization's custom mappings. Default mappings cannot be removed.
+func DeleteControlMappingHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	mappingID, err := uuid.Parse(c.Param("mappingId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mapping ID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	var mapping models.ControlMapping
+	if err := db.Where("id = ? AND (organization_id IS NULL OR organization_id = ?)", mappingID, orgID).First(&mapping).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Control mapping not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch control mapping: " + err.Error()})
+		return
+	}
+	if mapping.OrganizationID == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Default control mappings cannot be removed"})
+		return
+	}
+	if err := db.Delete(&mapping).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete control mapping: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Control mapping deleted successfully"})
+}
+
+// GetCoverageProjectionHandler projects the organization's assessments of source_framework_id onto the
+// framework in :frameworkId through the control mappings, estimating its coverage ("assess once, comply many").
+func GetCoverageProjectionHandler(c *gin.Context) {
+	db := database.GetDB()
+	target, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	sourceFrameworkID, err := uuid.Parse(c.Query("source_framework_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source_framework_id query parameter is required and must be a valid UUID"})
+		return
+	}
+	if sourceFrameworkID == target.ID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source_framework_id must differ from the projected framework"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+
+	targetControls, err := loadOrderedFrameworkControls(db, organizationID, target.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list controls for framework: " + err.Error()})
+		return
+	}
+	var rows []controlMappingRow
+	err = controlMappingsQuery(db, organizationID).
+		Where("(sc.framework_id = ? AND tc.framework_id = ?) OR (sc.framework_id = ? AND tc.framework_id = ?)",
+			sourceFrameworkID, target.ID, target.ID, sourceFrameworkID).
+		Order("sc.sort_key asc, tc.sort_key asc").
+		Scan(&rows).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load control mappings: " + err.Error()})
+		return
+	}
+
+	var assessments []models.AuditAssessment
+	if err := db.Select("audit_control_id", "status").
+		Joins("JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id").
+		Where("audit_assessments.organization_id = ? AND audit_controls.framework_id IN ?", organizationID, []uuid.UUID{sourceFrameworkID, target.ID}).
+		Scopes(models.OrgWideAssessments).
+		Find(&assessments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load assessments: " + err.Error()})
+		return
+	}
+	statusByControl := make(map[uuid.UUID]models.AuditControlStatus, len(assessments))
+	for _, a := range assessments {
+		statusByControl[a.AuditControlID] = a.Status
+	}
+
+	// Os mapeamentos valem nos dois sentidos: normaliza para (controle alvo -> controles de origem).
+	sourcesByTarget := make(map[uuid.UUID][]ProjectedSourceControl)
+	for _, row := range rows {
+		targetID, sourceID, sourceCode := row.TargetID, row.SourceID, row.SourceControlID
+		if row.SourceFrameworkID == target.ID {
+			targetID, sourceID, sourceCode = row.SourceID, row.TargetID, row.TargetControlID
+		}
+		sourcesByTarget[targetID] = append(sourcesByTarget[targetID], ProjectedSourceControl{
+			ID:               sourceID,
+			ControlID:        sourceCode,
+			Relationship:     row.Relationship,
+			AssessmentStatus: statusByControl[sourceID],
+		})
+	}
+
+	response := CoverageProjectionResponse{
+		SourceFrameworkID:   sourceFrameworkID,
+		TargetFrameworkID:   target.ID,
+		TargetFrameworkName: target.Name,
+		TotalControls:       len(targetControls),
+		Controls:            make([]ProjectedControl, 0, len(targetControls)),
+	}
+	for _, ctrl := range targetControls {
+		sources := sourcesByTarget[ctrl.ID]
+		projected := ProjectedControl{
+			ID:            ctrl.ID,
+			ControlID:     ctrl.ControlID,
+			Description:   ctrl.Description,
+			Mapped:        len(sources) > 0,
+			CurrentStatus: statusByControl[ctrl.ID],
+			Sources:       sources,
+		}
+		if projected.Sources == nil {
+			projected.Sources = []ProjectedSourceControl{}
+		}
+		if projected.Mapped {
+			response.MappedControls++
+			inputs := make([]controlmapping.Source, 0, len(sources))
+			for _, s := range sources {
+				inputs = append(inputs, controlmapping.Source{Relationship: s.Relationship, Status: s.AssessmentStatus})
+			}
+			if status, ok := controlmapping.ProjectStatus(inputs); ok {
+				projected.ProjectedStatus = status
+				switch status {
+				case models.ControlStatusConformant:
+					response.ProjectedConformant++
+				case models.ControlStatusPartiallyConformant:
+					response.ProjectedPartial++
+				case models.ControlStatusNonConformant:
+					response.ProjectedNonConformant++
+				}
+			}
+		}
+		response.Controls = append(response.Controls, projected)
+	}
+	response.EstimatedCoveragePercent = controlmapping.EstimatedCoverage(response.ProjectedConformant, response.ProjectedPartial, response.TotalControls)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ControlOrderPayload define a ordem personalizada dos controles de um framework.
+// Controles não listados seguem após os listados, na sequência oficial.
+type ControlOrderPayload struct {
+	ControlIDs []string `json:"control_ids" binding:"required,min=1"`
+}
+
+// ControlOrderEntry é um controle na ordem efetiva do framework para a organização.
+type ControlOrderEntry struct {
+	ID          uuid.UUID `json:"id"`
+	ControlID   string    `json:"control_id"`
+	Position    int       `json:"position"`
+	CustomOrder bool      `json:"custom_order"` // true quando a posição vem da ordem personalizada da organização
+}
+
+// orderedControlsScope ordena audit_controls pela ordem personalizada da organização (se houver),
+// depois pela sequência oficial do framework e pela ordem natural do ControlID.
+func orderedControlsScope(organizationID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins("LEFT JOIN org_control_orders ON org_control_orders.audit_control_id = audit_controls.id AND org_control_orders.organization_id = ?", organizationID).
+			Order("org_control_orders.position ASC NULLS LAST, " + models.ControlOrderClause)
+	}
+}
+
+// loadOrderedFrameworkControls lista os controles do framework na ordem efetiva da organização.
+func loadOrderedFrameworkControls(db *gorm.DB, organizationID, frameworkID uuid.UUID) ([]models.AuditControl, error) {
+	var controls []models.AuditControl
+	err := db.Select("audit_controls.*").
+		Scopes(orderedControlsScope(organizationID)).
+		Where("audit_controls.framework_id = ?", frameworkID).
+		Find(&controls).Error
+	return controls, err
+}
+
+// loadControlOrder retorna a ordem efetiva (posição 1..n) dos controles do framework.
+func loadControlOrder(db *gorm.DB, organizationID, frameworkID uuid.UUID) ([]ControlOrderEntry, error) {
+	var rows []struct {
+		ID             uuid.UUID
+		ControlID      string
+		CustomPosition *int
+	}
+	err := db.Table("audit_controls").
+		Select("audit_controls.id, audit_controls.control_id, org_control_orders.position AS custom_position").
+		Scopes(orderedControlsScope(organizationID)).
+		Where("audit_controls.framework_id = ?", frameworkID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]ControlOrderEntry, 0, len(rows))
+	for i, row := range rows {
+		entries = append(entries, ControlOrderEntry{
+			ID:          row.ID,
+			ControlID:   row.ControlID,
+			Position:    i + 1,
+			CustomOrder: row.CustomPosition != nil,
+		})
+	}
+	return entries, nil
+}
+
+// findAuditFramework carrega o framework de :frameworkId (semeado ou personalizado da organização).
+func findAuditFramework(c *gin.Context, db *gorm.DB) (*models.AuditFramework, bool) {
+	frameworkID, err := uuid.Parse(c.Param("frameworkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid framework ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var framework models.AuditFramework
+	if err := db.Scopes(visibleFrameworksScope(orgID.(uuid.UUID))).First(&framework, "id = ?", frameworkID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch framework: " + err.Error()})
+		return nil, false
+	}
+	return &framework, true
+}
+
+// GetControlOrderHandler returns the effective control order of a framework for the organization.
+func GetControlOrderHandler(c *gin.Context) {
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	entries, err := loadControlOrder(db, orgID.(uuid.UUID), framework.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load control order: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// SetControlOrderHandler replaces the organization's custom control order for a framework.
+func SetControlOrderHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	var payload ControlOrderPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	controlIDs, err := parseUniqueUUIDs(payload.ControlIDs, "control_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var count int64
+	db.Model(&models.AuditControl{}).Where("id IN ? AND framework_id = ?", controlIDs, framework.ID).Count(&count)
+	if int(count) != len(controlIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "One or more controls were not found in this framework"})
+		return
+	}
+
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	orders := make([]models.OrgControlOrder, 0, len(controlIDs))
+	for i, id := range controlIDs {
+		orders = append(orders, models.OrgControlOrder{
+			OrganizationID: organizationID,
+			FrameworkID:    framework.ID,
+			AuditControlID: id,
+			Position:       i + 1,
+		})
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ? AND framework_id = ?", organizationID, framework.ID).Delete(&models.OrgControlOrder{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&orders).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save control order: " + err.Error()})
+		return
+	}
+	entries, err := loadControlOrder(db, organizationID, framework.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load control order: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// ResetControlOrderHandler removes the organization's custom order, restoring the framework's official sequence.
+func ResetControlOrderHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	if err := db.Where("organization_id = ? AND framework_id = ?", orgID, framework.ID).Delete(&models.OrgControlOrder{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset control order: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Control order reset to the framework's official sequence"})
+}
@@ -0,0 +1,412 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/testplanner"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ControlTestPlanPayload defines the structure for creating or updating a recurring control test.
+type ControlTestPlanPayload struct {
+	AuditControlID string                      `json:"audit_control_id" binding:"required"`
+	OwnerID        string                      `json:"owner_id" binding:"required"`
+	OwnerTeamID    string                      `json:"owner_team_id"` // Equipe responsável opcional; o planejamento usa a capacidade de owner_id
+	Frequency      models.ControlTestFrequency `json:"frequency" binding:"required,oneof=monthly quarterly semiannual annual"`
+	EstimatedHours float64                     `json:"estimated_hours" binding:"required,gt=0,lte=500"`
+	Notes          string                      `json:"notes"`
+	IsActive       *bool                       `json:"is_active"`
+}
+
+// ControlTestCapacityPayload define a capacidade mensal de um responsável.
+type ControlTestCapacityPayload struct {
+	MonthlyHours float64 `json:"monthly_hours" binding:"required,gt=0,lte=744"`
+}
+
+// ScheduledControlTest é uma execução agendada, enriquecida para exibição.
+type ScheduledControlTest struct {
+	testplanner.ScheduledTest
+	AuditControlID uuid.UUID `json:"audit_control_id"`
+	ControlCode    string    `json:"control_code"`
+	OwnerName      string    `json:"owner_name"`
+}
+
+// ControlTestScheduleMonth agrupa as execuções de um mês.
+type ControlTestScheduleMonth struct {
+	Month      int                    `json:"month"`
+	TotalHours float64                `json:"total_hours"`
+	Tests      []ScheduledControlTest `json:"tests"`
+}
+
+// ControlTestScheduleResponse é o calendário anual de testes.
+type ControlTestScheduleResponse struct {
+	Year       int                        `json:"year"`
+	Months     []ControlTestScheduleMonth `json:"months"`
+	Overbooked []testplanner.Overbooking  `json:"overbooked"`
+}
+
+// validateControlTestPlanPayload converte e valida os IDs do payload para a organização.
+func validateControlTestPlanPayload(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, payload ControlTestPlanPayload) (uuid.UUID, uuid.UUID, bool) {
+	controlID, err := uuid.Parse(payload.AuditControlID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit_control_id format"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	var count int64
+	db.Model(&models.AuditControl{}).Where("id = ?", controlID).Count(&count)
+	if count == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audit control not found"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	ownerID, err := parseOrgUserID(db, organizationID, payload.OwnerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid owner_id: " + err.Error()})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return controlID, ownerID, true
+}
+
+// CreateControlTestPlanHandler creates a recurring control test.
+func CreateControlTestPlanHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ControlTestsManage) {
+		return
+	}
+	var payload ControlTestPlanPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+
+	controlID, ownerID, ok := validateControlTestPlanPayload(c, db, organizationID, payload)
+	if !ok {
+		return
+	}
+	ownerTeamID, ok := parseOptionalOrgTeamID(c, db, organizationID, "owner_team_id", payload.OwnerTeamID)
+	if !ok {
+		return
+	}
+	plan := models.ControlTestPlan{
+		OrganizationID: organizationID,
+		AuditControlID: controlID,
+		OwnerID:        ownerID,
+		OwnerTeamID:    ownerTeamID,
+		Frequency:      payload.Frequency,
+		EstimatedHours: payload.EstimatedHours,
+		Notes:          payload.Notes,
+		IsActive:       payload.IsActive == nil || *payload.IsActive,
+	}
+	if err := db.Create(&plan).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create control test plan: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, plan)
+}
+
+// ListControlTestPlansHandler lists the organization's recurring control tests with pagination.
+// Filtros opcionais: owner_id, owner_team_id, audit_control_id, frequency.
+func ListControlTestPlansHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	page, pageSize := GetPaginationParams(c)
+	db := database.GetDB()
+
+	query := db.Model(&models.ControlTestPlan{}).Where("organization_id = ?", orgID)
+	if ownerID := c.Query("owner_id"); ownerID != "" {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+	if teamID := c.Query("owner_team_id"); teamID != "" {
+		if _, err := uuid.Parse(teamID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid owner_team_id: use a team ID"})
+			return
+		}
+		query = query.Where("owner_team_id = ?", teamID)
+	}
+	if controlID := c.Query("audit_control_id"); controlID != "" {
+		query = query.Where("audit_control_id = ?", controlID)
+	}
+	if frequency := c.Query("frequency"); frequency != "" {
+		query = query.Where("frequency = ?", frequency)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count control test plans: " + err.Error()})
+		return
+	}
+	var plans []models.ControlTestPlan
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at asc").Find(&plans).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list control test plans: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      plans,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// findOrgControlTestPlan carrega o plano de :planId pertencente à organização do token.
+func findOrgControlTestPlan(c *gin.Context, db *gorm.DB) (*models.ControlTestPlan, bool) {
+	planID, err := uuid.Parse(c.Param("planId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid plan ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var plan models.ControlTestPlan
+	if err := db.Where("id = ? AND organization_id = ?", planID, orgID).First(&plan).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Control test plan not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch control test plan: " + err.Error()})
+		return nil, false
+	}
+	return &plan, true
+}
+
+// UpdateControlTestPlanHandler updates a recurring control test.
+func UpdateControlTestPlanHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ControlTestsManage) {
+		return
+	}
+	var payload ControlTestPlanPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	plan, ok := findOrgControlTestPlan(c, db)
+	if !ok {
+		return
+	}
+	controlID, ownerID, ok := validateControlTestPlanPayload(c, db, plan.OrganizationID, payload)
+	if !ok {
+		return
+	}
+	ownerTeamID, ok := parseOptionalOrgTeamID(c, db, plan.OrganizationID, "owner_team_id", payload.OwnerTeamID)
+	if !ok {
+		return
+	}
+
+	plan.AuditControlID = controlID
+	plan.OwnerID = ownerID
+	plan.OwnerTeamID = ownerTeamID
+	plan.Frequency = payload.Frequency
+	plan.EstimatedHours = payload.EstimatedHours
+	plan.Notes = payload.Notes
+	if payload.IsActive != nil {
+		plan.IsActive = *payload.IsActive
+	}
+	if err := db.Save(plan).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update control test plan: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+// DeleteControlTestPlanHandler deletes a recurring control test.
+func DeleteControlTestPlanHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ControlTestsManage) {
+		return
+	}
+	db := database.GetDB()
+	plan, ok := findOrgControlTestPlan(c, db)
+	if !ok {
+		return
+	}
+	if err := db.Delete(plan).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete control test plan: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Control test plan deleted successfully"})
+}
+
+// ListControlTestCapacitiesHandler lists the configured monthly testing capacity per owner.
+func ListControlTestCapacitiesHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	var capacities []models.ControlTestCapacity
+	if err := database.GetDB().Where("organization_id = ?", orgID).Find(&capacities).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list capacities: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"default_monthly_hours": testplanner.DefaultMonthlyCapacityHours,
+		"capacities":            capacities,
+	})
+}
+
+// SetControlTestCapacityHandler sets a user's monthly testing capacity (hours).
+func SetControlTestCapacityHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ControlTestsManage) {
+		return
+	}
+	var payload ControlTestCapacityPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+
+	userID, err := parseOrgUserID(db, organizationID, c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user: " + err.Error()})
+		return
+	}
+	capacity := models.ControlTestCapacity{OrganizationID: organizationID, UserID: userID, MonthlyHours: payload.MonthlyHours}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"monthly_hours", "updated_at"}),
+	}).Create(&capacity).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save capacity: " + err.Error()})
+		return
+	}
+	db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&capacity)
+	c.JSON(http.StatusOK, capacity)
+}
+
+// buildControlTestSchedule planeja o ano para a organização do token.
+// O planejamento considera todos os testes ativos (a capacidade é por responsável); owner_id apenas filtra a saída.
+func buildControlTestSchedule(c *gin.Context) (*ControlTestScheduleResponse, bool) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+
+	year := time.Now().Year()
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil || parsed < 2000 || parsed > 2100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+			return nil, false
+		}
+		year = parsed
+	}
+	var ownerFilter uuid.UUID
+	if ownerStr := c.Query("owner_id"); ownerStr != "" {
+		parsed, err := uuid.Parse(ownerStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid owner_id format"})
+			return nil, false
+		}
+		ownerFilter = parsed
+	}
+
+	var plans []models.ControlTestPlan
+	if err := db.Preload("AuditControl").Where("organization_id = ? AND is_active = ?", orgID, true).Find(&plans).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load control test plans: " + err.Error()})
+		return nil, false
+	}
+	var capacities []models.ControlTestCapacity
+	if err := db.Where("organization_id = ?", orgID).Find(&capacities).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load capacities: " + err.Error()})
+		return nil, false
+	}
+
+	inputs := make([]testplanner.PlanInput, 0, len(plans))
+	plansByID := make(map[uuid.UUID]models.ControlTestPlan, len(plans))
+	ownerIDs := []uuid.UUID{}
+	for _, p := range plans {
+		inputs = append(inputs, testplanner.PlanInput{PlanID: p.ID, OwnerID: p.OwnerID, Frequency: p.Frequency, EstimatedHours: p.EstimatedHours})
+		plansByID[p.ID] = p
+		ownerIDs = append(ownerIDs, p.OwnerID)
+	}
+	capacityByOwner := make(map[uuid.UUID]float64, len(capacities))
+	for _, capacity := range capacities {
+		capacityByOwner[capacity.UserID] = capacity.MonthlyHours
+	}
+	ownerNames := make(map[uuid.UUID]string)
+	if len(ownerIDs) > 0 {
+		var owners []models.User
+		db.Select("id", "name").Where("id IN ?", ownerIDs).Find(&owners)
+		for _, u := range owners {
+			ownerNames[u.ID] = u.Name
+		}
+	}
+
+	schedule := testplanner.Plan(inputs, capacityByOwner)
+
+	response := &ControlTestScheduleResponse{Year: year, Months: make([]ControlTestScheduleMonth, 12), Overbooked: []testplanner.Overbooking{}}
+	for m := range response.Months {
+		response.Months[m] = ControlTestScheduleMonth{Month: m + 1, Tests: []ScheduledControlTest{}}
+	}
+	for _, test := range schedule.Tests {
+		if ownerFilter != uuid.Nil && test.OwnerID != ownerFilter {
+			continue
+		}
+		plan := plansByID[test.PlanID]
+		month := &response.Months[test.Month-1]
+		month.TotalHours += test.EstimatedHours
+		month.Tests = append(month.Tests, ScheduledControlTest{
+			ScheduledTest:  test,
+			AuditControlID: plan.AuditControlID,
+			ControlCode:    plan.AuditControl.ControlID,
+			OwnerName:      ownerNames[test.OwnerID],
+		})
+	}
+	for _, ob := range schedule.Overbooked {
+		if ownerFilter == uuid.Nil || ob.OwnerID == ownerFilter {
+			response.Overbooked = append(response.Overbooked, ob)
+		}
+	}
+	return response, true
+}
+
+// GetControlTestScheduleHandler returns the per-month control testing schedule for a year.
+// Query params opcionais: year (padrão: ano atual), owner_id.
+func GetControlTestScheduleHandler(c *gin.Context) {
+	response, ok := buildControlTestSchedule(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ExportControlTestScheduleICSHandler exports the schedule as an iCalendar file.
+// Cada execução vira um evento de dia inteiro cobrindo o mês em que deve ser realizada.
+func ExportControlTestScheduleICSHandler(c *gin.Context) {
+	response, ok := buildControlTestSchedule(c)
+	if !ok {
+		return
+	}
+
+	var events []testplanner.ICSEvent
+	for _, month := range response.Months {
+		start := time.Date(response.Year, time.Month(month.Month), 1, 0, 0, 0, 0, time.UTC)
+		for _, test := range month.Tests {
+			events = append(events, testplanner.ICSEvent{
+				UID:     fmt.Sprintf("%s-%d-%d@phoenixgrc", test.PlanID, response.Year, test.Occurrence),
+				Summary: fmt.Sprintf("Teste de controle %s", test.ControlCode),
+				Description: fmt.Sprintf("Responsável: %s\nEsforço estimado: %.1f h\nExecução %d do ano",
+					test.OwnerName, test.EstimatedHours, test.Occurrence),
+				Start: start,
+				End:   start.AddDate(0, 1, 0),
+			})
+		}
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"control_tests_%d.ics\"", response.Year))
+	c.Status(http.StatusOK)
+	if err := testplanner.WriteICS(c.Writer, fmt.Sprintf("Testes de Controle %d", response.Year), events); err != nil {
+		c.Error(err)
+	}
+}
@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/waivers"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ControlWaiverPayload defines the structure for requesting a control waiver.
+type ControlWaiverPayload struct {
+	AuditControlID         string   `json:"audit_control_id" binding:"required,uuid"`
+	Justification          string   `json:"justification" binding:"required,min=10"`
+	CompensatingMeasures   string   `json:"compensating_measures"`
+	CompensatingControlIDs []string `json:"compensating_control_ids" binding:"omitempty,dive,uuid"`
+	ExpiresAt              string   `json:"expires_at" binding:"required"`     // YYYY-MM-DD, último dia de validade
+	OwnerID                string   `json:"owner_id" binding:"omitempty,uuid"` // Padrão: o solicitante
+	ApproverID             string   `json:"approver_id" binding:"required,uuid"`
+}
+
+// ControlWaiverApprovalResponse é uma etapa de aprovação (ApprovalWorkflow) da exceção.
+type ControlWaiverApprovalResponse struct {
+	ID          uuid.UUID             `json:"id"`
+	RequesterID uuid.UUID             `json:"requester_id"`
+	ApproverID  uuid.UUID             `json:"approver_id"`
+	Status      models.ApprovalStatus `json:"status"`
+	Comments    string                `json:"comments,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// ControlWaiverResponse é a exceção com o histórico de aprovação.
+type ControlWaiverResponse struct {
+	models.ControlWaiver
+	Approvals []ControlWaiverApprovalResponse `json:"approvals"`
+}
+
+func newControlWaiverResponse(db *gorm.DB, waiver models.ControlWaiver) (ControlWaiverResponse, error) {
+	var workflows []models.ApprovalWorkflow
+	if err := db.Where("control_waiver_id = ?", waiver.ID).Order("created_at desc").Find(&workflows).Error; err != nil {
+		return ControlWaiverResponse{}, err
+	}
+	resp := ControlWaiverResponse{ControlWaiver: waiver, Approvals: make([]ControlWaiverApprovalResponse, 0, len(workflows))}
+	for _, wf := range workflows {
+		resp.Approvals = append(resp.Approvals, ControlWaiverApprovalResponse{
+			ID:          wf.ID,
+			RequesterID: wf.RequesterID,
+			ApproverID:  wf.ApproverID,
+			Status:      wf.Status,
+			Comments:    wf.Comments,
+			CreatedAt:   wf.CreatedAt,
+			UpdatedAt:   wf.UpdatedAt,
+		})
+	}
+	return resp, nil
+}
+
+// findOrgControlWaiver carrega a exceção :waiverId da organização, com os controles compensatórios.
+func findOrgControlWaiver(c *gin.Context, db *gorm.DB, organizationID uuid.UUID) (*models.ControlWaiver, bool) {
+	waiverID, err := uuid.Parse(c.Param("waiverId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid waiver ID format"})
+		return nil, false
+	}
+	var waiver models.ControlWaiver
+	if err := db.Preload("CompensatingControls").Where("id = ? AND organization_id = ?", waiverID, organizationID).First(&waiver).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Control waiver not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch control waiver: " + err.Error()})
+		return nil, false
+	}
+	return &waiver, true
+}
+
+// respondControlWaiver responde a exceção com o histórico de aprovação.
+func respondControlWaiver(c *gin.Context, db *gorm.DB, status int, waiver models.ControlWaiver) {
+	resp, err := newControlWaiverResponse(db, waiver)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch waiver approvals: " + err.Error()})
+		return
+	}
+	c.JSON(status, resp)
+}
+
+// CreateControlWaiverHandler requests a waiver for a control. The waiver stays pending until the chosen
+// approver decides it; once approved the control is marked as waived until expires_at.
+func CreateControlWaiverHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	requesterID := userID.(uuid.UUID)
+
+	var payload ControlWaiverPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	expiresAt, err := time.Parse("2006-01-02", payload.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expires_at format, use YYYY-MM-DD"})
+		return
+	}
+	if expiresAt.Format("2006-01-02") < time.Now().Format("2006-01-02") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at cannot be in the past"})
+		return
+	}
+
+	db := database.GetDB()
+	controlID := uuid.MustParse(payload.AuditControlID)
+	var controlCount int64
+	db.Model(&models.AuditControl{}).
+		Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Scopes(visibleFrameworksScope(organizationID)).
+		Where("audit_controls.id = ?", controlID).Count(&controlCount)
+	if controlCount == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audit control not found"})
+		return
+	}
+
+	var compensating []models.ControlWaiverCompensatingControl
+	seen := map[uuid.UUID]bool{}
+	for _, raw := range payload.CompensatingControlIDs {
+		id := uuid.MustParse(raw)
+		if id == controlID || seen[id] {
+			continue
+		}
+		seen[id] = true
+		compensating = append(compensating, models.ControlWaiverCompensatingControl{AuditControlID: id})
+	}
+	if len(compensating) > 0 {
+		ids := make([]uuid.UUID, 0, len(compensating))
+		for _, cc := range compensating {
+			ids = append(ids, cc.AuditControlID)
+		}
+		var found int64
+		db.Model(&models.AuditControl{}).
+			Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+			Scopes(visibleFrameworksScope(organizationID)).
+			Where("audit_controls.id IN ?", ids).Count(&found)
+		if found != int64(len(ids)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more compensating controls were not found"})
+			return
+		}
+	}
+
+	ownerID := requesterID
+	if payload.OwnerID != "" {
+		ownerID = uuid.MustParse(payload.OwnerID)
+		var ownerCount int64
+		db.Model(&models.User{}).Where("id = ? AND organization_id = ? AND is_active = ?", ownerID, organizationID, true).Count(&ownerCount)
+		if ownerCount == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Owner not found or not part of your organization"})
+			return
+		}
+	}
+	// Quatro olhos: o aprovador é um usuário ativo da organização com control_waivers:manage, diferente do solicitante
+	approverID := uuid.MustParse(payload.ApproverID)
+	if approverID == requesterID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The approver must be different from the requester"})
+		return
+	}
+	var approver models.User
+	if err := db.Where("id = ? AND organization_id = ? AND is_active = ?", approverID, organizationID, true).
+		First(&approver).Error; err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Approver must be an active admin or manager of your organization"})
+		return
+	}
+	canApprove, err := rbac.UserCan(db, approver, rbac.ControlWaiversManage)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check approver permissions: " + err.Error()})
+		return
+	}
+	if !canApprove {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Approver must be an active admin or manager of your organization"})
+		return
+	}
+
+	var openCount int64
+	db.Model(&models.ControlWaiver{}).
+		Where("organization_id = ? AND audit_control_id = ? AND status IN ?", organizationID, controlID,
+			[]models.ControlWaiverStatus{models.ControlWaiverPending, models.ControlWaiverActive}).
+		Count(&openCount)
+	if openCount > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "This control already has a pending or active waiver"})
+		return
+	}
+
+	waiver := models.ControlWaiver{
+		OrganizationID:       organizationID,
+		AuditControlID:       controlID,
+		Justification:        payload.Justification,
+		CompensatingMeasures: payload.CompensatingMeasures,
+		ExpiresAt:            expiresAt,
+		Status:               models.ControlWaiverPending,
+		OwnerID:              ownerID,
+		RequestedByID:        requesterID,
+		ApproverID:           approverID,
+		CompensatingControls: compensating,
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&waiver).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.ApprovalWorkflow{
+			OrganizationID:  organizationID,
+			ControlWaiverID: &waiver.ID,
+			RequesterID:     requesterID,
+			ApproverID:      approverID,
+			Status:          models.ApprovalPending,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create control waiver: " + err.Error()})
+		return
+	}
+
+	var control models.AuditControl
+	db.Select("id", "control_id", "description").First(&control, "id = ?", controlID)
+	subject := fmt.Sprintf("Ação Requerida: Aprovação de Exceção para o Controle %s", control.ControlID)
+	body := fmt.Sprintf("Olá %s,\n\nFoi solicitada uma exceção para o controle %s (%s), válida até %s.\n\nJustificativa: %s\n\nMedidas compensatórias: %s\n\nPor favor, acesse o Phoenix GRC para revisar e tomar uma decisão.",
+		approver.Name, control.ControlID, control.Description, expiresAt.Format("02/01/2006"), waiver.Justification, waiver.CompensatingMeasures)
+	notifications.NotifyUserByEmail(c.Request.Context(), approverID, subject, body)
+
+	respondControlWaiver(c, db, http.StatusCreated, waiver)
+}
+
+// ListControlWaiversHandler lists the organization's control waivers, newest first.
+// Filtros opcionais: status, audit_control_id.
+func ListControlWaiversHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Model(&models.ControlWaiver{}).Where("organization_id = ?", orgID)
+	if status := c.Query("status"); status != "" {
+		switch models.ControlWaiverStatus(status) {
+		case models.ControlWaiverPending, models.ControlWaiverActive, models.ControlWaiverRejected, models.ControlWaiverExpired, models.ControlWaiverRevoked:
+			query = query.Where("status = ?", status)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status filter"})
+			return
+		}
+	}
+	if raw := c.Query("audit_control_id"); raw != "" {
+		controlID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit_control_id format"})
+			return
+		}
+		query = query.Where("audit_control_id = ?", controlID)
+	}
+
+	page, pageSize := GetPaginationParams(c)
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count control waivers: " + err.Error()})
+		return
+	}
+	var waiverList []models.ControlWaiver
+	if err := query.Scopes(PaginateScope(page, pageSize)).Preload("CompensatingControls").Order("created_at desc").Find(&waiverList).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list control waivers: " + err.Error()})
+		return
+	}
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: waiverList, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
+
+// GetControlWaiverHandler returns a control waiver with its approval history.
+func GetControlWaiverHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	waiver, ok := findOrgControlWaiver(c, db, orgID.(uuid.UUID))
+	if !ok {
+		return
+	}
+	respondControlWaiver(c, db, http.StatusOK, *waiver)
+}
+
+// DecideControlWaiverHandler approves or rejects a pending waiver. Only the designated approver can decide;
+// approval marks the control as waived in the organization's continuous assessment.
+func DecideControlWaiverHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	waiver, ok := findOrgControlWaiver(c, db, organizationID)
+	if !ok {
+		return
+	}
+	approvalID, err := uuid.Parse(c.Param("approvalId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval workflow ID format"})
+		return
+	}
+	var payload DecisionPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	var workflow models.ApprovalWorkflow
+	if err := db.Where("id = ? AND control_waiver_id = ?", approvalID, waiver.ID).First(&workflow).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Approval workflow not found for this waiver"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch approval workflow: " + err.Error()})
+		return
+	}
+	if workflow.ApproverID != userID.(uuid.UUID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the designated approver can decide this waiver"})
+		return
+	}
+	if workflow.Status != models.ApprovalPending || waiver.Status != models.ControlWaiverPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "This waiver has already been decided"})
+		return
+	}
+
+	now := time.Now()
+	decideWorkflow := func(tx *gorm.DB) error {
+		return tx.Model(&workflow).Updates(map[string]interface{}{"status": payload.Decision, "comments": payload.Comments}).Error
+	}
+	var tally *models.ComplianceScoreTally
+	if payload.Decision == models.ApprovalApproved {
+		if waiver.ExpiresAt.Format("2006-01-02") < now.Format("2006-01-02") {
+			c.JSON(http.StatusConflict, gin.H{"error": "This waiver expired before it was approved; reject it and request a new one"})
+			return
+		}
+		tally, err = waivers.Activate(db, waiver, now, decideWorkflow)
+	} else {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&models.ControlWaiver{}).Where("id = ? AND status = ?", waiver.ID, models.ControlWaiverPending).
+				Updates(map[string]interface{}{"status": models.ControlWaiverRejected, "closed_at": now})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return waivers.ErrNotPending
+			}
+			waiver.Status = models.ControlWaiverRejected
+			waiver.ClosedAt = &now
+			return decideWorkflow(tx)
+		})
+	}
+	if errors.Is(err, waivers.ErrNotPending) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This waiver has already been decided"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decide control waiver: " + err.Error()})
+		return
+	}
+	publishComplianceScore(organizationID, tally)
+
+	var control models.AuditControl
+	db.Select("id", "control_id").First(&control, "id = ?", waiver.AuditControlID)
+	decision := "rejeitada"
+	if waiver.Status == models.ControlWaiverActive {
+		decision = fmt.Sprintf("aprovada e o controle está dispensado até %s", waiver.ExpiresAt.Format("02/01/2006"))
+	}
+	subject := fmt.Sprintf("Exceção do Controle %s: %s", control.ControlID, payload.Decision)
+	body := fmt.Sprintf("A exceção solicitada para o controle %s foi %s.\n\nComentários: %s\n\nAcesse o Phoenix GRC para mais detalhes.",
+		control.ControlID, decision, payload.Comments)
+	notifications.NotifyUserByEmail(c.Request.Context(), waiver.RequestedByID, subject, body)
+	if waiver.OwnerID != waiver.RequestedByID {
+		notifications.NotifyUserByEmail(c.Request.Context(), waiver.OwnerID, subject, body)
+	}
+
+	respondControlWaiver(c, db, http.StatusOK, *waiver)
+}
+
+// RevokeControlWaiverHandler revokes a pending or active waiver (control_waivers:manage). An active waiver returns
+// the control to non-conformant, as on expiry.
+func RevokeControlWaiverHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ControlWaiversManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+	waiver, ok := findOrgControlWaiver(c, db, organizationID)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	var tally *models.ComplianceScoreTally
+	var err error
+	switch waiver.Status {
+	case models.ControlWaiverActive:
+		tally, err = waivers.Lapse(db, waiver, models.ControlWaiverRevoked, now)
+	case models.ControlWaiverPending:
+		err = db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&models.ControlWaiver{}).Where("id = ? AND status = ?", waiver.ID, models.ControlWaiverPending).
+				Updates(map[string]interface{}{"status": models.ControlWaiverRevoked, "closed_at": now})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return waivers.ErrNotPending
+			}
+			waiver.Status = models.ControlWaiverRevoked
+			waiver.ClosedAt = &now
+			// A aprovação pendente deixa de valer
+			return tx.Model(&models.ApprovalWorkflow{}).
+				Where("control_waiver_id = ? AND status = ?", waiver.ID, models.ApprovalPending).
+				Updates(map[string]interface{}{"status": models.ApprovalRejected, "comments": "Exceção revogada antes da decisão"}).Error
+		})
+	default:
+		c.JSON(http.StatusConflict, gin.H{"error": "Only pending or active waivers can be revoked"})
+		return
+	}
+	if errors.Is(err, waivers.ErrNotActive) || errors.Is(err, waivers.ErrNotPending) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only pending or active waivers can be revoked"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke control waiver: " + err.Error()})
+		return
+	}
+	publishComplianceScore(organizationID, tally)
+	respondControlWaiver(c, db, http.StatusOK, *waiver)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // RiskMatrixData defines the structure for the risk matrix data.
@@ -59,33 +60,45 @@ func GetRecentActivityHandler(c *gin.Context) {
 	}
 	organizationID := orgID.(uuid.UUID)
 
-	db := database.GetDB()
-	var activities []RecentActivityData
+	activities, err := recentActivity(database.GetDB(), organizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch recent activity"})
+		return
+	}
+
+	c.JSON(http.StatusOK, activities)
+}
+
+// recentActivity retorna as 10 atividades mais recentes da organização (riscos e vulnerabilidades criados).
+func recentActivity(db *gorm.DB, organizationID uuid.UUID) ([]RecentActivityData, error) {
+	activities := []RecentActivityData{}
 
 	// Fetch recent risks
 	var risks []models.Risk
-	if err := db.Where("organization_id = ?", organizationID).Order("created_at desc").Limit(5).Find(&risks).Error; err == nil {
-		for _, r := range risks {
-			activities = append(activities, RecentActivityData{
-				Type:      "Risco",
-				Title:     r.Title,
-				Timestamp: r.CreatedAt,
-				Link:      fmt.Sprintf("/admin/risks/%s", r.ID),
-			})
-		}
+	if err := db.Where("organization_id = ?", organizationID).Order("created_at desc").Limit(5).Find(&risks).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range risks {
+		activities = append(activities, RecentActivityData{
+			Type:      "Risco",
+			Title:     r.Title,
+			Timestamp: r.CreatedAt,
+			Link:      fmt.Sprintf("/admin/risks/%s", r.ID),
+		})
 	}
 
 	// Fetch recent vulnerabilities
 	var vulnerabilities []models.Vulnerability
-	if err := db.Where("organization_id = ?", organizationID).Order("created_at desc").Limit(5).Find(&vulnerabilities).Error; err == nil {
-		for _, v := range vulnerabilities {
-			activities = append(activities, RecentActivityData{
-				Type:      "Vulnerabilidade",
-				Title:     v.Title,
-				Timestamp: v.CreatedAt,
-				Link:      fmt.Sprintf("/admin/vulnerabilities/edit/%s", v.ID),
-			})
-		}
+	if err := db.Where("organization_id = ?", organizationID).Order("created_at desc").Limit(5).Find(&vulnerabilities).Error; err != nil {
+		return nil, err
+	}
+	for _, v := range vulnerabilities {
+		activities = append(activities, RecentActivityData{
+			Type:      "Vulnerabilidade",
+			Title:     v.Title,
+			Timestamp: v.CreatedAt,
+			Link:      fmt.Sprintf("/admin/vulnerabilities/edit/%s", v.ID),
+		})
 	}
 
 	// Sort activities by timestamp desc
@@ -97,8 +110,7 @@ func GetRecentActivityHandler(c *gin.Context) {
 	if len(activities) > 10 {
 		activities = activities[:10]
 	}
-
-	c.JSON(http.StatusOK, activities)
+	return activities, nil
 }
 
 // VulnerabilitySummaryData defines the structure for the vulnerability summary data.
@@ -170,7 +182,7 @@ func GetComplianceOverviewHandler(c *gin.Context) {
 	if err := db.Table("audit_frameworks").
 		Select("audit_frameworks.name as framework_name, COALESCE(AVG(audit_assessments.score), 0) as score").
 		Joins("LEFT JOIN audit_controls ON audit_controls.framework_id = audit_frameworks.id").
-		Joins("LEFT JOIN audit_assessments ON audit_assessments.audit_control_id = audit_controls.id AND audit_assessments.organization_id = ?", organizationID).
+		Joins("LEFT JOIN audit_assessments ON audit_assessments.audit_control_id = audit_controls.id AND audit_assessments.organization_id = ? AND audit_assessments.audit_campaign_id IS NULL", organizationID).
 		Group("audit_frameworks.id").
 		Scan(&results).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch compliance overview data"})
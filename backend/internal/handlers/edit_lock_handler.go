@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// editLockTTL é a duração de um lease de edição. Clientes devem renovar (POST) antes de expirar,
+// tipicamente a cada 30-60 segundos enquanto o formulário estiver aberto.
+const editLockTTL = 2 * time.Minute
+
+// parseEditLockResource valida o tipo/ID do recurso e garante que ele pertence à organização do token.
+func parseEditLockResource(c *gin.Context, db *gorm.DB, organizationID uuid.UUID) (models.EditLockResourceType, uuid.UUID, bool) {
+	resourceType := models.EditLockResourceType(c.Param("resourceType"))
+	resourceID, err := uuid.Parse(c.Param("resourceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID format"})
+		return "", uuid.Nil, false
+	}
+
+	var count int64
+	switch resourceType {
+	case models.EditLockResourceRisk:
+		db.Model(&models.Risk{}).Where("id = ? AND organization_id = ?", resourceID, organizationID).Count(&count)
+	case models.EditLockResourceAssessment:
+		// Avaliações são únicas por (organização, controle); o lock é pelo controle.
+		db.Model(&models.AuditControl{}).Where("id = ?", resourceID).Count(&count)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource type, must be one of: risk, assessment"})
+		return "", uuid.Nil, false
+	}
+	if count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found or not part of your organization"})
+		return "", uuid.Nil, false
+	}
+	return resourceType, resourceID, true
+}
+
+// findActiveEditLock retorna o lock não expirado do recurso, ou nil.
+func findActiveEditLock(db *gorm.DB, organizationID uuid.UUID, resourceType models.EditLockResourceType, resourceID uuid.UUID) (*models.EditLock, error) {
+	var lock models.EditLock
+	err := db.Where("organization_id = ? AND resource_type = ? AND resource_id = ? AND expires_at > ?",
+		organizationID, resourceType, resourceID, time.Now()).First(&lock).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// ensureNotLockedByOther é usado pelos handlers de edição antes de salvar.
+// Se outro usuário detém um lock ativo, responde 409 com os dados do lock e retorna false.
+func ensureNotLockedByOther(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, resourceType models.EditLockResourceType, resourceID, userID uuid.UUID) bool {
+	lock, err := findActiveEditLock(db, organizationID, resourceType, resourceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check edit lock: " + err.Error()})
+		return false
+	}
+	if lock != nil && lock.UserID != userID {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "This record is being edited by " + lock.UserName + ". Try again after they finish.",
+			"lock":  lock,
+		})
+		return false
+	}
+	return true
+}
+
+// AcquireEditLockHandler acquires or renews the edit lease on a risk or assessment.
+// Retorna 409 com o detentor atual se outro usuário estiver editando.
+func AcquireEditLockHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	currentUserID := userID.(uuid.UUID)
+	db := database.GetDB()
+
+	resourceType, resourceID, ok := parseEditLockResource(c, db, organizationID)
+	if !ok {
+		return
+	}
+
+	var user models.User
+	db.Select("id", "name").First(&user, "id = ?", currentUserID)
+
+	now := time.Now()
+	lock := models.EditLock{
+		OrganizationID: organizationID,
+		ResourceType:   resourceType,
+		ResourceID:     resourceID,
+		UserID:         currentUserID,
+		UserName:       user.Name,
+		AcquiredAt:     now,
+		ExpiresAt:      now.Add(editLockTTL),
+	}
+
+	// Upsert atômico: só sobrescreve o lock existente se ele expirou ou pertence ao mesmo usuário.
+	result := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "organization_id"}, {Name: "resource_type"}, {Name: "resource_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"user_id":     currentUserID,
+			"user_name":   user.Name,
+			"acquired_at": gorm.Expr("CASE WHEN edit_locks.user_id = ? THEN edit_locks.acquired_at ELSE ? END", currentUserID, now),
+			"expires_at":  lock.ExpiresAt,
+			"updated_at":  now,
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "edit_locks.expires_at <= ? OR edit_locks.user_id = ?", Vars: []interface{}{now, currentUserID}},
+		}},
+	}).Create(&lock)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to acquire edit lock: " + result.Error.Error()})
+		return
+	}
+
+	current, err := findActiveEditLock(db, organizationID, resourceType, resourceID)
+	if err != nil || current == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read edit lock after acquiring"})
+		return
+	}
+	if result.RowsAffected == 0 || current.UserID != currentUserID {
+		c.JSON(http.StatusConflict, gin.H{"error": "This record is being edited by " + current.UserName, "lock": current})
+		return
+	}
+	c.JSON(http.StatusOK, current)
+}
+
+// GetEditLockHandler returns who is currently editing the resource (presence).
+func GetEditLockHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+
+	resourceType, resourceID, ok := parseEditLockResource(c, db, organizationID)
+	if !ok {
+		return
+	}
+	lock, err := findActiveEditLock(db, organizationID, resourceType, resourceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch edit lock: " + err.Error()})
+		return
+	}
+	if lock == nil {
+		c.JSON(http.StatusOK, gin.H{"locked": false})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"locked": true, "lock": lock})
+}
+
+// ReleaseEditLockHandler releases the lease. Com edit_locks:release_any, libera também locks de outros usuários.
+func ReleaseEditLockHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+
+	resourceType, resourceID, ok := parseEditLockResource(c, db, organizationID)
+	if !ok {
+		return
+	}
+
+	query := db.Where("organization_id = ? AND resource_type = ? AND resource_id = ?", organizationID, resourceType, resourceID)
+	if !rbac.Can(c, rbac.EditLocksReleaseAny) {
+		query = query.Where("user_id = ?", userID.(uuid.UUID))
+	}
+	if err := query.Delete(&models.EditLock{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to release edit lock: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Edit lock released"})
+}
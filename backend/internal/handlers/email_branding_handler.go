@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/i18n"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailBrandingPayload substitui a identidade visual dos e-mails. Campos vazios usam o branding da organização.
+type EmailBrandingPayload struct {
+	FromName       string `json:"from_name" binding:"max=100"`
+	LogoURL        string `json:"logo_url" binding:"max=500"`
+	PrimaryColor   string `json:"primary_color"`
+	SecondaryColor string `json:"secondary_color"`
+	FooterText     string `json:"footer_text" binding:"max=500"`
+	Locale         string `json:"locale" binding:"omitempty,oneof=pt-BR en es"`
+}
+
+// EmailBrandingResponse é a configuração salva e a identidade visual efetivamente aplicada aos e-mails.
+type EmailBrandingResponse struct {
+	models.EmailBranding
+	Effective       templates.Branding `json:"effective"`
+	EffectiveLocale i18n.Locale        `json:"effective_locale"`
+}
+
+func newEmailBrandingResponse(c *gin.Context, db *gorm.DB, branding models.EmailBranding) EmailBrandingResponse {
+	brand, locale := notifications.OrganizationBranding(c.Request.Context(), db, branding.OrganizationID)
+	return EmailBrandingResponse{EmailBranding: branding, Effective: brand, EffectiveLocale: locale}
+}
+
+// findEmailBranding carrega a configuração da organização; found é false se ela ainda não configurou uma.
+func findEmailBranding(db *gorm.DB, organizationID uuid.UUID) (branding models.EmailBranding, found bool, err error) {
+	err = db.Where("organization_id = ?", organizationID).Take(&branding).Error
+	if err == gorm.ErrRecordNotFound {
+		return models.EmailBranding{OrganizationID: organizationID}, false, nil
+	}
+	return branding, err == nil, err
+}
+
+// GetEmailBrandingHandler returns the email branding of the organization and the effective branding (with the
+// organization's logo and colors filling the empty fields).
+func GetEmailBrandingHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.BrandingManage) {
+		return
+	}
+	db := database.GetDB()
+	branding, _, err := findEmailBranding(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load email branding: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newEmailBrandingResponse(c, db, branding))
+}
+
+// UpsertEmailBrandingHandler replaces the sender name, logo URL, colors, footer and language of the
+// organization's notification emails.
+func UpsertEmailBrandingHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.BrandingManage) {
+		return
+	}
+	var payload EmailBrandingPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	payload.PrimaryColor = strings.TrimSpace(payload.PrimaryColor)
+	payload.SecondaryColor = strings.TrimSpace(payload.SecondaryColor)
+	if (payload.PrimaryColor != "" && !hexColorRegex.MatchString(payload.PrimaryColor)) ||
+		(payload.SecondaryColor != "" && !hexColorRegex.MatchString(payload.SecondaryColor)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid color: use #RRGGBB or #RGB"})
+		return
+	}
+	payload.LogoURL = strings.TrimSpace(payload.LogoURL)
+	if payload.LogoURL != "" {
+		// O logo é carregado pelo cliente de e-mail do destinatário: precisa ser uma URL https pública
+		if parsed, err := url.Parse(payload.LogoURL); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid logo_url: use a public https URL"})
+			return
+		}
+	}
+	// O nome do remetente vai para o cabeçalho From
+	if strings.ContainsAny(payload.FromName, "\r\n") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from_name: line breaks are not allowed"})
+		return
+	}
+
+	db := database.GetDB()
+	branding, found, err := findEmailBranding(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load email branding: " + err.Error()})
+		return
+	}
+	userID, _ := c.Get("userID")
+	updatedByID := userID.(uuid.UUID)
+	branding.FromName = strings.TrimSpace(payload.FromName)
+	branding.LogoURL = payload.LogoURL
+	branding.PrimaryColor = strings.ToUpper(payload.PrimaryColor)
+	branding.SecondaryColor = strings.ToUpper(payload.SecondaryColor)
+	branding.FooterText = strings.TrimSpace(payload.FooterText)
+	branding.Locale = payload.Locale
+	branding.UpdatedByID = &updatedByID
+
+	if found {
+		err = db.Save(&branding).Error
+	} else {
+		err = db.Create(&branding).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save email branding: " + err.Error()})
+		return
+	}
+	status := http.StatusOK
+	if !found {
+		status = http.StatusCreated
+	}
+	c.JSON(status, newEmailBrandingResponse(c, db, branding))
+}
+
+// ListEmailTemplatesHandler lists the notification email templates that can be previewed.
+func ListEmailTemplatesHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.BrandingManage) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"templates": templates.List()})
+}
+
+// PreviewEmailTemplateHandler renders a notification email template with sample data and the organization's
+// email branding (?locale= overrides the organization's language). ?format=html returns the HTML version
+// for display; the default is JSON with subject, text and html.
+func PreviewEmailTemplateHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.BrandingManage) {
+		return
+	}
+	name := c.Param("name")
+	sample, ok := templates.Sample(name)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Email template not found"})
+		return
+	}
+	brand, locale := notifications.OrganizationBranding(c.Request.Context(), database.GetDB(), targetOrgID)
+	if raw := c.Query("locale"); raw != "" {
+		if !templates.SupportedLocale(i18n.Locale(raw)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid locale: use pt-BR, en or es"})
+			return
+		}
+		locale = i18n.Locale(raw)
+	}
+	recipient := ""
+	if userID, exists := c.Get("userID"); exists {
+		var user models.User
+		if database.GetDB().Select("id", "name").Take(&user, "id = ?", userID).Error == nil {
+			recipient = user.Name
+		}
+	}
+	email, err := templates.Render(name, locale, brand, recipient, sample)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render email template: " + err.Error()})
+		return
+	}
+	if c.Query("format") == "html" {
+		// A pré-visualização é exibida em um iframe: sem scripts, apenas estilos inline e imagens
+		c.Header("Content-Security-Policy", "default-src 'none'; img-src https: http: data:; style-src 'unsafe-inline'")
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(email.HTML))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"template": name, "locale": locale, "from_name": brand.FromName, "email": email})
+}
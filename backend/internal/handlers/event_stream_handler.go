@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"phoenixgrc/backend/internal/events"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// eventStreamHeartbeatInterval mantém a conexão viva atrás de proxies que encerram conexões ociosas.
+const eventStreamHeartbeatInterval = 25 * time.Second
+
+// StreamEventsHandler streams org-scoped events to the client using Server-Sent Events.
+// Query param opcional "types" (separado por vírgula) filtra os tipos de evento entregues.
+func StreamEventsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+
+	var typeFilter map[events.EventType]bool
+	if typesParam := c.Query("types"); typesParam != "" {
+		typeFilter = make(map[events.EventType]bool)
+		for _, t := range strings.Split(typesParam, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				typeFilter[events.EventType(t)] = true
+			}
+		}
+	}
+
+	stream, unsubscribe := events.DefaultHub.Subscribe(organizationID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Desabilita buffering no nginx
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			// Comentário SSE, ignorado pelo EventSource do navegador.
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case ev, ok := <-stream:
+			if !ok {
+				return false
+			}
+			if typeFilter != nil && !typeFilter[ev.Type] {
+				return true
+			}
+			c.Render(-1, sse.Event{Id: ev.ID, Event: string(ev.Type), Data: ev})
+			return true
+		}
+	})
+}
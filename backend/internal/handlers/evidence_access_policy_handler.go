@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/evidenceaccess"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxEvidenceAllowedNetworks é o número máximo de redes de uma política de acesso às evidências.
+const maxEvidenceAllowedNetworks = 100
+
+// EvidenceAccessPolicyPayload define a política de acesso às evidências da organização.
+type EvidenceAccessPolicyPayload struct {
+	AllowedNetworks      []string `json:"allowed_networks"`
+	BusinessHoursEnabled bool     `json:"business_hours_enabled"`
+	Timezone             string   `json:"timezone"`
+	Weekdays             []int    `json:"weekdays" binding:"dive,min=0,max=6"`
+	StartTime            string   `json:"start_time"`
+	EndTime              string   `json:"end_time"`
+	AllowBreakGlass      *bool    `json:"allow_break_glass"`
+}
+
+// EvidenceAccessPolicyView é a política com as listas decodificadas.
+type EvidenceAccessPolicyView struct {
+	models.EvidenceAccessPolicy
+	AllowedNetworks []string `json:"allowed_networks"`
+	Weekdays        []int    `json:"weekdays"`
+}
+
+// EvidenceAccessPolicyResponse traz a política (nula quando não configurada) e a avaliação da requisição atual,
+// para que o admin confira se o próprio acesso seria permitido.
+type EvidenceAccessPolicyResponse struct {
+	Policy           *EvidenceAccessPolicyView `json:"policy"`
+	ClientIP         string                    `json:"client_ip"`
+	CurrentlyAllowed bool                      `json:"currently_allowed"`
+	Violation        string                    `json:"violation,omitempty"`
+}
+
+func newEvidenceAccessPolicyResponse(c *gin.Context, policy *models.EvidenceAccessPolicy) EvidenceAccessPolicyResponse {
+	decision := evidenceaccess.Evaluate(policy, net.ParseIP(c.ClientIP()), time.Now())
+	resp := EvidenceAccessPolicyResponse{ClientIP: c.ClientIP(), CurrentlyAllowed: decision.Allowed, Violation: decision.Violation}
+	if policy != nil {
+		resp.Policy = &EvidenceAccessPolicyView{EvidenceAccessPolicy: *policy, AllowedNetworks: policy.NetworkList(), Weekdays: policy.WeekdayList()}
+	}
+	return resp
+}
+
+// enforceEvidenceAccessPolicy aplica a política de acesso às evidências da organização do token ao download de
+// resource. Fora da política, o download só prossegue com a justificativa de break-glass no cabeçalho
+// X-Break-Glass-Reason, registrada na trilha de auditoria. Responde o erro e retorna false se o download
+// não puder prosseguir.
+func enforceEvidenceAccessPolicy(c *gin.Context, resource string) bool {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+	policy, err := evidenceaccess.LoadPolicy(db, organizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load evidence access policy: " + err.Error()})
+		return false
+	}
+	decision := evidenceaccess.Evaluate(policy, net.ParseIP(c.ClientIP()), time.Now())
+	if decision.Allowed {
+		return true
+	}
+
+	reason := strings.TrimSpace(c.GetHeader(evidenceaccess.BreakGlassHeader))
+	if reason == "" || !policy.AllowBreakGlass {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":               "Evidence download blocked by the organization's access policy: " + decision.Message,
+			"violation":           decision.Violation,
+			"break_glass_allowed": policy.AllowBreakGlass,
+		})
+		return false
+	}
+	if len([]rune(reason)) < evidenceaccess.MinBreakGlassReasonLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Break-glass reason must have at least %d characters", evidenceaccess.MinBreakGlassReasonLength)})
+		return false
+	}
+	if len([]rune(reason)) > 500 {
+		reason = string([]rune(reason)[:500])
+	}
+	// Sem o registro na trilha de auditoria, o download não é liberado
+	if _, err := recordAuditTrail(c, db, organizationID, models.AuditTrailEvidenceBreakGlass, "evidence", nil,
+		"Evidence downloaded outside the access policy (break-glass): "+resource,
+		gin.H{"resource": resource, "reason": reason, "violation": decision.Violation, "client_ip": c.ClientIP()}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record break-glass access: " + err.Error()})
+		return false
+	}
+	userID, _ := c.Get("userID")
+	phxlog.L.Warn("Evidence access policy overridden with break-glass",
+		zap.String("organizationID", organizationID.String()),
+		zap.Any("userID", userID),
+		zap.String("resource", resource),
+		zap.String("violation", decision.Violation),
+		zap.String("clientIP", c.ClientIP()))
+	return true
+}
+
+// GetEvidenceAccessPolicyHandler returns the organization's evidence access policy (null when none is configured)
+// and whether the current request would be allowed to download evidence.
+func GetEvidenceAccessPolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceManageAccess) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	policy, err := evidenceaccess.LoadPolicy(database.GetDB(), orgID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load evidence access policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newEvidenceAccessPolicyResponse(c, policy))
+}
+
+// SetEvidenceAccessPolicyHandler creates or replaces the organization's evidence access policy: allowed networks
+// (CIDR) and business hours for evidence downloads, and whether break-glass overrides are allowed.
+func SetEvidenceAccessPolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceManageAccess) {
+		return
+	}
+	var payload EvidenceAccessPolicyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if len(payload.AllowedNetworks) > maxEvidenceAllowedNetworks {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d allowed networks are supported", maxEvidenceAllowedNetworks)})
+		return
+	}
+	networks, err := evidenceaccess.ParseNetworks(payload.AllowedNetworks)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid allowed_networks: " + err.Error()})
+		return
+	}
+	payload.Timezone = strings.TrimSpace(payload.Timezone)
+	if payload.Timezone == "" {
+		payload.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(payload.Timezone); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone: use an IANA name such as America/Sao_Paulo"})
+		return
+	}
+	weekdays := make([]int, 0, 7)
+	seenDays := map[int]bool{}
+	for _, day := range payload.Weekdays {
+		if !seenDays[day] {
+			seenDays[day] = true
+			weekdays = append(weekdays, day)
+		}
+	}
+	if payload.BusinessHoursEnabled {
+		start, errStart := evidenceaccess.ParseClock(payload.StartTime)
+		end, errEnd := evidenceaccess.ParseClock(payload.EndTime)
+		if errStart != nil || errEnd != nil || start >= end {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid business hours: start_time and end_time must be HH:MM with start_time before end_time"})
+			return
+		}
+		if len(weekdays) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid business hours: at least one weekday is required"})
+			return
+		}
+	}
+	allowBreakGlass := true
+	if payload.AllowBreakGlass != nil {
+		allowBreakGlass = *payload.AllowBreakGlass
+	}
+
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	updatedBy := userID.(uuid.UUID)
+
+	var policy *models.EvidenceAccessPolicy
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		current, err := evidenceaccess.LoadPolicy(tx, organizationID)
+		if err != nil {
+			return err
+		}
+		previous := gin.H{}
+		if current == nil {
+			current = &models.EvidenceAccessPolicy{OrganizationID: organizationID}
+		} else {
+			previous = evidenceAccessPolicyDetails(current)
+		}
+		current.SetNetworks(networks)
+		current.BusinessHoursEnabled = payload.BusinessHoursEnabled
+		current.Timezone = payload.Timezone
+		current.SetWeekdays(weekdays)
+		current.StartTime = payload.StartTime
+		current.EndTime = payload.EndTime
+		current.AllowBreakGlass = allowBreakGlass
+		current.UpdatedByID = &updatedBy
+		if err := tx.Save(current).Error; err != nil {
+			return err
+		}
+		policy = current
+		_, err = recordAuditTrail(c, tx, organizationID, models.AuditTrailEvidencePolicyUpdated, "evidence_access_policy", &current.ID,
+			fmt.Sprintf("Evidence access policy set (%d allowed networks, business hours %t)", len(networks), payload.BusinessHoursEnabled),
+			gin.H{"previous": previous, "current": evidenceAccessPolicyDetails(current)})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save evidence access policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newEvidenceAccessPolicyResponse(c, policy))
+}
+
+// DeleteEvidenceAccessPolicyHandler removes the organization's evidence access policy; downloads become unrestricted.
+func DeleteEvidenceAccessPolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceManageAccess) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		current, err := evidenceaccess.LoadPolicy(tx, organizationID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Delete(current).Error; err != nil {
+			return err
+		}
+		_, err = recordAuditTrail(c, tx, organizationID, models.AuditTrailEvidencePolicyUpdated, "evidence_access_policy", &current.ID,
+			"Evidence access policy removed", gin.H{"previous": evidenceAccessPolicyDetails(current)})
+		return err
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No evidence access policy configured for your organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete evidence access policy: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// evidenceAccessPolicyDetails resume a política para a trilha de auditoria.
+func evidenceAccessPolicyDetails(p *models.EvidenceAccessPolicy) gin.H {
+	return gin.H{
+		"allowed_networks":       p.NetworkList(),
+		"business_hours_enabled": p.BusinessHoursEnabled,
+		"timezone":               p.Timezone,
+		"weekdays":               p.WeekdayList(),
+		"start_time":             p.StartTime,
+		"end_time":               p.EndTime,
+		"allow_break_glass":      p.AllowBreakGlass,
+	}
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"phoenixgrc/backend/internal/avscan"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// evidencePortalOpenStatuses são as solicitações que aguardam ação do colaborador.
+var evidencePortalOpenStatuses = []models.EvidenceRequestStatus{models.EvidenceRequestPending, models.EvidenceRequestRejected}
+
+// EvidencePortalTask é uma evidência que o colaborador precisa enviar; não expõe controles nem outros dados do GRC.
+type EvidencePortalTask struct {
+	ID           uuid.UUID                    `json:"id"`
+	Title        string                       `json:"title"`
+	Instructions string                       `json:"instructions,omitempty"`
+	DueDate      time.Time                    `json:"due_date"`
+	Status       models.EvidenceRequestStatus `json:"status"`
+	Overdue      bool                         `json:"overdue"`
+	ReviewNote   string                       `json:"review_note,omitempty"` // Motivo da rejeição, quando houver
+}
+
+// EvidencePortalSummary resume as pendências do colaborador.
+type EvidencePortalSummary struct {
+	ToDo           int `json:"to_do"`
+	Overdue        int `json:"overdue"`
+	DueThisMonth   int `json:"due_this_month"`
+	AwaitingReview int `json:"awaiting_review"`
+}
+
+// EvidencePortalResponse é o que o colaborador vê no portal de evidências.
+type EvidencePortalResponse struct {
+	OrganizationName string                `json:"organization_name"`
+	ContributorName  string                `json:"contributor_name,omitempty"`
+	Summary          EvidencePortalSummary `json:"summary"`
+	Tasks            []EvidencePortalTask  `json:"tasks"`
+}
+
+// EvidencePortalAuthMiddleware autentica o colaborador pelo token do link (:token).
+// Links revogados ou expirados retornam 410.
+func EvidencePortalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := database.GetDB()
+		var link models.EvidencePortalLink
+		err := db.Where("token_hash = ?", hashEvidencePortalToken(c.Param("token"))).First(&link).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Invalid evidence portal link"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate evidence portal link"})
+			return
+		}
+		if link.RevokedAt != nil {
+			c.AbortWithStatusJSON(http.StatusGone, gin.H{"error": "This evidence portal link has been revoked"})
+			return
+		}
+		if time.Now().After(link.ExpiresAt) {
+			c.AbortWithStatusJSON(http.StatusGone, gin.H{"error": "This evidence portal link has expired"})
+			return
+		}
+		db.Model(&link).UpdateColumn("last_used_at", time.Now())
+		c.Set("evidencePortalLink", &link)
+		c.Next()
+	}
+}
+
+// portalEvidenceLink retorna o link autenticado pelo middleware.
+func portalEvidenceLink(c *gin.Context) *models.EvidencePortalLink {
+	link, _ := c.Get("evidencePortalLink")
+	return link.(*models.EvidencePortalLink)
+}
+
+// checkEvidencePortalUpload aplica as regras dos arquivos de evidência (tamanho, tipos permitidos e
+// verificação antivírus) e retorna o resultado da verificação.
+func checkEvidencePortalUpload(c *gin.Context, file multipart.File, header *multipart.FileHeader) (string, bool) {
+	if header.Size > maxEvidenceFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("File size exceeds limit of %d MB", maxEvidenceFileSize/(1024*1024))})
+		return "", false
+	}
+
+	buffer := make([]byte, 512)
+	if _, err := file.Read(buffer); err != nil && err != io.EOF {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file for MIME type detection"})
+		return "", false
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset file pointer"})
+		return "", false
+	}
+	mimeType := http.DetectContentType(buffer)
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	isOfficeZip := mimeType == "application/zip" && (ext == ".docx" || ext == ".xlsx")
+	if !allowedMimeTypes[mimeType] && !isOfficeZip {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("File type '%s' (detected: '%s') is not allowed", header.Filename, mimeType)})
+		return "", false
+	}
+
+	scanResult, err := avscan.ScanUpload(c.Request.Context(), header.Filename, file)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to scan evidence file for malware: " + err.Error()})
+		return "", false
+	}
+	if scanResult.Verdict == avscan.VerdictInfected {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Evidence file rejected: malware detected", "signature": scanResult.Signature})
+		return "", false
+	}
+	return string(scanResult.Verdict), true
+}
+
+// GetEvidencePortalHandler returns only the evidence the contributor still has to upload, ordered by due date.
+func GetEvidencePortalHandler(c *gin.Context) {
+	link := portalEvidenceLink(c)
+	db := database.GetDB()
+
+	var organization models.Organization
+	db.Select("name").First(&organization, "id = ?", link.OrganizationID)
+
+	var requests []models.EvidenceRequest
+	err := db.Where("organization_id = ? AND assignee_email = ? AND status IN ?", link.OrganizationID, link.Email, evidencePortalOpenStatuses).
+		Order("due_date asc").Find(&requests).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load evidence requests"})
+		return
+	}
+	var awaitingReview int64
+	db.Model(&models.EvidenceRequest{}).
+		Where("organization_id = ? AND assignee_email = ? AND status = ?", link.OrganizationID, link.Email, models.EvidenceRequestSubmitted).
+		Count(&awaitingReview)
+
+	now := time.Now()
+	endOfMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+	response := EvidencePortalResponse{
+		OrganizationName: organization.Name,
+		ContributorName:  link.Name,
+		Summary:          EvidencePortalSummary{ToDo: len(requests), AwaitingReview: int(awaitingReview)},
+		Tasks:            make([]EvidencePortalTask, 0, len(requests)),
+	}
+	for _, r := range requests {
+		task := EvidencePortalTask{
+			ID:           r.ID,
+			Title:        r.Title,
+			Instructions: r.Instructions,
+			DueDate:      r.DueDate,
+			Status:       r.Status,
+			Overdue:      r.DueDate.Before(now),
+		}
+		if r.Status == models.EvidenceRequestRejected {
+			task.ReviewNote = r.ReviewNote
+		}
+		if task.Overdue {
+			response.Summary.Overdue++
+		}
+		if r.DueDate.Before(endOfMonth) {
+			response.Summary.DueThisMonth++
+		}
+		response.Tasks = append(response.Tasks, task)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// UploadEvidencePortalHandler receives the contributor's file ('file', optional 'note') for one of their requests.
+// A request awaiting review can be re-uploaded; accepted requests are closed.
+func UploadEvidencePortalHandler(c *gin.Context) {
+	link := portalEvidenceLink(c)
+	requestID, err := uuid.Parse(c.Param("requestId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid evidence request ID format"})
+		return
+	}
+	db := database.GetDB()
+	var request models.EvidenceRequest
+	err = db.Where("id = ? AND organization_id = ? AND assignee_email = ?", requestID, link.OrganizationID, link.Email).First(&request).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Evidence request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load evidence request"})
+		return
+	}
+	if request.Status == models.EvidenceRequestAccepted {
+		c.JSON(http.StatusConflict, gin.H{"error": "This evidence has already been accepted"})
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Evidence file not provided in 'file' field"})
+		return
+	}
+	defer file.Close()
+	scanStatus, ok := checkEvidencePortalUpload(c, file, header)
+	if !ok {
+		return
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+	objectPath := fmt.Sprintf("%s/evidence_requests/%s/%s_%s",
+		request.OrganizationID.String(), request.ID.String(), uuid.New().String(), filepath.Base(header.Filename))
+	objectName, err := filestorage.DefaultFileStorageProvider.UploadFile(c.Request.Context(), request.OrganizationID.String(), objectPath, file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload evidence file"})
+		return
+	}
+
+	now := time.Now()
+	submission := models.EvidenceSubmission{
+		EvidenceRequestID: request.ID,
+		ObjectName:        objectName,
+		FileName:          filepath.Base(header.Filename),
+		SizeBytes:         header.Size,
+		ScanStatus:        scanStatus,
+		Note:              c.PostForm("note"),
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&submission).Error; err != nil {
+			return err
+		}
+		return tx.Model(&request).Updates(map[string]interface{}{
+			"status":       models.EvidenceRequestSubmitted,
+			"submitted_at": now,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record evidence submission"})
+		return
+	}
+
+	notifications.NotifyUserByEmail(c.Request.Context(), request.CreatedByID,
+		fmt.Sprintf("Evidência enviada: %s", request.Title),
+		fmt.Sprintf("%s enviou o arquivo '%s' para a solicitação de evidência '%s'. Acesse o Phoenix GRC para revisar.",
+			link.Email, submission.FileName, request.Title))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "Evidence uploaded successfully",
+		"status":    models.EvidenceRequestSubmitted,
+		"file_name": submission.FileName,
+	})
+}
@@ -0,0 +1,435 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EvidenceRequestPayload defines the structure for creating or updating an evidence request.
+type EvidenceRequestPayload struct {
+	Title          string    `json:"title" binding:"required,min=3,max=255"`
+	Instructions   string    `json:"instructions"`
+	AuditControlID string    `json:"audit_control_id"`
+	AssigneeEmail  string    `json:"assignee_email" binding:"required,email,max=255"`
+	AssigneeName   string    `json:"assignee_name" binding:"max=255"`
+	DueDate        time.Time `json:"due_date" binding:"required"`
+}
+
+// EvidenceRequestReviewPayload aceita ou rejeita a evidência enviada.
+type EvidenceRequestReviewPayload struct {
+	Decision string `json:"decision" binding:"required,oneof=accept reject"`
+	Note     string `json:"note"`
+}
+
+// EvidencePortalLinkPayload defines the structure for sending a portal link to a contributor.
+type EvidencePortalLinkPayload struct {
+	Email         string `json:"email" binding:"required,email,max=255"`
+	Name          string `json:"name" binding:"max=255"`
+	ExpiresInDays int    `json:"expires_in_days" binding:"omitempty,min=1,max=90"`
+}
+
+// EvidencePortalLinkResponse inclui a URL do portal; o token só é exibido na criação.
+type EvidencePortalLinkResponse struct {
+	models.EvidencePortalLink
+	PortalURL string `json:"portal_url"`
+}
+
+// hashEvidencePortalToken retorna o hash armazenado para o token do portal de evidências.
+func hashEvidencePortalToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// evidencePortalURL monta o link enviado ao colaborador.
+func evidencePortalURL(token string) string {
+	frontendBaseURL := config.Cfg.FrontendBaseURL
+	if frontendBaseURL == "" {
+		frontendBaseURL = "http://localhost:3000" // Fallback
+	}
+	return fmt.Sprintf("%s/evidence-portal/%s", strings.TrimSuffix(frontendBaseURL, "/"), token)
+}
+
+// applyEvidenceRequestPayload copia o payload para a solicitação, validando o controle de auditoria.
+func applyEvidenceRequestPayload(db *gorm.DB, request *models.EvidenceRequest, payload EvidenceRequestPayload) error {
+	request.Title = strings.TrimSpace(payload.Title)
+	request.Instructions = payload.Instructions
+	request.AssigneeEmail = strings.ToLower(strings.TrimSpace(payload.AssigneeEmail))
+	request.AssigneeName = payload.AssigneeName
+	request.DueDate = payload.DueDate
+	request.AuditControlID = nil
+	if payload.AuditControlID != "" {
+		controlID, err := uuid.Parse(payload.AuditControlID)
+		if err != nil {
+			return fmt.Errorf("invalid audit_control_id format")
+		}
+		var count int64
+		db.Model(&models.AuditControl{}).Where("id = ?", controlID).Count(&count)
+		if count == 0 {
+			return fmt.Errorf("audit control not found")
+		}
+		request.AuditControlID = &controlID
+	}
+	return nil
+}
+
+// findOrgEvidenceRequest carrega a solicitação de :requestId na organização do usuário.
+func findOrgEvidenceRequest(c *gin.Context, db *gorm.DB, preloadSubmissions bool) (*models.EvidenceRequest, bool) {
+	requestID, err := uuid.Parse(c.Param("requestId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid evidence request ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	query := db
+	if preloadSubmissions {
+		query = query.Preload("Submissions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at desc")
+		})
+	}
+	var request models.EvidenceRequest
+	if err := query.Where("id = ? AND organization_id = ?", requestID, orgID).First(&request).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Evidence request not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch evidence request: " + err.Error()})
+		return nil, false
+	}
+	return &request, true
+}
+
+// CreateEvidenceRequestHandler asks a contributor (by e-mail) to upload a piece of evidence by a due date.
+func CreateEvidenceRequestHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceRequestsManage) {
+		return
+	}
+	var payload EvidenceRequestPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	request := models.EvidenceRequest{
+		OrganizationID: orgID.(uuid.UUID),
+		Status:         models.EvidenceRequestPending,
+		CreatedByID:    userID.(uuid.UUID),
+	}
+	if err := applyEvidenceRequestPayload(db, &request, payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := db.Create(&request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create evidence request: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, request)
+}
+
+// ListEvidenceRequestsHandler lists the organization's evidence requests with pagination.
+// Filtros: status, assignee_email, audit_control_id, overdue=true.
+func ListEvidenceRequestsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	page, pageSize := GetPaginationParams(c)
+
+	query := db.Model(&models.EvidenceRequest{}).Where("organization_id = ?", orgID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if email := c.Query("assignee_email"); email != "" {
+		query = query.Where("assignee_email = ?", strings.ToLower(email))
+	}
+	if controlID := c.Query("audit_control_id"); controlID != "" {
+		query = query.Where("audit_control_id = ?", controlID)
+	}
+	if c.Query("overdue") == "true" {
+		query = query.Where("due_date < ? AND status IN ?", time.Now(),
+			[]models.EvidenceRequestStatus{models.EvidenceRequestPending, models.EvidenceRequestRejected})
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count evidence requests: " + err.Error()})
+		return
+	}
+	var requests []models.EvidenceRequest
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("due_date asc").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list evidence requests: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      requests,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// GetEvidenceRequestHandler returns an evidence request with its submissions (most recent first).
+func GetEvidenceRequestHandler(c *gin.Context) {
+	request, ok := findOrgEvidenceRequest(c, database.GetDB(), true)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
+
+// UpdateEvidenceRequestHandler updates an evidence request that has not been accepted yet.
+func UpdateEvidenceRequestHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceRequestsManage) {
+		return
+	}
+	var payload EvidenceRequestPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	request, ok := findOrgEvidenceRequest(c, db, false)
+	if !ok {
+		return
+	}
+	if request.Status == models.EvidenceRequestAccepted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Accepted evidence requests cannot be changed"})
+		return
+	}
+	if err := applyEvidenceRequestPayload(db, request, payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := db.Save(request).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update evidence request: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
+
+// DeleteEvidenceRequestHandler deletes an evidence request and its submissions.
+func DeleteEvidenceRequestHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceRequestsManage) {
+		return
+	}
+	db := database.GetDB()
+	request, ok := findOrgEvidenceRequest(c, db, false)
+	if !ok {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("evidence_request_id = ?", request.ID).Delete(&models.EvidenceSubmission{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(request).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete evidence request: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Evidence request deleted successfully"})
+}
+
+// ReviewEvidenceRequestHandler accepts or rejects the submitted evidence. Accepted evidence for a request
+// linked to an audit control becomes the evidence of the organization's assessment for that control;
+// rejected requests go back to the contributor's portal with the review note.
+func ReviewEvidenceRequestHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceRequestsManage) {
+		return
+	}
+	var payload EvidenceRequestReviewPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	request, ok := findOrgEvidenceRequest(c, db, true)
+	if !ok {
+		return
+	}
+	if request.Status != models.EvidenceRequestSubmitted || len(request.Submissions) == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only submitted evidence requests can be reviewed"})
+		return
+	}
+	userID, _ := c.Get("userID")
+	reviewerID := userID.(uuid.UUID)
+	now := time.Now()
+	request.ReviewNote = payload.Note
+	request.ReviewedByID = &reviewerID
+	request.ReviewedAt = &now
+	if payload.Decision == "accept" {
+		request.Status = models.EvidenceRequestAccepted
+	} else {
+		request.Status = models.EvidenceRequestRejected
+	}
+
+	latest := request.Submissions[0]
+	var tally *models.ComplianceScoreTally
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Submissions").Save(request).Error; err != nil {
+			return err
+		}
+		if request.Status != models.EvidenceRequestAccepted || request.AuditControlID == nil {
+			return nil
+		}
+		assessment := models.AuditAssessment{
+			OrganizationID:     request.OrganizationID,
+			AuditControlID:     *request.AuditControlID,
+			EvidenceURL:        latest.ObjectName,
+			EvidenceScanStatus: latest.ScanStatus,
+			AssessmentDate:     &now,
+		}
+		var err error
+		tally, err = compliancescore.RecordAssessmentWrite(tx, request.OrganizationID, *request.AuditControlID, func(tx *gorm.DB) error {
+			return tx.Clauses(models.OrgWideAssessmentConflict(
+				clause.AssignmentColumns([]string{"evidence_url", "evidence_scan_status", "updated_at"}),
+			)).Create(&assessment).Error
+		})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to review evidence request: " + err.Error()})
+		return
+	}
+	publishComplianceScore(request.OrganizationID, tally)
+	c.JSON(http.StatusOK, request)
+}
+
+// CreateEvidencePortalLinkHandler e-mails a contributor a tokenized link to the evidence portal, where they
+// only see and upload the evidence requests assigned to their e-mail.
+func CreateEvidencePortalLinkHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceRequestsManage) {
+		return
+	}
+	var payload EvidencePortalLinkPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	expiresInDays := payload.ExpiresInDays
+	if expiresInDays == 0 {
+		expiresInDays = 30
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	db := database.GetDB()
+	link := models.EvidencePortalLink{
+		OrganizationID: orgID.(uuid.UUID),
+		Email:          strings.ToLower(strings.TrimSpace(payload.Email)),
+		Name:           payload.Name,
+		TokenHash:      hashEvidencePortalToken(token),
+		ExpiresAt:      time.Now().AddDate(0, 0, expiresInDays),
+		CreatedByID:    userID.(uuid.UUID),
+	}
+	if err := db.Create(&link).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create evidence portal link: " + err.Error()})
+		return
+	}
+
+	var pending int64
+	db.Model(&models.EvidenceRequest{}).
+		Where("organization_id = ? AND assignee_email = ? AND status IN ?", link.OrganizationID, link.Email,
+			[]models.EvidenceRequestStatus{models.EvidenceRequestPending, models.EvidenceRequestRejected}).
+		Count(&pending)
+
+	portalURL := evidencePortalURL(token)
+	msg := notifications.Message{
+		EventType:      "evidence_request.portal_link",
+		OrganizationID: link.OrganizationID,
+		Subject:        "Evidências solicitadas para você",
+		Body: fmt.Sprintf("Olá %s,\n\nVocê tem %d evidência(s) pendente(s) para enviar. Use o link abaixo para ver o que é necessário e enviar os arquivos. Não é necessário criar uma conta. O link é válido até %s.",
+			link.Name, pending, link.ExpiresAt.Format("02/01/2006")),
+		Link: portalURL,
+	}
+	go func() {
+		if err := notifications.Deliver(context.Background(), notifications.ChannelEmail, link.Email, msg); err != nil {
+			phxlog.L.Error("Failed to send evidence portal link email",
+				zap.String("linkID", link.ID.String()),
+				zap.Error(err))
+		}
+	}()
+
+	c.JSON(http.StatusCreated, EvidencePortalLinkResponse{EvidencePortalLink: link, PortalURL: portalURL})
+}
+
+// ListEvidencePortalLinksHandler lists the organization's evidence portal links. Filtro: email.
+func ListEvidencePortalLinksHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceRequestsManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Where("organization_id = ?", orgID)
+	if email := c.Query("email"); email != "" {
+		query = query.Where("email = ?", strings.ToLower(email))
+	}
+	var links []models.EvidencePortalLink
+	if err := query.Order("created_at desc").Find(&links).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list evidence portal links: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, links)
+}
+
+// RevokeEvidencePortalLinkHandler invalidates an evidence portal link.
+func RevokeEvidencePortalLinkHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.EvidenceRequestsManage) {
+		return
+	}
+	linkID, err := uuid.Parse(c.Param("linkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid evidence portal link ID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	var link models.EvidencePortalLink
+	if err := db.Where("id = ? AND organization_id = ?", linkID, orgID).First(&link).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Evidence portal link not found or not part of your organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch evidence portal link: " + err.Error()})
+		return
+	}
+	if link.RevokedAt == nil {
+		now := time.Now()
+		link.RevokedAt = &now
+		if err := db.Save(&link).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke evidence portal link: " + err.Error()})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, link)
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/avscan"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/pdfdoc"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/reports"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxExecutiveLogoSize é o tamanho máximo do logo do relatório executivo.
+const maxExecutiveLogoSize = 2 << 20
+
+// allowedExecutiveLogoTypes são os formatos de logo que podem ser embutidos no PDF (SVG não é suportado).
+var allowedExecutiveLogoTypes = map[string]bool{"image/jpeg": true, "image/png": true, "image/gif": true}
+
+// ExecutiveReportTemplatePayload substitui o modelo do relatório executivo. Campos vazios usam o padrão.
+type ExecutiveReportTemplatePayload struct {
+	Title        string `json:"title" binding:"max=150"`
+	Subtitle     string `json:"subtitle" binding:"max=255"`
+	FooterText   string `json:"footer_text" binding:"max=255"`
+	PrimaryColor string `json:"primary_color"`
+}
+
+// ExecutiveReportTemplateResponse é o modelo com a origem do logo usado no relatório.
+type ExecutiveReportTemplateResponse struct {
+	models.ExecutiveReportTemplate
+	LogoSource string `json:"logo_source"` // template, organization ou none
+}
+
+func newExecutiveReportTemplateResponse(db *gorm.DB, template models.ExecutiveReportTemplate) ExecutiveReportTemplateResponse {
+	resp := ExecutiveReportTemplateResponse{ExecutiveReportTemplate: template, LogoSource: "none"}
+	if template.LogoURL != "" {
+		resp.LogoSource = "template"
+	} else {
+		var org models.Organization
+		if db.Select("id", "logo_url").Take(&org, "id = ?", template.OrganizationID).Error == nil && org.LogoURL != "" {
+			resp.LogoSource = "organization"
+		}
+	}
+	return resp
+}
+
+// findExecutiveReportTemplate carrega o modelo da organização; found é false se ela ainda não configurou um.
+func findExecutiveReportTemplate(db *gorm.DB, organizationID uuid.UUID) (template models.ExecutiveReportTemplate, found bool, err error) {
+	err = db.Where("organization_id = ?", organizationID).Take(&template).Error
+	if err == gorm.ErrRecordNotFound {
+		return models.ExecutiveReportTemplate{OrganizationID: organizationID}, false, nil
+	}
+	return template, err == nil, err
+}
+
+// GetExecutiveReportHandler produces the board-level executive PDF: KPIs, heatmap of open risks, top-10 risks,
+// compliance gauges per framework and monthly trend charts (?months=3..24, default 12), with the organization's
+// template (title, logo, colors and footer).
+func GetExecutiveReportHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.ReportsManageExecutive) {
+		return
+	}
+	months := reports.DefaultExecutiveMonths
+	if raw := c.Query("months"); raw != "" {
+		months, err = strconv.Atoi(raw)
+		if err != nil || months < 3 || months > reports.MaxExecutiveMonths {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid months: must be between 3 and %d", reports.MaxExecutiveMonths)})
+			return
+		}
+	}
+
+	db := database.GetDB()
+	matrix, _, err := loadRiskScoringMatrix(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk scoring matrix: " + err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	now := time.Now().UTC()
+	executive, err := reports.BuildExecutive(ctx, db, targetOrgID, matrix, months, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build report: " + err.Error()})
+		return
+	}
+	branding, err := reports.LoadBranding(ctx, db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load executive report template: " + err.Error()})
+		return
+	}
+	pdf, err := reports.RenderExecutive(executive, branding)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render report: " + err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"executive_report_%s.pdf\"", now.Format("2006-01")))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// GetExecutiveReportTemplateHandler returns the organization's executive report template (defaults when not configured).
+func GetExecutiveReportTemplateHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.ReportsManageExecutive) {
+		return
+	}
+	db := database.GetDB()
+	template, _, err := findExecutiveReportTemplate(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load executive report template: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newExecutiveReportTemplateResponse(db, template))
+}
+
+// UpsertExecutiveReportTemplateHandler replaces the title, subtitle, footer text and color of the executive report.
+func UpsertExecutiveReportTemplateHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.ReportsManageExecutive) {
+		return
+	}
+	var payload ExecutiveReportTemplatePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	payload.PrimaryColor = strings.TrimSpace(payload.PrimaryColor)
+	if _, ok := pdfdoc.ParseHexColor(payload.PrimaryColor); payload.PrimaryColor != "" && (!ok || !strings.HasPrefix(payload.PrimaryColor, "#")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid primary_color: use #RRGGBB"})
+		return
+	}
+
+	db := database.GetDB()
+	template, found, err := findExecutiveReportTemplate(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load executive report template: " + err.Error()})
+		return
+	}
+	userID, _ := c.Get("userID")
+	updatedByID := userID.(uuid.UUID)
+	template.Title = strings.TrimSpace(payload.Title)
+	template.Subtitle = strings.TrimSpace(payload.Subtitle)
+	template.FooterText = strings.TrimSpace(payload.FooterText)
+	template.PrimaryColor = strings.ToUpper(payload.PrimaryColor)
+	template.UpdatedByID = &updatedByID
+
+	if found {
+		err = db.Save(&template).Error
+	} else {
+		err = db.Create(&template).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save executive report template: " + err.Error()})
+		return
+	}
+	status := http.StatusOK
+	if !found {
+		status = http.StatusCreated
+	}
+	c.JSON(status, newExecutiveReportTemplateResponse(db, template))
+}
+
+// UploadExecutiveReportLogoHandler sets a logo specific to the executive report (multipart field logo_file;
+// PNG, JPEG or GIF up to 2 MB), replacing the organization's branding logo in the report.
+func UploadExecutiveReportLogoHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.ReportsManageExecutive) {
+		return
+	}
+	file, header, err := c.Request.FormFile("logo_file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "logo_file is required"})
+		return
+	}
+	defer file.Close()
+	if header.Size > maxExecutiveLogoSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Logo file exceeds the limit of %d MB", maxExecutiveLogoSize/(1024*1024))})
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(file, maxExecutiveLogoSize+1))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read logo file"})
+		return
+	}
+	if mimeType := http.DetectContentType(data); !allowedExecutiveLogoTypes[mimeType] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Logo file type '%s' is not allowed: use PNG, JPEG or GIF", mimeType)})
+		return
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Logo file is not a valid image"})
+		return
+	}
+	scanResult, err := avscan.ScanUpload(c.Request.Context(), header.Filename, bytes.NewReader(data))
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to scan logo file for malware: " + err.Error()})
+		return
+	}
+	if scanResult.Verdict == avscan.VerdictInfected {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Logo file rejected: malware detected", "signature": scanResult.Signature})
+		return
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured"})
+		return
+	}
+
+	db := database.GetDB()
+	template, found, err := findExecutiveReportTemplate(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load executive report template: " + err.Error()})
+		return
+	}
+	objectName := fmt.Sprintf("%s/branding/executive_report_logo_%d%s", targetOrgID, time.Now().UnixNano(), strings.ToLower(filepath.Ext(header.Filename)))
+	storedName, err := filestorage.DefaultFileStorageProvider.UploadFile(c.Request.Context(), targetOrgID.String(), objectName, bytes.NewReader(data))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload logo: " + err.Error()})
+		return
+	}
+	previous := template.LogoURL
+	userID, _ := c.Get("userID")
+	updatedByID := userID.(uuid.UUID)
+	template.LogoURL = storedName
+	template.UpdatedByID = &updatedByID
+	if found {
+		err = db.Save(&template).Error
+	} else {
+		err = db.Create(&template).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save executive report template: " + err.Error()})
+		return
+	}
+	deleteExecutiveReportLogo(c, previous)
+	c.JSON(http.StatusOK, newExecutiveReportTemplateResponse(db, template))
+}
+
+// DeleteExecutiveReportLogoHandler removes the report-specific logo; the report falls back to the organization's logo.
+func DeleteExecutiveReportLogoHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.ReportsManageExecutive) {
+		return
+	}
+	db := database.GetDB()
+	template, found, err := findExecutiveReportTemplate(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load executive report template: " + err.Error()})
+		return
+	}
+	if found && template.LogoURL != "" {
+		previous := template.LogoURL
+		if err := db.Model(&template).Update("logo_url", "").Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save executive report template: " + err.Error()})
+			return
+		}
+		template.LogoURL = ""
+		deleteExecutiveReportLogo(c, previous)
+	}
+	c.JSON(http.StatusOK, newExecutiveReportTemplateResponse(db, template))
+}
+
+// deleteExecutiveReportLogo remove do armazenamento o logo substituído; falhas são apenas registradas.
+func deleteExecutiveReportLogo(c *gin.Context, objectName string) {
+	if objectName == "" || filestorage.DefaultFileStorageProvider == nil {
+		return
+	}
+	if err := filestorage.DefaultFileStorageProvider.DeleteFile(c.Request.Context(), objectName); err != nil {
+		phxlog.L.Warn("Failed to delete previous executive report logo", zap.String("objectName", objectName), zap.Error(err))
+	}
+}
@@ -0,0 +1,452 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/pkg/features"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// featureFlagKeyPattern é o formato das chaves, o mesmo das variáveis FEATURE_<KEY> que as sobrepõem.
+var featureFlagKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{1,99}$`)
+
+// FeatureFlagRulePayload é uma regra de segmentação; organização e papel vazios valem para todos.
+type FeatureFlagRulePayload struct {
+	OrganizationID *uuid.UUID       `json:"organization_id"`
+	Role           *models.UserRole `json:"role"`
+	Enabled        bool             `json:"enabled"`
+}
+
+// FeatureFlagPayload cadastra uma flag. As regras são avaliadas na ordem da lista.
+type FeatureFlagPayload struct {
+	Key               string                   `json:"key" binding:"required"`
+	Description       string                   `json:"description"`
+	Enabled           bool                     `json:"enabled"`
+	RolloutPercentage int                      `json:"rollout_percentage" binding:"min=0,max=100"`
+	Rules             []FeatureFlagRulePayload `json:"rules"`
+}
+
+// FeatureFlagUpdatePayload altera uma flag; os campos ausentes não mudam. Rules substitui todas as regras
+// (uma lista vazia as remove).
+type FeatureFlagUpdatePayload struct {
+	Description       *string                   `json:"description"`
+	Enabled           *bool                     `json:"enabled"`
+	RolloutPercentage *int                      `json:"rollout_percentage" binding:"omitempty,min=0,max=100"`
+	Rules             *[]FeatureFlagRulePayload `json:"rules"`
+}
+
+// FeatureFlagOverridePayload liga ou desliga a flag para uma organização.
+type FeatureFlagOverridePayload struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// FeatureFlagView é a flag cadastrada com as suas regras.
+type FeatureFlagView struct {
+	models.FeatureFlag
+	EnvOverride *bool  `json:"env_override,omitempty"` // FEATURE_<KEY> definida: prevalece sobre o cadastro em todas as organizações
+	Warning     string `json:"warning,omitempty"`
+}
+
+// FeatureFlagSubject identifica para quem a flag foi avaliada.
+type FeatureFlagSubject struct {
+	UserID         uuid.UUID `json:"user_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Role           string    `json:"role"`
+}
+
+// FeatureFlagEvaluationResponse é o resultado da avaliação com o motivo (ver features.Evaluation).
+type FeatureFlagEvaluationResponse struct {
+	features.Evaluation
+	Subject FeatureFlagSubject `json:"subject"`
+	Warning string             `json:"warning,omitempty"` // Falha ao recarregar as flags; avaliado com o cache anterior
+}
+
+// featureFlagSubject monta o sujeito da avaliação de flags a partir do usuário autenticado.
+func featureFlagSubject(c *gin.Context) features.Subject {
+	subject := features.Subject{}
+	if userID, ok := c.Get("userID"); ok {
+		subject.UserID = userID.(uuid.UUID)
+	}
+	if orgID, ok := c.Get("organizationID"); ok {
+		subject.OrganizationID = orgID.(uuid.UUID)
+	}
+	if role, ok := c.Get("userRole"); ok {
+		subject.Role = string(role.(models.UserRole))
+	}
+	return subject
+}
+
+// EvaluateFeatureFlagHandler explains whether a feature flag is on or off for the caller or, with
+// feature_flags:inspect, for another user of the organization (?user_id=): environment override, disabled flag,
+// matched targeting rule or rollout bucket.
+func EvaluateFeatureFlagHandler(c *gin.Context) {
+	subject := featureFlagSubject(c)
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id format"})
+			return
+		}
+		if userID != subject.UserID {
+			if !rbac.Require(c, rbac.FeatureFlagsInspect) {
+				return
+			}
+			var user models.User
+			err := database.GetDB().Select("id", "role").
+				Where("id = ? AND organization_id = ?", userID, subject.OrganizationID).First(&user).Error
+			if err != nil {
+				if err == gorm.ErrRecordNotFound {
+					c.JSON(http.StatusNotFound, gin.H{"error": "User not found in your organization"})
+					return
+				}
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user: " + err.Error()})
+				return
+			}
+			subject.UserID = user.ID
+			subject.Role = string(user.Role)
+		}
+	}
+
+	evaluation, err := features.Explain(c.Request.Context(), c.Param("key"), subject)
+	response := FeatureFlagEvaluationResponse{
+		Evaluation: evaluation,
+		Subject: FeatureFlagSubject{
+			UserID:         subject.UserID,
+			OrganizationID: subject.OrganizationID,
+			Role:           subject.Role,
+		},
+	}
+	if err != nil {
+		response.Warning = err.Error()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func featureFlagView(flag models.FeatureFlag) FeatureFlagView {
+	view := FeatureFlagView{FeatureFlag: flag}
+	if enabled, exists := features.GetFeatureToggleState(flag.Key); exists {
+		view.EnvOverride = &enabled
+	}
+	if view.Rules == nil {
+		view.Rules = []models.FeatureFlagRule{}
+	}
+	return view
+}
+
+// loadFeatureFlag busca a flag do parâmetro key com as regras na ordem de avaliação, respondendo 404 se
+// ela não existir.
+func loadFeatureFlag(c *gin.Context, db *gorm.DB) (models.FeatureFlag, bool) {
+	var flag models.FeatureFlag
+	err := db.Preload("Rules", func(db *gorm.DB) *gorm.DB { return db.Order("position asc, created_at asc") }).
+		Where("key = ?", c.Param("key")).First(&flag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Feature flag not found"})
+			return flag, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feature flag: " + err.Error()})
+		return flag, false
+	}
+	return flag, true
+}
+
+// buildFeatureFlagRules valida as regras (papel conhecido, organização existente) e as converte na ordem
+// informada, respondendo 400 se alguma for inválida.
+func buildFeatureFlagRules(c *gin.Context, db *gorm.DB, payloads []FeatureFlagRulePayload) ([]models.FeatureFlagRule, bool) {
+	rules := make([]models.FeatureFlagRule, 0, len(payloads))
+	orgIDs := make(map[uuid.UUID]bool)
+	for i, payload := range payloads {
+		if payload.Role != nil {
+			switch *payload.Role {
+			case models.RoleSystemAdmin, models.RoleAdmin, models.RoleManager, models.RoleUser:
+			default:
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role in rule: " + string(*payload.Role)})
+				return nil, false
+			}
+		}
+		if payload.OrganizationID != nil {
+			orgIDs[*payload.OrganizationID] = true
+		}
+		rules = append(rules, models.FeatureFlagRule{
+			Position:       i,
+			OrganizationID: payload.OrganizationID,
+			Role:           payload.Role,
+			Enabled:        payload.Enabled,
+		})
+	}
+	if len(orgIDs) > 0 {
+		ids := make([]uuid.UUID, 0, len(orgIDs))
+		for id := range orgIDs {
+			ids = append(ids, id)
+		}
+		var count int64
+		if err := db.Model(&models.Organization{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check organizations: " + err.Error()})
+			return nil, false
+		}
+		if count != int64(len(ids)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A rule references an organization that does not exist"})
+			return nil, false
+		}
+	}
+	return rules, true
+}
+
+// replaceFeatureFlagRules substitui as regras da flag, renumerando as posições na ordem da lista.
+func replaceFeatureFlagRules(tx *gorm.DB, flagID uuid.UUID, rules []models.FeatureFlagRule) error {
+	if err := tx.Where("feature_flag_id = ?", flagID).Delete(&models.FeatureFlagRule{}).Error; err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	for i := range rules {
+		rules[i].ID = uuid.Nil
+		rules[i].FeatureFlagID = flagID
+		rules[i].Position = i
+	}
+	return tx.Create(&rules).Error
+}
+
+// featureFlagChanged descarta o cache das flags desta instância, para a alteração valer já na próxima
+// avaliação. As demais instâncias (e o worker) recarregam em até FLAGS_CACHE_TTL_SECONDS.
+func featureFlagChanged(c *gin.Context, action string, key string, fields ...zap.Field) {
+	features.Invalidate()
+	userID, _ := c.Get("userID")
+	phxlog.L.Info("Feature flag "+action, append([]zap.Field{zap.String("flag", key), zap.Any("userID", userID)}, fields...)...)
+}
+
+// ListFeatureFlagsHandler lists the platform feature flags with their targeting rules, ordered by key.
+func ListFeatureFlagsHandler(c *gin.Context) {
+	var flags []models.FeatureFlag
+	err := database.GetDB().
+		Preload("Rules", func(db *gorm.DB) *gorm.DB { return db.Order("position asc, created_at asc") }).
+		Order("key asc").Find(&flags).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list feature flags: " + err.Error()})
+		return
+	}
+	views := make([]FeatureFlagView, 0, len(flags))
+	for _, flag := range flags {
+		views = append(views, featureFlagView(flag))
+	}
+	c.JSON(http.StatusOK, views)
+}
+
+// GetFeatureFlagHandler returns a feature flag with its targeting rules.
+func GetFeatureFlagHandler(c *gin.Context) {
+	flag, ok := loadFeatureFlag(c, database.GetDB())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, featureFlagView(flag))
+}
+
+// CreateFeatureFlagHandler creates a feature flag. A disabled flag is off for everyone; an enabled one
+// applies the rules in order and then the rollout percentage.
+func CreateFeatureFlagHandler(c *gin.Context) {
+	var payload FeatureFlagPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	key := strings.TrimSpace(payload.Key)
+	if !featureFlagKeyPattern.MatchString(key) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid key: use 2 to 100 uppercase letters, digits or underscores, starting with a letter"})
+		return
+	}
+	db := database.GetDB()
+	var existing int64
+	if err := db.Model(&models.FeatureFlag{}).Where("key = ?", key).Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check feature flag: " + err.Error()})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "A feature flag with this key already exists"})
+		return
+	}
+	rules, ok := buildFeatureFlagRules(c, db, payload.Rules)
+	if !ok {
+		return
+	}
+	flag := models.FeatureFlag{
+		Key:               key,
+		Description:       payload.Description,
+		Enabled:           payload.Enabled,
+		RolloutPercentage: payload.RolloutPercentage,
+		Rules:             rules,
+	}
+	if err := db.Create(&flag).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create feature flag: " + err.Error()})
+		return
+	}
+	featureFlagChanged(c, "created", key, zap.Bool("enabled", flag.Enabled), zap.Int("rolloutPercentage", flag.RolloutPercentage))
+	c.JSON(http.StatusCreated, featureFlagView(flag))
+}
+
+// UpdateFeatureFlagHandler changes the description, master switch, rollout percentage or rules of a
+// feature flag. The key cannot change.
+func UpdateFeatureFlagHandler(c *gin.Context) {
+	var payload FeatureFlagUpdatePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	flag, ok := loadFeatureFlag(c, db)
+	if !ok {
+		return
+	}
+	var rules []models.FeatureFlagRule
+	if payload.Rules != nil {
+		if rules, ok = buildFeatureFlagRules(c, db, *payload.Rules); !ok {
+			return
+		}
+	}
+	updates := map[string]interface{}{}
+	if payload.Description != nil {
+		updates["description"] = *payload.Description
+	}
+	if payload.Enabled != nil {
+		updates["enabled"] = *payload.Enabled
+	}
+	if payload.RolloutPercentage != nil {
+		updates["rollout_percentage"] = *payload.RolloutPercentage
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if len(updates) > 0 {
+			if err := tx.Model(&models.FeatureFlag{}).Where("id = ?", flag.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		if payload.Rules != nil {
+			return replaceFeatureFlagRules(tx, flag.ID, rules)
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update feature flag: " + err.Error()})
+		return
+	}
+	if flag, ok = loadFeatureFlag(c, db); !ok {
+		return
+	}
+	featureFlagChanged(c, "updated", flag.Key, zap.Bool("enabled", flag.Enabled), zap.Int("rolloutPercentage", flag.RolloutPercentage), zap.Int("rules", len(flag.Rules)))
+	c.JSON(http.StatusOK, featureFlagView(flag))
+}
+
+// DeleteFeatureFlagHandler removes a feature flag and its rules. Code checking the key then sees it as
+// off (flag_not_found), unless FEATURE_<KEY> is set.
+func DeleteFeatureFlagHandler(c *gin.Context) {
+	db := database.GetDB()
+	flag, ok := loadFeatureFlag(c, db)
+	if !ok {
+		return
+	}
+	if err := db.Delete(&flag).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete feature flag: " + err.Error()})
+		return
+	}
+	featureFlagChanged(c, "deleted", flag.Key)
+	c.JSON(http.StatusOK, gin.H{"message": "Feature flag deleted successfully"})
+}
+
+// isOrganizationOverride indica se a regra é o override da organização: só a organização, sem papel.
+func isOrganizationOverride(rule models.FeatureFlagRule, orgID uuid.UUID) bool {
+	return rule.OrganizationID != nil && *rule.OrganizationID == orgID && rule.Role == nil
+}
+
+// SetFeatureFlagOrganizationOverrideHandler turns a feature flag on or off for one organization. The
+// override becomes the first rule, so it wins over the other rules and the rollout for that organization;
+// it only applies while the flag is enabled (the master switch still turns it off for everyone).
+func SetFeatureFlagOrganizationOverrideHandler(c *gin.Context) {
+	var payload FeatureFlagOverridePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	db := database.GetDB()
+	flag, ok := loadFeatureFlag(c, db)
+	if !ok {
+		return
+	}
+	var org models.Organization
+	if err := db.Select("id").Where("id = ?", orgID).First(&org).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organization: " + err.Error()})
+		return
+	}
+
+	rules := []models.FeatureFlagRule{{OrganizationID: &orgID, Enabled: *payload.Enabled}}
+	for _, rule := range flag.Rules {
+		if !isOrganizationOverride(rule, orgID) {
+			rules = append(rules, rule)
+		}
+	}
+	if err := db.Transaction(func(tx *gorm.DB) error { return replaceFeatureFlagRules(tx, flag.ID, rules) }); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set organization override: " + err.Error()})
+		return
+	}
+	if flag, ok = loadFeatureFlag(c, db); !ok {
+		return
+	}
+	featureFlagChanged(c, "organization override set", flag.Key, zap.String("organizationID", orgID.String()), zap.Bool("enabled", *payload.Enabled))
+	view := featureFlagView(flag)
+	if !flag.Enabled {
+		view.Warning = "The flag is disabled for everyone; the override applies once it is enabled"
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteFeatureFlagOrganizationOverrideHandler removes the override of an organization, which then follows
+// the other rules and the rollout again.
+func DeleteFeatureFlagOrganizationOverrideHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	db := database.GetDB()
+	flag, ok := loadFeatureFlag(c, db)
+	if !ok {
+		return
+	}
+	rules := make([]models.FeatureFlagRule, 0, len(flag.Rules))
+	for _, rule := range flag.Rules {
+		if !isOrganizationOverride(rule, orgID) {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == len(flag.Rules) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "The organization has no override for this feature flag"})
+		return
+	}
+	if err := db.Transaction(func(tx *gorm.DB) error { return replaceFeatureFlagRules(tx, flag.ID, rules) }); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove organization override: " + err.Error()})
+		return
+	}
+	if flag, ok = loadFeatureFlag(c, db); !ok {
+		return
+	}
+	featureFlagChanged(c, "organization override removed", flag.Key, zap.String("organizationID", orgID.String()))
+	c.JSON(http.StatusOK, featureFlagView(flag))
+}
@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"os"
+	"path/filepath"
 	"phoenixgrc/backend/internal/filestorage"
 	"strconv"
 
@@ -19,6 +24,9 @@ func GetSignedURLForObjectHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "objectKey query parameter is required"})
 		return
 	}
+	if !enforceEvidenceAccessPolicy(c, objectKey) {
+		return
+	}
 
 	durationStr := c.Query("durationMinutes")
 	durationMinutes := defaultSignedURLDurationMinutes
@@ -44,3 +52,42 @@ func GetSignedURLForObjectHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"signed_url": signedURL})
 }
+
+// ServeLocalFileHandler serve arquivos do provider de armazenamento local a partir de uma URL assinada.
+// Rota pública: a autorização é feita pela assinatura HMAC gerada em GetSignedURL.
+// Query params: ?key=...&expires=...&sig=...
+func ServeLocalFileHandler(c *gin.Context) {
+	localProvider, ok := filestorage.Unwrap(filestorage.DefaultFileStorageProvider).(*filestorage.LocalStorageProvider)
+	if !ok || localProvider == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Local file storage is not enabled"})
+		return
+	}
+
+	objectKey := c.Query("key")
+	if err := localProvider.VerifySignedURL(objectKey, c.Query("expires"), c.Query("sig")); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid or expired file URL"})
+		return
+	}
+
+	file, err := localProvider.OpenFile(c.Request.Context(), objectKey)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open file: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(filepath.Ext(objectKey))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Disposition", "attachment; filename=\""+filepath.Base(objectKey)+"\"")
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, file); err != nil {
+		c.Error(err)
+	}
+}
@@ -0,0 +1,548 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxAssessmentCommentLength é o tamanho máximo de um comentário de avaliação.
+const maxAssessmentCommentLength = 5000
+
+// FrameworkCoordinatorPayload designa um coordenador do framework.
+type FrameworkCoordinatorPayload struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+// FrameworkCoordinatorResponse é o coordenador com o nome e o e-mail do usuário.
+type FrameworkCoordinatorResponse struct {
+	models.FrameworkCoordinator
+	UserName  string `json:"user_name"`
+	UserEmail string `json:"user_email"`
+}
+
+// AssessmentCommentPayload é o texto de um comentário de avaliação. MentionedUserIDs são os usuários
+// mencionados (resolvidos pelo frontend), notificados na central de notificações e por e-mail.
+type AssessmentCommentPayload struct {
+	Body             string   `json:"body" binding:"required"`
+	MentionedUserIDs []string `json:"mentioned_user_ids" binding:"omitempty,max=20,dive,uuid"`
+}
+
+// AssessmentCommentResponse é o comentário com o nome do autor.
+type AssessmentCommentResponse struct {
+	models.AssessmentComment
+	AuthorName string `json:"author_name"`
+}
+
+// AssessmentEvidenceDraftResponse é o rascunho de evidência com o nome de quem o enviou.
+type AssessmentEvidenceDraftResponse struct {
+	models.AssessmentEvidenceDraft
+	UploadedByName string `json:"uploaded_by_name"`
+}
+
+// canSubmitAssessment indica se o usuário pode registrar a avaliação contínua dos controles do framework:
+// sem coordenadores designados, todos podem; com coordenadores, só eles e quem tem audit:submit_any (submitAny).
+func canSubmitAssessment(db *gorm.DB, organizationID, frameworkID, userID uuid.UUID, submitAny bool) (bool, error) {
+	if submitAny {
+		return true, nil
+	}
+	var coordinators, self int64
+	if err := db.Model(&models.FrameworkCoordinator{}).
+		Where("organization_id = ? AND framework_id = ?", organizationID, frameworkID).Count(&coordinators).Error; err != nil {
+		return false, err
+	}
+	if coordinators == 0 {
+		return true, nil
+	}
+	if err := db.Model(&models.FrameworkCoordinator{}).
+		Where("organization_id = ? AND framework_id = ? AND user_id = ?", organizationID, frameworkID, userID).Count(&self).Error; err != nil {
+		return false, err
+	}
+	return self > 0, nil
+}
+
+// ensureCanSubmitAssessment responde 403 se o usuário do token não puder registrar avaliações do framework.
+func ensureCanSubmitAssessment(c *gin.Context, db *gorm.DB, organizationID, frameworkID uuid.UUID) bool {
+	userID, _ := c.Get("userID")
+	allowed, err := canSubmitAssessment(db, organizationID, frameworkID, userID.(uuid.UUID), rbac.Can(c, rbac.AuditSubmitAny))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check framework coordinators: " + err.Error()})
+		return false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Only the framework coordinators can submit assessments for this framework; you can comment and upload evidence drafts",
+			"code":  "framework_coordinator_required",
+		})
+		return false
+	}
+	return true
+}
+
+// findVisibleAuditControl carrega o controle de :controlId de um framework visível para a organização.
+func findVisibleAuditControl(c *gin.Context, db *gorm.DB, organizationID uuid.UUID) (*models.AuditControl, bool) {
+	controlID, err := uuid.Parse(c.Param("controlId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid control UUID format"})
+		return nil, false
+	}
+	var control models.AuditControl
+	if err := db.Select("audit_controls.*").
+		Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Scopes(visibleFrameworksScope(organizationID)).
+		First(&control, "audit_controls.id = ?", controlID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Audit control not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch control: " + err.Error()})
+		return nil, false
+	}
+	return &control, true
+}
+
+// ListFrameworkCoordinatorsHandler lists the coordinators of a framework in the organization.
+func ListFrameworkCoordinatorsHandler(c *gin.Context) {
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	coordinators := []FrameworkCoordinatorResponse{}
+	if err := db.Model(&models.FrameworkCoordinator{}).
+		Select("framework_coordinators.*, users.name AS user_name, users.email AS user_email").
+		Joins("JOIN users ON users.id = framework_coordinators.user_id").
+		Where("framework_coordinators.organization_id = ? AND framework_coordinators.framework_id = ?", orgID, framework.ID).
+		Order("users.name").Scan(&coordinators).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list framework coordinators: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, coordinators)
+}
+
+// AddFrameworkCoordinatorHandler designates a user of the organization as coordinator of the framework. Once a
+// framework has coordinators, only they and users with audit:submit_any (organization admins) can submit its
+// assessments.
+func AddFrameworkCoordinatorHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	var payload FrameworkCoordinatorPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID := uuid.MustParse(payload.UserID)
+
+	var user models.User
+	if err := db.Select("id", "name", "email").Where("id = ? AND organization_id = ? AND is_active = ?", userID, organizationID, true).
+		First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "User not found or not an active member of your organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user: " + err.Error()})
+		return
+	}
+	var existing int64
+	if err := db.Model(&models.FrameworkCoordinator{}).
+		Where("organization_id = ? AND framework_id = ? AND user_id = ?", organizationID, framework.ID, userID).Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check framework coordinators: " + err.Error()})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "User is already a coordinator of this framework"})
+		return
+	}
+
+	currentUserID, _ := c.Get("userID")
+	assignedBy := currentUserID.(uuid.UUID)
+	coordinator := models.FrameworkCoordinator{OrganizationID: organizationID, FrameworkID: framework.ID, UserID: userID, AssignedByID: &assignedBy}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&coordinator).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, organizationID, models.AuditTrailCoordinatorAdded, "audit_framework", &framework.ID,
+			fmt.Sprintf("%s designado coordenador do framework %s", user.Email, framework.Name),
+			gin.H{"user_id": userID, "user_email": user.Email})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add framework coordinator: " + err.Error()})
+		return
+	}
+	phxlog.L.Info("Framework coordinator added",
+		zap.String("organizationID", organizationID.String()),
+		zap.String("frameworkID", framework.ID.String()),
+		zap.String("userID", userID.String()),
+		zap.String("assignedByID", assignedBy.String()))
+	c.JSON(http.StatusCreated, FrameworkCoordinatorResponse{FrameworkCoordinator: coordinator, UserName: user.Name, UserEmail: user.Email})
+}
+
+// RemoveFrameworkCoordinatorHandler removes a coordinator from the framework. Removing the last one lifts the
+// restriction on who can submit assessments.
+func RemoveFrameworkCoordinatorHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	errNotCoordinator := errors.New("not a coordinator")
+	err = db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("organization_id = ? AND framework_id = ? AND user_id = ?", orgID, framework.ID, userID).
+			Delete(&models.FrameworkCoordinator{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errNotCoordinator
+		}
+		_, err := recordAuditTrail(c, tx, orgID.(uuid.UUID), models.AuditTrailCoordinatorRemoved, "audit_framework", &framework.ID,
+			fmt.Sprintf("Coordenador %s removido do framework %s", userID, framework.Name), gin.H{"user_id": userID})
+		return err
+	})
+	if errors.Is(err, errNotCoordinator) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User is not a coordinator of this framework"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove framework coordinator: " + err.Error()})
+		return
+	}
+	currentUserID, _ := c.Get("userID")
+	phxlog.L.Info("Framework coordinator removed",
+		zap.Any("organizationID", orgID),
+		zap.String("frameworkID", framework.ID.String()),
+		zap.String("userID", userID.String()),
+		zap.Any("removedByID", currentUserID))
+	c.Status(http.StatusNoContent)
+}
+
+// GetMyAssessmentPermissionHandler tells whether the current user can submit assessments for the framework.
+func GetMyAssessmentPermissionHandler(c *gin.Context) {
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	allowed, err := canSubmitAssessment(db, orgID.(uuid.UUID), framework.ID, userID.(uuid.UUID), rbac.Can(c, rbac.AuditSubmitAny))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check framework coordinators: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"framework_id": framework.ID, "can_submit_assessments": allowed})
+}
+
+// ListAssessmentCommentsHandler lists the comments on the organization's assessment of a control, oldest first.
+func ListAssessmentCommentsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	control, ok := findVisibleAuditControl(c, db, orgID.(uuid.UUID))
+	if !ok {
+		return
+	}
+	comments := []AssessmentCommentResponse{}
+	if err := db.Model(&models.AssessmentComment{}).
+		Select("assessment_comments.*, users.name AS author_name").
+		Joins("LEFT JOIN users ON users.id = assessment_comments.author_id").
+		Where("assessment_comments.organization_id = ? AND assessment_comments.audit_control_id = ?", orgID, control.ID).
+		Order("assessment_comments.created_at").Scan(&comments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assessment comments: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comments)
+}
+
+// CreateAssessmentCommentHandler comments on the organization's assessment of a control. Any member of the
+// organization can comment, including users who cannot submit the assessment.
+func CreateAssessmentCommentHandler(c *gin.Context) {
+	var payload AssessmentCommentPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	body := strings.TrimSpace(payload.Body)
+	if body == "" || len([]rune(body)) > maxAssessmentCommentLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Comment must have between 1 and %d characters", maxAssessmentCommentLength)})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	control, ok := findVisibleAuditControl(c, db, orgID.(uuid.UUID))
+	if !ok {
+		return
+	}
+	comment := models.AssessmentComment{OrganizationID: orgID.(uuid.UUID), AuditControlID: control.ID, AuthorID: userID.(uuid.UUID), Body: body}
+	if err := db.Create(&comment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create assessment comment: " + err.Error()})
+		return
+	}
+	var author models.User
+	db.Select("name").First(&author, "id = ?", comment.AuthorID)
+	if len(payload.MentionedUserIDs) > 0 {
+		// Só membros da organização são notificados; quem comenta não notifica a si mesmo
+		var mentionedIDs []uuid.UUID
+		if err := db.Model(&models.User{}).
+			Where("id IN ? AND organization_id = ? AND id <> ? AND is_active = ?", payload.MentionedUserIDs, comment.OrganizationID, comment.AuthorID, true).
+			Pluck("id", &mentionedIDs).Error; err != nil {
+			phxlog.L.Warn("Failed to load mentioned users", zap.String("commentID", comment.ID.String()), zap.Error(err))
+		}
+		mention := templates.AssessmentMention{
+			AuthorName: author.Name, ControlID: control.ControlID, Comment: comment.Body,
+			Link: notifications.FrontendLink("/admin/audit/frameworks/" + control.FrameworkID.String()),
+		}
+		for _, mentionedID := range mentionedIDs {
+			notifications.NotifyUserWithTemplate(c.Request.Context(), mentionedID, templates.NameAssessmentMention, mention)
+		}
+	}
+	c.JSON(http.StatusCreated, AssessmentCommentResponse{AssessmentComment: comment, AuthorName: author.Name})
+}
+
+// DeleteAssessmentCommentHandler deletes a comment. Only its author or an organization admin can delete it.
+func DeleteAssessmentCommentHandler(c *gin.Context) {
+	controlID, err := uuid.Parse(c.Param("controlId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid control UUID format"})
+		return
+	}
+	commentID, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	var comment models.AssessmentComment
+	if err := db.Where("id = ? AND organization_id = ? AND audit_control_id = ?", commentID, orgID, controlID).
+		First(&comment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch comment: " + err.Error()})
+		return
+	}
+	if comment.AuthorID != userID.(uuid.UUID) && !rbac.Can(c, rbac.AuditModerateComments) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the author or an admin can delete this comment"})
+		return
+	}
+	if err := db.Delete(&comment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete comment: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListAssessmentEvidenceDraftsHandler lists the evidence drafts of a control, newest first. Filtro: status.
+func ListAssessmentEvidenceDraftsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	control, ok := findVisibleAuditControl(c, db, orgID.(uuid.UUID))
+	if !ok {
+		return
+	}
+	query := db.Model(&models.AssessmentEvidenceDraft{}).
+		Select("assessment_evidence_drafts.*, users.name AS uploaded_by_name").
+		Joins("LEFT JOIN users ON users.id = assessment_evidence_drafts.uploaded_by_id").
+		Where("assessment_evidence_drafts.organization_id = ? AND assessment_evidence_drafts.audit_control_id = ?", orgID, control.ID)
+	if status := c.Query("status"); status != "" {
+		if status != models.EvidenceDraftPending && status != models.EvidenceDraftAccepted {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status filter"})
+			return
+		}
+		query = query.Where("assessment_evidence_drafts.status = ?", status)
+	}
+	drafts := []AssessmentEvidenceDraftResponse{}
+	if err := query.Order("assessment_evidence_drafts.created_at desc").Scan(&drafts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list evidence drafts: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, drafts)
+}
+
+// UploadAssessmentEvidenceDraftHandler uploads an evidence draft for the organization's assessment of a control
+// (multipart: file, note). The framework coordinators are notified and can use the draft as the assessment
+// evidence.
+func UploadAssessmentEvidenceDraftHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	control, ok := findVisibleAuditControl(c, db, organizationID)
+	if !ok {
+		return
+	}
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Evidence file not provided in 'file' field"})
+		return
+	}
+	defer file.Close()
+	scanStatus, ok := checkEvidencePortalUpload(c, file, header)
+	if !ok {
+		return
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+	objectPath := fmt.Sprintf("%s/audit_evidences/%s/drafts/%s_%s",
+		organizationID.String(), control.ID.String(), uuid.New().String(), filepath.Base(header.Filename))
+	objectName, err := filestorage.DefaultFileStorageProvider.UploadFile(c.Request.Context(), organizationID.String(), objectPath, file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload evidence file: " + err.Error()})
+		return
+	}
+	draft := models.AssessmentEvidenceDraft{
+		OrganizationID: organizationID,
+		AuditControlID: control.ID,
+		UploadedByID:   userID.(uuid.UUID),
+		FileName:       filepath.Base(header.Filename),
+		ObjectName:     objectName,
+		SizeBytes:      header.Size,
+		ScanStatus:     scanStatus,
+		Note:           strings.TrimSpace(c.PostForm("note")),
+		Status:         models.EvidenceDraftPending,
+	}
+	if err := db.Create(&draft).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record evidence draft: " + err.Error()})
+		return
+	}
+
+	var uploader models.User
+	db.Select("name").First(&uploader, "id = ?", draft.UploadedByID)
+	var coordinatorIDs []uuid.UUID
+	if err := db.Model(&models.FrameworkCoordinator{}).
+		Where("organization_id = ? AND framework_id = ? AND user_id <> ?", organizationID, control.FrameworkID, draft.UploadedByID).
+		Pluck("user_id", &coordinatorIDs).Error; err != nil {
+		phxlog.L.Warn("Failed to load framework coordinators to notify", zap.String("draftID", draft.ID.String()), zap.Error(err))
+	}
+	for _, coordinatorID := range coordinatorIDs {
+		notifications.NotifyUserByEmail(c.Request.Context(), coordinatorID,
+			fmt.Sprintf("Rascunho de evidência enviado: controle %s", control.ControlID),
+			fmt.Sprintf("%s enviou o arquivo '%s' como rascunho de evidência para o controle %s. Revise-o e, se adequado, use-o ao registrar a avaliação.",
+				uploader.Name, draft.FileName, control.ControlID))
+	}
+	c.JSON(http.StatusCreated, AssessmentEvidenceDraftResponse{AssessmentEvidenceDraft: draft, UploadedByName: uploader.Name})
+}
+
+// DeleteAssessmentEvidenceDraftHandler deletes a pending evidence draft and its file. Allowed for the uploader
+// and for users who can submit the framework's assessments.
+func DeleteAssessmentEvidenceDraftHandler(c *gin.Context) {
+	draftID, err := uuid.Parse(c.Param("draftId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid evidence draft ID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	control, ok := findVisibleAuditControl(c, db, organizationID)
+	if !ok {
+		return
+	}
+	var draft models.AssessmentEvidenceDraft
+	if err := db.Where("id = ? AND organization_id = ? AND audit_control_id = ?", draftID, organizationID, control.ID).
+		First(&draft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Evidence draft not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch evidence draft: " + err.Error()})
+		return
+	}
+	if draft.Status != models.EvidenceDraftPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "Accepted evidence drafts cannot be deleted; they are the evidence of an assessment"})
+		return
+	}
+	if draft.UploadedByID != userID.(uuid.UUID) && !ensureCanSubmitAssessment(c, db, organizationID, control.FrameworkID) {
+		return
+	}
+	if err := db.Delete(&draft).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete evidence draft: " + err.Error()})
+		return
+	}
+	if filestorage.DefaultFileStorageProvider != nil {
+		if err := filestorage.DefaultFileStorageProvider.DeleteFile(c.Request.Context(), draft.ObjectName); err != nil {
+			phxlog.L.Warn("Failed to delete evidence draft file", zap.String("objectName", draft.ObjectName), zap.Error(err))
+		}
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// findPendingEvidenceDraft carrega o rascunho pendente draftIDStr do controle para ser usado como evidência
+// da avaliação. Responde o erro e retorna false se ele não puder ser usado.
+func findPendingEvidenceDraft(c *gin.Context, db *gorm.DB, organizationID, controlID uuid.UUID, draftIDStr string) (*models.AssessmentEvidenceDraft, bool) {
+	draftID, err := uuid.Parse(draftIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid evidence_draft_id format"})
+		return nil, false
+	}
+	var draft models.AssessmentEvidenceDraft
+	if err := db.Where("id = ? AND organization_id = ? AND audit_control_id = ?", draftID, organizationID, controlID).
+		First(&draft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Evidence draft not found for this control"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch evidence draft: " + err.Error()})
+		return nil, false
+	}
+	if draft.Status != models.EvidenceDraftPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "Evidence draft has already been accepted"})
+		return nil, false
+	}
+	return &draft, true
+}
+
+// markEvidenceDraftAccepted registra o aceite do rascunho na transação da avaliação.
+func markEvidenceDraftAccepted(tx *gorm.DB, draft *models.AssessmentEvidenceDraft, acceptedBy uuid.UUID) error {
+	now := time.Now()
+	res := tx.Model(&models.AssessmentEvidenceDraft{}).Where("id = ? AND status = ?", draft.ID, models.EvidenceDraftPending).
+		Updates(map[string]interface{}{"status": models.EvidenceDraftAccepted, "accepted_by_id": acceptedBy, "accepted_at": now})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("evidence draft has already been accepted")
+	}
+	return nil
+}
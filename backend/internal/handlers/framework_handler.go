@@ -0,0 +1,474 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/frameworkimport"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FrameworkPayload cria ou edita um framework personalizado da organização.
+type FrameworkPayload struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description"`
+	Version     string `json:"version" binding:"max=50"`
+}
+
+// FrameworkControlPayload cria ou edita um controle de um framework personalizado.
+// Position é a posição oficial do controle; sem ela, o controle entra no fim do framework.
+type FrameworkControlPayload struct {
+	ControlID   string `json:"control_id" binding:"required,max=100"`
+	Description string `json:"description" binding:"required"`
+	Family      string `json:"family" binding:"max=100"`
+	Position    *int   `json:"position" binding:"omitempty,min=1"`
+	// Orientação ao avaliador; omitida mantém a atual na edição, e um objeto vazio a remove
+	Guidance *models.ControlGuidance `json:"guidance"`
+}
+
+// FrameworkControlResponse é o controle com a orientação ao avaliador decodificada.
+type FrameworkControlResponse struct {
+	models.AuditControl
+	Guidance *models.ControlGuidance `json:"guidance,omitempty"`
+}
+
+// respondFrameworkControl responde o controle com a orientação ao avaliador.
+func respondFrameworkControl(c *gin.Context, status int, control *models.AuditControl) {
+	guidance, err := control.GuidanceDetails()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read control guidance: " + err.Error()})
+		return
+	}
+	c.JSON(status, FrameworkControlResponse{AuditControl: *control, Guidance: guidance})
+}
+
+// applyControlGuidance valida e grava a orientação do payload no controle; responde 400 se inválida.
+func applyControlGuidance(c *gin.Context, control *models.AuditControl, guidance *models.ControlGuidance) bool {
+	if guidance == nil {
+		return true
+	}
+	if err := guidance.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid guidance: " + err.Error()})
+		return false
+	}
+	if err := control.SetGuidance(guidance); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save control guidance: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// ControlFamilyRenamePayload renomeia uma família de controles.
+type ControlFamilyRenamePayload struct {
+	Name    string `json:"name" binding:"required"`
+	NewName string `json:"new_name" binding:"required,max=100"`
+}
+
+// ImportFrameworkResponse resume a importação de um framework.
+type ImportFrameworkResponse struct {
+	Framework        models.AuditFramework `json:"framework"`
+	ControlsImported int                   `json:"controls_imported"`
+}
+
+// visibleFrameworksScope limita audit_frameworks aos semeados e aos personalizados da organização.
+func visibleFrameworksScope(organizationID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("audit_frameworks.organization_id IS NULL OR audit_frameworks.organization_id = ?", organizationID)
+	}
+}
+
+// findEditableFramework carrega o framework de :frameworkId para edição: exige audit:manage_frameworks e
+// um framework personalizado da organização (os semeados são somente leitura).
+func findEditableFramework(c *gin.Context, db *gorm.DB) (*models.AuditFramework, bool) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return nil, false
+	}
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return nil, false
+	}
+	if framework.OrganizationID == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Seeded frameworks are read-only"})
+		return nil, false
+	}
+	return framework, true
+}
+
+// frameworkNameTaken indica se o nome já é usado por um framework visível para a organização.
+func frameworkNameTaken(db *gorm.DB, organizationID uuid.UUID, name string, excludeID uuid.UUID) bool {
+	var count int64
+	db.Model(&models.AuditFramework{}).Scopes(visibleFrameworksScope(organizationID)).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", strings.TrimSpace(name), excludeID).
+		Count(&count)
+	return count > 0
+}
+
+// findFrameworkControl carrega o controle :controlId do framework.
+func findFrameworkControl(c *gin.Context, db *gorm.DB, framework *models.AuditFramework) (*models.AuditControl, bool) {
+	controlID, err := uuid.Parse(c.Param("controlId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit control ID format"})
+		return nil, false
+	}
+	var control models.AuditControl
+	if err := db.Where("id = ? AND framework_id = ?", controlID, framework.ID).First(&control).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Control not found in this framework"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch control: " + err.Error()})
+		return nil, false
+	}
+	return &control, true
+}
+
+// controlIDTaken indica se o ControlID já existe no framework (sem diferenciar maiúsculas).
+func controlIDTaken(db *gorm.DB, frameworkID uuid.UUID, controlID string, excludeID uuid.UUID) bool {
+	var count int64
+	db.Model(&models.AuditControl{}).
+		Where("framework_id = ? AND UPPER(control_id) = UPPER(?) AND id <> ?", frameworkID, strings.TrimSpace(controlID), excludeID).
+		Count(&count)
+	return count > 0
+}
+
+// CreateFrameworkHandler creates a custom framework owned by the organization.
+func CreateFrameworkHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	var payload FrameworkPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+	if frameworkNameTaken(db, organizationID, payload.Name, uuid.Nil) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A framework with this name already exists"})
+		return
+	}
+	framework := models.AuditFramework{
+		Name:           strings.TrimSpace(payload.Name),
+		Description:    payload.Description,
+		Version:        payload.Version,
+		OrganizationID: &organizationID,
+	}
+	if err := db.Create(&framework).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create framework: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, framework)
+}
+
+// UpdateFrameworkHandler edits the name, description and version of a custom framework.
+func UpdateFrameworkHandler(c *gin.Context) {
+	var payload FrameworkPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findEditableFramework(c, db)
+	if !ok {
+		return
+	}
+	if frameworkNameTaken(db, *framework.OrganizationID, payload.Name, framework.ID) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A framework with this name already exists"})
+		return
+	}
+	framework.Name = strings.TrimSpace(payload.Name)
+	framework.Description = payload.Description
+	framework.Version = payload.Version
+	if err := db.Save(framework).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update framework: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, framework)
+}
+
+// DeleteFrameworkHandler deletes a custom framework together with its controls and assessments. A framework
+// with assessments is only deleted after a second admin approves it (?approver_id=).
+func DeleteFrameworkHandler(c *gin.Context) {
+	db := database.GetDB()
+	framework, ok := findEditableFramework(c, db)
+	if !ok {
+		return
+	}
+	var assessments int64
+	if err := db.Model(&models.AuditAssessment{}).
+		Joins("JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id").
+		Where("audit_controls.framework_id = ?", framework.ID).Count(&assessments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check framework assessments: " + err.Error()})
+		return
+	}
+	if assessments > 0 {
+		requestSecondAdminApproval(c, db, models.AdminActionFrameworkDelete, framework.ID, framework.Name, nil)
+		return
+	}
+	if err := deleteFramework(db, framework.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete framework: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Framework deleted successfully"})
+}
+
+// deleteFramework exclui o framework, com os controles e as avaliações em cascata, e as tags dos controles.
+func deleteFramework(db *gorm.DB, frameworkID uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		controls := tx.Model(&models.AuditControl{}).Select("id").Where("framework_id = ?", frameworkID)
+		if err := deleteEntityTags(tx, models.TagEntityControl, controls); err != nil {
+			return err
+		}
+		return tx.Delete(&models.AuditFramework{}, "id = ?", frameworkID).Error
+	})
+}
+
+// CreateFrameworkControlHandler adds a control to a custom framework.
+func CreateFrameworkControlHandler(c *gin.Context) {
+	var payload FrameworkControlPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findEditableFramework(c, db)
+	if !ok {
+		return
+	}
+	if controlIDTaken(db, framework.ID, payload.ControlID, uuid.Nil) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A control with this control_id already exists in the framework"})
+		return
+	}
+	position := payload.Position
+	if position == nil {
+		var last int
+		db.Model(&models.AuditControl{}).Where("framework_id = ?", framework.ID).Select("COALESCE(MAX(position), 0)").Scan(&last)
+		next := last + 1
+		position = &next
+	}
+	control := models.AuditControl{
+		FrameworkID: framework.ID,
+		ControlID:   strings.TrimSpace(payload.ControlID),
+		Description: payload.Description,
+		Family:      strings.TrimSpace(payload.Family),
+		Position:    position,
+	}
+	if !applyControlGuidance(c, &control, payload.Guidance) {
+		return
+	}
+	if err := db.Create(&control).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create control: " + err.Error()})
+		return
+	}
+	respondFrameworkControl(c, http.StatusCreated, &control)
+}
+
+// UpdateFrameworkControlHandler edits a control of a custom framework.
+func UpdateFrameworkControlHandler(c *gin.Context) {
+	var payload FrameworkControlPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findEditableFramework(c, db)
+	if !ok {
+		return
+	}
+	control, ok := findFrameworkControl(c, db, framework)
+	if !ok {
+		return
+	}
+	if controlIDTaken(db, framework.ID, payload.ControlID, control.ID) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A control with this control_id already exists in the framework"})
+		return
+	}
+	control.ControlID = strings.TrimSpace(payload.ControlID)
+	control.Description = payload.Description
+	control.Family = strings.TrimSpace(payload.Family)
+	if payload.Position != nil {
+		control.Position = payload.Position
+	}
+	if !applyControlGuidance(c, control, payload.Guidance) {
+		return
+	}
+	if err := db.Save(control).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update control: " + err.Error()})
+		return
+	}
+	respondFrameworkControl(c, http.StatusOK, control)
+}
+
+// DeleteFrameworkControlHandler deletes a control (and its assessments) from a custom framework.
+func DeleteFrameworkControlHandler(c *gin.Context) {
+	db := database.GetDB()
+	framework, ok := findEditableFramework(c, db)
+	if !ok {
+		return
+	}
+	control, ok := findFrameworkControl(c, db, framework)
+	if !ok {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// As avaliações do controle são removidas em cascata: o score do framework é recalculado na próxima leitura
+		if err := compliancescore.Invalidate(tx, *framework.OrganizationID, framework.ID); err != nil {
+			return err
+		}
+		if err := deleteEntityTags(tx, models.TagEntityControl, []uuid.UUID{control.ID}); err != nil {
+			return err
+		}
+		return tx.Delete(control).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete control: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Control deleted successfully"})
+}
+
+// RenameControlFamilyHandler renames a control family across the controls of a custom framework.
+func RenameControlFamilyHandler(c *gin.Context) {
+	var payload ControlFamilyRenamePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findEditableFramework(c, db)
+	if !ok {
+		return
+	}
+	result := db.Model(&models.AuditControl{}).
+		Where("framework_id = ? AND family = ?", framework.ID, payload.Name).
+		Update("family", strings.TrimSpace(payload.NewName))
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rename control family: " + result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Control family not found in this framework"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Control family renamed successfully", "controls_updated": result.RowsAffected})
+}
+
+// DeleteControlFamilyHandler removes a control family (?name=) from a custom framework.
+// Os controles da família são mantidos, sem família.
+func DeleteControlFamilyHandler(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name query parameter is required"})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findEditableFramework(c, db)
+	if !ok {
+		return
+	}
+	result := db.Model(&models.AuditControl{}).
+		Where("framework_id = ? AND family = ?", framework.ID, name).
+		Update("family", "")
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete control family: " + result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Control family not found in this framework"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Control family deleted successfully", "controls_updated": result.RowsAffected})
+}
+
+// ImportFrameworkHandler creates a custom framework from a file ('file' field) in JSON, CSV or OSCAL catalog format.
+// The form fields name, description and version override the values from the file (CSV files require name).
+func ImportFrameworkHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	format := frameworkimport.Format(strings.ToLower(c.DefaultQuery("format", c.PostForm("format"))))
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Framework file not provided in 'file' field"})
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open uploaded file"})
+		return
+	}
+	defer src.Close()
+
+	parsed, err := frameworkimport.Parse(format, src)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to import framework: " + err.Error()})
+		return
+	}
+	if name := c.PostForm("name"); name != "" {
+		parsed.Name = name
+	}
+	if description := c.PostForm("description"); description != "" {
+		parsed.Description = description
+	}
+	if version := c.PostForm("version"); version != "" {
+		parsed.Version = version
+	}
+	parsed.Name = strings.TrimSpace(parsed.Name)
+	if parsed.Name == "" || len(parsed.Name) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Framework name is required (max 255 characters); provide it in the 'name' field"})
+		return
+	}
+
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+	if frameworkNameTaken(db, organizationID, parsed.Name, uuid.Nil) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A framework with this name already exists"})
+		return
+	}
+
+	framework := models.AuditFramework{
+		Name:           parsed.Name,
+		Description:    parsed.Description,
+		Version:        parsed.Version,
+		OrganizationID: &organizationID,
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&framework).Error; err != nil {
+			return err
+		}
+		controls := make([]models.AuditControl, 0, len(parsed.Controls))
+		for i, ctrl := range parsed.Controls {
+			position := i + 1
+			controls = append(controls, models.AuditControl{
+				FrameworkID: framework.ID,
+				ControlID:   strings.TrimSpace(ctrl.ControlID),
+				Description: ctrl.Description,
+				Family:      truncateFamily(ctrl.Family),
+				Position:    &position,
+			})
+		}
+		return tx.CreateInBatches(&controls, 200).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save imported framework: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, ImportFrameworkResponse{Framework: framework, ControlsImported: len(parsed.Controls)})
+}
+
+// truncateFamily limita o nome da família ao tamanho da coluna (100 caracteres).
+func truncateFamily(family string) string {
+	family = strings.TrimSpace(family)
+	if runes := []rune(family); len(runes) > 100 {
+		return string(runes[:100])
+	}
+	return family
+}
@@ -5,6 +5,9 @@ import (
 	"net/http"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/ssomapping"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -20,11 +23,33 @@ type IdentityProviderPayload struct {
 	AttributeMappingJSON json.RawMessage           `json:"attribute_mapping_json"`       // Optional
 }
 
-// checkOrgAdminOrManager é definido em organization_user_handler.go
-// Se não for movido para um pacote comum, precisaria ser acessível ou duplicado.
-// Para este exercício, vamos assumir que podemos usar checkOrgAdminOrManager de alguma forma,
-// ou que a lógica embutida abaixo é suficiente e já é Admin OU Manager.
-// A função checkOrgAdmin foi removida pois checkOrgAdminOrManager é mais genérica.
+// idpGroupMappingProblem confere o mapeamento de papéis e equipes do attribute_mapping_json (ver ssomapping):
+// papéis válidos e equipes existentes na organização. Retorna o problema encontrado, ou "" se ele for válido.
+func idpGroupMappingProblem(db *gorm.DB, orgID uuid.UUID, attributeMappingJSON json.RawMessage) (string, error) {
+	mapping, err := ssomapping.Parse(string(attributeMappingJSON))
+	if err != nil {
+		return err.Error(), nil
+	}
+	missing, err := mapping.MissingTeams(db, orgID)
+	if err != nil || len(missing) == 0 {
+		return "", err
+	}
+	return "unknown teams in team_mappings: " + strings.Join(missing, ", "), nil
+}
+
+// validIdPGroupMapping responde 400 e retorna false se o mapeamento de papéis e equipes for inválido.
+func validIdPGroupMapping(c *gin.Context, orgID uuid.UUID, attributeMappingJSON json.RawMessage) bool {
+	problem, err := idpGroupMappingProblem(database.GetDB(), orgID, attributeMappingJSON)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate attribute mapping: " + err.Error()})
+		return false
+	}
+	if problem != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attribute mapping: " + problem})
+		return false
+	}
+	return true
+}
 
 // CreateIdentityProviderHandler handles adding a new identity provider for an organization.
 func CreateIdentityProviderHandler(c *gin.Context) {
@@ -35,14 +60,7 @@ func CreateIdentityProviderHandler(c *gin.Context) {
 		return
 	}
 
-	// Usar checkOrgAdminOrManager (definido em organization_user_handler.go ou um helper comum)
-	// Por enquanto, replicando a lógica de verificação de admin/manager aqui para manter o arquivo self-contained
-	// até que helpers de autorização sejam centralizados.
-	tokenOrgID, orgOk := c.Get("organizationID")
-	tokenUserRole, roleOk := c.Get("userRole")
-	if !orgOk || !roleOk || tokenOrgID.(uuid.UUID) != targetOrgID ||
-		(tokenUserRole.(models.UserRole) != models.RoleAdmin && tokenUserRole.(models.UserRole) != models.RoleManager) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied or insufficient privileges to manage identity providers"})
+	if !checkOrgPermission(c, targetOrgID, rbac.IdentityProvidersManage) {
 		return
 	}
 
@@ -66,6 +84,9 @@ func CreateIdentityProviderHandler(c *gin.Context) {
 			return
 		}
 	}
+	if !validIdPGroupMapping(c, targetOrgID, payload.AttributeMappingJSON) {
+		return
+	}
 
 	isActive := true // Default to true if not provided
 	if payload.IsActive != nil {
@@ -86,6 +107,7 @@ func CreateIdentityProviderHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create identity provider: " + err.Error()})
 		return
 	}
+	recordConfigChange(c, db, targetOrgID, models.ConfigEntityIdentityProvider, idp.ID, models.ConfigChangeCreate, nil)
 
 	c.JSON(http.StatusCreated, idp)
 }
@@ -156,12 +178,7 @@ func GetIdentityProviderHandler(c *gin.Context) {
 		return
 	}
 
-	// Usar checkOrgAdminOrManager ou lógica similar
-	tokenOrgIDGet, orgOkGet := c.Get("organizationID")
-	tokenUserRoleGet, roleOkGet := c.Get("userRole")
-	if !orgOkGet || !roleOkGet || tokenOrgIDGet.(uuid.UUID) != targetOrgID ||
-		(tokenUserRoleGet.(models.UserRole) != models.RoleAdmin && tokenUserRoleGet.(models.UserRole) != models.RoleManager) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied or insufficient privileges"})
+	if !checkOrgPermission(c, targetOrgID, rbac.IdentityProvidersManage) {
 		return
 	}
 
@@ -193,11 +210,7 @@ func UpdateIdentityProviderHandler(c *gin.Context) {
 		return
 	}
 
-	tokenOrgIDUpd, orgOkUpd := c.Get("organizationID")
-	tokenUserRoleUpd, roleOkUpd := c.Get("userRole")
-	if !orgOkUpd || !roleOkUpd || tokenOrgIDUpd.(uuid.UUID) != targetOrgID ||
-		(tokenUserRoleUpd.(models.UserRole) != models.RoleAdmin && tokenUserRoleUpd.(models.UserRole) != models.RoleManager) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied or insufficient privileges"})
+	if !checkOrgPermission(c, targetOrgID, rbac.IdentityProvidersManage) {
 		return
 	}
 
@@ -220,6 +233,9 @@ func UpdateIdentityProviderHandler(c *gin.Context) {
 			return
 		}
 	}
+	if !validIdPGroupMapping(c, targetOrgID, payload.AttributeMappingJSON) {
+		return
+	}
 
 	db := database.GetDB()
 	var idp models.IdentityProvider
@@ -232,6 +248,7 @@ func UpdateIdentityProviderHandler(c *gin.Context) {
 		return
 	}
 
+	before := snapshotConfigEntity(db, models.ConfigEntityIdentityProvider, targetOrgID, idp.ID)
 	idp.ProviderType = payload.ProviderType
 	idp.Name = payload.Name
 	if payload.IsActive != nil {
@@ -245,6 +262,7 @@ func UpdateIdentityProviderHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update identity provider: " + err.Error()})
 		return
 	}
+	recordConfigChange(c, db, targetOrgID, models.ConfigEntityIdentityProvider, idp.ID, models.ConfigChangeUpdate, before)
 	c.JSON(http.StatusOK, idp)
 }
 
@@ -263,11 +281,7 @@ func DeleteIdentityProviderHandler(c *gin.Context) {
 		return
 	}
 
-	tokenOrgIDDel, orgOkDel := c.Get("organizationID")
-	tokenUserRoleDel, roleOkDel := c.Get("userRole")
-	if !orgOkDel || !roleOkDel || tokenOrgIDDel.(uuid.UUID) != targetOrgID ||
-		(tokenUserRoleDel.(models.UserRole) != models.RoleAdmin && tokenUserRoleDel.(models.UserRole) != models.RoleManager) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied or insufficient privileges"})
+	if !checkOrgPermission(c, targetOrgID, rbac.IdentityProvidersManage) {
 		return
 	}
 
@@ -283,10 +297,12 @@ func DeleteIdentityProviderHandler(c *gin.Context) {
 		return
 	}
 
+	before := snapshotConfigEntity(db, models.ConfigEntityIdentityProvider, targetOrgID, idpID)
 
 	if err := db.Delete(&models.IdentityProvider{}, "id = ? AND organization_id = ?", idpID, targetOrgID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete identity provider: " + err.Error()})
 		return
 	}
+	recordConfigChange(c, db, targetOrgID, models.ConfigEntityIdentityProvider, idpID, models.ConfigChangeDelete, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Identity provider deleted successfully"})
 }
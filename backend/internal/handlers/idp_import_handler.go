@@ -0,0 +1,551 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/oauth2auth"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/samlauth"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maskedSecretValue substitui segredos na exportação. Na importação, o valor mascarado mantém o segredo já salvo.
+const maskedSecretValue = "********"
+
+// idpSecretKeys são as chaves do config_json tratadas como segredo na exportação.
+var idpSecretKeys = []string{"client_secret"}
+
+// idpRequiredKeys lista as chaves obrigatórias do config_json por tipo de provedor.
+var idpRequiredKeys = map[models.IdentityProviderType][]string{
+	models.IDPTypeSAML:         {"idp_entity_id", "idp_sso_url", "idp_x509_cert"},
+	models.IDPTypeOAuth2Google: {"client_id", "client_secret"},
+	models.IDPTypeOAuth2Github: {"client_id", "client_secret"},
+}
+
+// idpCSVHeaders são as colunas da importação/exportação CSV. As colunas de configuração viram chaves do config_json.
+var idpCSVHeaders = []string{"name", "provider_type", "is_active", "metadata_url", "issuer", "idp_entity_id", "idp_sso_url", "idp_x509_cert", "sp_entity_id", "sign_request", "client_id", "client_secret", "scopes", "attribute_mapping_json"}
+
+// IdentityProviderImportEntry é um IdP na importação estruturada. A configuração pode ser informada
+// diretamente em config_json ou preenchida a partir dos metadados SAML (metadata_url/metadata_xml)
+// ou da descoberta OIDC (issuer). Valores explícitos em config_json têm precedência.
+type IdentityProviderImportEntry struct {
+	Name                 string                      `json:"name"`
+	ProviderType         models.IdentityProviderType `json:"provider_type"`
+	IsActive             *bool                       `json:"is_active"`
+	ConfigJSON           map[string]interface{}      `json:"config_json"`
+	AttributeMappingJSON json.RawMessage             `json:"attribute_mapping_json"`
+	MetadataURL          string                      `json:"metadata_url"`
+	MetadataXML          string                      `json:"metadata_xml"`
+	Issuer               string                      `json:"issuer"`
+}
+
+// IdentityProviderImportPayload é o corpo JSON da importação.
+type IdentityProviderImportPayload struct {
+	IdentityProviders []IdentityProviderImportEntry `json:"identity_providers"`
+}
+
+// ImportIdentityProvidersResponse resume o resultado da importação. Em JSON, line_number é a posição (1-based) na lista.
+type ImportIdentityProvidersResponse struct {
+	Created    int                     `json:"created"`
+	Updated    int                     `json:"updated"`
+	FailedRows []BulkUploadErrorDetail `json:"failed_rows,omitempty"`
+}
+
+// IdentityProviderExportEntry é um IdP na exportação JSON, no mesmo formato aceito pela importação.
+type IdentityProviderExportEntry struct {
+	Name                 string                      `json:"name"`
+	ProviderType         models.IdentityProviderType `json:"provider_type"`
+	IsActive             bool                        `json:"is_active"`
+	ConfigJSON           map[string]interface{}      `json:"config_json"`
+	AttributeMappingJSON json.RawMessage             `json:"attribute_mapping_json,omitempty"`
+}
+
+// SAMLMetadataPayload pede a leitura dos metadados publicados pelo IdP.
+type SAMLMetadataPayload struct {
+	MetadataURL string `json:"metadata_url" binding:"required,url"`
+}
+
+// OIDCDiscoveryPayload pede a descoberta OIDC do issuer.
+type OIDCDiscoveryPayload struct {
+	Issuer string `json:"issuer" binding:"required,url"`
+}
+
+// parseIdPOrgID valida :orgId e a permissão identity_providers:manage na organização.
+func parseIdPOrgID(c *gin.Context) (uuid.UUID, bool) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return uuid.Nil, false
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.IdentityProvidersManage) {
+		return uuid.Nil, false
+	}
+	return targetOrgID, true
+}
+
+// ParseSAMLMetadataHandler parses IdP metadata (multipart "file" or JSON {"metadata_url"}) and
+// returns the config_json fields without saving anything.
+func ParseSAMLMetadataHandler(c *gin.Context) {
+	if _, ok := parseIdPOrgID(c); !ok {
+		return
+	}
+	var (
+		cfg *samlauth.IdPMetadataConfig
+		err error
+	)
+	if file, fileErr := c.FormFile("file"); fileErr == nil {
+		src, openErr := file.Open()
+		if openErr != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open uploaded file"})
+			return
+		}
+		defer src.Close()
+		data, readErr := io.ReadAll(src)
+		if readErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file: " + readErr.Error()})
+			return
+		}
+		cfg, err = samlauth.ParseIdPMetadata(data)
+	} else {
+		var payload SAMLMetadataPayload
+		if bindErr := c.ShouldBindJSON(&payload); bindErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Provide a metadata XML in 'file' or a JSON body with metadata_url: " + bindErr.Error()})
+			return
+		}
+		cfg, err = samlauth.FetchIdPMetadata(c.Request.Context(), payload.MetadataURL)
+	}
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cfg)
+}
+
+// DiscoverOIDCHandler fetches the issuer's OIDC discovery document for auto-filling an OAuth2 IdP.
+func DiscoverOIDCHandler(c *gin.Context) {
+	if _, ok := parseIdPOrgID(c); !ok {
+		return
+	}
+	var payload OIDCDiscoveryPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	doc, err := oauth2auth.DiscoverOIDC(c.Request.Context(), payload.Issuer)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
+
+// buildImportedIdPConfig monta o config_json final: configuração existente < metadados/descoberta < config_json explícito.
+func buildImportedIdPConfig(ctx context.Context, entry IdentityProviderImportEntry, existing *models.IdentityProvider) (map[string]interface{}, []string) {
+	var errs []string
+	config := make(map[string]interface{})
+	if existing != nil && existing.ConfigJSON != "" {
+		_ = json.Unmarshal([]byte(existing.ConfigJSON), &config)
+	}
+
+	switch entry.ProviderType {
+	case models.IDPTypeSAML:
+		metadataURL := entry.MetadataURL
+		if metadataURL == "" {
+			if v, ok := entry.ConfigJSON["idp_metadata_url"].(string); ok {
+				metadataURL = v
+			}
+		}
+		var (
+			metadata *samlauth.IdPMetadataConfig
+			err      error
+		)
+		if entry.MetadataXML != "" {
+			metadata, err = samlauth.ParseIdPMetadata([]byte(entry.MetadataXML))
+		} else if metadataURL != "" {
+			metadata, err = samlauth.FetchIdPMetadata(ctx, metadataURL)
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else if metadata != nil {
+			raw, _ := json.Marshal(metadata)
+			var fromMetadata map[string]interface{}
+			_ = json.Unmarshal(raw, &fromMetadata)
+			for k, v := range fromMetadata {
+				config[k] = v
+			}
+		}
+	case models.IDPTypeOAuth2Google, models.IDPTypeOAuth2Github:
+		if entry.Issuer != "" {
+			doc, err := oauth2auth.DiscoverOIDC(ctx, entry.Issuer)
+			if err != nil {
+				errs = append(errs, err.Error())
+			} else {
+				config["issuer"] = doc.Issuer
+				config["authorization_endpoint"] = doc.AuthorizationEndpoint
+				config["token_endpoint"] = doc.TokenEndpoint
+				config["jwks_uri"] = doc.JWKSURI
+				if doc.UserinfoEndpoint != "" {
+					config["userinfo_endpoint"] = doc.UserinfoEndpoint
+				}
+			}
+		}
+	}
+
+	for k, v := range entry.ConfigJSON {
+		if s, ok := v.(string); ok && s == maskedSecretValue {
+			continue
+		}
+		config[k] = v
+	}
+
+	for _, key := range idpRequiredKeys[entry.ProviderType] {
+		if s, _ := config[key].(string); strings.TrimSpace(s) == "" {
+			errs = append(errs, fmt.Sprintf("config_json.%s is required for %s", key, entry.ProviderType))
+		}
+	}
+	return config, errs
+}
+
+// idpEntryFromCSV converte uma linha CSV em entrada de importação.
+func idpEntryFromCSV(record []string, headerMap map[string]int) (IdentityProviderImportEntry, []string) {
+	var errs []string
+	entry := IdentityProviderImportEntry{
+		Name:         strings.TrimSpace(getCSVField(record, headerMap, "name")),
+		ProviderType: models.IdentityProviderType(strings.ToLower(strings.TrimSpace(getCSVField(record, headerMap, "provider_type")))),
+		MetadataURL:  strings.TrimSpace(getCSVField(record, headerMap, "metadata_url")),
+		Issuer:       strings.TrimSpace(getCSVField(record, headerMap, "issuer")),
+		ConfigJSON:   make(map[string]interface{}),
+	}
+	if v := strings.TrimSpace(getCSVField(record, headerMap, "is_active")); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid is_active: '%s'", v))
+		} else {
+			entry.IsActive = &isActive
+		}
+	}
+	for _, key := range []string{"idp_entity_id", "idp_sso_url", "idp_x509_cert", "sp_entity_id", "client_id", "client_secret"} {
+		if v := strings.TrimSpace(getCSVField(record, headerMap, key)); v != "" {
+			entry.ConfigJSON[key] = v
+		}
+	}
+	if v := strings.TrimSpace(getCSVField(record, headerMap, "sign_request")); v != "" {
+		signRequest, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid sign_request: '%s'", v))
+		} else {
+			entry.ConfigJSON["sign_request"] = signRequest
+		}
+	}
+	if v := strings.TrimSpace(getCSVField(record, headerMap, "scopes")); v != "" {
+		scopes := []interface{}{}
+		for _, scope := range strings.Fields(v) {
+			scopes = append(scopes, scope)
+		}
+		entry.ConfigJSON["scopes"] = scopes
+	}
+	if v := strings.TrimSpace(getCSVField(record, headerMap, "attribute_mapping_json")); v != "" {
+		entry.AttributeMappingJSON = json.RawMessage(v)
+	}
+	return entry, errs
+}
+
+// readIdPImportEntries lê as entradas do corpo JSON ou do arquivo (CSV ou JSON) enviado em "file".
+// O segundo retorno traz os erros de leitura por linha do CSV.
+func readIdPImportEntries(c *gin.Context) ([]IdentityProviderImportEntry, []int, []BulkUploadErrorDetail, error) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		var payload IdentityProviderImportPayload
+		if bindErr := c.ShouldBindJSON(&payload); bindErr != nil {
+			return nil, nil, nil, fmt.Errorf("provide a CSV/JSON file in 'file' or a JSON body with identity_providers: %w", bindErr)
+		}
+		lines := make([]int, len(payload.IdentityProviders))
+		for i := range lines {
+			lines[i] = i + 1
+		}
+		return payload.IdentityProviders, lines, nil, nil
+	}
+	src, err := file.Open()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to open uploaded file")
+	}
+	defer src.Close()
+
+	if strings.HasSuffix(strings.ToLower(file.Filename), ".json") {
+		var entries []IdentityProviderImportEntry
+		data, readErr := io.ReadAll(src)
+		if readErr != nil {
+			return nil, nil, nil, fmt.Errorf("failed to read uploaded file: %w", readErr)
+		}
+		// Aceita tanto a lista quanto o formato da exportação ({"identity_providers": [...]}).
+		if jsonErr := json.Unmarshal(data, &entries); jsonErr != nil {
+			var payload IdentityProviderImportPayload
+			if wrappedErr := json.Unmarshal(data, &payload); wrappedErr != nil {
+				return nil, nil, nil, fmt.Errorf("invalid JSON file: %w", jsonErr)
+			}
+			entries = payload.IdentityProviders
+		}
+		lines := make([]int, len(entries))
+		for i := range lines {
+			lines[i] = i + 1
+		}
+		return entries, lines, nil, nil
+	}
+
+	reader := csv.NewReader(src)
+	headers, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read CSV headers: %w", err)
+	}
+	headerMap := make(map[string]int)
+	for i, h := range headers {
+		headerMap[normalizeHeader(h)] = i
+	}
+	for _, required := range []string{"name", "provider_type"} {
+		if _, ok := headerMap[required]; !ok {
+			return nil, nil, nil, fmt.Errorf("missing required CSV header: %s", required)
+		}
+	}
+
+	var entries []IdentityProviderImportEntry
+	var lines []int
+	var failed []BulkUploadErrorDetail
+	lineNumber := 1
+	for {
+		lineNumber++
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			failed = append(failed, BulkUploadErrorDetail{LineNumber: lineNumber, Errors: []string{"Failed to parse CSV row: " + readErr.Error()}})
+			continue
+		}
+		entry, rowErrs := idpEntryFromCSV(record, headerMap)
+		if len(rowErrs) > 0 {
+			failed = append(failed, BulkUploadErrorDetail{LineNumber: lineNumber, Errors: rowErrs})
+			continue
+		}
+		entries = append(entries, entry)
+		lines = append(lines, lineNumber)
+	}
+	return entries, lines, failed, nil
+}
+
+// ImportIdentityProvidersHandler creates or updates identity providers from JSON or CSV (chave: name).
+func ImportIdentityProvidersHandler(c *gin.Context) {
+	targetOrgID, ok := parseIdPOrgID(c)
+	if !ok {
+		return
+	}
+	entries, lines, failedRows, err := readIdPImportEntries(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := database.GetDB()
+	var existing []models.IdentityProvider
+	db.Where("organization_id = ?", targetOrgID).Find(&existing)
+	existingByName := make(map[string]models.IdentityProvider, len(existing))
+	for _, idp := range existing {
+		existingByName[strings.ToLower(idp.Name)] = idp
+	}
+
+	var toCreate, toUpdate []models.IdentityProvider
+	seenNames := make(map[string]int)
+	for i, entry := range entries {
+		lineNumber := lines[i]
+		var rowErrors []string
+		entry.Name = strings.TrimSpace(entry.Name)
+		if len(entry.Name) < 3 || len(entry.Name) > 100 {
+			rowErrors = append(rowErrors, "name must be between 3 and 100 characters")
+		} else if prev, dup := seenNames[strings.ToLower(entry.Name)]; dup {
+			rowErrors = append(rowErrors, fmt.Sprintf("duplicate name (already on line %d)", prev))
+		}
+		if _, valid := idpRequiredKeys[entry.ProviderType]; !valid {
+			rowErrors = append(rowErrors, fmt.Sprintf("invalid provider_type: '%s'", entry.ProviderType))
+		}
+		if len(entry.AttributeMappingJSON) > 0 && !json.Valid(entry.AttributeMappingJSON) {
+			rowErrors = append(rowErrors, "attribute_mapping_json is not valid JSON")
+		} else if problem, err := idpGroupMappingProblem(db, targetOrgID, entry.AttributeMappingJSON); err != nil {
+			rowErrors = append(rowErrors, "failed to validate attribute_mapping_json: "+err.Error())
+		} else if problem != "" {
+			rowErrors = append(rowErrors, "invalid attribute_mapping_json: "+problem)
+		}
+		if len(rowErrors) > 0 {
+			failedRows = append(failedRows, BulkUploadErrorDetail{LineNumber: lineNumber, Errors: rowErrors})
+			continue
+		}
+
+		idp, exists := existingByName[strings.ToLower(entry.Name)]
+		var current *models.IdentityProvider
+		if exists && idp.ProviderType == entry.ProviderType {
+			current = &idp
+		}
+		config, configErrs := buildImportedIdPConfig(c.Request.Context(), entry, current)
+		if len(configErrs) > 0 {
+			failedRows = append(failedRows, BulkUploadErrorDetail{LineNumber: lineNumber, Errors: configErrs})
+			continue
+		}
+		seenNames[strings.ToLower(entry.Name)] = lineNumber
+
+		configJSON, _ := json.Marshal(config)
+		if !exists {
+			idp = models.IdentityProvider{OrganizationID: targetOrgID, Name: entry.Name, IsActive: true}
+		}
+		idp.ProviderType = entry.ProviderType
+		idp.ConfigJSON = string(configJSON)
+		if entry.IsActive != nil {
+			idp.IsActive = *entry.IsActive
+		}
+		if len(entry.AttributeMappingJSON) > 0 {
+			idp.AttributeMappingJSON = string(entry.AttributeMappingJSON)
+		}
+		if exists {
+			toUpdate = append(toUpdate, idp)
+		} else {
+			toCreate = append(toCreate, idp)
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if len(toCreate) > 0 {
+			if err := tx.Create(&toCreate).Error; err != nil {
+				return err
+			}
+		}
+		for i := range toUpdate {
+			if err := tx.Save(&toUpdate[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error during identity provider import: " + err.Error()})
+		return
+	}
+
+	response := ImportIdentityProvidersResponse{Created: len(toCreate), Updated: len(toUpdate), FailedRows: failedRows}
+	imported := len(toCreate) + len(toUpdate)
+	if len(failedRows) > 0 && imported > 0 {
+		c.JSON(http.StatusMultiStatus, response)
+	} else if len(failedRows) > 0 {
+		c.JSON(http.StatusBadRequest, response)
+	} else {
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// exportIdPConfig decodifica o config_json, mascarando segredos se necessário.
+func exportIdPConfig(idp models.IdentityProvider, includeSecrets bool) map[string]interface{} {
+	config := make(map[string]interface{})
+	if idp.ConfigJSON != "" {
+		_ = json.Unmarshal([]byte(idp.ConfigJSON), &config)
+	}
+	if !includeSecrets {
+		for _, key := range idpSecretKeys {
+			if _, ok := config[key]; ok {
+				config[key] = maskedSecretValue
+			}
+		}
+	}
+	return config
+}
+
+// ExportIdentityProvidersHandler exports the organization's identity providers as JSON or CSV.
+// Query: format (json|csv, default json), include_secrets (default false; segredos saem como "********").
+func ExportIdentityProvidersHandler(c *gin.Context) {
+	targetOrgID, ok := parseIdPOrgID(c)
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, expected json or csv"})
+		return
+	}
+	includeSecrets := c.Query("include_secrets") == "true"
+
+	var idps []models.IdentityProvider
+	if err := database.GetDB().Where("organization_id = ?", targetOrgID).Order("name asc").Find(&idps).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list identity providers: " + err.Error()})
+		return
+	}
+
+	if format == "json" {
+		entries := make([]IdentityProviderExportEntry, 0, len(idps))
+		for _, idp := range idps {
+			entry := IdentityProviderExportEntry{
+				Name:         idp.Name,
+				ProviderType: idp.ProviderType,
+				IsActive:     idp.IsActive,
+				ConfigJSON:   exportIdPConfig(idp, includeSecrets),
+			}
+			if idp.AttributeMappingJSON != "" {
+				entry.AttributeMappingJSON = json.RawMessage(idp.AttributeMappingJSON)
+			}
+			entries = append(entries, entry)
+		}
+		c.Header("Content-Disposition", "attachment; filename=identity_providers.json")
+		c.JSON(http.StatusOK, gin.H{"identity_providers": entries})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename=identity_providers.csv")
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write(idpCSVHeaders)
+	for _, idp := range idps {
+		config := exportIdPConfig(idp, includeSecrets)
+		row := make([]string, 0, len(idpCSVHeaders))
+		for _, header := range idpCSVHeaders {
+			switch header {
+			case "name":
+				row = append(row, idp.Name)
+			case "provider_type":
+				row = append(row, string(idp.ProviderType))
+			case "is_active":
+				row = append(row, strconv.FormatBool(idp.IsActive))
+			case "metadata_url":
+				row = append(row, fmt.Sprint(valueOrEmpty(config["idp_metadata_url"])))
+			case "scopes":
+				var scopes []string
+				if list, ok := config["scopes"].([]interface{}); ok {
+					for _, s := range list {
+						scopes = append(scopes, fmt.Sprint(s))
+					}
+				}
+				row = append(row, strings.Join(scopes, " "))
+			case "attribute_mapping_json":
+				row = append(row, idp.AttributeMappingJSON)
+			default:
+				row = append(row, fmt.Sprint(valueOrEmpty(config[header])))
+			}
+		}
+		_ = writer.Write(row)
+	}
+	writer.Flush()
+}
+
+func valueOrEmpty(v interface{}) interface{} {
+	if v == nil {
+		return ""
+	}
+	return v
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/samlauth"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SAMLCertificatePayload adds a service provider certificate to a SAML identity provider. Without a key pair,
+// Phoenix generates a self-signed one valid for validity_days (default 730).
+type SAMLCertificatePayload struct {
+	ValidityDays   int    `json:"validity_days" binding:"omitempty,min=1,max=3650"`
+	CertificatePEM string `json:"certificate_pem"`
+	PrivateKeyPEM  string `json:"private_key_pem"`
+}
+
+// SAMLCertificateListResponse lists the SP certificates of an identity provider. uses_global_certificate is true
+// while none is active and the global pair (SAML_SP_KEY_PEM / SAML_SP_CERT_PEM) signs in its place.
+type SAMLCertificateListResponse struct {
+	Certificates          []models.SAMLCertificate `json:"certificates"`
+	UsesGlobalCertificate bool                     `json:"uses_global_certificate"`
+	MetadataURL           string                   `json:"metadata_url"`
+}
+
+// loadSAMLIdentityProvider carrega o IdP SAML da organização para as rotas de certificados, respondendo o erro se
+// ele não existir ou não for SAML.
+func loadSAMLIdentityProvider(c *gin.Context, db *gorm.DB) (*models.IdentityProvider, bool) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return nil, false
+	}
+	idpID, err := uuid.Parse(c.Param("idpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid identity provider ID format"})
+		return nil, false
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.IdentityProvidersManage) {
+		return nil, false
+	}
+
+	var idp models.IdentityProvider
+	if err := db.Where("id = ? AND organization_id = ?", idpID, targetOrgID).First(&idp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Identity provider not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch identity provider: " + err.Error()})
+		return nil, false
+	}
+	if idp.ProviderType != models.IDPTypeSAML {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Service provider certificates only apply to SAML identity providers"})
+		return nil, false
+	}
+	return &idp, true
+}
+
+// loadSAMLCertificate carrega o certificado do IdP indicado em :certId.
+func loadSAMLCertificate(c *gin.Context, db *gorm.DB, idp *models.IdentityProvider) (*models.SAMLCertificate, bool) {
+	certID, err := uuid.Parse(c.Param("certId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid certificate ID format"})
+		return nil, false
+	}
+	var cert models.SAMLCertificate
+	if err := db.Where("id = ? AND identity_provider_id = ?", certID, idp.ID).First(&cert).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "SAML certificate not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch SAML certificate: " + err.Error()})
+		return nil, false
+	}
+	return &cert, true
+}
+
+func samlCertificateAuditDetails(idp *models.IdentityProvider, cert *models.SAMLCertificate) gin.H {
+	return gin.H{
+		"identity_provider_id": idp.ID,
+		"identity_provider":    idp.Name,
+		"fingerprint_sha256":   cert.FingerprintSHA256,
+		"not_after":            cert.NotAfter,
+		"status":               cert.Status,
+	}
+}
+
+// ListSAMLCertificatesHandler lists the service provider certificates of a SAML identity provider (identity_providers:manage).
+func ListSAMLCertificatesHandler(c *gin.Context) {
+	db := database.GetDB()
+	idp, ok := loadSAMLIdentityProvider(c, db)
+	if !ok {
+		return
+	}
+
+	var certificates []models.SAMLCertificate
+	if err := db.Where("identity_provider_id = ?", idp.ID).Order("created_at DESC").Find(&certificates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list SAML certificates: " + err.Error()})
+		return
+	}
+	hasActive := false
+	for _, cert := range certificates {
+		hasActive = hasActive || cert.Status == models.SAMLCertificateActive
+	}
+	c.JSON(http.StatusOK, SAMLCertificateListResponse{
+		Certificates:          certificates,
+		UsesGlobalCertificate: !hasActive && samlauth.HasGlobalCertificate(),
+		MetadataURL:           fmt.Sprintf("/auth/saml/%s/metadata", idp.ID),
+	})
+}
+
+// AddSAMLCertificateHandler adds a service provider certificate to a SAML identity provider (identity_providers:manage).
+// It starts as standby: it is published in the SP metadata so the IdP can trust it before it is activated. When the
+// provider has nothing to sign with (no active certificate and no global pair), the new certificate is activated at once.
+func AddSAMLCertificateHandler(c *gin.Context) {
+	db := database.GetDB()
+	idp, ok := loadSAMLIdentityProvider(c, db)
+	if !ok {
+		return
+	}
+
+	var payload SAMLCertificatePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if (payload.CertificatePEM == "") != (payload.PrivateKeyPEM == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "certificate_pem and private_key_pem must be provided together"})
+		return
+	}
+	certPEM, keyPEM := payload.CertificatePEM, payload.PrivateKeyPEM
+	if certPEM == "" {
+		validity := samlauth.DefaultCertificateValidity
+		if payload.ValidityDays > 0 {
+			validity = time.Duration(payload.ValidityDays) * 24 * time.Hour
+		}
+		var err error
+		certPEM, keyPEM, err = samlauth.GenerateCertificate("phoenix-grc-sp-"+idp.ID.String(), validity)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate SAML certificate: " + err.Error()})
+			return
+		}
+	}
+	cert, err := samlauth.NewCertificate(idp.ID, certPEM, keyPEM)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid SAML certificate: " + err.Error()})
+		return
+	}
+	userID, _ := c.Get("userID")
+	if createdByID, ok := userID.(uuid.UUID); ok {
+		cert.CreatedByID = &createdByID
+	}
+
+	limitReached := false
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Trava o IdP para serializar as alterações nos seus certificados
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.IdentityProvider{}, "id = ?", idp.ID).Error; err != nil {
+			return err
+		}
+		var existing []models.SAMLCertificate
+		if err := tx.Select("id, status").Where("identity_provider_id = ?", idp.ID).Find(&existing).Error; err != nil {
+			return err
+		}
+		if len(existing) >= models.MaxSAMLCertificatesPerIdP {
+			limitReached = true
+			return nil
+		}
+		hasActive := false
+		for _, other := range existing {
+			hasActive = hasActive || other.Status == models.SAMLCertificateActive
+		}
+		if !hasActive && !samlauth.HasGlobalCertificate() {
+			now := time.Now()
+			cert.Status, cert.ActivatedAt = models.SAMLCertificateActive, &now
+		}
+		if err := tx.Create(cert).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, idp.OrganizationID, models.AuditTrailSAMLCertAdded, "saml_certificate", &cert.ID,
+			fmt.Sprintf("SAML certificate %s added to identity provider %s (%s)", cert.FingerprintSHA256, idp.Name, cert.Status),
+			samlCertificateAuditDetails(idp, cert))
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save SAML certificate: " + err.Error()})
+		return
+	}
+	if limitReached {
+		c.JSON(http.StatusConflict, gin.H{"error": "SAML certificate limit reached; remove an unused one first"})
+		return
+	}
+	c.JSON(http.StatusCreated, cert)
+}
+
+// ActivateSAMLCertificateHandler makes a certificate the one that signs requests and decrypts assertions for the
+// identity provider (identity_providers:manage). The previously active certificate stays published as standby
+// until it is removed, so assertions and signatures made with it in flight keep validating at the IdP.
+func ActivateSAMLCertificateHandler(c *gin.Context) {
+	db := database.GetDB()
+	idp, ok := loadSAMLIdentityProvider(c, db)
+	if !ok {
+		return
+	}
+	cert, ok := loadSAMLCertificate(c, db, idp)
+	if !ok {
+		return
+	}
+	if cert.Status == models.SAMLCertificateActive {
+		c.JSON(http.StatusOK, cert)
+		return
+	}
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		c.JSON(http.StatusConflict, gin.H{"error": "The SAML certificate is not within its validity period"})
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.IdentityProvider{}, "id = ?", idp.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SAMLCertificate{}).
+			Where("identity_provider_id = ? AND status = ?", idp.ID, models.SAMLCertificateActive).
+			Update("status", models.SAMLCertificateStandby).Error; err != nil {
+			return err
+		}
+		cert.Status, cert.ActivatedAt = models.SAMLCertificateActive, &now
+		if err := tx.Model(cert).Updates(map[string]interface{}{"status": cert.Status, "activated_at": now}).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, idp.OrganizationID, models.AuditTrailSAMLCertActivated, "saml_certificate", &cert.ID,
+			fmt.Sprintf("SAML certificate %s activated for identity provider %s", cert.FingerprintSHA256, idp.Name),
+			samlCertificateAuditDetails(idp, cert))
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to activate SAML certificate: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cert)
+}
+
+// DeleteSAMLCertificateHandler removes a standby certificate from the identity provider (identity_providers:manage).
+// The active certificate cannot be removed; activate another one first.
+func DeleteSAMLCertificateHandler(c *gin.Context) {
+	db := database.GetDB()
+	idp, ok := loadSAMLIdentityProvider(c, db)
+	if !ok {
+		return
+	}
+	cert, ok := loadSAMLCertificate(c, db, idp)
+	if !ok {
+		return
+	}
+	if cert.Status == models.SAMLCertificateActive {
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot remove the active SAML certificate; activate another one first"})
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("status <> ?", models.SAMLCertificateActive).Delete(&models.SAMLCertificate{}, "id = ?", cert.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		_, err := recordAuditTrail(c, tx, idp.OrganizationID, models.AuditTrailSAMLCertRemoved, "saml_certificate", &cert.ID,
+			fmt.Sprintf("SAML certificate %s removed from identity provider %s", cert.FingerprintSHA256, idp.Name),
+			samlCertificateAuditDetails(idp, cert))
+		return err
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot remove the active SAML certificate; activate another one first"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove SAML certificate: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "SAML certificate removed successfully"})
+}
@@ -0,0 +1,493 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/internal/passwordpolicy"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// defaultInvitationExpiryDays é a validade do link do convite quando expires_in_days não é informado.
+const defaultInvitationExpiryDays = 7
+
+// InvitationPayload convida uma pessoa para a organização. role é o papel base (padrão user); custom_role_id
+// exige roles:manage e o papel admin exige users:assign_admin.
+type InvitationPayload struct {
+	Email         string          `json:"email" binding:"required,email,max=255"`
+	Name          string          `json:"name" binding:"max=255"`
+	Role          models.UserRole `json:"role" binding:"omitempty,oneof=admin manager user"`
+	CustomRoleID  *string         `json:"custom_role_id" binding:"omitempty,uuid"`
+	ExpiresInDays int             `json:"expires_in_days" binding:"omitempty,min=1,max=30"`
+}
+
+// ResendInvitationPayload define a validade do novo link (opcional).
+type ResendInvitationPayload struct {
+	ExpiresInDays int `json:"expires_in_days" binding:"omitempty,min=1,max=30"`
+}
+
+// InvitationView é um convite com a situação calculada.
+type InvitationView struct {
+	models.UserInvitation
+	Status models.InvitationStatus `json:"status"`
+}
+
+func newInvitationView(invitation models.UserInvitation) InvitationView {
+	return InvitationView{UserInvitation: invitation, Status: invitation.Status(time.Now())}
+}
+
+// PublicInvitationResponse é o que a tela de aceite mostra antes de a pessoa ter uma conta.
+type PublicInvitationResponse struct {
+	OrganizationName string                 `json:"organization_name"`
+	Email            string                 `json:"email"`
+	Name             string                 `json:"name,omitempty"`
+	Role             models.UserRole        `json:"role"`
+	ExpiresAt        time.Time              `json:"expires_at"`
+	PasswordAllowed  bool                   `json:"password_allowed"` // false se a organização exige SSO
+	PasswordPolicy   PasswordPolicyResponse `json:"password_policy"`
+	SSO              []LoginPageSSOProvider `json:"sso"`
+}
+
+// AcceptInvitationPayload cria a conta do convidado com senha.
+type AcceptInvitationPayload struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name" binding:"max=255"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// hashInvitationToken retorna o hash armazenado para o token do convite.
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newInvitationToken gera o token aleatório do link do convite.
+func newInvitationToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+// invitationAcceptURL monta o link enviado ao convidado.
+func invitationAcceptURL(token string) string {
+	return notifications.FrontendLink("/auth/accept-invitation?token=" + token)
+}
+
+// sendInvitationEmail envia o link do convite com a identidade visual da organização. Falhas de montagem são
+// só registradas: o convite já existe e pode ser reenviado.
+func sendInvitationEmail(c *gin.Context, db *gorm.DB, invitation models.UserInvitation, token string, expiresInDays int) {
+	var inviter models.User
+	db.Select("name").Take(&inviter, "id = ?", invitation.InvitedByID)
+	data := templates.UserInvitation{
+		InviterName:   inviter.Name,
+		Role:          string(invitation.Role),
+		ExpiresInDays: expiresInDays,
+		Link:          invitationAcceptURL(token),
+	}
+	if err := notifications.EmailWithTemplate(c.Request.Context(), invitation.OrganizationID, invitation.Email, invitation.Name,
+		templates.NameUserInvitation, data); err != nil {
+		phxlog.L.Error("Failed to send user invitation email",
+			zap.String("invitationID", invitation.ID.String()), zap.Error(err))
+	}
+}
+
+// findOrgInvitation carrega o convite :invitationId da organização :orgId, verificando users:manage.
+func findOrgInvitation(c *gin.Context, db *gorm.DB) (*models.UserInvitation, bool) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return nil, false
+	}
+	invitationID, err := uuid.Parse(c.Param("invitationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID format"})
+		return nil, false
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
+		return nil, false
+	}
+	var invitation models.UserInvitation
+	if err := db.Where("id = ? AND organization_id = ?", invitationID, targetOrgID).Take(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load invitation: " + err.Error()})
+		return nil, false
+	}
+	return &invitation, true
+}
+
+// findInvitationByToken carrega o convite do token do link; convites aceitos, revogados ou expirados
+// respondem 410.
+func findInvitationByToken(c *gin.Context, db *gorm.DB, token string) (*models.UserInvitation, bool) {
+	var invitation models.UserInvitation
+	if err := db.Where("token_hash = ?", hashInvitationToken(token)).Take(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load invitation: " + err.Error()})
+		return nil, false
+	}
+	if status := invitation.Status(time.Now()); status != models.InvitationPending {
+		c.JSON(http.StatusGone, gin.H{"error": "Invitation is no longer valid", "status": status})
+		return nil, false
+	}
+	return &invitation, true
+}
+
+// emailInUse indica se já existe um usuário (em qualquer organização) com o e-mail.
+func emailInUse(db *gorm.DB, email string) (bool, error) {
+	var count int64
+	err := db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error
+	return count > 0, err
+}
+
+// CreateInvitationHandler invites a person to the organization by email (users:manage). The email carries a
+// time-limited link with a single-use random token; earlier pending invitations for the same email are revoked.
+func CreateInvitationHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
+		return
+	}
+	var payload InvitationPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if payload.Role == "" {
+		payload.Role = models.RoleUser
+	}
+	if payload.Role == models.RoleAdmin && !rbac.Require(c, rbac.UsersAssignAdmin) {
+		return
+	}
+
+	db := database.GetDB()
+	var customRoleID *uuid.UUID
+	if payload.CustomRoleID != nil {
+		if !rbac.Require(c, rbac.RolesManage) {
+			return
+		}
+		roleID := uuid.MustParse(*payload.CustomRoleID) // Validado pelo binding
+		var count int64
+		if err := db.Model(&models.CustomRole{}).Where("id = ? AND organization_id = ?", roleID, targetOrgID).Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load custom role: " + err.Error()})
+			return
+		}
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Custom role not found in this organization"})
+			return
+		}
+		customRoleID = &roleID
+	}
+
+	email := strings.ToLower(strings.TrimSpace(payload.Email))
+	inUse, err := emailInUse(db, email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check existing users: " + err.Error()})
+		return
+	}
+	if inUse {
+		c.JSON(http.StatusConflict, gin.H{"error": "A user with this email already exists"})
+		return
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	expiresInDays := payload.ExpiresInDays
+	if expiresInDays == 0 {
+		expiresInDays = defaultInvitationExpiryDays
+	}
+	userID, _ := c.Get("userID")
+	invitation := models.UserInvitation{
+		OrganizationID: targetOrgID,
+		Email:          email,
+		Name:           strings.TrimSpace(payload.Name),
+		Role:           payload.Role,
+		CustomRoleID:   customRoleID,
+		TokenHash:      hashInvitationToken(token),
+		ExpiresAt:      time.Now().AddDate(0, 0, expiresInDays),
+		InvitedByID:    userID.(uuid.UUID),
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.UserInvitation{}).Scopes(models.PendingInvitations).
+			Where("organization_id = ? AND email = ?", targetOrgID, email).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(&invitation).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation: " + err.Error()})
+		return
+	}
+	sendInvitationEmail(c, db, invitation, token, expiresInDays)
+	c.JSON(http.StatusCreated, newInvitationView(invitation))
+}
+
+// ListInvitationsHandler lists the organization's invitations, newest first (users:manage). ?status= filters
+// by pending, accepted, revoked or expired.
+func ListInvitationsHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
+		return
+	}
+	query := database.GetDB().Where("organization_id = ?", targetOrgID)
+	now := time.Now()
+	switch models.InvitationStatus(c.Query("status")) {
+	case "":
+	case models.InvitationPending:
+		query = query.Scopes(models.PendingInvitations)
+	case models.InvitationAccepted:
+		query = query.Where("accepted_at IS NOT NULL")
+	case models.InvitationRevoked:
+		query = query.Where("accepted_at IS NULL AND revoked_at IS NOT NULL")
+	case models.InvitationExpired:
+		query = query.Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at <= ?", now)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status filter"})
+		return
+	}
+	var invitations []models.UserInvitation
+	if err := query.Order("created_at desc").Find(&invitations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list invitations: " + err.Error()})
+		return
+	}
+	views := make([]InvitationView, 0, len(invitations))
+	for _, invitation := range invitations {
+		views = append(views, newInvitationView(invitation))
+	}
+	c.JSON(http.StatusOK, views)
+}
+
+// ResendInvitationHandler emails a new link for a pending or expired invitation, invalidating the previous
+// one (users:manage).
+func ResendInvitationHandler(c *gin.Context) {
+	db := database.GetDB()
+	invitation, ok := findOrgInvitation(c, db)
+	if !ok {
+		return
+	}
+	var payload ResendInvitationPayload
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+			return
+		}
+	}
+	switch invitation.Status(time.Now()) {
+	case models.InvitationAccepted:
+		c.JSON(http.StatusConflict, gin.H{"error": "Invitation has already been accepted"})
+		return
+	case models.InvitationRevoked:
+		c.JSON(http.StatusConflict, gin.H{"error": "Invitation has been revoked"})
+		return
+	}
+	if invitation.Role == models.RoleAdmin && !rbac.Require(c, rbac.UsersAssignAdmin) {
+		return
+	}
+	inUse, err := emailInUse(db, invitation.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check existing users: " + err.Error()})
+		return
+	}
+	if inUse {
+		c.JSON(http.StatusConflict, gin.H{"error": "A user with this email already exists"})
+		return
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	expiresInDays := payload.ExpiresInDays
+	if expiresInDays == 0 {
+		expiresInDays = defaultInvitationExpiryDays
+	}
+	invitation.TokenHash = hashInvitationToken(token)
+	invitation.ExpiresAt = time.Now().AddDate(0, 0, expiresInDays)
+	if err := db.Model(invitation).Updates(map[string]interface{}{
+		"token_hash": invitation.TokenHash, "expires_at": invitation.ExpiresAt,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update invitation: " + err.Error()})
+		return
+	}
+	sendInvitationEmail(c, db, *invitation, token, expiresInDays)
+	c.JSON(http.StatusOK, newInvitationView(*invitation))
+}
+
+// RevokeInvitationHandler revokes an invitation that was not accepted yet, invalidating its link (users:manage).
+func RevokeInvitationHandler(c *gin.Context) {
+	db := database.GetDB()
+	invitation, ok := findOrgInvitation(c, db)
+	if !ok {
+		return
+	}
+	if invitation.AcceptedAt != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Invitation has already been accepted"})
+		return
+	}
+	if invitation.RevokedAt == nil {
+		now := time.Now()
+		invitation.RevokedAt = &now
+		if err := db.Model(invitation).Update("revoked_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke invitation: " + err.Error()})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, newInvitationView(*invitation))
+}
+
+// GetPublicInvitationHandler serves what the acceptance page shows for the link's token (sem autenticação):
+// organization, invited email and role, and how the person can join (password and/or the organization's SSO).
+func GetPublicInvitationHandler(c *gin.Context) {
+	db := database.GetDB()
+	invitation, ok := findInvitationByToken(c, db, c.Param("token"))
+	if !ok {
+		return
+	}
+	var organization models.Organization
+	if err := db.Select("id", "name").Take(&organization, "id = ?", invitation.OrganizationID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load organization: " + err.Error()})
+		return
+	}
+	ssoProviders, err := organizationSSOProviders(db, invitation.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load identity providers: " + err.Error()})
+		return
+	}
+	invitee := models.User{OrganizationID: uuid.NullUUID{UUID: invitation.OrganizationID, Valid: true}, Role: invitation.Role}
+	passwordDisabled, err := passwordLoginDisabled(db, &invitee)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load login page: " + err.Error()})
+		return
+	}
+	policy, err := passwordpolicy.Load(db, invitation.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load password policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, PublicInvitationResponse{
+		OrganizationName: organization.Name,
+		Email:            invitation.Email,
+		Name:             invitation.Name,
+		Role:             invitation.Role,
+		ExpiresAt:        invitation.ExpiresAt,
+		PasswordAllowed:  !passwordDisabled,
+		PasswordPolicy:   newPasswordPolicyResponse(policy),
+		SSO:              ssoProviders,
+	})
+}
+
+// AcceptInvitationHandler creates the invited user with a password in the invitation's organization and role,
+// and signs them in (sem autenticação). Invitees of organizations that require SSO sign in through the
+// identity provider instead, which accepts the invitation on the first login.
+func AcceptInvitationHandler(c *gin.Context) {
+	var payload AcceptInvitationPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	invitation, ok := findInvitationByToken(c, db, payload.Token)
+	if !ok {
+		return
+	}
+
+	user := models.User{
+		OrganizationID: uuid.NullUUID{UUID: invitation.OrganizationID, Valid: true},
+		Name:           strings.TrimSpace(payload.Name),
+		Email:          invitation.Email,
+		IsActive:       true,
+	}
+	invitation.Apply(&user)
+	if user.Name == "" {
+		user.Name = invitation.Email
+	}
+	passwordDisabled, err := passwordLoginDisabled(db, &user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load login page: " + err.Error()})
+		return
+	}
+	if passwordDisabled {
+		c.JSON(http.StatusForbidden, gin.H{"error": "This organization requires single sign-on; accept the invitation by signing in with SSO"})
+		return
+	}
+	inUse, err := emailInUse(db, user.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check existing users: " + err.Error()})
+		return
+	}
+	if inUse {
+		c.JSON(http.StatusConflict, gin.H{"error": "A user with this email already exists"})
+		return
+	}
+	if !enforcePasswordPolicy(c, db, invitation.OrganizationID, payload.Password) {
+		return
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		return
+	}
+	passwordChangedAt := time.Now()
+	user.PasswordHash = string(hashedPassword)
+	user.PasswordChangedAt = &passwordChangedAt
+
+	errInvitationUsed := errors.New("invitation used")
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		accepted, err := invitation.MarkAccepted(tx, user.ID)
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			return errInvitationUsed
+		}
+		return nil
+	})
+	if errors.Is(err, errInvitationUsed) {
+		c.JSON(http.StatusGone, gin.H{"error": "Invitation is no longer valid"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user: " + err.Error()})
+		return
+	}
+	phxlog.L.Info("User invitation accepted",
+		zap.String("invitationID", invitation.ID.String()), zap.String("userID", user.ID.String()))
+	respondWithNewSession(c, db, &user)
+}
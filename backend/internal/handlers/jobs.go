@@ -0,0 +1,16 @@
+package handlers
+
+import "phoenixgrc/backend/internal/jobs"
+
+// Tipos de job executados por handlers deste pacote.
+const (
+	JobAuditTrailExport   = "audit_trail.export"  // Geração de uma exportação assinada da trilha de auditoria
+	JobOrganizationExport = "organization.export" // Geração da exportação completa dos dados da organização
+)
+
+// RegisterJobHandlers registra os handlers dos jobs deste pacote. Deve ser chamado pelos processos que
+// executam a fila de jobs.
+func RegisterJobHandlers() {
+	jobs.Register(JobAuditTrailExport, runAuditTrailExportJob)
+	jobs.Register(JobOrganizationExport, runOrganizationExportJob)
+}
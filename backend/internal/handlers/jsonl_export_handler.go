@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/jsonlexport"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ExportAssessmentsJSONLHandler exports the organization's assessments and evidence metadata of a framework as JSON Lines.
+func ExportAssessmentsJSONLHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, _ := c.Get("organizationID")
+	if tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization's assessments"})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	var organization models.Organization
+	if err := db.Select("id", "name").First(&organization, "id = ?", targetOrgID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organization: " + err.Error()})
+		return
+	}
+
+	controls, err := loadOrderedFrameworkControls(db, targetOrgID, framework.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list controls for framework: " + err.Error()})
+		return
+	}
+	controlIDs := make([]uuid.UUID, len(controls))
+	for i, ctrl := range controls {
+		controlIDs[i] = ctrl.ID
+	}
+	var assessments []models.AuditAssessment
+	var requests []models.EvidenceRequest
+	if len(controlIDs) > 0 {
+		if err := db.Scopes(models.OrgWideAssessments).Where("organization_id = ? AND audit_control_id IN ?", targetOrgID, controlIDs).Find(&assessments).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assessments: " + err.Error()})
+			return
+		}
+		if err := db.Preload("Submissions", func(tx *gorm.DB) *gorm.DB { return tx.Order("created_at") }).
+			Where("organization_id = ? AND audit_control_id IN ?", targetOrgID, controlIDs).
+			Order("created_at").Find(&requests).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list evidence requests: " + err.Error()})
+			return
+		}
+	}
+	assessmentMap := make(map[uuid.UUID]*models.AuditAssessment, len(assessments))
+	for i := range assessments {
+		assessmentMap[assessments[i].AuditControlID] = &assessments[i]
+	}
+	requestMap := make(map[uuid.UUID][]models.EvidenceRequest)
+	for _, r := range requests {
+		requestMap[*r.AuditControlID] = append(requestMap[*r.AuditControlID], r)
+	}
+
+	c.Header("Content-Type", jsonlexport.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"assessments_%s.jsonl\"", framework.ID))
+	c.Status(http.StatusOK)
+	w := jsonlexport.NewWriter(c.Writer)
+	err = w.WriteHeader(jsonlexport.ExportRecord{
+		GeneratedAt:      time.Now().UTC(),
+		OrganizationID:   organization.ID,
+		OrganizationName: organization.Name,
+		FrameworkID:      framework.ID,
+		FrameworkName:    framework.Name,
+		FrameworkVersion: framework.Version,
+		TotalControls:    len(controls),
+	})
+	for _, ctrl := range controls {
+		if err != nil {
+			break
+		}
+		err = w.WriteControl(ctrl, assessmentMap[ctrl.ID], requestMap[ctrl.ID])
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		// O status já foi enviado: o cliente recebe o arquivo truncado
+		phxlog.L.Error("Failed to write JSONL export",
+			zap.String("organizationID", targetOrgID.String()),
+			zap.String("frameworkID", framework.ID.String()),
+			zap.Error(err))
+	}
+}
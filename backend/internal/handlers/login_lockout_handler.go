@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/internal/ratelimit"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Limites das rotas de login e de segundo fator (ver router). O bloqueio por conta (auth.RecordLoginFailure)
+// não alcança quem testa uma senha em muitas contas; o limite por IP sim. O limite por conta (email ou
+// user_id do payload) freia tentativas distribuídas entre muitos IPs antes que elas bloqueiem a conta.
+var (
+	LoginRateLimiter            = ratelimit.New("login", 30, 15*time.Minute)
+	LoginAccountRateLimiter     = ratelimit.New("login_account", 10, 15*time.Minute)
+	MFAVerifyRateLimiter        = ratelimit.New("mfa_verify", 30, 15*time.Minute)
+	MFAVerifyAccountRateLimiter = ratelimit.New("mfa_verify_account", 10, 15*time.Minute)
+)
+
+const accountLockedMessage = "Account is temporarily locked due to too many failed login attempts"
+
+// respondIfLoginLocked responde 423 se o login do usuário estiver bloqueado. A verificação vem antes da
+// senha ou do código: durante o bloqueio, nem a credencial correta entra.
+func respondIfLoginLocked(c *gin.Context, user *models.User) bool {
+	if !user.IsLocked(time.Now()) {
+		return false
+	}
+	c.JSON(http.StatusLocked, gin.H{"error": accountLockedMessage, "locked_until": user.LockedUntil})
+	return true
+}
+
+// handleLoginFailure conta a falha de senha ou de segundo fator e responde 401 com a mensagem informada. Se
+// a falha bloqueou a conta, avisa o usuário por e-mail, registra o bloqueio na trilha de auditoria e
+// responde 423.
+func handleLoginFailure(c *gin.Context, db *gorm.DB, user *models.User, message string) {
+	log := phxlog.L.Named("LoginLockout")
+	policy := auth.CurrentLockoutPolicy()
+	failure, err := auth.RecordLoginFailure(db, user.ID, policy, time.Now())
+	if err != nil {
+		// A falha de contagem não libera o login: a credencial já foi recusada
+		log.Error("Failed to record login failure", zap.String("userID", user.ID.String()), zap.Error(err))
+	}
+	if failure.LockedUntil == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": message})
+		return
+	}
+
+	log.Warn("Account locked after failed login attempts",
+		zap.String("userID", user.ID.String()), zap.String("ip", c.ClientIP()), zap.Int("threshold", policy.Threshold))
+	if user.OrganizationID.Valid {
+		if _, err := recordAuditTrail(c, db, user.OrganizationID.UUID, models.AuditTrailUserLoginLocked, "user", &user.ID,
+			fmt.Sprintf("Login de %s bloqueado após %d tentativas sem sucesso", user.Email, policy.Threshold),
+			gin.H{"user_email": user.Email, "ip": c.ClientIP(), "attempts": policy.Threshold, "locked_until": failure.LockedUntil}); err != nil {
+			log.Error("Failed to record account lock in audit trail", zap.String("userID", user.ID.String()), zap.Error(err))
+		}
+	}
+	data := templates.AccountLocked{
+		LockedMinutes: int(policy.Duration.Minutes()),
+		IPAddress:     c.ClientIP(),
+		Link:          notifications.FrontendLink("/auth/forgot-password"),
+	}
+	if err := notifications.EmailWithTemplate(c.Request.Context(), user.OrganizationID.UUID, user.Email, user.Name, templates.NameAccountLocked, data); err != nil {
+		log.Error("Failed to send account locked email", zap.String("userID", user.ID.String()), zap.Error(err))
+	}
+	c.JSON(http.StatusLocked, gin.H{"error": accountLockedMessage, "locked_until": failure.LockedUntil})
+}
+
+// loginSucceeded zera as falhas de login depois que o usuário concluiu o login (senha e, se houver, segundo fator).
+func loginSucceeded(db *gorm.DB, user *models.User) {
+	if err := auth.ResetLoginFailures(db, user); err != nil {
+		phxlog.L.Error("Failed to reset login failures", zap.String("userID", user.ID.String()), zap.Error(err))
+	}
+}
+
+// UnlockOrganizationUserHandler lifts a temporary login lock and clears the user's failed attempts (users:manage).
+func UnlockOrganizationUserHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	targetUserID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
+		return
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.Where("id = ? AND organization_id = ?", targetUserID, targetOrgID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found in this organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user: " + err.Error()})
+		return
+	}
+	wasLocked := user.IsLocked(time.Now())
+	previousAttempts := user.FailedLoginAttempts
+	if !wasLocked && previousAttempts == 0 {
+		c.JSON(http.StatusOK, newUserResponse(user))
+		return
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := auth.ResetLoginFailures(tx, &user); err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, targetOrgID, models.AuditTrailUserUnlocked, "user", &user.ID,
+			fmt.Sprintf("Login de %s desbloqueado", user.Email),
+			gin.H{"user_email": user.Email, "was_locked": wasLocked, "failed_attempts": previousAttempts})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlock user: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newUserResponse(user))
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var loginDomainRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// loginPageLogoURLMinutes é a validade da URL assinada do logo servida na tela de login.
+const loginPageLogoURLMinutes = 60
+
+// LoginPageSettingsPayload defines the structure for configuring the organization's login page.
+type LoginPageSettingsPayload struct {
+	Slug                 string `json:"slug" binding:"required"`
+	CustomDomain         string `json:"custom_domain"`
+	WelcomeMessage       string `json:"welcome_message" binding:"max=2000"`
+	PasswordLoginEnabled *bool  `json:"password_login_enabled"` // default true
+	TermsOfServiceURL    string `json:"terms_of_service_url" binding:"omitempty,url,max=500"`
+	PrivacyPolicyURL     string `json:"privacy_policy_url" binding:"omitempty,url,max=500"`
+	LegalNotice          string `json:"legal_notice" binding:"max=5000"`
+}
+
+// LoginPageSSOProvider is an organization identity provider offered on the login page.
+type LoginPageSSOProvider struct {
+	ID       uuid.UUID                   `json:"id"`
+	Type     models.IdentityProviderType `json:"type"`
+	Name     string                      `json:"name"`
+	LoginURL string                      `json:"login_url"`
+}
+
+// PublicLoginPageResponse is the unauthenticated payload used by the frontend to render a tenant-aware login screen.
+type PublicLoginPageResponse struct {
+	Slug         string `json:"slug"`
+	Organization struct {
+		Name           string `json:"name"`
+		LogoURL        string `json:"logo_url,omitempty"`
+		PrimaryColor   string `json:"primary_color,omitempty"`
+		SecondaryColor string `json:"secondary_color,omitempty"`
+	} `json:"organization"`
+	WelcomeMessage string `json:"welcome_message,omitempty"`
+	AuthMethods    struct {
+		Password bool                   `json:"password"`
+		SSO      []LoginPageSSOProvider `json:"sso"`
+		Social   []GlobalIdPResponse    `json:"social"`
+	} `json:"auth_methods"`
+	TwoFactor struct {
+		Methods []string `json:"methods"`
+		// Hoje o segundo fator é habilitado por usuário; a organização ainda não o torna obrigatório
+		Enforced bool `json:"enforced"`
+	} `json:"two_factor"`
+	Legal struct {
+		TermsOfServiceURL string `json:"terms_of_service_url,omitempty"`
+		PrivacyPolicyURL  string `json:"privacy_policy_url,omitempty"`
+		Notice            string `json:"notice,omitempty"`
+	} `json:"legal"`
+}
+
+// normalizeLoginDomain reduz o host informado (com porta opcional) ao domínio em minúsculas.
+func normalizeLoginDomain(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+// passwordLoginDisabled indica se a organização desabilitou o login por senha para o usuário. Admins
+// mantêm o acesso por senha como contingência caso o SSO fique indisponível.
+func passwordLoginDisabled(db *gorm.DB, user *models.User) (bool, error) {
+	if !user.OrganizationID.Valid || user.Role == models.RoleAdmin {
+		return false, nil
+	}
+	var count int64
+	err := db.Model(&models.OrganizationLoginPage{}).
+		Where("organization_id = ? AND disable_password_login = ?", user.OrganizationID.UUID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// loginPageLogoURL retorna a URL do logo para exibição pública: URLs externas são mantidas e objetos do
+// armazenamento recebem uma URL assinada de curta duração.
+func loginPageLogoURL(ctx context.Context, logo string) string {
+	if logo == "" || strings.HasPrefix(logo, "http://") || strings.HasPrefix(logo, "https://") {
+		return logo
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		return ""
+	}
+	signedURL, err := filestorage.DefaultFileStorageProvider.GetSignedURL(ctx, logo, loginPageLogoURLMinutes)
+	if err != nil {
+		phxlog.L.Warn("Failed to sign organization logo for login page", zap.String("objectName", logo), zap.Error(err))
+		return ""
+	}
+	return signedURL
+}
+
+// GetLoginPageSettingsHandler returns the organization's login page configuration.
+func GetLoginPageSettingsHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.BrandingManage) {
+		return
+	}
+
+	var page models.OrganizationLoginPage
+	if err := database.GetDB().Where("organization_id = ?", targetOrgID).First(&page).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Login page is not configured for this organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch login page settings: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// UpsertLoginPageSettingsHandler creates or replaces the organization's login page configuration.
+func UpsertLoginPageSettingsHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.BrandingManage) {
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	var payload LoginPageSettingsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	// Mesmo formato dos slugs do trust center
+	payload.Slug = strings.ToLower(strings.TrimSpace(payload.Slug))
+	if !trustCenterSlugRegex.MatchString(payload.Slug) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid slug: use 3-63 lowercase letters, digits or hyphens"})
+		return
+	}
+	var customDomain *string
+	if domain := normalizeLoginDomain(payload.CustomDomain); domain != "" {
+		if len(domain) > 255 || !loginDomainRegex.MatchString(domain) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom_domain: use a host name such as grc.example.com"})
+			return
+		}
+		customDomain = &domain
+	}
+
+	db := database.GetDB()
+
+	var slugOwnerCount int64
+	db.Model(&models.OrganizationLoginPage{}).Where("slug = ? AND organization_id <> ?", payload.Slug, targetOrgID).Count(&slugOwnerCount)
+	if slugOwnerCount > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Slug is already in use by another organization"})
+		return
+	}
+	if customDomain != nil {
+		var domainOwnerCount int64
+		db.Model(&models.OrganizationLoginPage{}).Where("custom_domain = ? AND organization_id <> ?", *customDomain, targetOrgID).Count(&domainOwnerCount)
+		if domainOwnerCount > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "Custom domain is already in use by another organization"})
+			return
+		}
+	}
+
+	var page models.OrganizationLoginPage
+	err = db.Where("organization_id = ?", targetOrgID).First(&page).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch login page settings: " + err.Error()})
+		return
+	}
+	isNew := err == gorm.ErrRecordNotFound
+
+	if payload.PasswordLoginEnabled != nil {
+		page.DisablePasswordLogin = !*payload.PasswordLoginEnabled
+	}
+	if page.DisablePasswordLogin {
+		// Sem SSO ativo, os usuários não teriam como entrar
+		var activeIdPs int64
+		db.Model(&models.IdentityProvider{}).Where("organization_id = ? AND is_active = ?", targetOrgID, true).Count(&activeIdPs)
+		if activeIdPs == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Password login can only be disabled when the organization has an active identity provider"})
+			return
+		}
+	}
+
+	updatedByID := userID.(uuid.UUID)
+	page.OrganizationID = targetOrgID
+	page.Slug = payload.Slug
+	page.CustomDomain = customDomain
+	page.WelcomeMessage = payload.WelcomeMessage
+	page.TermsOfServiceURL = payload.TermsOfServiceURL
+	page.PrivacyPolicyURL = payload.PrivacyPolicyURL
+	page.LegalNotice = payload.LegalNotice
+	page.UpdatedByID = &updatedByID
+
+	if isNew {
+		err = db.Create(&page).Error
+	} else {
+		err = db.Save(&page).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save login page settings: " + err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if isNew {
+		status = http.StatusCreated
+	}
+	c.JSON(status, page)
+}
+
+// organizationSSOProviders lista os provedores de identidade ativos da organização, com a URL de login de cada um.
+func organizationSSOProviders(db *gorm.DB, orgID uuid.UUID) ([]LoginPageSSOProvider, error) {
+	var idps []models.IdentityProvider
+	if err := db.Select("id", "provider_type", "name").
+		Where("organization_id = ? AND is_active = ?", orgID, true).
+		Order("name asc").Find(&idps).Error; err != nil {
+		return nil, err
+	}
+	providers := make([]LoginPageSSOProvider, 0, len(idps))
+	baseURL := loginBaseURL()
+	for _, idp := range idps {
+		var loginPath string
+		switch idp.ProviderType {
+		case models.IDPTypeSAML:
+			loginPath = "/auth/saml/%s/login"
+		case models.IDPTypeOAuth2Google:
+			loginPath = "/auth/oauth2/google/%s/login"
+		case models.IDPTypeOAuth2Github:
+			loginPath = "/auth/oauth2/github/%s/login"
+		default:
+			continue
+		}
+		providers = append(providers, LoginPageSSOProvider{
+			ID:       idp.ID,
+			Type:     idp.ProviderType,
+			Name:     idp.Name,
+			LoginURL: baseURL + fmt.Sprintf(loginPath, idp.ID),
+		})
+	}
+	return providers, nil
+}
+
+// GetPublicLoginPageHandler serves the login page metadata of an organization, looked up by ?slug= or
+// ?domain= (sem autenticação): branding, enabled login methods, 2FA methods and legal notices.
+func GetPublicLoginPageHandler(c *gin.Context) {
+	slug := strings.ToLower(strings.TrimSpace(c.Query("slug")))
+	domain := normalizeLoginDomain(c.Query("domain"))
+	if (slug == "") == (domain == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide either slug or domain"})
+		return
+	}
+
+	db := database.GetDB()
+	query := db.Model(&models.OrganizationLoginPage{})
+	if slug != "" {
+		query = query.Where("slug = ?", slug)
+	} else {
+		query = query.Where("custom_domain = ?", domain)
+	}
+	var page models.OrganizationLoginPage
+	if err := query.First(&page).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Login page not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load login page"})
+		return
+	}
+
+	var organization models.Organization
+	if err := db.Select("id", "name", "logo_url", "primary_color", "secondary_color").First(&organization, "id = ?", page.OrganizationID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load login page"})
+		return
+	}
+	ssoProviders, err := organizationSSOProviders(db, page.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load login page"})
+		return
+	}
+
+	var resp PublicLoginPageResponse
+	resp.Slug = page.Slug
+	resp.Organization.Name = organization.Name
+	resp.Organization.LogoURL = loginPageLogoURL(c.Request.Context(), organization.LogoURL)
+	resp.Organization.PrimaryColor = organization.PrimaryColor
+	resp.Organization.SecondaryColor = organization.SecondaryColor
+	resp.WelcomeMessage = page.WelcomeMessage
+
+	resp.AuthMethods.Password = !page.DisablePasswordLogin
+	resp.AuthMethods.SSO = ssoProviders
+	resp.AuthMethods.Social = globalSocialIdentityProviders()
+	if resp.AuthMethods.Social == nil {
+		resp.AuthMethods.Social = []GlobalIdPResponse{}
+	}
+
+	resp.TwoFactor.Methods = []string{"totp", "backup_code"}
+	resp.Legal.TermsOfServiceURL = page.TermsOfServiceURL
+	resp.Legal.PrivacyPolicyURL = page.PrivacyPolicyURL
+	resp.Legal.Notice = page.LegalNotice
+
+	// O logo é uma URL assinada; o cache não pode ultrapassar a validade dela
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, resp)
+}
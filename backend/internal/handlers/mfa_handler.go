@@ -228,6 +228,24 @@ func DisableTOTPHandler(c *gin.Context) {
 		return
 	}
 
+	// TOTP não pode ser o último fator de quem a política de MFA da organização obriga a ter um
+	policy, err := userMFAPolicy(db, &user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load MFA policy: " + err.Error()})
+		return
+	}
+	if policy.RequiresMFA(user.Role) {
+		hasPasskey, err := userHasPasskey(db, user.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load passkeys: " + err.Error()})
+			return
+		}
+		if !hasPasskey {
+			c.JSON(http.StatusConflict, gin.H{"error": lastRequiredFactorMessage})
+			return
+		}
+	}
+
 	user.IsTOTPEnabled = false
 	user.TOTPSecret = "" // Clear the secret
 	user.TOTPBackupCodes = "" // Clear backup codes as well
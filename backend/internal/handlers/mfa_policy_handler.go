@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// MFAPolicyPayload defines the organization's second-factor requirements.
+type MFAPolicyPayload struct {
+	RequireMFAForAll         bool              `json:"require_mfa_for_all"`
+	RequireMFARoles          []models.UserRole `json:"require_mfa_roles" binding:"omitempty,dive,oneof=system_admin admin manager user"`
+	RequireWebAuthnForAdmins bool              `json:"require_webauthn_for_admins"`
+}
+
+// MFAPolicyResponse is the policy shown to the frontend; is_default is true while the organization has not configured one.
+type MFAPolicyResponse struct {
+	models.MFAPolicy
+	RequiredRoles []models.UserRole `json:"require_mfa_roles"`
+	IsDefault     bool              `json:"is_default"`
+	// Filled in when the policy is saved: users who will only get an enrollment-restricted session at their next sign-in
+	PendingEnrollment []MFAPendingUser `json:"pending_enrollment,omitempty"`
+	SessionsRevoked   int64            `json:"sessions_revoked,omitempty"`
+}
+
+// MFAPendingUser identifies an active user who still lacks the second factor the policy requires.
+type MFAPendingUser struct {
+	ID    uuid.UUID       `json:"id"`
+	Name  string          `json:"name"`
+	Email string          `json:"email"`
+	Role  models.UserRole `json:"role"`
+}
+
+func newMFAPolicyResponse(policy models.MFAPolicy) MFAPolicyResponse {
+	return MFAPolicyResponse{MFAPolicy: policy, RequiredRoles: policy.MFARoles(), IsDefault: policy.CreatedAt.IsZero()}
+}
+
+// userMFAPolicy carrega a política de MFA da organização do usuário (a política vazia se ele não tiver organização).
+func userMFAPolicy(db *gorm.DB, user *models.User) (models.MFAPolicy, error) {
+	if !user.OrganizationID.Valid {
+		return models.MFAPolicy{}, nil
+	}
+	return models.LoadMFAPolicy(db, user.OrganizationID.UUID)
+}
+
+func userHasPasskey(db *gorm.DB, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&models.WebAuthnCredential{}).Where("user_id = ?", userID).Count(&count).Error
+	return count > 0, err
+}
+
+// mfaEnrollmentRequired indica se falta ao usuário o segundo fator exigido pela política de MFA da organização.
+func mfaEnrollmentRequired(db *gorm.DB, user *models.User) (bool, error) {
+	policy, err := userMFAPolicy(db, user)
+	if err != nil || !policy.RequiresMFA(user.Role) {
+		return false, err
+	}
+	hasPasskey, err := userHasPasskey(db, user.ID)
+	if err != nil {
+		return false, err
+	}
+	return policy.EnrollmentRequired(user.Role, user.IsTOTPEnabled, hasPasskey), nil
+}
+
+// usersPendingMFAEnrollment lista os usuários ativos da organização que ainda não têm o segundo fator exigido.
+func usersPendingMFAEnrollment(db *gorm.DB, policy models.MFAPolicy) ([]MFAPendingUser, error) {
+	var users []models.User
+	err := db.Select("id, name, email, role, is_totp_enabled").
+		Where("organization_id = ? AND is_active = ?", policy.OrganizationID, true).
+		Where("NOT EXISTS (SELECT 1 FROM web_authn_credentials w WHERE w.user_id = users.id)").
+		Order("email ASC").Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	pending := []MFAPendingUser{}
+	for _, user := range users {
+		if policy.EnrollmentRequired(user.Role, user.IsTOTPEnabled, false) {
+			pending = append(pending, MFAPendingUser{ID: user.ID, Name: user.Name, Email: user.Email, Role: user.Role})
+		}
+	}
+	return pending, nil
+}
+
+// GetMFAPolicyHandler returns the organization's MFA policy. Any organization member.
+func GetMFAPolicyHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, _ := c.Get("organizationID")
+	if orgID, ok := tokenOrgID.(uuid.UUID); !ok || orgID != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied: You do not belong to this organization"})
+		return
+	}
+
+	policy, err := models.LoadMFAPolicy(database.GetDB(), targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch MFA policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newMFAPolicyResponse(policy))
+}
+
+// UpsertMFAPolicyHandler creates or replaces the organization's MFA policy (security:manage). Users who lack a
+// required factor get an enrollment-restricted session at their next sign-in; when the requirements change, their
+// open sessions (except the caller's current one) are revoked so the policy applies right away.
+func UpsertMFAPolicyHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.SecurityManage) {
+		return
+	}
+
+	var payload MFAPolicyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	userID, _ := c.Get("userID")
+	updatedByID := userID.(uuid.UUID)
+	settings := models.MFAPolicy{
+		OrganizationID:           targetOrgID,
+		RequireMFAForAll:         payload.RequireMFAForAll,
+		RequireWebAuthnForAdmins: payload.RequireWebAuthnForAdmins,
+		UpdatedByID:              &updatedByID,
+	}
+	roles := []models.UserRole{}
+	seen := map[models.UserRole]bool{}
+	for _, role := range payload.RequireMFARoles {
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+	if err := settings.SetMFARoles(roles); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save MFA policy: " + err.Error()})
+		return
+	}
+
+	db := database.GetDB()
+	var previous models.MFAPolicy
+	err = db.Transaction(func(tx *gorm.DB) error {
+		previous, err = models.LoadMFAPolicy(tx, targetOrgID)
+		if err != nil {
+			return err
+		}
+		if previous.CreatedAt.IsZero() {
+			err = tx.Create(&settings).Error
+		} else {
+			settings.CreatedAt = previous.CreatedAt
+			err = tx.Save(&settings).Error
+		}
+		if err != nil {
+			return err
+		}
+		_, err = recordAuditTrail(c, tx, targetOrgID, models.AuditTrailMFAPolicyUpdated, "mfa_policy", &targetOrgID,
+			fmt.Sprintf("MFA policy set (all users: %t, roles: %v, passkey required for administrators: %t)",
+				settings.RequireMFAForAll, roles, settings.RequireWebAuthnForAdmins),
+			gin.H{"previous": newMFAPolicyResponse(previous), "current": newMFAPolicyResponse(settings)})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save MFA policy: " + err.Error()})
+		return
+	}
+
+	response := newMFAPolicyResponse(settings)
+	response.PendingEnrollment, err = usersPendingMFAEnrollment(db, settings)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list users pending MFA enrollment: " + err.Error()})
+		return
+	}
+	changed := previous.RequireMFAForAll != settings.RequireMFAForAll ||
+		previous.RequireWebAuthnForAdmins != settings.RequireWebAuthnForAdmins ||
+		fmt.Sprint(previous.MFARoles()) != fmt.Sprint(roles)
+	if changed {
+		var except *uuid.UUID
+		if sessionID, ok := currentSessionID(c); ok {
+			except = &sessionID
+		}
+		for _, pending := range response.PendingEnrollment {
+			revoked, err := auth.RevokeUserSessions(db, pending.ID, models.SessionRevokedMFARequired, except)
+			if err != nil {
+				phxlog.L.Error("Failed to revoke sessions of user pending MFA enrollment",
+					zap.String("userID", pending.ID.String()), zap.Error(err))
+				continue
+			}
+			response.SessionsRevoked += revoked
+		}
+	}
+
+	status := http.StatusOK
+	if previous.CreatedAt.IsZero() {
+		status = http.StatusCreated
+	}
+	c.JSON(status, response)
+}
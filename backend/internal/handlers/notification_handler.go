@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxNotificationIDsPerRequest limita os IDs (e os tipos) informados de uma vez nas ações em lote.
+const maxNotificationIDsPerRequest = 500
+
+// maxNotificationSnooze é o maior adiamento aceito por SnoozeNotificationsHandler.
+const maxNotificationSnooze = 90 * 24 * time.Hour
+
+// NotificationListResponse é a página de notificações com o total de não lidas do usuário.
+type NotificationListResponse struct {
+	PaginatedResponse
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// NotificationReadPayload marca uma notificação como lida (read=true) ou não lida (read=false).
+type NotificationReadPayload struct {
+	Read *bool `json:"read" binding:"required"`
+}
+
+// MarkNotificationsPayload marca várias notificações do usuário; sem IDs, todas.
+type MarkNotificationsPayload struct {
+	Read *bool    `json:"read" binding:"required"`
+	IDs  []string `json:"ids" binding:"omitempty,dive,uuid"`
+}
+
+// NotificationFilterPayload seleciona as notificações do usuário nas ações em lote, por IDs e/ou por tipo
+// (ex: ["risk_acceptance_requested"]).
+type NotificationFilterPayload struct {
+	IDs   []string `json:"ids" binding:"omitempty,dive,uuid"`
+	Types []string `json:"types" binding:"omitempty,dive,max=50"`
+}
+
+// MarkAllNotificationsReadPayload marca como lidas todas as notificações visíveis, opcionalmente só as dos tipos.
+type MarkAllNotificationsReadPayload struct {
+	Types []string `json:"types" binding:"omitempty,dive,max=50"`
+}
+
+// SnoozeNotificationsPayload adia as notificações não lidas selecionadas (sem filtros, todas) até Until.
+type SnoozeNotificationsPayload struct {
+	NotificationFilterPayload
+	Until time.Time `json:"until" binding:"required"`
+}
+
+// DismissNotificationsPayload descarta as notificações selecionadas. Sem IDs nem tipos, All precisa confirmar o
+// descarte de todas.
+type DismissNotificationsPayload struct {
+	NotificationFilterPayload
+	All bool `json:"all"`
+}
+
+// userNotificationsQuery restringe a consulta às notificações do usuário autenticado que não foram descartadas.
+func userNotificationsQuery(c *gin.Context, db *gorm.DB) *gorm.DB {
+	userID, _ := c.Get("userID")
+	return db.Model(&models.Notification{}).Where("user_id = ? AND dismissed_at IS NULL", userID)
+}
+
+// notSnoozed exclui as notificações adiadas até uma data futura.
+func notSnoozed(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("snoozed_until IS NULL OR snoozed_until <= ?", now)
+}
+
+// applyNotificationFilter aplica os IDs e os tipos informados; filtros vazios não restringem.
+func applyNotificationFilter(query *gorm.DB, ids, types []string) *gorm.DB {
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+	return query
+}
+
+// bindNotificationBatch lê o payload de uma ação em lote (corpo opcional quando optional) e limita a
+// quantidade de IDs e de tipos informados.
+func bindNotificationBatch(c *gin.Context, payload interface{}, optional bool, ids, types *[]string) bool {
+	if !optional || c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+			return false
+		}
+	}
+	if len(*ids) > maxNotificationIDsPerRequest || len(*types) > maxNotificationIDsPerRequest {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many notification IDs in one request"})
+		return false
+	}
+	return true
+}
+
+// countUnreadNotifications conta as não lidas visíveis (as adiadas só voltam a contar ao fim do adiamento).
+func countUnreadNotifications(c *gin.Context, db *gorm.DB) (int64, error) {
+	var unread int64
+	err := notSnoozed(userNotificationsQuery(c, db), time.Now()).Where("read_at IS NULL").Count(&unread).Error
+	return unread, err
+}
+
+// respondNotificationBatch responde uma ação em lote com a quantidade alterada e o novo total de não lidas.
+func respondNotificationBatch(c *gin.Context, db *gorm.DB, result *gorm.DB) {
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notifications: " + result.Error.Error()})
+		return
+	}
+	unread, err := countUnreadNotifications(c, db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": result.RowsAffected, "unread_count": unread})
+}
+
+// ListMyNotificationsHandler lists the authenticated user's in-app notifications, newest first, with the number
+// of unread ones. Snoozed notifications are hidden until the snooze ends (snoozed=true lists only them).
+// Optional filters: unread=true, type.
+func ListMyNotificationsHandler(c *gin.Context) {
+	db := database.GetDB()
+	query := userNotificationsQuery(c, db)
+	now := time.Now()
+	snoozedOnly := false
+	if raw := c.Query("snoozed"); raw != "" {
+		var err error
+		if snoozedOnly, err = strconv.ParseBool(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid snoozed filter: use true or false"})
+			return
+		}
+	}
+	if snoozedOnly {
+		query = query.Where("snoozed_until > ?", now)
+	} else {
+		query = notSnoozed(query, now)
+	}
+	if raw := c.Query("unread"); raw != "" {
+		unreadOnly, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unread filter: use true or false"})
+			return
+		}
+		if unreadOnly {
+			query = query.Where("read_at IS NULL")
+		}
+	}
+	if kind := c.Query("type"); kind != "" {
+		query = query.Where("type = ?", kind)
+	}
+
+	page, pageSize := GetPaginationParams(c)
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications: " + err.Error()})
+		return
+	}
+	notifications := []models.Notification{}
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at desc").Find(&notifications).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list notifications: " + err.Error()})
+		return
+	}
+	unread, err := countUnreadNotifications(c, db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications: " + err.Error()})
+		return
+	}
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, NotificationListResponse{
+		PaginatedResponse: PaginatedResponse{Items: notifications, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize},
+		UnreadCount:       unread,
+	})
+}
+
+// GetMyUnreadNotificationCountHandler returns the number of unread in-app notifications (for the badge).
+func GetMyUnreadNotificationCountHandler(c *gin.Context) {
+	unread, err := countUnreadNotifications(c, database.GetDB())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unread_count": unread})
+}
+
+// MarkNotificationHandler marks one of the user's notifications as read or unread.
+func MarkNotificationHandler(c *gin.Context) {
+	notificationID, err := uuid.Parse(c.Param("notificationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID format"})
+		return
+	}
+	var payload NotificationReadPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	var notification models.Notification
+	if err := userNotificationsQuery(c, db).Where("id = ?", notificationID).Take(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load notification: " + err.Error()})
+		return
+	}
+	// Marcar como lida de novo mantém a data da primeira leitura
+	if *payload.Read && notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+	} else if !*payload.Read {
+		notification.ReadAt = nil
+	}
+	if err := db.Model(&notification).Update("read_at", notification.ReadAt).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, notification)
+}
+
+// MarkNotificationsHandler marks the given notifications of the user (or all of them, without ids) as read or
+// unread and returns how many changed and the new number of unread ones.
+func MarkNotificationsHandler(c *gin.Context) {
+	var payload MarkNotificationsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if len(payload.IDs) > maxNotificationIDsPerRequest {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many notification IDs in one request"})
+		return
+	}
+	db := database.GetDB()
+	query := userNotificationsQuery(c, db)
+	if len(payload.IDs) > 0 {
+		query = query.Where("id IN ?", payload.IDs)
+	}
+	var result *gorm.DB
+	if *payload.Read {
+		result = query.Where("read_at IS NULL").Update("read_at", time.Now())
+	} else {
+		result = query.Where("read_at IS NOT NULL").Update("read_at", nil)
+	}
+	respondNotificationBatch(c, db, result)
+}
+
+// MarkAllNotificationsReadHandler marks every visible unread notification of the user as read, optionally only
+// those of the given types. Snoozed notifications are left for when the snooze ends.
+func MarkAllNotificationsReadHandler(c *gin.Context) {
+	var payload MarkAllNotificationsReadPayload
+	var noIDs []string
+	if !bindNotificationBatch(c, &payload, true, &noIDs, &payload.Types) {
+		return
+	}
+	db := database.GetDB()
+	now := time.Now()
+	query := applyNotificationFilter(notSnoozed(userNotificationsQuery(c, db), now), nil, payload.Types)
+	respondNotificationBatch(c, db, query.Where("read_at IS NULL").Update("read_at", now))
+}
+
+// SnoozeNotificationsHandler hides the selected unread notifications (by ids and/or types; all unread ones
+// without filters) from the list and the unread count until the given time, at most 90 days ahead.
+func SnoozeNotificationsHandler(c *gin.Context) {
+	var payload SnoozeNotificationsPayload
+	if !bindNotificationBatch(c, &payload, false, &payload.IDs, &payload.Types) {
+		return
+	}
+	now := time.Now()
+	if !payload.Until.After(now) || payload.Until.Sub(now) > maxNotificationSnooze {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "until must be in the future and at most 90 days ahead"})
+		return
+	}
+	db := database.GetDB()
+	query := applyNotificationFilter(userNotificationsQuery(c, db), payload.IDs, payload.Types)
+	respondNotificationBatch(c, db, query.Where("read_at IS NULL").Update("snoozed_until", payload.Until))
+}
+
+// DismissNotificationsHandler removes the selected notifications (by ids and/or types) from the user's
+// notification center. Dismissing everything requires {"all": true}.
+func DismissNotificationsHandler(c *gin.Context) {
+	var payload DismissNotificationsPayload
+	if !bindNotificationBatch(c, &payload, false, &payload.IDs, &payload.Types) {
+		return
+	}
+	if len(payload.IDs) == 0 && len(payload.Types) == 0 && !payload.All {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Inform ids or types, or all=true to dismiss every notification"})
+		return
+	}
+	db := database.GetDB()
+	query := applyNotificationFilter(userNotificationsQuery(c, db), payload.IDs, payload.Types)
+	respondNotificationBatch(c, db, query.Update("dismissed_at", time.Now()))
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/rbac"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationRoutePayload defines the structure for creating or updating a notification routing rule.
+type NotificationRoutePayload struct {
+	Name             string `json:"name" binding:"required,min=2,max=100"`
+	EventType        string `json:"event_type" binding:"required,max=100"` // Ex: "risk_created" ou "*"
+	Channel          string `json:"channel" binding:"required"`
+	Target           string `json:"target" binding:"max=2048"`
+	TargetSecretName string `json:"target_secret_name" binding:"max=100"`
+	IsActive         *bool  `json:"is_active"`
+}
+
+// validateNotificationRoutePayload verifica o canal e o destino (direto ou via cofre de credenciais).
+func validateNotificationRoutePayload(db *gorm.DB, orgID interface{}, payload NotificationRoutePayload) error {
+	if _, ok := notifications.GetChannel(payload.Channel); !ok {
+		return fmt.Errorf("unknown channel '%s' (available: %s)", payload.Channel, strings.Join(notifications.RegisteredChannels(), ", "))
+	}
+	if (payload.Target == "") == (payload.TargetSecretName == "") {
+		return fmt.Errorf("provide exactly one of target or target_secret_name")
+	}
+	if payload.TargetSecretName != "" {
+		var count int64
+		db.Model(&models.OrgSecret{}).Where("organization_id = ? AND name = ?", orgID, payload.TargetSecretName).Count(&count)
+		if count == 0 {
+			return fmt.Errorf("secret '%s' not found in your organization", payload.TargetSecretName)
+		}
+		return nil
+	}
+	switch payload.Channel {
+	case notifications.ChannelWebhook, notifications.ChannelSlack, notifications.ChannelTeams:
+		u, err := url.ParseRequestURI(payload.Target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("target must be a valid http(s) URL for channel '%s'", payload.Channel)
+		}
+	case notifications.ChannelEmail:
+		if !strings.Contains(payload.Target, "@") {
+			return fmt.Errorf("target must be an email address for channel 'email'")
+		}
+	}
+	return nil
+}
+
+// findOrgNotificationRoute carrega a regra de :routeId na organização do usuário.
+func findOrgNotificationRoute(c *gin.Context, db *gorm.DB) (*models.NotificationRoute, bool) {
+	routeID, err := uuid.Parse(c.Param("routeId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification route ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var route models.NotificationRoute
+	if err := db.Where("id = ? AND organization_id = ?", routeID, orgID).First(&route).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification route not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notification route: " + err.Error()})
+		return nil, false
+	}
+	return &route, true
+}
+
+// ListNotificationChannelsHandler lists the notification channels registered at startup.
+func ListNotificationChannelsHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"channels": notifications.RegisteredChannels()})
+}
+
+// CreateNotificationRouteHandler creates a per-event routing rule for the organization.
+func CreateNotificationRouteHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	var payload NotificationRoutePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	if err := validateNotificationRoutePayload(db, orgID, payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	route := models.NotificationRoute{
+		OrganizationID:   orgID.(uuid.UUID),
+		Name:             payload.Name,
+		EventType:        payload.EventType,
+		Channel:          payload.Channel,
+		Target:           payload.Target,
+		TargetSecretName: payload.TargetSecretName,
+		IsActive:         true,
+	}
+	if payload.IsActive != nil {
+		route.IsActive = *payload.IsActive
+	}
+	if err := db.Create(&route).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create notification route: " + err.Error()})
+		return
+	}
+	recordConfigChange(c, db, route.OrganizationID, models.ConfigEntityNotificationRoute, route.ID, models.ConfigChangeCreate, nil)
+	c.JSON(http.StatusCreated, route)
+}
+
+// ListNotificationRoutesHandler lists the organization's routing rules. Filtros: event_type, channel.
+func ListNotificationRoutesHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Where("organization_id = ?", orgID)
+	if eventType := c.Query("event_type"); eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+	if channel := c.Query("channel"); channel != "" {
+		query = query.Where("channel = ?", channel)
+	}
+	var routes []models.NotificationRoute
+	if err := query.Order("event_type asc, name asc").Find(&routes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list notification routes: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, routes)
+}
+
+// UpdateNotificationRouteHandler replaces a routing rule.
+func UpdateNotificationRouteHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	var payload NotificationRoutePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	route, ok := findOrgNotificationRoute(c, db)
+	if !ok {
+		return
+	}
+	if err := validateNotificationRoutePayload(db, route.OrganizationID, payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before := snapshotConfigEntity(db, models.ConfigEntityNotificationRoute, route.OrganizationID, route.ID)
+	route.Name = payload.Name
+	route.EventType = payload.EventType
+	route.Channel = payload.Channel
+	route.Target = payload.Target
+	route.TargetSecretName = payload.TargetSecretName
+	if payload.IsActive != nil {
+		route.IsActive = *payload.IsActive
+	}
+	if err := db.Save(route).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification route: " + err.Error()})
+		return
+	}
+	recordConfigChange(c, db, route.OrganizationID, models.ConfigEntityNotificationRoute, route.ID, models.ConfigChangeUpdate, before)
+	c.JSON(http.StatusOK, route)
+}
+
+// DeleteNotificationRouteHandler removes a routing rule.
+func DeleteNotificationRouteHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	db := database.GetDB()
+	route, ok := findOrgNotificationRoute(c, db)
+	if !ok {
+		return
+	}
+	before := snapshotConfigEntity(db, models.ConfigEntityNotificationRoute, route.OrganizationID, route.ID)
+	if err := db.Delete(route).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete notification route: " + err.Error()})
+		return
+	}
+	recordConfigChange(c, db, route.OrganizationID, models.ConfigEntityNotificationRoute, route.ID, models.ConfigChangeDelete, before)
+	c.JSON(http.StatusOK, gin.H{"message": "Notification route deleted successfully"})
+}
+
+// TestNotificationRouteHandler sends a test message through a routing rule and reports the delivery result.
+func TestNotificationRouteHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	db := database.GetDB()
+	route, ok := findOrgNotificationRoute(c, db)
+	if !ok {
+		return
+	}
+	target, err := notifications.ResolveRouteTarget(c.Request.Context(), *route)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to resolve route target: " + err.Error()})
+		return
+	}
+	msg := notifications.Message{
+		EventType:      "notification.test",
+		OrganizationID: route.OrganizationID,
+		Subject:        fmt.Sprintf("Phoenix GRC: teste da regra de notificação '%s'", route.Name),
+		Body:           "Esta é uma mensagem de teste. Se você a recebeu, a regra está configurada corretamente.",
+	}
+	if err := notifications.Deliver(c.Request.Context(), route.Channel, target, msg); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Test delivery failed: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Test notification delivered"})
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// dashboardTopRisksLimit é a quantidade de riscos em top_risks.
+const dashboardTopRisksLimit = 5
+
+// riskLevelRankSQL ordena os níveis de risco do mais grave para o menos grave.
+const riskLevelRankSQL = "CASE risk_level WHEN 'Extremo' THEN 4 WHEN 'Alto' THEN 3 WHEN 'Moderado' THEN 2 WHEN 'Baixo' THEN 1 ELSE 0 END DESC"
+
+// DashboardRiskCounts resume os riscos da organização.
+type DashboardRiskCounts struct {
+	Total    int64            `json:"total"`
+	ByLevel  map[string]int64 `json:"by_level"`
+	ByStatus map[string]int64 `json:"by_status"`
+}
+
+// DashboardRisk é um risco em top_risks.
+type DashboardRisk struct {
+	ID          uuid.UUID              `json:"id"`
+	Title       string                 `json:"title"`
+	RiskLevel   string                 `json:"risk_level"`
+	Impact      models.RiskImpact      `json:"impact"`
+	Probability models.RiskProbability `json:"probability"`
+	Status      models.RiskStatus      `json:"status"`
+	OwnerID     uuid.UUID              `json:"owner_id"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// DashboardPendingApprovals conta as aprovações pendentes da organização.
+type DashboardPendingApprovals struct {
+	Total        int64 `json:"total"`
+	AssignedToMe int64 `json:"assigned_to_me"`
+}
+
+// DashboardOverdue conta as ações vencidas da organização.
+type DashboardOverdue struct {
+	Assessments      int64 `json:"assessments"`       // Avaliações atribuídas e não enviadas após o prazo
+	EvidenceRequests int64 `json:"evidence_requests"` // Solicitações de evidência pendentes ou rejeitadas após o prazo
+	AccessReviews    int64 `json:"access_reviews"`    // Campanhas de revisão de acessos abertas após o prazo
+	PolicyReviews    int64 `json:"policy_reviews"`    // Políticas aprovadas com a revisão periódica vencida
+	Total            int64 `json:"total"`
+}
+
+// OrganizationDashboardResponse agrega os dados do painel da organização em uma única resposta.
+type OrganizationDashboardResponse struct {
+	OrganizationID   uuid.UUID                 `json:"organization_id"`
+	GeneratedAt      time.Time                 `json:"generated_at"`
+	Risks            DashboardRiskCounts       `json:"risks"`
+	TopRisks         []DashboardRisk           `json:"top_risks"`
+	Compliance       []ComplianceScoreResponse `json:"compliance"`
+	PendingApprovals DashboardPendingApprovals `json:"pending_approvals"`
+	Overdue          DashboardOverdue          `json:"overdue"`
+	RecentActivity   []RecentActivityData      `json:"recent_activity"`
+}
+
+// pendingApprovalsScope restringe approval_workflows às aprovações pendentes da organização (aceites de
+// risco, exceções de controles e ações administrativas).
+func pendingApprovalsScope(orgID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins("LEFT JOIN risks ON risks.id = approval_workflows.risk_id AND risks.deleted_at IS NULL").
+			Joins("LEFT JOIN control_waivers ON control_waivers.id = approval_workflows.control_waiver_id").
+			Joins("LEFT JOIN admin_action_requests ON admin_action_requests.id = approval_workflows.admin_action_request_id").
+			Where("approval_workflows.status = ? AND (risks.organization_id = ? OR control_waivers.organization_id = ? OR admin_action_requests.organization_id = ?)",
+				models.ApprovalPending, orgID, orgID, orgID)
+	}
+}
+
+// GetOrganizationDashboardHandler returns the organization's dashboard data in a single payload.
+func GetOrganizationDashboardHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, orgExists := c.Get("organizationID")
+	userID, userExists := c.Get("userID")
+	if !orgExists || !userExists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User or organization ID not found in token"})
+		return
+	}
+	if tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this organization's dashboard"})
+		return
+	}
+
+	db := database.GetDB()
+	now := time.Now()
+	resp := OrganizationDashboardResponse{OrganizationID: targetOrgID, GeneratedAt: now}
+
+	// 1. Riscos por nível e por status
+	var riskGroups []struct {
+		RiskLevel string
+		Status    string
+		Count     int64
+	}
+	if err := db.Model(&models.Risk{}).Select("risk_level, status, COUNT(*) AS count").
+		Where("organization_id = ?", targetOrgID).Group("risk_level, status").Scan(&riskGroups).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count risks: " + err.Error()})
+		return
+	}
+	resp.Risks = DashboardRiskCounts{ByLevel: map[string]int64{}, ByStatus: map[string]int64{}}
+	for _, g := range riskGroups {
+		resp.Risks.Total += g.Count
+		resp.Risks.ByLevel[g.RiskLevel] += g.Count
+		resp.Risks.ByStatus[g.Status] += g.Count
+	}
+
+	// 2. Riscos em aberto mais graves
+	var topRisks []models.Risk
+	if err := db.Where("organization_id = ? AND status IN ?", targetOrgID, []models.RiskStatus{models.StatusOpen, models.StatusInProgress}).
+		Order(riskLevelRankSQL).Order("updated_at DESC").Limit(dashboardTopRisksLimit).Find(&topRisks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch top risks: " + err.Error()})
+		return
+	}
+	resp.TopRisks = make([]DashboardRisk, 0, len(topRisks))
+	for _, r := range topRisks {
+		resp.TopRisks = append(resp.TopRisks, DashboardRisk{
+			ID:          r.ID,
+			Title:       r.Title,
+			RiskLevel:   r.RiskLevel,
+			Impact:      r.Impact,
+			Probability: r.Probability,
+			Status:      r.Status,
+			OwnerID:     r.OwnerID,
+			UpdatedAt:   r.UpdatedAt,
+		})
+	}
+
+	// 3. Score de conformidade em cada framework visível que tenha controles
+	var frameworks []struct {
+		ID            uuid.UUID
+		Name          string
+		TotalControls int
+	}
+	if err := db.Table("audit_frameworks").
+		Select("audit_frameworks.id, audit_frameworks.name, COUNT(audit_controls.id) AS total_controls").
+		Joins("JOIN audit_controls ON audit_controls.framework_id = audit_frameworks.id").
+		Scopes(visibleFrameworksScope(targetOrgID)).
+		Group("audit_frameworks.id, audit_frameworks.name").Order("audit_frameworks.name").
+		Scan(&frameworks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch frameworks: " + err.Error()})
+		return
+	}
+	resp.Compliance = make([]ComplianceScoreResponse, 0, len(frameworks))
+	for _, f := range frameworks {
+		// Agregado mantido incrementalmente a cada avaliação (recalculado por completo só na primeira leitura)
+		tally, err := compliancescore.Load(db, targetOrgID, f.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate compliance score: " + err.Error()})
+			return
+		}
+		summary := compliancescore.Summarize(tally)
+		resp.Compliance = append(resp.Compliance, ComplianceScoreResponse{
+			FrameworkID:                 f.ID,
+			FrameworkName:               f.Name,
+			OrganizationID:              targetOrgID,
+			ComplianceScore:             summary.ComplianceScore,
+			TotalControls:               f.TotalControls,
+			EvaluatedControls:           summary.EvaluatedControls,
+			ConformantControls:          summary.ConformantControls,
+			PartiallyConformantControls: summary.PartiallyConformantControls,
+			NonConformantControls:       summary.NonConformantControls,
+		})
+	}
+
+	// 4. Aprovações pendentes
+	if err := db.Model(&models.ApprovalWorkflow{}).Scopes(pendingApprovalsScope(targetOrgID)).
+		Count(&resp.PendingApprovals.Total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count pending approvals: " + err.Error()})
+		return
+	}
+	if err := db.Model(&models.ApprovalWorkflow{}).Scopes(pendingApprovalsScope(targetOrgID)).
+		Where("approval_workflows.approver_id = ?", userID.(uuid.UUID)).
+		Count(&resp.PendingApprovals.AssignedToMe).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count pending approvals: " + err.Error()})
+		return
+	}
+
+	// 5. Ações vencidas
+	overdue := &resp.Overdue
+	for _, q := range []struct {
+		query *gorm.DB
+		count *int64
+	}{
+		{db.Model(&models.AuditAssessment{}).Where("organization_id = ? AND assigned_to_id IS NOT NULL AND assignment_completed_at IS NULL AND due_date < ?",
+			targetOrgID, now.Format("2006-01-02")), &overdue.Assessments},
+		{db.Model(&models.EvidenceRequest{}).Where("organization_id = ? AND status IN ? AND due_date < ?",
+			targetOrgID, []models.EvidenceRequestStatus{models.EvidenceRequestPending, models.EvidenceRequestRejected}, now), &overdue.EvidenceRequests},
+		{db.Model(&models.AccessReviewCampaign{}).Where("organization_id = ? AND status = ? AND due_date < ?",
+			targetOrgID, models.AccessReviewStatusOpen, now), &overdue.AccessReviews},
+		{db.Model(&models.Policy{}).Where("organization_id = ? AND status = ? AND next_review_date <= ?",
+			targetOrgID, models.PolicyStatusApproved, now), &overdue.PolicyReviews},
+	} {
+		if err := q.query.Count(q.count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count overdue actions: " + err.Error()})
+			return
+		}
+	}
+	overdue.Total = overdue.Assessments + overdue.EvidenceRequests + overdue.AccessReviews + overdue.PolicyReviews
+
+	// 6. Atividade recente
+	resp.RecentActivity, err = recentActivity(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch recent activity: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
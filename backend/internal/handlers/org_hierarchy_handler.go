@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/orghierarchy"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrganizationParentPayload define a organização pai; parent_id nulo torna a organização uma raiz.
+type OrganizationParentPayload struct {
+	ParentID *string `json:"parent_id" binding:"omitempty,uuid"`
+}
+
+// OrganizationHierarchyItem é uma organização na listagem da hierarquia.
+type OrganizationHierarchyItem struct {
+	ID         uuid.UUID  `json:"organization_id"`
+	Name       string     `json:"name"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	ChildCount int64      `json:"child_count"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ListOrganizationHierarchyHandler lists every organization with its parent and number of children (system admin).
+func ListOrganizationHierarchyHandler(c *gin.Context) {
+	items := []OrganizationHierarchyItem{}
+	if err := database.GetDB().Model(&models.Organization{}).
+		Select("organizations.id, organizations.name, organizations.parent_id, organizations.created_at, " +
+			"(SELECT COUNT(*) FROM organizations AS children WHERE children.parent_id = organizations.id) AS child_count").
+		Order("organizations.name asc").
+		Scan(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list organizations: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// SetOrganizationParentHandler places an organization under a parent organization, or makes it a root with
+// parent_id null (system admin). Cycles and hierarchies deeper than orghierarchy.MaxDepth are rejected.
+func SetOrganizationParentHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	var payload OrganizationParentPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	var org models.Organization
+	if err := db.Take(&org, "id = ?", orgID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load organization: " + err.Error()})
+		return
+	}
+
+	var parentID *uuid.UUID
+	if payload.ParentID != nil {
+		id := uuid.MustParse(*payload.ParentID) // Validado pelo binding
+		var count int64
+		db.Model(&models.Organization{}).Where("id = ?", id).Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent organization not found"})
+			return
+		}
+		if err := orghierarchy.ValidateParent(db, org.ID, id); err != nil {
+			if errors.Is(err, orghierarchy.ErrCycle) || errors.Is(err, orghierarchy.ErrTooDeep) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate organization hierarchy: " + err.Error()})
+			return
+		}
+		parentID = &id
+	}
+
+	if err := db.Model(&org).Update("parent_id", parentID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update organization: " + err.Error()})
+		return
+	}
+	item := OrganizationHierarchyItem{ID: org.ID, Name: org.Name, ParentID: parentID, CreatedAt: org.CreatedAt}
+	db.Model(&models.Organization{}).Where("parent_id = ?", org.ID).Count(&item.ChildCount)
+	c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/orghierarchy"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskRollupCounts conta os riscos por status (nativo) e por nível.
+type RiskRollupCounts struct {
+	TotalRisks int64            `json:"total_risks"`
+	ByStatus   map[string]int64 `json:"by_status"`
+	ByLevel    map[string]int64 `json:"by_level"`
+}
+
+func newRiskRollupCounts() RiskRollupCounts {
+	return RiskRollupCounts{ByStatus: map[string]int64{}, ByLevel: map[string]int64{}}
+}
+
+func (r *RiskRollupCounts) add(status, level string, count int64) {
+	r.TotalRisks += count
+	r.ByStatus[status] += count
+	r.ByLevel[level] += count
+}
+
+// RiskRollupOrganization são os números de uma organização da hierarquia.
+type RiskRollupOrganization struct {
+	orghierarchy.Node
+	RiskRollupCounts
+}
+
+// RiskRollupResponse consolida os riscos da organização e das descendentes.
+type RiskRollupResponse struct {
+	OrganizationID uuid.UUID                `json:"organization_id"`
+	Totals         RiskRollupCounts         `json:"totals"`
+	Organizations  []RiskRollupOrganization `json:"organizations"`
+}
+
+// ComplianceRollupScore são os números de conformidade de uma organização ou do grupo em um framework.
+type ComplianceRollupScore struct {
+	ComplianceScore             float64 `json:"compliance_score"`
+	EvaluatedControls           int     `json:"evaluated_controls"`
+	ConformantControls          int     `json:"conformant_controls"`
+	PartiallyConformantControls int     `json:"partially_conformant_controls"`
+	NonConformantControls       int     `json:"non_conformant_controls"`
+}
+
+func newComplianceRollupScore(c compliancescore.Contribution) ComplianceRollupScore {
+	return ComplianceRollupScore{
+		ComplianceScore:             math.Round(c.Score()*100) / 100,
+		EvaluatedControls:           c.Evaluated,
+		ConformantControls:          c.Conformant,
+		PartiallyConformantControls: c.PartiallyConformant,
+		NonConformantControls:       c.NonConformant,
+	}
+}
+
+// ComplianceRollupOrganization é o score de uma organização da hierarquia no framework.
+type ComplianceRollupOrganization struct {
+	orghierarchy.Node
+	ComplianceRollupScore
+}
+
+// ComplianceRollupFramework consolida um framework: o score do grupo é a média dos controles avaliados em
+// todas as organizações (as organizações com mais controles avaliados pesam mais).
+type ComplianceRollupFramework struct {
+	FrameworkID   uuid.UUID `json:"framework_id"`
+	FrameworkName string    `json:"framework_name"`
+	TotalControls int64     `json:"total_controls"`
+	ComplianceRollupScore
+	Organizations []ComplianceRollupOrganization `json:"organizations"`
+}
+
+// rollupSubtree verifica o acesso ao consolidado de :orgId (usuário da organização com reports:view_rollup)
+// e carrega a organização e as suas descendentes.
+func rollupSubtree(c *gin.Context, db *gorm.DB) ([]orghierarchy.Node, bool) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return nil, false
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.ReportsViewRollup) {
+		return nil, false
+	}
+	nodes, err := orghierarchy.Subtree(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load organization hierarchy: " + err.Error()})
+		return nil, false
+	}
+	if len(nodes) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		return nil, false
+	}
+	return nodes, true
+}
+
+// GetRiskRollupHandler consolidates the risks of the organization and of all its descendant organizations by
+// status and level, with the numbers of each organization (reports:view_rollup).
+func GetRiskRollupHandler(c *gin.Context) {
+	db := database.GetDB()
+	nodes, ok := rollupSubtree(c, db)
+	if !ok {
+		return
+	}
+	var rows []struct {
+		OrganizationID uuid.UUID
+		Status         string
+		RiskLevel      string
+		Count          int64
+	}
+	if err := db.Model(&models.Risk{}).
+		Select("organization_id, status, risk_level, COUNT(*) AS count").
+		Where("organization_id IN ?", orghierarchy.IDs(nodes)).
+		Group("organization_id, status, risk_level").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to aggregate risks: " + err.Error()})
+		return
+	}
+
+	resp := RiskRollupResponse{OrganizationID: nodes[0].ID, Totals: newRiskRollupCounts()}
+	index := make(map[uuid.UUID]int, len(nodes))
+	for i, node := range nodes {
+		index[node.ID] = i
+		resp.Organizations = append(resp.Organizations, RiskRollupOrganization{Node: node, RiskRollupCounts: newRiskRollupCounts()})
+	}
+	for _, row := range rows {
+		resp.Organizations[index[row.OrganizationID]].add(row.Status, row.RiskLevel, row.Count)
+		resp.Totals.add(row.Status, row.RiskLevel, row.Count)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetComplianceScoreRollupHandler consolidates the compliance score of the organization and of all its
+// descendant organizations, per framework assessed by any of them (reports:view_rollup). ?framework_id=
+// restricts the result to one framework.
+func GetComplianceScoreRollupHandler(c *gin.Context) {
+	db := database.GetDB().WithContext(c.Request.Context())
+	var frameworkFilter uuid.UUID
+	if raw := c.Query("framework_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid framework ID format"})
+			return
+		}
+		frameworkFilter = id
+	}
+	nodes, ok := rollupSubtree(c, db)
+	if !ok {
+		return
+	}
+
+	// Pares organização x framework com avaliações contínuas; o score de cada par vem do agregado incremental
+	query := db.Model(&models.AuditAssessment{}).Scopes(models.OrgWideAssessments).
+		Joins("JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id").
+		Where("audit_assessments.organization_id IN ?", orghierarchy.IDs(nodes))
+	if frameworkFilter != uuid.Nil {
+		query = query.Where("audit_controls.framework_id = ?", frameworkFilter)
+	}
+	var pairs []struct {
+		OrganizationID uuid.UUID
+		FrameworkID    uuid.UUID
+	}
+	if err := query.Distinct("audit_assessments.organization_id", "audit_controls.framework_id").Scan(&pairs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assessed frameworks: " + err.Error()})
+		return
+	}
+
+	contributions := map[uuid.UUID]map[uuid.UUID]compliancescore.Contribution{}
+	var frameworkIDs []uuid.UUID
+	for _, pair := range pairs {
+		tally, err := compliancescore.Load(db, pair.OrganizationID, pair.FrameworkID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load compliance score: " + err.Error()})
+			return
+		}
+		if contributions[pair.FrameworkID] == nil {
+			contributions[pair.FrameworkID] = map[uuid.UUID]compliancescore.Contribution{}
+			frameworkIDs = append(frameworkIDs, pair.FrameworkID)
+		}
+		contributions[pair.FrameworkID][pair.OrganizationID] = compliancescore.FromTally(tally)
+	}
+
+	frameworks := []ComplianceRollupFramework{}
+	if len(frameworkIDs) > 0 {
+		var names []models.AuditFramework
+		if err := db.Select("id", "name").Where("id IN ?", frameworkIDs).Find(&names).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch framework details: " + err.Error()})
+			return
+		}
+		var controlCounts []struct {
+			FrameworkID uuid.UUID
+			Count       int64
+		}
+		if err := db.Model(&models.AuditControl{}).Select("framework_id, COUNT(*) AS count").
+			Where("framework_id IN ?", frameworkIDs).Group("framework_id").Scan(&controlCounts).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve controls for framework: " + err.Error()})
+			return
+		}
+		totalControls := make(map[uuid.UUID]int64, len(controlCounts))
+		for _, row := range controlCounts {
+			totalControls[row.FrameworkID] = row.Count
+		}
+		for _, framework := range names {
+			entry := ComplianceRollupFramework{
+				FrameworkID: framework.ID, FrameworkName: framework.Name, TotalControls: totalControls[framework.ID],
+			}
+			var group compliancescore.Contribution
+			for _, node := range nodes {
+				contribution, assessed := contributions[framework.ID][node.ID]
+				if !assessed {
+					continue
+				}
+				group = group.Add(contribution)
+				entry.Organizations = append(entry.Organizations, ComplianceRollupOrganization{
+					Node: node, ComplianceRollupScore: newComplianceRollupScore(contribution),
+				})
+			}
+			entry.ComplianceRollupScore = newComplianceRollupScore(group)
+			frameworks = append(frameworks, entry)
+		}
+		sort.Slice(frameworks, func(i, j int) bool { return frameworks[i].FrameworkName < frameworks[j].FrameworkName })
+	}
+	c.JSON(http.StatusOK, gin.H{"organization_id": nodes[0].ID, "frameworks": frameworks})
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/secrets"
+	"phoenixgrc/backend/internal/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrgSecretPayload defines the structure for storing a new organization secret.
+type OrgSecretPayload struct {
+	Name                 string               `json:"name" binding:"required,min=2,max=100"`
+	Kind                 models.OrgSecretKind `json:"kind" binding:"required,oneof=jira_token slack_webhook teams_webhook aws_role api_key generic"`
+	Description          string               `json:"description" binding:"max=255"`
+	Value                string               `json:"value" binding:"required"`
+	RotationIntervalDays int                  `json:"rotation_interval_days" binding:"omitempty,min=1,max=3650"`
+}
+
+// UpdateOrgSecretPayload atualiza apenas os metadados; o valor é alterado via rotação.
+type UpdateOrgSecretPayload struct {
+	Description          *string `json:"description" binding:"omitempty,max=255"`
+	RotationIntervalDays *int    `json:"rotation_interval_days" binding:"omitempty,min=0,max=3650"`
+}
+
+// RotateOrgSecretPayload contém o novo valor do segredo.
+type RotateOrgSecretPayload struct {
+	Value string `json:"value" binding:"required"`
+}
+
+// rotationIntervalWithinPolicy valida o intervalo de rotação contra a idade máxima da política de credenciais:
+// intervalos maiores são rejeitados e a ausência de intervalo (0) assume a idade máxima.
+func rotationIntervalWithinPolicy(c *gin.Context, policy *models.OrgSecretPolicy, days int) (int, bool) {
+	if policy == nil {
+		return days, true
+	}
+	if days == 0 {
+		return policy.MaxAgeDays, true
+	}
+	if days > policy.MaxAgeDays {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        fmt.Sprintf("rotation_interval_days exceeds the organization's maximum credential age of %d days", policy.MaxAgeDays),
+			"max_age_days": policy.MaxAgeDays,
+		})
+		return 0, false
+	}
+	return days, true
+}
+
+// findOrgSecret carrega o segredo de :secretId na organização do usuário.
+func findOrgSecret(c *gin.Context, db *gorm.DB) (*models.OrgSecret, bool) {
+	secretID, err := uuid.Parse(c.Param("secretId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid secret ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var secret models.OrgSecret
+	if err := db.Where("id = ? AND organization_id = ?", secretID, orgID).First(&secret).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Secret not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch secret: " + err.Error()})
+		return nil, false
+	}
+	return &secret, true
+}
+
+// CreateOrgSecretHandler stores a new encrypted credential for the organization.
+// A resposta contém apenas a dica mascarada do valor.
+func CreateOrgSecretHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	var payload OrgSecretPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+
+	policy, err := secrets.LoadPolicy(db, orgID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load credential policy: " + err.Error()})
+		return
+	}
+	interval, ok := rotationIntervalWithinPolicy(c, policy, payload.RotationIntervalDays)
+	if !ok {
+		return
+	}
+
+	name := strings.TrimSpace(payload.Name)
+	var count int64
+	db.Model(&models.OrgSecret{}).Where("organization_id = ? AND name = ?", orgID, name).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "A secret with this name already exists in your organization"})
+		return
+	}
+
+	secret := models.OrgSecret{
+		OrganizationID:       orgID.(uuid.UUID),
+		Name:                 name,
+		Kind:                 payload.Kind,
+		Description:          payload.Description,
+		RotationIntervalDays: interval,
+		CreatedByID:          userID.(uuid.UUID),
+	}
+	if err := secret.SetValue(payload.Value); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encrypt secret: " + err.Error()})
+		return
+	}
+	if err := db.Create(&secret).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create secret: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, secret)
+}
+
+// ListOrgSecretsHandler lists the organization's secrets (masked). Filtros: kind, rotation_due=true.
+func ListOrgSecretsHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Where("organization_id = ?", orgID)
+	if kind := c.Query("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if c.Query("rotation_due") == "true" {
+		query = query.Where("rotation_due_at IS NOT NULL AND rotation_due_at <= NOW()")
+	}
+	var secrets []models.OrgSecret
+	if err := query.Order("name asc").Find(&secrets).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list secrets: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, secrets)
+}
+
+// GetOrgSecretHandler returns a secret's metadata and usage; o valor nunca é retornado.
+func GetOrgSecretHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	secret, ok := findOrgSecret(c, database.GetDB())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, secret)
+}
+
+// UpdateOrgSecretHandler updates a secret's description and rotation interval.
+func UpdateOrgSecretHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	var payload UpdateOrgSecretPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	secret, ok := findOrgSecret(c, db)
+	if !ok {
+		return
+	}
+	if payload.Description != nil {
+		secret.Description = *payload.Description
+	}
+	if payload.RotationIntervalDays != nil {
+		policy, err := secrets.LoadPolicy(db, secret.OrganizationID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load credential policy: " + err.Error()})
+			return
+		}
+		interval, ok := rotationIntervalWithinPolicy(c, policy, *payload.RotationIntervalDays)
+		if !ok {
+			return
+		}
+		secret.RotationIntervalDays = interval
+		secret.ScheduleRotation()
+	}
+	if err := db.Save(secret).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update secret: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, secret)
+}
+
+// RotateOrgSecretHandler requests the replacement of a secret's value, which also restarts its rotation cycle.
+// The new value is only applied after a second admin approves it (?approver_id=).
+func RotateOrgSecretHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	var payload RotateOrgSecretPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	secret, ok := findOrgSecret(c, db)
+	if !ok {
+		return
+	}
+	// O novo valor fica criptografado na solicitação até a aprovação
+	encrypted, err := utils.Encrypt(payload.Value)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encrypt secret: " + err.Error()})
+		return
+	}
+	requestSecondAdminApproval(c, db, models.AdminActionOrgSecretRotate, secret.ID, secret.Name, &encrypted)
+}
+
+// DeleteOrgSecretHandler requests the removal of a secret from the organization vault, executed after a second
+// admin approves it (?approver_id=).
+func DeleteOrgSecretHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	db := database.GetDB()
+	secret, ok := findOrgSecret(c, db)
+	if !ok {
+		return
+	}
+	requestSecondAdminApproval(c, db, models.AdminActionOrgSecretDelete, secret.ID, secret.Name, nil)
+}
@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/secrets"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxBulkRevokeSecrets limita as credenciais de uma solicitação de revogação em lote.
+const maxBulkRevokeSecrets = 100
+
+// OrgSecretInventoryItem é uma credencial do inventário com a avaliação de idade, uso e política.
+type OrgSecretInventoryItem struct {
+	models.OrgSecret
+	Assessment secrets.Assessment `json:"assessment"`
+}
+
+// OrgSecretInventoryResponse é o inventário de credenciais da organização.
+type OrgSecretInventoryResponse struct {
+	GeneratedAt    time.Time                `json:"generated_at"`
+	Policy         *models.OrgSecretPolicy  `json:"policy,omitempty"`
+	Total          int                      `json:"total"`
+	RotationDue    int                      `json:"rotation_due"`
+	MaxAgeExceeded int                      `json:"max_age_exceeded"`
+	Blocked        int                      `json:"blocked"`
+	Items          []OrgSecretInventoryItem `json:"items"`
+}
+
+// BulkRevokeOrgSecretsPayload lista as credenciais a revogar.
+type BulkRevokeOrgSecretsPayload struct {
+	SecretIDs []uuid.UUID `json:"secret_ids" binding:"required,min=1"`
+	Reason    string      `json:"reason" binding:"max=500"`
+}
+
+// bulkRevokePayload é o payload guardado na solicitação de revogação em lote.
+type bulkRevokePayload struct {
+	SecretIDs []uuid.UUID `json:"secret_ids"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// OrgSecretPolicyPayload define a política de credenciais da organização.
+type OrgSecretPolicyPayload struct {
+	MaxAgeDays  int                         `json:"max_age_days" binding:"required,min=1,max=3650"`
+	Enforcement models.OrgSecretEnforcement `json:"enforcement" binding:"omitempty,oneof=warn block"`
+}
+
+// OrgSecretPolicyResponse é a política configurada (nil se não houver) e, na alteração, quantas credenciais
+// tiveram o intervalo de rotação ajustado à idade máxima.
+type OrgSecretPolicyResponse struct {
+	Policy          *models.OrgSecretPolicy `json:"policy"`
+	AdjustedSecrets *int64                  `json:"adjusted_secrets,omitempty"`
+}
+
+// revokeOrgSecrets executa a revogação em lote aprovada: remove as credenciais que ainda existem.
+func revokeOrgSecrets(tx *gorm.DB, req *models.AdminActionRequest) error {
+	if req.Payload == nil {
+		return errors.New("the list of secrets to revoke is missing")
+	}
+	var payload bulkRevokePayload
+	if err := json.Unmarshal([]byte(*req.Payload), &payload); err != nil {
+		return err
+	}
+	result := tx.Where("organization_id = ? AND id IN ?", req.OrganizationID, payload.SecretIDs).Delete(&models.OrgSecret{})
+	if result.Error == nil && result.RowsAffected == 0 {
+		return errAdminActionTargetGone
+	}
+	return result.Error
+}
+
+// ListOrgSecretInventoryHandler lists every credential in the organization vault with its age, last use and
+// compliance with the credential policy. Filtros: kind, status (ok, rotation_due, max_age_exceeded),
+// idle_days=N (sem uso há N dias ou nunca usadas); format=csv baixa o inventário.
+func ListOrgSecretInventoryHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+
+	status := c.Query("status")
+	if status != "" && status != secrets.StatusOK && status != secrets.StatusRotationDue && status != secrets.StatusMaxAgeExceeded {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status filter: use ok, rotation_due or max_age_exceeded"})
+		return
+	}
+	idleDays := -1
+	if raw := c.Query("idle_days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "idle_days must be a non-negative integer"})
+			return
+		}
+		idleDays = n
+	}
+
+	policy, err := secrets.LoadPolicy(db, organizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load credential policy: " + err.Error()})
+		return
+	}
+	query := db.Where("organization_id = ?", organizationID)
+	if kind := c.Query("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	var vault []models.OrgSecret
+	if err := query.Order("last_rotated_at asc, name asc").Find(&vault).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list secrets: " + err.Error()})
+		return
+	}
+
+	now := time.Now()
+	resp := OrgSecretInventoryResponse{GeneratedAt: now, Policy: policy, Items: []OrgSecretInventoryItem{}}
+	for i := range vault {
+		assessment := secrets.Evaluate(&vault[i], policy, now)
+		if status != "" && assessment.Status != status {
+			continue
+		}
+		if idleDays >= 0 && assessment.IdleDays != nil && *assessment.IdleDays < idleDays {
+			continue
+		}
+		resp.Items = append(resp.Items, OrgSecretInventoryItem{OrgSecret: vault[i], Assessment: assessment})
+		switch assessment.Status {
+		case secrets.StatusRotationDue:
+			resp.RotationDue++
+		case secrets.StatusMaxAgeExceeded:
+			resp.MaxAgeExceeded++
+		}
+		if assessment.Blocked {
+			resp.Blocked++
+		}
+	}
+	resp.Total = len(resp.Items)
+
+	if c.Query("format") == "csv" {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"credential_inventory_%s.csv\"", now.UTC().Format("20060102")))
+		w := csv.NewWriter(c.Writer)
+		_ = w.Write([]string{"id", "name", "kind", "value_hint", "created_at", "last_rotated_at", "age_days",
+			"rotation_due_at", "max_age_at", "status", "blocked", "usage_count", "last_used_at", "last_used_by"})
+		for _, item := range resp.Items {
+			_ = w.Write([]string{
+				item.ID.String(), item.Name, string(item.Kind), item.ValueHint,
+				item.CreatedAt.UTC().Format(time.RFC3339), item.LastRotatedAt.UTC().Format(time.RFC3339),
+				strconv.Itoa(item.Assessment.AgeDays), formatOptionalTime(item.RotationDueAt),
+				formatOptionalTime(item.Assessment.MaxAgeAt), item.Assessment.Status,
+				strconv.FormatBool(item.Assessment.Blocked), strconv.FormatInt(item.UsageCount, 10),
+				formatOptionalTime(item.LastUsedAt), item.LastUsedBy,
+			})
+		}
+		w.Flush()
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// formatOptionalTime formata a data em RFC3339 (UTC), ou vazio se nula.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// BulkRevokeOrgSecretsHandler requests the revocation of several credentials at once, executed after a second
+// admin approves it (?approver_id=). Credenciais removidas antes da aprovação são ignoradas.
+func BulkRevokeOrgSecretsHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	var payload BulkRevokeOrgSecretsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	ids := make([]uuid.UUID, 0, len(payload.SecretIDs))
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range payload.SecretIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > maxBulkRevokeSecrets {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d secrets can be revoked per request", maxBulkRevokeSecrets)})
+		return
+	}
+
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	var found []models.OrgSecret
+	if err := db.Select("id", "name").Where("organization_id = ? AND id IN ?", orgID, ids).Order("name asc").Find(&found).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch secrets: " + err.Error()})
+		return
+	}
+	if len(found) != len(ids) {
+		present := make(map[uuid.UUID]bool, len(found))
+		for _, s := range found {
+			present[s.ID] = true
+		}
+		var missing []uuid.UUID
+		for _, id := range ids {
+			if !present[id] {
+				missing = append(missing, id)
+			}
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Some secrets were not found or are not part of your organization", "missing_ids": missing})
+		return
+	}
+
+	data, err := json.Marshal(bulkRevokePayload{SecretIDs: ids, Reason: strings.TrimSpace(payload.Reason)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to prepare revocation: " + err.Error()})
+		return
+	}
+	names := make([]string, len(found))
+	for i, s := range found {
+		names[i] = s.Name
+	}
+	label := fmt.Sprintf("%d credenciais: %s", len(found), strings.Join(names, ", "))
+	if runes := []rune(label); len(runes) > 255 {
+		label = string(runes[:252]) + "..."
+	}
+	encoded := string(data)
+	// Cada lote é um alvo próprio: o ID identifica a solicitação na trilha de auditoria
+	requestSecondAdminApproval(c, db, models.AdminActionOrgSecretBulkRevoke, uuid.New(), label, &encoded)
+}
+
+// GetOrgSecretPolicyHandler returns the organization's credential policy (null when none is configured).
+func GetOrgSecretPolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	policy, err := secrets.LoadPolicy(database.GetDB(), orgID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load credential policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, OrgSecretPolicyResponse{Policy: policy})
+}
+
+// SetOrgSecretPolicyHandler creates or replaces the organization's credential policy. Credenciais sem
+// intervalo de rotação, ou com intervalo maior que a idade máxima, passam a rotacionar na idade máxima,
+// para que os lembretes de rotação avisem antes do limite.
+func SetOrgSecretPolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	var payload OrgSecretPolicyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if payload.Enforcement == "" {
+		payload.Enforcement = models.OrgSecretEnforcementWarn
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	updatedBy := userID.(uuid.UUID)
+	db := database.GetDB()
+
+	var policy *models.OrgSecretPolicy
+	var adjusted int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		current, err := secrets.LoadPolicy(tx, organizationID)
+		if err != nil {
+			return err
+		}
+		previous := gin.H{}
+		if current == nil {
+			current = &models.OrgSecretPolicy{OrganizationID: organizationID}
+		} else {
+			previous = gin.H{"max_age_days": current.MaxAgeDays, "enforcement": current.Enforcement}
+		}
+		current.MaxAgeDays = payload.MaxAgeDays
+		current.Enforcement = payload.Enforcement
+		current.UpdatedByID = &updatedBy
+		if err := tx.Save(current).Error; err != nil {
+			return err
+		}
+		policy = current
+
+		result := tx.Model(&models.OrgSecret{}).
+			Where("organization_id = ? AND (rotation_interval_days = 0 OR rotation_interval_days > ?)", organizationID, payload.MaxAgeDays).
+			Updates(map[string]interface{}{
+				"rotation_interval_days":    payload.MaxAgeDays,
+				"rotation_due_at":           gorm.Expr("last_rotated_at + make_interval(days => ?)", payload.MaxAgeDays),
+				"rotation_reminder_sent_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		adjusted = result.RowsAffected
+
+		_, err = recordAuditTrail(c, tx, organizationID, models.AuditTrailSecretPolicyUpdated, "org_secret_policy", &current.ID,
+			fmt.Sprintf("Credential policy set to a maximum age of %d days (%s)", payload.MaxAgeDays, payload.Enforcement),
+			gin.H{"previous": previous, "max_age_days": payload.MaxAgeDays, "enforcement": payload.Enforcement, "adjusted_secrets": adjusted})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save credential policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, OrgSecretPolicyResponse{Policy: policy, AdjustedSecrets: &adjusted})
+}
+
+// DeleteOrgSecretPolicyHandler removes the organization's credential policy; os intervalos de rotação das
+// credenciais são mantidos.
+func DeleteOrgSecretPolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.SecretsManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	db := database.GetDB()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		current, err := secrets.LoadPolicy(tx, organizationID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Delete(current).Error; err != nil {
+			return err
+		}
+		_, err = recordAuditTrail(c, tx, organizationID, models.AuditTrailSecretPolicyUpdated, "org_secret_policy", &current.ID,
+			"Credential policy removed", gin.H{"previous": gin.H{"max_age_days": current.MaxAgeDays, "enforcement": current.Enforcement}})
+		return err
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No credential policy configured for your organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete credential policy: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/orgexport"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// organizationExportStaleAfter é o tempo sem progresso após o qual uma exportação em andamento é considerada abandonada.
+const organizationExportStaleAfter = time.Hour
+
+// errOrganizationExportUploadDone interrompe a geração do ZIP quando o envio ao armazenamento terminou antes.
+var errOrganizationExportUploadDone = errors.New("upload finished before the export was fully written")
+
+// OrganizationExportResponse é a exportação com os arquivos do manifesto, quando concluída.
+type OrganizationExportResponse struct {
+	models.OrganizationExport
+	Files []orgexport.File `json:"files,omitempty"`
+}
+
+// organizationExportObject é o caminho do ZIP da exportação no armazenamento.
+func organizationExportObject(export *models.OrganizationExport) string {
+	return fmt.Sprintf("%s/organization_exports/%s/%s", export.OrganizationID.String(), export.ID.String(), organizationExportFileName(export))
+}
+
+func organizationExportFileName(export *models.OrganizationExport) string {
+	return fmt.Sprintf("organization_export_%s.zip", export.CreatedAt.UTC().Format("20060102_150405"))
+}
+
+// organizationExportJob é o payload de JobOrganizationExport.
+type organizationExportJob struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// runOrganizationExportJob executa a exportação de um JobOrganizationExport. Exportações já concluídas ou com
+// falha (ex: job repetido pelo administrador) não são refeitas.
+func runOrganizationExportJob(ctx context.Context, job *models.BackgroundJob) error {
+	var p organizationExportJob
+	if err := jobs.DecodePayload(job, &p); err != nil {
+		return err
+	}
+	provider := filestorage.DefaultFileStorageProvider
+	if provider == nil {
+		return errors.New("file storage service is not configured")
+	}
+	db := database.GetDB()
+	var export models.OrganizationExport
+	if err := db.WithContext(ctx).First(&export, "id = ?", p.ExportID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if export.Status != models.OrganizationExportPending && export.Status != models.OrganizationExportRunning {
+		return nil
+	}
+	runOrganizationExport(ctx, db, provider, export)
+	return nil
+}
+
+// organizationExportResult é o resultado da geração do ZIP, que roda em paralelo ao envio.
+type organizationExportResult struct {
+	manifest *orgexport.Manifest
+	err      error
+}
+
+// byteCounter conta os bytes escritos.
+type byteCounter struct {
+	n int64
+}
+
+func (b *byteCounter) Write(p []byte) (int, error) {
+	b.n += int64(len(p))
+	return len(p), nil
+}
+
+// runOrganizationExport gera o ZIP enviando-o ao armazenamento à medida que é escrito (io.Pipe), grava o
+// manifesto e marca a exportação como concluída até orgexport.DownloadTTL; em caso de erro, marca-a como falha.
+func runOrganizationExport(ctx context.Context, db *gorm.DB, provider filestorage.FileStorageProvider, export models.OrganizationExport) {
+	startedAt := time.Now()
+	db.Model(&export).Updates(map[string]interface{}{"status": models.OrganizationExportRunning, "started_at": startedAt})
+
+	pr, pw := io.Pipe()
+	size := &byteCounter{}
+	digest := sha256.New()
+	done := make(chan organizationExportResult, 1)
+	go func() {
+		manifest, err := orgexport.Write(ctx, db, export.ID, export.OrganizationID, io.MultiWriter(pw, size, digest), startedAt)
+		pw.CloseWithError(err)
+		done <- organizationExportResult{manifest: manifest, err: err}
+	}()
+	objectName, err := provider.UploadFile(ctx, export.OrganizationID.String(), organizationExportObject(&export), pr)
+	pr.CloseWithError(errOrganizationExportUploadDone)
+	result := <-done
+	if err == nil && result.err != nil {
+		err = result.err
+		_ = provider.DeleteFile(ctx, objectName)
+	}
+	var raw []byte
+	if err == nil {
+		raw, err = json.Marshal(result.manifest)
+	}
+	if err != nil {
+		phxlog.L.Error("Organization export failed",
+			zap.String("exportID", export.ID.String()),
+			zap.Error(err))
+		db.Model(&export).Updates(map[string]interface{}{"status": models.OrganizationExportFailed, "error": err.Error(), "completed_at": time.Now()})
+		return
+	}
+	completedAt := time.Now()
+	db.Model(&export).Updates(map[string]interface{}{
+		"status":       models.OrganizationExportCompleted,
+		"object_name":  objectName,
+		"size_bytes":   size.n,
+		"sha256":       hex.EncodeToString(digest.Sum(nil)),
+		"manifest":     string(raw),
+		"completed_at": completedAt,
+		"expires_at":   completedAt.Add(orgexport.DownloadTTL),
+	})
+	phxlog.L.Info("Organization export completed",
+		zap.String("exportID", export.ID.String()),
+		zap.Int64("bytes", size.n),
+		zap.Duration("duration", time.Since(startedAt)))
+}
+
+// findOrgOrganizationExport carrega a exportação :exportId da organização do token.
+func findOrgOrganizationExport(c *gin.Context, db *gorm.DB) (*models.OrganizationExport, bool) {
+	exportID, err := uuid.Parse(c.Param("exportId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var export models.OrganizationExport
+	if err := db.Where("id = ? AND organization_id = ?", exportID, orgID).First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization export not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organization export: " + err.Error()})
+		return nil, false
+	}
+	return &export, true
+}
+
+// CreateOrganizationExportHandler starts the background generation of a ZIP with the organization's whole dataset
+// (users, risks, assessments, evidence manifest and policies as JSON/CSV files), for backup and off-boarding.
+func CreateOrganizationExportHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.OrgExport) {
+		return
+	}
+	provider := filestorage.DefaultFileStorageProvider
+	if provider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+	orgID := c.MustGet("organizationID").(uuid.UUID)
+	userID := c.MustGet("userID").(uuid.UUID)
+	db := database.GetDB()
+
+	// Exportações sem progresso há mais de organizationExportStaleAfter (ex: servidor reiniciado) não bloqueiam novas
+	var running int64
+	db.Model(&models.OrganizationExport{}).
+		Where("organization_id = ? AND status IN ? AND updated_at > ?", orgID,
+			[]models.OrganizationExportStatus{models.OrganizationExportPending, models.OrganizationExportRunning}, time.Now().Add(-organizationExportStaleAfter)).
+		Count(&running)
+	if running > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "An organization export is already in progress"})
+		return
+	}
+
+	export := models.OrganizationExport{
+		OrganizationID: orgID,
+		RequestedByID:  userID,
+		Status:         models.OrganizationExportPending,
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&export).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, orgID, models.AuditTrailOrgExportRequested, "organization_export", &export.ID,
+			"Exportação completa dos dados da organização solicitada", nil)
+		if err != nil {
+			return err
+		}
+		_, err = jobs.Enqueue(c.Request.Context(), tx, JobOrganizationExport, organizationExportJob{ExportID: export.ID}, jobs.WithOrganization(orgID))
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization export: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+// ListOrganizationExportsHandler lists the organization's full exports, newest first.
+func ListOrganizationExportsHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.OrgExport) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Model(&models.OrganizationExport{}).Where("organization_id = ?", orgID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	page, pageSize := GetPaginationParams(c)
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count organization exports: " + err.Error()})
+		return
+	}
+	var exports []models.OrganizationExport
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("created_at desc").Find(&exports).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list organization exports: " + err.Error()})
+		return
+	}
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: exports, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
+
+// GetOrganizationExportHandler returns an organization export and, once completed, the files in the ZIP.
+func GetOrganizationExportHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.OrgExport) {
+		return
+	}
+	export, ok := findOrgOrganizationExport(c, database.GetDB())
+	if !ok {
+		return
+	}
+	resp := OrganizationExportResponse{OrganizationExport: *export}
+	if export.Manifest != nil {
+		var manifest orgexport.Manifest
+		if err := json.Unmarshal([]byte(*export.Manifest), &manifest); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decode export manifest: " + err.Error()})
+			return
+		}
+		resp.Files = manifest.Files
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DownloadOrganizationExportHandler returns a short-lived signed URL to download the ZIP of a completed export.
+func DownloadOrganizationExportHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.OrgExport) {
+		return
+	}
+	db := database.GetDB()
+	export, ok := findOrgOrganizationExport(c, db)
+	if !ok {
+		return
+	}
+	if export.Status != models.OrganizationExportCompleted || (export.ExpiresAt != nil && !time.Now().Before(*export.ExpiresAt)) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Organization export is not available for download", "status": export.Status})
+		return
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+	signedURL, err := filestorage.DefaultFileStorageProvider.GetSignedURL(c.Request.Context(), export.ObjectName, 15)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate download URL: " + err.Error()})
+		return
+	}
+	// Cada download fica registrado: o arquivo contém todos os dados da organização
+	if _, err := recordAuditTrail(c, db, export.OrganizationID, models.AuditTrailOrgExportDownloaded, "organization_export", &export.ID,
+		"Download da exportação completa dos dados da organização", gin.H{"size_bytes": export.SizeBytes, "sha256": export.SHA256}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record export download: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": signedURL, "file_name": organizationExportFileName(export), "expires_in_minutes": 15, "sha256": export.SHA256})
+}
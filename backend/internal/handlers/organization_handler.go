@@ -9,9 +9,11 @@ import (
 	"path/filepath"
 	phxlog "phoenixgrc/backend/pkg/log" // Importar o logger zap
 	"go.uber.org/zap"                 // Importar zap
+	"phoenixgrc/backend/internal/avscan"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/filestorage"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
 	"regexp" // Para validar cores HEX
 	"time"   // Adicionado
 
@@ -38,7 +40,7 @@ func UpdateOrganizationBrandingHandler(c *gin.Context) {
 	}
 
 	// Autorização: verificar se o usuário logado é admin/manager da targetOrgID
-	if !checkOrgAdminOrManager(c, targetOrgID) { // Reutilizando helper de organization_user_handler
+	if !checkOrgPermission(c, targetOrgID, rbac.BrandingManage) {
 		return
 	}
 	actingUserID, _ := c.Get("userID") // Para log ou auditoria futura
@@ -109,6 +111,16 @@ func UpdateOrganizationBrandingHandler(c *gin.Context) {
 			return
 		}
 
+		scanResult, errScan := avscan.ScanUpload(c.Request.Context(), header.Filename, file)
+		if errScan != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Falha na verificação antivírus do arquivo de logo: " + errScan.Error()})
+			return
+		}
+		if scanResult.Verdict == avscan.VerdictInfected {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Arquivo de logo rejeitado: malware detectado", "signature": scanResult.Signature})
+			return
+		}
+
 		if filestorage.DefaultFileStorageProvider == nil {
 			phxlog.L.Error("Attempted logo upload, but FileStorageProvider not configured.",
 				zap.String("organizationID", targetOrgID.String()),
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"net/http"
+	"phoenixgrc/backend/internal/auth"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
 	// "strconv" // Removido - não usado
 	"time"    // Adicionado
 
@@ -20,8 +22,11 @@ type UserResponse struct {
 	Email          string         `json:"email"`
 	Role           models.UserRole `json:"role"`
 	IsActive       bool           `json:"is_active"`
+	CustomRoleID   *uuid.UUID     `json:"custom_role_id,omitempty"`
 	SSOProvider    string         `json:"sso_provider,omitempty"`
 	SocialLoginID  string         `json:"social_login_id,omitempty"`
+	LockedUntil    *time.Time     `json:"locked_until,omitempty"` // Login bloqueado por falhas seguidas (ver POST .../unlock)
+	AnonymizedAt   *time.Time     `json:"anonymized_at,omitempty"` // Dados pessoais removidos (ver POST .../anonymize)
 	CreatedAt      string         `json:"created_at"`
 	UpdatedAt      string         `json:"updated_at"`
 }
@@ -31,18 +36,24 @@ func newUserResponse(user models.User) UserResponse {
 	if user.OrganizationID.Valid {
 		orgID = user.OrganizationID.UUID
 	}
-	return UserResponse{
+	response := UserResponse{
 		ID:             user.ID,
 		OrganizationID: orgID,
 		Name:           user.Name,
 		Email:          user.Email,
 		Role:           user.Role,
 		IsActive:       user.IsActive,
+		CustomRoleID:   user.CustomRoleID,
 		SSOProvider:    user.SSOProvider,
 		SocialLoginID:  user.SocialLoginID,
 		CreatedAt:      user.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:      user.UpdatedAt.Format(time.RFC3339),
+		AnonymizedAt:   user.AnonymizedAt,
 	}
+	if user.IsLocked(time.Now()) {
+		response.LockedUntil = user.LockedUntil
+	}
+	return response
 }
 
 func newListUserResponse(users []models.User) []UserResponse {
@@ -53,13 +64,10 @@ func newListUserResponse(users []models.User) []UserResponse {
 	return responses
 }
 
-// checkOrgAdminOrManager verifica se o usuário autenticado é admin ou manager da organização alvo.
-// Esta é uma função helper que pode ser movida para um pacote de utils/auth no futuro.
-func checkOrgAdminOrManager(c *gin.Context, targetOrgID uuid.UUID) bool {
+// checkOrgPermission verifica se o usuário autenticado pertence à organização alvo e tem a permissão (ver rbac).
+func checkOrgPermission(c *gin.Context, targetOrgID uuid.UUID, permission rbac.Permission) bool {
 	tokenOrgID, orgOk := c.Get("organizationID")
-	tokenUserRole, roleOk := c.Get("userRole")
-
-	if !orgOk || !roleOk {
+	if !orgOk {
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Acesso negado: Informações do token ausentes"})
 		return false
 	}
@@ -67,13 +75,7 @@ func checkOrgAdminOrManager(c *gin.Context, targetOrgID uuid.UUID) bool {
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Acesso negado: Você não pertence a esta organização"})
 		return false
 	}
-
-	role := tokenUserRole.(models.UserRole)
-	if role != models.RoleAdmin && role != models.RoleManager {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Acesso negado: Privilégios insuficientes (requer Admin ou Manager da organização)"})
-		return false
-	}
-	return true
+	return rbac.Require(c, permission)
 }
 
 
@@ -86,8 +88,8 @@ func ListOrganizationUsersHandler(c *gin.Context) {
 		return
 	}
 
-	if !checkOrgAdminOrManager(c, targetOrgID) {
-		return // Erro já enviado por checkOrgAdminOrManager
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
+		return // Erro já enviado por checkOrgPermission
 	}
 
 	page, pageSize := GetPaginationParams(c) // Helper de common.go
@@ -140,7 +142,7 @@ func GetOrganizationUserHandler(c *gin.Context) {
 		return
 	}
 
-	if !checkOrgAdminOrManager(c, targetOrgID) {
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
 		return
 	}
 
@@ -180,7 +182,7 @@ func UpdateOrganizationUserRoleHandler(c *gin.Context) {
 
 	actingUserID, _ := c.Get("userID") // ID do usuário que está fazendo a ação
 
-	if !checkOrgAdminOrManager(c, targetOrgID) {
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
 		return
 	}
 
@@ -201,6 +203,12 @@ func UpdateOrganizationUserRoleHandler(c *gin.Context) {
 		return
 	}
 
+	// Conceder ou revogar o papel de admin exige uma permissão própria, que o manager não tem
+	if (payload.Role == models.RoleAdmin || userToUpdate.Role == models.RoleAdmin) && payload.Role != userToUpdate.Role &&
+		!rbac.Require(c, rbac.UsersAssignAdmin) {
+		return
+	}
+
 	// Lógica de prevenção de bloqueio: não permitir que o último admin/manager se rebaixe
 	if userToUpdate.ID == actingUserID.(uuid.UUID) && (userToUpdate.Role == models.RoleAdmin || userToUpdate.Role == models.RoleManager) && payload.Role == models.RoleUser {
 		var adminOrManagerCount int64
@@ -238,8 +246,7 @@ func OrganizationUserLookupHandler(c *gin.Context) {
 	organizationID := orgIDToken.(uuid.UUID)
 
 	// Qualquer usuário autenticado da organização pode acessar este lookup.
-	// Se fosse necessário restringir mais (ex: apenas admin/manager),
-	// a função checkOrgAdminOrManager poderia ser usada aqui.
+	// Se fosse necessário restringir mais, checkOrgPermission poderia ser usada aqui.
 
 	db := database.GetDB()
 	var users []models.User
@@ -290,7 +297,7 @@ func UpdateOrganizationUserStatusHandler(c *gin.Context) {
 
 	actingUserID, _ := c.Get("userID")
 
-	if !checkOrgAdminOrManager(c, targetOrgID) {
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
 		return
 	}
 
@@ -311,6 +318,12 @@ func UpdateOrganizationUserStatusHandler(c *gin.Context) {
 		return
 	}
 
+	// Um usuário anonimizado não tem mais credenciais nem dados pessoais: não pode voltar a ser ativado
+	if userToUpdate.AnonymizedAt != nil && *payload.IsActive {
+		c.JSON(http.StatusConflict, gin.H{"error": "Não é possível reativar um usuário anonimizado."})
+		return
+	}
+
 	// Lógica de prevenção de bloqueio: não permitir desativar o último admin/manager ativo
 	if userToUpdate.ID == actingUserID.(uuid.UUID) && !(*payload.IsActive) && (userToUpdate.Role == models.RoleAdmin || userToUpdate.Role == models.RoleManager) {
 		var activeAdminOrManagerCount int64
@@ -328,5 +341,12 @@ func UpdateOrganizationUserStatusHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar status do usuário: " + err.Error()})
 		return
 	}
+	// Um usuário desativado perde as sessões abertas imediatamente, não só na próxima renovação
+	if !userToUpdate.IsActive {
+		if _, err := auth.RevokeUserSessions(db, userToUpdate.ID, models.SessionRevokedUserDeactivated, nil); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao encerrar as sessões do usuário: " + err.Error()})
+			return
+		}
+	}
 	c.JSON(http.StatusOK, newUserResponse(userToUpdate))
 }
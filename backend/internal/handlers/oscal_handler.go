@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/oscal"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ExportFrameworkOSCALCatalogHandler exports a framework as an OSCAL catalog (JSON), in the official control order.
+func ExportFrameworkOSCALCatalogHandler(c *gin.Context) {
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	// uuid.Nil: sem ordem personalizada, o catálogo segue a sequência oficial
+	controls, err := loadOrderedFrameworkControls(db, uuid.Nil, framework.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list controls for framework: " + err.Error()})
+		return
+	}
+	entries := make([]oscal.CatalogEntry, 0, len(controls))
+	for _, ctrl := range controls {
+		entries = append(entries, oscal.CatalogEntry{ControlID: ctrl.ControlID, Description: ctrl.Description, Family: ctrl.Family})
+	}
+	doc := oscal.BuildCatalog(framework.Name, framework.Version, framework.Description, entries, framework.UpdatedAt)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"oscal_catalog_%s.json\"", framework.ID))
+	c.JSON(http.StatusOK, doc)
+}
+
+// ExportOSCALAssessmentResultsHandler exports the organization's assessments of a framework as OSCAL Assessment Results (JSON).
+func ExportOSCALAssessmentResultsHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, _ := c.Get("organizationID")
+	if tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization's assessments"})
+		return
+	}
+	db := database.GetDB()
+	framework, ok := findAuditFramework(c, db)
+	if !ok {
+		return
+	}
+	var organization models.Organization
+	if err := db.Select("id", "name").First(&organization, "id = ?", targetOrgID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organization: " + err.Error()})
+		return
+	}
+
+	controls, err := loadOrderedFrameworkControls(db, targetOrgID, framework.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list controls for framework: " + err.Error()})
+		return
+	}
+	controlIDs := make([]uuid.UUID, len(controls))
+	for i, ctrl := range controls {
+		controlIDs[i] = ctrl.ID
+	}
+	var assessments []models.AuditAssessment
+	if len(controlIDs) > 0 {
+		if err := db.Scopes(models.OrgWideAssessments).Where("organization_id = ? AND audit_control_id IN ?", targetOrgID, controlIDs).Find(&assessments).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assessments: " + err.Error()})
+			return
+		}
+	}
+	assessmentMap := make(map[uuid.UUID]models.AuditAssessment, len(assessments))
+	for _, a := range assessments {
+		assessmentMap[a.AuditControlID] = a
+	}
+
+	input := oscal.AssessmentResultsInput{
+		OrganizationID:   organization.ID,
+		OrganizationName: organization.Name,
+		FrameworkName:    framework.Name,
+		GeneratedAt:      time.Now(),
+	}
+	for _, ctrl := range controls {
+		assessment, found := assessmentMap[ctrl.ID]
+		if !found || assessment.Status == "" {
+			continue // Controles não avaliados (ou apenas atribuídos) ficam fora dos resultados
+		}
+		assessedAt := assessment.UpdatedAt
+		if assessment.AssessmentDate != nil {
+			assessedAt = *assessment.AssessmentDate
+		}
+		input.Controls = append(input.Controls, oscal.AssessedControl{
+			AssessmentID: assessment.ID,
+			ControlID:    ctrl.ControlID,
+			Description:  ctrl.Description,
+			Status:       assessment.Status,
+			Score:        assessment.Score,
+			AssessedAt:   assessedAt,
+			EvidenceURL:  assessment.EvidenceURL,
+		})
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"oscal_assessment_results_%s.json\"", framework.ID))
+	c.JSON(http.StatusOK, oscal.BuildAssessmentResults(input))
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/passwordpolicy"
+	"phoenixgrc/backend/internal/ratelimit"
+	"phoenixgrc/backend/internal/rbac"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Limites por IP e por conta (email do payload) da rota pública de troca de senha (ver router).
+var (
+	ChangePasswordRateLimiter        = ratelimit.New("change_password", 10, 15*time.Minute)
+	ChangePasswordAccountRateLimiter = ratelimit.New("change_password_account", 5, 15*time.Minute)
+)
+
+// PasswordPolicyPayload defines the organization's password policy.
+type PasswordPolicyPayload struct {
+	MinLength        int  `json:"min_length" binding:"required"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSymbol    bool `json:"require_symbol"`
+	CheckBreached    bool `json:"check_breached"`
+	MaxAgeDays       int  `json:"max_age_days"`
+}
+
+// PasswordPolicyResponse is the policy shown to the frontend; is_default is true while the organization has not configured one.
+type PasswordPolicyResponse struct {
+	models.PasswordPolicy
+	IsDefault bool `json:"is_default"`
+}
+
+func newPasswordPolicyResponse(policy models.PasswordPolicy) PasswordPolicyResponse {
+	return PasswordPolicyResponse{PasswordPolicy: policy, IsDefault: policy.CreatedAt.IsZero()}
+}
+
+// enforcePasswordPolicy aplica a política de senhas da organização (a padrão, se uuid.Nil) e responde 400 com
+// as violações quando a senha não a atende.
+func enforcePasswordPolicy(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, password string) bool {
+	policy, err := passwordpolicy.Load(db, organizationID)
+	if err != nil {
+		phxlog.L.Error("Failed to load password policy", zap.String("organizationID", organizationID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load password policy"})
+		return false
+	}
+	if violations := passwordpolicy.Check(c.Request.Context(), policy, password); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Password does not meet the password policy", "violations": violations})
+		return false
+	}
+	return true
+}
+
+// GetPasswordPolicyHandler returns the organization's password policy (or the default one). Any organization member.
+func GetPasswordPolicyHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, _ := c.Get("organizationID")
+	if orgID, ok := tokenOrgID.(uuid.UUID); !ok || orgID != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied: You do not belong to this organization"})
+		return
+	}
+
+	policy, err := passwordpolicy.Load(database.GetDB(), targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch password policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newPasswordPolicyResponse(policy))
+}
+
+// UpsertPasswordPolicyHandler creates or replaces the organization's password policy (security:manage).
+// Existing passwords stay valid until they expire or are changed; the new rules apply to the next password set.
+func UpsertPasswordPolicyHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.SecurityManage) {
+		return
+	}
+
+	var payload PasswordPolicyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	userID, _ := c.Get("userID")
+	updatedByID := userID.(uuid.UUID)
+	settings := models.PasswordPolicy{
+		OrganizationID:   targetOrgID,
+		MinLength:        payload.MinLength,
+		RequireUppercase: payload.RequireUppercase,
+		RequireLowercase: payload.RequireLowercase,
+		RequireDigit:     payload.RequireDigit,
+		RequireSymbol:    payload.RequireSymbol,
+		CheckBreached:    payload.CheckBreached,
+		MaxAgeDays:       payload.MaxAgeDays,
+		UpdatedByID:      &updatedByID,
+	}
+	if err := passwordpolicy.CheckSettings(settings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid password policy: " + err.Error()})
+		return
+	}
+
+	var previous models.PasswordPolicy
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		previous, err = passwordpolicy.Load(tx, targetOrgID)
+		if err != nil {
+			return err
+		}
+		if previous.CreatedAt.IsZero() {
+			err = tx.Create(&settings).Error
+		} else {
+			settings.CreatedAt = previous.CreatedAt
+			err = tx.Save(&settings).Error
+		}
+		if err != nil {
+			return err
+		}
+		_, err = recordAuditTrail(c, tx, targetOrgID, models.AuditTrailPasswordPolicyUpdated, "password_policy", &targetOrgID,
+			fmt.Sprintf("Password policy set (minimum length %d, breached check %t, max age %d days)", settings.MinLength, settings.CheckBreached, settings.MaxAgeDays),
+			gin.H{"previous": previous, "current": settings})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save password policy: " + err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if previous.CreatedAt.IsZero() {
+		status = http.StatusCreated
+	}
+	c.JSON(status, newPasswordPolicyResponse(settings))
+}
+
+// GetDefaultPasswordPolicyHandler returns the default password policy, used before an organization exists (initial setup).
+func GetDefaultPasswordPolicyHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, newPasswordPolicyResponse(passwordpolicy.Default()))
+}
+
+type ChangePasswordPayload struct {
+	Email           string `json:"email" binding:"required,email"`
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// ChangePasswordHandler troca a senha com a senha atual. É pública para atender quem teve o login recusado
+// por senha expirada; as sessões abertas são encerradas e o usuário entra de novo com a nova senha.
+func ChangePasswordHandler(c *gin.Context) {
+	log := phxlog.L.Named("ChangePasswordHandler")
+	var payload ChangePasswordPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.Where("email = ? AND is_active = ?", payload.Email, true).First(&user).Error; err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		return
+	}
+	// A troca também confere a senha atual: conta no bloqueio por falhas seguidas, como o login
+	if respondIfLoginLocked(c, &user) {
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(payload.CurrentPassword)); err != nil {
+		handleLoginFailure(c, db, &user, "Invalid email or password")
+		return
+	}
+	if disabled, err := passwordLoginDisabled(db, &user); err != nil {
+		log.Error("Failed to check login page settings", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process password change"})
+		return
+	} else if disabled {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Password login is disabled for your organization; use single sign-on"})
+		return
+	}
+	if payload.NewPassword == payload.CurrentPassword {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "New password must be different from the current password"})
+		return
+	}
+	if !enforcePasswordPolicy(c, db, user.OrganizationID.UUID, payload.NewPassword) {
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error("Failed to hash new password", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process new password"})
+		return
+	}
+	// A senha atual é conferida de novo no UPDATE: só uma troca concorrente vence
+	result := db.Model(&models.User{}).Where("id = ? AND password_hash = ?", user.ID, user.PasswordHash).
+		Updates(map[string]interface{}{"password_hash": string(hashedPassword), "password_changed_at": time.Now()})
+	if result.Error != nil {
+		log.Error("Failed to update password", zap.Error(result.Error))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update password"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		return
+	}
+
+	if _, err := auth.RevokeUserSessions(db, user.ID, models.SessionRevokedPasswordChange, nil); err != nil {
+		log.Error("Failed to revoke sessions after password change", zap.String("userID", user.ID.String()), zap.Error(err))
+	}
+	recordPasswordResetAudit(c, db, user, models.AuditTrailPasswordChanged,
+		fmt.Sprintf("Senha de %s alterada pelo próprio usuário", user.Email))
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been changed successfully."})
+}
+
+// passwordExpired indica se a senha do usuário passou da validade da política da organização.
+func passwordExpired(db *gorm.DB, user *models.User) (bool, error) {
+	if !user.OrganizationID.Valid {
+		return false, nil
+	}
+	policy, err := passwordpolicy.Load(db, user.OrganizationID.UUID)
+	if err != nil {
+		return false, err
+	}
+	return passwordpolicy.Expired(policy, passwordpolicy.ChangedAt(user), time.Now()), nil
+}
@@ -2,24 +2,86 @@ package handlers
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
+	"phoenixgrc/backend/internal/auth"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
 	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/internal/passwordpolicy"
+	"phoenixgrc/backend/internal/ratelimit"
 	phxlog "phoenixgrc/backend/pkg/log"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+const (
+	// passwordResetTokenTTL é a validade do link de redefinição de senha.
+	passwordResetTokenTTL = time.Hour
+	// maxPasswordResetsPerAccount limita os e-mails de redefinição por conta em passwordResetTokenTTL; os
+	// pedidos além do limite recebem a mesma resposta, sem e-mail.
+	maxPasswordResetsPerAccount = 3
+)
+
+// Limites por IP das rotas públicas de redefinição de senha (ver router).
+var (
+	ForgotPasswordRateLimiter = ratelimit.New("forgot_password", 10, 15*time.Minute)
+	ResetPasswordRateLimiter  = ratelimit.New("reset_password", 20, 15*time.Minute)
+)
+
+const forgotPasswordResponse = "If an account with that email exists, a password reset link has been sent."
+
 type ForgotPasswordPayload struct {
 	Email string `json:"email" binding:"required,email"`
 }
 
+// hashPasswordResetToken retorna o hash armazenado para o token de redefinição de senha.
+func hashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// passwordResetURL monta o link enviado ao usuário. A configuração de sistema FRONTEND_BASE_URL, se definida,
+// tem precedência sobre a variável de ambiente.
+func passwordResetURL(db *gorm.DB, token string) string {
+	path := "/auth/reset-password?token=" + token
+	if frontendBaseURL, _ := models.GetSystemSetting(db, "FRONTEND_BASE_URL"); frontendBaseURL != "" {
+		return strings.TrimSuffix(frontendBaseURL, "/") + path
+	}
+	return notifications.FrontendLink(path)
+}
+
+// recordPasswordResetAudit registra o evento na trilha de auditoria da organização do usuário; usuários sem
+// organização ficam só no log.
+func recordPasswordResetAudit(c *gin.Context, db *gorm.DB, user models.User, action models.AuditTrailAction, summary string) {
+	if !user.OrganizationID.Valid {
+		return
+	}
+	if _, err := recordAuditTrail(c, db, user.OrganizationID.UUID, action, "user", &user.ID, summary,
+		gin.H{"user_email": user.Email, "ip": c.ClientIP()}); err != nil {
+		phxlog.L.Error("Failed to record password reset in audit trail", zap.String("userID", user.ID.String()), zap.Error(err))
+	}
+}
+
+// findPasswordResetToken carrega o token válido (não usado e não expirado), com o usuário.
+func findPasswordResetToken(db *gorm.DB, token string) (*models.PasswordResetToken, bool) {
+	var resetToken models.PasswordResetToken
+	if err := db.Where("token_hash = ? AND expires_at > ?", hashPasswordResetToken(token), time.Now()).
+		Preload("User").First(&resetToken).Error; err != nil {
+		return nil, false
+	}
+	return &resetToken, true
+}
+
 // ForgotPasswordHandler inicia o processo de reset de senha.
 func ForgotPasswordHandler(c *gin.Context) {
 	log := phxlog.L.Named("ForgotPasswordHandler")
@@ -31,10 +93,25 @@ func ForgotPasswordHandler(c *gin.Context) {
 
 	db := database.GetDB()
 	var user models.User
-	if err := db.Where("email = ?", payload.Email).First(&user).Error; err != nil {
-		// Não revele se o e-mail existe ou não.
+	if err := db.Where("email = ? AND is_active = ?", payload.Email, true).First(&user).Error; err != nil {
+		// Não revele se o e-mail existe (ou se a conta está inativa).
 		log.Info("Password reset requested for non-existent email", zap.String("email", payload.Email))
-		c.JSON(http.StatusOK, gin.H{"message": "If an account with that email exists, a password reset link has been sent."})
+		c.JSON(http.StatusOK, gin.H{"message": forgotPasswordResponse})
+		return
+	}
+
+	// Os tokens já usados (soft delete) também contam no limite
+	var recent int64
+	if err := db.Unscoped().Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND created_at > ?", user.ID, time.Now().Add(-passwordResetTokenTTL)).
+		Count(&recent).Error; err != nil {
+		log.Error("Failed to count recent password reset tokens", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save token"})
+		return
+	}
+	if recent >= maxPasswordResetsPerAccount {
+		log.Warn("Password reset rate limit reached for account", zap.String("userID", user.ID.String()), zap.String("ip", c.ClientIP()))
+		c.JSON(http.StatusOK, gin.H{"message": forgotPasswordResponse})
 		return
 	}
 
@@ -48,9 +125,9 @@ func ForgotPasswordHandler(c *gin.Context) {
 	token := hex.EncodeToString(tokenBytes)
 
 	resetToken := models.PasswordResetToken{
-		Token:     token,
+		TokenHash: hashPasswordResetToken(token),
 		UserID:    user.ID,
-		ExpiresAt: time.Now().Add(1 * time.Hour), // Token válido por 1 hora
+		ExpiresAt: time.Now().Add(passwordResetTokenTTL),
 	}
 
 	if err := db.Create(&resetToken).Error; err != nil {
@@ -59,28 +136,42 @@ func ForgotPasswordHandler(c *gin.Context) {
 		return
 	}
 
-	// Enviar e-mail de reset de senha
-	frontendBaseURL, _ := models.GetSystemSetting(db, "FRONTEND_BASE_URL")
-	if frontendBaseURL == "" {
-		frontendBaseURL = "http://localhost" // Fallback
-	}
-	resetLink := fmt.Sprintf("%s/auth/reset-password?token=%s", frontendBaseURL, token)
-
-	bodyHTML := fmt.Sprintf(`
-        <h2>Password Reset Request</h2>
-        <p>You requested a password reset. Click the link below to reset your password:</p>
-        <p><a href="%s">Reset Password</a></p>
-        <p>This link is valid for 1 hour. If you did not request this, please ignore this email.</p>
-    `, resetLink)
-
-	// O corpo do e-mail para SES pode ser o mesmo para HTML e Texto por simplicidade,
-	// ou você pode criar versões diferentes. Usando bodyHTML para ambos.
-	if err := notifications.DefaultEmailNotifier.Send(c.Request.Context(), user.Email, "Password Reset Request", bodyHTML); err != nil {
+	// Enviar e-mail de reset de senha, com a identidade visual da organização do usuário
+	data := templates.PasswordReset{ExpiresInMinutes: int(passwordResetTokenTTL.Minutes()), Link: passwordResetURL(db, token)}
+	if err := notifications.EmailWithTemplate(c.Request.Context(), user.OrganizationID.UUID, user.Email, user.Name, templates.NamePasswordReset, data); err != nil {
 		log.Error("Failed to send password reset email", zap.Error(err))
 		// Não retorne o erro ao usuário por segurança.
 	}
+	recordPasswordResetAudit(c, db, user, models.AuditTrailPasswordResetRequest,
+		fmt.Sprintf("Redefinição de senha solicitada para %s", user.Email))
+
+	c.JSON(http.StatusOK, gin.H{"message": forgotPasswordResponse})
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "If an account with that email exists, a password reset link has been sent."})
+type VerifyResetTokenPayload struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// VerifyResetTokenHandler informa se o token de redefinição ainda é válido, para a tela de nova senha.
+func VerifyResetTokenHandler(c *gin.Context) {
+	var payload VerifyResetTokenPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	resetToken, ok := findPasswordResetToken(database.GetDB(), payload.Token)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+	policy, err := passwordpolicy.Load(database.GetDB(), resetToken.User.OrganizationID.UUID)
+	if err != nil {
+		phxlog.L.Error("Failed to load password policy", zap.String("userID", resetToken.UserID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load password policy"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"valid": true, "email": resetToken.User.Email, "expires_at": resetToken.ExpiresAt,
+		"password_policy": newPasswordPolicyResponse(policy)})
 }
 
 type ResetPasswordPayload struct {
@@ -98,16 +189,14 @@ func ResetPasswordHandler(c *gin.Context) {
 	}
 
 	db := database.GetDB()
-	var resetToken models.PasswordResetToken
-	if err := db.Where("token = ?", payload.Token).Preload("User").First(&resetToken).Error; err != nil {
+	resetToken, ok := findPasswordResetToken(db, payload.Token)
+	if !ok {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
 		return
 	}
 
-	if time.Now().After(resetToken.ExpiresAt) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
-		// Opcional: deletar o token expirado
-		db.Delete(&resetToken)
+	user := resetToken.User
+	if !enforcePasswordPolicy(c, db, user.OrganizationID.UUID, payload.Password) {
 		return
 	}
 
@@ -119,16 +208,36 @@ func ResetPasswordHandler(c *gin.Context) {
 		return
 	}
 
-	user := resetToken.User
-	user.PasswordHash = string(hashedPassword)
-	if err := db.Save(&user).Error; err != nil {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Invalidar o token usado e os demais tokens pendentes do usuário; só um pedido concorrente vence
+		result := tx.Where("id = ?", resetToken.ID).Delete(&models.PasswordResetToken{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&user).Updates(map[string]interface{}{"password_hash": string(hashedPassword), "password_changed_at": time.Now()}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+	if err != nil {
 		log.Error("Failed to update password", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update password"})
 		return
 	}
 
-	// Invalidar o token após o uso
-	db.Delete(&resetToken)
+	// Quem tinha a senha antiga pode ter sessões abertas: todas são encerradas
+	if _, err := auth.RevokeUserSessions(db, user.ID, models.SessionRevokedPasswordReset, nil); err != nil {
+		log.Error("Failed to revoke sessions after password reset", zap.String("userID", user.ID.String()), zap.Error(err))
+	}
+	recordPasswordResetAudit(c, db, user, models.AuditTrailPasswordResetComplete,
+		fmt.Sprintf("Senha de %s redefinida pelo link enviado por e-mail", user.Email))
 
 	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset successfully."})
 }
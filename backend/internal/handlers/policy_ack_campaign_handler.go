@@ -0,0 +1,387 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/preflight"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PolicyAckCampaignPayload defines the structure for launching a policy acknowledgment campaign.
+type PolicyAckCampaignPayload struct {
+	Title                string   `json:"title" binding:"required,min=3,max=255"`
+	Message              string   `json:"message"`
+	DueDate              string   `json:"due_date" binding:"required,datetime=2006-01-02"`
+	AllUsers             bool     `json:"all_users"`
+	UserIDs              []string `json:"user_ids"`
+	ReminderIntervalDays int      `json:"reminder_interval_days" binding:"omitempty,min=1,max=30"`
+}
+
+// PolicyAckCampaignReport é o relatório de conclusão de uma campanha.
+type PolicyAckCampaignReport struct {
+	Campaign       models.PolicyAckCampaign     `json:"campaign"`
+	TotalTargets   int                          `json:"total_targets"`
+	Acknowledged   int                          `json:"acknowledged"`
+	Pending        int                          `json:"pending"`
+	CompletionRate float64                      `json:"completion_rate"` // Percentual (0-100)
+	IsOverdue      bool                         `json:"is_overdue"`
+	Users          []PolicyAcknowledgmentStatus `json:"users"`
+}
+
+// policyAckCampaignLaunch é um lançamento de campanha validado.
+type policyAckCampaignLaunch struct {
+	policy    *models.Policy
+	payload   PolicyAckCampaignPayload
+	dueDate   time.Time
+	targetIDs []uuid.UUID // Vazio quando AllUsers
+}
+
+// fingerprint vincula o token de confirmação à política e ao payload do lançamento.
+func (l *policyAckCampaignLaunch) fingerprint() string {
+	payload, _ := json.Marshal(l.payload)
+	return preflight.Fingerprint([]byte(l.policy.ID.String()), payload)
+}
+
+// parsePolicyAckCampaignLaunch valida o payload do lançamento e resolve os usuários alvo.
+func parsePolicyAckCampaignLaunch(c *gin.Context, db *gorm.DB) (*policyAckCampaignLaunch, bool) {
+	var payload PolicyAckCampaignPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return nil, false
+	}
+	policy, ok := findOrgPolicy(c, db)
+	if !ok || !canManagePolicy(c, policy) {
+		return nil, false
+	}
+	if policy.Status != models.PolicyStatusApproved || policy.CurrentVersionID == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Acknowledgment campaigns require an approved policy version"})
+		return nil, false
+	}
+
+	dueDate, _ := time.Parse("2006-01-02", payload.DueDate)
+	dueDate = dueDate.Add(24*time.Hour - time.Second) // Fim do dia limite
+	if dueDate.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "due_date must not be in the past"})
+		return nil, false
+	}
+	if !payload.AllUsers && len(payload.UserIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide user_ids or set all_users to true"})
+		return nil, false
+	}
+
+	var targetIDs []uuid.UUID
+	if !payload.AllUsers {
+		seen := make(map[uuid.UUID]bool)
+		for _, idStr := range payload.UserIDs {
+			userID, err := parseOrgUserID(db, policy.OrganizationID, idStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid user in user_ids (%s): %s", idStr, err.Error())})
+				return nil, false
+			}
+			if !seen[userID] {
+				seen[userID] = true
+				targetIDs = append(targetIDs, userID)
+			}
+		}
+	}
+	return &policyAckCampaignLaunch{policy: policy, payload: payload, dueDate: dueDate, targetIDs: targetIDs}, true
+}
+
+// estimatePolicyAckCampaign conta os usuários alvo e os e-mails do primeiro envio (alvos ativos que ainda
+// não aceitaram a versão vigente).
+func estimatePolicyAckCampaign(db *gorm.DB, launch *policyAckCampaignLaunch) (preflight.Estimate, error) {
+	query := db.Model(&models.User{}).Where("users.organization_id = ? AND users.is_active = ?", launch.policy.OrganizationID, true)
+	if !launch.payload.AllUsers {
+		query = query.Where("users.id IN ?", launch.targetIDs)
+	}
+	var targets, emails int64
+	if err := query.Session(&gorm.Session{}).Count(&targets).Error; err != nil {
+		return preflight.Estimate{}, err
+	}
+	err := query.Where("users.email <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM policy_acknowledgments pa WHERE pa.user_id = users.id AND pa.policy_version_id = ?)", *launch.policy.CurrentVersionID).
+		Count(&emails).Error
+	if err != nil {
+		return preflight.Estimate{}, err
+	}
+	return preflight.Estimate{
+		Operation:       bulkOperationPolicyAckCampaign,
+		AffectedRecords: int(targets),
+		Notifications:   int(emails),
+		Details:         map[string]int{"targets": int(targets), "already_acknowledged": int(targets - emails)},
+	}, nil
+}
+
+// PreflightPolicyAckCampaignHandler estimates a campaign launch (same payload as CreatePolicyAckCampaignHandler)
+// without creating it, returning a confirm token when required.
+func PreflightPolicyAckCampaignHandler(c *gin.Context) {
+	db := database.GetDB()
+	launch, ok := parsePolicyAckCampaignLaunch(c, db)
+	if !ok {
+		return
+	}
+	estimate, err := estimatePolicyAckCampaign(db, launch)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to estimate acknowledgment campaign: " + err.Error()})
+		return
+	}
+	respondBulkPreflight(c, db, estimate, launch.fingerprint())
+}
+
+// CreatePolicyAckCampaignHandler launches a campaign requiring users to acknowledge the policy's current version by a deadline.
+// Os usuários alvo recebem o primeiro e-mail imediatamente; os lembretes seguintes são enviados pelo agendador.
+// Campanhas acima do limite de confirmação exigem o X-Confirm-Token retornado pelo preflight.
+func CreatePolicyAckCampaignHandler(c *gin.Context) {
+	db := database.GetDB()
+	launch, ok := parsePolicyAckCampaignLaunch(c, db)
+	if !ok {
+		return
+	}
+	estimate, err := estimatePolicyAckCampaign(db, launch)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to estimate acknowledgment campaign: " + err.Error()})
+		return
+	}
+	confirmed, ok := confirmBulkOperation(c, db, &estimate, launch.fingerprint())
+	if !ok {
+		return
+	}
+	policy, payload, dueDate, targetIDs := launch.policy, launch.payload, launch.dueDate, launch.targetIDs
+
+	userID, _ := c.Get("userID")
+	campaign := models.PolicyAckCampaign{
+		OrganizationID:       policy.OrganizationID,
+		PolicyID:             policy.ID,
+		PolicyVersionID:      *policy.CurrentVersionID,
+		Title:                payload.Title,
+		Message:              payload.Message,
+		DueDate:              dueDate,
+		Status:               models.PolicyAckCampaignOpen,
+		AllUsers:             payload.AllUsers,
+		ReminderIntervalDays: payload.ReminderIntervalDays,
+		CreatedByID:          userID.(uuid.UUID),
+	}
+	if campaign.ReminderIntervalDays == 0 {
+		campaign.ReminderIntervalDays = 3
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&campaign).Error; err != nil {
+			return err
+		}
+		if len(targetIDs) == 0 {
+			return nil
+		}
+		targets := make([]models.PolicyAckCampaignTarget, 0, len(targetIDs))
+		for _, id := range targetIDs {
+			targets = append(targets, models.PolicyAckCampaignTarget{CampaignID: campaign.ID, UserID: id})
+		}
+		return tx.Create(&targets).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create acknowledgment campaign: " + err.Error()})
+		return
+	}
+
+	sent, err := notifications.SendPolicyAckCampaignReminders(c.Request.Context(), campaign)
+	if err != nil {
+		phxlog.L.Error("Failed to send initial policy acknowledgment campaign emails",
+			zap.String("campaignID", campaign.ID.String()),
+			zap.Error(err))
+	}
+	recordBulkOperation(c, db, bulkOperationPolicyAckCampaign, estimate.AffectedRecords, sent, confirmed)
+	c.JSON(http.StatusCreated, campaign)
+}
+
+// ListPolicyAckCampaignsHandler lists the organization's acknowledgment campaigns. Filtros: status, policy_id.
+func ListPolicyAckCampaignsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Where("organization_id = ?", orgID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if policyID := c.Query("policy_id"); policyID != "" {
+		query = query.Where("policy_id = ?", policyID)
+	}
+	var campaigns []models.PolicyAckCampaign
+	if err := query.Order("due_date asc").Find(&campaigns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list acknowledgment campaigns: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, campaigns)
+}
+
+// findOrgPolicyAckCampaign carrega a campanha de :campaignId e verifica se o usuário pode gerenciá-la.
+func findOrgPolicyAckCampaign(c *gin.Context, db *gorm.DB) (*models.PolicyAckCampaign, bool) {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var campaign models.PolicyAckCampaign
+	if err := db.Preload("Policy").Where("id = ? AND organization_id = ?", campaignID, orgID).First(&campaign).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Acknowledgment campaign not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch acknowledgment campaign: " + err.Error()})
+		return nil, false
+	}
+	if !canManagePolicy(c, &campaign.Policy) {
+		return nil, false
+	}
+	return &campaign, true
+}
+
+// GetPolicyAckCampaignReportHandler returns the completion report of a campaign (JSON, or CSV with ?format=csv).
+func GetPolicyAckCampaignReportHandler(c *gin.Context) {
+	db := database.GetDB()
+	campaign, ok := findOrgPolicyAckCampaign(c, db)
+	if !ok {
+		return
+	}
+
+	query := db.Table("users").
+		Select("users.id AS user_id, users.name, users.email, pa.acknowledged_at").
+		Joins("LEFT JOIN policy_acknowledgments pa ON pa.user_id = users.id AND pa.policy_version_id = ?", campaign.PolicyVersionID).
+		Where("users.organization_id = ?", campaign.OrganizationID)
+	if campaign.AllUsers {
+		query = query.Where("users.is_active = ?", true)
+	} else {
+		query = query.Joins("JOIN policy_ack_campaign_targets t ON t.user_id = users.id AND t.campaign_id = ?", campaign.ID)
+	}
+	var users []PolicyAcknowledgmentStatus
+	if err := query.Order("users.name asc").Scan(&users).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build campaign report: " + err.Error()})
+		return
+	}
+
+	report := PolicyAckCampaignReport{Campaign: *campaign, TotalTargets: len(users), Users: users}
+	for _, u := range users {
+		if u.AcknowledgedAt != nil {
+			report.Acknowledged++
+		}
+	}
+	report.Pending = report.TotalTargets - report.Acknowledged
+	if report.TotalTargets > 0 {
+		report.CompletionRate = float64(report.Acknowledged) * 100 / float64(report.TotalTargets)
+	}
+	report.IsOverdue = campaign.Status == models.PolicyAckCampaignOpen && report.Pending > 0 && time.Now().After(campaign.DueDate)
+
+	if c.Query("format") == "csv" {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"policy_ack_campaign_%s.csv\"", campaign.ID))
+		w := csv.NewWriter(c.Writer)
+		_ = w.Write([]string{"user_id", "name", "email", "acknowledged_at", "on_time"})
+		for _, u := range users {
+			acknowledgedAt, onTime := "", "false"
+			if u.AcknowledgedAt != nil {
+				acknowledgedAt = u.AcknowledgedAt.UTC().Format(time.RFC3339)
+				if !u.AcknowledgedAt.After(campaign.DueDate) {
+					onTime = "true"
+				}
+			}
+			_ = w.Write([]string{u.UserID.String(), u.Name, u.Email, acknowledgedAt, onTime})
+		}
+		w.Flush()
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// estimatePolicyAckReminders conta os lembretes que seriam enviados agora (usuários pendentes com e-mail).
+func estimatePolicyAckReminders(c *gin.Context, db *gorm.DB, campaign *models.PolicyAckCampaign) (preflight.Estimate, error) {
+	pending, err := notifications.PolicyAckCampaignPendingUsers(c.Request.Context(), db, *campaign)
+	if err != nil {
+		return preflight.Estimate{}, err
+	}
+	emails := 0
+	for _, user := range pending {
+		if user.Email != "" {
+			emails++
+		}
+	}
+	return preflight.Estimate{Operation: bulkOperationPolicyAckReminders, AffectedRecords: emails, Notifications: emails}, nil
+}
+
+// PreflightPolicyAckRemindersHandler estimates an immediate reminder round, returning a confirm token when required.
+func PreflightPolicyAckRemindersHandler(c *gin.Context) {
+	db := database.GetDB()
+	campaign, ok := findOrgPolicyAckCampaign(c, db)
+	if !ok {
+		return
+	}
+	if campaign.Status != models.PolicyAckCampaignOpen {
+		c.JSON(http.StatusConflict, gin.H{"error": "Campaign is closed"})
+		return
+	}
+	estimate, err := estimatePolicyAckReminders(c, db, campaign)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to estimate reminders: " + err.Error()})
+		return
+	}
+	respondBulkPreflight(c, db, estimate, preflight.Fingerprint([]byte(campaign.ID.String())))
+}
+
+// SendPolicyAckCampaignRemindersHandler sends reminder emails to pending users immediately.
+// Rodadas acima do limite de confirmação exigem o X-Confirm-Token retornado pelo preflight.
+func SendPolicyAckCampaignRemindersHandler(c *gin.Context) {
+	db := database.GetDB()
+	campaign, ok := findOrgPolicyAckCampaign(c, db)
+	if !ok {
+		return
+	}
+	if campaign.Status != models.PolicyAckCampaignOpen {
+		c.JSON(http.StatusConflict, gin.H{"error": "Campaign is closed"})
+		return
+	}
+	estimate, err := estimatePolicyAckReminders(c, db, campaign)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to estimate reminders: " + err.Error()})
+		return
+	}
+	confirmed, ok := confirmBulkOperation(c, db, &estimate, preflight.Fingerprint([]byte(campaign.ID.String())))
+	if !ok {
+		return
+	}
+	sent, err := notifications.SendPolicyAckCampaignReminders(c.Request.Context(), *campaign)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send reminders: " + err.Error()})
+		return
+	}
+	recordBulkOperation(c, db, bulkOperationPolicyAckReminders, sent, sent, confirmed)
+	c.JSON(http.StatusOK, gin.H{"reminders_sent": sent})
+}
+
+// ClosePolicyAckCampaignHandler closes a campaign; nenhum lembrete adicional é enviado.
+func ClosePolicyAckCampaignHandler(c *gin.Context) {
+	db := database.GetDB()
+	campaign, ok := findOrgPolicyAckCampaign(c, db)
+	if !ok {
+		return
+	}
+	if campaign.Status == models.PolicyAckCampaignClosed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Campaign is already closed"})
+		return
+	}
+	now := time.Now()
+	campaign.Status = models.PolicyAckCampaignClosed
+	campaign.ClosedAt = &now
+	if err := db.Omit("Policy").Save(campaign).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to close campaign: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, campaign)
+}
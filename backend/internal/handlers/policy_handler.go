@@ -0,0 +1,669 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"phoenixgrc/backend/internal/avscan"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/trash"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PolicyPayload defines the structure for creating or updating a policy.
+type PolicyPayload struct {
+	Title               string `json:"title" binding:"required,min=3,max=255"`
+	Description         string `json:"description"`
+	OwnerID             string `json:"owner_id"`
+	ReviewCadenceMonths int    `json:"review_cadence_months" binding:"omitempty,min=1,max=60"`
+}
+
+// SubmitPolicyVersionPayload permite indicar o aprovador; por padrão é o dono da política.
+type SubmitPolicyVersionPayload struct {
+	ApproverID string `json:"approver_id"`
+}
+
+// PolicyControlsPayload substitui o conjunto de controles atendidos pela política.
+type PolicyControlsPayload struct {
+	AuditControlIDs []string `json:"audit_control_ids"`
+}
+
+// PolicyAcknowledgmentStatus é uma linha do relatório de aceite de uma versão.
+type PolicyAcknowledgmentStatus struct {
+	UserID         uuid.UUID  `json:"user_id"`
+	Name           string     `json:"name"`
+	Email          string     `json:"email"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+// findOrgPolicy carrega a política de :policyId pertencente à organização do token.
+// Responde com erro e retorna false se não encontrada.
+func findOrgPolicy(c *gin.Context, db *gorm.DB) (*models.Policy, bool) {
+	policyID, err := uuid.Parse(c.Param("policyId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var policy models.Policy
+	if err := db.Where("id = ? AND organization_id = ?", policyID, orgID).First(&policy).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Policy not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch policy: " + err.Error()})
+		return nil, false
+	}
+	return &policy, true
+}
+
+// canManagePolicy retorna true para o dono da política e para quem tem policies:manage.
+func canManagePolicy(c *gin.Context, policy *models.Policy) bool {
+	userID, _ := c.Get("userID")
+	if policy.OwnerID == userID.(uuid.UUID) || rbac.Can(c, rbac.PoliciesManage) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "Only the policy owner, admins or managers can manage this policy"})
+	return false
+}
+
+// parseOrgUserID valida que o ID informado pertence a um usuário ativo da organização.
+func parseOrgUserID(db *gorm.DB, organizationID uuid.UUID, idStr string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format")
+	}
+	var count int64
+	db.Model(&models.User{}).Where("id = ? AND organization_id = ? AND is_active = ?", userID, organizationID, true).Count(&count)
+	if count == 0 {
+		return uuid.Nil, fmt.Errorf("user not found or not an active member of your organization")
+	}
+	return userID, nil
+}
+
+// checkPolicyDocumentUpload aplica às versões de política as mesmas regras dos arquivos de evidência:
+// tamanho máximo, tipos permitidos e verificação antivírus.
+func checkPolicyDocumentUpload(c *gin.Context, file multipart.File, header *multipart.FileHeader) bool {
+	if header.Size > maxEvidenceFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("File size exceeds limit of %d MB", maxEvidenceFileSize/(1024*1024))})
+		return false
+	}
+
+	buffer := make([]byte, 512)
+	if _, err := file.Read(buffer); err != nil && err != io.EOF {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file for MIME type detection"})
+		return false
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset file pointer"})
+		return false
+	}
+	mimeType := http.DetectContentType(buffer)
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	isOfficeZip := mimeType == "application/zip" && (ext == ".docx" || ext == ".xlsx")
+	if !allowedMimeTypes[mimeType] && !isOfficeZip {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("File type '%s' (detected: '%s') is not allowed", header.Filename, mimeType)})
+		return false
+	}
+
+	scanResult, err := avscan.ScanUpload(c.Request.Context(), header.Filename, file)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to scan policy document for malware: " + err.Error()})
+		return false
+	}
+	if scanResult.Verdict == avscan.VerdictInfected {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Policy document rejected: malware detected", "signature": scanResult.Signature})
+		return false
+	}
+	return true
+}
+
+// CreatePolicyHandler creates a new policy in draft status.
+func CreatePolicyHandler(c *gin.Context) {
+	var payload PolicyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	if !rbac.Require(c, rbac.PoliciesManage) {
+		return
+	}
+	db := database.GetDB()
+
+	ownerID := userID.(uuid.UUID)
+	if payload.OwnerID != "" {
+		parsedOwnerID, err := parseOrgUserID(db, organizationID, payload.OwnerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid owner_id: " + err.Error()})
+			return
+		}
+		ownerID = parsedOwnerID
+	}
+
+	policy := models.Policy{
+		OrganizationID:      organizationID,
+		Title:               payload.Title,
+		Description:         payload.Description,
+		OwnerID:             ownerID,
+		Status:              models.PolicyStatusDraft,
+		ReviewCadenceMonths: payload.ReviewCadenceMonths,
+	}
+	if policy.ReviewCadenceMonths == 0 {
+		policy.ReviewCadenceMonths = 12
+	}
+	if err := db.Create(&policy).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, policy)
+}
+
+// ListPoliciesHandler lists the organization's policies with pagination.
+// Filtros opcionais: status, owner_id, due_for_review=true (revisão vencida).
+func ListPoliciesHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	page, pageSize := GetPaginationParams(c)
+	db := database.GetDB().WithContext(c.Request.Context())
+
+	query := db.Model(&models.Policy{}).Where("organization_id = ?", orgID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if ownerID := c.Query("owner_id"); ownerID != "" {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+	if c.Query("due_for_review") == "true" {
+		query = query.Where("status = ? AND next_review_date <= ?", models.PolicyStatusApproved, time.Now())
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count policies: " + err.Error()})
+		return
+	}
+	var policies []models.Policy
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("title asc").Find(&policies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list policies: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      policies,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// GetPolicyHandler returns a policy with its versions and mapped controls.
+func GetPolicyHandler(c *gin.Context) {
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok {
+		return
+	}
+	if err := db.Preload("Versions", func(db *gorm.DB) *gorm.DB { return db.Order("version_number desc") }).
+		Preload("Controls.AuditControl").First(policy, "id = ?", policy.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load policy details: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicyHandler updates a policy's metadata (title, owner, review cadence).
+func UpdatePolicyHandler(c *gin.Context) {
+	var payload PolicyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok || !canManagePolicy(c, policy) {
+		return
+	}
+
+	policy.Title = payload.Title
+	policy.Description = payload.Description
+	if payload.OwnerID != "" {
+		ownerID, err := parseOrgUserID(db, policy.OrganizationID, payload.OwnerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid owner_id: " + err.Error()})
+			return
+		}
+		policy.OwnerID = ownerID
+	}
+	if payload.ReviewCadenceMonths != 0 && payload.ReviewCadenceMonths != policy.ReviewCadenceMonths {
+		policy.ReviewCadenceMonths = payload.ReviewCadenceMonths
+		// Recalcula a próxima revisão a partir da aprovação da versão vigente.
+		if policy.Status == models.PolicyStatusApproved && policy.CurrentVersionID != nil {
+			var current models.PolicyVersion
+			if err := db.First(&current, "id = ?", *policy.CurrentVersionID).Error; err == nil && current.DecidedAt != nil {
+				next := current.DecidedAt.AddDate(0, policy.ReviewCadenceMonths, 0)
+				policy.NextReviewDate = &next
+			}
+		}
+	}
+
+	if err := db.Save(policy).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// DeletePolicyHandler deletes a policy, its versions, control mappings and acknowledgments.
+func DeletePolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.PoliciesDelete) {
+		return
+	}
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok {
+		return
+	}
+
+	// Exclusão lógica: a política vai para a lixeira com as versões, os aceites e os arquivos, que só são
+	// removidos no expurgo (internal/trash)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(policy).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, policy.OrganizationID, models.AuditTrailPolicyDeleted, "policy", &policy.ID,
+			fmt.Sprintf("Policy %s moved to the trash", policy.ReferenceCode), nil)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Policy deleted successfully", "purge_at": trash.PurgeAt(policy.DeletedAt.Time)})
+}
+
+// UploadPolicyVersionHandler uploads a new version of the policy document (multipart: file, changelog).
+func UploadPolicyVersionHandler(c *gin.Context) {
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok || !canManagePolicy(c, policy) {
+		return
+	}
+	if policy.Status == models.PolicyStatusRetired {
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot add versions to a retired policy"})
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Policy document not provided in 'file' field: " + err.Error()})
+		return
+	}
+	defer file.Close()
+	if !checkPolicyDocumentUpload(c, file, header) {
+		return
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+
+	var maxVersion int
+	db.Model(&models.PolicyVersion{}).Where("policy_id = ?", policy.ID).Select("COALESCE(MAX(version_number), 0)").Scan(&maxVersion)
+	versionNumber := maxVersion + 1
+
+	objectPath := fmt.Sprintf("%s/policies/%s/v%d_%s_%s", policy.OrganizationID.String(), policy.ID.String(),
+		versionNumber, uuid.New().String(), filepath.Base(header.Filename))
+	objectName, err := filestorage.DefaultFileStorageProvider.UploadFile(c.Request.Context(), policy.OrganizationID.String(), objectPath, file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload policy document: " + err.Error()})
+		return
+	}
+
+	version := models.PolicyVersion{
+		PolicyID:      policy.ID,
+		VersionNumber: versionNumber,
+		FileURL:       objectName,
+		FileName:      filepath.Base(header.Filename),
+		Changelog:     c.Request.FormValue("changelog"),
+		UploadedByID:  userID.(uuid.UUID),
+	}
+	if err := db.Create(&version).Error; err != nil {
+		// Upload concorrente com o mesmo número de versão: remove o arquivo órfão.
+		_ = filestorage.DefaultFileStorageProvider.DeleteFile(c.Request.Context(), objectName)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save policy version: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, version)
+}
+
+// findPolicyVersion carrega a versão :versionId da política informada.
+func findPolicyVersion(c *gin.Context, db *gorm.DB, policy *models.Policy) (*models.PolicyVersion, bool) {
+	versionID, err := uuid.Parse(c.Param("versionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version ID format"})
+		return nil, false
+	}
+	var version models.PolicyVersion
+	if err := db.Where("id = ? AND policy_id = ?", versionID, policy.ID).First(&version).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Policy version not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch policy version: " + err.Error()})
+		return nil, false
+	}
+	return &version, true
+}
+
+// GetPolicyVersionDownloadURLHandler returns a short-lived signed URL for a policy version document.
+func GetPolicyVersionDownloadURLHandler(c *gin.Context) {
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok {
+		return
+	}
+	version, ok := findPolicyVersion(c, db, policy)
+	if !ok {
+		return
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "File storage service is not configured."})
+		return
+	}
+	signedURL, err := filestorage.DefaultFileStorageProvider.GetSignedURL(c.Request.Context(), version.FileURL, 15)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate download URL: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": signedURL, "expires_in_minutes": 15})
+}
+
+// SubmitPolicyVersionForApprovalHandler submits a version for approval, mirroring the risk acceptance workflow.
+func SubmitPolicyVersionForApprovalHandler(c *gin.Context) {
+	var payload SubmitPolicyVersionPayload
+	if err := c.ShouldBindJSON(&payload); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok || !canManagePolicy(c, policy) {
+		return
+	}
+	version, ok := findPolicyVersion(c, db, policy)
+	if !ok {
+		return
+	}
+	if version.ApprovalStatus == models.ApprovalPending || version.ApprovalStatus == models.ApprovalApproved {
+		c.JSON(http.StatusConflict, gin.H{"error": "This policy version has already been submitted: " + version.ApprovalStatus})
+		return
+	}
+	var pendingCount int64
+	db.Model(&models.PolicyVersion{}).Where("policy_id = ? AND approval_status = ?", policy.ID, models.ApprovalPending).Count(&pendingCount)
+	if pendingCount > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Another version of this policy is already pending approval"})
+		return
+	}
+
+	approverID := policy.OwnerID
+	if payload.ApproverID != "" {
+		parsedApproverID, err := parseOrgUserID(db, policy.OrganizationID, payload.ApproverID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approver_id: " + err.Error()})
+			return
+		}
+		approverID = parsedApproverID
+	}
+	userID, _ := c.Get("userID")
+	requesterID := userID.(uuid.UUID)
+
+	version.ApprovalStatus = models.ApprovalPending
+	version.RequesterID = &requesterID
+	version.ApproverID = &approverID
+	version.ApprovalComments = ""
+	version.DecidedAt = nil
+	if err := db.Save(version).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit policy version: " + err.Error()})
+		return
+	}
+
+	emailSubject := fmt.Sprintf("Ação Requerida: Aprovação da Política '%s' (versão %d)", policy.Title, version.VersionNumber)
+	emailBody := fmt.Sprintf("A versão %d da política '%s' foi submetida para sua aprovação.\n\nAlterações: %s\n\nAcesse o Phoenix GRC para revisar e tomar uma decisão.",
+		version.VersionNumber, policy.Title, version.Changelog)
+	notifications.NotifyUserByEmail(c.Request.Context(), approverID, emailSubject, emailBody)
+
+	c.JSON(http.StatusOK, version)
+}
+
+// DecidePolicyVersionHandler approves or rejects a pending policy version. Only the designated approver can decide.
+// A aprovação torna a versão vigente, marca a política como aprovada e agenda a próxima revisão.
+func DecidePolicyVersionHandler(c *gin.Context) {
+	var payload DecisionPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok {
+		return
+	}
+	version, ok := findPolicyVersion(c, db, policy)
+	if !ok {
+		return
+	}
+	userID, _ := c.Get("userID")
+	if version.ApproverID == nil || *version.ApproverID != userID.(uuid.UUID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not the designated approver for this policy version"})
+		return
+	}
+	if version.ApprovalStatus != models.ApprovalPending {
+		c.JSON(http.StatusConflict, gin.H{"error": "This policy version is not pending approval"})
+		return
+	}
+
+	now := time.Now()
+	version.ApprovalStatus = payload.Decision
+	version.ApprovalComments = payload.Comments
+	version.DecidedAt = &now
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(version).Error; err != nil {
+			return err
+		}
+		if payload.Decision != models.ApprovalApproved {
+			return nil
+		}
+		nextReview := now.AddDate(0, policy.ReviewCadenceMonths, 0)
+		policy.Status = models.PolicyStatusApproved
+		policy.CurrentVersionID = &version.ID
+		policy.NextReviewDate = &nextReview
+		return tx.Save(policy).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record policy decision: " + err.Error()})
+		return
+	}
+
+	if version.RequesterID != nil {
+		emailSubject := fmt.Sprintf("Política '%s' (versão %d): %s", policy.Title, version.VersionNumber, payload.Decision)
+		emailBody := fmt.Sprintf("A versão %d da política '%s' foi %s.\n\nComentários: %s\n\nAcesse o Phoenix GRC para mais detalhes.",
+			version.VersionNumber, policy.Title, payload.Decision, payload.Comments)
+		notifications.NotifyUserByEmail(c.Request.Context(), *version.RequesterID, emailSubject, emailBody)
+	}
+	c.JSON(http.StatusOK, version)
+}
+
+// RetirePolicyHandler retires a policy. A política deixa de exigir revisão e aceite.
+func RetirePolicyHandler(c *gin.Context) {
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok || !canManagePolicy(c, policy) {
+		return
+	}
+	policy.Status = models.PolicyStatusRetired
+	policy.NextReviewDate = nil
+	if err := db.Save(policy).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retire policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// SetPolicyControlsHandler replaces the audit controls satisfied by the policy.
+func SetPolicyControlsHandler(c *gin.Context) {
+	var payload PolicyControlsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok || !canManagePolicy(c, policy) {
+		return
+	}
+
+	controlIDs := make([]uuid.UUID, 0, len(payload.AuditControlIDs))
+	seen := make(map[uuid.UUID]bool)
+	for _, idStr := range payload.AuditControlIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit_control_id format: " + idStr})
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			controlIDs = append(controlIDs, id)
+		}
+	}
+	if len(controlIDs) > 0 {
+		var count int64
+		db.Model(&models.AuditControl{}).Where("id IN ?", controlIDs).Count(&count)
+		if int(count) != len(controlIDs) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more audit controls were not found"})
+			return
+		}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("policy_id = ?", policy.ID).Delete(&models.PolicyControlMapping{}).Error; err != nil {
+			return err
+		}
+		if len(controlIDs) == 0 {
+			return nil
+		}
+		mappings := make([]models.PolicyControlMapping, 0, len(controlIDs))
+		for _, id := range controlIDs {
+			mappings = append(mappings, models.PolicyControlMapping{PolicyID: policy.ID, AuditControlID: id})
+		}
+		return tx.Create(&mappings).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update policy controls: " + err.Error()})
+		return
+	}
+
+	var mappings []models.PolicyControlMapping
+	db.Preload("AuditControl").Where("policy_id = ?", policy.ID).Find(&mappings)
+	c.JSON(http.StatusOK, mappings)
+}
+
+// AcknowledgePolicyHandler records that the current user has read the policy's current approved version.
+func AcknowledgePolicyHandler(c *gin.Context) {
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok {
+		return
+	}
+	if policy.Status != models.PolicyStatusApproved || policy.CurrentVersionID == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only approved policies can be acknowledged"})
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	ack := models.PolicyAcknowledgment{
+		PolicyID:        policy.ID,
+		PolicyVersionID: *policy.CurrentVersionID,
+		UserID:          userID.(uuid.UUID),
+		AcknowledgedAt:  time.Now(),
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&ack).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record acknowledgment: " + err.Error()})
+		return
+	}
+	// Em caso de aceite repetido, retorna o registro original.
+	db.Where("policy_version_id = ? AND user_id = ?", ack.PolicyVersionID, ack.UserID).First(&ack)
+	c.JSON(http.StatusOK, ack)
+}
+
+// ListPolicyAcknowledgmentsHandler reports which active users have (and have not) acknowledged a policy version.
+// Query param opcional "version_id"; por padrão usa a versão vigente.
+func ListPolicyAcknowledgmentsHandler(c *gin.Context) {
+	db := database.GetDB()
+	policy, ok := findOrgPolicy(c, db)
+	if !ok || !canManagePolicy(c, policy) {
+		return
+	}
+
+	var versionID uuid.UUID
+	if versionIDStr := c.Query("version_id"); versionIDStr != "" {
+		parsed, err := uuid.Parse(versionIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version_id format"})
+			return
+		}
+		versionID = parsed
+	} else if policy.CurrentVersionID != nil {
+		versionID = *policy.CurrentVersionID
+	} else {
+		c.JSON(http.StatusConflict, gin.H{"error": "Policy has no approved version to report on"})
+		return
+	}
+
+	var rows []PolicyAcknowledgmentStatus
+	err := db.Table("users").
+		Select("users.id AS user_id, users.name, users.email, policy_acknowledgments.acknowledged_at").
+		Joins("LEFT JOIN policy_acknowledgments ON policy_acknowledgments.user_id = users.id AND policy_acknowledgments.policy_version_id = ?", versionID).
+		Where("users.organization_id = ? AND users.is_active = ?", policy.OrganizationID, true).
+		Order("users.name asc").
+		Scan(&rows).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list acknowledgments: " + err.Error()})
+		return
+	}
+
+	acknowledged := []PolicyAcknowledgmentStatus{}
+	pending := []PolicyAcknowledgmentStatus{}
+	for _, row := range rows {
+		if row.AcknowledgedAt != nil {
+			acknowledged = append(acknowledged, row)
+		} else {
+			pending = append(pending, row)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"policy_version_id": versionID,
+		"acknowledged":      acknowledged,
+		"pending":           pending,
+		"total_users":       len(rows),
+	})
+}
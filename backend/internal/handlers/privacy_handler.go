@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/privacy"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AnonymizeUserPayload registra o motivo da anonimização (ex: o protocolo do pedido do titular).
+type AnonymizeUserPayload struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ExportMyDataHandler returns the current user's personal data as a downloadable JSON bundle (profile, comments
+// and owned risks metadata), to answer data subject access requests.
+func ExportMyDataHandler(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	var user models.User
+	if err := db.First(&user, "id = ?", userID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user: " + err.Error()})
+		return
+	}
+	now := time.Now()
+	export, err := privacy.ExportUser(db, &user, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export personal data: " + err.Error()})
+		return
+	}
+	if user.OrganizationID.Valid {
+		if _, err := recordAuditTrail(c, db, user.OrganizationID.UUID, models.AuditTrailUserDataExported, "user", &user.ID,
+			"Personal data exported by the user", gin.H{"comments": len(export.Comments), "owned_risks": len(export.OwnedRisks)}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record data export: " + err.Error()})
+			return
+		}
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"personal_data_%s.json\"", now.UTC().Format("20060102")))
+	c.JSON(http.StatusOK, export)
+}
+
+// AnonymizeOrganizationUserHandler irreversibly removes a user's personal data (users:anonymize): name, email,
+// credentials and notifications are scrubbed and the user is deactivated, while risks, comments and audit
+// records keep pointing to the same user.
+func AnonymizeOrganizationUserHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	targetUserID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersAnonymize) {
+		return
+	}
+	if userID, _ := c.Get("userID"); userID.(uuid.UUID) == targetUserID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot anonymize your own account"})
+		return
+	}
+	var payload AnonymizeUserPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	reason := strings.TrimSpace(payload.Reason)
+	if reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A reason is required to anonymize a user"})
+		return
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.Where("id = ? AND organization_id = ?", targetUserID, targetOrgID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found in this organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user: " + err.Error()})
+		return
+	}
+	if user.AnonymizedAt != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "User is already anonymized"})
+		return
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := privacy.AnonymizeUser(tx, &user, time.Now()); err != nil {
+			return err
+		}
+		// Registrada depois da anonimização, sem o nome nem o e-mail anteriores
+		_, err := recordAuditTrail(c, tx, targetOrgID, models.AuditTrailUserAnonymized, "user", &user.ID,
+			"User personal data anonymized", gin.H{"reason": reason})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to anonymize user: " + err.Error()})
+		return
+	}
+	db.First(&user, "id = ?", user.ID)
+	c.JSON(http.StatusOK, newUserResponse(user))
+}
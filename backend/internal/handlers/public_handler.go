@@ -8,8 +8,11 @@ import (
 	phxlog "phoenixgrc/backend/pkg/log"  // Importar o logger zap
 	"go.uber.org/zap"                 // Importar zap
 	"strings"
+	"time"
 
+	"phoenixgrc/backend/internal/health"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/passwordpolicy"
 	"phoenixgrc/backend/internal/seeders"
 
 	"github.com/gin-gonic/gin"
@@ -46,6 +49,11 @@ func PerformSetupHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload da requisição inválido: " + err.Error()})
 		return
 	}
+	// A organização ainda não existe: vale a política de senhas padrão
+	if violations := passwordpolicy.Check(c.Request.Context(), passwordpolicy.Default(), payload.AdminPassword); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A senha do administrador não atende à política de senhas", "violations": violations})
+		return
+	}
 
 	// 3. Executar as migrações do banco de dados
 	log.Info("Starting database migrations...")
@@ -77,13 +85,15 @@ func PerformSetupHandler(c *gin.Context) {
 		return
 	}
 
+	passwordChangedAt := time.Now()
 	adminUser := models.User{
-		Name:           payload.AdminName,
-		Email:          payload.AdminEmail,
-		PasswordHash:   string(hashedPassword),
-		Role:           models.RoleAdmin,
-		IsActive:       true,
-		OrganizationID: uuid.NullUUID{UUID: org.ID, Valid: true},
+		Name:              payload.AdminName,
+		Email:             payload.AdminEmail,
+		PasswordHash:      string(hashedPassword),
+		PasswordChangedAt: &passwordChangedAt,
+		Role:              models.RoleAdmin,
+		IsActive:          true,
+		OrganizationID:    uuid.NullUUID{UUID: org.ID, Valid: true},
 	}
 	if err := db.Create(&adminUser).Error; err != nil {
 		log.Error("Failed to create admin user", zap.Error(err))
@@ -119,8 +129,11 @@ type GlobalIdPResponse struct {
 // ListGlobalSocialIdentityProvidersHandler retorna uma lista de provedores de identidade sociais
 // configurados globalmente através de variáveis de ambiente.
 func ListGlobalSocialIdentityProvidersHandler(c *gin.Context) {
-	var providers []GlobalIdPResponse
+	c.JSON(http.StatusOK, globalSocialIdentityProviders())
+}
 
+// loginBaseURL é a URL base usada para montar as URLs de início dos fluxos de login.
+func loginBaseURL() string {
 	appRootURL := config.Cfg.FrontendBaseURL
 	if appRootURL == "" {
 		appRootURL = "http://localhost:8080" // Fallback para desenvolvimento se não configurado via .env
@@ -128,7 +141,13 @@ func ListGlobalSocialIdentityProvidersHandler(c *gin.Context) {
 			zap.String("fallback_url", appRootURL))
 	}
 	// Remover quaisquer barras extras do final para evitar // no path
-	appRootURL = strings.TrimSuffix(appRootURL, "/")
+	return strings.TrimSuffix(appRootURL, "/")
+}
+
+// globalSocialIdentityProviders lista os provedores sociais globais configurados.
+func globalSocialIdentityProviders() []GlobalIdPResponse {
+	var providers []GlobalIdPResponse
+	appRootURL := loginBaseURL()
 
 	// Verificar Google
 	if config.Cfg.GoogleClientID != "" && config.Cfg.GoogleClientSecret != "" {
@@ -150,32 +169,36 @@ func ListGlobalSocialIdentityProvidersHandler(c *gin.Context) {
 		})
 	}
 
-	c.JSON(http.StatusOK, providers)
+	return providers
 }
 
 // SetupStatusResponse define a resposta para o endpoint de status do setup.
 type SetupStatusResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
+	Status     string          `json:"status"`
+	Message    string          `json:"message"`
+	Components []health.Status `json:"components"` // Situação de cada serviço, como no /readyz
 }
 
 // GetSetupStatusHandler verifica e retorna o estado atual da configuração da aplicação.
 func GetSetupStatusHandler(c *gin.Context) {
 	db := database.GetDB()
+	components := health.Default.Evaluate(c.Request.Context(), health.ServiceChecks(), health.DefaultCheckTimeout).Components
 
 	// 1. Verificar conexão com o DB
 	sqlDB, err := db.DB()
 	if err != nil {
 		c.JSON(http.StatusServiceUnavailable, SetupStatusResponse{
-			Status:  "database_not_configured",
-			Message: "A instância do banco de dados não está disponível.",
+			Status:     "database_not_configured",
+			Message:    "A instância do banco de dados não está disponível.",
+			Components: components,
 		})
 		return
 	}
 	if err := sqlDB.Ping(); err != nil {
 		c.JSON(http.StatusServiceUnavailable, SetupStatusResponse{
-			Status:  "database_not_connected",
-			Message: "Não foi possível conectar ao banco de dados. Verifique as credenciais e a conectividade.",
+			Status:     "database_not_connected",
+			Message:    "Não foi possível conectar ao banco de dados. Verifique as credenciais e a conectividade.",
+			Components: components,
 		})
 		return
 	}
@@ -184,8 +207,9 @@ func GetSetupStatusHandler(c *gin.Context) {
 	// Uma forma simples é verificar se a tabela 'users' existe.
 	if !db.Migrator().HasTable(&models.User{}) {
 		c.JSON(http.StatusOK, SetupStatusResponse{
-			Status:  "migrations_not_run",
-			Message: "Conexão com o banco de dados OK, mas as tabelas da aplicação não foram criadas. Execute o setup.",
+			Status:     "migrations_not_run",
+			Message:    "Conexão com o banco de dados OK, mas as tabelas da aplicação não foram criadas. Execute o setup.",
+			Components: components,
 		})
 		return
 	}
@@ -195,8 +219,9 @@ func GetSetupStatusHandler(c *gin.Context) {
 	db.Model(&models.Organization{}).Count(&orgCount)
 	if orgCount == 0 {
 		c.JSON(http.StatusOK, SetupStatusResponse{
-			Status:  "setup_pending_org",
-			Message: "Migrações concluídas, mas a primeira organização e o usuário administrador precisam ser criados.",
+			Status:     "setup_pending_org",
+			Message:    "Migrações concluídas, mas a primeira organização e o usuário administrador precisam ser criados.",
+			Components: components,
 		})
 		return
 	}
@@ -205,16 +230,18 @@ func GetSetupStatusHandler(c *gin.Context) {
 	db.Model(&models.User{}).Where("role = ?", models.RoleAdmin).Count(&adminCount)
 	if adminCount == 0 {
 		c.JSON(http.StatusOK, SetupStatusResponse{
-			Status:  "setup_pending_admin",
-			Message: "Organização criada, mas o usuário administrador não foi encontrado. Complete o setup.",
+			Status:     "setup_pending_admin",
+			Message:    "Organização criada, mas o usuário administrador não foi encontrado. Complete o setup.",
+			Components: components,
 		})
 		return
 	}
 
 	// Se tudo estiver OK
 	c.JSON(http.StatusOK, SetupStatusResponse{
-		Status:  "setup_complete",
-		Message: "A aplicação está configurada e pronta para uso.",
+		Status:     "setup_complete",
+		Message:    "A aplicação está configurada e pronta para uso.",
+		Components: components,
 	})
 }
 
@@ -0,0 +1,463 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/questionnaires"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// QuestionnaireQuestionPayload define uma pergunta no construtor de questionários.
+type QuestionnaireQuestionPayload struct {
+	Text     string                       `json:"text" binding:"required"`
+	Type     models.QuestionType          `json:"type" binding:"required"`
+	Weight   *float64                     `json:"weight"` // Default 1
+	Required bool                         `json:"required"`
+	Options  []models.QuestionnaireOption `json:"options"`
+}
+
+// QuestionnaireSectionPayload define uma seção e suas perguntas.
+type QuestionnaireSectionPayload struct {
+	Title       string                         `json:"title" binding:"required,max=255"`
+	Description string                         `json:"description"`
+	Questions   []QuestionnaireQuestionPayload `json:"questions" binding:"required,min=1,dive"`
+}
+
+// QuestionnairePayload defines the structure for creating or replacing a questionnaire.
+type QuestionnairePayload struct {
+	Title       string                        `json:"title" binding:"required,min=3,max=255"`
+	Description string                        `json:"description"`
+	Sections    []QuestionnaireSectionPayload `json:"sections" binding:"required,min=1,dive"`
+}
+
+// QuestionnaireInvitationPayload defines the structure for sending a questionnaire to a vendor contact.
+type QuestionnaireInvitationPayload struct {
+	VendorID       string `json:"vendor_id" binding:"required"`
+	RecipientName  string `json:"recipient_name" binding:"max=255"`
+	RecipientEmail string `json:"recipient_email" binding:"omitempty,email"` // Default: contact_email do fornecedor
+	ExpiresInDays  int    `json:"expires_in_days" binding:"omitempty,min=1,max=90"`
+}
+
+// QuestionnaireInvitationResponse inclui a URL do portal; o token só é exibido na criação.
+type QuestionnaireInvitationResponse struct {
+	models.QuestionnaireInvitation
+	PortalURL string `json:"portal_url"`
+}
+
+// buildQuestionnaireSections converte e valida as seções do payload.
+func buildQuestionnaireSections(payload QuestionnairePayload) ([]models.QuestionnaireSection, error) {
+	sections := make([]models.QuestionnaireSection, 0, len(payload.Sections))
+	for i, sp := range payload.Sections {
+		section := models.QuestionnaireSection{Title: sp.Title, Description: sp.Description, Position: i + 1}
+		for j, qp := range sp.Questions {
+			question := models.QuestionnaireQuestion{
+				Text:     strings.TrimSpace(qp.Text),
+				Type:     qp.Type,
+				Weight:   1,
+				Required: qp.Required,
+				Position: j + 1,
+				Options:  qp.Options,
+			}
+			if qp.Weight != nil {
+				question.Weight = *qp.Weight
+			}
+			if err := questionnaires.ValidateQuestion(question); err != nil {
+				return nil, fmt.Errorf("section %d, question %d: %w", i+1, j+1, err)
+			}
+			section.Questions = append(section.Questions, question)
+		}
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+// findOrgQuestionnaire carrega o questionário de :questionnaireId (com seções e perguntas, se withStructure).
+func findOrgQuestionnaire(c *gin.Context, db *gorm.DB, withStructure bool) (*models.Questionnaire, bool) {
+	questionnaireID, err := uuid.Parse(c.Param("questionnaireId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid questionnaire ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	query := db.Where("id = ? AND organization_id = ?", questionnaireID, orgID)
+	if withStructure {
+		query = query.Preload("Sections", func(db *gorm.DB) *gorm.DB { return db.Order("position asc") }).
+			Preload("Sections.Questions", func(db *gorm.DB) *gorm.DB { return db.Order("position asc") })
+	}
+	var questionnaire models.Questionnaire
+	if err := query.First(&questionnaire).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Questionnaire not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch questionnaire: " + err.Error()})
+		return nil, false
+	}
+	return &questionnaire, true
+}
+
+// loadQuestionnaireQuestions retorna todas as perguntas do questionário.
+func loadQuestionnaireQuestions(db *gorm.DB, questionnaireID uuid.UUID) ([]models.QuestionnaireQuestion, error) {
+	var questions []models.QuestionnaireQuestion
+	err := db.Joins("JOIN questionnaire_sections ON questionnaire_sections.id = questionnaire_questions.section_id").
+		Where("questionnaire_sections.questionnaire_id = ?", questionnaireID).
+		Order("questionnaire_sections.position asc, questionnaire_questions.position asc").
+		Find(&questions).Error
+	return questions, err
+}
+
+// CreateQuestionnaireHandler creates a draft questionnaire with its sections and questions.
+func CreateQuestionnaireHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.QuestionnairesManage) {
+		return
+	}
+	var payload QuestionnairePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	sections, err := buildQuestionnaireSections(payload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	questionnaire := models.Questionnaire{
+		OrganizationID: orgID.(uuid.UUID),
+		Title:          payload.Title,
+		Description:    payload.Description,
+		Status:         models.QuestionnaireDraft,
+		CreatedByID:    userID.(uuid.UUID),
+		Sections:       sections,
+	}
+	if err := database.GetDB().Create(&questionnaire).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create questionnaire: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, questionnaire)
+}
+
+// ListQuestionnairesHandler lists the organization's questionnaires (without structure). Filtro: status.
+func ListQuestionnairesHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Where("organization_id = ?", orgID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var list []models.Questionnaire
+	if err := query.Order("created_at desc").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list questionnaires: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetQuestionnaireHandler returns a questionnaire with its sections, questions and scoring.
+func GetQuestionnaireHandler(c *gin.Context) {
+	questionnaire, ok := findOrgQuestionnaire(c, database.GetDB(), true)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, questionnaire)
+}
+
+// UpdateQuestionnaireHandler replaces the title, description and structure of a draft questionnaire.
+func UpdateQuestionnaireHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.QuestionnairesManage) {
+		return
+	}
+	var payload QuestionnairePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	questionnaire, ok := findOrgQuestionnaire(c, db, false)
+	if !ok {
+		return
+	}
+	if questionnaire.Status != models.QuestionnaireDraft {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only draft questionnaires can be edited; create a new questionnaire instead"})
+		return
+	}
+	sections, err := buildQuestionnaireSections(payload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		sectionIDs := tx.Model(&models.QuestionnaireSection{}).Select("id").Where("questionnaire_id = ?", questionnaire.ID)
+		if err := tx.Where("section_id IN (?)", sectionIDs).Delete(&models.QuestionnaireQuestion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("questionnaire_id = ?", questionnaire.ID).Delete(&models.QuestionnaireSection{}).Error; err != nil {
+			return err
+		}
+		questionnaire.Title = payload.Title
+		questionnaire.Description = payload.Description
+		if err := tx.Save(questionnaire).Error; err != nil {
+			return err
+		}
+		for i := range sections {
+			sections[i].QuestionnaireID = questionnaire.ID
+		}
+		return tx.Create(&sections).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update questionnaire: " + err.Error()})
+		return
+	}
+	questionnaire.Sections = sections
+	c.JSON(http.StatusOK, questionnaire)
+}
+
+// PublishQuestionnaireHandler publishes a draft questionnaire so it can be sent to vendors.
+func PublishQuestionnaireHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.QuestionnairesManage) {
+		return
+	}
+	db := database.GetDB()
+	questionnaire, ok := findOrgQuestionnaire(c, db, false)
+	if !ok {
+		return
+	}
+	if questionnaire.Status != models.QuestionnaireDraft {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only draft questionnaires can be published"})
+		return
+	}
+	now := time.Now()
+	questionnaire.Status = models.QuestionnairePublished
+	questionnaire.PublishedAt = &now
+	if err := db.Save(questionnaire).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to publish questionnaire: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, questionnaire)
+}
+
+// ArchiveQuestionnaireHandler archives a questionnaire; convites já enviados continuam respondíveis.
+func ArchiveQuestionnaireHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.QuestionnairesManage) {
+		return
+	}
+	db := database.GetDB()
+	questionnaire, ok := findOrgQuestionnaire(c, db, false)
+	if !ok {
+		return
+	}
+	questionnaire.Status = models.QuestionnaireArchived
+	if err := db.Save(questionnaire).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to archive questionnaire: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, questionnaire)
+}
+
+// DeleteQuestionnaireHandler deletes a questionnaire that was never sent.
+func DeleteQuestionnaireHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.QuestionnairesManage) {
+		return
+	}
+	db := database.GetDB()
+	questionnaire, ok := findOrgQuestionnaire(c, db, false)
+	if !ok {
+		return
+	}
+	var sent int64
+	db.Model(&models.QuestionnaireInvitation{}).Where("questionnaire_id = ?", questionnaire.ID).Count(&sent)
+	if sent > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Questionnaire has been sent to vendors; archive it instead"})
+		return
+	}
+	// Seções e perguntas são removidas por ON DELETE CASCADE.
+	if err := db.Delete(questionnaire).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete questionnaire: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Questionnaire deleted successfully"})
+}
+
+// hashQuestionnaireToken retorna o hash armazenado para o token do portal.
+func hashQuestionnaireToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// questionnairePortalURL monta o link enviado ao respondente externo.
+func questionnairePortalURL(token string) string {
+	frontendBaseURL := config.Cfg.FrontendBaseURL
+	if frontendBaseURL == "" {
+		frontendBaseURL = "http://localhost:3000" // Fallback
+	}
+	return fmt.Sprintf("%s/vendor-questionnaire/%s", strings.TrimSuffix(frontendBaseURL, "/"), token)
+}
+
+// CreateQuestionnaireInvitationHandler sends a published questionnaire to a vendor contact via a tokenized link.
+func CreateQuestionnaireInvitationHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.QuestionnairesManage) {
+		return
+	}
+	var payload QuestionnaireInvitationPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	questionnaire, ok := findOrgQuestionnaire(c, db, false)
+	if !ok {
+		return
+	}
+	if questionnaire.Status != models.QuestionnairePublished {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only published questionnaires can be sent"})
+		return
+	}
+	vendor, ok := findOrgVendor(c, db, payload.VendorID)
+	if !ok {
+		return
+	}
+	recipientEmail := payload.RecipientEmail
+	if recipientEmail == "" {
+		recipientEmail = vendor.ContactEmail
+	}
+	if recipientEmail == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recipient_email is required when the vendor has no contact_email"})
+		return
+	}
+	recipientName := payload.RecipientName
+	if recipientName == "" {
+		recipientName = vendor.ContactName
+	}
+	expiresInDays := payload.ExpiresInDays
+	if expiresInDays == 0 {
+		expiresInDays = 14
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	userID, _ := c.Get("userID")
+	invitation := models.QuestionnaireInvitation{
+		OrganizationID:  questionnaire.OrganizationID,
+		QuestionnaireID: questionnaire.ID,
+		VendorID:        vendor.ID,
+		RecipientName:   recipientName,
+		RecipientEmail:  recipientEmail,
+		TokenHash:       hashQuestionnaireToken(token),
+		Status:          models.QuestionnaireInvitationSent,
+		ExpiresAt:       time.Now().AddDate(0, 0, expiresInDays),
+		CreatedByID:     userID.(uuid.UUID),
+	}
+	if err := db.Create(&invitation).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create questionnaire invitation: " + err.Error()})
+		return
+	}
+
+	portalURL := questionnairePortalURL(token)
+	msg := notifications.Message{
+		EventType:      "questionnaire.invitation",
+		OrganizationID: invitation.OrganizationID,
+		Subject:        fmt.Sprintf("Questionário de Segurança: %s", questionnaire.Title),
+		Body: fmt.Sprintf("Olá %s,\n\nSolicitamos que %s responda ao questionário de segurança '%s' até %s. Não é necessário criar uma conta.",
+			recipientName, vendor.Name, questionnaire.Title, invitation.ExpiresAt.Format("02/01/2006")),
+		Link: portalURL,
+	}
+	go func() {
+		if err := notifications.Deliver(context.Background(), notifications.ChannelEmail, recipientEmail, msg); err != nil {
+			phxlog.L.Error("Failed to send questionnaire invitation email",
+				zap.String("invitationID", invitation.ID.String()),
+				zap.Error(err))
+		}
+	}()
+
+	c.JSON(http.StatusCreated, QuestionnaireInvitationResponse{QuestionnaireInvitation: invitation, PortalURL: portalURL})
+}
+
+// ListQuestionnaireInvitationsHandler lists sent questionnaires and their scores. Filtros: vendor_id, questionnaire_id, status.
+func ListQuestionnaireInvitationsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	query := database.GetDB().Where("organization_id = ?", orgID)
+	for _, filter := range []string{"vendor_id", "questionnaire_id", "status"} {
+		if value := c.Query(filter); value != "" {
+			query = query.Where(filter+" = ?", value)
+		}
+	}
+	var invitations []models.QuestionnaireInvitation
+	if err := query.Order("created_at desc").Find(&invitations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list questionnaire invitations: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, invitations)
+}
+
+// findOrgQuestionnaireInvitation carrega o convite de :invitationId na organização do usuário.
+func findOrgQuestionnaireInvitation(c *gin.Context, db *gorm.DB) (*models.QuestionnaireInvitation, bool) {
+	invitationID, err := uuid.Parse(c.Param("invitationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var invitation models.QuestionnaireInvitation
+	if err := db.Preload("Answers").Where("id = ? AND organization_id = ?", invitationID, orgID).First(&invitation).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Questionnaire invitation not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch questionnaire invitation: " + err.Error()})
+		return nil, false
+	}
+	return &invitation, true
+}
+
+// GetQuestionnaireInvitationHandler returns a sent questionnaire with its answers and scores.
+func GetQuestionnaireInvitationHandler(c *gin.Context) {
+	invitation, ok := findOrgQuestionnaireInvitation(c, database.GetDB())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, invitation)
+}
+
+// RevokeQuestionnaireInvitationHandler invalidates the link of a pending invitation.
+func RevokeQuestionnaireInvitationHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.QuestionnairesManage) {
+		return
+	}
+	db := database.GetDB()
+	invitation, ok := findOrgQuestionnaireInvitation(c, db)
+	if !ok {
+		return
+	}
+	if invitation.Status == models.QuestionnaireInvitationSubmitted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Questionnaire has already been submitted"})
+		return
+	}
+	invitation.Status = models.QuestionnaireInvitationRevoked
+	if err := db.Model(invitation).Update("status", invitation.Status).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke invitation: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, invitation)
+}
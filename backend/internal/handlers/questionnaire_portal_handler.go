@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/questionnaires"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QuestionnairePortalAnswerPayload é a resposta do fornecedor a uma pergunta.
+type QuestionnairePortalAnswerPayload struct {
+	QuestionID string   `json:"question_id" binding:"required"`
+	Values     []string `json:"values"` // yes_no: ["yes"|"no"]; escolha: valores das opções; texto: [texto]
+	Comment    string   `json:"comment"`
+}
+
+// QuestionnairePortalAnswersPayload defines the structure for saving answers from the external portal.
+type QuestionnairePortalAnswersPayload struct {
+	Answers []QuestionnairePortalAnswerPayload `json:"answers" binding:"required,min=1,dive"`
+}
+
+// PortalQuestionOption é uma opção exibida ao respondente (sem a pontuação).
+type PortalQuestionOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// PortalQuestion é uma pergunta exibida ao respondente externo.
+type PortalQuestion struct {
+	ID       uuid.UUID              `json:"id"`
+	Text     string                 `json:"text"`
+	Type     models.QuestionType    `json:"type"`
+	Required bool                   `json:"required"`
+	Options  []PortalQuestionOption `json:"options,omitempty"`
+	Values   []string               `json:"values,omitempty"` // Resposta salva
+	Comment  string                 `json:"comment,omitempty"`
+}
+
+// PortalSection é uma seção exibida ao respondente externo.
+type PortalSection struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	Questions   []PortalQuestion `json:"questions"`
+}
+
+// QuestionnairePortalResponse é o questionário visto pelo fornecedor; não expõe pesos nem pontuações.
+type QuestionnairePortalResponse struct {
+	OrganizationName string                               `json:"organization_name"`
+	VendorName       string                               `json:"vendor_name"`
+	Title            string                               `json:"title"`
+	Description      string                               `json:"description,omitempty"`
+	Status           models.QuestionnaireInvitationStatus `json:"status"`
+	ExpiresAt        time.Time                            `json:"expires_at"`
+	Sections         []PortalSection                      `json:"sections"`
+}
+
+// QuestionnairePortalAuthMiddleware autentica o respondente externo pelo token do link (:token).
+// Links revogados ou expirados retornam 410.
+func QuestionnairePortalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var invitation models.QuestionnaireInvitation
+		err := database.GetDB().Where("token_hash = ?", hashQuestionnaireToken(c.Param("token"))).First(&invitation).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Invalid questionnaire link"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate questionnaire link"})
+			return
+		}
+		if invitation.Status == models.QuestionnaireInvitationRevoked {
+			c.AbortWithStatusJSON(http.StatusGone, gin.H{"error": "This questionnaire link has been revoked"})
+			return
+		}
+		if invitation.Status != models.QuestionnaireInvitationSubmitted && time.Now().After(invitation.ExpiresAt) {
+			c.AbortWithStatusJSON(http.StatusGone, gin.H{"error": "This questionnaire link has expired"})
+			return
+		}
+		c.Set("questionnaireInvitation", &invitation)
+		c.Next()
+	}
+}
+
+// portalInvitation retorna o convite autenticado pelo middleware.
+func portalInvitation(c *gin.Context) *models.QuestionnaireInvitation {
+	invitation, _ := c.Get("questionnaireInvitation")
+	return invitation.(*models.QuestionnaireInvitation)
+}
+
+// GetQuestionnairePortalHandler returns the questionnaire and saved answers for the external respondent.
+func GetQuestionnairePortalHandler(c *gin.Context) {
+	invitation := portalInvitation(c)
+	db := database.GetDB()
+
+	var questionnaire models.Questionnaire
+	err := db.Preload("Sections", func(db *gorm.DB) *gorm.DB { return db.Order("position asc") }).
+		Preload("Sections.Questions", func(db *gorm.DB) *gorm.DB { return db.Order("position asc") }).
+		First(&questionnaire, "id = ?", invitation.QuestionnaireID).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load questionnaire"})
+		return
+	}
+	var org models.Organization
+	db.Select("id", "name").First(&org, "id = ?", invitation.OrganizationID)
+	var vendor models.Vendor
+	db.Select("id", "name").First(&vendor, "id = ?", invitation.VendorID)
+
+	var answers []models.QuestionnaireAnswer
+	if err := db.Where("invitation_id = ?", invitation.ID).Find(&answers).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load answers"})
+		return
+	}
+	answerByQuestion := make(map[uuid.UUID]models.QuestionnaireAnswer, len(answers))
+	for _, a := range answers {
+		answerByQuestion[a.QuestionID] = a
+	}
+
+	resp := QuestionnairePortalResponse{
+		OrganizationName: org.Name,
+		VendorName:       vendor.Name,
+		Title:            questionnaire.Title,
+		Description:      questionnaire.Description,
+		Status:           invitation.Status,
+		ExpiresAt:        invitation.ExpiresAt,
+		Sections:         make([]PortalSection, 0, len(questionnaire.Sections)),
+	}
+	for _, section := range questionnaire.Sections {
+		ps := PortalSection{Title: section.Title, Description: section.Description, Questions: make([]PortalQuestion, 0, len(section.Questions))}
+		for _, q := range section.Questions {
+			pq := PortalQuestion{ID: q.ID, Text: q.Text, Type: q.Type, Required: q.Required}
+			for _, opt := range q.Options {
+				pq.Options = append(pq.Options, PortalQuestionOption{Value: opt.Value, Label: opt.Label})
+			}
+			if answer, ok := answerByQuestion[q.ID]; ok {
+				pq.Values = questionnaires.DecodeValues(answer.Value)
+				if q.Type == models.QuestionText {
+					pq.Values = []string{answer.Value}
+				}
+				pq.Comment = answer.Comment
+			}
+			ps.Questions = append(ps.Questions, pq)
+		}
+		resp.Sections = append(resp.Sections, ps)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// recalculateInvitationScore atualiza Score/MaxScore do convite a partir das respostas salvas.
+func recalculateInvitationScore(tx *gorm.DB, invitation *models.QuestionnaireInvitation, questions []models.QuestionnaireQuestion) error {
+	maxScore := 0.0
+	for _, q := range questions {
+		maxScore += questionnaires.MaxScore(q)
+	}
+	var score float64
+	if err := tx.Model(&models.QuestionnaireAnswer{}).Where("invitation_id = ?", invitation.ID).
+		Select("COALESCE(SUM(score), 0)").Scan(&score).Error; err != nil {
+		return err
+	}
+	invitation.Score = score
+	invitation.MaxScore = maxScore
+	return nil
+}
+
+// SaveQuestionnairePortalAnswersHandler saves (or overwrites) answers; pode ser chamado várias vezes antes da submissão.
+func SaveQuestionnairePortalAnswersHandler(c *gin.Context) {
+	invitation := portalInvitation(c)
+	if invitation.Status == models.QuestionnaireInvitationSubmitted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Questionnaire has already been submitted"})
+		return
+	}
+	var payload QuestionnairePortalAnswersPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	questions, err := loadQuestionnaireQuestions(db, invitation.QuestionnaireID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load questionnaire"})
+		return
+	}
+	questionByID := make(map[string]models.QuestionnaireQuestion, len(questions))
+	for _, q := range questions {
+		questionByID[q.ID.String()] = q
+	}
+
+	answers := make([]models.QuestionnaireAnswer, 0, len(payload.Answers))
+	for _, ap := range payload.Answers {
+		question, ok := questionByID[ap.QuestionID]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %s is not part of this questionnaire", ap.QuestionID)})
+			return
+		}
+		score, err := questionnaires.ScoreAnswer(question, ap.Values)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid answer for question %s: %s", ap.QuestionID, err.Error())})
+			return
+		}
+		value := questionnaires.EncodeValues(ap.Values)
+		if question.Type == models.QuestionText && len(ap.Values) > 0 {
+			value = ap.Values[0] // Texto livre pode conter vírgulas
+		}
+		answers = append(answers, models.QuestionnaireAnswer{
+			InvitationID: invitation.ID,
+			QuestionID:   question.ID,
+			Value:        value,
+			Comment:      ap.Comment,
+			Score:        score,
+		})
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "invitation_id"}, {Name: "question_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "comment", "score", "updated_at"}),
+		}).Create(&answers).Error; err != nil {
+			return err
+		}
+		if err := recalculateInvitationScore(tx, invitation, questions); err != nil {
+			return err
+		}
+		invitation.Status = models.QuestionnaireInvitationInProgress
+		return tx.Model(invitation).Select("status", "score", "max_score").Updates(invitation).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save answers"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Answers saved", "answers_saved": len(answers)})
+}
+
+// SubmitQuestionnairePortalHandler finalizes the response, records the score on the vendor and notifies the sender.
+func SubmitQuestionnairePortalHandler(c *gin.Context) {
+	invitation := portalInvitation(c)
+	if invitation.Status == models.QuestionnaireInvitationSubmitted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Questionnaire has already been submitted"})
+		return
+	}
+	db := database.GetDB()
+	questions, err := loadQuestionnaireQuestions(db, invitation.QuestionnaireID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load questionnaire"})
+		return
+	}
+
+	var answered []uuid.UUID
+	if err := db.Model(&models.QuestionnaireAnswer{}).Where("invitation_id = ? AND value <> ''", invitation.ID).
+		Pluck("question_id", &answered).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load answers"})
+		return
+	}
+	answeredSet := make(map[uuid.UUID]bool, len(answered))
+	for _, id := range answered {
+		answeredSet[id] = true
+	}
+	var missing []uuid.UUID
+	for _, q := range questions {
+		if q.Required && !answeredSet[q.ID] {
+			missing = append(missing, q.ID)
+		}
+	}
+	if len(missing) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Required questions are unanswered", "missing_question_ids": missing})
+		return
+	}
+
+	now := time.Now()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := recalculateInvitationScore(tx, invitation, questions); err != nil {
+			return err
+		}
+		percent := questionnaires.Percent(invitation.Score, invitation.MaxScore)
+		invitation.Status = models.QuestionnaireInvitationSubmitted
+		invitation.SubmittedAt = &now
+		invitation.ScorePercent = &percent
+		if err := tx.Model(invitation).Select("status", "submitted_at", "score", "max_score", "score_percent").Updates(invitation).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Vendor{}).Where("id = ?", invitation.VendorID).Updates(map[string]interface{}{
+			"last_questionnaire_score": percent,
+			"last_assessed_at":         now,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit questionnaire"})
+		return
+	}
+
+	var vendor models.Vendor
+	db.Select("id", "name").First(&vendor, "id = ?", invitation.VendorID)
+	notifications.NotifyUserByEmail(c.Request.Context(), invitation.CreatedByID,
+		fmt.Sprintf("Questionário respondido: %s", vendor.Name),
+		fmt.Sprintf("O fornecedor '%s' respondeu ao questionário de segurança. Pontuação: %.1f%%.", vendor.Name, *invitation.ScorePercent))
+
+	c.JSON(http.StatusOK, gin.H{"message": "Questionnaire submitted", "submitted_at": now})
+}
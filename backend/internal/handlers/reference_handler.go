@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// referenceModels são os modelos das entidades com código de referência; pelos modelos, os riscos e as políticas
+// na lixeira ficam de fora.
+var referenceModels = map[models.ReferenceEntityType]interface{}{
+	models.ReferenceRisk:          &models.Risk{},
+	models.ReferenceVulnerability: &models.Vulnerability{},
+	models.ReferencePolicy:        &models.Policy{},
+}
+
+// ReferenceLookupResponse identifica a entidade de um código de referência.
+type ReferenceLookupResponse struct {
+	EntityType    models.ReferenceEntityType `json:"entity_type"`
+	EntityID      uuid.UUID                  `json:"entity_id"`
+	ReferenceCode string                     `json:"reference_code"`
+	Title         string                     `json:"title"`
+}
+
+// LookupReferenceHandler resolves a human-readable reference code (e.g. RISK-0042, FND-7, pol-0003) to the
+// organization's entity, so links and conversations can use the code instead of the UUID.
+func LookupReferenceHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, exists := c.Get("organizationID")
+	if !exists || tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization"})
+		return
+	}
+	entityType, code, ok := models.ParseReferenceCode(c.Param("code"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reference code, expected RISK-, FND- or POL- followed by a number"})
+		return
+	}
+
+	response := ReferenceLookupResponse{EntityType: entityType, ReferenceCode: code}
+	err = database.GetDB().Model(referenceModels[entityType]).Select("id AS entity_id, title").
+		Where("organization_id = ? AND reference_code = ?", targetOrgID, code).Take(&response).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No entity found with this reference code"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up reference code: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/reports"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxReportRecipients é o tamanho máximo da lista de distribuição de um relatório agendado.
+const maxReportRecipients = 50
+
+// ReportSchedulePayload define um relatório agendado.
+type ReportSchedulePayload struct {
+	Name       string                 `json:"name" binding:"required,min=1,max=100"`
+	ReportType models.ReportType      `json:"report_type" binding:"required,oneof=risk_register compliance_delta overdue_actions"`
+	Format     models.ReportFormat    `json:"format" binding:"required,oneof=pdf xlsx"`
+	Frequency  models.ReportFrequency `json:"frequency" binding:"required,oneof=weekly monthly"`
+	DayOfWeek  *int                   `json:"day_of_week" binding:"omitempty,min=0,max=6"`
+	DayOfMonth *int                   `json:"day_of_month" binding:"omitempty,min=1,max=28"`
+	Hour       *int                   `json:"hour" binding:"omitempty,min=0,max=23"`
+	Recipients []string               `json:"recipients" binding:"required"`
+	Enabled    *bool                  `json:"enabled"`
+}
+
+// UpdateReportSchedulePayload altera os campos informados.
+type UpdateReportSchedulePayload struct {
+	Name       *string                 `json:"name" binding:"omitempty,min=1,max=100"`
+	ReportType *models.ReportType      `json:"report_type" binding:"omitempty,oneof=risk_register compliance_delta overdue_actions"`
+	Format     *models.ReportFormat    `json:"format" binding:"omitempty,oneof=pdf xlsx"`
+	Frequency  *models.ReportFrequency `json:"frequency" binding:"omitempty,oneof=weekly monthly"`
+	DayOfWeek  *int                    `json:"day_of_week" binding:"omitempty,min=0,max=6"`
+	DayOfMonth *int                    `json:"day_of_month" binding:"omitempty,min=1,max=28"`
+	Hour       *int                    `json:"hour" binding:"omitempty,min=0,max=23"`
+	Recipients *[]string               `json:"recipients"`
+	Enabled    *bool                   `json:"enabled"`
+}
+
+// ReportScheduleResponse é o agendamento com a lista de distribuição decodificada.
+type ReportScheduleResponse struct {
+	models.ReportSchedule
+	Recipients []string `json:"recipients"`
+}
+
+func newReportScheduleResponse(schedule models.ReportSchedule) ReportScheduleResponse {
+	return ReportScheduleResponse{ReportSchedule: schedule, Recipients: schedule.RecipientList()}
+}
+
+// normalizeReportRecipients valida os e-mails da lista de distribuição, em minúsculas e sem repetições.
+func normalizeReportRecipients(recipients []string) ([]string, error) {
+	seen := make(map[string]bool, len(recipients))
+	var normalized []string
+	for _, recipient := range recipients {
+		recipient = strings.ToLower(strings.TrimSpace(recipient))
+		if recipient == "" || seen[recipient] {
+			continue
+		}
+		addr, err := mail.ParseAddress(recipient)
+		if err != nil || addr.Address != recipient {
+			return nil, fmt.Errorf("'%s' is not a valid email address", recipient)
+		}
+		seen[recipient] = true
+		normalized = append(normalized, recipient)
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("at least one recipient is required")
+	}
+	if len(normalized) > maxReportRecipients {
+		return nil, fmt.Errorf("at most %d recipients are allowed", maxReportRecipients)
+	}
+	return normalized, nil
+}
+
+// findReportSchedule carrega o agendamento de :scheduleId na organização do token.
+func findReportSchedule(c *gin.Context, db *gorm.DB) (*models.ReportSchedule, bool) {
+	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var schedule models.ReportSchedule
+	if err := db.Where("id = ? AND organization_id = ?", scheduleID, orgID).First(&schedule).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report schedule not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch report schedule: " + err.Error()})
+		return nil, false
+	}
+	return &schedule, true
+}
+
+// ListReportSchedulesHandler lists the organization's scheduled reports.
+func ListReportSchedulesHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ReportsManageSchedules) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	var schedules []models.ReportSchedule
+	if err := database.GetDB().Where("organization_id = ?", orgID).Order("name asc").Find(&schedules).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list report schedules: " + err.Error()})
+		return
+	}
+	resp := make([]ReportScheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		resp[i] = newReportScheduleResponse(schedule)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateReportScheduleHandler schedules a weekly or monthly report emailed to a distribution list.
+func CreateReportScheduleHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ReportsManageSchedules) {
+		return
+	}
+	var payload ReportSchedulePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	recipients, err := normalizeReportRecipients(payload.Recipients)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipients: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	schedule := models.ReportSchedule{
+		OrganizationID: orgID.(uuid.UUID),
+		Name:           strings.TrimSpace(payload.Name),
+		ReportType:     payload.ReportType,
+		Format:         payload.Format,
+		Frequency:      payload.Frequency,
+		DayOfWeek:      1,
+		DayOfMonth:     1,
+		Hour:           8,
+		Enabled:        true,
+		CreatedByID:    userID.(uuid.UUID),
+	}
+	if payload.DayOfWeek != nil {
+		schedule.DayOfWeek = *payload.DayOfWeek
+	}
+	if payload.DayOfMonth != nil {
+		schedule.DayOfMonth = *payload.DayOfMonth
+	}
+	if payload.Hour != nil {
+		schedule.Hour = *payload.Hour
+	}
+	if payload.Enabled != nil {
+		schedule.Enabled = *payload.Enabled
+	}
+	schedule.SetRecipients(recipients)
+	schedule.NextRunAt = schedule.NextRunAfter(time.Now())
+
+	if err := database.GetDB().Create(&schedule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create report schedule: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, newReportScheduleResponse(schedule))
+}
+
+// GetReportScheduleHandler returns a scheduled report.
+func GetReportScheduleHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ReportsManageSchedules) {
+		return
+	}
+	schedule, ok := findReportSchedule(c, database.GetDB())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, newReportScheduleResponse(*schedule))
+}
+
+// UpdateReportScheduleHandler changes a scheduled report. Changing the timing recomputes the next run.
+func UpdateReportScheduleHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ReportsManageSchedules) {
+		return
+	}
+	db := database.GetDB()
+	schedule, ok := findReportSchedule(c, db)
+	if !ok {
+		return
+	}
+	var payload UpdateReportSchedulePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if payload.Recipients != nil {
+		recipients, err := normalizeReportRecipients(*payload.Recipients)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipients: " + err.Error()})
+			return
+		}
+		schedule.SetRecipients(recipients)
+	}
+	if payload.Name != nil {
+		schedule.Name = strings.TrimSpace(*payload.Name)
+	}
+	if payload.ReportType != nil {
+		schedule.ReportType = *payload.ReportType
+	}
+	if payload.Format != nil {
+		schedule.Format = *payload.Format
+	}
+	if payload.Frequency != nil {
+		schedule.Frequency = *payload.Frequency
+	}
+	if payload.DayOfWeek != nil {
+		schedule.DayOfWeek = *payload.DayOfWeek
+	}
+	if payload.DayOfMonth != nil {
+		schedule.DayOfMonth = *payload.DayOfMonth
+	}
+	if payload.Hour != nil {
+		schedule.Hour = *payload.Hour
+	}
+	reenabled := payload.Enabled != nil && *payload.Enabled && !schedule.Enabled
+	if payload.Enabled != nil {
+		schedule.Enabled = *payload.Enabled
+	}
+	// Um agendamento reativado não envia os períodos em que ficou desligado
+	if payload.Frequency != nil || payload.DayOfWeek != nil || payload.DayOfMonth != nil || payload.Hour != nil || reenabled {
+		schedule.NextRunAt = schedule.NextRunAfter(time.Now())
+	}
+
+	if err := db.Save(schedule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update report schedule: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newReportScheduleResponse(*schedule))
+}
+
+// DeleteReportScheduleHandler removes a scheduled report.
+func DeleteReportScheduleHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ReportsManageSchedules) {
+		return
+	}
+	db := database.GetDB()
+	schedule, ok := findReportSchedule(c, db)
+	if !ok {
+		return
+	}
+	if err := db.Delete(schedule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete report schedule: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Report schedule deleted successfully"})
+}
+
+// RunReportScheduleHandler generates and emails a scheduled report now, without changing its next run.
+func RunReportScheduleHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ReportsManageSchedules) {
+		return
+	}
+	db := database.GetDB()
+	schedule, ok := findReportSchedule(c, db)
+	if !ok {
+		return
+	}
+	if err := reports.Deliver(c.Request.Context(), db, schedule, time.Now().UTC()); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to deliver report: " + err.Error(), "schedule": newReportScheduleResponse(*schedule)})
+		return
+	}
+	c.JSON(http.StatusOK, newReportScheduleResponse(*schedule))
+}
+
+// DownloadReportHandler renders a report on demand (?format=pdf|xlsx&from=&to=, RFC 3339 or YYYY-MM-DD;
+// defaults to the last 7 days), as a preview of what a schedule would send.
+func DownloadReportHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.ReportsDownload) {
+		return
+	}
+	reportType := models.ReportType(c.Param("reportType"))
+	if _, ok := reports.Titles[reportType]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report type: use risk_register, compliance_delta or overdue_actions"})
+		return
+	}
+	format := models.ReportFormat(c.DefaultQuery("format", string(models.ReportFormatPDF)))
+	if format != models.ReportFormatPDF && format != models.ReportFormatXLSX {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format: use pdf or xlsx"})
+		return
+	}
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseReportDate(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date: use RFC 3339 or YYYY-MM-DD"})
+			return
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -7)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseReportDate(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date: use RFC 3339 or YYYY-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'from' must be before 'to'"})
+		return
+	}
+
+	orgID, _ := c.Get("organizationID")
+	report, err := reports.Build(c.Request.Context(), database.GetDB(), orgID.(uuid.UUID), reportType, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build report: " + err.Error()})
+		return
+	}
+	data, err := reports.Render(report, format)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render report: " + err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", reports.Filename(report, format)))
+	c.Data(http.StatusOK, reports.ContentType(format), data)
+}
+
+// parseReportDate aceita RFC 3339 ou apenas a data (meia-noite UTC).
+func parseReportDate(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/retention"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RetentionRulePayload define o prazo de retenção de uma categoria.
+type RetentionRulePayload struct {
+	Category      models.RetentionCategory `json:"category" binding:"required"`
+	RetentionDays int                      `json:"retention_days" binding:"required"`
+}
+
+// RetentionPolicyPayload substitui as regras de retenção da organização; as categorias ausentes passam a ser
+// mantidas indefinidamente.
+type RetentionPolicyPayload struct {
+	Rules []RetentionRulePayload `json:"rules" binding:"dive"`
+}
+
+// RetentionCategoryInfo descreve uma categoria e o tratamento dos seus registros vencidos.
+type RetentionCategoryInfo struct {
+	Category      models.RetentionCategory `json:"category"`
+	Action        retention.Action         `json:"action"`
+	RetentionDays *int                     `json:"retention_days"` // Nulo quando a organização não definiu prazo
+}
+
+// RetentionPolicyResponse traz as regras da organização e todas as categorias configuráveis.
+type RetentionPolicyResponse struct {
+	Rules            []models.DataRetentionRule `json:"rules"`
+	Categories       []RetentionCategoryInfo    `json:"categories"`
+	MinRetentionDays int                        `json:"min_retention_days"`
+	MaxRetentionDays int                        `json:"max_retention_days"`
+}
+
+func newRetentionPolicyResponse(rules []models.DataRetentionRule) RetentionPolicyResponse {
+	days := map[models.RetentionCategory]int{}
+	for _, rule := range rules {
+		days[rule.Category] = rule.RetentionDays
+	}
+	categories := make([]RetentionCategoryInfo, 0, len(models.RetentionCategories))
+	for _, category := range models.RetentionCategories {
+		action, _ := retention.CategoryAction(category)
+		info := RetentionCategoryInfo{Category: category, Action: action}
+		if d, ok := days[category]; ok {
+			info.RetentionDays = &d
+		}
+		categories = append(categories, info)
+	}
+	return RetentionPolicyResponse{
+		Rules:            rules,
+		Categories:       categories,
+		MinRetentionDays: retention.MinRetentionDays,
+		MaxRetentionDays: retention.MaxRetentionDays,
+	}
+}
+
+// GetRetentionPolicyHandler returns the organization's data retention rules and the configurable categories.
+func GetRetentionPolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.RetentionManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	rules, err := retention.LoadRules(database.GetDB(), orgID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load retention policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newRetentionPolicyResponse(rules))
+}
+
+// SetRetentionPolicyHandler replaces the organization's data retention rules. Expired records are purged, or
+// anonymized for inactive users, by the background retention job.
+func SetRetentionPolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.RetentionManage) {
+		return
+	}
+	var payload RetentionPolicyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	requested := map[models.RetentionCategory]int{}
+	for _, rule := range payload.Rules {
+		if _, ok := retention.CategoryAction(rule.Category); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid retention category: " + string(rule.Category)})
+			return
+		}
+		if _, dup := requested[rule.Category]; dup {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Duplicate retention category: " + string(rule.Category)})
+			return
+		}
+		if rule.RetentionDays < retention.MinRetentionDays || rule.RetentionDays > retention.MaxRetentionDays {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("retention_days must be between %d and %d", retention.MinRetentionDays, retention.MaxRetentionDays)})
+			return
+		}
+		requested[rule.Category] = rule.RetentionDays
+	}
+
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	updatedBy := userID.(uuid.UUID)
+
+	var rules []models.DataRetentionRule
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		current, err := retention.LoadRules(tx, organizationID)
+		if err != nil {
+			return err
+		}
+		previous := retentionRulesDetails(current)
+		for i := range current {
+			days, keep := requested[current[i].Category]
+			if !keep {
+				if err := tx.Delete(&current[i]).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			delete(requested, current[i].Category)
+			if current[i].RetentionDays != days {
+				current[i].RetentionDays = days
+				current[i].UpdatedByID = &updatedBy
+				if err := tx.Save(&current[i]).Error; err != nil {
+					return err
+				}
+			}
+		}
+		for category, days := range requested {
+			rule := models.DataRetentionRule{OrganizationID: organizationID, Category: category, RetentionDays: days, UpdatedByID: &updatedBy}
+			if err := tx.Create(&rule).Error; err != nil {
+				return err
+			}
+		}
+
+		if rules, err = retention.LoadRules(tx, organizationID); err != nil {
+			return err
+		}
+		_, err = recordAuditTrail(c, tx, organizationID, models.AuditTrailRetentionUpdated, "retention_policy", nil,
+			fmt.Sprintf("Data retention policy set (%d rules)", len(rules)),
+			gin.H{"previous": previous, "current": retentionRulesDetails(rules)})
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save retention policy: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newRetentionPolicyResponse(rules))
+}
+
+// GetRetentionReportHandler returns a dry-run report: how many records of each category the retention job would
+// purge or anonymize if it ran now. Nothing is changed.
+func GetRetentionReportHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.RetentionManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	report, err := retention.Evaluate(database.GetDB(), orgID.(uuid.UUID), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build retention report: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// retentionRulesDetails resume as regras para a trilha de auditoria (dias por categoria).
+func retentionRulesDetails(rules []models.DataRetentionRule) gin.H {
+	details := gin.H{}
+	for _, rule := range rules {
+		details[string(rule.Category)] = rule.RetentionDays
+	}
+	return details
+}
@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/internal/preflight"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/statuses"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// bulkRiskMaxItems limita os riscos de uma operação em lote.
+const bulkRiskMaxItems = 500
+
+// Ações da operação em lote de riscos.
+const (
+	bulkRiskActionChangeStatus  = "change_status"
+	bulkRiskActionReassignOwner = "reassign_owner"
+	bulkRiskActionDelete        = "delete"
+)
+
+// Resultado de cada risco da operação em lote.
+const (
+	bulkRiskResultUpdated   = "updated"
+	bulkRiskResultDeleted   = "deleted"
+	bulkRiskResultUnchanged = "unchanged" // Já estava no status ou com o responsável pedido
+	bulkRiskResultSkipped   = "skipped"   // Não pode ser alterado (ver Reason)
+	bulkRiskResultFailed    = "failed"    // Erro ao gravar (ver Error); os demais riscos não são afetados
+)
+
+// Motivos para um risco selecionado não ser alterado.
+const (
+	bulkRiskSkipNotFound           = "not_found"                    // ID não pertence à organização
+	bulkRiskSkipLocked             = "locked"                       // Outro usuário detém o lock de edição
+	bulkRiskSkipTransition         = "transition_not_allowed"       // As regras de transição não permitem o novo status
+	bulkRiskSkipAcceptanceWorkflow = "requires_acceptance_workflow" // Aceite acima do limite exige o fluxo de aprovação
+	bulkRiskSkipConflict           = "conflict"                     // Alterado por outra requisição durante a operação
+)
+
+// BulkRiskOperationPayload aplica uma ação a vários riscos da organização.
+type BulkRiskOperationPayload struct {
+	RiskIDs []string `json:"risk_ids" binding:"required,min=1"`
+	Action  string   `json:"action" binding:"required,oneof=change_status reassign_owner delete"`
+	Status  string   `json:"status" binding:"max=30"` // change_status: status nativo ou cadastrado
+	OwnerID string   `json:"owner_id"`                // reassign_owner: usuário ativo da organização
+	Reason  string   `json:"reason" binding:"max=500"`
+	DryRun  bool     `json:"dry_run"`
+}
+
+// BulkRiskItemResult é o resultado (ou, no dry-run, o resultado previsto) de um risco.
+type BulkRiskItemResult struct {
+	RiskID        uuid.UUID `json:"risk_id"`
+	ReferenceCode string    `json:"reference_code,omitempty"`
+	Title         string    `json:"title,omitempty"`
+	Result        string    `json:"result"`
+	Reason        string    `json:"reason,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	PreviousValue string    `json:"previous_value,omitempty"` // Status ou responsável anterior
+}
+
+// BulkRiskOperationReport é o relatório da operação em lote; o mesmo relatório é gravado na trilha de auditoria.
+type BulkRiskOperationReport struct {
+	DryRun            bool                 `json:"dry_run"`
+	Action            string               `json:"action"`
+	Status            string               `json:"status,omitempty"`
+	OwnerID           *uuid.UUID           `json:"owner_id,omitempty"`
+	Reason            string               `json:"reason,omitempty"`
+	Summary           map[string]int       `json:"summary"` // Quantidade de riscos por resultado
+	Results           []BulkRiskItemResult `json:"results"`
+	Preflight         *preflight.Estimate  `json:"preflight,omitempty"`
+	AuditTrailEntryID *uuid.UUID           `json:"audit_trail_entry_id,omitempty"`
+}
+
+func (r *BulkRiskOperationReport) add(result BulkRiskItemResult) {
+	r.Results = append(r.Results, result)
+	r.Summary[result.Result]++
+}
+
+// pending retorna os riscos que a operação altera (os que estão como updated ou deleted no dry-run).
+func (r *BulkRiskOperationReport) pending() []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, result := range r.Results {
+		if result.Result == bulkRiskResultUpdated || result.Result == bulkRiskResultDeleted {
+			ids = append(ids, result.RiskID)
+		}
+	}
+	return ids
+}
+
+// fingerprint vincula o token de confirmação à ação, ao valor e ao conjunto exato de riscos alterados.
+func (r *BulkRiskOperationReport) fingerprint() string {
+	parts := [][]byte{[]byte(r.Action), []byte(r.Status)}
+	if r.OwnerID != nil {
+		parts = append(parts, []byte(r.OwnerID.String()))
+	}
+	for _, id := range r.pending() {
+		parts = append(parts, []byte(id.String()))
+	}
+	return preflight.Fingerprint(parts...)
+}
+
+// BulkRiskOperationsHandler changes the status, reassigns the owner or deletes many risks of the organization
+// at once (risks:update, or risks:delete for deletion), with a result per risk. Riscos não encontrados, com lock
+// de outro usuário ou cuja transição de status não é permitida são pulados sem afetar os demais. Com dry_run
+// retorna o relatório previsto sem alterar nada (e o token de confirmação quando a operação está acima do limite).
+func BulkRiskOperationsHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	var payload BulkRiskOperationPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	permission := rbac.RisksUpdate
+	if payload.Action == bulkRiskActionDelete {
+		permission = rbac.RisksDelete
+	}
+	if !checkOrgPermission(c, targetOrgID, permission) {
+		return
+	}
+	if len(payload.RiskIDs) > bulkRiskMaxItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d risks can be changed at once", bulkRiskMaxItems)})
+		return
+	}
+	riskIDs := make([]uuid.UUID, 0, len(payload.RiskIDs))
+	seen := make(map[uuid.UUID]bool, len(payload.RiskIDs))
+	for _, raw := range payload.RiskIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid risk ID format: " + raw})
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			riskIDs = append(riskIDs, id)
+		}
+	}
+
+	db := database.GetDB()
+	userID, _ := c.Get("userID")
+	currentUserID := userID.(uuid.UUID)
+	report := BulkRiskOperationReport{
+		DryRun:  payload.DryRun,
+		Action:  payload.Action,
+		Reason:  strings.TrimSpace(payload.Reason),
+		Summary: map[string]int{},
+		Results: []BulkRiskItemResult{},
+	}
+
+	// Valida o valor da ação uma única vez
+	statusSet := statuses.Current(c.Request.Context(), db)
+	var statusDef models.StatusDefinition
+	var newOwnerID uuid.UUID
+	switch payload.Action {
+	case bulkRiskActionChangeStatus:
+		if payload.Status == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "status is required for change_status"})
+			return
+		}
+		if statusDef, err = statusSet.Resolve(models.StatusEntityRisk, payload.Status); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status: " + err.Error()})
+			return
+		}
+		report.Status = payload.Status
+	case bulkRiskActionReassignOwner:
+		if newOwnerID, err = uuid.Parse(payload.OwnerID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "owner_id is required for reassign_owner and must be a user ID"})
+			return
+		}
+		var owners int64
+		if err := db.Model(&models.User{}).Where("id = ? AND organization_id = ? AND is_active = ?", newOwnerID, targetOrgID, true).Count(&owners).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch owner: " + err.Error()})
+			return
+		}
+		if owners == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "owner_id must be an active user of the organization"})
+			return
+		}
+		report.OwnerID = &newOwnerID
+	}
+	_, matrixConfig, err := loadRiskScoringMatrix(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk scoring matrix: " + err.Error()})
+		return
+	}
+
+	var risks []models.Risk
+	if err := db.Where("organization_id = ? AND id IN ?", targetOrgID, riskIDs).Find(&risks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risks: " + err.Error()})
+		return
+	}
+	risksByID := make(map[uuid.UUID]*models.Risk, len(risks))
+	for i := range risks {
+		risksByID[risks[i].ID] = &risks[i]
+	}
+
+	// Classifica cada risco; os que seriam alterados ficam como updated ou deleted até a execução
+	for _, id := range riskIDs {
+		risk, ok := risksByID[id]
+		if !ok {
+			report.add(BulkRiskItemResult{RiskID: id, Result: bulkRiskResultSkipped, Reason: bulkRiskSkipNotFound})
+			continue
+		}
+		item := BulkRiskItemResult{RiskID: id, ReferenceCode: risk.ReferenceCode, Title: risk.Title}
+		lock, err := findActiveEditLock(db, targetOrgID, models.EditLockResourceRisk, id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check edit lock: " + err.Error()})
+			return
+		}
+		switch {
+		case lock != nil && lock.UserID != currentUserID:
+			item.Result, item.Reason = bulkRiskResultSkipped, bulkRiskSkipLocked
+		case payload.Action == bulkRiskActionChangeStatus:
+			item.PreviousValue = risk.EffectiveStatus()
+			switch {
+			case item.PreviousValue == payload.Status:
+				item.Result = bulkRiskResultUnchanged
+			case statusSet.CheckTransition(models.StatusEntityRisk, item.PreviousValue, payload.Status) != nil:
+				item.Result, item.Reason = bulkRiskResultSkipped, bulkRiskSkipTransition
+			case models.RiskStatus(statusDef.BaseStatus) == models.StatusAccepted && risk.Status != models.StatusAccepted &&
+				requiresAcceptanceWorkflow(matrixConfig, risk.RiskLevel):
+				item.Result, item.Reason = bulkRiskResultSkipped, bulkRiskSkipAcceptanceWorkflow
+			default:
+				item.Result = bulkRiskResultUpdated
+			}
+		case payload.Action == bulkRiskActionReassignOwner:
+			if risk.OwnerID != uuid.Nil {
+				item.PreviousValue = risk.OwnerID.String()
+			}
+			if risk.OwnerID == newOwnerID {
+				item.Result = bulkRiskResultUnchanged
+			} else {
+				item.Result = bulkRiskResultUpdated
+			}
+		default:
+			item.Result = bulkRiskResultDeleted
+		}
+		report.add(item)
+	}
+
+	estimate := preflight.Estimate{Operation: bulkOperationRiskBulkUpdate, AffectedRecords: len(report.pending())}
+	if payload.DryRun {
+		evaluateBulkPreflight(c, db, &estimate, report.fingerprint())
+		report.Preflight = &estimate
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	confirmed, ok := confirmBulkOperation(c, db, &estimate, report.fingerprint())
+	if !ok {
+		return
+	}
+
+	// Cada risco é gravado na sua própria transação: uma falha não desfaz os demais
+	changed := 0
+	for i := range report.Results {
+		item := &report.Results[i]
+		if item.Result != bulkRiskResultUpdated && item.Result != bulkRiskResultDeleted {
+			continue
+		}
+		risk := risksByID[item.RiskID]
+		err := applyBulkRiskOperation(c.Request.Context(), db, risk, payload.Action, statusDef, newOwnerID)
+		if err == nil {
+			changed++
+			continue
+		}
+		report.Summary[item.Result]--
+		if errors.Is(err, errVersionConflict) {
+			item.Result, item.Reason = bulkRiskResultSkipped, bulkRiskSkipConflict
+		} else {
+			item.Result, item.Error = bulkRiskResultFailed, err.Error()
+		}
+		report.Summary[item.Result]++
+	}
+
+	if changed > 0 {
+		action := models.AuditTrailRisksBulkUpdated
+		if payload.Action == bulkRiskActionDelete {
+			action = models.AuditTrailRisksBulkDeleted
+		}
+		summary := fmt.Sprintf("Bulk %s applied to %d risks", payload.Action, changed)
+		entry, err := recordAuditTrail(c, db, targetOrgID, action, "risk", nil, summary, report)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record audit trail: " + err.Error(), "report": report})
+			return
+		}
+		report.AuditTrailEntryID = &entry.ID
+		recordBulkOperation(c, db, bulkOperationRiskBulkUpdate, changed, 0, confirmed)
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// applyBulkRiskOperation grava a ação em um risco, desde que ele não tenha mudado desde a leitura (senão
+// retorna errVersionConflict). Mudanças de status e de responsável disparam os eventos e as notificações da edição.
+func applyBulkRiskOperation(ctx context.Context, db *gorm.DB, risk *models.Risk, action string, statusDef models.StatusDefinition, ownerID uuid.UUID) error {
+	previousStatus := risk.EffectiveStatus()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var result *gorm.DB
+		switch action {
+		case bulkRiskActionChangeStatus:
+			risk.SetStatus(models.RiskStatus(statusDef.BaseStatus), statusDef.Code)
+			result = tx.Model(risk).Where("updated_at = ?", risk.UpdatedAt).
+				Updates(map[string]interface{}{"status": risk.Status, "status_code": risk.StatusCode})
+		case bulkRiskActionReassignOwner:
+			risk.OwnerID = ownerID
+			result = tx.Model(risk).Where("updated_at = ?", risk.UpdatedAt).Update("owner_id", ownerID)
+		default:
+			// Exclusão lógica, como na exclusão individual: o risco vai para a lixeira
+			result = tx.Where("updated_at = ?", risk.UpdatedAt).Delete(risk)
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errVersionConflict
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case action == bulkRiskActionChangeStatus && risk.EffectiveStatus() != previousStatus:
+		notifications.QueueRiskEvent(ctx, risk.OrganizationID, *risk, models.EventTypeRiskStatusChanged)
+		changed := templates.RiskStatusChanged{
+			RiskTitle: risk.Title, OldStatus: previousStatus, NewStatus: risk.EffectiveStatus(),
+			Link: notifications.RiskLink(risk.ID),
+		}
+		if risk.OwnerID != uuid.Nil {
+			notifications.NotifyUserWithTemplate(ctx, risk.OwnerID, templates.NameRiskStatusChanged, changed)
+		}
+		if risk.OwnerTeamID != nil {
+			notifications.NotifyTeamWithTemplate(ctx, *risk.OwnerTeamID, templates.NameRiskStatusChanged, changed, risk.OwnerID)
+		}
+	case action == bulkRiskActionReassignOwner:
+		notifications.QueueRiskEvent(ctx, risk.OrganizationID, *risk, models.EventTypeRiskOwnerChanged)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// riskCategoryKeyPattern restringe a key a letras minúsculas, dígitos e "_" (ex: "terceiros", "lgpd_privacidade").
+var riskCategoryKeyPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// RiskCategoryPayload defines the structure for creating a risk category.
+type RiskCategoryPayload struct {
+	Key         string `json:"key" binding:"required,min=2,max=50"`
+	Name        string `json:"name" binding:"required,min=2,max=100"`
+	Description string `json:"description"`
+}
+
+// UpdateRiskCategoryPayload atualiza o nome, a descrição e a situação; a key é imutável.
+type UpdateRiskCategoryPayload struct {
+	Name        *string `json:"name" binding:"omitempty,min=2,max=100"`
+	Description *string `json:"description"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+// loadRiskCategories retorna as categorias de risco da organização ordenadas pelo nome. Organizações sem
+// taxonomia recebem as categorias padrão no primeiro acesso.
+func loadRiskCategories(db *gorm.DB, organizationID uuid.UUID) ([]models.RiskCategoryDefinition, error) {
+	var categories []models.RiskCategoryDefinition
+	if err := db.Where("organization_id = ?", organizationID).Order("name asc").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	if len(categories) > 0 {
+		return categories, nil
+	}
+	defaults := make([]models.RiskCategoryDefinition, len(models.DefaultRiskCategories))
+	for i, category := range models.DefaultRiskCategories {
+		category.OrganizationID = organizationID
+		category.IsActive = true
+		defaults[i] = category
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&defaults).Error; err != nil {
+		return nil, err
+	}
+	err := db.Where("organization_id = ?", organizationID).Order("name asc").Find(&categories).Error
+	return categories, err
+}
+
+// activeRiskCategoryKeys retorna as keys das categorias ativas da organização, em minúsculas, indexadas
+// também pelo nome (usado na importação CSV).
+func activeRiskCategoryKeys(db *gorm.DB, organizationID uuid.UUID) (map[string]string, error) {
+	categories, err := loadRiskCategories(db, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]string, 2*len(categories))
+	for _, category := range categories {
+		if !category.IsActive {
+			continue
+		}
+		keys[category.Key] = category.Key
+		keys[strings.ToLower(category.Name)] = category.Key
+	}
+	return keys, nil
+}
+
+// validateRiskCategory responde 400 e retorna false se a categoria não for uma categoria ativa da organização.
+func validateRiskCategory(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, category models.RiskCategory) bool {
+	keys, err := activeRiskCategoryKeys(db, organizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk categories: " + err.Error()})
+		return false
+	}
+	if keys[string(category)] != string(category) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category: not an active risk category of the organization"})
+		return false
+	}
+	return true
+}
+
+// findRiskCategory carrega a categoria :categoryId da organização.
+func findRiskCategory(c *gin.Context, db *gorm.DB, organizationID uuid.UUID) (*models.RiskCategoryDefinition, bool) {
+	categoryID, err := uuid.Parse(c.Param("categoryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID format"})
+		return nil, false
+	}
+	var category models.RiskCategoryDefinition
+	if err := db.Where("id = ? AND organization_id = ?", categoryID, organizationID).First(&category).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Risk category not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risk category: " + err.Error()})
+		return nil, false
+	}
+	return &category, true
+}
+
+// ListRiskCategoriesHandler lists the organization's risk categories; inactive ones only with ?include_inactive=true.
+func ListRiskCategoriesHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, exists := c.Get("organizationID")
+	if !exists || tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization's risks"})
+		return
+	}
+	categories, err := loadRiskCategories(database.GetDB(), targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk categories: " + err.Error()})
+		return
+	}
+	includeInactive := c.Query("include_inactive") == "true"
+	result := make([]models.RiskCategoryDefinition, 0, len(categories))
+	for _, category := range categories {
+		if category.IsActive || includeInactive {
+			result = append(result, category)
+		}
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CreateRiskCategoryHandler adds a category to the organization's risk taxonomy.
+func CreateRiskCategoryHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RisksConfigure) {
+		return
+	}
+	var payload RiskCategoryPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	key := strings.TrimSpace(payload.Key)
+	if !riskCategoryKeyPattern.MatchString(key) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category key: use lowercase letters, digits and underscores"})
+		return
+	}
+	db := database.GetDB()
+	// Garante as categorias padrão antes da primeira categoria personalizada
+	if _, err := loadRiskCategories(db, targetOrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk categories: " + err.Error()})
+		return
+	}
+	var count int64
+	db.Model(&models.RiskCategoryDefinition{}).Where("organization_id = ? AND key = ?", targetOrgID, key).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "A risk category with this key already exists"})
+		return
+	}
+	category := models.RiskCategoryDefinition{
+		OrganizationID: targetOrgID,
+		Key:            key,
+		Name:           strings.TrimSpace(payload.Name),
+		Description:    payload.Description,
+		IsActive:       true,
+	}
+	if err := db.Create(&category).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create risk category: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateRiskCategoryHandler updates a risk category's name, description or active flag.
+func UpdateRiskCategoryHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RisksConfigure) {
+		return
+	}
+	var payload UpdateRiskCategoryPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	category, ok := findRiskCategory(c, db, targetOrgID)
+	if !ok {
+		return
+	}
+	if payload.Name != nil {
+		category.Name = strings.TrimSpace(*payload.Name)
+	}
+	if payload.Description != nil {
+		category.Description = *payload.Description
+	}
+	if payload.IsActive != nil {
+		category.IsActive = *payload.IsActive
+	}
+	if err := db.Save(category).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update risk category: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, category)
+}
+
+// DeleteRiskCategoryHandler removes a risk category that no risk uses; categories in use can only be deactivated.
+func DeleteRiskCategoryHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RisksConfigure) {
+		return
+	}
+	db := database.GetDB()
+	category, ok := findRiskCategory(c, db, targetOrgID)
+	if !ok {
+		return
+	}
+	var inUse int64
+	if err := db.Model(&models.Risk{}).Where("organization_id = ? AND category = ?", targetOrgID, category.Key).Count(&inUse).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check risks using the category: " + err.Error()})
+		return
+	}
+	if inUse > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "The category is used by existing risks; deactivate it instead", "risks": inUse})
+		return
+	}
+	if err := db.Delete(category).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete risk category: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Risk category deleted successfully"})
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RiskControlsPayload vincula controles mitigadores a um risco.
+type RiskControlsPayload struct {
+	AuditControlIDs []string `json:"audit_control_ids" binding:"required,min=1"`
+	Notes           string   `json:"notes"`
+}
+
+// RiskControlSummary é um controle mitigador vinculado a um risco, com o status da avaliação da organização.
+type RiskControlSummary struct {
+	ID               uuid.UUID `json:"id"`
+	ControlID        string    `json:"control_id"`
+	Description      string    `json:"description"`
+	FrameworkID      uuid.UUID `json:"framework_id"`
+	FrameworkName    string    `json:"framework_name"`
+	Notes            string    `json:"notes,omitempty"`
+	AssessmentStatus *string   `json:"assessment_status,omitempty"`
+}
+
+// findRiskForControlMapping carrega o risco de :riskId; alterações exigem ser o responsável ou ter risks:update.
+func findRiskForControlMapping(c *gin.Context, db *gorm.DB, forWrite bool) (*models.Risk, bool) {
+	riskID, err := uuid.Parse(c.Param("riskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid risk ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var risk models.Risk
+	if err := db.Where("id = ? AND organization_id = ?", riskID, orgID).First(&risk).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Risk not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risk: " + err.Error()})
+		return nil, false
+	}
+	if forWrite {
+		userID, _ := c.Get("userID")
+		if risk.OwnerID != userID.(uuid.UUID) && !rbac.Can(c, rbac.RisksUpdate) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to manage controls for this risk"})
+			return nil, false
+		}
+	}
+	return &risk, true
+}
+
+// loadRiskControls lista os controles vinculados ao risco com o status da avaliação da organização.
+func loadRiskControls(db *gorm.DB, risk *models.Risk) ([]RiskControlSummary, error) {
+	controls := []RiskControlSummary{}
+	err := db.Table("risk_controls").
+		Select("audit_controls.id, audit_controls.control_id, audit_controls.description, audit_controls.framework_id, audit_frameworks.name AS framework_name, risk_controls.notes, audit_assessments.status AS assessment_status").
+		Joins("JOIN audit_controls ON audit_controls.id = risk_controls.audit_control_id").
+		Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Joins("LEFT JOIN audit_assessments ON audit_assessments.audit_control_id = audit_controls.id AND audit_assessments.organization_id = ? AND audit_assessments.audit_campaign_id IS NULL", risk.OrganizationID).
+		Where("risk_controls.risk_id = ?", risk.ID).
+		Order("audit_frameworks.name asc, " + models.ControlOrderClause).
+		Scan(&controls).Error
+	return controls, err
+}
+
+// loadMappedRisksByControl retorna, por controle, os riscos da organização vinculados a ele.
+func loadMappedRisksByControl(db *gorm.DB, organizationID uuid.UUID, controlIDs []uuid.UUID) (map[uuid.UUID][]AssetRiskSummary, error) {
+	type mappedRisk struct {
+		AuditControlID uuid.UUID
+		AssetRiskSummary
+	}
+	var rows []mappedRisk
+	result := make(map[uuid.UUID][]AssetRiskSummary)
+	if len(controlIDs) == 0 {
+		return result, nil
+	}
+	err := db.Table("risk_controls").
+		Select("risk_controls.audit_control_id, risks.id, risks.title, risks.risk_level, risks.status").
+		Joins("JOIN risks ON risks.id = risk_controls.risk_id AND risks.deleted_at IS NULL").
+		Where("risk_controls.organization_id = ? AND risk_controls.audit_control_id IN ?", organizationID, controlIDs).
+		Order("risks.title asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.AuditControlID] = append(result[row.AuditControlID], row.AssetRiskSummary)
+	}
+	return result, nil
+}
+
+// ListRiskControlsHandler lists the audit controls mapped as mitigating controls of a risk.
+func ListRiskControlsHandler(c *gin.Context) {
+	db := database.GetDB()
+	risk, ok := findRiskForControlMapping(c, db, false)
+	if !ok {
+		return
+	}
+	controls, err := loadRiskControls(db, risk)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list risk controls: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, controls)
+}
+
+// AddRiskControlsHandler maps one or more audit controls to a risk. Existing links are kept (notes unchanged).
+func AddRiskControlsHandler(c *gin.Context) {
+	var payload RiskControlsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	risk, ok := findRiskForControlMapping(c, db, true)
+	if !ok {
+		return
+	}
+	controlIDs, err := parseUniqueUUIDs(payload.AuditControlIDs, "audit_control_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var count int64
+	db.Model(&models.AuditControl{}).Where("id IN ?", controlIDs).Count(&count)
+	if int(count) != len(controlIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "One or more audit controls were not found"})
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	links := make([]models.RiskControl, 0, len(controlIDs))
+	for _, id := range controlIDs {
+		links = append(links, models.RiskControl{
+			OrganizationID: risk.OrganizationID,
+			RiskID:         risk.ID,
+			AuditControlID: id,
+			Notes:          payload.Notes,
+			CreatedByID:    userID.(uuid.UUID),
+		})
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to map controls to risk: " + err.Error()})
+		return
+	}
+	controls, err := loadRiskControls(db, risk)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list risk controls: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, controls)
+}
+
+// RemoveRiskControlHandler removes the mapping between a risk and an audit control.
+func RemoveRiskControlHandler(c *gin.Context) {
+	controlID, err := uuid.Parse(c.Param("controlId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit control ID format"})
+		return
+	}
+	db := database.GetDB()
+	risk, ok := findRiskForControlMapping(c, db, true)
+	if !ok {
+		return
+	}
+	result := db.Where("risk_id = ? AND audit_control_id = ?", risk.ID, controlID).Delete(&models.RiskControl{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove control from risk: " + result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Control is not mapped to this risk"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Control removed from risk successfully"})
+}
+
+// ListControlRisksHandler lists the organization's risks mitigated by an audit control.
+func ListControlRisksHandler(c *gin.Context) {
+	controlID, err := uuid.Parse(c.Param("controlId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit control ID format"})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	risksByControl, err := loadMappedRisksByControl(database.GetDB(), orgID.(uuid.UUID), []uuid.UUID{controlID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list control risks: " + err.Error()})
+		return
+	}
+	risks := risksByControl[controlID]
+	if risks == nil {
+		risks = []AssetRiskSummary{}
+	}
+	c.JSON(http.StatusOK, risks)
+}
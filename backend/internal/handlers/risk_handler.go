@@ -1,16 +1,23 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/csv"
 	"fmt"
 	"io"
 	"net/http"
 	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/events"
 	"phoenixgrc/backend/internal/models"
 	phxlog "phoenixgrc/backend/pkg/log"
 	"phoenixgrc/backend/internal/notifications"
-	"phoenixgrc/backend/internal/riskutils"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/internal/preflight"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/statuses"
+	"phoenixgrc/backend/internal/trash"
 	"phoenixgrc/backend/pkg/features"
+	"sort"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -24,11 +31,12 @@ import (
 type RiskPayload struct {
 	Title       string                `json:"title" binding:"required,min=3,max=255"`
 	Description string                `json:"description"`
-	Category    models.RiskCategory   `json:"category" binding:"omitempty,oneof=tecnologico operacional legal"`
+	Category    models.RiskCategory   `json:"category" binding:"omitempty,max=50"` // Key de uma categoria ativa da organização
 	Impact      models.RiskImpact     `json:"impact" binding:"omitempty,oneof=Baixo Médio Alto Crítico"`
 	Probability models.RiskProbability `json:"probability" binding:"omitempty,oneof=Baixo Médio Alto Crítico"`
-	Status      models.RiskStatus     `json:"status" binding:"omitempty,oneof=aberto em_andamento mitigado aceito"`
+	Status      models.RiskStatus     `json:"status" binding:"omitempty,max=30"` // Status nativo ou cadastrado (ver StatusDefinition)
 	OwnerID     string                `json:"owner_id"`
+	OwnerTeamID *string               `json:"owner_team_id"` // Equipe dona do risco; na atualização, nil mantém e "" remove
 }
 
 // CreateRiskHandler handles the creation of a new risk.
@@ -52,6 +60,13 @@ func CreateRiskHandler(c *gin.Context) {
 	} else {
 		ownerUUID = userID.(uuid.UUID)
 	}
+	var ownerTeamID *uuid.UUID
+	if payload.OwnerTeamID != nil {
+		var ok bool
+		if ownerTeamID, ok = parseOptionalOrgTeamID(c, db, orgID.(uuid.UUID), "owner_team_id", *payload.OwnerTeamID); !ok {
+			return
+		}
+	}
 	risk := models.Risk{
 		OrganizationID: orgID.(uuid.UUID),
 		Title:          payload.Title,
@@ -59,24 +74,46 @@ func CreateRiskHandler(c *gin.Context) {
 		Category:       payload.Category,
 		Impact:         payload.Impact,
 		Probability:    payload.Probability,
-		Status:         payload.Status,
+		Status:         models.StatusOpen,
 		OwnerID:        ownerUUID,
+		OwnerTeamID:    ownerTeamID,
 	}
-	if risk.Status == "" {
-		risk.Status = models.StatusOpen
+	if payload.Status != "" {
+		def, ok := resolveStatus(c, db, models.StatusEntityRisk, "", string(payload.Status))
+		if !ok {
+			return
+		}
+		risk.SetStatus(models.RiskStatus(def.BaseStatus), def.Code)
+	}
+	if risk.Category != "" && !validateRiskCategory(c, db, risk.OrganizationID, risk.Category) {
+		return
+	}
+	matrix, matrixConfig, err := loadRiskScoringMatrix(db, risk.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk scoring matrix: " + err.Error()})
+		return
+	}
+	risk.RiskLevel = matrix.Level(risk.Impact, risk.Probability)
+	if risk.Status == models.StatusAccepted && requiresAcceptanceWorkflow(matrixConfig, risk.RiskLevel) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Risks at or above the acceptance threshold must be accepted through the approval workflow"})
+		return
 	}
-	risk.RiskLevel = riskutils.CalculateRiskLevel(risk.Impact, risk.Probability)
 	if err := db.Create(&risk).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create risk: " + err.Error()})
 		return
 	}
 
-	go notifications.NotifyRiskEvent(c.Request.Context(), risk.OrganizationID, risk, models.EventTypeRiskCreated)
+	notifications.QueueRiskEvent(c.Request.Context(), risk.OrganizationID, risk, models.EventTypeRiskCreated)
+	events.Publish(risk.OrganizationID, events.EventRiskCreated, risk.ID, risk)
+	created := templates.RiskCreated{
+		RiskTitle: risk.Title, Description: risk.Description, Impact: string(risk.Impact), Probability: string(risk.Probability),
+		Link: notifications.RiskLink(risk.ID),
+	}
 	if risk.OwnerID != uuid.Nil {
-		emailSubject := fmt.Sprintf("Novo Risco Criado: %s", risk.Title)
-		emailBody := fmt.Sprintf("Um novo risco foi criado e atribuído a você ou à sua equipe:\n\nTítulo: %s\nDescrição: %s\nImpacto: %s\nProbabilidade: %s\n\nAcesse o Phoenix GRC para mais detalhes.",
-			risk.Title, risk.Description, risk.Impact, risk.Probability)
-		go notifications.NotifyUserByEmail(c.Request.Context(), risk.OwnerID, emailSubject, emailBody)
+		notifications.NotifyUserWithTemplate(c.Request.Context(), risk.OwnerID, templates.NameRiskCreated, created)
+	}
+	if risk.OwnerTeamID != nil {
+		notifications.NotifyTeamWithTemplate(c.Request.Context(), *risk.OwnerTeamID, templates.NameRiskCreated, created, risk.OwnerID)
 	}
 	c.JSON(http.StatusCreated, risk)
 }
@@ -100,41 +137,82 @@ func GetRiskHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risk: " + err.Error()})
 		return
 	}
-	if features.IsEnabled("LOG_DETALHADO_RISCO") {
+	if features.IsEnabledFor(c.Request.Context(), "LOG_DETALHADO_RISCO", featureFlagSubject(c)) {
 		phxlog.L.Debug("Detailed risk information requested (feature flag enabled)",
 			zap.String("riskID", riskID.String()),
 			zap.Any("risk", risk),
 		)
 	}
+	setVersionETag(c, risk.UpdatedAt)
 	c.JSON(http.StatusOK, risk)
 }
 
+// riskFiltersScope aplica os filtros da listagem de riscos (status, impact, probability, category, owner_team_id
+// e my_teams=true, os riscos das equipes do usuário).
+func riskFiltersScope(c *gin.Context) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if status := c.Query("status"); status != "" {
+			// Um status nativo inclui os cadastrados que o refinam; um cadastrado filtra apenas ele
+			if statuses.IsBuiltin(models.StatusEntityRisk, status) {
+				query = query.Where("status = ?", status)
+			} else {
+				query = query.Where("status_code = ?", status)
+			}
+		}
+		if impact := c.Query("impact"); impact != "" {
+			query = query.Where("impact = ?", impact)
+		}
+		if probability := c.Query("probability"); probability != "" {
+			query = query.Where("probability = ?", probability)
+		}
+		if category := c.Query("category"); category != "" {
+			query = query.Where("category = ?", category)
+		}
+		if teamID := c.Query("owner_team_id"); teamID != "" {
+			query = query.Where("risks.owner_team_id = ?", teamID) // Validado por validateRiskFilters
+		}
+		if c.Query("my_teams") == "true" {
+			userID, _ := c.Get("userID")
+			query = query.Where("risks.owner_team_id IN (?)", models.UserTeamIDs(database.GetDB(), userID.(uuid.UUID)))
+		}
+		return query.Scopes(tagFilterScope(c, models.TagEntityRisk, "risks.id"))
+	}
+}
+
+// validateRiskFilters rejeita os filtros de riskFiltersScope com valores inválidos para o banco.
+func validateRiskFilters(c *gin.Context) bool {
+	if teamID := c.Query("owner_team_id"); teamID != "" {
+		if _, err := uuid.Parse(teamID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid owner_team_id: use a team ID"})
+			return false
+		}
+	}
+	return true
+}
+
 // ListRisksHandler handles fetching all risks for the organization with pagination.
+// ?view_id= aplica os filtros de uma visão salva. Os itens vêm do modelo de leitura risk_list_items, com o
+// responsável, a situação do aceite, o número de stakeholders e o andamento do tratamento.
 func ListRisksHandler(c *gin.Context) {
+	if !applySavedView(c, models.SavedViewRisk) {
+		return
+	}
+	if !validateRiskFilters(c) {
+		return
+	}
 	orgID, _ := c.Get("organizationID")
 	organizationID := orgID.(uuid.UUID)
 	page, pageSize := GetPaginationParams(c)
-	db := database.GetDB()
-	var risks []models.Risk
+	db := database.GetDB().WithContext(c.Request.Context())
+	risks := []models.RiskListItem{}
 	var totalItems int64
-	query := db.Model(&models.Risk{}).Where("organization_id = ?", organizationID)
-	if status := c.Query("status"); status != "" {
-		query = query.Where("status = ?", status)
-	}
-	if impact := c.Query("impact"); impact != "" {
-		query = query.Where("impact = ?", impact)
-	}
-	if probability := c.Query("probability"); probability != "" {
-		query = query.Where("probability = ?", probability)
-	}
-	if category := c.Query("category"); category != "" {
-		query = query.Where("category = ?", category)
-	}
-	if err := query.Count(&totalItems).Error; err != nil {
+	// A contagem usa a tabela, sem os agregados da view; a página usa a view com o mesmo alias e filtros.
+	if err := db.Table("risks").Where("risks.organization_id = ? AND risks.deleted_at IS NULL", organizationID).Scopes(riskFiltersScope(c)).Count(&totalItems).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count risks: " + err.Error()})
 		return
 	}
-	if err := query.Scopes(PaginateScope(page, pageSize)).Preload("Owner").Order("created_at desc").Find(&risks).Error; err != nil {
+	query := db.Table("risk_list_items AS risks").Where("risks.organization_id = ?", organizationID).Scopes(riskFiltersScope(c))
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("risks.created_at desc, risks.id").Find(&risks).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list risks: " + err.Error()})
 		return
 	}
@@ -155,6 +233,8 @@ func ListRisksHandler(c *gin.Context) {
 }
 
 // UpdateRiskHandler handles updating an existing risk.
+// Com If-Match (o ETag de GET /risks/:riskId), responde 412 se o risco mudou desde a leitura do cliente; uma
+// alteração concorrente entre a leitura e a gravação responde 409.
 func UpdateRiskHandler(c *gin.Context) {
 	riskIDStr := c.Param("riskId")
 	riskID, err := uuid.Parse(riskIDStr)
@@ -169,10 +249,9 @@ func UpdateRiskHandler(c *gin.Context) {
 	}
 	orgID, _ := c.Get("organizationID")
 	userIDToken, _ := c.Get("userID")
-	userRoleToken, _ := c.Get("userRole")
 	db := database.GetDB()
 	var risk models.Risk
-	var originalStatus models.RiskStatus
+	var originalStatus string
 
 	if err := db.Where("id = ? AND organization_id = ?", riskID, orgID).First(&risk).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -183,24 +262,48 @@ func UpdateRiskHandler(c *gin.Context) {
 		return
 	}
 
-	currentUserRole := userRoleToken.(models.UserRole)
 	currentUserID := userIDToken.(uuid.UUID)
-	isOwner := risk.OwnerID == currentUserID
-	isAdmin := currentUserRole == models.RoleAdmin
-	isManager := currentUserRole == models.RoleManager
+	isOwner, err := isOwnerOrTeamMember(db, currentUserID, risk.OwnerID, risk.OwnerTeamID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership: " + err.Error()})
+		return
+	}
+	canUpdateAny := rbac.Can(c, rbac.RisksUpdate)
 
-	if !isOwner && !isAdmin && !isManager {
+	if !isOwner && !canUpdateAny {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to update this risk"})
 		return
 	}
 
-	originalStatus = risk.Status
+	if !ensureNotLockedByOther(c, db, risk.OrganizationID, models.EditLockResourceRisk, risk.ID, currentUserID) {
+		return
+	}
+	if !checkIfMatch(c, risk.UpdatedAt, true) {
+		return
+	}
+
+	loadedUpdatedAt := risk.UpdatedAt
+	originalStatus = risk.EffectiveStatus()
+	originalBaseStatus := risk.Status
+	originalOwnerID := risk.OwnerID
 	risk.Title = payload.Title
 	risk.Description = payload.Description
-	if payload.Category != "" { risk.Category = payload.Category }
+	if payload.Category != "" && payload.Category != risk.Category {
+		// Uma categoria desativada é mantida no risco, mas não pode ser atribuída
+		if !validateRiskCategory(c, db, risk.OrganizationID, payload.Category) {
+			return
+		}
+		risk.Category = payload.Category
+	}
 	if payload.Impact != "" { risk.Impact = payload.Impact }
 	if payload.Probability != "" { risk.Probability = payload.Probability }
-	if payload.Status != "" { risk.Status = payload.Status }
+	if payload.Status != "" {
+		def, ok := resolveStatus(c, db, models.StatusEntityRisk, originalStatus, string(payload.Status))
+		if !ok {
+			return
+		}
+		risk.SetStatus(models.RiskStatus(def.BaseStatus), def.Code)
+	}
 
 	if payload.OwnerID != "" {
 		parsedOwnerID, err := uuid.Parse(payload.OwnerID)
@@ -209,7 +312,7 @@ func UpdateRiskHandler(c *gin.Context) {
 			return
 		}
 		if risk.OwnerID != parsedOwnerID {
-			if isAdmin || isManager {
+			if canUpdateAny {
 				risk.OwnerID = parsedOwnerID
 			} else {
 				c.JSON(http.StatusForbidden, gin.H{"error": "Only Admins or Managers can change the risk owner."})
@@ -217,27 +320,63 @@ func UpdateRiskHandler(c *gin.Context) {
 			}
 		}
 	}
+	if payload.OwnerTeamID != nil {
+		ownerTeamID, ok := parseOptionalOrgTeamID(c, db, risk.OrganizationID, "owner_team_id", *payload.OwnerTeamID)
+		if !ok {
+			return
+		}
+		if !sameUUIDPtr(risk.OwnerTeamID, ownerTeamID) {
+			if !canUpdateAny {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Only Admins or Managers can change the risk owner."})
+				return
+			}
+			risk.OwnerTeamID = ownerTeamID
+		}
+	}
 
+	matrix, matrixConfig, err := loadRiskScoringMatrix(db, risk.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk scoring matrix: " + err.Error()})
+		return
+	}
 	if payload.Impact != "" || payload.Probability != "" {
-		risk.RiskLevel = riskutils.CalculateRiskLevel(risk.Impact, risk.Probability)
+		risk.RiskLevel = matrix.Level(risk.Impact, risk.Probability)
+	}
+	if risk.Status == models.StatusAccepted && originalBaseStatus != models.StatusAccepted && requiresAcceptanceWorkflow(matrixConfig, risk.RiskLevel) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Risks at or above the acceptance threshold must be accepted through the approval workflow"})
+		return
 	}
 
-	if err := db.Save(&risk).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update risk: " + err.Error()})
+	// Grava somente se o risco não mudou desde a leitura acima
+	result := db.Model(&risk).Where("updated_at = ?", loadedUpdatedAt).Select("*").Omit(clause.Associations).Updates(&risk)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update risk: " + result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondVersionConflict(c)
 		return
 	}
 
 	var updatedRisk models.Risk
 	db.Preload("Owner").Where("id = ?", risk.ID).First(&updatedRisk)
+	setVersionETag(c, updatedRisk.UpdatedAt)
 
-	if updatedRisk.Status != originalStatus {
-		go notifications.NotifyRiskEvent(c.Request.Context(), updatedRisk.OrganizationID, updatedRisk, models.EventTypeRiskStatusChanged)
+	if updatedRisk.EffectiveStatus() != originalStatus {
+		notifications.QueueRiskEvent(c.Request.Context(), updatedRisk.OrganizationID, updatedRisk, models.EventTypeRiskStatusChanged)
+		changed := templates.RiskStatusChanged{
+			RiskTitle: updatedRisk.Title, OldStatus: originalStatus, NewStatus: updatedRisk.EffectiveStatus(),
+			Link: notifications.RiskLink(updatedRisk.ID),
+		}
 		if updatedRisk.OwnerID != uuid.Nil {
-			emailSubject := fmt.Sprintf("Status do Risco '%s' Alterado para '%s'", updatedRisk.Title, updatedRisk.Status)
-			emailBody := fmt.Sprintf("O status do risco '%s' foi alterado de '%s' para '%s'.\n\nAcesse o Phoenix GRC para mais detalhes.",
-				updatedRisk.Title, originalStatus, updatedRisk.Status)
-			go notifications.NotifyUserByEmail(c.Request.Context(), updatedRisk.OwnerID, emailSubject, emailBody)
+			notifications.NotifyUserWithTemplate(c.Request.Context(), updatedRisk.OwnerID, templates.NameRiskStatusChanged, changed)
 		}
+		if updatedRisk.OwnerTeamID != nil {
+			notifications.NotifyTeamWithTemplate(c.Request.Context(), *updatedRisk.OwnerTeamID, templates.NameRiskStatusChanged, changed, updatedRisk.OwnerID)
+		}
+	}
+	if updatedRisk.OwnerID != originalOwnerID {
+		notifications.QueueRiskEvent(c.Request.Context(), updatedRisk.OrganizationID, updatedRisk, models.EventTypeRiskOwnerChanged)
 	}
 	c.JSON(http.StatusOK, updatedRisk)
 }
@@ -252,7 +391,6 @@ func DeleteRiskHandler(c *gin.Context) {
 	}
 	orgID, _ := c.Get("organizationID")
 	userIDToken, _ := c.Get("userID")
-	userRoleToken, _ := c.Get("userRole")
 	db := database.GetDB()
 	var risk models.Risk
 
@@ -265,22 +403,32 @@ func DeleteRiskHandler(c *gin.Context) {
 		return
 	}
 
-	currentUserRole := userRoleToken.(models.UserRole)
 	currentUserID := userIDToken.(uuid.UUID)
-	isOwner := risk.OwnerID == currentUserID
-	isAdmin := currentUserRole == models.RoleAdmin
-	isManager := currentUserRole == models.RoleManager
+	isOwner, err := isOwnerOrTeamMember(db, currentUserID, risk.OwnerID, risk.OwnerTeamID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership: " + err.Error()})
+		return
+	}
 
-	if !isOwner && !isAdmin && !isManager {
+	if !isOwner && !rbac.Can(c, rbac.RisksDelete) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete this risk"})
 		return
 	}
 
-	if err := db.Delete(&risk).Error; err != nil {
+	// Exclusão lógica: o risco vai para a lixeira com as tags e os vínculos, e pode ser restaurado até o expurgo
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&risk).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, risk.OrganizationID, models.AuditTrailRiskDeleted, "risk", &risk.ID,
+			fmt.Sprintf("Risk %s moved to the trash", risk.ReferenceCode), nil)
+		return err
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete risk: " + err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Risk deleted successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Risk deleted successfully", "purge_at": trash.PurgeAt(risk.DeletedAt.Time)})
 }
 
 // --- Approval Workflow Handlers ---
@@ -293,10 +441,8 @@ func SubmitRiskForAcceptanceHandler(c *gin.Context) {
 	}
 	tokenOrgID, _ := c.Get("organizationID")
 	tokenUserID, _ := c.Get("userID")
-	tokenUserRole, _ := c.Get("userRole")
 
-	if tokenUserRole.(models.UserRole) != models.RoleAdmin && tokenUserRole.(models.UserRole) != models.RoleManager {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins or managers can submit risks for acceptance"})
+	if !rbac.Require(c, rbac.RisksSubmitAcceptance) {
 		return
 	}
 	db := database.GetDB()
@@ -324,10 +470,11 @@ func SubmitRiskForAcceptanceHandler(c *gin.Context) {
 		return
 	}
 	approvalWorkflow := models.ApprovalWorkflow{
-		RiskID:      riskID,
-		RequesterID: tokenUserID.(uuid.UUID),
-		ApproverID:  risk.OwnerID,
-		Status:      models.ApprovalPending,
+		OrganizationID: risk.OrganizationID,
+		RiskID:         &riskID,
+		RequesterID:    tokenUserID.(uuid.UUID),
+		ApproverID:     risk.OwnerID,
+		Status:         models.ApprovalPending,
 	}
 	if err := db.Create(&approvalWorkflow).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create approval workflow: " + err.Error()})
@@ -338,13 +485,10 @@ func SubmitRiskForAcceptanceHandler(c *gin.Context) {
 	db.First(&requesterUser, "id = ?", approvalWorkflow.RequesterID)
 	db.First(&approverUser, "id = ?", approvalWorkflow.ApproverID)
 	if approverUser.ID != uuid.Nil && approverUser.IsActive {
-		emailSubject := fmt.Sprintf("Ação Requerida: Aprovação de Aceite para o Risco '%s'", risk.Title)
-		emailBody := fmt.Sprintf(
-			"Olá %s,\n\nO risco '%s' (Descrição: %s) foi submetido para sua aprovação de aceite por %s.\n\nPor favor, acesse o Phoenix GRC para revisar e tomar uma decisão.\n\nDetalhes do Risco:\nImpacto: %s\nProbabilidade: %s\nNível de Risco: %s",
-			approverUser.Name, risk.Title, risk.Description, requesterUser.Name,
-			risk.Impact, risk.Probability, risk.RiskLevel,
-		)
-		go notifications.NotifyUserByEmail(c.Request.Context(), approverUser.ID, emailSubject, emailBody)
+		notifications.NotifyUserWithTemplate(c.Request.Context(), approverUser.ID, templates.NameRiskAcceptanceRequested, templates.RiskAcceptanceRequested{
+			RiskTitle: risk.Title, Description: risk.Description, Impact: string(risk.Impact), Probability: string(risk.Probability),
+			RiskLevel: risk.RiskLevel, RequesterName: requesterUser.Name, Link: notifications.RiskLink(risk.ID),
+		})
 		phxlog.L.Info("Risk submission approval notification sent",
 			zap.String("approverEmail", approverUser.Email),
 			zap.String("riskTitle", risk.Title),
@@ -391,47 +535,42 @@ func ApproveOrRejectRiskAcceptanceHandler(c *gin.Context) {
 	if err := tx.Save(&approvalWorkflow).Error; err != nil { tx.Rollback(); c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update approval workflow..."}); return }
 	if payload.Decision == models.ApprovalApproved {
 		var riskToUpdate models.Risk
-		if err := tx.Where("id = ?", approvalWorkflow.RiskID).First(&riskToUpdate).Error; err != nil { tx.Rollback(); c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risk for status update..."}); return }
-		riskToUpdate.Status = models.StatusAccepted
+		if err := tx.Where("id = ?", *approvalWorkflow.RiskID).First(&riskToUpdate).Error; err != nil { tx.Rollback(); c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risk for status update..."}); return }
+		riskToUpdate.SetStatus(models.StatusAccepted, "")
 		if err := tx.Save(&riskToUpdate).Error; err != nil { tx.Rollback(); c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update risk status..."}); return }
 	}
 	if err := tx.Commit().Error; err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction"}); return }
+	events.Publish(tokenOrgID.(uuid.UUID), events.EventRiskApprovalDecided, *approvalWorkflow.RiskID, approvalWorkflow)
 	if approvalWorkflow.Status == models.ApprovalApproved {
 		var approvedRisk models.Risk
-		if err := db.First(&approvedRisk, approvalWorkflow.RiskID).Error; err == nil {
-			go notifications.NotifyRiskEvent(c.Request.Context(), approvedRisk.OrganizationID, approvedRisk, models.EventTypeRiskStatusChanged)
+		if err := db.First(&approvedRisk, *approvalWorkflow.RiskID).Error; err == nil {
+			notifications.QueueRiskEvent(c.Request.Context(), approvedRisk.OrganizationID, approvedRisk, models.EventTypeRiskStatusChanged)
+			decision := templates.RiskAcceptanceDecided{
+				RiskTitle: approvedRisk.Title, Approved: true, Status: approvedRisk.EffectiveStatus(),
+				Comments: approvalWorkflow.Comments, Link: notifications.RiskLink(approvedRisk.ID),
+			}
 			if approvedRisk.OwnerID != uuid.Nil {
-				emailSubjectOwner := fmt.Sprintf("Risco '%s' Aceito (Status: %s)", approvedRisk.Title, approvedRisk.Status)
-				emailBodyOwner := fmt.Sprintf("O risco '%s' que você aprovou foi atualizado para o status '%s'.\n\nComentários da aprovação: %s\n\nAcesse o Phoenix GRC para mais detalhes.",
-					approvedRisk.Title, approvedRisk.Status, approvalWorkflow.Comments)
-				go notifications.NotifyUserByEmail(c.Request.Context(), approvedRisk.OwnerID, emailSubjectOwner, emailBodyOwner)
+				notifications.NotifyUserWithTemplate(c.Request.Context(), approvedRisk.OwnerID, templates.NameRiskAcceptanceDecided, decision)
 			}
 			if approvalWorkflow.RequesterID != uuid.Nil && approvalWorkflow.RequesterID != approvedRisk.OwnerID {
 				var approverDetails models.User
 				if errDb := db.First(&approverDetails, tokenUserID.(uuid.UUID)).Error; errDb == nil {
-					emailSubjectRequester := fmt.Sprintf("Sua solicitação de aceite para o Risco '%s' foi Aprovada", approvedRisk.Title)
-					emailBodyRequester := fmt.Sprintf("A solicitação de aceite para o risco '%s' foi aprovada por %s.\nO status do risco foi atualizado para '%s'.\n\nComentários: %s\n\nAcesse o Phoenix GRC para mais detalhes.",
-						approvedRisk.Title, approverDetails.Name, approvedRisk.Status, approvalWorkflow.Comments)
-					go notifications.NotifyUserByEmail(c.Request.Context(), approvalWorkflow.RequesterID, emailSubjectRequester, emailBodyRequester)
+					decision.ApproverName = approverDetails.Name
 				} else {
 					phxlog.L.Error("Failed to fetch approver details for notification",
 						zap.String("approverID", tokenUserID.(uuid.UUID).String()),
 						zap.Error(errDb))
-					emailSubjectRequester := fmt.Sprintf("Sua solicitação de aceite para o Risco '%s' foi Aprovada", approvedRisk.Title)
-					emailBodyRequester := fmt.Sprintf("A solicitação de aceite para o risco '%s' foi aprovada.\nO status do risco foi atualizado para '%s'.\n\nComentários: %s\n\nAcesse o Phoenix GRC para mais detalhes.",
-						approvedRisk.Title, approvedRisk.Status, approvalWorkflow.Comments)
-					go notifications.NotifyUserByEmail(c.Request.Context(), approvalWorkflow.RequesterID, emailSubjectRequester, emailBodyRequester)
 				}
+				notifications.NotifyUserWithTemplate(c.Request.Context(), approvalWorkflow.RequesterID, templates.NameRiskAcceptanceDecided, decision)
 			}
 		}
 	} else if approvalWorkflow.Status == models.ApprovalRejected {
         if approvalWorkflow.RequesterID != uuid.Nil {
             var rejectedRisk models.Risk
-            db.First(&rejectedRisk, approvalWorkflow.RiskID)
-            emailSubjectRequester := fmt.Sprintf("Sua solicitação de aceite para o Risco '%s' foi Rejeitada", rejectedRisk.Title)
-            emailBodyRequester := fmt.Sprintf("A solicitação de aceite para o risco '%s' foi rejeitada.\n\nComentários: %s\n\nAcesse o Phoenix GRC para mais detalhes e para discutir os próximos passos.",
-                rejectedRisk.Title, approvalWorkflow.Comments)
-            go notifications.NotifyUserByEmail(c.Request.Context(), approvalWorkflow.RequesterID, emailSubjectRequester, emailBodyRequester)
+            db.First(&rejectedRisk, *approvalWorkflow.RiskID)
+            notifications.NotifyUserWithTemplate(c.Request.Context(), approvalWorkflow.RequesterID, templates.NameRiskAcceptanceDecided, templates.RiskAcceptanceDecided{
+                RiskTitle: rejectedRisk.Title, Comments: approvalWorkflow.Comments, Link: notifications.RiskLink(rejectedRisk.ID),
+            })
         }
     }
 	c.JSON(http.StatusOK, approvalWorkflow)
@@ -513,10 +652,12 @@ func AddRiskStakeholderHandler(c *gin.Context) {
 	}
 
 	tokenUserID, _ := c.Get("userID")
-	tokenUserRole, _ := c.Get("userRole")
-	isOwner := risk.OwnerID == tokenUserID.(uuid.UUID)
-	isAdminOrManager := tokenUserRole.(models.UserRole) == models.RoleAdmin || tokenUserRole.(models.UserRole) == models.RoleManager
-	if !isOwner && !isAdminOrManager {
+	isOwner, err := isOwnerOrTeamMember(db, tokenUserID.(uuid.UUID), risk.OwnerID, risk.OwnerTeamID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership: " + err.Error()})
+		return
+	}
+	if !isOwner && !rbac.Can(c, rbac.RisksManageStakeholders) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to manage stakeholders for this risk"})
 		return
 	}
@@ -550,10 +691,12 @@ func RemoveRiskStakeholderHandler(c *gin.Context) {
 	}
 
 	tokenUserID, _ := c.Get("userID")
-	tokenUserRole, _ := c.Get("userRole")
-	isOwner := risk.OwnerID == tokenUserID.(uuid.UUID)
-	isAdminOrManager := tokenUserRole.(models.UserRole) == models.RoleAdmin || tokenUserRole.(models.UserRole) == models.RoleManager
-	if !isOwner && !isAdminOrManager {
+	isOwner, err := isOwnerOrTeamMember(db, tokenUserID.(uuid.UUID), risk.OwnerID, risk.OwnerTeamID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership: " + err.Error()})
+		return
+	}
+	if !isOwner && !rbac.Can(c, rbac.RisksManageStakeholders) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to manage stakeholders for this risk"})
 		return
 	}
@@ -623,7 +766,12 @@ func BulkUploadRisksCSVHandler(c *gin.Context) {
 	if err != nil { c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file not provided..."}); return }
 	src, err := file.Open(); if err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open file..."}); return }
 	defer src.Close()
-	reader := csv.NewReader(src)
+	content, err := io.ReadAll(src)
+	if err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file..."}); return }
+	estimate := estimateRiskCSVUpload(content)
+	confirmed, ok := confirmBulkOperation(c, database.GetDB(), &estimate, preflight.Fingerprint(content))
+	if !ok { return }
+	reader := csv.NewReader(bytes.NewReader(content))
 	headers, err := reader.Read()
 	if err == io.EOF { c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file is empty"}); return }
 	if err != nil { c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read CSV headers..."}); return }
@@ -633,10 +781,17 @@ func BulkUploadRisksCSVHandler(c *gin.Context) {
 	for _, reqHeader := range requiredHeaders {
 		if _, ok := headerMap[reqHeader]; !ok { c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Missing required CSV header: %s", reqHeader)}); return }
 	}
+	matrix, _, err := loadRiskScoringMatrix(database.GetDB(), organizationID)
+	if err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk scoring matrix: " + err.Error()}); return }
 	validImpacts := map[string]string{"baixo": string(models.ImpactLow), "médio": string(models.ImpactMedium), "medio": string(models.ImpactMedium), "alto": string(models.ImpactHigh), "crítico": string(models.ImpactCritical), "critico": string(models.ImpactCritical)}
 	validProbabilities := map[string]string{"baixo": string(models.ProbabilityLow), "médio": string(models.ProbabilityMedium), "medio": string(models.ProbabilityMedium), "alto": string(models.ProbabilityHigh), "crítico": string(models.ProbabilityCritical), "critico": string(models.ProbabilityCritical)}
-	validCategories := map[string]string{"tecnologico": string(models.CategoryTechnological), "operacional": string(models.CategoryOperational), "legal": string(models.CategoryLegal)}
-	defaultCategory := models.CategoryTechnological
+	validCategories, err := activeRiskCategoryKeys(database.GetDB(), organizationID)
+	if err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk categories: " + err.Error()}); return }
+	// Sem coluna category, usa tecnologico se ainda for uma categoria ativa da organização
+	defaultCategory := models.RiskCategory(validCategories[string(models.CategoryTechnological)])
+	validCategoryKeys := make([]string, 0, len(validCategories))
+	for key, canonical := range validCategories { if key == canonical { validCategoryKeys = append(validCategoryKeys, key) } }
+	sort.Strings(validCategoryKeys)
 	var risksToCreate []models.Risk
 	var failedRows []BulkUploadErrorDetail
 	lineNumber := 1
@@ -657,7 +812,7 @@ func BulkUploadRisksCSVHandler(c *gin.Context) {
 			catValue := strings.TrimSpace(record[catIdx])
 			if catValue != "" {
 				if canonicalCat := isValidEnumValue(catValue, validCategories); canonicalCat != "" { risk.Category = models.RiskCategory(canonicalCat)
-				} else { rowErrors = append(rowErrors, fmt.Sprintf("invalid category: '%s'. Valid are: tecnologico, operacional, legal. Using default '%s'.", catValue, defaultCategory)) }
+				} else { rowErrors = append(rowErrors, fmt.Sprintf("invalid category: '%s'. Valid are: %s.", catValue, strings.Join(validCategoryKeys, ", "))) }
 			}
 		}
 		impactIdx, _ := headerMap["impact"]
@@ -674,7 +829,7 @@ func BulkUploadRisksCSVHandler(c *gin.Context) {
 		risk.OrganizationID = organizationID
 		risk.OwnerID = ownerID
 		risk.Status = models.StatusOpen
-		risk.RiskLevel = riskutils.CalculateRiskLevel(risk.Impact, risk.Probability)
+		risk.RiskLevel = matrix.Level(risk.Impact, risk.Probability)
 		risksToCreate = append(risksToCreate, risk)
 	}
 	if len(risksToCreate) > 0 {
@@ -691,9 +846,52 @@ func BulkUploadRisksCSVHandler(c *gin.Context) {
 			return
 		}
 		if err := tx.Commit().Error; err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error committing bulk insert..."}); return }
+		recordBulkOperation(c, db, bulkOperationRiskCSVUpload, len(risksToCreate), 0, confirmed)
 	}
 	response := BulkUploadRisksResponse{SuccessfullyImported: len(risksToCreate), FailedRows: failedRows}
 	if len(failedRows) > 0 && len(risksToCreate) > 0 { c.JSON(http.StatusMultiStatus, response)
 	} else if len(failedRows) > 0 && len(risksToCreate) == 0 { c.JSON(http.StatusBadRequest, response)
 	} else { c.JSON(http.StatusOK, response) }
 }
+
+// estimateRiskCSVUpload estima a importação pelo número de linhas de dados do CSV (a validação ocorre na importação).
+// A importação de riscos não envia notificações.
+func estimateRiskCSVUpload(content []byte) preflight.Estimate {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	rows := 0
+	for {
+		if _, err := reader.Read(); err == io.EOF {
+			break
+		} else if err != nil {
+			continue
+		}
+		rows++
+	}
+	if rows > 0 {
+		rows-- // Cabeçalho
+	}
+	return preflight.Estimate{Operation: bulkOperationRiskCSVUpload, AffectedRecords: rows}
+}
+
+// PreflightRiskCSVUploadHandler estimates a bulk risk CSV upload (same 'file' as BulkUploadRisksCSVHandler)
+// without importing anything, returning a confirm token when required.
+func PreflightRiskCSVUploadHandler(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file not provided in 'file' field"})
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open file"})
+		return
+	}
+	defer src.Close()
+	content, err := io.ReadAll(src)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return
+	}
+	respondBulkPreflight(c, database.GetDB(), estimateRiskCSVUpload(content), preflight.Fingerprint(content))
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/riskutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RiskHeatmapCell é uma célula da matriz de calor.
+type RiskHeatmapCell struct {
+	ProbabilityIndex int         `json:"probability_index"` // Linha, 0 = probabilidade mais baixa
+	ImpactIndex      int         `json:"impact_index"`      // Coluna, 0 = impacto mais baixo
+	Probability      string      `json:"probability"`
+	Impact           string      `json:"impact"`
+	RiskLevel        string      `json:"risk_level,omitempty"` // Nível da célula; só com as dimensões da matriz de pontuação
+	Count            int         `json:"count"`
+	RiskIDs          []uuid.UUID `json:"risk_ids"`
+}
+
+// RiskHeatmapResponse é a matriz de calor impacto x probabilidade.
+type RiskHeatmapResponse struct {
+	Size              int               `json:"size"`
+	ProbabilityLevels []string          `json:"probability_levels"`
+	ImpactLevels      []string          `json:"impact_levels"`
+	Cells             []RiskHeatmapCell `json:"cells"` // size x size, por linha (probabilidade) e coluna (impacto)
+	Total             int               `json:"total"`
+	Unrated           int               `json:"unrated"` // Riscos sem impacto ou probabilidade válidos, fora da matriz
+}
+
+// GetRiskHeatmapHandler returns the impact x probability heatmap of the organization's risks, honoring the risk list filters.
+func GetRiskHeatmapHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, exists := c.Get("organizationID")
+	if !exists || tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization's risks"})
+		return
+	}
+	if !validateRiskFilters(c) {
+		return
+	}
+	db := database.GetDB()
+	matrix, _, err := loadRiskScoringMatrix(db, targetOrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk scoring matrix: " + err.Error()})
+		return
+	}
+	size := matrix.Size
+	if sizeStr := c.Query("size"); sizeStr != "" {
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil || size < riskutils.MinMatrixSize || size > riskutils.MatrixLevels {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid size, must be between 2 and 4"})
+			return
+		}
+	}
+
+	var risks []models.Risk
+	if err := db.Model(&models.Risk{}).Select("id", "impact", "probability").
+		Where("organization_id = ?", targetOrgID).Scopes(riskFiltersScope(c)).
+		Order("created_at desc").Find(&risks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list risks: " + err.Error()})
+		return
+	}
+
+	impactLevels := make([]string, len(riskutils.ImpactLevels))
+	for i, level := range riskutils.ImpactLevels {
+		impactLevels[i] = string(level)
+	}
+	probabilityLevels := make([]string, len(riskutils.ProbabilityLevels))
+	for i, level := range riskutils.ProbabilityLevels {
+		probabilityLevels[i] = string(level)
+	}
+	resp := RiskHeatmapResponse{
+		Size:              size,
+		ProbabilityLevels: riskutils.MatrixLabels(probabilityLevels, size),
+		ImpactLevels:      riskutils.MatrixLabels(impactLevels, size),
+		Cells:             make([]RiskHeatmapCell, 0, size*size),
+		Total:             len(risks),
+	}
+	for row := 0; row < size; row++ {
+		for col := 0; col < size; col++ {
+			cell := RiskHeatmapCell{
+				ProbabilityIndex: row,
+				ImpactIndex:      col,
+				Probability:      resp.ProbabilityLevels[row],
+				Impact:           resp.ImpactLevels[col],
+				RiskIDs:          []uuid.UUID{},
+			}
+			if size == matrix.Size {
+				cell.RiskLevel = matrix.Cells[row][col]
+			}
+			resp.Cells = append(resp.Cells, cell)
+		}
+	}
+	for _, risk := range risks {
+		row, col, ok := riskutils.MatrixCell(risk.Impact, risk.Probability, size)
+		if !ok {
+			resp.Unrated++
+			continue
+		}
+		cell := &resp.Cells[row*size+col]
+		cell.Count++
+		cell.RiskIDs = append(cell.RiskIDs, risk.ID)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskNotificationFilterPayload defines the structure for creating or updating a risk notification filter.
+type RiskNotificationFilterPayload struct {
+	Name       string   `json:"name" binding:"required,min=2,max=100"`
+	EventTypes []string `json:"event_types" binding:"dive,oneof=risk_created risk_status_changed risk_owner_changed"`
+	RiskLevels []string `json:"risk_levels" binding:"dive,oneof=Baixo Moderado Alto Extremo Indefinido"`
+	Impacts    []string `json:"impacts" binding:"dive,oneof=Baixo Médio Alto Crítico"`
+	Categories []string `json:"categories" binding:"dive,min=1,max=50"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+// RiskNotificationFilterEvaluatePayload simula a avaliação dos filtros para um risco.
+type RiskNotificationFilterEvaluatePayload struct {
+	RiskID    string `json:"risk_id" binding:"required,uuid"`
+	EventType string `json:"event_type" binding:"required,oneof=risk_created risk_status_changed risk_owner_changed"`
+}
+
+func (p RiskNotificationFilterPayload) applyTo(filter *models.RiskNotificationFilter) {
+	filter.Name = p.Name
+	filter.Criteria = models.RiskNotificationCriteria{
+		EventTypes: p.EventTypes,
+		RiskLevels: p.RiskLevels,
+		Impacts:    p.Impacts,
+		Categories: p.Categories,
+	}
+	if p.IsActive != nil {
+		filter.IsActive = *p.IsActive
+	}
+}
+
+// findOrgRiskNotificationFilter carrega o filtro de :filterId na organização do usuário.
+func findOrgRiskNotificationFilter(c *gin.Context, db *gorm.DB) (*models.RiskNotificationFilter, bool) {
+	filterID, err := uuid.Parse(c.Param("filterId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid risk notification filter ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var filter models.RiskNotificationFilter
+	if err := db.Where("id = ? AND organization_id = ?", filterID, orgID).First(&filter).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Risk notification filter not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risk notification filter: " + err.Error()})
+		return nil, false
+	}
+	return &filter, true
+}
+
+// CreateRiskNotificationFilterHandler creates a risk notification filter for the organization.
+func CreateRiskNotificationFilterHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	var payload RiskNotificationFilterPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	filter := models.RiskNotificationFilter{OrganizationID: orgID.(uuid.UUID), IsActive: true}
+	payload.applyTo(&filter)
+	db := database.GetDB()
+	if err := db.Create(&filter).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create risk notification filter: " + err.Error()})
+		return
+	}
+	recordConfigChange(c, db, filter.OrganizationID, models.ConfigEntityRiskNotificationFilter, filter.ID, models.ConfigChangeCreate, nil)
+	c.JSON(http.StatusCreated, filter)
+}
+
+// ListRiskNotificationFiltersHandler lists the organization's risk notification filters.
+func ListRiskNotificationFiltersHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	var filters []models.RiskNotificationFilter
+	if err := database.GetDB().Where("organization_id = ?", orgID).Order("name asc").Find(&filters).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list risk notification filters: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, filters)
+}
+
+// GetRiskNotificationFilterHandler returns a risk notification filter.
+func GetRiskNotificationFilterHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	filter, ok := findOrgRiskNotificationFilter(c, database.GetDB())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, filter)
+}
+
+// UpdateRiskNotificationFilterHandler updates a risk notification filter.
+func UpdateRiskNotificationFilterHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	var payload RiskNotificationFilterPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	filter, ok := findOrgRiskNotificationFilter(c, db)
+	if !ok {
+		return
+	}
+	before := snapshotConfigEntity(db, models.ConfigEntityRiskNotificationFilter, filter.OrganizationID, filter.ID)
+	payload.applyTo(filter)
+	if err := db.Save(filter).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update risk notification filter: " + err.Error()})
+		return
+	}
+	recordConfigChange(c, db, filter.OrganizationID, models.ConfigEntityRiskNotificationFilter, filter.ID, models.ConfigChangeUpdate, before)
+	c.JSON(http.StatusOK, filter)
+}
+
+// DeleteRiskNotificationFilterHandler deletes a risk notification filter.
+func DeleteRiskNotificationFilterHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	db := database.GetDB()
+	filter, ok := findOrgRiskNotificationFilter(c, db)
+	if !ok {
+		return
+	}
+	before := snapshotConfigEntity(db, models.ConfigEntityRiskNotificationFilter, filter.OrganizationID, filter.ID)
+	if err := db.Delete(filter).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete risk notification filter: " + err.Error()})
+		return
+	}
+	recordConfigChange(c, db, filter.OrganizationID, models.ConfigEntityRiskNotificationFilter, filter.ID, models.ConfigChangeDelete, before)
+	c.JSON(http.StatusOK, gin.H{"message": "Risk notification filter deleted successfully"})
+}
+
+// EvaluateRiskNotificationFiltersHandler reports whether an event for the given risk would be notified
+// under the organization's current filters, without sending anything.
+func EvaluateRiskNotificationFiltersHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.NotificationsManage) {
+		return
+	}
+	var payload RiskNotificationFilterEvaluatePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	var risk models.Risk
+	if err := db.Where("id = ? AND organization_id = ?", payload.RiskID, orgID).First(&risk).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Risk not found or not part of your organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risk: " + err.Error()})
+		return
+	}
+	var filters []models.RiskNotificationFilter
+	if err := db.Where("organization_id = ? AND is_active = ?", orgID, true).Find(&filters).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list risk notification filters: " + err.Error()})
+		return
+	}
+	allowed, matched := notifications.RiskEventAllowed(filters, risk, payload.EventType)
+	if matched == nil {
+		matched = []uuid.UUID{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"notified":           allowed,
+		"active_filters":     len(filters),
+		"matched_filter_ids": matched,
+	})
+}
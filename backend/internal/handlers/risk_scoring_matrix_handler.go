@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/riskutils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Origem da matriz de pontuação efetiva.
+const (
+	RiskMatrixSourceOrganization = "organization"
+	RiskMatrixSourceSystem       = "system"
+)
+
+// RiskScoringMatrixPayload define a matriz de pontuação de riscos da organização.
+type RiskScoringMatrixPayload struct {
+	Size                int        `json:"size" binding:"required,min=2,max=4"`
+	Cells               [][]string `json:"cells" binding:"required"`
+	AcceptanceThreshold string     `json:"acceptance_threshold" binding:"omitempty,oneof=Baixo Moderado Alto Extremo"`
+}
+
+// RiskScoringMatrixResponse é a matriz efetiva e de onde ela vem (organization ou system).
+type RiskScoringMatrixResponse struct {
+	riskutils.ScoringMatrix
+	AcceptanceThreshold string                    `json:"acceptance_threshold"`
+	Source              string                    `json:"source"`
+	Matrix              *models.RiskScoringMatrix `json:"matrix,omitempty"`             // Registro configurado (source organization)
+	RecalculatedRisks   *int64                    `json:"recalculated_risks,omitempty"` // Riscos com o nível recalculado pela alteração
+}
+
+// loadRiskScoringMatrix retorna a matriz efetiva da organização e o registro configurado (nil se a
+// organização usa a matriz padrão).
+func loadRiskScoringMatrix(db *gorm.DB, organizationID uuid.UUID) (riskutils.ScoringMatrix, *models.RiskScoringMatrix, error) {
+	var config models.RiskScoringMatrix
+	if err := db.Where("organization_id = ?", organizationID).Take(&config).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return riskutils.DefaultScoringMatrix(), nil, nil
+		}
+		return riskutils.ScoringMatrix{}, nil, err
+	}
+	matrix := riskutils.ScoringMatrix{Size: config.Size}
+	if err := json.Unmarshal([]byte(config.Cells), &matrix.Cells); err != nil {
+		return riskutils.ScoringMatrix{}, nil, err
+	}
+	return matrix, &config, nil
+}
+
+// requiresAcceptanceWorkflow indica se um risco do nível informado só pode ser aceito pelo workflow de aprovação.
+func requiresAcceptanceWorkflow(config *models.RiskScoringMatrix, level string) bool {
+	if config == nil || config.AcceptanceThreshold == "" {
+		return false
+	}
+	return riskutils.LevelRank(level) >= riskutils.LevelRank(config.AcceptanceThreshold)
+}
+
+// recalculateRiskLevels grava o nível de todos os riscos da organização segundo a matriz, retornando quantos mudaram.
+func recalculateRiskLevels(tx *gorm.DB, organizationID uuid.UUID, matrix riskutils.ScoringMatrix) (int64, error) {
+	var changed int64
+	for _, impact := range riskutils.ImpactLevels {
+		for _, probability := range riskutils.ProbabilityLevels {
+			level := matrix.Level(impact, probability)
+			// Inclui a lixeira: o risco restaurado volta com o nível atual
+			result := tx.Unscoped().Model(&models.Risk{}).
+				Where("organization_id = ? AND impact = ? AND probability = ? AND risk_level IS DISTINCT FROM ?", organizationID, impact, probability, level).
+				UpdateColumn("risk_level", level)
+			if result.Error != nil {
+				return 0, result.Error
+			}
+			changed += result.RowsAffected
+		}
+	}
+	return changed, nil
+}
+
+// respondRiskScoringMatrix carrega e retorna a matriz efetiva da organização.
+func respondRiskScoringMatrix(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, recalculated *int64) {
+	matrix, config, err := loadRiskScoringMatrix(db, organizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk scoring matrix: " + err.Error()})
+		return
+	}
+	resp := RiskScoringMatrixResponse{ScoringMatrix: matrix, Source: RiskMatrixSourceSystem, RecalculatedRisks: recalculated}
+	if config != nil {
+		resp.Source = RiskMatrixSourceOrganization
+		resp.AcceptanceThreshold = config.AcceptanceThreshold
+		resp.Matrix = config
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetRiskScoringMatrixHandler returns the organization's effective risk scoring matrix.
+func GetRiskScoringMatrixHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, exists := c.Get("organizationID")
+	if !exists || tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization's risks"})
+		return
+	}
+	respondRiskScoringMatrix(c, database.GetDB(), targetOrgID, nil)
+}
+
+// SetRiskScoringMatrixHandler creates or replaces the organization's risk scoring matrix and recalculates
+// the level of its risks.
+func SetRiskScoringMatrixHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RisksConfigure) {
+		return
+	}
+	var payload RiskScoringMatrixPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	matrix := riskutils.ScoringMatrix{Size: payload.Size, Cells: payload.Cells}
+	if err := matrix.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid risk scoring matrix: " + err.Error()})
+		return
+	}
+	cells, err := json.Marshal(matrix.Cells)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save risk scoring matrix: " + err.Error()})
+		return
+	}
+	userID, _ := c.Get("userID")
+	updatedBy := userID.(uuid.UUID)
+
+	db := database.GetDB()
+	var config models.RiskScoringMatrix
+	var before *string
+	var recalculated int64
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("organization_id = ?", targetOrgID).Take(&config).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		if err == nil {
+			before = snapshotConfigEntity(tx, models.ConfigEntityRiskScoringMatrix, config.OrganizationID, config.ID)
+		}
+		config.OrganizationID = targetOrgID
+		config.Size = matrix.Size
+		config.Cells = string(cells)
+		config.AcceptanceThreshold = payload.AcceptanceThreshold
+		config.UpdatedByID = &updatedBy
+		if err := tx.Save(&config).Error; err != nil {
+			return err
+		}
+		recalculated, err = recalculateRiskLevels(tx, targetOrgID, matrix)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save risk scoring matrix: " + err.Error()})
+		return
+	}
+	action := models.ConfigChangeUpdate
+	if before == nil {
+		action = models.ConfigChangeCreate
+	}
+	recordConfigChange(c, db, config.OrganizationID, models.ConfigEntityRiskScoringMatrix, config.ID, action, before)
+	respondRiskScoringMatrix(c, db, targetOrgID, &recalculated)
+}
+
+// DeleteRiskScoringMatrixHandler removes the organization's risk scoring matrix, falling back to the default
+// matrix (without acceptance threshold), and recalculates the level of its risks.
+func DeleteRiskScoringMatrixHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RisksConfigure) {
+		return
+	}
+	db := database.GetDB()
+	var config models.RiskScoringMatrix
+	if err := db.Where("organization_id = ?", targetOrgID).Take(&config).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Risk scoring matrix not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch risk scoring matrix: " + err.Error()})
+		return
+	}
+	before := snapshotConfigEntity(db, models.ConfigEntityRiskScoringMatrix, config.OrganizationID, config.ID)
+	var recalculated int64
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&config).Error; err != nil {
+			return err
+		}
+		var err error
+		recalculated, err = recalculateRiskLevels(tx, targetOrgID, riskutils.DefaultScoringMatrix())
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete risk scoring matrix: " + err.Error()})
+		return
+	}
+	recordConfigChange(c, db, config.OrganizationID, models.ConfigEntityRiskScoringMatrix, config.ID, models.ConfigChangeDelete, before)
+	respondRiskScoringMatrix(c, db, targetOrgID, &recalculated)
+}
@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxCustomRolesPerOrganization limita os papéis personalizados de uma organização.
+const maxCustomRolesPerOrganization = 50
+
+// CustomRolePayload cria ou substitui um papel personalizado.
+type CustomRolePayload struct {
+	Name        string   `json:"name" binding:"required,min=2,max=100"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required,min=1"`
+}
+
+// CustomRoleView é o papel personalizado com as permissões decodificadas e o número de usuários que o têm.
+type CustomRoleView struct {
+	models.CustomRole
+	Permissions []string `json:"permissions"`
+	UserCount   int64    `json:"user_count"`
+}
+
+// AssignCustomRolePayload atribui um papel personalizado ao usuário; null remove o papel atual.
+type AssignCustomRolePayload struct {
+	CustomRoleID *string `json:"custom_role_id" binding:"omitempty,uuid"`
+}
+
+func newCustomRoleView(db *gorm.DB, role models.CustomRole) (CustomRoleView, error) {
+	view := CustomRoleView{CustomRole: role, Permissions: role.PermissionList()}
+	err := db.Model(&models.User{}).Where("custom_role_id = ?", role.ID).Count(&view.UserCount).Error
+	return view, err
+}
+
+// normalizePermissions valida as permissões do payload e as devolve sem repetições, na ordem do catálogo.
+func normalizePermissions(raw []string) ([]string, string, bool) {
+	requested := make(map[rbac.Permission]bool, len(raw))
+	for _, p := range raw {
+		permission := rbac.Permission(strings.TrimSpace(p))
+		if !rbac.Valid(permission) {
+			return nil, p, false
+		}
+		requested[permission] = true
+	}
+	permissions := []string{}
+	for _, info := range rbac.Catalog {
+		if requested[info.Permission] {
+			permissions = append(permissions, string(info.Permission))
+		}
+	}
+	return permissions, "", true
+}
+
+// findCustomRole carrega o papel de :roleId da organização, respondendo 400/404/500 em caso de erro.
+func findCustomRole(c *gin.Context, db *gorm.DB, organizationID uuid.UUID) (*models.CustomRole, bool) {
+	roleID, err := uuid.Parse(c.Param("roleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID format"})
+		return nil, false
+	}
+	var role models.CustomRole
+	if err := db.Where("id = ? AND organization_id = ?", roleID, organizationID).Take(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Custom role not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load custom role: " + err.Error()})
+		return nil, false
+	}
+	return &role, true
+}
+
+// customRoleNameTaken indica se outro papel da organização já usa o nome (sem diferenciar maiúsculas).
+func customRoleNameTaken(db *gorm.DB, organizationID uuid.UUID, name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&models.CustomRole{}).
+		Where("organization_id = ? AND LOWER(name) = LOWER(?) AND id <> ?", organizationID, name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// bindCustomRolePayload valida o payload e retorna as permissões normalizadas.
+func bindCustomRolePayload(c *gin.Context) (CustomRolePayload, []string, bool) {
+	var payload CustomRolePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return payload, nil, false
+	}
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.Description = strings.TrimSpace(payload.Description)
+	permissions, invalid, ok := normalizePermissions(payload.Permissions)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown permission: " + invalid})
+		return payload, nil, false
+	}
+	return payload, permissions, true
+}
+
+// ListPermissionsHandler returns the permission catalog and the permissions of each base role.
+func ListPermissionsHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, _ := c.Get("organizationID")
+	if orgID, ok := tokenOrgID.(uuid.UUID); !ok || orgID != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied: You do not belong to this organization"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"permissions": rbac.Catalog,
+		"roles": gin.H{
+			string(models.RoleAdmin):   rbac.RolePermissions(models.RoleAdmin),
+			string(models.RoleManager): rbac.RolePermissions(models.RoleManager),
+			string(models.RoleUser):    rbac.RolePermissions(models.RoleUser),
+		},
+	})
+}
+
+// ListCustomRolesHandler lists the organization's custom roles (roles:manage or users:manage).
+func ListCustomRolesHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	// Quem gerencia usuários precisa ver os papéis para exibi-los, mesmo sem poder atribuí-los
+	permission := rbac.RolesManage
+	if rbac.Can(c, rbac.UsersManage) {
+		permission = rbac.UsersManage
+	}
+	if !checkOrgPermission(c, targetOrgID, permission) {
+		return
+	}
+	db := database.GetDB()
+	var roles []models.CustomRole
+	if err := db.Where("organization_id = ?", targetOrgID).Order("name asc").Find(&roles).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list custom roles: " + err.Error()})
+		return
+	}
+	views := make([]CustomRoleView, 0, len(roles))
+	for _, role := range roles {
+		view, err := newCustomRoleView(db, role)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list custom roles: " + err.Error()})
+			return
+		}
+		views = append(views, view)
+	}
+	c.JSON(http.StatusOK, views)
+}
+
+// CreateCustomRoleHandler creates a custom role with a set of permissions (roles:manage).
+func CreateCustomRoleHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RolesManage) {
+		return
+	}
+	payload, permissions, ok := bindCustomRolePayload(c)
+	if !ok {
+		return
+	}
+	db := database.GetDB()
+	var count int64
+	if err := db.Model(&models.CustomRole{}).Where("organization_id = ?", targetOrgID).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count custom roles: " + err.Error()})
+		return
+	}
+	if count >= maxCustomRolesPerOrganization {
+		c.JSON(http.StatusConflict, gin.H{"error": "Custom role limit reached for this organization"})
+		return
+	}
+	taken, err := customRoleNameTaken(db, targetOrgID, payload.Name, uuid.Nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check custom role name: " + err.Error()})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "A custom role with this name already exists"})
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	createdByID := userID.(uuid.UUID)
+	role := models.CustomRole{
+		OrganizationID: targetOrgID,
+		Name:           payload.Name,
+		Description:    payload.Description,
+		CreatedByID:    &createdByID,
+	}
+	role.SetPermissions(permissions)
+	if err := db.Create(&role).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create custom role: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, CustomRoleView{CustomRole: role, Permissions: permissions})
+}
+
+// UpdateCustomRoleHandler replaces the name, description and permissions of a custom role (roles:manage). The
+// change applies to its users on their next request.
+func UpdateCustomRoleHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RolesManage) {
+		return
+	}
+	db := database.GetDB()
+	role, ok := findCustomRole(c, db, targetOrgID)
+	if !ok {
+		return
+	}
+	payload, permissions, ok := bindCustomRolePayload(c)
+	if !ok {
+		return
+	}
+	taken, err := customRoleNameTaken(db, targetOrgID, payload.Name, role.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check custom role name: " + err.Error()})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "A custom role with this name already exists"})
+		return
+	}
+	role.Name = payload.Name
+	role.Description = payload.Description
+	role.SetPermissions(permissions)
+	if err := db.Save(role).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update custom role: " + err.Error()})
+		return
+	}
+	view, err := newCustomRoleView(db, *role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count custom role users: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteCustomRoleHandler deletes a custom role (roles:manage). Its users keep only their base role.
+func DeleteCustomRoleHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RolesManage) {
+		return
+	}
+	db := database.GetDB()
+	role, ok := findCustomRole(c, db, targetOrgID)
+	if !ok {
+		return
+	}
+	var unassigned int64
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// A FK da migração já faz ON DELETE SET NULL; a atualização explícita cobre bancos criados só pelo AutoMigrate
+		result := tx.Model(&models.User{}).Where("custom_role_id = ?", role.ID).Update("custom_role_id", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		unassigned = result.RowsAffected
+		return tx.Delete(role).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete custom role: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Custom role deleted successfully", "users_unassigned": unassigned})
+}
+
+// AssignUserCustomRoleHandler assigns a custom role to a user of the organization, or removes it with
+// custom_role_id null (roles:manage).
+func AssignUserCustomRoleHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	targetUserID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.RolesManage) {
+		return
+	}
+	var payload AssignCustomRolePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	var user models.User
+	if err := db.Where("id = ? AND organization_id = ?", targetUserID, targetOrgID).Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found in this organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load user: " + err.Error()})
+		return
+	}
+	user.CustomRoleID = nil
+	if payload.CustomRoleID != nil {
+		roleID := uuid.MustParse(*payload.CustomRoleID)
+		var count int64
+		if err := db.Model(&models.CustomRole{}).Where("id = ? AND organization_id = ?", roleID, targetOrgID).Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load custom role: " + err.Error()})
+			return
+		}
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Custom role not found in this organization"})
+			return
+		}
+		user.CustomRoleID = &roleID
+	}
+	if err := db.Model(&user).Update("custom_role_id", user.CustomRoleID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign custom role: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newUserResponse(user))
+}
+
+// GetMyPermissionsHandler returns the authenticated user's effective permissions: those of the base role plus
+// those of the custom role, if any.
+func GetMyPermissionsHandler(c *gin.Context) {
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(models.UserRole)
+	custom, err := rbac.CustomPermissions(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load custom role: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"role": role, "permissions": rbac.Effective(role, custom)})
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Limites dos filtros de uma visão salva.
+const (
+	maxSavedViewFilterValues = 20
+	maxSavedViewValueLength  = 100
+)
+
+// savedViewMe é o valor de assigned_to_id que representa o usuário que consulta a listagem, permitindo
+// visões compartilhadas como "Minhas avaliações atrasadas".
+const savedViewMe = "me"
+
+// savedViewFilterKeys são os parâmetros de cada listagem que uma visão salva pode guardar; o valor indica se a
+// listagem aceita o parâmetro repetido (ex: ?tag=a&tag=b).
+var savedViewFilterKeys = map[models.SavedViewEntityType]map[string]bool{
+	models.SavedViewRisk:       {"status": false, "impact": false, "probability": false, "category": false, "tag": true, "owner_team_id": false, "my_teams": false},
+	models.SavedViewAssessment: {"status": true, "assigned_to_id": false, "assigned_team_id": false, "overdue": false},
+}
+
+// SavedViewPayload define uma visão salva.
+type SavedViewPayload struct {
+	Name        string                     `json:"name" binding:"required,min=1,max=100"`
+	EntityType  models.SavedViewEntityType `json:"entity_type" binding:"required,oneof=risk assessment"`
+	Description string                     `json:"description" binding:"max=255"`
+	Filters     map[string][]string        `json:"filters" binding:"required"`
+	Shared      bool                       `json:"shared"`
+}
+
+// UpdateSavedViewPayload altera os campos informados; entity_type não muda.
+type UpdateSavedViewPayload struct {
+	Name        *string              `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string              `json:"description" binding:"omitempty,max=255"`
+	Filters     *map[string][]string `json:"filters"`
+	Shared      *bool                `json:"shared"`
+}
+
+// SavedViewResponse é a visão com os filtros decodificados e prontos para a query string da listagem.
+type SavedViewResponse struct {
+	models.SavedView
+	Filters     map[string][]string `json:"filters"`
+	QueryString string              `json:"query_string"`
+	Editable    bool                `json:"editable"` // O usuário pode alterar ou excluir a visão
+}
+
+// validateSavedViewFilters normaliza os filtros e verifica se são parâmetros da listagem do tipo.
+func validateSavedViewFilters(entityType models.SavedViewEntityType, filters map[string][]string) (map[string][]string, error) {
+	allowed := savedViewFilterKeys[entityType]
+	normalized := make(map[string][]string, len(filters))
+	for key, values := range filters {
+		multiple, ok := allowed[key]
+		if !ok {
+			return nil, fmt.Errorf("filter '%s' is not supported for %s views (supported: %s)", key, entityType,
+				strings.Join(savedViewFilterNames(entityType), ", "))
+		}
+		var cleaned []string
+		for _, value := range values {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if len([]rune(value)) > maxSavedViewValueLength {
+				return nil, fmt.Errorf("filter '%s' has a value longer than %d characters", key, maxSavedViewValueLength)
+			}
+			switch key {
+			case "assigned_to_id":
+				if _, err := uuid.Parse(value); err != nil && value != savedViewMe {
+					return nil, fmt.Errorf("filter 'assigned_to_id' must be a user ID or 'me'")
+				}
+			case "owner_team_id", "assigned_team_id":
+				if _, err := uuid.Parse(value); err != nil {
+					return nil, fmt.Errorf("filter '%s' must be a team ID", key)
+				}
+			case "overdue", "my_teams":
+				if value != "true" && value != "false" {
+					return nil, fmt.Errorf("filter '%s' must be true or false", key)
+				}
+			}
+			cleaned = append(cleaned, value)
+		}
+		if len(cleaned) == 0 {
+			continue
+		}
+		if len(cleaned) > 1 && !multiple {
+			return nil, fmt.Errorf("filter '%s' accepts a single value", key)
+		}
+		if len(cleaned) > maxSavedViewFilterValues {
+			return nil, fmt.Errorf("filter '%s' has more than %d values", key, maxSavedViewFilterValues)
+		}
+		normalized[key] = cleaned
+	}
+	return normalized, nil
+}
+
+// savedViewEditable indica se o usuário pode alterar a visão: o dono, ou quem tem saved_views:share nas compartilhadas.
+func savedViewEditable(c *gin.Context, view *models.SavedView) bool {
+	userID, _ := c.Get("userID")
+	if view.OwnerID == userID.(uuid.UUID) {
+		return true
+	}
+	return view.Shared && rbac.Can(c, rbac.SavedViewsShare)
+}
+
+func newSavedViewResponse(c *gin.Context, view models.SavedView) SavedViewResponse {
+	filters := map[string][]string{}
+	_ = json.Unmarshal([]byte(view.Filters), &filters)
+	return SavedViewResponse{
+		SavedView:   view,
+		Filters:     filters,
+		QueryString: url.Values(filters).Encode(),
+		Editable:    savedViewEditable(c, &view),
+	}
+}
+
+// visibleSavedViewsQuery restringe às visões da organização que o usuário enxerga: as suas e as compartilhadas.
+func visibleSavedViewsQuery(c *gin.Context, db *gorm.DB) *gorm.DB {
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	return db.Where("organization_id = ? AND (owner_id = ? OR shared = ?)", orgID, userID, true)
+}
+
+// findVisibleSavedView carrega a visão de :viewId, se visível ao usuário.
+func findVisibleSavedView(c *gin.Context, db *gorm.DB) (*models.SavedView, bool) {
+	viewID, err := uuid.Parse(c.Param("viewId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID format"})
+		return nil, false
+	}
+	var view models.SavedView
+	if err := visibleSavedViewsQuery(c, db).Where("id = ?", viewID).First(&view).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Saved view not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved view: " + err.Error()})
+		return nil, false
+	}
+	return &view, true
+}
+
+// applySavedView aplica a visão de ?view_id= à listagem do tipo: os filtros da visão entram na query string,
+// exceto os parâmetros já informados na requisição, que prevalecem. Deve ser chamada antes de qualquer leitura
+// dos parâmetros, pois o gin guarda a query string em cache na primeira leitura. Responde o erro e retorna
+// false se a visão não puder ser usada.
+func applySavedView(c *gin.Context, entityType models.SavedViewEntityType) bool {
+	query := c.Request.URL.Query()
+	rawID := query.Get("view_id")
+	if rawID == "" {
+		return true
+	}
+	viewID, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view_id format"})
+		return false
+	}
+	var view models.SavedView
+	if err := visibleSavedViewsQuery(c, database.GetDB()).Where("id = ? AND entity_type = ?", viewID, entityType).
+		First(&view).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Saved view not found"})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved view: " + err.Error()})
+		return false
+	}
+	filters := map[string][]string{}
+	if err := json.Unmarshal([]byte(view.Filters), &filters); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read saved view filters: " + err.Error()})
+		return false
+	}
+	for key, values := range filters {
+		if _, set := query[key]; !set {
+			query[key] = values
+		}
+	}
+	c.Request.URL.RawQuery = query.Encode()
+	return true
+}
+
+// ListSavedViewsHandler lists the user's saved views and the organization's shared views (?entity_type=).
+func ListSavedViewsHandler(c *gin.Context) {
+	db := database.GetDB()
+	query := visibleSavedViewsQuery(c, db)
+	if entityType := c.Query("entity_type"); entityType != "" {
+		if _, ok := savedViewFilterKeys[models.SavedViewEntityType(entityType)]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity_type: use risk or assessment"})
+			return
+		}
+		query = query.Where("entity_type = ?", entityType)
+	}
+	var views []models.SavedView
+	if err := query.Order("entity_type asc, name asc").Find(&views).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list saved views: " + err.Error()})
+		return
+	}
+	resp := make([]SavedViewResponse, len(views))
+	for i, view := range views {
+		resp[i] = newSavedViewResponse(c, view)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateSavedViewHandler saves a named filter set. Shared views require saved_views:share.
+func CreateSavedViewHandler(c *gin.Context) {
+	var payload SavedViewPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if payload.Shared && !rbac.Require(c, rbac.SavedViewsShare) {
+		return
+	}
+	filters, err := validateSavedViewFilters(payload.EntityType, payload.Filters)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filters: " + err.Error()})
+		return
+	}
+	encoded, err := json.Marshal(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save view: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	view := models.SavedView{
+		OrganizationID: orgID.(uuid.UUID),
+		OwnerID:        userID.(uuid.UUID),
+		EntityType:     payload.EntityType,
+		Name:           strings.TrimSpace(payload.Name),
+		Description:    payload.Description,
+		Filters:        string(encoded),
+		Shared:         payload.Shared,
+	}
+	if err := database.GetDB().Create(&view).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save view: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, newSavedViewResponse(c, view))
+}
+
+// GetSavedViewHandler returns a saved view visible to the user.
+func GetSavedViewHandler(c *gin.Context) {
+	view, ok := findVisibleSavedView(c, database.GetDB())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, newSavedViewResponse(c, *view))
+}
+
+// UpdateSavedViewHandler updates a saved view. Visões pessoais só podem ser alteradas pelo dono; as
+// compartilhadas, com saved_views:share, que também é exigida para compartilhar uma visão.
+func UpdateSavedViewHandler(c *gin.Context) {
+	var payload UpdateSavedViewPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	view, ok := findVisibleSavedView(c, db)
+	if !ok {
+		return
+	}
+	if !savedViewEditable(c, view) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins or managers can change shared views"})
+		return
+	}
+	if payload.Shared != nil && *payload.Shared && !view.Shared && !rbac.Require(c, rbac.SavedViewsShare) {
+		return
+	}
+	if payload.Name != nil {
+		view.Name = strings.TrimSpace(*payload.Name)
+	}
+	if payload.Description != nil {
+		view.Description = *payload.Description
+	}
+	if payload.Filters != nil {
+		filters, err := validateSavedViewFilters(view.EntityType, *payload.Filters)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filters: " + err.Error()})
+			return
+		}
+		encoded, err := json.Marshal(filters)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save view: " + err.Error()})
+			return
+		}
+		view.Filters = string(encoded)
+	}
+	if payload.Shared != nil {
+		view.Shared = *payload.Shared
+	}
+	if err := db.Save(view).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save view: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newSavedViewResponse(c, *view))
+}
+
+// DeleteSavedViewHandler deletes a saved view, with the same permissions as the update.
+func DeleteSavedViewHandler(c *gin.Context) {
+	db := database.GetDB()
+	view, ok := findVisibleSavedView(c, db)
+	if !ok {
+		return
+	}
+	if !savedViewEditable(c, view) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins or managers can change shared views"})
+		return
+	}
+	if err := db.Delete(view).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete saved view: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// savedViewFilterNames lista os parâmetros aceitos pelas visões do tipo, em ordem alfabética.
+func savedViewFilterNames(entityType models.SavedViewEntityType) []string {
+	names := make([]string, 0, len(savedViewFilterKeys[entityType]))
+	for name := range savedViewFilterKeys[entityType] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScoreRubricPayload define os scores padrão por status. Sem framework_id, define a rubrica padrão da organização.
+type ScoreRubricPayload struct {
+	FrameworkID         string `json:"framework_id" binding:"omitempty,uuid"`
+	Conformant          *int   `json:"conformant" binding:"required,min=0,max=100"`
+	PartiallyConformant *int   `json:"partially_conformant" binding:"required,min=0,max=100"`
+	NonConformant       *int   `json:"non_conformant" binding:"required,min=0,max=100"`
+	NotApplicable       *int   `json:"not_applicable" binding:"required,min=0,max=100"`
+}
+
+// ScoreRubricResponse é a rubrica efetiva e de onde ela vem (framework, organization ou system).
+type ScoreRubricResponse struct {
+	models.AssessmentScoreRubric
+	Source string `json:"source"`
+}
+
+// scoreRubricFrameworkFilter filtra a rubrica pelo framework (ou a padrão da organização, quando nil).
+func scoreRubricFrameworkFilter(db *gorm.DB, frameworkID *uuid.UUID) *gorm.DB {
+	if frameworkID == nil {
+		return db.Where("framework_id IS NULL")
+	}
+	return db.Where("framework_id = ?", *frameworkID)
+}
+
+// parseOptionalFrameworkID lê framework_id (opcional) e confirma que o framework existe.
+func parseOptionalFrameworkID(c *gin.Context, db *gorm.DB, raw string) (*uuid.UUID, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	frameworkID, err := uuid.Parse(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid framework_id format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var count int64
+	db.Model(&models.AuditFramework{}).Scopes(visibleFrameworksScope(orgID.(uuid.UUID))).Where("id = ?", frameworkID).Count(&count)
+	if count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Framework not found"})
+		return nil, false
+	}
+	return &frameworkID, true
+}
+
+// GetScoreRubricHandler returns the effective score rubric for the organization (optionally for ?framework_id=).
+func GetScoreRubricHandler(c *gin.Context) {
+	db := database.GetDB()
+	frameworkID, ok := parseOptionalFrameworkID(c, db, c.Query("framework_id"))
+	if !ok {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	lookupID := uuid.Nil
+	if frameworkID != nil {
+		lookupID = *frameworkID
+	}
+	rubric, source, err := models.ResolveScoreRubric(db, orgID.(uuid.UUID), lookupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load score rubric: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, ScoreRubricResponse{AssessmentScoreRubric: rubric, Source: source})
+}
+
+// ListScoreRubricsHandler lists the rubrics configured by the organization (default and per framework).
+func ListScoreRubricsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	rubrics := []models.AssessmentScoreRubric{}
+	if err := database.GetDB().Where("organization_id = ?", orgID).Order("framework_id asc nulls first").Find(&rubrics).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list score rubrics: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rubrics)
+}
+
+// SetScoreRubricHandler creates or replaces the organization's default rubric or a framework rubric.
+// Scores must be within 0-100 and keep the status order (conformant >= partially conformant >= non conformant).
+func SetScoreRubricHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	var payload ScoreRubricPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if *payload.Conformant < *payload.PartiallyConformant || *payload.PartiallyConformant < *payload.NonConformant {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Scores must satisfy conformant >= partially_conformant >= non_conformant"})
+		return
+	}
+	db := database.GetDB()
+	frameworkID, ok := parseOptionalFrameworkID(c, db, payload.FrameworkID)
+	if !ok {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	updatedBy := userID.(uuid.UUID)
+
+	var rubric models.AssessmentScoreRubric
+	var before *string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := scoreRubricFrameworkFilter(tx.Where("organization_id = ?", orgID), frameworkID).First(&rubric).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		if err == nil {
+			before = snapshotConfigEntity(tx, models.ConfigEntityScoreRubric, rubric.OrganizationID, rubric.ID)
+		}
+		rubric.OrganizationID = orgID.(uuid.UUID)
+		rubric.FrameworkID = frameworkID
+		rubric.ConformantScore = *payload.Conformant
+		rubric.PartiallyConformantScore = *payload.PartiallyConformant
+		rubric.NonConformantScore = *payload.NonConformant
+		rubric.NotApplicableScore = *payload.NotApplicable
+		rubric.UpdatedByID = &updatedBy
+		return tx.Save(&rubric).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save score rubric: " + err.Error()})
+		return
+	}
+	action := models.ConfigChangeUpdate
+	if before == nil {
+		action = models.ConfigChangeCreate
+	}
+	recordConfigChange(c, db, rubric.OrganizationID, models.ConfigEntityScoreRubric, rubric.ID, action, before)
+	source := models.ScoreRubricSourceOrganization
+	if frameworkID != nil {
+		source = models.ScoreRubricSourceFramework
+	}
+	c.JSON(http.StatusOK, ScoreRubricResponse{AssessmentScoreRubric: rubric, Source: source})
+}
+
+// DeleteScoreRubricHandler removes the organization's default rubric or the rubric of ?framework_id=,
+// falling back to the next level (organization default, then system defaults).
+func DeleteScoreRubricHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.AuditManageFrameworks) {
+		return
+	}
+	db := database.GetDB()
+	frameworkID, ok := parseOptionalFrameworkID(c, db, c.Query("framework_id"))
+	if !ok {
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	var rubric models.AssessmentScoreRubric
+	if err := scoreRubricFrameworkFilter(db.Where("organization_id = ?", orgID), frameworkID).First(&rubric).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Score rubric not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch score rubric: " + err.Error()})
+		return
+	}
+	before := snapshotConfigEntity(db, models.ConfigEntityScoreRubric, rubric.OrganizationID, rubric.ID)
+	if err := db.Delete(&rubric).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete score rubric: " + err.Error()})
+		return
+	}
+	recordConfigChange(c, db, rubric.OrganizationID, models.ConfigEntityScoreRubric, rubric.ID, models.ConfigChangeDelete, before)
+	c.JSON(http.StatusOK, gin.H{"message": "Score rubric deleted successfully"})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/search"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SearchHandler runs a full-text search over the organization's risks, controls, assessment comments,
+// policies and vendors, returning typed results ranked by relevance.
+// Parâmetros: q (obrigatório; aceita "frase exata", OR e -termo), types (ex: risk,policy), page e page_size.
+func SearchHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	tokenOrgID, exists := c.Get("organizationID")
+	if !exists || tokenOrgID.(uuid.UUID) != targetOrgID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to the specified organization"})
+		return
+	}
+	q, err := search.NormalizeQuery(c.Query("q"))
+	if err != nil {
+		msg := "Search query must have at least 2 characters"
+		if errors.Is(err, search.ErrQueryTooLong) {
+			msg = "Search query must have at most 200 characters"
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	sources, err := search.ParseTypes(c.Query("types"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid types, must be risk, control, assessment, policy or vendor"})
+		return
+	}
+
+	page, pageSize := GetPaginationParams(c)
+	results, totalItems, err := search.Search(database.GetDB(), targetOrgID, q, sources, pageSize, (page-1)*pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search: " + err.Error()})
+		return
+	}
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{Items: results, TotalItems: totalItems, TotalPages: totalPages, Page: page, PageSize: pageSize})
+}
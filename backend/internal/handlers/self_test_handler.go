@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/selftest"
+	"phoenixgrc/backend/pkg/features"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// selfTestFeatureFlag habilita o autoteste de falhas de dependências.
+const selfTestFeatureFlag = "CHAOS_SELF_TEST"
+
+// SelfTestPayload seleciona os cenários do autoteste; vazio executa todos.
+type SelfTestPayload struct {
+	Scenarios []string `json:"scenarios"`
+}
+
+// RunSelfTestHandler simulates storage, email and webhook failures against test doubles and reports whether
+// the degradation paths return the error without panicking and whether the failure reaches the alerting metrics.
+// Requer a feature flag CHAOS_SELF_TEST.
+func RunSelfTestHandler(c *gin.Context) {
+	if !features.IsEnabledFor(c.Request.Context(), selfTestFeatureFlag, featureFlagSubject(c)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "The self-test is disabled; enable the CHAOS_SELF_TEST feature flag"})
+		return
+	}
+	var payload SelfTestPayload
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+			return
+		}
+	}
+	for _, scenario := range payload.Scenarios {
+		if !selftest.Known(scenario) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown self-test scenario: " + scenario})
+			return
+		}
+	}
+
+	report := selftest.Run(c.Request.Context(), payload.Scenarios)
+	userID, _ := c.Get("userID")
+	fields := []zap.Field{zap.Any("userID", userID), zap.Bool("passed", report.Passed)}
+	for _, result := range report.Results {
+		fields = append(fields, zap.Bool(result.Scenario, result.Passed))
+	}
+	if report.Passed {
+		phxlog.L.Info("Dependency failure self-test passed", fields...)
+	} else {
+		phxlog.L.Error("Dependency failure self-test failed", fields...)
+	}
+	c.JSON(http.StatusOK, report)
+}
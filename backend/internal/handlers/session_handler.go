@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenPayload troca um refresh token por um novo par de tokens.
+type RefreshTokenPayload struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutAllPayload encerra todas as sessões do usuário; com keep_current, mantém a sessão da requisição.
+type LogoutAllPayload struct {
+	KeepCurrent bool `json:"keep_current"`
+}
+
+// SessionResponse é uma sessão de login do usuário, indicando se é a da requisição.
+type SessionResponse struct {
+	models.AuthSession
+	Current bool `json:"current"`
+}
+
+// currentSessionID retorna a sessão do token de acesso da requisição; tokens sem sid não têm sessão.
+func currentSessionID(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get("sessionID")
+	if !exists {
+		return uuid.Nil, false
+	}
+	sessionID, ok := value.(uuid.UUID)
+	return sessionID, ok
+}
+
+// RefreshTokenHandler exchanges a refresh token for a new access token and a new refresh token (the old one
+// stops working). Reusing an already rotated refresh token revokes the whole session.
+func RefreshTokenHandler(c *gin.Context) {
+	var payload RefreshTokenPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	pair, user, err := auth.RefreshSession(database.GetDB(), payload.RefreshToken)
+	if errors.Is(err, auth.ErrRefreshTokenReused) {
+		phxlog.L.Warn("Rotated refresh token reused; session revoked", zap.String("ip", c.ClientIP()))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token has already been used; sign in again"})
+		return
+	}
+	if errors.Is(err, auth.ErrInvalidRefreshToken) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh session: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newLoginResponse(pair, user))
+}
+
+// LogoutHandler revokes the session of the access token used in the request, together with its refresh token.
+func LogoutHandler(c *gin.Context) {
+	sessionID, ok := currentSessionID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This token is not bound to a session"})
+		return
+	}
+	if err := auth.RevokeSession(database.GetDB(), sessionID, models.SessionRevokedLogout); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Signed out"})
+}
+
+// LogoutAllHandler revokes all sessions of the authenticated user ("sign out everywhere"). With keep_current,
+// the session of the request stays active.
+func LogoutAllHandler(c *gin.Context) {
+	var payload LogoutAllPayload
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+			return
+		}
+	}
+	userID, _ := c.Get("userID")
+	var except *uuid.UUID
+	if sessionID, ok := currentSessionID(c); ok && payload.KeepCurrent {
+		except = &sessionID
+	}
+	revoked, err := auth.RevokeUserSessions(database.GetDB(), userID.(uuid.UUID), models.SessionRevokedLogoutAll, except)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke sessions: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// ListMySessionsHandler lists the authenticated user's active sessions (not revoked and not expired), most
+// recent first.
+func ListMySessionsHandler(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sessions := []models.AuthSession{}
+	err := database.GetDB().
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("COALESCE(last_refreshed_at, created_at) desc").
+		Find(&sessions).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list sessions: " + err.Error()})
+		return
+	}
+	currentID, _ := currentSessionID(c)
+	items := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		items = append(items, SessionResponse{AuthSession: session, Current: session.ID == currentID})
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// RevokeMySessionHandler revokes one of the authenticated user's sessions (e.g. a lost device).
+func RevokeMySessionHandler(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID format"})
+		return
+	}
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	var session models.AuthSession
+	if err := db.Where("id = ? AND user_id = ?", sessionID, userID).Take(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session: " + err.Error()})
+		return
+	}
+	if err := auth.RevokeSession(db, session.ID, models.SessionRevokedByUser); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/statuses"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StatusDefinitionPayload cadastra um status que refina um status nativo.
+type StatusDefinitionPayload struct {
+	Entity      models.StatusEntity `json:"entity" binding:"required"`
+	Code        string              `json:"code" binding:"required"`
+	Label       string              `json:"label" binding:"required,max=100"`
+	Description string              `json:"description"`
+	BaseStatus  string              `json:"base_status" binding:"required"`
+	SortOrder   int                 `json:"sort_order"`
+}
+
+// StatusDefinitionUpdatePayload altera um status nativo ou cadastrado. O código e a base não mudam.
+type StatusDefinitionUpdatePayload struct {
+	Label       *string `json:"label" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description"`
+	Deprecated  *bool   `json:"deprecated"`
+	ReplacedBy  *string `json:"replaced_by"`
+	SortOrder   *int    `json:"sort_order"`
+}
+
+// StatusTransitionPayload é uma transição permitida.
+type StatusTransitionPayload struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+// StatusTransitionsPayload substitui as transições da entidade; uma lista vazia remove as restrições.
+type StatusTransitionsPayload struct {
+	Transitions []StatusTransitionPayload `json:"transitions"`
+}
+
+// StatusCatalogResponse são os status e as transições de uma entidade.
+type StatusCatalogResponse struct {
+	Entity      models.StatusEntity       `json:"entity"`
+	Statuses    []models.StatusDefinition `json:"statuses"`
+	Transitions map[string][]string       `json:"transitions"` // Destinos permitidos por origem; origens ausentes não têm restrição
+}
+
+// resolveStatus valida o status code para ser atribuído a um registro cujo status atual é current (vazio na
+// criação). Manter o status atual é sempre permitido, mesmo descontinuado. Responde 400 para um status
+// inexistente ou descontinuado e 409 para uma transição não permitida.
+func resolveStatus(c *gin.Context, db *gorm.DB, entity models.StatusEntity, current, code string) (models.StatusDefinition, bool) {
+	set := statuses.Current(c.Request.Context(), db)
+	if code == current {
+		if def, ok := set.Lookup(entity, code); ok {
+			return def, true
+		}
+	}
+	def, err := set.Resolve(entity, code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status: " + err.Error()})
+		return def, false
+	}
+	if err := set.CheckTransition(entity, current, code); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Status change not allowed: " + err.Error(), "allowed": set.Transitions(entity)[current]})
+		return def, false
+	}
+	return def, true
+}
+
+// statusEntityParam lê a entidade do parâmetro (ou query) entity, respondendo 400 se for inválida.
+func statusEntityParam(c *gin.Context, value string) (models.StatusEntity, bool) {
+	entity := models.StatusEntity(value)
+	if !statuses.ValidEntity(entity) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity, use risk or assessment"})
+		return "", false
+	}
+	return entity, true
+}
+
+// ListStatusDefinitionsHandler returns the statuses (built-in and custom, including deprecated ones) and the
+// transition rules of an entity (?entity=risk|assessment), for status pickers.
+func ListStatusDefinitionsHandler(c *gin.Context) {
+	entity, ok := statusEntityParam(c, c.DefaultQuery("entity", string(models.StatusEntityRisk)))
+	if !ok {
+		return
+	}
+	set := statuses.Current(c.Request.Context(), database.GetDB())
+	c.JSON(http.StatusOK, StatusCatalogResponse{Entity: entity, Statuses: set.Definitions(entity), Transitions: set.Transitions(entity)})
+}
+
+// CreateStatusDefinitionHandler adds a custom status refining a built-in one (base_status). Records keep the
+// base status in status and the new code in status_code.
+func CreateStatusDefinitionHandler(c *gin.Context) {
+	var payload StatusDefinitionPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	entity, ok := statusEntityParam(c, string(payload.Entity))
+	if !ok {
+		return
+	}
+	code := strings.TrimSpace(payload.Code)
+	if !statuses.CodePattern.MatchString(code) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status code: use 2 to 30 lowercase letters, digits or underscores, starting with a letter"})
+		return
+	}
+	if !statuses.IsBuiltin(entity, payload.BaseStatus) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "base_status must be a built-in status of the entity"})
+		return
+	}
+	db := database.GetDB()
+	if _, exists := statuses.Current(c.Request.Context(), db).Lookup(entity, code); exists || code == string(models.ControlStatusWaived) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A status with this code already exists"})
+		return
+	}
+	def := models.StatusDefinition{
+		Entity:      entity,
+		Code:        code,
+		Label:       strings.TrimSpace(payload.Label),
+		Description: payload.Description,
+		BaseStatus:  payload.BaseStatus,
+		SortOrder:   payload.SortOrder,
+	}
+	if err := db.Create(&def).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create status definition: " + err.Error()})
+		return
+	}
+	statuses.Invalidate()
+	userID, _ := c.Get("userID")
+	phxlog.L.Info("Status definition created",
+		zap.String("entity", string(entity)),
+		zap.String("code", code),
+		zap.String("baseStatus", def.BaseStatus),
+		zap.Any("userID", userID))
+	c.JSON(http.StatusCreated, def)
+}
+
+// UpdateStatusDefinitionHandler changes the label, description, order or deprecation of a status (built-in or
+// custom). Statuses are never deleted: a deprecated status stays valid on existing records but can no
+// longer be assigned.
+func UpdateStatusDefinitionHandler(c *gin.Context) {
+	var payload StatusDefinitionUpdatePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	entity, ok := statusEntityParam(c, c.Param("entity"))
+	if !ok {
+		return
+	}
+	db := database.GetDB()
+	set := statuses.Current(c.Request.Context(), db)
+	code := c.Param("code")
+	def, exists := set.Lookup(entity, code)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Status not found"})
+		return
+	}
+	if payload.Label != nil {
+		def.Label = strings.TrimSpace(*payload.Label)
+	}
+	if payload.Description != nil {
+		def.Description = *payload.Description
+	}
+	if payload.SortOrder != nil {
+		def.SortOrder = *payload.SortOrder
+	}
+	if payload.Deprecated != nil {
+		def.Deprecated = *payload.Deprecated
+	}
+	if payload.ReplacedBy != nil {
+		def.ReplacedBy = *payload.ReplacedBy
+	}
+	if !def.Deprecated {
+		def.ReplacedBy = ""
+	}
+	if def.ReplacedBy != "" {
+		replacement, ok := set.Lookup(entity, def.ReplacedBy)
+		if !ok || replacement.Deprecated || replacement.Code == code {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "replaced_by must be another active status of the entity"})
+			return
+		}
+	}
+	if def.Deprecated {
+		active := 0
+		for _, other := range set.Definitions(entity) {
+			if other.Code != code && !other.Deprecated {
+				active++
+			}
+		}
+		if active == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "At least one active status must remain"})
+			return
+		}
+	}
+
+	// Os nativos só existem no banco depois do primeiro ajuste
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var stored models.StatusDefinition
+		err := tx.Where("entity = ? AND code = ?", entity, code).First(&stored).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(&def).Error
+		}
+		if err != nil {
+			return err
+		}
+		def.ID, def.CreatedAt = stored.ID, stored.CreatedAt
+		return tx.Model(&stored).Updates(map[string]interface{}{
+			"label": def.Label, "description": def.Description, "sort_order": def.SortOrder,
+			"deprecated": def.Deprecated, "replaced_by": def.ReplacedBy,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update status definition: " + err.Error()})
+		return
+	}
+	statuses.Invalidate()
+	userID, _ := c.Get("userID")
+	phxlog.L.Info("Status definition updated",
+		zap.String("entity", string(entity)),
+		zap.String("code", code),
+		zap.Bool("deprecated", def.Deprecated),
+		zap.Any("userID", userID))
+	updated, _ := statuses.Current(c.Request.Context(), db).Lookup(entity, code)
+	c.JSON(http.StatusOK, updated)
+}
+
+// ReplaceStatusTransitionsHandler replaces the transition rules of an entity. A status with transitions can
+// only change to their targets; statuses without transitions can change to any other.
+func ReplaceStatusTransitionsHandler(c *gin.Context) {
+	var payload StatusTransitionsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	entity, ok := statusEntityParam(c, c.Param("entity"))
+	if !ok {
+		return
+	}
+	db := database.GetDB()
+	set := statuses.Current(c.Request.Context(), db)
+	seen := map[StatusTransitionPayload]bool{}
+	transitions := make([]models.StatusTransition, 0, len(payload.Transitions))
+	for _, t := range payload.Transitions {
+		if _, ok := set.Lookup(entity, t.From); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown status in transitions: " + t.From})
+			return
+		}
+		if _, ok := set.Lookup(entity, t.To); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown status in transitions: " + t.To})
+			return
+		}
+		if t.From == t.To || seen[t] {
+			continue
+		}
+		seen[t] = true
+		transitions = append(transitions, models.StatusTransition{Entity: entity, FromCode: t.From, ToCode: t.To})
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entity = ?", entity).Delete(&models.StatusTransition{}).Error; err != nil {
+			return err
+		}
+		if len(transitions) == 0 {
+			return nil
+		}
+		return tx.Create(&transitions).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update status transitions: " + err.Error()})
+		return
+	}
+	statuses.Invalidate()
+	userID, _ := c.Get("userID")
+	phxlog.L.Info("Status transitions replaced",
+		zap.String("entity", string(entity)),
+		zap.Int("transitions", len(transitions)),
+		zap.Any("userID", userID))
+	set = statuses.Current(c.Request.Context(), db)
+	c.JSON(http.StatusOK, StatusCatalogResponse{Entity: entity, Statuses: set.Definitions(entity), Transitions: set.Transitions(entity)})
+}
@@ -5,6 +5,7 @@ import (
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
 	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/pkg/config"
 	phxlog "phoenixgrc/backend/pkg/log"
 
 	"github.com/gin-gonic/gin"
@@ -124,7 +125,7 @@ func SendTestEmailHandler(c *gin.Context) {
 	// que podem ter sido acabadas de salvar pelo usuário.
 	notifications.InitEmailService()
 
-	if err := notifications.DefaultEmailNotifier.Send(c.Request.Context(), to, subject, bodyHTML); err != nil {
+	if err := notifications.EmailNotifier().Send(c.Request.Context(), to, subject, bodyHTML); err != nil {
 		log.Error("Failed to send test email", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send test email: " + err.Error()})
 		return
@@ -132,3 +133,30 @@ func SendTestEmailHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Test email sent successfully to " + to})
 }
+
+// ReloadConfigHandler re-reads the environment and the configuration file (CONFIG_FILE) and applies the
+// reloadable settings (email, feature flags and rate limits) without restarting the process. Other changed
+// settings are listed in restart_required. Only this instance is reloaded.
+func ReloadConfigHandler(c *gin.Context) {
+	log := phxlog.L.Named("ReloadConfigHandler")
+	userID, _ := c.Get("userID")
+
+	result, err := config.Reload()
+	if err != nil {
+		log.Warn("Configuration reload rejected", zap.Any("userID", userID), zap.Error(err))
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Any("userID", userID),
+		zap.Strings("changed", result.Changed),
+		zap.Strings("restartRequired", result.RestartRequired),
+	}
+	if len(result.Errors) > 0 {
+		log.Error("Configuration reloaded with errors", append(fields, zap.Strings("errors", result.Errors))...)
+	} else {
+		log.Info("Configuration reloaded", fields...)
+	}
+	c.JSON(http.StatusOK, result)
+}
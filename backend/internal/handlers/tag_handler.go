@@ -0,0 +1,442 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxTagEntitiesPerRequest limita as entidades marcadas em uma requisição.
+const maxTagEntitiesPerRequest = 500
+
+// tagEntityTables são as tabelas de cada tipo de entidade.
+var tagEntityTables = map[models.TagEntityType]string{
+	models.TagEntityRisk:    "risks",
+	models.TagEntityControl: "audit_controls",
+	models.TagEntityVendor:  "vendors",
+}
+
+// TagPayload defines the structure for creating a tag.
+type TagPayload struct {
+	Name        string `json:"name" binding:"required,min=1,max=50"`
+	Color       string `json:"color" binding:"omitempty,hexcolor,max=7"`
+	Description string `json:"description"`
+}
+
+// UpdateTagPayload atualiza o nome, a cor e a descrição da tag.
+type UpdateTagPayload struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=50"`
+	Color       *string `json:"color" binding:"omitempty,hexcolor,max=7"`
+	Description *string `json:"description"`
+}
+
+// TagEntitiesPayload marca entidades de um mesmo tipo com a tag.
+type TagEntitiesPayload struct {
+	EntityType models.TagEntityType `json:"entity_type" binding:"required"`
+	EntityIDs  []string             `json:"entity_ids" binding:"required,min=1"`
+}
+
+// TagResponse é a tag com o número de entidades marcadas por tipo.
+type TagResponse struct {
+	models.Tag
+	Usage map[models.TagEntityType]int64 `json:"usage"`
+}
+
+// TaggedEntity é uma entidade marcada com a tag, com o rótulo para exibição (título do risco, nome do
+// fornecedor ou identificador do controle).
+type TaggedEntity struct {
+	EntityType models.TagEntityType `json:"entity_type"`
+	EntityID   uuid.UUID            `json:"entity_id"`
+	Label      string               `json:"label"`
+	TaggedAt   time.Time            `json:"tagged_at"`
+}
+
+func validTagEntityType(entityType models.TagEntityType) bool {
+	for _, t := range models.TagEntityTypes {
+		if t == entityType {
+			return true
+		}
+	}
+	return false
+}
+
+// tagNameTaken indica se já existe outra tag com o nome na organização, sem diferenciar maiúsculas.
+func tagNameTaken(db *gorm.DB, organizationID uuid.UUID, name string, excludeID uuid.UUID) bool {
+	var count int64
+	db.Model(&models.Tag{}).Where("organization_id = ? AND LOWER(name) = LOWER(?) AND id <> ?", organizationID, name, excludeID).Count(&count)
+	return count > 0
+}
+
+// findOrgTag carrega a tag :tagId da organização do token.
+func findOrgTag(c *gin.Context, db *gorm.DB) (*models.Tag, bool) {
+	tagID, err := uuid.Parse(c.Param("tagId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var tag models.Tag
+	if err := db.Where("id = ? AND organization_id = ?", tagID, orgID).First(&tag).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tag: " + err.Error()})
+		return nil, false
+	}
+	return &tag, true
+}
+
+// visibleTagEntitiesQuery restringe os IDs de entidade aos que a organização enxerga: riscos e fornecedores
+// da organização e controles dos frameworks visíveis a ela.
+func visibleTagEntitiesQuery(db *gorm.DB, organizationID uuid.UUID, entityType models.TagEntityType) *gorm.DB {
+	switch entityType {
+	case models.TagEntityRisk:
+		return db.Model(&models.Risk{}).Select("risks.id").Where("risks.organization_id = ?", organizationID)
+	case models.TagEntityVendor:
+		return db.Model(&models.Vendor{}).Select("vendors.id").Where("vendors.organization_id = ?", organizationID)
+	default:
+		return db.Model(&models.AuditControl{}).Select("audit_controls.id").
+			Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+			Scopes(visibleFrameworksScope(organizationID))
+	}
+}
+
+// tagFilterNames retorna os nomes de tag do filtro ?tag= (repetível ou separado por vírgulas).
+func tagFilterNames(c *gin.Context) []string {
+	var names []string
+	for _, value := range c.QueryArray("tag") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// taggedEntitiesSubquery retorna os IDs das entidades do tipo marcadas com a tag de nome name na organização.
+func taggedEntitiesSubquery(db *gorm.DB, organizationID uuid.UUID, entityType models.TagEntityType, name string) *gorm.DB {
+	return db.Table("entity_tags").Select("entity_tags.entity_id").
+		Joins("JOIN tags ON tags.id = entity_tags.tag_id").
+		Where("tags.organization_id = ? AND LOWER(tags.name) = LOWER(?) AND entity_tags.entity_type = ?", organizationID, name, entityType)
+}
+
+// tagFilterScope aplica o filtro ?tag= às listagens: a entidade (coluna idColumn) deve ter todas as tags informadas.
+func tagFilterScope(c *gin.Context, entityType models.TagEntityType, idColumn string) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		names := tagFilterNames(c)
+		if len(names) == 0 {
+			return query
+		}
+		orgID, _ := c.Get("organizationID")
+		db := database.GetDB()
+		for _, name := range names {
+			query = query.Where(idColumn+" IN (?)", taggedEntitiesSubquery(db, orgID.(uuid.UUID), entityType, name))
+		}
+		return query
+	}
+}
+
+// taggedEntityIDs retorna os IDs das entidades com todas as tags do filtro ?tag=, ou nil sem filtro. Usado
+// nas listagens montadas em memória (controles).
+func taggedEntityIDs(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, entityType models.TagEntityType) (map[uuid.UUID]bool, error) {
+	names := tagFilterNames(c)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	query := db.Table("entity_tags").Distinct("entity_id").Where("organization_id = ? AND entity_type = ?", organizationID, entityType)
+	for _, name := range names {
+		query = query.Where("entity_id IN (?)", taggedEntitiesSubquery(db, organizationID, entityType, name))
+	}
+	var ids []uuid.UUID
+	if err := query.Pluck("entity_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
+
+// deleteEntityTags remove as tags das entidades excluídas; entityIDs pode ser uma lista ou uma subconsulta.
+func deleteEntityTags(tx *gorm.DB, entityType models.TagEntityType, entityIDs interface{}) error {
+	return tx.Where("entity_type = ? AND entity_id IN (?)", entityType, entityIDs).Delete(&models.EntityTag{}).Error
+}
+
+// ListTagsHandler lists the organization's tags with usage counts. Com entity_type e entity_id, lista as tags da entidade.
+func ListTagsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	query := db.Where("organization_id = ?", orgID)
+	if entityType := c.Query("entity_type"); entityType != "" || c.Query("entity_id") != "" {
+		entityID, err := uuid.Parse(c.Query("entity_id"))
+		if err != nil || !validTagEntityType(models.TagEntityType(entityType)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "entity_type (risk, control or vendor) and a valid entity_id are required together"})
+			return
+		}
+		query = query.Where("id IN (?)", db.Table("entity_tags").Select("tag_id").Where("entity_type = ? AND entity_id = ?", entityType, entityID))
+	}
+	var tags []models.Tag
+	if err := query.Order("LOWER(name) asc").Find(&tags).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tags: " + err.Error()})
+		return
+	}
+
+	type usageRow struct {
+		TagID      uuid.UUID
+		EntityType models.TagEntityType
+		Count      int64
+	}
+	var rows []usageRow
+	trashedRisks := db.Unscoped().Model(&models.Risk{}).Select("id").Where("organization_id = ? AND deleted_at IS NOT NULL", orgID)
+	if err := db.Table("entity_tags").Select("tag_id, entity_type, COUNT(*) AS count").
+		Where("organization_id = ? AND entity_id NOT IN (?)", orgID, trashedRisks).Group("tag_id, entity_type").Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count tag usage: " + err.Error()})
+		return
+	}
+	usage := make(map[uuid.UUID]map[models.TagEntityType]int64)
+	for _, row := range rows {
+		if usage[row.TagID] == nil {
+			usage[row.TagID] = make(map[models.TagEntityType]int64)
+		}
+		usage[row.TagID][row.EntityType] = row.Count
+	}
+	resp := make([]TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		counts := map[models.TagEntityType]int64{}
+		for _, entityType := range models.TagEntityTypes {
+			counts[entityType] = usage[tag.ID][entityType]
+		}
+		resp = append(resp, TagResponse{Tag: tag, Usage: counts})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateTagHandler creates a tag for the organization.
+func CreateTagHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.TagsManage) {
+		return
+	}
+	var payload TagPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	name := strings.TrimSpace(payload.Name)
+	if name == "" || strings.Contains(name, ",") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag name: it must not be blank or contain commas"})
+		return
+	}
+	orgID := c.MustGet("organizationID").(uuid.UUID)
+	db := database.GetDB()
+	if tagNameTaken(db, orgID, name, uuid.Nil) {
+		c.JSON(http.StatusConflict, gin.H{"error": "A tag with this name already exists"})
+		return
+	}
+	tag := models.Tag{OrganizationID: orgID, Name: name, Color: payload.Color, Description: payload.Description}
+	if err := db.Create(&tag).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create tag: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, tag)
+}
+
+// UpdateTagHandler renames a tag or changes its color or description.
+func UpdateTagHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.TagsManage) {
+		return
+	}
+	var payload UpdateTagPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	tag, ok := findOrgTag(c, db)
+	if !ok {
+		return
+	}
+	if payload.Name != nil {
+		name := strings.TrimSpace(*payload.Name)
+		if name == "" || strings.Contains(name, ",") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag name: it must not be blank or contain commas"})
+			return
+		}
+		if tagNameTaken(db, tag.OrganizationID, name, tag.ID) {
+			c.JSON(http.StatusConflict, gin.H{"error": "A tag with this name already exists"})
+			return
+		}
+		tag.Name = name
+	}
+	if payload.Color != nil {
+		tag.Color = *payload.Color
+	}
+	if payload.Description != nil {
+		tag.Description = *payload.Description
+	}
+	if err := db.Save(tag).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update tag: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTagHandler deletes a tag and removes it from every entity.
+func DeleteTagHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.TagsManage) {
+		return
+	}
+	db := database.GetDB()
+	tag, ok := findOrgTag(c, db)
+	if !ok {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tag_id = ?", tag.ID).Delete(&models.EntityTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(tag).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete tag: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
+}
+
+// ListTagEntitiesHandler lists the entities tagged with a tag. Filtro opcional: entity_type.
+func ListTagEntitiesHandler(c *gin.Context) {
+	db := database.GetDB()
+	tag, ok := findOrgTag(c, db)
+	if !ok {
+		return
+	}
+	entityTypes := models.TagEntityTypes
+	if entityType := models.TagEntityType(c.Query("entity_type")); entityType != "" {
+		if !validTagEntityType(entityType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity_type, must be risk, control or vendor"})
+			return
+		}
+		entityTypes = []models.TagEntityType{entityType}
+	}
+	labelColumns := map[models.TagEntityType]string{
+		models.TagEntityRisk:    "risks.title",
+		models.TagEntityVendor:  "vendors.name",
+		models.TagEntityControl: "audit_controls.control_id",
+	}
+	entities := []TaggedEntity{}
+	for _, entityType := range entityTypes {
+		var rows []struct {
+			EntityID  uuid.UUID
+			Label     string
+			CreatedAt time.Time
+		}
+		visible := visibleTagEntitiesQuery(db, tag.OrganizationID, entityType).Select(labelColumns[entityType] + " AS label, entity_tags.entity_id, entity_tags.created_at")
+		if err := visible.Joins("JOIN entity_tags ON entity_tags.entity_id = "+tagEntityTables[entityType]+".id").
+			Where("entity_tags.tag_id = ? AND entity_tags.entity_type = ?", tag.ID, entityType).
+			Order(labelColumns[entityType] + " asc").Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tagged entities: " + err.Error()})
+			return
+		}
+		for _, row := range rows {
+			entities = append(entities, TaggedEntity{EntityType: entityType, EntityID: row.EntityID, Label: row.Label, TaggedAt: row.CreatedAt})
+		}
+	}
+	c.JSON(http.StatusOK, entities)
+}
+
+// TagEntitiesHandler tags risks, controls or vendors of the organization; already tagged entities are ignored.
+func TagEntitiesHandler(c *gin.Context) {
+	var payload TagEntitiesPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if !validTagEntityType(payload.EntityType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity_type, must be risk, control or vendor"})
+		return
+	}
+	if len(payload.EntityIDs) > maxTagEntitiesPerRequest {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many entities in one request", "max": maxTagEntitiesPerRequest})
+		return
+	}
+	ids := make([]uuid.UUID, 0, len(payload.EntityIDs))
+	seen := make(map[uuid.UUID]bool, len(payload.EntityIDs))
+	for _, raw := range payload.EntityIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID format: " + raw})
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	db := database.GetDB()
+	tag, ok := findOrgTag(c, db)
+	if !ok {
+		return
+	}
+	var visible int64
+	if err := visibleTagEntitiesQuery(db, tag.OrganizationID, payload.EntityType).
+		Where(tagEntityTables[payload.EntityType]+".id IN ?", ids).Count(&visible).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check entities: " + err.Error()})
+		return
+	}
+	if visible != int64(len(ids)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "One or more entities were not found in the organization"})
+		return
+	}
+	userID := c.MustGet("userID").(uuid.UUID)
+	links := make([]models.EntityTag, len(ids))
+	for i, id := range ids {
+		links[i] = models.EntityTag{OrganizationID: tag.OrganizationID, TagID: tag.ID, EntityType: payload.EntityType, EntityID: id, CreatedByID: &userID}
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to tag entities: " + result.Error.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tagged": result.RowsAffected, "already_tagged": int64(len(ids)) - result.RowsAffected})
+}
+
+// UntagEntityHandler removes a tag from an entity.
+func UntagEntityHandler(c *gin.Context) {
+	entityType := models.TagEntityType(c.Param("entityType"))
+	if !validTagEntityType(entityType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity_type, must be risk, control or vendor"})
+		return
+	}
+	entityID, err := uuid.Parse(c.Param("entityId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID format"})
+		return
+	}
+	db := database.GetDB()
+	tag, ok := findOrgTag(c, db)
+	if !ok {
+		return
+	}
+	result := db.Where("tag_id = ? AND entity_type = ? AND entity_id = ?", tag.ID, entityType, entityID).Delete(&models.EntityTag{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to untag entity: " + result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "The entity does not have this tag"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Tag removed from entity"})
+}
@@ -0,0 +1,396 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TeamPayload cria ou atualiza uma equipe. member_ids só é usado na criação.
+type TeamPayload struct {
+	Name        string   `json:"name" binding:"required,min=2,max=100"`
+	Description string   `json:"description" binding:"max=255"`
+	MemberIDs   []string `json:"member_ids" binding:"omitempty,max=500,dive,uuid"`
+}
+
+// TeamMembersPayload adiciona usuários da organização à equipe (até 500 por requisição).
+type TeamMembersPayload struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1,max=500,dive,uuid"`
+}
+
+// TeamView é a equipe com o número de membros.
+type TeamView struct {
+	models.Team
+	MemberCount int64 `json:"member_count"`
+}
+
+// TeamMemberView é um membro da equipe com o nome e o e-mail.
+type TeamMemberView struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	IsActive bool      `json:"is_active"`
+}
+
+// parseOrgTeamID converte o ID e verifica se a equipe pertence à organização.
+func parseOrgTeamID(db *gorm.DB, organizationID uuid.UUID, idStr string) (uuid.UUID, error) {
+	teamID, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid team ID format")
+	}
+	var count int64
+	db.Model(&models.Team{}).Where("id = ? AND organization_id = ?", teamID, organizationID).Count(&count)
+	if count == 0 {
+		return uuid.Nil, fmt.Errorf("team not found in your organization")
+	}
+	return teamID, nil
+}
+
+// parseOptionalOrgTeamID converte o campo opcional field (vazio = sem equipe), respondendo 400 se a equipe
+// não for da organização.
+func parseOptionalOrgTeamID(c *gin.Context, db *gorm.DB, organizationID uuid.UUID, field, raw string) (*uuid.UUID, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	teamID, err := parseOrgTeamID(db, organizationID, raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + field + ": " + err.Error()})
+		return nil, false
+	}
+	return &teamID, true
+}
+
+// isOwnerOrTeamMember indica se o usuário é o responsável individual ou membro da equipe dona do item.
+func isOwnerOrTeamMember(db *gorm.DB, userID, ownerID uuid.UUID, ownerTeamID *uuid.UUID) (bool, error) {
+	if ownerID == userID {
+		return true, nil
+	}
+	if ownerTeamID == nil {
+		return false, nil
+	}
+	return models.IsTeamMember(db, *ownerTeamID, userID)
+}
+
+// sameUUIDPtr compara dois IDs opcionais (ex: a equipe dona antes e depois da atualização).
+func sameUUIDPtr(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// findOrgTeam carrega a equipe de :teamId da organização do token.
+func findOrgTeam(c *gin.Context, db *gorm.DB) (*models.Team, bool) {
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var team models.Team
+	if err := db.Where("id = ? AND organization_id = ?", teamID, orgID).Take(&team).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load team: " + err.Error()})
+		return nil, false
+	}
+	return &team, true
+}
+
+// teamNameTaken indica se outra equipe da organização já usa o nome (sem diferenciar maiúsculas).
+func teamNameTaken(db *gorm.DB, organizationID uuid.UUID, name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&models.Team{}).
+		Where("organization_id = ? AND LOWER(name) = LOWER(?) AND id <> ?", organizationID, name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// parseTeamMemberIDs valida que os usuários são membros ativos da organização, sem repetições.
+func parseTeamMemberIDs(db *gorm.DB, organizationID uuid.UUID, raw []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	seen := make(map[uuid.UUID]bool, len(raw))
+	for _, value := range raw {
+		id := uuid.MustParse(value) // Validado pelo binding
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	var count int64
+	if err := db.Model(&models.User{}).Where("id IN ? AND organization_id = ? AND is_active = ?", ids, organizationID, true).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if int(count) != len(ids) {
+		return nil, fmt.Errorf("all members must be active users of your organization")
+	}
+	return ids, nil
+}
+
+func addTeamMembers(tx *gorm.DB, teamID uuid.UUID, userIDs []uuid.UUID) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+	members := make([]models.TeamMember, len(userIDs))
+	for i, userID := range userIDs {
+		members[i] = models.TeamMember{TeamID: teamID, UserID: userID}
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&members)
+	return result.RowsAffected, result.Error
+}
+
+func countTeamMembers(db *gorm.DB, teamID uuid.UUID) (int64, error) {
+	var count int64
+	err := db.Model(&models.TeamMember{}).Where("team_id = ?", teamID).Count(&count).Error
+	return count, err
+}
+
+// ListTeamsHandler lists the organization's teams with their number of members. ?mine=true lists only the
+// teams of the authenticated user.
+func ListTeamsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+	query := db.Where("organization_id = ?", orgID)
+	if c.Query("mine") == "true" {
+		query = query.Where("id IN (?)", models.UserTeamIDs(db, userID.(uuid.UUID)))
+	}
+	var teams []models.Team
+	if err := query.Order("name asc").Find(&teams).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list teams: " + err.Error()})
+		return
+	}
+	views := make([]TeamView, 0, len(teams))
+	for _, team := range teams {
+		count, err := countTeamMembers(db, team.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count team members: " + err.Error()})
+			return
+		}
+		views = append(views, TeamView{Team: team, MemberCount: count})
+	}
+	c.JSON(http.StatusOK, views)
+}
+
+// GetTeamHandler returns a team with its members.
+func GetTeamHandler(c *gin.Context) {
+	db := database.GetDB()
+	team, ok := findOrgTeam(c, db)
+	if !ok {
+		return
+	}
+	members := []TeamMemberView{}
+	if err := db.Model(&models.TeamMember{}).
+		Select("team_members.user_id, users.name, users.email, users.is_active").
+		Joins("JOIN users ON users.id = team_members.user_id").
+		Where("team_members.team_id = ?", team.ID).
+		Order("users.name asc").
+		Scan(&members).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list team members: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"team": TeamView{Team: *team, MemberCount: int64(len(members))}, "members": members})
+}
+
+// CreateTeamHandler creates a team, optionally with its initial members (teams:manage).
+func CreateTeamHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.TeamsManage) {
+		return
+	}
+	var payload TeamPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	organizationID := orgID.(uuid.UUID)
+	userID, _ := c.Get("userID")
+	db := database.GetDB()
+
+	name := strings.TrimSpace(payload.Name)
+	taken, err := teamNameTaken(db, organizationID, name, uuid.Nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team name: " + err.Error()})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "A team with this name already exists"})
+		return
+	}
+	memberIDs, err := parseTeamMemberIDs(db, organizationID, payload.MemberIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member_ids: " + err.Error()})
+		return
+	}
+
+	createdByID := userID.(uuid.UUID)
+	team := models.Team{
+		OrganizationID: organizationID,
+		Name:           name,
+		Description:    strings.TrimSpace(payload.Description),
+		CreatedByID:    &createdByID,
+	}
+	var added int64
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&team).Error; err != nil {
+			return err
+		}
+		added, err = addTeamMembers(tx, team.ID, memberIDs)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create team: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, TeamView{Team: team, MemberCount: added})
+}
+
+// UpdateTeamHandler renames a team or changes its description (teams:manage).
+func UpdateTeamHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.TeamsManage) {
+		return
+	}
+	var payload TeamPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	team, ok := findOrgTeam(c, db)
+	if !ok {
+		return
+	}
+	name := strings.TrimSpace(payload.Name)
+	taken, err := teamNameTaken(db, team.OrganizationID, name, team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team name: " + err.Error()})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "A team with this name already exists"})
+		return
+	}
+	team.Name = name
+	team.Description = strings.TrimSpace(payload.Description)
+	if err := db.Save(team).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update team: " + err.Error()})
+		return
+	}
+	count, err := countTeamMembers(db, team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count team members: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, TeamView{Team: *team, MemberCount: count})
+}
+
+// DeleteTeamHandler deletes a team (teams:manage). Risks, assessments and control tests owned by it keep
+// only their individual owner.
+func DeleteTeamHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.TeamsManage) {
+		return
+	}
+	db := database.GetDB()
+	team, ok := findOrgTeam(c, db)
+	if !ok {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// As FKs da migração já fazem ON DELETE SET NULL; as atualizações explícitas cobrem bancos criados só
+		// pelo AutoMigrate
+		if err := tx.Unscoped().Model(&models.Risk{}).Where("owner_team_id = ?", team.ID).Update("owner_team_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.AuditAssessment{}).Where("assigned_team_id = ?", team.ID).Update("assigned_team_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ControlTestPlan{}).Where("owner_team_id = ?", team.ID).Update("owner_team_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("team_id = ?", team.ID).Delete(&models.TeamMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(team).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete team: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Team deleted successfully"})
+}
+
+// AddTeamMembersHandler adds users of the organization to a team (teams:manage). Users already in the team
+// are ignored.
+func AddTeamMembersHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.TeamsManage) {
+		return
+	}
+	var payload TeamMembersPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	team, ok := findOrgTeam(c, db)
+	if !ok {
+		return
+	}
+	memberIDs, err := parseTeamMemberIDs(db, team.OrganizationID, payload.UserIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_ids: " + err.Error()})
+		return
+	}
+	added, err := addTeamMembers(db, team.ID, memberIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add team members: " + err.Error()})
+		return
+	}
+	count, err := countTeamMembers(db, team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count team members: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"added": added, "member_count": count})
+}
+
+// RemoveTeamMemberHandler removes a user from a team (teams:manage).
+func RemoveTeamMemberHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.TeamsManage) {
+		return
+	}
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	db := database.GetDB()
+	team, ok := findOrgTeam(c, db)
+	if !ok {
+		return
+	}
+	result := db.Where("team_id = ? AND user_id = ?", team.ID, userID).Delete(&models.TeamMember{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove team member: " + result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User is not a member of this team"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/trash"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TrashItem é um risco ou uma política na lixeira.
+type TrashItem struct {
+	ID            uuid.UUID `json:"id"`
+	ReferenceCode string    `json:"reference_code"`
+	Title         string    `json:"title"`
+	Status        string    `json:"status"`
+	OwnerID       uuid.UUID `json:"owner_id"`
+	DeletedAt     time.Time `json:"deleted_at"`
+	PurgeAt       time.Time `json:"purge_at"` // Quando o registro será expurgado definitivamente
+}
+
+// listTrash responde a página da lixeira de model (riscos ou políticas) da organização, dos excluídos mais
+// recentemente aos mais antigos.
+func listTrash(c *gin.Context, model interface{}) {
+	orgID, _ := c.Get("organizationID")
+	page, pageSize := GetPaginationParams(c)
+	query := database.GetDB().Unscoped().Model(model).Where("organization_id = ? AND deleted_at IS NOT NULL", orgID)
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count deleted records: " + err.Error()})
+		return
+	}
+	items := []TrashItem{}
+	if err := query.Select("id, reference_code, title, status, owner_id, deleted_at").
+		Scopes(PaginateScope(page, pageSize)).Order("deleted_at desc, id").Scan(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list deleted records: " + err.Error()})
+		return
+	}
+	for i := range items {
+		items[i].PurgeAt = trash.PurgeAt(items[i].DeletedAt)
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      items,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// findTrashed carrega o registro da lixeira da organização identificado pelo parâmetro param. Se não estiver na
+// lixeira, responde 404 e retorna false.
+func findTrashed(c *gin.Context, db *gorm.DB, param string, dest interface{}) bool {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return false
+	}
+	orgID, _ := c.Get("organizationID")
+	if err := db.Unscoped().Where("id = ? AND organization_id = ? AND deleted_at IS NOT NULL", id, orgID).First(dest).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Record not found in the trash"})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deleted record: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// restoreTrashed tira o registro da lixeira e grava a restauração na trilha de auditoria.
+func restoreTrashed(c *gin.Context, db *gorm.DB, model interface{}, organizationID, id uuid.UUID, action models.AuditTrailAction, entityType, summary string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(model).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, organizationID, action, entityType, &id, summary, nil)
+		return err
+	})
+}
+
+// ListDeletedRisksHandler lists the organization's risks in the trash (risks:delete), with the date each one will
+// be purged (TRASH_RETENTION_DAYS after deletion).
+func ListDeletedRisksHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.RisksDelete) {
+		return
+	}
+	listTrash(c, &models.Risk{})
+}
+
+// RestoreRiskHandler restores a risk from the trash (risks:delete), with its tags and links.
+func RestoreRiskHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.RisksDelete) {
+		return
+	}
+	db := database.GetDB()
+	var risk models.Risk
+	if !findTrashed(c, db, "riskId", &risk) {
+		return
+	}
+	err := restoreTrashed(c, db, &risk, risk.OrganizationID, risk.ID, models.AuditTrailRiskRestored, "risk",
+		fmt.Sprintf("Risk %s restored from the trash", risk.ReferenceCode))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore risk: " + err.Error()})
+		return
+	}
+	db.Preload("Owner").Where("id = ?", risk.ID).First(&risk)
+	setVersionETag(c, risk.UpdatedAt)
+	c.JSON(http.StatusOK, risk)
+}
+
+// ListDeletedPoliciesHandler lists the organization's policies in the trash (policies:restore).
+func ListDeletedPoliciesHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.PoliciesRestore) {
+		return
+	}
+	listTrash(c, &models.Policy{})
+}
+
+// RestorePolicyHandler restores a policy from the trash (policies:restore), with its versions,
+// acknowledgments and mapped controls.
+func RestorePolicyHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.PoliciesRestore) {
+		return
+	}
+	db := database.GetDB()
+	var policy models.Policy
+	if !findTrashed(c, db, "policyId", &policy) {
+		return
+	}
+	err := restoreTrashed(c, db, &policy, policy.OrganizationID, policy.ID, models.AuditTrailPolicyRestored, "policy",
+		fmt.Sprintf("Policy %s restored from the trash", policy.ReferenceCode))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore policy: " + err.Error()})
+		return
+	}
+	db.Where("id = ?", policy.ID).First(&policy)
+	c.JSON(http.StatusOK, policy)
+}
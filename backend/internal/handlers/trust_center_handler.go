@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var trustCenterSlugRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,61}[a-z0-9]$`)
+
+// TrustCenterProfilePayload defines the structure for configuring the organization's trust center.
+type TrustCenterProfilePayload struct {
+	Slug              string                            `json:"slug" binding:"required"`
+	Headline          string                            `json:"headline" binding:"max=255"`
+	Description       string                            `json:"description"`
+	Certifications    []models.TrustCenterCertification `json:"certifications"`
+	FrameworkIDs      []string                          `json:"framework_ids"`
+	ShowLastAuditDate *bool                             `json:"show_last_audit_date"`
+}
+
+// TrustCenterProfileResponse is the authenticated view of the trust center configuration.
+type TrustCenterProfileResponse struct {
+	models.TrustCenterProfile
+	Certifications []models.TrustCenterCertification `json:"certifications"`
+	FrameworkIDs   []string                          `json:"framework_ids"`
+}
+
+// TrustCenterFrameworkFact is the public compliance summary for one framework.
+type TrustCenterFrameworkFact struct {
+	FrameworkName      string  `json:"framework_name"`
+	CoveragePercentage float64 `json:"coverage_percentage"` // % de controles avaliados
+	ComplianceScore    float64 `json:"compliance_score"`    // média dos scores avaliados
+}
+
+// PublicTrustCenterResponse is the unauthenticated payload served to trust pages.
+type PublicTrustCenterResponse struct {
+	OrganizationName string                            `json:"organization_name"`
+	PrimaryColor     string                            `json:"primary_color,omitempty"`
+	Headline         string                            `json:"headline"`
+	Description      string                            `json:"description"`
+	Certifications   []models.TrustCenterCertification `json:"certifications"`
+	Frameworks       []TrustCenterFrameworkFact        `json:"frameworks"`
+	LastAuditDate    *time.Time                        `json:"last_audit_date,omitempty"`
+	ApprovedAt       *time.Time                        `json:"approved_at,omitempty"`
+}
+
+func newTrustCenterProfileResponse(profile models.TrustCenterProfile) TrustCenterProfileResponse {
+	resp := TrustCenterProfileResponse{
+		TrustCenterProfile: profile,
+		Certifications:     []models.TrustCenterCertification{},
+		FrameworkIDs:       []string{},
+	}
+	if profile.CertificationsJSON != "" {
+		_ = json.Unmarshal([]byte(profile.CertificationsJSON), &resp.Certifications)
+	}
+	if profile.FrameworkIDs != "" {
+		resp.FrameworkIDs = strings.Split(profile.FrameworkIDs, ",")
+	}
+	return resp
+}
+
+// GetTrustCenterProfileHandler returns the organization's trust center configuration.
+func GetTrustCenterProfileHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.TrustCenterManage) {
+		return
+	}
+
+	var profile models.TrustCenterProfile
+	if err := database.GetDB().Where("organization_id = ?", targetOrgID).First(&profile).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Trust center is not configured for this organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trust center profile: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newTrustCenterProfileResponse(profile))
+}
+
+// UpsertTrustCenterProfileHandler creates or updates the trust center configuration.
+// Qualquer alteração despublica o perfil até que um admin aprove novamente.
+func UpsertTrustCenterProfileHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.TrustCenterManage) {
+		return
+	}
+
+	var payload TrustCenterProfilePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	payload.Slug = strings.ToLower(strings.TrimSpace(payload.Slug))
+	if !trustCenterSlugRegex.MatchString(payload.Slug) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid slug: use 3-63 lowercase letters, digits or hyphens"})
+		return
+	}
+
+	db := database.GetDB()
+
+	frameworkIDs := make([]string, 0, len(payload.FrameworkIDs))
+	if len(payload.FrameworkIDs) > 0 {
+		parsedIDs := make([]uuid.UUID, 0, len(payload.FrameworkIDs))
+		for _, idStr := range payload.FrameworkIDs {
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid framework ID format: " + idStr})
+				return
+			}
+			parsedIDs = append(parsedIDs, id)
+			frameworkIDs = append(frameworkIDs, id.String())
+		}
+		var count int64
+		db.Model(&models.AuditFramework{}).Scopes(visibleFrameworksScope(targetOrgID)).Where("id IN ?", parsedIDs).Count(&count)
+		if int(count) != len(parsedIDs) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more framework IDs do not exist"})
+			return
+		}
+	}
+
+	var slugOwnerCount int64
+	db.Model(&models.TrustCenterProfile{}).Where("slug = ? AND organization_id <> ?", payload.Slug, targetOrgID).Count(&slugOwnerCount)
+	if slugOwnerCount > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Slug is already in use by another organization"})
+		return
+	}
+
+	certifications := payload.Certifications
+	if certifications == nil {
+		certifications = []models.TrustCenterCertification{}
+	}
+	certificationsJSON, err := json.Marshal(certifications)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid certifications: " + err.Error()})
+		return
+	}
+
+	var profile models.TrustCenterProfile
+	err = db.Where("organization_id = ?", targetOrgID).First(&profile).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trust center profile: " + err.Error()})
+		return
+	}
+	isNew := err == gorm.ErrRecordNotFound
+
+	profile.OrganizationID = targetOrgID
+	profile.Slug = payload.Slug
+	profile.Headline = payload.Headline
+	profile.Description = payload.Description
+	profile.CertificationsJSON = string(certificationsJSON)
+	profile.FrameworkIDs = strings.Join(frameworkIDs, ",")
+	if payload.ShowLastAuditDate != nil {
+		profile.ShowLastAuditDate = *payload.ShowLastAuditDate
+	} else if isNew {
+		profile.ShowLastAuditDate = true
+	}
+	// Conteúdo alterado: requer nova aprovação antes de voltar a ser público.
+	profile.IsPublished = false
+	profile.ApprovedByID = nil
+	profile.ApprovedAt = nil
+
+	if isNew {
+		err = db.Create(&profile).Error
+	} else {
+		err = db.Save(&profile).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save trust center profile: " + err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if isNew {
+		status = http.StatusCreated
+	}
+	c.JSON(status, newTrustCenterProfileResponse(profile))
+}
+
+// PublishTrustCenterHandler approves and publishes the trust center (requer role admin).
+func PublishTrustCenterHandler(c *gin.Context) {
+	setTrustCenterPublished(c, true)
+}
+
+// UnpublishTrustCenterHandler removes the trust center from the public endpoint.
+func UnpublishTrustCenterHandler(c *gin.Context) {
+	setTrustCenterPublished(c, false)
+}
+
+// setTrustCenterPublished publica ou despublica o perfil.
+// Publicar é a aprovação explícita da organização e exige também rbac.TrustCenterPublish (admin).
+func setTrustCenterPublished(c *gin.Context, publish bool) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.TrustCenterManage) {
+		return
+	}
+	if publish && !rbac.Require(c, rbac.TrustCenterPublish) {
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	db := database.GetDB()
+	var profile models.TrustCenterProfile
+	if err := db.Where("organization_id = ?", targetOrgID).First(&profile).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Trust center is not configured for this organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trust center profile: " + err.Error()})
+		return
+	}
+
+	profile.IsPublished = publish
+	if publish {
+		approverID := userID.(uuid.UUID)
+		now := time.Now()
+		profile.ApprovedByID = &approverID
+		profile.ApprovedAt = &now
+	}
+	if err := db.Save(&profile).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update trust center profile: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newTrustCenterProfileResponse(profile))
+}
+
+// GetPublicTrustCenterHandler serves the published compliance facts of an organization (sem autenticação).
+func GetPublicTrustCenterHandler(c *gin.Context) {
+	slug := strings.ToLower(c.Param("slug"))
+	db := database.GetDB()
+
+	var profile models.TrustCenterProfile
+	if err := db.Preload("Organization").Where("slug = ? AND is_published = ?", slug, true).First(&profile).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Trust center not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load trust center"})
+		return
+	}
+	view := newTrustCenterProfileResponse(profile)
+
+	resp := PublicTrustCenterResponse{
+		OrganizationName: profile.Organization.Name,
+		PrimaryColor:     profile.Organization.PrimaryColor,
+		Headline:         profile.Headline,
+		Description:      profile.Description,
+		Certifications:   view.Certifications,
+		Frameworks:       []TrustCenterFrameworkFact{},
+		ApprovedAt:       profile.ApprovedAt,
+	}
+
+	if len(view.FrameworkIDs) > 0 {
+		type frameworkRow struct {
+			FrameworkName     string
+			TotalControls     int64
+			EvaluatedControls int64
+			AvgScore          float64
+			LastAssessment    *time.Time
+		}
+		var rows []frameworkRow
+		err := db.Table("audit_frameworks").
+			Select(`audit_frameworks.name AS framework_name,
+				COUNT(DISTINCT audit_controls.id) AS total_controls,
+				COUNT(DISTINCT audit_assessments.id) AS evaluated_controls,
+				COALESCE(AVG(audit_assessments.score), 0) AS avg_score,
+				MAX(audit_assessments.assessment_date) AS last_assessment`).
+			Joins("LEFT JOIN audit_controls ON audit_controls.framework_id = audit_frameworks.id").
+			Joins("LEFT JOIN audit_assessments ON audit_assessments.audit_control_id = audit_controls.id AND audit_assessments.organization_id = ? AND audit_assessments.status <> '' AND audit_assessments.audit_campaign_id IS NULL", profile.OrganizationID).
+			Where("audit_frameworks.id IN ?", view.FrameworkIDs).
+			Group("audit_frameworks.id, audit_frameworks.name").
+			Order("audit_frameworks.name asc").
+			Scan(&rows).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute compliance facts"})
+			return
+		}
+		for _, row := range rows {
+			fact := TrustCenterFrameworkFact{FrameworkName: row.FrameworkName, ComplianceScore: row.AvgScore}
+			if row.TotalControls > 0 {
+				fact.CoveragePercentage = float64(row.EvaluatedControls) / float64(row.TotalControls) * 100
+			}
+			resp.Frameworks = append(resp.Frameworks, fact)
+			if row.LastAssessment != nil && (resp.LastAuditDate == nil || row.LastAssessment.After(*resp.LastAuditDate)) {
+				resp.LastAuditDate = row.LastAssessment
+			}
+		}
+	}
+	if !profile.ShowLastAuditDate {
+		resp.LastAuditDate = nil
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/preflight"
+	"phoenixgrc/backend/internal/rbac"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// bulkUserRoleMaxUsers limita os usuários selecionados em uma atribuição em lote.
+const bulkUserRoleMaxUsers = 5000
+
+// Motivos para um usuário selecionado não ser alterado.
+const (
+	bulkRoleSkipNotFound           = "not_found"            // ID não pertence à organização
+	bulkRoleSkipAdminRequiresAdmin = "admin_requires_admin" // Sem users:assign_admin (ex: manager), não altera a role de admins
+)
+
+// BulkUserRoleFilter seleciona os usuários da organização por critérios (combinados com AND).
+type BulkUserRoleFilter struct {
+	Roles       []models.UserRole `json:"roles" binding:"omitempty,dive,oneof=admin manager user"` // Role atual
+	IsActive    *bool             `json:"is_active"`
+	EmailDomain string            `json:"email_domain"` // ex: "financeiro.empresa.com"
+	SSOProvider string            `json:"sso_provider"`
+	Search      string            `json:"search"` // Trecho do nome ou do email
+}
+
+func (f BulkUserRoleFilter) isEmpty() bool {
+	return len(f.Roles) == 0 && f.IsActive == nil && strings.TrimSpace(f.EmailDomain) == "" &&
+		strings.TrimSpace(f.SSOProvider) == "" && strings.TrimSpace(f.Search) == ""
+}
+
+// apply restringe a consulta de usuários aos critérios do filtro.
+func (f BulkUserRoleFilter) apply(query *gorm.DB) *gorm.DB {
+	if len(f.Roles) > 0 {
+		query = query.Where("role IN ?", f.Roles)
+	}
+	if f.IsActive != nil {
+		query = query.Where("is_active = ?", *f.IsActive)
+	}
+	if domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(f.EmailDomain), "@")); domain != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%@"+escapeLike(domain))
+	}
+	if provider := strings.TrimSpace(f.SSOProvider); provider != "" {
+		query = query.Where("sso_provider = ?", provider)
+	}
+	if search := strings.TrimSpace(f.Search); search != "" {
+		pattern := "%" + escapeLike(strings.ToLower(search)) + "%"
+		query = query.Where("(LOWER(name) LIKE ? OR LOWER(email) LIKE ?)", pattern, pattern)
+	}
+	return query
+}
+
+// escapeLike escapa os curingas do LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// BulkUserRolePayload define a atribuição de role em lote: por lista de IDs ou por filtro (exatamente um).
+type BulkUserRolePayload struct {
+	UserIDs []string            `json:"user_ids"`
+	Filter  *BulkUserRoleFilter `json:"filter"`
+	Role    models.UserRole     `json:"role" binding:"required,oneof=admin manager user"`
+	Reason  string              `json:"reason" binding:"max=500"`
+	DryRun  bool                `json:"dry_run"`
+}
+
+// BulkUserRoleChange é um usuário cuja role é (ou seria, no dry-run) alterada.
+type BulkUserRoleChange struct {
+	UserID       uuid.UUID       `json:"user_id"`
+	Name         string          `json:"name"`
+	Email        string          `json:"email"`
+	PreviousRole models.UserRole `json:"previous_role"`
+	NewRole      models.UserRole `json:"new_role"`
+}
+
+// BulkUserRoleSkip é um usuário selecionado que não é alterado.
+type BulkUserRoleSkip struct {
+	UserID uuid.UUID `json:"user_id"`
+	Reason string    `json:"reason"`
+}
+
+// BulkUserRoleReport é o relatório da atribuição em lote; o mesmo relatório é gravado na trilha de auditoria.
+type BulkUserRoleReport struct {
+	DryRun            bool                 `json:"dry_run"`
+	Role              models.UserRole      `json:"role"`
+	Reason            string               `json:"reason,omitempty"`
+	Matched           int                  `json:"matched"`
+	Changed           []BulkUserRoleChange `json:"changed"`
+	Unchanged         []uuid.UUID          `json:"unchanged"` // Já possuem a role
+	Skipped           []BulkUserRoleSkip   `json:"skipped"`
+	Preflight         *preflight.Estimate  `json:"preflight,omitempty"`
+	AuditTrailEntryID *uuid.UUID           `json:"audit_trail_entry_id,omitempty"`
+}
+
+// fingerprint vincula o token de confirmação à role e ao conjunto exato de usuários alterados.
+func (r BulkUserRoleReport) fingerprint() string {
+	parts := [][]byte{[]byte(r.Role)}
+	for _, change := range r.Changed {
+		parts = append(parts, []byte(change.UserID.String()))
+	}
+	return preflight.Fingerprint(parts...)
+}
+
+// BulkAssignOrganizationUserRoleHandler changes the role of many users at once, selected by ID list or by
+// filter. Com dry_run retorna o relatório sem alterar nada (e o token de confirmação quando a operação está
+// acima do limite); a execução grava o relatório na trilha de auditoria.
+func BulkAssignOrganizationUserRoleHandler(c *gin.Context) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return
+	}
+	if !checkOrgPermission(c, targetOrgID, rbac.UsersManage) {
+		return
+	}
+	canAssignAdmin := rbac.Can(c, rbac.UsersAssignAdmin)
+
+	var payload BulkUserRolePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if (len(payload.UserIDs) > 0) == (payload.Filter != nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide either user_ids or filter"})
+		return
+	}
+	// Sem users:assign_admin (managers) não se concede nem revoga o papel de admin
+	if !canAssignAdmin && payload.Role == models.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can grant the admin role"})
+		return
+	}
+
+	db := database.GetDB()
+	report := BulkUserRoleReport{
+		DryRun:    payload.DryRun,
+		Role:      payload.Role,
+		Reason:    strings.TrimSpace(payload.Reason),
+		Changed:   []BulkUserRoleChange{},
+		Unchanged: []uuid.UUID{},
+		Skipped:   []BulkUserRoleSkip{},
+	}
+
+	query := db.Model(&models.User{}).Select("id", "name", "email", "role", "is_active").Where("organization_id = ?", targetOrgID)
+	var requestedIDs []uuid.UUID
+	if payload.Filter != nil {
+		if payload.Filter.isEmpty() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Filter must have at least one criterion"})
+			return
+		}
+		query = payload.Filter.apply(query)
+	} else {
+		if len(payload.UserIDs) > bulkUserRoleMaxUsers {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d users can be changed at once", bulkUserRoleMaxUsers)})
+			return
+		}
+		seen := make(map[uuid.UUID]bool, len(payload.UserIDs))
+		for _, raw := range payload.UserIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format: " + raw})
+				return
+			}
+			if !seen[id] {
+				seen[id] = true
+				requestedIDs = append(requestedIDs, id)
+			}
+		}
+		query = query.Where("id IN ?", requestedIDs)
+	}
+
+	var users []models.User
+	if err := query.Order("email asc").Limit(bulkUserRoleMaxUsers + 1).Find(&users).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to select users: " + err.Error()})
+		return
+	}
+	if len(users) > bulkUserRoleMaxUsers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("The filter matches more than %d users; narrow it down", bulkUserRoleMaxUsers)})
+		return
+	}
+	report.Matched = len(users)
+
+	found := make(map[uuid.UUID]bool, len(users))
+	for _, user := range users {
+		found[user.ID] = true
+		switch {
+		case user.Role == payload.Role:
+			report.Unchanged = append(report.Unchanged, user.ID)
+		case user.Role == models.RoleAdmin && !canAssignAdmin:
+			report.Skipped = append(report.Skipped, BulkUserRoleSkip{UserID: user.ID, Reason: bulkRoleSkipAdminRequiresAdmin})
+		default:
+			report.Changed = append(report.Changed, BulkUserRoleChange{
+				UserID:       user.ID,
+				Name:         user.Name,
+				Email:        user.Email,
+				PreviousRole: user.Role,
+				NewRole:      payload.Role,
+			})
+		}
+	}
+	for _, id := range requestedIDs {
+		if !found[id] {
+			report.Skipped = append(report.Skipped, BulkUserRoleSkip{UserID: id, Reason: bulkRoleSkipNotFound})
+		}
+	}
+
+	// Lógica de prevenção de bloqueio: a organização precisa manter ao menos um admin/manager ativo
+	if payload.Role == models.RoleUser && len(report.Changed) > 0 {
+		changedIDs := make([]uuid.UUID, 0, len(report.Changed))
+		for _, change := range report.Changed {
+			changedIDs = append(changedIDs, change.UserID)
+		}
+		var remaining int64
+		if err := db.Model(&models.User{}).
+			Where("organization_id = ? AND is_active = ? AND role IN ? AND id NOT IN ?", targetOrgID, true, []models.UserRole{models.RoleAdmin, models.RoleManager}, changedIDs).
+			Count(&remaining).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to select users: " + err.Error()})
+			return
+		}
+		if remaining == 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "This change would leave the organization without an active admin or manager"})
+			return
+		}
+	}
+
+	estimate := preflight.Estimate{Operation: bulkOperationUserRoleAssignment, AffectedRecords: len(report.Changed)}
+	if payload.DryRun {
+		evaluateBulkPreflight(c, db, &estimate, report.fingerprint())
+		report.Preflight = &estimate
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	confirmed, ok := confirmBulkOperation(c, db, &estimate, report.fingerprint())
+	if !ok {
+		return
+	}
+
+	summary := fmt.Sprintf("Role %s assigned to %d users", payload.Role, len(report.Changed))
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for previousRole, ids := range report.changedByPreviousRole() {
+			// A condição na role anterior evita sobrescrever uma alteração concorrente
+			if err := tx.Model(&models.User{}).
+				Where("organization_id = ? AND id IN ? AND role = ?", targetOrgID, ids, previousRole).
+				Update("role", payload.Role).Error; err != nil {
+				return err
+			}
+		}
+		entry, err := recordAuditTrail(c, tx, targetOrgID, models.AuditTrailUserRolesBulkAssigned, "user", nil, summary, report)
+		if err != nil {
+			return err
+		}
+		report.AuditTrailEntryID = &entry.ID
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign roles: " + err.Error()})
+		return
+	}
+	recordBulkOperation(c, db, bulkOperationUserRoleAssignment, len(report.Changed), 0, confirmed)
+	c.JSON(http.StatusOK, report)
+}
+
+// changedByPreviousRole agrupa os usuários alterados pela role anterior.
+func (r BulkUserRoleReport) changedByPreviousRole() map[models.UserRole][]uuid.UUID {
+	groups := make(map[models.UserRole][]uuid.UUID)
+	for _, change := range r.Changed {
+		groups[change.PreviousRole] = append(groups[change.PreviousRole], change.UserID)
+	}
+	return groups
+}
@@ -53,10 +53,9 @@ func GetUserDashboardSummaryHandler(c *gin.Context) {
 	}
 
 	// 3. Contagem de Tarefas de Aprovação Pendentes para o Usuário
-	err = db.Model(&models.ApprovalWorkflow{}).
-		Joins("JOIN risks ON risks.id = approval_workflows.risk_id").
-		Where("approval_workflows.approver_id = ? AND approval_workflows.status = ? AND risks.organization_id = ?",
-			userID, models.ApprovalPending, orgID).
+	// Aceites de risco e exceções de controles
+	err = db.Model(&models.ApprovalWorkflow{}).Scopes(pendingApprovalsScope(orgID)).
+		Where("approval_workflows.approver_id = ?", userID).
 		Count(&summary.PendingApprovalTasksCount).Error
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count pending approval tasks: " + err.Error()})
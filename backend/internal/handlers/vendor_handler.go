@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VendorPayload defines the structure for creating or updating a vendor.
+type VendorPayload struct {
+	Name         string                   `json:"name" binding:"required,min=2,max=255"`
+	Website      string                   `json:"website" binding:"omitempty,url,max=255"`
+	ContactName  string                   `json:"contact_name" binding:"max=255"`
+	ContactEmail string                   `json:"contact_email" binding:"omitempty,email,max=255"`
+	Criticality  models.VendorCriticality `json:"criticality" binding:"omitempty,oneof=low medium high critical"`
+	IsActive     *bool                    `json:"is_active"`
+	Notes        string                   `json:"notes"`
+}
+
+func (p VendorPayload) applyTo(vendor *models.Vendor) {
+	vendor.Name = p.Name
+	vendor.Website = p.Website
+	vendor.ContactName = p.ContactName
+	vendor.ContactEmail = p.ContactEmail
+	vendor.Notes = p.Notes
+	if p.Criticality != "" {
+		vendor.Criticality = p.Criticality
+	}
+	if p.IsActive != nil {
+		vendor.IsActive = *p.IsActive
+	}
+}
+
+// findOrgVendor carrega o fornecedor pelo ID na organização do usuário.
+func findOrgVendor(c *gin.Context, db *gorm.DB, vendorIDStr string) (*models.Vendor, bool) {
+	vendorID, err := uuid.Parse(vendorIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor ID format"})
+		return nil, false
+	}
+	orgID, _ := c.Get("organizationID")
+	var vendor models.Vendor
+	if err := db.Where("id = ? AND organization_id = ?", vendorID, orgID).First(&vendor).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Vendor not found or not part of your organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vendor: " + err.Error()})
+		return nil, false
+	}
+	return &vendor, true
+}
+
+// CreateVendorHandler registers a vendor for the organization.
+func CreateVendorHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.VendorsManage) {
+		return
+	}
+	var payload VendorPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	orgID, _ := c.Get("organizationID")
+	vendor := models.Vendor{
+		OrganizationID: orgID.(uuid.UUID),
+		Criticality:    models.VendorCriticalityMedium,
+		IsActive:       true,
+	}
+	payload.applyTo(&vendor)
+	if err := database.GetDB().Create(&vendor).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create vendor: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, vendor)
+}
+
+// ListVendorsHandler lists the organization's vendors with pagination. Filtros: criticality, is_active.
+func ListVendorsHandler(c *gin.Context) {
+	orgID, _ := c.Get("organizationID")
+	db := database.GetDB()
+	page, pageSize := GetPaginationParams(c)
+
+	query := db.Model(&models.Vendor{}).Where("organization_id = ?", orgID)
+	if criticality := c.Query("criticality"); criticality != "" {
+		query = query.Where("criticality = ?", criticality)
+	}
+	if isActive := c.Query("is_active"); isActive != "" {
+		query = query.Where("is_active = ?", isActive == "true")
+	}
+	query = query.Scopes(tagFilterScope(c, models.TagEntityVendor, "vendors.id"))
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count vendors: " + err.Error()})
+		return
+	}
+	var vendors []models.Vendor
+	if err := query.Scopes(PaginateScope(page, pageSize)).Order("name asc").Find(&vendors).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list vendors: " + err.Error()})
+		return
+	}
+
+	totalPages := totalItems / int64(pageSize)
+	if totalItems%int64(pageSize) != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items:      vendors,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+}
+
+// GetVendorHandler returns a vendor.
+func GetVendorHandler(c *gin.Context) {
+	vendor, ok := findOrgVendor(c, database.GetDB(), c.Param("vendorId"))
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, vendor)
+}
+
+// UpdateVendorHandler updates a vendor.
+func UpdateVendorHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.VendorsManage) {
+		return
+	}
+	var payload VendorPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	vendor, ok := findOrgVendor(c, db, c.Param("vendorId"))
+	if !ok {
+		return
+	}
+	payload.applyTo(vendor)
+	if err := db.Save(vendor).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update vendor: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, vendor)
+}
+
+// DeleteVendorHandler deletes a vendor and its questionnaire responses.
+func DeleteVendorHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.VendorsManage) {
+		return
+	}
+	db := database.GetDB()
+	vendor, ok := findOrgVendor(c, db, c.Param("vendorId"))
+	if !ok {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteEntityTags(tx, models.TagEntityVendor, []uuid.UUID{vendor.ID}); err != nil {
+			return err
+		}
+		return tx.Delete(vendor).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete vendor: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Vendor deleted successfully"})
+}
+
+// VendorRisksPayload substitui os riscos de terceiro vinculados a um fornecedor.
+type VendorRisksPayload struct {
+	RiskIDs []string `json:"risk_ids"`
+}
+
+// loadVendorRisks lista os riscos vinculados ao fornecedor.
+func loadVendorRisks(db *gorm.DB, vendorID uuid.UUID) ([]AssetRiskSummary, error) {
+	risks := []AssetRiskSummary{}
+	err := db.Table("risks").
+		Select("risks.id, risks.title, risks.risk_level, risks.status").
+		Joins("JOIN vendor_risks ON vendor_risks.risk_id = risks.id").
+		Where("vendor_risks.vendor_id = ? AND risks.deleted_at IS NULL", vendorID).
+		Order("risks.title asc").
+		Scan(&risks).Error
+	return risks, err
+}
+
+// ListVendorRisksHandler lists the risks linked to a vendor.
+func ListVendorRisksHandler(c *gin.Context) {
+	db := database.GetDB()
+	vendor, ok := findOrgVendor(c, db, c.Param("vendorId"))
+	if !ok {
+		return
+	}
+	risks, err := loadVendorRisks(db, vendor.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load vendor risks: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, risks)
+}
+
+// SetVendorRisksHandler replaces the risks linked to a vendor.
+func SetVendorRisksHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.VendorsManage) {
+		return
+	}
+	var payload VendorRisksPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	db := database.GetDB()
+	vendor, ok := findOrgVendor(c, db, c.Param("vendorId"))
+	if !ok {
+		return
+	}
+	riskIDs, err := parseUniqueUUIDs(payload.RiskIDs, "risk_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(riskIDs) > 0 {
+		var count int64
+		db.Model(&models.Risk{}).Where("id IN ? AND organization_id = ?", riskIDs, vendor.OrganizationID).Count(&count)
+		if int(count) != len(riskIDs) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "One or more risks were not found in your organization"})
+			return
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("vendor_id = ?", vendor.ID).Delete(&models.VendorRisk{}).Error; err != nil {
+			return err
+		}
+		if len(riskIDs) == 0 {
+			return nil
+		}
+		links := make([]models.VendorRisk, 0, len(riskIDs))
+		for _, id := range riskIDs {
+			links = append(links, models.VendorRisk{VendorID: vendor.ID, RiskID: id})
+		}
+		return tx.Create(&links).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update vendor risks: " + err.Error()})
+		return
+	}
+	risks, err := loadVendorRisks(db, vendor.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load vendor risks: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, risks)
+}
@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/ratelimit"
+	"phoenixgrc/backend/internal/rbac"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DomainTXTLookup consulta os registros TXT do domínio na verificação; substituível nos testes.
+var DomainTXTLookup = net.DefaultResolver.LookupTXT
+
+// SSODiscoveryRateLimiter limita as consultas de descoberta de IdP por IP, para dificultar a enumeração de domínios.
+var SSODiscoveryRateLimiter = ratelimit.New("sso_discover", 60, 15*time.Minute)
+
+// domainVerificationTimeout limita a consulta DNS feita na verificação do domínio.
+const domainVerificationTimeout = 10 * time.Second
+
+// VerifiedDomainPayload claims an email domain for the organization and routes its users to an identity provider.
+type VerifiedDomainPayload struct {
+	Domain             string `json:"domain" binding:"required"`
+	IdentityProviderID string `json:"identity_provider_id" binding:"required"`
+}
+
+// VerifiedDomainUpdatePayload changes the identity provider a domain routes to.
+type VerifiedDomainUpdatePayload struct {
+	IdentityProviderID string `json:"identity_provider_id" binding:"required"`
+}
+
+// VerifiedDomainView is a domain of the organization with the DNS TXT record that proves its ownership.
+type VerifiedDomainView struct {
+	models.VerifiedDomain
+	Verified  bool   `json:"verified"`
+	TXTRecord string `json:"txt_record"`
+}
+
+// SSODiscoveryResponse tells the login page where to send a user, based on the domain of their email.
+type SSODiscoveryResponse struct {
+	SSO      bool                  `json:"sso"`
+	Provider *LoginPageSSOProvider `json:"provider,omitempty"`
+	// Se a organização ainda aceita login por senha (ver OrganizationLoginPage.DisablePasswordLogin)
+	PasswordLogin bool `json:"password_login"`
+}
+
+func newVerifiedDomainView(domain models.VerifiedDomain) VerifiedDomainView {
+	return VerifiedDomainView{VerifiedDomain: domain, Verified: domain.Verified(), TXTRecord: domain.TXTRecord()}
+}
+
+// newDomainVerificationToken gera o token publicado no registro TXT do domínio.
+func newDomainVerificationToken() (string, error) {
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+// emailDomain extrai o domínio (normalizado) de um endereço de email; vazio se o endereço não for válido.
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return ""
+	}
+	domain := normalizeLoginDomain(email[at+1:])
+	if len(domain) > 255 || !loginDomainRegex.MatchString(domain) {
+		return ""
+	}
+	return domain
+}
+
+// verifiedDomainOrg valida :orgId e a permissão de gerenciar provedores de identidade.
+func verifiedDomainOrg(c *gin.Context) (uuid.UUID, bool) {
+	targetOrgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format"})
+		return uuid.Nil, false
+	}
+	return targetOrgID, checkOrgPermission(c, targetOrgID, rbac.IdentityProvidersManage)
+}
+
+// loadVerifiedDomain carrega o domínio da organização indicado em :domainId.
+func loadVerifiedDomain(c *gin.Context, db *gorm.DB, orgID uuid.UUID) (*models.VerifiedDomain, bool) {
+	domainID, err := uuid.Parse(c.Param("domainId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid domain ID format"})
+		return nil, false
+	}
+	var domain models.VerifiedDomain
+	if err := db.Where("id = ? AND organization_id = ?", domainID, orgID).First(&domain).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Domain not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch domain: " + err.Error()})
+		return nil, false
+	}
+	return &domain, true
+}
+
+// domainIdentityProvider resolve o identity_provider_id do payload, que precisa ser um IdP da organização.
+func domainIdentityProvider(c *gin.Context, db *gorm.DB, orgID uuid.UUID, rawID string) (*models.IdentityProvider, bool) {
+	idpID, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid identity provider ID format"})
+		return nil, false
+	}
+	var idp models.IdentityProvider
+	if err := db.Select("id", "name", "provider_type").Where("id = ? AND organization_id = ?", idpID, orgID).First(&idp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identity provider not found in this organization"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch identity provider: " + err.Error()})
+		return nil, false
+	}
+	return &idp, true
+}
+
+// domainVerifiedElsewhere indica se o domínio já foi verificado por outra organização.
+func domainVerifiedElsewhere(db *gorm.DB, orgID uuid.UUID, domain string) (bool, error) {
+	var count int64
+	err := db.Model(&models.VerifiedDomain{}).
+		Where("domain = ? AND organization_id <> ? AND verified_at IS NOT NULL", domain, orgID).Count(&count).Error
+	return count > 0, err
+}
+
+func verifiedDomainAuditDetails(domain *models.VerifiedDomain) gin.H {
+	return gin.H{"domain": domain.Domain, "identity_provider_id": domain.IdentityProviderID, "verified_at": domain.VerifiedAt}
+}
+
+// ListVerifiedDomainsHandler lists the email domains of the organization used for IdP discovery (identity_providers:manage).
+func ListVerifiedDomainsHandler(c *gin.Context) {
+	targetOrgID, ok := verifiedDomainOrg(c)
+	if !ok {
+		return
+	}
+	var domains []models.VerifiedDomain
+	if err := database.GetDB().Where("organization_id = ?", targetOrgID).Order("domain asc").Find(&domains).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list domains: " + err.Error()})
+		return
+	}
+	views := make([]VerifiedDomainView, 0, len(domains))
+	for _, domain := range domains {
+		views = append(views, newVerifiedDomainView(domain))
+	}
+	c.JSON(http.StatusOK, views)
+}
+
+// AddVerifiedDomainHandler claims an email domain for the organization (identity_providers:manage). The domain
+// only routes logins after its TXT record is published and checked with the verify endpoint.
+func AddVerifiedDomainHandler(c *gin.Context) {
+	targetOrgID, ok := verifiedDomainOrg(c)
+	if !ok {
+		return
+	}
+	var payload VerifiedDomainPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	domainName := normalizeLoginDomain(payload.Domain)
+	if len(domainName) > 255 || !loginDomainRegex.MatchString(domainName) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid domain: use a domain name such as example.com"})
+		return
+	}
+
+	db := database.GetDB()
+	idp, ok := domainIdentityProvider(c, db, targetOrgID, payload.IdentityProviderID)
+	if !ok {
+		return
+	}
+	var existing int64
+	if err := db.Model(&models.VerifiedDomain{}).Where("organization_id = ? AND domain = ?", targetOrgID, domainName).Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save domain: " + err.Error()})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Domain has already been added to this organization"})
+		return
+	}
+	if taken, err := domainVerifiedElsewhere(db, targetOrgID, domainName); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save domain: " + err.Error()})
+		return
+	} else if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "Domain is already verified by another organization"})
+		return
+	}
+
+	token, err := newDomainVerificationToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate verification token"})
+		return
+	}
+	domain := models.VerifiedDomain{
+		OrganizationID:     targetOrgID,
+		Domain:             domainName,
+		IdentityProviderID: &idp.ID,
+		VerificationToken:  token,
+	}
+	userID, _ := c.Get("userID")
+	if createdByID, ok := userID.(uuid.UUID); ok {
+		domain.CreatedByID = &createdByID
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&domain).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, targetOrgID, models.AuditTrailDomainAdded, "verified_domain", &domain.ID,
+			fmt.Sprintf("Domain %s added for identity provider %s", domain.Domain, idp.Name), verifiedDomainAuditDetails(&domain))
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save domain: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, newVerifiedDomainView(domain))
+}
+
+// UpdateVerifiedDomainHandler changes the identity provider a domain routes to (identity_providers:manage).
+func UpdateVerifiedDomainHandler(c *gin.Context) {
+	targetOrgID, ok := verifiedDomainOrg(c)
+	if !ok {
+		return
+	}
+	db := database.GetDB()
+	domain, ok := loadVerifiedDomain(c, db, targetOrgID)
+	if !ok {
+		return
+	}
+	var payload VerifiedDomainUpdatePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	idp, ok := domainIdentityProvider(c, db, targetOrgID, payload.IdentityProviderID)
+	if !ok {
+		return
+	}
+
+	domain.IdentityProviderID = &idp.ID
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(domain).Update("identity_provider_id", idp.ID).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, targetOrgID, models.AuditTrailDomainUpdated, "verified_domain", &domain.ID,
+			fmt.Sprintf("Domain %s now routes to identity provider %s", domain.Domain, idp.Name), verifiedDomainAuditDetails(domain))
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update domain: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newVerifiedDomainView(*domain))
+}
+
+// VerifyDomainHandler checks the DNS TXT record of the domain and marks it verified (identity_providers:manage).
+// A domain can only be verified by one organization.
+func VerifyDomainHandler(c *gin.Context) {
+	targetOrgID, ok := verifiedDomainOrg(c)
+	if !ok {
+		return
+	}
+	db := database.GetDB()
+	domain, ok := loadVerifiedDomain(c, db, targetOrgID)
+	if !ok {
+		return
+	}
+	if domain.Verified() {
+		c.JSON(http.StatusOK, newVerifiedDomainView(*domain))
+		return
+	}
+	if taken, err := domainVerifiedElsewhere(db, targetOrgID, domain.Domain); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify domain: " + err.Error()})
+		return
+	} else if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "Domain is already verified by another organization"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), domainVerificationTimeout)
+	defer cancel()
+	records, lookupErr := DomainTXTLookup(ctx, domain.Domain)
+	found := false
+	for _, record := range records {
+		found = found || strings.TrimSpace(record) == domain.TXTRecord()
+	}
+	now := time.Now()
+	domain.LastCheckedAt = &now
+	if !found {
+		if err := db.Model(domain).Update("last_checked_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify domain: " + err.Error()})
+			return
+		}
+		resp := gin.H{"error": "Verification TXT record not found for the domain", "txt_record": domain.TXTRecord()}
+		if lookupErr != nil {
+			resp["details"] = lookupErr.Error()
+		}
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	domain.VerifiedAt = &now
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(domain).Updates(map[string]interface{}{"verified_at": now, "last_checked_at": now}).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, targetOrgID, models.AuditTrailDomainVerified, "verified_domain", &domain.ID,
+			fmt.Sprintf("Domain %s verified", domain.Domain), verifiedDomainAuditDetails(domain))
+		return err
+	})
+	if err != nil {
+		// O índice único de domínios verificados barra duas organizações verificando ao mesmo tempo
+		if taken, _ := domainVerifiedElsewhere(db, targetOrgID, domain.Domain); taken {
+			c.JSON(http.StatusConflict, gin.H{"error": "Domain is already verified by another organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify domain: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, newVerifiedDomainView(*domain))
+}
+
+// DeleteVerifiedDomainHandler removes a domain from the organization (identity_providers:manage); its users are
+// no longer routed to the identity provider.
+func DeleteVerifiedDomainHandler(c *gin.Context) {
+	targetOrgID, ok := verifiedDomainOrg(c)
+	if !ok {
+		return
+	}
+	db := database.GetDB()
+	domain, ok := loadVerifiedDomain(c, db, targetOrgID)
+	if !ok {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(domain).Error; err != nil {
+			return err
+		}
+		_, err := recordAuditTrail(c, tx, targetOrgID, models.AuditTrailDomainRemoved, "verified_domain", &domain.ID,
+			fmt.Sprintf("Domain %s removed", domain.Domain), verifiedDomainAuditDetails(domain))
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove domain: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Domain removed successfully"})
+}
+
+// DiscoverSSOHandler maps the domain of ?email= to the identity provider of the organization that verified it,
+// so the login page can send the user straight to the SAML/OAuth2 flow (sem autenticação). Unknown or
+// unverified domains, and domains whose identity provider is missing or inactive, answer sso: false.
+func DiscoverSSOHandler(c *gin.Context) {
+	domainName := emailDomain(strings.TrimSpace(c.Query("email")))
+	if domainName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide a valid email address"})
+		return
+	}
+
+	db := database.GetDB()
+	var domain models.VerifiedDomain
+	err := db.Where("domain = ? AND verified_at IS NOT NULL", domainName).First(&domain).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && domain.IdentityProviderID == nil) {
+		c.JSON(http.StatusOK, SSODiscoveryResponse{SSO: false, PasswordLogin: true})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to discover identity provider"})
+		return
+	}
+
+	providers, err := organizationSSOProviders(db, domain.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to discover identity provider"})
+		return
+	}
+	resp := SSODiscoveryResponse{PasswordLogin: true}
+	for i := range providers {
+		if providers[i].ID == *domain.IdentityProviderID {
+			resp.SSO, resp.Provider = true, &providers[i]
+			break
+		}
+	}
+	if resp.SSO {
+		var disabled int64
+		if err := db.Model(&models.OrganizationLoginPage{}).
+			Where("organization_id = ? AND disable_password_login = ?", domain.OrganizationID, true).
+			Count(&disabled).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to discover identity provider"})
+			return
+		}
+		resp.PasswordLogin = disabled == 0
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errVersionConflict indica que o registro mudou entre a leitura e a gravação (ver a condição em updated_at).
+var errVersionConflict = errors.New("record was modified by another request")
+
+// versionETag é a versão do registro anunciada no header ETag: o instante da última alteração (updated_at), em
+// microssegundos, a precisão guardada no banco.
+func versionETag(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixMicro(), 10) + `"`
+}
+
+// setVersionETag anuncia a versão do registro; o cliente a devolve em If-Match ao gravar.
+func setVersionETag(c *gin.Context, updatedAt time.Time) {
+	c.Header("ETag", versionETag(updatedAt))
+}
+
+// checkIfMatch confere o header If-Match (opcional) com a versão atual do registro. exists é false quando o registro
+// ainda não existe (ex: a primeira avaliação de um controle), caso em que qualquer If-Match falha. Se a versão não
+// confere, responde 412 com o ETag atual e retorna false.
+func checkIfMatch(c *gin.Context, updatedAt time.Time, exists bool) bool {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" {
+		return true
+	}
+	if exists {
+		current := versionETag(updatedAt)
+		for _, tag := range strings.Split(ifMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == current {
+				return true
+			}
+		}
+		setVersionETag(c, updatedAt)
+	}
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "The record was changed since you loaded it; reload it and try again"})
+	return false
+}
+
+// respondVersionConflict responde 409 quando outra requisição alterou o registro durante a gravação.
+func respondVersionConflict(c *gin.Context) {
+	c.JSON(http.StatusConflict, gin.H{"error": "The record was changed by another request; reload it and try again"})
+}
+
+// ifMatchSent indica se o cliente enviou If-Match, isto é, se a gravação deve falhar caso o registro mude.
+func ifMatchSent(c *gin.Context) bool {
+	return strings.TrimSpace(c.GetHeader("If-Match")) != ""
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -100,7 +101,7 @@ func ListVulnerabilitiesHandler(c *gin.Context) {
 
 	page, pageSize := GetPaginationParams(c) // Usando a função helper de common.go
 
-	db := database.GetDB()
+	db := database.GetDB().WithContext(c.Request.Context())
 	var vulnerabilities []models.Vulnerability
 	var totalItems int64
 
@@ -169,18 +170,13 @@ func UpdateVulnerabilityHandler(c *gin.Context) {
 	}
 
 	orgIDToken, orgExists := c.Get("organizationID")
-	userRoleToken, roleExists := c.Get("userRole")
-
-	if !orgExists || !roleExists {
+	if !orgExists {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Authentication token is missing required information."})
 		return
 	}
 	organizationID := orgIDToken.(uuid.UUID)
-	currentUserRole := userRoleToken.(models.UserRole)
 
-	// Authorization: Only Admin or Manager can update vulnerabilities
-	if currentUserRole != models.RoleAdmin && currentUserRole != models.RoleManager {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to update vulnerabilities."})
+	if !rbac.Require(c, rbac.VulnerabilitiesManage) {
 		return
 	}
 
@@ -228,18 +224,13 @@ func DeleteVulnerabilityHandler(c *gin.Context) {
 	}
 
 	orgIDToken, orgExists := c.Get("organizationID")
-	userRoleToken, roleExists := c.Get("userRole")
-
-	if !orgExists || !roleExists {
+	if !orgExists {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Authentication token is missing required information."})
 		return
 	}
 	organizationID := orgIDToken.(uuid.UUID)
-	currentUserRole := userRoleToken.(models.UserRole)
 
-	// Authorization: Only Admin or Manager can delete vulnerabilities
-	if currentUserRole != models.RoleAdmin && currentUserRole != models.RoleManager {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete vulnerabilities."})
+	if !rbac.Require(c, rbac.VulnerabilitiesManage) {
 		return
 	}
 
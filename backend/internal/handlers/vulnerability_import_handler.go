@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/events"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/preflight"
+	"phoenixgrc/backend/internal/rbac"
+	"phoenixgrc/backend/internal/riskutils"
+	"phoenixgrc/backend/internal/vulnimport"
+	"phoenixgrc/backend/pkg/config"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportVulnerabilityScanResponse resume a importação de uma exportação de scanner.
+type ImportVulnerabilityScanResponse struct {
+	Format        string                  `json:"format"`
+	Parsed        int                     `json:"parsed"`
+	Unique        int                     `json:"unique"`
+	Created       int                     `json:"created"`
+	Updated       int                     `json:"updated"`
+	Reopened      int                     `json:"reopened"`
+	RisksCreated  int                     `json:"risks_created"`
+	RiskIDs       []uuid.UUID             `json:"risk_ids"`
+	CVSSThreshold *float64                `json:"cvss_threshold,omitempty"`
+	FailedRows    []BulkUploadErrorDetail `json:"failed_rows,omitempty"`
+}
+
+// vulnerabilityDedupKey usa a mesma chave dos achados importados (CVE ou título + ativo).
+func vulnerabilityDedupKey(v models.Vulnerability) string {
+	return vulnimport.Finding{CVEID: v.CVEID, Title: v.Title, Asset: v.AssetAffected}.DedupKey()
+}
+
+// riskFromFinding monta o risco criado automaticamente para um achado acima do limiar de CVSS, com o nível
+// pela matriz de pontuação da organização.
+func riskFromFinding(orgID, ownerID uuid.UUID, f vulnimport.Finding, matrix riskutils.ScoringMatrix) models.Risk {
+	label := f.Title
+	if f.CVEID != "" && !strings.Contains(strings.ToUpper(f.Title), f.CVEID) {
+		label = f.CVEID + ": " + f.Title
+	}
+	title := fmt.Sprintf("%s (%s)", label, f.Asset)
+	if runes := []rune(title); len(runes) > 255 {
+		title = string(runes[:252]) + "..."
+	}
+	cve := f.CVEID
+	if cve == "" {
+		cve = "-"
+	}
+	probability := models.ProbabilityMedium
+	if f.CVSS >= 9.0 {
+		probability = models.ProbabilityHigh
+	}
+	risk := models.Risk{
+		OrganizationID: orgID,
+		Title:          title,
+		Description: fmt.Sprintf("Risco criado automaticamente a partir da importação %s.\n\nAtivo: %s\nCVE: %s\nCVSS: %.1f\n\n%s",
+			f.Source, f.Asset, cve, f.CVSS, f.Description),
+		Category:    models.CategoryTechnological,
+		Impact:      models.RiskImpact(f.Severity),
+		Probability: probability,
+		Status:      models.StatusOpen,
+		OwnerID:     ownerID,
+	}
+	risk.RiskLevel = matrix.Level(risk.Impact, risk.Probability)
+	return risk
+}
+
+// vulnerabilityImportRequest é a exportação de scanner lida e interpretada, com as opções da importação.
+type vulnerabilityImportRequest struct {
+	format     vulnimport.Format
+	autoCreate bool
+	threshold  float64
+	content    []byte
+	findings   []vulnimport.Finding
+	rowErrs    []vulnimport.RowError
+	unique     []vulnimport.Finding
+}
+
+// fingerprint vincula o token de confirmação ao arquivo e às opções da importação.
+func (r *vulnerabilityImportRequest) fingerprint() string {
+	return preflight.Fingerprint(r.content, []byte(r.format), []byte(strconv.FormatBool(r.autoCreate)),
+		[]byte(strconv.FormatFloat(r.threshold, 'f', -1, 64)))
+}
+
+// parseVulnerabilityImportRequest lê o arquivo ('file') e as opções format, auto_create_risks e cvss_threshold.
+func parseVulnerabilityImportRequest(c *gin.Context) (*vulnerabilityImportRequest, bool) {
+	req := &vulnerabilityImportRequest{
+		format:     vulnimport.Format(strings.ToLower(c.DefaultQuery("format", c.PostForm("format")))),
+		autoCreate: c.Query("auto_create_risks") == "true",
+		threshold:  config.Cfg.VulnAutoRiskCVSSThreshold,
+	}
+	if raw := c.Query("cvss_threshold"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 || parsed > 10 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cvss_threshold must be a number between 0 and 10"})
+			return nil, false
+		}
+		req.threshold = parsed
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Scan export not provided in 'file' field"})
+		return nil, false
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open uploaded file"})
+		return nil, false
+	}
+	defer src.Close()
+	if req.content, err = io.ReadAll(src); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read uploaded file"})
+		return nil, false
+	}
+
+	req.findings, req.rowErrs, err = vulnimport.Parse(req.format, bytes.NewReader(req.content))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse scan export: " + err.Error()})
+		return nil, false
+	}
+	req.unique = vulnimport.Dedupe(req.findings)
+	return req, true
+}
+
+// loadVulnerabilitiesByKey indexa as vulnerabilidades da organização pela chave de deduplicação (a mais antiga por chave).
+func loadVulnerabilitiesByKey(db *gorm.DB, organizationID uuid.UUID) (map[string]models.Vulnerability, error) {
+	var existing []models.Vulnerability
+	if err := db.Where("organization_id = ?", organizationID).Order("created_at asc").Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	existingByKey := make(map[string]models.Vulnerability, len(existing))
+	for _, v := range existing {
+		if _, dup := existingByKey[vulnerabilityDedupKey(v)]; !dup {
+			existingByKey[vulnerabilityDedupKey(v)] = v
+		}
+	}
+	return existingByKey, nil
+}
+
+// estimateVulnerabilityImport prevê o efeito da importação: vulnerabilidades criadas, atualizadas e reabertas,
+// riscos criados automaticamente e as notificações de "risco criado" que eles disparam.
+func estimateVulnerabilityImport(c *gin.Context, organizationID uuid.UUID, req *vulnerabilityImportRequest, existingByKey map[string]models.Vulnerability) (preflight.Estimate, error) {
+	var created, updated, reopened, risks int
+	for _, f := range req.unique {
+		vuln, exists := existingByKey[f.DedupKey()]
+		if exists {
+			updated++
+			if vuln.Status == models.VStatusRemediated {
+				reopened++
+			}
+		} else {
+			created++
+		}
+		if req.autoCreate && f.CVSS >= req.threshold && f.CVSS > 0 && vuln.RiskID == nil {
+			risks++
+		}
+	}
+	estimate := preflight.Estimate{
+		Operation:       bulkOperationVulnerabilityImport,
+		AffectedRecords: created + updated + risks,
+		Details: map[string]int{
+			"parsed":        len(req.findings),
+			"failed_rows":   len(req.rowErrs),
+			"created":       created,
+			"updated":       updated,
+			"reopened":      reopened,
+			"risks_created": risks,
+		},
+	}
+	if risks > 0 {
+		deliveries, err := notifications.CountRiskEventDeliveries(c.Request.Context(), organizationID, models.EventTypeRiskCreated)
+		if err != nil {
+			return estimate, err
+		}
+		estimate.Notifications = risks * deliveries
+	}
+	return estimate, nil
+}
+
+// PreflightVulnerabilityScanImportHandler estimates a scanner import (same file and options as
+// ImportVulnerabilityScanHandler) without changing anything, returning a confirm token when required.
+func PreflightVulnerabilityScanImportHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.VulnerabilitiesManage) {
+		return
+	}
+	orgIDValue, _ := c.Get("organizationID")
+	organizationID := orgIDValue.(uuid.UUID)
+	req, ok := parseVulnerabilityImportRequest(c)
+	if !ok {
+		return
+	}
+	db := database.GetDB()
+	existingByKey, err := loadVulnerabilitiesByKey(db, organizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load vulnerabilities: " + err.Error()})
+		return
+	}
+	estimate, err := estimateVulnerabilityImport(c, organizationID, req, existingByKey)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to estimate notifications: " + err.Error()})
+		return
+	}
+	respondBulkPreflight(c, db, estimate, req.fingerprint())
+}
+
+// ImportVulnerabilityScanHandler ingests a scanner export (Nessus CSV, Qualys CSV or SARIF) from the 'file' field.
+// Findings are deduplicated by CVE (or title) + asset, merged into existing vulnerabilities and, when
+// auto_create_risks=true, findings with CVSS >= cvss_threshold get a linked risk (once per vulnerability).
+// Imports above the confirmation threshold require the X-Confirm-Token returned by the preflight endpoint.
+func ImportVulnerabilityScanHandler(c *gin.Context) {
+	if !rbac.Require(c, rbac.VulnerabilitiesManage) {
+		return
+	}
+	orgIDValue, _ := c.Get("organizationID")
+	organizationID := orgIDValue.(uuid.UUID)
+	userIDValue, _ := c.Get("userID")
+	userID, _ := userIDValue.(uuid.UUID)
+
+	req, ok := parseVulnerabilityImportRequest(c)
+	if !ok {
+		return
+	}
+	var failedRows []BulkUploadErrorDetail
+	for _, rowErr := range req.rowErrs {
+		failedRows = append(failedRows, BulkUploadErrorDetail{LineNumber: rowErr.Line, Errors: []string{rowErr.Message}})
+	}
+	autoCreate, threshold, unique := req.autoCreate, req.threshold, req.unique
+	response := ImportVulnerabilityScanResponse{
+		Format:     string(req.format),
+		Parsed:     len(req.findings),
+		Unique:     len(unique),
+		RiskIDs:    []uuid.UUID{},
+		FailedRows: failedRows,
+	}
+	if autoCreate {
+		response.CVSSThreshold = &threshold
+	}
+
+	db := database.GetDB()
+	existingByKey, err := loadVulnerabilitiesByKey(db, organizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load vulnerabilities: " + err.Error()})
+		return
+	}
+	estimate, err := estimateVulnerabilityImport(c, organizationID, req, existingByKey)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to estimate notifications: " + err.Error()})
+		return
+	}
+	confirmed, ok := confirmBulkOperation(c, db, &estimate, req.fingerprint())
+	if !ok {
+		return
+	}
+	matrix, _, err := loadRiskScoringMatrix(db, organizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load risk scoring matrix: " + err.Error()})
+		return
+	}
+	var assets []models.Asset
+	db.Select("id", "name").Where("organization_id = ?", organizationID).Find(&assets)
+	assetsByName := make(map[string]uuid.UUID, len(assets))
+	for _, a := range assets {
+		assetsByName[strings.ToLower(a.Name)] = a.ID
+	}
+
+	now := time.Now()
+	var createdRisks []models.Risk
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, f := range unique {
+			vuln, exists := existingByKey[f.DedupKey()]
+			if !exists {
+				vuln = models.Vulnerability{
+					OrganizationID: organizationID,
+					Title:          f.Title,
+					Description:    f.Description,
+					CVEID:          f.CVEID,
+					Status:         models.VStatusDiscovered,
+					AssetAffected:  f.Asset,
+				}
+			} else if vuln.Status == models.VStatusRemediated {
+				vuln.Status = models.VStatusDiscovered
+				response.Reopened++
+			}
+			vuln.Severity = f.Severity
+			vuln.Source = f.Source
+			vuln.LastSeenAt = &now
+			if f.CVSS > 0 {
+				score := f.CVSS
+				vuln.CVSSScore = &score
+			}
+
+			if autoCreate && f.CVSS >= threshold && f.CVSS > 0 && vuln.RiskID == nil {
+				risk := riskFromFinding(organizationID, userID, f, matrix)
+				if err := tx.Create(&risk).Error; err != nil {
+					return err
+				}
+				if assetID, ok := assetsByName[strings.ToLower(f.Asset)]; ok {
+					if err := tx.Create(&models.AssetRisk{AssetID: assetID, RiskID: risk.ID}).Error; err != nil {
+						return err
+					}
+				}
+				vuln.RiskID = &risk.ID
+				createdRisks = append(createdRisks, risk)
+			}
+
+			if exists {
+				if err := tx.Save(&vuln).Error; err != nil {
+					return err
+				}
+				response.Updated++
+			} else {
+				if err := tx.Create(&vuln).Error; err != nil {
+					return err
+				}
+				response.Created++
+			}
+			existingByKey[f.DedupKey()] = vuln
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error during vulnerability import: " + err.Error()})
+		return
+	}
+
+	for _, risk := range createdRisks {
+		response.RiskIDs = append(response.RiskIDs, risk.ID)
+		notifications.QueueRiskEvent(c.Request.Context(), risk.OrganizationID, risk, models.EventTypeRiskCreated)
+		events.Publish(risk.OrganizationID, events.EventRiskCreated, risk.ID, risk)
+	}
+	response.RisksCreated = len(createdRisks)
+	recordBulkOperation(c, db, bulkOperationVulnerabilityImport, response.Created+response.Updated+response.RisksCreated, estimate.Notifications, confirmed)
+
+	imported := response.Created + response.Updated
+	if len(failedRows) > 0 && imported > 0 {
+		c.JSON(http.StatusMultiStatus, response)
+	} else if len(failedRows) > 0 {
+		c.JSON(http.StatusBadRequest, response)
+	} else {
+		c.JSON(http.StatusOK, response)
+	}
+}
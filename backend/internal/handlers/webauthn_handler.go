@@ -0,0 +1,489 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/webauthn"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Métodos de segundo fator informados ao frontend na resposta "2fa_required" do login.
+const (
+	SecondFactorTOTP       = "totp"
+	SecondFactorBackupCode = "backup_code"
+	SecondFactorWebAuthn   = "webauthn"
+)
+
+const (
+	webAuthnNotConfiguredMessage = "Passkeys are not configured on this server"
+	webAuthnRequiredMessage      = "Your organization requires administrators to sign in with a passkey or security key"
+	invalidPasskeyMessage        = "Invalid passkey"
+	lastRequiredFactorMessage    = "Cannot remove your last second factor while two-factor authentication is required"
+)
+
+// WebAuthnRegistrationPayload carries the browser's navigator.credentials.create() result (PublicKeyCredential.toJSON()).
+type WebAuthnRegistrationPayload struct {
+	Name       string                        `json:"name" binding:"required,max=100"`
+	Credential webauthn.RegistrationResponse `json:"credential"`
+}
+
+// LoginVerifyWebAuthnPayload carries the browser's navigator.credentials.get() result for the 2FA login step.
+type LoginVerifyWebAuthnPayload struct {
+	UserID     string                     `json:"user_id" binding:"required"`
+	Credential webauthn.AssertionResponse `json:"credential"`
+}
+
+// configuredRelyingParty retorna a relying party configurada; responde 503 se não houver domínio ou origem.
+func configuredRelyingParty(c *gin.Context) (webauthn.RelyingParty, bool) {
+	rp := webauthn.FromConfig()
+	if rp.ID == "" || len(rp.Origins) == 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": webAuthnNotConfiguredMessage})
+		return rp, false
+	}
+	return rp, true
+}
+
+// issueWebAuthnChallenge emite um desafio de uso único para a cerimônia e descarta os desafios vencidos do usuário.
+func issueWebAuthnChallenge(db *gorm.DB, userID uuid.UUID, ceremony string) (string, error) {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	if err := db.Where("user_id = ? AND expires_at <= ?", userID, now).Delete(&models.WebAuthnChallenge{}).Error; err != nil {
+		return "", err
+	}
+	record := models.WebAuthnChallenge{UserID: userID, Ceremony: ceremony, Challenge: challenge, ExpiresAt: now.Add(webauthn.ChallengeTTL)}
+	if err := db.Create(&record).Error; err != nil {
+		return "", err
+	}
+	return challenge, nil
+}
+
+// consumeWebAuthnChallenge apaga o desafio se ele foi emitido para o usuário e a cerimônia e ainda vale. Só uma
+// requisição consegue apagá-lo, então a mesma resposta do autenticador não é aceita duas vezes.
+func consumeWebAuthnChallenge(db *gorm.DB, userID uuid.UUID, ceremony, challenge string) (bool, error) {
+	result := db.Where("user_id = ? AND ceremony = ? AND challenge = ? AND expires_at > ?", userID, ceremony, challenge, time.Now()).
+		Delete(&models.WebAuthnChallenge{})
+	return result.RowsAffected == 1, result.Error
+}
+
+func userWebAuthnCredentials(db *gorm.DB, userID uuid.UUID) ([]models.WebAuthnCredential, error) {
+	var credentials []models.WebAuthnCredential
+	err := db.Where("user_id = ?", userID).Order("created_at ASC").Find(&credentials).Error
+	return credentials, err
+}
+
+func webAuthnDescriptors(credentials []models.WebAuthnCredential) []webauthn.CredentialDescriptor {
+	descriptors := make([]webauthn.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		var transports []string
+		if credential.Transports != "" {
+			transports = strings.Split(credential.Transports, ",")
+		}
+		descriptors = append(descriptors, webauthn.NewCredentialDescriptor(credential.CredentialID, transports))
+	}
+	return descriptors
+}
+
+// webAuthnRequiredFor indica se a política de MFA da organização obriga o usuário a confirmar o login com WebAuthn.
+func webAuthnRequiredFor(db *gorm.DB, user *models.User) (bool, error) {
+	if !models.IsAdminRole(user.Role) {
+		return false, nil
+	}
+	policy, err := userMFAPolicy(db, user)
+	return policy.RequiresWebAuthn(user.Role), err
+}
+
+// respondIfWebAuthnRequired recusa TOTP e códigos de backup quando a organização exige WebAuthn do usuário e ele
+// tem passkey. Sem passkey, o login com TOTP segue e termina numa sessão restrita ao cadastro de uma.
+func respondIfWebAuthnRequired(c *gin.Context, db *gorm.DB, user *models.User) bool {
+	required, err := webAuthnRequiredFor(db, user)
+	if err == nil && required {
+		required, err = userHasPasskey(db, user.ID)
+	}
+	if err != nil {
+		phxlog.L.Error("Failed to load MFA policy", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return true
+	}
+	if required {
+		c.JSON(http.StatusForbidden, gin.H{"error": webAuthnRequiredMessage, "methods": []string{SecondFactorWebAuthn}})
+		return true
+	}
+	return false
+}
+
+// respondWithSecondFactorChallenge responde "2fa_required" quando o usuário precisa de segundo fator depois da
+// senha, com os métodos aceitos e, se houver passkeys, as opções de navigator.credentials.get(). O desafio só é
+// emitido aqui, depois da senha conferida, então a asserção fica vinculada a este login. Retorna false se o
+// usuário não tem segundo fator: o login segue e respondWithNewSession aplica a política de MFA.
+func respondWithSecondFactorChallenge(c *gin.Context, db *gorm.DB, user *models.User) bool {
+	log := phxlog.L.Named("LoginHandler")
+	required, err := webAuthnRequiredFor(db, user)
+	if err != nil {
+		log.Error("Failed to load MFA policy", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return true
+	}
+	credentials, err := userWebAuthnCredentials(db, user.ID)
+	if err != nil {
+		log.Error("Failed to load passkeys", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return true
+	}
+	// Exigida a passkey, TOTP só é oferecido a quem ainda não tem uma, e leva a uma sessão restrita ao cadastro dela
+	passkeyOnly := required && len(credentials) > 0
+
+	var methods []string
+	if user.IsTOTPEnabled && !passkeyOnly {
+		methods = append(methods, SecondFactorTOTP)
+		if user.TOTPBackupCodes != "" {
+			methods = append(methods, SecondFactorBackupCode)
+		}
+	}
+	if len(credentials) > 0 {
+		methods = append(methods, SecondFactorWebAuthn)
+	}
+	if len(methods) == 0 {
+		return false
+	}
+
+	response := gin.H{
+		"2fa_required": true,
+		"user_id":      user.ID.String(),
+		"methods":      methods,
+		"message":      "Password verified. Please provide TOTP token.",
+	}
+	if required && len(credentials) == 0 {
+		response["mfa_enrollment_required"] = true
+	}
+	if len(credentials) > 0 {
+		rp, ok := configuredRelyingParty(c)
+		if !ok {
+			return true
+		}
+		challenge, err := issueWebAuthnChallenge(db, user.ID, models.WebAuthnCeremonyLogin)
+		if err != nil {
+			log.Error("Failed to issue passkey challenge", zap.String("userID", user.ID.String()), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+			return true
+		}
+		response["webauthn"] = rp.RequestOptions(challenge, webAuthnDescriptors(credentials))
+		if !user.IsTOTPEnabled || passkeyOnly {
+			response["message"] = "Password verified. Please confirm with your passkey."
+		}
+	}
+	c.JSON(http.StatusOK, response)
+	return true
+}
+
+// BeginWebAuthnRegistrationHandler returns the options for navigator.credentials.create() to register a passkey.
+func BeginWebAuthnRegistrationHandler(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
+		return
+	}
+	rp, ok := configuredRelyingParty(c)
+	if !ok {
+		return
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.First(&user, "id = ?", userID.(uuid.UUID)).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	credentials, err := userWebAuthnCredentials(db, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch passkeys: " + err.Error()})
+		return
+	}
+	challenge, err := issueWebAuthnChallenge(db, user.ID, models.WebAuthnCeremonyRegistration)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start passkey registration: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rp.CreationOptions(challenge,
+		webauthn.User{ID: user.ID[:], Name: user.Email, DisplayName: user.Name},
+		webAuthnDescriptors(credentials)))
+}
+
+// FinishWebAuthnRegistrationHandler verifies the authenticator's response and stores the new passkey.
+func FinishWebAuthnRegistrationHandler(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
+		return
+	}
+	var payload WebAuthnRegistrationPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	rp, ok := configuredRelyingParty(c)
+	if !ok {
+		return
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.First(&user, "id = ?", userID.(uuid.UUID)).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	challenge, err := payload.Credential.Challenge()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Passkey registration could not be verified: " + err.Error()})
+		return
+	}
+	if consumed, err := consumeWebAuthnChallenge(db, user.ID, models.WebAuthnCeremonyRegistration, challenge); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify passkey registration: " + err.Error()})
+		return
+	} else if !consumed {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Passkey challenge is invalid or has expired"})
+		return
+	}
+	verified, err := rp.VerifyRegistration(payload.Credential, challenge)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Passkey registration could not be verified: " + err.Error()})
+		return
+	}
+
+	credential := models.WebAuthnCredential{
+		UserID:         user.ID,
+		Name:           strings.TrimSpace(payload.Name),
+		CredentialID:   verified.ID,
+		PublicKey:      verified.PublicKey,
+		Algorithm:      verified.Algorithm,
+		SignCount:      int64(verified.SignCount),
+		Transports:     strings.Join(verified.Transports, ","),
+		BackupEligible: verified.BackupEligible,
+	}
+	if verified.AAGUID != uuid.Nil {
+		credential.AAGUID = verified.AAGUID.String()
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.WebAuthnCredential{}).Where("credential_id = ?", credential.CredentialID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return errPasskeyAlreadyRegistered
+		}
+		if err := tx.Create(&credential).Error; err != nil {
+			return err
+		}
+		if !user.OrganizationID.Valid {
+			return nil
+		}
+		_, err := recordAuditTrail(c, tx, user.OrganizationID.UUID, models.AuditTrailWebAuthnRegistered, "webauthn_credential", &credential.ID,
+			fmt.Sprintf("Passkey \"%s\" registrada por %s", credential.Name, user.Email),
+			gin.H{"user_id": user.ID, "user_email": user.Email, "name": credential.Name, "aaguid": credential.AAGUID, "backup_eligible": credential.BackupEligible})
+		return err
+	})
+	if errors.Is(err, errPasskeyAlreadyRegistered) {
+		c.JSON(http.StatusConflict, gin.H{"error": "This passkey is already registered"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save passkey: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, credential)
+}
+
+var errPasskeyAlreadyRegistered = errors.New("passkey already registered")
+
+// ListWebAuthnCredentialsHandler lists the authenticated user's passkeys.
+func ListWebAuthnCredentialsHandler(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
+		return
+	}
+	credentials, err := userWebAuthnCredentials(database.GetDB(), userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch passkeys: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, credentials)
+}
+
+// DeleteWebAuthnCredentialHandler removes one of the authenticated user's passkeys. The last passkey of an
+// administrator cannot be removed while the organization requires WebAuthn for administrators.
+func DeleteWebAuthnCredentialHandler(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
+		return
+	}
+	credentialID, err := uuid.Parse(c.Param("credentialId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid passkey ID format"})
+		return
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.First(&user, "id = ?", userID.(uuid.UUID)).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	var credential models.WebAuthnCredential
+	if err := db.Where("id = ? AND user_id = ?", credentialID, user.ID).First(&credential).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Passkey not found in your account"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch passkey: " + err.Error()})
+		return
+	}
+	policy, err := userMFAPolicy(db, &user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load MFA policy: " + err.Error()})
+		return
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// A última passkey só sai se a política não depender dela: exigência de passkey ou de MFA sem TOTP ativo
+		if policy.RequiresWebAuthn(user.Role) || (policy.RequiresMFA(user.Role) && !user.IsTOTPEnabled) {
+			var remaining int64
+			if err := tx.Model(&models.WebAuthnCredential{}).Where("user_id = ? AND id <> ?", user.ID, credential.ID).Count(&remaining).Error; err != nil {
+				return err
+			}
+			if remaining == 0 {
+				return errLastRequiredPasskey
+			}
+		}
+		if err := tx.Delete(&credential).Error; err != nil {
+			return err
+		}
+		if !user.OrganizationID.Valid {
+			return nil
+		}
+		_, err := recordAuditTrail(c, tx, user.OrganizationID.UUID, models.AuditTrailWebAuthnRemoved, "webauthn_credential", &credential.ID,
+			fmt.Sprintf("Passkey \"%s\" removida por %s", credential.Name, user.Email),
+			gin.H{"user_id": user.ID, "user_email": user.Email, "name": credential.Name})
+		return err
+	})
+	if errors.Is(err, errLastRequiredPasskey) {
+		if policy.RequiresWebAuthn(user.Role) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot remove your last passkey while passkeys are required for administrators"})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": lastRequiredFactorMessage})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove passkey: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Passkey removed"})
+}
+
+var errLastRequiredPasskey = errors.New("last required passkey")
+
+// LoginVerifyWebAuthnHandler completes the 2FA login step with a passkey, using the challenge issued by LoginHandler.
+func LoginVerifyWebAuthnHandler(c *gin.Context) {
+	log := phxlog.L.Named("LoginVerifyWebAuthnHandler")
+	var payload LoginVerifyWebAuthnPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	userUUID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UserID format"})
+		return
+	}
+	rp, ok := configuredRelyingParty(c)
+	if !ok {
+		return
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.First(&user, "id = ?", userUUID).Error; err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found or invalid state"})
+		return
+	}
+	if !user.IsActive {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User account is inactive"})
+		return
+	}
+	if respondIfLoginLocked(c, &user) {
+		return
+	}
+
+	credentialID, err := payload.Credential.CredentialID()
+	if err != nil {
+		handleLoginFailure(c, db, &user, invalidPasskeyMessage)
+		return
+	}
+	var credential models.WebAuthnCredential
+	if err := db.Where("user_id = ? AND credential_id = ?", user.ID, credentialID).First(&credential).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			handleLoginFailure(c, db, &user, invalidPasskeyMessage)
+			return
+		}
+		log.Error("Failed to fetch passkey", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return
+	}
+
+	// O desafio foi emitido pelo login com senha; sem ele, é preciso informar a senha de novo
+	challenge, err := payload.Credential.Challenge()
+	if err != nil {
+		handleLoginFailure(c, db, &user, invalidPasskeyMessage)
+		return
+	}
+	if consumed, err := consumeWebAuthnChallenge(db, user.ID, models.WebAuthnCeremonyLogin, challenge); err != nil {
+		log.Error("Failed to consume passkey challenge", zap.String("userID", user.ID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return
+	} else if !consumed {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Passkey challenge is invalid or has expired; sign in again"})
+		return
+	}
+
+	assertion, err := rp.VerifyAssertion(payload.Credential, challenge, credential.PublicKey, uint32(credential.SignCount))
+	if err != nil {
+		log.Warn("Passkey assertion rejected", zap.String("userID", user.ID.String()), zap.String("credentialID", credential.ID.String()), zap.Error(err))
+		handleLoginFailure(c, db, &user, invalidPasskeyMessage)
+		return
+	}
+	// O contador só avança a partir do valor lido: uma asserção simultânea com o mesmo contador perde
+	now := time.Now()
+	result := db.Model(&models.WebAuthnCredential{}).Where("id = ? AND sign_count = ?", credential.ID, credential.SignCount).
+		Updates(map[string]interface{}{"sign_count": int64(assertion.SignCount), "last_used_at": now})
+	if result.Error != nil {
+		log.Error("Failed to update passkey", zap.String("credentialID", credential.ID.String()), zap.Error(result.Error))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process login"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		handleLoginFailure(c, db, &user, invalidPasskeyMessage)
+		return
+	}
+
+	if !user.OrganizationID.Valid {
+		log.Error("User without a valid OrganizationID attempted to log in with a passkey", zap.String("userID", user.ID.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User is not associated with an organization"})
+		return
+	}
+	loginSucceeded(db, &user)
+	respondWithNewSession(c, db, &user)
+}
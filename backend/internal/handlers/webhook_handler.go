@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
 	phxlog "phoenixgrc/backend/pkg/log"
 	"strings" // Para manipular EventTypes
 	"time"
@@ -95,7 +96,7 @@ func sendWebhook(webhook models.WebhookConfiguration, payload []byte) {
 type WebhookPayload struct {
 	Name       string   `json:"name" binding:"required,min=3,max=100"`
 	URL        string   `json:"url" binding:"required,url,max=2048"`
-	EventTypes []string `json:"event_types" binding:"required,dive,oneof=risk_created risk_status_changed"` // `dive` valida cada item do slice
+	EventTypes []string `json:"event_types" binding:"required,dive,oneof=risk_created risk_status_changed risk_owner_changed"` // `dive` valida cada item do slice
 	IsActive   *bool    `json:"is_active"`    // Pointer to distinguish false from not provided
 }
 
@@ -134,13 +135,7 @@ func CreateWebhookHandler(c *gin.Context) {
 		return
 	}
 
-	// checkOrgAdmin é definido em idp_handler.go, idealmente seria movido para um pacote helper de auth/permissions
-	// Por enquanto, vamos assumir que uma função similar está disponível ou reimplementar uma verificação básica.
-	tokenOrgID, orgOk := c.Get("organizationID")
-	tokenUserRole, roleOk := c.Get("userRole")
-	if !orgOk || !roleOk || tokenOrgID.(uuid.UUID) != targetOrgID ||
-		(tokenUserRole.(models.UserRole) != models.RoleAdmin && tokenUserRole.(models.UserRole) != models.RoleManager) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied or insufficient privileges"})
+	if !checkOrgPermission(c, targetOrgID, rbac.WebhooksManage) {
 		return
 	}
 
@@ -169,6 +164,7 @@ func CreateWebhookHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook configuration: " + err.Error()})
 		return
 	}
+	recordConfigChange(c, db, targetOrgID, models.ConfigEntityWebhook, webhookConfig.ID, models.ConfigChangeCreate, nil)
 
 	c.JSON(http.StatusCreated, newWebhookResponseItem(webhookConfig))
 }
@@ -284,11 +280,7 @@ func UpdateWebhookHandler(c *gin.Context) {
 		return
 	}
 
-    tokenOrgID, orgOk := c.Get("organizationID")
-	tokenUserRole, roleOk := c.Get("userRole")
-	if !orgOk || !roleOk || tokenOrgID.(uuid.UUID) != targetOrgID ||
-		(tokenUserRole.(models.UserRole) != models.RoleAdmin && tokenUserRole.(models.UserRole) != models.RoleManager) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied or insufficient privileges"})
+	if !checkOrgPermission(c, targetOrgID, rbac.WebhooksManage) {
 		return
 	}
 
@@ -309,6 +301,7 @@ func UpdateWebhookHandler(c *gin.Context) {
 		return
 	}
 
+	before := snapshotConfigEntity(db, models.ConfigEntityWebhook, targetOrgID, webhook.ID)
 	webhook.Name = payload.Name
 	webhook.URL = payload.URL
 	webhook.EventTypes = eventTypesToString(payload.EventTypes)
@@ -320,6 +313,7 @@ func UpdateWebhookHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook configuration: " + err.Error()})
 		return
 	}
+	recordConfigChange(c, db, targetOrgID, models.ConfigEntityWebhook, webhook.ID, models.ConfigChangeUpdate, before)
 	c.JSON(http.StatusOK, newWebhookResponseItem(webhook))
 }
 
@@ -338,11 +332,7 @@ func DeleteWebhookHandler(c *gin.Context) {
 		return
 	}
 
-    tokenOrgID, orgOk := c.Get("organizationID")
-	tokenUserRole, roleOk := c.Get("userRole")
-	if !orgOk || !roleOk || tokenOrgID.(uuid.UUID) != targetOrgID ||
-		(tokenUserRole.(models.UserRole) != models.RoleAdmin && tokenUserRole.(models.UserRole) != models.RoleManager) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied or insufficient privileges"})
+	if !checkOrgPermission(c, targetOrgID, rbac.WebhooksManage) {
 		return
 	}
 
@@ -358,10 +348,12 @@ func DeleteWebhookHandler(c *gin.Context) {
 		return
     }
 
+	before := snapshotConfigEntity(db, models.ConfigEntityWebhook, targetOrgID, webhookID)
 	if err := db.Delete(&models.WebhookConfiguration{}, "id = ? AND organization_id = ?", webhookID, targetOrgID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook configuration: " + err.Error()})
 		return
 	}
+	recordConfigChange(c, db, targetOrgID, models.ConfigEntityWebhook, webhookID, models.ConfigChangeDelete, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Webhook configuration deleted successfully"})
 }
 
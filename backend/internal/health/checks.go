@@ -0,0 +1,142 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/notifications"
+)
+
+// DefaultCheckTimeout limita cada verificação do /readyz, para a sonda não ficar presa num serviço lento.
+const DefaultCheckTimeout = 3 * time.Second
+
+// Situação geral retornada pelo /readyz.
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+)
+
+// ErrEmailNotConfigured indica que o envio de e-mails não está configurado.
+var ErrEmailNotConfigured = errors.New("email delivery is not configured (AWS_REGION and AWS_SES_EMAIL_SENDER)")
+
+// Check é uma verificação executada a cada chamada do /readyz.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+// Report é a situação da instância e de cada componente.
+type Report struct {
+	Status     string   `json:"status"` // ok, degraded ou unavailable
+	Components []Status `json:"components"`
+}
+
+// HTTPStatus retorna 503 quando algum componente crítico está indisponível e 200 nos demais casos.
+func (r Report) HTTPStatus() int {
+	if r.Status == StatusUnavailable {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// ServiceChecks são as verificações dos serviços externos: banco de dados e migrações (críticos),
+// armazenamento de arquivos e e-mail (opcionais).
+func ServiceChecks() []Check {
+	return []Check{
+		{Name: "database", Critical: true, Run: func(ctx context.Context) error {
+			if database.GetDB() == nil {
+				return errors.New("database is not connected")
+			}
+			sqlDB, err := database.GetDB().DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		{Name: "migrations", Critical: true, Run: func(ctx context.Context) error {
+			if database.GetDB() == nil {
+				return errors.New("database is not connected")
+			}
+			_, err := database.CheckMigrations(ctx, database.GetDB())
+			return err
+		}},
+		{Name: "file_storage", Run: filestorage.Ping},
+		{Name: "email", Run: func(ctx context.Context) error {
+			if !notifications.EmailConfigured() {
+				return ErrEmailNotConfigured
+			}
+			return nil
+		}},
+	}
+}
+
+// Evaluate executa as verificações em paralelo, cada uma limitada a timeout, e as combina com os subsistemas
+// registrados. Um subsistema registrado como indisponível (ainda em nova tentativa de inicialização) prevalece
+// sobre a verificação de mesmo nome, pois traz as tentativas e a próxima tentativa.
+func (r *Registry) Evaluate(ctx context.Context, checks []Check, timeout time.Duration) Report {
+	registered := r.Snapshot()
+	byName := make(map[string]Status, len(registered))
+	for _, status := range registered {
+		byName[status.Name] = status
+	}
+
+	results := make([]*Status, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		base, ok := byName[check.Name]
+		if ok && !base.Healthy {
+			continue
+		}
+		if !ok {
+			base = Status{Name: check.Name, Healthy: true}
+		}
+		base.Critical = check.Critical
+		wg.Add(1)
+		go func(i int, status Status, run func(context.Context) error) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if err := run(checkCtx); err != nil {
+				status.Healthy, status.Error, status.Since = false, err.Error(), time.Now()
+			}
+			results[i] = &status
+		}(i, base, check.Run)
+	}
+	wg.Wait()
+
+	checked := make(map[string]bool)
+	components := make([]Status, 0, len(checks)+len(registered))
+	for _, status := range results {
+		if status != nil {
+			checked[status.Name] = true
+			components = append(components, *status)
+		}
+	}
+	for _, status := range registered {
+		if !checked[status.Name] {
+			components = append(components, status)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool { return components[i].Name < components[j].Name })
+
+	report := Report{Status: StatusOK, Components: components}
+	for _, status := range components {
+		switch {
+		case status.Healthy:
+		case status.Critical:
+			report.Status = StatusUnavailable
+		case report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
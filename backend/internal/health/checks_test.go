@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func okCheck(context.Context) error { return nil }
+
+func TestEvaluateReportsEachComponent(t *testing.T) {
+	r := NewRegistry()
+	r.SetHealthy("jwt", true)
+	report := r.Evaluate(context.Background(), []Check{
+		{Name: "database", Critical: true, Run: okCheck},
+		{Name: "email", Run: func(context.Context) error { return ErrEmailNotConfigured }},
+	}, time.Second)
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, http.StatusOK, report.HTTPStatus())
+	require.Len(t, report.Components, 3)
+	names := []string{report.Components[0].Name, report.Components[1].Name, report.Components[2].Name}
+	assert.Equal(t, []string{"database", "email", "jwt"}, names)
+	assert.False(t, report.Components[1].Healthy)
+	assert.Equal(t, ErrEmailNotConfigured.Error(), report.Components[1].Error)
+}
+
+func TestEvaluateCriticalFailureIsUnavailable(t *testing.T) {
+	report := NewRegistry().Evaluate(context.Background(), []Check{
+		{Name: "database", Critical: true, Run: func(context.Context) error { return errors.New("connection refused") }},
+		{Name: "email", Run: okCheck},
+	}, time.Second)
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, report.HTTPStatus())
+}
+
+func TestEvaluateTimesOutSlowChecks(t *testing.T) {
+	report := NewRegistry().Evaluate(context.Background(), []Check{
+		{Name: "file_storage", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	}, 10*time.Millisecond)
+	require.Len(t, report.Components, 1)
+	assert.Contains(t, report.Components[0].Error, "deadline exceeded")
+}
+
+func TestEvaluateKeepsRetryingSubsystem(t *testing.T) {
+	r := NewRegistry()
+	r.SetUnhealthy("file_storage", false, errors.New("bucket not found"))
+	called := false
+	report := r.Evaluate(context.Background(), []Check{
+		{Name: "file_storage", Run: func(context.Context) error { called = true; return nil }},
+	}, time.Second)
+	assert.False(t, called, "o subsistema ainda em nova tentativa não é verificado de novo")
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, "bucket not found", report.Components[0].Error)
+	assert.Equal(t, 1, report.Components[0].Attempts)
+}
@@ -0,0 +1,158 @@
+// Package health acompanha a situação dos subsistemas do servidor para o /readyz. Subsistemas críticos
+// (banco de dados, JWT) impedem a inicialização; os opcionais (SAML, OAuth2, armazenamento de arquivos,
+// antivírus) são marcados como indisponíveis quando falham e reinicializados em segundo plano, sem derrubar
+// a API.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// Intervalos padrão das novas tentativas de inicialização (backoff exponencial).
+const (
+	DefaultRetryInitial = 5 * time.Second
+	DefaultRetryMax     = 5 * time.Minute
+)
+
+// Status é a situação de um subsistema.
+type Status struct {
+	Name        string     `json:"name"`
+	Critical    bool       `json:"critical"`
+	Healthy     bool       `json:"healthy"`
+	Error       string     `json:"error,omitempty"`
+	Attempts    int        `json:"attempts"`
+	LastAttempt *time.Time `json:"last_attempt,omitempty"`
+	NextRetry   *time.Time `json:"next_retry,omitempty"`
+	Since       time.Time  `json:"since"` // Início da situação atual
+}
+
+// Registry guarda a situação dos subsistemas.
+type Registry struct {
+	mu         sync.RWMutex
+	components map[string]*Status
+}
+
+// NewRegistry cria um registro vazio.
+func NewRegistry() *Registry {
+	return &Registry{components: make(map[string]*Status)}
+}
+
+// Default é o registro usado pelo servidor.
+var Default = NewRegistry()
+
+// report registra o resultado de uma tentativa de inicialização.
+func (r *Registry) report(name string, critical bool, err error, nextRetry *time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	status, ok := r.components[name]
+	if !ok {
+		status = &Status{Name: name, Since: now}
+		r.components[name] = status
+	}
+	healthy := err == nil
+	if healthy != status.Healthy {
+		status.Since = now
+	}
+	status.Critical = critical
+	status.Healthy = healthy
+	status.Attempts++
+	status.LastAttempt = &now
+	status.NextRetry = nextRetry
+	status.Error = ""
+	if err != nil {
+		status.Error = err.Error()
+	}
+}
+
+// SetHealthy marca o subsistema como disponível.
+func (r *Registry) SetHealthy(name string, critical bool) {
+	r.report(name, critical, nil, nil)
+}
+
+// SetUnhealthy marca o subsistema como indisponível.
+func (r *Registry) SetUnhealthy(name string, critical bool, err error) {
+	r.report(name, critical, err, nil)
+}
+
+// Snapshot retorna a situação dos subsistemas, ordenados pelo nome.
+func (r *Registry) Snapshot() []Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]Status, 0, len(r.components))
+	for _, status := range r.components {
+		result = append(result, *status)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Ready indica se todos os subsistemas críticos estão disponíveis; degraded indica que algum opcional não está.
+func (r *Registry) Ready() (ready, degraded bool) {
+	ready = true
+	for _, status := range r.Snapshot() {
+		if status.Healthy {
+			continue
+		}
+		if status.Critical {
+			ready = false
+		} else {
+			degraded = true
+		}
+	}
+	return ready, degraded
+}
+
+// InitOptional executa a inicialização de um subsistema opcional. Em caso de falha, registra o subsistema
+// como indisponível e tenta novamente em segundo plano, com intervalos dobrando de initial até max, até
+// conseguir ou até o contexto ser cancelado. Retorna o erro da primeira tentativa.
+func (r *Registry) InitOptional(ctx context.Context, name string, init func() error, initial, max time.Duration) error {
+	log := phxlog.L.Named("Health")
+	err := init()
+	if err == nil {
+		r.SetHealthy(name, false)
+		return nil
+	}
+	next := time.Now().Add(initial)
+	r.report(name, false, err, &next)
+	log.Warn("Subsistema opcional indisponível; a API inicia sem ele e a inicialização será repetida em segundo plano",
+		zap.String("component", name), zap.Duration("retry_in", initial), zap.Error(err))
+
+	go func() {
+		delay := initial
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			retryErr := init()
+			if retryErr == nil {
+				r.SetHealthy(name, false)
+				log.Info("Subsistema opcional inicializado após nova tentativa", zap.String("component", name))
+				return
+			}
+			delay *= 2
+			if delay > max {
+				delay = max
+			}
+			next := time.Now().Add(delay)
+			r.report(name, false, retryErr, &next)
+			log.Warn("Nova tentativa de inicialização falhou",
+				zap.String("component", name), zap.Duration("retry_in", delay), zap.Error(retryErr))
+		}
+	}()
+	return err
+}
+
+// InitOptional inicializa um subsistema opcional no registro padrão com os intervalos padrão.
+func InitOptional(ctx context.Context, name string, init func() error) error {
+	return Default.InitOptional(ctx, name, init, DefaultRetryInitial, DefaultRetryMax)
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func init() {
+	phxlog.L = zap.NewNop()
+}
+
+func TestInitOptionalSucceedsImmediately(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.InitOptional(context.Background(), "saml", func() error { return nil }, time.Millisecond, time.Millisecond))
+	ready, degraded := r.Ready()
+	assert.True(t, ready)
+	assert.False(t, degraded)
+	snapshot := r.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.True(t, snapshot[0].Healthy)
+	assert.Equal(t, 1, snapshot[0].Attempts)
+}
+
+func TestInitOptionalRetriesInBackground(t *testing.T) {
+	r := NewRegistry()
+	var calls int32
+	init := func() error {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return errors.New("certificate expired")
+		}
+		return nil
+	}
+	err := r.InitOptional(context.Background(), "saml", init, time.Millisecond, 2*time.Millisecond)
+	require.Error(t, err)
+
+	ready, degraded := r.Ready()
+	assert.True(t, ready, "subsistema opcional não impede a prontidão")
+	assert.True(t, degraded)
+
+	require.Eventually(t, func() bool {
+		s := r.Snapshot()
+		return len(s) == 1 && s[0].Healthy
+	}, time.Second, time.Millisecond)
+	s := r.Snapshot()[0]
+	assert.Equal(t, 3, s.Attempts)
+	assert.Empty(t, s.Error)
+	assert.Nil(t, s.NextRetry)
+	_, degraded = r.Ready()
+	assert.False(t, degraded)
+}
+
+func TestInitOptionalStopsOnContextCancel(t *testing.T) {
+	r := NewRegistry()
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	_ = r.InitOptional(ctx, "filestorage", func() error {
+		atomic.AddInt32(&calls, 1)
+		return errors.New("bucket not found")
+	}, 5*time.Millisecond, 5*time.Millisecond)
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	assert.LessOrEqual(t, atomic.LoadInt32(&calls), int32(2))
+	assert.False(t, r.Snapshot()[0].Healthy)
+}
+
+func TestCriticalComponentBlocksReadiness(t *testing.T) {
+	r := NewRegistry()
+	r.SetHealthy("database", true)
+	r.SetUnhealthy("jwt", true, errors.New("invalid key"))
+	ready, _ := r.Ready()
+	assert.False(t, ready)
+	r.SetHealthy("jwt", true)
+	ready, _ = r.Ready()
+	assert.True(t, ready)
+}
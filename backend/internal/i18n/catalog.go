@@ -0,0 +1,860 @@
+package i18n
+
+// translation traz as versões de uma mensagem da API. Campos vazios mantêm a mensagem original,
+// que na maior parte da API já está em inglês.
+type translation struct {
+	en string
+	pt string
+	es string
+}
+
+// catalog traduz as mensagens de erro retornadas pelos handlers, indexadas pelo texto original.
+// Chaves terminadas em ": " são prefixos seguidos de um detalhe (que também é traduzido quando
+// conhecido); chaves com %s/%d são mensagens formatadas com fmt.Sprintf.
+var catalog = map[string]translation{
+	"'from' must be before 'to'":                                                         {pt: "'from' deve ser anterior a 'to'", es: "'from' debe ser anterior a 'to'"},
+	"2FA / Backup codes not enabled or not generated for this user.":                     {pt: "2FA / códigos de backup não habilitados ou não gerados para este usuário.", es: "2FA / códigos de respaldo no habilitados o no generados para este usuario."},
+	"A business process with this name already exists in your organization":              {pt: "Já existe um processo de negócio com este nome na sua organização", es: "Ya existe un proceso de negocio con este nombre en su organización"},
+	"A control with this control_id already exists in the framework":                     {pt: "Já existe um controle com este control_id no framework", es: "Ya existe un control con este control_id en el framework"},
+	"A custom role with this name already exists":                                        {pt: "Já existe um papel personalizado com este nome", es: "Ya existe un rol personalizado con este nombre"},
+	"A framework with this name already exists":                                          {pt: "Já existe um framework com este nome", es: "Ya existe un framework con este nombre"},
+	"A reason is required to anonymize a user":                                           {pt: "É necessário informar o motivo para anonimizar um usuário", es: "Se requiere un motivo para anonimizar a un usuario"},
+	"A request with this Idempotency-Key is still being processed":                       {pt: "Uma requisição com esta Idempotency-Key ainda está em processamento", es: "Una solicitud con esta Idempotency-Key todavía se está procesando"},
+	"A risk category with this key already exists":                                       {pt: "Já existe uma categoria de risco com esta key", es: "Ya existe una categoría de riesgo con esta key"},
+	"A secret with this name already exists in your organization":                        {pt: "Já existe um segredo com este nome na sua organização", es: "Ya existe un secreto con este nombre en su organización"},
+	"A senha do administrador não atende à política de senhas":                           {en: "The administrator password does not meet the password policy", es: "La contraseña del administrador no cumple la política de contraseñas"},
+	"A status with this code already exists":                                             {pt: "Já existe um status com este código", es: "Ya existe un estado con este código"},
+	"A tag with this name already exists":                                                {pt: "Já existe uma tag com este nome", es: "Ya existe una etiqueta con este nombre"},
+	"A team with this name already exists":                                               {pt: "Já existe uma equipe com este nome", es: "Ya existe un equipo con este nombre"},
+	"A user with this email already exists":                                              {pt: "Já existe um usuário com este e-mail", es: "Ya existe un usuario con este correo electrónico"},
+	"Accepted evidence drafts cannot be deleted; they are the evidence of an assessment": {pt: "Rascunhos de evidência aceitos não podem ser excluídos; eles são a evidência de uma avaliação", es: "Los borradores de evidencia aceptados no se pueden eliminar; son la evidencia de una evaluación"},
+	"Accepted evidence requests cannot be changed":                                       {pt: "Solicitações de evidência aceitas não podem ser alteradas", es: "Las solicitudes de evidencia aceptadas no se pueden modificar"},
+	"Access denied": {pt: "Acesso negado", es: "Acceso denegado"},
+	"Access denied to the specified organization":                                       {pt: "Acesso negado à organização informada", es: "Acceso denegado a la organización indicada"},
+	"Access denied to the specified organization's assessments":                         {pt: "Acesso negado às avaliações da organização informada", es: "Acceso denegado a las evaluaciones de la organización indicada"},
+	"Access denied to the specified organization's compliance history":                  {pt: "Acesso negado ao histórico de conformidade da organização informada", es: "Acceso denegado al historial de cumplimiento de la organización indicada"},
+	"Access denied to the specified organization's compliance score":                    {pt: "Acesso negado ao score de conformidade da organização informada", es: "Acceso denegado a la puntuación de cumplimiento de la organización indicada"},
+	"Access denied to the specified organization's C2M2 practice summary":               {pt: "Acesso negado ao sumário de práticas C2M2 da organização informada", es: "Acceso denegado al resumen de prácticas C2M2 de la organización indicada"},
+	"Access denied to the specified organization's maturity summary":                    {pt: "Acesso negado ao sumário de maturidade da organização informada", es: "Acceso denegado al resumen de madurez de la organización indicada"},
+	"Access denied to the specified organization's risks":                               {pt: "Acesso negado aos riscos da organização especificada", es: "Acceso denegado a los riesgos de la organización especificada"},
+	"Access denied to this organization's identity providers":                           {pt: "Acesso negado aos provedores de identidade desta organização", es: "Acceso denegado a los proveedores de identidad de esta organización"},
+	"Access denied to this organization's webhooks":                                     {pt: "Acesso negado aos webhooks desta organização", es: "Acceso denegado a los webhooks de esta organización"},
+	"Access denied: Missing authentication token information":                           {pt: "Acesso negado: informações do token de autenticação ausentes", es: "Acceso denegado: falta información del token de autenticación"},
+	"Access review campaign is already closed":                                          {pt: "A campanha de revisão de acessos já está encerrada", es: "La campaña de revisión de accesos ya está cerrada"},
+	"Access review campaign is closed or past its due date":                             {pt: "A campanha de revisão de acessos está encerrada ou com o prazo vencido", es: "La campaña de revisión de accesos está cerrada o vencida"},
+	"Access review campaign not found or not part of your organization":                 {pt: "Campanha de revisão de acessos não encontrada ou não pertence à sua organização", es: "Campaña de revisión de accesos no encontrada o no pertenece a su organización"},
+	"Access review item not found in this campaign":                                     {pt: "Item de revisão de acessos não encontrado nesta campanha", es: "Elemento de revisión de accesos no encontrado en esta campaña"},
+	"Account is temporarily locked due to too many failed login attempts":               {pt: "A conta está temporariamente bloqueada devido a muitas tentativas de login sem sucesso", es: "La cuenta está bloqueada temporalmente por demasiados intentos fallidos de inicio de sesión"},
+	"Acesso negado: Informações do token ausentes":                                      {en: "Access denied: Missing token information", es: "Acceso denegado: falta información del token"},
+	"Acesso negado: Privilégios insuficientes (requer Admin ou Manager da organização)": {en: "Access denied: Insufficient privileges (requires organization Admin or Manager)", es: "Acceso denegado: privilegios insuficientes (requiere Admin o Manager de la organización)"},
+	"Acesso negado: Você não pertence a esta organização":                               {en: "Access denied: You do not belong to this organization", es: "Acceso denegado: usted no pertenece a esta organización"},
+	"Acesso negado: Você não pode visualizar o branding desta organização.":             {en: "Access denied: You cannot view this organization's branding.", es: "Acceso denegado: no puede ver el branding de esta organización."},
+	"Acknowledgment campaign not found or not part of your organization":                {pt: "Campanha de aceite não encontrada ou não pertence à sua organização", es: "Campaña de aceptación no encontrada o no pertenece a su organización"},
+	"Acknowledgment campaigns require an approved policy version":                       {pt: "Campanhas de aceite exigem uma versão aprovada da política", es: "Las campañas de aceptación requieren una versión aprobada de la política"},
+	"Admin action request not found":                                                    {pt: "Solicitação de ação administrativa não encontrada", es: "Solicitud de acción administrativa no encontrada"},
+	"An approval request for this action is already pending":                            {pt: "Já existe uma solicitação de aprovação pendente para esta ação", es: "Ya existe una solicitud de aprobación pendiente para esta acción"},
+	"An approval workflow for this risk is already pending":                             {pt: "Já existe um fluxo de aprovação pendente para este risco", es: "Ya hay un flujo de aprobación pendiente para este riesgo"},
+	"An asset with this name already exists in your organization":                       {pt: "Já existe um ativo com este nome na sua organização", es: "Ya existe un activo con este nombre en su organización"},
+	"An audit trail export is already in progress for this organization":                {pt: "Já há uma exportação da trilha de auditoria em andamento para esta organização", es: "Ya hay una exportación de la pista de auditoría en curso para esta organización"},
+	"an organization cannot be placed under itself or one of its descendants":           {pt: "uma organização não pode ficar abaixo de si mesma ou de uma descendente", es: "una organización no puede quedar debajo de sí misma o de una descendiente"},
+	"An organization export is already in progress":                                     {pt: "Já existe uma exportação da organização em andamento", es: "Ya hay una exportación de la organización en curso"},
+	"Another version of this policy is already pending approval":                        {pt: "Outra versão desta política já está aguardando aprovação", es: "Otra versión de esta política ya está pendiente de aprobación"},
+	"API key not found":                                                                 {pt: "Chave de API não encontrada", es: "Clave de API no encontrada"},
+	"API key scopes do not allow this request":                                          {pt: "Os escopos da chave de API não permitem esta requisição", es: "Los alcances de la clave de API no permiten esta solicitud"},
+	"Approval workflow not found for this waiver":                                       {pt: "Workflow de aprovação não encontrado para esta exceção", es: "Flujo de aprobación no encontrado para esta excepción"},
+	"Approval workflow not found...":                                                    {pt: "Fluxo de aprovação não encontrado...", es: "Flujo de aprobación no encontrado..."},
+	"Approver must be an active admin or manager of your organization":                  {pt: "O aprovador deve ser um admin ou manager ativo da sua organização", es: "El aprobador debe ser un admin o manager activo de su organización"},
+	"Arquivo de logo excede o limite de %dMB":                                           {en: "Logo file exceeds the %dMB limit", es: "El archivo de logotipo supera el límite de %dMB"},
+	"Arquivo de logo rejeitado: malware detectado":                                      {en: "Logo file rejected: malware detected", es: "Archivo de logotipo rechazado: malware detectado"},
+	"Assessment not found":                                                              {pt: "Avaliação não encontrada", es: "Evaluación no encontrada"},
+	"Assessment not found or not part of your organization":                             {pt: "Avaliação não encontrada ou não pertence à sua organização", es: "Evaluación no encontrada o no pertenece a su organización"},
+	"Asset not found or not part of your organization":                                  {pt: "Ativo não encontrado ou não pertence à sua organização", es: "Activo no encontrado o no pertenece a su organización"},
+	"assigned_team_id requires assigned_to_id":                                          {pt: "assigned_team_id exige assigned_to_id", es: "assigned_team_id requiere assigned_to_id"},
+	"Assignee not found or inactive in your organization":                               {pt: "Avaliador não encontrado ou inativo na sua organização", es: "Evaluador no encontrado o inactivo en su organización"},
+	"At least one active status must remain":                                            {pt: "Ao menos um status deve continuar ativo", es: "Al menos un estado debe permanecer activo"},
+	"At most %d allowed networks are supported":                                         {pt: "No máximo %d redes permitidas são suportadas", es: "Se admiten como máximo %d redes permitidas"},
+	"At most %d risks can be changed at once":                                           {pt: "No máximo %d riscos podem ser alterados de uma vez", es: "Como máximo se pueden modificar %d riesgos a la vez"},
+	"At most %d secrets can be revoked per request":                                     {pt: "No máximo %d segredos podem ser revogados por solicitação", es: "Como máximo se pueden revocar %d secretos por solicitud"},
+	"At most 5000 users can be changed at once":                                         {pt: "No máximo 5000 usuários podem ser alterados de uma vez", es: "Como máximo se pueden modificar 5000 usuarios a la vez"},
+	"Audit campaign is closed":                                                          {pt: "A auditoria está encerrada", es: "La auditoría está cerrada"},
+	"Audit campaign is outside its time window":                                         {pt: "A auditoria está fora da sua janela de execução", es: "La auditoría está fuera de su ventana de ejecución"},
+	"Audit campaign not found or not part of your organization":                         {pt: "Auditoria não encontrada ou não pertence à sua organização", es: "Auditoría no encontrada o no pertenece a su organización"},
+	"Audit control does not belong to the campaign's framework":                         {pt: "O controle de auditoria não pertence ao framework da auditoria", es: "El control de auditoría no pertenece al framework de la auditoría"},
+	"Audit control is outside the campaign's scope":                                     {pt: "O controle de auditoria está fora do escopo da auditoria", es: "El control de auditoría está fuera del alcance de la auditoría"},
+	"Audit controls not found: ":                                                        {pt: "Controles de auditoria não encontrados: ", es: "Controles de auditoría no encontrados: "},
+	"Audit trail export is not completed":                                               {pt: "A exportação da trilha de auditoria não está concluída", es: "La exportación de la pista de auditoría no está completada"},
+	"Audit trail export not found":                                                      {pt: "Exportação da trilha de auditoria não encontrada", es: "Exportación de la pista de auditoría no encontrada"},
+	"Audit trail export part not found":                                                 {pt: "Parte da exportação da trilha de auditoria não encontrada", es: "Parte de la exportación de la pista de auditoría no encontrada"},
+	"Background job cannot be changed in its current status: ":                          {pt: "O job em segundo plano não pode ser alterado no status atual: ", es: "El job en segundo plano no se puede modificar en su estado actual: "},
+	"Background job not found":                                                          {pt: "Job em segundo plano não encontrado", es: "Job en segundo plano no encontrado"},
+	"base_status must be a built-in status of the entity":                               {pt: "base_status deve ser um status nativo da entidade", es: "base_status debe ser un estado nativo de la entidad"},
+	"Break-glass reason must have at least %d characters":                               {pt: "A justificativa de break-glass deve ter pelo menos %d caracteres", es: "La justificación de break-glass debe tener al menos %d caracteres"},
+	"Cannot remove the active SAML certificate; activate another one first":             {pt: "Não é possível remover o certificado SAML ativo; ative outro antes", es: "No se puede eliminar el certificado SAML activo; active otro antes"},
+	"Cannot remove your last passkey while passkeys are required for administrators":    {pt: "Não é possível remover sua última passkey enquanto passkeys forem exigidas dos administradores", es: "No se puede eliminar su última passkey mientras se exijan passkeys a los administradores"},
+	"Cannot remove your last second factor while two-factor authentication is required": {pt: "Não é possível remover o seu último segundo fator enquanto a autenticação de dois fatores for exigida", es: "No se puede eliminar su último segundo factor mientras se exija la autenticación de dos factores"},
+	"certificate_pem and private_key_pem must be provided together":                     {pt: "certificate_pem e private_key_pem devem ser informados juntos", es: "certificate_pem y private_key_pem deben enviarse juntos"},
+	"Comment must have between 1 and %d characters":                                     {pt: "O comentário deve ter entre 1 e %d caracteres", es: "El comentario debe tener entre 1 y %d caracteres"},
+	"Comment not found":                                                                 {pt: "Comentário não encontrado", es: "Comentario no encontrado"},
+	"Control families not found in framework: ":                                         {pt: "Famílias de controles não encontradas no framework: ", es: "Familias de controles no encontradas en el framework: "},
+	"Control waiver not found or not part of your organization":                         {pt: "Exceção de controle não encontrada ou não pertence à sua organização", es: "Excepción de control no encontrada o no pertenece a su organización"},
+	"Could not verify your groups with the identity provider":                           {pt: "Não foi possível confirmar seus grupos no provedor de identidade", es: "No se pudieron verificar sus grupos en el proveedor de identidad"},
+	"Custom domain is already in use by another organization":                           {pt: "O domínio personalizado já está em uso por outra organização", es: "El dominio personalizado ya está en uso por otra organización"},
+	"Custom role limit reached for this organization":                                   {pt: "Limite de papéis personalizados atingido para esta organização", es: "Se alcanzó el límite de roles personalizados de esta organización"},
+	"Custom role not found":                                                             {pt: "Papel personalizado não encontrado", es: "Rol personalizado no encontrado"},
+	"Custom role not found in this organization":                                        {pt: "Papel personalizado não encontrado nesta organização", es: "Rol personalizado no encontrado en esta organización"},
+	"Domain has already been added to this organization":                                {pt: "O domínio já foi adicionado a esta organização", es: "El dominio ya se agregó a esta organización"},
+	"Domain is already verified by another organization":                                {pt: "O domínio já foi verificado por outra organização", es: "El dominio ya fue verificado por otra organización"},
+	"Domain not found":                                                                  {pt: "Domínio não encontrado", es: "Dominio no encontrado"},
+	"Domain removed successfully":                                                       {pt: "Domínio removido com sucesso", es: "Dominio eliminado correctamente"},
+	"due_date requires assigned_to_id":                                                  {pt: "due_date exige assigned_to_id", es: "due_date requiere assigned_to_id"},
+	"Duplicate participant: ":                                                           {pt: "Participante duplicado: ", es: "Participante duplicado: "},
+	"Duplicate retention category: ":                                                    {pt: "Categoria de retenção duplicada: ", es: "Categoría de retención duplicada: "},
+	"Email template not found":                                                          {pt: "Modelo de e-mail não encontrado", es: "Plantilla de correo no encontrada"},
+	"ends_at must not be before starts_at":                                              {pt: "ends_at não pode ser anterior a starts_at", es: "ends_at no puede ser anterior a starts_at"},
+	"entity_type (risk, control or vendor) and a valid entity_id are required together": {pt: "entity_type (risk, control ou vendor) e um entity_id válido devem ser informados juntos", es: "entity_type (risk, control o vendor) y un entity_id válido deben informarse juntos"},
+	"Evidence download blocked by the organization's access policy: ":                   {pt: "Download de evidência bloqueado pela política de acesso da organização: ", es: "Descarga de evidencia bloqueada por la política de acceso de la organización: "},
+	"Evidence draft has already been accepted":                                          {pt: "O rascunho de evidência já foi aceito", es: "El borrador de evidencia ya fue aceptado"},
+	"Evidence draft not found":                                                          {pt: "Rascunho de evidência não encontrado", es: "Borrador de evidencia no encontrado"},
+	"Evidence draft not found for this control":                                         {pt: "Rascunho de evidência não encontrado para este controle", es: "Borrador de evidencia no encontrado para este control"},
+	"Evidence drafts can only be used in the continuous assessment":                     {pt: "Rascunhos de evidência só podem ser usados na avaliação contínua", es: "Los borradores de evidencia solo se pueden usar en la evaluación continua"},
+	"evidence examples must not be blank and must have at most %d characters":           {pt: "os exemplos de evidência não podem ser vazios e devem ter no máximo %d caracteres", es: "los ejemplos de evidencia no pueden estar vacíos y deben tener como máximo %d caracteres"},
+	"expires_at cannot be in the past":                                                  {pt: "expires_at não pode estar no passado", es: "expires_at no puede estar en el pasado"},
+	"Failed to acknowledge reminder: ":                                                  {pt: "Falha ao registrar a resposta ao lembrete: ", es: "Error al registrar la respuesta al recordatorio: "},
+	"Failed to activate SAML certificate: ":                                             {pt: "Falha ao ativar o certificado SAML: ", es: "Error al activar el certificado SAML: "},
+	"Failed to add framework coordinator: ":                                             {pt: "Falha ao adicionar o coordenador do framework: ", es: "Error al agregar el coordinador del framework: "},
+	"Failed to add team members: ":                                                      {pt: "Falha ao adicionar os membros da equipe: ", es: "Error al agregar los miembros del equipo: "},
+	"Failed to aggregate risks: ":                                                       {pt: "Falha ao consolidar os riscos: ", es: "Error al consolidar los riesgos: "},
+	"Failed to anonymize user: ":                                                        {pt: "Falha ao anonimizar o usuário: ", es: "Error al anonimizar al usuario: "},
+	"Failed to apply identity provider role mapping.":                                   {pt: "Falha ao aplicar o mapeamento de papéis do provedor de identidade.", es: "Error al aplicar el mapeo de roles del proveedor de identidad."},
+	"Failed to apply identity provider role mapping: ":                                  {pt: "Falha ao aplicar o mapeamento de papéis do provedor de identidade: ", es: "Error al aplicar el mapeo de roles del proveedor de identidad: "},
+	"Failed to assign custom role: ":                                                    {pt: "Falha ao atribuir o papel personalizado: ", es: "Error al asignar el rol personalizado: "},
+	"Failed to assign roles: ":                                                          {pt: "Falha ao atribuir as roles: ", es: "Error al asignar los roles: "},
+	"Failed to build report: ":                                                          {pt: "Falha ao gerar o relatório: ", es: "Error al generar el informe: "},
+	"Failed to build retention report: ":                                                {pt: "Falha ao gerar o relatório de retenção: ", es: "Error al generar el informe de retención: "},
+	"Failed to calculate compliance score: ":                                            {pt: "Falha ao calcular o score de conformidade: ", es: "Error al calcular la puntuación de cumplimiento: "},
+	"Failed to cancel admin action request: ":                                           {pt: "Falha ao cancelar a solicitação de ação administrativa: ", es: "Error al cancelar la solicitud de acción administrativa: "},
+	"Failed to check custom role name: ":                                                {pt: "Falha ao verificar o nome do papel personalizado: ", es: "Error al verificar el nombre del rol personalizado: "},
+	"Failed to check edit lock: ":                                                       {pt: "Falha ao verificar o bloqueio de edição: ", es: "Error al verificar el bloqueo de edición: "},
+	"Failed to check entities: ":                                                        {pt: "Falha ao verificar as entidades: ", es: "Error al verificar las entidades: "},
+	"Failed to check existing users: ":                                                  {pt: "Falha ao verificar usuários existentes: ", es: "Error al verificar usuarios existentes: "},
+	"Failed to check framework assessments: ":                                           {pt: "Falha ao verificar as avaliações do framework: ", es: "Error al verificar las evaluaciones del framework: "},
+	"Failed to check framework coordinators: ":                                          {pt: "Falha ao verificar os coordenadores do framework: ", es: "Error al verificar los coordinadores del framework: "},
+	"Failed to check Idempotency-Key: ":                                                 {pt: "Falha ao verificar a Idempotency-Key: ", es: "Error al verificar la Idempotency-Key: "},
+	"Failed to check pending approval requests: ":                                       {pt: "Falha ao verificar as solicitações de aprovação pendentes: ", es: "Error al verificar las solicitudes de aprobación pendientes: "},
+	"Failed to check risks using the category: ":                                        {pt: "Falha ao verificar os riscos que usam a categoria: ", es: "Error al verificar los riesgos que usan la categoría: "},
+	"Failed to check team membership: ":                                                 {pt: "Falha ao verificar a participação na equipe: ", es: "Error al verificar la pertenencia al equipo: "},
+	"Failed to check team name: ":                                                       {pt: "Falha ao verificar o nome da equipe: ", es: "Error al verificar el nombre del equipo: "},
+	"Failed to compute background job queue lag: ":                                      {pt: "Falha ao calcular o atraso da fila de jobs: ", es: "Error al calcular el retraso de la cola de jobs: "},
+	"Failed to configure SAML service provider for metadata.":                           {pt: "Falha ao configurar o provedor de serviço SAML para os metadados.", es: "Error al configurar el proveedor de servicio SAML para los metadatos."},
+	"Failed to count admin action requests: ":                                           {pt: "Falha ao contar as solicitações de ações administrativas: ", es: "Error al contar las solicitudes de acciones administrativas: "},
+	"Failed to count API keys: ":                                                        {pt: "Falha ao contar as chaves de API: ", es: "Error al contar las claves de API: "},
+	"Failed to count audit trail entries: ":                                             {pt: "Falha ao contar os registros da trilha de auditoria: ", es: "Error al contar los registros de la pista de auditoría: "},
+	"Failed to count audit trail exports: ":                                             {pt: "Falha ao contar as exportações da trilha de auditoria: ", es: "Error al contar las exportaciones de la pista de auditoría: "},
+	"Failed to count background jobs: ":                                                 {pt: "Falha ao contar os jobs em segundo plano: ", es: "Error al contar los jobs en segundo plano: "},
+	"Failed to count control waivers: ":                                                 {pt: "Falha ao contar as exceções de controles: ", es: "Error al contar las excepciones de controles: "},
+	"Failed to count custom role users: ":                                               {pt: "Falha ao contar os usuários do papel personalizado: ", es: "Error al contar los usuarios del rol personalizado: "},
+	"Failed to count custom roles: ":                                                    {pt: "Falha ao contar os papéis personalizados: ", es: "Error al contar los roles personalizados: "},
+	"Failed to count deleted records: ":                                                 {pt: "Falha ao contar os registros excluídos: ", es: "Error al contar los registros eliminados: "},
+	"Failed to count notifications: ":                                                   {pt: "Falha ao contar as notificações: ", es: "Error al contar las notificaciones: "},
+	"Failed to count organization exports: ":                                            {pt: "Falha ao contar as exportações da organização: ", es: "Error al contar las exportaciones de la organización: "},
+	"Failed to count overdue actions: ":                                                 {pt: "Falha ao contar as ações vencidas: ", es: "Error al contar las acciones vencidas: "},
+	"Failed to count pending approvals: ":                                               {pt: "Falha ao contar as aprovações pendentes: ", es: "Error al contar las aprobaciones pendientes: "},
+	"Failed to count risks: ":                                                           {pt: "Falha ao contar os riscos: ", es: "Error al contar los riesgos: "},
+	"Failed to count tag usage: ":                                                       {pt: "Falha ao contar o uso das tags: ", es: "Error al contar el uso de las etiquetas: "},
+	"Failed to count team members: ":                                                    {pt: "Falha ao contar os membros da equipe: ", es: "Error al contar los miembros del equipo: "},
+	"Failed to create API key: ":                                                        {pt: "Falha ao criar a chave de API: ", es: "Error al crear la clave de API: "},
+	"Failed to create approval request: ":                                               {pt: "Falha ao criar a solicitação de aprovação: ", es: "Error al crear la solicitud de aprobación: "},
+	"Failed to create assessment comment: ":                                             {pt: "Falha ao criar o comentário da avaliação: ", es: "Error al crear el comentario de la evaluación: "},
+	"Failed to create audit trail export: ":                                             {pt: "Falha ao criar a exportação da trilha de auditoria: ", es: "Error al crear la exportación de la pista de auditoría: "},
+	"Failed to create control waiver: ":                                                 {pt: "Falha ao criar a exceção de controle: ", es: "Error al crear la excepción de control: "},
+	"Failed to create custom role: ":                                                    {pt: "Falha ao criar o papel personalizado: ", es: "Error al crear el rol personalizado: "},
+	"Failed to create invitation: ":                                                     {pt: "Falha ao criar convite: ", es: "Error al crear la invitación: "},
+	"Failed to create organization export: ":                                            {pt: "Falha ao criar a exportação da organização: ", es: "Error al crear la exportación de la organización: "},
+	"Failed to create report schedule: ":                                                {pt: "Falha ao criar o agendamento de relatório: ", es: "Error al crear la programación de informe: "},
+	"Failed to create risk category: ":                                                  {pt: "Falha ao criar a categoria de risco: ", es: "Error al crear la categoría de riesgo: "},
+	"Failed to create status definition: ":                                              {pt: "Falha ao criar a definição de status: ", es: "Error al crear la definición de estado: "},
+	"Failed to create tag: ":                                                            {pt: "Falha ao criar a tag: ", es: "Error al crear la etiqueta: "},
+	"Failed to create team: ":                                                           {pt: "Falha ao criar a equipe: ", es: "Error al crear el equipo: "},
+	"Failed to create user: ":                                                           {pt: "Falha ao criar usuário: ", es: "Error al crear el usuario: "},
+	"Failed to decide admin action request: ":                                           {pt: "Falha ao decidir a solicitação de ação administrativa: ", es: "Error al decidir la solicitud de acción administrativa: "},
+	"Failed to decide control waiver: ":                                                 {pt: "Falha ao decidir a exceção de controle: ", es: "Error al decidir la excepción de control: "},
+	"Failed to decode export manifest: ":                                                {pt: "Falha ao decodificar o manifesto da exportação: ", es: "Error al decodificar el manifiesto de la exportación: "},
+	"Failed to delete comment: ":                                                        {pt: "Falha ao excluir o comentário: ", es: "Error al eliminar el comentario: "},
+	"Failed to delete credential policy: ":                                              {pt: "Falha ao excluir a política de credenciais: ", es: "Error al eliminar la política de credenciales: "},
+	"Failed to delete custom role: ":                                                    {pt: "Falha ao excluir o papel personalizado: ", es: "Error al eliminar el rol personalizado: "},
+	"Failed to delete evidence access policy: ":                                         {pt: "Falha ao remover a política de acesso às evidências: ", es: "Error al eliminar la política de acceso a las evidencias: "},
+	"Failed to delete evidence draft: ":                                                 {pt: "Falha ao excluir o rascunho de evidência: ", es: "Error al eliminar el borrador de evidencia: "},
+	"Failed to delete report schedule: ":                                                {pt: "Falha ao excluir o agendamento de relatório: ", es: "Error al eliminar la programación de informe: "},
+	"Failed to delete risk category: ":                                                  {pt: "Falha ao excluir a categoria de risco: ", es: "Error al eliminar la categoría de riesgo: "},
+	"Failed to delete risk scoring matrix: ":                                            {pt: "Falha ao excluir a matriz de pontuação de riscos: ", es: "Error al eliminar la matriz de puntuación de riesgos: "},
+	"Failed to delete saved view: ":                                                     {pt: "Falha ao excluir a visão salva: ", es: "Error al eliminar la vista guardada: "},
+	"Failed to delete tag: ":                                                            {pt: "Falha ao excluir a tag: ", es: "Error al eliminar la etiqueta: "},
+	"Failed to delete team: ":                                                           {pt: "Falha ao excluir a equipe: ", es: "Error al eliminar el equipo: "},
+	"Failed to deliver report: ":                                                        {pt: "Falha ao enviar o relatório: ", es: "Error al enviar el informe: "},
+	"Failed to discover identity provider":                                              {pt: "Falha ao descobrir o provedor de identidade", es: "Error al descubrir el proveedor de identidad"},
+	"Failed to export personal data: ":                                                  {pt: "Falha ao exportar os dados pessoais: ", es: "Error al exportar los datos personales: "},
+	"Failed to fetch admin action request: ":                                            {pt: "Falha ao buscar a solicitação de ação administrativa: ", es: "Error al obtener la solicitud de acción administrativa: "},
+	"Failed to fetch approval workflow: ":                                               {pt: "Falha ao buscar o workflow de aprovação: ", es: "Error al obtener el flujo de aprobación: "},
+	"Failed to fetch approver: ":                                                        {pt: "Falha ao buscar o aprovador: ", es: "Error al obtener el aprobador: "},
+	"Failed to fetch assessment: ":                                                      {pt: "Falha ao buscar a avaliação: ", es: "Error al obtener la evaluación: "},
+	"Failed to fetch audit trail export: ":                                              {pt: "Falha ao buscar a exportação da trilha de auditoria: ", es: "Error al obtener la exportación de la pista de auditoría: "},
+	"Failed to fetch background job: ":                                                  {pt: "Falha ao buscar o job em segundo plano: ", es: "Error al obtener el job en segundo plano: "},
+	"Failed to fetch comment: ":                                                         {pt: "Falha ao buscar o comentário: ", es: "Error al obtener el comentario: "},
+	"Failed to fetch control waiver: ":                                                  {pt: "Falha ao buscar a exceção de controle: ", es: "Error al obtener la excepción de control: "},
+	"Failed to fetch control: ":                                                         {pt: "Falha ao buscar o controle: ", es: "Error al obtener el control: "},
+	"Failed to fetch deleted record: ":                                                  {pt: "Falha ao buscar o registro excluído: ", es: "Error al obtener el registro eliminado: "},
+	"Failed to fetch domain: ":                                                          {pt: "Falha ao buscar o domínio: ", es: "Error al obtener el dominio: "},
+	"Failed to fetch evidence draft: ":                                                  {pt: "Falha ao buscar o rascunho de evidência: ", es: "Error al obtener el borrador de evidencia: "},
+	"Failed to fetch framework details: ":                                               {pt: "Falha ao buscar os detalhes do framework: ", es: "Error al obtener los detalles del framework: "},
+	"Failed to fetch frameworks: ":                                                      {pt: "Falha ao buscar os frameworks: ", es: "Error al obtener los marcos: "},
+	"Failed to fetch identity provider: ":                                               {pt: "Falha ao buscar o provedor de identidade: ", es: "Error al obtener el proveedor de identidad: "},
+	"Failed to fetch login page settings: ":                                             {pt: "Falha ao buscar as configurações da tela de login: ", es: "Error al obtener la configuración de la página de inicio de sesión: "},
+	"Failed to fetch MFA policy: ":                                                      {pt: "Falha ao buscar a política de MFA: ", es: "Error al obtener la política de MFA: "},
+	"Failed to fetch organization export: ":                                             {pt: "Falha ao buscar a exportação da organização: ", es: "Error al obtener la exportación de la organización: "},
+	"Failed to fetch owner: ":                                                           {pt: "Falha ao buscar o responsável: ", es: "Error al obtener el responsable: "},
+	"Failed to fetch passkey: ":                                                         {pt: "Falha ao buscar a passkey: ", es: "Error al obtener la passkey: "},
+	"Failed to fetch passkeys: ":                                                        {pt: "Falha ao buscar as passkeys: ", es: "Error al obtener las passkeys: "},
+	"Failed to fetch password policy: ":                                                 {pt: "Falha ao buscar a política de senhas: ", es: "Error al obtener la política de contraseñas: "},
+	"Failed to fetch recent activity":                                                   {pt: "Falha ao buscar a atividade recente", es: "Error al obtener la actividad reciente"},
+	"Failed to fetch recent activity: ":                                                 {pt: "Falha ao buscar a atividade recente: ", es: "Error al obtener la actividad reciente: "},
+	"Failed to fetch report schedule: ":                                                 {pt: "Falha ao buscar o agendamento de relatório: ", es: "Error al obtener la programación de informe: "},
+	"Failed to fetch risk category: ":                                                   {pt: "Falha ao buscar a categoria de risco: ", es: "Error al obtener la categoría de riesgo: "},
+	"Failed to fetch risk scoring matrix: ":                                             {pt: "Falha ao buscar a matriz de pontuação de riscos: ", es: "Error al obtener la matriz de puntuación de riesgos: "},
+	"Failed to fetch risks: ":                                                           {pt: "Falha ao buscar os riscos: ", es: "Error al obtener los riesgos: "},
+	"Failed to fetch SAML certificate: ":                                                {pt: "Falha ao buscar o certificado SAML: ", es: "Error al obtener el certificado SAML: "},
+	"Failed to fetch saved view: ":                                                      {pt: "Falha ao buscar a visão salva: ", es: "Error al obtener la vista guardada: "},
+	"Failed to fetch secrets: ":                                                         {pt: "Falha ao buscar os segredos: ", es: "Error al obtener los secretos: "},
+	"Failed to fetch tag: ":                                                             {pt: "Falha ao buscar a tag: ", es: "Error al obtener la etiqueta: "},
+	"Failed to fetch top risks: ":                                                       {pt: "Falha ao buscar os principais riscos: ", es: "Error al obtener los principales riesgos: "},
+	"Failed to fetch user: ":                                                            {pt: "Falha ao buscar o usuário: ", es: "Error al obtener el usuario: "},
+	"Failed to fetch waiver approvals: ":                                                {pt: "Falha ao buscar as aprovações da exceção: ", es: "Error al obtener las aprobaciones de la excepción: "},
+	"Failed to filter controls by tag: ":                                                {pt: "Falha ao filtrar os controles pela tag: ", es: "Error al filtrar los controles por etiqueta: "},
+	"Failed to generate API key: ":                                                      {pt: "Falha ao gerar a chave de API: ", es: "Error al generar la clave de API: "},
+	"Failed to generate SAML certificate: ":                                             {pt: "Falha ao gerar o certificado SAML: ", es: "Error al generar el certificado SAML: "},
+	"Failed to generate token":                                                          {pt: "Falha ao gerar token", es: "Error al generar el token"},
+	"Failed to generate verification token":                                             {pt: "Falha ao gerar o token de verificação", es: "Error al generar el token de verificación"},
+	"Failed to hash password":                                                           {pt: "Falha ao processar a senha", es: "Error al procesar la contraseña"},
+	"Failed to list admin action requests: ":                                            {pt: "Falha ao listar as solicitações de ações administrativas: ", es: "Error al listar las solicitudes de acciones administrativas: "},
+	"Failed to list API keys: ":                                                         {pt: "Falha ao listar as chaves de API: ", es: "Error al listar las claves de API: "},
+	"Failed to list assessed frameworks: ":                                              {pt: "Falha ao listar os frameworks avaliados: ", es: "Error al listar los frameworks evaluados: "},
+	"Failed to list assessment comments: ":                                              {pt: "Falha ao listar os comentários da avaliação: ", es: "Error al listar los comentarios de la evaluación: "},
+	"Failed to list audit trail entries: ":                                              {pt: "Falha ao listar os registros da trilha de auditoria: ", es: "Error al listar los registros de la pista de auditoría: "},
+	"Failed to list audit trail exports: ":                                              {pt: "Falha ao listar as exportações da trilha de auditoria: ", es: "Error al listar las exportaciones de la pista de auditoría: "},
+	"Failed to list background jobs: ":                                                  {pt: "Falha ao listar os jobs em segundo plano: ", es: "Error al listar los jobs en segundo plano: "},
+	"Failed to list control waivers: ":                                                  {pt: "Falha ao listar as exceções de controles: ", es: "Error al listar las excepciones de controles: "},
+	"Failed to list custom roles: ":                                                     {pt: "Falha ao listar os papéis personalizados: ", es: "Error al listar los roles personalizados: "},
+	"Failed to list deleted records: ":                                                  {pt: "Falha ao listar os registros excluídos: ", es: "Error al listar los registros eliminados: "},
+	"Failed to list domains: ":                                                          {pt: "Falha ao listar os domínios: ", es: "Error al listar los dominios: "},
+	"Failed to list evidence drafts: ":                                                  {pt: "Falha ao listar os rascunhos de evidência: ", es: "Error al listar los borradores de evidencia: "},
+	"Failed to list evidence requests: ":                                                {pt: "Falha ao listar as solicitações de evidência: ", es: "Error al listar las solicitudes de evidencia: "},
+	"Failed to list framework coordinators: ":                                           {pt: "Falha ao listar os coordenadores do framework: ", es: "Error al listar los coordinadores del framework: "},
+	"Failed to list invitations: ":                                                      {pt: "Falha ao listar convites: ", es: "Error al listar las invitaciones: "},
+	"Failed to list notifications: ":                                                    {pt: "Falha ao listar as notificações: ", es: "Error al listar las notificaciones: "},
+	"Failed to list organization exports: ":                                             {pt: "Falha ao listar as exportações da organização: ", es: "Error al listar las exportaciones de la organización: "},
+	"Failed to list organizations: ":                                                    {pt: "Falha ao listar as organizações: ", es: "Error al listar las organizaciones: "},
+	"Failed to list reminder acknowledgments: ":                                         {pt: "Falha ao listar as respostas aos lembretes: ", es: "Error al listar las respuestas a los recordatorios: "},
+	"Failed to list report schedules: ":                                                 {pt: "Falha ao listar os agendamentos de relatórios: ", es: "Error al listar las programaciones de informes: "},
+	"Failed to list SAML certificates: ":                                                {pt: "Falha ao listar os certificados SAML: ", es: "Error al listar los certificados SAML: "},
+	"Failed to list saved views: ":                                                      {pt: "Falha ao listar as visões salvas: ", es: "Error al listar las vistas guardadas: "},
+	"Failed to list sessions: ":                                                         {pt: "Falha ao listar as sessões: ", es: "Error al listar las sesiones: "},
+	"Failed to list tagged entities: ":                                                  {pt: "Falha ao listar as entidades marcadas: ", es: "Error al listar las entidades etiquetadas: "},
+	"Failed to list tags: ":                                                             {pt: "Falha ao listar as tags: ", es: "Error al listar las etiquetas: "},
+	"Failed to list team members: ":                                                     {pt: "Falha ao listar os membros da equipe: ", es: "Error al listar los miembros del equipo: "},
+	"Failed to list teams: ":                                                            {pt: "Falha ao listar as equipes: ", es: "Error al listar los equipos: "},
+	"Failed to list users pending MFA enrollment: ":                                     {pt: "Falha ao listar os usuários com cadastro de MFA pendente: ", es: "Error al listar los usuarios con registro de MFA pendiente: "},
+	"Failed to load API key: ":                                                          {pt: "Falha ao carregar a chave de API: ", es: "Error al cargar la clave de API: "},
+	"Failed to load compliance score: ":                                                 {pt: "Falha ao carregar o score de conformidade: ", es: "Error al cargar la puntuación de cumplimiento: "},
+	"Failed to load credential policy: ":                                                {pt: "Falha ao carregar a política de credenciais: ", es: "Error al cargar la política de credenciales: "},
+	"Failed to load custom role: ":                                                      {pt: "Falha ao carregar o papel personalizado: ", es: "Error al cargar el rol personalizado: "},
+	"Failed to load email branding: ":                                                   {pt: "Falha ao carregar a identidade visual dos e-mails: ", es: "Error al cargar la identidad visual de los correos: "},
+	"Failed to load evidence access policy: ":                                           {pt: "Falha ao carregar a política de acesso às evidências: ", es: "Error al cargar la política de acceso a las evidencias: "},
+	"Failed to load executive report template: ":                                        {pt: "Falha ao carregar o modelo do relatório executivo: ", es: "Error al cargar la plantilla del informe ejecutivo: "},
+	"Failed to load identity providers: ":                                               {pt: "Falha ao carregar provedores de identidade: ", es: "Error al cargar los proveedores de identidad: "},
+	"Failed to load invitation: ":                                                       {pt: "Falha ao carregar convite: ", es: "Error al cargar la invitación: "},
+	"Failed to load login page":                                                         {pt: "Falha ao carregar a tela de login", es: "Error al cargar la página de inicio de sesión"},
+	"Failed to load login page: ":                                                       {pt: "Falha ao carregar a página de login: ", es: "Error al cargar la página de inicio de sesión: "},
+	"Failed to load MFA policy: ":                                                       {pt: "Falha ao carregar a política de MFA: ", es: "Error al cargar la política de MFA: "},
+	"Failed to load notification: ":                                                     {pt: "Falha ao carregar a notificação: ", es: "Error al cargar la notificación: "},
+	"Failed to load organization hierarchy: ":                                           {pt: "Falha ao carregar a hierarquia de organizações: ", es: "Error al cargar la jerarquía de organizaciones: "},
+	"Failed to load organization: ":                                                     {pt: "Falha ao carregar a organização: ", es: "Error al cargar la organización: "},
+	"Failed to load passkeys: ":                                                         {pt: "Falha ao carregar as passkeys: ", es: "Error al cargar las passkeys: "},
+	"Failed to load password policy":                                                    {pt: "Falha ao carregar a política de senhas", es: "Error al cargar la política de contraseñas"},
+	"Failed to load password policy: ":                                                  {pt: "Falha ao carregar a política de senhas: ", es: "Error al cargar la política de contraseñas: "},
+	"Failed to load retention policy: ":                                                 {pt: "Falha ao carregar a política de retenção: ", es: "Error al cargar la política de retención: "},
+	"Failed to load risk categories: ":                                                  {pt: "Falha ao carregar as categorias de risco: ", es: "Error al cargar las categorías de riesgo: "},
+	"Failed to load risk scoring matrix: ":                                              {pt: "Falha ao carregar a matriz de pontuação de riscos: ", es: "Error al cargar la matriz de puntuación de riesgos: "},
+	"Failed to load session: ":                                                          {pt: "Falha ao carregar a sessão: ", es: "Error al cargar la sesión: "},
+	"Failed to load team: ":                                                             {pt: "Falha ao carregar a equipe: ", es: "Error al cargar el equipo: "},
+	"Failed to load user: ":                                                             {pt: "Falha ao carregar o usuário: ", es: "Error al cargar el usuario: "},
+	"Failed to look up reference code: ":                                                {pt: "Falha ao consultar o código de referência: ", es: "Error al consultar el código de referencia: "},
+	"Failed to prepare revocation: ":                                                    {pt: "Falha ao preparar a revogação: ", es: "Error al preparar la revocación: "},
+	"Failed to process login":                                                           {pt: "Falha ao processar o login", es: "Error al procesar el inicio de sesión"},
+	"Failed to process new password":                                                    {pt: "Falha ao processar a nova senha", es: "Error al procesar la nueva contraseña"},
+	"Failed to process password change":                                                 {pt: "Falha ao processar a troca de senha", es: "Error al procesar el cambio de contraseña"},
+	"Failed to read control guidance: ":                                                 {pt: "Falha ao ler a orientação do controle: ", es: "Error al leer la guía del control: "},
+	"Failed to read logo file":                                                          {pt: "Falha ao ler o arquivo de logo", es: "Error al leer el archivo de logo"},
+	"Failed to read request body":                                                       {pt: "Falha ao ler o corpo da requisição", es: "Error al leer el cuerpo de la solicitud"},
+	"Failed to read saved view filters: ":                                               {pt: "Falha ao ler os filtros da visão salva: ", es: "Error al leer los filtros de la vista guardada: "},
+	"Failed to record audit trail: ":                                                    {pt: "Falha ao registrar a trilha de auditoria: ", es: "Error al registrar la pista de auditoría: "},
+	"Failed to record break-glass access: ":                                             {pt: "Falha ao registrar o acesso por break-glass: ", es: "Error al registrar el acceso por break-glass: "},
+	"Failed to record data export: ":                                                    {pt: "Falha ao registrar a exportação de dados: ", es: "Error al registrar la exportación de datos: "},
+	"Failed to record evidence draft: ":                                                 {pt: "Falha ao registrar o rascunho de evidência: ", es: "Error al registrar el borrador de evidencia: "},
+	"Failed to record export download: ":                                                {pt: "Falha ao registrar o download da exportação: ", es: "Error al registrar la descarga de la exportación: "},
+	"Failed to refresh session: ":                                                       {pt: "Falha ao renovar a sessão: ", es: "Error al renovar la sesión: "},
+	"Failed to remove domain: ":                                                         {pt: "Falha ao remover o domínio: ", es: "Error al eliminar el dominio: "},
+	"Failed to remove framework coordinator: ":                                          {pt: "Falha ao remover o coordenador do framework: ", es: "Error al eliminar el coordinador del framework: "},
+	"Failed to remove passkey: ":                                                        {pt: "Falha ao remover a passkey: ", es: "Error al eliminar la passkey: "},
+	"Failed to remove SAML certificate: ":                                               {pt: "Falha ao remover o certificado SAML: ", es: "Error al eliminar el certificado SAML: "},
+	"Failed to remove team member: ":                                                    {pt: "Falha ao remover o membro da equipe: ", es: "Error al eliminar el miembro del equipo: "},
+	"Failed to render email template: ":                                                 {pt: "Falha ao montar o modelo de e-mail: ", es: "Error al generar la plantilla de correo: "},
+	"Failed to render report: ":                                                         {pt: "Falha ao renderizar o relatório: ", es: "Error al renderizar el informe: "},
+	"Failed to restore policy: ":                                                        {pt: "Falha ao restaurar a política: ", es: "Error al restaurar la política: "},
+	"Failed to restore risk: ":                                                          {pt: "Falha ao restaurar o risco: ", es: "Error al restaurar el riesgo: "},
+	"Failed to retrieve controls for framework: ":                                       {pt: "Falha ao buscar os controles do framework: ", es: "Error al obtener los controles del framework: "},
+	"Failed to revoke API key: ":                                                        {pt: "Falha ao revogar a chave de API: ", es: "Error al revocar la clave de API: "},
+	"Failed to revoke control waiver: ":                                                 {pt: "Falha ao revogar a exceção de controle: ", es: "Error al revocar la excepción de control: "},
+	"Failed to revoke invitation: ":                                                     {pt: "Falha ao revogar convite: ", es: "Error al revocar la invitación: "},
+	"Failed to revoke session: ":                                                        {pt: "Falha ao encerrar a sessão: ", es: "Error al cerrar la sesión: "},
+	"Failed to revoke sessions: ":                                                       {pt: "Falha ao encerrar as sessões: ", es: "Error al cerrar las sesiones: "},
+	"Failed to save control guidance: ":                                                 {pt: "Falha ao salvar a orientação do controle: ", es: "Error al guardar la guía del control: "},
+	"Failed to save credential policy: ":                                                {pt: "Falha ao salvar a política de credenciais: ", es: "Error al guardar la política de credenciales: "},
+	"Failed to save domain: ":                                                           {pt: "Falha ao salvar o domínio: ", es: "Error al guardar el dominio: "},
+	"Failed to save email branding: ":                                                   {pt: "Falha ao salvar a identidade visual dos e-mails: ", es: "Error al guardar la identidad visual de los correos: "},
+	"Failed to save evidence access policy: ":                                           {pt: "Falha ao salvar a política de acesso às evidências: ", es: "Error al guardar la política de acceso a las evidencias: "},
+	"Failed to save executive report template: ":                                        {pt: "Falha ao salvar o modelo do relatório executivo: ", es: "Error al guardar la plantilla del informe ejecutivo: "},
+	"Failed to save login page settings: ":                                              {pt: "Falha ao salvar as configurações da tela de login: ", es: "Error al guardar la configuración de la página de inicio de sesión: "},
+	"Failed to save MFA policy: ":                                                       {pt: "Falha ao salvar a política de MFA: ", es: "Error al guardar la política de MFA: "},
+	"Failed to save passkey: ":                                                          {pt: "Falha ao salvar a passkey: ", es: "Error al guardar la passkey: "},
+	"Failed to save password policy: ":                                                  {pt: "Falha ao salvar a política de senhas: ", es: "Error al guardar la política de contraseñas: "},
+	"Failed to save retention policy: ":                                                 {pt: "Falha ao salvar a política de retenção: ", es: "Error al guardar la política de retención: "},
+	"Failed to save risk scoring matrix: ":                                              {pt: "Falha ao salvar a matriz de pontuação de riscos: ", es: "Error al guardar la matriz de puntuación de riesgos: "},
+	"Failed to save SAML certificate: ":                                                 {pt: "Falha ao salvar o certificado SAML: ", es: "Error al guardar el certificado SAML: "},
+	"Failed to save token":                                                              {pt: "Falha ao salvar token", es: "Error al guardar el token"},
+	"Failed to save view: ":                                                             {pt: "Falha ao salvar a visão: ", es: "Error al guardar la vista: "},
+	"Failed to scan logo file for malware: ":                                            {pt: "Falha na verificação antivírus do arquivo de logo: ", es: "Error en el análisis antivirus del archivo de logo: "},
+	"Failed to search: ":                                                                {pt: "Falha ao buscar: ", es: "Error al buscar: "},
+	"Failed to select users: ":                                                          {pt: "Falha ao selecionar os usuários: ", es: "Error al seleccionar los usuarios: "},
+	"Failed to start passkey registration: ":                                            {pt: "Falha ao iniciar o registro da passkey: ", es: "Error al iniciar el registro de la passkey: "},
+	"Failed to tag entities: ":                                                          {pt: "Falha ao marcar as entidades: ", es: "Error al etiquetar las entidades: "},
+	"Failed to unlock user: ":                                                           {pt: "Falha ao desbloquear o usuário: ", es: "Error al desbloquear el usuario: "},
+	"Failed to untag entity: ":                                                          {pt: "Falha ao remover a tag da entidade: ", es: "Error al quitar la etiqueta de la entidad: "},
+	"Failed to update background job: ":                                                 {pt: "Falha ao atualizar o job em segundo plano: ", es: "Error al actualizar el job en segundo plano: "},
+	"Failed to update custom role: ":                                                    {pt: "Falha ao atualizar o papel personalizado: ", es: "Error al actualizar el rol personalizado: "},
+	"Failed to update domain: ":                                                         {pt: "Falha ao atualizar o domínio: ", es: "Error al actualizar el dominio: "},
+	"Failed to update invitation: ":                                                     {pt: "Falha ao atualizar convite: ", es: "Error al actualizar la invitación: "},
+	"Failed to update notification: ":                                                   {pt: "Falha ao atualizar a notificação: ", es: "Error al actualizar la notificación: "},
+	"Failed to update notifications: ":                                                  {pt: "Falha ao atualizar as notificações: ", es: "Error al actualizar las notificaciones: "},
+	"Failed to update organization: ":                                                   {pt: "Falha ao atualizar a organização: ", es: "Error al actualizar la organización: "},
+	"Failed to update password":                                                         {pt: "Falha ao atualizar a senha", es: "Error al actualizar la contraseña"},
+	"Failed to update report schedule: ":                                                {pt: "Falha ao atualizar o agendamento de relatório: ", es: "Error al actualizar la programación de informe: "},
+	"Failed to update risk category: ":                                                  {pt: "Falha ao atualizar a categoria de risco: ", es: "Error al actualizar la categoría de riesgo: "},
+	"Failed to update status definition: ":                                              {pt: "Falha ao atualizar a definição de status: ", es: "Error al actualizar la definición de estado: "},
+	"Failed to update status transitions: ":                                             {pt: "Falha ao atualizar as transições de status: ", es: "Error al actualizar las transiciones de estado: "},
+	"Failed to update tag: ":                                                            {pt: "Falha ao atualizar a tag: ", es: "Error al actualizar la etiqueta: "},
+	"Failed to update team: ":                                                           {pt: "Falha ao atualizar a equipe: ", es: "Error al actualizar el equipo: "},
+	"Failed to upload logo: ":                                                           {pt: "Falha ao fazer upload do logo: ", es: "Error al subir el logo: "},
+	"Failed to validate attribute mapping: ":                                            {pt: "Falha ao validar o mapeamento de atributos: ", es: "Error al validar el mapeo de atributos: "},
+	"Failed to validate organization hierarchy: ":                                       {pt: "Falha ao validar a hierarquia de organizações: ", es: "Error al validar la jerarquía de organizaciones: "},
+	"Failed to verify API key":                                                          {pt: "Falha ao verificar a chave de API", es: "Error al verificar la clave de API"},
+	"Failed to verify domain: ":                                                         {pt: "Falha ao verificar o domínio: ", es: "Error al verificar el dominio: "},
+	"Failed to verify passkey registration: ":                                           {pt: "Falha ao verificar o registro da passkey: ", es: "Error al verificar el registro de la passkey: "},
+	"Failed to verify session":                                                          {pt: "Falha ao verificar a sessão", es: "Error al verificar la sesión"},
+	"File storage service is not configured":                                            {pt: "Serviço de armazenamento de arquivos não configurado", es: "Servicio de almacenamiento de archivos no configurado"},
+	"filter '%s' accepts a single value":                                                {pt: "o filtro '%s' aceita um único valor", es: "el filtro '%s' admite un solo valor"},
+	"filter '%s' has a value longer than %d characters":                                 {pt: "o filtro '%s' tem um valor com mais de %d caracteres", es: "el filtro '%s' tiene un valor de más de %d caracteres"},
+	"filter '%s' has more than %d values":                                               {pt: "o filtro '%s' tem mais de %d valores", es: "el filtro '%s' tiene más de %d valores"},
+	"filter '%s' is not supported for %s views (supported: %s)":                         {pt: "o filtro '%s' não é aceito em visões de %s (aceitos: %s)", es: "el filtro '%s' no se admite en vistas de %s (admitidos: %s)"},
+	"filter '%s' must be a team ID":                                                     {pt: "o filtro '%s' deve ser um ID de equipe", es: "el filtro '%s' debe ser un ID de equipo"},
+	"filter '%s' must be true or false":                                                 {pt: "o filtro '%s' deve ser true ou false", es: "el filtro '%s' debe ser true o false"},
+	"filter 'assigned_to_id' must be a user ID or 'me'":                                 {pt: "o filtro 'assigned_to_id' deve ser um ID de usuário ou 'me'", es: "el filtro 'assigned_to_id' debe ser un ID de usuario o 'me'"},
+	"Filter must have at least one criterion":                                           {pt: "O filtro deve ter ao menos um critério", es: "El filtro debe tener al menos un criterio"},
+	"from must be before to and in the past":                                            {pt: "from deve ser anterior a to e estar no passado", es: "from debe ser anterior a to y estar en el pasado"},
+	"guidance links must have a title":                                                  {pt: "os links da orientação devem ter um título", es: "los enlaces de la guía deben tener un título"},
+	"guidance must have at most %d evidence examples":                                   {pt: "a orientação deve ter no máximo %d exemplos de evidência", es: "la guía debe tener como máximo %d ejemplos de evidencia"},
+	"guidance must have at most %d links":                                               {pt: "a orientação deve ter no máximo %d links", es: "la guía debe tener como máximo %d enlaces"},
+	"guidance text must have at most %d characters":                                     {pt: "o texto da orientação deve ter no máximo %d caracteres", es: "el texto de la guía debe tener como máximo %d caracteres"},
+	"Idempotency-Key must have at most 255 characters":                                  {pt: "Idempotency-Key deve ter no máximo 255 caracteres", es: "Idempotency-Key debe tener como máximo 255 caracteres"},
+	"Idempotency-Key was already used with a different request":                         {pt: "Idempotency-Key já foi usada em uma requisição diferente", es: "Idempotency-Key ya se usó en una solicitud diferente"},
+	"Identity provider not found in this organization":                                  {pt: "Provedor de identidade não encontrado nesta organização", es: "Proveedor de identidad no encontrado en esta organización"},
+	"idle_days must be a non-negative integer":                                          {pt: "idle_days deve ser um número inteiro não negativo", es: "idle_days debe ser un número entero no negativo"},
+	"Insufficient permissions":                                                          {pt: "Permissões insuficientes", es: "Permisos insuficientes"},
+	"Invalid 'from' date: use RFC 3339 or YYYY-MM-DD":                                   {pt: "Data 'from' inválida: use RFC 3339 ou AAAA-MM-DD", es: "Fecha 'from' no válida: use RFC 3339 o AAAA-MM-DD"},
+	"Invalid 'to' date: use RFC 3339 or YYYY-MM-DD":                                     {pt: "Data 'to' inválida: use RFC 3339 ou AAAA-MM-DD", es: "Fecha 'to' no válida: use RFC 3339 o AAAA-MM-DD"},
+	"Invalid actor_id format":                                                           {pt: "Formato de actor_id inválido", es: "Formato de actor_id no válido"},
+	"Invalid allowed_networks: ":                                                        {pt: "allowed_networks inválido: ", es: "allowed_networks no válido: "},
+	"Invalid API key ID format":                                                         {pt: "Formato de ID de chave de API inválido", es: "Formato de ID de clave de API inválido"},
+	"Invalid API key scope: ":                                                           {pt: "Escopo de chave de API inválido: ", es: "Alcance de clave de API inválido: "},
+	"Invalid approver_id format":                                                        {pt: "Formato de approver_id inválido", es: "Formato de approver_id no válido"},
+	"Invalid asset ID format: ":                                                         {pt: "Formato de ID do ativo inválido: ", es: "Formato de ID del activo no válido: "},
+	"Invalid assigned_team_id: ":                                                        {pt: "assigned_team_id inválido: ", es: "assigned_team_id no válido: "},
+	"Invalid assigned_team_id: use a team ID":                                           {pt: "assigned_team_id inválido: use um ID de equipe", es: "assigned_team_id no válido: use un ID de equipo"},
+	"Invalid assigned_to_id: use a user ID or 'me'":                                     {pt: "assigned_to_id inválido: use um ID de usuário ou 'me'", es: "assigned_to_id no válido: use un ID de usuario o 'me'"},
+	"Invalid attribute mapping: ":                                                       {pt: "Mapeamento de atributos inválido: ", es: "Mapeo de atributos no válido: "},
+	"Invalid audit_campaign_id format":                                                  {pt: "Formato de audit_campaign_id inválido", es: "Formato de audit_campaign_id no válido"},
+	"Invalid business hours: at least one weekday is required":                          {pt: "Horário comercial inválido: informe pelo menos um dia da semana", es: "Horario comercial no válido: indique al menos un día de la semana"},
+	"Invalid business hours: start_time and end_time must be HH:MM with start_time before end_time": {pt: "Horário comercial inválido: start_time e end_time devem estar no formato HH:MM, com start_time antes de end_time", es: "Horario comercial no válido: start_time y end_time deben tener el formato HH:MM, con start_time antes de end_time"},
+	"Invalid category ID format":                                                  {pt: "Formato de ID de categoria inválido", es: "Formato de ID de categoría no válido"},
+	"Invalid category key: use lowercase letters, digits and underscores":         {pt: "Key de categoria inválida: use letras minúsculas, dígitos e sublinhados", es: "Key de categoría no válida: use letras minúsculas, dígitos y guiones bajos"},
+	"Invalid category: not an active risk category of the organization":           {pt: "Categoria inválida: não é uma categoria de risco ativa da organização", es: "Categoría no válida: no es una categoría de riesgo activa de la organización"},
+	"Invalid certificate ID format":                                               {pt: "Formato de ID do certificado inválido", es: "Formato de ID del certificado no válido"},
+	"Invalid color: use #RRGGBB or #RGB":                                          {pt: "Cor inválida: use #RRGGBB ou #RGB", es: "Color no válido: use #RRGGBB o #RGB"},
+	"Invalid comment ID format":                                                   {pt: "Formato de ID de comentário inválido", es: "Formato de ID de comentario inválido"},
+	"Invalid custom_domain: use a host name such as grc.example.com":              {pt: "custom_domain inválido: use um nome de host como grc.exemplo.com", es: "custom_domain no válido: use un nombre de host como grc.ejemplo.com"},
+	"Invalid domain: use a domain name such as example.com":                       {pt: "Domínio inválido: use um nome de domínio como example.com", es: "Dominio no válido: use un nombre de dominio como example.com"},
+	"Invalid ends_at format, use YYYY-MM-DD":                                      {pt: "Formato de ends_at inválido, use AAAA-MM-DD", es: "Formato de ends_at no válido, use AAAA-MM-DD"},
+	"Invalid entity ID format":                                                    {pt: "Formato de ID de entidade inválido", es: "Formato de ID de entidad no válido"},
+	"Invalid entity ID format: ":                                                  {pt: "Formato de ID de entidade inválido: ", es: "Formato de ID de entidad no válido: "},
+	"Invalid entity, use risk or assessment":                                      {pt: "Entidade inválida, use risk ou assessment", es: "Entidad no válida, use risk o assessment"},
+	"Invalid entity_type, must be risk, control or vendor":                        {pt: "entity_type inválido, deve ser risk, control ou vendor", es: "entity_type no válido, debe ser risk, control o vendor"},
+	"Invalid entity_type: use risk or assessment":                                 {pt: "entity_type inválido: use risk ou assessment", es: "entity_type no válido: use risk o assessment"},
+	"Invalid evidence draft ID format":                                            {pt: "Formato de ID de rascunho de evidência inválido", es: "Formato de ID de borrador de evidencia inválido"},
+	"Invalid evidence_draft_id format":                                            {pt: "Formato de evidence_draft_id inválido", es: "Formato de evidence_draft_id inválido"},
+	"Invalid expires_at format, use YYYY-MM-DD":                                   {pt: "Formato de expires_at inválido, use AAAA-MM-DD", es: "Formato de expires_at inválido, use AAAA-MM-DD"},
+	"Invalid export ID format":                                                    {pt: "Formato de ID da exportação inválido", es: "Formato de ID de exportación no válido"},
+	"Invalid filters: ":                                                           {pt: "Filtros inválidos: ", es: "Filtros no válidos: "},
+	"Invalid format: use pdf or xlsx":                                             {pt: "Formato inválido: use pdf ou xlsx", es: "Formato no válido: use pdf o xlsx"},
+	"Invalid from_name: line breaks are not allowed":                              {pt: "from_name inválido: quebras de linha não são permitidas", es: "from_name no válido: no se permiten saltos de línea"},
+	"Invalid granularity, use daily or weekly":                                    {pt: "Granularidade inválida, use daily ou weekly", es: "Granularidad inválida, use daily o weekly"},
+	"invalid guidance link URL: %s":                                               {pt: "URL de link da orientação inválida: %s", es: "URL de enlace de la guía no válida: %s"},
+	"Invalid guidance: ":                                                          {pt: "Orientação inválida: ", es: "Guía no válida: "},
+	"Invalid ID format":                                                           {pt: "Formato de ID inválido", es: "Formato de ID no válido"},
+	"Invalid job UUID format":                                                     {pt: "Formato de UUID do job inválido", es: "Formato de UUID del job no válido"},
+	"Invalid locale: use pt-BR, en or es":                                         {pt: "Idioma inválido: use pt-BR, en ou es", es: "Idioma no válido: use pt-BR, en o es"},
+	"Invalid logo_url: use a public https URL":                                    {pt: "logo_url inválido: use uma URL https pública", es: "logo_url no válido: use una URL https pública"},
+	"Invalid manifest signature":                                                  {pt: "Assinatura do manifesto inválida", es: "Firma del manifiesto no válida"},
+	"Invalid member_ids: ":                                                        {pt: "member_ids inválido: ", es: "member_ids no válido: "},
+	"Invalid notification ID format":                                              {pt: "Formato de ID de notificação inválido", es: "Formato de ID de notificación no válido"},
+	"Invalid or expired refresh token":                                            {pt: "Refresh token inválido ou expirado", es: "Refresh token inválido o expirado"},
+	"Invalid organization_id format":                                              {pt: "Formato de organization_id inválido", es: "Formato de organization_id no válido"},
+	"Invalid owner_team_id: ":                                                     {pt: "owner_team_id inválido: ", es: "owner_team_id no válido: "},
+	"Invalid owner_team_id: use a team ID":                                        {pt: "owner_team_id inválido: use um ID de equipe", es: "owner_team_id no válido: use un ID de equipo"},
+	"Invalid passkey":                                                             {pt: "Passkey inválida", es: "Passkey no válida"},
+	"Invalid passkey ID format":                                                   {pt: "Formato de ID da passkey inválido", es: "Formato de ID de passkey no válido"},
+	"Invalid password policy: ":                                                   {pt: "Política de senhas inválida: ", es: "Política de contraseñas no válida: "},
+	"Invalid primary_color: use #RRGGBB":                                          {pt: "primary_color inválida: use #RRGGBB", es: "primary_color no válido: use #RRGGBB"},
+	"Invalid recipients: ":                                                        {pt: "Destinatários inválidos: ", es: "Destinatarios no válidos: "},
+	"Invalid reference code, expected RISK-, FND- or POL- followed by a number":   {pt: "Código de referência inválido, esperado RISK-, FND- ou POL- seguido de um número", es: "Código de referencia no válido, se esperaba RISK-, FND- o POL- seguido de un número"},
+	"Invalid report type: use risk_register, compliance_delta or overdue_actions": {pt: "Tipo de relatório inválido: use risk_register, compliance_delta ou overdue_actions", es: "Tipo de informe no válido: use risk_register, compliance_delta u overdue_actions"},
+	"Invalid request ID format":                                                   {pt: "Formato de ID de solicitação inválido", es: "Formato de ID de solicitud no válido"},
+	"Invalid retention category: ":                                                {pt: "Categoria de retenção inválida: ", es: "Categoría de retención no válida: "},
+	"Invalid risk ID format: ":                                                    {pt: "Formato de ID do risco inválido: ", es: "Formato de ID del riesgo no válido: "},
+	"Invalid risk scoring matrix: ":                                               {pt: "Matriz de pontuação de riscos inválida: ", es: "Matriz de puntuación de riesgos no válida: "},
+	"Invalid role ID format":                                                      {pt: "Formato de ID do papel inválido", es: "Formato de ID del rol inválido"},
+	"Invalid SAML certificate: ":                                                  {pt: "Certificado SAML inválido: ", es: "Certificado SAML no válido: "},
+	"Invalid schedule ID format":                                                  {pt: "Formato de ID de agendamento inválido", es: "Formato de ID de programación no válido"},
+	"Invalid session ID format":                                                   {pt: "Formato de ID de sessão inválido", es: "Formato de ID de sesión inválido"},
+	"Invalid size, must be between 2 and 4":                                       {pt: "Tamanho inválido, deve estar entre 2 e 4", es: "Tamaño no válido, debe estar entre 2 y 4"},
+	"Invalid status code: use 2 to 30 lowercase letters, digits or underscores, starting with a letter": {pt: "Código de status inválido: use de 2 a 30 letras minúsculas, dígitos ou sublinhados, começando por uma letra", es: "Código de estado no válido: use de 2 a 30 letras minúsculas, dígitos o guiones bajos, comenzando por una letra"},
+	"Invalid status filter": {pt: "Filtro de status inválido", es: "Filtro de estado no válido"},
+	"Invalid status filter. Valid are: open, overdue, completed, all": {pt: "Filtro de status inválido. Válidos: open, overdue, completed, all", es: "Filtro de estado no válido. Válidos: open, overdue, completed, all"},
+	"Audit control not found":                                      {pt: "Controle de auditoria não encontrado", es: "Control de auditoría no encontrado"},
+	"Authentication token is missing required information.":        {pt: "O token de autenticação não contém as informações necessárias.", es: "Al token de autenticación le falta información requerida."},
+	"Authorization header format must be Bearer {token}":           {pt: "O cabeçalho Authorization deve estar no formato Bearer {token}", es: "El encabezado Authorization debe tener el formato Bearer {token}"},
+	"Authorization header required":                                {pt: "Cabeçalho Authorization obrigatório", es: "Se requiere el encabezado Authorization"},
+	"Business process not found or not part of your organization":  {pt: "Processo de negócio não encontrado ou não pertence à sua organização", es: "Proceso de negocio no encontrado o no pertenece a su organización"},
+	"C2M2 domain not found":                                        {pt: "Domínio C2M2 não encontrado", es: "Dominio C2M2 no encontrado"},
+	"Campaign is already closed":                                   {pt: "A campanha já está encerrada", es: "La campaña ya está cerrada"},
+	"Campaign is closed":                                           {pt: "A campanha está encerrada", es: "La campaña está cerrada"},
+	"Cannot add versions to a retired policy":                      {pt: "Não é possível adicionar versões a uma política aposentada", es: "No se pueden agregar versiones a una política retirada"},
+	"Configuration change not found":                               {pt: "Alteração de configuração não encontrada", es: "Cambio de configuración no encontrado"},
+	"confirmation token has expired":                               {pt: "o token de confirmação expirou", es: "el token de confirmación ha caducado"},
+	"confirmation token is invalid for this operation":             {pt: "o token de confirmação é inválido para esta operação", es: "el token de confirmación no es válido para esta operación"},
+	"confirmation token is required":                               {pt: "o token de confirmação é obrigatório", es: "el token de confirmación es obligatorio"},
+	"Control family not found in this framework":                   {pt: "Família de controles não encontrada neste framework", es: "Familia de controles no encontrada en este framework"},
+	"Control is not mapped to this risk":                           {pt: "O controle não está vinculado a este risco", es: "El control no está vinculado a este riesgo"},
+	"Control mapping not found":                                    {pt: "Mapeamento de controles não encontrado", es: "Mapeo de controles no encontrado"},
+	"Control not found in this framework":                          {pt: "Controle não encontrado neste framework", es: "Control no encontrado en este framework"},
+	"Control test plan not found or not part of your organization": {pt: "Plano de teste de controle não encontrado ou não pertence à sua organização", es: "Plan de prueba de control no encontrado o no pertenece a su organización"},
+	"CSV file is empty":                                            {pt: "O arquivo CSV está vazio", es: "El archivo CSV está vacío"},
+	"CSV file must have a header and at least one data row":        {pt: "O arquivo CSV deve ter um cabeçalho e pelo menos uma linha de dados", es: "El archivo CSV debe tener un encabezado y al menos una fila de datos"},
+	"CSV file not provided in 'file' field":                        {pt: "Arquivo CSV não enviado no campo 'file'", es: "Archivo CSV no enviado en el campo 'file'"},
+	"CSV file not provided in 'file' field: ":                      {pt: "Arquivo CSV não enviado no campo 'file': ", es: "Archivo CSV no enviado en el campo 'file': "},
+	"CSV file not provided...":                                     {pt: "Arquivo CSV não enviado...", es: "Archivo CSV no enviado..."},
+	"cvss_threshold must be a number between 0 and 10":             {pt: "cvss_threshold deve ser um número entre 0 e 10", es: "cvss_threshold debe ser un número entre 0 y 10"},
+	"Default control mappings cannot be removed":                   {pt: "Os mapeamentos de controles padrão não podem ser removidos", es: "Los mapeos de controles predeterminados no se pueden eliminar"},
+	"Default reviewer not found in your organization":              {pt: "Revisor padrão não encontrado na sua organização", es: "Revisor predeterminado no encontrado en su organización"},
+	"due_date must be after starts_at":                             {pt: "due_date deve ser posterior a starts_at", es: "due_date debe ser posterior a starts_at"},
+	"due_date must not be in the past":                             {pt: "due_date não pode estar no passado", es: "due_date no puede estar en el pasado"},
+	"Email attribute missing or empty in SAML assertion.":          {pt: "Atributo de e-mail ausente ou vazio na asserção SAML.", es: "Atributo de correo electrónico ausente o vacío en la aserción SAML."},
+	"Email not provided by Google":                                 {pt: "E-mail não fornecido pelo Google", es: "Correo electrónico no proporcionado por Google"},
+	"Email not provided or accessible from Github. Ensure 'user:email' scope is granted and a verified public email exists.": {pt: "E-mail não fornecido ou inacessível no GitHub. Verifique se o escopo 'user:email' foi concedido e se existe um e-mail público verificado.", es: "Correo electrónico no proporcionado o inaccesible en GitHub. Asegúrese de conceder el alcance 'user:email' y de tener un correo público verificado."},
+	"Erro ao processar arquivo de logo: ":                                             {en: "Error processing logo file: ", es: "Error al procesar el archivo de logotipo: "},
+	"Error processing evidence file: ":                                                {pt: "Erro ao processar o arquivo de evidência: ", es: "Error al procesar el archivo de evidencia: "},
+	"Evidence file not provided in 'file' field":                                      {pt: "Arquivo de evidência não enviado no campo 'file'", es: "Archivo de evidencia no enviado en el campo 'file'"},
+	"Evidence file rejected: malware detected":                                        {pt: "Arquivo de evidência rejeitado: malware detectado", es: "Archivo de evidencia rechazado: malware detectado"},
+	"Evidence portal link not found or not part of your organization":                 {pt: "Link do portal de evidências não encontrado ou não pertence à sua organização", es: "Enlace del portal de evidencias no encontrado o no pertenece a su organización"},
+	"Evidence request not found":                                                      {pt: "Solicitação de evidência não encontrada", es: "Solicitud de evidencia no encontrada"},
+	"Evidence request not found or not part of your organization":                     {pt: "Solicitação de evidência não encontrada ou não pertence à sua organização", es: "Solicitud de evidencia no encontrada o no pertenece a su organización"},
+	"Failed to import framework: ":                                                    {pt: "Falha ao importar o framework: ", es: "Error al importar el framework: "},
+	"Failed to parse multipart form: ":                                                {pt: "Falha ao processar o formulário multipart: ", es: "Error al procesar el formulario multipart: "},
+	"Failed to parse scan export: ":                                                   {pt: "Falha ao interpretar a exportação do scanner: ", es: "Error al interpretar la exportación del escáner: "},
+	"Failed to read CSV file":                                                         {pt: "Falha ao ler o arquivo CSV", es: "Error al leer el archivo CSV"},
+	"Failed to read CSV headers...":                                                   {pt: "Falha ao ler os cabeçalhos do CSV...", es: "Error al leer los encabezados del CSV..."},
+	"Failed to read CSV headers: ":                                                    {pt: "Falha ao ler os cabeçalhos do CSV: ", es: "Error al leer los encabezados del CSV: "},
+	"Failed to read uploaded file: ":                                                  {pt: "Falha ao ler o arquivo enviado: ", es: "Error al leer el archivo enviado: "},
+	"Failed to resolve route target: ":                                                {pt: "Falha ao resolver o destino da rota: ", es: "Error al resolver el destino de la ruta: "},
+	"Failed to scan evidence file for malware: ":                                      {pt: "Falha na verificação antivírus do arquivo de evidência: ", es: "Error en el análisis antivirus del archivo de evidencia: "},
+	"Failed to scan policy document for malware: ":                                    {pt: "Falha na verificação antivírus do documento da política: ", es: "Error en el análisis antivirus del documento de la política: "},
+	"Failed to validate evidence portal link":                                         {pt: "Falha ao validar o link do portal de evidências", es: "Error al validar el enlace del portal de evidencias"},
+	"Failed to validate questionnaire link":                                           {pt: "Falha ao validar o link do questionário", es: "Error al validar el enlace del cuestionario"},
+	"Falha ao processar formulário multipart: ":                                       {en: "Failed to parse multipart form: ", es: "Error al procesar el formulario multipart: "},
+	"Falha na verificação antivírus do arquivo de logo: ":                             {en: "Failed to scan logo file for malware: ", es: "Error en el análisis antivirus del archivo de logotipo: "},
+	"File is required":                                                                {pt: "O arquivo é obrigatório", es: "El archivo es obligatorio"},
+	"File not found":                                                                  {pt: "Arquivo não encontrado", es: "Archivo no encontrado"},
+	"File size exceeds limit of %d MB":                                                {pt: "O tamanho do arquivo excede o limite de %d MB", es: "El tamaño del archivo supera el límite de %d MB"},
+	"File type '%s' (detected: '%s') is not allowed":                                  {pt: "Tipo de arquivo '%s' (detectado: '%s') não permitido", es: "Tipo de archivo '%s' (detectado: '%s') no permitido"},
+	"File type '%s' (detected: '%s') is not allowed. Allowed types: %s":               {pt: "Tipo de arquivo '%s' (detectado: '%s') não permitido. Tipos permitidos: %s", es: "Tipo de archivo '%s' (detectado: '%s') no permitido. Tipos permitidos: %s"},
+	"Formato de Cor Primária inválido. Use #RRGGBB ou #RGB.":                          {en: "Invalid primary color format. Use #RRGGBB or #RGB.", es: "Formato de color primario no válido. Use #RRGGBB o #RGB."},
+	"Formato de Cor Secundária inválido. Use #RRGGBB ou #RGB.":                        {en: "Invalid secondary color format. Use #RRGGBB or #RGB.", es: "Formato de color secundario no válido. Use #RRGGBB o #RGB."},
+	"Formato de ID da organização inválido":                                           {en: "Invalid organization ID format", es: "Formato de ID de organización no válido"},
+	"Formato de ID do usuário inválido":                                               {en: "Invalid user ID format", es: "Formato de ID de usuario no válido"},
+	"Framework file not provided in 'file' field":                                     {pt: "Arquivo do framework não enviado no campo 'file'", es: "Archivo del framework no enviado en el campo 'file'"},
+	"Framework name is required (max 255 characters); provide it in the 'name' field": {pt: "O nome do framework é obrigatório (máx. 255 caracteres); informe-o no campo 'name'", es: "El nombre del framework es obligatorio (máx. 255 caracteres); indíquelo en el campo 'name'"},
+	"Framework not found":                                                             {pt: "Framework não encontrado", es: "Framework no encontrado"},
+	"Framework not found or has no controls":                                          {pt: "Framework não encontrado ou sem controles", es: "Framework no encontrado o sin controles"},
+	"from must be before to":                                                          {pt: "from deve ser anterior a to", es: "from debe ser anterior a to"},
+	"Identity provider not found":                                                     {pt: "Provedor de identidade não encontrado", es: "Proveedor de identidad no encontrado"},
+	"Identity provider not found for deletion":                                        {pt: "Provedor de identidade não encontrado para exclusão", es: "Proveedor de identidad no encontrado para eliminación"},
+	"Identity provider not found for update":                                          {pt: "Provedor de identidade não encontrado para atualização", es: "Proveedor de identidad no encontrado para actualización"},
+	"Insufficient privileges":                                                         {pt: "Privilégios insuficientes", es: "Privilegios insuficientes"},
+	"Internal Server Error - Panic Recovered":                                         {pt: "Erro interno do servidor", es: "Error interno del servidor"},
+	"Invalid '%s' date, use YYYY-MM-DD":                                               {pt: "Data '%s' inválida, use AAAA-MM-DD", es: "Fecha '%s' no válida, use AAAA-MM-DD"},
+	"Invalid answer for question %s: %s":                                              {pt: "Resposta inválida para a pergunta %s: %s", es: "Respuesta no válida para la pregunta %s: %s"},
+	"Invalid approval workflow ID format":                                             {pt: "Formato de ID do fluxo de aprovação inválido", es: "Formato de ID del flujo de aprobación no válido"},
+	"Invalid approver_id: ":                                                           {pt: "approver_id inválido: ", es: "approver_id no válido: "},
+	"Invalid assessment ID format":                                                    {pt: "Formato de ID da avaliação inválido", es: "Formato de ID de la evaluación no válido"},
+	"Invalid assessment_date format, use YYYY-MM-DD: ":                                {pt: "Formato de assessment_date inválido, use AAAA-MM-DD: ", es: "Formato de assessment_date no válido, use AAAA-MM-DD: "},
+	"Invalid asset ID format":                                                         {pt: "Formato de ID do ativo inválido", es: "Formato de ID del activo no válido"},
+	"Invalid AttributeMappingJSON format: ":                                           {pt: "Formato inválido de AttributeMappingJSON: ", es: "Formato de AttributeMappingJSON no válido: "},
+	"Invalid audit control ID format":                                                 {pt: "Formato de ID do controle de auditoria inválido", es: "Formato de ID del control de auditoría no válido"},
+	"Invalid audit_control_id format":                                                 {pt: "Formato de audit_control_id inválido", es: "Formato de audit_control_id no válido"},
+	"Invalid audit_control_id format: ":                                               {pt: "Formato de audit_control_id inválido: ", es: "Formato de audit_control_id no válido: "},
+	"Invalid backup code.":                                                            {pt: "Código de backup inválido.", es: "Código de respaldo no válido."},
+	"Invalid business process ID format":                                              {pt: "Formato de ID do processo de negócio inválido", es: "Formato de ID del proceso de negocio no válido"},
+	"Invalid c2m2_assessment_date format, use YYYY-MM-DD: ":                           {pt: "Formato de c2m2_assessment_date inválido, use AAAA-MM-DD: ", es: "Formato de c2m2_assessment_date no válido, use AAAA-MM-DD: "},
+	"Invalid campaign ID format":                                                      {pt: "Formato de ID da campanha inválido", es: "Formato de ID de la campaña no válido"},
+	"Invalid certifications: ":                                                        {pt: "Certificações inválidas: ", es: "Certificaciones no válidas: "},
+	"Invalid ConfigJSON format: ":                                                     {pt: "Formato inválido de ConfigJSON: ", es: "Formato de ConfigJSON no válido: "},
+	"Invalid configuration change ID format":                                          {pt: "Formato de ID da alteração de configuração inválido", es: "Formato de ID del cambio de configuración no válido"},
+	"Invalid control UUID format":                                                     {pt: "Formato de UUID do controle inválido", es: "Formato de UUID del control no válido"},
+	"Invalid control_id format":                                                       {pt: "Formato de control_id inválido", es: "Formato de control_id no válido"},
+	"Invalid default_reviewer_id format":                                              {pt: "Formato de default_reviewer_id inválido", es: "Formato de default_reviewer_id no válido"},
+	"Invalid domain ID format":                                                        {pt: "Formato de ID do domínio inválido", es: "Formato de ID del dominio no válido"},
+	"Invalid due_date format, use YYYY-MM-DD":                                         {pt: "Formato de due_date inválido, use AAAA-MM-DD", es: "Formato de due_date no válido, use AAAA-MM-DD"},
+	"Invalid durationMinutes, must be a positive integer (max 10080 for 7 days).":     {pt: "durationMinutes inválido, deve ser um inteiro positivo (máx. 10080, ou 7 dias).", es: "durationMinutes no válido, debe ser un entero positivo (máx. 10080, o 7 días)."},
+	"Invalid email or password":                                                       {pt: "E-mail ou senha inválidos", es: "Correo electrónico o contraseña no válidos"},
+	"Invalid entity_id format":                                                        {pt: "Formato de entity_id inválido", es: "Formato de entity_id no válido"},
+	"Invalid entity_type":                                                             {pt: "entity_type inválido", es: "entity_type no válido"},
+	"Invalid evidence portal link":                                                    {pt: "Link do portal de evidências inválido", es: "Enlace del portal de evidencias no válido"},
+	"Invalid evidence portal link ID format":                                          {pt: "Formato de ID do link do portal de evidências inválido", es: "Formato de ID del enlace del portal de evidencias no válido"},
+	"Invalid evidence request ID format":                                              {pt: "Formato de ID da solicitação de evidência inválido", es: "Formato de ID de la solicitud de evidencia no válido"},
+	"Invalid format, expected json or csv":                                            {pt: "Formato inválido, esperado json ou csv", es: "Formato no válido, se esperaba json o csv"},
+	"Invalid framework ID format":                                                     {pt: "Formato de ID do framework inválido", es: "Formato de ID del framework no válido"},
+	"Invalid framework ID format: ":                                                   {pt: "Formato de ID do framework inválido: ", es: "Formato de ID del framework no válido: "},
+	"Invalid framework_id format":                                                     {pt: "Formato de framework_id inválido", es: "Formato de framework_id no válido"},
+	"Invalid from format, expected RFC3339":                                           {pt: "Formato de from inválido, esperado RFC3339", es: "Formato de from no válido, se esperaba RFC3339"},
+	"Invalid group_by, expected endpoint, credential or hour":                         {pt: "group_by inválido, esperado endpoint, credential ou hour", es: "group_by no válido, se esperaba endpoint, credential u hour"},
+	"Invalid identity provider ID format":                                             {pt: "Formato de ID do provedor de identidade inválido", es: "Formato de ID del proveedor de identidad no válido"},
+	"Invalid IdP ID format":                                                           {pt: "Formato de ID do IdP inválido", es: "Formato de ID del IdP no válido"},
+	"Invalid invitation ID format":                                                    {pt: "Formato de ID do convite inválido", es: "Formato de ID de la invitación no válido"},
+	"Invalid item ID format":                                                          {pt: "Formato de ID do item inválido", es: "Formato de ID del elemento no válido"},
+	"Invalid item ID format: ":                                                        {pt: "Formato de ID do item inválido: ", es: "Formato de ID del elemento no válido: "},
+	"Invalid JSON in 'data' field: ":                                                  {pt: "JSON inválido no campo 'data': ", es: "JSON no válido en el campo 'data': "},
+	"Invalid limit, expected 1-500":                                                   {pt: "limit inválido, esperado 1-500", es: "limit no válido, se esperaba 1-500"},
+	"Invalid mapping ID format":                                                       {pt: "Formato de ID do mapeamento inválido", es: "Formato de ID del mapeo no válido"},
+	"Invalid notification route ID format":                                            {pt: "Formato de ID da rota de notificação inválido", es: "Formato de ID de la ruta de notificación no válido"},
+	"Invalid OAuth state":                                                             {pt: "Estado OAuth inválido", es: "Estado OAuth no válido"},
+	"Invalid or expired file URL":                                                     {pt: "URL de arquivo inválida ou expirada", es: "URL de archivo no válida o caducada"},
+	"Invalid or expired token":                                                        {pt: "Token inválido ou expirado", es: "Token no válido o caducado"},
+	"Invalid organization ID format":                                                  {pt: "Formato de ID da organização inválido", es: "Formato de ID de organización no válido"},
+	"Invalid owner_id format":                                                         {pt: "Formato de owner_id inválido", es: "Formato de owner_id no válido"},
+	"Invalid owner_id: ":                                                              {pt: "owner_id inválido: ", es: "owner_id no válido: "},
+	"Invalid OwnerID format":                                                          {pt: "Formato de OwnerID inválido", es: "Formato de OwnerID no válido"},
+	"Invalid OwnerID format for update":                                               {pt: "Formato de OwnerID inválido para atualização", es: "Formato de OwnerID no válido para la actualización"},
+	"Invalid password":                                                                {pt: "Senha inválida", es: "Contraseña no válida"},
+	"Invalid plan ID format":                                                          {pt: "Formato de ID do plano inválido", es: "Formato de ID del plan no válido"},
+	"Invalid policy ID format":                                                        {pt: "Formato de ID da política inválido", es: "Formato de ID de la política no válido"},
+	"Invalid policy_id format":                                                        {pt: "Formato de policy_id inválido", es: "Formato de policy_id no válido"},
+	"Invalid practice ID format in c2m2_practice_evaluations: %s":                     {pt: "Formato de ID da prática inválido em c2m2_practice_evaluations: %s", es: "Formato de ID de la práctica no válido en c2m2_practice_evaluations: %s"},
+	"Invalid questionnaire ID format":                                                 {pt: "Formato de ID do questionário inválido", es: "Formato de ID del cuestionario no válido"},
+	"Invalid questionnaire link":                                                      {pt: "Link do questionário inválido", es: "Enlace del cuestionario no válido"},
+	"Invalid request payload":                                                         {pt: "Payload da requisição inválido", es: "Carga útil de la solicitud no válida"},
+	"Invalid request payload: ":                                                       {pt: "Payload da requisição inválido: ", es: "Carga útil de la solicitud no válida: "},
+	"Invalid resource ID format":                                                      {pt: "Formato de ID do recurso inválido", es: "Formato de ID del recurso no válido"},
+	"Invalid resource type, must be one of: risk, assessment":                         {pt: "Tipo de recurso inválido, deve ser um de: risk, assessment", es: "Tipo de recurso no válido, debe ser uno de: risk, assessment"},
+	"Invalid reviewer_id format":                                                      {pt: "Formato de reviewer_id inválido", es: "Formato de reviewer_id no válido"},
+	"Invalid risk ID format":                                                          {pt: "Formato de ID do risco inválido", es: "Formato de ID del riesgo no válido"},
+	"Invalid risk notification filter ID format":                                      {pt: "Formato de ID do filtro de notificação de risco inválido", es: "Formato de ID del filtro de notificación de riesgo no válido"},
+	"Invalid secret ID format":                                                        {pt: "Formato de ID do segredo inválido", es: "Formato de ID del secreto no válido"},
+	"Invalid slug: use 3-63 lowercase letters, digits or hyphens":                     {pt: "Slug inválido: use de 3 a 63 letras minúsculas, dígitos ou hífens", es: "Slug no válido: use de 3 a 63 letras minúsculas, dígitos o guiones"},
+	"Invalid stakeholder UserID format":                                               {pt: "Formato de UserID da parte interessada inválido", es: "Formato de UserID de la parte interesada no válido"},
+	"Invalid starts_at format, use YYYY-MM-DD":                                        {pt: "Formato de starts_at inválido, use AAAA-MM-DD", es: "Formato de starts_at no válido, use AAAA-MM-DD"},
+	"Invalid status '%s' for practice ID %s":                                          {pt: "Status '%s' inválido para a prática %s", es: "Estado '%s' no válido para la práctica %s"},
+	"Invalid status filter: use ok, rotation_due or max_age_exceeded":                 {pt: "Filtro de situação inválido: use ok, rotation_due ou max_age_exceeded", es: "Filtro de estado no válido: use ok, rotation_due o max_age_exceeded"},
+	"Invalid status: ":                                                                {pt: "Status inválido: ", es: "Estado no válido: "},
+	"Invalid tag ID format":                                                           {pt: "Formato de ID da tag inválido", es: "Formato de ID de etiqueta no válido"},
+	"Invalid tag name: it must not be blank or contain commas":                        {pt: "Nome de tag inválido: não pode ser vazio nem conter vírgulas", es: "Nombre de etiqueta no válido: no puede estar vacío ni contener comas"},
+	"Invalid team ID format":                                                          {pt: "Formato de ID de equipe inválido", es: "Formato de ID de equipo no válido"},
+	"Invalid timezone: use an IANA name such as America/Sao_Paulo":                    {pt: "Fuso horário inválido: use um nome IANA como America/Sao_Paulo", es: "Zona horaria no válida: use un nombre IANA como America/Sao_Paulo"},
+	"Invalid to format, expected RFC3339":                                             {pt: "Formato de to inválido, esperado RFC3339", es: "Formato de to no válido, se esperaba RFC3339"},
+	"Invalid token: ":                                                                 {pt: "Token inválido: ", es: "Token no válido: "},
+	"Invalid TOTP token":                                                              {pt: "Código TOTP inválido", es: "Código TOTP no válido"},
+	"Invalid types, must be risk, control, assessment, policy or vendor":              {pt: "types inválido, deve ser risk, control, assessment, policy ou vendor", es: "types no válido, debe ser risk, control, assessment, policy o vendor"},
+	"Invalid unread filter: use true or false":                                        {pt: "Filtro unread inválido: use true ou false", es: "Filtro unread no válido: use true o false"},
+	"Invalid user ID format":                                                          {pt: "Formato de ID do usuário inválido", es: "Formato de ID de usuario no válido"},
+	"Invalid user ID format: ":                                                        {pt: "Formato de ID de usuário inválido: ", es: "Formato de ID de usuario no válido: "},
+	"Invalid user in user_ids (%s): %s":                                               {pt: "Usuário inválido em user_ids (%s): %s", es: "Usuario no válido en user_ids (%s): %s"},
+	"Invalid user role format in token":                                               {pt: "Formato do papel do usuário inválido no token", es: "Formato del rol de usuario no válido en el token"},
+	"Invalid user: ":                                                                  {pt: "Usuário inválido: ", es: "Usuario no válido: "},
+	"Invalid user_ids: ":                                                              {pt: "user_ids inválido: ", es: "user_ids no válido: "},
+	"Invalid UserID format":                                                           {pt: "Formato de UserID inválido", es: "Formato de UserID no válido"},
+	"Invalid UserID format for stakeholder":                                           {pt: "Formato de UserID inválido para a parte interessada", es: "Formato de UserID no válido para la parte interesada"},
+	"Invalid user_id format":                                                          {pt: "Formato de user_id inválido", es: "Formato de user_id no válido"},
+	"Invalid vendor ID format":                                                        {pt: "Formato de ID do fornecedor inválido", es: "Formato de ID del proveedor no válido"},
+	"Invalid version ID format":                                                       {pt: "Formato de ID da versão inválido", es: "Formato de ID de la versión no válido"},
+	"Invalid version_id format":                                                       {pt: "Formato de version_id inválido", es: "Formato de version_id no válido"},
+	"Invalid view ID format":                                                          {pt: "Formato de ID da visão inválido", es: "Formato de ID de vista no válido"},
+	"Invalid view_id format":                                                          {pt: "Formato de view_id inválido", es: "Formato de view_id no válido"},
+	"Invalid vulnerability ID format":                                                 {pt: "Formato de ID da vulnerabilidade inválido", es: "Formato de ID de la vulnerabilidad no válido"},
+	"Invalid waiver ID format":                                                        {pt: "Formato de ID da exceção inválido", es: "Formato de ID de la excepción inválido"},
+	"Invalid webhook ID format":                                                       {pt: "Formato de ID do webhook inválido", es: "Formato de ID del webhook no válido"},
+	"Invalid year":                                                                    {pt: "Ano inválido", es: "Año no válido"},
+	"Invalid, revoked or expired API key":                                             {pt: "Chave de API inválida, revogada ou expirada", es: "Clave de API inválida, revocada o expirada"},
+	"Invitation has already been accepted":                                            {pt: "O convite já foi aceito", es: "La invitación ya fue aceptada"},
+	"Invitation has been revoked":                                                     {pt: "O convite foi revogado", es: "La invitación fue revocada"},
+	"Invitation is no longer valid":                                                   {pt: "O convite não é mais válido", es: "La invitación ya no es válida"},
+	"Invitation not found":                                                            {pt: "Convite não encontrado", es: "Invitación no encontrada"},
+	"JSON de branding ('data') inválido: ":                                            {en: "Invalid branding JSON ('data'): ", es: "JSON de branding ('data') no válido: "},
+	"Local file storage is not enabled":                                               {pt: "O armazenamento local de arquivos não está habilitado", es: "El almacenamiento local de archivos no está habilitado"},
+	"Login page is not configured for this organization":                              {pt: "A tela de login não está configurada para esta organização", es: "La página de inicio de sesión no está configurada para esta organización"},
+	"Login page not found":                                                            {pt: "Tela de login não encontrada", es: "Página de inicio de sesión no encontrada"},
+	"Logo file is not a valid image":                                                  {pt: "O arquivo de logo não é uma imagem válida", es: "El archivo de logo no es una imagen válida"},
+	"Logo file rejected: malware detected":                                            {pt: "Arquivo de logo rejeitado: malware detectado", es: "Archivo de logo rechazado: malware detectado"},
+	"logo_file is required":                                                           {pt: "logo_file é obrigatório", es: "logo_file es obligatorio"},
+	"Manifest belongs to another organization":                                        {pt: "O manifesto pertence a outra organização", es: "El manifiesto pertenece a otra organización"},
+	"max_age_days must be between 0 and %d":                                           {pt: "max_age_days deve estar entre 0 e %d", es: "max_age_days debe estar entre 0 y %d"},
+	"min_length must be between %d and %d":                                            {pt: "min_length deve estar entre %d e %d", es: "min_length debe estar entre %d y %d"},
+	"Missing 'data' field in multipart form":                                          {pt: "Campo 'data' ausente no formulário multipart", es: "Falta el campo 'data' en el formulario multipart"},
+	"Missing OAuth state cookie":                                                      {pt: "Cookie de estado OAuth ausente", es: "Falta la cookie de estado OAuth"},
+	"Missing required CSV header: %s":                                                 {pt: "Cabeçalho obrigatório ausente no CSV: %s", es: "Falta el encabezado obligatorio del CSV: %s"},
+	"name query parameter is required":                                                {pt: "O parâmetro de consulta name é obrigatório", es: "El parámetro de consulta name es obligatorio"},
+	"New password must be different from the current password":                        {pt: "A nova senha deve ser diferente da senha atual", es: "La nueva contraseña debe ser diferente de la contraseña actual"},
+	"New user registration via global Github SSO is disabled.":                        {pt: "O cadastro de novos usuários via SSO global do GitHub está desabilitado.", es: "El registro de nuevos usuarios mediante el SSO global de GitHub está deshabilitado."},
+	"New user registration via global Google SSO is disabled. Please use an organization-specific login or contact support.": {pt: "O cadastro de novos usuários via SSO global do Google está desabilitado. Use o login específico da organização ou contate o suporte.", es: "El registro de nuevos usuarios mediante el SSO global de Google está deshabilitado. Use el inicio de sesión de su organización o contacte con soporte."},
+	"New user registration via this SAML provider is disabled.":                                                              {pt: "O cadastro de novos usuários via este provedor SAML está desabilitado.", es: "El registro de nuevos usuarios mediante este proveedor SAML está deshabilitado."},
+	"No credential policy configured for your organization":                                                                  {pt: "Nenhuma política de credenciais configurada para a sua organização", es: "No hay una política de credenciales configurada para su organización"},
+	"No entity found with this reference code":                                                                               {pt: "Nenhuma entidade encontrada com este código de referência", es: "No se encontró ninguna entidad con este código de referencia"},
+	"No evidence access policy configured for your organization":                                                             {pt: "Nenhuma política de acesso às evidências configurada para sua organização", es: "No hay ninguna política de acceso a las evidencias configurada para su organización"},
+	"No SAML service provider certificate is configured for this identity provider":                                          {pt: "Nenhum certificado do provedor de serviço SAML configurado para este IdP", es: "Ningún certificado del proveedor de servicio SAML configurado para este IdP"},
+	"Notification not found": {pt: "Notificação não encontrada", es: "Notificación no encontrada"},
+	"Notification route not found or not part of your organization":                                                         {pt: "Rota de notificação não encontrada ou não pertence à sua organização", es: "Ruta de notificación no encontrada o no pertenece a su organización"},
+	"Não é possível desativar o último administrador/gerente ativo da organização.":                                         {en: "Cannot deactivate the organization's last active admin/manager.", es: "No se puede desactivar al último administrador/gerente activo de la organización."},
+	"Não é possível rebaixar o último administrador/gerente da organização.":                                                {en: "Cannot demote the organization's last admin/manager.", es: "No se puede degradar al último administrador/gerente de la organización."},
+	"O sistema já parece estar configurado. Setup não pode ser executado novamente.":                                        {en: "The system already appears to be configured. Setup cannot be run again.", es: "El sistema ya parece estar configurado. El setup no se puede ejecutar de nuevo."},
+	"OAuth authorization code not found or access denied":                                                                   {pt: "Código de autorização OAuth não encontrado ou acesso negado", es: "Código de autorización OAuth no encontrado o acceso denegado"},
+	"objectKey query parameter is required":                                                                                 {pt: "O parâmetro de consulta objectKey é obrigatório", es: "El parámetro de consulta objectKey es obligatorio"},
+	"One or more assets not found in your organization":                                                                     {pt: "Um ou mais ativos não foram encontrados na sua organização", es: "Uno o más activos no se encontraron en su organización"},
+	"One or more audit controls were not found":                                                                             {pt: "Um ou mais controles de auditoria não foram encontrados", es: "No se encontraron uno o más controles de auditoría"},
+	"One or more compensating controls were not found":                                                                      {pt: "Um ou mais controles compensatórios não foram encontrados", es: "Uno o más controles compensatorios no fueron encontrados"},
+	"One or more controls were not found in this framework":                                                                 {pt: "Um ou mais controles não foram encontrados neste framework", es: "No se encontraron uno o más controles en este framework"},
+	"One or more entities were not found in the organization":                                                               {pt: "Uma ou mais entidades não foram encontradas na organização", es: "Una o más entidades no se encontraron en la organización"},
+	"One or more framework IDs do not exist":                                                                                {pt: "Um ou mais IDs de framework não existem", es: "Uno o más IDs de framework no existen"},
+	"One or more participants not found or inactive in your organization":                                                   {pt: "Um ou mais participantes não foram encontrados ou estão inativos na sua organização", es: "Uno o más participantes no se encontraron o están inactivos en su organización"},
+	"One or more risks were not found in your organization":                                                                 {pt: "Um ou mais riscos não foram encontrados na sua organização", es: "No se encontraron uno o más riesgos en su organización"},
+	"Only admins can anonymize users":                                                                                       {pt: "Somente admins podem anonimizar usuários", es: "Solo los administradores pueden anonimizar usuarios"},
+	"Only admins can grant the admin role":                                                                                  {pt: "Somente admins podem conceder o papel de admin", es: "Solo los administradores pueden conceder el rol de administrador"},
+	"Only admins can manage organization exports":                                                                           {pt: "Somente admins podem gerenciar as exportações da organização", es: "Solo los administradores pueden gestionar las exportaciones de la organización"},
+	"Only admins or managers can change shared views":                                                                       {pt: "Somente admins ou managers podem alterar visões compartilhadas", es: "Solo los administradores o gerentes pueden modificar vistas compartidas"},
+	"Only Admins or Managers can change the risk owner.":                                                                    {pt: "Somente Admins ou Managers podem alterar o responsável pelo risco.", es: "Solo los Admins o Managers pueden cambiar el responsable del riesgo."},
+	"Only approved policies can be acknowledged":                                                                            {pt: "Somente políticas aprovadas podem receber aceite", es: "Solo se pueden aceptar políticas aprobadas"},
+	"Only draft questionnaires can be edited; create a new questionnaire instead":                                           {pt: "Somente questionários em rascunho podem ser editados; crie um novo questionário", es: "Solo se pueden editar cuestionarios en borrador; cree un cuestionario nuevo"},
+	"Only draft questionnaires can be published":                                                                            {pt: "Somente questionários em rascunho podem ser publicados", es: "Solo se pueden publicar cuestionarios en borrador"},
+	"Only organization admins can approve the public trust center":                                                          {pt: "Somente admins da organização podem aprovar o trust center público", es: "Solo los admins de la organización pueden aprobar el trust center público"},
+	"Only pending or active waivers can be revoked":                                                                         {pt: "Somente exceções pendentes ou ativas podem ser revogadas", es: "Solo las excepciones pendientes o activas pueden ser revocadas"},
+	"Only published questionnaires can be sent":                                                                             {pt: "Somente questionários publicados podem ser enviados", es: "Solo se pueden enviar cuestionarios publicados"},
+	"Only submitted evidence requests can be reviewed":                                                                      {pt: "Somente solicitações de evidência enviadas podem ser revisadas", es: "Solo se pueden revisar solicitudes de evidencia enviadas"},
+	"Only the assessor assigned to this assessment can acknowledge its reminders":                                           {pt: "Apenas o avaliador atribuído a esta avaliação pode responder aos lembretes", es: "Solo el evaluador asignado a esta evaluación puede responder a los recordatorios"},
+	"Only the assigned assessor, admins or managers can view the reminder history":                                          {pt: "Apenas o avaliador atribuído, administradores ou gerentes podem ver o histórico de lembretes", es: "Solo el evaluador asignado, administradores o gerentes pueden ver el historial de recordatorios"},
+	"Only the author or an admin can delete this comment":                                                                   {pt: "Apenas o autor ou um admin pode excluir este comentário", es: "Solo el autor o un administrador puede eliminar este comentario"},
+	"Only the campaign's leads and assessors can record assessments in it":                                                  {pt: "Somente os líderes e avaliadores da auditoria podem registrar avaliações nela", es: "Solo los líderes y evaluadores de la auditoría pueden registrar evaluaciones en ella"},
+	"Only the designated approver can decide this waiver":                                                                   {pt: "Somente o aprovador designado pode decidir esta exceção", es: "Solo el aprobador designado puede decidir esta excepción"},
+	"Only the designated approver, an admin other than the requester, can decide this request":                              {pt: "Apenas o aprovador designado, um administrador diferente do solicitante, pode decidir esta solicitação", es: "Solo el aprobador designado, un administrador distinto del solicitante, puede decidir esta solicitud"},
+	"Only the framework coordinators can submit assessments for this framework; you can comment and upload evidence drafts": {pt: "Apenas os coordenadores do framework podem registrar avaliações deste framework; você pode comentar e enviar rascunhos de evidência", es: "Solo los coordinadores del framework pueden registrar evaluaciones de este framework; puede comentar y enviar borradores de evidencia"},
+	"Only the policy owner, admins or managers can manage this policy":                                                      {pt: "Somente o responsável pela política, admins ou managers podem gerenciá-la", es: "Solo el responsable de la política, los admins o los managers pueden gestionarla"},
+	"Only the requester can cancel this request":                                                                            {pt: "Apenas o solicitante pode cancelar esta solicitação", es: "Solo el solicitante puede cancelar esta solicitud"},
+	"Organization export is not available for download":                                                                     {pt: "A exportação da organização não está disponível para download", es: "La exportación de la organización no está disponible para descarga"},
+	"Organization export not found":                                                                                         {pt: "Exportação da organização não encontrada", es: "Exportación de la organización no encontrada"},
+	"Organization ID not found in token":                                                                                    {pt: "ID da organização não encontrado no token", es: "ID de organización no encontrado en el token"},
+	"Organization ID not found in token for fetching assessments":                                                           {pt: "ID da organização não encontrado no token para buscar avaliações", es: "ID de organización no encontrado en el token para obtener evaluaciones"},
+	"Organization not found":                                                                                                {pt: "Organização não encontrada", es: "Organización no encontrada"},
+	"Organização não encontrada":                                                                                            {en: "Organization not found", es: "Organización no encontrada"},
+	"Owner not found or not part of your organization":                                                                      {pt: "Responsável não encontrado ou não pertence à sua organização", es: "Responsable no encontrado o no pertenece a su organización"},
+	"owner_id is required for reassign_owner and must be a user ID":                                                         {pt: "owner_id é obrigatório para reassign_owner e deve ser o ID de um usuário", es: "owner_id es obligatorio para reassign_owner y debe ser el ID de un usuario"},
+	"owner_id must be an active user of the organization":                                                                   {pt: "owner_id deve ser um usuário ativo da organização", es: "owner_id debe ser un usuario activo de la organización"},
+	"Parent organization not found":                                                                                         {pt: "Organização pai não encontrada", es: "Organización principal no encontrada"},
+	"Passkey challenge is invalid or has expired":                                                                           {pt: "O desafio da passkey é inválido ou expirou", es: "El desafío de la passkey no es válido o ha expirado"},
+	"Passkey challenge is invalid or has expired; sign in again":                                                            {pt: "O desafio da passkey é inválido ou expirou; entre novamente", es: "El desafío de la passkey no es válido o ha expirado; inicie sesión de nuevo"},
+	"Passkey not found in your account":                                                                                     {pt: "Passkey não encontrada na sua conta", es: "Passkey no encontrada en su cuenta"},
+	"Passkey registration could not be verified: ":                                                                          {pt: "Não foi possível verificar o registro da passkey: ", es: "No se pudo verificar el registro de la passkey: "},
+	"Passkeys are not configured on this server":                                                                            {pt: "As passkeys não estão configuradas neste servidor", es: "Las passkeys no están configuradas en este servidor"},
+	"Password does not meet the password policy":                                                                            {pt: "A senha não atende à política de senhas", es: "La contraseña no cumple la política de contraseñas"},
+	"Password has expired and must be changed":                                                                              {pt: "A senha expirou e precisa ser trocada", es: "La contraseña ha caducado y debe cambiarse"},
+	"Password login can only be disabled when the organization has an active identity provider":                             {pt: "O login por senha só pode ser desabilitado quando a organização tiver um provedor de identidade ativo", es: "El inicio de sesión con contraseña solo puede desactivarse cuando la organización tiene un proveedor de identidad activo"},
+	"Password login is disabled for your organization; use single sign-on":                                                  {pt: "O login por senha está desabilitado para a sua organização; use o login único (SSO)", es: "El inicio de sesión con contraseña está desactivado para su organización; use el inicio de sesión único (SSO)"},
+	"Payload da requisição inválido: ":                                                                                      {en: "Invalid request payload: ", es: "Carga útil de la solicitud no válida: "},
+	"Payload inválido: ":                                                                                                    {en: "Invalid payload: ", es: "Carga útil no válida: "},
+	"Policy document not provided in 'file' field: ":                                                                        {pt: "Documento da política não enviado no campo 'file': ", es: "Documento de la política no enviado en el campo 'file': "},
+	"Policy document rejected: malware detected":                                                                            {pt: "Documento da política rejeitado: malware detectado", es: "Documento de la política rechazado: malware detectado"},
+	"Policy has no approved version to report on":                                                                           {pt: "A política não tem versão aprovada para relatar", es: "La política no tiene una versión aprobada sobre la que informar"},
+	"Policy not found or not part of your organization":                                                                     {pt: "Política não encontrada ou não pertence à sua organização", es: "Política no encontrada o no pertenece a su organización"},
+	"Policy version not found":                                                                                              {pt: "Versão da política não encontrada", es: "Versión de la política no encontrada"},
+	"Provide a metadata XML in 'file' or a JSON body with metadata_url: ":                                                   {pt: "Envie um XML de metadados em 'file' ou um corpo JSON com metadata_url: ", es: "Envíe un XML de metadatos en 'file' o un cuerpo JSON con metadata_url: "},
+	"Provide a valid email address":                                                                                         {pt: "Informe um endereço de email válido", es: "Indique una dirección de correo electrónico válida"},
+	"Provide either evidence_file or evidence_draft_id, not both":                                                           {pt: "Informe evidence_file ou evidence_draft_id, não ambos", es: "Indique evidence_file o evidence_draft_id, no ambos"},
+	"Provide either slug or domain":                                                                                         {pt: "Informe o slug ou o domínio", es: "Indique el slug o el dominio"},
+	"Provide either user_ids or filter":                                                                                     {pt: "Informe user_ids ou filter", es: "Indique user_ids o filter"},
+	"Provide user_ids or set all_users to true":                                                                             {pt: "Informe user_ids ou defina all_users como true", es: "Indique user_ids o establezca all_users en true"},
+	"Question %s is not part of this questionnaire":                                                                         {pt: "A pergunta %s não faz parte deste questionário", es: "La pregunta %s no forma parte de este cuestionario"},
+	"Questionnaire has already been submitted":                                                                              {pt: "O questionário já foi respondido", es: "El cuestionario ya fue enviado"},
+	"Questionnaire has been sent to vendors; archive it instead":                                                            {pt: "O questionário já foi enviado a fornecedores; arquive-o", es: "El cuestionario ya se envió a proveedores; archívelo en su lugar"},
+	"Questionnaire invitation not found or not part of your organization":                                                   {pt: "Convite de questionário não encontrado ou não pertence à sua organização", es: "Invitación de cuestionario no encontrada o no pertenece a su organización"},
+	"Questionnaire not found or not part of your organization":                                                              {pt: "Questionário não encontrado ou não pertence à sua organização", es: "Cuestionario no encontrado o no pertenece a su organización"},
+	"recipient_email is required when the vendor has no contact_email":                                                      {pt: "recipient_email é obrigatório quando o fornecedor não tem contact_email", es: "recipient_email es obligatorio cuando el proveedor no tiene contact_email"},
+	"Record not found in the trash":                                                                                         {pt: "Registro não encontrado na lixeira", es: "Registro no encontrado en la papelera"},
+	"Refresh token has already been used; sign in again":                                                                    {pt: "O refresh token já foi usado; faça login novamente", es: "El refresh token ya fue usado; inicie sesión de nuevo"},
+	"replaced_by must be another active status of the entity":                                                               {pt: "replaced_by deve ser outro status ativo da entidade", es: "replaced_by debe ser otro estado activo de la entidad"},
+	"Report schedule deleted successfully":                                                                                  {pt: "Agendamento de relatório excluído com sucesso", es: "Programación de informe eliminada correctamente"},
+	"Report schedule not found":                                                                                             {pt: "Agendamento de relatório não encontrado", es: "Programación de informe no encontrada"},
+	"Request body is too large for an idempotent request":                                                                   {pt: "Corpo da requisição grande demais para uma requisição idempotente", es: "Cuerpo de la solicitud demasiado grande para una solicitud idempotente"},
+	"Required questions are unanswered":                                                                                     {pt: "Há perguntas obrigatórias sem resposta", es: "Hay preguntas obligatorias sin responder"},
+	"Resource not found or not part of your organization":                                                                   {pt: "Recurso não encontrado ou não pertence à sua organização", es: "Recurso no encontrado o no pertenece a su organización"},
+	"retention_days must be between %d and %d":                                                                              {pt: "retention_days deve estar entre %d e %d", es: "retention_days debe estar entre %d y %d"},
+	"Reviewer not found in your organization":                                                                               {pt: "Revisor não encontrado na sua organização", es: "Revisor no encontrado en su organización"},
+	"Risk category deleted successfully":                                                                                    {pt: "Categoria de risco excluída com sucesso", es: "Categoría de riesgo eliminada con éxito"},
+	"Risk category not found":                                                                                               {pt: "Categoria de risco não encontrada", es: "Categoría de riesgo no encontrada"},
+	"Risk must have an owner assigned before submitting for acceptance":                                                     {pt: "O risco precisa ter um responsável antes de ser submetido para aceitação", es: "El riesgo debe tener un responsable asignado antes de enviarlo para aceptación"},
+	"Risk not found or not part of your organization":                                                                       {pt: "Risco não encontrado ou não pertence à sua organização", es: "Riesgo no encontrado o no pertenece a su organización"},
+	"Risk not found or not part of your organization, cannot remove stakeholder.":                                           {pt: "Risco não encontrado ou não pertence à sua organização; não é possível remover a parte interessada.", es: "Riesgo no encontrado o no pertenece a su organización; no se puede quitar la parte interesada."},
+	"Risk notification filter not found or not part of your organization":                                                   {pt: "Filtro de notificação de risco não encontrado ou não pertence à sua organização", es: "Filtro de notificación de riesgo no encontrado o no pertenece a su organización"},
+	"Risk scoring matrix not found":                                                                                         {pt: "Matriz de pontuação de riscos não encontrada", es: "Matriz de puntuación de riesgos no encontrada"},
+	"Risks at or above the acceptance threshold must be accepted through the approval workflow":                             {pt: "Riscos no limiar de aceitação ou acima dele devem ser aceitos pelo workflow de aprovação", es: "Los riesgos en el umbral de aceptación o por encima deben aceptarse mediante el flujo de aprobación"},
+	"rotation_interval_days exceeds the organization's maximum credential age of %d days":                                   {pt: "rotation_interval_days excede a idade máxima de credenciais da organização, de %d dias", es: "rotation_interval_days supera la antigüedad máxima de credenciales de la organización, de %d días"},
+	"SAML certificate limit reached; remove an unused one first":                                                            {pt: "Limite de certificados SAML atingido; remova um que não esteja em uso", es: "Límite de certificados SAML alcanzado; elimine uno que no esté en uso"},
+	"SAML certificate not found":                                                                                            {pt: "Certificado SAML não encontrado", es: "Certificado SAML no encontrado"},
+	"SAML certificate removed successfully":                                                                                 {pt: "Certificado SAML removido com sucesso", es: "Certificado SAML eliminado correctamente"},
+	"SAML identity provider not found":                                                                                      {pt: "Provedor de identidade SAML não encontrado", es: "Proveedor de identidad SAML no encontrado"},
+	"Saved view not found":                                                                                                  {pt: "Visão salva não encontrada", es: "Vista guardada no encontrada"},
+	"Scan export not provided in 'file' field":                                                                              {pt: "Exportação do scanner não enviada no campo 'file'", es: "Exportación del escáner no enviada en el campo 'file'"},
+	"Score rubric not found":                                                                                                {pt: "Rubrica de pontuação não encontrada", es: "Rúbrica de puntuación no encontrada"},
+	"Scores must satisfy conformant >= partially_conformant >= non_conformant":                                              {pt: "As pontuações devem respeitar conformant >= partially_conformant >= non_conformant", es: "Las puntuaciones deben cumplir conformant >= partially_conformant >= non_conformant"},
+	"Search query must have at least 2 characters":                                                                          {pt: "A busca deve ter pelo menos 2 caracteres", es: "La búsqueda debe tener al menos 2 caracteres"},
+	"Search query must have at most 200 characters":                                                                         {pt: "A busca deve ter no máximo 200 caracteres", es: "La búsqueda debe tener como máximo 200 caracteres"},
+	"Secret not found or not part of your organization":                                                                     {pt: "Segredo não encontrado ou não pertence à sua organização", es: "Secreto no encontrado o no pertenece a su organización"},
+	"Seeded frameworks are read-only":                                                                                       {pt: "Frameworks padrão são somente leitura", es: "Los frameworks predeterminados son de solo lectura"},
+	"Service provider certificates only apply to SAML identity providers":                                                   {pt: "Certificados do provedor de serviço só se aplicam a IdPs SAML", es: "Los certificados del proveedor de servicio solo aplican a IdPs SAML"},
+	"Session has been revoked":                                                                                              {pt: "A sessão foi encerrada", es: "La sesión fue cerrada"},
+	"Session not found":                                                                                                     {pt: "Sessão não encontrada", es: "Sesión no encontrada"},
+	"Setting not found or not updatable: ":                                                                                  {pt: "Configuração não encontrada ou não atualizável: ", es: "Configuración no encontrada o no actualizable: "},
+	"Slug is already in use by another organization":                                                                        {pt: "O slug já está em uso por outra organização", es: "El slug ya está en uso por otra organización"},
+	"Some secrets were not found or are not part of your organization":                                                      {pt: "Alguns segredos não foram encontrados ou não pertencem à sua organização", es: "Algunos secretos no se encontraron o no pertenecen a su organización"},
+	"Source and target controls must belong to different frameworks":                                                        {pt: "Os controles de origem e destino devem pertencer a frameworks diferentes", es: "Los controles de origen y destino deben pertenecer a frameworks distintos"},
+	"Source and target must be two existing controls":                                                                       {pt: "Origem e destino devem ser dois controles existentes", es: "Origen y destino deben ser dos controles existentes"},
+	"source_framework_id must differ from the projected framework":                                                          {pt: "source_framework_id deve ser diferente do framework projetado", es: "source_framework_id debe ser distinto del framework proyectado"},
+	"source_framework_id query parameter is required and must be a valid UUID":                                              {pt: "O parâmetro de consulta source_framework_id é obrigatório e deve ser um UUID válido", es: "El parámetro de consulta source_framework_id es obligatorio y debe ser un UUID válido"},
+	"Stakeholder association not found":                                                                                     {pt: "Vínculo de parte interessada não encontrado", es: "Vínculo de parte interesada no encontrado"},
+	"Status change not allowed: ":                                                                                           {pt: "Mudança de status não permitida: ", es: "Cambio de estado no permitido: "},
+	"status is required for change_status":                                                                                  {pt: "status é obrigatório para change_status", es: "status es obligatorio para change_status"},
+	"Status not found":                                                                                                      {pt: "Status não encontrado", es: "Estado no encontrado"},
+	"Tag deleted successfully":                                                                                              {pt: "Tag excluída com sucesso", es: "Etiqueta eliminada correctamente"},
+	"Tag not found":                                                                                                         {pt: "Tag não encontrada", es: "Etiqueta no encontrada"},
+	"Tag removed from entity":                                                                                               {pt: "Tag removida da entidade", es: "Etiqueta quitada de la entidad"},
+	"target_date must be between today and 180 days ahead":                                                                  {pt: "target_date deve estar entre hoje e 180 dias à frente", es: "target_date debe estar entre hoy y 180 días en adelante"},
+	"Team not found":                                                                                              {pt: "Equipe não encontrada", es: "Equipo no encontrado"},
+	"The action was approved but failed: ":                                                                        {pt: "A ação foi aprovada, mas falhou: ", es: "La acción fue aprobada, pero falló: "},
+	"The approver must be a different admin than the requester":                                                   {pt: "O aprovador deve ser um administrador diferente do solicitante", es: "El aprobador debe ser un administrador distinto del solicitante"},
+	"The approver must be an active admin of the organization":                                                    {pt: "O aprovador deve ser um administrador ativo da organização", es: "El aprobador debe ser un administrador activo de la organización"},
+	"The approver must be different from the requester":                                                           {pt: "O aprovador deve ser diferente do solicitante", es: "El aprobador debe ser diferente del solicitante"},
+	"The assessment has already been submitted":                                                                   {pt: "A avaliação já foi enviada", es: "La evaluación ya fue enviada"},
+	"The assessment has no due date":                                                                              {pt: "A avaliação não tem prazo", es: "La evaluación no tiene plazo"},
+	"The category is used by existing risks; deactivate it instead":                                               {pt: "A categoria é usada por riscos existentes; desative-a", es: "La categoría es usada por riesgos existentes; desactívela"},
+	"The entity does not have this tag":                                                                           {pt: "A entidade não tem esta tag", es: "La entidad no tiene esta etiqueta"},
+	"The filter matches more than 5000 users; narrow it down":                                                     {pt: "O filtro seleciona mais de 5000 usuários; restrinja-o", es: "El filtro selecciona más de 5000 usuarios; restrínjalo"},
+	"the hierarchy cannot have more than %d levels below the root organization":                                   {pt: "a hierarquia não pode ter mais de %d níveis abaixo da organização raiz", es: "la jerarquía no puede tener más de %d niveles debajo de la organización raíz"},
+	"The record was changed by another request; reload it and try again":                                          {pt: "O registro foi alterado por outra requisição; recarregue e tente novamente", es: "El registro fue modificado por otra solicitud; recárguelo e intente nuevamente"},
+	"The record was changed since you loaded it; reload it and try again":                                         {pt: "O registro foi alterado desde que você o carregou; recarregue e tente novamente", es: "El registro cambió desde que lo cargó; recárguelo e intente nuevamente"},
+	"The SAML certificate is not within its validity period":                                                      {pt: "O certificado SAML está fora do período de validade", es: "El certificado SAML está fuera de su período de validez"},
+	"The self-test is disabled; enable the CHAOS_SELF_TEST feature flag":                                          {pt: "O autoteste está desativado; habilite a feature flag CHAOS_SELF_TEST", es: "La autoprueba está desactivada; habilite la feature flag CHAOS_SELF_TEST"},
+	"These controls are already mapped":                                                                           {pt: "Estes controles já estão mapeados", es: "Estos controles ya están mapeados"},
+	"This action can no longer be executed":                                                                       {pt: "Esta ação não pode mais ser executada", es: "Esta acción ya no puede ejecutarse"},
+	"This action requires a second admin's approval: provide approver_id":                                         {pt: "Esta ação exige a aprovação de um segundo administrador: informe approver_id", es: "Esta acción requiere la aprobación de un segundo administrador: indique approver_id"},
+	"This approval workflow has already been decided: ":                                                           {pt: "Este fluxo de aprovação já foi decidido: ", es: "Este flujo de aprobación ya fue resuelto: "},
+	"This bulk operation requires confirmation (run the preflight check and send its token in X-Confirm-Token): ": {pt: "Esta operação em lote exige confirmação (execute a pré-verificação e envie o token em X-Confirm-Token): ", es: "Esta operación masiva requiere confirmación (ejecute la verificación previa y envíe el token en X-Confirm-Token): "},
+	"This change would leave the organization without an active admin or manager":                                 {pt: "Esta alteração deixaria a organização sem um admin ou manager ativo", es: "Este cambio dejaría a la organización sin un administrador o gestor activo"},
+	"This configuration entity can no longer be rolled back":                                                      {pt: "Esta configuração não pode mais ser revertida", es: "Esta configuración ya no se puede revertir"},
+	"This control already has a pending or active waiver":                                                         {pt: "Este controle já possui uma exceção pendente ou ativa", es: "Este control ya tiene una excepción pendiente o activa"},
+	"This evidence has already been accepted":                                                                     {pt: "Esta evidência já foi aceita", es: "Esta evidencia ya fue aceptada"},
+	"This evidence portal link has been revoked":                                                                  {pt: "Este link do portal de evidências foi revogado", es: "Este enlace del portal de evidencias fue revocado"},
+	"This evidence portal link has expired":                                                                       {pt: "Este link do portal de evidências expirou", es: "Este enlace del portal de evidencias ha caducado"},
+	"This organization requires single sign-on; accept the invitation by signing in with SSO":                     {pt: "Esta organização exige login único; aceite o convite entrando pelo SSO", es: "Esta organización exige inicio de sesión único; acepte la invitación ingresando con SSO"},
+	"This passkey is already registered":                                                                          {pt: "Esta passkey já está registrada", es: "Esta passkey ya está registrada"},
+	"This policy version has already been submitted: ":                                                            {pt: "Esta versão da política já foi submetida: ", es: "Esta versión de la política ya fue enviada: "},
+	"This policy version is not pending approval":                                                                 {pt: "Esta versão da política não está aguardando aprovação", es: "Esta versión de la política no está pendiente de aprobación"},
+	"This questionnaire link has been revoked":                                                                    {pt: "Este link do questionário foi revogado", es: "Este enlace del cuestionario fue revocado"},
+	"This questionnaire link has expired":                                                                         {pt: "Este link do questionário expirou", es: "Este enlace del cuestionario ha caducado"},
+	"This record is being edited by ":                                                                             {pt: "Este registro está sendo editado por ", es: "Este registro está siendo editado por "},
+	"This request has already been decided":                                                                       {pt: "Esta solicitação já foi decidida", es: "Esta solicitud ya fue decidida"},
+	"This token is not bound to a session":                                                                        {pt: "Este token não pertence a uma sessão", es: "Este token no pertenece a una sesión"},
+	"This waiver expired before it was approved; reject it and request a new one":                                 {pt: "Esta exceção venceu antes de ser aprovada; rejeite-a e solicite uma nova", es: "Esta excepción venció antes de ser aprobada; recházela y solicite una nueva"},
+	"This waiver has already been decided":                                                                        {pt: "Esta exceção já foi decidida", es: "Esta excepción ya fue decidida"},
+	"Tipo de arquivo de logo não permitido: %s. Permitidos: JPEG, PNG, GIF, SVG.":                                 {en: "Logo file type not allowed: %s. Allowed: JPEG, PNG, GIF, SVG.", es: "Tipo de archivo de logotipo no permitido: %s. Permitidos: JPEG, PNG, GIF, SVG."},
+	"Too many active API keys; revoke unused keys first":                                                          {pt: "Muitas chaves de API ativas; revogue as não utilizadas primeiro", es: "Demasiadas claves de API activas; revoque primero las que no use"},
+	"Too many entities in one request":                                                                            {pt: "Entidades demais em uma requisição", es: "Demasiadas entidades en una solicitud"},
+	"Too many notification IDs in one request":                                                                    {pt: "Notificações demais em uma única requisição", es: "Demasiadas notificaciones en una sola solicitud"},
+	"Too many requests, please try again later":                                                                   {pt: "Muitas requisições, tente novamente mais tarde", es: "Demasiadas solicitudes, intente nuevamente más tarde"},
+	"TOTP is not currently enabled for this account.":                                                             {pt: "O TOTP não está habilitado para esta conta.", es: "TOTP no está habilitado para esta cuenta."},
+	"TOTP is not enabled for this user.":                                                                          {pt: "O TOTP não está habilitado para este usuário.", es: "TOTP no está habilitado para este usuario."},
+	"TOTP must be enabled to generate backup codes.":                                                              {pt: "O TOTP precisa estar habilitado para gerar códigos de backup.", es: "TOTP debe estar habilitado para generar códigos de respaldo."},
+	"TOTP not set up for this user. Please set up TOTP first.":                                                    {pt: "TOTP não configurado para este usuário. Configure o TOTP primeiro.", es: "TOTP no configurado para este usuario. Configure TOTP primero."},
+	"Trust center is not configured for this organization":                                                        {pt: "O trust center não está configurado para esta organização", es: "El trust center no está configurado para esta organización"},
+	"Trust center not found":                                                                                      {pt: "Trust center não encontrado", es: "Trust center no encontrado"},
+	"Unknown permission: ":                                                                                        {pt: "Permissão desconhecida: ", es: "Permiso desconocido: "},
+	"Unknown self-test scenario: ":                                                                                {pt: "Cenário de autoteste desconhecido: ", es: "Escenario de autoprueba desconocido: "},
+	"Unknown status in transitions: ":                                                                             {pt: "Status desconhecido nas transições: ", es: "Estado desconocido en las transiciones: "},
+	"User account is inactive":                                                                                    {pt: "A conta do usuário está inativa", es: "La cuenta del usuario está inactiva"},
+	"User ID not found in token":                                                                                  {pt: "ID do usuário não encontrado no token", es: "ID de usuario no encontrado en el token"},
+	"User is already a coordinator of this framework":                                                             {pt: "O usuário já é coordenador deste framework", es: "El usuario ya es coordinador de este framework"},
+	"User is already anonymized":                                                                                  {pt: "O usuário já foi anonimizado", es: "El usuario ya fue anonimizado"},
+	"User is not a coordinator of this framework":                                                                 {pt: "O usuário não é coordenador deste framework", es: "El usuario no es coordinador de este framework"},
+	"User is not a member of this team":                                                                           {pt: "O usuário não é membro desta equipe", es: "El usuario no es miembro de este equipo"},
+	"User not found":                                                                                              {pt: "Usuário não encontrado", es: "Usuario no encontrado"},
+	"User not found in this organization":                                                                         {pt: "Usuário não encontrado nesta organização", es: "Usuario no encontrado en esta organización"},
+	"User not found in your organization":                                                                         {pt: "Usuário não encontrado na sua organização", es: "Usuario no encontrado en su organización"},
+	"User not found or invalid state":                                                                             {pt: "Usuário não encontrado ou em estado inválido", es: "Usuario no encontrado o en estado no válido"},
+	"User not found or not an active member of your organization":                                                 {pt: "Usuário não encontrado ou não é um membro ativo da sua organização", es: "Usuario no encontrado o no es un miembro activo de su organización"},
+	"User or Organization ID not found in token":                                                                  {pt: "ID do usuário ou da organização não encontrado no token", es: "ID de usuario u organización no encontrado en el token"},
+	"User role not found in token":                                                                                {pt: "Papel do usuário não encontrado no token", es: "Rol de usuario no encontrado en el token"},
+	"User to be added as stakeholder not found or not part of your organization":                                  {pt: "Usuário a ser adicionado como parte interessada não encontrado ou não pertence à sua organização", es: "Usuario a agregar como parte interesada no encontrado o no pertenece a su organización"},
+	"Usuário não encontrado nesta organização":                                                                    {en: "User not found in this organization", es: "Usuario no encontrado en esta organización"},
+	"Usuário não encontrado para atualizar role":                                                                  {en: "User not found to update role", es: "Usuario no encontrado para actualizar el rol"},
+	"Usuário não encontrado para atualizar status":                                                                {en: "User not found to update status", es: "Usuario no encontrado para actualizar el estado"},
+	"Vendor not found or not part of your organization":                                                           {pt: "Fornecedor não encontrado ou não pertence à sua organização", es: "Proveedor no encontrado o no pertenece a su organización"},
+	"Verification TXT record not found for the domain":                                                            {pt: "Registro TXT de verificação não encontrado no domínio", es: "Registro TXT de verificación no encontrado en el dominio"},
+	"Vulnerability not found or not part of your organization":                                                    {pt: "Vulnerabilidade não encontrada ou não pertence à sua organização", es: "Vulnerabilidad no encontrada o no pertenece a su organización"},
+	"Vulnerability not found or not part of your organization for deletion":                                       {pt: "Vulnerabilidade não encontrada ou não pertence à sua organização para exclusão", es: "Vulnerabilidad no encontrada o no pertenece a su organización para eliminación"},
+	"Vulnerability not found or not part of your organization for update":                                         {pt: "Vulnerabilidade não encontrada ou não pertence à sua organização para atualização", es: "Vulnerabilidad no encontrada o no pertenece a su organización para actualización"},
+	"Webhook configuration not found":                                                                             {pt: "Configuração de webhook não encontrada", es: "Configuración de webhook no encontrada"},
+	"Webhook configuration not found for deletion":                                                                {pt: "Configuração de webhook não encontrada para exclusão", es: "Configuración de webhook no encontrada para eliminación"},
+	"Webhook configuration not found for update":                                                                  {pt: "Configuração de webhook não encontrada para atualização", es: "Configuración de webhook no encontrada para actualización"},
+	"You are not authorized to delete this risk":                                                                  {pt: "Você não tem permissão para excluir este risco", es: "No tiene permiso para eliminar este riesgo"},
+	"You are not authorized to manage controls for this risk":                                                     {pt: "Você não tem permissão para gerenciar os controles deste risco", es: "No tiene permiso para gestionar los controles de este riesgo"},
+	"You are not authorized to manage stakeholders for this risk":                                                 {pt: "Você não tem permissão para gerenciar as partes interessadas deste risco", es: "No tiene permiso para gestionar las partes interesadas de este riesgo"},
+	"You are not authorized to update this risk":                                                                  {pt: "Você não tem permissão para atualizar este risco", es: "No tiene permiso para actualizar este riesgo"},
+	"You are not authorized to view this organization's dashboard":                                                {pt: "Você não tem permissão para ver o painel desta organização", es: "No tiene permiso para ver el panel de esta organización"},
+	"You are not authorized...":                                                                                   {pt: "Você não tem permissão...", es: "No tiene permiso..."},
+	"You are not the assigned reviewer for this item":                                                             {pt: "Você não é o revisor designado para este item", es: "Usted no es el revisor asignado para este elemento"},
+	"You are not the designated approver for this policy version":                                                 {pt: "Você não é o aprovador designado para esta versão da política", es: "Usted no es el aprobador designado para esta versión de la política"},
+	"You cannot anonymize your own account":                                                                       {pt: "Você não pode anonimizar a sua própria conta", es: "No puede anonimizar su propia cuenta"},
+	"Your identity provider account is not in a group allowed to access this organization.":                       {pt: "Sua conta no provedor de identidade não está em um grupo com acesso a esta organização.", es: "Su cuenta en el proveedor de identidad no está en un grupo con acceso a esta organización."},
+	"Your organization requires administrators to sign in with a passkey or security key":                         {pt: "Sua organização exige que administradores entrem com uma passkey ou chave de segurança", es: "Su organización exige que los administradores inicien sesión con una passkey o llave de seguridad"},
+	"Your organization requires two-factor authentication; set it up before continuing":                           {pt: "Sua organização exige autenticação de dois fatores; configure-a antes de continuar", es: "Su organización exige autenticación de dos factores; configúrela antes de continuar"},
+}
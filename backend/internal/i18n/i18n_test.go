@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]Locale{
+		"":                              DefaultLocale,
+		"pt-BR,pt;q=0.9,en;q=0.8":       LocalePortuguese,
+		"pt-PT":                         LocalePortuguese,
+		"es-MX,es;q=0.9":                LocaleSpanish,
+		"fr-FR,fr;q=0.9":                DefaultLocale,
+		"fr;q=1, es;q=0.5, pt-BR;q=0.7": LocalePortuguese,
+		"en;q=0.5, es":                  LocaleSpanish,
+		"es;q=0, pt":                    LocalePortuguese,
+		"EN-us":                         LocaleEnglish,
+	}
+	for header, expected := range cases {
+		assert.Equal(t, expected, Negotiate(header), header)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	// Mensagem exata
+	assert.Equal(t, "Risco não encontrado ou não pertence à sua organização",
+		Translate(LocalePortuguese, "Risk not found or not part of your organization"))
+	assert.Equal(t, "Risk not found or not part of your organization",
+		Translate(LocaleEnglish, "Risk not found or not part of your organization"))
+	// Mensagens originalmente em português também têm versão em inglês
+	assert.Equal(t, "Organization not found", Translate(LocaleEnglish, "Organização não encontrada"))
+	// Prefixo conhecido com detalhe desconhecido (mantido)
+	assert.Equal(t, "Formato de ID do framework inválido: invalid UUID length: 3",
+		Translate(LocalePortuguese, "Invalid framework ID format: invalid UUID length: 3"))
+	// Prefixo conhecido com detalhe também traduzível
+	assert.Equal(t, "Esta operación masiva requiere confirmación (ejecute la verificación previa y envíe el token en X-Confirm-Token): el token de confirmación ha caducado",
+		Translate(LocaleSpanish, "This bulk operation requires confirmation (run the preflight check and send its token in X-Confirm-Token): confirmation token has expired"))
+	// Mensagens formatadas
+	assert.Equal(t, "O tamanho do arquivo excede o limite de 10 MB", Translate(LocalePortuguese, "File size exceeds limit of 10 MB"))
+	assert.Equal(t, "Falta el encabezado obligatorio del CSV: title", Translate(LocaleSpanish, "Missing required CSV header: title"))
+	assert.Equal(t, "Tipo de arquivo 'a.exe' (detectado: 'application/octet-stream') não permitido. Tipos permitidos: pdf",
+		Translate(LocalePortuguese, "File type 'a.exe' (detected: 'application/octet-stream') is not allowed. Allowed types: pdf"))
+	// Mensagens desconhecidas são mantidas
+	assert.Equal(t, "Failed to frobnicate: boom", Translate(LocalePortuguese, "Failed to frobnicate: boom"))
+}
+
+func TestTranslateValidationMessages(t *testing.T) {
+	msg := "Invalid request payload: title is required; impact must be one of: Baixo Médio Alto; score must be greater than or equal to 5"
+	assert.Equal(t, "Payload da requisição inválido: title é obrigatório; impact deve ser um de: Baixo Médio Alto; score deve ser maior ou igual a 5",
+		Translate(LocalePortuguese, msg))
+	assert.Equal(t, "Carga útil de la solicitud no válida: title es obligatorio; impact debe ser uno de: Baixo Médio Alto; score debe ser mayor o igual que 5",
+		Translate(LocaleSpanish, msg))
+	assert.Equal(t, msg, Translate(LocaleEnglish, msg))
+}
+
+func TestValidatorUsesJSONFieldNames(t *testing.T) {
+	InstallValidator()
+	InstallValidator() // idempotente
+
+	type payload struct {
+		Title   string   `json:"title" binding:"required,min=3"`
+		Email   string   `json:"email" binding:"omitempty,email"`
+		Kind    string   `form:"kind" binding:"omitempty,oneof=a b"`
+		Emails  []string `json:"emails" binding:"dive,email"`
+		Ignored string   `json:"-" binding:"omitempty,hexcolor"`
+	}
+	err := binding.Validator.ValidateStruct(&payload{Title: "ab", Email: "x", Kind: "c", Emails: []string{"y"}, Ignored: "z"})
+	require.Error(t, err)
+	var validationErr ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "title must be at least 3; email must be a valid email address; kind must be one of: a b; emails[0] must be a valid email address; Ignored is invalid", err.Error())
+	assert.Equal(t, "title deve ser no mínimo 3; email deve ser um e-mail válido; kind deve ser um de: a b; emails[0] deve ser um e-mail válido; Ignored é inválido",
+		validationErr.Localize(LocalePortuguese))
+	assert.Equal(t, validationErr.Localize(LocaleSpanish), Translate(LocaleSpanish, err.Error()))
+
+	assert.NoError(t, binding.Validator.ValidateStruct(&payload{Title: "abc"}))
+}
@@ -0,0 +1,80 @@
+// Package i18n traduz as mensagens de erro e de validação da API para o idioma preferido
+// do cliente (cabeçalho Accept-Language).
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale é um idioma suportado pela API.
+type Locale string
+
+const (
+	LocalePortuguese Locale = "pt-BR"
+	LocaleEnglish    Locale = "en"
+	LocaleSpanish    Locale = "es"
+
+	// DefaultLocale é usado quando o cliente não informa um idioma suportado.
+	DefaultLocale = LocaleEnglish
+
+	// ContextKey é a chave do gin.Context com o idioma negociado da requisição.
+	ContextKey = "locale"
+)
+
+// SupportedLocales lista os idiomas com catálogo de mensagens.
+var SupportedLocales = []Locale{LocalePortuguese, LocaleEnglish, LocaleSpanish}
+
+// matchLocale associa uma tag de idioma (ex.: "pt", "pt-PT", "es-MX") a um idioma suportado.
+func matchLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	primary := tag
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		primary = tag[:i]
+	}
+	switch primary {
+	case "pt":
+		return LocalePortuguese, true
+	case "en":
+		return LocaleEnglish, true
+	case "es":
+		return LocaleSpanish, true
+	}
+	return "", false
+}
+
+// Negotiate escolhe o idioma a partir do cabeçalho Accept-Language, respeitando os pesos (q).
+// Em caso de empate vale a ordem do cabeçalho; sem idioma suportado retorna DefaultLocale.
+func Negotiate(acceptLanguage string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+		order  int
+	}
+	var candidates []candidate
+	for i, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		if locale, ok := matchLocale(fields[0]); ok {
+			candidates = append(candidates, candidate{locale: locale, q: q, order: i})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+	sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].q > candidates[b].q })
+	return candidates[0].locale
+}
@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// pick retorna a versão da mensagem no idioma, ou original quando não há tradução.
+func (t translation) pick(locale Locale, original string) string {
+	var text string
+	switch locale {
+	case LocalePortuguese:
+		text = t.pt
+	case LocaleSpanish:
+		text = t.es
+	default:
+		text = t.en
+	}
+	if text == "" {
+		return original
+	}
+	return text
+}
+
+// formattedMessage é uma chave do catálogo com verbos de formatação (%s, %d).
+type formattedMessage struct {
+	key     string
+	pattern *regexp.Regexp
+}
+
+var (
+	formatVerb        = regexp.MustCompile(`%[sd]`)
+	formattedMessages = compileFormattedMessages()
+)
+
+func compileFormattedMessages() []formattedMessage {
+	var messages []formattedMessage
+	for key := range catalog {
+		if !formatVerb.MatchString(key) {
+			continue
+		}
+		expr := formatVerb.ReplaceAllStringFunc(regexp.QuoteMeta(key), func(verb string) string {
+			if verb == "%d" {
+				return `(-?\d+)`
+			}
+			return `(.+?)`
+		})
+		messages = append(messages, formattedMessage{key: key, pattern: regexp.MustCompile("^" + expr + "$")})
+	}
+	// Chaves mais longas primeiro: são mais específicas.
+	sort.Slice(messages, func(i, j int) bool {
+		if len(messages[i].key) != len(messages[j].key) {
+			return len(messages[i].key) > len(messages[j].key)
+		}
+		return messages[i].key < messages[j].key
+	})
+	return messages
+}
+
+// Translate traduz uma mensagem de erro da API para o idioma informado. A busca é feita pela
+// mensagem completa, depois pelas mensagens formatadas, pelos erros de validação e, por fim,
+// pelo prefixo até o primeiro ": " conhecido (o detalhe é traduzido quando possível).
+// Mensagens desconhecidas, como detalhes de erros do banco, são mantidas.
+func Translate(locale Locale, msg string) string {
+	if translated, ok := translate(locale, msg); ok {
+		return translated
+	}
+	return msg
+}
+
+func translate(locale Locale, msg string) (string, bool) {
+	if t, ok := catalog[msg]; ok {
+		return t.pick(locale, msg), true
+	}
+	for _, fm := range formattedMessages {
+		match := fm.pattern.FindStringSubmatch(msg)
+		if match == nil {
+			continue
+		}
+		template := formatVerb.ReplaceAllString(catalog[fm.key].pick(locale, fm.key), "%s")
+		args := make([]any, 0, len(match)-1)
+		for _, arg := range match[1:] {
+			args = append(args, arg)
+		}
+		return fmt.Sprintf(template, args...), true
+	}
+	if translated, ok := translateValidation(locale, msg); ok {
+		return translated, true
+	}
+	for offset := 0; ; {
+		i := strings.Index(msg[offset:], ": ")
+		if i < 0 {
+			return msg, false
+		}
+		end := offset + i + len(": ")
+		prefix := msg[:end]
+		if t, ok := catalog[prefix]; ok {
+			return t.pick(locale, prefix) + Translate(locale, msg[end:]), true
+		}
+		offset = end
+	}
+}
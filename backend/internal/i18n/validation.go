@@ -0,0 +1,188 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// validationSeparator separa as mensagens de cada campo inválido.
+const validationSeparator = "; "
+
+// fallbackRule é usada para tags do validator sem texto próprio.
+const fallbackRule = "invalid"
+
+// rules são os textos das regras de validação (tags `binding`) por idioma.
+// %[1]s é o nome do campo (tag json) e %[2]s o parâmetro da regra.
+var rules = map[string]translation{
+	"required":   {en: "%[1]s is required", pt: "%[1]s é obrigatório", es: "%[1]s es obligatorio"},
+	"min":        {en: "%[1]s must be at least %[2]s", pt: "%[1]s deve ser no mínimo %[2]s", es: "%[1]s debe ser como mínimo %[2]s"},
+	"max":        {en: "%[1]s must be at most %[2]s", pt: "%[1]s deve ser no máximo %[2]s", es: "%[1]s debe ser como máximo %[2]s"},
+	"len":        {en: "%[1]s must have length %[2]s", pt: "%[1]s deve ter tamanho %[2]s", es: "%[1]s debe tener longitud %[2]s"},
+	"gt":         {en: "%[1]s must be greater than %[2]s", pt: "%[1]s deve ser maior que %[2]s", es: "%[1]s debe ser mayor que %[2]s"},
+	"gte":        {en: "%[1]s must be greater than or equal to %[2]s", pt: "%[1]s deve ser maior ou igual a %[2]s", es: "%[1]s debe ser mayor o igual que %[2]s"},
+	"lt":         {en: "%[1]s must be less than %[2]s", pt: "%[1]s deve ser menor que %[2]s", es: "%[1]s debe ser menor que %[2]s"},
+	"lte":        {en: "%[1]s must be less than or equal to %[2]s", pt: "%[1]s deve ser menor ou igual a %[2]s", es: "%[1]s debe ser menor o igual que %[2]s"},
+	"email":      {en: "%[1]s must be a valid email address", pt: "%[1]s deve ser um e-mail válido", es: "%[1]s debe ser un correo electrónico válido"},
+	"url":        {en: "%[1]s must be a valid URL", pt: "%[1]s deve ser uma URL válida", es: "%[1]s debe ser una URL válida"},
+	"uuid":       {en: "%[1]s must be a valid UUID", pt: "%[1]s deve ser um UUID válido", es: "%[1]s debe ser un UUID válido"},
+	"oneof":      {en: "%[1]s must be one of: %[2]s", pt: "%[1]s deve ser um de: %[2]s", es: "%[1]s debe ser uno de: %[2]s"},
+	"datetime":   {en: "%[1]s must be a date in the format %[2]s", pt: "%[1]s deve ser uma data no formato %[2]s", es: "%[1]s debe ser una fecha con el formato %[2]s"},
+	fallbackRule: {en: "%[1]s is invalid", pt: "%[1]s é inválido", es: "%[1]s no es válido"},
+}
+
+// rulePattern reconhece a mensagem em inglês de uma regra para traduzi-la depois.
+type rulePattern struct {
+	tag     string
+	pattern *regexp.Regexp
+}
+
+// rulePatterns ficam ordenados do texto mais longo para o mais curto, para que
+// "must be greater than or equal to" não seja lido como "must be greater than".
+var rulePatterns = compileRulePatterns()
+
+func compileRulePatterns() []rulePattern {
+	patterns := make([]rulePattern, 0, len(rules))
+	for tag, rule := range rules {
+		expr := regexp.QuoteMeta(rule.en)
+		expr = strings.Replace(expr, regexp.QuoteMeta("%[1]s"), `(\S+)`, 1)
+		expr = strings.Replace(expr, regexp.QuoteMeta("%[2]s"), `(.+)`, 1)
+		patterns = append(patterns, rulePattern{tag: tag, pattern: regexp.MustCompile("^" + expr + "$")})
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(rules[patterns[i].tag].en) != len(rules[patterns[j].tag].en) {
+			return len(rules[patterns[i].tag].en) > len(rules[patterns[j].tag].en)
+		}
+		return patterns[i].tag < patterns[j].tag
+	})
+	return patterns
+}
+
+// FieldError descreve um campo que não passou em uma regra de validação.
+type FieldError struct {
+	Field string
+	Rule  string
+	Param string
+}
+
+// ValidationError substitui validator.ValidationErrors com mensagens legíveis por campo,
+// ex.: "title is required; name must be at least 3".
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	return e.Localize(LocaleEnglish)
+}
+
+// Localize retorna a mensagem no idioma informado.
+func (e ValidationError) Localize(locale Locale) string {
+	messages := make([]string, 0, len(e))
+	for _, fe := range e {
+		messages = append(messages, formatRule(locale, fe))
+	}
+	return strings.Join(messages, validationSeparator)
+}
+
+func formatRule(locale Locale, fe FieldError) string {
+	rule, ok := rules[fe.Rule]
+	if !ok {
+		rule = rules[fallbackRule]
+	}
+	template := rule.pick(locale, rule.en)
+	if strings.Contains(template, "%[2]s") {
+		return fmt.Sprintf(template, fe.Field, fe.Param)
+	}
+	return fmt.Sprintf(template, fe.Field)
+}
+
+// translateValidation traduz uma mensagem gerada por ValidationError.Error.
+func translateValidation(locale Locale, msg string) (string, bool) {
+	segments := strings.Split(msg, validationSeparator)
+	translated := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		fe, ok := parseRule(segment)
+		if !ok {
+			return "", false
+		}
+		translated = append(translated, formatRule(locale, fe))
+	}
+	return strings.Join(translated, validationSeparator), true
+}
+
+func parseRule(segment string) (FieldError, bool) {
+	for _, rp := range rulePatterns {
+		match := rp.pattern.FindStringSubmatch(segment)
+		if match == nil {
+			continue
+		}
+		fe := FieldError{Field: match[1], Rule: rp.tag}
+		if len(match) > 2 {
+			fe.Param = match[2]
+		}
+		return fe, true
+	}
+	return FieldError{}, false
+}
+
+func newValidationError(errs validator.ValidationErrors) ValidationError {
+	result := make(ValidationError, 0, len(errs))
+	for _, fe := range errs {
+		result = append(result, FieldError{Field: fe.Field(), Rule: fe.Tag(), Param: fe.Param()})
+	}
+	return result
+}
+
+// localizedValidator embrulha o validador do Gin convertendo os erros em ValidationError.
+type localizedValidator struct {
+	binding.StructValidator
+}
+
+func (v localizedValidator) ValidateStruct(obj any) error {
+	err := v.StructValidator.ValidateStruct(obj)
+	var sliceErrs binding.SliceValidationError
+	if errors.As(err, &sliceErrs) {
+		var fields ValidationError
+		for _, elemErr := range sliceErrs {
+			var errs validator.ValidationErrors
+			if errors.As(elemErr, &errs) {
+				fields = append(fields, newValidationError(errs)...)
+			}
+		}
+		if len(fields) > 0 {
+			return fields
+		}
+		return err
+	}
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		return newValidationError(errs)
+	}
+	return err
+}
+
+// jsonFieldName usa o nome da tag json nas mensagens, que é o que o cliente enviou.
+func jsonFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// InstallValidator faz o binding do Gin retornar ValidationError com os nomes json dos campos.
+func InstallValidator() {
+	if _, ok := binding.Validator.(localizedValidator); ok {
+		return
+	}
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(jsonFieldName)
+	}
+	binding.Validator = localizedValidator{binding.Validator}
+}
@@ -0,0 +1,147 @@
+// Package idempotency guarda as respostas das requisições enviadas com o header Idempotency-Key, para que um
+// cliente que repete a requisição (ex: depois de um timeout de rede) receba a mesma resposta sem que a operação
+// seja executada de novo. As chaves valem por usuário e expiram depois do prazo de retenção.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"mime"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LockTimeout é o tempo depois do qual uma requisição ainda sem resposta gravada é considerada abandonada (ex: o
+// processo caiu) e a chave pode ser usada de novo.
+const LockTimeout = 5 * time.Minute
+
+var (
+	// ErrKeyMismatch indica que a chave já foi usada em uma requisição diferente (método, caminho ou corpo).
+	ErrKeyMismatch = errors.New("idempotency key was already used with a different request")
+	// ErrInProgress indica que a primeira requisição com a chave ainda está sendo processada.
+	ErrInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// Fingerprint identifica a requisição pelo método, caminho e corpo. Em corpos multipart, o boundary (gerado a
+// cada envio pelo cliente) é descartado, para que a repetição do mesmo formulário tenha a mesma impressão.
+func Fingerprint(method, requestURI, contentType string, body []byte) string {
+	if mediaType, params, err := mime.ParseMediaType(contentType); err == nil && params["boundary"] != "" &&
+		(mediaType == "multipart/form-data" || mediaType == "multipart/mixed") {
+		body = bytes.ReplaceAll(body, []byte(params["boundary"]), nil)
+	}
+	hash := sha256.New()
+	hash.Write([]byte(method + "\n" + requestURI + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Request é a requisição que reserva a chave.
+type Request struct {
+	UserID uuid.UUID
+	Key    string
+	Method string
+	Path   string
+	Hash   string // Ver Fingerprint
+}
+
+// Begin reserva a chave para a requisição. Se a chave é nova (ou expirou, ou sua requisição foi abandonada),
+// retorna o registro reservado e replay false: o chamador executa a operação e chama Complete ou Release. Se a
+// chave já tem a resposta gravada, retorna o registro com replay true para reenviá-la. Retorna ErrKeyMismatch se a
+// chave foi usada em outra requisição e ErrInProgress se a primeira ainda não terminou.
+func Begin(db *gorm.DB, req Request, ttl time.Duration, now time.Time) (record *models.IdempotencyKey, replay bool, err error) {
+	// Duas tentativas: a segunda depois de descartar uma chave expirada ou abandonada
+	for attempt := 0; attempt < 2; attempt++ {
+		created := models.IdempotencyKey{
+			UserID:      req.UserID,
+			Key:         req.Key,
+			Method:      req.Method,
+			Path:        req.Path,
+			RequestHash: req.Hash,
+			ExpiresAt:   now.Add(ttl),
+			CreatedAt:   now,
+		}
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&created)
+		if result.Error != nil {
+			return nil, false, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return &created, false, nil
+		}
+
+		var existing models.IdempotencyKey
+		if err := db.Where("user_id = ? AND key = ?", req.UserID, req.Key).First(&existing).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue // Removida entre o insert e a consulta
+			}
+			return nil, false, err
+		}
+		if existing.ExpiresAt.Before(now) || (!existing.Completed() && existing.CreatedAt.Before(now.Add(-LockTimeout))) {
+			if err := db.Where("id = ?", existing.ID).Delete(&models.IdempotencyKey{}).Error; err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+		if existing.RequestHash != req.Hash {
+			return nil, false, ErrKeyMismatch
+		}
+		if !existing.Completed() {
+			return nil, false, ErrInProgress
+		}
+		return &existing, true, nil
+	}
+	return nil, false, ErrInProgress
+}
+
+// Complete grava a resposta da requisição que reservou a chave.
+func Complete(db *gorm.DB, record *models.IdempotencyKey, statusCode int, contentType string, body []byte, now time.Time) error {
+	return db.Model(&models.IdempotencyKey{}).Where("id = ? AND status_code = 0", record.ID).Updates(map[string]interface{}{
+		"status_code":           statusCode,
+		"response_content_type": contentType,
+		"response_body":         body,
+		"completed_at":          now,
+	}).Error
+}
+
+// Release libera a chave sem gravar a resposta (ex: erro interno), para que o cliente possa repetir a requisição.
+func Release(db *gorm.DB, record *models.IdempotencyKey) error {
+	return db.Where("id = ? AND status_code = 0", record.ID).Delete(&models.IdempotencyKey{}).Error
+}
+
+// Purge remove as chaves expiradas e retorna quantas foram removidas.
+func Purge(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
+
+// StartPurgeScheduler remove as chaves expiradas a cada intervalo até o contexto ser cancelado.
+func StartPurgeScheduler(ctx context.Context, every time.Duration) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				count, err := Purge(database.GetDB().WithContext(ctx), now)
+				if err != nil {
+					phxlog.L.Error("Idempotency key purge failed", zap.Error(err))
+				}
+				if count > 0 {
+					phxlog.L.Debug("Expired idempotency keys removed", zap.Int64("count", count))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,30 @@
+package idempotency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	body := []byte(`{"title":"Risco"}`)
+	base := Fingerprint("POST", "/api/v1/risks", "application/json", body)
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, Fingerprint("POST", "/api/v1/risks", "application/json", body))
+
+	assert.NotEqual(t, base, Fingerprint("POST", "/api/v1/risks", "application/json", []byte(`{"title":"Outro"}`)))
+	assert.NotEqual(t, base, Fingerprint("PUT", "/api/v1/risks", "application/json", body))
+	assert.NotEqual(t, base, Fingerprint("POST", "/api/v1/risks?x=1", "application/json", body))
+}
+
+func TestFingerprintIgnoresMultipartBoundary(t *testing.T) {
+	form := func(boundary string) []byte {
+		return []byte("--" + boundary + "\r\nContent-Disposition: form-data; name=\"file\"; filename=\"riscos.csv\"\r\n\r\ntitle\nRisco\r\n--" + boundary + "--\r\n")
+	}
+	first := Fingerprint("POST", "/api/v1/risks/bulk-upload-csv", "multipart/form-data; boundary=abc123", form("abc123"))
+	retry := Fingerprint("POST", "/api/v1/risks/bulk-upload-csv", "multipart/form-data; boundary=xyz789", form("xyz789"))
+	assert.Equal(t, first, retry)
+
+	other := []byte("--xyz789\r\nContent-Disposition: form-data; name=\"file\"; filename=\"riscos.csv\"\r\n\r\ntitle\nOutro\r\n--xyz789--\r\n")
+	assert.NotEqual(t, first, Fingerprint("POST", "/api/v1/risks/bulk-upload-csv", "multipart/form-data; boundary=xyz789", other))
+}
@@ -0,0 +1,129 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessReviewCampaignFlow(t *testing.T) {
+	org := h.NewOrganization(t, "Revisão de acessos")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	reviewer, reviewerToken := h.NewUser(t, org, models.RoleUser)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	outsider, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	payload := handlers.AccessReviewCampaignPayload{Name: "Revisão trimestral", DueDate: tomorrow, DefaultReviewerID: reviewer.ID.String()}
+
+	// Só quem tem access_reviews:manage cria campanhas, e o revisor padrão precisa ser da organização
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/access-reviews", payload, http.StatusForbidden, nil)
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/access-reviews", handlers.AccessReviewCampaignPayload{
+		Name: "Revisão trimestral", DueDate: tomorrow, DefaultReviewerID: outsider.ID.String(),
+	}, http.StatusBadRequest, nil)
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/access-reviews", handlers.AccessReviewCampaignPayload{
+		Name: "Revisão trimestral", DueDate: time.Now().AddDate(0, 0, -1).Format("2006-01-02"),
+	}, http.StatusBadRequest, nil)
+
+	// A campanha tira um snapshot dos usuários ativos da organização
+	var campaign models.AccessReviewCampaign
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/access-reviews", payload, http.StatusCreated, &campaign)
+	assert.Equal(t, models.AccessReviewStatusOpen, campaign.Status)
+	require.Len(t, campaign.Items, 4)
+	for _, item := range campaign.Items {
+		assert.Equal(t, models.AccessReviewSourcePhoenix, item.SourceSystem)
+		assert.NotEqual(t, outsider.Email, item.AccountIdentifier)
+		require.NotNil(t, item.ReviewerID)
+		assert.Equal(t, reviewer.ID, *item.ReviewerID)
+	}
+	campaignPath := "/api/v1/access-reviews/" + campaign.ID.String()
+
+	// Outras organizações não veem a campanha
+	h.DoJSON(t, outsiderToken, http.MethodGet, campaignPath, nil, http.StatusNotFound, nil)
+	var list struct {
+		Items []models.AccessReviewCampaign `json:"items"`
+	}
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/access-reviews", nil, http.StatusOK, &list)
+	assert.Empty(t, list.Items)
+
+	// Contas de outros sistemas entram por CSV; revisores de outra organização são rejeitados
+	csvContent := "source_system,account_identifier,entitlement,reviewer_email\n" +
+		"erp,joao.silva,financeiro,\n" +
+		"erp,maria.souza,admin," + outsider.Email + "\n"
+	rec := h.DoMultipart(t, memberToken, http.MethodPost, campaignPath+"/items/import-csv", "file", "contas.csv", []byte(csvContent), nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var imported struct {
+		ImportedCount int      `json:"imported_count"`
+		Errors        []string `json:"errors"`
+	}
+	rec = h.DoMultipart(t, managerToken, http.MethodPost, campaignPath+"/items/import-csv", "file", "contas.csv", []byte(csvContent), nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &imported))
+	assert.Equal(t, 1, imported.ImportedCount)
+	require.Len(t, imported.Errors, 1)
+	assert.Contains(t, imported.Errors[0], "line 3")
+
+	var erpItem models.AccessReviewItem
+	require.NoError(t, h.DB.First(&erpItem, "campaign_id = ? AND source_system = ?", campaign.ID, "erp").Error)
+	assert.Nil(t, erpItem.ReviewerID)
+
+	// O revisor decide os itens atribuídos a ele; os demais usuários não
+	phoenixItem := campaign.Items[0]
+	decision := handlers.AccessReviewDecisionPayload{Decision: models.AccessReviewDecisionKeep, Justification: "Acesso necessário"}
+	h.DoJSON(t, memberToken, http.MethodPost, campaignPath+"/items/"+phoenixItem.ID.String()+"/decision", decision, http.StatusForbidden, nil)
+	h.DoJSON(t, reviewerToken, http.MethodPost, campaignPath+"/items/"+erpItem.ID.String()+"/decision", decision, http.StatusForbidden, nil)
+	var decided models.AccessReviewItem
+	h.DoJSON(t, reviewerToken, http.MethodPost, campaignPath+"/items/"+phoenixItem.ID.String()+"/decision", decision, http.StatusOK, &decided)
+	assert.Equal(t, models.AccessReviewDecisionKeep, decided.Decision)
+	require.NotNil(t, decided.DecidedByID)
+	assert.Equal(t, reviewer.ID, *decided.DecidedByID)
+
+	// Gestores decidem qualquer item
+	h.DoJSON(t, managerToken, http.MethodPost, campaignPath+"/items/"+erpItem.ID.String()+"/decision",
+		handlers.AccessReviewDecisionPayload{Decision: models.AccessReviewDecisionRevoke, Justification: "Conta desligada"}, http.StatusOK, nil)
+
+	var detail struct {
+		Progress map[models.AccessReviewDecision]int `json:"progress"`
+		IsActive bool                                `json:"is_active"`
+	}
+	h.DoJSON(t, reviewerToken, http.MethodGet, campaignPath, nil, http.StatusOK, &detail)
+	assert.True(t, detail.IsActive)
+	assert.Equal(t, 1, detail.Progress[models.AccessReviewDecisionKeep])
+	assert.Equal(t, 1, detail.Progress[models.AccessReviewDecisionRevoke])
+	assert.Equal(t, 3, detail.Progress[models.AccessReviewDecisionPending])
+
+	// O encerramento congela as decisões
+	h.DoJSON(t, memberToken, http.MethodPost, campaignPath+"/close", nil, http.StatusForbidden, nil)
+	var closed struct {
+		Campaign     models.AccessReviewCampaign `json:"campaign"`
+		PendingItems int64                       `json:"pending_items"`
+	}
+	h.DoJSON(t, managerToken, http.MethodPost, campaignPath+"/close", nil, http.StatusOK, &closed)
+	assert.Equal(t, models.AccessReviewStatusClosed, closed.Campaign.Status)
+	assert.EqualValues(t, 3, closed.PendingItems)
+	h.DoJSON(t, managerToken, http.MethodPost, campaignPath+"/close", nil, http.StatusConflict, nil)
+	h.DoJSON(t, reviewerToken, http.MethodPost, campaignPath+"/items/"+phoenixItem.ID.String()+"/decision", decision, http.StatusConflict, nil)
+	rec = h.DoMultipart(t, managerToken, http.MethodPost, campaignPath+"/items/import-csv", "file", "contas.csv", []byte(csvContent), nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	// A exportação registra quem revisou e a justificativa
+	rec = h.Do(t, adminToken, http.MethodGet, campaignPath+"/export", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 6)
+	assert.Contains(t, rec.Body.String(), "keep,Acesso necessário,"+reviewer.Email)
+	assert.Contains(t, rec.Body.String(), "erp,joao.silva")
+	h.DoJSON(t, outsiderToken, http.MethodGet, campaignPath+"/export", nil, http.StatusNotFound, nil)
+}
@@ -0,0 +1,130 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/secrets"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adminActionPage é a resposta paginada de GET /admin-actions.
+type adminActionPage struct {
+	Items      []handlers.AdminActionRequestResponse `json:"items"`
+	TotalItems int64                                 `json:"total_items"`
+}
+
+func TestSecondAdminApproval(t *testing.T) {
+	org := h.NewOrganization(t, "Regra das duas pessoas")
+	_, requesterToken := h.NewUser(t, org, models.RoleAdmin)
+	approver, approverToken := h.NewUser(t, org, models.RoleAdmin)
+	manager, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	outsider, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	var secret models.OrgSecret
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/secrets", handlers.OrgSecretPayload{
+		Name: "slack-alertas", Kind: models.OrgSecretSlackWebhook, Value: "https://hooks.slack.test/antigo",
+	}, http.StatusCreated, &secret)
+	secretPath := "/api/v1/secrets/" + secret.ID.String()
+	rotate := handlers.RotateOrgSecretPayload{Value: "https://hooks.slack.test/novo-valor"}
+
+	// Só admins e gestores solicitam; o aprovador precisa ser outro admin ativo da organização
+	h.DoJSON(t, memberToken, http.MethodPost, secretPath+"/rotate?approver_id="+approver.ID.String(), rotate, http.StatusForbidden, nil)
+	h.DoJSON(t, requesterToken, http.MethodPost, secretPath+"/rotate", rotate, http.StatusPreconditionRequired, nil)
+	for _, invalid := range []string{"nao-e-uuid", manager.ID.String(), outsider.ID.String()} {
+		h.DoJSON(t, requesterToken, http.MethodPost, secretPath+"/rotate?approver_id="+invalid, rotate, http.StatusBadRequest, nil)
+	}
+	h.DoJSON(t, approverToken, http.MethodPost, secretPath+"/rotate?approver_id="+approver.ID.String(), rotate, http.StatusBadRequest, nil)
+
+	// A solicitação fica pendente e o valor não muda até a aprovação
+	var request handlers.AdminActionRequestResponse
+	h.DoJSON(t, requesterToken, http.MethodPost, secretPath+"/rotate?approver_id="+approver.ID.String(), rotate, http.StatusAccepted, &request)
+	assert.Equal(t, models.AdminActionPending, request.Status)
+	assert.Equal(t, models.AdminActionOrgSecretRotate, request.Action)
+	require.NotNil(t, request.ApprovalWorkflowID)
+	h.DoJSON(t, managerToken, http.MethodPost, secretPath+"/rotate?approver_id="+approver.ID.String(), rotate, http.StatusConflict, nil)
+	value, err := secrets.Resolve(context.Background(), org.ID, "slack-alertas", "test")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.test/antigo", value)
+	requestPath := "/api/v1/admin-actions/" + request.ID.String()
+
+	// Só o aprovador designado decide; outras organizações não veem a solicitação
+	approve := handlers.DecisionPayload{Decision: models.ApprovalApproved, Comments: "Ok"}
+	h.DoJSON(t, requesterToken, http.MethodPost, requestPath+"/decide", approve, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, requestPath+"/decide", approve, http.StatusForbidden, nil)
+	h.DoJSON(t, outsiderToken, http.MethodPost, requestPath+"/decide", approve, http.StatusNotFound, nil)
+	h.DoJSON(t, outsiderToken, http.MethodGet, requestPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, memberToken, http.MethodGet, requestPath, nil, http.StatusForbidden, nil)
+
+	var executed handlers.AdminActionRequestResponse
+	h.DoJSON(t, approverToken, http.MethodPost, requestPath+"/decide", approve, http.StatusOK, &executed)
+	assert.Equal(t, models.AdminActionExecuted, executed.Status)
+	require.NotNil(t, executed.ExecutedAt)
+	value, err = secrets.Resolve(context.Background(), org.ID, "slack-alertas", "test")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.test/novo-valor", value)
+	h.DoJSON(t, approverToken, http.MethodPost, requestPath+"/decide", approve, http.StatusConflict, nil)
+	var workflow models.ApprovalWorkflow
+	require.NoError(t, h.DB.First(&workflow, "id = ?", *request.ApprovalWorkflowID).Error)
+	assert.Equal(t, models.ApprovalApproved, workflow.Status)
+
+	// Só o solicitante cancela, e apenas enquanto pendente
+	var cancelled handlers.AdminActionRequestResponse
+	h.DoJSON(t, managerToken, http.MethodDelete, secretPath+"?approver_id="+approver.ID.String(), nil, http.StatusAccepted, &request)
+	requestPath = "/api/v1/admin-actions/" + request.ID.String()
+	h.DoJSON(t, approverToken, http.MethodPost, requestPath+"/cancel", nil, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, requestPath+"/cancel", nil, http.StatusOK, &cancelled)
+	assert.Equal(t, models.AdminActionCancelled, cancelled.Status)
+	h.DoJSON(t, managerToken, http.MethodPost, requestPath+"/cancel", nil, http.StatusConflict, nil)
+	h.DoJSON(t, approverToken, http.MethodPost, requestPath+"/decide", approve, http.StatusConflict, nil)
+
+	// A rejeição mantém o segredo
+	var rejected handlers.AdminActionRequestResponse
+	h.DoJSON(t, requesterToken, http.MethodDelete, secretPath+"?approver_id="+approver.ID.String(), nil, http.StatusAccepted, &request)
+	h.DoJSON(t, approverToken, http.MethodPost, "/api/v1/admin-actions/"+request.ID.String()+"/decide",
+		handlers.DecisionPayload{Decision: models.ApprovalRejected, Comments: "Ainda em uso"}, http.StatusOK, &rejected)
+	assert.Equal(t, models.AdminActionRejected, rejected.Status)
+	h.DoJSON(t, managerToken, http.MethodGet, secretPath, nil, http.StatusOK, nil)
+
+	// Se o alvo some antes da aprovação, a execução falha e fica registrada
+	var failed struct {
+		Request handlers.AdminActionRequestResponse `json:"request"`
+	}
+	h.DoJSON(t, requesterToken, http.MethodDelete, secretPath+"?approver_id="+approver.ID.String(), nil, http.StatusAccepted, &request)
+	require.NoError(t, h.DB.Delete(&models.OrgSecret{}, "id = ?", secret.ID).Error)
+	h.DoJSON(t, approverToken, http.MethodPost, "/api/v1/admin-actions/"+request.ID.String()+"/decide", approve, http.StatusConflict, &failed)
+	assert.Equal(t, models.AdminActionFailed, failed.Request.Status)
+	assert.NotEmpty(t, failed.Request.FailureReason)
+
+	// Histórico das solicitações e trilha de auditoria
+	var pending adminActionPage
+	h.DoJSON(t, managerToken, http.MethodGet, "/api/v1/admin-actions?status=pending", nil, http.StatusOK, &pending)
+	assert.Zero(t, pending.TotalItems)
+	var all adminActionPage
+	h.DoJSON(t, managerToken, http.MethodGet, "/api/v1/admin-actions", nil, http.StatusOK, &all)
+	assert.EqualValues(t, 4, all.TotalItems)
+	var foreign adminActionPage
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/admin-actions", nil, http.StatusOK, &foreign)
+	assert.Zero(t, foreign.TotalItems)
+
+	for action, want := range map[models.AuditTrailAction]int64{
+		models.AuditTrailAdminActionRequested: 4,
+		models.AuditTrailAdminActionExecuted:  1,
+		models.AuditTrailAdminActionCancelled: 1,
+		models.AuditTrailAdminActionRejected:  1,
+		models.AuditTrailAdminActionFailed:    1,
+	} {
+		var trail auditTrailPage
+		h.DoJSON(t, managerToken, http.MethodGet, "/api/v1/audit-trail?action="+string(action), nil, http.StatusOK, &trail)
+		assert.Equal(t, want, trail.TotalItems, string(action))
+	}
+}
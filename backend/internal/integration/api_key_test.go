@@ -0,0 +1,65 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doAPIKey envia a requisição autenticada com a chave de API e verifica o status.
+func doAPIKey(t *testing.T, token, method, path string, body interface{}, wantStatus int) {
+	t.Helper()
+	rec := h.DoWithAuthorization(t, "ApiKey "+token, method, path, body)
+	require.Equal(t, wantStatus, rec.Code, "%s %s: %s", method, path, rec.Body.String())
+}
+
+func TestAPIKeyPushesRisksWithinItsScopes(t *testing.T) {
+	org := h.NewOrganization(t, "CI")
+	owner, ownerToken := h.NewUser(t, org, models.RoleManager)
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+
+	var created handlers.CreatedAPIKeyResponse
+	h.DoJSON(t, ownerToken, http.MethodPost, "/api/v1/api-keys", handlers.APIKeyPayload{
+		Name: "Pipeline", Scopes: []models.APIKeyScope{models.ScopeRisksWrite},
+	}, http.StatusCreated, &created)
+	require.NotEmpty(t, created.Token)
+	assert.Equal(t, owner.ID, created.UserID)
+	h.DoJSON(t, ownerToken, http.MethodPost, "/api/v1/api-keys", handlers.APIKeyPayload{
+		Name: "Inválida", Scopes: []models.APIKeyScope{"admin:all"},
+	}, http.StatusBadRequest, nil)
+
+	// A chave cria riscos como o dono, mas não acessa rotas fora dos escopos (inclusive as de chaves)
+	doAPIKey(t, created.Token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco enviado pelo CI"}, http.StatusCreated)
+	var risk models.Risk
+	require.NoError(t, h.DB.Where("organization_id = ? AND title = ?", org.ID, "Risco enviado pelo CI").Take(&risk).Error)
+	doAPIKey(t, created.Token, http.MethodGet, "/api/v1/policies", nil, http.StatusForbidden)
+	doAPIKey(t, created.Token, http.MethodPost, "/api/v1/api-keys", handlers.APIKeyPayload{Name: "Outra", Scopes: []models.APIKeyScope{models.ScopeRisksRead}}, http.StatusForbidden)
+
+	var key models.APIKey
+	require.NoError(t, h.DB.First(&key, "id = ?", created.ID).Error)
+	assert.NotNil(t, key.LastUsedAt)
+	assert.NotEqual(t, created.Token, key.SecretHash)
+
+	// O admin vê e revoga as chaves da organização; a chave revogada deixa de autenticar
+	var list struct {
+		Items []handlers.APIKeyView `json:"items"`
+	}
+	h.DoJSON(t, ownerToken, http.MethodGet, "/api/v1/api-keys?all=true", nil, http.StatusForbidden, nil)
+	h.DoJSON(t, adminToken, http.MethodGet, "/api/v1/api-keys?all=true", nil, http.StatusOK, &list)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, []models.APIKeyScope{models.ScopeRisksWrite}, list.Items[0].Scopes)
+	h.DoJSON(t, adminToken, http.MethodDelete, "/api/v1/api-keys/"+created.ID.String(), nil, http.StatusOK, nil)
+	doAPIKey(t, created.Token, http.MethodGet, "/api/v1/risks", nil, http.StatusUnauthorized)
+
+	// Chaves de outra organização não são visíveis
+	other := h.NewOrganization(t, "Outra")
+	_, otherAdminToken := h.NewUser(t, other, models.RoleAdmin)
+	h.DoJSON(t, otherAdminToken, http.MethodDelete, "/api/v1/api-keys/"+created.ID.String(), nil, http.StatusNotFound, nil)
+}
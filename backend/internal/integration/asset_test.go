@@ -0,0 +1,137 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assetPage é a resposta paginada de GET /assets.
+type assetPage struct {
+	Items      []models.Asset `json:"items"`
+	TotalItems int64          `json:"total_items"`
+}
+
+func TestAssetInventory(t *testing.T) {
+	org := h.NewOrganization(t, "Inventário de ativos")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	owner, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	outsider, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	payload := handlers.AssetPayload{Name: "ERP", Type: models.AssetTypeSoftware, OwnerID: owner.ID.String(), Criticality: models.AssetCriticalityHigh}
+
+	// Só admins e gestores mantêm o inventário; o responsável precisa ser da organização
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/assets", payload, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/assets",
+		handlers.AssetPayload{Name: "ERP", Type: models.AssetTypeSoftware, OwnerID: outsider.ID.String()}, http.StatusBadRequest, nil)
+
+	var asset models.Asset
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/assets", payload, http.StatusCreated, &asset)
+	assert.Equal(t, models.AssetCriticalityHigh, asset.Criticality)
+	assert.Equal(t, models.DataClassificationInternal, asset.DataClassification)
+	require.NotNil(t, asset.OwnerID)
+	assert.Equal(t, owner.ID, *asset.OwnerID)
+	assetPath := "/api/v1/assets/" + asset.ID.String()
+
+	// O nome é único por organização, mas outras organizações podem usá-lo
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/assets", payload, http.StatusConflict, nil)
+	h.DoJSON(t, outsiderToken, http.MethodPost, "/api/v1/assets", handlers.AssetPayload{Name: "ERP", Type: models.AssetTypeSoftware}, http.StatusCreated, nil)
+
+	// Outras organizações não veem nem alteram o ativo
+	h.DoJSON(t, outsiderToken, http.MethodGet, assetPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, outsiderToken, http.MethodPut, assetPath, payload, http.StatusNotFound, nil)
+	h.DoJSON(t, outsiderToken, http.MethodDelete, assetPath, nil, http.StatusNotFound, nil)
+
+	// Vínculos: apenas riscos da organização e controles existentes
+	var risk, foreignRisk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Indisponibilidade do ERP", OwnerID: owner.ID.String()}, http.StatusCreated, &risk)
+	h.DoJSON(t, outsiderToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco externo", OwnerID: outsider.ID.String()}, http.StatusCreated, &foreignRisk)
+	h.DoJSON(t, memberToken, http.MethodPut, assetPath+"/risks", handlers.AssetRisksPayload{RiskIDs: []string{risk.ID.String()}}, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPut, assetPath+"/risks",
+		handlers.AssetRisksPayload{RiskIDs: []string{risk.ID.String(), foreignRisk.ID.String()}}, http.StatusBadRequest, nil)
+	var linked handlers.AssetDetailResponse
+	h.DoJSON(t, managerToken, http.MethodPut, assetPath+"/risks", handlers.AssetRisksPayload{RiskIDs: []string{risk.ID.String()}}, http.StatusOK, &linked)
+	require.Len(t, linked.Risks, 1)
+	assert.Equal(t, risk.ID, linked.Risks[0].ID)
+
+	framework := models.AuditFramework{Name: "Framework de ativos " + uuid.NewString()}
+	require.NoError(t, h.DB.Create(&framework).Error)
+	control := models.AuditControl{FrameworkID: framework.ID, ControlID: "AS-1"}
+	require.NoError(t, h.DB.Create(&control).Error)
+	h.DoJSON(t, managerToken, http.MethodPut, assetPath+"/controls",
+		handlers.AssetControlsPayload{AuditControlIDs: []string{uuid.NewString()}}, http.StatusBadRequest, nil)
+	h.DoJSON(t, managerToken, http.MethodPut, assetPath+"/controls",
+		handlers.AssetControlsPayload{AuditControlIDs: []string{control.ID.String()}}, http.StatusOK, nil)
+
+	var detail handlers.AssetDetailResponse
+	h.DoJSON(t, memberToken, http.MethodGet, assetPath, nil, http.StatusOK, &detail)
+	require.Len(t, detail.Risks, 1)
+	require.Len(t, detail.Controls, 1)
+	assert.Equal(t, "AS-1", detail.Controls[0].ControlID)
+	assert.Equal(t, framework.Name, detail.Controls[0].FrameworkName)
+
+	var riskAssets []models.Asset
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/risks/"+risk.ID.String()+"/assets", nil, http.StatusOK, &riskAssets)
+	require.Len(t, riskAssets, 1)
+	assert.Equal(t, asset.ID, riskAssets[0].ID)
+	var leaked []models.Asset
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/risks/"+risk.ID.String()+"/assets", nil, http.StatusOK, &leaked)
+	assert.Empty(t, leaked)
+
+	// Importação CSV: cria pelo nome, atualiza os existentes e informa as linhas inválidas
+	csvContent := "name,type,owner_email,criticality,data_classification,location\n" +
+		"erp,software,,critical,restricted,AWS\n" +
+		"Notebooks,hardware," + owner.Email + ",,,Escritório\n" +
+		"Firewall,router,,,,\n" +
+		"Backup,service," + outsider.Email + ",,,\n" +
+		"Notebooks,hardware,,,,\n"
+	rec := h.DoMultipart(t, memberToken, http.MethodPost, "/api/v1/assets/import-csv", "file", "ativos.csv", []byte(csvContent), nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = h.DoMultipart(t, managerToken, http.MethodPost, "/api/v1/assets/import-csv", "file", "ativos.csv", []byte("name,description\nERP,x\n"), nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "the type column is required")
+
+	rec = h.DoMultipart(t, managerToken, http.MethodPost, "/api/v1/assets/import-csv", "file", "ativos.csv", []byte(csvContent), nil)
+	require.Equal(t, http.StatusMultiStatus, rec.Code, rec.Body.String())
+	var result handlers.ImportAssetsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	require.Len(t, result.FailedRows, 3)
+	assert.Equal(t, 4, result.FailedRows[0].LineNumber)
+	assert.Equal(t, 5, result.FailedRows[1].LineNumber)
+	assert.Equal(t, 6, result.FailedRows[2].LineNumber)
+
+	var updated handlers.AssetDetailResponse
+	h.DoJSON(t, memberToken, http.MethodGet, assetPath, nil, http.StatusOK, &updated)
+	assert.Equal(t, models.AssetCriticalityCritical, updated.Criticality)
+	assert.Equal(t, models.DataClassificationRestricted, updated.DataClassification)
+	assert.Equal(t, "AWS", updated.Location)
+	assert.Nil(t, updated.OwnerID)
+	assert.Len(t, updated.Risks, 1, "the import keeps the asset's links")
+
+	var page assetPage
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/assets?criticality=critical", nil, http.StatusOK, &page)
+	require.EqualValues(t, 1, page.TotalItems)
+	assert.Equal(t, asset.ID, page.Items[0].ID)
+	var all assetPage
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/assets", nil, http.StatusOK, &all)
+	assert.EqualValues(t, 2, all.TotalItems)
+
+	// A exclusão remove o ativo e seus vínculos
+	h.DoJSON(t, memberToken, http.MethodDelete, assetPath, nil, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodDelete, assetPath, nil, http.StatusOK, nil)
+	h.DoJSON(t, managerToken, http.MethodGet, assetPath, nil, http.StatusNotFound, nil)
+	var links int64
+	require.NoError(t, h.DB.Model(&models.AssetRisk{}).Where("asset_id = ?", asset.ID).Count(&links).Error)
+	assert.Zero(t, links)
+}
@@ -0,0 +1,122 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessProcessBIA(t *testing.T) {
+	org := h.NewOrganization(t, "Continuidade de negócios")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	owner, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	// Dependências: um ativo com um risco alto e um fornecedor com um risco baixo
+	var asset, foreignAsset models.Asset
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/assets", handlers.AssetPayload{Name: "ERP", Type: models.AssetTypeSoftware}, http.StatusCreated, &asset)
+	h.DoJSON(t, outsiderToken, http.MethodPost, "/api/v1/assets", handlers.AssetPayload{Name: "ERP", Type: models.AssetTypeSoftware}, http.StatusCreated, &foreignAsset)
+	vendor := models.Vendor{OrganizationID: org.ID, Name: "Provedor de nuvem", IsActive: true, Criticality: models.VendorCriticalityHigh}
+	require.NoError(t, h.DB.Create(&vendor).Error)
+
+	var highRisk, lowRisk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{
+		Title: "Indisponibilidade do ERP", Impact: models.ImpactHigh, Probability: models.ProbabilityHigh, OwnerID: owner.ID.String(),
+	}, http.StatusCreated, &highRisk)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{
+		Title: "Atraso no suporte", Impact: models.ImpactLow, Probability: models.ProbabilityLow, OwnerID: owner.ID.String(),
+	}, http.StatusCreated, &lowRisk)
+	h.DoJSON(t, managerToken, http.MethodPut, "/api/v1/assets/"+asset.ID.String()+"/risks",
+		handlers.AssetRisksPayload{RiskIDs: []string{highRisk.ID.String()}}, http.StatusOK, nil)
+	h.DoJSON(t, managerToken, http.MethodPut, "/api/v1/vendors/"+vendor.ID.String()+"/risks",
+		handlers.VendorRisksPayload{RiskIDs: []string{lowRisk.ID.String()}}, http.StatusOK, nil)
+
+	rto, rpo, shortMTPD := 4, 1, 2
+	payload := handlers.BusinessProcessPayload{
+		Name: "Faturamento", OwnerID: owner.ID.String(), Criticality: models.AssetCriticalityCritical,
+		RTOHours: &rto, RPOHours: &rpo, AssetIDs: []string{asset.ID.String()}, VendorIDs: []string{vendor.ID.String()},
+	}
+
+	// Só admins e gestores cadastram processos; MTPD e dependências são validados
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/business-processes", payload, http.StatusForbidden, nil)
+	invalid := payload
+	invalid.MTPDHours = &shortMTPD
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/business-processes", invalid, http.StatusBadRequest, nil)
+	invalid = payload
+	invalid.AssetIDs = []string{foreignAsset.ID.String()}
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/business-processes", invalid, http.StatusBadRequest, nil)
+
+	var process handlers.BusinessProcessDetailResponse
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/business-processes", payload, http.StatusCreated, &process)
+	assert.Equal(t, 4, process.RTOHours)
+	require.Len(t, process.Assets, 1)
+	assert.Equal(t, asset.ID, process.Assets[0].ID)
+	require.Len(t, process.Vendors, 1)
+	assert.Equal(t, vendor.ID, process.Vendors[0].ID)
+	processPath := "/api/v1/business-processes/" + process.ID.String()
+
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/business-processes", payload, http.StatusConflict, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/business-processes",
+		handlers.BusinessProcessPayload{Name: "Recrutamento", Criticality: models.AssetCriticalityLow, RTOHours: &rto, RPOHours: &rpo}, http.StatusCreated, nil)
+
+	// Outras organizações não veem o processo nem entram no relatório
+	h.DoJSON(t, outsiderToken, http.MethodGet, processPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, outsiderToken, http.MethodPut, processPath, payload, http.StatusNotFound, nil)
+	var foreignReport handlers.BIAReportResponse
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/business-processes/bia-report", nil, http.StatusOK, &foreignReport)
+	assert.Zero(t, foreignReport.Summary.TotalProcesses)
+
+	// O relatório BIA traz os riscos abertos das dependências a partir do nível mínimo (padrão Alto)
+	var report handlers.BIAReportResponse
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/business-processes/bia-report", nil, http.StatusOK, &report)
+	assert.Equal(t, 2, report.Summary.TotalProcesses)
+	assert.Equal(t, 1, report.Summary.AtRiskProcesses)
+	assert.Equal(t, 1, report.Summary.WithoutDependencies)
+	assert.Equal(t, 1, report.Summary.DistinctRisks)
+	require.Len(t, report.Processes, 2)
+	assert.Equal(t, process.ID, report.Processes[0].ID, "processes at risk come first")
+	require.Len(t, report.Processes[0].OpenRisks, 1)
+	assert.Equal(t, highRisk.ID, report.Processes[0].OpenRisks[0].RiskID)
+	assert.Equal(t, "asset", report.Processes[0].OpenRisks[0].DependencyType)
+	assert.Equal(t, 1, report.Processes[0].AssetCount)
+	assert.Equal(t, 1, report.Processes[0].VendorCount)
+
+	var allLevels handlers.BIAReportResponse
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/business-processes/bia-report?min_risk_level=Baixo&at_risk_only=true", nil, http.StatusOK, &allLevels)
+	assert.Equal(t, 2, allLevels.Summary.DistinctRisks)
+	require.Len(t, allLevels.Processes, 1)
+	assert.Len(t, allLevels.Processes[0].OpenRisks, 2)
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/business-processes/bia-report?min_risk_level=Urgente", nil, http.StatusBadRequest, nil)
+
+	// Um risco mitigado deixa de colocar o processo em risco
+	require.NoError(t, h.DB.Model(&models.Risk{}).Where("id = ?", highRisk.ID).UpdateColumn("status", models.StatusMitigated).Error)
+	var mitigated handlers.BIAReportResponse
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/business-processes/bia-report", nil, http.StatusOK, &mitigated)
+	assert.Zero(t, mitigated.Summary.AtRiskProcesses)
+
+	// Dependências omitidas são mantidas; uma lista vazia as remove
+	update := payload
+	update.AssetIDs = nil
+	update.VendorIDs = []string{}
+	var updated handlers.BusinessProcessDetailResponse
+	h.DoJSON(t, memberToken, http.MethodPut, processPath, update, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPut, processPath, update, http.StatusOK, &updated)
+	assert.Len(t, updated.Assets, 1)
+	assert.Empty(t, updated.Vendors)
+
+	// A exclusão remove o processo e suas dependências
+	h.DoJSON(t, memberToken, http.MethodDelete, processPath, nil, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodDelete, processPath, nil, http.StatusOK, nil)
+	h.DoJSON(t, managerToken, http.MethodGet, processPath, nil, http.StatusNotFound, nil)
+	var links int64
+	require.NoError(t, h.DB.Model(&models.BusinessProcessAsset{}).Where("business_process_id = ?", process.ID).Count(&links).Error)
+	assert.Zero(t, links)
+}
@@ -0,0 +1,95 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoricalAssessmentImport(t *testing.T) {
+	org := h.NewOrganization(t, "Histórico de auditorias")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	framework := models.AuditFramework{Name: "Framework histórico " + uuid.NewString()}
+	require.NoError(t, h.DB.Create(&framework).Error)
+	for _, code := range []string{"H-1", "H-2"} {
+		require.NoError(t, h.DB.Create(&models.AuditControl{FrameworkID: framework.ID, ControlID: code}).Error)
+	}
+	frameworkPath := "/api/v1/audit/organizations/" + org.ID.String() + "/frameworks/" + framework.ID.String()
+	importPath := frameworkPath + "/historical-assessments/import"
+	historyPath := frameworkPath + "/compliance-score/history"
+
+	future := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+	csvContent := "control_id,status,assessment_date,score\n" +
+		"H-1,conforme,2024-01-10,\n" +
+		"h-2,nao_conforme,2024-01-10,20\n" +
+		"H-1,nao_conforme,2024-06-01,0\n" +
+		"H-9,conforme,2024-06-01,\n" +
+		"H-2,conforme," + future + ",\n"
+
+	// Só quem tem audit:import_history importa, e apenas na própria organização
+	rec := h.DoMultipart(t, memberToken, http.MethodPost, importPath, "file", "historico.csv", []byte(csvContent), nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = h.DoMultipart(t, outsiderToken, http.MethodPost, importPath, "file", "historico.csv", []byte(csvContent), nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = h.DoMultipart(t, managerToken, http.MethodPost, importPath, "file", "historico.csv", []byte("control_id,status,assessment_date\nH-9,conforme,2024-01-10\n"), nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "nothing valid to import")
+
+	// Frameworks personalizados de outra organização não são visíveis
+	foreign := models.AuditFramework{Name: "Framework alheio " + uuid.NewString(), OrganizationID: &other.ID}
+	require.NoError(t, h.DB.Create(&foreign).Error)
+	require.NoError(t, h.DB.Create(&models.AuditControl{FrameworkID: foreign.ID, ControlID: "H-1"}).Error)
+	foreignImportPath := "/api/v1/audit/organizations/" + org.ID.String() + "/frameworks/" + foreign.ID.String() + "/historical-assessments/import"
+	rec = h.DoMultipart(t, managerToken, http.MethodPost, foreignImportPath, "file", "historico.csv", []byte(csvContent), nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// As linhas válidas são importadas; as inválidas são informadas
+	rec = h.DoMultipart(t, managerToken, http.MethodPost, importPath, "file", "historico.csv", []byte(csvContent), nil)
+	require.Equal(t, http.StatusMultiStatus, rec.Code, rec.Body.String())
+	var result handlers.ImportHistoricalAssessmentsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, 3, result.SuccessfullyImported)
+	assert.Equal(t, 2, result.SnapshotsGenerated)
+	require.Len(t, result.FailedRows, 2)
+	assert.Equal(t, 5, result.FailedRows[0].LineNumber)
+	assert.Equal(t, 6, result.FailedRows[1].LineNumber)
+
+	// O histórico não altera as avaliações correntes
+	var current int64
+	require.NoError(t, h.DB.Model(&models.AuditAssessment{}).Where("organization_id = ?", org.ID).Count(&current).Error)
+	assert.Zero(t, current)
+
+	// Cada data importada gera um snapshot com o resultado mais recente de cada controle até ela
+	var snapshots []models.ComplianceScoreSnapshot
+	h.DoJSON(t, memberToken, http.MethodGet, historyPath, nil, http.StatusOK, &snapshots)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, "2024-01-10", snapshots[0].SnapshotDate.Format("2006-01-02"))
+	assert.Equal(t, models.ComplianceSnapshotSourceHistoricalImport, snapshots[0].Source)
+	assert.Equal(t, 2, snapshots[0].TotalControls)
+	assert.Equal(t, 2, snapshots[0].EvaluatedControls)
+	assert.Equal(t, 1, snapshots[0].ConformantControls)
+	assert.InDelta(t, 60, snapshots[0].ComplianceScore, 0.01, "rubric score 100 for conforme and 20 from the file")
+	assert.Equal(t, "2024-06-01", snapshots[1].SnapshotDate.Format("2006-01-02"))
+	assert.Equal(t, 2, snapshots[1].NonConformantControls)
+	assert.InDelta(t, 10, snapshots[1].ComplianceScore, 0.01)
+
+	var filtered []models.ComplianceScoreSnapshot
+	h.DoJSON(t, memberToken, http.MethodGet, historyPath+"?from=2024-03-01", nil, http.StatusOK, &filtered)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "2024-06-01", filtered[0].SnapshotDate.Format("2006-01-02"))
+	h.DoJSON(t, memberToken, http.MethodGet, historyPath+"?granularity=monthly", nil, http.StatusBadRequest, nil)
+	h.DoJSON(t, outsiderToken, http.MethodGet, historyPath, nil, http.StatusForbidden, nil)
+}
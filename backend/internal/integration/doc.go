@@ -0,0 +1,12 @@
+// Package integration contém os testes de ponta a ponta do backend contra um PostgreSQL real: o esquema é
+// criado pelas migrações SQL e pelo AutoMigrate do assistente de setup, os dados iniciais pelos seeders, e os
+// fluxos são exercitados pelas rotas HTTP do router, com autenticação JWT.
+//
+// Os testes só compilam com a build tag integration:
+//
+//	go test -tags integration ./internal/integration/...
+//
+// Com TEST_DATABASE_URL definida, o banco informado é usado (ele deve ser descartável). Sem ela, um
+// contêiner postgres (INTEGRATION_POSTGRES_IMAGE, padrão postgres:16-alpine) é iniciado com o Docker e
+// removido ao final. Sem nenhum dos dois, os testes são ignorados.
+package integration
@@ -0,0 +1,84 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// editLockPresence é a resposta de GET /edit-locks/:resourceType/:resourceId.
+type editLockPresence struct {
+	Locked bool             `json:"locked"`
+	Lock   *models.EditLock `json:"lock"`
+}
+
+func TestEditLockOnRisk(t *testing.T) {
+	org := h.NewOrganization(t, "Edição concorrente")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	owner, ownerToken := h.NewUser(t, org, models.RoleUser)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	var risk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco em edição", OwnerID: owner.ID.String()}, http.StatusCreated, &risk)
+	lockPath := "/api/v1/edit-locks/risk/" + risk.ID.String()
+	riskPath := "/api/v1/risks/" + risk.ID.String()
+
+	// O recurso precisa ser da organização do token e de um tipo suportado
+	h.DoJSON(t, outsiderToken, http.MethodPost, lockPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, ownerToken, http.MethodPost, "/api/v1/edit-locks/policy/"+risk.ID.String(), nil, http.StatusBadRequest, nil)
+
+	var lock models.EditLock
+	h.DoJSON(t, ownerToken, http.MethodPost, lockPath, nil, http.StatusOK, &lock)
+	assert.Equal(t, owner.ID, lock.UserID)
+	assert.Equal(t, owner.Name, lock.UserName)
+	assert.True(t, lock.ExpiresAt.After(time.Now()))
+
+	// Presença: os demais veem quem está editando e não conseguem o lock nem salvar
+	var presence editLockPresence
+	h.DoJSON(t, memberToken, http.MethodGet, lockPath, nil, http.StatusOK, &presence)
+	assert.True(t, presence.Locked)
+	require.NotNil(t, presence.Lock)
+	assert.Equal(t, owner.ID, presence.Lock.UserID)
+
+	var conflict struct {
+		Lock models.EditLock `json:"lock"`
+	}
+	h.DoJSON(t, managerToken, http.MethodPost, lockPath, nil, http.StatusConflict, &conflict)
+	assert.Equal(t, owner.ID, conflict.Lock.UserID)
+	h.DoJSON(t, managerToken, http.MethodPut, riskPath, handlers.RiskPayload{Title: "Alterado pelo gestor"}, http.StatusConflict, nil)
+
+	// O detentor renova o lease (mantendo o início) e salva normalmente
+	var renewed models.EditLock
+	h.DoJSON(t, ownerToken, http.MethodPost, lockPath, nil, http.StatusOK, &renewed)
+	assert.WithinDuration(t, lock.AcquiredAt, renewed.AcquiredAt, time.Millisecond)
+	assert.False(t, renewed.ExpiresAt.Before(lock.ExpiresAt))
+	h.DoJSON(t, ownerToken, http.MethodPut, riskPath, handlers.RiskPayload{Title: "Alterado pelo responsável", OwnerID: owner.ID.String()}, http.StatusOK, nil)
+
+	// Um usuário comum não libera o lock de outro; gestores liberam
+	h.DoJSON(t, memberToken, http.MethodDelete, lockPath, nil, http.StatusOK, nil)
+	h.DoJSON(t, memberToken, http.MethodGet, lockPath, nil, http.StatusOK, &presence)
+	assert.True(t, presence.Locked)
+	h.DoJSON(t, managerToken, http.MethodDelete, lockPath, nil, http.StatusOK, nil)
+	var released editLockPresence
+	h.DoJSON(t, memberToken, http.MethodGet, lockPath, nil, http.StatusOK, &released)
+	assert.False(t, released.Locked)
+
+	// Um lease vencido é assumido por outro usuário
+	h.DoJSON(t, ownerToken, http.MethodPost, lockPath, nil, http.StatusOK, nil)
+	require.NoError(t, h.DB.Model(&models.EditLock{}).Where("resource_id = ?", risk.ID).
+		UpdateColumn("expires_at", time.Now().Add(-time.Second)).Error)
+	var takenOver models.EditLock
+	h.DoJSON(t, managerToken, http.MethodPost, lockPath, nil, http.StatusOK, &takenOver)
+	assert.NotEqual(t, owner.ID, takenOver.UserID)
+	h.DoJSON(t, ownerToken, http.MethodPut, riskPath, handlers.RiskPayload{Title: "Sem o lock", OwnerID: owner.ID.String()}, http.StatusConflict, nil)
+}
@@ -0,0 +1,148 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// evidenceRequestPage é a resposta paginada de GET /evidence-requests.
+type evidenceRequestPage struct {
+	Items      []models.EvidenceRequest `json:"items"`
+	TotalItems int64                    `json:"total_items"`
+}
+
+func TestEvidenceRequestPortal(t *testing.T) {
+	storage := useMemoryStorage(t)
+	org := h.NewOrganization(t, "Portal de evidências")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	framework := models.AuditFramework{Name: "Framework de evidências " + uuid.NewString()}
+	require.NoError(t, h.DB.Create(&framework).Error)
+	control := models.AuditControl{FrameworkID: framework.ID, ControlID: "EV-1"}
+	require.NoError(t, h.DB.Create(&control).Error)
+
+	const contributor = "contato@fornecedor.test"
+	payload := handlers.EvidenceRequestPayload{
+		Title: "Relatório de backup", AuditControlID: control.ID.String(),
+		AssigneeEmail: "Contato@Fornecedor.test", DueDate: time.Now().AddDate(0, 0, 5),
+	}
+
+	// Só admins e gestores solicitam evidências, sempre para controles existentes
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/evidence-requests", payload, http.StatusForbidden, nil)
+	invalid := payload
+	invalid.AuditControlID = uuid.NewString()
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/evidence-requests", invalid, http.StatusBadRequest, nil)
+
+	var backup, overdue, unrelated models.EvidenceRequest
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/evidence-requests", payload, http.StatusCreated, &backup)
+	assert.Equal(t, models.EvidenceRequestPending, backup.Status)
+	assert.Equal(t, contributor, backup.AssigneeEmail)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/evidence-requests", handlers.EvidenceRequestPayload{
+		Title: "Política de acesso assinada", AssigneeEmail: contributor, DueDate: time.Now().AddDate(0, 0, -1),
+	}, http.StatusCreated, &overdue)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/evidence-requests", handlers.EvidenceRequestPayload{
+		Title: "Inventário de licenças", AssigneeEmail: "outra@pessoa.test", DueDate: time.Now().AddDate(0, 0, 5),
+	}, http.StatusCreated, &unrelated)
+	backupPath := "/api/v1/evidence-requests/" + backup.ID.String()
+
+	// Outras organizações não veem as solicitações
+	h.DoJSON(t, outsiderToken, http.MethodGet, backupPath, nil, http.StatusNotFound, nil)
+	var foreign evidenceRequestPage
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/evidence-requests", nil, http.StatusOK, &foreign)
+	assert.Zero(t, foreign.TotalItems)
+	var late evidenceRequestPage
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/evidence-requests?overdue=true", nil, http.StatusOK, &late)
+	require.EqualValues(t, 1, late.TotalItems)
+	assert.Equal(t, overdue.ID, late.Items[0].ID)
+
+	// O link do portal é enviado ao colaborador; o token só aparece na URL da criação
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/evidence-portal-links", handlers.EvidencePortalLinkPayload{Email: contributor}, http.StatusForbidden, nil)
+	var link handlers.EvidencePortalLinkResponse
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/evidence-portal-links", handlers.EvidencePortalLinkPayload{Email: contributor, Name: "Contato"}, http.StatusCreated, &link)
+	portalPath := "/api/public/evidence-portal/" + path.Base(link.PortalURL)
+	h.DoJSON(t, "", http.MethodGet, "/api/public/evidence-portal/"+uuid.NewString(), nil, http.StatusNotFound, nil)
+
+	// O colaborador vê apenas as suas pendências, por prazo
+	var portal handlers.EvidencePortalResponse
+	h.DoJSON(t, "", http.MethodGet, portalPath, nil, http.StatusOK, &portal)
+	assert.Equal(t, org.Name, portal.OrganizationName)
+	assert.Equal(t, 2, portal.Summary.ToDo)
+	assert.Equal(t, 1, portal.Summary.Overdue)
+	require.Len(t, portal.Tasks, 2)
+	assert.Equal(t, overdue.ID, portal.Tasks[0].ID)
+	assert.True(t, portal.Tasks[0].Overdue)
+	assert.Equal(t, backup.ID, portal.Tasks[1].ID)
+
+	// O envio vale apenas para as solicitações do colaborador e para os tipos de arquivo permitidos
+	pdf := []byte("%PDF-1.4\n% backup\n")
+	rec := h.DoMultipart(t, "", http.MethodPost, portalPath+"/requests/"+unrelated.ID.String()+"/upload", "file", "backup.pdf", pdf, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = h.DoMultipart(t, "", http.MethodPost, portalPath+"/requests/"+backup.ID.String()+"/upload", "file", "backup.exe", []byte{0x4d, 0x5a, 0x00, 0x01, 0x02}, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = h.DoMultipart(t, "", http.MethodPost, portalPath+"/requests/"+backup.ID.String()+"/upload", "file", "backup.pdf", pdf, map[string]string{"note": "Janeiro"})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var awaiting handlers.EvidencePortalResponse
+	h.DoJSON(t, "", http.MethodGet, portalPath, nil, http.StatusOK, &awaiting)
+	assert.Equal(t, 1, awaiting.Summary.ToDo)
+	assert.Equal(t, 1, awaiting.Summary.AwaitingReview)
+
+	// Revisão: só gestores, só o que foi enviado; a rejeição volta ao portal com o motivo
+	review := handlers.EvidenceRequestReviewPayload{Decision: "reject", Note: "Falta o mês de fevereiro"}
+	h.DoJSON(t, memberToken, http.MethodPost, backupPath+"/review", review, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/evidence-requests/"+overdue.ID.String()+"/review", review, http.StatusConflict, nil)
+	var rejected models.EvidenceRequest
+	h.DoJSON(t, managerToken, http.MethodPost, backupPath+"/review", review, http.StatusOK, &rejected)
+	assert.Equal(t, models.EvidenceRequestRejected, rejected.Status)
+
+	var resubmit handlers.EvidencePortalResponse
+	h.DoJSON(t, "", http.MethodGet, portalPath, nil, http.StatusOK, &resubmit)
+	require.Len(t, resubmit.Tasks, 2)
+	assert.Equal(t, "Falta o mês de fevereiro", resubmit.Tasks[1].ReviewNote)
+
+	// O reenvio aceito vira a evidência da avaliação do controle
+	rec = h.DoMultipart(t, "", http.MethodPost, portalPath+"/requests/"+backup.ID.String()+"/upload", "file", "backup-completo.pdf", pdf, nil)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var detail models.EvidenceRequest
+	h.DoJSON(t, managerToken, http.MethodGet, backupPath, nil, http.StatusOK, &detail)
+	require.Len(t, detail.Submissions, 2)
+	assert.Equal(t, "backup-completo.pdf", detail.Submissions[0].FileName)
+	assert.Contains(t, storage.objects, detail.Submissions[0].ObjectName)
+
+	var accepted models.EvidenceRequest
+	h.DoJSON(t, managerToken, http.MethodPost, backupPath+"/review", handlers.EvidenceRequestReviewPayload{Decision: "accept"}, http.StatusOK, &accepted)
+	assert.Equal(t, models.EvidenceRequestAccepted, accepted.Status)
+	var assessment models.AuditAssessment
+	require.NoError(t, h.DB.First(&assessment, "organization_id = ? AND audit_control_id = ?", org.ID, control.ID).Error)
+	assert.Equal(t, detail.Submissions[0].ObjectName, assessment.EvidenceURL)
+
+	// Uma evidência aceita está encerrada
+	rec = h.DoMultipart(t, "", http.MethodPost, portalPath+"/requests/"+backup.ID.String()+"/upload", "file", "backup.pdf", pdf, nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	h.DoJSON(t, managerToken, http.MethodPut, backupPath, payload, http.StatusConflict, nil)
+
+	// Links revogados ou expirados deixam de funcionar
+	h.DoJSON(t, outsiderToken, http.MethodPost, "/api/v1/evidence-portal-links/"+link.ID.String()+"/revoke", nil, http.StatusNotFound, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/evidence-portal-links/"+link.ID.String()+"/revoke", nil, http.StatusOK, nil)
+	h.DoJSON(t, "", http.MethodGet, portalPath, nil, http.StatusGone, nil)
+
+	var expiring handlers.EvidencePortalLinkResponse
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/evidence-portal-links", handlers.EvidencePortalLinkPayload{Email: contributor}, http.StatusCreated, &expiring)
+	require.NoError(t, h.DB.Model(&models.EvidencePortalLink{}).Where("id = ?", expiring.ID).
+		UpdateColumn("expires_at", time.Now().Add(-time.Minute)).Error)
+	h.DoJSON(t, "", http.MethodGet, "/api/public/evidence-portal/"+path.Base(expiring.PortalURL), nil, http.StatusGone, nil)
+}
@@ -0,0 +1,76 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/featureflags"
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/features"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagAdminPerOrganizationOverride(t *testing.T) {
+	// Cache longo: sem a invalidação feita pelos endpoints, as alterações não seriam vistas no teste
+	features.Init(featureflags.Source{}, time.Hour)
+
+	platform := h.NewOrganization(t, "Plataforma")
+	_, sysAdminToken := h.NewUser(t, platform, models.RoleSystemAdmin)
+	tenant := h.NewOrganization(t, "Cliente piloto")
+	_, tenantToken := h.NewUser(t, tenant, models.RoleUser)
+	other := h.NewOrganization(t, "Outro cliente")
+	_, otherToken := h.NewUser(t, other, models.RoleAdmin)
+
+	const key = "INTEGRATION_TENANT_FLAG"
+	flagsPath := "/api/v1/admin/feature-flags"
+	flagPath := flagsPath + "/" + key
+	evaluationPath := "/api/v1/feature-flags/" + key + "/evaluation"
+	t.Cleanup(func() { h.Do(t, sysAdminToken, http.MethodDelete, flagPath, nil) })
+
+	// Só o administrador do sistema gerencia as flags
+	h.DoJSON(t, otherToken, http.MethodGet, flagsPath, nil, http.StatusForbidden, nil)
+	h.DoJSON(t, sysAdminToken, http.MethodPost, flagsPath, handlers.FeatureFlagPayload{Key: "minusculas"}, http.StatusBadRequest, nil)
+
+	var flag handlers.FeatureFlagView
+	h.DoJSON(t, sysAdminToken, http.MethodPost, flagsPath, handlers.FeatureFlagPayload{Key: key, Description: "Piloto por cliente", Enabled: true}, http.StatusCreated, &flag)
+	assert.Empty(t, flag.Rules)
+	h.DoJSON(t, sysAdminToken, http.MethodPost, flagsPath, handlers.FeatureFlagPayload{Key: key}, http.StatusConflict, nil)
+
+	var evaluation handlers.FeatureFlagEvaluationResponse
+	h.DoJSON(t, tenantToken, http.MethodGet, evaluationPath, nil, http.StatusOK, &evaluation)
+	assert.False(t, evaluation.Enabled, "0% rollout")
+
+	// O override liga a flag só para o cliente piloto, já na próxima avaliação
+	enabled := true
+	h.DoJSON(t, sysAdminToken, http.MethodPut, flagPath+"/organizations/"+tenant.ID.String(), handlers.FeatureFlagOverridePayload{Enabled: &enabled}, http.StatusOK, &flag)
+	require.Len(t, flag.Rules, 1)
+	assert.Equal(t, tenant.ID, *flag.Rules[0].OrganizationID)
+
+	h.DoJSON(t, tenantToken, http.MethodGet, evaluationPath, nil, http.StatusOK, &evaluation)
+	assert.True(t, evaluation.Enabled)
+	assert.Equal(t, features.ReasonRuleMatch, evaluation.Reason)
+	h.DoJSON(t, otherToken, http.MethodGet, evaluationPath, nil, http.StatusOK, &evaluation)
+	assert.False(t, evaluation.Enabled)
+
+	// A chave geral desliga a flag para todos, inclusive com override
+	disabled := false
+	h.DoJSON(t, sysAdminToken, http.MethodPut, flagPath, handlers.FeatureFlagUpdatePayload{Enabled: &disabled}, http.StatusOK, &flag)
+	assert.Len(t, flag.Rules, 1, "rules are kept when not sent")
+	h.DoJSON(t, tenantToken, http.MethodGet, evaluationPath, nil, http.StatusOK, &evaluation)
+	assert.False(t, evaluation.Enabled)
+	assert.Equal(t, features.ReasonFlagDisabled, evaluation.Reason)
+
+	h.DoJSON(t, sysAdminToken, http.MethodDelete, flagPath+"/organizations/"+tenant.ID.String(), nil, http.StatusOK, &flag)
+	assert.Empty(t, flag.Rules)
+	h.DoJSON(t, sysAdminToken, http.MethodDelete, flagPath+"/organizations/"+tenant.ID.String(), nil, http.StatusNotFound, nil)
+
+	h.DoJSON(t, sysAdminToken, http.MethodDelete, flagPath, nil, http.StatusOK, nil)
+	h.DoJSON(t, tenantToken, http.MethodGet, evaluationPath, nil, http.StatusOK, &evaluation)
+	assert.Equal(t, features.ReasonFlagNotFound, evaluation.Reason)
+}
@@ -0,0 +1,275 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/router"
+	"phoenixgrc/backend/internal/seeders"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-migrate/migrate/v4"
+	postgresdriver "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPostgresImage = "postgres:16-alpine"
+	postgresReadyTimeout = 60 * time.Second
+)
+
+// errNoDatabase indica que não há TEST_DATABASE_URL nem Docker: os testes são ignorados.
+var errNoDatabase = errors.New("TEST_DATABASE_URL is not set and docker is not available")
+
+// Harness é o ambiente compartilhado pelos testes: o banco com o esquema e os dados iniciais e o router da API.
+type Harness struct {
+	DB     *gorm.DB
+	Router *gin.Engine
+}
+
+var h *Harness
+
+func TestMain(m *testing.M) {
+	phxlog.L = zap.NewNop()
+	phxlog.S = phxlog.L.Sugar()
+	gin.SetMode(gin.TestMode)
+
+	stop, err := setup()
+	if errors.Is(err, errNoDatabase) {
+		fmt.Println("integration: skipping,", err)
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Println("integration: setup failed:", err)
+		if stop != nil {
+			stop()
+		}
+		os.Exit(1)
+	}
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// setup conecta ao banco (TEST_DATABASE_URL ou um contêiner), prepara o esquema e monta o router. stop
+// libera o banco e deve ser chamada mesmo se setup falhar depois de iniciá-lo.
+func setup() (stop func(), err error) {
+	stop = func() {}
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		if dsn, stop, err = startPostgresContainer(); err != nil {
+			return nil, err
+		}
+	}
+	config.Cfg.JWTSecret = "integration-test-secret-key"
+	if err := auth.InitializeJWT(); err != nil {
+		return stop, err
+	}
+	if err := waitForDatabase(dsn); err != nil {
+		return stop, err
+	}
+	if err := prepareSchema(database.DB); err != nil {
+		return stop, err
+	}
+	h = &Harness{DB: database.DB, Router: router.SetupRouter(zap.NewNop())}
+	return stop, nil
+}
+
+// startPostgresContainer inicia um contêiner postgres descartável com o Docker e retorna a sua DSN.
+func startPostgresContainer() (dsn string, stop func(), err error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return "", nil, errNoDatabase
+	}
+	image := os.Getenv("INTEGRATION_POSTGRES_IMAGE")
+	if image == "" {
+		image = defaultPostgresImage
+	}
+	out, err := exec.Command("docker", "run", "--detach", "--rm",
+		"--env", "POSTGRES_USER=phoenix", "--env", "POSTGRES_PASSWORD=phoenix", "--env", "POSTGRES_DB=phoenix_test",
+		"--publish", "127.0.0.1::5432", image).Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("docker run %s: %w", image, commandError(err))
+	}
+	containerID := strings.TrimSpace(string(out))
+	stop = func() { _ = exec.Command("docker", "rm", "--force", containerID).Run() }
+
+	out, err = exec.Command("docker", "port", containerID, "5432/tcp").Output()
+	if err != nil {
+		stop()
+		return "", nil, fmt.Errorf("docker port: %w", commandError(err))
+	}
+	// Uma linha por endereço publicado (ex: 127.0.0.1:49153)
+	hostPort := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	return fmt.Sprintf("postgres://phoenix:phoenix@%s/phoenix_test?sslmode=disable", hostPort), stop, nil
+}
+
+func commandError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
+
+// waitForDatabase conecta database.DB, tentando novamente enquanto o postgres inicia.
+func waitForDatabase(dsn string) error {
+	deadline := time.Now().Add(postgresReadyTimeout)
+	for {
+		err := database.ConnectDB(dsn)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("database not ready after %s: %w", postgresReadyTimeout, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// prepareSchema aplica as migrações SQL (tabelas e visões como risk_list_items) e, como o assistente de setup,
+// o AutoMigrate dos modelos e os seeders.
+func prepareSchema(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	driver, err := postgresdriver.WithInstance(sqlDB, &postgresdriver.Config{})
+	if err != nil {
+		return fmt.Errorf("migrate driver: %w", err)
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://"+filepath.ToSlash(migrationsDir()), "postgres", driver)
+	if err != nil {
+		return fmt.Errorf("migrate source: %w", err)
+	}
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	if err := seeders.RunMigrations(db); err != nil {
+		return fmt.Errorf("auto-migrate: %w", err)
+	}
+	if err := seeders.SeedInitialData(db); err != nil {
+		return fmt.Errorf("seed initial data: %w", err)
+	}
+	return nil
+}
+
+// migrationsDir é o caminho absoluto de internal/database/migrations, independente do diretório do go test.
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "database", "migrations")
+}
+
+// NewOrganization cria uma organização. Cada teste usa as suas, para não depender dos dados dos outros.
+func (h *Harness) NewOrganization(t *testing.T, name string) models.Organization {
+	t.Helper()
+	org := models.Organization{Name: name}
+	require.NoError(t, h.DB.Create(&org).Error)
+	return org
+}
+
+// NewUser cria um usuário ativo da organização com o papel informado e retorna o usuário e um token de acesso.
+func (h *Harness) NewUser(t *testing.T, org models.Organization, role models.UserRole) (models.User, string) {
+	t.Helper()
+	id := uuid.New()
+	user := models.User{
+		ID:             id,
+		OrganizationID: uuid.NullUUID{UUID: org.ID, Valid: true},
+		Name:           string(role) + " " + id.String()[:8],
+		Email:          id.String() + "@integration.test",
+		PasswordHash:   "not-used",
+		Role:           role,
+		IsActive:       true,
+	}
+	require.NoError(t, h.DB.Create(&user).Error)
+	token, err := auth.GenerateToken(&user, org.ID)
+	require.NoError(t, err)
+	return user, token
+}
+
+// Do envia uma requisição ao router com o token (se houver) e o corpo em JSON (se não for nil).
+func (h *Harness) Do(t *testing.T, token, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	authorization := ""
+	if token != "" {
+		authorization = "Bearer " + token
+	}
+	return h.DoWithAuthorization(t, authorization, method, path, body)
+}
+
+// DoWithAuthorization envia a requisição com o cabeçalho Authorization informado (ex: "ApiKey phx_...").
+func (h *Harness) DoWithAuthorization(t *testing.T, authorization, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+// DoMultipart envia um formulário multipart com o arquivo no campo field e os demais campos informados
+// (ex: importações CSV).
+func (h *Harness) DoMultipart(t *testing.T, token, method, path, field, filename string, content []byte, fields map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	for key, value := range fields {
+		require.NoError(t, writer.WriteField(key, value))
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(method, path, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+// DoJSON envia a requisição, verifica o status da resposta e decodifica o corpo em out (se não for nil).
+func (h *Harness) DoJSON(t *testing.T, token, method, path string, body interface{}, wantStatus int, out interface{}) {
+	t.Helper()
+	rec := h.Do(t, token, method, path, body)
+	require.Equal(t, wantStatus, rec.Code, "%s %s: %s", method, path, rec.Body.String())
+	if out != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out))
+	}
+}
@@ -0,0 +1,61 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStorage é um provedor de armazenamento em memória para os testes.
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memoryStorage) UploadFile(ctx context.Context, organizationID, objectName string, fileContent io.Reader) (string, error) {
+	data, err := io.ReadAll(fileContent)
+	if err != nil {
+		return "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[objectName] = data
+	return objectName, nil
+}
+
+func (m *memoryStorage) DeleteFile(ctx context.Context, objectName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, objectName)
+	return nil
+}
+
+func (m *memoryStorage) GetSignedURL(ctx context.Context, objectName string, durationMinutes int) (string, error) {
+	return "https://storage.test/" + objectName, nil
+}
+
+// useMemoryStorage troca o armazenamento padrão por um em memória durante o teste.
+func useMemoryStorage(t *testing.T) *memoryStorage {
+	storage := &memoryStorage{objects: map[string][]byte{}}
+	previous := filestorage.DefaultFileStorageProvider
+	filestorage.DefaultFileStorageProvider = storage
+	t.Cleanup(func() { filestorage.DefaultFileStorageProvider = previous })
+	return storage
+}
+
+// runQueuedJob executa o job enfileirado mais recente do tipo, como faria um worker.
+func runQueuedJob(t *testing.T, jobType string) {
+	var job models.BackgroundJob
+	require.NoError(t, h.DB.Where("type = ? AND status = ?", jobType, models.JobQueued).Order("created_at desc").First(&job).Error)
+	require.NoError(t, h.DB.Model(&job).Updates(map[string]interface{}{"status": models.JobRunning, "locked_by": "test-worker"}).Error)
+	jobs.Process(context.Background(), h.DB, "test-worker", &job)
+}
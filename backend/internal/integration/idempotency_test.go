@@ -0,0 +1,96 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/idempotency"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doIdempotent envia a requisição com o header Idempotency-Key.
+func doIdempotent(t *testing.T, token, key, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", key)
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotentRiskCreation(t *testing.T) {
+	org := h.NewOrganization(t, "Idempotência")
+	manager, token := h.NewUser(t, org, models.RoleManager)
+	payload := handlers.RiskPayload{Title: "Risco repetido pelo cliente"}
+
+	first := doIdempotent(t, token, "create-risk-1", http.MethodPost, "/api/v1/risks", payload)
+	require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	// A repetição recebe a mesma resposta, sem criar outro risco
+	retry := doIdempotent(t, token, "create-risk-1", http.MethodPost, "/api/v1/risks", payload)
+	require.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	var count int64
+	require.NoError(t, h.DB.Model(&models.Risk{}).Where("organization_id = ? AND title = ?", org.ID, payload.Title).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// A mesma chave com outro corpo é recusada
+	w := doIdempotent(t, token, "create-risk-1", http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Outro risco"})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// As chaves são por usuário: outro usuário com a mesma chave cria o seu risco
+	_, otherToken := h.NewUser(t, org, models.RoleManager)
+	w = doIdempotent(t, otherToken, "create-risk-1", http.MethodPost, "/api/v1/risks", payload)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+
+	// Uma requisição ainda em andamento bloqueia as repetições
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.NoError(t, h.DB.Create(&models.IdempotencyKey{
+		UserID: manager.ID, Key: "in-flight", Method: http.MethodPost, Path: "/api/v1/risks",
+		RequestHash: idempotency.Fingerprint(http.MethodPost, "/api/v1/risks", "application/json", body),
+		ExpiresAt:   time.Now().Add(time.Hour), CreatedAt: time.Now(),
+	}).Error)
+	w = doIdempotent(t, token, "in-flight", http.MethodPost, "/api/v1/risks", payload)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Uma chave expirada é reutilizada como nova
+	require.NoError(t, h.DB.Model(&models.IdempotencyKey{}).Where("user_id = ? AND key = ?", manager.ID, "create-risk-1").
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	w = doIdempotent(t, token, "create-risk-1", http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Outro risco"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotentValidationErrorsAreReplayed(t *testing.T) {
+	org := h.NewOrganization(t, "Idempotência Erros")
+	_, token := h.NewUser(t, org, models.RoleManager)
+
+	// Erros de validação são gravados como qualquer resposta final
+	w := doIdempotent(t, token, "invalid-risk", http.MethodPost, "/api/v1/risks", handlers.RiskPayload{})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = doIdempotent(t, token, "invalid-risk", http.MethodPost, "/api/v1/risks", handlers.RiskPayload{})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+
+	// Sem o header, nada muda
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Sem chave"}, http.StatusCreated, nil)
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Sem chave"}, http.StatusCreated, nil)
+}
@@ -0,0 +1,98 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invitationTokenPattern = regexp.MustCompile(`token=([0-9a-f]{64})`)
+
+// lastInvitationToken extrai o token do último e-mail de convite enfileirado para o endereço.
+func lastInvitationToken(t *testing.T, org models.Organization, email string) string {
+	t.Helper()
+	var body string
+	require.NoError(t, h.DB.Model(&models.BackgroundJob{}).
+		Select("payload->>'body'").
+		Where("organization_id = ? AND type = ? AND payload->>'target' = ?", org.ID, notifications.JobDeliver, email).
+		Order("created_at desc").Limit(1).
+		Scan(&body).Error)
+	match := invitationTokenPattern.FindStringSubmatch(body)
+	require.NotNil(t, match, "invitation email with link: %q", body)
+	return match[1]
+}
+
+func TestUserInvitationFlow(t *testing.T) {
+	org := h.NewOrganization(t, "Org Convites")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, userToken := h.NewUser(t, org, models.RoleUser)
+	invitationsPath := "/api/v1/organizations/" + org.ID.String() + "/invitations"
+
+	// 1. Convidar exige users:manage; o papel admin exige users:assign_admin
+	h.DoJSON(t, userToken, http.MethodPost, invitationsPath, handlers.InvitationPayload{Email: "nova@example.com"}, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, invitationsPath, handlers.InvitationPayload{Email: "nova@example.com", Role: models.RoleAdmin}, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, invitationsPath, handlers.InvitationPayload{Email: admin.Email}, http.StatusConflict, nil)
+
+	var first handlers.InvitationView
+	h.DoJSON(t, managerToken, http.MethodPost, invitationsPath, handlers.InvitationPayload{Email: "Nova@Example.com", Name: "Nova Pessoa", Role: models.RoleManager}, http.StatusCreated, &first)
+	assert.Equal(t, "nova@example.com", first.Email)
+	assert.Equal(t, models.InvitationPending, first.Status)
+	firstToken := lastInvitationToken(t, org, "nova@example.com")
+
+	// 2. Um novo convite para o mesmo e-mail revoga o anterior
+	var second handlers.InvitationView
+	h.DoJSON(t, adminToken, http.MethodPost, invitationsPath, handlers.InvitationPayload{Email: "nova@example.com", Name: "Nova Pessoa", Role: models.RoleManager}, http.StatusCreated, &second)
+	assert.EqualValues(t, 2, deliveriesTo(t, org, "nova@example.com"))
+	h.DoJSON(t, "", http.MethodGet, "/auth/invitations/"+firstToken, nil, http.StatusGone, nil)
+
+	var pending []handlers.InvitationView
+	h.DoJSON(t, managerToken, http.MethodGet, invitationsPath+"?status=pending", nil, http.StatusOK, &pending)
+	require.Len(t, pending, 1)
+	assert.Equal(t, second.ID, pending[0].ID)
+
+	// 3. O reenvio troca o link
+	h.DoJSON(t, managerToken, http.MethodPost, invitationsPath+"/"+second.ID.String()+"/resend", nil, http.StatusOK, nil)
+	token := lastInvitationToken(t, org, "nova@example.com")
+
+	var public handlers.PublicInvitationResponse
+	h.DoJSON(t, "", http.MethodGet, "/auth/invitations/"+token, nil, http.StatusOK, &public)
+	assert.Equal(t, org.Name, public.OrganizationName)
+	assert.Equal(t, models.RoleManager, public.Role)
+	assert.True(t, public.PasswordAllowed)
+	h.DoJSON(t, "", http.MethodGet, "/auth/invitations/"+firstToken+"00", nil, http.StatusNotFound, nil)
+
+	// 4. O convidado define a senha e entra na organização com o papel do convite
+	var login handlers.LoginResponse
+	h.DoJSON(t, "", http.MethodPost, "/auth/invitations/accept", handlers.AcceptInvitationPayload{Token: token, Password: "SenhaForte123"}, http.StatusOK, &login)
+	assert.Equal(t, "Nova Pessoa", login.Name)
+	assert.Equal(t, models.RoleManager, login.Role)
+	assert.Equal(t, org.ID.String(), login.OrganizationID)
+	h.DoJSON(t, login.Token, http.MethodGet, "/api/v1/organizations/"+org.ID.String()+"/users", nil, http.StatusOK, nil)
+
+	// 5. O link só vale uma vez
+	h.DoJSON(t, "", http.MethodPost, "/auth/invitations/accept", handlers.AcceptInvitationPayload{Token: token, Password: "OutraSenha123"}, http.StatusGone, nil)
+	h.DoJSON(t, managerToken, http.MethodDelete, invitationsPath+"/"+second.ID.String(), nil, http.StatusConflict, nil)
+
+	// 6. Convite revogado não pode ser aceito
+	var revoked handlers.InvitationView
+	h.DoJSON(t, managerToken, http.MethodPost, invitationsPath, handlers.InvitationPayload{Email: "outra@example.com"}, http.StatusCreated, &revoked)
+	revokedToken := lastInvitationToken(t, org, "outra@example.com")
+	h.DoJSON(t, managerToken, http.MethodDelete, invitationsPath+"/"+revoked.ID.String(), nil, http.StatusOK, &revoked)
+	assert.Equal(t, models.InvitationRevoked, revoked.Status)
+	h.DoJSON(t, "", http.MethodPost, "/auth/invitations/accept", handlers.AcceptInvitationPayload{Token: revokedToken, Password: "SenhaForte123"}, http.StatusGone, nil)
+
+	// 7. Outras organizações não veem os convites
+	other := h.NewOrganization(t, "Org Alheia")
+	_, otherAdminToken := h.NewUser(t, other, models.RoleAdmin)
+	h.DoJSON(t, otherAdminToken, http.MethodGet, invitationsPath, nil, http.StatusForbidden, nil)
+}
@@ -0,0 +1,81 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLoginLockout(t *testing.T) {
+	previousThreshold, previousDuration := config.Cfg.LoginLockoutThreshold, config.Cfg.LoginLockoutDuration
+	config.Cfg.LoginLockoutThreshold, config.Cfg.LoginLockoutDuration = 3, 15*time.Minute
+	t.Cleanup(func() {
+		config.Cfg.LoginLockoutThreshold, config.Cfg.LoginLockoutDuration = previousThreshold, previousDuration
+	})
+
+	org := h.NewOrganization(t, "Org Bloqueio de Login")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, userToken := h.NewUser(t, org, models.RoleUser)
+	user, _ := h.NewUser(t, org, models.RoleUser)
+	hash, err := bcrypt.GenerateFromPassword([]byte("Senha-Correta-1"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, h.DB.Model(&user).Update("password_hash", string(hash)).Error)
+
+	login := func(password string, wantStatus int) {
+		t.Helper()
+		h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: user.Email, Password: password}, wantStatus, nil)
+	}
+
+	// 1. A terceira falha seguida bloqueia a conta; nem a senha correta entra durante o bloqueio
+	login("errada-1", http.StatusUnauthorized)
+	login("errada-2", http.StatusUnauthorized)
+	var locked struct {
+		Error       string    `json:"error"`
+		LockedUntil time.Time `json:"locked_until"`
+	}
+	h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: user.Email, Password: "errada-3"}, http.StatusLocked, &locked)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), locked.LockedUntil, time.Minute)
+	login("Senha-Correta-1", http.StatusLocked)
+	h.DoJSON(t, "", http.MethodPost, "/auth/change-password",
+		handlers.ChangePasswordPayload{Email: user.Email, CurrentPassword: "Senha-Correta-1", NewPassword: "Outra-Senha-123"}, http.StatusLocked, nil)
+
+	// 2. O usuário é avisado por e-mail e o bloqueio entra na trilha de auditoria
+	assert.EqualValues(t, 1, deliveriesTo(t, org, user.Email))
+	var lockEntries int64
+	require.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND action = ? AND entity_id = ?", org.ID, models.AuditTrailUserLoginLocked, user.ID).
+		Count(&lockEntries).Error)
+	assert.EqualValues(t, 1, lockEntries)
+
+	// 3. Quem gerencia usuários vê o bloqueio e desbloqueia a conta
+	userPath := "/api/v1/organizations/" + org.ID.String() + "/users/" + user.ID.String()
+	var view handlers.UserResponse
+	h.DoJSON(t, managerToken, http.MethodGet, userPath, nil, http.StatusOK, &view)
+	require.NotNil(t, view.LockedUntil)
+	h.DoJSON(t, userToken, http.MethodPost, userPath+"/unlock", nil, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, userPath+"/unlock", nil, http.StatusOK, &view)
+	assert.Nil(t, view.LockedUntil)
+	var unlockEntries int64
+	require.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND action = ? AND entity_id = ?", org.ID, models.AuditTrailUserUnlocked, user.ID).
+		Count(&unlockEntries).Error)
+	assert.EqualValues(t, 1, unlockEntries)
+
+	// 4. Depois do desbloqueio o login volta a funcionar, e um login completo zera as falhas
+	login("errada-4", http.StatusUnauthorized)
+	login("Senha-Correta-1", http.StatusOK)
+	var reloaded models.User
+	require.NoError(t, h.DB.First(&reloaded, "id = ?", user.ID).Error)
+	assert.Zero(t, reloaded.FailedLoginAttempts)
+	assert.Nil(t, reloaded.LockedUntil)
+}
@@ -0,0 +1,93 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/webauthn"
+	"phoenixgrc/backend/internal/webauthn/webauthntest"
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestOrganizationEnforcedMFA(t *testing.T) {
+	previousRPID, previousOrigins := config.Cfg.WebAuthnRPID, config.Cfg.WebAuthnOrigins
+	config.Cfg.WebAuthnRPID, config.Cfg.WebAuthnOrigins = "grc.example.test", []string{"https://grc.example.test"}
+	t.Cleanup(func() { config.Cfg.WebAuthnRPID, config.Cfg.WebAuthnOrigins = previousRPID, previousOrigins })
+
+	org := h.NewOrganization(t, "Org MFA Obrigatório")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	manager, _ := h.NewUser(t, org, models.RoleManager)
+	member, _ := h.NewUser(t, org, models.RoleUser)
+	hash, err := bcrypt.GenerateFromPassword([]byte("Senha-Do-Usuario-1"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, h.DB.Model(&models.User{}).Where("id IN ?", []interface{}{manager.ID, member.ID}).
+		Update("password_hash", string(hash)).Error)
+	login := func(user models.User) handlers.LoginResponse {
+		t.Helper()
+		var resp handlers.LoginResponse
+		h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: user.Email, Password: "Senha-Do-Usuario-1"}, http.StatusOK, &resp)
+		return resp
+	}
+	memberSession := login(member)
+	assert.False(t, memberSession.MFAEnrollmentRequired)
+
+	// 1. Exigir MFA dos gerentes: o gerente sem segundo fator fica pendente
+	policyPath := "/api/v1/organizations/" + org.ID.String() + "/mfa-policy"
+	var policy handlers.MFAPolicyResponse
+	h.DoJSON(t, adminToken, http.MethodPut, policyPath, handlers.MFAPolicyPayload{RequireMFARoles: []models.UserRole{models.RoleManager}}, http.StatusCreated, &policy)
+	assert.Equal(t, []models.UserRole{models.RoleManager}, policy.RequiredRoles)
+	if assert.Len(t, policy.PendingEnrollment, 1) {
+		assert.Equal(t, manager.ID, policy.PendingEnrollment[0].ID)
+	}
+	h.DoJSON(t, adminToken, http.MethodPut, policyPath, map[string]interface{}{"require_mfa_roles": []string{"auditor"}}, http.StatusBadRequest, nil)
+
+	// 2. O login do gerente recebe um token restrito ao cadastro de MFA
+	restricted := login(manager)
+	require.True(t, restricted.MFAEnrollmentRequired)
+	var denied struct {
+		MFAEnrollmentRequired bool `json:"mfa_enrollment_required"`
+	}
+	h.DoJSON(t, restricted.Token, http.MethodGet, "/api/v1/risks", nil, http.StatusForbidden, &denied)
+	assert.True(t, denied.MFAEnrollmentRequired)
+	h.DoJSON(t, restricted.Token, http.MethodGet, policyPath, nil, http.StatusForbidden, nil)
+
+	// 3. Com o token restrito ele registra uma passkey; o próximo login pede a passkey e dá acesso completo
+	authenticator := webauthntest.New("grc.example.test", "https://grc.example.test")
+	var creation webauthn.CreationOptions
+	h.DoJSON(t, restricted.Token, http.MethodPost, "/api/v1/users/me/2fa/webauthn/register/options", nil, http.StatusOK, &creation)
+	var credential models.WebAuthnCredential
+	h.DoJSON(t, restricted.Token, http.MethodPost, "/api/v1/users/me/2fa/webauthn/register/verify",
+		handlers.WebAuthnRegistrationPayload{Name: "Notebook", Credential: authenticator.Register(creation.Challenge)}, http.StatusCreated, &credential)
+	h.DoJSON(t, restricted.Token, http.MethodGet, "/api/v1/risks", nil, http.StatusForbidden, nil)
+
+	var second secondFactorResponse
+	h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: manager.Email, Password: "Senha-Do-Usuario-1"}, http.StatusOK, &second)
+	require.True(t, second.TwoFARequired)
+	var full handlers.LoginResponse
+	h.DoJSON(t, "", http.MethodPost, "/auth/login/2fa/webauthn/verify",
+		handlers.LoginVerifyWebAuthnPayload{UserID: second.UserID, Credential: authenticator.Assert(second.WebAuthn.Challenge)}, http.StatusOK, &full)
+	assert.False(t, full.MFAEnrollmentRequired)
+	h.DoJSON(t, full.Token, http.MethodGet, "/api/v1/risks", nil, http.StatusOK, nil)
+	// A única passkey de quem precisa de MFA e não tem TOTP não pode ser removida
+	h.DoJSON(t, full.Token, http.MethodDelete, "/api/v1/users/me/2fa/webauthn/credentials/"+credential.ID.String(), nil, http.StatusConflict, nil)
+
+	// 4. Exigir MFA de todos revoga as sessões abertas de quem ainda não tem segundo fator
+	h.DoJSON(t, memberSession.Token, http.MethodGet, "/api/v1/risks", nil, http.StatusOK, nil)
+	h.DoJSON(t, adminToken, http.MethodPut, policyPath, handlers.MFAPolicyPayload{RequireMFAForAll: true}, http.StatusOK, &policy)
+	assert.EqualValues(t, 1, policy.SessionsRevoked)
+	h.DoJSON(t, memberSession.Token, http.MethodGet, "/api/v1/risks", nil, http.StatusUnauthorized, nil)
+	assert.True(t, login(member).MFAEnrollmentRequired)
+
+	var entries int64
+	assert.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND action = ?", org.ID, models.AuditTrailMFAPolicyUpdated).Count(&entries).Error)
+	assert.EqualValues(t, 2, entries)
+}
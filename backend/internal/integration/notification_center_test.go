@@ -0,0 +1,144 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications/templates"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type notificationList struct {
+	Items       []models.Notification `json:"items"`
+	TotalItems  int64                 `json:"total_items"`
+	UnreadCount int64                 `json:"unread_count"`
+}
+
+func TestNotificationCenter(t *testing.T) {
+	org := h.NewOrganization(t, "ACME Notificações")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	owner, ownerToken := h.NewUser(t, org, models.RoleUser)
+
+	var risk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Fornecedor sem SOC 2", OwnerID: owner.ID.String()}, http.StatusCreated, &risk)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks/"+risk.ID.String()+"/submit-acceptance", nil, http.StatusCreated, nil)
+
+	// O responsável vê o risco atribuído e a aprovação pendente, mais recente primeiro
+	var list notificationList
+	h.DoJSON(t, ownerToken, http.MethodGet, "/api/v1/me/notifications", nil, http.StatusOK, &list)
+	require.EqualValues(t, 2, list.TotalItems)
+	assert.EqualValues(t, 2, list.UnreadCount)
+	assert.Equal(t, templates.NameRiskAcceptanceRequested, list.Items[0].Type)
+	assert.Equal(t, templates.NameRiskCreated, list.Items[1].Type)
+	assert.Contains(t, list.Items[1].Title, "Fornecedor sem SOC 2")
+	assert.Contains(t, list.Items[1].Link, "/risks/"+risk.ID.String())
+	assert.Nil(t, list.Items[1].ReadAt)
+
+	// Quem criou não recebe notificações
+	h.DoJSON(t, managerToken, http.MethodGet, "/api/v1/me/notifications", nil, http.StatusOK, &list)
+	assert.EqualValues(t, 0, list.TotalItems)
+
+	// Marcar uma como lida; outro usuário não a encontra
+	var created models.Notification
+	h.DoJSON(t, ownerToken, http.MethodGet, "/api/v1/me/notifications?type="+templates.NameRiskCreated, nil, http.StatusOK, &list)
+	require.Len(t, list.Items, 1)
+	notificationPath := "/api/v1/me/notifications/" + list.Items[0].ID.String()
+	h.DoJSON(t, managerToken, http.MethodPatch, notificationPath, map[string]bool{"read": true}, http.StatusNotFound, nil)
+	h.DoJSON(t, ownerToken, http.MethodPatch, notificationPath, map[string]bool{"read": true}, http.StatusOK, &created)
+	require.NotNil(t, created.ReadAt)
+
+	var count struct {
+		UnreadCount int64 `json:"unread_count"`
+	}
+	h.DoJSON(t, ownerToken, http.MethodGet, "/api/v1/me/notifications/unread-count", nil, http.StatusOK, &count)
+	assert.EqualValues(t, 1, count.UnreadCount)
+	h.DoJSON(t, ownerToken, http.MethodGet, "/api/v1/me/notifications?unread=true", nil, http.StatusOK, &list)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, templates.NameRiskAcceptanceRequested, list.Items[0].Type)
+
+	// Marcar todas
+	var marked struct {
+		Updated     int64 `json:"updated"`
+		UnreadCount int64 `json:"unread_count"`
+	}
+	h.DoJSON(t, ownerToken, http.MethodPatch, "/api/v1/me/notifications", map[string]bool{"read": true}, http.StatusOK, &marked)
+	assert.EqualValues(t, 1, marked.Updated)
+	assert.EqualValues(t, 0, marked.UnreadCount)
+}
+
+func TestNotificationBulkActions(t *testing.T) {
+	org := h.NewOrganization(t, "ACME Lembretes")
+	user, userToken := h.NewUser(t, org, models.RoleUser)
+	other, otherToken := h.NewUser(t, org, models.RoleUser)
+
+	// Volta das férias: lembretes de dois tipos acumulados, além de uma notificação de outro usuário
+	for i := 0; i < 3; i++ {
+		require.NoError(t, h.DB.Create(&models.Notification{UserID: user.ID, OrganizationID: &org.ID, Type: templates.NameRiskAcceptanceRequested, Title: "Aprovação pendente"}).Error)
+	}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, h.DB.Create(&models.Notification{UserID: user.ID, OrganizationID: &org.ID, Type: templates.NameAssessmentMention, Title: "Menção"}).Error)
+	}
+	require.NoError(t, h.DB.Create(&models.Notification{UserID: other.ID, OrganizationID: &org.ID, Type: templates.NameAssessmentMention, Title: "Menção"}).Error)
+
+	var batch struct {
+		Updated     int64 `json:"updated"`
+		UnreadCount int64 `json:"unread_count"`
+	}
+	var list notificationList
+
+	// Adiar as menções: somem da lista e do contador até a data
+	until := time.Now().Add(48 * time.Hour)
+	h.DoJSON(t, userToken, http.MethodPost, "/api/v1/me/notifications/snooze", handlers.SnoozeNotificationsPayload{Until: time.Now().Add(-time.Hour)}, http.StatusBadRequest, nil)
+	h.DoJSON(t, userToken, http.MethodPost, "/api/v1/me/notifications/snooze", handlers.SnoozeNotificationsPayload{Until: time.Now().Add(91 * 24 * time.Hour)}, http.StatusBadRequest, nil)
+	h.DoJSON(t, userToken, http.MethodPost, "/api/v1/me/notifications/snooze", handlers.SnoozeNotificationsPayload{
+		NotificationFilterPayload: handlers.NotificationFilterPayload{Types: []string{templates.NameAssessmentMention}},
+		Until:                     until,
+	}, http.StatusOK, &batch)
+	assert.EqualValues(t, 2, batch.Updated)
+	assert.EqualValues(t, 3, batch.UnreadCount)
+
+	h.DoJSON(t, userToken, http.MethodGet, "/api/v1/me/notifications", nil, http.StatusOK, &list)
+	assert.EqualValues(t, 3, list.TotalItems)
+	h.DoJSON(t, userToken, http.MethodGet, "/api/v1/me/notifications?snoozed=true", nil, http.StatusOK, &list)
+	require.EqualValues(t, 2, list.TotalItems)
+	require.NotNil(t, list.Items[0].SnoozedUntil)
+
+	// Marcar todas como lidas, por tipo: as adiadas ficam para depois
+	h.DoJSON(t, userToken, http.MethodPost, "/api/v1/me/notifications/read-all", handlers.MarkAllNotificationsReadPayload{Types: []string{templates.NameRiskCreated}}, http.StatusOK, &batch)
+	assert.EqualValues(t, 0, batch.Updated)
+	h.DoJSON(t, userToken, http.MethodPost, "/api/v1/me/notifications/read-all", nil, http.StatusOK, &batch)
+	assert.EqualValues(t, 3, batch.Updated)
+	assert.EqualValues(t, 0, batch.UnreadCount)
+
+	// Ao fim do adiamento, as menções voltam a contar como não lidas
+	require.NoError(t, h.DB.Model(&models.Notification{}).Where("user_id = ?", user.ID).Update("snoozed_until", time.Now().Add(-time.Minute)).Error)
+	h.DoJSON(t, userToken, http.MethodGet, "/api/v1/me/notifications?unread=true", nil, http.StatusOK, &list)
+	assert.EqualValues(t, 2, list.TotalItems)
+	assert.EqualValues(t, 2, list.UnreadCount)
+
+	// Descartar em lote exige um filtro ou all=true e só alcança as notificações do próprio usuário
+	h.DoJSON(t, userToken, http.MethodPost, "/api/v1/me/notifications/dismiss", handlers.DismissNotificationsPayload{}, http.StatusBadRequest, nil)
+	h.DoJSON(t, userToken, http.MethodPost, "/api/v1/me/notifications/dismiss", handlers.DismissNotificationsPayload{
+		NotificationFilterPayload: handlers.NotificationFilterPayload{Types: []string{templates.NameAssessmentMention}},
+	}, http.StatusOK, &batch)
+	assert.EqualValues(t, 2, batch.Updated)
+	assert.EqualValues(t, 0, batch.UnreadCount)
+	h.DoJSON(t, otherToken, http.MethodGet, "/api/v1/me/notifications", nil, http.StatusOK, &list)
+	assert.EqualValues(t, 1, list.TotalItems)
+
+	h.DoJSON(t, userToken, http.MethodGet, "/api/v1/me/notifications", nil, http.StatusOK, &list)
+	require.EqualValues(t, 3, list.TotalItems)
+	dismissedPath := "/api/v1/me/notifications/" + list.Items[0].ID.String()
+	h.DoJSON(t, userToken, http.MethodPost, "/api/v1/me/notifications/dismiss", handlers.DismissNotificationsPayload{All: true}, http.StatusOK, &batch)
+	assert.EqualValues(t, 3, batch.Updated)
+	h.DoJSON(t, userToken, http.MethodGet, "/api/v1/me/notifications", nil, http.StatusOK, &list)
+	assert.EqualValues(t, 0, list.TotalItems)
+	h.DoJSON(t, userToken, http.MethodPatch, dismissedPath, map[string]bool{"read": false}, http.StatusNotFound, nil)
+}
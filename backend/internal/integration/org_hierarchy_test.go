@@ -0,0 +1,70 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationRiskRollup(t *testing.T) {
+	group := h.NewOrganization(t, "Grupo Holding")
+	unit := h.NewOrganization(t, "Unidade Varejo")
+	subUnit := h.NewOrganization(t, "Unidade Varejo Sul")
+	_, systemAdminToken := h.NewUser(t, group, models.RoleSystemAdmin)
+	_, groupManagerToken := h.NewUser(t, group, models.RoleManager)
+	_, groupUserToken := h.NewUser(t, group, models.RoleUser)
+	_, unitManagerToken := h.NewUser(t, unit, models.RoleManager)
+	_, subUnitManagerToken := h.NewUser(t, subUnit, models.RoleManager)
+
+	// 1. Só o system admin monta a hierarquia; ciclos são rejeitados
+	setParent := func(token string, org, parent models.Organization, wantStatus int) {
+		t.Helper()
+		parentID := parent.ID.String()
+		h.DoJSON(t, token, http.MethodPut, "/api/v1/admin/organizations/"+org.ID.String()+"/parent",
+			handlers.OrganizationParentPayload{ParentID: &parentID}, wantStatus, nil)
+	}
+	setParent(groupManagerToken, unit, group, http.StatusForbidden)
+	setParent(systemAdminToken, unit, group, http.StatusOK)
+	setParent(systemAdminToken, subUnit, unit, http.StatusOK)
+	setParent(systemAdminToken, group, subUnit, http.StatusBadRequest)
+	setParent(systemAdminToken, unit, unit, http.StatusBadRequest)
+
+	h.DoJSON(t, groupManagerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco do grupo", Impact: "Alto", Probability: "Alto"}, http.StatusCreated, nil)
+	h.DoJSON(t, unitManagerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco da unidade", Impact: "Baixo", Probability: "Baixo"}, http.StatusCreated, nil)
+	var subUnitRisk models.Risk
+	h.DoJSON(t, subUnitManagerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco da subunidade"}, http.StatusCreated, &subUnitRisk)
+
+	// 2. O grupo vê o consolidado de todas as descendentes
+	var rollup handlers.RiskRollupResponse
+	h.DoJSON(t, groupManagerToken, http.MethodGet, "/api/v1/organizations/"+group.ID.String()+"/rollup/risks", nil, http.StatusOK, &rollup)
+	assert.EqualValues(t, 3, rollup.Totals.TotalRisks)
+	assert.EqualValues(t, 3, rollup.Totals.ByStatus[string(models.StatusOpen)])
+	require.Len(t, rollup.Organizations, 3)
+	assert.Equal(t, group.ID, rollup.Organizations[0].ID)
+	assert.Equal(t, 2, rollup.Organizations[2].Depth)
+	for _, org := range rollup.Organizations {
+		assert.EqualValues(t, 1, org.TotalRisks, org.Name)
+	}
+
+	// 3. A unidade vê só a sua subárvore; o consolidado exige reports:view_rollup
+	h.DoJSON(t, unitManagerToken, http.MethodGet, "/api/v1/organizations/"+unit.ID.String()+"/rollup/risks", nil, http.StatusOK, &rollup)
+	assert.EqualValues(t, 2, rollup.Totals.TotalRisks)
+	h.DoJSON(t, groupUserToken, http.MethodGet, "/api/v1/organizations/"+group.ID.String()+"/rollup/risks", nil, http.StatusForbidden, nil)
+	h.DoJSON(t, unitManagerToken, http.MethodGet, "/api/v1/organizations/"+group.ID.String()+"/rollup/risks", nil, http.StatusForbidden, nil)
+
+	// 4. O isolamento dos demais endpoints continua: o grupo não lê os riscos da subunidade
+	h.DoJSON(t, groupManagerToken, http.MethodGet, "/api/v1/risks/"+subUnitRisk.ID.String(), nil, http.StatusNotFound, nil)
+
+	var scores struct {
+		Frameworks []handlers.ComplianceRollupFramework `json:"frameworks"`
+	}
+	h.DoJSON(t, groupManagerToken, http.MethodGet, "/api/v1/organizations/"+group.ID.String()+"/rollup/compliance-score", nil, http.StatusOK, &scores)
+	assert.Empty(t, scores.Frameworks, "no assessments yet")
+}
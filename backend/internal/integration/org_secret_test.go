@@ -0,0 +1,105 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/secrets"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgSecretsVault(t *testing.T) {
+	org := h.NewOrganization(t, "Cofre de credenciais")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	const value = "jira-token-0123456789abcd"
+	payload := handlers.OrgSecretPayload{Name: "jira-prod", Kind: models.OrgSecretJiraToken, Value: value, RotationIntervalDays: 90}
+
+	// Só admins e gestores acessam o cofre
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/secrets", payload, http.StatusForbidden, nil)
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/secrets", nil, http.StatusForbidden, nil)
+
+	// A resposta nunca contém o valor, apenas a dica mascarada
+	rec := h.Do(t, managerToken, http.MethodPost, "/api/v1/secrets", payload)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), value)
+	var secret models.OrgSecret
+	require.NoError(t, h.DB.First(&secret, "organization_id = ? AND name = ?", org.ID, "jira-prod").Error)
+	assert.Equal(t, "••••abcd", secret.ValueHint)
+	assert.NotContains(t, secret.EncryptedValue, value)
+	require.NotNil(t, secret.RotationDueAt)
+	assert.WithinDuration(t, secret.LastRotatedAt.AddDate(0, 0, 90), *secret.RotationDueAt, time.Second)
+	secretPath := "/api/v1/secrets/" + secret.ID.String()
+
+	rec = h.Do(t, adminToken, http.MethodGet, secretPath, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), value)
+	assert.NotContains(t, rec.Body.String(), secret.EncryptedValue)
+
+	// Nomes são únicos por organização; outras organizações não veem o segredo
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/secrets", payload, http.StatusConflict, nil)
+	h.DoJSON(t, outsiderToken, http.MethodGet, secretPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, outsiderToken, http.MethodPut, secretPath, handlers.UpdateOrgSecretPayload{}, http.StatusNotFound, nil)
+	var foreign []models.OrgSecret
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/secrets", nil, http.StatusOK, &foreign)
+	assert.Empty(t, foreign)
+	h.DoJSON(t, outsiderToken, http.MethodPost, "/api/v1/secrets", payload, http.StatusCreated, nil)
+
+	// As integrações leem o valor pelo pacote secrets, que registra o uso
+	resolved, err := secrets.Resolve(context.Background(), org.ID, "jira-prod", "jira")
+	require.NoError(t, err)
+	assert.Equal(t, value, resolved)
+	_, err = secrets.Resolve(context.Background(), org.ID, "inexistente", "jira")
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	var used models.OrgSecret
+	h.DoJSON(t, adminToken, http.MethodGet, secretPath, nil, http.StatusOK, &used)
+	assert.EqualValues(t, 1, used.UsageCount)
+	assert.Equal(t, "jira", used.LastUsedBy)
+	require.NotNil(t, used.LastUsedAt)
+
+	// Alterar o intervalo reagenda a rotação; 0 desativa
+	interval := 3
+	var updated models.OrgSecret
+	h.DoJSON(t, managerToken, http.MethodPut, secretPath, handlers.UpdateOrgSecretPayload{RotationIntervalDays: &interval}, http.StatusOK, &updated)
+	require.NotNil(t, updated.RotationDueAt)
+	assert.WithinDuration(t, secret.LastRotatedAt.AddDate(0, 0, 3), *updated.RotationDueAt, time.Second)
+
+	var due []models.OrgSecret
+	h.DoJSON(t, adminToken, http.MethodGet, "/api/v1/secrets?rotation_due=true", nil, http.StatusOK, &due)
+	assert.Empty(t, due, "the rotation is not overdue yet")
+
+	// O lembrete de rotação vai para admins e gestores, uma vez por ciclo
+	notifications.RunSecretRotationReminderSweep(context.Background())
+	var reminded models.OrgSecret
+	require.NoError(t, h.DB.First(&reminded, "id = ?", secret.ID).Error)
+	require.NotNil(t, reminded.RotationReminderSentAt)
+	var deliveries []models.BackgroundJob
+	require.NoError(t, h.DB.Where("type = ? AND organization_id = ?", notifications.JobDeliver, org.ID).Find(&deliveries).Error)
+	require.Len(t, deliveries, 2)
+	for _, delivery := range deliveries {
+		assert.Contains(t, delivery.Payload, "jira-prod")
+	}
+	notifications.RunSecretRotationReminderSweep(context.Background())
+	var count int64
+	require.NoError(t, h.DB.Model(&models.BackgroundJob{}).Where("type = ? AND organization_id = ?", notifications.JobDeliver, org.ID).Count(&count).Error)
+	assert.EqualValues(t, 2, count)
+
+	disabled := 0
+	var unscheduled models.OrgSecret
+	h.DoJSON(t, managerToken, http.MethodPut, secretPath, handlers.UpdateOrgSecretPayload{RotationIntervalDays: &disabled}, http.StatusOK, &unscheduled)
+	assert.Nil(t, unscheduled.RotationDueAt)
+}
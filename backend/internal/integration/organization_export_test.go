@@ -0,0 +1,100 @@
+//go:build integration
+
+package integration
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/orgexport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationExport(t *testing.T) {
+	storage := useMemoryStorage(t)
+	handlers.RegisterJobHandlers()
+
+	org := h.NewOrganization(t, "Exportação completa")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+
+	var risk models.Risk
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco exportado"}, http.StatusCreated, &risk)
+
+	assert.Equal(t, http.StatusForbidden, h.Do(t, managerToken, http.MethodPost, "/api/v1/organization-exports", nil).Code, "only admins export the organization")
+
+	var export models.OrganizationExport
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/organization-exports", nil, http.StatusAccepted, &export)
+	assert.Equal(t, models.OrganizationExportPending, export.Status)
+	assert.Equal(t, http.StatusConflict, h.Do(t, adminToken, http.MethodPost, "/api/v1/organization-exports", nil).Code)
+
+	path := "/api/v1/organization-exports/" + export.ID.String()
+	assert.Equal(t, http.StatusConflict, h.Do(t, adminToken, http.MethodGet, path+"/download", nil).Code, "not completed yet")
+
+	runQueuedJob(t, handlers.JobOrganizationExport)
+
+	var resp handlers.OrganizationExportResponse
+	h.DoJSON(t, adminToken, http.MethodGet, path, nil, http.StatusOK, &resp)
+	require.Equal(t, models.OrganizationExportCompleted, resp.Status, resp.Error)
+	require.NotNil(t, resp.ExpiresAt)
+	assert.NotEmpty(t, resp.SHA256)
+	files := map[string]orgexport.File{}
+	for _, f := range resp.Files {
+		files[f.Name] = f
+	}
+	assert.Equal(t, 2, files["users.json"].Records)
+	assert.Equal(t, 1, files["risks.csv"].Records)
+
+	// O ZIP no armazenamento contém os arquivos do manifesto
+	var stored models.OrganizationExport
+	require.NoError(t, h.DB.First(&stored, "id = ?", export.ID).Error)
+	data, ok := storage.objects[stored.ObjectName]
+	require.True(t, ok, "the zip should be uploaded to the storage")
+	assert.EqualValues(t, len(data), stored.SizeBytes)
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	contents := map[string][]byte{}
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		contents[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	require.Contains(t, contents, orgexport.ManifestFileName)
+	var risks []orgexport.RiskRecord
+	require.NoError(t, json.Unmarshal(contents["risks.json"], &risks))
+	require.Len(t, risks, 1)
+	assert.Equal(t, risk.ReferenceCode, risks[0].ReferenceCode)
+	var users []orgexport.UserRecord
+	require.NoError(t, json.Unmarshal(contents["users.json"], &users))
+	assert.NotContains(t, string(contents["users.json"]), "password")
+	assert.Contains(t, string(contents["users.csv"]), admin.Email)
+
+	var download struct {
+		URL      string `json:"url"`
+		FileName string `json:"file_name"`
+	}
+	h.DoJSON(t, adminToken, http.MethodGet, path+"/download", nil, http.StatusOK, &download)
+	assert.Contains(t, download.URL, stored.ObjectName)
+	assert.Equal(t, http.StatusForbidden, h.Do(t, managerToken, http.MethodGet, path+"/download", nil).Code)
+
+	var count int64
+	h.DB.Model(&models.AuditTrailEntry{}).Where("entity_id = ? AND action = ?", export.ID, models.AuditTrailOrgExportDownloaded).Count(&count)
+	assert.EqualValues(t, 1, count)
+
+	// Depois do prazo, o arquivo é removido e o download deixa de estar disponível
+	purged, err := orgexport.PurgeExpired(context.Background(), h.DB, stored.ExpiresAt.Add(1))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, purged, 1)
+	assert.NotContains(t, storage.objects, stored.ObjectName)
+	assert.Equal(t, http.StatusConflict, h.Do(t, adminToken, http.MethodGet, path+"/download", nil).Code)
+}
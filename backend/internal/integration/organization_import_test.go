@@ -0,0 +1,111 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/orgexport"
+	"phoenixgrc/backend/internal/orgimport"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationImport(t *testing.T) {
+	ctx := context.Background()
+	org := h.NewOrganization(t, "Origem da migração")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	inactive, _ := h.NewUser(t, org, models.RoleUser)
+	require.NoError(t, h.DB.Model(&inactive).UpdateColumn("is_active", false).Error)
+
+	var risk models.Risk
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco migrado"}, http.StatusCreated, &risk)
+
+	policy := models.Policy{OrganizationID: org.ID, Title: "Política migrada", OwnerID: admin.ID, Status: models.PolicyStatusDraft}
+	require.NoError(t, h.DB.Create(&policy).Error)
+	version := models.PolicyVersion{PolicyID: policy.ID, VersionNumber: 1, FileURL: org.ID.String() + "/policies/v1.pdf", UploadedByID: admin.ID}
+	require.NoError(t, h.DB.Create(&version).Error)
+	require.NoError(t, h.DB.Model(&policy).UpdateColumn("current_version_id", version.ID).Error)
+
+	// Framework semeado (global), presente nas duas instâncias
+	framework := models.AuditFramework{Name: "Framework migrado " + uuid.NewString()}
+	require.NoError(t, h.DB.Create(&framework).Error)
+	control := models.AuditControl{FrameworkID: framework.ID, ControlID: "MIG-1"}
+	require.NoError(t, h.DB.Create(&control).Error)
+	assessment := models.AuditAssessment{OrganizationID: org.ID, AuditControlID: control.ID, Status: models.ControlStatusConformant, PolicyID: &policy.ID, AssignedToID: &admin.ID}
+	require.NoError(t, h.DB.Create(&assessment).Error)
+
+	var buf bytes.Buffer
+	_, err := orgexport.Write(ctx, h.DB, uuid.New(), org.ID, &buf, time.Now())
+	require.NoError(t, err)
+	archive := bytes.NewReader(buf.Bytes())
+
+	// Na mesma instância, os e-mails já existem
+	_, err = orgimport.Import(ctx, h.DB, archive, archive.Size(), orgimport.Options{})
+	require.ErrorContains(t, err, "users already exist")
+
+	// Simula a instância nova liberando os e-mails
+	require.NoError(t, h.DB.Exec("UPDATE users SET email = 'old-' || email WHERE organization_id = ?", org.ID).Error)
+
+	dry, err := orgimport.Import(ctx, h.DB, archive, archive.Size(), orgimport.Options{DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, dry.DryRun)
+	var count int64
+	h.DB.Model(&models.Organization{}).Where("id = ?", dry.OrganizationID).Count(&count)
+	assert.Zero(t, count, "a dry run writes nothing")
+
+	result, err := orgimport.Import(ctx, h.DB, archive, archive.Size(), orgimport.Options{OrganizationName: "Destino da migração"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported["users"])
+	assert.Equal(t, 1, result.Imported["risks"])
+	assert.Equal(t, 1, result.Imported["policies"])
+	assert.Equal(t, 1, result.Imported["policy_versions"])
+	assert.Equal(t, 1, result.Imported["assessments"])
+
+	var newOrg models.Organization
+	require.NoError(t, h.DB.First(&newOrg, "id = ?", result.OrganizationID).Error)
+	assert.Equal(t, "Destino da migração", newOrg.Name)
+
+	var newAdmin models.User
+	require.NoError(t, h.DB.First(&newAdmin, "email = ?", admin.Email).Error)
+	assert.NotEqual(t, admin.ID, newAdmin.ID)
+	assert.Equal(t, result.OrganizationID, newAdmin.OrganizationID.UUID)
+	assert.Empty(t, newAdmin.PasswordHash, "credentials are not migrated")
+	var newInactive models.User
+	require.NoError(t, h.DB.First(&newInactive, "email = ?", inactive.Email).Error)
+	assert.False(t, newInactive.IsActive)
+
+	var newRisk models.Risk
+	require.NoError(t, h.DB.First(&newRisk, "organization_id = ?", result.OrganizationID).Error)
+	assert.Equal(t, risk.ReferenceCode, newRisk.ReferenceCode)
+	assert.Equal(t, newAdmin.ID, newRisk.OwnerID)
+
+	var newPolicy models.Policy
+	require.NoError(t, h.DB.First(&newPolicy, "organization_id = ?", result.OrganizationID).Error)
+	var newVersion models.PolicyVersion
+	require.NoError(t, h.DB.First(&newVersion, "policy_id = ?", newPolicy.ID).Error)
+	require.NotNil(t, newPolicy.CurrentVersionID)
+	assert.Equal(t, newVersion.ID, *newPolicy.CurrentVersionID)
+	assert.Equal(t, version.FileURL, newVersion.FileURL)
+
+	var newAssessment models.AuditAssessment
+	require.NoError(t, h.DB.First(&newAssessment, "organization_id = ?", result.OrganizationID).Error)
+	assert.Equal(t, control.ID, newAssessment.AuditControlID)
+	require.NotNil(t, newAssessment.PolicyID)
+	assert.Equal(t, newPolicy.ID, *newAssessment.PolicyID)
+	require.NotNil(t, newAssessment.AssignedToID)
+	assert.Equal(t, newAdmin.ID, *newAssessment.AssignedToID)
+
+	// A sequência continua após o maior código importado
+	next, err := models.NextReferenceCode(h.DB, result.OrganizationID, models.ReferenceRisk)
+	require.NoError(t, err)
+	assert.NotEqual(t, risk.ReferenceCode, next)
+}
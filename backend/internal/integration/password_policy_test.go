@@ -0,0 +1,111 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/passwordpolicy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// breachedPasswords substitui a API Pwned Passwords nos testes.
+type breachedPasswords map[string]bool
+
+func (b breachedPasswords) BreachCount(ctx context.Context, password string) (int, error) {
+	if b[password] {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+type passwordPolicyError struct {
+	Error           string                     `json:"error"`
+	Violations      []passwordpolicy.Violation `json:"violations"`
+	PasswordExpired bool                       `json:"password_expired"`
+}
+
+func violationCodes(resp passwordPolicyError) []string {
+	var codes []string
+	for _, v := range resp.Violations {
+		codes = append(codes, v.Code)
+	}
+	return codes
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	previousChecker := passwordpolicy.DefaultBreachChecker
+	passwordpolicy.DefaultBreachChecker = breachedPasswords{"Vazada-Senha-2024": true}
+	t.Cleanup(func() { passwordpolicy.DefaultBreachChecker = previousChecker })
+
+	org := h.NewOrganization(t, "Org Política de Senhas")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, userToken := h.NewUser(t, org, models.RoleUser)
+	policyPath := "/api/v1/organizations/" + org.ID.String() + "/password-policy"
+
+	// 1. Sem configuração, vale a política padrão; qualquer usuário a consulta
+	var policy handlers.PasswordPolicyResponse
+	h.DoJSON(t, userToken, http.MethodGet, policyPath, nil, http.StatusOK, &policy)
+	assert.True(t, policy.IsDefault)
+	assert.Equal(t, passwordpolicy.MinLengthFloor, policy.MinLength)
+
+	// 2. Configurar exige security:manage, que o manager não tem
+	strict := handlers.PasswordPolicyPayload{MinLength: 12, RequireUppercase: true, RequireDigit: true, CheckBreached: true, MaxAgeDays: 30}
+	h.DoJSON(t, managerToken, http.MethodPut, policyPath, strict, http.StatusForbidden, nil)
+	h.DoJSON(t, adminToken, http.MethodPut, policyPath, handlers.PasswordPolicyPayload{MinLength: 6}, http.StatusBadRequest, nil)
+	h.DoJSON(t, adminToken, http.MethodPut, policyPath, strict, http.StatusCreated, &policy)
+	assert.False(t, policy.IsDefault)
+	h.DoJSON(t, adminToken, http.MethodPut, policyPath, strict, http.StatusOK, nil)
+	h.DoJSON(t, userToken, http.MethodGet, policyPath, nil, http.StatusOK, &policy)
+	assert.Equal(t, 12, policy.MinLength)
+
+	// 3. O aceite de convite aplica a política, que a tela de aceite recebe
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/organizations/"+org.ID.String()+"/invitations", handlers.InvitationPayload{Email: "politica@example.com"}, http.StatusCreated, nil)
+	token := lastInvitationToken(t, org, "politica@example.com")
+	var public handlers.PublicInvitationResponse
+	h.DoJSON(t, "", http.MethodGet, "/auth/invitations/"+token, nil, http.StatusOK, &public)
+	assert.Equal(t, 12, public.PasswordPolicy.MinLength)
+
+	var rejected passwordPolicyError
+	h.DoJSON(t, "", http.MethodPost, "/auth/invitations/accept", handlers.AcceptInvitationPayload{Token: token, Password: "senhafraca"}, http.StatusBadRequest, &rejected)
+	assert.ElementsMatch(t, []string{passwordpolicy.ViolationTooShort, passwordpolicy.ViolationUppercase, passwordpolicy.ViolationDigit}, violationCodes(rejected))
+	h.DoJSON(t, "", http.MethodPost, "/auth/invitations/accept", handlers.AcceptInvitationPayload{Token: token, Password: "Vazada-Senha-2024"}, http.StatusBadRequest, &rejected)
+	assert.Equal(t, []string{passwordpolicy.ViolationBreached}, violationCodes(rejected))
+	var login handlers.LoginResponse
+	h.DoJSON(t, "", http.MethodPost, "/auth/invitations/accept", handlers.AcceptInvitationPayload{Token: token, Password: "Senha-Forte-2024"}, http.StatusOK, &login)
+
+	// 4. Senha vencida: o login é recusado até a troca
+	require.NoError(t, h.DB.Model(&models.User{}).Where("email = ?", "politica@example.com").
+		Update("password_changed_at", time.Now().AddDate(0, 0, -31)).Error)
+	var expired passwordPolicyError
+	h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: "politica@example.com", Password: "Senha-Forte-2024"}, http.StatusForbidden, &expired)
+	assert.True(t, expired.PasswordExpired)
+
+	change := handlers.ChangePasswordPayload{Email: "politica@example.com", CurrentPassword: "Senha-Errada-2024", NewPassword: "Outra-Senha-2025"}
+	h.DoJSON(t, "", http.MethodPost, "/auth/change-password", change, http.StatusUnauthorized, nil)
+	change.CurrentPassword = "Senha-Forte-2024"
+	change.NewPassword = "Senha-Forte-2024"
+	h.DoJSON(t, "", http.MethodPost, "/auth/change-password", change, http.StatusBadRequest, nil)
+	change.NewPassword = "outrasenhafraca"
+	h.DoJSON(t, "", http.MethodPost, "/auth/change-password", change, http.StatusBadRequest, nil)
+	change.NewPassword = "Outra-Senha-2025"
+	h.DoJSON(t, "", http.MethodPost, "/auth/change-password", change, http.StatusOK, nil)
+
+	// A troca encerra as sessões abertas e o usuário entra com a nova senha
+	h.DoJSON(t, login.Token, http.MethodGet, "/api/v1/me", nil, http.StatusUnauthorized, nil)
+	h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: "politica@example.com", Password: "Outra-Senha-2025"}, http.StatusOK, &login)
+
+	var entries int64
+	require.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND action IN ?", org.ID, []models.AuditTrailAction{models.AuditTrailPasswordPolicyUpdated, models.AuditTrailPasswordChanged}).
+		Count(&entries).Error)
+	assert.EqualValues(t, 3, entries)
+}
@@ -0,0 +1,61 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var passwordResetTokenPattern = regexp.MustCompile(`reset-password\?token=([0-9a-f]{64})`)
+
+func TestPasswordResetFlow(t *testing.T) {
+	org := h.NewOrganization(t, "Org Senhas")
+	user, _ := h.NewUser(t, org, models.RoleUser)
+
+	// 1. O pedido responde igual para e-mails desconhecidos
+	h.DoJSON(t, "", http.MethodPost, "/auth/forgot-password", handlers.ForgotPasswordPayload{Email: "ninguem@example.com"}, http.StatusOK, nil)
+	h.DoJSON(t, "", http.MethodPost, "/auth/forgot-password", handlers.ForgotPasswordPayload{Email: user.Email}, http.StatusOK, nil)
+	require.EqualValues(t, 1, deliveriesTo(t, org, user.Email))
+
+	var body string
+	require.NoError(t, h.DB.Model(&models.BackgroundJob{}).Select("payload->>'body'").
+		Where("type = ? AND payload->>'target' = ?", notifications.JobDeliver, user.Email).
+		Order("created_at desc").Limit(1).Scan(&body).Error)
+	match := passwordResetTokenPattern.FindStringSubmatch(body)
+	require.NotNil(t, match, "password reset email with link: %q", body)
+	token := match[1]
+
+	var stored models.PasswordResetToken
+	require.NoError(t, h.DB.Where("user_id = ?", user.ID).Take(&stored).Error)
+	assert.NotEqual(t, token, stored.TokenHash, "only the token hash is stored")
+
+	// 2. Verificação e redefinição; o token só vale uma vez
+	h.DoJSON(t, "", http.MethodPost, "/auth/reset-password/verify", handlers.VerifyResetTokenPayload{Token: token}, http.StatusOK, nil)
+	h.DoJSON(t, "", http.MethodPost, "/auth/reset-password/verify", handlers.VerifyResetTokenPayload{Token: "invalido"}, http.StatusBadRequest, nil)
+	h.DoJSON(t, "", http.MethodPost, "/auth/reset-password", handlers.ResetPasswordPayload{Token: token, Password: "NovaSenha123"}, http.StatusOK, nil)
+	h.DoJSON(t, "", http.MethodPost, "/auth/reset-password", handlers.ResetPasswordPayload{Token: token, Password: "OutraSenha123"}, http.StatusBadRequest, nil)
+
+	h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: user.Email, Password: "NovaSenha123"}, http.StatusOK, nil)
+
+	var entries int64
+	require.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND entity_id = ? AND action IN ?", org.ID, user.ID,
+			[]models.AuditTrailAction{models.AuditTrailPasswordResetRequest, models.AuditTrailPasswordResetComplete}).
+		Count(&entries).Error)
+	assert.EqualValues(t, 2, entries)
+
+	// 3. No máximo 3 e-mails por conta por hora, sem revelar o limite
+	for i := 0; i < 3; i++ {
+		h.DoJSON(t, "", http.MethodPost, "/auth/forgot-password", handlers.ForgotPasswordPayload{Email: user.Email}, http.StatusOK, nil)
+	}
+	assert.EqualValues(t, 3, deliveriesTo(t, org, user.Email))
+}
@@ -0,0 +1,164 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// policyAcknowledgmentReport é a resposta de GET /policies/:policyId/acknowledgments.
+type policyAcknowledgmentReport struct {
+	PolicyVersionID uuid.UUID                             `json:"policy_version_id"`
+	Acknowledged    []handlers.PolicyAcknowledgmentStatus `json:"acknowledged"`
+	Pending         []handlers.PolicyAcknowledgmentStatus `json:"pending"`
+	TotalUsers      int                                   `json:"total_users"`
+}
+
+func TestPolicyLifecycle(t *testing.T) {
+	storage := useMemoryStorage(t)
+	org := h.NewOrganization(t, "Políticas")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	owner, ownerToken := h.NewUser(t, org, models.RoleUser)
+	member, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	outsider, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	// Só admins e gestores criam políticas, e o responsável precisa ser da organização
+	payload := handlers.PolicyPayload{Title: "Política de senhas", OwnerID: owner.ID.String()}
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/policies", payload, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/policies",
+		handlers.PolicyPayload{Title: "Política de senhas", OwnerID: outsider.ID.String()}, http.StatusBadRequest, nil)
+
+	var policy models.Policy
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/policies", payload, http.StatusCreated, &policy)
+	assert.Equal(t, models.PolicyStatusDraft, policy.Status)
+	assert.Equal(t, owner.ID, policy.OwnerID)
+	assert.Equal(t, 12, policy.ReviewCadenceMonths)
+	policyPath := "/api/v1/policies/" + policy.ID.String()
+
+	// Outras organizações não enxergam a política; usuários comuns não a editam
+	h.DoJSON(t, outsiderToken, http.MethodGet, policyPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, memberToken, http.MethodPut, policyPath, handlers.PolicyPayload{Title: "Alterada"}, http.StatusForbidden, nil)
+	h.DoJSON(t, ownerToken, http.MethodPut, policyPath,
+		handlers.PolicyPayload{Title: "Política de senhas e MFA", OwnerID: owner.ID.String(), ReviewCadenceMonths: 6}, http.StatusOK, nil)
+
+	// Aceite exige uma versão aprovada
+	h.DoJSON(t, memberToken, http.MethodPost, policyPath+"/acknowledge", nil, http.StatusConflict, nil)
+
+	// Upload de versões pelo responsável
+	pdf := []byte("%PDF-1.4\n% política\n")
+	rec := h.DoMultipart(t, memberToken, http.MethodPost, policyPath+"/versions", "file", "politica.pdf", pdf, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = h.DoMultipart(t, ownerToken, http.MethodPost, policyPath+"/versions", "file", "politica.pdf", pdf, map[string]string{"changelog": "Primeira versão"})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var v1 models.PolicyVersion
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &v1))
+	assert.Equal(t, 1, v1.VersionNumber)
+	assert.Equal(t, "Primeira versão", v1.Changelog)
+	assert.Empty(t, v1.ApprovalStatus)
+	assert.Contains(t, storage.objects, v1.FileURL)
+	v1Path := policyPath + "/versions/" + v1.ID.String()
+
+	// Submissão: aprovador da organização, uma versão pendente por vez
+	h.DoJSON(t, ownerToken, http.MethodPost, v1Path+"/submit",
+		handlers.SubmitPolicyVersionPayload{ApproverID: outsider.ID.String()}, http.StatusBadRequest, nil)
+	var submitted models.PolicyVersion
+	h.DoJSON(t, ownerToken, http.MethodPost, v1Path+"/submit",
+		handlers.SubmitPolicyVersionPayload{ApproverID: admin.ID.String()}, http.StatusOK, &submitted)
+	assert.Equal(t, models.ApprovalPending, submitted.ApprovalStatus)
+	require.NotNil(t, submitted.ApproverID)
+	assert.Equal(t, admin.ID, *submitted.ApproverID)
+	h.DoJSON(t, ownerToken, http.MethodPost, v1Path+"/submit", nil, http.StatusConflict, nil)
+
+	// Só o aprovador designado decide; a rejeição mantém a política em rascunho
+	h.DoJSON(t, managerToken, http.MethodPost, v1Path+"/decision",
+		handlers.DecisionPayload{Decision: models.ApprovalApproved}, http.StatusForbidden, nil)
+	var rejected models.PolicyVersion
+	h.DoJSON(t, adminToken, http.MethodPost, v1Path+"/decision",
+		handlers.DecisionPayload{Decision: models.ApprovalRejected, Comments: "Falta o item de MFA"}, http.StatusOK, &rejected)
+	assert.Equal(t, models.ApprovalRejected, rejected.ApprovalStatus)
+	h.DoJSON(t, adminToken, http.MethodPost, v1Path+"/decision",
+		handlers.DecisionPayload{Decision: models.ApprovalApproved}, http.StatusConflict, nil)
+	var stillDraft models.Policy
+	h.DoJSON(t, ownerToken, http.MethodGet, policyPath, nil, http.StatusOK, &stillDraft)
+	assert.Equal(t, models.PolicyStatusDraft, stillDraft.Status)
+	assert.Nil(t, stillDraft.CurrentVersionID)
+
+	// Uma versão rejeitada pode ser ressubmetida; o aprovador padrão é o responsável
+	var resubmitted models.PolicyVersion
+	h.DoJSON(t, managerToken, http.MethodPost, v1Path+"/submit", nil, http.StatusOK, &resubmitted)
+	require.NotNil(t, resubmitted.ApproverID)
+	assert.Equal(t, owner.ID, *resubmitted.ApproverID)
+	assert.Empty(t, resubmitted.ApprovalComments)
+	h.DoJSON(t, ownerToken, http.MethodPost, v1Path+"/decision",
+		handlers.DecisionPayload{Decision: models.ApprovalApproved}, http.StatusOK, nil)
+
+	// A aprovação torna a versão vigente e agenda a próxima revisão pela cadência
+	var approved models.Policy
+	h.DoJSON(t, memberToken, http.MethodGet, policyPath, nil, http.StatusOK, &approved)
+	assert.Equal(t, models.PolicyStatusApproved, approved.Status)
+	require.NotNil(t, approved.CurrentVersionID)
+	assert.Equal(t, v1.ID, *approved.CurrentVersionID)
+	require.NotNil(t, approved.NextReviewDate)
+	assert.WithinDuration(t, time.Now().AddDate(0, 6, 0), *approved.NextReviewDate, time.Minute)
+
+	// Controles atendidos: todos precisam existir
+	framework := models.AuditFramework{Name: "Framework de políticas " + uuid.NewString()}
+	require.NoError(t, h.DB.Create(&framework).Error)
+	control := models.AuditControl{FrameworkID: framework.ID, ControlID: "POL-1"}
+	require.NoError(t, h.DB.Create(&control).Error)
+	h.DoJSON(t, ownerToken, http.MethodPut, policyPath+"/controls",
+		handlers.PolicyControlsPayload{AuditControlIDs: []string{control.ID.String(), uuid.NewString()}}, http.StatusBadRequest, nil)
+	h.DoJSON(t, memberToken, http.MethodPut, policyPath+"/controls",
+		handlers.PolicyControlsPayload{AuditControlIDs: []string{control.ID.String()}}, http.StatusForbidden, nil)
+	var mappings []models.PolicyControlMapping
+	h.DoJSON(t, ownerToken, http.MethodPut, policyPath+"/controls",
+		handlers.PolicyControlsPayload{AuditControlIDs: []string{control.ID.String(), control.ID.String()}}, http.StatusOK, &mappings)
+	require.Len(t, mappings, 1)
+	assert.Equal(t, "POL-1", mappings[0].AuditControl.ControlID)
+
+	// Aceite: idempotente e restrito à organização
+	h.DoJSON(t, outsiderToken, http.MethodPost, policyPath+"/acknowledge", nil, http.StatusNotFound, nil)
+	var ack models.PolicyAcknowledgment
+	h.DoJSON(t, memberToken, http.MethodPost, policyPath+"/acknowledge", nil, http.StatusOK, &ack)
+	assert.Equal(t, v1.ID, ack.PolicyVersionID)
+	assert.Equal(t, member.ID, ack.UserID)
+	var repeated models.PolicyAcknowledgment
+	h.DoJSON(t, memberToken, http.MethodPost, policyPath+"/acknowledge", nil, http.StatusOK, &repeated)
+	assert.Equal(t, ack.ID, repeated.ID)
+	assert.WithinDuration(t, ack.AcknowledgedAt, repeated.AcknowledgedAt, time.Millisecond)
+
+	// O relatório de aceites é restrito a quem gerencia a política
+	h.DoJSON(t, memberToken, http.MethodGet, policyPath+"/acknowledgments", nil, http.StatusForbidden, nil)
+	var report policyAcknowledgmentReport
+	h.DoJSON(t, managerToken, http.MethodGet, policyPath+"/acknowledgments", nil, http.StatusOK, &report)
+	assert.Equal(t, v1.ID, report.PolicyVersionID)
+	assert.Equal(t, 4, report.TotalUsers)
+	require.Len(t, report.Acknowledged, 1)
+	assert.Equal(t, member.ID, report.Acknowledged[0].UserID)
+	assert.Len(t, report.Pending, 3)
+	for _, pending := range report.Pending {
+		assert.NotEqual(t, outsider.ID, pending.UserID)
+	}
+
+	// Uma política aposentada não recebe novas versões
+	h.DoJSON(t, memberToken, http.MethodPost, policyPath+"/retire", nil, http.StatusForbidden, nil)
+	var retired models.Policy
+	h.DoJSON(t, ownerToken, http.MethodPost, policyPath+"/retire", nil, http.StatusOK, &retired)
+	assert.Equal(t, models.PolicyStatusRetired, retired.Status)
+	assert.Nil(t, retired.NextReviewDate)
+	rec = h.DoMultipart(t, ownerToken, http.MethodPost, policyPath+"/versions", "file", "politica.pdf", pdf, nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	h.DoJSON(t, memberToken, http.MethodPost, policyPath+"/acknowledge", nil, http.StatusConflict, nil)
+}
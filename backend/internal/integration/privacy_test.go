@@ -0,0 +1,90 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/privacy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonalDataExport(t *testing.T) {
+	org := h.NewOrganization(t, "Exportação de dados pessoais")
+	user, token := h.NewUser(t, org, models.RoleManager)
+
+	var risk models.Risk
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco do titular"}, http.StatusCreated, &risk)
+	framework := models.AuditFramework{Name: "Framework da exportação", OrganizationID: &org.ID}
+	require.NoError(t, h.DB.Create(&framework).Error)
+	control := models.AuditControl{FrameworkID: framework.ID, ControlID: "EX-1"}
+	require.NoError(t, h.DB.Create(&control).Error)
+	require.NoError(t, h.DB.Create(&models.AssessmentComment{OrganizationID: org.ID, AuditControlID: control.ID, AuthorID: user.ID, Body: "Evidência revisada"}).Error)
+
+	w := h.Do(t, token, http.MethodGet, "/api/v1/me/data-export", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+	var export privacy.DataExport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+	assert.Equal(t, user.Email, export.Profile.Email)
+	require.Len(t, export.Comments, 1)
+	assert.Equal(t, "Evidência revisada", export.Comments[0].Body)
+	require.Len(t, export.OwnedRisks, 1)
+	assert.Equal(t, risk.ReferenceCode, export.OwnedRisks[0].ReferenceCode)
+
+	var count int64
+	h.DB.Model(&models.AuditTrailEntry{}).Where("entity_id = ? AND action = ?", user.ID, models.AuditTrailUserDataExported).Count(&count)
+	assert.EqualValues(t, 1, count)
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	org := h.NewOrganization(t, "Anonimização")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	target, _ := h.NewUser(t, org, models.RoleUser)
+	base := "/api/v1/organizations/" + org.ID.String() + "/users/"
+	path := base + target.ID.String() + "/anonymize"
+
+	// Um registro da trilha de auditoria com o nome e o e-mail do usuário
+	details := `{"user_email": "` + target.Email + `", "user_name": "` + target.Name + `"}`
+	entry := models.AuditTrailEntry{OrganizationID: org.ID, ActorID: &target.ID, Action: models.AuditTrailUserUnlocked,
+		EntityType: "user", EntityID: &target.ID, Summary: "Login de " + target.Email + " desbloqueado", Details: &details}
+	require.NoError(t, h.DB.Create(&entry).Error)
+
+	payload := handlers.AnonymizeUserPayload{Reason: "Pedido do titular DPO-123"}
+	assert.Equal(t, http.StatusForbidden, h.Do(t, managerToken, http.MethodPost, path, payload).Code, "only admins anonymize users")
+	assert.Equal(t, http.StatusBadRequest, h.Do(t, adminToken, http.MethodPost, base+admin.ID.String()+"/anonymize", payload).Code)
+	assert.Equal(t, http.StatusBadRequest, h.Do(t, adminToken, http.MethodPost, path, handlers.AnonymizeUserPayload{}).Code, "a reason is required")
+
+	var resp handlers.UserResponse
+	h.DoJSON(t, adminToken, http.MethodPost, path, payload, http.StatusOK, &resp)
+	assert.Equal(t, privacy.AnonymizedName, resp.Name)
+	assert.NotEqual(t, target.Email, resp.Email)
+	assert.False(t, resp.IsActive)
+	require.NotNil(t, resp.AnonymizedAt)
+
+	assert.Equal(t, http.StatusConflict, h.Do(t, adminToken, http.MethodPost, path, payload).Code)
+	active := true
+	w := h.Do(t, adminToken, http.MethodPut, base+target.ID.String()+"/status", handlers.UpdateUserStatusPayload{IsActive: &active})
+	assert.Equal(t, http.StatusConflict, w.Code, "anonymized users cannot be reactivated")
+
+	// A trilha de auditoria continua apontando para o usuário, sem os dados pessoais
+	var stored models.AuditTrailEntry
+	require.NoError(t, h.DB.First(&stored, "id = ?", entry.ID).Error)
+	assert.Equal(t, target.ID, *stored.ActorID)
+	assert.NotContains(t, stored.Summary, target.Email)
+	require.NotNil(t, stored.Details)
+	assert.NotContains(t, *stored.Details, target.Email)
+	assert.NotContains(t, *stored.Details, target.Name)
+	assert.Contains(t, *stored.Details, privacy.AnonymizedName)
+
+	var anonymized models.AuditTrailEntry
+	require.NoError(t, h.DB.First(&anonymized, "entity_id = ? AND action = ?", target.ID, models.AuditTrailUserAnonymized).Error)
+	assert.Contains(t, *anonymized.Details, "DPO-123")
+}
@@ -0,0 +1,101 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAccountRateLimit(t *testing.T) {
+	// Política menor durante o teste; o limite por IP é compartilhado com os outros testes de login
+	previous := handlers.LoginAccountRateLimiter.Policy()
+	handlers.LoginAccountRateLimiter.SetPolicy(ratelimit.Policy{Limit: 2, Period: 10 * time.Minute})
+	t.Cleanup(func() { handlers.LoginAccountRateLimiter.SetPolicy(previous) })
+
+	payload := handlers.LoginPayload{Email: "Rate.Limited@example.com", Password: "wrong-password"}
+	w := h.Do(t, "", http.MethodPost, "/auth/login", payload)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"), "headers show the most restrictive policy")
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "300", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=600", w.Header().Get("RateLimit-Policy"))
+
+	// O email é comparado sem diferenciar maiúsculas
+	payload.Email = "rate.limited@example.com"
+	w = h.Do(t, "", http.MethodPost, "/auth/login", payload)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	w = h.Do(t, "", http.MethodPost, "/auth/login", payload)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "300", w.Header().Get("Retry-After"))
+
+	// Outra conta, no mesmo IP, não é afetada
+	payload.Email = "other.account@example.com"
+	w = h.Do(t, "", http.MethodPost, "/auth/login", payload)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Um corpo inflado além da leitura do limitador conta no limite do IP, em vez de escapar do limite por conta
+	padded, err := json.Marshal(map[string]string{
+		"padding": strings.Repeat("a", 70<<10), "email": "padded.account@example.com", "password": "wrong-password",
+	})
+	require.NoError(t, err)
+	login := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(padded))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "198.51.100.78:40000" // IP só deste teste, sem consumo anterior do limite
+		rec := httptest.NewRecorder()
+		h.Router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	assert.NotEqual(t, http.StatusTooManyRequests, login())
+	assert.NotEqual(t, http.StatusTooManyRequests, login())
+	assert.Equal(t, http.StatusTooManyRequests, login())
+}
+
+func TestAPIRateLimitHeaders(t *testing.T) {
+	org := h.NewOrganization(t, "Rate Limit API")
+	_, token := h.NewUser(t, org, models.RoleUser)
+
+	w := h.Do(t, token, http.MethodGet, "/api/v1/me", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1200", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1199", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1200;w=60", w.Header().Get("RateLimit-Policy"))
+}
+
+func TestSpoofedForwardedForDoesNotChangeRateLimitKey(t *testing.T) {
+	previous := handlers.ForgotPasswordRateLimiter.Policy()
+	handlers.ForgotPasswordRateLimiter.SetPolicy(ratelimit.Policy{Limit: 2, Period: 10 * time.Minute})
+	t.Cleanup(func() { handlers.ForgotPasswordRateLimiter.SetPolicy(previous) })
+
+	body, err := json.Marshal(handlers.ForgotPasswordPayload{Email: "spoofed.ip@example.com"})
+	require.NoError(t, err)
+	forgotPassword := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/auth/forgot-password", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		req.RemoteAddr = "198.51.100.77:40000" // IP só deste teste, sem consumo anterior do limite
+		rec := httptest.NewRecorder()
+		h.Router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Sem TRUSTED_PROXIES, o X-Forwarded-For é ignorado: trocar o header não zera o limite por IP
+	assert.NotEqual(t, http.StatusTooManyRequests, forgotPassword("203.0.113.1"))
+	assert.NotEqual(t, http.StatusTooManyRequests, forgotPassword("203.0.113.2"))
+	assert.Equal(t, http.StatusTooManyRequests, forgotPassword("203.0.113.3"))
+}
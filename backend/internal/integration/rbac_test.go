@@ -0,0 +1,77 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/rbac"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomRoleGrantsPermissionsToRegularUsers(t *testing.T) {
+	org := h.NewOrganization(t, "Org RBAC")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	analyst, analystToken := h.NewUser(t, org, models.RoleUser)
+	owner, _ := h.NewUser(t, org, models.RoleUser)
+
+	var risk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Fornecedor crítico", OwnerID: owner.ID.String()}, http.StatusCreated, &risk)
+	riskPath := "/api/v1/risks/" + risk.ID.String()
+
+	// Sem papel personalizado, o usuário comum não submete riscos de terceiros para aceite
+	rec := h.Do(t, analystToken, http.MethodPost, riskPath+"/submit-acceptance", nil)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), string(rbac.RisksSubmitAcceptance))
+
+	// Só quem tem roles:manage (admin) cria papéis
+	rolesPath := "/api/v1/organizations/" + org.ID.String() + "/roles"
+	payload := handlers.CustomRolePayload{Name: "Analista de riscos", Permissions: []string{"risks:submit_acceptance", "risks:update"}}
+	h.DoJSON(t, managerToken, http.MethodPost, rolesPath, payload, http.StatusForbidden, nil)
+	h.DoJSON(t, adminToken, http.MethodPost, rolesPath, handlers.CustomRolePayload{Name: "Inválido", Permissions: []string{"risks:fly"}}, http.StatusBadRequest, nil)
+
+	var role handlers.CustomRoleView
+	h.DoJSON(t, adminToken, http.MethodPost, rolesPath, payload, http.StatusCreated, &role)
+	assert.Equal(t, []string{"risks:update", "risks:submit_acceptance"}, role.Permissions, "catalog order")
+	h.DoJSON(t, adminToken, http.MethodPost, rolesPath, payload, http.StatusConflict, nil)
+
+	assignPath := "/api/v1/organizations/" + org.ID.String() + "/users/" + analyst.ID.String() + "/custom-role"
+	roleID := role.ID.String()
+	h.DoJSON(t, adminToken, http.MethodPut, assignPath, handlers.AssignCustomRolePayload{CustomRoleID: &roleID}, http.StatusOK, nil)
+
+	var mine struct {
+		Permissions []string `json:"permissions"`
+	}
+	h.DoJSON(t, analystToken, http.MethodGet, "/api/v1/me/permissions", nil, http.StatusOK, &mine)
+	assert.Equal(t, []string{"risks:update", "risks:submit_acceptance"}, mine.Permissions)
+
+	h.DoJSON(t, analystToken, http.MethodPost, riskPath+"/submit-acceptance", nil, http.StatusCreated, nil)
+
+	// Excluir o papel devolve os usuários ao papel base
+	var deleted struct {
+		UsersUnassigned int64 `json:"users_unassigned"`
+	}
+	h.DoJSON(t, adminToken, http.MethodDelete, rolesPath+"/"+roleID, nil, http.StatusOK, &deleted)
+	assert.EqualValues(t, 1, deleted.UsersUnassigned)
+	h.DoJSON(t, analystToken, http.MethodGet, "/api/v1/me/permissions", nil, http.StatusOK, &mine)
+	assert.Empty(t, mine.Permissions)
+}
+
+func TestManagersCannotGrantTheAdminRole(t *testing.T) {
+	org := h.NewOrganization(t, "Org Papéis")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	user, _ := h.NewUser(t, org, models.RoleUser)
+
+	rolePath := "/api/v1/organizations/" + org.ID.String() + "/users/" + user.ID.String() + "/role"
+	h.DoJSON(t, managerToken, http.MethodPut, rolePath, handlers.UpdateUserRolePayload{Role: models.RoleAdmin}, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPut, rolePath, handlers.UpdateUserRolePayload{Role: models.RoleManager}, http.StatusOK, nil)
+	h.DoJSON(t, adminToken, http.MethodPut, rolePath, handlers.UpdateUserRolePayload{Role: models.RoleAdmin}, http.StatusOK, nil)
+	h.DoJSON(t, managerToken, http.MethodPut, rolePath, handlers.UpdateUserRolePayload{Role: models.RoleUser}, http.StatusForbidden, nil)
+}
@@ -0,0 +1,43 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func componentsByName(components []health.Status) map[string]health.Status {
+	byName := make(map[string]health.Status, len(components))
+	for _, component := range components {
+		byName[component.Name] = component
+	}
+	return byName
+}
+
+func TestHealthAndReadinessEndpoints(t *testing.T) {
+	var liveness map[string]string
+	h.DoJSON(t, "", http.MethodGet, "/healthz", nil, http.StatusOK, &liveness)
+	assert.Equal(t, health.StatusOK, liveness["status"])
+
+	var report health.Report
+	h.DoJSON(t, "", http.MethodGet, "/readyz", nil, http.StatusOK, &report)
+	components := componentsByName(report.Components)
+	for _, name := range []string{"database", "migrations", "file_storage", "email"} {
+		require.Contains(t, components, name)
+	}
+	assert.True(t, components["database"].Healthy)
+	assert.True(t, components["database"].Critical)
+	assert.True(t, components["migrations"].Healthy, components["migrations"].Error)
+	assert.False(t, components["email"].Critical)
+
+	var setup handlers.SetupStatusResponse
+	h.DoJSON(t, "", http.MethodGet, "/api/public/setup-status", nil, http.StatusOK, &setup)
+	assert.Contains(t, componentsByName(setup.Components), "migrations")
+}
@@ -0,0 +1,104 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/privacy"
+	"phoenixgrc/backend/internal/retention"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordsByCategory indexa os resultados do relatório de retenção pela categoria.
+func recordsByCategory(report retention.Report) map[models.RetentionCategory]int64 {
+	records := map[models.RetentionCategory]int64{}
+	for _, item := range report.Items {
+		records[item.Category] = item.Records
+	}
+	return records
+}
+
+func TestRetentionPolicyValidation(t *testing.T) {
+	org := h.NewOrganization(t, "Retenção inválida")
+	_, token := h.NewUser(t, org, models.RoleManager)
+	_, userToken := h.NewUser(t, org, models.RoleUser)
+
+	assert.Equal(t, http.StatusForbidden, h.Do(t, userToken, http.MethodGet, "/api/v1/retention-policy", nil).Code)
+
+	for name, rules := range map[string][]handlers.RetentionRulePayload{
+		"unknown category": {{Category: "comments", RetentionDays: 365}},
+		"too short":        {{Category: models.RetentionAuditTrail, RetentionDays: 7}},
+		"duplicate":        {{Category: models.RetentionAuditTrail, RetentionDays: 365}, {Category: models.RetentionAuditTrail, RetentionDays: 730}},
+	} {
+		w := h.Do(t, token, http.MethodPut, "/api/v1/retention-policy", handlers.RetentionPolicyPayload{Rules: rules})
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+
+	var policy handlers.RetentionPolicyResponse
+	h.DoJSON(t, token, http.MethodGet, "/api/v1/retention-policy", nil, http.StatusOK, &policy)
+	assert.Empty(t, policy.Rules)
+	assert.Len(t, policy.Categories, len(models.RetentionCategories))
+}
+
+func TestRetentionReportAndApply(t *testing.T) {
+	org := h.NewOrganization(t, "Retenção de dados")
+	manager, token := h.NewUser(t, org, models.RoleManager)
+	inactive, _ := h.NewUser(t, org, models.RoleUser)
+
+	var closed, open models.Risk
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco mitigado há anos"}, http.StatusCreated, &closed)
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco aberto antigo"}, http.StatusCreated, &open)
+	longAgo := time.Now().AddDate(-8, 0, 0)
+	require.NoError(t, h.DB.Model(&closed).UpdateColumns(map[string]interface{}{"status": models.StatusMitigated, "updated_at": longAgo}).Error)
+	require.NoError(t, h.DB.Model(&open).UpdateColumn("updated_at", longAgo).Error)
+	require.NoError(t, h.DB.Model(&inactive).UpdateColumns(map[string]interface{}{"is_active": false, "updated_at": longAgo}).Error)
+
+	var policy handlers.RetentionPolicyResponse
+	h.DoJSON(t, token, http.MethodPut, "/api/v1/retention-policy", handlers.RetentionPolicyPayload{Rules: []handlers.RetentionRulePayload{
+		{Category: models.RetentionClosedRisks, RetentionDays: 7 * 365},
+		{Category: models.RetentionInactiveUsers, RetentionDays: 365},
+	}}, http.StatusOK, &policy)
+	require.Len(t, policy.Rules, 2)
+
+	var report retention.Report
+	h.DoJSON(t, token, http.MethodGet, "/api/v1/retention-policy/report", nil, http.StatusOK, &report)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, map[models.RetentionCategory]int64{models.RetentionClosedRisks: 1, models.RetentionInactiveUsers: 1}, recordsByCategory(report))
+	var count int64
+	h.DB.Model(&models.Risk{}).Where("id = ?", closed.ID).Count(&count)
+	assert.EqualValues(t, 1, count, "the report changes nothing")
+
+	applied, err := retention.Apply(h.DB, org.ID, time.Now())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, applied.TotalRecords)
+
+	h.DB.Unscoped().Model(&models.Risk{}).Where("id = ?", closed.ID).Count(&count)
+	assert.Zero(t, count)
+	assert.Equal(t, http.StatusOK, h.Do(t, token, http.MethodGet, "/api/v1/risks/"+open.ID.String(), nil).Code, "open risks are kept")
+
+	var stored models.User
+	require.NoError(t, h.DB.First(&stored, "id = ?", inactive.ID).Error)
+	require.NotNil(t, stored.AnonymizedAt)
+	assert.Equal(t, privacy.AnonymizedName, stored.Name)
+	assert.Equal(t, privacy.AnonymizedEmail(&stored), stored.Email)
+	assert.Empty(t, stored.PasswordHash)
+	require.NoError(t, h.DB.First(&stored, "id = ?", manager.ID).Error)
+	assert.Nil(t, stored.AnonymizedAt, "active users are kept")
+
+	// A alteração da política e a execução ficam na trilha de auditoria
+	h.DB.Model(&models.AuditTrailEntry{}).Where("organization_id = ? AND action = ?", org.ID, models.AuditTrailRetentionUpdated).Count(&count)
+	assert.EqualValues(t, 1, count)
+	h.DB.Model(&models.AuditTrailEntry{}).Where("organization_id = ? AND action = ?", org.ID, models.AuditTrailRetentionApplied).Count(&count)
+	assert.EqualValues(t, 1, count)
+
+	// Uma nova execução não encontra mais nada vencido
+	h.DoJSON(t, token, http.MethodGet, "/api/v1/retention-policy/report", nil, http.StatusOK, &report)
+	assert.Zero(t, report.TotalRecords)
+}
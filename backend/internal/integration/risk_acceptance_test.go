@@ -0,0 +1,114 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deliveriesTo conta as entregas de notificação enfileiradas para o e-mail informado.
+func deliveriesTo(t *testing.T, org models.Organization, email string) int64 {
+	t.Helper()
+	var count int64
+	require.NoError(t, h.DB.Model(&models.BackgroundJob{}).
+		Where("organization_id = ? AND type = ? AND payload->>'target' = ?", org.ID, notifications.JobDeliver, email).
+		Count(&count).Error)
+	return count
+}
+
+func TestRiskAcceptanceFlow(t *testing.T) {
+	org := h.NewOrganization(t, "ACME Integração")
+	manager, managerToken := h.NewUser(t, org, models.RoleManager)
+	owner, ownerToken := h.NewUser(t, org, models.RoleUser)
+
+	// 1. O gestor cria o risco e atribui ao responsável
+	var risk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{
+		Title: "Vazamento de dados de clientes", Impact: "Alto", Probability: "Alto", OwnerID: owner.ID.String(),
+	}, http.StatusCreated, &risk)
+	assert.Equal(t, models.StatusOpen, risk.Status)
+	assert.Equal(t, org.ID, risk.OrganizationID)
+	assert.NotEqual(t, "Indefinido", risk.RiskLevel, "the scoring matrix sets the level")
+	assert.EqualValues(t, 1, deliveriesTo(t, org, owner.Email), "owner is notified of the new risk")
+
+	riskPath := "/api/v1/risks/" + risk.ID.String()
+
+	// 2. Só administradores e gestores submetem para aceite
+	h.DoJSON(t, ownerToken, http.MethodPost, riskPath+"/submit-acceptance", nil, http.StatusForbidden, nil)
+
+	var first models.ApprovalWorkflow
+	h.DoJSON(t, managerToken, http.MethodPost, riskPath+"/submit-acceptance", nil, http.StatusCreated, &first)
+	assert.Equal(t, models.ApprovalPending, first.Status)
+	assert.Equal(t, owner.ID, first.ApproverID)
+	assert.Equal(t, manager.ID, first.RequesterID)
+	h.DoJSON(t, managerToken, http.MethodPost, riskPath+"/submit-acceptance", nil, http.StatusConflict, nil)
+
+	// 3. Só o aprovador decide; a rejeição mantém o status do risco
+	decidePath := riskPath + "/approval/" + first.ID.String() + "/decide"
+	h.DoJSON(t, managerToken, http.MethodPost, decidePath, handlers.DecisionPayload{Decision: models.ApprovalApproved}, http.StatusForbidden, nil)
+	h.DoJSON(t, ownerToken, http.MethodPost, decidePath, handlers.DecisionPayload{Decision: models.ApprovalRejected, Comments: "Sem justificativa"}, http.StatusOK, nil)
+	h.DoJSON(t, ownerToken, http.MethodPost, decidePath, handlers.DecisionPayload{Decision: models.ApprovalApproved}, http.StatusConflict, nil)
+
+	var reloaded models.Risk
+	require.NoError(t, h.DB.First(&reloaded, "id = ?", risk.ID).Error)
+	assert.Equal(t, models.StatusOpen, reloaded.Status)
+	assert.EqualValues(t, 1, deliveriesTo(t, org, manager.Email), "requester is notified of the rejection")
+
+	// 4. Nova submissão aprovada: o risco passa a aceito
+	var second models.ApprovalWorkflow
+	h.DoJSON(t, managerToken, http.MethodPost, riskPath+"/submit-acceptance", nil, http.StatusCreated, &second)
+	h.DoJSON(t, ownerToken, http.MethodPost, riskPath+"/approval/"+second.ID.String()+"/decide",
+		handlers.DecisionPayload{Decision: models.ApprovalApproved, Comments: "Dentro do apetite"}, http.StatusOK, nil)
+
+	require.NoError(t, h.DB.First(&reloaded, "id = ?", risk.ID).Error)
+	assert.Equal(t, models.StatusAccepted, reloaded.Status)
+	assert.Nil(t, reloaded.StatusCode)
+	assert.EqualValues(t, 2, deliveriesTo(t, org, manager.Email), "requester is notified of the approval")
+
+	var history struct {
+		Items      []models.ApprovalWorkflow `json:"items"`
+		TotalItems int64                     `json:"total_items"`
+	}
+	h.DoJSON(t, ownerToken, http.MethodGet, riskPath+"/approval-history", nil, http.StatusOK, &history)
+	require.EqualValues(t, 2, history.TotalItems)
+	assert.Equal(t, models.ApprovalApproved, history.Items[0].Status, "newest first")
+	assert.Equal(t, models.ApprovalRejected, history.Items[1].Status)
+
+	// 5. A listagem (modelo de leitura risk_list_items) reflete o novo status
+	var list struct {
+		Items []models.RiskListItem `json:"items"`
+	}
+	h.DoJSON(t, managerToken, http.MethodGet, "/api/v1/risks?status=aceito", nil, http.StatusOK, &list)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, risk.ID, list.Items[0].ID)
+	require.NotNil(t, list.Items[0].ApprovalStatus)
+	assert.Equal(t, models.ApprovalApproved, *list.Items[0].ApprovalStatus, "latest acceptance request")
+
+	var riskEvents int64
+	require.NoError(t, h.DB.Model(&models.BackgroundJob{}).
+		Where("organization_id = ? AND type = ?", org.ID, notifications.JobRiskEvent).Count(&riskEvents).Error)
+	assert.EqualValues(t, 2, riskEvents, "risk created and status changed events are queued")
+}
+
+func TestRiskAcceptanceIsScopedToTheOrganization(t *testing.T) {
+	org := h.NewOrganization(t, "Org A")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	other := h.NewOrganization(t, "Org B")
+	_, otherToken := h.NewUser(t, other, models.RoleAdmin)
+
+	var risk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco da Org A"}, http.StatusCreated, &risk)
+
+	riskPath := "/api/v1/risks/" + risk.ID.String()
+	h.DoJSON(t, otherToken, http.MethodGet, riskPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, otherToken, http.MethodPost, riskPath+"/submit-acceptance", nil, http.StatusNotFound, nil)
+	h.DoJSON(t, "", http.MethodGet, riskPath, nil, http.StatusUnauthorized, nil)
+}
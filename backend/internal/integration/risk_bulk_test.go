@@ -0,0 +1,114 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resultsByRisk indexa os resultados do relatório pelo risco.
+func resultsByRisk(report handlers.BulkRiskOperationReport) map[uuid.UUID]handlers.BulkRiskItemResult {
+	results := map[uuid.UUID]handlers.BulkRiskItemResult{}
+	for _, result := range report.Results {
+		results[result.RiskID] = result
+	}
+	return results
+}
+
+func TestBulkRiskStatusChange(t *testing.T) {
+	org := h.NewOrganization(t, "Riscos em lote")
+	_, token := h.NewUser(t, org, models.RoleManager)
+	_, otherToken := h.NewUser(t, org, models.RoleManager)
+	path := "/api/v1/organizations/" + org.ID.String() + "/risks/bulk"
+
+	risks := make([]models.Risk, 3)
+	for i := range risks {
+		h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco em lote"}, http.StatusCreated, &risks[i])
+	}
+	require.NoError(t, h.DB.Model(&risks[1]).Update("status", models.StatusInProgress).Error)
+	// Outro usuário edita o terceiro risco
+	w := h.Do(t, otherToken, http.MethodPost, "/api/v1/edit-locks/risk/"+risks[2].ID.String(), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	missing := uuid.New()
+
+	payload := handlers.BulkRiskOperationPayload{
+		RiskIDs: []string{risks[0].ID.String(), risks[1].ID.String(), risks[2].ID.String(), missing.String()},
+		Action:  "change_status",
+		Status:  string(models.StatusInProgress),
+		DryRun:  true,
+	}
+	var report handlers.BulkRiskOperationReport
+	h.DoJSON(t, token, http.MethodPost, path, payload, http.StatusOK, &report)
+	assert.True(t, report.DryRun)
+	require.NotNil(t, report.Preflight)
+	assert.Equal(t, 1, report.Preflight.AffectedRecords)
+	var stored models.Risk
+	require.NoError(t, h.DB.First(&stored, "id = ?", risks[0].ID).Error)
+	assert.Equal(t, models.StatusOpen, stored.Status, "dry-run changes nothing")
+
+	payload.DryRun = false
+	report = handlers.BulkRiskOperationReport{}
+	h.DoJSON(t, token, http.MethodPost, path, payload, http.StatusOK, &report)
+	results := resultsByRisk(report)
+	assert.Equal(t, "updated", results[risks[0].ID].Result)
+	assert.Equal(t, string(models.StatusOpen), results[risks[0].ID].PreviousValue)
+	assert.Equal(t, "unchanged", results[risks[1].ID].Result)
+	assert.Equal(t, "locked", results[risks[2].ID].Reason)
+	assert.Equal(t, "not_found", results[missing].Reason)
+	assert.Equal(t, map[string]int{"updated": 1, "unchanged": 1, "skipped": 2}, report.Summary)
+	require.NotNil(t, report.AuditTrailEntryID)
+
+	require.NoError(t, h.DB.First(&stored, "id = ?", risks[0].ID).Error)
+	assert.Equal(t, models.StatusInProgress, stored.Status)
+	require.NoError(t, h.DB.First(&stored, "id = ?", risks[2].ID).Error)
+	assert.Equal(t, models.StatusOpen, stored.Status, "locked risks are not changed")
+
+	var entry models.AuditTrailEntry
+	require.NoError(t, h.DB.First(&entry, "id = ?", *report.AuditTrailEntryID).Error)
+	assert.Equal(t, models.AuditTrailRisksBulkUpdated, entry.Action)
+}
+
+func TestBulkRiskReassignAndDelete(t *testing.T) {
+	org := h.NewOrganization(t, "Riscos em lote 2")
+	_, token := h.NewUser(t, org, models.RoleManager)
+	newOwner, _ := h.NewUser(t, org, models.RoleUser)
+	_, userToken := h.NewUser(t, org, models.RoleUser)
+	outsiderOrg := h.NewOrganization(t, "Outra organização")
+	outsider, _ := h.NewUser(t, outsiderOrg, models.RoleManager)
+	path := "/api/v1/organizations/" + org.ID.String() + "/risks/bulk"
+
+	var first, second models.Risk
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Primeiro"}, http.StatusCreated, &first)
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Segundo"}, http.StatusCreated, &second)
+	ids := []string{first.ID.String(), second.ID.String()}
+
+	// O novo responsável precisa ser um usuário ativo da organização
+	w := h.Do(t, token, http.MethodPost, path, handlers.BulkRiskOperationPayload{RiskIDs: ids, Action: "reassign_owner", OwnerID: outsider.ID.String()})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var report handlers.BulkRiskOperationReport
+	h.DoJSON(t, token, http.MethodPost, path, handlers.BulkRiskOperationPayload{RiskIDs: ids, Action: "reassign_owner", OwnerID: newOwner.ID.String()}, http.StatusOK, &report)
+	assert.Equal(t, 2, report.Summary["updated"])
+	var stored models.Risk
+	require.NoError(t, h.DB.First(&stored, "id = ?", second.ID).Error)
+	assert.Equal(t, newOwner.ID, stored.OwnerID)
+
+	// Excluir exige risks:delete
+	w = h.Do(t, userToken, http.MethodPost, path, handlers.BulkRiskOperationPayload{RiskIDs: ids, Action: "delete"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	report = handlers.BulkRiskOperationReport{}
+	h.DoJSON(t, token, http.MethodPost, path, handlers.BulkRiskOperationPayload{RiskIDs: ids, Action: "delete", Reason: "Duplicados"}, http.StatusOK, &report)
+	assert.Equal(t, 2, report.Summary["deleted"])
+	var remaining int64
+	h.DB.Model(&models.Risk{}).Where("id IN ?", []uuid.UUID{first.ID, second.ID}).Count(&remaining)
+	assert.Zero(t, remaining)
+}
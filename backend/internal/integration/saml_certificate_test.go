@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/xml"
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/samlauth"
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSAMLCertificateRotation(t *testing.T) {
+	previousConfig := config.Cfg
+	t.Cleanup(func() { config.Cfg = previousConfig })
+	config.Cfg.AppRootURL, config.Cfg.SAMLSPKeyPEM, config.Cfg.SAMLSPCertPEM = "https://grc.example.test", "", ""
+	require.NoError(t, samlauth.InitializeSAMLSPGlobalConfig())
+
+	org := h.NewOrganization(t, "Org Certificados SAML")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	var idp models.IdentityProvider
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/organizations/"+org.ID.String()+"/identity-providers", map[string]interface{}{
+		"provider_type": "saml", "name": "IdP Corporativo", "is_active": false,
+		"config_json": map[string]interface{}{"idp_entity_id": "https://idp.example.test", "sign_request": true},
+	}, http.StatusCreated, &idp)
+	certsPath := "/api/v1/organizations/" + org.ID.String() + "/identity-providers/" + idp.ID.String() + "/saml-certificates"
+	metadataPath := "/auth/saml/" + idp.ID.String() + "/metadata"
+	signingCerts := func() []string {
+		t.Helper()
+		resp := h.Do(t, "", http.MethodGet, metadataPath, nil)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		assert.Equal(t, "application/samlmetadata+xml", resp.Header().Get("Content-Type"))
+		var metadata saml.EntityDescriptor
+		require.NoError(t, xml.Unmarshal(resp.Body.Bytes(), &metadata))
+		var certs []string
+		for _, descriptor := range metadata.SPSSODescriptors[0].KeyDescriptors {
+			if descriptor.Use == "signing" {
+				certs = append(certs, descriptor.KeyInfo.X509Data.X509Certificates[0].Data)
+			}
+		}
+		return certs
+	}
+
+	// 1. Sem certificado nenhum não há metadados; o primeiro certificado gerado já fica ativo
+	assert.Equal(t, http.StatusServiceUnavailable, h.Do(t, "", http.MethodGet, metadataPath, nil).Code)
+	h.DoJSON(t, memberToken, http.MethodPost, certsPath, handlers.SAMLCertificatePayload{}, http.StatusForbidden, nil)
+	var first models.SAMLCertificate
+	h.DoJSON(t, adminToken, http.MethodPost, certsPath, handlers.SAMLCertificatePayload{ValidityDays: 30}, http.StatusCreated, &first)
+	assert.Equal(t, models.SAMLCertificateActive, first.Status)
+	assert.Len(t, signingCerts(), 1)
+
+	// 2. O próximo certificado entra como reserva e é publicado junto com o ativo
+	certPEM, keyPEM, err := samlauth.GenerateCertificate("next", 365*24*time.Hour)
+	require.NoError(t, err)
+	h.DoJSON(t, adminToken, http.MethodPost, certsPath, handlers.SAMLCertificatePayload{CertificatePEM: certPEM}, http.StatusBadRequest, nil)
+	var next models.SAMLCertificate
+	h.DoJSON(t, adminToken, http.MethodPost, certsPath, handlers.SAMLCertificatePayload{CertificatePEM: certPEM, PrivateKeyPEM: keyPEM}, http.StatusCreated, &next)
+	assert.Equal(t, models.SAMLCertificateStandby, next.Status)
+	assert.Len(t, signingCerts(), 2)
+
+	// 3. A troca rebaixa o anterior a reserva; o ativo não pode ser removido
+	h.DoJSON(t, adminToken, http.MethodPost, certsPath+"/"+next.ID.String()+"/activate", nil, http.StatusOK, &next)
+	assert.Equal(t, models.SAMLCertificateActive, next.Status)
+	var list handlers.SAMLCertificateListResponse
+	h.DoJSON(t, adminToken, http.MethodGet, certsPath, nil, http.StatusOK, &list)
+	require.Len(t, list.Certificates, 2)
+	assert.False(t, list.UsesGlobalCertificate)
+	for _, cert := range list.Certificates {
+		if cert.ID == first.ID {
+			assert.Equal(t, models.SAMLCertificateStandby, cert.Status)
+		}
+	}
+	h.DoJSON(t, adminToken, http.MethodDelete, certsPath+"/"+next.ID.String(), nil, http.StatusConflict, nil)
+
+	// 4. Depois que o IdP passa a usar o novo certificado, o anterior é removido dos metadados
+	h.DoJSON(t, adminToken, http.MethodDelete, certsPath+"/"+first.ID.String(), nil, http.StatusOK, nil)
+	assert.Len(t, signingCerts(), 1)
+
+	var entries int64
+	assert.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND action IN ?", org.ID, []models.AuditTrailAction{
+			models.AuditTrailSAMLCertAdded, models.AuditTrailSAMLCertActivated, models.AuditTrailSAMLCertRemoved,
+		}).Count(&entries).Error)
+	assert.EqualValues(t, 4, entries)
+}
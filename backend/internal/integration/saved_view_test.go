@@ -0,0 +1,163 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedViews(t *testing.T) {
+	org := h.NewOrganization(t, "Visões salvas")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	member, memberToken := h.NewUser(t, org, models.RoleUser)
+	colleague, colleagueToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	var highRisk, lowRisk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{
+		Title: "Vazamento de dados", Impact: models.ImpactHigh, Probability: models.ProbabilityHigh, OwnerID: member.ID.String(),
+	}, http.StatusCreated, &highRisk)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{
+		Title: "Atraso de fornecedor", Impact: models.ImpactLow, Probability: models.ProbabilityLow, OwnerID: member.ID.String(),
+	}, http.StatusCreated, &lowRisk)
+
+	// Os filtros precisam ser parâmetros da listagem; só admins e gestores compartilham visões
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/saved-views", handlers.SavedViewPayload{
+		Name: "Críticos", EntityType: models.SavedViewRisk, Filters: map[string][]string{"severity": {"Alto"}},
+	}, http.StatusBadRequest, nil)
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/saved-views", handlers.SavedViewPayload{
+		Name: "Críticos", EntityType: models.SavedViewRisk, Filters: map[string][]string{"impact": {"Alto", "Crítico"}},
+	}, http.StatusBadRequest, nil)
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/saved-views", handlers.SavedViewPayload{
+		Name: "Críticos", EntityType: "vendor", Filters: map[string][]string{},
+	}, http.StatusBadRequest, nil)
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/saved-views", handlers.SavedViewPayload{
+		Name: "Críticos", EntityType: models.SavedViewRisk, Filters: map[string][]string{"impact": {"Alto"}}, Shared: true,
+	}, http.StatusForbidden, nil)
+
+	var personal handlers.SavedViewResponse
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/saved-views", handlers.SavedViewPayload{
+		Name: " Meus riscos altos ", EntityType: models.SavedViewRisk, Filters: map[string][]string{"impact": {" Alto "}, "category": {""}},
+	}, http.StatusCreated, &personal)
+	assert.Equal(t, "Meus riscos altos", personal.Name)
+	assert.Equal(t, map[string][]string{"impact": {"Alto"}}, personal.Filters)
+	assert.Equal(t, "impact=Alto", personal.QueryString)
+	assert.True(t, personal.Editable)
+	assert.False(t, personal.Shared)
+	personalPath := "/api/v1/saved-views/" + personal.ID.String()
+
+	var shared, managerOnly handlers.SavedViewResponse
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/saved-views", handlers.SavedViewPayload{
+		Name: "Minhas avaliações atrasadas", EntityType: models.SavedViewAssessment,
+		Filters: map[string][]string{"assigned_to_id": {"me"}, "overdue": {"true"}}, Shared: true,
+	}, http.StatusCreated, &shared)
+	sharedPath := "/api/v1/saved-views/" + shared.ID.String()
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/saved-views", handlers.SavedViewPayload{
+		Name: "Riscos baixos", EntityType: models.SavedViewRisk, Filters: map[string][]string{"impact": {"Baixo"}},
+	}, http.StatusCreated, &managerOnly)
+
+	// Cada usuário vê as suas visões e as compartilhadas da organização
+	var mine []handlers.SavedViewResponse
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/saved-views", nil, http.StatusOK, &mine)
+	require.Len(t, mine, 2)
+	assert.Equal(t, shared.ID, mine[0].ID, "assessment views come first")
+	assert.False(t, mine[0].Editable)
+	assert.Equal(t, personal.ID, mine[1].ID)
+	var riskViews []handlers.SavedViewResponse
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/saved-views?entity_type=risk", nil, http.StatusOK, &riskViews)
+	require.Len(t, riskViews, 1)
+	assert.Equal(t, personal.ID, riskViews[0].ID)
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/saved-views?entity_type=vendor", nil, http.StatusBadRequest, nil)
+	h.DoJSON(t, colleagueToken, http.MethodGet, personalPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/saved-views/"+managerOnly.ID.String(), nil, http.StatusNotFound, nil)
+
+	// Outras organizações não veem as visões, nem as compartilhadas
+	h.DoJSON(t, outsiderToken, http.MethodGet, sharedPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, outsiderToken, http.MethodDelete, personalPath, nil, http.StatusNotFound, nil)
+	var foreign []handlers.SavedViewResponse
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/saved-views", nil, http.StatusOK, &foreign)
+	assert.Empty(t, foreign)
+
+	// view_id aplica os filtros na listagem de riscos; parâmetros explícitos prevalecem
+	var risks struct {
+		Items []models.RiskListItem `json:"items"`
+	}
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/risks?view_id="+personal.ID.String(), nil, http.StatusOK, &risks)
+	require.Len(t, risks.Items, 1)
+	assert.Equal(t, highRisk.ID, risks.Items[0].ID)
+	var overridden struct {
+		Items []models.RiskListItem `json:"items"`
+	}
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/risks?impact=Baixo&view_id="+personal.ID.String(), nil, http.StatusOK, &overridden)
+	require.Len(t, overridden.Items, 1)
+	assert.Equal(t, lowRisk.ID, overridden.Items[0].ID)
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/risks?view_id="+shared.ID.String(), nil, http.StatusNotFound, nil)
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/risks?view_id="+managerOnly.ID.String(), nil, http.StatusNotFound, nil)
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/risks?view_id="+personal.ID.String(), nil, http.StatusNotFound, nil)
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/risks?view_id=invalido", nil, http.StatusBadRequest, nil)
+
+	// Na visão compartilhada, assigned_to_id=me resolve para quem consulta
+	framework := models.AuditFramework{Name: "Framework de visões " + uuid.NewString()}
+	require.NoError(t, h.DB.Create(&framework).Error)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	var assessmentIDs []uuid.UUID
+	for i, assignee := range []uuid.UUID{member.ID, colleague.ID, member.ID} {
+		control := models.AuditControl{FrameworkID: framework.ID, ControlID: fmt.Sprintf("SV-%d", i+1)}
+		require.NoError(t, h.DB.Create(&control).Error)
+		assignee := assignee
+		assessment := models.AuditAssessment{OrganizationID: org.ID, AuditControlID: control.ID, AssignedToID: &assignee, DueDate: &yesterday}
+		if i == 2 {
+			done := time.Now()
+			assessment.AssignmentCompletedAt = &done
+		}
+		require.NoError(t, h.DB.Create(&assessment).Error)
+		assessmentIDs = append(assessmentIDs, assessment.ID)
+	}
+	assessmentsPath := "/api/v1/audit/organizations/" + org.ID.String() + "/frameworks/" + framework.ID.String() + "/assessments?view_id=" + shared.ID.String()
+	for token, want := range map[string]uuid.UUID{memberToken: assessmentIDs[0], colleagueToken: assessmentIDs[1]} {
+		var page struct {
+			Items      []models.AuditAssessment `json:"items"`
+			TotalItems int64                    `json:"total_items"`
+		}
+		h.DoJSON(t, token, http.MethodGet, assessmentsPath, nil, http.StatusOK, &page)
+		require.EqualValues(t, 1, page.TotalItems)
+		assert.Equal(t, want, page.Items[0].ID)
+	}
+
+	// Visões compartilhadas só são alteradas por admins e gestores; compartilhar também exige a role
+	rename := "Atrasadas (equipe)"
+	h.DoJSON(t, memberToken, http.MethodPut, sharedPath, handlers.UpdateSavedViewPayload{Name: &rename}, http.StatusForbidden, nil)
+	h.DoJSON(t, memberToken, http.MethodDelete, sharedPath, nil, http.StatusForbidden, nil)
+	share := true
+	h.DoJSON(t, memberToken, http.MethodPut, personalPath, handlers.UpdateSavedViewPayload{Shared: &share}, http.StatusForbidden, nil)
+	var renamed handlers.SavedViewResponse
+	h.DoJSON(t, managerToken, http.MethodPut, sharedPath, handlers.UpdateSavedViewPayload{Name: &rename}, http.StatusOK, &renamed)
+	assert.Equal(t, rename, renamed.Name)
+	assert.True(t, renamed.Shared)
+
+	// O dono altera os filtros (validados pelo tipo da visão) e exclui a visão
+	invalidFilters := map[string][]string{"overdue": {"true"}}
+	h.DoJSON(t, memberToken, http.MethodPut, personalPath, handlers.UpdateSavedViewPayload{Filters: &invalidFilters}, http.StatusBadRequest, nil)
+	filters := map[string][]string{"impact": {"Baixo"}}
+	var updated handlers.SavedViewResponse
+	h.DoJSON(t, memberToken, http.MethodPut, personalPath, handlers.UpdateSavedViewPayload{Filters: &filters}, http.StatusOK, &updated)
+	assert.Equal(t, "impact=Baixo", updated.QueryString)
+	assert.Equal(t, models.SavedViewRisk, updated.EntityType)
+
+	h.DoJSON(t, colleagueToken, http.MethodDelete, personalPath, nil, http.StatusNotFound, nil)
+	rec := h.Do(t, memberToken, http.MethodDelete, personalPath, nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	h.DoJSON(t, memberToken, http.MethodGet, personalPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/risks?view_id="+personal.ID.String(), nil, http.StatusNotFound, nil)
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newPasswordUser cria um usuário que faz login por senha.
+func newPasswordUser(t *testing.T, org models.Organization, password string) models.User {
+	t.Helper()
+	user, _ := h.NewUser(t, org, models.RoleUser)
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, h.DB.Model(&user).Update("password_hash", string(hash)).Error)
+	return user
+}
+
+func login(t *testing.T, email, password string) handlers.LoginResponse {
+	t.Helper()
+	var resp handlers.LoginResponse
+	h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: email, Password: password}, http.StatusOK, &resp)
+	require.NotEmpty(t, resp.RefreshToken)
+	return resp
+}
+
+func TestRefreshTokenRotationAndReuse(t *testing.T) {
+	org := h.NewOrganization(t, "Sessões")
+	user := newPasswordUser(t, org, "s3nha-forte!")
+	first := login(t, user.Email, "s3nha-forte!")
+
+	// A renovação emite um novo par na mesma sessão; o refresh token antigo deixa de valer
+	var second handlers.LoginResponse
+	h.DoJSON(t, "", http.MethodPost, "/auth/refresh", handlers.RefreshTokenPayload{RefreshToken: first.RefreshToken}, http.StatusOK, &second)
+	assert.Equal(t, first.SessionID, second.SessionID)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	h.DoJSON(t, second.Token, http.MethodGet, "/api/v1/me/notifications/unread-count", nil, http.StatusOK, nil)
+
+	// Reusar o token rotacionado revoga a sessão inteira, inclusive os tokens de acesso já emitidos
+	h.DoJSON(t, "", http.MethodPost, "/auth/refresh", handlers.RefreshTokenPayload{RefreshToken: first.RefreshToken}, http.StatusUnauthorized, nil)
+	h.DoJSON(t, second.Token, http.MethodGet, "/api/v1/me/notifications/unread-count", nil, http.StatusUnauthorized, nil)
+	h.DoJSON(t, "", http.MethodPost, "/auth/refresh", handlers.RefreshTokenPayload{RefreshToken: second.RefreshToken}, http.StatusUnauthorized, nil)
+
+	var session models.AuthSession
+	require.NoError(t, h.DB.First(&session, "id = ?", first.SessionID).Error)
+	assert.Equal(t, models.SessionRevokedRefreshReuse, session.RevokedReason)
+}
+
+func TestLogoutAndSignOutEverywhere(t *testing.T) {
+	org := h.NewOrganization(t, "Logout")
+	user := newPasswordUser(t, org, "s3nha-forte!")
+	laptop := login(t, user.Email, "s3nha-forte!")
+	phone := login(t, user.Email, "s3nha-forte!")
+	tablet := login(t, user.Email, "s3nha-forte!")
+
+	var sessions struct {
+		Items []handlers.SessionResponse `json:"items"`
+	}
+	h.DoJSON(t, laptop.Token, http.MethodGet, "/api/v1/me/sessions", nil, http.StatusOK, &sessions)
+	require.Len(t, sessions.Items, 3)
+
+	// Logout encerra só a sessão da requisição
+	h.DoJSON(t, tablet.Token, http.MethodPost, "/api/v1/auth/logout", nil, http.StatusOK, nil)
+	h.DoJSON(t, tablet.Token, http.MethodGet, "/api/v1/me/sessions", nil, http.StatusUnauthorized, nil)
+	h.DoJSON(t, "", http.MethodPost, "/auth/refresh", handlers.RefreshTokenPayload{RefreshToken: tablet.RefreshToken}, http.StatusUnauthorized, nil)
+
+	// "Sair de todos os dispositivos" mantendo o atual
+	var result struct {
+		Revoked int64 `json:"revoked"`
+	}
+	h.DoJSON(t, laptop.Token, http.MethodPost, "/api/v1/auth/logout-all", handlers.LogoutAllPayload{KeepCurrent: true}, http.StatusOK, &result)
+	assert.EqualValues(t, 1, result.Revoked)
+	h.DoJSON(t, phone.Token, http.MethodGet, "/api/v1/me/sessions", nil, http.StatusUnauthorized, nil)
+	h.DoJSON(t, laptop.Token, http.MethodGet, "/api/v1/me/sessions", nil, http.StatusOK, &sessions)
+	require.Len(t, sessions.Items, 1)
+	assert.True(t, sessions.Items[0].Current)
+
+	// As sessões de outro usuário não são visíveis nem revogáveis
+	other := newPasswordUser(t, org, "outra-s3nha!")
+	otherLogin := login(t, other.Email, "outra-s3nha!")
+	h.DoJSON(t, otherLogin.Token, http.MethodDelete, "/api/v1/me/sessions/"+laptop.SessionID, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, laptop.Token, http.MethodGet, "/api/v1/me/sessions", nil, http.StatusOK, nil)
+}
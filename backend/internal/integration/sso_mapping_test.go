@@ -0,0 +1,153 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/ssomapping"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSOGroupMapping(t *testing.T) {
+	org := h.NewOrganization(t, "Org Mapeamento SSO")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	user, _ := h.NewUser(t, org, models.RoleUser)
+	newTeam := func(name string, members ...models.User) handlers.TeamView {
+		t.Helper()
+		payload := handlers.TeamPayload{Name: name}
+		for _, member := range members {
+			payload.MemberIDs = append(payload.MemberIDs, member.ID.String())
+		}
+		var team handlers.TeamView
+		h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/teams", payload, http.StatusCreated, &team)
+		return team
+	}
+	security := newTeam("Segurança")
+	finance := newTeam("Financeiro", user)
+	audit := newTeam("Auditoria", user)
+	teamsOf := func(u models.User) []string {
+		t.Helper()
+		var names []string
+		require.NoError(t, h.DB.Model(&models.Team{}).Joins("JOIN team_members ON team_members.team_id = teams.id").
+			Where("team_members.user_id = ?", u.ID).Order("teams.name").Pluck("teams.name", &names).Error)
+		return names
+	}
+
+	// 1. O mapeamento é validado ao salvar o IdP: papéis mapeáveis e equipes existentes
+	idpsPath := "/api/v1/organizations/" + org.ID.String() + "/identity-providers"
+	idpPayload := func(mapping map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"provider_type": "saml", "name": "IdP Corporativo",
+			"config_json":            map[string]interface{}{"idp_entity_id": "https://idp.example.test", "idp_sso_url": "https://idp.example.test/sso"},
+			"attribute_mapping_json": mapping,
+		}
+	}
+	h.DoJSON(t, adminToken, http.MethodPost, idpsPath, idpPayload(map[string]interface{}{
+		"role_mappings": []map[string]string{{"group": "root", "role": "system_admin"}},
+	}), http.StatusBadRequest, nil)
+	h.DoJSON(t, adminToken, http.MethodPost, idpsPath, idpPayload(map[string]interface{}{
+		"team_mappings": []map[string]string{{"group": "sec", "team": "Inexistente"}},
+	}), http.StatusBadRequest, nil)
+	var idp models.IdentityProvider
+	h.DoJSON(t, adminToken, http.MethodPost, idpsPath, idpPayload(map[string]interface{}{
+		"email":  "mail",
+		"groups": "memberOf",
+		"role_mappings": []map[string]string{
+			{"group": "GRC-Managers", "role": "manager"},
+			{"group": "GRC-Admins", "role": "admin"},
+		},
+		"default_role": "user",
+		"team_mappings": []map[string]string{
+			{"group": "sec", "team": "segurança"},
+			{"group": "fin", "team": "Financeiro"},
+		},
+	}), http.StatusCreated, &idp)
+
+	// 2. No login, o papel mais privilegiado dos grupos vence e só as equipes mapeadas são sincronizadas
+	result, err := ssomapping.Apply(h.DB, &idp, &user, []string{"grc-managers", "GRC-Admins", "sec"})
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleAdmin, result.Role)
+	assert.Equal(t, []string{security.Name}, result.TeamsAdded)
+	assert.Equal(t, []string{finance.Name}, result.TeamsRemoved)
+	assert.Equal(t, []string{audit.Name, security.Name}, teamsOf(user))
+	var stored models.User
+	require.NoError(t, h.DB.First(&stored, "id = ?", user.ID).Error)
+	assert.Equal(t, models.RoleAdmin, stored.Role)
+
+	// 3. Grupos indisponíveis não alteram nada; sem grupos vale default_role e as equipes mapeadas são retiradas
+	result, err = ssomapping.Apply(h.DB, &idp, &user, nil)
+	require.NoError(t, err)
+	assert.False(t, result.Changed())
+	result, err = ssomapping.Apply(h.DB, &idp, &user, []string{})
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleUser, result.Role)
+	assert.Equal(t, []string{audit.Name}, teamsOf(user))
+
+	// 4. system_admin não é alterado pelo IdP
+	admin.Role = models.RoleSystemAdmin
+	require.NoError(t, h.DB.Model(&admin).Update("role", admin.Role).Error)
+	result, err = ssomapping.Apply(h.DB, &idp, &admin, []string{"grc-managers"})
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleSystemAdmin, result.Role)
+
+	var entries int64
+	assert.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND action = ?", org.ID, models.AuditTrailSSOMappingApplied).Count(&entries).Error)
+	assert.EqualValues(t, 2, entries)
+}
+
+func TestSSOJITDeprovisioning(t *testing.T) {
+	org := h.NewOrganization(t, "Org Desprovisionamento SSO")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	user := newPasswordUser(t, org, "s3nha-forte!")
+	session := login(t, user.Email, "s3nha-forte!")
+
+	// jit_deprovisioning só faz sentido com allowed_groups
+	idpsPath := "/api/v1/organizations/" + org.ID.String() + "/identity-providers"
+	idpPayload := func(mapping map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"provider_type": "saml", "name": "IdP Desprovisionamento",
+			"config_json":            map[string]interface{}{"idp_entity_id": "https://idp.example.test", "idp_sso_url": "https://idp.example.test/sso"},
+			"attribute_mapping_json": mapping,
+		}
+	}
+	h.DoJSON(t, adminToken, http.MethodPost, idpsPath, idpPayload(map[string]interface{}{"jit_deprovisioning": true}), http.StatusBadRequest, nil)
+	var idp models.IdentityProvider
+	h.DoJSON(t, adminToken, http.MethodPost, idpsPath, idpPayload(map[string]interface{}{
+		"groups": "memberOf", "allowed_groups": []string{"GRC-Users"}, "jit_deprovisioning": true,
+	}), http.StatusCreated, &idp)
+
+	// Com o grupo permitido o login segue normalmente
+	require.NoError(t, ssomapping.Authorize(h.DB, &idp, &user, []string{"grc-users"}))
+	h.DoJSON(t, session.Token, http.MethodGet, "/api/v1/me/notifications/unread-count", nil, http.StatusOK, nil)
+
+	// Grupos indisponíveis barram o login sem desativar ninguém
+	assert.ErrorIs(t, ssomapping.Authorize(h.DB, &idp, &user, nil), ssomapping.ErrGroupsUnavailable)
+	h.DoJSON(t, session.Token, http.MethodGet, "/api/v1/me/notifications/unread-count", nil, http.StatusOK, nil)
+
+	// Sem o grupo, o usuário é desativado e as sessões abertas deixam de valer
+	assert.ErrorIs(t, ssomapping.Authorize(h.DB, &idp, &user, []string{"ex-funcionarios"}), ssomapping.ErrAccessDenied)
+	assert.False(t, user.IsActive)
+	var stored models.User
+	require.NoError(t, h.DB.First(&stored, "id = ?", user.ID).Error)
+	assert.False(t, stored.IsActive)
+	h.DoJSON(t, session.Token, http.MethodGet, "/api/v1/me/notifications/unread-count", nil, http.StatusUnauthorized, nil)
+	var revoked models.AuthSession
+	require.NoError(t, h.DB.First(&revoked, "id = ?", session.SessionID).Error)
+	assert.Equal(t, models.SessionRevokedDeprovisioned, revoked.RevokedReason)
+
+	// Um novo login negado de quem já está desativado não gera outro registro; um usuário novo não é criado
+	assert.ErrorIs(t, ssomapping.Authorize(h.DB, &idp, &user, []string{}), ssomapping.ErrAccessDenied)
+	assert.ErrorIs(t, ssomapping.Authorize(h.DB, &idp, nil, []string{}), ssomapping.ErrAccessDenied)
+	var entries int64
+	assert.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND action = ? AND entity_id = ?", org.ID, models.AuditTrailSSODeprovisioned, user.ID).
+		Count(&entries).Error)
+	assert.EqualValues(t, 1, entries)
+}
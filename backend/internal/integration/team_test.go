@@ -0,0 +1,95 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamOwnedRisk(t *testing.T) {
+	org := h.NewOrganization(t, "Org Equipes")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	owner, _ := h.NewUser(t, org, models.RoleUser)
+	member, memberToken := h.NewUser(t, org, models.RoleUser)
+	outsider, outsiderToken := h.NewUser(t, org, models.RoleUser)
+
+	// 1. Só quem tem teams:manage cria equipes
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/teams", handlers.TeamPayload{Name: "Segurança"}, http.StatusForbidden, nil)
+	var team handlers.TeamView
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/teams", handlers.TeamPayload{
+		Name: "Segurança", MemberIDs: []string{member.ID.String(), owner.ID.String()},
+	}, http.StatusCreated, &team)
+	assert.EqualValues(t, 2, team.MemberCount)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/teams", handlers.TeamPayload{Name: "segurança"}, http.StatusConflict, nil)
+
+	var mine []handlers.TeamView
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/teams?mine=true", nil, http.StatusOK, &mine)
+	require.Len(t, mine, 1)
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/teams?mine=true", nil, http.StatusOK, &mine)
+	assert.Empty(t, mine)
+
+	// 2. Risco da equipe: os membros são notificados, sem repetir o responsável
+	teamID := team.ID.String()
+	var risk models.Risk
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{
+		Title: "Indisponibilidade do ERP", OwnerID: owner.ID.String(), OwnerTeamID: &teamID,
+	}, http.StatusCreated, &risk)
+	require.NotNil(t, risk.OwnerTeamID)
+	assert.EqualValues(t, 1, deliveriesTo(t, org, owner.Email))
+	assert.EqualValues(t, 1, deliveriesTo(t, org, member.Email))
+	assert.EqualValues(t, 0, deliveriesTo(t, org, outsider.Email))
+
+	// 3. Membros agem como donos; os demais usuários não
+	riskPath := "/api/v1/risks/" + risk.ID.String()
+	h.DoJSON(t, memberToken, http.MethodPut, riskPath, handlers.RiskPayload{Title: "Indisponibilidade do ERP (revisado)"}, http.StatusOK, nil)
+	h.DoJSON(t, outsiderToken, http.MethodPut, riskPath, handlers.RiskPayload{Title: "Outro título"}, http.StatusForbidden, nil)
+	empty := ""
+	h.DoJSON(t, memberToken, http.MethodPut, riskPath, handlers.RiskPayload{Title: "Sem equipe", OwnerTeamID: &empty}, http.StatusForbidden, nil)
+
+	// 4. Filtros por equipe na listagem
+	var list struct {
+		Items []models.RiskListItem `json:"items"`
+	}
+	h.DoJSON(t, memberToken, http.MethodGet, "/api/v1/risks?my_teams=true", nil, http.StatusOK, &list)
+	require.Len(t, list.Items, 1)
+	require.NotNil(t, list.Items[0].OwnerTeamName)
+	assert.Equal(t, "Segurança", *list.Items[0].OwnerTeamName)
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/risks?my_teams=true", nil, http.StatusOK, &list)
+	assert.Empty(t, list.Items)
+	h.DoJSON(t, managerToken, http.MethodGet, "/api/v1/risks?owner_team_id=invalid", nil, http.StatusBadRequest, nil)
+
+	// 5. Excluir a equipe mantém o risco com o responsável individual
+	h.DoJSON(t, managerToken, http.MethodDelete, "/api/v1/teams/"+teamID, nil, http.StatusOK, nil)
+	var reloaded models.Risk
+	require.NoError(t, h.DB.First(&reloaded, "id = ?", risk.ID).Error)
+	assert.Nil(t, reloaded.OwnerTeamID)
+	assert.Equal(t, owner.ID, reloaded.OwnerID)
+}
+
+func TestTeamsAreScopedToTheOrganization(t *testing.T) {
+	org := h.NewOrganization(t, "Org Equipes A")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	other := h.NewOrganization(t, "Org Equipes B")
+	otherUser, otherToken := h.NewUser(t, other, models.RoleAdmin)
+
+	var team handlers.TeamView
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/teams", handlers.TeamPayload{Name: "Auditoria"}, http.StatusCreated, &team)
+	teamPath := "/api/v1/teams/" + team.ID.String()
+
+	h.DoJSON(t, otherToken, http.MethodGet, teamPath, nil, http.StatusNotFound, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, teamPath+"/members", handlers.TeamMembersPayload{
+		UserIDs: []string{otherUser.ID.String()},
+	}, http.StatusBadRequest, nil)
+
+	teamID := team.ID.String()
+	h.DoJSON(t, otherToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{
+		Title: "Risco da Org B", OwnerTeamID: &teamID,
+	}, http.StatusBadRequest, nil)
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/trash"
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trashPage é a página da lixeira com os itens tipados.
+type trashPage struct {
+	Items      []handlers.TrashItem `json:"items"`
+	TotalItems int64                `json:"total_items"`
+}
+
+func TestRiskTrashRestore(t *testing.T) {
+	previousRetention := config.Cfg.TrashRetention
+	config.Cfg.TrashRetention = 30 * 24 * time.Hour
+	t.Cleanup(func() { config.Cfg.TrashRetention = previousRetention })
+
+	org := h.NewOrganization(t, "Lixeira de riscos")
+	_, token := h.NewUser(t, org, models.RoleManager)
+	_, userToken := h.NewUser(t, org, models.RoleUser)
+
+	var risk models.Risk
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco excluído por engano"}, http.StatusCreated, &risk)
+	path := "/api/v1/risks/" + risk.ID.String()
+
+	w := h.Do(t, token, http.MethodDelete, path, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "purge_at")
+	assert.Equal(t, http.StatusNotFound, h.Do(t, token, http.MethodGet, path, nil).Code, "deleted risks leave the regular endpoints")
+
+	var page trashPage
+	h.DoJSON(t, token, http.MethodGet, "/api/v1/risks/trash", nil, http.StatusOK, &page)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, risk.ID, page.Items[0].ID)
+	assert.Equal(t, risk.ReferenceCode, page.Items[0].ReferenceCode)
+	assert.WithinDuration(t, page.Items[0].DeletedAt.Add(30*24*time.Hour), page.Items[0].PurgeAt, time.Second)
+
+	// A lixeira e a restauração exigem risks:delete
+	assert.Equal(t, http.StatusForbidden, h.Do(t, userToken, http.MethodGet, "/api/v1/risks/trash", nil).Code)
+	assert.Equal(t, http.StatusForbidden, h.Do(t, userToken, http.MethodPost, path+"/restore", nil).Code)
+
+	var restored models.Risk
+	h.DoJSON(t, token, http.MethodPost, path+"/restore", nil, http.StatusOK, &restored)
+	assert.Equal(t, risk.ReferenceCode, restored.ReferenceCode)
+	assert.Equal(t, http.StatusOK, h.Do(t, token, http.MethodGet, path, nil).Code)
+	assert.Equal(t, http.StatusNotFound, h.Do(t, token, http.MethodPost, path+"/restore", nil).Code, "only trashed risks can be restored")
+
+	var actions []models.AuditTrailAction
+	h.DB.Model(&models.AuditTrailEntry{}).Where("entity_id = ?", risk.ID).Order("created_at").Pluck("action", &actions)
+	assert.Equal(t, []models.AuditTrailAction{models.AuditTrailRiskDeleted, models.AuditTrailRiskRestored}, actions)
+}
+
+func TestPolicyTrashRestoreAndPurge(t *testing.T) {
+	org := h.NewOrganization(t, "Lixeira de políticas")
+	_, token := h.NewUser(t, org, models.RoleManager)
+
+	var kept, purged models.Policy
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/policies", handlers.PolicyPayload{Title: "Política restaurada"}, http.StatusCreated, &kept)
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/policies", handlers.PolicyPayload{Title: "Política expurgada"}, http.StatusCreated, &purged)
+	for _, policy := range []models.Policy{kept, purged} {
+		w := h.Do(t, token, http.MethodDelete, "/api/v1/policies/"+policy.ID.String(), nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var page trashPage
+	h.DoJSON(t, token, http.MethodGet, "/api/v1/policies/trash", nil, http.StatusOK, &page)
+	assert.EqualValues(t, 2, page.TotalItems)
+
+	h.DoJSON(t, token, http.MethodPost, "/api/v1/policies/"+kept.ID.String()+"/restore", nil, http.StatusOK, nil)
+
+	// O expurgo remove definitivamente o que está na lixeira antes do corte
+	_, err := trash.Purge(context.Background(), h.DB, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	var count int64
+	h.DB.Unscoped().Model(&models.Policy{}).Where("id = ?", purged.ID).Count(&count)
+	assert.Zero(t, count)
+	assert.Equal(t, http.StatusOK, h.Do(t, token, http.MethodGet, "/api/v1/policies/"+kept.ID.String(), nil).Code)
+}
@@ -0,0 +1,104 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustCenterPublicationFlow(t *testing.T) {
+	org := h.NewOrganization(t, "ACME Trust")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, otherAdminToken := h.NewUser(t, other, models.RoleAdmin)
+
+	// Framework semeado (global) com dois controles, um avaliado
+	framework := models.AuditFramework{Name: "Framework público " + uuid.NewString()}
+	require.NoError(t, h.DB.Create(&framework).Error)
+	evaluated := models.AuditControl{FrameworkID: framework.ID, ControlID: "TC-1"}
+	require.NoError(t, h.DB.Create(&evaluated).Error)
+	require.NoError(t, h.DB.Create(&models.AuditControl{FrameworkID: framework.ID, ControlID: "TC-2"}).Error)
+	score := 80
+	auditDate := time.Now().Add(-24 * time.Hour)
+	require.NoError(t, h.DB.Create(&models.AuditAssessment{
+		OrganizationID: org.ID, AuditControlID: evaluated.ID, Status: models.ControlStatusConformant, Score: &score, AssessmentDate: &auditDate,
+	}).Error)
+
+	slug := "acme-" + uuid.NewString()[:8]
+	profilePath := "/api/v1/organizations/" + org.ID.String() + "/trust-center"
+	publicPath := "/api/public/trust-center/" + slug
+	payload := handlers.TrustCenterProfilePayload{
+		Slug:           slug,
+		Headline:       "Segurança em primeiro lugar",
+		Certifications: []models.TrustCenterCertification{{Name: "ISO 27001", Issuer: "BSI"}},
+		FrameworkIDs:   []string{framework.ID.String()},
+	}
+
+	// Só quem gerencia o trust center da própria organização o configura
+	h.DoJSON(t, memberToken, http.MethodPut, profilePath, payload, http.StatusForbidden, nil)
+	h.DoJSON(t, otherAdminToken, http.MethodPut, profilePath, payload, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPut, profilePath, handlers.TrustCenterProfilePayload{Slug: "A"}, http.StatusBadRequest, nil)
+
+	var profile handlers.TrustCenterProfileResponse
+	h.DoJSON(t, managerToken, http.MethodPut, profilePath, payload, http.StatusCreated, &profile)
+	assert.False(t, profile.IsPublished)
+	assert.True(t, profile.ShowLastAuditDate)
+	assert.Equal(t, []string{framework.ID.String()}, profile.FrameworkIDs)
+
+	// O slug é único entre as organizações
+	h.DoJSON(t, otherAdminToken, http.MethodPut, "/api/v1/organizations/"+other.ID.String()+"/trust-center",
+		handlers.TrustCenterProfilePayload{Slug: slug}, http.StatusConflict, nil)
+
+	// Antes da aprovação, nada é público
+	h.DoJSON(t, "", http.MethodGet, publicPath, nil, http.StatusNotFound, nil)
+
+	// Publicar é a aprovação da organização: só o admin
+	h.DoJSON(t, managerToken, http.MethodPost, profilePath+"/publish", nil, http.StatusForbidden, nil)
+	h.DoJSON(t, otherAdminToken, http.MethodPost, profilePath+"/publish", nil, http.StatusForbidden, nil)
+	h.DoJSON(t, adminToken, http.MethodPost, profilePath+"/publish", nil, http.StatusOK, &profile)
+	assert.True(t, profile.IsPublished)
+	require.NotNil(t, profile.ApprovedByID)
+	assert.Equal(t, admin.ID, *profile.ApprovedByID)
+
+	var public handlers.PublicTrustCenterResponse
+	h.DoJSON(t, "", http.MethodGet, publicPath, nil, http.StatusOK, &public)
+	assert.Equal(t, org.Name, public.OrganizationName)
+	assert.Equal(t, "Segurança em primeiro lugar", public.Headline)
+	require.Len(t, public.Certifications, 1)
+	assert.Equal(t, "ISO 27001", public.Certifications[0].Name)
+	require.Len(t, public.Frameworks, 1)
+	assert.InDelta(t, 50, public.Frameworks[0].CoveragePercentage, 0.01)
+	assert.InDelta(t, 80, public.Frameworks[0].ComplianceScore, 0.01)
+	require.NotNil(t, public.LastAuditDate)
+	assert.WithinDuration(t, auditDate, *public.LastAuditDate, time.Second)
+
+	// Qualquer alteração despublica até uma nova aprovação
+	showLastAuditDate := false
+	payload.ShowLastAuditDate = &showLastAuditDate
+	var edited handlers.TrustCenterProfileResponse
+	h.DoJSON(t, managerToken, http.MethodPut, profilePath, payload, http.StatusOK, &edited)
+	assert.False(t, edited.IsPublished)
+	assert.Nil(t, edited.ApprovedAt)
+	h.DoJSON(t, "", http.MethodGet, publicPath, nil, http.StatusNotFound, nil)
+
+	h.DoJSON(t, adminToken, http.MethodPost, profilePath+"/publish", nil, http.StatusOK, nil)
+	var hidden handlers.PublicTrustCenterResponse
+	h.DoJSON(t, "", http.MethodGet, publicPath, nil, http.StatusOK, &hidden)
+	assert.Nil(t, hidden.LastAuditDate, "the audit date is hidden when show_last_audit_date is false")
+
+	// Gestores podem retirar a página do ar
+	h.DoJSON(t, managerToken, http.MethodPost, profilePath+"/unpublish", nil, http.StatusOK, &profile)
+	assert.False(t, profile.IsPublished)
+	h.DoJSON(t, "", http.MethodGet, publicPath, nil, http.StatusNotFound, nil)
+}
@@ -0,0 +1,116 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditTrailPage é a resposta paginada de GET /audit-trail.
+type auditTrailPage struct {
+	Items      []handlers.AuditTrailEntryResponse `json:"items"`
+	TotalItems int64                              `json:"total_items"`
+}
+
+// userRole lê a role atual do usuário no banco.
+func userRole(t *testing.T, id uuid.UUID) models.UserRole {
+	var user models.User
+	require.NoError(t, h.DB.Select("role").First(&user, "id = ?", id).Error)
+	return user.Role
+}
+
+func TestBulkUserRoleAssignment(t *testing.T) {
+	org := h.NewOrganization(t, "Papéis em lote")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	manager, managerToken := h.NewUser(t, org, models.RoleManager)
+	first, memberToken := h.NewUser(t, org, models.RoleUser)
+	second, _ := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+	foreignUser, _ := h.NewUser(t, other, models.RoleUser)
+	path := "/api/v1/organizations/" + org.ID.String() + "/users/bulk-role"
+
+	ids := []string{first.ID.String(), second.ID.String(), admin.ID.String(), manager.ID.String(), foreignUser.ID.String()}
+	payload := handlers.BulkUserRolePayload{UserIDs: ids, Role: models.RoleManager, Reason: "Novos aprovadores", DryRun: true}
+
+	// Só quem gerencia usuários da própria organização; admin só é concedido por admins
+	h.DoJSON(t, memberToken, http.MethodPost, path, payload, http.StatusForbidden, nil)
+	h.DoJSON(t, outsiderToken, http.MethodPost, path, payload, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, path, handlers.BulkUserRolePayload{UserIDs: ids, Role: models.RoleAdmin}, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, path, handlers.BulkUserRolePayload{
+		UserIDs: ids, Filter: &handlers.BulkUserRoleFilter{Search: "user"}, Role: models.RoleManager,
+	}, http.StatusBadRequest, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, path, handlers.BulkUserRolePayload{Filter: &handlers.BulkUserRoleFilter{}, Role: models.RoleManager}, http.StatusBadRequest, nil)
+
+	// O dry-run relata o que mudaria sem alterar nada
+	var preview handlers.BulkUserRoleReport
+	h.DoJSON(t, managerToken, http.MethodPost, path, payload, http.StatusOK, &preview)
+	assert.True(t, preview.DryRun)
+	assert.Equal(t, 4, preview.Matched)
+	require.Len(t, preview.Changed, 2)
+	assert.Equal(t, []uuid.UUID{manager.ID}, preview.Unchanged)
+	skipped := map[uuid.UUID]string{}
+	for _, skip := range preview.Skipped {
+		skipped[skip.UserID] = skip.Reason
+	}
+	assert.Equal(t, map[uuid.UUID]string{admin.ID: "admin_requires_admin", foreignUser.ID: "not_found"}, skipped)
+	require.NotNil(t, preview.Preflight)
+	assert.Equal(t, 2, preview.Preflight.AffectedRecords)
+	assert.Nil(t, preview.AuditTrailEntryID)
+	assert.Equal(t, models.RoleUser, userRole(t, first.ID))
+
+	// A execução altera as roles e grava o relatório na trilha de auditoria
+	payload.DryRun = false
+	var applied handlers.BulkUserRoleReport
+	h.DoJSON(t, managerToken, http.MethodPost, path, payload, http.StatusOK, &applied)
+	require.NotNil(t, applied.AuditTrailEntryID)
+	assert.Equal(t, models.RoleManager, userRole(t, first.ID))
+	assert.Equal(t, models.RoleManager, userRole(t, second.ID))
+	assert.Equal(t, models.RoleAdmin, userRole(t, admin.ID))
+	assert.Equal(t, models.RoleUser, userRole(t, foreignUser.ID))
+
+	var trail auditTrailPage
+	h.DoJSON(t, adminToken, http.MethodGet, "/api/v1/audit-trail?action="+string(models.AuditTrailUserRolesBulkAssigned), nil, http.StatusOK, &trail)
+	require.EqualValues(t, 1, trail.TotalItems)
+	entry := trail.Items[0]
+	assert.Equal(t, *applied.AuditTrailEntryID, entry.ID)
+	require.NotNil(t, entry.ActorID)
+	assert.Equal(t, manager.ID, *entry.ActorID)
+	var details handlers.BulkUserRoleReport
+	require.NoError(t, json.Unmarshal(entry.Details, &details))
+	assert.Equal(t, "Novos aprovadores", details.Reason)
+	assert.Len(t, details.Changed, 2)
+
+	var foreignTrail auditTrailPage
+	h.DoJSON(t, outsiderToken, http.MethodGet, "/api/v1/audit-trail?action="+string(models.AuditTrailUserRolesBulkAssigned), nil, http.StatusOK, &foreignTrail)
+	assert.Zero(t, foreignTrail.TotalItems)
+
+	// A organização não pode ficar sem admin ou gestor ativo
+	h.DoJSON(t, adminToken, http.MethodPost, path, handlers.BulkUserRolePayload{
+		Filter: &handlers.BulkUserRoleFilter{Roles: []models.UserRole{models.RoleAdmin, models.RoleManager}}, Role: models.RoleUser,
+	}, http.StatusForbidden, nil)
+	assert.Equal(t, models.RoleAdmin, userRole(t, admin.ID))
+
+	// Seleção por filtro: domínio do e-mail
+	for _, u := range []models.User{first, second} {
+		require.NoError(t, h.DB.Model(&models.User{}).Where("id = ?", u.ID).
+			UpdateColumn("email", uuid.NewString()[:8]+"@Financeiro.Acme.test").Error)
+	}
+	var demoted handlers.BulkUserRoleReport
+	h.DoJSON(t, adminToken, http.MethodPost, path, handlers.BulkUserRolePayload{
+		Filter: &handlers.BulkUserRoleFilter{EmailDomain: "@financeiro.acme.test"}, Role: models.RoleUser,
+	}, http.StatusOK, &demoted)
+	assert.Equal(t, 2, demoted.Matched)
+	assert.Len(t, demoted.Changed, 2)
+	assert.Equal(t, models.RoleUser, userRole(t, first.ID))
+	assert.Equal(t, models.RoleManager, userRole(t, manager.ID))
+}
@@ -0,0 +1,87 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifiedDomainDiscovery(t *testing.T) {
+	org := h.NewOrganization(t, "Org Descoberta SSO")
+	_, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	other := h.NewOrganization(t, "Outra Org Descoberta")
+	_, otherToken := h.NewUser(t, other, models.RoleAdmin)
+
+	txt := map[string][]string{}
+	previousLookup := handlers.DomainTXTLookup
+	handlers.DomainTXTLookup = func(_ context.Context, name string) ([]string, error) { return txt[name], nil }
+	t.Cleanup(func() { handlers.DomainTXTLookup = previousLookup })
+
+	newIdP := func(token string, orgID string, name string) models.IdentityProvider {
+		t.Helper()
+		var idp models.IdentityProvider
+		h.DoJSON(t, token, http.MethodPost, "/api/v1/organizations/"+orgID+"/identity-providers", map[string]interface{}{
+			"provider_type": "saml", "name": name, "is_active": true,
+			"config_json": map[string]interface{}{"idp_entity_id": "https://idp.example.test", "idp_sso_url": "https://idp.example.test/sso"},
+		}, http.StatusCreated, &idp)
+		return idp
+	}
+	idp := newIdP(adminToken, org.ID.String(), "IdP Descoberta")
+	otherIdP := newIdP(otherToken, other.ID.String(), "IdP Outra Org")
+	domainsPath := "/api/v1/organizations/" + org.ID.String() + "/verified-domains"
+	discover := func(email string) handlers.SSODiscoveryResponse {
+		t.Helper()
+		var resp handlers.SSODiscoveryResponse
+		h.DoJSON(t, "", http.MethodGet, "/auth/sso/discover?email="+email, nil, http.StatusOK, &resp)
+		return resp
+	}
+
+	// 1. O IdP precisa ser da organização; o domínio começa pendente e não roteia logins
+	h.DoJSON(t, adminToken, http.MethodPost, domainsPath, handlers.VerifiedDomainPayload{Domain: "acme.example", IdentityProviderID: otherIdP.ID.String()}, http.StatusBadRequest, nil)
+	h.DoJSON(t, adminToken, http.MethodPost, domainsPath, handlers.VerifiedDomainPayload{Domain: "not a domain", IdentityProviderID: idp.ID.String()}, http.StatusBadRequest, nil)
+	var domain handlers.VerifiedDomainView
+	h.DoJSON(t, adminToken, http.MethodPost, domainsPath, handlers.VerifiedDomainPayload{Domain: "ACME.example", IdentityProviderID: idp.ID.String()}, http.StatusCreated, &domain)
+	assert.Equal(t, "acme.example", domain.Domain)
+	assert.False(t, domain.Verified)
+	assert.Contains(t, domain.TXTRecord, models.VerifiedDomainTXTPrefix)
+	assert.False(t, discover("ana@acme.example").SSO)
+
+	// 2. Sem o registro TXT a verificação falha; com ele o domínio passa a levar ao IdP
+	domainPath := domainsPath + "/" + domain.ID.String()
+	h.DoJSON(t, adminToken, http.MethodPost, domainPath+"/verify", nil, http.StatusUnprocessableEntity, nil)
+	txt["acme.example"] = []string{"v=spf1 -all", domain.TXTRecord}
+	h.DoJSON(t, adminToken, http.MethodPost, domainPath+"/verify", nil, http.StatusOK, &domain)
+	assert.True(t, domain.Verified)
+
+	resp := discover("Ana@ACME.example")
+	require.True(t, resp.SSO)
+	assert.Equal(t, idp.ID, resp.Provider.ID)
+	assert.Contains(t, resp.Provider.LoginURL, "/auth/saml/"+idp.ID.String()+"/login")
+	assert.True(t, resp.PasswordLogin)
+	assert.False(t, discover("ana@unknown.example").SSO)
+	h.DoJSON(t, "", http.MethodGet, "/auth/sso/discover?email=sem-arroba", nil, http.StatusBadRequest, nil)
+
+	// 3. Um domínio verificado não pode ser reivindicado por outra organização
+	h.DoJSON(t, otherToken, http.MethodPost, "/api/v1/organizations/"+other.ID.String()+"/verified-domains",
+		handlers.VerifiedDomainPayload{Domain: "acme.example", IdentityProviderID: otherIdP.ID.String()}, http.StatusConflict, nil)
+
+	// 4. IdP inativo ou domínio removido deixam de rotear
+	require.NoError(t, h.DB.Model(&models.IdentityProvider{}).Where("id = ?", idp.ID).Update("is_active", false).Error)
+	assert.False(t, discover("ana@acme.example").SSO)
+	require.NoError(t, h.DB.Model(&models.IdentityProvider{}).Where("id = ?", idp.ID).Update("is_active", true).Error)
+	h.DoJSON(t, adminToken, http.MethodDelete, domainPath, nil, http.StatusOK, nil)
+	assert.False(t, discover("ana@acme.example").SSO)
+
+	var entries int64
+	assert.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND entity_type = ?", org.ID, "verified_domain").Count(&entries).Error)
+	assert.EqualValues(t, 3, entries)
+}
@@ -0,0 +1,72 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doIfMatch envia a requisição com o header If-Match.
+func doIfMatch(t *testing.T, token, ifMatch, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", ifMatch)
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRiskUpdateIfMatch(t *testing.T) {
+	org := h.NewOrganization(t, "Concorrência")
+	_, firstToken := h.NewUser(t, org, models.RoleManager)
+	_, secondToken := h.NewUser(t, org, models.RoleManager)
+
+	var risk models.Risk
+	h.DoJSON(t, firstToken, http.MethodPost, "/api/v1/risks", handlers.RiskPayload{Title: "Risco editado por dois"}, http.StatusCreated, &risk)
+	path := "/api/v1/risks/" + risk.ID.String()
+
+	// Os dois editores carregam o risco na mesma versão
+	w := h.Do(t, firstToken, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	w = h.Do(t, secondToken, http.MethodGet, path, nil)
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	// O primeiro grava e recebe a nova versão
+	w = doIfMatch(t, firstToken, etag, http.MethodPut, path, handlers.RiskPayload{Title: "Versão do primeiro editor"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	newETag := w.Header().Get("ETag")
+	assert.NotEqual(t, etag, newETag)
+
+	// O segundo, com a versão antiga, recebe 412 e o ETag atual, sem sobrescrever
+	w = doIfMatch(t, secondToken, etag, http.MethodPut, path, handlers.RiskPayload{Title: "Versão do segundo editor"})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, newETag, w.Header().Get("ETag"))
+	var stored models.Risk
+	require.NoError(t, h.DB.First(&stored, "id = ?", risk.ID).Error)
+	assert.Equal(t, "Versão do primeiro editor", stored.Title)
+
+	// Com a versão atual (ou *), a gravação passa
+	w = doIfMatch(t, secondToken, newETag, http.MethodPut, path, handlers.RiskPayload{Title: "Versão do segundo editor"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = doIfMatch(t, secondToken, "*", http.MethodPut, path, handlers.RiskPayload{Title: "Sem conferir a versão"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Sem If-Match, o comportamento anterior é mantido
+	h.DoJSON(t, firstToken, http.MethodPut, path, handlers.RiskPayload{Title: "Sem precondição"}, http.StatusOK, nil)
+}
@@ -0,0 +1,96 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/webauthn"
+	"phoenixgrc/backend/internal/webauthn/webauthntest"
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type secondFactorResponse struct {
+	TwoFARequired bool                    `json:"2fa_required"`
+	UserID        string                  `json:"user_id"`
+	Methods       []string                `json:"methods"`
+	WebAuthn      webauthn.RequestOptions `json:"webauthn"`
+}
+
+func TestWebAuthnSecondFactor(t *testing.T) {
+	previousRPID, previousOrigins := config.Cfg.WebAuthnRPID, config.Cfg.WebAuthnOrigins
+	config.Cfg.WebAuthnRPID, config.Cfg.WebAuthnOrigins = "grc.example.test", []string{"https://grc.example.test"}
+	t.Cleanup(func() { config.Cfg.WebAuthnRPID, config.Cfg.WebAuthnOrigins = previousRPID, previousOrigins })
+
+	org := h.NewOrganization(t, "Org Passkeys")
+	admin, adminToken := h.NewUser(t, org, models.RoleAdmin)
+	otherAdmin, _ := h.NewUser(t, org, models.RoleAdmin)
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	hash, err := bcrypt.GenerateFromPassword([]byte("Senha-Do-Admin-1"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, h.DB.Model(&admin).Update("password_hash", string(hash)).Error)
+	authenticator := webauthntest.New("grc.example.test", "https://grc.example.test")
+
+	// 1. O administrador registra uma passkey; a mesma resposta não é aceita de novo
+	var creation webauthn.CreationOptions
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/users/me/2fa/webauthn/register/options", nil, http.StatusOK, &creation)
+	assert.Equal(t, "grc.example.test", creation.RP.ID)
+	registration := handlers.WebAuthnRegistrationPayload{Name: "Chave USB", Credential: authenticator.Register(creation.Challenge)}
+	var credential models.WebAuthnCredential
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/users/me/2fa/webauthn/register/verify", registration, http.StatusCreated, &credential)
+	assert.Equal(t, "Chave USB", credential.Name)
+	h.DoJSON(t, adminToken, http.MethodPost, "/api/v1/users/me/2fa/webauthn/register/verify", registration, http.StatusBadRequest, nil)
+	var credentials []models.WebAuthnCredential
+	h.DoJSON(t, adminToken, http.MethodGet, "/api/v1/users/me/2fa/webauthn/credentials", nil, http.StatusOK, &credentials)
+	require.Len(t, credentials, 1)
+
+	// 2. O login com senha passa a pedir a passkey, com o desafio já emitido; a asserção só vale uma vez
+	login := func(wantStatus int) secondFactorResponse {
+		t.Helper()
+		var resp secondFactorResponse
+		h.DoJSON(t, "", http.MethodPost, "/auth/login", handlers.LoginPayload{Email: admin.Email, Password: "Senha-Do-Admin-1"}, wantStatus, &resp)
+		return resp
+	}
+	second := login(http.StatusOK)
+	require.True(t, second.TwoFARequired)
+	assert.Equal(t, []string{handlers.SecondFactorWebAuthn}, second.Methods)
+	require.Len(t, second.WebAuthn.AllowCredentials, 1)
+	assertion := handlers.LoginVerifyWebAuthnPayload{UserID: second.UserID, Credential: authenticator.Assert(second.WebAuthn.Challenge)}
+	var session handlers.LoginResponse
+	h.DoJSON(t, "", http.MethodPost, "/auth/login/2fa/webauthn/verify", assertion, http.StatusOK, &session)
+	assert.NotEmpty(t, session.Token)
+	h.DoJSON(t, "", http.MethodPost, "/auth/login/2fa/webauthn/verify", assertion, http.StatusUnauthorized, nil)
+
+	// 3. Exigir passkey dos administradores: só security:manage; quem ainda não tem passkey fica pendente
+	policyPath := "/api/v1/organizations/" + org.ID.String() + "/mfa-policy"
+	requirePasskeys := handlers.MFAPolicyPayload{RequireWebAuthnForAdmins: true}
+	h.DoJSON(t, managerToken, http.MethodPut, policyPath, requirePasskeys, http.StatusForbidden, nil)
+	var policy handlers.MFAPolicyResponse
+	h.DoJSON(t, adminToken, http.MethodPut, policyPath, requirePasskeys, http.StatusCreated, &policy)
+	assert.True(t, policy.RequireWebAuthnForAdmins)
+	if assert.Len(t, policy.PendingEnrollment, 1) {
+		assert.Equal(t, otherAdmin.ID, policy.PendingEnrollment[0].ID)
+	}
+	h.DoJSON(t, managerToken, http.MethodGet, policyPath, nil, http.StatusOK, &policy)
+	assert.False(t, policy.IsDefault)
+
+	// 4. Com a exigência, TOTP não é oferecido nem aceito para o administrador, e a última passkey não pode ser removida
+	assert.NoError(t, h.DB.Model(&admin).Updates(map[string]interface{}{"is_totp_enabled": true, "totp_secret": "não-usado"}).Error)
+	second = login(http.StatusOK)
+	assert.Equal(t, []string{handlers.SecondFactorWebAuthn}, second.Methods)
+	h.DoJSON(t, "", http.MethodPost, "/auth/login/2fa/verify", handlers.LoginVerifyTOTPPayload{UserID: second.UserID, Token: "123456"}, http.StatusForbidden, nil)
+	h.DoJSON(t, adminToken, http.MethodDelete, "/api/v1/users/me/2fa/webauthn/credentials/"+credential.ID.String(), nil, http.StatusConflict, nil)
+
+	var entries int64
+	assert.NoError(t, h.DB.Model(&models.AuditTrailEntry{}).
+		Where("organization_id = ? AND action IN ?", org.ID, []models.AuditTrailAction{models.AuditTrailWebAuthnRegistered, models.AuditTrailMFAPolicyUpdated}).
+		Count(&entries).Error)
+	assert.EqualValues(t, 2, entries)
+}
@@ -0,0 +1,168 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/bootstrap"
+	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/internal/reports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attachmentRecorder registra os e-mails com anexo enviados; com fail, todos os envios falham.
+type attachmentRecorder struct {
+	mu   sync.Mutex
+	sent []string
+	fail bool
+}
+
+func (r *attachmentRecorder) Send(ctx context.Context, to, subject, body string) error {
+	return nil
+}
+
+func (r *attachmentRecorder) SendWithAttachments(ctx context.Context, to, subject, body string, attachments []notifications.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("smtp indisponível")
+	}
+	r.sent = append(r.sent, to)
+	return nil
+}
+
+// orgJob carrega o job mais recente do tipo na organização.
+func orgJob(t *testing.T, jobType string, org models.Organization) models.BackgroundJob {
+	var job models.BackgroundJob
+	require.NoError(t, h.DB.Where("type = ? AND organization_id = ?", jobType, org.ID).Order("created_at desc").First(&job).Error)
+	return job
+}
+
+// TestWorkerJobHandlers cobre os jobs registrados pelo worker dedicado: a exportação da trilha de auditoria e o
+// envio dos relatórios agendados, que deixaram de rodar em goroutines do servidor.
+func TestWorkerJobHandlers(t *testing.T) {
+	useMemoryStorage(t)
+	bootstrap.RegisterJobHandlers()
+	recorder := &attachmentRecorder{}
+	original := notifications.EmailNotifier()
+	notifications.SetEmailNotifier(recorder)
+	t.Cleanup(func() { notifications.SetEmailNotifier(original) })
+
+	org := h.NewOrganization(t, "Worker dedicado")
+	_, managerToken := h.NewUser(t, org, models.RoleManager)
+	_, memberToken := h.NewUser(t, org, models.RoleUser)
+	other := h.NewOrganization(t, "Outra organização")
+	_, outsiderToken := h.NewUser(t, other, models.RoleAdmin)
+
+	// A exportação da trilha é enfileirada com a organização, e não executada pela API
+	period := handlers.AuditTrailExportPayload{From: time.Now().Add(-24 * time.Hour), To: time.Now()}
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/audit-trail/exports", period, http.StatusForbidden, nil)
+	var export models.AuditTrailExport
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/audit-trail/exports", period, http.StatusAccepted, &export)
+	assert.Equal(t, models.AuditTrailExportPending, export.Status)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/audit-trail/exports", period, http.StatusConflict, nil)
+	exportJob := orgJob(t, handlers.JobAuditTrailExport, org)
+	assert.Equal(t, models.JobQueued, exportJob.Status)
+	assert.Contains(t, exportJob.Payload, export.ID.String())
+	exportPath := "/api/v1/audit-trail/exports/" + export.ID.String()
+	h.DoJSON(t, outsiderToken, http.MethodGet, exportPath, nil, http.StatusNotFound, nil)
+
+	runQueuedJob(t, handlers.JobAuditTrailExport)
+	var completed handlers.AuditTrailExportResponse
+	h.DoJSON(t, managerToken, http.MethodGet, exportPath, nil, http.StatusOK, &completed)
+	assert.Equal(t, models.AuditTrailExportCompleted, completed.Status)
+	require.NotNil(t, completed.CompletedAt)
+	h.DoJSON(t, managerToken, http.MethodGet, exportPath+"/manifest", nil, http.StatusOK, nil)
+	assert.Equal(t, models.JobSucceeded, orgJob(t, handlers.JobAuditTrailExport, org).Status)
+
+	// Um job repetido não refaz uma exportação concluída
+	require.NoError(t, h.DB.Model(&models.BackgroundJob{}).Where("id = ?", exportJob.ID).
+		Updates(map[string]interface{}{"status": models.JobQueued, "completed_at": nil}).Error)
+	runQueuedJob(t, handlers.JobAuditTrailExport)
+	var again models.AuditTrailExport
+	require.NoError(t, h.DB.First(&again, "id = ?", export.ID).Error)
+	assert.Equal(t, models.AuditTrailExportCompleted, again.Status)
+	assert.WithinDuration(t, *completed.CompletedAt, *again.CompletedAt, time.Millisecond)
+
+	// O agendador apenas reserva os relatórios vencidos e enfileira o envio
+	var schedule handlers.ReportScheduleResponse
+	h.DoJSON(t, memberToken, http.MethodPost, "/api/v1/report-schedules", handlers.ReportSchedulePayload{
+		Name: "Riscos semanais", ReportType: models.ReportRiskRegister, Format: models.ReportFormatPDF, Frequency: models.ReportWeekly,
+		Recipients: []string{"ciso@empresa.test"},
+	}, http.StatusForbidden, nil)
+	h.DoJSON(t, managerToken, http.MethodPost, "/api/v1/report-schedules", handlers.ReportSchedulePayload{
+		Name: "Riscos semanais", ReportType: models.ReportRiskRegister, Format: models.ReportFormatPDF, Frequency: models.ReportWeekly,
+		Recipients: []string{"ciso@empresa.test", "cfo@empresa.test"},
+	}, http.StatusCreated, &schedule)
+	schedulePath := "/api/v1/report-schedules/" + schedule.ID.String()
+	h.DoJSON(t, outsiderToken, http.MethodGet, schedulePath, nil, http.StatusNotFound, nil)
+
+	makeDue := func() {
+		require.NoError(t, h.DB.Model(&models.ReportSchedule{}).Where("id = ?", schedule.ID).
+			UpdateColumn("next_run_at", time.Now().Add(-time.Minute)).Error)
+	}
+	makeDue()
+	now := time.Now()
+	count, err := reports.RunDueSchedules(context.Background(), h.DB, now)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, 1)
+	reportJob := orgJob(t, reports.JobScheduledReport, org)
+	assert.Equal(t, models.JobQueued, reportJob.Status)
+	assert.Contains(t, reportJob.Payload, schedule.ID.String())
+	var claimed models.ReportSchedule
+	require.NoError(t, h.DB.First(&claimed, "id = ?", schedule.ID).Error)
+	assert.True(t, claimed.NextRunAt.After(now), "the next run is advanced when the schedule is claimed")
+	assert.Nil(t, claimed.LastRunAt, "nothing is delivered by the scheduler itself")
+	assert.Empty(t, recorder.sent)
+
+	_, err = reports.RunDueSchedules(context.Background(), h.DB, now)
+	require.NoError(t, err)
+	var queued int64
+	require.NoError(t, h.DB.Model(&models.BackgroundJob{}).Where("type = ? AND organization_id = ?", reports.JobScheduledReport, org.ID).Count(&queued).Error)
+	assert.EqualValues(t, 1, queued, "a claimed schedule is not enqueued twice")
+
+	// O worker gera e envia o relatório a cada destinatário
+	runQueuedJob(t, reports.JobScheduledReport)
+	assert.ElementsMatch(t, []string{"ciso@empresa.test", "cfo@empresa.test"}, recorder.sent)
+	var delivered handlers.ReportScheduleResponse
+	h.DoJSON(t, managerToken, http.MethodGet, schedulePath, nil, http.StatusOK, &delivered)
+	assert.Equal(t, models.ReportRunSucceeded, delivered.LastStatus)
+	require.NotNil(t, delivered.LastRunAt)
+	assert.Equal(t, models.JobSucceeded, orgJob(t, reports.JobScheduledReport, org).Status)
+
+	// Uma falha de envio não é repetida pela fila (evita e-mails duplicados): o job vai direto para dead
+	recorder.fail = true
+	makeDue()
+	_, err = reports.RunDueSchedules(context.Background(), h.DB, time.Now())
+	require.NoError(t, err)
+	runQueuedJob(t, reports.JobScheduledReport)
+	failedJob := orgJob(t, reports.JobScheduledReport, org)
+	assert.Equal(t, models.JobDead, failedJob.Status)
+	assert.Greater(t, failedJob.MaxAttempts, 1, "dead before exhausting its attempts")
+	assert.Contains(t, failedJob.LastError, "smtp indisponível")
+	var failed handlers.ReportScheduleResponse
+	h.DoJSON(t, managerToken, http.MethodGet, schedulePath, nil, http.StatusOK, &failed)
+	assert.Equal(t, models.ReportRunFailed, failed.LastStatus)
+	assert.Contains(t, failed.LastError, "2 of 2 recipients")
+
+	// Um agendamento excluído antes da execução encerra o job sem envio
+	recorder.fail = false
+	recorder.sent = nil
+	makeDue()
+	_, err = reports.RunDueSchedules(context.Background(), h.DB, time.Now())
+	require.NoError(t, err)
+	h.DoJSON(t, managerToken, http.MethodDelete, schedulePath, nil, http.StatusOK, nil)
+	runQueuedJob(t, reports.JobScheduledReport)
+	assert.Equal(t, models.JobSucceeded, orgJob(t, reports.JobScheduledReport, org).Status)
+	assert.Empty(t, recorder.sent)
+}
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrJobNotFound indica que o job não existe.
+	ErrJobNotFound = errors.New("background job not found")
+	// ErrInvalidJobState indica que o job não está em um estado que permite a operação.
+	ErrInvalidJobState = errors.New("background job is not in a state that allows this operation")
+)
+
+// Stat é a contagem de jobs de um tipo em um estado.
+type Stat struct {
+	Type   string                     `json:"type"`
+	Status models.BackgroundJobStatus `json:"status"`
+	Count  int64                      `json:"count"`
+}
+
+// Stats conta os jobs por tipo e estado.
+func Stats(ctx context.Context, db *gorm.DB) ([]Stat, error) {
+	stats := []Stat{}
+	err := db.WithContext(ctx).Model(&models.BackgroundJob{}).
+		Select("type, status, COUNT(*) AS count").Group("type, status").Order("type, status").Scan(&stats).Error
+	return stats, err
+}
+
+// OldestDue retorna o horário previsto do job na fila há mais tempo vencido em now (nil se não houver), para
+// medir o atraso da fila.
+func OldestDue(ctx context.Context, db *gorm.DB, now time.Time) (*time.Time, error) {
+	var job models.BackgroundJob
+	err := db.WithContext(ctx).Select("run_at").Where("status = ? AND run_at <= ?", models.JobQueued, now).
+		Order("run_at").Take(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job.RunAt, nil
+}
+
+// Retry devolve à fila um job morto ou cancelado, com as tentativas zeradas.
+func Retry(ctx context.Context, db *gorm.DB, id uuid.UUID, now time.Time) (*models.BackgroundJob, error) {
+	return transition(ctx, db, id, []models.BackgroundJobStatus{models.JobDead, models.JobCanceled}, map[string]interface{}{
+		"status": models.JobQueued, "attempts": 0, "run_at": now, "completed_at": nil,
+	})
+}
+
+// Cancel cancela um job na fila, inclusive um que aguarda nova tentativa.
+func Cancel(ctx context.Context, db *gorm.DB, id uuid.UUID, now time.Time) (*models.BackgroundJob, error) {
+	return transition(ctx, db, id, []models.BackgroundJobStatus{models.JobQueued}, map[string]interface{}{
+		"status": models.JobCanceled, "completed_at": now,
+	})
+}
+
+// transition aplica updates ao job se ele estiver em um dos estados from. A condição no UPDATE evita
+// disputar o job com um worker que o reservou entre a leitura e a escrita.
+func transition(ctx context.Context, db *gorm.DB, id uuid.UUID, from []models.BackgroundJobStatus, updates map[string]interface{}) (*models.BackgroundJob, error) {
+	db = db.WithContext(ctx)
+	res := db.Model(&models.BackgroundJob{}).Where("id = ? AND status IN ?", id, from).Updates(updates)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	var job models.BackgroundJob
+	if err := db.First(&job, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	if res.RowsAffected == 0 {
+		return &job, ErrInvalidJobState
+	}
+	return &job, nil
+}
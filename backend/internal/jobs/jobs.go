@@ -0,0 +1,169 @@
+// Package jobs implementa a fila durável de tarefas em segundo plano, persistida na tabela background_jobs.
+// Os jobs são reservados com SELECT ... FOR UPDATE SKIP LOCKED, de modo que várias instâncias (API ou worker
+// dedicado) processam a mesma fila sem executar um job duas vezes; falhas são repetidas com backoff
+// exponencial até MaxAttempts.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultMaxAttempts é o número de tentativas de um job quando Enqueue não recebe WithMaxAttempts.
+const DefaultMaxAttempts = 5
+
+// Intervalos da retentativa: o primeiro após baseBackoff, dobrando a cada falha até maxBackoff.
+const (
+	baseBackoff = 30 * time.Second
+	maxBackoff  = time.Hour
+)
+
+// ErrNoDatabase indica que o job não pôde ser enfileirado porque o banco não foi inicializado.
+var ErrNoDatabase = errors.New("jobs: database is not initialized")
+
+// Handler executa um job. Um erro agenda uma nova tentativa, exceto os marcados com Permanent.
+type Handler func(ctx context.Context, job *models.BackgroundJob) error
+
+var (
+	handlersMu sync.RWMutex
+	handlers   = map[string]Handler{}
+
+	// wake acorda os workers deste processo quando um job é enfileirado, sem esperar o próximo ciclo.
+	wake = make(chan struct{}, 1)
+)
+
+// Register associa o handler ao tipo de job. Deve ser chamado na inicialização dos processos que executam
+// jobs; enfileirar não exige o registro.
+func Register(jobType string, handler Handler) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	handlers[jobType] = handler
+}
+
+func lookup(jobType string) Handler {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+	return handlers[jobType]
+}
+
+// RegisteredTypes retorna os tipos de job com handler registrado neste processo, em ordem alfabética.
+func RegisteredTypes() []string {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+	types := make([]string, 0, len(handlers))
+	for jobType := range handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Option ajusta um job enfileirado.
+type Option func(*models.BackgroundJob)
+
+// WithOrganization associa o job a uma organização (filtro da listagem de jobs).
+func WithOrganization(orgID uuid.UUID) Option {
+	return func(j *models.BackgroundJob) {
+		if orgID != uuid.Nil {
+			j.OrganizationID = &orgID
+		}
+	}
+}
+
+// WithMaxAttempts define o número máximo de tentativas (mínimo 1).
+func WithMaxAttempts(n int) Option {
+	return func(j *models.BackgroundJob) {
+		if n < 1 {
+			n = 1
+		}
+		j.MaxAttempts = n
+	}
+}
+
+// WithRunAt adia a primeira execução do job.
+func WithRunAt(t time.Time) Option {
+	return func(j *models.BackgroundJob) { j.RunAt = t }
+}
+
+// Enqueue grava um job com o payload serializado em JSON. Passe a transação da alteração que originou o job
+// para que ele só seja executado se ela for confirmada.
+func Enqueue(ctx context.Context, db *gorm.DB, jobType string, payload interface{}, opts ...Option) (*models.BackgroundJob, error) {
+	if db == nil {
+		return nil, ErrNoDatabase
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("jobs: failed to encode payload for %s: %w", jobType, err)
+	}
+	job := &models.BackgroundJob{
+		Type:        jobType,
+		Payload:     string(data),
+		Status:      models.JobQueued,
+		RunAt:       time.Now(),
+		MaxAttempts: DefaultMaxAttempts,
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	if err := db.WithContext(ctx).Create(job).Error; err != nil {
+		return nil, fmt.Errorf("jobs: failed to enqueue %s: %w", jobType, err)
+	}
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// DecodePayload decodifica o payload do job em v.
+func DecodePayload(job *models.BackgroundJob, v interface{}) error {
+	if err := json.Unmarshal([]byte(job.Payload), v); err != nil {
+		return Permanent(fmt.Errorf("invalid payload for job type %s: %w", job.Type, err))
+	}
+	return nil
+}
+
+// permanentError é uma falha que não deve ser repetida.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marca o erro como definitivo: o job vai direto para o estado dead, sem novas tentativas.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent indica se o erro foi marcado com Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// Backoff é o intervalo até a próxima tentativa depois de attempts tentativas falhas.
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := baseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func mockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(0))
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 4*time.Minute, Backoff(4))
+	assert.Equal(t, 32*time.Minute, Backoff(7))
+	assert.Equal(t, time.Hour, Backoff(8))
+	assert.Equal(t, time.Hour, Backoff(50))
+}
+
+func TestPermanent(t *testing.T) {
+	assert.NoError(t, Permanent(nil))
+	base := errors.New("boom")
+	err := Permanent(base)
+	assert.True(t, IsPermanent(err))
+	assert.ErrorIs(t, err, base)
+	assert.True(t, IsPermanent(errors.Join(errors.New("context"), err)))
+	assert.False(t, IsPermanent(base))
+}
+
+func TestExecute(t *testing.T) {
+	job := &models.BackgroundJob{ID: uuid.New(), Type: "test.unregistered", Payload: `{}`}
+	err := execute(context.Background(), job)
+	assert.True(t, IsPermanent(err), "tipo sem handler não deve ser repetido")
+
+	Register("test.panic", func(ctx context.Context, job *models.BackgroundJob) error { panic("kaboom") })
+	job.Type = "test.panic"
+	err = execute(context.Background(), job)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kaboom")
+	assert.False(t, IsPermanent(err))
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+	var got payload
+	Register("test.decode", func(ctx context.Context, job *models.BackgroundJob) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return DecodePayload(job, &got)
+	})
+	job.Type, job.Payload = "test.decode", `{"name":"ok"}`
+	require.NoError(t, execute(context.Background(), job))
+	assert.Equal(t, "ok", got.Name)
+
+	job.Payload = `not json`
+	assert.True(t, IsPermanent(execute(context.Background(), job)))
+	assert.Contains(t, RegisteredTypes(), "test.decode")
+}
+
+func TestEnqueueWithoutDatabase(t *testing.T) {
+	_, err := Enqueue(context.Background(), nil, "test.any", nil)
+	assert.ErrorIs(t, err, ErrNoDatabase)
+}
+
+func TestEnqueueOptions(t *testing.T) {
+	db, mock := mockDB(t)
+	orgID := uuid.New()
+	runAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectExec(`INSERT INTO "background_jobs"`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	job, err := Enqueue(context.Background(), db, "test.enqueue", map[string]int{"n": 1},
+		WithOrganization(orgID), WithMaxAttempts(0), WithRunAt(runAt))
+	require.NoError(t, err)
+	assert.Equal(t, `{"n":1}`, job.Payload)
+	assert.Equal(t, models.JobQueued, job.Status)
+	assert.Equal(t, &orgID, job.OrganizationID)
+	assert.Equal(t, 1, job.MaxAttempts)
+	assert.Equal(t, runAt, job.RunAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestProcessRecordsOutcome confere o estado gravado para sucesso, nova tentativa e tentativas esgotadas, sempre
+// restrito ao job ainda reservado pelo worker.
+func TestProcessRecordsOutcome(t *testing.T) {
+	calls := 0
+	Register("test.flaky", func(ctx context.Context, job *models.BackgroundJob) error {
+		calls++
+		if job.Payload == `"fail"` {
+			return errors.New("temporary")
+		}
+		return nil
+	})
+	cases := []struct {
+		name     string
+		payload  string
+		attempts int
+		status   models.BackgroundJobStatus
+	}{
+		{"succeeded", `"ok"`, 1, models.JobSucceeded},
+		{"retried", `"fail"`, 1, models.JobQueued},
+		{"dead", `"fail"`, 3, models.JobDead},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := mockDB(t)
+			job := &models.BackgroundJob{ID: uuid.New(), Type: "test.flaky", Payload: tc.payload, Attempts: tc.attempts, MaxAttempts: 3}
+			mock.ExpectExec(`UPDATE "background_jobs" SET .*"status"=\$\d.* WHERE id = \$\d+ AND status = \$\d+ AND locked_by = \$\d+`).
+				WithArgs(anyArgs(tc.status)...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Process(context.Background(), db, "worker-1", job)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+	assert.Equal(t, 3, calls)
+}
+
+// anyArgs aceita os valores do UPDATE de Process exigindo o status gravado e o filtro pelo worker.
+func anyArgs(status models.BackgroundJobStatus) []driver.Value {
+	args := []driver.Value{}
+	// Colunas em ordem alfabética: o status é sempre a quinta (completed_at ou run_at, last_error, locked_at, locked_by)
+	n := 6
+	for i := 0; i < n; i++ {
+		if i == 4 {
+			args = append(args, string(status))
+			continue
+		}
+		args = append(args, sqlmock.AnyArg())
+	}
+	return append(args, sqlmock.AnyArg(), string(models.JobRunning), "worker-1")
+}
@@ -0,0 +1,241 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// JobTimeout é o tempo máximo de uma execução.
+	JobTimeout = 5 * time.Minute
+	// LeaseTimeout é o tempo após o qual um job em execução é considerado abandonado (worker encerrado no meio
+	// da execução) e volta para a fila.
+	LeaseTimeout = 15 * time.Minute
+	// FinishedRetention é o tempo que os jobs concluídos ou cancelados ficam na tabela; os mortos são mantidos
+	// para inspeção.
+	FinishedRetention = 7 * 24 * time.Hour
+
+	maintenanceInterval = time.Minute
+)
+
+// Resultados de uma execução, para a métrica JobsProcessed.
+const (
+	resultSucceeded = "succeeded"
+	resultRetried   = "retried"
+	resultDead      = "dead"
+)
+
+// JobsProcessed conta as execuções de jobs por tipo e resultado.
+var JobsProcessed = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "phoenixgrc_jobs_processed_total",
+		Help: "Total number of background job executions, by type and result (succeeded, retried, dead).",
+	},
+	[]string{"type", "result"},
+)
+
+// Pool executa os jobs da fila com Concurrency workers.
+type Pool struct {
+	DB           *gorm.DB
+	Concurrency  int
+	PollInterval time.Duration
+	WorkerID     string
+
+	wg sync.WaitGroup
+}
+
+// NewPool cria um pool identificado pelo host e pelo PID do processo.
+func NewPool(db *gorm.DB, concurrency int, pollInterval time.Duration) *Pool {
+	host, _ := os.Hostname()
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	return &Pool{DB: db, Concurrency: concurrency, PollInterval: pollInterval, WorkerID: fmt.Sprintf("%s:%d", host, os.Getpid())}
+}
+
+// Start inicia os workers e a manutenção da fila (jobs abandonados e limpeza) até o contexto ser cancelado.
+func (p *Pool) Start(ctx context.Context) {
+	for i := 0; i < p.Concurrency; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.work(ctx)
+		}()
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(maintenanceInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if n, err := RecoverStale(ctx, p.DB, now); err != nil {
+					phxlog.L.Error("Failed to recover stale background jobs", zap.Error(err))
+				} else if n > 0 {
+					phxlog.L.Warn("Stale background jobs returned to the queue", zap.Int64("count", n))
+				}
+				if err := PruneFinished(ctx, p.DB, now.Add(-FinishedRetention)); err != nil {
+					phxlog.L.Error("Failed to prune finished background jobs", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Wait bloqueia até os workers terminarem os jobs em andamento após o cancelamento do contexto de Start.
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+func (p *Pool) work(ctx context.Context) {
+	ticker := time.NewTicker(p.PollInterval)
+	defer ticker.Stop()
+	for {
+		// Esvazia a fila antes de esperar o próximo ciclo
+		for ctx.Err() == nil {
+			job, err := claimNext(ctx, p.DB, p.WorkerID, time.Now())
+			if err != nil {
+				if ctx.Err() == nil {
+					phxlog.L.Error("Failed to claim background job", zap.Error(err))
+				}
+				break
+			}
+			if job == nil {
+				break
+			}
+			// A execução não herda o cancelamento do pool: o job em andamento termina (ou atinge JobTimeout)
+			Process(context.Background(), p.DB, p.WorkerID, job)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// claimNext reserva o próximo job vencido em now para o worker, contando a tentativa. Retorna nil se a fila
+// estiver vazia.
+func claimNext(ctx context.Context, db *gorm.DB, workerID string, now time.Time) (*models.BackgroundJob, error) {
+	var job models.BackgroundJob
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ?", models.JobQueued, now).
+			Order("run_at").Take(&job).Error; err != nil {
+			return err
+		}
+		job.Status, job.LockedBy, job.LockedAt = models.JobRunning, workerID, &now
+		job.Attempts++
+		return tx.Model(&models.BackgroundJob{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+			"status": job.Status, "locked_by": workerID, "locked_at": now, "attempts": job.Attempts,
+		}).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Process executa o job reservado pelo worker e grava o resultado: concluído, nova tentativa com backoff ou
+// morto (tentativas esgotadas, erro permanente ou tipo sem handler).
+func Process(ctx context.Context, db *gorm.DB, workerID string, job *models.BackgroundJob) {
+	log := phxlog.L.With(zap.String("jobID", job.ID.String()), zap.String("type", job.Type), zap.Int("attempt", job.Attempts))
+	err := execute(ctx, job)
+	now := time.Now()
+	updates := map[string]interface{}{"locked_by": "", "locked_at": nil}
+	result := resultSucceeded
+	switch {
+	case err == nil:
+		updates["status"], updates["completed_at"], updates["last_error"] = models.JobSucceeded, now, ""
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		result = resultDead
+		updates["status"], updates["completed_at"], updates["last_error"] = models.JobDead, now, err.Error()
+		log.Error("Background job failed permanently", zap.Error(err))
+	default:
+		result = resultRetried
+		next := now.Add(Backoff(job.Attempts))
+		updates["status"], updates["run_at"], updates["last_error"] = models.JobQueued, next, err.Error()
+		log.Warn("Background job failed, will retry", zap.Time("runAt", next), zap.Error(err))
+	}
+	JobsProcessed.WithLabelValues(job.Type, result).Inc()
+
+	// Só grava se o job ainda for deste worker (não foi devolvido à fila por lease expirado)
+	res := db.WithContext(ctx).Model(&models.BackgroundJob{}).
+		Where("id = ? AND status = ? AND locked_by = ?", job.ID, models.JobRunning, workerID).Updates(updates)
+	if res.Error != nil {
+		log.Error("Failed to record background job result", zap.Error(res.Error))
+	} else if res.RowsAffected == 0 {
+		log.Warn("Background job result discarded: the job is no longer held by this worker")
+	}
+}
+
+// execute roda o handler do job com timeout, convertendo panics em erro.
+func execute(ctx context.Context, job *models.BackgroundJob) (err error) {
+	handler := lookup(job.Type)
+	if handler == nil {
+		return Permanent(fmt.Errorf("no handler registered for job type %s", job.Type))
+	}
+	ctx, cancel := context.WithTimeout(ctx, JobTimeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// RecoverStale devolve à fila os jobs em execução há mais de LeaseTimeout (ou os marca como mortos, se as
+// tentativas se esgotaram). Retorna quantos jobs foram recuperados.
+func RecoverStale(ctx context.Context, db *gorm.DB, now time.Time) (int64, error) {
+	cutoff := now.Add(-LeaseTimeout)
+	var recovered int64
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stale := func() *gorm.DB {
+			return tx.Model(&models.BackgroundJob{}).Where("status = ? AND locked_at < ?", models.JobRunning, cutoff)
+		}
+		res := stale().Where("attempts >= max_attempts").Updates(map[string]interface{}{
+			"status": models.JobDead, "completed_at": now, "locked_by": "", "locked_at": nil, "last_error": "worker lease expired",
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		recovered = res.RowsAffected
+		res = stale().Where("attempts < max_attempts").Updates(map[string]interface{}{
+			"status": models.JobQueued, "run_at": now, "locked_by": "", "locked_at": nil, "last_error": "worker lease expired",
+		})
+		recovered += res.RowsAffected
+		return res.Error
+	})
+	return recovered, err
+}
+
+// PruneFinished remove os jobs concluídos ou cancelados antes de before.
+func PruneFinished(ctx context.Context, db *gorm.DB, before time.Time) error {
+	return db.WithContext(ctx).
+		Where("status IN ? AND updated_at < ?", []models.BackgroundJobStatus{models.JobSucceeded, models.JobCanceled}, before).
+		Delete(&models.BackgroundJob{}).Error
+}
@@ -0,0 +1,191 @@
+// Package jsonlexport gera a exportação das avaliações e dos metadados de evidências de um framework em
+// JSON Lines (um objeto JSON por linha), para ingestão por ferramentas externas de análise de GRC ou
+// sistemas de arquivamento.
+//
+// O esquema é estável: todo registro tem schema_version e record_type. Dentro da mesma versão do esquema,
+// campos só são acrescentados, nunca removidos, renomeados ou mudados de tipo; consumidores devem ignorar
+// campos desconhecidos. Mudanças incompatíveis incrementam SchemaVersion.
+package jsonlexport
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SchemaVersion é a versão do esquema dos registros exportados.
+const SchemaVersion = 1
+
+// ContentType é o tipo de mídia da exportação.
+const ContentType = "application/x-ndjson"
+
+// Tipos de registro. O primeiro registro é sempre o cabeçalho (RecordExport); cada avaliação vem seguida
+// das suas evidências.
+const (
+	RecordExport     = "export"
+	RecordAssessment = "assessment"
+	RecordEvidence   = "evidence"
+)
+
+// Origens das evidências.
+const (
+	EvidenceSourceAssessment      = "assessment"       // Evidência registrada na própria avaliação (evidence_url)
+	EvidenceSourceEvidenceRequest = "evidence_request" // Arquivo enviado pelo portal de evidências
+)
+
+// Envelope são os campos comuns a todos os registros.
+type Envelope struct {
+	SchemaVersion int    `json:"schema_version"`
+	RecordType    string `json:"record_type"`
+}
+
+// ExportRecord é o cabeçalho da exportação.
+type ExportRecord struct {
+	Envelope
+	GeneratedAt      time.Time `json:"generated_at"`
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	FrameworkID      uuid.UUID `json:"framework_id"`
+	FrameworkName    string    `json:"framework_name"`
+	FrameworkVersion string    `json:"framework_version"`
+	TotalControls    int       `json:"total_controls"`
+}
+
+// AssessmentRecord é a avaliação contínua de um controle. Controles sem avaliação são exportados com
+// status vazio e assessment_id nulo, para que o consumidor veja o framework completo.
+type AssessmentRecord struct {
+	Envelope
+	AssessmentID          *uuid.UUID `json:"assessment_id"`
+	ControlUUID           uuid.UUID  `json:"control_uuid"`
+	ControlID             string     `json:"control_id"`
+	ControlFamily         string     `json:"control_family"`
+	ControlDescription    string     `json:"control_description"`
+	Status                string     `json:"status"`
+	Score                 *int       `json:"score"`
+	AssessmentDate        *time.Time `json:"assessment_date"`
+	Comments              *string    `json:"comments"`
+	PolicyID              *uuid.UUID `json:"policy_id"`
+	AssignedToID          *uuid.UUID `json:"assigned_to_id"`
+	DueDate               *time.Time `json:"due_date"`
+	AssignmentCompletedAt *time.Time `json:"assignment_completed_at"`
+	CreatedAt             *time.Time `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+}
+
+// EvidenceRecord são os metadados de uma evidência; o conteúdo dos arquivos não é exportado.
+type EvidenceRecord struct {
+	Envelope
+	Source            string     `json:"source"`
+	ControlUUID       uuid.UUID  `json:"control_uuid"`
+	ControlID         string     `json:"control_id"`
+	AssessmentID      *uuid.UUID `json:"assessment_id"`
+	EvidenceRequestID *uuid.UUID `json:"evidence_request_id"`
+	SubmissionID      *uuid.UUID `json:"submission_id"`
+	RequestStatus     string     `json:"request_status"` // Status da solicitação de evidência; vazio para evidências da avaliação
+	URL               string     `json:"url"`            // evidence_url da avaliação
+	ObjectName        string     `json:"object_name"`    // Chave no provider de armazenamento
+	FileName          string     `json:"file_name"`
+	SizeBytes         *int64     `json:"size_bytes"`
+	ScanStatus        string     `json:"scan_status"`
+	RecordedAt        *time.Time `json:"recorded_at"`
+}
+
+// Writer grava os registros, um por linha.
+type Writer struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+// NewWriter cria um Writer sobre w. Chame Flush ao terminar.
+func NewWriter(w io.Writer) *Writer {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &Writer{buf: buf, enc: enc}
+}
+
+// Flush grava os registros pendentes.
+func (w *Writer) Flush() error {
+	return w.buf.Flush()
+}
+
+// WriteHeader grava o cabeçalho da exportação.
+func (w *Writer) WriteHeader(r ExportRecord) error {
+	r.Envelope = Envelope{SchemaVersion: SchemaVersion, RecordType: RecordExport}
+	return w.enc.Encode(r)
+}
+
+// WriteControl grava a avaliação do controle (nil se não avaliado) seguida das evidências da avaliação e
+// das submissões das solicitações de evidência vinculadas ao controle.
+func (w *Writer) WriteControl(control models.AuditControl, assessment *models.AuditAssessment, requests []models.EvidenceRequest) error {
+	record := AssessmentRecord{
+		Envelope:           Envelope{SchemaVersion: SchemaVersion, RecordType: RecordAssessment},
+		ControlUUID:        control.ID,
+		ControlID:          control.ControlID,
+		ControlFamily:      control.Family,
+		ControlDescription: control.Description,
+	}
+	var assessmentID *uuid.UUID
+	if assessment != nil {
+		id, createdAt, updatedAt := assessment.ID, assessment.CreatedAt, assessment.UpdatedAt
+		assessmentID = &id
+		record.AssessmentID = assessmentID
+		record.Status = string(assessment.Status)
+		record.Score = assessment.Score
+		record.AssessmentDate = assessment.AssessmentDate
+		record.Comments = assessment.Comments
+		record.PolicyID = assessment.PolicyID
+		record.AssignedToID = assessment.AssignedToID
+		record.DueDate = assessment.DueDate
+		record.AssignmentCompletedAt = assessment.AssignmentCompletedAt
+		record.CreatedAt = &createdAt
+		record.UpdatedAt = &updatedAt
+	}
+	if err := w.enc.Encode(record); err != nil {
+		return err
+	}
+
+	if assessment != nil && assessment.EvidenceURL != "" {
+		if err := w.enc.Encode(EvidenceRecord{
+			Envelope:     Envelope{SchemaVersion: SchemaVersion, RecordType: RecordEvidence},
+			Source:       EvidenceSourceAssessment,
+			ControlUUID:  control.ID,
+			ControlID:    control.ControlID,
+			AssessmentID: assessmentID,
+			URL:          assessment.EvidenceURL,
+			ScanStatus:   assessment.EvidenceScanStatus,
+			RecordedAt:   assessment.EvidenceScannedAt,
+		}); err != nil {
+			return err
+		}
+	}
+	for i := range requests {
+		request := &requests[i]
+		for j := range request.Submissions {
+			submission := &request.Submissions[j]
+			if err := w.enc.Encode(EvidenceRecord{
+				Envelope:          Envelope{SchemaVersion: SchemaVersion, RecordType: RecordEvidence},
+				Source:            EvidenceSourceEvidenceRequest,
+				ControlUUID:       control.ID,
+				ControlID:         control.ControlID,
+				AssessmentID:      assessmentID,
+				EvidenceRequestID: &request.ID,
+				SubmissionID:      &submission.ID,
+				RequestStatus:     string(request.Status),
+				ObjectName:        submission.ObjectName,
+				FileName:          submission.FileName,
+				SizeBytes:         &submission.SizeBytes,
+				ScanStatus:        submission.ScanStatus,
+				RecordedAt:        &submission.CreatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package jsonlexport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "line: %s", scanner.Text())
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func TestWriterEmitsOneVersionedRecordPerLine(t *testing.T) {
+	score := 100
+	control := models.AuditControl{ID: uuid.New(), ControlID: "A.5.15", Family: "Organizational", Description: "Access control"}
+	assessment := &models.AuditAssessment{
+		ID:                 uuid.New(),
+		AuditControlID:     control.ID,
+		Status:             models.ControlStatusConformant,
+		Score:              &score,
+		EvidenceURL:        "https://storage.example/evidence.pdf",
+		EvidenceScanStatus: "clean",
+	}
+	request := models.EvidenceRequest{
+		ID:     uuid.New(),
+		Status: models.EvidenceRequestAccepted,
+		Submissions: []models.EvidenceSubmission{
+			{ID: uuid.New(), ObjectName: "org/evidence/log.csv", FileName: "log.csv", SizeBytes: 42, CreatedAt: time.Now()},
+		},
+	}
+	unassessed := models.AuditControl{ID: uuid.New(), ControlID: "A.5.16"}
+
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	require.NoError(t, w.WriteHeader(ExportRecord{FrameworkName: "ISO 27001", TotalControls: 2}))
+	require.NoError(t, w.WriteControl(control, assessment, []models.EvidenceRequest{request}))
+	require.NoError(t, w.WriteControl(unassessed, nil, nil))
+	require.NoError(t, w.Flush())
+
+	records := decodeLines(t, out.Bytes())
+	require.Len(t, records, 5)
+	for _, r := range records {
+		assert.EqualValues(t, SchemaVersion, r["schema_version"])
+	}
+	assert.Equal(t, RecordExport, records[0]["record_type"])
+	assert.Equal(t, "ISO 27001", records[0]["framework_name"])
+
+	assert.Equal(t, RecordAssessment, records[1]["record_type"])
+	assert.Equal(t, "A.5.15", records[1]["control_id"])
+	assert.Equal(t, string(models.ControlStatusConformant), records[1]["status"])
+	assert.EqualValues(t, 100, records[1]["score"])
+
+	assert.Equal(t, RecordEvidence, records[2]["record_type"])
+	assert.Equal(t, EvidenceSourceAssessment, records[2]["source"])
+	assert.Equal(t, assessment.ID.String(), records[2]["assessment_id"])
+	assert.Equal(t, "https://storage.example/evidence.pdf", records[2]["url"])
+
+	assert.Equal(t, EvidenceSourceEvidenceRequest, records[3]["source"])
+	assert.Equal(t, request.ID.String(), records[3]["evidence_request_id"])
+	assert.Equal(t, "log.csv", records[3]["file_name"])
+	assert.EqualValues(t, 42, records[3]["size_bytes"])
+
+	// Controle não avaliado: campos presentes, com valores nulos
+	assert.Equal(t, "A.5.16", records[4]["control_id"])
+	assert.Contains(t, records[4], "assessment_id")
+	assert.Nil(t, records[4]["assessment_id"])
+	assert.Equal(t, "", records[4]["status"])
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"time"
+
+	"phoenixgrc/backend/internal/apiusage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// APIUsage contabiliza as chamadas autenticadas por organização, credencial e rota.
+// Deve ser registrado depois do middleware de autenticação, que define organizationID.
+func APIUsage() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		var organizationID uuid.UUID
+		switch orgID := c.Value("organizationID").(type) {
+		case uuid.UUID:
+			organizationID = orgID
+		case uuid.NullUUID:
+			organizationID = orgID.UUID
+		}
+		if organizationID == uuid.Nil {
+			return
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		apiusage.Default.Record(organizationID, c.GetString(apiusage.CredentialContextKey), c.Request.Method, route, c.Writer.Status(), time.Since(start), start)
+	}
+}
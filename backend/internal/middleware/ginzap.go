@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -32,6 +33,13 @@ func GinZap(logger *zap.Logger, timeFormat string, utc bool) gin.HandlerFunc {
 			zap.String("user_agent", c.Request.UserAgent()),
 			zap.Duration("latency", latency),
 		}
+		if requestID := c.GetString("requestID"); requestID != "" {
+			fields = append(fields, zap.String("request_id", requestID))
+		}
+		// Liga a linha do log ao trace da requisição (ver Tracing)
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.IsValid() {
+			fields = append(fields, zap.String("trace_id", spanContext.TraceID().String()))
+		}
 
 		if timeFormat != "" {
 			fields = append(fields, zap.String("time", end.Format(timeFormat)))
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/idempotency"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader é o header com a chave escolhida pelo cliente (ex: um UUID por operação).
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marca as respostas reenviadas de uma requisição anterior com a mesma chave.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyMaxKeyLength = 255
+	// idempotencyMaxBodyBytes limita o corpo lido para calcular a impressão da requisição.
+	idempotencyMaxBodyBytes = 32 << 20
+)
+
+// idempotencyWriter retém uma cópia da resposta para gravá-la com a chave.
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency atende o header Idempotency-Key: a primeira requisição com a chave é executada e sua resposta é
+// gravada; as repetições com o mesmo corpo recebem a resposta gravada (com Idempotent-Replayed: true) sem
+// executar a operação de novo. Reusar a chave em outra requisição responde 422, e repetir enquanto a primeira
+// ainda está em andamento responde 409. Respostas 5xx e 429 não são gravadas, para que o cliente possa repetir.
+// Sem o header, a requisição segue normalmente. Deve ser registrado depois do middleware de autenticação.
+func Idempotency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > idempotencyMaxKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must have at most 255 characters"})
+			return
+		}
+		userID, ok := c.Value("userID").(uuid.UUID)
+		if !ok || userID == uuid.Nil {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(io.LimitReader(c.Request.Body, idempotencyMaxBodyBytes+1))
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+				return
+			}
+			if len(body) > idempotencyMaxBodyBytes {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body is too large for an idempotent request"})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		db := database.GetDB().WithContext(c.Request.Context())
+		record, replay, err := idempotency.Begin(db, idempotency.Request{
+			UserID: userID,
+			Key:    key,
+			Method: c.Request.Method,
+			Path:   c.Request.URL.Path,
+			Hash:   idempotency.Fingerprint(c.Request.Method, c.Request.URL.RequestURI(), c.GetHeader("Content-Type"), body),
+		}, config.Cfg.IdempotencyKeyTTL, time.Now())
+		switch {
+		case errors.Is(err, idempotency.ErrKeyMismatch):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request"})
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still being processed"})
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check Idempotency-Key: " + err.Error()})
+			return
+		}
+		if replay {
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(record.StatusCode, record.ResponseContentType, record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		completed := false
+		// Também libera a chave se o handler entrar em pânico
+		defer func() {
+			c.Writer = writer.ResponseWriter
+			if !completed {
+				if err := idempotency.Release(db, record); err != nil {
+					phxlog.L.Warn("Failed to release idempotency key", zap.String("key", key), zap.Error(err))
+				}
+			}
+		}()
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			return
+		}
+		if err := idempotency.Complete(db, record, status, writer.Header().Get("Content-Type"), writer.body.Bytes(), time.Now()); err != nil {
+			phxlog.L.Warn("Failed to store idempotent response", zap.String("key", key), zap.Error(err))
+			return
+		}
+		completed = true
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"phoenixgrc/backend/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// localizedErrorWriter retém o corpo das respostas JSON de erro (status >= 400) para que a
+// mensagem seja traduzida antes do envio. As demais respostas (incluindo streams e downloads)
+// são escritas diretamente.
+type localizedErrorWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *localizedErrorWriter) buffering() bool {
+	return w.Status() >= 400 && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+}
+
+func (w *localizedErrorWriter) Write(data []byte) (int, error) {
+	if w.buffering() {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *localizedErrorWriter) WriteString(s string) (int, error) {
+	if w.buffering() {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// localizeErrorBody traduz o campo "error" de um corpo JSON; outros corpos são mantidos.
+func localizeErrorBody(locale i18n.Locale, body []byte) []byte {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return body
+	}
+	var message string
+	if err := json.Unmarshal(payload["error"], &message); err != nil {
+		return body
+	}
+	translated := i18n.Translate(locale, message)
+	if translated == message {
+		return body
+	}
+	encoded, err := json.Marshal(translated)
+	if err != nil {
+		return body
+	}
+	payload["error"] = encoded
+	localized, err := json.Marshal(payload)
+	if err != nil {
+		return body
+	}
+	return localized
+}
+
+// Localize negocia o idioma pelo cabeçalho Accept-Language (pt-BR, en ou es), expõe-no no
+// contexto (i18n.ContextKey) e traduz a mensagem "error" das respostas de erro.
+// Deve ser registrado antes do middleware de recuperação para cobrir as respostas de panic.
+func Localize() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(i18n.ContextKey, locale)
+		c.Header("Content-Language", string(locale))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		original := c.Writer
+		writer := &localizedErrorWriter{ResponseWriter: original}
+		c.Writer = writer
+		c.Next()
+		c.Writer = original
+
+		if writer.body.Len() > 0 {
+			original.Write(localizeErrorBody(locale, writer.body.Bytes()))
+		}
+	}
+}
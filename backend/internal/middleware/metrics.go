@@ -3,7 +3,8 @@ package middleware
 import (
 	"strconv"
 	"time"
-	"os"
+
+	"phoenixgrc/backend/pkg/config"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,10 +21,7 @@ var (
 )
 
 func init() {
-	appVersion := os.Getenv("APP_VERSION")
-	if appVersion == "" {
-		appVersion = "unknown"
-	}
+	appVersion := config.Cfg.AppVersion
 
 	HTTPRequestCounter = promauto.NewCounterVec(
 		prometheus.CounterOpts{
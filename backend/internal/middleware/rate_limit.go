@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/ratelimit"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// rateLimitMaxPeekBytes limita o corpo lido por RateLimitKeyJSONField para achar a conta.
+const rateLimitMaxPeekBytes = 64 << 10
+
+// RateLimitKey extrai da requisição a chave do limitador (ex: o IP ou a conta). Chave vazia dispensa a
+// requisição daquele limitador.
+type RateLimitKey func(c *gin.Context) string
+
+// RateLimitKeyIP usa o IP do cliente como chave.
+func RateLimitKeyIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimitKeyUser usa o usuário autenticado como chave; deve ser registrado depois do middleware de autenticação.
+func RateLimitKeyUser(c *gin.Context) string {
+	if userID, ok := c.Value("userID").(uuid.UUID); ok && userID != uuid.Nil {
+		return "user:" + userID.String()
+	}
+	return ""
+}
+
+// RateLimitKeyJSONField usa como chave o campo do corpo JSON que identifica a conta (ex: "email" no login ou
+// "user_id" na verificação do segundo fator), em minúsculas. O corpo é restaurado para o handler. Sem a conta
+// (corpo ausente, acima de 64KB, JSON inválido ou campo que não é string), a chave passa a ser o IP do cliente,
+// para que um corpo inflado não escape do limite por conta.
+func RateLimitKeyJSONField(field string) RateLimitKey {
+	return func(c *gin.Context) string {
+		invalid := "invalid:" + c.ClientIP()
+		if c.Request.Body == nil {
+			return invalid
+		}
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, rateLimitMaxPeekBytes+1))
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+		if err != nil || len(body) > rateLimitMaxPeekBytes {
+			return invalid
+		}
+		var payload map[string]interface{}
+		if json.Unmarshal(body, &payload) != nil {
+			return invalid
+		}
+		value, _ := payload[field].(string)
+		if value = strings.ToLower(strings.TrimSpace(value)); value == "" {
+			return invalid
+		}
+		return "account:" + value
+	}
+}
+
+// RateLimit limita as requisições de cada chave com o limitador informado e anuncia o limite nos headers
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset e RateLimit-Policy. Com vários limitadores na mesma
+// rota (ex: por IP e por conta), os headers mostram o mais restritivo. Acima do limite, responde 429 com
+// Retry-After. Compartilhar o limitador entre rotas soma as requisições delas. Se o armazenamento dos limites
+// falhar (ex: Redis indisponível), a requisição passa e a falha é registrada em log.
+func RateLimit(limiter *ratelimit.Limiter, key RateLimitKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		k := key(c)
+		if k == "" {
+			c.Next()
+			return
+		}
+		result, err := limiter.Take(c.Request.Context(), k)
+		if err != nil {
+			phxlog.L.Warn("Rate limit store unavailable, request allowed",
+				zap.String("policy", limiter.Name()), zap.Error(err))
+		}
+		if result.Limit > 0 {
+			setRateLimitHeaders(c, limiter.Policy(), result)
+		}
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, please try again later"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RateLimitByIP limita as requisições de cada IP de cliente com o limitador informado (ver RateLimit).
+func RateLimitByIP(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return RateLimit(limiter, RateLimitKeyIP)
+}
+
+// setRateLimitHeaders escreve os headers RateLimit-*, a menos que um limitador anterior da rota esteja mais perto
+// do limite.
+func setRateLimitHeaders(c *gin.Context, policy ratelimit.Policy, result ratelimit.Result) {
+	if current, err := strconv.Atoi(c.Writer.Header().Get("RateLimit-Remaining")); err == nil && current < result.Remaining {
+		return
+	}
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	c.Header("RateLimit-Policy", strconv.Itoa(policy.Limit)+";w="+strconv.Itoa(ceilSeconds(policy.Period)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"regexp"
+
+	"phoenixgrc/backend/internal/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader é o header que identifica a requisição nos logs e nas respostas.
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID aceita IDs de proxies e load balancers sem permitir injeção nos logs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID atribui um ID a cada requisição (reaproveitando o X-Request-ID recebido, se válido), devolve-o
+// no header da resposta e o anexa ao contexto da requisição junto com a rota, para o log de consultas lentas.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Set("requestID", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		route := c.FullPath()
+		if route != "" {
+			route = c.Request.Method + " " + route
+		}
+		c.Request = c.Request.WithContext(database.WithRequestTags(c.Request.Context(), database.RequestTags{RequestID: requestID, Route: route}))
+		c.Next()
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"phoenixgrc/backend/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing cria o span de servidor de cada requisição, continuando o trace recebido no header traceparent, e
+// o anexa ao contexto da requisição: as consultas feitas com db.WithContext(c.Request.Context()) e as chamadas
+// externas com o mesmo contexto viram spans filhos. Deve vir depois de RequestID.
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		route := c.FullPath()
+		name := c.Request.Method + " " + route
+		if route == "" {
+			name = c.Request.Method // Rota não encontrada: o caminho não entra no nome, para não explodir a cardinalidade
+		}
+		ctx, span := tracing.Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("request.id", c.GetString("requestID")),
+			))
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if userID, ok := c.Get("userID"); ok {
+			span.SetAttributes(attribute.String("enduser.id", contextIDString(userID)))
+		}
+		if orgID, ok := c.Get("organizationID"); ok {
+			span.SetAttributes(attribute.String("phoenixgrc.organization_id", contextIDString(orgID)))
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, strconv.Itoa(status))
+		}
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
+	}
+}
+
+// contextIDString converte os IDs guardados no contexto do Gin (uuid.UUID ou string).
+func contextIDString(value interface{}) string {
+	if s, ok := value.(interface{ String() string }); ok {
+		return s.String()
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccessReviewCampaignStatus representa o ciclo de vida de uma campanha de revisão de acessos.
+type AccessReviewCampaignStatus string
+
+// AccessReviewDecision é a decisão de um revisor sobre um acesso.
+type AccessReviewDecision string
+
+const (
+	AccessReviewStatusOpen   AccessReviewCampaignStatus = "open"
+	AccessReviewStatusClosed AccessReviewCampaignStatus = "closed"
+
+	AccessReviewDecisionPending AccessReviewDecision = "pending"
+	AccessReviewDecisionKeep    AccessReviewDecision = "keep"
+	AccessReviewDecisionRevoke  AccessReviewDecision = "revoke"
+
+	// AccessReviewSourcePhoenix identifica itens gerados a partir dos usuários do próprio Phoenix GRC.
+	AccessReviewSourcePhoenix = "phoenix"
+)
+
+// AccessReviewCampaign é uma revisão periódica (com prazo) dos acessos de uma organização.
+type AccessReviewCampaign struct {
+	ID             uuid.UUID                  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID                  `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Name           string                     `gorm:"size:255;not null" json:"name"`
+	Description    string                     `gorm:"type:text" json:"description"`
+	Status         AccessReviewCampaignStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	StartsAt       time.Time                  `gorm:"type:timestamptz;not null" json:"starts_at"`
+	DueDate        time.Time                  `gorm:"type:timestamptz;not null" json:"due_date"`
+	CreatedByID    uuid.UUID                  `gorm:"type:uuid" json:"created_by_id"`
+	ClosedAt       *time.Time                 `gorm:"type:timestamptz" json:"closed_at,omitempty"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+	Items          []AccessReviewItem         `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE;" json:"items,omitempty"`
+}
+
+func (arc *AccessReviewCampaign) BeforeCreate(tx *gorm.DB) (err error) {
+	if arc.ID == uuid.Nil {
+		arc.ID = uuid.New()
+	}
+	return
+}
+
+// AccessReviewItem é um acesso (conta + papel/permissão) a ser revisado dentro de uma campanha.
+// Itens do Phoenix são um snapshot de usuários/papéis no momento da criação da campanha;
+// itens de outros sistemas vêm de importação CSV e não possuem UserID.
+type AccessReviewItem struct {
+	ID                uuid.UUID            `gorm:"type:uuid;primary_key;" json:"id"`
+	CampaignID        uuid.UUID            `gorm:"type:uuid;not null;index" json:"campaign_id"`
+	SourceSystem      string               `gorm:"size:100;not null;default:'phoenix'" json:"source_system"`
+	UserID            *uuid.UUID           `gorm:"type:uuid;index" json:"user_id,omitempty"`
+	AccountIdentifier string               `gorm:"size:255;not null" json:"account_identifier"` // email ou username no sistema de origem
+	AccountName       string               `gorm:"size:255" json:"account_name"`
+	Entitlement       string               `gorm:"size:255" json:"entitlement"` // papel/grupo/permissão no momento do snapshot
+	ReviewerID        *uuid.UUID           `gorm:"type:uuid;index" json:"reviewer_id,omitempty"`
+	Decision          AccessReviewDecision `gorm:"type:varchar(20);not null;default:'pending';index" json:"decision"`
+	Justification     string               `gorm:"type:text" json:"justification"`
+	DecidedByID       *uuid.UUID           `gorm:"type:uuid" json:"decided_by_id,omitempty"`
+	DecidedAt         *time.Time           `gorm:"type:timestamptz" json:"decided_at,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+}
+
+func (ari *AccessReviewItem) BeforeCreate(tx *gorm.DB) (err error) {
+	if ari.ID == uuid.Nil {
+		ari.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdminActionType identifica uma ação administrativa destrutiva sujeita à regra das duas pessoas.
+type AdminActionType string
+
+const (
+	AdminActionFrameworkDelete     AdminActionType = "framework.delete"  // Framework com avaliações
+	AdminActionOrgSecretRotate     AdminActionType = "org_secret.rotate" // Troca do valor de um segredo do cofre
+	AdminActionOrgSecretDelete     AdminActionType = "org_secret.delete"
+	AdminActionOrgSecretBulkRevoke AdminActionType = "org_secret.bulk_revoke" // Revogação de várias credenciais (higiene periódica)
+)
+
+// AdminActionStatus representa o ciclo de vida de uma solicitação de ação administrativa.
+type AdminActionStatus string
+
+const (
+	AdminActionPending   AdminActionStatus = "pending"  // Aguardando o segundo administrador
+	AdminActionExecuted  AdminActionStatus = "executed" // Aprovada e executada
+	AdminActionFailed    AdminActionStatus = "failed"   // Aprovada, mas a execução falhou (ver FailureReason)
+	AdminActionRejected  AdminActionStatus = "rejected"
+	AdminActionCancelled AdminActionStatus = "cancelled" // Cancelada pelo solicitante
+)
+
+// AdminActionRequest é uma ação administrativa destrutiva que só é executada depois de aprovada por um
+// segundo administrador da organização (regra das duas pessoas), via ApprovalWorkflow. A ação é executada
+// no momento da aprovação.
+type AdminActionRequest struct {
+	ID             uuid.UUID       `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID       `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Action         AdminActionType `gorm:"type:varchar(50);not null" json:"action"`
+	TargetID       uuid.UUID       `gorm:"type:uuid;not null;index" json:"target_id"`
+	TargetLabel    string          `gorm:"size:255" json:"target_label"` // Nome do alvo no momento da solicitação
+	// Dados necessários à execução (ex: o novo valor de um segredo, criptografado); nunca expostos pela API
+	Payload       *string           `gorm:"type:text" json:"-"`
+	Status        AdminActionStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	RequestedByID uuid.UUID         `gorm:"type:uuid;not null" json:"requested_by_id"`
+	ApproverID    uuid.UUID         `gorm:"type:uuid;not null;index" json:"approver_id"`
+	DecidedAt     *time.Time        `gorm:"type:timestamptz" json:"decided_at,omitempty"`
+	ExecutedAt    *time.Time        `gorm:"type:timestamptz" json:"executed_at,omitempty"`
+	FailureReason string            `gorm:"type:text" json:"failure_reason,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (r *AdminActionRequest) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKeyScope limita as rotas acessíveis por uma chave de API. O escopo de escrita inclui a leitura.
+type APIKeyScope string
+
+const (
+	ScopeRisksRead            APIKeyScope = "risks:read"
+	ScopeRisksWrite           APIKeyScope = "risks:write"
+	ScopeAuditRead            APIKeyScope = "audit:read" // Frameworks, avaliações e evidências (/audit)
+	ScopeAuditWrite           APIKeyScope = "audit:write"
+	ScopeVulnerabilitiesRead  APIKeyScope = "vulnerabilities:read"
+	ScopeVulnerabilitiesWrite APIKeyScope = "vulnerabilities:write"
+	ScopeAssetsRead           APIKeyScope = "assets:read"
+	ScopeAssetsWrite          APIKeyScope = "assets:write"
+)
+
+// APIKeyScopes são os escopos válidos, na ordem em que são listados.
+var APIKeyScopes = []APIKeyScope{
+	ScopeRisksRead, ScopeRisksWrite,
+	ScopeAuditRead, ScopeAuditWrite,
+	ScopeVulnerabilitiesRead, ScopeVulnerabilitiesWrite,
+	ScopeAssetsRead, ScopeAssetsWrite,
+}
+
+// ValidAPIKeyScope indica se o escopo existe.
+func ValidAPIKeyScope(scope APIKeyScope) bool {
+	for _, valid := range APIKeyScopes {
+		if scope == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey é uma chave de API (token de acesso pessoal) para integrações como pipelines de CI. A chave age
+// como o usuário dono, com o papel atual dele, mas só nas rotas dos seus escopos. Apenas o hash do segredo é
+// armazenado; o prefixo identifica a chave nas listagens.
+type APIKey struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"organization_id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name           string     `gorm:"size:100;not null" json:"name"`
+	Prefix         string     `gorm:"size:16;not null" json:"prefix"`        // Início do token (ex: phx_Ab3dE6gH), para identificação
+	SecretHash     string     `gorm:"size:64;not null;uniqueIndex" json:"-"` // SHA-256 do token
+	Scopes         string     `gorm:"type:jsonb;not null" json:"-"`          // []APIKeyScope em JSON
+	ExpiresAt      time.Time  `gorm:"not null;index" json:"expires_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP     string     `gorm:"size:45" json:"last_used_ip,omitempty"`
+	RevokedAt      *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+	RevokedByID    *uuid.UUID `gorm:"type:uuid" json:"revoked_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	User         User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (k *APIKey) BeforeCreate(tx *gorm.DB) (err error) {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return
+}
+
+// ScopeList retorna os escopos da chave.
+func (k *APIKey) ScopeList() []APIKeyScope {
+	scopes := []APIKeyScope{}
+	if k.Scopes != "" {
+		_ = json.Unmarshal([]byte(k.Scopes), &scopes)
+	}
+	return scopes
+}
+
+// SetScopes grava os escopos da chave.
+func (k *APIKey) SetScopes(scopes []APIKeyScope) {
+	if scopes == nil {
+		scopes = []APIKeyScope{}
+	}
+	data, _ := json.Marshal(scopes)
+	k.Scopes = string(data)
+}
+
+// Active indica se a chave não foi revogada e não expirou.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIUsageSessionCredential identifica chamadas feitas com o token de sessão (JWT) do usuário,
+// em oposição a chamadas feitas com uma chave de API.
+const APIUsageSessionCredential = "session"
+
+// APIUsageCounter agrega as chamadas à API por organização, credencial e endpoint em janelas de uma hora.
+type APIUsageCounter struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_api_usage_bucket" json:"organization_id"`
+	Credential     string    `gorm:"size:64;not null;uniqueIndex:idx_api_usage_bucket" json:"credential"` // "session" ou o ID da chave de API
+	Method         string    `gorm:"size:10;not null;uniqueIndex:idx_api_usage_bucket" json:"method"`
+	Route          string    `gorm:"size:255;not null;uniqueIndex:idx_api_usage_bucket" json:"route"` // Rota do Gin (ex: /api/v1/risks/:riskId)
+	WindowStart    time.Time `gorm:"not null;uniqueIndex:idx_api_usage_bucket;index" json:"window_start"`
+	RequestCount   int64     `gorm:"not null;default:0" json:"request_count"`
+	ErrorCount     int64     `gorm:"not null;default:0" json:"error_count"` // Respostas com status >= 400
+	TotalLatencyMs int64     `gorm:"not null;default:0" json:"total_latency_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (u *APIUsageCounter) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AssessmentReminderAck registra a resposta do avaliador a um lembrete de prazo: a nova data prevista e a
+// justificativa. Os lembretes ficam pausados até a proximidade da nova data (AuditAssessment.ReminderTargetDate).
+type AssessmentReminderAck struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"organization_id"`
+	AuditAssessmentID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"audit_assessment_id"`
+	UserID             uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	DueDate            *time.Time `gorm:"type:date" json:"due_date,omitempty"`             // Prazo da atribuição no momento da resposta
+	PreviousTargetDate *time.Time `gorm:"type:date" json:"previous_target_date,omitempty"` // Data prevista anterior, se houve outra resposta
+	TargetDate         time.Time  `gorm:"type:date;not null" json:"target_date"`
+	Justification      string     `gorm:"type:text;not null" json:"justification"`
+	CreatedAt          time.Time  `json:"created_at"`
+
+	AuditAssessment AuditAssessment `gorm:"foreignKey:AuditAssessmentID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (a *AssessmentReminderAck) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
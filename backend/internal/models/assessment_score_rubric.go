@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AssessmentScoreRubric define o score padrão atribuído a cada status de avaliação quando o score não é informado.
+// FrameworkID nulo = rubrica padrão da organização; uma rubrica por framework tem precedência sobre ela.
+type AssessmentScoreRubric struct {
+	ID                       uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"organization_id"`
+	FrameworkID              *uuid.UUID `gorm:"type:uuid;index" json:"framework_id,omitempty"`
+	ConformantScore          int        `gorm:"not null" json:"conformant"`
+	PartiallyConformantScore int        `gorm:"not null" json:"partially_conformant"`
+	NonConformantScore       int        `gorm:"not null" json:"non_conformant"`
+	NotApplicableScore       int        `gorm:"not null" json:"not_applicable"`
+	UpdatedByID              *uuid.UUID `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt                time.Time  `json:"created_at"`
+	UpdatedAt                time.Time  `json:"updated_at"`
+
+	Framework *AuditFramework `gorm:"foreignKey:FrameworkID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (r *AssessmentScoreRubric) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// Origem da rubrica efetiva.
+const (
+	ScoreRubricSourceFramework    = "framework"
+	ScoreRubricSourceOrganization = "organization"
+	ScoreRubricSourceSystem       = "system"
+)
+
+// DefaultAssessmentScoreRubric é a rubrica do sistema, usada quando a organização não configurou uma (100/50/0/0).
+func DefaultAssessmentScoreRubric() AssessmentScoreRubric {
+	return AssessmentScoreRubric{ConformantScore: 100, PartiallyConformantScore: 50}
+}
+
+// ScoreFor retorna o score padrão da rubrica para o status.
+func (r AssessmentScoreRubric) ScoreFor(status AuditControlStatus) int {
+	switch status {
+	case ControlStatusConformant:
+		return r.ConformantScore
+	case ControlStatusPartiallyConformant:
+		return r.PartiallyConformantScore
+	case ControlStatusNonConformant:
+		return r.NonConformantScore
+	case ControlStatusNotApplicable, ControlStatusWaived:
+		// Controle dispensado por exceção aprovada pontua como não aplicável
+		return r.NotApplicableScore
+	}
+	return 0
+}
+
+// ResolveScoreRubric retorna a rubrica efetiva e sua origem: a do framework, senão a padrão da organização,
+// senão a do sistema.
+func ResolveScoreRubric(db *gorm.DB, organizationID, frameworkID uuid.UUID) (AssessmentScoreRubric, string, error) {
+	var rubrics []AssessmentScoreRubric
+	err := db.Where("organization_id = ? AND (framework_id = ? OR framework_id IS NULL)", organizationID, frameworkID).
+		Find(&rubrics).Error
+	if err != nil {
+		return AssessmentScoreRubric{}, "", err
+	}
+	var orgDefault *AssessmentScoreRubric
+	for i := range rubrics {
+		if rubrics[i].FrameworkID != nil {
+			return rubrics[i], ScoreRubricSourceFramework, nil
+		}
+		orgDefault = &rubrics[i]
+	}
+	if orgDefault != nil {
+		return *orgDefault, ScoreRubricSourceOrganization, nil
+	}
+	rubric := DefaultAssessmentScoreRubric()
+	rubric.OrganizationID = organizationID
+	return rubric, ScoreRubricSourceSystem, nil
+}
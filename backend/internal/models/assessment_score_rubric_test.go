@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultAssessmentScoreRubric(t *testing.T) {
+	rubric := DefaultAssessmentScoreRubric()
+	assert.Equal(t, 100, rubric.ScoreFor(ControlStatusConformant))
+	assert.Equal(t, 50, rubric.ScoreFor(ControlStatusPartiallyConformant))
+	assert.Equal(t, 0, rubric.ScoreFor(ControlStatusNonConformant))
+	assert.Equal(t, 0, rubric.ScoreFor(ControlStatusNotApplicable))
+}
+
+func TestAssessmentScoreRubricScoreFor(t *testing.T) {
+	rubric := AssessmentScoreRubric{ConformantScore: 90, PartiallyConformantScore: 60, NonConformantScore: 10, NotApplicableScore: 100}
+	assert.Equal(t, 60, rubric.ScoreFor(ControlStatusPartiallyConformant))
+	assert.Equal(t, 100, rubric.ScoreFor(ControlStatusNotApplicable))
+	assert.Equal(t, 100, rubric.ScoreFor(ControlStatusWaived))
+	assert.Equal(t, 0, rubric.ScoreFor("desconhecido"))
+}
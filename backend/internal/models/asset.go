@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AssetType classifica o ativo do inventário.
+type AssetType string
+
+const (
+	AssetTypeHardware    AssetType = "hardware"
+	AssetTypeSoftware    AssetType = "software"
+	AssetTypeInformation AssetType = "information"
+	AssetTypeService     AssetType = "service"
+	AssetTypeCloud       AssetType = "cloud"
+	AssetTypeFacility    AssetType = "facility"
+	AssetTypePeople      AssetType = "people"
+	AssetTypeOther       AssetType = "other"
+)
+
+// AssetCriticality indica o quão crítico o ativo é para o negócio.
+type AssetCriticality string
+
+const (
+	AssetCriticalityLow      AssetCriticality = "low"
+	AssetCriticalityMedium   AssetCriticality = "medium"
+	AssetCriticalityHigh     AssetCriticality = "high"
+	AssetCriticalityCritical AssetCriticality = "critical"
+)
+
+// DataClassification é o nível de classificação dos dados tratados pelo ativo.
+type DataClassification string
+
+const (
+	DataClassificationPublic       DataClassification = "public"
+	DataClassificationInternal     DataClassification = "internal"
+	DataClassificationConfidential DataClassification = "confidential"
+	DataClassificationRestricted   DataClassification = "restricted"
+)
+
+// Asset é um item do inventário de ativos da organização. O nome é único por organização
+// (usado como chave na importação CSV).
+type Asset struct {
+	ID                 uuid.UUID          `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID     uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_asset_org_name" json:"organization_id"`
+	Name               string             `gorm:"size:255;not null;uniqueIndex:idx_asset_org_name" json:"name"`
+	Type               AssetType          `gorm:"type:varchar(30);not null;index" json:"type"`
+	Description        string             `gorm:"type:text" json:"description,omitempty"`
+	OwnerID            *uuid.UUID         `gorm:"type:uuid;index" json:"owner_id,omitempty"`
+	Criticality        AssetCriticality   `gorm:"type:varchar(20);not null;default:'medium';index" json:"criticality"`
+	DataClassification DataClassification `gorm:"type:varchar(20);not null;default:'internal'" json:"data_classification"`
+	Location           string             `gorm:"size:255" json:"location,omitempty"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+func (a *Asset) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// AssetRisk vincula um ativo a um risco que o afeta.
+type AssetRisk struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	AssetID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_asset_risk" json:"asset_id"`
+	RiskID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_asset_risk;index" json:"risk_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Asset Asset `gorm:"foreignKey:AssetID;constraint:OnDelete:CASCADE;" json:"-"`
+	Risk  Risk  `gorm:"foreignKey:RiskID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (ar *AssetRisk) BeforeCreate(tx *gorm.DB) (err error) {
+	if ar.ID == uuid.Nil {
+		ar.ID = uuid.New()
+	}
+	return
+}
+
+// AssetControl vincula um ativo a um controle de auditoria em cujo escopo ele está.
+type AssetControl struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	AssetID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_asset_control" json:"asset_id"`
+	AuditControlID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_asset_control;index" json:"audit_control_id"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Asset        Asset        `gorm:"foreignKey:AssetID;constraint:OnDelete:CASCADE;" json:"-"`
+	AuditControl AuditControl `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (ac *AssetControl) BeforeCreate(tx *gorm.DB) (err error) {
+	if ac.ID == uuid.Nil {
+		ac.ID = uuid.New()
+	}
+	return
+}
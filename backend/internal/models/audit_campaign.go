@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AuditCampaignStatus representa o ciclo de vida de uma auditoria (campanha de avaliação).
+type AuditCampaignStatus string
+
+// AuditCampaignRole é o papel de um participante na auditoria.
+type AuditCampaignRole string
+
+const (
+	AuditCampaignStatusPlanned    AuditCampaignStatus = "planned"
+	AuditCampaignStatusInProgress AuditCampaignStatus = "in_progress"
+	AuditCampaignStatusClosed     AuditCampaignStatus = "closed"
+
+	AuditCampaignRoleLead     AuditCampaignRole = "lead"     // Coordena a auditoria
+	AuditCampaignRoleAssessor AuditCampaignRole = "assessor" // Registra avaliações
+	AuditCampaignRoleReviewer AuditCampaignRole = "reviewer" // Revisa avaliações (somente leitura)
+	AuditCampaignRoleAuditor  AuditCampaignRole = "auditor"  // Auditor externo (somente leitura)
+)
+
+// AuditCampaign agrupa uma auditoria sobre um framework: escopo (famílias de controles e/ou ativos), janela de
+// execução e participantes. As avaliações registradas na auditoria ficam separadas das avaliações contínuas
+// da organização (AuditCampaignID nulo), de modo que auditorias simultâneas sobre o mesmo framework não
+// sobrescrevem as avaliações umas das outras nem alteram o score de conformidade.
+type AuditCampaign struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID           `gorm:"type:uuid;not null;index" json:"organization_id"`
+	FrameworkID    uuid.UUID           `gorm:"type:uuid;not null;index" json:"framework_id"`
+	Name           string              `gorm:"size:255;not null" json:"name"`
+	Description    string              `gorm:"type:text" json:"description"`
+	Status         AuditCampaignStatus `gorm:"type:varchar(20);not null;default:'planned';index" json:"status"`
+	StartsAt       time.Time           `gorm:"type:date;not null" json:"starts_at"`
+	EndsAt         time.Time           `gorm:"type:date;not null" json:"ends_at"`
+	CreatedByID    uuid.UUID           `gorm:"type:uuid" json:"created_by_id"`
+	ClosedAt       *time.Time          `gorm:"type:timestamptz" json:"closed_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+
+	ScopeFamilies []AuditCampaignScopeFamily `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE;" json:"scope_families,omitempty"`
+	ScopeAssets   []AuditCampaignScopeAsset  `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE;" json:"scope_assets,omitempty"`
+	Participants  []AuditCampaignParticipant `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE;" json:"participants,omitempty"`
+}
+
+func (ac *AuditCampaign) BeforeCreate(tx *gorm.DB) (err error) {
+	if ac.ID == uuid.Nil {
+		ac.ID = uuid.New()
+	}
+	return
+}
+
+// AuditCampaignScopeFamily inclui uma família de controles do framework no escopo da auditoria.
+type AuditCampaignScopeFamily struct {
+	CampaignID uuid.UUID `gorm:"type:uuid;primaryKey" json:"campaign_id"`
+	Family     string    `gorm:"size:255;primaryKey" json:"family"`
+}
+
+// AuditCampaignScopeAsset inclui no escopo da auditoria os controles vinculados a um ativo (asset_controls).
+type AuditCampaignScopeAsset struct {
+	CampaignID uuid.UUID `gorm:"type:uuid;primaryKey" json:"campaign_id"`
+	AssetID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"asset_id"`
+}
+
+// AuditCampaignParticipant é um usuário da organização que participa da auditoria.
+type AuditCampaignParticipant struct {
+	CampaignID uuid.UUID         `gorm:"type:uuid;primaryKey" json:"campaign_id"`
+	UserID     uuid.UUID         `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	Role       AuditCampaignRole `gorm:"type:varchar(20);not null" json:"role"`
+	CreatedAt  time.Time         `json:"created_at"`
+	User       User              `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// CanAssess indica se o papel permite registrar avaliações na auditoria.
+func (r AuditCampaignRole) CanAssess() bool {
+	return r == AuditCampaignRoleLead || r == AuditCampaignRoleAssessor
+}
+
+// OrgWideAssessments restringe a consulta às avaliações contínuas da organização, ignorando as registradas
+// em auditorias (campanhas).
+func OrgWideAssessments(db *gorm.DB) *gorm.DB {
+	return db.Where("audit_assessments.audit_campaign_id IS NULL")
+}
+
+// AssessmentsOf restringe a consulta às avaliações da auditoria informada, ou às avaliações contínuas se nil.
+func AssessmentsOf(campaignID *uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if campaignID == nil {
+			return OrgWideAssessments(db)
+		}
+		return db.Where("audit_assessments.audit_campaign_id = ?", *campaignID)
+	}
+}
+
+// OrgWideAssessmentConflict é o alvo do upsert das avaliações contínuas: o índice único parcial
+// (organization_id, audit_control_id) WHERE audit_campaign_id IS NULL.
+func OrgWideAssessmentConflict(doUpdates clause.Set) clause.OnConflict {
+	return clause.OnConflict{
+		Columns:     []clause.Column{{Name: "organization_id"}, {Name: "audit_control_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "audit_campaign_id IS NULL"}}},
+		DoUpdates:   doUpdates,
+	}
+}
+
+// CampaignAssessmentConflict é o alvo do upsert das avaliações de uma auditoria: o índice único parcial
+// (organization_id, audit_control_id, audit_campaign_id) WHERE audit_campaign_id IS NOT NULL.
+func CampaignAssessmentConflict(doUpdates clause.Set) clause.OnConflict {
+	return clause.OnConflict{
+		Columns:     []clause.Column{{Name: "organization_id"}, {Name: "audit_control_id"}, {Name: "audit_campaign_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "audit_campaign_id IS NOT NULL"}}},
+		DoUpdates:   doUpdates,
+	}
+}
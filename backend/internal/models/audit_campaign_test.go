@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func dryRunDB(t *testing.T) *gorm.DB {
+	sqlDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{DryRun: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	return db
+}
+
+// TestAssessmentUpsertTargetsPartialIndexes garante que os upserts apontam para os índices únicos parciais
+// (sem o predicado, o Postgres não encontra o índice e rejeita o ON CONFLICT).
+func TestAssessmentUpsertTargetsPartialIndexes(t *testing.T) {
+	db := dryRunDB(t)
+	assessment := AuditAssessment{ID: uuid.New(), OrganizationID: uuid.New(), AuditControlID: uuid.New()}
+
+	stmt := db.Clauses(OrgWideAssessmentConflict(clause.AssignmentColumns([]string{"status"}))).Create(&assessment).Statement
+	assert.Regexp(t, `ON CONFLICT \("organization_id","audit_control_id"\)\s+WHERE audit_campaign_id IS NULL DO UPDATE SET "status"="excluded"."status"`, stmt.SQL.String())
+
+	campaignID := uuid.New()
+	assessment.AuditCampaignID = &campaignID
+	stmt = db.Clauses(CampaignAssessmentConflict(clause.AssignmentColumns([]string{"status"}))).Create(&assessment).Statement
+	assert.Regexp(t, `ON CONFLICT \("organization_id","audit_control_id","audit_campaign_id"\)\s+WHERE audit_campaign_id IS NOT NULL DO UPDATE`, stmt.SQL.String())
+}
+
+func TestAssessmentsOf(t *testing.T) {
+	db := dryRunDB(t)
+	var assessments []AuditAssessment
+
+	stmt := db.Scopes(AssessmentsOf(nil)).Find(&assessments).Statement
+	assert.Contains(t, stmt.SQL.String(), "audit_assessments.audit_campaign_id IS NULL")
+
+	campaignID := uuid.New()
+	stmt = db.Scopes(AssessmentsOf(&campaignID)).Find(&assessments).Statement
+	assert.Contains(t, stmt.SQL.String(), "audit_assessments.audit_campaign_id = $1")
+	assert.Equal(t, []interface{}{campaignID}, stmt.Vars)
+}
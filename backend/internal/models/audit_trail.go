@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditTrailAction identifica a ação registrada na trilha de auditoria da organização.
+type AuditTrailAction string
+
+const (
+	AuditTrailUserRolesBulkAssigned AuditTrailAction = "user_roles.bulk_assigned"
+	AuditTrailAdminActionRequested  AuditTrailAction = "admin_action.requested"
+	AuditTrailAdminActionExecuted   AuditTrailAction = "admin_action.executed"
+	AuditTrailAdminActionFailed     AuditTrailAction = "admin_action.failed"
+	AuditTrailAdminActionRejected   AuditTrailAction = "admin_action.rejected"
+	AuditTrailAdminActionCancelled  AuditTrailAction = "admin_action.cancelled"
+	AuditTrailExportRequested       AuditTrailAction = "audit_trail.export_requested"
+	AuditTrailSecretPolicyUpdated   AuditTrailAction = "org_secret.policy_updated"
+	AuditTrailEvidencePolicyUpdated AuditTrailAction = "evidence_access.policy_updated"
+	AuditTrailEvidenceBreakGlass    AuditTrailAction = "evidence_access.break_glass"
+	AuditTrailCoordinatorAdded      AuditTrailAction = "framework.coordinator_added"
+	AuditTrailCoordinatorRemoved    AuditTrailAction = "framework.coordinator_removed"
+	AuditTrailPasswordResetRequest  AuditTrailAction = "user.password_reset_requested"
+	AuditTrailPasswordResetComplete AuditTrailAction = "user.password_reset_completed"
+	AuditTrailPasswordChanged       AuditTrailAction = "user.password_changed"
+	AuditTrailPasswordPolicyUpdated AuditTrailAction = "password_policy.updated"
+	AuditTrailUserLoginLocked       AuditTrailAction = "user.login_locked"
+	AuditTrailUserUnlocked          AuditTrailAction = "user.unlocked"
+	AuditTrailWebAuthnRegistered    AuditTrailAction = "user.webauthn_registered"
+	AuditTrailWebAuthnRemoved       AuditTrailAction = "user.webauthn_removed"
+	AuditTrailMFAPolicyUpdated      AuditTrailAction = "mfa_policy.updated"
+	AuditTrailSAMLCertAdded         AuditTrailAction = "saml_certificate.added"
+	AuditTrailSAMLCertActivated     AuditTrailAction = "saml_certificate.activated"
+	AuditTrailSAMLCertRemoved       AuditTrailAction = "saml_certificate.removed"
+	AuditTrailSSOMappingApplied     AuditTrailAction = "user.sso_mapping_applied"
+	AuditTrailSSODeprovisioned      AuditTrailAction = "user.sso_deprovisioned"
+	AuditTrailDomainAdded           AuditTrailAction = "verified_domain.added"
+	AuditTrailDomainUpdated         AuditTrailAction = "verified_domain.updated"
+	AuditTrailDomainVerified        AuditTrailAction = "verified_domain.verified"
+	AuditTrailDomainRemoved         AuditTrailAction = "verified_domain.removed"
+	AuditTrailRisksBulkUpdated      AuditTrailAction = "risks.bulk_updated"
+	AuditTrailRisksBulkDeleted      AuditTrailAction = "risks.bulk_deleted"
+	AuditTrailRiskDeleted           AuditTrailAction = "risk.deleted"
+	AuditTrailRiskRestored          AuditTrailAction = "risk.restored"
+	AuditTrailPolicyDeleted         AuditTrailAction = "policy.deleted"
+	AuditTrailPolicyRestored        AuditTrailAction = "policy.restored"
+	AuditTrailRetentionUpdated      AuditTrailAction = "retention_policy.updated"
+	AuditTrailRetentionApplied      AuditTrailAction = "retention_policy.applied"
+	AuditTrailUserDataExported      AuditTrailAction = "user.data_exported"
+	AuditTrailUserAnonymized        AuditTrailAction = "user.anonymized"
+	AuditTrailOrgExportRequested    AuditTrailAction = "organization_export.requested"
+	AuditTrailOrgExportDownloaded   AuditTrailAction = "organization_export.downloaded"
+)
+
+// AuditTrailEntry é um registro imutável da trilha de auditoria da organização: quem fez o quê, quando e
+// sobre qual entidade, com o relatório da alteração em Details (JSON).
+type AuditTrailEntry struct {
+	ID             uuid.UUID        `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID        `gorm:"type:uuid;not null;index:idx_audit_trail_org_created,priority:1" json:"organization_id"`
+	ActorID        *uuid.UUID       `gorm:"type:uuid;index" json:"actor_id,omitempty"`
+	Action         AuditTrailAction `gorm:"type:varchar(100);not null;index" json:"action"`
+	EntityType     string           `gorm:"type:varchar(50)" json:"entity_type,omitempty"`
+	EntityID       *uuid.UUID       `gorm:"type:uuid" json:"entity_id,omitempty"` // Nulo em ações sobre vários registros
+	Summary        string           `gorm:"size:500" json:"summary"`
+	Details        *string          `gorm:"type:jsonb" json:"-"`
+	CreatedAt      time.Time        `gorm:"index:idx_audit_trail_org_created,priority:2" json:"created_at"`
+}
+
+func (e *AuditTrailEntry) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditTrailExportStatus é a situação da geração de uma exportação da trilha de auditoria.
+type AuditTrailExportStatus string
+
+const (
+	AuditTrailExportPending   AuditTrailExportStatus = "pending"
+	AuditTrailExportRunning   AuditTrailExportStatus = "running"
+	AuditTrailExportCompleted AuditTrailExportStatus = "completed"
+	AuditTrailExportFailed    AuditTrailExportStatus = "failed"
+)
+
+// AuditTrailExport é uma exportação da trilha de auditoria da organização no período [From, To), gerada em
+// segundo plano em partes CSV no armazenamento de arquivos, com o manifesto de integridade assinado (ver
+// o pacote audittrailexport).
+type AuditTrailExport struct {
+	ID             uuid.UUID              `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID              `gorm:"type:uuid;not null;index" json:"organization_id"`
+	RequestedByID  uuid.UUID              `gorm:"type:uuid;not null" json:"requested_by_id"`
+	From           time.Time              `gorm:"column:from_time;not null" json:"from"`
+	To             time.Time              `gorm:"column:to_time;not null" json:"to"`
+	Status         AuditTrailExportStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	TotalEntries   int                    `gorm:"default:0" json:"total_entries"`
+	PartCount      int                    `gorm:"default:0" json:"part_count"`
+	Manifest       *string                `gorm:"type:jsonb" json:"-"` // Manifesto assinado, preenchido ao concluir
+	Error          string                 `gorm:"type:text" json:"error,omitempty"`
+	StartedAt      *time.Time             `json:"started_at,omitempty"`
+	CompletedAt    *time.Time             `json:"completed_at,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+func (e *AuditTrailExport) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Motivos de revogação de uma sessão.
+const (
+	SessionRevokedLogout          = "logout"
+	SessionRevokedLogoutAll       = "logout_all"
+	SessionRevokedByUser          = "revoked_by_user"     // Encerrada pelo usuário na lista de sessões
+	SessionRevokedRefreshReuse    = "refresh_token_reuse" // Refresh token já rotacionado usado de novo: possível roubo
+	SessionRevokedPasswordReset   = "password_reset"
+	SessionRevokedPasswordChange  = "password_change"
+	SessionRevokedUserDeactivated = "user_deactivated"
+	SessionRevokedMFARequired     = "mfa_required" // A política de MFA passou a exigir um fator que o usuário não tem
+	// O IdP deixou de afirmar um grupo permitido e o usuário foi desativado no login (jit_deprovisioning)
+	SessionRevokedDeprovisioned = "sso_deprovisioned"
+	SessionRevokedAnonymized    = "user_anonymized" // Dados pessoais removidos (ver privacy.AnonymizeUser)
+)
+
+// SessionScopeMFAEnrollment é o escopo das sessões de quem ainda precisa cadastrar o segundo fator exigido pela
+// organização: os tokens só valem nas rotas de cadastro de MFA. Sessões sem escopo têm acesso completo.
+const SessionScopeMFAEnrollment = "mfa_enrollment"
+
+// AuthSession é uma sessão de login. Os tokens de acesso (JWT) levam o ID da sessão na claim sid e deixam de
+// ser aceitos quando ela é revogada; o refresh token é rotacionado a cada uso e só o seu hash é armazenado.
+type AuthSession struct {
+	ID                       uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	UserID                   uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	OrganizationID           *uuid.UUID `gorm:"type:uuid;index" json:"organization_id,omitempty"`
+	RefreshTokenHash         string     `gorm:"size:64;not null;uniqueIndex" json:"-"` // SHA-256 do refresh token atual
+	PreviousRefreshTokenHash string     `gorm:"size:64;index" json:"-"`                // Hash do token anterior, para detectar reuso
+	ExpiresAt                time.Time  `gorm:"not null;index" json:"expires_at"`      // Expiração do refresh token atual
+	LastRefreshedAt          *time.Time `json:"last_refreshed_at,omitempty"`
+	RevokedAt                *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+	RevokedReason            string     `gorm:"size:50" json:"revoked_reason,omitempty"`
+	Scope                    string     `gorm:"size:30;not null;default:''" json:"scope,omitempty"` // Vazio ou SessionScopeMFAEnrollment
+	UserAgent                string     `gorm:"size:255" json:"user_agent"`
+	IPAddress                string     `gorm:"size:45" json:"ip_address"`
+	CreatedAt                time.Time  `json:"created_at"`
+	UpdatedAt                time.Time  `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (s *AuthSession) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// Active indica se a sessão não foi revogada e o refresh token ainda não expirou.
+func (s *AuthSession) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
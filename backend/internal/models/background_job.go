@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BackgroundJobStatus é o estado de um job da fila de processamento em segundo plano.
+type BackgroundJobStatus string
+
+const (
+	JobQueued    BackgroundJobStatus = "queued"    // Aguardando execução (inclusive retentativas agendadas)
+	JobRunning   BackgroundJobStatus = "running"   // Reservado por um worker
+	JobSucceeded BackgroundJobStatus = "succeeded" // Concluído
+	JobDead      BackgroundJobStatus = "dead"      // Falhou em todas as tentativas ou com erro permanente
+	JobCanceled  BackgroundJobStatus = "canceled"  // Cancelado por um admin antes da execução
+)
+
+// BackgroundJob é uma tarefa assíncrona durável (ver o pacote jobs). Payload é o JSON interpretado pelo
+// handler registrado para o Type.
+type BackgroundJob struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;" json:"id"`
+	Type           string              `gorm:"size:100;not null;index" json:"type"`
+	Payload        string              `gorm:"type:jsonb;not null" json:"payload"`
+	OrganizationID *uuid.UUID          `gorm:"type:uuid;index" json:"organization_id,omitempty"`
+	Status         BackgroundJobStatus `gorm:"type:varchar(20);not null;index:idx_background_jobs_status_run_at" json:"status"`
+	RunAt          time.Time           `gorm:"not null;index:idx_background_jobs_status_run_at" json:"run_at"` // Próxima execução
+	Attempts       int                 `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts    int                 `gorm:"not null" json:"max_attempts"`
+	LastError      string              `gorm:"type:text" json:"last_error,omitempty"`
+	LockedBy       string              `gorm:"size:255" json:"locked_by,omitempty"` // Worker que reservou o job
+	LockedAt       *time.Time          `json:"locked_at,omitempty"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+func (j *BackgroundJob) BeforeCreate(tx *gorm.DB) (err error) {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	return
+}
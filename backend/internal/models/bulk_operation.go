@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BulkOperationLog registra uma operação em lote executada (importação, lançamento de campanha),
+// com os registros afetados e as notificações enviadas. É a base da cota diária de notificações.
+type BulkOperationLog struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID  uuid.UUID `gorm:"type:uuid;not null;index:idx_bulk_operation_org_created,priority:1" json:"organization_id"`
+	Operation       string    `gorm:"type:varchar(50);not null" json:"operation"`
+	AffectedRecords int       `gorm:"not null;default:0" json:"affected_records"`
+	Notifications   int       `gorm:"not null;default:0" json:"notifications"`
+	Confirmed       bool      `gorm:"not null;default:false" json:"confirmed"` // Executada com token de confirmação
+	PerformedByID   uuid.UUID `gorm:"type:uuid;not null" json:"performed_by_id"`
+	CreatedAt       time.Time `gorm:"index:idx_bulk_operation_org_created,priority:2" json:"created_at"`
+}
+
+func (b *BulkOperationLog) BeforeCreate(tx *gorm.DB) (err error) {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return
+}
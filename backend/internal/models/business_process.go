@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BusinessProcess é um processo de negócio avaliado na análise de impacto no negócio (BIA).
+// RTO/RPO/MTPD são expressos em horas.
+type BusinessProcess struct {
+	ID             uuid.UUID        `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_business_process_org_name" json:"organization_id"`
+	Name           string           `gorm:"size:255;not null;uniqueIndex:idx_business_process_org_name" json:"name"`
+	Description    string           `gorm:"type:text" json:"description,omitempty"`
+	OwnerID        *uuid.UUID       `gorm:"type:uuid;index" json:"owner_id,omitempty"`
+	Criticality    AssetCriticality `gorm:"type:varchar(20);not null;default:'medium';index" json:"criticality"`
+	RTOHours       int              `gorm:"not null" json:"rto_hours"`               // Recovery Time Objective
+	RPOHours       int              `gorm:"not null" json:"rpo_hours"`               // Recovery Point Objective
+	MTPDHours      *int             `json:"mtpd_hours,omitempty"`                    // Maximum Tolerable Period of Disruption
+	ImpactNotes    string           `gorm:"type:text" json:"impact_notes,omitempty"` // Impactos financeiros/operacionais/regulatórios da interrupção
+	LastReviewedAt *time.Time       `gorm:"type:timestamptz" json:"last_reviewed_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+func (bp *BusinessProcess) BeforeCreate(tx *gorm.DB) (err error) {
+	if bp.ID == uuid.Nil {
+		bp.ID = uuid.New()
+	}
+	return
+}
+
+// BusinessProcessAsset registra que o processo depende de um ativo do inventário.
+type BusinessProcessAsset struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	BusinessProcessID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_business_process_asset" json:"business_process_id"`
+	AssetID           uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_business_process_asset;index" json:"asset_id"`
+	CreatedAt         time.Time `json:"created_at"`
+
+	BusinessProcess BusinessProcess `gorm:"foreignKey:BusinessProcessID;constraint:OnDelete:CASCADE;" json:"-"`
+	Asset           Asset           `gorm:"foreignKey:AssetID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (bpa *BusinessProcessAsset) BeforeCreate(tx *gorm.DB) (err error) {
+	if bpa.ID == uuid.Nil {
+		bpa.ID = uuid.New()
+	}
+	return
+}
+
+// BusinessProcessVendor registra que o processo depende de um fornecedor.
+type BusinessProcessVendor struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	BusinessProcessID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_business_process_vendor" json:"business_process_id"`
+	VendorID          uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_business_process_vendor;index" json:"vendor_id"`
+	CreatedAt         time.Time `json:"created_at"`
+
+	BusinessProcess BusinessProcess `gorm:"foreignKey:BusinessProcessID;constraint:OnDelete:CASCADE;" json:"-"`
+	Vendor          Vendor          `gorm:"foreignKey:VendorID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (bpv *BusinessProcessVendor) BeforeCreate(tx *gorm.DB) (err error) {
+	if bpv.ID == uuid.Nil {
+		bpv.ID = uuid.New()
+	}
+	return
+}
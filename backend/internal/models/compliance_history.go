@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ComplianceSnapshotSource indica como um snapshot de score foi gerado.
+type ComplianceSnapshotSource string
+
+const (
+	ComplianceSnapshotSourceHistoricalImport ComplianceSnapshotSource = "historical_import"
+	ComplianceSnapshotSourceNightly          ComplianceSnapshotSource = "nightly" // Snapshot diário do score corrente
+)
+
+// AuditAssessmentHistory guarda resultados de avaliação passados (ex: importados de auditorias de anos anteriores)
+// sem sobrescrever a avaliação corrente em AuditAssessment, que é única por (organização, controle).
+type AuditAssessmentHistory struct {
+	ID             uuid.UUID          `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID          `gorm:"type:uuid;not null;index:idx_assessment_history_org_control" json:"organization_id"`
+	AuditControlID uuid.UUID          `gorm:"type:uuid;not null;index:idx_assessment_history_org_control" json:"audit_control_id"`
+	Status         AuditControlStatus `gorm:"type:varchar(30);not null" json:"status"`
+	Score          *int               `json:"score,omitempty"`
+	AssessmentDate time.Time          `gorm:"type:timestamptz;not null" json:"assessment_date"`
+	Comments       string             `gorm:"type:text" json:"comments,omitempty"`
+	ImportBatchID  uuid.UUID          `gorm:"type:uuid;index" json:"import_batch_id"`
+	ImportedByID   uuid.UUID          `gorm:"type:uuid" json:"imported_by_id"`
+	CreatedAt      time.Time          `json:"created_at"`
+	AuditControl   AuditControl       `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (h *AuditAssessmentHistory) BeforeCreate(tx *gorm.DB) (err error) {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return
+}
+
+// ComplianceScoreSnapshot é o score de conformidade de um framework em uma data, usado nos gráficos de tendência.
+type ComplianceScoreSnapshot struct {
+	ID                          uuid.UUID                `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID              uuid.UUID                `gorm:"type:uuid;not null;uniqueIndex:idx_compliance_snapshot_org_fw_date" json:"organization_id"`
+	FrameworkID                 uuid.UUID                `gorm:"type:uuid;not null;uniqueIndex:idx_compliance_snapshot_org_fw_date" json:"framework_id"`
+	SnapshotDate                time.Time                `gorm:"type:date;not null;uniqueIndex:idx_compliance_snapshot_org_fw_date" json:"snapshot_date"`
+	ComplianceScore             float64                  `json:"compliance_score"`
+	TotalControls               int                      `json:"total_controls"`
+	EvaluatedControls           int                      `json:"evaluated_controls"`
+	ConformantControls          int                      `json:"conformant_controls"`
+	PartiallyConformantControls int                      `json:"partially_conformant_controls"`
+	NonConformantControls       int                      `json:"non_conformant_controls"`
+	Source                      ComplianceSnapshotSource `gorm:"type:varchar(30);not null" json:"source"`
+	CreatedAt                   time.Time                `json:"created_at"`
+	UpdatedAt                   time.Time                `json:"updated_at"`
+}
+
+func (s *ComplianceScoreSnapshot) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// ComplianceScoreTally é o agregado corrente das avaliações de uma organização em um framework, mantido de forma
+// incremental a cada escrita de avaliação (ver pacote compliancescore). O score é ScoreSum / EvaluatedControls.
+type ComplianceScoreTally struct {
+	OrganizationID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	FrameworkID                 uuid.UUID `gorm:"type:uuid;primaryKey" json:"framework_id"`
+	ScoreSum                    int64     `gorm:"not null;default:0" json:"score_sum"`
+	EvaluatedControls           int       `gorm:"not null;default:0" json:"evaluated_controls"`
+	ConformantControls          int       `gorm:"not null;default:0" json:"conformant_controls"`
+	PartiallyConformantControls int       `gorm:"not null;default:0" json:"partially_conformant_controls"`
+	NonConformantControls       int       `gorm:"not null;default:0" json:"non_conformant_controls"`
+	RebuiltAt                   time.Time `json:"rebuilt_at"` // Último recálculo completo
+	UpdatedAt                   time.Time `json:"updated_at"`
+}
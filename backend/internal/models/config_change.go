@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConfigEntityType identifica o tipo de entidade de configuração versionada.
+type ConfigEntityType string
+
+const (
+	ConfigEntityIdentityProvider       ConfigEntityType = "identity_provider"
+	ConfigEntityNotificationRoute      ConfigEntityType = "notification_route"
+	ConfigEntityRiskNotificationFilter ConfigEntityType = "risk_notification_filter"
+	ConfigEntityWebhook                ConfigEntityType = "webhook"
+	ConfigEntityScoreRubric            ConfigEntityType = "score_rubric"
+	ConfigEntityRiskScoringMatrix      ConfigEntityType = "risk_scoring_matrix"
+)
+
+// ConfigChangeAction é a operação registrada no histórico de configuração.
+type ConfigChangeAction string
+
+const (
+	ConfigChangeCreate   ConfigChangeAction = "create"
+	ConfigChangeUpdate   ConfigChangeAction = "update"
+	ConfigChangeDelete   ConfigChangeAction = "delete"
+	ConfigChangeRollback ConfigChangeAction = "rollback"
+)
+
+// ConfigChange é uma versão de uma entidade de configuração da organização: o registro completo
+// (todas as colunas) antes e depois da alteração. Before nulo = criação; After nulo = remoção.
+type ConfigChange struct {
+	ID               uuid.UUID          `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID   uuid.UUID          `gorm:"type:uuid;not null;index:idx_config_change_entity,priority:1" json:"organization_id"`
+	EntityType       ConfigEntityType   `gorm:"type:varchar(50);not null;index:idx_config_change_entity,priority:2" json:"entity_type"`
+	EntityID         uuid.UUID          `gorm:"type:uuid;not null;index:idx_config_change_entity,priority:3" json:"entity_id"`
+	Action           ConfigChangeAction `gorm:"type:varchar(20);not null" json:"action"`
+	Before           *string            `gorm:"type:jsonb" json:"-"`
+	After            *string            `gorm:"type:jsonb" json:"-"`
+	ChangedByID      *uuid.UUID         `gorm:"type:uuid" json:"changed_by_id,omitempty"`
+	RolledBackFromID *uuid.UUID         `gorm:"type:uuid" json:"rolled_back_from_id,omitempty"` // Alteração desfeita por um rollback
+	CreatedAt        time.Time          `gorm:"index" json:"created_at"`
+}
+
+func (cc *ConfigChange) BeforeCreate(tx *gorm.DB) (err error) {
+	if cc.ID == uuid.Nil {
+		cc.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ControlGuidanceLink é uma referência externa da orientação do controle (norma, guia de implementação).
+type ControlGuidanceLink struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// ControlGuidance orienta o avaliador sobre o que o controle exige para ser considerado conforme, com
+// exemplos de evidências usuais e referências.
+type ControlGuidance struct {
+	Text             string                `json:"text"`
+	EvidenceExamples []string              `json:"evidence_examples"`
+	Links            []ControlGuidanceLink `json:"links"`
+}
+
+// IsEmpty indica se a orientação não tem conteúdo.
+func (g *ControlGuidance) IsEmpty() bool {
+	return g == nil || (strings.TrimSpace(g.Text) == "" && len(g.EvidenceExamples) == 0 && len(g.Links) == 0)
+}
+
+// GuidanceDetails decodifica a orientação do controle; retorna nil se o controle não tem orientação.
+func (ac *AuditControl) GuidanceDetails() (*ControlGuidance, error) {
+	if ac.Guidance == nil || *ac.Guidance == "" {
+		return nil, nil
+	}
+	var guidance ControlGuidance
+	if err := json.Unmarshal([]byte(*ac.Guidance), &guidance); err != nil {
+		return nil, err
+	}
+	if guidance.EvidenceExamples == nil {
+		guidance.EvidenceExamples = []string{}
+	}
+	if guidance.Links == nil {
+		guidance.Links = []ControlGuidanceLink{}
+	}
+	return &guidance, nil
+}
+
+// SetGuidance grava a orientação do controle; uma orientação vazia remove a existente.
+func (ac *AuditControl) SetGuidance(guidance *ControlGuidance) error {
+	if guidance.IsEmpty() {
+		ac.Guidance = nil
+		return nil
+	}
+	data, err := json.Marshal(guidance)
+	if err != nil {
+		return err
+	}
+	encoded := string(data)
+	ac.Guidance = &encoded
+	return nil
+}
+
+// Limites da orientação de um controle.
+const (
+	MaxControlGuidanceTextLength   = 5000
+	MaxControlGuidanceEvidence     = 20
+	MaxControlGuidanceEvidenceSize = 500
+	MaxControlGuidanceLinks        = 10
+)
+
+// Validate verifica os limites da orientação e se os links são URLs http(s).
+func (g *ControlGuidance) Validate() error {
+	if len([]rune(g.Text)) > MaxControlGuidanceTextLength {
+		return fmt.Errorf("guidance text must have at most %d characters", MaxControlGuidanceTextLength)
+	}
+	if len(g.EvidenceExamples) > MaxControlGuidanceEvidence {
+		return fmt.Errorf("guidance must have at most %d evidence examples", MaxControlGuidanceEvidence)
+	}
+	for _, example := range g.EvidenceExamples {
+		if strings.TrimSpace(example) == "" || len([]rune(example)) > MaxControlGuidanceEvidenceSize {
+			return fmt.Errorf("evidence examples must not be blank and must have at most %d characters", MaxControlGuidanceEvidenceSize)
+		}
+	}
+	if len(g.Links) > MaxControlGuidanceLinks {
+		return fmt.Errorf("guidance must have at most %d links", MaxControlGuidanceLinks)
+	}
+	for _, link := range g.Links {
+		u, err := url.Parse(link.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid guidance link URL: %s", link.URL)
+		}
+		if strings.TrimSpace(link.Title) == "" {
+			return errors.New("guidance links must have a title")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlGuidanceRoundTrip(t *testing.T) {
+	control := AuditControl{}
+	guidance, err := control.GuidanceDetails()
+	require.NoError(t, err)
+	assert.Nil(t, guidance, "controle sem orientação")
+
+	require.NoError(t, control.SetGuidance(&ControlGuidance{Text: "Política aprovada pela direção"}))
+	require.NotNil(t, control.Guidance)
+	guidance, err = control.GuidanceDetails()
+	require.NoError(t, err)
+	assert.Equal(t, "Política aprovada pela direção", guidance.Text)
+	assert.Equal(t, []string{}, guidance.EvidenceExamples)
+	assert.Equal(t, []ControlGuidanceLink{}, guidance.Links)
+
+	require.NoError(t, control.SetGuidance(&ControlGuidance{Text: "  "}))
+	assert.Nil(t, control.Guidance, "orientação vazia remove a existente")
+}
+
+func TestControlGuidanceValidate(t *testing.T) {
+	valid := ControlGuidance{
+		Text:             "Conforme quando...",
+		EvidenceExamples: []string{"Ata de aprovação"},
+		Links:            []ControlGuidanceLink{{Title: "ISO/IEC 27001", URL: "https://www.iso.org/standard/27001"}},
+	}
+	assert.NoError(t, valid.Validate())
+
+	invalid := []ControlGuidance{
+		{Text: strings.Repeat("a", MaxControlGuidanceTextLength+1)},
+		{EvidenceExamples: []string{" "}},
+		{Links: []ControlGuidanceLink{{Title: "Local", URL: "javascript:alert(1)"}}},
+		{Links: []ControlGuidanceLink{{Title: "", URL: "https://example.com"}}},
+	}
+	for _, g := range invalid {
+		assert.Error(t, g.Validate())
+	}
+}
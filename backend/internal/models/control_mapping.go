@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ControlMappingRelationship indica o quanto um controle atende o outro.
+type ControlMappingRelationship string
+
+const (
+	ControlMappingEquivalent ControlMappingRelationship = "equivalent" // Os controles têm o mesmo objetivo
+	ControlMappingPartial    ControlMappingRelationship = "partial"    // Atender um cobre parte do outro
+)
+
+// ControlMapping relaciona controles equivalentes de frameworks diferentes. O mapeamento vale nos dois sentidos.
+// Mapeamentos sem OrganizationID são os padrões (semeados) e valem para todas as organizações.
+type ControlMapping struct {
+	ID              uuid.UUID                  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID  *uuid.UUID                 `gorm:"type:uuid;index" json:"organization_id,omitempty"`
+	SourceControlID uuid.UUID                  `gorm:"type:uuid;not null;index" json:"source_control_id"`
+	TargetControlID uuid.UUID                  `gorm:"type:uuid;not null;index" json:"target_control_id"`
+	Relationship    ControlMappingRelationship `gorm:"type:varchar(20);not null" json:"relationship"`
+	Notes           string                     `gorm:"type:text" json:"notes,omitempty"`
+	CreatedByID     *uuid.UUID                 `gorm:"type:uuid" json:"created_by_id,omitempty"`
+	CreatedAt       time.Time                  `json:"created_at"`
+
+	SourceControl AuditControl `gorm:"foreignKey:SourceControlID;constraint:OnDelete:CASCADE;" json:"-"`
+	TargetControl AuditControl `gorm:"foreignKey:TargetControlID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (cm *ControlMapping) BeforeCreate(tx *gorm.DB) (err error) {
+	if cm.ID == uuid.Nil {
+		cm.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// controlSortKeyDigits é a largura usada para alinhar os trechos numéricos da chave de ordenação.
+const controlSortKeyDigits = 10
+
+// ControlSortKey gera a chave de ordenação natural de um ControlID: cada sequência de dígitos é alinhada
+// com zeros à esquerda, de modo que "A.2" < "A.10" e "AC-01" == "AC-1" na comparação de strings.
+func ControlSortKey(controlID string) string {
+	var key strings.Builder
+	var digits strings.Builder
+	flush := func() {
+		if digits.Len() == 0 {
+			return
+		}
+		number := strings.TrimLeft(digits.String(), "0")
+		if len(number) < controlSortKeyDigits {
+			key.WriteString(strings.Repeat("0", controlSortKeyDigits-len(number)))
+		}
+		key.WriteString(number)
+		digits.Reset()
+	}
+	for _, r := range strings.ToLower(strings.TrimSpace(controlID)) {
+		if unicode.IsDigit(r) {
+			digits.WriteRune(r)
+			continue
+		}
+		flush()
+		key.WriteRune(r)
+	}
+	flush()
+	return key.String()
+}
+
+// ControlOrderClause é a ordenação padrão dos controles: sequência oficial do framework (Position),
+// depois a ordem natural do ControlID.
+const ControlOrderClause = "audit_controls.position ASC NULLS LAST, audit_controls.sort_key ASC"
+
+// OrgControlOrder é a posição de um controle na ordenação personalizada de um framework para uma organização.
+type OrgControlOrder struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_org_control_order" json:"organization_id"`
+	FrameworkID    uuid.UUID `gorm:"type:uuid;not null;index" json:"framework_id"`
+	AuditControlID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_org_control_order" json:"audit_control_id"`
+	Position       int       `gorm:"not null" json:"position"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	AuditControl AuditControl `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (oco *OrgControlOrder) BeforeCreate(tx *gorm.DB) (err error) {
+	if oco.ID == uuid.Nil {
+		oco.ID = uuid.New()
+	}
+	return
+}
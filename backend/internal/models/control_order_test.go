@@ -0,0 +1,20 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControlSortKeyOrdersNaturally(t *testing.T) {
+	ids := []string{"A.10", "A.2", "A.5.15", "A.5.2", "A.5.1", "A.8.16", "A.8.9"}
+	sort.Slice(ids, func(i, j int) bool { return ControlSortKey(ids[i]) < ControlSortKey(ids[j]) })
+	assert.Equal(t, []string{"A.2", "A.5.1", "A.5.2", "A.5.15", "A.8.9", "A.8.16", "A.10"}, ids)
+}
+
+func TestControlSortKeyIgnoresLeadingZerosAndCase(t *testing.T) {
+	assert.Equal(t, ControlSortKey("AC-1"), ControlSortKey("ac-01"))
+	assert.Less(t, ControlSortKey("CIS-2.2"), ControlSortKey("CIS-10.1"))
+	assert.Less(t, ControlSortKey("GV.OC-01"), ControlSortKey("GV.OC-10"))
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ControlTestFrequency define com que frequência um controle deve ser testado.
+type ControlTestFrequency string
+
+const (
+	ControlTestMonthly    ControlTestFrequency = "monthly"
+	ControlTestQuarterly  ControlTestFrequency = "quarterly"
+	ControlTestSemiannual ControlTestFrequency = "semiannual"
+	ControlTestAnnual     ControlTestFrequency = "annual"
+)
+
+// ControlTestPlan é um teste recorrente de controle, com responsável e esforço estimado,
+// usado pelo planejador para distribuir os testes ao longo do ano.
+type ControlTestPlan struct {
+	ID             uuid.UUID            `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID            `gorm:"type:uuid;not null;index" json:"organization_id"`
+	AuditControlID uuid.UUID            `gorm:"type:uuid;not null;index" json:"audit_control_id"`
+	OwnerID        uuid.UUID            `gorm:"type:uuid;not null;index" json:"owner_id"`
+	OwnerTeamID    *uuid.UUID           `gorm:"type:uuid;index" json:"owner_team_id,omitempty"` // Equipe responsável; a capacidade continua sendo a do OwnerID
+	Frequency      ControlTestFrequency `gorm:"type:varchar(20);not null" json:"frequency"`
+	EstimatedHours float64              `gorm:"not null" json:"estimated_hours"` // Esforço por execução
+	Notes          string               `gorm:"type:text" json:"notes,omitempty"`
+	IsActive       bool                 `gorm:"not null" json:"is_active"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+	AuditControl   AuditControl         `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (p *ControlTestPlan) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// ControlTestCapacity é a capacidade mensal (em horas) de um responsável para executar testes de controle.
+// Usuários sem registro usam a capacidade padrão do planejador.
+type ControlTestCapacity struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_control_test_capacity_org_user" json:"organization_id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_control_test_capacity_org_user" json:"user_id"`
+	MonthlyHours   float64   `gorm:"not null" json:"monthly_hours"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (ctc *ControlTestCapacity) BeforeCreate(tx *gorm.DB) (err error) {
+	if ctc.ID == uuid.Nil {
+		ctc.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ControlWaiverStatus representa o ciclo de vida de uma exceção (waiver) de controle.
+type ControlWaiverStatus string
+
+const (
+	ControlWaiverPending  ControlWaiverStatus = "pending"  // Aguardando aprovação (ApprovalWorkflow)
+	ControlWaiverActive   ControlWaiverStatus = "active"   // Aprovada: o controle fica dispensado até ExpiresAt
+	ControlWaiverRejected ControlWaiverStatus = "rejected" // Rejeitada pelo aprovador
+	ControlWaiverExpired  ControlWaiverStatus = "expired"  // Venceu: o controle voltou a não conforme
+	ControlWaiverRevoked  ControlWaiverStatus = "revoked"  // Revogada antes do vencimento
+)
+
+// ControlWaiver é uma exceção aprovada para um controle da organização: enquanto ativa, a avaliação contínua
+// do controle fica com o status dispensado (ControlStatusWaived). No vencimento o controle volta a não
+// conforme e o responsável é notificado.
+type ControlWaiver struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index" json:"organization_id"`
+	AuditControlID uuid.UUID `gorm:"type:uuid;not null;index" json:"audit_control_id"`
+	Justification  string    `gorm:"type:text;not null" json:"justification"`
+	// Descrição das medidas compensatórias adotadas enquanto o controle está dispensado
+	CompensatingMeasures string              `gorm:"type:text" json:"compensating_measures"`
+	ExpiresAt            time.Time           `gorm:"type:date;not null;index" json:"expires_at"` // Último dia de validade
+	Status               ControlWaiverStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	OwnerID              uuid.UUID           `gorm:"type:uuid;not null" json:"owner_id"` // Responsável, notificado no vencimento
+	RequestedByID        uuid.UUID           `gorm:"type:uuid;not null" json:"requested_by_id"`
+	ApproverID           uuid.UUID           `gorm:"type:uuid;not null" json:"approver_id"`
+	// Status da avaliação contínua quando a exceção foi aplicada (informativo)
+	PreviousAssessmentStatus AuditControlStatus `gorm:"type:varchar(50)" json:"previous_assessment_status,omitempty"`
+	ApprovedAt               *time.Time         `json:"approved_at,omitempty"`
+	ClosedAt                 *time.Time         `json:"closed_at,omitempty"` // Rejeição, vencimento ou revogação
+	CreatedAt                time.Time          `json:"created_at"`
+	UpdatedAt                time.Time          `json:"updated_at"`
+
+	AuditControl         AuditControl                       `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"-"`
+	CompensatingControls []ControlWaiverCompensatingControl `gorm:"foreignKey:WaiverID;constraint:OnDelete:CASCADE;" json:"compensating_controls,omitempty"`
+}
+
+func (w *ControlWaiver) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return
+}
+
+// ControlWaiverCompensatingControl é um controle (de qualquer framework visível) que compensa o controle dispensado.
+type ControlWaiverCompensatingControl struct {
+	WaiverID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"waiver_id"`
+	AuditControlID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"audit_control_id"`
+}
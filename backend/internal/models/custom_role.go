@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomRole é um papel definido pela organização: um conjunto de permissões (recurso:ação, ver o pacote rbac)
+// concedidas aos usuários que o recebem, além das do papel base (admin, manager ou user).
+type CustomRole struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_custom_roles_org_name,priority:1" json:"organization_id"`
+	Name           string     `gorm:"size:100;not null;uniqueIndex:idx_custom_roles_org_name,priority:2" json:"name"`
+	Description    string     `gorm:"size:255" json:"description"`
+	Permissions    string     `gorm:"type:jsonb;not null" json:"-"` // []string em JSON
+	CreatedByID    *uuid.UUID `gorm:"type:uuid" json:"created_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (r *CustomRole) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// PermissionList retorna as permissões do papel.
+func (r *CustomRole) PermissionList() []string {
+	permissions := []string{}
+	if r.Permissions != "" {
+		_ = json.Unmarshal([]byte(r.Permissions), &permissions)
+	}
+	return permissions
+}
+
+// SetPermissions grava as permissões do papel.
+func (r *CustomRole) SetPermissions(permissions []string) {
+	if permissions == nil {
+		permissions = []string{}
+	}
+	data, _ := json.Marshal(permissions)
+	r.Permissions = string(data)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RetentionCategory identifica um conjunto de dados com prazo de retenção configurável pela organização.
+type RetentionCategory string
+
+const (
+	RetentionClosedRisks           RetentionCategory = "closed_risks"           // Riscos mitigados ou aceitos, pela última alteração
+	RetentionClosedVulnerabilities RetentionCategory = "closed_vulnerabilities" // Vulnerabilidades corrigidas, pela última alteração
+	RetentionAuditTrail            RetentionCategory = "audit_trail"            // Trilha de auditoria, pela data do registro
+	RetentionNotifications         RetentionCategory = "notifications"          // Notificações in-app dos usuários da organização
+	RetentionInactiveUsers         RetentionCategory = "inactive_users"         // Usuários desativados, anonimizados em vez de removidos
+)
+
+// RetentionCategories lista as categorias na ordem em que o expurgo as processa.
+var RetentionCategories = []RetentionCategory{
+	RetentionClosedRisks,
+	RetentionClosedVulnerabilities,
+	RetentionAuditTrail,
+	RetentionNotifications,
+	RetentionInactiveUsers,
+}
+
+// DataRetentionRule define por quantos dias a organização mantém os registros de uma categoria. Sem regra, a
+// categoria é mantida indefinidamente.
+type DataRetentionRule struct {
+	ID             uuid.UUID         `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex:idx_data_retention_rules_org_category,priority:1" json:"organization_id"`
+	Category       RetentionCategory `gorm:"type:varchar(50);not null;uniqueIndex:idx_data_retention_rules_org_category,priority:2" json:"category"`
+	RetentionDays  int               `gorm:"not null" json:"retention_days"`
+	UpdatedByID    *uuid.UUID        `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (r *DataRetentionRule) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EditLockResourceType identifica o tipo de registro protegido por um lock de edição.
+type EditLockResourceType string
+
+const (
+	EditLockResourceRisk       EditLockResourceType = "risk"
+	EditLockResourceAssessment EditLockResourceType = "assessment" // ResourceID é o ID do AuditControl avaliado
+)
+
+// EditLock é um lease de edição: enquanto não expirar, apenas o usuário que o detém pode salvar o registro.
+// Clientes renovam o lease periodicamente (heartbeat) enquanto o formulário de edição estiver aberto.
+type EditLock struct {
+	ID             uuid.UUID            `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_edit_lock_resource" json:"organization_id"`
+	ResourceType   EditLockResourceType `gorm:"type:varchar(30);not null;uniqueIndex:idx_edit_lock_resource" json:"resource_type"`
+	ResourceID     uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_edit_lock_resource" json:"resource_id"`
+	UserID         uuid.UUID            `gorm:"type:uuid;not null" json:"user_id"`
+	UserName       string               `gorm:"size:255" json:"user_name"`
+	AcquiredAt     time.Time            `gorm:"type:timestamptz;not null" json:"acquired_at"`
+	ExpiresAt      time.Time            `gorm:"type:timestamptz;not null;index" json:"expires_at"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+func (el *EditLock) BeforeCreate(tx *gorm.DB) (err error) {
+	if el.ID == uuid.Nil {
+		el.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailBranding personaliza os e-mails de notificação da organização. Campos vazios usam a identidade visual
+// da organização (logo e cores do branding) ou o padrão da aplicação.
+type EmailBranding struct {
+	OrganizationID uuid.UUID  `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	FromName       string     `gorm:"size:100" json:"from_name"`     // Nome do remetente; o endereço continua sendo AWS_SENDER_EMAIL
+	LogoURL        string     `gorm:"size:500" json:"logo_url"`      // URL https pública; vazio = logo da organização
+	PrimaryColor   string     `gorm:"size:7" json:"primary_color"`   // #RRGGBB; vazio = cor primária da organização
+	SecondaryColor string     `gorm:"size:7" json:"secondary_color"` // #RRGGBB; vazio = cor secundária da organização
+	FooterText     string     `gorm:"size:500" json:"footer_text"`   // Ex: aviso de confidencialidade
+	Locale         string     `gorm:"size:10" json:"locale"`         // pt-BR, en ou es; vazio = pt-BR
+	UpdatedByID    *uuid.UUID `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
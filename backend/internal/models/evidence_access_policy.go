@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EvidenceAccessPolicy restringe os downloads de evidências da organização a redes (CIDR) e a um horário
+// comercial. Fora da política, o download só é liberado por break-glass (com justificativa registrada na
+// trilha de auditoria), se a política permitir.
+type EvidenceAccessPolicy struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"organization_id"`
+	// Redes permitidas em JSON ([]string de CIDRs); vazio = qualquer rede
+	AllowedNetworks string `gorm:"type:jsonb;not null" json:"-"`
+	// Horário comercial: dias da semana em JSON ([]int, 0 = domingo) e faixa [StartTime, EndTime) no fuso Timezone
+	BusinessHoursEnabled bool       `gorm:"not null;default:false" json:"business_hours_enabled"`
+	Timezone             string     `gorm:"size:64;not null;default:'UTC'" json:"timezone"`
+	Weekdays             string     `gorm:"type:jsonb;not null" json:"-"`
+	StartTime            string     `gorm:"size:5" json:"start_time"`          // HH:MM
+	EndTime              string     `gorm:"size:5" json:"end_time"`            // HH:MM
+	AllowBreakGlass      bool       `gorm:"not null" json:"allow_break_glass"` // Sem default no GORM: o false precisa ser gravado na criação
+	UpdatedByID          *uuid.UUID `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (p *EvidenceAccessPolicy) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// NetworkList retorna as redes permitidas.
+func (p *EvidenceAccessPolicy) NetworkList() []string {
+	networks := []string{}
+	if p.AllowedNetworks != "" {
+		_ = json.Unmarshal([]byte(p.AllowedNetworks), &networks)
+	}
+	return networks
+}
+
+// SetNetworks grava as redes permitidas.
+func (p *EvidenceAccessPolicy) SetNetworks(networks []string) {
+	if networks == nil {
+		networks = []string{}
+	}
+	data, _ := json.Marshal(networks)
+	p.AllowedNetworks = string(data)
+}
+
+// WeekdayList retorna os dias da semana do horário comercial.
+func (p *EvidenceAccessPolicy) WeekdayList() []int {
+	weekdays := []int{}
+	if p.Weekdays != "" {
+		_ = json.Unmarshal([]byte(p.Weekdays), &weekdays)
+	}
+	return weekdays
+}
+
+// SetWeekdays grava os dias da semana do horário comercial.
+func (p *EvidenceAccessPolicy) SetWeekdays(weekdays []int) {
+	if weekdays == nil {
+		weekdays = []int{}
+	}
+	data, _ := json.Marshal(weekdays)
+	p.Weekdays = string(data)
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EvidenceRequestStatus é o estado de uma solicitação de evidência.
+type EvidenceRequestStatus string
+
+const (
+	EvidenceRequestPending   EvidenceRequestStatus = "pending"   // Aguardando envio do colaborador
+	EvidenceRequestSubmitted EvidenceRequestStatus = "submitted" // Enviada, aguardando revisão
+	EvidenceRequestAccepted  EvidenceRequestStatus = "accepted"
+	EvidenceRequestRejected  EvidenceRequestStatus = "rejected" // Volta para a lista do colaborador para reenvio
+)
+
+// EvidenceRequest é uma evidência solicitada a um colaborador (não necessariamente usuário do sistema),
+// identificado pelo e-mail. O colaborador envia o arquivo pelo portal de evidências; ao ser aceita,
+// a evidência é vinculada à avaliação do controle de auditoria (quando informado).
+type EvidenceRequest struct {
+	ID             uuid.UUID             `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID             `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Title          string                `gorm:"size:255;not null" json:"title"`
+	Instructions   string                `gorm:"type:text" json:"instructions,omitempty"`
+	AuditControlID *uuid.UUID            `gorm:"type:uuid;index" json:"audit_control_id,omitempty"`
+	AssigneeEmail  string                `gorm:"size:255;not null;index" json:"assignee_email"` // Sempre em minúsculas
+	AssigneeName   string                `gorm:"size:255" json:"assignee_name,omitempty"`
+	DueDate        time.Time             `gorm:"type:timestamptz;not null;index" json:"due_date"`
+	Status         EvidenceRequestStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	SubmittedAt    *time.Time            `gorm:"type:timestamptz" json:"submitted_at,omitempty"`
+	ReviewNote     string                `gorm:"type:text" json:"review_note,omitempty"`
+	ReviewedByID   *uuid.UUID            `gorm:"type:uuid" json:"reviewed_by_id,omitempty"`
+	ReviewedAt     *time.Time            `gorm:"type:timestamptz" json:"reviewed_at,omitempty"`
+	CreatedByID    uuid.UUID             `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+
+	Submissions []EvidenceSubmission `gorm:"foreignKey:EvidenceRequestID;constraint:OnDelete:CASCADE;" json:"submissions,omitempty"`
+}
+
+func (er *EvidenceRequest) BeforeCreate(tx *gorm.DB) (err error) {
+	if er.ID == uuid.Nil {
+		er.ID = uuid.New()
+	}
+	return
+}
+
+// EvidenceSubmission é um arquivo enviado para uma solicitação de evidência (reenvios geram novos registros).
+type EvidenceSubmission struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	EvidenceRequestID uuid.UUID `gorm:"type:uuid;not null;index" json:"evidence_request_id"`
+	ObjectName        string    `gorm:"size:512;not null" json:"object_name"` // Chave no provider de armazenamento
+	FileName          string    `gorm:"size:255" json:"file_name"`
+	SizeBytes         int64     `json:"size_bytes"`
+	ScanStatus        string    `gorm:"size:20" json:"scan_status,omitempty"`
+	Note              string    `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func (es *EvidenceSubmission) BeforeCreate(tx *gorm.DB) (err error) {
+	if es.ID == uuid.Nil {
+		es.ID = uuid.New()
+	}
+	return
+}
+
+// EvidencePortalLink dá a um colaborador acesso ao portal de evidências, restrito às solicitações
+// atribuídas ao seu e-mail. Somente o hash SHA-256 do token é armazenado.
+type EvidencePortalLink struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Email          string     `gorm:"size:255;not null;index" json:"email"` // Sempre em minúsculas
+	Name           string     `gorm:"size:255" json:"name,omitempty"`
+	TokenHash      string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt      time.Time  `gorm:"type:timestamptz;not null" json:"expires_at"`
+	RevokedAt      *time.Time `gorm:"type:timestamptz" json:"revoked_at,omitempty"`
+	LastUsedAt     *time.Time `gorm:"type:timestamptz" json:"last_used_at,omitempty"`
+	CreatedByID    uuid.UUID  `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (epl *EvidencePortalLink) BeforeCreate(tx *gorm.DB) (err error) {
+	if epl.ID == uuid.Nil {
+		epl.ID = uuid.New()
+	}
+	return
+}
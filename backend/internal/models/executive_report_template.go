@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultExecutiveReportTitle é o título do relatório executivo das organizações sem modelo configurado.
+const DefaultExecutiveReportTitle = "Relatório Executivo de Riscos e Conformidade"
+
+// ExecutiveReportTemplate personaliza o relatório executivo (PDF para o conselho) da organização. Campos
+// vazios usam o padrão: o título padrão, o logo e a cor primária da identidade visual da organização.
+type ExecutiveReportTemplate struct {
+	OrganizationID uuid.UUID  `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	Title          string     `gorm:"size:150" json:"title"`
+	Subtitle       string     `gorm:"size:255" json:"subtitle"`
+	FooterText     string     `gorm:"size:255" json:"footer_text"` // Ex: "Confidencial - uso restrito ao conselho"
+	LogoURL        string     `gorm:"size:255" json:"-"`           // Objeto no armazenamento; vazio = logo da organização
+	PrimaryColor   string     `gorm:"size:7" json:"primary_color"` // #RRGGBB; vazio = cor primária da organização
+	UpdatedByID    *uuid.UUID `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
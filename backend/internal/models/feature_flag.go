@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FeatureFlag é uma feature flag da plataforma, avaliada em tempo de execução por pkg/features.
+// Desligada (Enabled=false), vale para todos; ligada, aplica as regras e depois o rollout percentual.
+type FeatureFlag struct {
+	ID                uuid.UUID         `gorm:"type:uuid;primary_key;" json:"id"`
+	Key               string            `gorm:"size:100;not null;uniqueIndex" json:"key"` // Ex: "LOG_DETALHADO_RISCO"
+	Description       string            `gorm:"type:text" json:"description,omitempty"`
+	Enabled           bool              `gorm:"not null;default:false" json:"enabled"`
+	RolloutPercentage int               `gorm:"not null;default:0" json:"rollout_percentage"` // 0-100
+	Rules             []FeatureFlagRule `gorm:"foreignKey:FeatureFlagID;constraint:OnDelete:CASCADE;" json:"rules,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+func (f *FeatureFlag) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
+
+// FeatureFlagRule é uma regra de segmentação da flag por organização e/ou papel. As regras são
+// avaliadas pela posição; a primeira que coincide define se a flag fica ligada.
+type FeatureFlagRule struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	FeatureFlagID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"feature_flag_id"`
+	Position       int        `gorm:"not null;default:0" json:"position"`
+	OrganizationID *uuid.UUID `gorm:"type:uuid;index" json:"organization_id,omitempty"` // Vazio: qualquer organização
+	Role           *UserRole  `gorm:"type:varchar(50)" json:"role,omitempty"`           // Vazio: qualquer papel
+	Enabled        bool       `gorm:"not null" json:"enabled"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (r *FeatureFlagRule) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FrameworkCoordinator designa um usuário como coordenador de um framework na organização. Quando o framework
+// tem coordenadores, só eles (e os admins da organização) registram as avaliações contínuas dos controles;
+// os demais usuários comentam e enviam rascunhos de evidência (AssessmentEvidenceDraft).
+type FrameworkCoordinator struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_framework_coordinator,priority:1" json:"organization_id"`
+	FrameworkID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_framework_coordinator,priority:2" json:"framework_id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_framework_coordinator,priority:3;index" json:"user_id"`
+	AssignedByID   *uuid.UUID `gorm:"type:uuid" json:"assigned_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	Organization Organization   `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+	Framework    AuditFramework `gorm:"foreignKey:FrameworkID;constraint:OnDelete:CASCADE;" json:"-"`
+	User         User           `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (f *FrameworkCoordinator) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
+
+// Estados de um rascunho de evidência.
+const (
+	EvidenceDraftPending  = "pending"  // Aguardando um coordenador
+	EvidenceDraftAccepted = "accepted" // Usado como evidência de uma avaliação
+)
+
+// AssessmentEvidenceDraft é um arquivo de evidência enviado para a avaliação contínua de um controle por quem
+// não pode registrá-la. Um coordenador o aceita ao registrar a avaliação com evidence_draft_id.
+type AssessmentEvidenceDraft struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;index:idx_evidence_draft_org_control,priority:1" json:"organization_id"`
+	AuditControlID uuid.UUID  `gorm:"type:uuid;not null;index:idx_evidence_draft_org_control,priority:2" json:"audit_control_id"`
+	UploadedByID   uuid.UUID  `gorm:"type:uuid;not null" json:"uploaded_by_id"`
+	FileName       string     `gorm:"size:255;not null" json:"file_name"`
+	ObjectName     string     `gorm:"size:1024;not null" json:"object_name"`
+	SizeBytes      int64      `json:"size_bytes"`
+	ScanStatus     string     `gorm:"size:20" json:"scan_status,omitempty"`
+	Note           string     `gorm:"type:text" json:"note,omitempty"`
+	Status         string     `gorm:"size:20;not null;default:'pending'" json:"status"`
+	AcceptedByID   *uuid.UUID `gorm:"type:uuid" json:"accepted_by_id,omitempty"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+	AuditControl AuditControl `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (d *AssessmentEvidenceDraft) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
+
+// AssessmentComment é um comentário sobre a avaliação contínua de um controle.
+type AssessmentComment struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index:idx_assessment_comment_org_control,priority:1" json:"organization_id"`
+	AuditControlID uuid.UUID `gorm:"type:uuid;not null;index:idx_assessment_comment_org_control,priority:2" json:"audit_control_id"`
+	AuthorID       uuid.UUID `gorm:"type:uuid;not null" json:"author_id"`
+	Body           string    `gorm:"type:text;not null" json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+	AuditControl AuditControl `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (a *AssessmentComment) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
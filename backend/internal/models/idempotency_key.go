@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey guarda a resposta de uma requisição enviada com o header Idempotency-Key, para que as
+// repetições do cliente recebam a mesma resposta sem executar a operação de novo (ver internal/idempotency).
+// Enquanto a primeira requisição é processada, StatusCode fica 0.
+type IdempotencyKey struct {
+	ID     uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_keys_user_key,priority:1" json:"user_id"`
+	User   User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	Key    string    `gorm:"size:255;not null;uniqueIndex:idx_idempotency_keys_user_key,priority:2" json:"key"`
+	Method string    `gorm:"size:10;not null" json:"method"`
+	Path   string    `gorm:"size:2048;not null" json:"path"`
+	// SHA-256 do método, do caminho e do corpo; a chave não pode ser reaproveitada em outra requisição
+	RequestHash         string     `gorm:"size:64;not null" json:"-"`
+	StatusCode          int        `gorm:"not null;default:0" json:"status_code"`
+	ResponseContentType string     `gorm:"size:255" json:"-"`
+	ResponseBody        []byte     `json:"-"`
+	CompletedAt         *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt           time.Time  `gorm:"not null;index" json:"expires_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+func (k *IdempotencyKey) BeforeCreate(tx *gorm.DB) (err error) {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return
+}
+
+// Completed indica se a resposta da primeira requisição já foi gravada.
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
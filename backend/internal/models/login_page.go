@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationLoginPage configura a tela de login da organização, servida sem autenticação pelo slug ou
+// pelo domínio personalizado para que o frontend exiba a marca, os métodos de login e os avisos legais
+// do tenant antes do login.
+type OrganizationLoginPage struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	Slug           string    `gorm:"size:63;not null;uniqueIndex" json:"slug"`
+	// Host em que a organização serve a tela de login (ex: grc.empresa.com); nulo quando não há
+	CustomDomain   *string `gorm:"size:255;uniqueIndex" json:"custom_domain,omitempty"`
+	WelcomeMessage string  `gorm:"type:text" json:"welcome_message"`
+	// Login por email e senha desabilitado para os usuários da organização (admins mantêm o acesso
+	// por senha como contingência caso o SSO fique indisponível)
+	DisablePasswordLogin bool       `gorm:"not null;default:false" json:"disable_password_login"`
+	TermsOfServiceURL    string     `gorm:"size:500" json:"terms_of_service_url"`
+	PrivacyPolicyURL     string     `gorm:"size:500" json:"privacy_policy_url"`
+	LegalNotice          string     `gorm:"type:text" json:"legal_notice"` // Aviso exibido abaixo do formulário de login
+	UpdatedByID          *uuid.UUID `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
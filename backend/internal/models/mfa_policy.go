@@ -0,0 +1,95 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MFAPolicy define as exigências de segundo fator da organização. Organizações sem registro não exigem nada
+// além do que cada usuário configurou.
+type MFAPolicy struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	// Todos os usuários precisam de um segundo fator (TOTP ou passkey); quem não tiver recebe, no login, um
+	// token restrito ao cadastro de MFA
+	RequireMFAForAll bool `gorm:"column:require_mfa_for_all;not null;default:false" json:"require_mfa_for_all"`
+	// Papéis que precisam de segundo fator quando RequireMFAForAll é false. Lista JSON; use MFARoles/SetMFARoles
+	RequireMFARoles string `gorm:"column:require_mfa_roles;type:jsonb;not null;default:'[]'" json:"-"`
+	// Administradores (admin e system_admin) que entram com senha precisam confirmar o login com uma passkey ou
+	// chave de segurança; TOTP e códigos de backup deixam de valer para eles
+	RequireWebAuthnForAdmins bool       `gorm:"not null;default:false" json:"require_webauthn_for_admins"`
+	UpdatedByID              *uuid.UUID `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt                time.Time  `json:"created_at"`
+	UpdatedAt                time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// LoadMFAPolicy retorna a política de MFA da organização, ou a política vazia (CreatedAt zero) se ela não
+// configurou nenhuma.
+func LoadMFAPolicy(db *gorm.DB, organizationID uuid.UUID) (MFAPolicy, error) {
+	var policy MFAPolicy
+	err := db.Where("organization_id = ?", organizationID).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return MFAPolicy{OrganizationID: organizationID, RequireMFARoles: "[]"}, nil
+	}
+	return policy, err
+}
+
+// MFARoles retorna os papéis que precisam de segundo fator.
+func (p MFAPolicy) MFARoles() []UserRole {
+	roles := []UserRole{}
+	if p.RequireMFARoles != "" {
+		_ = json.Unmarshal([]byte(p.RequireMFARoles), &roles)
+	}
+	return roles
+}
+
+// SetMFARoles grava os papéis que precisam de segundo fator.
+func (p *MFAPolicy) SetMFARoles(roles []UserRole) error {
+	if roles == nil {
+		roles = []UserRole{}
+	}
+	data, err := json.Marshal(roles)
+	if err != nil {
+		return err
+	}
+	p.RequireMFARoles = string(data)
+	return nil
+}
+
+// RequiresWebAuthn indica se o papel precisa confirmar o login com uma passkey.
+func (p MFAPolicy) RequiresWebAuthn(role UserRole) bool {
+	return p.RequireWebAuthnForAdmins && IsAdminRole(role)
+}
+
+// RequiresMFA indica se o papel precisa de algum segundo fator. Exigir passkey dos administradores implica
+// exigir segundo fator deles.
+func (p MFAPolicy) RequiresMFA(role UserRole) bool {
+	if p.RequireMFAForAll || p.RequiresWebAuthn(role) {
+		return true
+	}
+	for _, required := range p.MFARoles() {
+		if required == role {
+			return true
+		}
+	}
+	return false
+}
+
+// EnrollmentRequired indica se falta ao usuário o segundo fator exigido pela política: nesse caso ele só recebe
+// um token restrito ao cadastro de MFA até registrar o fator.
+func (p MFAPolicy) EnrollmentRequired(role UserRole, totpEnabled, hasPasskey bool) bool {
+	if hasPasskey {
+		return false
+	}
+	return p.RequiresWebAuthn(role) || (p.RequiresMFA(role) && !totpEnabled)
+}
+
+// IsAdminRole indica os papéis alcançados pelas exigências de MFA para administradores.
+func IsAdminRole(role UserRole) bool {
+	return role == RoleAdmin || role == RoleSystemAdmin
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFAPolicyRequiresMFA(t *testing.T) {
+	var policy MFAPolicy
+	require.NoError(t, policy.SetMFARoles([]UserRole{RoleManager}))
+	assert.Equal(t, []UserRole{RoleManager}, policy.MFARoles())
+	assert.True(t, policy.RequiresMFA(RoleManager))
+	assert.False(t, policy.RequiresMFA(RoleUser))
+	assert.False(t, policy.RequiresMFA(RoleAdmin))
+
+	policy.RequireWebAuthnForAdmins = true
+	assert.True(t, policy.RequiresMFA(RoleAdmin), "requiring passkeys from administrators requires a second factor")
+
+	policy.RequireMFAForAll = true
+	assert.True(t, policy.RequiresMFA(RoleUser))
+}
+
+func TestMFAPolicyEnrollmentRequired(t *testing.T) {
+	policy := MFAPolicy{RequireMFAForAll: true}
+	assert.True(t, policy.EnrollmentRequired(RoleUser, false, false))
+	assert.False(t, policy.EnrollmentRequired(RoleUser, true, false))
+	assert.False(t, policy.EnrollmentRequired(RoleUser, false, true))
+
+	policy = MFAPolicy{RequireWebAuthnForAdmins: true}
+	assert.True(t, policy.EnrollmentRequired(RoleAdmin, true, false), "TOTP does not satisfy a passkey requirement")
+	assert.False(t, policy.EnrollmentRequired(RoleAdmin, false, true))
+	assert.False(t, policy.EnrollmentRequired(RoleManager, false, false))
+
+	assert.Empty(t, MFAPolicy{}.MFARoles())
+	assert.False(t, MFAPolicy{}.EnrollmentRequired(RoleAdmin, false, false))
+}
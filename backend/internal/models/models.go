@@ -64,6 +64,7 @@ const (
 	ControlStatusNonConformant      AuditControlStatus = "nao_conforme"
 	ControlStatusPartiallyConformant AuditControlStatus = "parcialmente_conforme"
 	ControlStatusNotApplicable      AuditControlStatus = "nao_aplicavel"
+	ControlStatusWaived             AuditControlStatus = "dispensado" // Exceção (ControlWaiver) aprovada e vigente
 
 	CategoryTechnological RiskCategory = "tecnologico"
 	CategoryOperational   RiskCategory = "operacional"
@@ -84,6 +85,7 @@ type Organization struct {
 	LogoURL        string    `gorm:"size:255"`
 	PrimaryColor   string    `gorm:"size:7"` // #RRGGBB
 	SecondaryColor string    `gorm:"size:7"` // #RRGGBB
+	ParentID       *uuid.UUID `gorm:"type:uuid;index"` // Organização pai (unidade de negócio de um grupo); ver orghierarchy
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	Users          []User          `gorm:"foreignKey:OrganizationID"`
@@ -111,9 +113,15 @@ type User struct {
 	SocialLoginID  string    `gorm:"size:100"`
 	Role           UserRole  `gorm:"type:varchar(20);not null;default:'user'"`
 	IsActive       bool      `gorm:"default:true;not null;index"` // Novo campo para status do usuário
+	CustomRoleID   *uuid.UUID `gorm:"type:uuid;index"` // Papel personalizado da organização, somado ao Role (ver rbac)
 	TOTPSecret     string    `gorm:"size:255"` // Armazenar criptografado! No DB será string.
 	IsTOTPEnabled  bool      `gorm:"default:false;not null"`
 	TOTPBackupCodes string   `gorm:"type:text"` // JSON array de hashes dos códigos de backup
+	PasswordChangedAt *time.Time `gorm:"type:timestamptz"` // Última definição de senha; nulo usa CreatedAt (ver passwordpolicy.Expired)
+	FailedLoginAttempts int        `gorm:"not null;default:0"` // Falhas seguidas de login na janela de bloqueio (ver auth.RecordLoginFailure)
+	LastFailedLoginAt   *time.Time `gorm:"type:timestamptz"`
+	LockedUntil         *time.Time `gorm:"type:timestamptz"` // Login bloqueado até este instante
+	AnonymizedAt        *time.Time `gorm:"type:timestamptz"` // Dados pessoais removidos (ver privacy.AnonymizeUser); o registro é mantido pelas referências
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	AuthoredRisks  []Risk `gorm:"foreignKey:OwnerID"` // Risks where this user is the owner
@@ -129,9 +137,15 @@ func (user *User) BeforeCreate(tx *gorm.DB) (err error) {
 	return
 }
 
+// IsLocked indica se o login do usuário está bloqueado no instante informado.
+func (user *User) IsLocked(now time.Time) bool {
+	return user.LockedUntil != nil && now.Before(*user.LockedUntil)
+}
+
 type Risk struct {
 	ID             uuid.UUID       `gorm:"type:uuid;primary_key;"`
-	OrganizationID uuid.UUID       `gorm:"type:uuid;not null;index;constraint:OnDelete:CASCADE;"`
+	OrganizationID uuid.UUID       `gorm:"type:uuid;not null;index;constraint:OnDelete:CASCADE;uniqueIndex:idx_risks_org_reference_code,priority:1"`
+	ReferenceCode  string          `gorm:"size:20;uniqueIndex:idx_risks_org_reference_code,priority:2"` // Código sequencial da organização, ex: RISK-0042
 	Title          string          `gorm:"size:255;not null"`
 	Description    string          `gorm:"type:text"`
 	Category       RiskCategory    `gorm:"type:varchar(50)"`
@@ -139,9 +153,12 @@ type Risk struct {
 	Probability    RiskProbability `gorm:"type:varchar(20)"`
 	RiskLevel      string          `gorm:"type:varchar(20);default:'Indefinido'"` // Nível de Risco Calculado
 	Status         RiskStatus      `gorm:"type:varchar(20);default:'aberto';index"`
+	StatusCode     *string         `gorm:"type:varchar(30)"` // Status definido em StatusDefinition que refina Status; nulo para os nativos
 	OwnerID        uuid.UUID       `gorm:"type:uuid;constraint:OnDelete:SET NULL;"` // FK to User
+	OwnerTeamID    *uuid.UUID      `gorm:"type:uuid;index"` // Equipe dona do risco (opcional): os membros agem e são notificados como o responsável
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt    `gorm:"index"` // Exclusão lógica: o risco fica na lixeira até ser restaurado ou expurgado
 	Owner          User              `gorm:"foreignKey:OwnerID"` // Relação Belongs To User
 	Stakeholders   []RiskStakeholder `gorm:"foreignKey:RiskID;constraint:OnDelete:CASCADE;"`
 	ApprovalWorkflows []ApprovalWorkflow `gorm:"foreignKey:RiskID;constraint:OnDelete:CASCADE;"`
@@ -151,18 +168,23 @@ func (risk *Risk) BeforeCreate(tx *gorm.DB) (err error) {
 	if risk.ID == uuid.Nil {
 		risk.ID = uuid.New()
 	}
-	return
+	return assignReferenceCode(tx, &risk.ReferenceCode, risk.OrganizationID, ReferenceRisk)
 }
 
 type Vulnerability struct {
 	ID             uuid.UUID             `gorm:"type:uuid;primary_key;"`
-	OrganizationID uuid.UUID             `gorm:"type:uuid;not null;index;constraint:OnDelete:CASCADE;"`
+	OrganizationID uuid.UUID             `gorm:"type:uuid;not null;index;constraint:OnDelete:CASCADE;uniqueIndex:idx_vulnerabilities_org_reference_code,priority:1"`
+	ReferenceCode  string                `gorm:"size:20;uniqueIndex:idx_vulnerabilities_org_reference_code,priority:2"` // Código sequencial do achado na organização, ex: FND-0007
 	Title          string                `gorm:"size:255;not null"`
 	Description    string                `gorm:"type:text"`
 	CVEID          string                `gorm:"size:50;index"` // Optional
 	Severity       VulnerabilitySeverity `gorm:"type:varchar(20);index"`
 	Status         VulnerabilityStatus   `gorm:"type:varchar(20);default:'descoberta';index"`
 	AssetAffected  string                `gorm:"size:255"`
+	CVSSScore      *float64              `gorm:"type:numeric(3,1)"` // Nota CVSS informada pelo scanner (importação)
+	Source         string                `gorm:"size:50"`           // Origem da importação: nessus, qualys ou a ferramenta do SARIF
+	RiskID         *uuid.UUID            `gorm:"type:uuid;index"`   // Risco criado automaticamente a partir da vulnerabilidade
+	LastSeenAt     *time.Time            // Última importação em que o achado apareceu
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -171,7 +193,7 @@ func (vuln *Vulnerability) BeforeCreate(tx *gorm.DB) (err error) {
 	if vuln.ID == uuid.Nil {
 		vuln.ID = uuid.New()
 	}
-	return
+	return assignReferenceCode(tx, &vuln.ReferenceCode, vuln.OrganizationID, ReferenceVulnerability)
 }
 
 // Join table for many-to-many relationship between Risks and Users (Stakeholders)
@@ -183,18 +205,24 @@ type RiskStakeholder struct {
 	CreatedAt time.Time
 }
 
+// ApprovalWorkflow é uma solicitação de aprovação: aceite de risco (RiskID), exceção de controle (ControlWaiverID)
+// ou ação administrativa destrutiva (AdminActionRequestID).
 type ApprovalWorkflow struct {
-	ID          uuid.UUID      `gorm:"type:uuid;primary_key;"`
-	RiskID      uuid.UUID      `gorm:"type:uuid;not null;constraint:OnDelete:CASCADE;"`
-	RequesterID uuid.UUID      `gorm:"type:uuid;constraint:OnDelete:SET NULL;"` // FK to User
-	ApproverID  uuid.UUID      `gorm:"type:uuid;constraint:OnDelete:SET NULL;"` // FK to User
-	Status      ApprovalStatus `gorm:"type:varchar(20);default:'pendente'"`
-	Comments    string         `gorm:"type:text"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Risk        Risk           `gorm:"foreignKey:RiskID"`
-	Requester   User           `gorm:"foreignKey:RequesterID"`
-	Approver    User           `gorm:"foreignKey:ApproverID"`
+	ID                   uuid.UUID      `gorm:"type:uuid;primary_key;"`
+	OrganizationID       uuid.UUID      `gorm:"type:uuid;index"`
+	RiskID               *uuid.UUID     `gorm:"type:uuid;index;constraint:OnDelete:CASCADE;"`
+	ControlWaiverID      *uuid.UUID     `gorm:"type:uuid;index"`
+	AdminActionRequestID *uuid.UUID     `gorm:"type:uuid;index"`
+	RequesterID          uuid.UUID      `gorm:"type:uuid;constraint:OnDelete:SET NULL;"` // FK to User
+	ApproverID           uuid.UUID      `gorm:"type:uuid;constraint:OnDelete:SET NULL;"` // FK to User
+	Status               ApprovalStatus `gorm:"type:varchar(20);default:'pendente'"`
+	Comments             string         `gorm:"type:text"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Risk                 Risk           `gorm:"foreignKey:RiskID"`
+	ControlWaiver        *ControlWaiver `gorm:"foreignKey:ControlWaiverID;constraint:OnDelete:CASCADE;" json:",omitempty"`
+	Requester            User           `gorm:"foreignKey:RequesterID"`
+	Approver             User           `gorm:"foreignKey:ApproverID"`
 }
 
 func (aw *ApprovalWorkflow) BeforeCreate(tx *gorm.DB) (err error) {
@@ -206,7 +234,10 @@ func (aw *ApprovalWorkflow) BeforeCreate(tx *gorm.DB) (err error) {
 
 type AuditFramework struct {
 	ID             uuid.UUID `gorm:"type:uuid;primary_key;"`
-	Name           string    `gorm:"size:255;not null;uniqueIndex"` // NIST CSF 2.0, CIS Controls v8, etc.
+	Name           string    `gorm:"size:255;not null;index"` // NIST CSF 2.0, CIS Controls v8, etc. Único entre os frameworks semeados e por organização
+	Description    string    `gorm:"type:text"`
+	Version        string    `gorm:"size:50"`
+	OrganizationID *uuid.UUID `gorm:"type:uuid;index"` // Framework personalizado da organização; nulo = semeado (somente leitura)
 	AuditControls  []AuditControl `gorm:"foreignKey:FrameworkID;constraint:OnDelete:CASCADE;"`
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
@@ -225,6 +256,10 @@ type AuditControl struct {
 	ControlID   string    `gorm:"size:50;not null"` // e.g., AC-1, PR.IP-2
 	Description string    `gorm:"type:text"`
 	Family      string    `gorm:"size:100"` // e.g., Access Control, Identify
+	Position    *int      `gorm:"index"`          // Sequência oficial do controle no framework (nulo = ordem natural do ControlID)
+	SortKey     string    `gorm:"size:255;index"` // Chave de ordenação natural do ControlID (ver ControlSortKey)
+	// Orientação ao avaliador em JSON (ControlGuidance): o que é exigido, exemplos de evidências e referências
+	Guidance    *string   `gorm:"type:jsonb" json:"-"`
 	Framework   AuditFramework `gorm:"foreignKey:FrameworkID;constraint:OnDelete:CASCADE;"` // Se o Framework for deletado, os controles também são.
 	AuditAssessments []AuditAssessment `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;"`
 	CreatedAt   time.Time
@@ -239,19 +274,50 @@ func (ac *AuditControl) BeforeCreate(tx *gorm.DB) (err error) {
 	return
 }
 
+// BeforeSave mantém a chave de ordenação natural em sincronia com o ControlID.
+func (ac *AuditControl) BeforeSave(tx *gorm.DB) (err error) {
+	ac.SortKey = ControlSortKey(ac.ControlID)
+	return
+}
+
 
 type AuditAssessment struct {
 	ID             uuid.UUID          `gorm:"type:uuid;primary_key;"`
-	OrganizationID uuid.UUID          `gorm:"type:uuid;not null;index;constraint:OnDelete:CASCADE;"`
+	OrganizationID uuid.UUID          `gorm:"type:uuid;not null;index;constraint:OnDelete:CASCADE;uniqueIndex:idx_audit_assessments_org_control,where:audit_campaign_id IS NULL;uniqueIndex:idx_audit_assessments_campaign_control,where:audit_campaign_id IS NOT NULL"`
 	// Storing AuditControl's UUID for a more robust FK relationship
-	AuditControlID uuid.UUID          `gorm:"type:uuid;not null;index"` // FK to AuditControl's ID
+	AuditControlID uuid.UUID          `gorm:"type:uuid;not null;index;uniqueIndex:idx_audit_assessments_org_control,where:audit_campaign_id IS NULL;uniqueIndex:idx_audit_assessments_campaign_control,where:audit_campaign_id IS NOT NULL"` // FK to AuditControl's ID
+	// Auditoria (campanha) à qual a avaliação pertence; nulo para a avaliação contínua da organização.
+	// Há no máximo uma avaliação contínua e uma por auditoria para cada controle.
+	AuditCampaignID *uuid.UUID `gorm:"type:uuid;index;uniqueIndex:idx_audit_assessments_campaign_control,where:audit_campaign_id IS NOT NULL" json:"audit_campaign_id,omitempty"`
 	Status         AuditControlStatus `gorm:"type:varchar(30)" json:"status"`
+	StatusCode     *string            `gorm:"type:varchar(30)" json:"status_code,omitempty"` // Status definido em StatusDefinition que refina Status; nulo para os nativos
 	EvidenceURL    string             `gorm:"size:255" json:"evidence_url"`
+	// Resultado da verificação antivírus do último arquivo de evidência enviado (ver pacote avscan)
+	EvidenceScanStatus    string     `gorm:"size:20" json:"evidence_scan_status,omitempty"`
+	EvidenceScanSignature string     `gorm:"size:255" json:"evidence_scan_signature,omitempty"`
+	EvidenceScannedAt     *time.Time `gorm:"type:timestamptz" json:"evidence_scanned_at,omitempty"`
+	PolicyID              *uuid.UUID `gorm:"type:uuid;index" json:"policy_id,omitempty"` // Política que atende o controle (opcional)
 	Score          *int               `json:"score,omitempty"` // Integer score, ponteiro para ser omitempty
 	AssessmentDate *time.Time         `gorm:"type:timestamptz" json:"assessment_date,omitempty"` // Ponteiro para ser omitempty
+	Comments       *string            `gorm:"type:text" json:"comments,omitempty"`                 // Comentários da avaliação
+	// Narrativa da organização sobre como o controle é implementado (apresentada no dossiê aos auditores)
+	ImplementationNarrative *string `gorm:"type:text" json:"implementation_narrative,omitempty"`
 	CreatedAt      time.Time          `json:"created_at"`
 	UpdatedAt      time.Time          `json:"updated_at"`
 
+	// Atribuição do controle a um avaliador (campanhas distribuídas). Uma avaliação sem Status foi apenas
+	// atribuída (ou recebeu evidência) e ainda não conta como controle avaliado no score.
+	AssignedToID          *uuid.UUID `gorm:"type:uuid;index" json:"assigned_to_id,omitempty"`
+	AssignedTeamID        *uuid.UUID `gorm:"type:uuid;index" json:"assigned_team_id,omitempty"` // Equipe responsável pelo controle
+	AssignedByID          *uuid.UUID `gorm:"type:uuid" json:"assigned_by_id,omitempty"`
+	AssignedAt            *time.Time `gorm:"type:timestamptz" json:"assigned_at,omitempty"`
+	DueDate               *time.Time `gorm:"type:date;index" json:"due_date,omitempty"`
+	AssignmentCompletedAt *time.Time `gorm:"type:timestamptz" json:"assignment_completed_at,omitempty"` // Avaliação enviada após a atribuição
+	LastDueReminderAt     *time.Time `gorm:"type:timestamptz" json:"-"`
+	// Nova data prevista informada pelo avaliador ao responder um lembrete (ver AssessmentReminderAck); os
+	// lembretes passam a considerar esta data no lugar de DueDate
+	ReminderTargetDate    *time.Time `gorm:"type:date" json:"reminder_target_date,omitempty"`
+
 	// Campos para Maturidade C2M2
 	C2M2AssessmentDate *time.Time `gorm:"type:timestamptz" json:"c2m2_assessment_date,omitempty"` // Data da avaliação de maturidade C2M2
 	C2M2Comments      *string    `gorm:"type:text" json:"c2m2_comments,omitempty"`         // Comentários da avaliação C2M2
@@ -375,6 +441,7 @@ type WebhookEventType string
 const (
 	EventTypeRiskCreated        WebhookEventType = "risk_created"
 	EventTypeRiskStatusChanged  WebhookEventType = "risk_status_changed"
+	EventTypeRiskOwnerChanged   WebhookEventType = "risk_owner_changed"
 	// Adicionar outros tipos de evento conforme necessário
 )
 
@@ -424,6 +491,117 @@ func AutoMigrateDB(db *gorm.DB) error {
 		&C2M2Domain{},
 		&C2M2Practice{},
 		&C2M2PracticeEvaluation{},
+		// Access Reviews
+		&AccessReviewCampaign{},
+		&AccessReviewItem{},
+		// Trust Center
+		&TrustCenterProfile{},
+		// Edit Locks
+		&EditLock{},
+		// Compliance History
+		&AuditAssessmentHistory{},
+		&ComplianceScoreSnapshot{},
+		// Policies
+		&Policy{},
+		&PolicyVersion{},
+		&PolicyControlMapping{},
+		&PolicyAcknowledgment{},
+		&PolicyAckCampaign{},
+		&PolicyAckCampaignTarget{},
+		// Control Testing Planner
+		&ControlTestPlan{},
+		&ControlTestCapacity{},
+		// Organization Secrets Vault
+		&OrgSecret{},
+		// Notification Routing
+		&NotificationRoute{},
+		// Vendors & Security Questionnaires
+		&Vendor{},
+		&Questionnaire{},
+		&QuestionnaireSection{},
+		&QuestionnaireQuestion{},
+		&QuestionnaireInvitation{},
+		&QuestionnaireAnswer{},
+		// Asset Inventory
+		&Asset{},
+		&AssetRisk{},
+		&AssetControl{},
+		// API Usage Analytics
+		&APIUsageCounter{},
+		// Risk Notification Filters
+		&RiskNotificationFilter{},
+		// Business Continuity / BIA
+		&VendorRisk{},
+		&BusinessProcess{},
+		&BusinessProcessAsset{},
+		&BusinessProcessVendor{},
+		// Evidence Request Portal
+		&EvidenceRequest{},
+		&EvidenceSubmission{},
+		&EvidencePortalLink{},
+		// Control-to-Risk Mapping
+		&RiskControl{},
+		// Control Ordering
+		&OrgControlOrder{},
+		// Cross-Framework Control Mapping
+		&ControlMapping{},
+		// Assessment Score Rubrics
+		&AssessmentScoreRubric{},
+		// Configuration Change History
+		&ConfigChange{},
+		// Bulk Operation Log
+		&BulkOperationLog{},
+		// Feature Flags
+		&FeatureFlag{},
+		&FeatureFlagRule{},
+		// Compliance Score Tallies (score incremental)
+		&ComplianceScoreTally{},
+		// Audit Campaigns (auditorias com escopo, janela e participantes)
+		&AuditCampaign{},
+		&AuditCampaignScopeFamily{},
+		&AuditCampaignScopeAsset{},
+		&AuditCampaignParticipant{},
+		// Login Page (tela de login por organização)
+		&OrganizationLoginPage{},
+		// Audit Trail (trilha de auditoria da organização)
+		&AuditTrailEntry{},
+		// Control Waivers (exceções de controles)
+		&ControlWaiver{},
+		&ControlWaiverCompensatingControl{},
+		// Risk Scoring Matrix (matriz de pontuação de riscos da organização)
+		&RiskScoringMatrix{},
+		// Admin Action Requests (regra das duas pessoas)
+		&AdminActionRequest{},
+		&RiskCategoryDefinition{},
+		&AssessmentReminderAck{},
+		&AuditTrailExport{},
+		&Tag{},
+		&EntityTag{},
+		&OrgSecretPolicy{},
+		&SavedView{},
+		&OrgSequence{},
+		&ReportSchedule{},
+		&ExecutiveReportTemplate{},
+		&EvidenceAccessPolicy{}, &BackgroundJob{},
+		&FrameworkCoordinator{}, &AssessmentEvidenceDraft{}, &AssessmentComment{},
+		&StatusDefinition{}, &StatusTransition{},
+		&EmailBranding{},
+		&Notification{},
+		&AuthSession{},
+		&APIKey{},
+		&CustomRole{},
+		&Team{},
+		&TeamMember{},
+		&UserInvitation{},
+		&PasswordPolicy{},
+		&WebAuthnCredential{},
+		&WebAuthnChallenge{},
+		&MFAPolicy{},
+		&SAMLCertificate{},
+		&VerifiedDomain{},
+		&IdempotencyKey{},
+		&DataRetentionRule{},
+		&OrganizationExport{},
 	)
 	return err
 }
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notification é um item da central de notificações do usuário (GET /me/notifications). É registrada junto
+// com os e-mails de notificação direcionados ao usuário, para que ele veja aprovações pendentes, riscos
+// atribuídos e menções ao entrar na aplicação, mesmo que o e-mail não chegue.
+type Notification struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index:idx_notifications_user_created,priority:1" json:"user_id"`
+	OrganizationID *uuid.UUID `gorm:"type:uuid;index" json:"organization_id,omitempty"`
+	Type           string     `gorm:"size:50;not null" json:"type"` // Modelo de e-mail que originou a notificação (ex: risk_created)
+	Title          string     `gorm:"size:255;not null" json:"title"`
+	Body           string     `gorm:"type:text" json:"body"`
+	Link           string     `gorm:"size:500" json:"link,omitempty"`       // URL no frontend do item notificado
+	ReadAt         *time.Time `gorm:"index" json:"read_at,omitempty"`       // Nulo enquanto não lida
+	SnoozedUntil   *time.Time `gorm:"index" json:"snoozed_until,omitempty"` // Oculta da central e do contador até esta data
+	DismissedAt    *time.Time `gorm:"index" json:"-"`                       // Descartada pelo usuário; não volta a aparecer
+	CreatedAt      time.Time  `gorm:"index:idx_notifications_user_created,priority:2" json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return
+}
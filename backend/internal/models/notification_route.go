@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationRouteAnyEvent faz a regra valer para todos os eventos.
+const NotificationRouteAnyEvent = "*"
+
+// NotificationRoute é uma regra de roteamento por organização: quando o evento ocorre, a notificação
+// é entregue pelo canal informado ao destino (e-mail, URL de webhook ou telefone).
+// O destino pode vir do cofre de credenciais (TargetSecretName), ex: a URL do webhook do Slack.
+type NotificationRoute struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID   uuid.UUID `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Name             string    `gorm:"size:100;not null" json:"name"`
+	EventType        string    `gorm:"size:100;not null;index" json:"event_type"` // Ex: "risk_created" ou "*"
+	Channel          string    `gorm:"size:30;not null" json:"channel"`           // email, slack, teams, webhook, sms
+	Target           string    `gorm:"size:2048" json:"target,omitempty"`
+	TargetSecretName string    `gorm:"size:100" json:"target_secret_name,omitempty"`
+	IsActive         bool      `gorm:"not null" json:"is_active"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func (nr *NotificationRoute) BeforeCreate(tx *gorm.DB) (err error) {
+	if nr.ID == uuid.Nil {
+		nr.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+
+	"phoenixgrc/backend/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrgSecretKind classifica a credencial armazenada no cofre da organização.
+type OrgSecretKind string
+
+const (
+	OrgSecretJiraToken    OrgSecretKind = "jira_token"
+	OrgSecretSlackWebhook OrgSecretKind = "slack_webhook"
+	OrgSecretTeamsWebhook OrgSecretKind = "teams_webhook"
+	OrgSecretAWSRole      OrgSecretKind = "aws_role"
+	OrgSecretAPIKey       OrgSecretKind = "api_key"
+	OrgSecretGeneric      OrgSecretKind = "generic"
+)
+
+// OrgSecret é uma credencial de integração da organização, criptografada com ENCRYPTION_KEY_HEX (AES-GCM).
+// O valor nunca é serializado; leituras pela API retornam apenas ValueHint (últimos caracteres).
+type OrgSecret struct {
+	ID                     uuid.UUID     `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID         uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_org_secret_name" json:"organization_id"`
+	Name                   string        `gorm:"size:100;not null;uniqueIndex:idx_org_secret_name" json:"name"` // Ex: "jira-prod"
+	Kind                   OrgSecretKind `gorm:"type:varchar(50);not null" json:"kind"`
+	Description            string        `gorm:"size:255" json:"description,omitempty"`
+	EncryptedValue         string        `gorm:"type:text;not null" json:"-"`
+	ValueHint              string        `gorm:"size:20" json:"value_hint"`
+	RotationIntervalDays   int           `gorm:"not null;default:0" json:"rotation_interval_days"` // 0 = sem rotação obrigatória
+	LastRotatedAt          time.Time     `gorm:"type:timestamptz;not null" json:"last_rotated_at"`
+	RotationDueAt          *time.Time    `gorm:"type:timestamptz;index" json:"rotation_due_at,omitempty"`
+	RotationReminderSentAt *time.Time    `gorm:"type:timestamptz" json:"rotation_reminder_sent_at,omitempty"`
+	UsageCount             int64         `gorm:"not null;default:0" json:"usage_count"`
+	LastUsedAt             *time.Time    `gorm:"type:timestamptz" json:"last_used_at,omitempty"`
+	LastUsedBy             string        `gorm:"size:100" json:"last_used_by,omitempty"` // Integração que leu o valor
+	CreatedByID            uuid.UUID     `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt              time.Time     `json:"created_at"`
+	UpdatedAt              time.Time     `json:"updated_at"`
+}
+
+func (s *OrgSecret) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// SetValue criptografa o valor, atualiza a dica mascarada e reinicia o ciclo de rotação.
+func (s *OrgSecret) SetValue(plaintext string) error {
+	encrypted, err := utils.Encrypt(plaintext)
+	if err != nil {
+		return err
+	}
+	s.EncryptedValue = encrypted
+	s.ValueHint = MaskSecretValue(plaintext)
+	s.LastRotatedAt = time.Now()
+	s.RotationReminderSentAt = nil
+	s.ScheduleRotation()
+	return nil
+}
+
+// ScheduleRotation recalcula RotationDueAt a partir da última rotação.
+func (s *OrgSecret) ScheduleRotation() {
+	if s.RotationIntervalDays <= 0 {
+		s.RotationDueAt = nil
+		return
+	}
+	due := s.LastRotatedAt.AddDate(0, 0, s.RotationIntervalDays)
+	s.RotationDueAt = &due
+}
+
+// DecryptValue retorna o valor em texto claro.
+func (s *OrgSecret) DecryptValue() (string, error) {
+	return utils.Decrypt(s.EncryptedValue)
+}
+
+// MaskSecretValue retorna uma representação segura para exibição (ex: "••••abcd").
+// Valores curtos são totalmente mascarados.
+func MaskSecretValue(value string) string {
+	if len(value) < 12 {
+		return "••••"
+	}
+	return "••••" + value[len(value)-4:]
+}
+
+// OrgSecretEnforcement define o que acontece quando uma credencial ultrapassa a idade máxima da política.
+type OrgSecretEnforcement string
+
+const (
+	OrgSecretEnforcementWarn  OrgSecretEnforcement = "warn"  // Apenas sinaliza no inventário e nos lembretes
+	OrgSecretEnforcementBlock OrgSecretEnforcement = "block" // Além disso, as integrações deixam de ler o valor
+)
+
+// OrgSecretPolicy é a política de higiene de credenciais da organização: a idade máxima (desde a última
+// rotação) das credenciais do cofre. Sem política configurada não há idade máxima.
+type OrgSecretPolicy struct {
+	ID             uuid.UUID            `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex" json:"organization_id"`
+	MaxAgeDays     int                  `gorm:"not null" json:"max_age_days"`
+	Enforcement    OrgSecretEnforcement `gorm:"type:varchar(20);not null;default:'warn'" json:"enforcement"`
+	UpdatedByID    *uuid.UUID           `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (p *OrgSecretPolicy) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReferenceEntityType identifica as entidades com código de referência sequencial por organização.
+type ReferenceEntityType string
+
+const (
+	ReferenceRisk          ReferenceEntityType = "risk"
+	ReferenceVulnerability ReferenceEntityType = "vulnerability" // Achados (FND)
+	ReferencePolicy        ReferenceEntityType = "policy"
+)
+
+// ReferencePrefixes são os prefixos dos códigos de referência (ex: RISK-0042).
+var ReferencePrefixes = map[ReferenceEntityType]string{
+	ReferenceRisk:          "RISK",
+	ReferenceVulnerability: "FND",
+	ReferencePolicy:        "POL",
+}
+
+// OrgSequence é o último número de referência usado por tipo de entidade na organização.
+type OrgSequence struct {
+	OrganizationID uuid.UUID           `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	EntityType     ReferenceEntityType `gorm:"type:varchar(30);primaryKey" json:"entity_type"`
+	LastValue      int64               `gorm:"not null;default:0" json:"last_value"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// FormatReferenceCode formata o código com ao menos quatro dígitos (RISK-0042, RISK-12345).
+func FormatReferenceCode(entityType ReferenceEntityType, n int64) string {
+	return fmt.Sprintf("%s-%04d", ReferencePrefixes[entityType], n)
+}
+
+var referenceCodePattern = regexp.MustCompile(`^([A-Za-z]+)-0*(\d+)$`)
+
+// ParseReferenceCode interpreta um código de referência, sem diferenciar maiúsculas e aceitando zeros à
+// esquerda a mais ou a menos (risk-42 equivale a RISK-0042). Retorna o código normalizado.
+func ParseReferenceCode(code string) (ReferenceEntityType, string, bool) {
+	match := referenceCodePattern.FindStringSubmatch(strings.TrimSpace(code))
+	if match == nil {
+		return "", "", false
+	}
+	n, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil || n <= 0 {
+		return "", "", false
+	}
+	prefix := strings.ToUpper(match[1])
+	for entityType, p := range ReferencePrefixes {
+		if p == prefix {
+			return entityType, FormatReferenceCode(entityType, n), true
+		}
+	}
+	return "", "", false
+}
+
+// NextReferenceCode reserva o próximo número do tipo na organização e retorna o código. O incremento é
+// atômico (a linha da sequência fica bloqueada até o fim da transação) e é desfeito se a transação falhar.
+func NextReferenceCode(tx *gorm.DB, organizationID uuid.UUID, entityType ReferenceEntityType) (string, error) {
+	var next int64
+	err := tx.Session(&gorm.Session{NewDB: true}).Raw(
+		"INSERT INTO org_sequences (organization_id, entity_type, last_value, updated_at) VALUES (?, ?, 1, NOW()) "+
+			"ON CONFLICT (organization_id, entity_type) DO UPDATE SET last_value = org_sequences.last_value + 1, updated_at = NOW() "+
+			"RETURNING last_value", organizationID, entityType).Scan(&next).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate %s reference code: %w", entityType, err)
+	}
+	return FormatReferenceCode(entityType, next), nil
+}
+
+// assignReferenceCode preenche o código de referência de uma entidade nova, se ainda não tiver um.
+func assignReferenceCode(tx *gorm.DB, code *string, organizationID uuid.UUID, entityType ReferenceEntityType) error {
+	if *code != "" {
+		return nil
+	}
+	next, err := NextReferenceCode(tx, organizationID, entityType)
+	if err != nil {
+		return err
+	}
+	*code = next
+	return nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatReferenceCode(t *testing.T) {
+	assert.Equal(t, "RISK-0042", FormatReferenceCode(ReferenceRisk, 42))
+	assert.Equal(t, "FND-0007", FormatReferenceCode(ReferenceVulnerability, 7))
+	assert.Equal(t, "POL-12345", FormatReferenceCode(ReferencePolicy, 12345), "não trunca acima de quatro dígitos")
+}
+
+func TestParseReferenceCode(t *testing.T) {
+	entityType, code, ok := ParseReferenceCode(" risk-42 ")
+	assert.True(t, ok)
+	assert.Equal(t, ReferenceRisk, entityType)
+	assert.Equal(t, "RISK-0042", code)
+
+	entityType, code, ok = ParseReferenceCode("FND-000007")
+	assert.True(t, ok)
+	assert.Equal(t, ReferenceVulnerability, entityType)
+	assert.Equal(t, "FND-0007", code)
+
+	_, code, ok = ParseReferenceCode("pol-12345")
+	assert.True(t, ok)
+	assert.Equal(t, "POL-12345", code)
+
+	for _, invalid := range []string{"", "RISK", "RISK-", "RISK-0", "ABC-12", "RISK-12a", "risco de backup"} {
+		_, _, ok := ParseReferenceCode(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrganizationExportStatus é a situação de uma exportação completa da organização.
+type OrganizationExportStatus string
+
+const (
+	OrganizationExportPending   OrganizationExportStatus = "pending"
+	OrganizationExportRunning   OrganizationExportStatus = "running"
+	OrganizationExportCompleted OrganizationExportStatus = "completed"
+	OrganizationExportFailed    OrganizationExportStatus = "failed"
+	OrganizationExportExpired   OrganizationExportStatus = "expired" // O arquivo foi removido do armazenamento
+)
+
+// OrganizationExport é uma exportação completa dos dados da organização (riscos, avaliações, manifesto de
+// evidências, políticas e usuários), gerada em segundo plano como um ZIP no armazenamento de arquivos (ver o
+// pacote orgexport). O arquivo fica disponível para download até ExpiresAt.
+type OrganizationExport struct {
+	ID             uuid.UUID                `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID                `gorm:"type:uuid;not null;index" json:"organization_id"`
+	RequestedByID  uuid.UUID                `gorm:"type:uuid;not null" json:"requested_by_id"`
+	Status         OrganizationExportStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	ObjectName     string                   `gorm:"size:512" json:"-"` // Chave do ZIP no provedor de armazenamento
+	SizeBytes      int64                    `gorm:"not null;default:0" json:"size_bytes"`
+	SHA256         string                   `gorm:"column:sha256;size:64" json:"sha256,omitempty"` // Do ZIP inteiro
+	Manifest       *string                  `gorm:"type:jsonb" json:"-"`                           // orgexport.Manifest, preenchido ao concluir
+	Error          string                   `gorm:"type:text" json:"error,omitempty"`
+	StartedAt      *time.Time               `json:"started_at,omitempty"`
+	CompletedAt    *time.Time               `json:"completed_at,omitempty"`
+	ExpiresAt      *time.Time               `gorm:"index" json:"expires_at,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (e *OrganizationExport) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordPolicy define as regras das senhas dos usuários da organização: tamanho mínimo, complexidade,
+// verificação em bases de senhas vazadas e validade. Organizações sem registro usam a política padrão
+// (ver passwordpolicy.Default).
+type PasswordPolicy struct {
+	OrganizationID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	MinLength        int       `gorm:"not null" json:"min_length"`
+	RequireUppercase bool      `gorm:"not null" json:"require_uppercase"`
+	RequireLowercase bool      `gorm:"not null" json:"require_lowercase"`
+	RequireDigit     bool      `gorm:"not null" json:"require_digit"`
+	RequireSymbol    bool      `gorm:"not null" json:"require_symbol"`
+	// Consulta a senha na API Pwned Passwords por k-anonimato: só os 5 primeiros caracteres do hash SHA-1 saem do servidor
+	CheckBreached bool `gorm:"not null" json:"check_breached"`
+	// Dias até a senha expirar e precisar ser trocada no login; 0 desativa a expiração
+	MaxAgeDays  int        `gorm:"not null" json:"max_age_days"`
+	UpdatedByID *uuid.UUID `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
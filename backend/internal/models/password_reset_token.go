@@ -7,11 +7,13 @@ import (
 	"gorm.io/gorm"
 )
 
-// PasswordResetToken armazena tokens para a funcionalidade de "esqueci minha senha".
+// PasswordResetToken armazena tokens para a funcionalidade de "esqueci minha senha". Só o hash (SHA-256) do
+// token enviado por e-mail é guardado; o token é removido (soft delete) ao ser usado, e os registros
+// removidos continuam contando no limite de pedidos por conta.
 type PasswordResetToken struct {
 	gorm.Model
-	Token     string    `gorm:"type:varchar(255);uniqueIndex;not null"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
 	User      User      `gorm:"foreignKey:UserID"`
 	ExpiresAt time.Time `gorm:"not null"`
 }
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PolicyStatus é o ciclo de vida de uma política.
+type PolicyStatus string
+
+const (
+	PolicyStatusDraft    PolicyStatus = "draft"
+	PolicyStatusApproved PolicyStatus = "approved"
+	PolicyStatusRetired  PolicyStatus = "retired"
+)
+
+// Policy é um documento de política da organização (ex: Política de Segurança da Informação).
+// O conteúdo fica nas versões (PolicyVersion); CurrentVersionID aponta para a última versão aprovada.
+type Policy struct {
+	ID                  uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID      uuid.UUID      `gorm:"type:uuid;not null;index;uniqueIndex:idx_policies_org_reference_code,priority:1" json:"organization_id"`
+	ReferenceCode       string         `gorm:"size:20;uniqueIndex:idx_policies_org_reference_code,priority:2" json:"reference_code"` // Código sequencial da organização, ex: POL-0003
+	Title               string         `gorm:"size:255;not null" json:"title"`
+	Description         string         `gorm:"type:text" json:"description"`
+	OwnerID             uuid.UUID      `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Status              PolicyStatus   `gorm:"type:varchar(20);not null;default:'draft';index" json:"status"`
+	ReviewCadenceMonths int            `gorm:"not null;default:12" json:"review_cadence_months"`
+	NextReviewDate      *time.Time     `gorm:"type:timestamptz" json:"next_review_date,omitempty"`
+	CurrentVersionID    *uuid.UUID     `gorm:"type:uuid" json:"current_version_id,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"` // Exclusão lógica: a política fica na lixeira até ser restaurada ou expurgada
+
+	Owner    User                   `gorm:"foreignKey:OwnerID" json:"-"`
+	Versions []PolicyVersion        `gorm:"foreignKey:PolicyID;constraint:OnDelete:CASCADE;" json:"versions,omitempty"`
+	Controls []PolicyControlMapping `gorm:"foreignKey:PolicyID;constraint:OnDelete:CASCADE;" json:"controls,omitempty"`
+}
+
+func (p *Policy) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return assignReferenceCode(tx, &p.ReferenceCode, p.OrganizationID, ReferencePolicy)
+}
+
+// PolicyVersion é uma versão enviada do documento. A aprovação segue o mesmo fluxo do aceite de riscos
+// (ApprovalStatus pendente/aprovado/rejeitado, decidido pelo aprovador designado).
+type PolicyVersion struct {
+	ID               uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
+	PolicyID         uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_policy_version_number" json:"policy_id"`
+	VersionNumber    int            `gorm:"not null;uniqueIndex:idx_policy_version_number" json:"version_number"`
+	FileURL          string         `gorm:"size:1024;not null" json:"file_url"` // objectName no provedor de armazenamento
+	FileName         string         `gorm:"size:255" json:"file_name"`
+	Changelog        string         `gorm:"type:text" json:"changelog"`
+	UploadedByID     uuid.UUID      `gorm:"type:uuid" json:"uploaded_by_id"`
+	ApprovalStatus   ApprovalStatus `gorm:"type:varchar(20)" json:"approval_status,omitempty"` // Vazio até ser submetida
+	RequesterID      *uuid.UUID     `gorm:"type:uuid" json:"requester_id,omitempty"`
+	ApproverID       *uuid.UUID     `gorm:"type:uuid" json:"approver_id,omitempty"`
+	ApprovalComments string         `gorm:"type:text" json:"approval_comments,omitempty"`
+	DecidedAt        *time.Time     `gorm:"type:timestamptz" json:"decided_at,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+func (pv *PolicyVersion) BeforeCreate(tx *gorm.DB) (err error) {
+	if pv.ID == uuid.Nil {
+		pv.ID = uuid.New()
+	}
+	return
+}
+
+// PolicyControlMapping vincula uma política aos controles de auditoria que ela atende.
+type PolicyControlMapping struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	PolicyID       uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_policy_control" json:"policy_id"`
+	AuditControlID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_policy_control;index" json:"audit_control_id"`
+	CreatedAt      time.Time    `json:"created_at"`
+	AuditControl   AuditControl `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"audit_control,omitempty"`
+}
+
+func (pcm *PolicyControlMapping) BeforeCreate(tx *gorm.DB) (err error) {
+	if pcm.ID == uuid.Nil {
+		pcm.ID = uuid.New()
+	}
+	return
+}
+
+// PolicyAcknowledgment registra que um usuário leu e aceitou uma versão específica da política.
+type PolicyAcknowledgment struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	PolicyID        uuid.UUID `gorm:"type:uuid;not null;index" json:"policy_id"`
+	PolicyVersionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_policy_ack_version_user" json:"policy_version_id"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_policy_ack_version_user" json:"user_id"`
+	AcknowledgedAt  time.Time `gorm:"type:timestamptz;not null" json:"acknowledged_at"`
+	User            User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (pa *PolicyAcknowledgment) BeforeCreate(tx *gorm.DB) (err error) {
+	if pa.ID == uuid.Nil {
+		pa.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PolicyAckCampaignStatus é o estado de uma campanha de aceite de política.
+type PolicyAckCampaignStatus string
+
+const (
+	PolicyAckCampaignOpen   PolicyAckCampaignStatus = "open"
+	PolicyAckCampaignClosed PolicyAckCampaignStatus = "closed"
+)
+
+// PolicyAckCampaign exige que usuários selecionados (ou toda a organização) aceitem uma versão
+// da política até a data limite. O progresso é calculado a partir de PolicyAcknowledgment.
+type PolicyAckCampaign struct {
+	ID                   uuid.UUID               `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID       uuid.UUID               `gorm:"type:uuid;not null;index" json:"organization_id"`
+	PolicyID             uuid.UUID               `gorm:"type:uuid;not null;index" json:"policy_id"`
+	PolicyVersionID      uuid.UUID               `gorm:"type:uuid;not null" json:"policy_version_id"` // Versão vigente no lançamento
+	Title                string                  `gorm:"size:255;not null" json:"title"`
+	Message              string                  `gorm:"type:text" json:"message,omitempty"` // Incluída nos e-mails
+	DueDate              time.Time               `gorm:"type:timestamptz;not null" json:"due_date"`
+	Status               PolicyAckCampaignStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	AllUsers             bool                    `gorm:"not null" json:"all_users"` // true: todos os usuários ativos da organização
+	ReminderIntervalDays int                     `gorm:"not null" json:"reminder_interval_days"`
+	LastReminderAt       *time.Time              `gorm:"type:timestamptz" json:"last_reminder_at,omitempty"`
+	CreatedByID          uuid.UUID               `gorm:"type:uuid" json:"created_by_id"`
+	ClosedAt             *time.Time              `gorm:"type:timestamptz" json:"closed_at,omitempty"`
+	CreatedAt            time.Time               `json:"created_at"`
+	UpdatedAt            time.Time               `json:"updated_at"`
+
+	Policy  Policy                    `gorm:"foreignKey:PolicyID;constraint:OnDelete:CASCADE;" json:"-"`
+	Targets []PolicyAckCampaignTarget `gorm:"foreignKey:CampaignID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (pac *PolicyAckCampaign) BeforeCreate(tx *gorm.DB) (err error) {
+	if pac.ID == uuid.Nil {
+		pac.ID = uuid.New()
+	}
+	return
+}
+
+// PolicyAckCampaignTarget é um usuário selecionado para uma campanha (não usado quando AllUsers é true).
+type PolicyAckCampaignTarget struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	CampaignID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_policy_ack_target" json:"campaign_id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_policy_ack_target" json:"user_id"`
+}
+
+func (t *PolicyAckCampaignTarget) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuestionnaireStatus é o ciclo de vida de um questionário.
+type QuestionnaireStatus string
+
+const (
+	QuestionnaireDraft     QuestionnaireStatus = "draft"
+	QuestionnairePublished QuestionnaireStatus = "published" // Pode ser enviado a fornecedores
+	QuestionnaireArchived  QuestionnaireStatus = "archived"
+)
+
+// QuestionType define como a pergunta é respondida e pontuada.
+type QuestionType string
+
+const (
+	QuestionYesNo          QuestionType = "yes_no"          // "yes" pontua o peso integral, "no" zero
+	QuestionSingleChoice   QuestionType = "single_choice"   // Pontua o score (0-1) da opção escolhida
+	QuestionMultipleChoice QuestionType = "multiple_choice" // Soma dos scores das opções (limitada a 1)
+	QuestionText           QuestionType = "text"            // Não pontuada
+)
+
+// QuestionnaireOption é uma alternativa de pergunta de escolha. Score é a fração (0-1) do peso da pergunta.
+type QuestionnaireOption struct {
+	Value string  `json:"value"`
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+// Questionnaire é um questionário de segurança montado pela organização para avaliar fornecedores.
+type Questionnaire struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID           `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Title          string              `gorm:"size:255;not null" json:"title"`
+	Description    string              `gorm:"type:text" json:"description,omitempty"`
+	Status         QuestionnaireStatus `gorm:"type:varchar(20);not null;default:'draft';index" json:"status"`
+	CreatedByID    uuid.UUID           `gorm:"type:uuid" json:"created_by_id"`
+	PublishedAt    *time.Time          `gorm:"type:timestamptz" json:"published_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+
+	Sections []QuestionnaireSection `gorm:"foreignKey:QuestionnaireID;constraint:OnDelete:CASCADE;" json:"sections,omitempty"`
+}
+
+func (q *Questionnaire) BeforeCreate(tx *gorm.DB) (err error) {
+	if q.ID == uuid.Nil {
+		q.ID = uuid.New()
+	}
+	return
+}
+
+// QuestionnaireSection agrupa perguntas de um questionário.
+type QuestionnaireSection struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	QuestionnaireID uuid.UUID `gorm:"type:uuid;not null;index" json:"questionnaire_id"`
+	Title           string    `gorm:"size:255;not null" json:"title"`
+	Description     string    `gorm:"type:text" json:"description,omitempty"`
+	Position        int       `gorm:"not null" json:"position"`
+
+	Questions []QuestionnaireQuestion `gorm:"foreignKey:SectionID;constraint:OnDelete:CASCADE;" json:"questions,omitempty"`
+}
+
+func (s *QuestionnaireSection) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// QuestionnaireQuestion é uma pergunta. As opções são persistidas em OptionsJSON.
+type QuestionnaireQuestion struct {
+	ID          uuid.UUID             `gorm:"type:uuid;primary_key;" json:"id"`
+	SectionID   uuid.UUID             `gorm:"type:uuid;not null;index" json:"section_id"`
+	Text        string                `gorm:"type:text;not null" json:"text"`
+	Type        QuestionType          `gorm:"type:varchar(30);not null" json:"type"`
+	Weight      float64               `gorm:"not null" json:"weight"`
+	Required    bool                  `gorm:"not null" json:"required"`
+	Position    int                   `gorm:"not null" json:"position"`
+	OptionsJSON string                `gorm:"type:jsonb" json:"-"` // []QuestionnaireOption
+	Options     []QuestionnaireOption `gorm:"-" json:"options,omitempty"`
+}
+
+func (qq *QuestionnaireQuestion) BeforeCreate(tx *gorm.DB) (err error) {
+	if qq.ID == uuid.Nil {
+		qq.ID = uuid.New()
+	}
+	return
+}
+
+// BeforeSave serializa Options em OptionsJSON.
+func (qq *QuestionnaireQuestion) BeforeSave(tx *gorm.DB) (err error) {
+	if len(qq.Options) == 0 {
+		qq.OptionsJSON = "[]"
+		return nil
+	}
+	data, err := json.Marshal(qq.Options)
+	if err != nil {
+		return err
+	}
+	qq.OptionsJSON = string(data)
+	return nil
+}
+
+// AfterFind preenche Options a partir de OptionsJSON.
+func (qq *QuestionnaireQuestion) AfterFind(tx *gorm.DB) (err error) {
+	if qq.OptionsJSON == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(qq.OptionsJSON), &qq.Options)
+}
+
+// QuestionnaireInvitationStatus é o estado do envio de um questionário a um fornecedor.
+type QuestionnaireInvitationStatus string
+
+const (
+	QuestionnaireInvitationSent       QuestionnaireInvitationStatus = "sent"
+	QuestionnaireInvitationInProgress QuestionnaireInvitationStatus = "in_progress"
+	QuestionnaireInvitationSubmitted  QuestionnaireInvitationStatus = "submitted"
+	QuestionnaireInvitationRevoked    QuestionnaireInvitationStatus = "revoked"
+)
+
+// QuestionnaireInvitation é o envio de um questionário a um contato externo do fornecedor.
+// O contato responde pelo portal público usando o token do link; somente o hash SHA-256 do token é armazenado.
+type QuestionnaireInvitation struct {
+	ID              uuid.UUID                     `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID  uuid.UUID                     `gorm:"type:uuid;not null;index" json:"organization_id"`
+	QuestionnaireID uuid.UUID                     `gorm:"type:uuid;not null;index" json:"questionnaire_id"`
+	VendorID        uuid.UUID                     `gorm:"type:uuid;not null;index" json:"vendor_id"`
+	RecipientName   string                        `gorm:"size:255" json:"recipient_name,omitempty"`
+	RecipientEmail  string                        `gorm:"size:255;not null" json:"recipient_email"`
+	TokenHash       string                        `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	Status          QuestionnaireInvitationStatus `gorm:"type:varchar(20);not null;default:'sent';index" json:"status"`
+	ExpiresAt       time.Time                     `gorm:"type:timestamptz;not null" json:"expires_at"`
+	SubmittedAt     *time.Time                    `gorm:"type:timestamptz" json:"submitted_at,omitempty"`
+	Score           float64                       `gorm:"not null;default:0" json:"score"`
+	MaxScore        float64                       `gorm:"not null;default:0" json:"max_score"`
+	ScorePercent    *float64                      `json:"score_percent,omitempty"` // Definido na submissão
+	CreatedByID     uuid.UUID                     `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt       time.Time                     `json:"created_at"`
+	UpdatedAt       time.Time                     `json:"updated_at"`
+
+	Questionnaire Questionnaire         `gorm:"foreignKey:QuestionnaireID;constraint:OnDelete:CASCADE;" json:"-"`
+	Vendor        Vendor                `gorm:"foreignKey:VendorID;constraint:OnDelete:CASCADE;" json:"-"`
+	Answers       []QuestionnaireAnswer `gorm:"foreignKey:InvitationID;constraint:OnDelete:CASCADE;" json:"answers,omitempty"`
+}
+
+func (qi *QuestionnaireInvitation) BeforeCreate(tx *gorm.DB) (err error) {
+	if qi.ID == uuid.Nil {
+		qi.ID = uuid.New()
+	}
+	return
+}
+
+// QuestionnaireAnswer é a resposta a uma pergunta. Para múltipla escolha, Value contém os valores separados por vírgula.
+type QuestionnaireAnswer struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	InvitationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_questionnaire_answer" json:"invitation_id"`
+	QuestionID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_questionnaire_answer" json:"question_id"`
+	Value        string    `gorm:"type:text" json:"value"`
+	Comment      string    `gorm:"type:text" json:"comment,omitempty"`
+	Score        float64   `gorm:"not null;default:0" json:"score"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (qa *QuestionnaireAnswer) BeforeCreate(tx *gorm.DB) (err error) {
+	if qa.ID == uuid.Nil {
+		qa.ID = uuid.New()
+	}
+	return
+}
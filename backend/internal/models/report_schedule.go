@@ -0,0 +1,117 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReportType é o conteúdo de um relatório agendado.
+type ReportType string
+
+const (
+	ReportRiskRegister    ReportType = "risk_register"    // Resumo do registro de riscos
+	ReportComplianceDelta ReportType = "compliance_delta" // Variação do score de conformidade no período
+	ReportOverdueActions  ReportType = "overdue_actions"  // Avaliações, solicitações, revisões e políticas vencidas
+)
+
+// ReportFormat é o formato do arquivo anexado ao e-mail.
+type ReportFormat string
+
+const (
+	ReportFormatPDF  ReportFormat = "pdf"
+	ReportFormatXLSX ReportFormat = "xlsx"
+)
+
+// ReportFrequency é a recorrência do envio.
+type ReportFrequency string
+
+const (
+	ReportWeekly  ReportFrequency = "weekly"
+	ReportMonthly ReportFrequency = "monthly"
+)
+
+// Resultado da última execução de um agendamento.
+const (
+	ReportRunSucceeded = "succeeded"
+	ReportRunFailed    = "failed"
+)
+
+// ReportSchedule é um relatório gerado periodicamente e enviado por e-mail a uma lista de distribuição.
+// Os horários são em UTC.
+type ReportSchedule struct {
+	ID             uuid.UUID       `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID       `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Name           string          `gorm:"size:100;not null" json:"name"`
+	ReportType     ReportType      `gorm:"type:varchar(30);not null" json:"report_type"`
+	Format         ReportFormat    `gorm:"type:varchar(10);not null" json:"format"`
+	Frequency      ReportFrequency `gorm:"type:varchar(10);not null" json:"frequency"`
+	DayOfWeek      int             `gorm:"not null;default:1" json:"day_of_week"`  // Envio semanal: 0 = domingo ... 6 = sábado
+	DayOfMonth     int             `gorm:"not null;default:1" json:"day_of_month"` // Envio mensal: 1 a 28
+	Hour           int             `gorm:"not null;default:8" json:"hour"`         // Hora do envio (UTC)
+	// Lista de distribuição em JSON ([]string de e-mails)
+	Recipients  string     `gorm:"type:jsonb;not null" json:"-"`
+	Enabled     bool       `gorm:"not null;default:true" json:"enabled"`
+	NextRunAt   time.Time  `gorm:"not null;index" json:"next_run_at"`
+	LastRunAt   *time.Time `json:"last_run_at,omitempty"`
+	LastStatus  string     `gorm:"size:20" json:"last_status,omitempty"`
+	LastError   string     `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedByID uuid.UUID  `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (s *ReportSchedule) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// RecipientList retorna a lista de distribuição.
+func (s *ReportSchedule) RecipientList() []string {
+	var recipients []string
+	if s.Recipients != "" {
+		_ = json.Unmarshal([]byte(s.Recipients), &recipients)
+	}
+	return recipients
+}
+
+// SetRecipients grava a lista de distribuição.
+func (s *ReportSchedule) SetRecipients(recipients []string) {
+	if recipients == nil {
+		recipients = []string{}
+	}
+	data, _ := json.Marshal(recipients)
+	s.Recipients = string(data)
+}
+
+// NextRunAfter retorna o primeiro horário de envio estritamente posterior a t.
+func (s *ReportSchedule) NextRunAfter(t time.Time) time.Time {
+	t = t.UTC()
+	if s.Frequency == ReportMonthly {
+		next := time.Date(t.Year(), t.Month(), s.DayOfMonth, s.Hour, 0, 0, 0, time.UTC)
+		if !next.After(t) {
+			next = next.AddDate(0, 1, 0) // DayOfMonth <= 28 existe em todos os meses
+		}
+		return next
+	}
+	next := time.Date(t.Year(), t.Month(), t.Day(), s.Hour, 0, 0, 0, time.UTC)
+	next = next.AddDate(0, 0, (s.DayOfWeek-int(next.Weekday())+7)%7)
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+// Period retorna o período coberto pelo envio em runAt: a semana ou o mês anterior.
+func (s *ReportSchedule) Period(runAt time.Time) (time.Time, time.Time) {
+	if s.Frequency == ReportMonthly {
+		return runAt.AddDate(0, -1, 0), runAt
+	}
+	return runAt.AddDate(0, 0, -7), runAt
+}
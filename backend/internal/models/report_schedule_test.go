@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportScheduleNextRunWeekly(t *testing.T) {
+	s := &ReportSchedule{Frequency: ReportWeekly, DayOfWeek: int(time.Monday), Hour: 8}
+	// Quinta-feira, 2026-10-15
+	thursday := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), s.NextRunAfter(thursday))
+
+	monday := time.Date(2026, 10, 19, 7, 59, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), s.NextRunAfter(monday), "mesmo dia, antes do horário")
+	assert.Equal(t, time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC), s.NextRunAfter(monday.Add(time.Minute)), "no horário, vai para a semana seguinte")
+}
+
+func TestReportScheduleNextRunMonthly(t *testing.T) {
+	s := &ReportSchedule{Frequency: ReportMonthly, DayOfMonth: 1, Hour: 6}
+	assert.Equal(t, time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC), s.NextRunAfter(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2027, 1, 1, 6, 0, 0, 0, time.UTC), s.NextRunAfter(time.Date(2026, 12, 1, 6, 0, 0, 0, time.UTC)))
+
+	s.DayOfMonth = 28
+	assert.Equal(t, time.Date(2027, 2, 28, 6, 0, 0, 0, time.UTC), s.NextRunAfter(time.Date(2027, 1, 28, 7, 0, 0, 0, time.UTC)))
+}
+
+func TestReportScheduleRecipientsAndPeriod(t *testing.T) {
+	s := &ReportSchedule{Frequency: ReportMonthly}
+	assert.Empty(t, s.RecipientList())
+	s.SetRecipients([]string{"ciso@example.com", "board@example.com"})
+	assert.Equal(t, []string{"ciso@example.com", "board@example.com"}, s.RecipientList())
+
+	runAt := time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC)
+	from, to := s.Period(runAt)
+	assert.Equal(t, time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, runAt, to)
+}
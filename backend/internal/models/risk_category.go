@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskCategoryDefinition é uma categoria da taxonomia de riscos da organização. Risk.Category guarda a Key.
+// Categorias inativas continuam nos riscos que já as usam, mas não podem ser atribuídas a novos riscos.
+type RiskCategoryDefinition struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_risk_category_org_key" json:"organization_id"`
+	Key            string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_risk_category_org_key" json:"key"` // Valor gravado em Risk.Category; imutável
+	Name           string    `gorm:"size:100;not null" json:"name"`
+	Description    string    `gorm:"type:text" json:"description,omitempty"`
+	IsActive       bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (rc *RiskCategoryDefinition) BeforeCreate(tx *gorm.DB) (err error) {
+	if rc.ID == uuid.Nil {
+		rc.ID = uuid.New()
+	}
+	return
+}
+
+// DefaultRiskCategories são as categorias criadas para organizações sem taxonomia própria.
+var DefaultRiskCategories = []RiskCategoryDefinition{
+	{Key: string(CategoryTechnological), Name: "Tecnológico"},
+	{Key: string(CategoryOperational), Name: "Operacional"},
+	{Key: string(CategoryLegal), Name: "Legal"},
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskControl vincula um risco da organização a um controle de auditoria que o mitiga.
+// OrganizationID é o do risco (os controles são compartilhados entre organizações).
+type RiskControl struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index" json:"organization_id"`
+	RiskID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_risk_control" json:"risk_id"`
+	AuditControlID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_risk_control;index" json:"audit_control_id"`
+	Notes          string    `gorm:"type:text" json:"notes,omitempty"` // Como o controle mitiga o risco
+	CreatedByID    uuid.UUID `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Risk         Risk         `gorm:"foreignKey:RiskID;constraint:OnDelete:CASCADE;" json:"-"`
+	AuditControl AuditControl `gorm:"foreignKey:AuditControlID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (rc *RiskControl) BeforeCreate(tx *gorm.DB) (err error) {
+	if rc.ID == uuid.Nil {
+		rc.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RiskTreatedControlStatuses são os status de avaliação que contam como tratamento concluído de um controle
+// mitigador no andamento do tratamento do risco.
+var RiskTreatedControlStatuses = []AuditControlStatus{ControlStatusConformant, ControlStatusNotApplicable, ControlStatusWaived}
+
+// RiskListItem é uma linha da view risk_list_items (migração 000046), o modelo de leitura da listagem de
+// riscos: o risco com tudo o que a tela de listagem exibe, em uma única consulta. Somente leitura; os riscos na
+// lixeira não aparecem na view.
+type RiskListItem struct {
+	Risk
+	OwnerName           string          `json:"-"` // Expostos em Owner
+	OwnerEmail          string          `json:"-"`
+	ApprovalStatus      *ApprovalStatus // Situação da solicitação de aceite mais recente; nulo se nunca solicitado
+	ApprovalUpdatedAt   *time.Time
+	StakeholderCount    int
+	ControlCount        int     // Controles mitigadores vinculados
+	TreatedControlCount int     // Controles com avaliação em RiskTreatedControlStatuses
+	TreatmentProgress   *int    // Percentual de controles tratados; nulo sem controles vinculados
+	OwnerTeamName       *string // Nome da equipe dona do risco; nulo sem equipe
+}
+
+// TableName aponta para a view.
+func (RiskListItem) TableName() string {
+	return "risk_list_items"
+}
+
+// AfterFind monta o responsável a partir das colunas da view, no lugar do Preload("Owner").
+func (item *RiskListItem) AfterFind(tx *gorm.DB) error {
+	item.Owner = User{ID: item.OwnerID, Name: item.OwnerName, Email: item.OwnerEmail}
+	return nil
+}
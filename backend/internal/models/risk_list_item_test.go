@@ -0,0 +1,52 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+// latestRiskListView retorna a migração mais recente que recria a view risk_list_items.
+func latestRiskListView(t *testing.T) string {
+	files, err := filepath.Glob("../database/migrations/*.up.sql")
+	require.NoError(t, err)
+	var view string
+	for _, file := range files { // Glob retorna em ordem lexical, que é a ordem das migrações
+		migration, err := os.ReadFile(file)
+		require.NoError(t, err)
+		if strings.Contains(string(migration), "CREATE OR REPLACE VIEW risk_list_items") {
+			view = string(migration)
+		}
+	}
+	require.NotEmpty(t, view)
+	return view
+}
+
+// A view da migração deve ter uma coluna para cada campo de RiskListItem.
+func TestRiskListViewHasAllItemColumns(t *testing.T) {
+	view := latestRiskListView(t)
+
+	s, err := schema.Parse(&RiskListItem{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		assert.True(t, strings.Contains(view, "."+field.DBName+",\n") || strings.Contains(view, "."+field.DBName+"\n") || strings.Contains(view, " AS "+field.DBName),
+			"coluna %s", field.DBName)
+	}
+}
+
+func TestRiskTreatedControlStatusesMatchView(t *testing.T) {
+	quoted := make([]string, len(RiskTreatedControlStatuses))
+	for i, status := range RiskTreatedControlStatuses {
+		quoted[i] = "'" + string(status) + "'"
+	}
+	assert.Contains(t, latestRiskListView(t), "IN ("+strings.Join(quoted, ", ")+")")
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskNotificationCriteria são os critérios de um filtro de notificação de risco.
+// Listas vazias não restringem; os critérios preenchidos precisam ser todos atendidos.
+type RiskNotificationCriteria struct {
+	EventTypes []string `json:"event_types,omitempty"` // Ex: risk_created, risk_status_changed, risk_owner_changed
+	RiskLevels []string `json:"risk_levels,omitempty"` // Nível calculado: Baixo, Moderado, Alto, Extremo
+	Impacts    []string `json:"impacts,omitempty"`     // Baixo, Médio, Alto, Crítico
+	Categories []string `json:"categories,omitempty"`
+}
+
+// RiskNotificationFilter é uma regra por organização avaliada antes do envio das notificações de risco
+// (webhooks e regras de roteamento). Se a organização tiver filtros ativos, o evento só é notificado
+// quando atende a pelo menos um deles; sem filtros ativos, todos os eventos são notificados.
+type RiskNotificationFilter struct {
+	ID             uuid.UUID                `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID                `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Name           string                   `gorm:"size:100;not null" json:"name"`
+	CriteriaJSON   string                   `gorm:"type:jsonb" json:"-"`
+	Criteria       RiskNotificationCriteria `gorm:"-" json:"criteria"`
+	IsActive       bool                     `gorm:"not null" json:"is_active"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+func (f *RiskNotificationFilter) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
+
+// BeforeSave serializa Criteria em CriteriaJSON.
+func (f *RiskNotificationFilter) BeforeSave(tx *gorm.DB) (err error) {
+	data, err := json.Marshal(f.Criteria)
+	if err != nil {
+		return err
+	}
+	f.CriteriaJSON = string(data)
+	return nil
+}
+
+// AfterFind preenche Criteria a partir de CriteriaJSON.
+func (f *RiskNotificationFilter) AfterFind(tx *gorm.DB) (err error) {
+	if f.CriteriaJSON == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(f.CriteriaJSON), &f.Criteria)
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskScoringMatrix é a matriz de pontuação de riscos (apetite a risco) configurada pela organização: as
+// dimensões da matriz impacto x probabilidade, o nível de risco de cada célula e o nível a partir do qual
+// aceitar um risco exige o workflow de aprovação. Sem matriz configurada vale a matriz 4x4 padrão.
+type RiskScoringMatrix struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"organization_id"`
+	Size           int       `gorm:"not null" json:"size"`
+	// Níveis das células em JSON ([][]string): linha pela probabilidade, coluna pelo impacto, do mais baixo ao mais alto
+	Cells string `gorm:"type:jsonb;not null" json:"-"`
+	// Nível de risco (ex: Alto) a partir do qual o risco só pode ser aceito pelo workflow de aprovação; vazio = sem exigência
+	AcceptanceThreshold string     `gorm:"type:varchar(20)" json:"acceptance_threshold"`
+	UpdatedByID         *uuid.UUID `gorm:"type:uuid" json:"updated_by_id,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (m *RiskScoringMatrix) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return
+}
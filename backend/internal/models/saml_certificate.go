@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Estados de um certificado do SP SAML.
+const (
+	// SAMLCertificateActive assina as AuthnRequests e decifra as asserções; no máximo um por IdP
+	SAMLCertificateActive = "active"
+	// SAMLCertificateStandby é publicado nos metadados sem assinar: o próximo certificado (antes da troca) ou o
+	// anterior (depois dela, até ser removido)
+	SAMLCertificateStandby = "standby"
+)
+
+// MaxSAMLCertificatesPerIdP limita os certificados do SP guardados para um IdP: o ativo e os de reserva da rotação.
+const MaxSAMLCertificatesPerIdP = 3
+
+// SAMLCertificate é um par chave/certificado do Service Provider usado com um IdP SAML. Sem certificados
+// guardados, o IdP usa o par global (SAML_SP_KEY_PEM / SAML_SP_CERT_PEM).
+type SAMLCertificate struct {
+	ID                 uuid.UUID        `gorm:"type:uuid;primary_key;" json:"id"`
+	IdentityProviderID uuid.UUID        `gorm:"type:uuid;not null;index" json:"identity_provider_id"`
+	IdentityProvider   IdentityProvider `gorm:"foreignKey:IdentityProviderID;constraint:OnDelete:CASCADE;" json:"-"`
+	Status             string           `gorm:"size:20;not null" json:"status"`
+	CertificatePEM     string           `gorm:"type:text;not null" json:"certificate_pem"`
+	// Chave privada em PEM, cifrada com utils.Encrypt
+	PrivateKeyEncrypted string     `gorm:"type:text;not null" json:"-"`
+	FingerprintSHA256   string     `gorm:"size:64;not null" json:"fingerprint_sha256"` // SHA-256 do DER, em hexadecimal
+	Subject             string     `gorm:"size:255" json:"subject"`
+	NotBefore           time.Time  `gorm:"not null" json:"not_before"`
+	NotAfter            time.Time  `gorm:"not null" json:"not_after"`
+	ActivatedAt         *time.Time `json:"activated_at,omitempty"`
+	CreatedByID         *uuid.UUID `gorm:"type:uuid" json:"created_by_id,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func (c *SAMLCertificate) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedViewEntityType identifica a listagem à qual a visão salva se aplica.
+type SavedViewEntityType string
+
+const (
+	SavedViewRisk       SavedViewEntityType = "risk"       // GET /api/v1/risks
+	SavedViewAssessment SavedViewEntityType = "assessment" // GET /api/v1/audit/organizations/:orgId/frameworks/:frameworkId/assessments
+)
+
+// SavedView é um conjunto nomeado de filtros de uma listagem (ex: "Meus riscos críticos abertos"). Visões
+// pessoais são visíveis apenas ao dono; visões compartilhadas (criadas por admins e managers) aparecem para
+// toda a organização.
+type SavedView struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID           `gorm:"type:uuid;not null;index:idx_saved_view_org_entity,priority:1" json:"organization_id"`
+	OwnerID        uuid.UUID           `gorm:"type:uuid;not null;index" json:"owner_id"`
+	EntityType     SavedViewEntityType `gorm:"type:varchar(20);not null;index:idx_saved_view_org_entity,priority:2" json:"entity_type"`
+	Name           string              `gorm:"size:100;not null" json:"name"`
+	Description    string              `gorm:"size:255" json:"description,omitempty"`
+	// Filtros em JSON (map[string][]string), com os mesmos nomes dos parâmetros da listagem
+	Filters   string    `gorm:"type:jsonb;not null" json:"-"`
+	Shared    bool      `gorm:"not null;default:false" json:"shared"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (v *SavedView) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatusEntity identifica a entidade cujos status são definidos em StatusDefinition.
+type StatusEntity string
+
+const (
+	StatusEntityRisk       StatusEntity = "risk"       // Risk.Status
+	StatusEntityAssessment StatusEntity = "assessment" // AuditAssessment.Status
+)
+
+// StatusDefinition define um status de risco ou de avaliação além dos nativos, ou ajusta um nativo (rótulo,
+// ordem, descontinuação). Um status novo é um refinamento de um status nativo (BaseStatus): o registro
+// guarda BaseStatus em Status, para que consultas, scores, relatórios e integrações que conhecem apenas os
+// status nativos continuem funcionando, e o código novo em StatusCode.
+// Status não são removidos: um status descontinuado (Deprecated) continua válido nos registros existentes,
+// mas não pode ser atribuído novamente.
+type StatusDefinition struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	Entity      StatusEntity `gorm:"type:varchar(20);not null;uniqueIndex:idx_status_definition_entity_code,priority:1" json:"entity"`
+	Code        string       `gorm:"size:30;not null;uniqueIndex:idx_status_definition_entity_code,priority:2" json:"code"`
+	Label       string       `gorm:"size:100;not null" json:"label"`
+	Description string       `gorm:"type:text" json:"description,omitempty"`
+	BaseStatus  string       `gorm:"size:30;not null" json:"base_status"` // Status nativo equivalente; igual a Code nos nativos
+	Deprecated  bool         `gorm:"not null" json:"deprecated"`
+	ReplacedBy  string       `gorm:"size:30" json:"replaced_by,omitempty"` // Status sugerido no lugar do descontinuado
+	SortOrder   int          `gorm:"not null" json:"sort_order"`
+	Builtin     bool         `gorm:"-" json:"builtin"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+func (s *StatusDefinition) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// StatusTransition permite a mudança de status FromCode -> ToCode. Um status de origem sem transições
+// cadastradas pode mudar para qualquer outro; com transições, só para os destinos cadastrados.
+type StatusTransition struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	Entity    StatusEntity `gorm:"type:varchar(20);not null;uniqueIndex:idx_status_transition,priority:1" json:"entity"`
+	FromCode  string       `gorm:"size:30;not null;uniqueIndex:idx_status_transition,priority:2" json:"from"`
+	ToCode    string       `gorm:"size:30;not null;uniqueIndex:idx_status_transition,priority:3" json:"to"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+func (s *StatusTransition) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// storedStatusCode retorna o StatusCode a gravar para o status code com o nativo base: nulo quando o código
+// é o próprio status nativo.
+func storedStatusCode(base, code string) *string {
+	if code == "" || code == base {
+		return nil
+	}
+	return &code
+}
+
+// SetStatus define o status do risco: base é o status nativo e code o status definido (vazio ou igual a
+// base para um status nativo).
+func (risk *Risk) SetStatus(base RiskStatus, code string) {
+	risk.Status = base
+	risk.StatusCode = storedStatusCode(string(base), code)
+}
+
+// EffectiveStatus retorna o código do status do risco: StatusCode, se houver, ou Status.
+func (risk *Risk) EffectiveStatus() string {
+	if risk.StatusCode != nil && *risk.StatusCode != "" {
+		return *risk.StatusCode
+	}
+	return string(risk.Status)
+}
+
+// SetStatus define o status da avaliação: base é o status nativo e code o status definido (vazio ou igual
+// a base para um status nativo).
+func (a *AuditAssessment) SetStatus(base AuditControlStatus, code string) {
+	a.Status = base
+	a.StatusCode = storedStatusCode(string(base), code)
+}
+
+// EffectiveStatus retorna o código do status da avaliação: StatusCode, se houver, ou Status.
+func (a *AuditAssessment) EffectiveStatus() string {
+	if a.StatusCode != nil && *a.StatusCode != "" {
+		return *a.StatusCode
+	}
+	return string(a.Status)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TagEntityType identifica o tipo de entidade que pode receber tags.
+type TagEntityType string
+
+const (
+	TagEntityRisk    TagEntityType = "risk"
+	TagEntityControl TagEntityType = "control"
+	TagEntityVendor  TagEntityType = "vendor"
+)
+
+// TagEntityTypes são os tipos de entidade aceitos pelas tags.
+var TagEntityTypes = []TagEntityType{TagEntityRisk, TagEntityControl, TagEntityVendor}
+
+// Tag é um rótulo da organização (ex: "cloud", "GDPR-related") aplicado a riscos, controles e fornecedores
+// para montar visões transversais. O nome é único na organização, sem diferenciar maiúsculas.
+type Tag struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tag_org_name,priority:1" json:"organization_id"`
+	Name           string    `gorm:"size:50;not null;uniqueIndex:idx_tag_org_name,priority:2" json:"name"`
+	Color          string    `gorm:"size:7" json:"color,omitempty"` // Hexadecimal, ex: #1f77b4
+	Description    string    `gorm:"type:text" json:"description,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
+
+// EntityTag associa uma tag a uma entidade (associação polimórfica por EntityType e EntityID). Os controles
+// são globais aos frameworks, por isso a associação guarda a organização.
+type EntityTag struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID     `gorm:"type:uuid;not null;index" json:"organization_id"`
+	TagID          uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_entity_tag_unique,priority:1" json:"tag_id"`
+	EntityType     TagEntityType `gorm:"type:varchar(20);not null;uniqueIndex:idx_entity_tag_unique,priority:2;index:idx_entity_tag_entity,priority:1" json:"entity_type"`
+	EntityID       uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_entity_tag_unique,priority:3;index:idx_entity_tag_entity,priority:2" json:"entity_id"`
+	CreatedByID    *uuid.UUID    `gorm:"type:uuid" json:"created_by_id,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+
+	Tag Tag `gorm:"foreignKey:TagID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (e *EntityTag) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
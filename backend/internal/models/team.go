@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Team é um grupo de usuários da organização (ex: um departamento) que pode ser dono de riscos, de avaliações
+// de controles e de testes de controle, no lugar de uma única pessoa ou junto com ela. As notificações do item
+// são enviadas a todos os membros.
+type Team struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_teams_org_name,priority:1" json:"organization_id"`
+	Name           string     `gorm:"size:100;not null;uniqueIndex:idx_teams_org_name,priority:2" json:"name"`
+	Description    string     `gorm:"size:255" json:"description,omitempty"`
+	CreatedByID    *uuid.UUID `gorm:"type:uuid" json:"created_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+	Members      []TeamMember `gorm:"foreignKey:TeamID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (t *Team) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
+
+// TeamMember associa um usuário a uma equipe. Um usuário pode estar em várias equipes.
+type TeamMember struct {
+	TeamID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"team_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// TeamMemberIDs retorna os usuários ativos da equipe.
+func TeamMemberIDs(db *gorm.DB, teamID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := db.Model(&TeamMember{}).
+		Joins("JOIN users ON users.id = team_members.user_id").
+		Where("team_members.team_id = ? AND users.is_active = ?", teamID, true).
+		Pluck("team_members.user_id", &ids).Error
+	return ids, err
+}
+
+// IsTeamMember indica se o usuário pertence à equipe.
+func IsTeamMember(db *gorm.DB, teamID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&TeamMember{}).Where("team_id = ? AND user_id = ?", teamID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+// UserTeamIDs é a subconsulta das equipes do usuário, para filtros como "owner_team_id IN (?)".
+func UserTeamIDs(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Model(&TeamMember{}).Select("team_id").Where("user_id = ?", userID)
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TrustCenterCertification é uma certificação exibida publicamente (ex: ISO 27001, SOC 2).
+type TrustCenterCertification struct {
+	Name       string `json:"name"`
+	Issuer     string `json:"issuer,omitempty"`
+	ValidUntil string `json:"valid_until,omitempty"` // YYYY-MM-DD
+}
+
+// TrustCenterProfile define quais fatos de conformidade uma organização expõe publicamente.
+// O perfil só é servido pela rota pública quando IsPublished é true; qualquer alteração de
+// conteúdo despublica o perfil, exigindo nova aprovação de um admin da organização.
+type TrustCenterProfile struct {
+	ID                 uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID     uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex" json:"organization_id"`
+	Slug               string       `gorm:"size:63;not null;uniqueIndex" json:"slug"` // Identificador usado na URL pública
+	Headline           string       `gorm:"size:255" json:"headline"`
+	Description        string       `gorm:"type:text" json:"description"`
+	CertificationsJSON string       `gorm:"type:jsonb" json:"-"` // []TrustCenterCertification
+	FrameworkIDs       string       `gorm:"type:text" json:"-"`  // UUIDs separados por vírgula
+	ShowLastAuditDate  bool         `gorm:"not null" json:"show_last_audit_date"`
+	IsPublished        bool         `gorm:"default:false;not null" json:"is_published"`
+	ApprovedByID       *uuid.UUID   `gorm:"type:uuid" json:"approved_by_id,omitempty"`
+	ApprovedAt         *time.Time   `gorm:"type:timestamptz" json:"approved_at,omitempty"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	Organization       Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (tcp *TrustCenterProfile) BeforeCreate(tx *gorm.DB) (err error) {
+	if tcp.ID == uuid.Nil {
+		tcp.ID = uuid.New()
+	}
+	return
+}
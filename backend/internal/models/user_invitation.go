@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InvitationStatus é a situação de um convite, calculada a partir das datas.
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+// UserInvitation convida uma pessoa, pelo e-mail, a entrar na organização com o papel definido. O link
+// enviado por e-mail contém um token aleatório, do qual só o hash é guardado; ao aceitar, a pessoa define a
+// senha, ou entra pelo SSO da organização com o mesmo e-mail.
+type UserInvitation struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Email          string     `gorm:"size:255;not null;index" json:"email"` // Sempre em minúsculas
+	Name           string     `gorm:"size:255" json:"name,omitempty"`
+	Role           UserRole   `gorm:"type:varchar(20);not null" json:"role"`
+	CustomRoleID   *uuid.UUID `gorm:"type:uuid" json:"custom_role_id,omitempty"`
+	TokenHash      string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt      time.Time  `gorm:"type:timestamptz;not null" json:"expires_at"`
+	InvitedByID    uuid.UUID  `gorm:"type:uuid;not null" json:"invited_by_id"`
+	AcceptedAt     *time.Time `gorm:"type:timestamptz" json:"accepted_at,omitempty"`
+	AcceptedUserID *uuid.UUID `gorm:"type:uuid" json:"accepted_user_id,omitempty"`
+	RevokedAt      *time.Time `gorm:"type:timestamptz" json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+	CustomRole   *CustomRole  `gorm:"foreignKey:CustomRoleID;constraint:OnDelete:SET NULL;" json:"-"`
+}
+
+func (i *UserInvitation) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
+
+// Status retorna a situação do convite no instante informado.
+func (i *UserInvitation) Status(now time.Time) InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case now.After(i.ExpiresAt):
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
+
+// PendingInvitations restringe a consulta aos convites que ainda podem ser aceitos.
+func PendingInvitations(db *gorm.DB) *gorm.DB {
+	return db.Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", time.Now())
+}
+
+// FindPendingInvitation retorna o convite pendente mais recente do e-mail na organização, ou nil se não houver.
+func FindPendingInvitation(db *gorm.DB, organizationID uuid.UUID, email string) (*UserInvitation, error) {
+	var invitation UserInvitation
+	err := db.Scopes(PendingInvitations).
+		Where("organization_id = ? AND email = ?", organizationID, email).
+		Order("created_at desc").
+		Take(&invitation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// Apply dá ao novo usuário o papel (e o papel personalizado) do convite.
+func (i *UserInvitation) Apply(user *User) {
+	user.Role = i.Role
+	user.CustomRoleID = i.CustomRoleID
+	if user.Name == "" || user.Name == user.Email {
+		if i.Name != "" {
+			user.Name = i.Name
+		}
+	}
+}
+
+// MarkAccepted registra que o convite foi aceito pelo usuário. Só altera convites ainda pendentes, então
+// um convite nunca é usado duas vezes; retorna false se ele deixou de estar pendente.
+func (i *UserInvitation) MarkAccepted(tx *gorm.DB, userID uuid.UUID) (bool, error) {
+	now := time.Now()
+	result := tx.Model(&UserInvitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", i.ID).
+		Updates(map[string]interface{}{"accepted_at": now, "accepted_user_id": userID})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	i.AcceptedAt = &now
+	i.AcceptedUserID = &userID
+	return true, nil
+}
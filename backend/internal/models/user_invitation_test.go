@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserInvitationStatus(t *testing.T) {
+	now := time.Now()
+	invitation := UserInvitation{ExpiresAt: now.Add(time.Hour)}
+	assert.Equal(t, InvitationPending, invitation.Status(now))
+	assert.Equal(t, InvitationExpired, invitation.Status(now.Add(2*time.Hour)))
+
+	invitation.RevokedAt = &now
+	assert.Equal(t, InvitationRevoked, invitation.Status(now))
+
+	invitation.AcceptedAt = &now
+	assert.Equal(t, InvitationAccepted, invitation.Status(now.Add(2*time.Hour)), "acceptance wins over expiry and revocation")
+}
+
+func TestUserInvitationApply(t *testing.T) {
+	customRoleID := uuid.New()
+	invitation := UserInvitation{Name: "Ana Lima", Role: RoleManager, CustomRoleID: &customRoleID}
+
+	user := User{Email: "ana@example.com", Name: "ana@example.com", Role: RoleUser}
+	invitation.Apply(&user)
+	assert.Equal(t, RoleManager, user.Role)
+	assert.Equal(t, &customRoleID, user.CustomRoleID)
+	assert.Equal(t, "Ana Lima", user.Name, "the invitation name replaces the e-mail placeholder")
+
+	named := User{Email: "ana@example.com", Name: "Ana L."}
+	invitation.Apply(&named)
+	assert.Equal(t, "Ana L.", named.Name, "a name from the IdP is kept")
+}
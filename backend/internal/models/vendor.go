@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VendorCriticality indica o quão crítico o fornecedor é para a organização.
+type VendorCriticality string
+
+const (
+	VendorCriticalityLow      VendorCriticality = "low"
+	VendorCriticalityMedium   VendorCriticality = "medium"
+	VendorCriticalityHigh     VendorCriticality = "high"
+	VendorCriticalityCritical VendorCriticality = "critical"
+)
+
+// Vendor é um fornecedor/terceiro avaliado pela organização (ex: via questionários de segurança).
+type Vendor struct {
+	ID                     uuid.UUID         `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID         uuid.UUID         `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Name                   string            `gorm:"size:255;not null" json:"name"`
+	Website                string            `gorm:"size:255" json:"website,omitempty"`
+	ContactName            string            `gorm:"size:255" json:"contact_name,omitempty"`
+	ContactEmail           string            `gorm:"size:255" json:"contact_email,omitempty"`
+	Criticality            VendorCriticality `gorm:"type:varchar(20);not null;default:'medium'" json:"criticality"`
+	IsActive               bool              `gorm:"not null" json:"is_active"`
+	Notes                  string            `gorm:"type:text" json:"notes,omitempty"`
+	LastQuestionnaireScore *float64          `json:"last_questionnaire_score,omitempty"` // Percentual (0-100) do último questionário respondido
+	LastAssessedAt         *time.Time        `gorm:"type:timestamptz" json:"last_assessed_at,omitempty"`
+	CreatedAt              time.Time         `json:"created_at"`
+	UpdatedAt              time.Time         `json:"updated_at"`
+}
+
+func (v *Vendor) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return
+}
+
+// VendorRisk vincula um fornecedor a um risco de terceiro.
+type VendorRisk struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	VendorID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_vendor_risk" json:"vendor_id"`
+	RiskID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_vendor_risk;index" json:"risk_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Vendor Vendor `gorm:"foreignKey:VendorID;constraint:OnDelete:CASCADE;" json:"-"`
+	Risk   Risk   `gorm:"foreignKey:RiskID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (vr *VendorRisk) BeforeCreate(tx *gorm.DB) (err error) {
+	if vr.ID == uuid.Nil {
+		vr.ID = uuid.New()
+	}
+	return
+}
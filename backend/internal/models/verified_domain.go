@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerifiedDomainTXTPrefix antecede o token no registro TXT que prova o controle do domínio
+// (ex: "phoenixgrc-domain-verification=<token>").
+const VerifiedDomainTXTPrefix = "phoenixgrc-domain-verification="
+
+// VerifiedDomain associa um domínio de email à organização e ao provedor de identidade usado na descoberta do
+// login (GET /auth/sso/discover). O domínio só passa a rotear logins depois de verificado por DNS, e um domínio
+// verificado pertence a uma única organização.
+type VerifiedDomain struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_verified_domains_org_domain,priority:1" json:"organization_id"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+	Domain         string       `gorm:"size:255;not null;uniqueIndex:idx_verified_domains_org_domain,priority:2;uniqueIndex:idx_verified_domains_verified,where:verified_at IS NOT NULL" json:"domain"`
+	// IdP para onde os usuários do domínio são levados; nulo depois que o IdP é removido
+	IdentityProviderID *uuid.UUID        `gorm:"type:uuid;index" json:"identity_provider_id"`
+	IdentityProvider   *IdentityProvider `gorm:"foreignKey:IdentityProviderID;constraint:OnDelete:SET NULL;" json:"-"`
+	VerificationToken  string            `gorm:"size:64;not null" json:"-"` // Publicado no registro TXT (ver TXTRecord)
+	VerifiedAt         *time.Time        `json:"verified_at,omitempty"`
+	LastCheckedAt      *time.Time        `json:"last_checked_at,omitempty"` // Última tentativa de verificação
+	CreatedByID        *uuid.UUID        `gorm:"type:uuid" json:"created_by_id,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+func (d *VerifiedDomain) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
+
+// Verified indica se o controle do domínio já foi comprovado.
+func (d *VerifiedDomain) Verified() bool {
+	return d.VerifiedAt != nil
+}
+
+// TXTRecord é o valor do registro TXT que o administrador publica no domínio para verificá-lo.
+func (d *VerifiedDomain) TXTRecord() string {
+	return VerifiedDomainTXTPrefix + d.VerificationToken
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebAuthnCredential é uma passkey ou chave de segurança registrada pelo usuário como segundo fator.
+type WebAuthnCredential struct {
+	ID     uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	User   User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	// Nome dado pelo usuário para reconhecer o autenticador na lista
+	Name         string `gorm:"size:100;not null" json:"name"`
+	CredentialID []byte `gorm:"type:bytea;not null;uniqueIndex" json:"-"`
+	PublicKey    []byte `gorm:"type:bytea;not null" json:"-"` // Chave COSE atestada no registro
+	Algorithm    int    `gorm:"not null" json:"algorithm"`    // Algoritmo COSE (ver webauthn.SupportedAlgorithms)
+	// Contador de assinaturas do autenticador; um valor que não avança indica clonagem
+	SignCount      int64      `gorm:"not null;default:0" json:"-"`
+	AAGUID         string     `gorm:"column:aaguid;size:36" json:"aaguid,omitempty"`
+	Transports     string     `gorm:"size:255" json:"-"` // Separados por vírgula (usb, nfc, ble, internal, hybrid)
+	BackupEligible bool       `gorm:"not null;default:false" json:"backup_eligible"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (c *WebAuthnCredential) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// Cerimônias WebAuthn às quais um desafio pertence.
+const (
+	WebAuthnCeremonyRegistration = "registration"
+	WebAuthnCeremonyLogin        = "login"
+)
+
+// WebAuthnChallenge é um desafio emitido para uma cerimônia WebAuthn. Vale uma vez: é apagado ao ser usado.
+type WebAuthnChallenge struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Ceremony  string    `gorm:"size:20;not null"`
+	Challenge string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+func (c *WebAuthnChallenge) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,162 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AssessmentDueReminderLeadTime é a antecedência com que o avaliador passa a ser lembrado do prazo.
+const AssessmentDueReminderLeadTime = 3 * 24 * time.Hour
+
+// assessmentDueReminderInterval evita mais de um lembrete por dia para a mesma avaliação.
+const assessmentDueReminderInterval = 20 * time.Hour
+
+// AssessmentDueItem é um controle pendente listado no lembrete ao avaliador. DueDate é a data considerada
+// pelo lembrete: a nova data prevista, se o avaliador respondeu a um lembrete (OriginalDueDate é o prazo da
+// atribuição), ou o prazo da atribuição.
+type AssessmentDueItem struct {
+	ControlID       string
+	Description     string
+	DueDate         time.Time
+	OriginalDueDate *time.Time
+}
+
+// BuildAssessmentDueReminder monta o assunto e o corpo do lembrete com os controles pendentes do avaliador,
+// separando os atrasados. now define o que está atrasado (prazo anterior ao dia de hoje).
+func BuildAssessmentDueReminder(assessorName string, items []AssessmentDueItem, now time.Time) (string, string) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var overdue, upcoming []string
+	for _, item := range items {
+		deadline := item.DueDate.Format("02/01/2006")
+		if item.OriginalDueDate != nil && !item.OriginalDueDate.Equal(item.DueDate) {
+			deadline += ", reprogramado de " + item.OriginalDueDate.Format("02/01/2006")
+		}
+		line := fmt.Sprintf("- %s (prazo: %s) %s", item.ControlID, deadline, item.Description)
+		if item.DueDate.Before(today) {
+			overdue = append(overdue, line)
+		} else {
+			upcoming = append(upcoming, line)
+		}
+	}
+
+	subject := fmt.Sprintf("Lembrete: %d controle(s) aguardando sua avaliação", len(items))
+	if len(overdue) > 0 {
+		subject = fmt.Sprintf("Avaliações Atrasadas: %d controle(s) com prazo vencido", len(overdue))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Olá %s,\n\n", assessorName)
+	if len(overdue) > 0 {
+		sb.WriteString("Controles com prazo vencido:\n")
+		sb.WriteString(strings.Join(overdue, "\n"))
+		sb.WriteString("\n\n")
+	}
+	if len(upcoming) > 0 {
+		sb.WriteString("Controles com prazo próximo:\n")
+		sb.WriteString(strings.Join(upcoming, "\n"))
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("Se não for possível concluir no prazo, informe uma nova data prevista com justificativa na avaliação para pausar os lembretes.\n")
+	return subject, sb.String()
+}
+
+// RunAssessmentDueReminderSweep envia a cada avaliador um e-mail com suas avaliações atribuídas ainda
+// pendentes cujo prazo vence dentro de AssessmentDueReminderLeadTime (ou já venceu). Enquanto a avaliação
+// estiver pendente, o lembrete é repetido no máximo uma vez por dia. Se o avaliador informou uma nova data
+// prevista, ela substitui o prazo: os lembretes ficam pausados até a proximidade da nova data.
+func RunAssessmentDueReminderSweep(ctx context.Context) {
+	db := database.GetDB()
+	now := time.Now()
+	var assessments []models.AuditAssessment
+	err := db.WithContext(ctx).Preload("AuditControl").
+		Where("assigned_to_id IS NOT NULL AND assignment_completed_at IS NULL").
+		Where("due_date IS NOT NULL AND COALESCE(reminder_target_date, due_date) <= ?", now.Add(AssessmentDueReminderLeadTime).Format("2006-01-02")).
+		Where("last_due_reminder_at IS NULL OR last_due_reminder_at <= ?", now.Add(-assessmentDueReminderInterval)).
+		Order("COALESCE(reminder_target_date, due_date) ASC").
+		Find(&assessments).Error
+	if err != nil {
+		phxlog.L.Error("Error fetching assigned assessments for due date reminders", zap.Error(err))
+		return
+	}
+	if len(assessments) == 0 {
+		return
+	}
+
+	byAssessor := make(map[uuid.UUID][]models.AuditAssessment)
+	var assessorIDs []uuid.UUID
+	for _, assessment := range assessments {
+		id := *assessment.AssignedToID
+		if _, ok := byAssessor[id]; !ok {
+			assessorIDs = append(assessorIDs, id)
+		}
+		byAssessor[id] = append(byAssessor[id], assessment)
+	}
+	var assessors []models.User
+	if err := db.WithContext(ctx).Select("id", "name", "email").
+		Where("id IN ? AND is_active = ?", assessorIDs, true).Find(&assessors).Error; err != nil {
+		phxlog.L.Error("Error fetching assessors for due date reminders", zap.Error(err))
+		return
+	}
+
+	frontendBaseURL := config.Cfg.FrontendBaseURL
+	if frontendBaseURL == "" {
+		frontendBaseURL = "http://localhost:3000" // Fallback
+	}
+	assignmentsURL := fmt.Sprintf("%s/audit/my-assessments", strings.TrimSuffix(frontendBaseURL, "/"))
+
+	for _, assessor := range assessors {
+		if assessor.Email == "" {
+			continue
+		}
+		pending := byAssessor[assessor.ID]
+		items := make([]AssessmentDueItem, 0, len(pending))
+		ids := make([]uuid.UUID, 0, len(pending))
+		for _, assessment := range pending {
+			item := AssessmentDueItem{
+				ControlID:   assessment.AuditControl.ControlID,
+				Description: assessment.AuditControl.Description,
+				DueDate:     *assessment.DueDate,
+			}
+			if assessment.ReminderTargetDate != nil {
+				item.DueDate = *assessment.ReminderTargetDate
+				item.OriginalDueDate = assessment.DueDate
+			}
+			items = append(items, item)
+			ids = append(ids, assessment.ID)
+		}
+		subject, body := BuildAssessmentDueReminder(assessor.Name, items, now)
+		deliverAsync(ChannelEmail, assessor.Email, Message{
+			EventType:      "assessment.due_reminder",
+			OrganizationID: pending[0].OrganizationID,
+			Subject:        subject,
+			Body:           body,
+			Link:           assignmentsURL,
+		})
+
+		if err := db.WithContext(ctx).Model(&models.AuditAssessment{}).Where("id IN ?", ids).
+			Update("last_due_reminder_at", now).Error; err != nil {
+			phxlog.L.Error("Failed to record assessment due date reminder",
+				zap.String("assessorID", assessor.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		phxlog.L.Info("Assessment due date reminder sent",
+			zap.String("assessorID", assessor.ID.String()),
+			zap.Int("controls", len(items)))
+	}
+}
+
+// StartAssessmentDueReminderScheduler executa RunAssessmentDueReminderSweep periodicamente até o contexto ser cancelado.
+func StartAssessmentDueReminderScheduler(ctx context.Context, every time.Duration) {
+	runPeriodically(ctx, every, RunAssessmentDueReminderSweep)
+}
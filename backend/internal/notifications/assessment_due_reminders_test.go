@@ -0,0 +1,38 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAssessmentDueReminder(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	items := []AssessmentDueItem{
+		{ControlID: "AC-1", Description: "Política de controle de acesso", DueDate: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{ControlID: "AC-2", Description: "Gestão de contas", DueDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	subject, body := BuildAssessmentDueReminder("Ana", items, now)
+	assert.Equal(t, "Avaliações Atrasadas: 1 controle(s) com prazo vencido", subject)
+	assert.Contains(t, body, "Olá Ana")
+	assert.Contains(t, body, "Controles com prazo vencido:\n- AC-1 (prazo: 09/03/2026)")
+	assert.Contains(t, body, "Controles com prazo próximo:\n- AC-2 (prazo: 10/03/2026)", "due today is not overdue yet")
+
+	subject, body = BuildAssessmentDueReminder("Ana", items[1:], now)
+	assert.Equal(t, "Lembrete: 1 controle(s) aguardando sua avaliação", subject)
+	assert.NotContains(t, body, "vencido")
+}
+
+func TestBuildAssessmentDueReminder_RescheduledTarget(t *testing.T) {
+	now := time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC)
+	original := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	items := []AssessmentDueItem{
+		{ControlID: "AC-1", Description: "Política de controle de acesso", DueDate: time.Date(2026, 3, 21, 0, 0, 0, 0, time.UTC), OriginalDueDate: &original},
+	}
+
+	subject, body := BuildAssessmentDueReminder("Ana", items, now)
+	assert.Equal(t, "Lembrete: 1 controle(s) aguardando sua avaliação", subject, "the new target date is not overdue yet")
+	assert.Contains(t, body, "- AC-1 (prazo: 21/03/2026, reprogramado de 09/03/2026)")
+}
@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordedDelivery é uma entrega capturada por RecordingChannel.
+type RecordedDelivery struct {
+	Target  string
+	Message Message
+}
+
+// RecordingChannel é um canal para testes: registra as entregas em memória em vez de enviá-las.
+// Err, se definido, é retornado por Deliver (após registrar a tentativa).
+type RecordingChannel struct {
+	ChannelName string
+	Err         error
+
+	mu         sync.Mutex
+	deliveries []RecordedDelivery
+}
+
+// NewRecordingChannel cria um canal de teste com o nome informado.
+func NewRecordingChannel(name string) *RecordingChannel {
+	return &RecordingChannel{ChannelName: name}
+}
+
+func (r *RecordingChannel) Name() string { return r.ChannelName }
+
+func (r *RecordingChannel) Deliver(ctx context.Context, target string, msg Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, RecordedDelivery{Target: target, Message: msg})
+	return r.Err
+}
+
+// Deliveries retorna uma cópia das entregas registradas.
+func (r *RecordingChannel) Deliveries() []RecordedDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]RecordedDelivery, len(r.deliveries))
+	copy(out, r.deliveries)
+	return out
+}
+
+// UseChannelsForTest substitui o registro de canais pelos informados e retorna uma função
+// que restaura o registro anterior. Uso: defer notifications.UseChannelsForTest(rec)().
+func UseChannelsForTest(chs ...Channel) (restore func()) {
+	channelsMu.Lock()
+	previous := channels
+	channels = make(map[string]Channel, len(chs))
+	for _, ch := range chs {
+		channels[ch.Name()] = ch
+	}
+	channelsMu.Unlock()
+	return func() {
+		channelsMu.Lock()
+		channels = previous
+		channelsMu.Unlock()
+	}
+}
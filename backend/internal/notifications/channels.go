@@ -0,0 +1,297 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+)
+
+// DeliveryFailures conta as entregas que falharam, por canal. A maioria das entregas é assíncrona e a falha
+// não chega a quem disparou a notificação: a métrica permite alertar sobre canais quebrados.
+var DeliveryFailures = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "phoenixgrc_notification_delivery_failures_total",
+		Help: "Total number of notification deliveries that failed, by channel.",
+	},
+	[]string{"channel"},
+)
+
+// Nomes dos canais padrão.
+const (
+	ChannelEmail   = "email"
+	ChannelSlack   = "slack"
+	ChannelTeams   = "teams"
+	ChannelWebhook = "webhook" // Webhook genérico (payload no formato do Google Chat)
+	ChannelSMS     = "sms"
+)
+
+// Message é uma notificação independente de canal. Cada canal decide como formatá-la.
+type Message struct {
+	EventType      string
+	OrganizationID uuid.UUID
+	Subject        string
+	Body           string
+	Link           string
+	HTML           string // Versão HTML já montada (e-mail); vazio = o canal de e-mail usa o modelo genérico
+	FromName       string // Nome do remetente do e-mail
+}
+
+// PlainText formata a mensagem como texto simples: assunto, corpo (se houver) e link.
+func (m Message) PlainText() string {
+	var sb strings.Builder
+	sb.WriteString(m.Subject)
+	if m.Body != "" {
+		sb.WriteString("\n")
+		sb.WriteString(m.Body)
+	}
+	if m.Link != "" {
+		sb.WriteString("\nLink: ")
+		sb.WriteString(m.Link)
+	}
+	return sb.String()
+}
+
+// Channel é um provedor de entrega de notificações. O target depende do canal:
+// endereço de e-mail, URL de webhook (Slack, Teams, genérico) ou telefone (SMS).
+type Channel interface {
+	Name() string
+	Deliver(ctx context.Context, target string, msg Message) error
+}
+
+var (
+	channelsMu sync.RWMutex
+	// Canais que não dependem de configuração ficam disponíveis desde o início.
+	channels = map[string]Channel{
+		ChannelEmail:   emailChannel{},
+		ChannelWebhook: webhookChannel{retryDelay: webhookRetryDelay},
+		ChannelSlack:   slackChannel{},
+		ChannelTeams:   teamsChannel{},
+	}
+)
+
+// RegisterChannel registra (ou substitui) um canal pelo nome.
+func RegisterChannel(ch Channel) {
+	channelsMu.Lock()
+	defer channelsMu.Unlock()
+	channels[ch.Name()] = ch
+}
+
+// GetChannel retorna o canal registrado com o nome informado.
+func GetChannel(name string) (Channel, bool) {
+	channelsMu.RLock()
+	defer channelsMu.RUnlock()
+	ch, ok := channels[name]
+	return ch, ok
+}
+
+// RegisteredChannels retorna os nomes dos canais registrados, em ordem alfabética.
+func RegisteredChannels() []string {
+	channelsMu.RLock()
+	defer channelsMu.RUnlock()
+	names := make([]string, 0, len(channels))
+	for name := range channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// InitChannels registra os canais que dependem de configuração (SMS). Deve ser chamado na inicialização.
+// Novos canais podem ser adicionados com RegisterChannel sem alterar os pontos que disparam notificações.
+func InitChannels() {
+	RegisterChannel(&smsChannel{gatewayURL: config.Cfg.SMSGatewayURL, token: config.Cfg.SMSGatewayToken})
+	phxlog.L.Info("Notification channels registered", zap.Strings("channels", RegisteredChannels()))
+}
+
+// Deliver entrega a mensagem pelo canal informado.
+func Deliver(ctx context.Context, channelName, target string, msg Message) error {
+	ch, ok := GetChannel(channelName)
+	if !ok {
+		return fmt.Errorf("notification channel %q is not registered", channelName)
+	}
+	return DeliverVia(ctx, ch, target, msg)
+}
+
+// DeliverVia entrega a mensagem pelo canal informado, registrado ou não (ex: o autoteste de falhas), e
+// conta a falha em DeliveryFailures.
+func DeliverVia(ctx context.Context, ch Channel, target string, msg Message) error {
+	err := ch.Deliver(ctx, target, msg)
+	if err != nil {
+		DeliveryFailures.WithLabelValues(ch.Name()).Inc()
+	}
+	return err
+}
+
+// DeliveryFailureCount retorna o total de falhas de entrega do canal desde a inicialização.
+func DeliveryFailureCount(channelName string) float64 {
+	var m dto.Metric
+	if err := DeliveryFailures.WithLabelValues(channelName).Write(&m); err != nil {
+		return 0
+	}
+	return m.GetCounter().GetValue()
+}
+
+// emailChannel envia pelo notifier informado ou, sem ele, pelo EmailNotifier (SES ou fallback em log).
+type emailChannel struct {
+	notifier Notifier
+}
+
+// NewEmailChannel cria um canal de e-mail que envia pelo notifier informado, fora do registro de canais.
+func NewEmailChannel(notifier Notifier) Channel {
+	return emailChannel{notifier: notifier}
+}
+
+func (emailChannel) Name() string { return ChannelEmail }
+
+func (e emailChannel) Deliver(ctx context.Context, target string, msg Message) error {
+	notifier := e.notifier
+	if notifier == nil {
+		notifier = EmailNotifier()
+	}
+	if notifier == nil {
+		return errors.New("email notifier is not initialized")
+	}
+	if htmlNotifier, ok := notifier.(HTMLNotifier); ok {
+		return htmlNotifier.SendEmail(ctx, composeEmail(ctx, target, msg))
+	}
+	body := msg.Body
+	if msg.Link != "" {
+		body += "\n\nAcesse: " + msg.Link
+	}
+	return notifier.Send(ctx, target, msg.Subject, body)
+}
+
+// webhookChannel mantém o formato usado pelas WebhookConfigurations existentes ({"text": "..."}).
+type webhookChannel struct {
+	retryDelay time.Duration
+}
+
+// NewWebhookChannel cria um canal de webhook genérico com o intervalo informado entre as tentativas, fora
+// do registro de canais.
+func NewWebhookChannel(retryDelay time.Duration) Channel {
+	return webhookChannel{retryDelay: retryDelay}
+}
+
+func (webhookChannel) Name() string { return ChannelWebhook }
+
+func (w webhookChannel) Deliver(ctx context.Context, target string, msg Message) error {
+	return sendWebhook(target, GoogleChatMessage{Text: msg.PlainText()}, w.retryDelay)
+}
+
+// slackChannel envia para um Incoming Webhook do Slack.
+type slackChannel struct{}
+
+func (slackChannel) Name() string { return ChannelSlack }
+
+func (slackChannel) Deliver(ctx context.Context, target string, msg Message) error {
+	return SendWebhookNotification(target, SlackMessage{Text: msg.PlainText()})
+}
+
+// SlackMessage é o payload de um Incoming Webhook do Slack.
+type SlackMessage struct {
+	Text string `json:"text"`
+}
+
+// teamsChannel envia para um Incoming Webhook do Microsoft Teams.
+type teamsChannel struct{}
+
+func (teamsChannel) Name() string { return ChannelTeams }
+
+func (teamsChannel) Deliver(ctx context.Context, target string, msg Message) error {
+	return SendWebhookNotification(target, NewTeamsMessageCard(msg))
+}
+
+// TeamsMessageCard é o payload (MessageCard) de um Incoming Webhook do Microsoft Teams.
+type TeamsMessageCard struct {
+	Type            string                   `json:"@type"`
+	Context         string                   `json:"@context"`
+	Summary         string                   `json:"summary"`
+	Title           string                   `json:"title"`
+	Text            string                   `json:"text,omitempty"`
+	PotentialAction []map[string]interface{} `json:"potentialAction,omitempty"`
+}
+
+// NewTeamsMessageCard converte a mensagem em um MessageCard, com botão para o link se houver.
+func NewTeamsMessageCard(msg Message) TeamsMessageCard {
+	card := TeamsMessageCard{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Summary: msg.Subject,
+		Title:   msg.Subject,
+		Text:    msg.Body,
+	}
+	if msg.Link != "" {
+		card.PotentialAction = []map[string]interface{}{{
+			"@type":   "OpenUri",
+			"name":    "Abrir no Phoenix GRC",
+			"targets": []map[string]string{{"os": "default", "uri": msg.Link}},
+		}}
+	}
+	return card
+}
+
+// smsMaxLength limita o texto enviado por SMS.
+const smsMaxLength = 320
+
+// smsChannel envia para um gateway HTTP genérico (SMS_GATEWAY_URL). Sem gateway, apenas registra em log.
+type smsChannel struct {
+	gatewayURL string
+	token      string
+}
+
+func (s *smsChannel) Name() string { return ChannelSMS }
+
+func (s *smsChannel) Deliver(ctx context.Context, target string, msg Message) error {
+	text := msg.Subject
+	if msg.Link != "" {
+		text += " " + msg.Link
+	}
+	if len(text) > smsMaxLength {
+		text = text[:smsMaxLength]
+	}
+	if s.gatewayURL == "" {
+		phxlog.L.Info("--- SIMULATING SMS SEND (no SMS_GATEWAY_URL) ---",
+			zap.String("to", target),
+			zap.String("message", text))
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]string{"to": target, "message": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal SMS payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.gatewayURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create SMS gateway request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("SMS gateway request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("SMS gateway returned status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagePlainText(t *testing.T) {
+	msg := Message{Subject: "Novo risco", Body: "Impacto: Alto", Link: "http://localhost:3000/risks/1"}
+	assert.Equal(t, "Novo risco\nImpacto: Alto\nLink: http://localhost:3000/risks/1", msg.PlainText())
+
+	assert.Equal(t, "Somente assunto", Message{Subject: "Somente assunto"}.PlainText())
+}
+
+func TestRiskEventMessageKeepsWebhookFormat(t *testing.T) {
+	risk := models.Risk{ID: uuid.New(), Title: "Vazamento", Description: "Dados expostos", Impact: "Alto", Probability: "Médio", Status: "aberto"}
+
+	msg, ok := riskEventMessage(uuid.New(), risk, models.EventTypeRiskCreated)
+	require.True(t, ok)
+	assert.Contains(t, msg.PlainText(), "🚀 Novo risco criado: *Vazamento*\nDescrição: Dados expostos\nImpacto: Alto, Probabilidade: Médio\nLink: ")
+
+	_, ok = riskEventMessage(uuid.New(), risk, models.WebhookEventType("unknown"))
+	assert.False(t, ok)
+}
+
+func TestChannelRegistry(t *testing.T) {
+	rec := NewRecordingChannel("pager")
+	restore := UseChannelsForTest(rec)
+	defer restore()
+
+	assert.Equal(t, []string{"pager"}, RegisteredChannels())
+
+	err := Deliver(context.Background(), "pager", "oncall", Message{Subject: "hello"})
+	require.NoError(t, err)
+	require.Len(t, rec.Deliveries(), 1)
+	assert.Equal(t, "oncall", rec.Deliveries()[0].Target)
+
+	err = Deliver(context.Background(), ChannelSlack, "https://example.com", Message{})
+	assert.Error(t, err, "unregistered channel should fail")
+
+	restore()
+	_, ok := GetChannel(ChannelEmail)
+	assert.True(t, ok, "built-in channels should be restored")
+}
+
+func TestDispatchToRoutes(t *testing.T) {
+	slack := NewRecordingChannel(ChannelSlack)
+	email := NewRecordingChannel(ChannelEmail)
+	failing := NewRecordingChannel(ChannelSMS)
+	failing.Err = errors.New("gateway down")
+	defer UseChannelsForTest(slack, email, failing)()
+
+	orgID := uuid.New()
+	routes := []models.NotificationRoute{
+		{ID: uuid.New(), OrganizationID: orgID, EventType: "risk_created", Channel: ChannelSlack, Target: "https://hooks.slack.com/a", IsActive: true},
+		{ID: uuid.New(), OrganizationID: orgID, EventType: "*", Channel: ChannelEmail, Target: "grc@example.com", IsActive: true},
+		{ID: uuid.New(), OrganizationID: orgID, EventType: "risk_status_changed", Channel: ChannelSlack, Target: "https://hooks.slack.com/b", IsActive: true},
+		{ID: uuid.New(), OrganizationID: orgID, EventType: "risk_created", Channel: ChannelEmail, Target: "inactive@example.com", IsActive: false},
+		{ID: uuid.New(), OrganizationID: orgID, EventType: "risk_created", Channel: ChannelSMS, Target: "+5511999999999", IsActive: true},
+		{ID: uuid.New(), OrganizationID: orgID, EventType: "risk_created", Channel: "unregistered", Target: "x", IsActive: true},
+	}
+
+	delivered := dispatchToRoutes(context.Background(), routes, Message{EventType: "risk_created", OrganizationID: orgID, Subject: "Novo risco"})
+
+	assert.Equal(t, 2, delivered)
+	require.Len(t, slack.Deliveries(), 1)
+	assert.Equal(t, "https://hooks.slack.com/a", slack.Deliveries()[0].Target)
+	require.Len(t, email.Deliveries(), 1)
+	assert.Equal(t, "grc@example.com", email.Deliveries()[0].Target)
+	assert.Len(t, failing.Deliveries(), 1, "failed deliveries are attempted")
+}
+
+func TestEmailChannelAppendsLink(t *testing.T) {
+	original := EmailNotifier()
+	mock := &MockNotifier{}
+	SetEmailNotifier(mock)
+	defer func() { SetEmailNotifier(original) }()
+
+	err := emailChannel{}.Deliver(context.Background(), "user@example.com", Message{Subject: "Assunto", Body: "Corpo", Link: "http://x/1"})
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", mock.LastTo)
+	assert.Equal(t, "Assunto", mock.LastSubject)
+	assert.Equal(t, "Corpo\n\nAcesse: http://x/1", mock.LastBody)
+}
+
+// htmlNotifier registra os e-mails recebidos por SendEmail.
+type htmlNotifier struct {
+	MockNotifier
+	emails []EmailMessage
+}
+
+func (h *htmlNotifier) SendEmail(ctx context.Context, email EmailMessage) error {
+	h.emails = append(h.emails, email)
+	return nil
+}
+
+func TestEmailChannelSendsBrandedHTML(t *testing.T) {
+	notifier := &htmlNotifier{}
+	err := NewEmailChannel(notifier).Deliver(context.Background(), "user@example.com",
+		Message{Subject: "Assunto", Body: "Linha 1\nLinha 2", Link: "http://x/1"})
+	require.NoError(t, err)
+	require.Len(t, notifier.emails, 1)
+	email := notifier.emails[0]
+	assert.Equal(t, "Assunto", email.Subject)
+	assert.Contains(t, email.Text, "Linha 1\nLinha 2\n\nAcesse: http://x/1")
+	assert.Contains(t, email.HTML, "Linha 1<br>Linha 2")
+	assert.Contains(t, email.HTML, `href="http://x/1"`)
+	assert.False(t, notifier.SendCalled, "HTML notifiers receive the full email")
+
+	// Mensagens já montadas por NotifyUserWithTemplate são enviadas como estão
+	err = NewEmailChannel(notifier).Deliver(context.Background(), "user@example.com",
+		Message{Subject: "Pronto", Body: "texto", HTML: "<p>html</p>", FromName: "ACME"})
+	require.NoError(t, err)
+	assert.Equal(t, EmailMessage{To: "user@example.com", FromName: "ACME", Subject: "Pronto", Text: "texto", HTML: "<p>html</p>"}, notifier.emails[1])
+}
+
+func TestNewTeamsMessageCard(t *testing.T) {
+	card := NewTeamsMessageCard(Message{Subject: "Risco aprovado", Body: "Detalhes", Link: "http://x/1"})
+	assert.Equal(t, "MessageCard", card.Type)
+	assert.Equal(t, "Risco aprovado", card.Title)
+	require.Len(t, card.PotentialAction, 1)
+
+	card = NewTeamsMessageCard(Message{Subject: "Sem link"})
+	assert.Empty(t, card.PotentialAction)
+}
@@ -0,0 +1,74 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/events"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/waivers"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// RunControlWaiverExpirySweep encerra as exceções de controles vencidas, devolvendo os controles a não
+// conforme, e avisa o responsável de cada exceção.
+func RunControlWaiverExpirySweep(ctx context.Context) {
+	db := database.GetDB()
+	lapsed, err := waivers.ExpireDue(ctx, db, time.Now())
+	if err != nil {
+		phxlog.L.Error("Error expiring control waivers", zap.Error(err))
+	}
+	if len(lapsed) == 0 {
+		return
+	}
+
+	frontendBaseURL := config.Cfg.FrontendBaseURL
+	if frontendBaseURL == "" {
+		frontendBaseURL = "http://localhost:3000" // Fallback
+	}
+	for _, item := range lapsed {
+		waiver := item.Waiver
+		if item.Tally != nil {
+			events.Publish(waiver.OrganizationID, events.EventComplianceScoreUpdated, item.Tally.FrameworkID, compliancescore.Summarize(*item.Tally))
+		}
+
+		var control models.AuditControl
+		if err := db.WithContext(ctx).Select("id", "control_id", "description").Take(&control, "id = ?", waiver.AuditControlID).Error; err != nil {
+			phxlog.L.Error("Failed to fetch control for waiver expiry notification",
+				zap.String("waiverID", waiver.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		var owner models.User
+		if err := db.WithContext(ctx).Select("id", "name", "email", "is_active").Take(&owner, "id = ?", waiver.OwnerID).Error; err != nil || owner.Email == "" || !owner.IsActive {
+			phxlog.L.Warn("Waiver owner not found or inactive for expiry notification", zap.String("waiverID", waiver.ID.String()))
+			continue
+		}
+
+		subject := fmt.Sprintf("Exceção Vencida: controle %s voltou a não conforme", control.ControlID)
+		body := fmt.Sprintf("Olá %s,\n\nA exceção do controle %s (%s) venceu em %s e o controle voltou ao status não conforme.\n\nJustificativa da exceção: %s\n\nReavalie o controle ou solicite uma nova exceção no Phoenix GRC.",
+			owner.Name, control.ControlID, control.Description, waiver.ExpiresAt.Format("02/01/2006"), waiver.Justification)
+		deliverAsync(ChannelEmail, owner.Email, Message{
+			EventType:      "control_waiver.expired",
+			OrganizationID: waiver.OrganizationID,
+			Subject:        subject,
+			Body:           body,
+			Link:           fmt.Sprintf("%s/audit/waivers/%s", strings.TrimSuffix(frontendBaseURL, "/"), waiver.ID),
+		})
+		phxlog.L.Info("Control waiver expired",
+			zap.String("waiverID", waiver.ID.String()),
+			zap.String("controlID", control.ControlID))
+	}
+}
+
+// StartControlWaiverExpiryScheduler executa RunControlWaiverExpirySweep periodicamente até o contexto ser cancelado.
+func StartControlWaiverExpiryScheduler(ctx context.Context, every time.Duration) {
+	runPeriodically(ctx, every, RunControlWaiverExpirySweep)
+}
@@ -0,0 +1,149 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"time"
+
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"go.uber.org/zap"
+)
+
+// MaxEmailAttachmentsSize é o tamanho máximo somado dos anexos de um e-mail (o SES aceita até 40 MB na
+// mensagem codificada; base64 aumenta o tamanho em cerca de um terço).
+const MaxEmailAttachmentsSize = 25 << 20
+
+// Attachment é um arquivo anexado a um e-mail.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// AttachmentNotifier é implementado pelos notificadores de e-mail que enviam anexos.
+type AttachmentNotifier interface {
+	SendWithAttachments(ctx context.Context, to, subject, body string, attachments []Attachment) error
+}
+
+// SendEmailWithAttachments envia um e-mail com anexos pelo EmailNotifier, contando a falha em
+// DeliveryFailures como as demais entregas de e-mail.
+func SendEmailWithAttachments(ctx context.Context, to, subject, body string, attachments []Attachment) error {
+	notifier, ok := EmailNotifier().(AttachmentNotifier)
+	if !ok {
+		DeliveryFailures.WithLabelValues(ChannelEmail).Inc()
+		return errors.New("email notifier does not support attachments")
+	}
+	if err := notifier.SendWithAttachments(ctx, to, subject, body, attachments); err != nil {
+		DeliveryFailures.WithLabelValues(ChannelEmail).Inc()
+		return err
+	}
+	return nil
+}
+
+// buildMIMEMessage monta a mensagem multipart/mixed com o corpo em texto e os anexos em base64.
+func buildMIMEMessage(from, to, subject, body string, attachments []Attachment, date time.Time) ([]byte, error) {
+	size := 0
+	for _, a := range attachments {
+		size += len(a.Data)
+	}
+	if size > MaxEmailAttachmentsSize {
+		return nil, fmt.Errorf("attachments exceed %d bytes", MaxEmailAttachmentsSize)
+	}
+
+	var msg bytes.Buffer
+	mw := multipart.NewWriter(&msg)
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%q\r\n\r\n",
+		from, to, mime.QEncoding.Encode("UTF-8", subject), date.Format(time.RFC1123Z), mw.Boundary())
+
+	textPart, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	qp := quotedprintable.NewWriter(textPart)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, a := range attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": a.Filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoded := base64.StdEncoding.EncodeToString(a.Data)
+		for len(encoded) > 76 {
+			if _, err := part.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+				return nil, err
+			}
+			encoded = encoded[76:]
+		}
+		if _, err := part.Write([]byte(encoded + "\r\n")); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// SendWithAttachments envia um e-mail com anexos pelo Amazon SES (mensagem MIME bruta).
+func (s *SESEmailNotifier) SendWithAttachments(ctx context.Context, to, subject, body string, attachments []Attachment) error {
+	raw, err := buildMIMEMessage(s.senderEmail, to, subject, body, attachments, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to build email with attachments: %w", err)
+	}
+	_, err = s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: &s.senderEmail,
+		Destination:      &types.Destination{ToAddresses: []string{to}},
+		Content:          &types.EmailContent{Raw: &types.RawMessage{Data: raw}},
+	})
+	if err != nil {
+		phxlog.L.Error("Failed to send email with attachments via SES",
+			zap.String("to", to),
+			zap.String("subject", subject),
+			zap.Error(err))
+		return fmt.Errorf("failed to send email via SES: %w", err)
+	}
+	phxlog.L.Info("Email with attachments sent successfully via AWS SES",
+		zap.String("to", to),
+		zap.String("subject", subject),
+		zap.Int("attachments", len(attachments)))
+	return nil
+}
+
+func (n *logNotifier) SendWithAttachments(ctx context.Context, to, subject, body string, attachments []Attachment) error {
+	names := make([]string, len(attachments))
+	for i, a := range attachments {
+		names[i] = fmt.Sprintf("%s (%d bytes)", a.Filename, len(a.Data))
+	}
+	phxlog.L.Info("--- SIMULATING EMAIL SEND WITH ATTACHMENTS (Fallback) ---",
+		zap.String("to", to),
+		zap.String("subject", subject),
+		zap.String("body", body),
+		zap.Strings("attachments", names))
+	return nil
+}
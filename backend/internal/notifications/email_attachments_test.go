@@ -0,0 +1,58 @@
+package notifications
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMIMEMessage(t *testing.T) {
+	pdf := bytes.Repeat([]byte("%PDF-1.4 conteúdo "), 20)
+	raw, err := buildMIMEMessage("grc@example.com", "board@example.com", "Relatório semanal de riscos",
+		"Segue o relatório.", []Attachment{{Filename: "riscos 2026-10.pdf", ContentType: "application/pdf", Data: pdf}},
+		time.Date(2026, 10, 5, 8, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Relatório semanal de riscos", subject)
+	assert.Equal(t, "board@example.com", msg.Header.Get("To"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	text, err := mr.NextPart()
+	require.NoError(t, err)
+	body, err := io.ReadAll(text) // multipart decodifica quoted-printable
+	require.NoError(t, err)
+	assert.Equal(t, "Segue o relatório.", string(body))
+
+	attachment, err := mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "riscos 2026-10.pdf", attachment.FileName())
+	encoded, err := io.ReadAll(attachment)
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(string(encoded)), "\r\n") {
+		assert.LessOrEqual(t, len(line), 76)
+	}
+	_, err = mr.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestBuildMIMEMessageRejectsLargeAttachments(t *testing.T) {
+	_, err := buildMIMEMessage("a@example.com", "b@example.com", "x", "y",
+		[]Attachment{{Filename: "big.bin", Data: make([]byte, MaxEmailAttachmentsSize+1)}}, time.Now())
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@ package notifications
 import (
 	"context"
 	"fmt"
+	"net/mail"
+	"sync/atomic"
 
 	"phoenixgrc/backend/pkg/config"
 	phxlog "phoenixgrc/backend/pkg/log"
@@ -19,64 +21,121 @@ type Notifier interface {
 	Send(ctx context.Context, to, subject, body string) error
 }
 
+// EmailMessage é um e-mail com versão em texto e, opcionalmente, em HTML.
+type EmailMessage struct {
+	To       string
+	FromName string // Nome exibido do remetente; vazio = apenas o endereço configurado
+	Subject  string
+	Text     string
+	HTML     string // Vazio = apenas texto
+}
+
+// HTMLNotifier é implementado pelos notificadores de e-mail que enviam a versão HTML e o nome do remetente.
+type HTMLNotifier interface {
+	SendEmail(ctx context.Context, email EmailMessage) error
+}
+
 // SESEmailNotifier implementa a interface Notifier para o Amazon SES.
 type SESEmailNotifier struct {
 	client      *sesv2.Client
 	senderEmail string
 }
 
-// DefaultEmailNotifier é a instância padrão do notificador de e-mail.
-var DefaultEmailNotifier Notifier
+// notifierHolder embrulha o Notifier para guardá-lo em um atomic.Pointer.
+type notifierHolder struct {
+	notifier Notifier
+}
+
+// defaultEmailNotifier é o notificador de e-mail padrão. InitEmailService pode trocá-lo com o servidor em
+// operação (recarga da configuração, e-mail de teste) enquanto as entregas o leem.
+var defaultEmailNotifier atomic.Pointer[notifierHolder]
+
+// EmailNotifier retorna o notificador de e-mail padrão, ou nil antes de InitEmailService.
+func EmailNotifier() Notifier {
+	if holder := defaultEmailNotifier.Load(); holder != nil {
+		return holder.notifier
+	}
+	return nil
+}
+
+// SetEmailNotifier troca o notificador de e-mail padrão.
+func SetEmailNotifier(notifier Notifier) {
+	defaultEmailNotifier.Store(&notifierHolder{notifier: notifier})
+}
 
-// InitEmailService inicializa o notificador de e-mail padrão.
+// InitEmailService inicializa o notificador de e-mail padrão com a configuração em vigor.
 func InitEmailService() {
 	log := phxlog.L.Named("EmailService")
-	region := config.Cfg.AWSRegion
-	sender := config.Cfg.AWSSESEmailSender
+	cfg := config.Get()
+	region := cfg.AWSRegion
+	sender := cfg.AWSSESEmailSender
 
 	if region == "" || sender == "" {
 		log.Warn("AWS SES email service is not configured (missing AWS_REGION or AWS_SENDER_EMAIL). Email notifications will be disabled.")
-		DefaultEmailNotifier = &logNotifier{} // Fallback para um notificador que apenas loga
+		SetEmailNotifier(&logNotifier{}) // Fallback para um notificador que apenas loga
 		return
 	}
 
 	sdkConfig, err := awsGoConfig.LoadDefaultConfig(context.TODO(), awsGoConfig.WithRegion(region))
 	if err != nil {
 		log.Error("Failed to load AWS SDK config for SES", zap.Error(err))
-		DefaultEmailNotifier = &logNotifier{}
+		SetEmailNotifier(&logNotifier{})
 		return
 	}
 
-	DefaultEmailNotifier = &SESEmailNotifier{
+	SetEmailNotifier(&SESEmailNotifier{
 		client:      sesv2.NewFromConfig(sdkConfig),
 		senderEmail: sender,
-	}
+	})
 	log.Info("AWS SES email service initialized successfully.", zap.String("sender", sender), zap.String("region", region))
 }
 
-// Send envia um e-mail usando o Amazon SES.
+// EmailConfigured indica se o envio de e-mails está configurado. Sem AWS_REGION e AWS_SES_EMAIL_SENDER, as
+// mensagens são apenas registradas no log.
+func EmailConfigured() bool {
+	notifier := EmailNotifier()
+	if notifier == nil {
+		return false
+	}
+	_, fallback := notifier.(*logNotifier)
+	return !fallback
+}
+
+// Send envia um e-mail em texto usando o Amazon SES.
 func (s *SESEmailNotifier) Send(ctx context.Context, to, subject, body string) error {
+	return s.SendEmail(ctx, EmailMessage{To: to, Subject: subject, Text: body})
+}
+
+// SendEmail envia um e-mail com as versões em texto e HTML usando o Amazon SES.
+func (s *SESEmailNotifier) SendEmail(ctx context.Context, email EmailMessage) error {
+	from := s.senderEmail
+	if email.FromName != "" {
+		from = (&mail.Address{Name: email.FromName, Address: s.senderEmail}).String()
+	}
+	body := &types.Body{
+		Text: &types.Content{
+			Data:    aws.String(email.Text),
+			Charset: aws.String("UTF-8"),
+		},
+	}
+	if email.HTML != "" {
+		body.Html = &types.Content{
+			Data:    aws.String(email.HTML),
+			Charset: aws.String("UTF-8"),
+		}
+	}
 	input := &sesv2.SendEmailInput{
-		FromEmailAddress: &s.senderEmail,
+		FromEmailAddress: &from,
 		Destination: &types.Destination{
-			ToAddresses: []string{to},
+			ToAddresses: []string{email.To},
 		},
 		Content: &types.EmailContent{
 			Simple: &types.Message{
 				Subject: &types.Content{
-					Data:    aws.String(subject),
+					Data:    aws.String(email.Subject),
 					Charset: aws.String("UTF-8"),
 				},
-				Body: &types.Body{
-					Text: &types.Content{
-						Data:    aws.String(body),
-						Charset: aws.String("UTF-8"),
-					},
-					Html: &types.Content{
-						Data:    aws.String(body), // Usando o mesmo corpo para HTML por simplicidade
-						Charset: aws.String("UTF-8"),
-					},
-				},
+				Body: body,
 			},
 		},
 	}
@@ -84,15 +143,15 @@ func (s *SESEmailNotifier) Send(ctx context.Context, to, subject, body string) e
 	_, err := s.client.SendEmail(ctx, input)
 	if err != nil {
 		phxlog.L.Error("Failed to send email via SES",
-			zap.String("to", to),
-			zap.String("subject", subject),
+			zap.String("to", email.To),
+			zap.String("subject", email.Subject),
 			zap.Error(err))
 		return fmt.Errorf("failed to send email via SES: %w", err)
 	}
 
 	phxlog.L.Info("Email sent successfully via AWS SES",
-		zap.String("to", to),
-		zap.String("subject", subject))
+		zap.String("to", email.To),
+		zap.String("subject", email.Subject))
 	return nil
 }
 
@@ -106,3 +165,14 @@ func (n *logNotifier) Send(ctx context.Context, to, subject, body string) error
 		zap.String("body", body))
 	return nil
 }
+
+// SendEmail registra o e-mail; o HTML não é registrado, apenas o seu tamanho.
+func (n *logNotifier) SendEmail(ctx context.Context, email EmailMessage) error {
+	phxlog.L.Info("--- SIMULATING EMAIL SEND (Fallback) ---",
+		zap.String("to", email.To),
+		zap.String("fromName", email.FromName),
+		zap.String("subject", email.Subject),
+		zap.String("body", email.Text),
+		zap.Int("htmlBytes", len(email.HTML)))
+	return nil
+}
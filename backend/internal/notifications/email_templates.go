@@ -0,0 +1,173 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/i18n"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications/templates"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// EmailLogoURLMinutes é a validade da URL assinada do logo da organização nos e-mails. Depois disso o
+// e-mail é exibido sem a imagem; para um logo permanente, use uma URL pública em EmailBranding.LogoURL.
+const EmailLogoURLMinutes = 7 * 24 * 60
+
+// FrontendLink retorna a URL do frontend para o caminho informado (ex: "/risks/<id>").
+func FrontendLink(path string) string {
+	frontendBaseURL := config.Cfg.FrontendBaseURL
+	if frontendBaseURL == "" {
+		frontendBaseURL = "http://localhost:3000" // Fallback
+	}
+	return strings.TrimSuffix(frontendBaseURL, "/") + path
+}
+
+// RiskLink retorna a URL do risco no frontend.
+func RiskLink(riskID uuid.UUID) string {
+	return FrontendLink("/risks/" + riskID.String())
+}
+
+// OrganizationBranding retorna a identidade visual e o idioma dos e-mails da organização: o branding da
+// organização (nome, logo e cores), sobreposto pelos campos preenchidos em EmailBranding. Sem organização
+// ou banco de dados, retorna o padrão.
+func OrganizationBranding(ctx context.Context, db *gorm.DB, orgID uuid.UUID) (templates.Branding, i18n.Locale) {
+	var brand templates.Branding
+	locale := templates.DefaultLocale
+	if db == nil || orgID == uuid.Nil {
+		return brand.WithDefaults(), locale
+	}
+	var org models.Organization
+	if err := db.WithContext(ctx).Select("id", "name", "logo_url", "primary_color", "secondary_color").
+		Take(&org, "id = ?", orgID).Error; err != nil {
+		phxlog.L.Warn("Failed to load organization branding for email", zap.String("organizationID", orgID.String()), zap.Error(err))
+		return brand.WithDefaults(), locale
+	}
+	brand = templates.Branding{OrganizationName: org.Name, PrimaryColor: org.PrimaryColor, SecondaryColor: org.SecondaryColor}
+
+	var custom models.EmailBranding
+	err := db.WithContext(ctx).Where("organization_id = ?", orgID).Take(&custom).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		phxlog.L.Warn("Failed to load email branding", zap.String("organizationID", orgID.String()), zap.Error(err))
+	}
+	brand.FromName = custom.FromName
+	brand.FooterText = custom.FooterText
+	if custom.PrimaryColor != "" {
+		brand.PrimaryColor = custom.PrimaryColor
+	}
+	if custom.SecondaryColor != "" {
+		brand.SecondaryColor = custom.SecondaryColor
+	}
+	if custom.LogoURL != "" {
+		brand.LogoURL = custom.LogoURL
+	} else {
+		brand.LogoURL = emailLogoURL(ctx, org.LogoURL)
+	}
+	if templates.SupportedLocale(i18n.Locale(custom.Locale)) {
+		locale = i18n.Locale(custom.Locale)
+	}
+	return brand.WithDefaults(), locale
+}
+
+// emailLogoURL retorna a URL do logo da organização para os e-mails: URLs externas são mantidas e objetos do
+// armazenamento recebem uma URL assinada de EmailLogoURLMinutes.
+func emailLogoURL(ctx context.Context, logo string) string {
+	if logo == "" || strings.HasPrefix(logo, "https://") || strings.HasPrefix(logo, "http://") {
+		return logo
+	}
+	if filestorage.DefaultFileStorageProvider == nil {
+		return ""
+	}
+	signedURL, err := filestorage.DefaultFileStorageProvider.GetSignedURL(ctx, logo, EmailLogoURLMinutes)
+	if err != nil {
+		phxlog.L.Warn("Failed to sign organization logo for email", zap.String("objectName", logo), zap.Error(err))
+		return ""
+	}
+	return signedURL
+}
+
+// NotifyUserWithTemplate monta o e-mail do modelo name (ver o pacote templates) com a identidade visual e o
+// idioma da organização do usuário, registra a notificação na central do usuário (com o assunto e o conteúdo
+// do modelo) e enfileira o envio.
+func NotifyUserWithTemplate(ctx context.Context, userID uuid.UUID, name string, data interface{}) {
+	user, ok := notificationRecipient(ctx, userID)
+	if !ok {
+		return
+	}
+	db := database.GetDB()
+	var orgID uuid.UUID
+	if user.OrganizationID.Valid {
+		orgID = user.OrganizationID.UUID
+	}
+	brand, locale := OrganizationBranding(ctx, db, orgID)
+	email, err := templates.Render(name, locale, brand, user.Name, data)
+	if err != nil {
+		phxlog.L.Error("Failed to render email template",
+			zap.String("template", name),
+			zap.String("userID", userID.String()),
+			zap.Error(err))
+		return
+	}
+	recordInApp(ctx, user, name, email.Subject, email.Content, templates.Link(data))
+	if !hasEmail(user) {
+		return
+	}
+	deliverAsync(ChannelEmail, user.Email, Message{
+		OrganizationID: orgID,
+		Subject:        email.Subject,
+		Body:           email.Text,
+		HTML:           email.HTML,
+		FromName:       brand.FromName,
+	})
+}
+
+// EmailWithTemplate monta o e-mail do modelo name com a identidade visual e o idioma da organização e enfileira
+// o envio para um endereço que não é (ainda) de um usuário, como o de um convite; não há registro na central
+// de notificações.
+func EmailWithTemplate(ctx context.Context, orgID uuid.UUID, to, recipientName, name string, data interface{}) error {
+	brand, locale := OrganizationBranding(ctx, database.GetDB(), orgID)
+	email, err := templates.Render(name, locale, brand, recipientName, data)
+	if err != nil {
+		return err
+	}
+	deliverAsync(ChannelEmail, to, Message{
+		OrganizationID: orgID,
+		Subject:        email.Subject,
+		Body:           email.Text,
+		HTML:           email.HTML,
+		FromName:       brand.FromName,
+	})
+	return nil
+}
+
+// composeEmail monta o e-mail da mensagem. Mensagens sem HTML (assunto e corpo livres) usam o modelo
+// genérico com a identidade visual da organização da mensagem.
+func composeEmail(ctx context.Context, to string, msg Message) EmailMessage {
+	if msg.HTML != "" {
+		return EmailMessage{To: to, FromName: msg.FromName, Subject: msg.Subject, Text: msg.Body, HTML: msg.HTML}
+	}
+	brand, locale := OrganizationBranding(ctx, database.GetDB(), msg.OrganizationID)
+	fromName := msg.FromName
+	if fromName == "" {
+		fromName = brand.FromName
+	}
+	email, err := templates.Render(templates.NameNotification, locale, brand, "",
+		templates.Notification{Subject: msg.Subject, Body: msg.Body, Link: msg.Link})
+	if err != nil {
+		phxlog.L.Error("Failed to render email template, sending plain text", zap.Error(err))
+		text := msg.Body
+		if msg.Link != "" {
+			text += "\n\nAcesse: " + msg.Link
+		}
+		return EmailMessage{To: to, FromName: fromName, Subject: msg.Subject, Text: text}
+	}
+	return EmailMessage{To: to, FromName: fromName, Subject: email.Subject, Text: email.Text, HTML: email.HTML}
+}
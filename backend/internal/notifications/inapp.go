@@ -0,0 +1,37 @@
+package notifications
+
+import (
+	"context"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// maxInAppTitleLength é o tamanho da coluna title de notifications.
+const maxInAppTitleLength = 255
+
+// recordInApp registra a notificação na central do usuário. kind é o modelo que a originou. Uma falha é
+// apenas registrada no log: o e-mail continua sendo enviado.
+func recordInApp(ctx context.Context, user models.User, kind, title, body, link string) {
+	db := database.GetDB()
+	if db == nil {
+		return
+	}
+	if r := []rune(title); len(r) > maxInAppTitleLength {
+		title = string(r[:maxInAppTitleLength-1]) + "…"
+	}
+	notification := models.Notification{UserID: user.ID, Type: kind, Title: title, Body: body, Link: link}
+	if user.OrganizationID.Valid {
+		orgID := user.OrganizationID.UUID
+		notification.OrganizationID = &orgID
+	}
+	if err := db.WithContext(ctx).Create(&notification).Error; err != nil {
+		phxlog.L.Error("Failed to record in-app notification",
+			zap.String("userID", user.ID.String()),
+			zap.String("type", kind),
+			zap.Error(err))
+	}
+}
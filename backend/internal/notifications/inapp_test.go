@@ -0,0 +1,38 @@
+package notifications
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRecordInApp(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	originalDB := database.DB
+	database.DB = db
+	defer func() { database.DB = originalDB }()
+
+	orgID := uuid.New()
+	user := models.User{ID: uuid.New(), OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true}}
+	longTitle := strings.Repeat("á", 300)
+	mock.ExpectExec(`INSERT INTO "notifications"`).
+		WithArgs(sqlmock.AnyArg(), user.ID, orgID, "risk_created", strings.Repeat("á", 254)+"…", "corpo", "https://grc.example.com/risks/1", nil, nil, nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	recordInApp(context.Background(), user, "risk_created", longTitle, "corpo", "https://grc.example.com/risks/1")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,103 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Tipos de job das notificações.
+const (
+	JobDeliver   = "notification.deliver"    // Entrega de uma mensagem por um canal
+	JobRiskEvent = "notification.risk_event" // Webhooks e regras de roteamento de um evento de risco
+)
+
+// deliveryJob é o payload de JobDeliver.
+type deliveryJob struct {
+	Channel        string    `json:"channel"`
+	Target         string    `json:"target"`
+	EventType      string    `json:"event_type,omitempty"`
+	OrganizationID uuid.UUID `json:"organization_id,omitempty"`
+	Subject        string    `json:"subject"`
+	Body           string    `json:"body,omitempty"`
+	Link           string    `json:"link,omitempty"`
+	HTML           string    `json:"html,omitempty"`
+	FromName       string    `json:"from_name,omitempty"`
+}
+
+// riskEventJob é o payload de JobRiskEvent. O risco é recarregado na execução.
+type riskEventJob struct {
+	OrganizationID uuid.UUID               `json:"organization_id"`
+	RiskID         uuid.UUID               `json:"risk_id"`
+	EventType      models.WebhookEventType `json:"event_type"`
+}
+
+// RegisterJobHandlers registra os handlers dos jobs de notificação. Deve ser chamado pelos processos que
+// executam a fila de jobs.
+func RegisterJobHandlers() {
+	jobs.Register(JobDeliver, func(ctx context.Context, job *models.BackgroundJob) error {
+		var p deliveryJob
+		if err := jobs.DecodePayload(job, &p); err != nil {
+			return err
+		}
+		msg := Message{EventType: p.EventType, OrganizationID: p.OrganizationID, Subject: p.Subject, Body: p.Body, Link: p.Link,
+			HTML: p.HTML, FromName: p.FromName}
+		return Deliver(ctx, p.Channel, p.Target, msg)
+	})
+	jobs.Register(JobRiskEvent, func(ctx context.Context, job *models.BackgroundJob) error {
+		var p riskEventJob
+		if err := jobs.DecodePayload(job, &p); err != nil {
+			return err
+		}
+		var risk models.Risk
+		if err := database.GetDB().WithContext(ctx).Preload("Owner").
+			Where("id = ? AND organization_id = ?", p.RiskID, p.OrganizationID).First(&risk).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				phxlog.L.Info("Risk removed before its event was notified", zap.String("riskID", p.RiskID.String()))
+				return nil
+			}
+			return err
+		}
+		NotifyRiskEvent(ctx, p.OrganizationID, risk, p.EventType)
+		return nil
+	})
+}
+
+// deliverAsync enfileira a entrega da mensagem na fila de jobs, com retentativas. Se a fila estiver
+// indisponível, entrega em uma goroutine, registrando falhas em log.
+func deliverAsync(channelName, target string, msg Message) {
+	payload := deliveryJob{Channel: channelName, Target: target, EventType: msg.EventType, OrganizationID: msg.OrganizationID,
+		Subject: msg.Subject, Body: msg.Body, Link: msg.Link, HTML: msg.HTML, FromName: msg.FromName}
+	if _, err := jobs.Enqueue(context.Background(), database.GetDB(), JobDeliver, payload, jobs.WithOrganization(msg.OrganizationID)); err == nil {
+		return
+	} else if !errors.Is(err, jobs.ErrNoDatabase) {
+		phxlog.L.Warn("Failed to enqueue notification delivery, delivering in process", zap.String("channel", channelName), zap.Error(err))
+	}
+	go func() {
+		if err := Deliver(context.Background(), channelName, target, msg); err != nil {
+			phxlog.L.Error("Failed to deliver notification",
+				zap.String("channel", channelName),
+				zap.String("eventType", msg.EventType),
+				zap.Error(err))
+		}
+	}()
+}
+
+// QueueRiskEvent enfileira a notificação de um evento de risco (ver NotifyRiskEvent). Se a fila estiver
+// indisponível, notifica em uma goroutine.
+func QueueRiskEvent(ctx context.Context, orgID uuid.UUID, risk models.Risk, eventType models.WebhookEventType) {
+	payload := riskEventJob{OrganizationID: orgID, RiskID: risk.ID, EventType: eventType}
+	if _, err := jobs.Enqueue(ctx, database.GetDB(), JobRiskEvent, payload, jobs.WithOrganization(orgID)); err != nil {
+		phxlog.L.Warn("Failed to enqueue risk event notification, notifying in process",
+			zap.String("riskID", risk.ID.String()), zap.Error(err))
+		go NotifyRiskEvent(context.Background(), orgID, risk, eventType)
+	}
+}
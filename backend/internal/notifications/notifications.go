@@ -1,108 +1,151 @@
 package notifications
 
 import (
-	"fmt"
 	"context"
-	"strings"
+	"fmt"
 
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
-	"phoenixgrc/backend/pkg/config"
+	"phoenixgrc/backend/internal/notifications/templates"
 	phxlog "phoenixgrc/backend/pkg/log"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-// NotifyRiskEvent busca webhooks e/ou outros métodos de notificação para um evento de risco.
+// NotifyRiskEvent notifica um evento de risco pelos webhooks configurados e pelas regras de roteamento da organização,
+// desde que o evento passe pelos filtros de notificação de risco da organização.
 func NotifyRiskEvent(ctx context.Context, orgID uuid.UUID, risk models.Risk, eventType models.WebhookEventType) {
-	// Notificação via Webhook
-	notifyRiskEventViaWebhook(ctx, orgID, risk, eventType)
+	msg, ok := riskEventMessage(orgID, risk, eventType)
+	if !ok {
+		phxlog.L.Warn("Unknown risk event type for notification", zap.String("eventType", string(eventType)))
+		return
+	}
+	if !riskEventPassesFilters(ctx, orgID, risk, msg.EventType) {
+		phxlog.L.Debug("Risk event suppressed by notification filters",
+			zap.String("organizationID", orgID.String()),
+			zap.String("riskID", risk.ID.String()),
+			zap.String("eventType", msg.EventType))
+		return
+	}
+
+	// Notificação via Webhook (WebhookConfiguration)
+	notifyRiskEventViaWebhook(ctx, msg)
 
-	// Outros métodos de notificação (ex: e-mail) podem ser adicionados aqui.
+	// Regras de roteamento por canal (e-mail, Slack, Teams, SMS...)
+	Dispatch(ctx, msg)
 }
 
-func notifyRiskEventViaWebhook(ctx context.Context, orgID uuid.UUID, risk models.Risk, eventType models.WebhookEventType) {
+// riskEventMessage monta a mensagem independente de canal para o evento de risco.
+func riskEventMessage(orgID uuid.UUID, risk models.Risk, eventType models.WebhookEventType) (Message, bool) {
+	msg := Message{
+		EventType:      string(eventType),
+		OrganizationID: orgID,
+		Link:           RiskLink(risk.ID),
+	}
+
+	switch eventType {
+	case models.EventTypeRiskCreated:
+		msg.Subject = fmt.Sprintf("🚀 Novo risco criado: *%s*", risk.Title)
+		msg.Body = fmt.Sprintf("Descrição: %s\nImpacto: %s, Probabilidade: %s", risk.Description, risk.Impact, risk.Probability)
+	case models.EventTypeRiskStatusChanged:
+		msg.Subject = fmt.Sprintf("🔄 Status do risco '*%s*' alterado para: *%s*", risk.Title, risk.Status)
+	case models.EventTypeRiskOwnerChanged:
+		msg.Subject = fmt.Sprintf("👤 Responsável pelo risco '*%s*' alterado", risk.Title)
+		if risk.Owner.Name != "" {
+			msg.Subject = fmt.Sprintf("👤 Responsável pelo risco '*%s*' alterado para: *%s*", risk.Title, risk.Owner.Name)
+		}
+	default:
+		return Message{}, false
+	}
+	return msg, true
+}
+
+func notifyRiskEventViaWebhook(ctx context.Context, msg Message) {
 	db := database.GetDB()
 	var webhooks []models.WebhookConfiguration
 
-	eventPattern := string(eventType)
-	err := db.WithContext(ctx).Where("organization_id = ? AND is_active = ?", orgID, true).
-		Where("event_types LIKE ?", "%"+eventPattern+"%").
+	err := db.WithContext(ctx).Where("organization_id = ? AND is_active = ?", msg.OrganizationID, true).
+		Where("event_types LIKE ?", "%"+msg.EventType+"%").
 		Find(&webhooks).Error
 
 	if err != nil {
 		phxlog.L.Error("Error fetching webhooks for notification",
-			zap.String("organizationID", orgID.String()),
-			zap.String("eventType", string(eventType)),
+			zap.String("organizationID", msg.OrganizationID.String()),
+			zap.String("eventType", msg.EventType),
 			zap.Error(err))
 		return
 	}
 
-	if len(webhooks) == 0 {
-		return // Nenhum webhook configurado para este evento/org
+	for _, wh := range webhooks {
+		deliverAsync(ChannelWebhook, wh.URL, msg)
 	}
+}
 
-	frontendBaseURL := config.Cfg.FrontendBaseURL
-	if frontendBaseURL == "" {
-		frontendBaseURL = "http://localhost:3000" // Fallback
+// NotifyUserByEmail registra uma notificação na central do usuário e enfileira o e-mail correspondente. O
+// e-mail usa o modelo genérico com a identidade visual da organização do usuário; para um modelo próprio, use
+// NotifyUserWithTemplate.
+func NotifyUserByEmail(ctx context.Context, userID uuid.UUID, subject, body string) {
+	user, ok := notificationRecipient(ctx, userID)
+	if !ok {
+		return
 	}
-	riskURL := fmt.Sprintf("%s/risks/%s", strings.TrimSuffix(frontendBaseURL, "/"), risk.ID.String())
-
-	var messageText string
-	switch eventType {
-	case models.EventTypeRiskCreated:
-		messageText = fmt.Sprintf("🚀 Novo risco criado: *%s*\nDescrição: %s\nImpacto: %s, Probabilidade: %s\nLink: %s",
-			risk.Title, risk.Description, risk.Impact, risk.Probability, riskURL)
-	case models.EventTypeRiskStatusChanged:
-		messageText = fmt.Sprintf("🔄 Status do risco '*%s*' alterado para: *%s*\nLink: %s",
-			risk.Title, risk.Status, riskURL)
-	default:
-		phxlog.L.Warn("Unknown risk event type for notification", zap.String("eventType", string(eventType)))
+	recordInApp(ctx, user, templates.NameNotification, subject, body, "")
+	if !hasEmail(user) {
 		return
 	}
-
-	payload := GoogleChatMessage{Text: messageText}
-
-	for _, wh := range webhooks {
-		go func(webhookURL, webhookName string) {
-			if err := SendWebhookNotification(webhookURL, payload); err != nil {
-				phxlog.L.Error("Failed to send webhook notification",
-					zap.String("webhookURL", webhookURL),
-					zap.String("webhookName", webhookName),
-					zap.Error(err))
-			}
-		}(wh.URL, wh.Name)
+	msg := Message{Subject: subject, Body: body}
+	if user.OrganizationID.Valid {
+		msg.OrganizationID = user.OrganizationID.UUID
 	}
+	deliverAsync(ChannelEmail, user.Email, msg)
 }
 
-// NotifyUserByEmail envia uma notificação por e-mail para um usuário específico.
-func NotifyUserByEmail(ctx context.Context, userID uuid.UUID, subject, body string) {
+// notificationRecipient carrega o usuário a notificar; ok é false (com log) se ele não existir.
+func notificationRecipient(ctx context.Context, userID uuid.UUID) (user models.User, ok bool) {
 	if userID == uuid.Nil {
-		phxlog.L.Warn("Attempted to notify user by email with nil UserID.")
-		return
+		phxlog.L.Warn("Attempted to notify user with nil UserID.")
+		return user, false
 	}
 	db := database.GetDB()
-	var user models.User
 	if err := db.WithContext(ctx).First(&user, userID).Error; err != nil {
-		phxlog.L.Error("Error fetching user for email notification",
+		phxlog.L.Error("Error fetching user for notification",
 			zap.String("userID", userID.String()),
 			zap.Error(err))
-		return
+		return user, false
 	}
+	return user, true
+}
+
+// hasEmail indica se o usuário tem e-mail para receber a notificação (com log se não tiver).
+func hasEmail(user models.User) bool {
 	if user.Email == "" {
 		phxlog.L.Warn("User has no email address for notification.",
-			zap.String("userID", userID.String()))
-		return
+			zap.String("userID", user.ID.String()))
+		return false
 	}
+	return true
+}
 
-	go func(email, subj, bdy string) {
-		if DefaultEmailNotifier != nil {
-			if err := DefaultEmailNotifier.Send(context.Background(), email, subj, bdy); err != nil {
-				phxlog.L.Error("Failed to send email notification",
-					zap.String("recipientEmail", email),
-					zap.Error(err))
-			}
-		}
-	}(user.Email, subject, body)
+// CountRiskEventDeliveries estima quantas entregas um evento de risco gera na organização: webhooks
+// ativos assinantes do evento mais regras de roteamento ativas. Não considera os filtros de notificação
+// de riscos, portanto é um limite superior.
+func CountRiskEventDeliveries(ctx context.Context, orgID uuid.UUID, eventType models.WebhookEventType) (int, error) {
+	db := database.GetDB().WithContext(ctx)
+	var webhooks, routes int64
+	err := db.Model(&models.WebhookConfiguration{}).
+		Where("organization_id = ? AND is_active = ?", orgID, true).
+		Where("event_types LIKE ?", "%"+string(eventType)+"%").
+		Count(&webhooks).Error
+	if err != nil {
+		return 0, err
+	}
+	err = db.Model(&models.NotificationRoute{}).
+		Where("organization_id = ? AND is_active = ?", orgID, true).
+		Where("event_type IN ?", []string{string(eventType), models.NotificationRouteAnyEvent}).
+		Count(&routes).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(webhooks + routes), nil
 }
@@ -31,10 +31,10 @@ func (m *MockNotifier) Send(ctx context.Context, to, subject, body string) error
 }
 
 func TestInitEmailService(t *testing.T) {
-	originalNotifier := DefaultEmailNotifier
+	originalNotifier := EmailNotifier()
 	originalCfg := config.Cfg
 	defer func() {
-		DefaultEmailNotifier = originalNotifier
+		SetEmailNotifier(originalNotifier)
 		config.Cfg = originalCfg
 	}()
 
@@ -44,9 +44,10 @@ func TestInitEmailService(t *testing.T) {
 
 		InitEmailService()
 
-		assert.NotNil(t, DefaultEmailNotifier)
-		_, ok := DefaultEmailNotifier.(*logNotifier)
-		assert.True(t, ok, "DefaultEmailNotifier should be a logNotifier")
+		assert.NotNil(t, EmailNotifier())
+		_, ok := EmailNotifier().(*logNotifier)
+		assert.True(t, ok, "EmailNotifier should be a logNotifier")
+		assert.False(t, EmailConfigured())
 	})
 
 	t.Run("Service initializes with logNotifier when AWS SDK fails", func(t *testing.T) {
@@ -57,7 +58,7 @@ func TestInitEmailService(t *testing.T) {
 		// Sem credenciais AWS reais, a inicialização do SDK falhará, caindo para logNotifier.
 		// Esta é uma suposição sobre o comportamento do SDK, mas reflete o design do nosso código.
 		InitEmailService()
-		assert.NotNil(t, DefaultEmailNotifier)
+		assert.NotNil(t, EmailNotifier())
 		// O tipo exato pode depender de onde a falha ocorre. O importante é que não seja nil.
 	})
 }
@@ -67,9 +68,9 @@ func TestNotifyUserByEmail(t *testing.T) {
 	// Testar a lógica de notificação em si é mais um teste de integração.
 	// Para um teste de unidade, podemos verificar se a função não entra em pânico com um nil Notifier.
 	t.Run("Does not panic with nil notifier", func(t *testing.T) {
-		originalNotifier := DefaultEmailNotifier
-		DefaultEmailNotifier = nil
-		defer func() { DefaultEmailNotifier = originalNotifier }()
+		originalNotifier := EmailNotifier()
+		SetEmailNotifier(nil)
+		defer func() { SetEmailNotifier(originalNotifier) }()
 
 		// Esta chamada irá logar um erro, mas não deve causar pânico.
 		// O teste não pode verificar o log facilmente sem uma configuração mais complexa.
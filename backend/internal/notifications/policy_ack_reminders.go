@@ -0,0 +1,122 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PolicyAckCampaignPendingUsers retorna os usuários ativos alvo da campanha que ainda não aceitaram a versão da política.
+func PolicyAckCampaignPendingUsers(ctx context.Context, db *gorm.DB, campaign models.PolicyAckCampaign) ([]models.User, error) {
+	query := db.WithContext(ctx).Model(&models.User{}).
+		Where("users.organization_id = ? AND users.is_active = ?", campaign.OrganizationID, true).
+		Where("NOT EXISTS (SELECT 1 FROM policy_acknowledgments pa WHERE pa.user_id = users.id AND pa.policy_version_id = ?)", campaign.PolicyVersionID)
+	if !campaign.AllUsers {
+		query = query.Joins("JOIN policy_ack_campaign_targets t ON t.user_id = users.id AND t.campaign_id = ?", campaign.ID)
+	}
+	var users []models.User
+	err := query.Select("users.id", "users.name", "users.email").Find(&users).Error
+	return users, err
+}
+
+// SendPolicyAckCampaignReminders envia e-mail de lembrete a cada usuário pendente e registra o envio na campanha.
+// Retorna quantos lembretes foram enfileirados.
+func SendPolicyAckCampaignReminders(ctx context.Context, campaign models.PolicyAckCampaign) (int, error) {
+	db := database.GetDB()
+	pending, err := PolicyAckCampaignPendingUsers(ctx, db, campaign)
+	if err != nil {
+		return 0, err
+	}
+
+	var policy models.Policy
+	if err := db.WithContext(ctx).Select("id", "title").First(&policy, "id = ?", campaign.PolicyID).Error; err != nil {
+		return 0, err
+	}
+
+	frontendBaseURL := config.Cfg.FrontendBaseURL
+	if frontendBaseURL == "" {
+		frontendBaseURL = "http://localhost:3000" // Fallback
+	}
+	policyURL := fmt.Sprintf("%s/policies/%s", strings.TrimSuffix(frontendBaseURL, "/"), policy.ID.String())
+
+	deadline := campaign.DueDate.Format("02/01/2006")
+	subject := fmt.Sprintf("Ação Requerida: Aceite da Política '%s' até %s", policy.Title, deadline)
+	if time.Now().After(campaign.DueDate) {
+		subject = fmt.Sprintf("Aceite Atrasado: Política '%s' (prazo: %s)", policy.Title, deadline)
+	}
+
+	sent := 0
+	for _, user := range pending {
+		if user.Email == "" {
+			continue
+		}
+		body := fmt.Sprintf("Olá %s,\n\nVocê precisa ler e aceitar a política '%s' até %s.\n\n%s\n\nAcesse: %s",
+			user.Name, policy.Title, deadline, campaign.Message, policyURL)
+		deliverAsync(ChannelEmail, user.Email, Message{EventType: "policy.ack_reminder", OrganizationID: campaign.OrganizationID, Subject: subject, Body: body})
+		sent++
+	}
+
+	now := time.Now()
+	if err := db.WithContext(ctx).Model(&models.PolicyAckCampaign{}).Where("id = ?", campaign.ID).
+		Update("last_reminder_at", now).Error; err != nil {
+		return sent, err
+	}
+	return sent, nil
+}
+
+// RunPolicyAckReminderSweep envia lembretes das campanhas abertas cujo intervalo de lembrete já passou.
+func RunPolicyAckReminderSweep(ctx context.Context) {
+	db := database.GetDB()
+	var campaigns []models.PolicyAckCampaign
+	err := db.WithContext(ctx).
+		Where("status = ?", models.PolicyAckCampaignOpen).
+		Where("policy_id IN (?)", db.Model(&models.Policy{}).Select("id")). // Campanhas de políticas na lixeira ficam pausadas
+		Where("last_reminder_at IS NULL OR last_reminder_at + (reminder_interval_days * INTERVAL '1 day') <= ?", time.Now()).
+		Find(&campaigns).Error
+	if err != nil {
+		phxlog.L.Error("Error fetching policy acknowledgment campaigns for reminders", zap.Error(err))
+		return
+	}
+	for _, campaign := range campaigns {
+		sent, err := SendPolicyAckCampaignReminders(ctx, campaign)
+		if err != nil {
+			phxlog.L.Error("Failed to send policy acknowledgment campaign reminders",
+				zap.String("campaignID", campaign.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		phxlog.L.Info("Policy acknowledgment reminders sent",
+			zap.String("campaignID", campaign.ID.String()),
+			zap.Int("recipients", sent))
+	}
+}
+
+// StartPolicyAckReminderScheduler executa RunPolicyAckReminderSweep periodicamente até o contexto ser cancelado.
+func StartPolicyAckReminderScheduler(ctx context.Context, every time.Duration) {
+	runPeriodically(ctx, every, RunPolicyAckReminderSweep)
+}
+
+// runPeriodically executa sweep a cada intervalo em uma goroutine até o contexto ser cancelado.
+func runPeriodically(ctx context.Context, every time.Duration, sweep func(context.Context)) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sweep(ctx)
+			}
+		}
+	}()
+}
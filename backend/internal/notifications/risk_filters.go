@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"context"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RiskFilterMatches indica se o evento atende a todos os critérios preenchidos do filtro.
+func RiskFilterMatches(criteria models.RiskNotificationCriteria, risk models.Risk, eventType string) bool {
+	return matchesAny(criteria.EventTypes, eventType) &&
+		matchesAny(criteria.RiskLevels, risk.RiskLevel) &&
+		matchesAny(criteria.Impacts, string(risk.Impact)) &&
+		matchesAny(criteria.Categories, string(risk.Category))
+}
+
+// RiskEventAllowed aplica os filtros ativos da organização: sem filtros, tudo é notificado;
+// com filtros, o evento precisa atender a pelo menos um. Retorna também os filtros atendidos.
+func RiskEventAllowed(filters []models.RiskNotificationFilter, risk models.Risk, eventType string) (bool, []uuid.UUID) {
+	active := 0
+	var matched []uuid.UUID
+	for _, filter := range filters {
+		if !filter.IsActive {
+			continue
+		}
+		active++
+		if RiskFilterMatches(filter.Criteria, risk, eventType) {
+			matched = append(matched, filter.ID)
+		}
+	}
+	return active == 0 || len(matched) > 0, matched
+}
+
+// riskEventPassesFilters carrega os filtros da organização e decide se o evento deve ser notificado.
+// Em caso de erro ao carregar os filtros, o evento é notificado (falha aberta, para não perder alertas).
+func riskEventPassesFilters(ctx context.Context, orgID uuid.UUID, risk models.Risk, eventType string) bool {
+	var filters []models.RiskNotificationFilter
+	err := database.GetDB().WithContext(ctx).
+		Where("organization_id = ? AND is_active = ?", orgID, true).
+		Find(&filters).Error
+	if err != nil {
+		phxlog.L.Error("Error fetching risk notification filters; notifying anyway",
+			zap.String("organizationID", orgID.String()),
+			zap.Error(err))
+		return true
+	}
+	allowed, _ := RiskEventAllowed(filters, risk, eventType)
+	return allowed
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskFilterMatchesRequiresAllFilledCriteria(t *testing.T) {
+	risk := models.Risk{RiskLevel: models.RiskLevelHigh, Impact: models.ImpactHigh, Category: models.CategoryLegal}
+	criteria := models.RiskNotificationCriteria{
+		RiskLevels: []string{models.RiskLevelHigh, models.RiskLevelExtreme},
+		Categories: []string{string(models.CategoryLegal)},
+	}
+
+	assert.True(t, RiskFilterMatches(criteria, risk, string(models.EventTypeRiskCreated)))
+	assert.True(t, RiskFilterMatches(models.RiskNotificationCriteria{}, risk, "risk_created"), "empty criteria match everything")
+
+	risk.Category = models.CategoryOperational
+	assert.False(t, RiskFilterMatches(criteria, risk, string(models.EventTypeRiskCreated)))
+}
+
+func TestRiskEventAllowed(t *testing.T) {
+	high := models.Risk{RiskLevel: models.RiskLevelHigh}
+	low := models.Risk{RiskLevel: models.RiskLevelLow}
+
+	allowed, matched := RiskEventAllowed(nil, low, "risk_created")
+	assert.True(t, allowed, "no filters keeps notifying everything")
+	assert.Empty(t, matched)
+
+	highOnly := models.RiskNotificationFilter{ID: uuid.New(), IsActive: true,
+		Criteria: models.RiskNotificationCriteria{RiskLevels: []string{models.RiskLevelHigh, models.RiskLevelExtreme}}}
+	ownerChanges := models.RiskNotificationFilter{ID: uuid.New(), IsActive: true,
+		Criteria: models.RiskNotificationCriteria{EventTypes: []string{string(models.EventTypeRiskOwnerChanged)}}}
+	inactive := models.RiskNotificationFilter{ID: uuid.New(), IsActive: false}
+	filters := []models.RiskNotificationFilter{highOnly, ownerChanges, inactive}
+
+	allowed, matched = RiskEventAllowed(filters, high, "risk_status_changed")
+	assert.True(t, allowed)
+	assert.Equal(t, []uuid.UUID{highOnly.ID}, matched)
+
+	allowed, _ = RiskEventAllowed(filters, low, "risk_status_changed")
+	assert.False(t, allowed, "low risk status change matches no active filter")
+
+	allowed, matched = RiskEventAllowed(filters, low, string(models.EventTypeRiskOwnerChanged))
+	assert.True(t, allowed)
+	assert.Equal(t, []uuid.UUID{ownerChanges.ID}, matched)
+
+	allowed, _ = RiskEventAllowed([]models.RiskNotificationFilter{inactive}, low, "risk_created")
+	assert.True(t, allowed, "only inactive filters behaves like no filters")
+}
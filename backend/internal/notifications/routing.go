@@ -0,0 +1,74 @@
+package notifications
+
+import (
+	"context"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/secrets"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// Dispatch entrega a mensagem por todas as regras de roteamento ativas da organização para o evento.
+// Falhas em uma regra são registradas em log e não impedem as demais.
+func Dispatch(ctx context.Context, msg Message) {
+	var routes []models.NotificationRoute
+	err := database.GetDB().WithContext(ctx).
+		Where("organization_id = ? AND is_active = ?", msg.OrganizationID, true).
+		Where("event_type IN ?", []string{msg.EventType, models.NotificationRouteAnyEvent}).
+		Find(&routes).Error
+	if err != nil {
+		phxlog.L.Error("Error fetching notification routes",
+			zap.String("organizationID", msg.OrganizationID.String()),
+			zap.String("eventType", msg.EventType),
+			zap.Error(err))
+		return
+	}
+	dispatchToRoutes(ctx, routes, msg)
+}
+
+// dispatchToRoutes entrega a mensagem, de forma síncrona, por cada regra que corresponde ao evento.
+// Retorna quantas entregas tiveram sucesso.
+func dispatchToRoutes(ctx context.Context, routes []models.NotificationRoute, msg Message) int {
+	delivered := 0
+	for _, route := range routes {
+		if !RouteMatches(route, msg.EventType) {
+			continue
+		}
+		target, err := ResolveRouteTarget(ctx, route)
+		if err != nil {
+			phxlog.L.Error("Failed to resolve notification route target",
+				zap.String("routeID", route.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		if err := Deliver(ctx, route.Channel, target, msg); err != nil {
+			phxlog.L.Error("Failed to deliver notification via route",
+				zap.String("routeID", route.ID.String()),
+				zap.String("channel", route.Channel),
+				zap.String("eventType", msg.EventType),
+				zap.Error(err))
+			continue
+		}
+		delivered++
+	}
+	return delivered
+}
+
+// RouteMatches indica se a regra ativa se aplica ao evento.
+func RouteMatches(route models.NotificationRoute, eventType string) bool {
+	if !route.IsActive {
+		return false
+	}
+	return route.EventType == eventType || route.EventType == models.NotificationRouteAnyEvent
+}
+
+// ResolveRouteTarget retorna o destino da regra, lendo-o do cofre quando TargetSecretName está definido.
+func ResolveRouteTarget(ctx context.Context, route models.NotificationRoute) (string, error) {
+	if route.TargetSecretName == "" {
+		return route.Target, nil
+	}
+	return secrets.Resolve(ctx, route.OrganizationID, route.TargetSecretName, "notifications:"+route.Channel)
+}
@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// SecretRotationReminderLeadTime é a antecedência com que os administradores são avisados da rotação.
+const SecretRotationReminderLeadTime = 7 * 24 * time.Hour
+
+// RunSecretRotationReminderSweep avisa admins e managers da organização sobre segredos cuja rotação
+// vence dentro de SecretRotationReminderLeadTime. Um aviso é enviado por ciclo de rotação.
+func RunSecretRotationReminderSweep(ctx context.Context) {
+	db := database.GetDB()
+	var secrets []models.OrgSecret
+	err := db.WithContext(ctx).
+		Where("rotation_due_at IS NOT NULL AND rotation_due_at <= ?", time.Now().Add(SecretRotationReminderLeadTime)).
+		Where("rotation_reminder_sent_at IS NULL").
+		Find(&secrets).Error
+	if err != nil {
+		phxlog.L.Error("Error fetching organization secrets for rotation reminders", zap.Error(err))
+		return
+	}
+
+	frontendBaseURL := config.Cfg.FrontendBaseURL
+	if frontendBaseURL == "" {
+		frontendBaseURL = "http://localhost:3000" // Fallback
+	}
+	secretsURL := fmt.Sprintf("%s/settings/secrets", strings.TrimSuffix(frontendBaseURL, "/"))
+
+	for _, secret := range secrets {
+		var recipients []models.User
+		err := db.WithContext(ctx).Select("id", "name", "email").
+			Where("organization_id = ? AND is_active = ? AND role IN ?", secret.OrganizationID, true,
+				[]models.UserRole{models.RoleAdmin, models.RoleManager}).
+			Find(&recipients).Error
+		if err != nil {
+			phxlog.L.Error("Failed to fetch recipients for secret rotation reminder",
+				zap.String("secretID", secret.ID.String()),
+				zap.Error(err))
+			continue
+		}
+
+		due := secret.RotationDueAt.Format("02/01/2006")
+		subject := fmt.Sprintf("Rotação de Credencial: '%s' vence em %s", secret.Name, due)
+		if time.Now().After(*secret.RotationDueAt) {
+			subject = fmt.Sprintf("Rotação de Credencial Atrasada: '%s' (prazo: %s)", secret.Name, due)
+		}
+		for _, user := range recipients {
+			if user.Email == "" {
+				continue
+			}
+			body := fmt.Sprintf("Olá %s,\n\nA credencial '%s' (%s) deve ser rotacionada até %s.\n\nAcesse: %s",
+				user.Name, secret.Name, secret.Kind, due, secretsURL)
+			deliverAsync(ChannelEmail, user.Email, Message{EventType: "secret.rotation_reminder", OrganizationID: secret.OrganizationID, Subject: subject, Body: body})
+		}
+
+		if err := db.WithContext(ctx).Model(&models.OrgSecret{}).Where("id = ?", secret.ID).
+			Update("rotation_reminder_sent_at", time.Now()).Error; err != nil {
+			phxlog.L.Error("Failed to record secret rotation reminder",
+				zap.String("secretID", secret.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		phxlog.L.Info("Secret rotation reminder sent",
+			zap.String("secretID", secret.ID.String()),
+			zap.Int("recipients", len(recipients)))
+	}
+}
+
+// StartSecretRotationReminderScheduler executa RunSecretRotationReminderSweep periodicamente até o contexto ser cancelado.
+func StartSecretRotationReminderScheduler(ctx context.Context, every time.Duration) {
+	runPeriodically(ctx, every, RunSecretRotationReminderSweep)
+}
@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"context"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// NotifyTeamWithTemplate envia a notificação do modelo name (ver NotifyUserWithTemplate) a cada membro ativo
+// da equipe, exceto os usuários em skip (ex: o responsável individual, já notificado).
+func NotifyTeamWithTemplate(ctx context.Context, teamID uuid.UUID, name string, data interface{}, skip ...uuid.UUID) {
+	memberIDs, err := models.TeamMemberIDs(database.GetDB().WithContext(ctx), teamID)
+	if err != nil {
+		phxlog.L.Error("Failed to load team members for notification",
+			zap.String("teamID", teamID.String()),
+			zap.String("template", name),
+			zap.Error(err))
+		return
+	}
+	skipped := make(map[uuid.UUID]bool, len(skip))
+	for _, id := range skip {
+		skipped[id] = true
+	}
+	for _, memberID := range memberIDs {
+		if !skipped[memberID] {
+			NotifyUserWithTemplate(ctx, memberID, name, data)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package templates
+
+import (
+	"fmt"
+
+	"phoenixgrc/backend/internal/i18n"
+)
+
+// messages são os textos dos modelos por idioma. Chaves ausentes em um idioma usam o texto em DefaultLocale.
+var messages = map[i18n.Locale]map[string]string{
+	i18n.LocalePortuguese: {
+		"greeting":          "Olá, %s,",
+		"button.open":       "Abrir no Phoenix GRC",
+		"link.access":       "Acesse:",
+		"footer.sent_by":    "Você recebeu este e-mail porque participa de %s no Phoenix GRC.",
+		"label.title":       "Título",
+		"label.description": "Descrição",
+		"label.impact":      "Impacto",
+		"label.probability": "Probabilidade",
+		"label.risk_level":  "Nível de risco",
+		"label.comments":    "Comentários",
+
+		"risk_created.subject": "Novo risco criado: %s",
+		"risk_created.intro":   "Um novo risco foi criado e atribuído a você ou à sua equipe.",
+
+		"risk_status_changed.subject": "Status do risco '%s' alterado para '%s'",
+		"risk_status_changed.intro":   "O status do risco '%s' foi alterado de '%s' para '%s'.",
+
+		"risk_acceptance_requested.subject": "Ação requerida: aprovação de aceite do risco '%s'",
+		"risk_acceptance_requested.intro":   "O risco '%s' foi submetido para sua aprovação de aceite por %s.",
+		"risk_acceptance_requested.action":  "Acesse o Phoenix GRC para revisar o risco e registrar sua decisão.",
+
+		"risk_acceptance_decided.subject_approved": "Aceite do risco '%s' aprovado",
+		"risk_acceptance_decided.subject_rejected": "Aceite do risco '%s' rejeitado",
+		"risk_acceptance_decided.approved_by":      "A solicitação de aceite do risco '%s' foi aprovada por %s. O status do risco foi atualizado para '%s'.",
+		"risk_acceptance_decided.approved":         "A solicitação de aceite do risco '%s' foi aprovada. O status do risco foi atualizado para '%s'.",
+		"risk_acceptance_decided.rejected":         "A solicitação de aceite do risco '%s' foi rejeitada.",
+		"risk_acceptance_decided.next_steps":       "Acesse o Phoenix GRC para discutir os próximos passos do tratamento.",
+
+		"assessment_mention.subject": "%s mencionou você no controle %s",
+		"assessment_mention.intro":   "%s mencionou você em um comentário na avaliação do controle %s:",
+
+		"role.admin":   "administrador",
+		"role.manager": "gestor",
+		"role.user":    "usuário",
+
+		"user_invitation.subject": "Convite para participar de %s no Phoenix GRC",
+		"user_invitation.intro":   "%s convidou você para participar de %s no Phoenix GRC com o papel de %s.",
+		"user_invitation.action":  "Use o botão abaixo para aceitar o convite: você define a sua senha ou entra pelo login único (SSO) da organização.",
+		"user_invitation.expiry":  "O convite expira em %d dia(s). Se você não esperava este convite, ignore este e-mail.",
+
+		"password_reset.subject": "Redefinição de senha",
+		"password_reset.intro":   "Recebemos um pedido para redefinir a senha da sua conta no Phoenix GRC.",
+		"password_reset.action":  "Use o botão abaixo para definir uma nova senha. O link vale por %d minutos e só pode ser usado uma vez.",
+		"password_reset.ignore":  "Se você não pediu a redefinição, ignore este e-mail: a sua senha continua a mesma.",
+
+		"account_locked.subject": "Conta bloqueada temporariamente",
+		"account_locked.intro":   "O login na sua conta no Phoenix GRC foi bloqueado por %d minutos após várias tentativas sem sucesso. A última veio do IP %s.",
+		"account_locked.action":  "Se foi você, aguarde o fim do bloqueio ou peça o desbloqueio a um administrador da organização. Se não foi você, redefina a sua senha pelo botão abaixo.",
+	},
+	i18n.LocaleEnglish: {
+		"greeting":          "Hello %s,",
+		"button.open":       "Open in Phoenix GRC",
+		"link.access":       "Open:",
+		"footer.sent_by":    "You received this email because you are a member of %s on Phoenix GRC.",
+		"label.title":       "Title",
+		"label.description": "Description",
+		"label.impact":      "Impact",
+		"label.probability": "Probability",
+		"label.risk_level":  "Risk level",
+		"label.comments":    "Comments",
+
+		"risk_created.subject": "New risk created: %s",
+		"risk_created.intro":   "A new risk was created and assigned to you or your team.",
+
+		"risk_status_changed.subject": "Status of risk '%s' changed to '%s'",
+		"risk_status_changed.intro":   "The status of risk '%s' changed from '%s' to '%s'.",
+
+		"risk_acceptance_requested.subject": "Action required: acceptance approval for risk '%s'",
+		"risk_acceptance_requested.intro":   "Risk '%s' was submitted for your acceptance approval by %s.",
+		"risk_acceptance_requested.action":  "Open Phoenix GRC to review the risk and record your decision.",
+
+		"risk_acceptance_decided.subject_approved": "Acceptance of risk '%s' approved",
+		"risk_acceptance_decided.subject_rejected": "Acceptance of risk '%s' rejected",
+		"risk_acceptance_decided.approved_by":      "The acceptance request for risk '%s' was approved by %s. The risk status was updated to '%s'.",
+		"risk_acceptance_decided.approved":         "The acceptance request for risk '%s' was approved. The risk status was updated to '%s'.",
+		"risk_acceptance_decided.rejected":         "The acceptance request for risk '%s' was rejected.",
+		"risk_acceptance_decided.next_steps":       "Open Phoenix GRC to discuss the next treatment steps.",
+
+		"assessment_mention.subject": "%s mentioned you on control %s",
+		"assessment_mention.intro":   "%s mentioned you in a comment on the assessment of control %s:",
+
+		"role.admin":   "administrator",
+		"role.manager": "manager",
+		"role.user":    "user",
+
+		"user_invitation.subject": "Invitation to join %s on Phoenix GRC",
+		"user_invitation.intro":   "%s invited you to join %s on Phoenix GRC as %s.",
+		"user_invitation.action":  "Use the button below to accept the invitation: set your password or sign in with your organization's single sign-on (SSO).",
+		"user_invitation.expiry":  "The invitation expires in %d day(s). If you were not expecting it, you can ignore this email.",
+
+		"password_reset.subject": "Password reset",
+		"password_reset.intro":   "We received a request to reset the password of your Phoenix GRC account.",
+		"password_reset.action":  "Use the button below to set a new password. The link is valid for %d minutes and can only be used once.",
+		"password_reset.ignore":  "If you did not request a reset, ignore this email: your password stays the same.",
+
+		"account_locked.subject": "Account temporarily locked",
+		"account_locked.intro":   "Sign-in to your Phoenix GRC account has been locked for %d minutes after several failed attempts. The last one came from IP %s.",
+		"account_locked.action":  "If this was you, wait for the lock to expire or ask an administrator of your organization to unlock it. If it was not you, reset your password using the button below.",
+	},
+	i18n.LocaleSpanish: {
+		"greeting":          "Hola, %s:",
+		"button.open":       "Abrir en Phoenix GRC",
+		"link.access":       "Acceda:",
+		"footer.sent_by":    "Recibió este correo porque participa en %s en Phoenix GRC.",
+		"label.title":       "Título",
+		"label.description": "Descripción",
+		"label.impact":      "Impacto",
+		"label.probability": "Probabilidad",
+		"label.risk_level":  "Nivel de riesgo",
+		"label.comments":    "Comentarios",
+
+		"risk_created.subject": "Nuevo riesgo creado: %s",
+		"risk_created.intro":   "Se creó un nuevo riesgo asignado a usted o a su equipo.",
+
+		"risk_status_changed.subject": "Estado del riesgo '%s' cambiado a '%s'",
+		"risk_status_changed.intro":   "El estado del riesgo '%s' cambió de '%s' a '%s'.",
+
+		"risk_acceptance_requested.subject": "Acción requerida: aprobación de aceptación del riesgo '%s'",
+		"risk_acceptance_requested.intro":   "%[2]s envió el riesgo '%[1]s' para su aprobación de aceptación.",
+		"risk_acceptance_requested.action":  "Acceda a Phoenix GRC para revisar el riesgo y registrar su decisión.",
+
+		"risk_acceptance_decided.subject_approved": "Aceptación del riesgo '%s' aprobada",
+		"risk_acceptance_decided.subject_rejected": "Aceptación del riesgo '%s' rechazada",
+		"risk_acceptance_decided.approved_by":      "La solicitud de aceptación del riesgo '%s' fue aprobada por %s. El estado del riesgo se actualizó a '%s'.",
+		"risk_acceptance_decided.approved":         "La solicitud de aceptación del riesgo '%s' fue aprobada. El estado del riesgo se actualizó a '%s'.",
+		"risk_acceptance_decided.rejected":         "La solicitud de aceptación del riesgo '%s' fue rechazada.",
+		"risk_acceptance_decided.next_steps":       "Acceda a Phoenix GRC para discutir los próximos pasos del tratamiento.",
+
+		"assessment_mention.subject": "%s lo mencionó en el control %s",
+		"assessment_mention.intro":   "%s lo mencionó en un comentario de la evaluación del control %s:",
+
+		"role.admin":   "administrador",
+		"role.manager": "gestor",
+		"role.user":    "usuario",
+
+		"user_invitation.subject": "Invitación para participar en %s en Phoenix GRC",
+		"user_invitation.intro":   "%s lo invitó a participar en %s en Phoenix GRC con el rol de %s.",
+		"user_invitation.action":  "Use el botón de abajo para aceptar la invitación: defina su contraseña o ingrese con el inicio de sesión único (SSO) de la organización.",
+		"user_invitation.expiry":  "La invitación vence en %d día(s). Si no esperaba esta invitación, ignore este correo.",
+
+		"password_reset.subject": "Restablecimiento de contraseña",
+		"password_reset.intro":   "Recibimos una solicitud para restablecer la contraseña de su cuenta en Phoenix GRC.",
+		"password_reset.action":  "Use el botón de abajo para definir una nueva contraseña. El enlace es válido por %d minutos y solo puede usarse una vez.",
+		"password_reset.ignore":  "Si no solicitó el restablecimiento, ignore este correo: su contraseña sigue siendo la misma.",
+
+		"account_locked.subject": "Cuenta bloqueada temporalmente",
+		"account_locked.intro":   "El inicio de sesión en su cuenta de Phoenix GRC se bloqueó durante %d minutos tras varios intentos fallidos. El último provino de la IP %s.",
+		"account_locked.action":  "Si fue usted, espere a que termine el bloqueo o pida a un administrador de su organización que la desbloquee. Si no fue usted, restablezca su contraseña con el botón de abajo.",
+	},
+}
+
+// SupportedLocale indica se há textos no idioma.
+func SupportedLocale(locale i18n.Locale) bool {
+	_, ok := messages[locale]
+	return ok
+}
+
+// translator retorna a função t dos modelos no idioma.
+func translator(locale i18n.Locale) func(key string, args ...interface{}) string {
+	return func(key string, args ...interface{}) string {
+		msg, ok := messages[locale][key]
+		if !ok {
+			if msg, ok = messages[DefaultLocale][key]; !ok {
+				return key
+			}
+		}
+		if len(args) == 0 {
+			return msg
+		}
+		return fmt.Sprintf(msg, args...)
+	}
+}
@@ -0,0 +1,343 @@
+// Package templates monta os e-mails de notificação: cada modelo tem uma versão HTML (html/template, com
+// escape automático) e uma em texto (text/template), dentro de um layout com a identidade visual da
+// organização (Branding) e com as mensagens no idioma escolhido (messages).
+//
+// Um modelo é um arquivo files/<nome>.tmpl que define "subject", "content_html" e "content_text". Os dados
+// ficam em .Data (o tipo registrado em definitions), a identidade visual em .Brand, o nome do destinatário
+// em .Recipient e o link principal em .Link. A função t traduz uma chave de messages, formatando os
+// argumentos como fmt.Sprintf; lines preserva as quebras de linha de textos livres no HTML.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	"phoenixgrc/backend/internal/i18n"
+)
+
+//go:embed files/*.tmpl
+var files embed.FS
+
+// Nomes dos modelos.
+const (
+	NameNotification            = "notification" // Assunto e corpo livres; usado nas notificações sem modelo próprio
+	NameRiskCreated             = "risk_created"
+	NameRiskStatusChanged       = "risk_status_changed"
+	NameRiskAcceptanceRequested = "risk_acceptance_requested"
+	NameRiskAcceptanceDecided   = "risk_acceptance_decided"
+	NameAssessmentMention       = "assessment_mention"
+	NameUserInvitation          = "user_invitation"
+	NamePasswordReset           = "password_reset"
+	NameAccountLocked           = "account_locked"
+)
+
+// DefaultLocale é o idioma dos e-mails das organizações que não escolheram outro.
+const DefaultLocale = i18n.LocalePortuguese
+
+// Notification são os dados de NameNotification.
+type Notification struct {
+	Subject string
+	Body    string
+	Link    string
+}
+
+// RiskCreated são os dados de NameRiskCreated.
+type RiskCreated struct {
+	RiskTitle   string
+	Description string
+	Impact      string
+	Probability string
+	Link        string
+}
+
+// RiskStatusChanged são os dados de NameRiskStatusChanged.
+type RiskStatusChanged struct {
+	RiskTitle string
+	OldStatus string
+	NewStatus string
+	Link      string
+}
+
+// RiskAcceptanceRequested são os dados de NameRiskAcceptanceRequested.
+type RiskAcceptanceRequested struct {
+	RiskTitle     string
+	Description   string
+	Impact        string
+	Probability   string
+	RiskLevel     string
+	RequesterName string
+	Link          string
+}
+
+// RiskAcceptanceDecided são os dados de NameRiskAcceptanceDecided.
+type RiskAcceptanceDecided struct {
+	RiskTitle    string
+	Approved     bool
+	ApproverName string // Opcional
+	Status       string // Status do risco após a decisão
+	Comments     string
+	Link         string
+}
+
+// AssessmentMention são os dados de NameAssessmentMention.
+type AssessmentMention struct {
+	AuthorName string
+	ControlID  string
+	Comment    string
+	Link       string
+}
+
+// UserInvitation são os dados de NameUserInvitation; o e-mail vai para quem ainda não é usuário, então não há
+// registro na central de notificações.
+type UserInvitation struct {
+	InviterName   string
+	Role          string // admin, manager ou user
+	ExpiresInDays int
+	Link          string // Link de aceite, com o token do convite
+}
+
+// PasswordReset são os dados de NamePasswordReset; o link contém o token, então também não há registro na
+// central de notificações.
+type PasswordReset struct {
+	ExpiresInMinutes int
+	Link             string
+}
+
+// AccountLocked são os dados de NameAccountLocked, enviado quando falhas seguidas de login bloqueiam a conta.
+type AccountLocked struct {
+	LockedMinutes int
+	IPAddress     string // IP da tentativa que bloqueou a conta
+	Link          string // Tela de "esqueci minha senha"
+}
+
+func (d Notification) link() string            { return d.Link }
+func (d RiskCreated) link() string             { return d.Link }
+func (d RiskStatusChanged) link() string       { return d.Link }
+func (d RiskAcceptanceRequested) link() string { return d.Link }
+func (d RiskAcceptanceDecided) link() string   { return d.Link }
+func (d AssessmentMention) link() string       { return d.Link }
+func (d UserInvitation) link() string          { return d.Link }
+func (d PasswordReset) link() string           { return d.Link }
+func (d AccountLocked) link() string           { return d.Link }
+
+// linked é implementado pelos tipos de dados dos modelos.
+type linked interface {
+	link() string
+}
+
+// definition descreve um modelo: o que ele notifica e dados de exemplo (que também definem o tipo aceito).
+type definition struct {
+	description string
+	sample      linked
+}
+
+const sampleLink = "https://grc.example.com/risks/00000000-0000-0000-0000-000000000000"
+
+var definitions = map[string]definition{
+	NameNotification: {
+		description: "Notificações sem modelo próprio (assunto e corpo livres)",
+		sample:      Notification{Subject: "Lembrete: avaliação pendente", Body: "O controle A.5.1 tem uma avaliação pendente.\nPrazo: 30/06.", Link: sampleLink},
+	},
+	NameRiskCreated: {
+		description: "Risco criado e atribuído ao responsável",
+		sample:      RiskCreated{RiskTitle: "Vazamento de dados de clientes", Description: "Exposição de dados pessoais por falha de controle de acesso.", Impact: "Alto", Probability: "Médio", Link: sampleLink},
+	},
+	NameRiskStatusChanged: {
+		description: "Status do risco alterado, para o responsável",
+		sample:      RiskStatusChanged{RiskTitle: "Vazamento de dados de clientes", OldStatus: "aberto", NewStatus: "em_andamento", Link: sampleLink},
+	},
+	NameRiskAcceptanceRequested: {
+		description: "Aceite de risco submetido ao aprovador",
+		sample:      RiskAcceptanceRequested{RiskTitle: "Vazamento de dados de clientes", Description: "Exposição de dados pessoais por falha de controle de acesso.", Impact: "Alto", Probability: "Médio", RiskLevel: "Alto", RequesterName: "Maria Souza", Link: sampleLink},
+	},
+	NameRiskAcceptanceDecided: {
+		description: "Decisão do aceite de risco, para o responsável e o solicitante",
+		sample:      RiskAcceptanceDecided{RiskTitle: "Vazamento de dados de clientes", Approved: true, ApproverName: "João Lima", Status: "aceito", Comments: "Risco residual dentro do apetite aprovado.", Link: sampleLink},
+	},
+	NameAssessmentMention: {
+		description: "Menção em um comentário da avaliação de um controle",
+		sample:      AssessmentMention{AuthorName: "Maria Souza", ControlID: "A.5.1", Comment: "Você pode anexar a política aprovada como evidência?", Link: "https://grc.example.com/admin/audit/frameworks/00000000-0000-0000-0000-000000000000"},
+	},
+	NameUserInvitation: {
+		description: "Convite para entrar na organização, com o link de aceite",
+		sample:      UserInvitation{InviterName: "Maria Souza", Role: "manager", ExpiresInDays: 7, Link: "https://grc.example.com/auth/accept-invitation?token=0000000000000000"},
+	},
+	NamePasswordReset: {
+		description: "Link para redefinir a senha, pedido em \"esqueci minha senha\"",
+		sample:      PasswordReset{ExpiresInMinutes: 60, Link: "https://grc.example.com/auth/reset-password?token=0000000000000000"},
+	},
+	NameAccountLocked: {
+		description: "Aviso de bloqueio temporário da conta após falhas seguidas de login",
+		sample:      AccountLocked{LockedMinutes: 15, IPAddress: "203.0.113.10", Link: "https://grc.example.com/auth/forgot-password"},
+	},
+}
+
+// Link retorna o link principal dos dados de um modelo; vazio se data não for de um modelo.
+func Link(data interface{}) string {
+	if l, ok := data.(linked); ok {
+		return l.link()
+	}
+	return ""
+}
+
+// Info descreve um modelo disponível.
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// List retorna os modelos disponíveis, por nome.
+func List() []Info {
+	list := make([]Info, 0, len(definitions))
+	for name, def := range definitions {
+		list = append(list, Info{Name: name, Description: def.description})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Sample retorna os dados de exemplo do modelo, para pré-visualização.
+func Sample(name string) (interface{}, bool) {
+	def, ok := definitions[name]
+	return def.sample, ok
+}
+
+// Branding é a identidade visual aplicada aos e-mails.
+type Branding struct {
+	OrganizationName string `json:"organization_name"`
+	LogoURL          string `json:"logo_url,omitempty"` // URL pública; sem logo, o cabeçalho mostra o nome
+	PrimaryColor     string `json:"primary_color"`      // Cabeçalho
+	SecondaryColor   string `json:"secondary_color"`    // Botões e destaques
+	FromName         string `json:"from_name,omitempty"`
+	FooterText       string `json:"footer_text,omitempty"`
+}
+
+// Valores padrão da identidade visual.
+const (
+	DefaultOrganizationName = "Phoenix GRC"
+	DefaultPrimaryColor     = "#1F3A5F"
+	DefaultSecondaryColor   = "#2F80ED"
+)
+
+var hexColor = regexp.MustCompile(`^#([A-Fa-f0-9]{6}|[A-Fa-f0-9]{3})$`)
+
+// WithDefaults completa os campos vazios ou inválidos com os valores padrão.
+func (b Branding) WithDefaults() Branding {
+	if strings.TrimSpace(b.OrganizationName) == "" {
+		b.OrganizationName = DefaultOrganizationName
+	}
+	if !hexColor.MatchString(b.PrimaryColor) {
+		b.PrimaryColor = DefaultPrimaryColor
+	}
+	if !hexColor.MatchString(b.SecondaryColor) {
+		b.SecondaryColor = DefaultSecondaryColor
+	}
+	return b
+}
+
+// Email é um e-mail montado.
+type Email struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+	Content string `json:"-"` // Só o conteúdo do modelo em texto, sem saudação, link e rodapé (central de notificações)
+}
+
+// view é o valor passado aos modelos.
+type view struct {
+	Data      interface{}
+	Brand     Branding
+	Recipient string
+	Link      string
+	Locale    i18n.Locale
+}
+
+// compiled são as versões HTML e texto de um modelo em um idioma.
+type compiled struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]*compiled{}
+)
+
+// load compila (uma vez por idioma) o layout e o arquivo do modelo.
+func load(name string, locale i18n.Locale) (*compiled, error) {
+	key := string(locale) + "/" + name
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if c, ok := cache[key]; ok {
+		return c, nil
+	}
+	t := translator(locale)
+	patterns := []string{"files/layout.tmpl", "files/" + name + ".tmpl"}
+	html, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap{"t": t, "lines": htmlLines}).ParseFS(files, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("parse html template %q: %w", name, err)
+	}
+	text, err := texttemplate.New(name).Funcs(texttemplate.FuncMap{"t": t, "lines": func(s string) string { return s }}).ParseFS(files, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("parse text template %q: %w", name, err)
+	}
+	c := &compiled{html: html, text: text}
+	cache[key] = c
+	return c, nil
+}
+
+// htmlLines escapa o texto e converte as quebras de linha em <br>.
+func htmlLines(s string) htmltemplate.HTML {
+	escaped := htmltemplate.HTMLEscapeString(strings.ReplaceAll(s, "\r\n", "\n"))
+	return htmltemplate.HTML(strings.ReplaceAll(escaped, "\n", "<br>"))
+}
+
+// Render monta o e-mail do modelo name com os dados data (do tipo registrado para o modelo), a identidade
+// visual brand e o idioma locale (sem suporte, usa DefaultLocale). recipient é o nome usado na saudação;
+// vazio omite a saudação.
+func Render(name string, locale i18n.Locale, brand Branding, recipient string, data interface{}) (Email, error) {
+	def, ok := definitions[name]
+	if !ok {
+		return Email{}, fmt.Errorf("unknown email template %q", name)
+	}
+	if reflect.TypeOf(data) != reflect.TypeOf(def.sample) {
+		return Email{}, fmt.Errorf("email template %q expects %T, got %T", name, def.sample, data)
+	}
+	if !SupportedLocale(locale) {
+		locale = DefaultLocale
+	}
+	c, err := load(name, locale)
+	if err != nil {
+		return Email{}, err
+	}
+	v := view{Data: data, Brand: brand.WithDefaults(), Recipient: strings.TrimSpace(recipient), Link: data.(linked).link(), Locale: locale}
+
+	var subject, text, html, content bytes.Buffer
+	if err := c.text.ExecuteTemplate(&subject, "subject", v); err != nil {
+		return Email{}, fmt.Errorf("render subject of %q: %w", name, err)
+	}
+	if err := c.text.ExecuteTemplate(&content, "content_text", v); err != nil {
+		return Email{}, fmt.Errorf("render content of %q: %w", name, err)
+	}
+	if err := c.text.ExecuteTemplate(&text, "text", v); err != nil {
+		return Email{}, fmt.Errorf("render text of %q: %w", name, err)
+	}
+	if err := c.html.ExecuteTemplate(&html, "html", v); err != nil {
+		return Email{}, fmt.Errorf("render html of %q: %w", name, err)
+	}
+	// O assunto é uma linha só (cabeçalho do e-mail)
+	return Email{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+		Content: strings.TrimSpace(content.String()),
+	}, nil
+}
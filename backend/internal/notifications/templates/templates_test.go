@@ -0,0 +1,91 @@
+package templates
+
+import (
+	"testing"
+
+	"phoenixgrc/backend/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllTemplatesRenderInEveryLocale(t *testing.T) {
+	for _, info := range List() {
+		sample, ok := Sample(info.Name)
+		require.True(t, ok)
+		for _, locale := range i18n.SupportedLocales {
+			email, err := Render(info.Name, locale, Branding{}, "Ana", sample)
+			require.NoError(t, err, "%s/%s", info.Name, locale)
+			assert.NotEmpty(t, email.Subject)
+			assert.NotContains(t, email.Subject, "\n")
+			assert.Contains(t, email.HTML, "<html lang=\""+string(locale)+"\">")
+			assert.Contains(t, email.Text, "Ana")
+			// Toda chave usada existe: t retorna a própria chave quando ela falta
+			for key := range messages[DefaultLocale] {
+				assert.NotContains(t, email.Text+email.HTML, key+" ", "%s/%s", info.Name, locale)
+			}
+		}
+	}
+}
+
+func TestRenderEscapesHTMLAndKeepsText(t *testing.T) {
+	data := RiskCreated{RiskTitle: `<script>alert("x")</script>`, Description: "linha 1\nlinha 2", Impact: "Alto", Probability: "Baixo", Link: "https://grc.example.com/risks/1"}
+	email, err := Render(NameRiskCreated, i18n.LocalePortuguese, Branding{}, "", data)
+	require.NoError(t, err)
+
+	assert.Equal(t, `Novo risco criado: <script>alert("x")</script>`, email.Subject)
+	assert.NotContains(t, email.HTML, "<script>")
+	assert.Contains(t, email.HTML, "&lt;script&gt;")
+	assert.Contains(t, email.HTML, "linha 1<br>linha 2")
+	assert.Contains(t, email.HTML, `href="https://grc.example.com/risks/1"`)
+	assert.Contains(t, email.Text, `Título: <script>alert("x")</script>`)
+	assert.Contains(t, email.Text, "Acesse: https://grc.example.com/risks/1")
+	assert.NotContains(t, email.Text, "Olá", "no greeting without recipient")
+}
+
+func TestRenderAppliesBranding(t *testing.T) {
+	brand := Branding{OrganizationName: "ACME", LogoURL: "https://cdn.example.com/logo.png", PrimaryColor: "#112233", SecondaryColor: "javascript:x", FooterText: "Confidencial"}
+	email, err := Render(NameNotification, i18n.LocaleEnglish, brand, "", Notification{Subject: "Hi", Body: "Body"})
+	require.NoError(t, err)
+
+	assert.Contains(t, email.HTML, `src="https://cdn.example.com/logo.png"`)
+	assert.Contains(t, email.HTML, "background-color:#112233")
+	assert.NotContains(t, email.HTML, "javascript")
+	assert.Contains(t, email.HTML, "Confidencial")
+	assert.Contains(t, email.Text, "member of ACME")
+	assert.NotContains(t, email.HTML, "<a href", "no button without link")
+}
+
+func TestRenderFallbacks(t *testing.T) {
+	email, err := Render(NameNotification, i18n.Locale("fr"), Branding{}, "", Notification{Subject: "Assunto", Body: "Corpo"})
+	require.NoError(t, err)
+	assert.Contains(t, email.Text, "participa de "+DefaultOrganizationName, "unsupported locales use the default")
+
+	_, err = Render("unknown", i18n.LocaleEnglish, Branding{}, "", Notification{})
+	assert.Error(t, err)
+	_, err = Render(NameRiskCreated, i18n.LocaleEnglish, Branding{}, "", Notification{})
+	assert.Error(t, err, "data must match the template type")
+}
+
+func TestRiskAcceptanceDecidedVariants(t *testing.T) {
+	approved, err := Render(NameRiskAcceptanceDecided, i18n.LocalePortuguese, Branding{}, "", RiskAcceptanceDecided{RiskTitle: "R1", Approved: true, Status: "aceito"})
+	require.NoError(t, err)
+	assert.Equal(t, "Aceite do risco 'R1' aprovado", approved.Subject)
+	assert.Contains(t, approved.Text, "foi aprovada. O status do risco foi atualizado para 'aceito'.")
+
+	rejected, err := Render(NameRiskAcceptanceDecided, i18n.LocaleSpanish, Branding{}, "", RiskAcceptanceDecided{RiskTitle: "R1", Comments: "Sin justificación"})
+	require.NoError(t, err)
+	assert.Equal(t, "Aceptación del riesgo 'R1' rechazada", rejected.Subject)
+	assert.Contains(t, rejected.Text, "Comentarios: Sin justificación")
+	assert.Contains(t, rejected.Text, "próximos pasos")
+}
+
+func TestRenderContentOmitsGreetingAndFooter(t *testing.T) {
+	data := AssessmentMention{AuthorName: "Maria", ControlID: "A.5.1", Comment: "Pode revisar?", Link: "https://grc.example.com/x"}
+	email, err := Render(NameAssessmentMention, i18n.LocalePortuguese, Branding{}, "Ana", data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Maria mencionou você no controle A.5.1", email.Subject)
+	assert.Equal(t, "Maria mencionou você em um comentário na avaliação do controle A.5.1:\n\nPode revisar?", email.Content)
+	assert.Contains(t, email.Text, "Olá, Ana,")
+}
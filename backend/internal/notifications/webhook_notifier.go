@@ -22,6 +22,11 @@ type GoogleChatMessage struct {
 
 // SendWebhookNotification envia uma notificação para uma URL de webhook.
 func SendWebhookNotification(webhookURL string, payload interface{}) error {
+	return sendWebhook(webhookURL, payload, webhookRetryDelay)
+}
+
+// sendWebhook envia o payload com até maxWebhookRetries tentativas, aguardando retryDelay entre elas.
+func sendWebhook(webhookURL string, payload interface{}, retryDelay time.Duration) error {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal webhook payload: %w", err)
@@ -36,7 +41,7 @@ func SendWebhookNotification(webhookURL string, payload interface{}) error {
 				zap.Int("attempt", i+1),
 				zap.Error(err))
 			lastErr = fmt.Errorf("failed to create request: %w", err)
-			time.Sleep(webhookRetryDelay)
+			time.Sleep(retryDelay)
 			continue
 		}
 		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
@@ -49,7 +54,7 @@ func SendWebhookNotification(webhookURL string, payload interface{}) error {
 				zap.Int("attempt", i+1),
 				zap.Error(err))
 			lastErr = fmt.Errorf("request failed: %w", err)
-			time.Sleep(webhookRetryDelay)
+			time.Sleep(retryDelay)
 			continue
 		}
 
@@ -80,7 +85,7 @@ func SendWebhookNotification(webhookURL string, payload interface{}) error {
 		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
 			break
 		}
-		time.Sleep(webhookRetryDelay)
+		time.Sleep(retryDelay)
 	}
 	return fmt.Errorf("failed to send webhook to %s after %d retries: %w", webhookURL, maxWebhookRetries, lastErr)
 }
@@ -1,4 +1,100 @@
 package oauth2auth
 
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/ssomapping"
+	"phoenixgrc/backend/internal/tracing"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
 // GlobalIdPIdentifier é o identificador usado para IdPs OAuth2 globais (não específicos de organização).
 const GlobalIdPIdentifier = "global"
+
+// providerHTTPClient faz as chamadas aos provedores OAuth2 (troca do código e dados do usuário), com spans de
+// cliente quando o rastreamento está ativo.
+var providerHTTPClient = tracing.HTTPClient(&http.Client{Timeout: 15 * time.Second})
+
+// oauthContext é o contexto das chamadas ao provedor: o da requisição (trace atual) com providerHTTPClient.
+func oauthContext(c *gin.Context) context.Context {
+	return context.WithValue(c.Request.Context(), oauth2.HTTPClient, providerHTTPClient)
+}
+
+// createOrgUser cria o usuário provisionado pelo IdP de uma organização. Se houver um convite pendente da
+// organização para o e-mail, o usuário recebe o papel do convite, que é marcado como aceito.
+func createOrgUser(db *gorm.DB, user *models.User) error {
+	invitation, err := models.FindPendingInvitation(db, user.OrganizationID.UUID, user.Email)
+	if err != nil {
+		return err
+	}
+	if invitation != nil {
+		invitation.Apply(user)
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		if invitation != nil {
+			if _, err := invitation.MarkAccepted(tx, user.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// groupsForLogin obtém os grupos do usuário no IdP da organização, só quando o IdP precisa deles (mapeamento de
+// papéis e equipes ou allowed_groups), e confere com ssomapping.Authorize se eles dão acesso. user é o usuário
+// já provisionado, ou nil no primeiro login. Deve ser chamado antes de criar ou reativar o usuário.
+func groupsForLogin(db *gorm.DB, idp *models.IdentityProvider, user *models.User, fetchGroups func() []string) ([]string, error) {
+	mapping, err := ssomapping.Parse(idp.AttributeMappingJSON)
+	if err != nil {
+		return nil, err
+	}
+	if !mapping.NeedsGroups() {
+		return nil, nil
+	}
+	groups := fetchGroups()
+	if err := ssomapping.Authorize(db, idp, user, groups); err != nil {
+		if errors.Is(err, ssomapping.ErrAccessDenied) {
+			phxlog.L.Warn("OAuth2 login denied: no allowed group asserted",
+				zap.String("idpName", idp.Name), zap.Bool("existingUser", user != nil))
+		}
+		return nil, err
+	}
+	return groups, nil
+}
+
+// respondGroupAccessError responde à falha de groupsForLogin: 403 para quem não tem um grupo permitido, 503
+// quando os grupos não puderam ser obtidos no provedor e 500 para os demais erros.
+func respondGroupAccessError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ssomapping.ErrAccessDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your identity provider account is not in a group allowed to access this organization."})
+	case errors.Is(err, ssomapping.ErrGroupsUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Could not verify your groups with the identity provider"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply identity provider role mapping: " + err.Error()})
+	}
+}
+
+// applyGroupMapping aplica ao usuário o mapeamento de papéis e equipes do IdP da organização (ver ssomapping),
+// com os grupos obtidos por groupsForLogin. Grupos nil (não obtidos) mantêm papel e equipes.
+func applyGroupMapping(db *gorm.DB, idp *models.IdentityProvider, user *models.User, groups []string) error {
+	result, err := ssomapping.Apply(db, idp, user, groups)
+	if err == nil && result.Changed() {
+		phxlog.L.Info("OAuth2 group mapping applied",
+			zap.String("userID", user.ID.String()), zap.String("idpName", idp.Name), zap.String("role", string(result.Role)),
+			zap.Strings("teamsAdded", result.TeamsAdded), zap.Strings("teamsRemoved", result.TeamsRemoved))
+	}
+	return err
+}
@@ -1,14 +1,12 @@
 package oauth2auth
 
 import (
-	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"phoenixgrc/backend/internal/auth"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
@@ -52,6 +50,48 @@ type GithubUserEmailResponse struct {
 	Verified bool   `json:"verified"`
 }
 
+// githubGroups lista os grupos do usuário no GitHub para o mapeamento de papéis e equipes: o login de cada
+// organização ("acme") e cada equipe como "organização/equipe" ("acme/security"). Exige o escopo read:org;
+// retorna nil se a consulta falhar.
+func githubGroups(client *http.Client) []string {
+	getJSON := func(url string, out interface{}) error {
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("GitHub API %s returned status %d", url, resp.StatusCode)
+		}
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	var orgs []struct {
+		Login string `json:"login"`
+	}
+	var teams []struct {
+		Slug         string `json:"slug"`
+		Organization struct {
+			Login string `json:"login"`
+		} `json:"organization"`
+	}
+	if err := getJSON("https://api.github.com/user/orgs?per_page=100", &orgs); err != nil {
+		phxlog.L.Warn("Failed to list GitHub organizations for group mapping", zap.Error(err))
+		return nil
+	}
+	if err := getJSON("https://api.github.com/user/teams?per_page=100", &teams); err != nil {
+		phxlog.L.Warn("Failed to list GitHub teams for group mapping", zap.Error(err))
+		return nil
+	}
+	groups := []string{}
+	for _, org := range orgs {
+		groups = append(groups, org.Login)
+	}
+	for _, team := range teams {
+		groups = append(groups, team.Organization.Login+"/"+team.Slug)
+	}
+	return groups
+}
+
 func getGithubOAuthConfig(idpIDStr string, db *gorm.DB) (*oauth2.Config, *GithubOAuthConfig, *models.IdentityProvider, error) {
 	currentAppRootURL := appConfig.Cfg.FrontendBaseURL
 	if currentAppRootURL == "" {
@@ -165,7 +205,7 @@ func GithubCallbackHandler(c *gin.Context) {
 		return
 	}
 
-	token, err := oauthCfg.Exchange(context.Background(), code)
+	token, err := oauthCfg.Exchange(oauthContext(c), code)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to exchange OAuth code for token: " + err.Error()})
 		return
@@ -176,8 +216,8 @@ func GithubCallbackHandler(c *gin.Context) {
 	}
 
 	// Get user info from Github
-	client := oauthCfg.Client(context.Background(), token)
-	req, _ := http.NewRequest("GET", "https://api.github.com/user", nil)
+	client := oauthCfg.Client(oauthContext(c), token)
+	req, _ := http.NewRequestWithContext(c.Request.Context(), "GET", "https://api.github.com/user", nil)
 	resp, err := client.Do(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user info from Github: " + err.Error()})
@@ -194,7 +234,7 @@ func GithubCallbackHandler(c *gin.Context) {
 
 	email := ghUser.Email
 	if email == "" {
-		reqEmails, _ := http.NewRequest("GET", "https://api.github.com/user/emails", nil)
+		reqEmails, _ := http.NewRequestWithContext(c.Request.Context(), "GET", "https://api.github.com/user/emails", nil)
 		respEmails, errEmails := client.Do(reqEmails)
 		if errEmails != nil {
 			phxlog.L.Warn("Failed to get user emails from Github during OAuth callback",
@@ -310,6 +350,16 @@ func GithubCallbackHandler(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error fetching user for org Github login: " + err.Error()})
 			return
 		}
+		// Acesso pelos grupos do GitHub (allowed_groups), antes de criar ou reativar o usuário
+		var existingUser *models.User
+		if err == nil {
+			existingUser = &user
+		}
+		groups, accessErr := groupsForLogin(db, idpModelFromDB, existingUser, func() []string { return githubGroups(client) })
+		if accessErr != nil {
+			respondGroupAccessError(c, accessErr)
+			return
+		}
 
 		if err == gorm.ErrRecordNotFound { // User does not exist in this org with this IdP, provision
 			user = models.User{
@@ -319,10 +369,10 @@ func GithubCallbackHandler(c *gin.Context) {
 				PasswordHash:   "OAUTH2_USER_NO_PASSWORD",
 				SSOProvider:    ssoProviderName,
 				SocialLoginID:  githubUserIDStr,
-				Role:           models.RoleUser, // Default role
+				Role:           models.RoleUser, // Papel padrão; um convite pendente define outro (ver createOrgUser)
 				IsActive:       true,
 			}
-			if createErr := db.Create(&user).Error; createErr != nil {
+			if createErr := createOrgUser(db, &user); createErr != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create new org Github SSO user: " + createErr.Error()})
 				return
 			}
@@ -340,6 +390,11 @@ func GithubCallbackHandler(c *gin.Context) {
 				return
 			}
 		}
+		// Papel e equipes pelas organizações e equipes do GitHub (ver applyGroupMapping)
+		if mapErr := applyGroupMapping(db, idpModelFromDB, &user, groups); mapErr != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply identity provider role mapping: " + mapErr.Error()})
+			return
+		}
 		userOrgID = user.OrganizationID // Should be valid and match idpModelFromDB.OrganizationID
 	}
 
@@ -350,13 +405,13 @@ func GithubCallbackHandler(c *gin.Context) {
 	} else {
 		orgIDForToken = uuid.Nil // Explicitly pass uuid.Nil if not valid
 	}
-	jwtToken, jwtErr := auth.GenerateToken(&user, orgIDForToken)
+	session, jwtErr := auth.StartSession(db, &user, orgIDForToken, c.Request.UserAgent(), c.ClientIP())
 	if jwtErr != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate session token: " + jwtErr.Error()})
 		return
 	}
 
-	frontendRedirectURL := os.Getenv("FRONTEND_OAUTH2_CALLBACK_URL")
+	frontendRedirectURL := appConfig.Cfg.FrontendOAuth2CallbackURL
 	if frontendRedirectURL == "" {
 		frontendRedirectURL = appConfig.Cfg.FrontendBaseURL // Use configured AppRootURL
 		if frontendRedirectURL == "" {
@@ -364,6 +419,6 @@ func GithubCallbackHandler(c *gin.Context) {
 		}
 	}
 	// Ensure no double slashes if frontendRedirectURL ends with / and APP_ROOT_URL also has it
-	targetURL := fmt.Sprintf("%s/oauth2/callback?token=%s&sso_success=true&provider=%s", strings.TrimSuffix(frontendRedirectURL, "/"), jwtToken, strings.ReplaceAll(ssoProviderName, "global_", ""))
+	targetURL := fmt.Sprintf("%s/oauth2/callback?token=%s&refresh_token=%s&sso_success=true&provider=%s", strings.TrimSuffix(frontendRedirectURL, "/"), session.AccessToken, session.RefreshToken, strings.ReplaceAll(ssoProviderName, "global_", ""))
 	c.Redirect(http.StatusFound, targetURL)
 }
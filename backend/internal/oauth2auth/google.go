@@ -1,7 +1,6 @@
 package oauth2auth
 
 import (
-	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
@@ -27,7 +26,6 @@ import (
 	// appConfig já deve estar importado por causa de InitializeOAuth2GlobalConfig e uso de Cfg
 	// mas se não, adicionar:
 	// appConfig "phoenixgrc/backend/pkg/config"
-	"os"
 )
 
 const googleOAuthStateCookie = "phoenixgrc_google_oauth_state"
@@ -183,7 +181,7 @@ func GoogleCallbackHandler(c *gin.Context) {
 	}
 
 	// Exchange authorization code for a token
-	token, err := oauthCfg.Exchange(context.Background(), code)
+	token, err := oauthCfg.Exchange(oauthContext(c), code)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to exchange OAuth code for token: " + err.Error()})
 		return
@@ -194,8 +192,8 @@ func GoogleCallbackHandler(c *gin.Context) {
 	}
 
 	// Get user info from Google
-	client := oauthCfg.Client(context.Background(), token)
-	oauth2Service, err := googleAPI.NewService(context.Background(), option.WithHTTPClient(client))
+	client := oauthCfg.Client(oauthContext(c), token)
+	oauth2Service, err := googleAPI.NewService(c.Request.Context(), option.WithHTTPClient(client))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create Google API service client: " + err.Error()})
 		return
@@ -293,6 +291,23 @@ func GoogleCallbackHandler(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error fetching user for org Google login: " + err.Error()})
 			return
 		}
+		// O Google não informa grupos ao login; o grupo mapeável (e permitido) é o domínio do Google Workspace (hd).
+		// O acesso é conferido antes de criar ou reativar o usuário.
+		var existingUser *models.User
+		if err == nil {
+			existingUser = &user
+		}
+		groups, accessErr := groupsForLogin(db, idpModelFromDB, existingUser, func() []string {
+			groups := []string{}
+			if userInfo.Hd != "" {
+				groups = append(groups, userInfo.Hd)
+			}
+			return groups
+		})
+		if accessErr != nil {
+			respondGroupAccessError(c, accessErr)
+			return
+		}
 
 		if err == gorm.ErrRecordNotFound { // User does not exist in this org, provision
 			user = models.User{
@@ -302,10 +317,10 @@ func GoogleCallbackHandler(c *gin.Context) {
 				PasswordHash:   "OAUTH2_USER_NO_PASSWORD",
 				SSOProvider:    ssoProviderName,
 				SocialLoginID:  externalID,
-				Role:           models.RoleUser, // Default role, could be customized based on IdP config later
+				Role:           models.RoleUser, // Papel padrão; um convite pendente define outro (ver createOrgUser)
 				IsActive:       true,
 			}
-			if createErr := db.Create(&user).Error; createErr != nil {
+			if createErr := createOrgUser(db, &user); createErr != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create new org Google SSO user: " + createErr.Error()})
 				return
 			}
@@ -323,6 +338,11 @@ func GoogleCallbackHandler(c *gin.Context) {
 				return
 			}
 		}
+		// Papel e equipes pelo domínio do Google Workspace (ver applyGroupMapping)
+		if mapErr := applyGroupMapping(db, idpModelFromDB, &user, groups); mapErr != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply identity provider role mapping: " + mapErr.Error()})
+			return
+		}
 		userOrgID = user.OrganizationID // Should be valid and match idpModelFromDB.OrganizationID
 	}
 
@@ -336,20 +356,20 @@ func GoogleCallbackHandler(c *gin.Context) {
 		orgIDForToken = uuid.Nil // Explicitly pass uuid.Nil if not valid
 	}
 
-	jwtToken, jwtErr := auth.GenerateToken(&user, orgIDForToken)
+	session, jwtErr := auth.StartSession(db, &user, orgIDForToken, c.Request.UserAgent(), c.ClientIP())
 	if jwtErr != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate session token: " + jwtErr.Error()})
 		return
 	}
 
 	// Redirect to frontend (similar to SAML)
-	frontendRedirectURL := os.Getenv("FRONTEND_OAUTH2_CALLBACK_URL")
+	frontendRedirectURL := config.Cfg.FrontendOAuth2CallbackURL
 	if frontendRedirectURL == "" {
-		frontendRedirectURL = os.Getenv("APP_ROOT_URL")
+		frontendRedirectURL = config.Cfg.AppRootURL
 		if frontendRedirectURL == "" {
 			frontendRedirectURL = "/"
 		}
 	}
-	targetURL := fmt.Sprintf("%s?token=%s&sso_success=true&provider=google", frontendRedirectURL, jwtToken)
+	targetURL := fmt.Sprintf("%s?token=%s&refresh_token=%s&sso_success=true&provider=google", frontendRedirectURL, session.AccessToken, session.RefreshToken)
 	c.Redirect(http.StatusFound, targetURL)
 }
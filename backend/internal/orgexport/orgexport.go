@@ -0,0 +1,389 @@
+// Package orgexport gera a exportação completa dos dados de uma organização, para backup e offboarding de
+// clientes: um ZIP com um arquivo JSON por conjunto de dados (array de registros), CSVs dos conjuntos tabulares
+// e um manifest.json com as contagens e o SHA-256 de cada arquivo. Os registros são lidos em streaming, sem
+// carregar a organização inteira na memória. O conteúdo dos arquivos de evidências e de políticas não é
+// exportado: o manifesto de evidências e as versões das políticas trazem a chave de cada arquivo no armazenamento.
+package orgexport
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"time"
+
+	"phoenixgrc/backend/internal/jsonlexport"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FormatVersion é a versão do formato da exportação. Dentro da mesma versão, campos e arquivos só são
+// acrescentados; consumidores devem ignorar os desconhecidos.
+const FormatVersion = 1
+
+// ContentType é o tipo de mídia do arquivo exportado.
+const ContentType = "application/zip"
+
+// ManifestFileName é o nome do manifesto dentro do ZIP, gravado por último.
+const ManifestFileName = "manifest.json"
+
+// File descreve um arquivo do ZIP.
+type File struct {
+	Name    string `json:"name"`
+	Records int    `json:"records"`
+	SHA256  string `json:"sha256"`
+}
+
+// Manifest descreve a exportação.
+type Manifest struct {
+	FormatVersion    int       `json:"format_version"`
+	ExportID         uuid.UUID `json:"export_id"`
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	GeneratedAt      time.Time `json:"generated_at"`
+	Files            []File    `json:"files"`
+}
+
+// UserRecord é um usuário da organização, sem credenciais.
+type UserRecord struct {
+	ID            uuid.UUID       `json:"id"`
+	Name          string          `json:"name"`
+	Email         string          `json:"email"`
+	Role          models.UserRole `json:"role"`
+	CustomRoleID  *uuid.UUID      `json:"custom_role_id"`
+	IsActive      bool            `json:"is_active"`
+	IsTOTPEnabled bool            `json:"is_totp_enabled"`
+	SSOProvider   string          `json:"sso_provider"`
+	AnonymizedAt  *time.Time      `json:"anonymized_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// RiskRecord é um risco da organização (os da lixeira não são exportados).
+type RiskRecord struct {
+	ID            uuid.UUID  `json:"id"`
+	ReferenceCode string     `json:"reference_code"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Category      string     `json:"category"`
+	Impact        string     `json:"impact"`
+	Probability   string     `json:"probability"`
+	RiskLevel     string     `json:"risk_level"`
+	Status        string     `json:"status"`
+	StatusCode    *string    `json:"status_code"`
+	OwnerID       uuid.UUID  `json:"owner_id"`
+	OwnerTeamID   *uuid.UUID `json:"owner_team_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// AssessmentRecord é uma avaliação de controle, contínua ou de uma auditoria.
+type AssessmentRecord struct {
+	ID                      uuid.UUID  `json:"id"`
+	AuditControlID          uuid.UUID  `json:"audit_control_id"`
+	ControlID               string     `json:"control_id"`
+	FrameworkID             uuid.UUID  `json:"framework_id"`
+	FrameworkName           string     `json:"framework_name"` // Identifica o framework em outra instância (ver orgimport)
+	AuditCampaignID         *uuid.UUID `json:"audit_campaign_id"`
+	Status                  string     `json:"status"`
+	StatusCode              *string    `json:"status_code"`
+	Score                   *int       `json:"score"`
+	AssessmentDate          *time.Time `json:"assessment_date"`
+	Comments                *string    `json:"comments"`
+	ImplementationNarrative *string    `json:"implementation_narrative"`
+	PolicyID                *uuid.UUID `json:"policy_id"`
+	AssignedToID            *uuid.UUID `json:"assigned_to_id"`
+	DueDate                 *time.Time `json:"due_date"`
+	EvidenceURL             string     `json:"evidence_url"`
+	CreatedAt               time.Time  `json:"created_at"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+}
+
+// EvidenceRecord são os metadados de uma evidência: a da própria avaliação (evidence_url) ou um arquivo
+// enviado pelo portal de evidências (jsonlexport.EvidenceSource*).
+type EvidenceRecord struct {
+	Source            string     `json:"source"`
+	AuditControlID    *uuid.UUID `json:"audit_control_id"`
+	AssessmentID      *uuid.UUID `json:"assessment_id"`
+	EvidenceRequestID *uuid.UUID `json:"evidence_request_id"`
+	SubmissionID      *uuid.UUID `json:"submission_id"`
+	URL               string     `json:"url"`
+	ObjectName        string     `json:"object_name"`
+	FileName          string     `json:"file_name"`
+	SizeBytes         *int64     `json:"size_bytes"`
+	ScanStatus        string     `json:"scan_status"`
+	RecordedAt        *time.Time `json:"recorded_at"`
+}
+
+// PolicyRecord é uma política da organização (as da lixeira não são exportadas).
+type PolicyRecord struct {
+	ID                  uuid.UUID  `json:"id"`
+	ReferenceCode       string     `json:"reference_code"`
+	Title               string     `json:"title"`
+	Description         string     `json:"description"`
+	OwnerID             uuid.UUID  `json:"owner_id"`
+	Status              string     `json:"status"`
+	ReviewCadenceMonths int        `json:"review_cadence_months"`
+	NextReviewDate      *time.Time `json:"next_review_date"`
+	CurrentVersionID    *uuid.UUID `json:"current_version_id"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// PolicyVersionRecord é uma versão de política; FileURL é a chave do arquivo no armazenamento.
+type PolicyVersionRecord struct {
+	ID             uuid.UUID  `json:"id"`
+	PolicyID       uuid.UUID  `json:"policy_id"`
+	VersionNumber  int        `json:"version_number"`
+	FileURL        string     `json:"file_url"`
+	FileName       string     `json:"file_name"`
+	Changelog      string     `json:"changelog"`
+	UploadedByID   uuid.UUID  `json:"uploaded_by_id"`
+	ApprovalStatus string     `json:"approval_status"`
+	ApproverID     *uuid.UUID `json:"approver_id"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// dataset é um conjunto de dados exportado em <name>.json e, se columns não for vazio, também em <name>.csv.
+type dataset struct {
+	name    string
+	query   func(db *gorm.DB, organizationID uuid.UUID) *gorm.DB
+	record  func() interface{} // Novo registro (ponteiro) para receber cada linha
+	columns []string
+	row     func(record interface{}) []string
+}
+
+var datasets = []dataset{
+	{
+		name: "users",
+		query: func(db *gorm.DB, organizationID uuid.UUID) *gorm.DB {
+			return db.Model(&models.User{}).Where("organization_id = ?", organizationID).Order("created_at, id")
+		},
+		record:  func() interface{} { return &UserRecord{} },
+		columns: []string{"id", "name", "email", "role", "custom_role_id", "is_active", "is_totp_enabled", "sso_provider", "anonymized_at", "created_at", "updated_at"},
+		row: func(record interface{}) []string {
+			u := record.(*UserRecord)
+			return []string{u.ID.String(), u.Name, u.Email, string(u.Role), uuidCell(u.CustomRoleID), strconv.FormatBool(u.IsActive),
+				strconv.FormatBool(u.IsTOTPEnabled), u.SSOProvider, timeCell(u.AnonymizedAt), timeCell(&u.CreatedAt), timeCell(&u.UpdatedAt)}
+		},
+	},
+	{
+		name: "risks",
+		query: func(db *gorm.DB, organizationID uuid.UUID) *gorm.DB {
+			return db.Model(&models.Risk{}).Where("organization_id = ?", organizationID).Order("reference_code, id")
+		},
+		record:  func() interface{} { return &RiskRecord{} },
+		columns: []string{"id", "reference_code", "title", "description", "category", "impact", "probability", "risk_level", "status", "status_code", "owner_id", "owner_team_id", "created_at", "updated_at"},
+		row: func(record interface{}) []string {
+			r := record.(*RiskRecord)
+			statusCode := ""
+			if r.StatusCode != nil {
+				statusCode = *r.StatusCode
+			}
+			return []string{r.ID.String(), r.ReferenceCode, r.Title, r.Description, r.Category, r.Impact, r.Probability, r.RiskLevel, r.Status,
+				statusCode, r.OwnerID.String(), uuidCell(r.OwnerTeamID), timeCell(&r.CreatedAt), timeCell(&r.UpdatedAt)}
+		},
+	},
+	{
+		name: "assessments",
+		query: func(db *gorm.DB, organizationID uuid.UUID) *gorm.DB {
+			return db.Table("audit_assessments").
+				Select("audit_assessments.*, audit_controls.control_id AS control_id, audit_controls.framework_id AS framework_id, audit_frameworks.name AS framework_name").
+				Joins("JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id").
+				Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+				Where("audit_assessments.organization_id = ?", organizationID).
+				Order("audit_assessments.created_at, audit_assessments.id")
+		},
+		record: func() interface{} { return &AssessmentRecord{} },
+	},
+	{
+		name: "evidence_manifest",
+		query: func(db *gorm.DB, organizationID uuid.UUID) *gorm.DB {
+			return db.Raw(`SELECT * FROM (
+				SELECT ?::text AS source, a.audit_control_id, a.id AS assessment_id, NULL::uuid AS evidence_request_id,
+					NULL::uuid AS submission_id, a.evidence_url AS url, '' AS object_name, '' AS file_name,
+					NULL::bigint AS size_bytes, COALESCE(a.evidence_scan_status, '') AS scan_status, a.evidence_scanned_at AS recorded_at
+				FROM audit_assessments a
+				WHERE a.organization_id = ? AND a.evidence_url <> ''
+				UNION ALL
+				SELECT ?::text, r.audit_control_id, NULL::uuid, r.id, s.id, '', s.object_name, COALESCE(s.file_name, ''),
+					s.size_bytes, COALESCE(s.scan_status, ''), s.created_at
+				FROM evidence_submissions s JOIN evidence_requests r ON r.id = s.evidence_request_id
+				WHERE r.organization_id = ?
+			) evidence ORDER BY recorded_at NULLS FIRST, assessment_id, submission_id`,
+				jsonlexport.EvidenceSourceAssessment, organizationID, jsonlexport.EvidenceSourceEvidenceRequest, organizationID)
+		},
+		record: func() interface{} { return &EvidenceRecord{} },
+	},
+	{
+		name: "policies",
+		query: func(db *gorm.DB, organizationID uuid.UUID) *gorm.DB {
+			return db.Model(&models.Policy{}).Where("organization_id = ?", organizationID).Order("reference_code, id")
+		},
+		record: func() interface{} { return &PolicyRecord{} },
+	},
+	{
+		name: "policy_versions",
+		query: func(db *gorm.DB, organizationID uuid.UUID) *gorm.DB {
+			policies := db.Model(&models.Policy{}).Select("id").Where("organization_id = ?", organizationID)
+			return db.Model(&models.PolicyVersion{}).Where("policy_id IN (?)", policies).Order("policy_id, version_number")
+		},
+		record: func() interface{} { return &PolicyVersionRecord{} },
+	},
+}
+
+// Write grava em w o ZIP com os dados da organização e retorna o manifesto, também gravado no ZIP.
+func Write(ctx context.Context, db *gorm.DB, exportID, organizationID uuid.UUID, w io.Writer, now time.Time) (*Manifest, error) {
+	var org models.Organization
+	if err := db.WithContext(ctx).First(&org, "id = ?", organizationID).Error; err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{
+		FormatVersion:    FormatVersion,
+		ExportID:         exportID,
+		OrganizationID:   organizationID,
+		OrganizationName: org.Name,
+		GeneratedAt:      now,
+		Files:            []File{},
+	}
+	archive := zip.NewWriter(w)
+	for _, ds := range datasets {
+		file, err := writeJSON(ctx, db, archive, ds, organizationID, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", ds.name, err)
+		}
+		manifest.Files = append(manifest.Files, file)
+		if len(ds.columns) > 0 {
+			if file, err = writeCSV(ctx, db, archive, ds, organizationID, now); err != nil {
+				return nil, fmt.Errorf("failed to export %s: %w", ds.name, err)
+			}
+			manifest.Files = append(manifest.Files, file)
+		}
+	}
+
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: ManifestFileName, Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(entry)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// hashedEntry abre o arquivo name no ZIP calculando o SHA-256 do conteúdo.
+func hashedEntry(archive *zip.Writer, name string, modified time.Time) (io.Writer, hash.Hash, error) {
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return nil, nil, err
+	}
+	h := sha256.New()
+	return io.MultiWriter(entry, h), h, nil
+}
+
+// eachRecord lê a consulta do conjunto linha a linha e entrega cada registro a fn.
+func eachRecord(ctx context.Context, db *gorm.DB, ds dataset, organizationID uuid.UUID, fn func(record interface{}) error) (int, error) {
+	query := ds.query(db.WithContext(ctx), organizationID)
+	rows, err := query.Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	count := 0
+	for rows.Next() {
+		record := ds.record()
+		if err := query.ScanRows(rows, record); err != nil {
+			return count, err
+		}
+		if err := fn(record); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// writeJSON grava o conjunto como um array JSON, um registro por linha.
+func writeJSON(ctx context.Context, db *gorm.DB, archive *zip.Writer, ds dataset, organizationID uuid.UUID, now time.Time) (File, error) {
+	name := ds.name + ".json"
+	out, h, err := hashedEntry(archive, name, now)
+	if err != nil {
+		return File{}, err
+	}
+	if _, err := io.WriteString(out, "["); err != nil {
+		return File{}, err
+	}
+	separator := "\n"
+	count, err := eachRecord(ctx, db, ds, organizationID, func(record interface{}) error {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, separator); err != nil {
+			return err
+		}
+		separator = ",\n"
+		_, err = out.Write(data)
+		return err
+	})
+	if err != nil {
+		return File{}, err
+	}
+	if _, err := io.WriteString(out, "\n]\n"); err != nil {
+		return File{}, err
+	}
+	return File{Name: name, Records: count, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// writeCSV grava o conjunto em CSV, com o cabeçalho das colunas.
+func writeCSV(ctx context.Context, db *gorm.DB, archive *zip.Writer, ds dataset, organizationID uuid.UUID, now time.Time) (File, error) {
+	name := ds.name + ".csv"
+	out, h, err := hashedEntry(archive, name, now)
+	if err != nil {
+		return File{}, err
+	}
+	writer := csv.NewWriter(out)
+	if err := writer.Write(ds.columns); err != nil {
+		return File{}, err
+	}
+	count, err := eachRecord(ctx, db, ds, organizationID, func(record interface{}) error {
+		return writer.Write(ds.row(record))
+	})
+	if err != nil {
+		return File{}, err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return File{}, err
+	}
+	return File{Name: name, Records: count, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+func uuidCell(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func timeCell(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
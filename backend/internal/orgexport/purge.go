@@ -0,0 +1,65 @@
+package orgexport
+
+import (
+	"context"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// DownloadTTL é por quanto tempo o ZIP de uma exportação concluída fica disponível para download.
+const DownloadTTL = 7 * 24 * time.Hour
+
+// PurgeExpired remove do armazenamento os ZIPs das exportações vencidas e as marca como expiradas. Falhas ao
+// remover um arquivo são registradas e a exportação é tentada de novo na próxima execução.
+func PurgeExpired(ctx context.Context, db *gorm.DB, now time.Time) (int, error) {
+	var exports []models.OrganizationExport
+	if err := db.Where("status = ? AND expires_at <= ?", models.OrganizationExportCompleted, now).Find(&exports).Error; err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, export := range exports {
+		if export.ObjectName != "" && filestorage.DefaultFileStorageProvider != nil {
+			if err := filestorage.DefaultFileStorageProvider.DeleteFile(ctx, export.ObjectName); err != nil {
+				phxlog.L.Warn("Failed to delete organization export file from storage",
+					zap.String("exportID", export.ID.String()),
+					zap.String("objectName", export.ObjectName),
+					zap.Error(err))
+				continue
+			}
+		}
+		if err := db.Model(&export).Updates(map[string]interface{}{"status": models.OrganizationExportExpired, "object_name": ""}).Error; err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// StartPurgeScheduler remove as exportações vencidas a cada intervalo até o contexto ser cancelado.
+func StartPurgeScheduler(ctx context.Context, every time.Duration) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				count, err := PurgeExpired(ctx, database.GetDB().WithContext(ctx), now)
+				if err != nil {
+					phxlog.L.Error("Organization export purge failed", zap.Error(err))
+				}
+				if count > 0 {
+					phxlog.L.Info("Expired organization exports removed", zap.Int("count", count))
+				}
+			}
+		}
+	}()
+}
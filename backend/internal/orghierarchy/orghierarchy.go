@@ -0,0 +1,115 @@
+// Package orghierarchy trata a hierarquia de organizações (grupo e unidades de negócio). A organização pai
+// vê os relatórios consolidados das descendentes (endpoints /rollup); todos os demais endpoints continuam
+// isolados por organização, então pertencer ao grupo não dá acesso aos dados de uma unidade.
+package orghierarchy
+
+import (
+	"errors"
+	"fmt"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxDepth é o número máximo de níveis abaixo da organização raiz.
+const MaxDepth = 5
+
+var (
+	// ErrCycle indica que a organização pai informada é a própria organização ou uma descendente dela.
+	ErrCycle = errors.New("an organization cannot be placed under itself or one of its descendants")
+	// ErrTooDeep indica que a hierarquia resultante passaria de MaxDepth níveis.
+	ErrTooDeep = fmt.Errorf("the hierarchy cannot have more than %d levels below the root organization", MaxDepth)
+)
+
+// Node é uma organização da subárvore, com a distância até a raiz da consulta (0 para a própria raiz).
+type Node struct {
+	ID       uuid.UUID  `json:"organization_id"`
+	Name     string     `json:"name"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	Depth    int        `json:"depth"`
+}
+
+// subtreeQuery percorre as descendentes de uma organização. O limite de profundidade protege contra ciclos
+// criados diretamente no banco.
+const subtreeQuery = `WITH RECURSIVE subtree AS (
+	SELECT id, name, parent_id, 0 AS depth FROM organizations WHERE id = ?
+	UNION ALL
+	SELECT o.id, o.name, o.parent_id, s.depth + 1 FROM organizations o JOIN subtree s ON o.parent_id = s.id
+	WHERE s.depth < ?
+)
+SELECT id, name, parent_id, depth FROM subtree ORDER BY depth, name`
+
+// Subtree retorna a organização e as suas descendentes, por nível e nome. Vazia se a organização não existir.
+func Subtree(db *gorm.DB, rootID uuid.UUID) ([]Node, error) {
+	var nodes []Node
+	err := db.Raw(subtreeQuery, rootID, MaxDepth).Scan(&nodes).Error
+	return nodes, err
+}
+
+// IDs retorna os IDs dos nós.
+func IDs(nodes []Node) []uuid.UUID {
+	ids := make([]uuid.UUID, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.ID
+	}
+	return ids
+}
+
+// Ancestors retorna a cadeia de organizações acima de orgID, da pai até a raiz.
+func Ancestors(db *gorm.DB, orgID uuid.UUID) ([]uuid.UUID, error) {
+	var chain []uuid.UUID
+	current := orgID
+	// Uma volta a mais que MaxDepth basta para detectar uma cadeia longa demais (ou um ciclo)
+	for i := 0; i <= MaxDepth+1; i++ {
+		var org models.Organization
+		if err := db.Select("id", "parent_id").Take(&org, "id = ?", current).Error; err != nil {
+			return nil, err
+		}
+		if org.ParentID == nil {
+			return chain, nil
+		}
+		chain = append(chain, *org.ParentID)
+		current = *org.ParentID
+	}
+	return chain, nil
+}
+
+// ValidateParent verifica se orgID pode passar a ficar abaixo de parentID: sem ciclos e sem passar de
+// MaxDepth níveis, contando as descendentes que orgID já tem.
+func ValidateParent(db *gorm.DB, orgID, parentID uuid.UUID) error {
+	parentChain, err := Ancestors(db, parentID)
+	if err != nil {
+		return err
+	}
+	subtree, err := Subtree(db, orgID)
+	if err != nil {
+		return err
+	}
+	height := 0
+	for _, node := range subtree {
+		if node.Depth > height {
+			height = node.Depth
+		}
+	}
+	return checkParent(orgID, parentID, parentChain, height)
+}
+
+// checkParent aplica as regras de ValidateParent: parentChain são as organizações acima da nova pai e
+// height, a profundidade da subárvore de orgID.
+func checkParent(orgID, parentID uuid.UUID, parentChain []uuid.UUID, height int) error {
+	if parentID == orgID {
+		return ErrCycle
+	}
+	for _, id := range parentChain {
+		if id == orgID {
+			return ErrCycle
+		}
+	}
+	// Nível da nova pai (len(parentChain)) + 1 para orgID + os níveis abaixo dela
+	if len(parentChain)+1+height > MaxDepth {
+		return ErrTooDeep
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package orghierarchy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckParent(t *testing.T) {
+	org, parent, root := uuid.New(), uuid.New(), uuid.New()
+
+	assert.NoError(t, checkParent(org, parent, nil, 0), "parent is a root organization")
+	assert.NoError(t, checkParent(org, parent, []uuid.UUID{root}, 2))
+
+	assert.ErrorIs(t, checkParent(org, org, nil, 0), ErrCycle)
+	assert.ErrorIs(t, checkParent(org, parent, []uuid.UUID{org, root}, 0), ErrCycle, "parent is a descendant of org")
+
+	chain := make([]uuid.UUID, MaxDepth-1)
+	for i := range chain {
+		chain[i] = uuid.New()
+	}
+	assert.NoError(t, checkParent(org, parent, chain, 0), "org becomes the deepest allowed level")
+	assert.ErrorIs(t, checkParent(org, parent, chain, 1), ErrTooDeep, "org's children would go past the limit")
+	assert.ErrorIs(t, checkParent(org, parent, append(chain, uuid.New()), 0), ErrTooDeep)
+}
+
+func TestIDs(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	assert.Equal(t, []uuid.UUID{a, b}, IDs([]Node{{ID: a}, {ID: b, ParentID: &a, Depth: 1}}))
+	assert.Empty(t, IDs(nil))
+}
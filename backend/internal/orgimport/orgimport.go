@@ -0,0 +1,495 @@
+// Package orgimport importa numa nova organização o ZIP gerado pela exportação completa (pacote orgexport),
+// para mover um cliente entre instâncias auto-hospedadas. Todos os registros recebem novos UUIDs e as
+// referências entre eles (responsáveis, políticas, versões) são remapeadas; os códigos de referência
+// (RISK-0042, POL-0003) são mantidos.
+//
+// O que não faz parte da exportação não é importado: senhas, TOTP e papéis personalizados dos usuários (eles
+// entram pelo SSO ou pela recuperação de senha), equipes, status personalizados e auditorias (campanhas).
+// Os arquivos de evidências e de políticas também não: as chaves no armazenamento são mantidas e os arquivos
+// devem ser copiados para o armazenamento da nova instância.
+package orgimport
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/orgexport"
+	"phoenixgrc/backend/internal/privacy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// requiredFiles são os arquivos do ZIP lidos pela importação.
+var requiredFiles = []string{"users.json", "policies.json", "policy_versions.json", "risks.json", "assessments.json"}
+
+// errDryRun desfaz a transação de uma simulação.
+var errDryRun = errors.New("dry run")
+
+// Options configura a importação.
+type Options struct {
+	OrganizationName string // Nome da nova organização; vazio usa o nome do manifesto
+	DryRun           bool   // Valida e importa numa transação desfeita ao final
+}
+
+// Result resume a importação.
+type Result struct {
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	Imported       map[string]int `json:"imported"` // Registros importados por conjunto de dados
+	Warnings       []string       `json:"warnings"`
+	DryRun         bool           `json:"dry_run"`
+}
+
+func (r *Result) warn(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// importer guarda o estado de uma importação: o ZIP e os novos IDs de cada registro de origem.
+type importer struct {
+	tx       *gorm.DB
+	files    map[string]*zip.File
+	orgID    uuid.UUID
+	users    map[uuid.UUID]uuid.UUID
+	policies map[uuid.UUID]uuid.UUID
+	versions map[uuid.UUID]uuid.UUID
+	controls map[string]*uuid.UUID // "framework|control_id" -> controle na instância (nil se não existir)
+	result   *Result
+}
+
+// Import lê o ZIP da exportação e cria uma nova organização com os seus dados, numa única transação.
+// Falha antes de gravar se o formato for mais novo que o suportado, se algum arquivo não conferir com o
+// SHA-256 do manifesto ou se o e-mail de algum usuário já existir na instância.
+func Import(ctx context.Context, db *gorm.DB, r io.ReaderAt, size int64, opts Options) (*Result, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid export file: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+	manifest, err := readManifest(files)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyFiles(files, manifest); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(opts.OrganizationName)
+	if name == "" {
+		name = manifest.OrganizationName
+	}
+	result := &Result{Imported: map[string]int{}, Warnings: []string{}, DryRun: opts.DryRun}
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		org := models.Organization{Name: name}
+		if err := tx.Create(&org).Error; err != nil {
+			return fmt.Errorf("failed to create organization: %w", err)
+		}
+		result.OrganizationID = org.ID
+		imp := &importer{
+			tx:       tx,
+			files:    files,
+			orgID:    org.ID,
+			users:    map[uuid.UUID]uuid.UUID{},
+			policies: map[uuid.UUID]uuid.UUID{},
+			versions: map[uuid.UUID]uuid.UUID{},
+			controls: map[string]*uuid.UUID{},
+			result:   result,
+		}
+		steps := []func() error{imp.importUsers, imp.importPolicies, imp.importRisks, imp.importAssessments, imp.advanceSequences}
+		for _, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return result, nil
+}
+
+func readManifest(files map[string]*zip.File) (*orgexport.Manifest, error) {
+	f, ok := files[orgexport.ManifestFileName]
+	if !ok {
+		return nil, fmt.Errorf("invalid export file: %s not found", orgexport.ManifestFileName)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	var manifest orgexport.Manifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", orgexport.ManifestFileName, err)
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > orgexport.FormatVersion {
+		return nil, fmt.Errorf("unsupported export format version %d (supported: up to %d)", manifest.FormatVersion, orgexport.FormatVersion)
+	}
+	return &manifest, nil
+}
+
+// verifyFiles confere o SHA-256 de cada arquivo listado no manifesto e a presença dos arquivos importados.
+func verifyFiles(files map[string]*zip.File, manifest *orgexport.Manifest) error {
+	listed := map[string]bool{}
+	for _, entry := range manifest.Files {
+		f, ok := files[entry.Name]
+		if !ok {
+			return fmt.Errorf("invalid export file: %s is listed in the manifest but missing", entry.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, rc)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.Name, err)
+		}
+		if hex.EncodeToString(h.Sum(nil)) != entry.SHA256 {
+			return fmt.Errorf("checksum mismatch for %s: the export file is corrupted or was modified", entry.Name)
+		}
+		listed[entry.Name] = true
+	}
+	for _, name := range requiredFiles {
+		if !listed[name] {
+			return fmt.Errorf("invalid export file: %s not found in the manifest", name)
+		}
+	}
+	return nil
+}
+
+// each decodifica o array JSON do arquivo name registro a registro, sem carregá-lo inteiro na memória.
+func (imp *importer) each(name string, newRecord func() interface{}, fn func(record interface{}) error) error {
+	rc, err := imp.files[name].Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	dec := json.NewDecoder(rc)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return fmt.Errorf("invalid %s: expected a JSON array", name)
+	}
+	for dec.More() {
+		record := newRecord()
+		if err := dec.Decode(record); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// user retorna o novo ID do usuário de origem; uuid.Nil continua nulo.
+func (imp *importer) user(id uuid.UUID, what string) (uuid.UUID, error) {
+	if id == uuid.Nil {
+		return uuid.Nil, nil
+	}
+	newID, ok := imp.users[id]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("%s references user %s, which is not in the export", what, id)
+	}
+	return newID, nil
+}
+
+// optionalUser remapeia uma referência opcional; usuários fora da exportação viram nulo.
+func (imp *importer) optionalUser(id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	newID, ok := imp.users[*id]
+	if !ok {
+		return nil
+	}
+	return &newID
+}
+
+func (imp *importer) importUsers() error {
+	var emails []string
+	err := imp.each("users.json", func() interface{} { return &orgexport.UserRecord{} }, func(record interface{}) error {
+		emails = append(emails, strings.ToLower(record.(*orgexport.UserRecord).Email))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(emails) > 0 {
+		var existing []string
+		if err := imp.tx.Model(&models.User{}).Where("LOWER(email) IN ?", emails).Pluck("email", &existing).Error; err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("users already exist in this instance: %s", strings.Join(existing, ", "))
+		}
+	}
+
+	return imp.each("users.json", func() interface{} { return &orgexport.UserRecord{} }, func(record interface{}) error {
+		u := record.(*orgexport.UserRecord)
+		user := models.User{
+			ID:             uuid.New(),
+			OrganizationID: uuid.NullUUID{UUID: imp.orgID, Valid: true},
+			Name:           u.Name,
+			Email:          u.Email,
+			Role:           u.Role,
+			IsActive:       u.IsActive,
+			SSOProvider:    u.SSOProvider,
+			AnonymizedAt:   u.AnonymizedAt,
+			CreatedAt:      u.CreatedAt,
+			UpdatedAt:      u.UpdatedAt,
+		}
+		if u.AnonymizedAt != nil {
+			user.Email = privacy.AnonymizedEmail(&user)
+		}
+		if err := imp.tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("failed to import user %s: %w", u.ID, err)
+		}
+		// is_active tem default true no banco: o valor zero (false) é ignorado pelo Create
+		if !u.IsActive {
+			if err := imp.tx.Model(&user).UpdateColumn("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+		imp.users[u.ID] = user.ID
+		imp.result.Imported["users"]++
+		return nil
+	})
+}
+
+func (imp *importer) importPolicies() error {
+	currentVersions := map[uuid.UUID]uuid.UUID{} // nova política -> versão atual de origem
+	err := imp.each("policies.json", func() interface{} { return &orgexport.PolicyRecord{} }, func(record interface{}) error {
+		p := record.(*orgexport.PolicyRecord)
+		ownerID, err := imp.user(p.OwnerID, "policy "+p.ReferenceCode)
+		if err != nil {
+			return err
+		}
+		policy := models.Policy{
+			ID:                  uuid.New(),
+			OrganizationID:      imp.orgID,
+			ReferenceCode:       p.ReferenceCode,
+			Title:               p.Title,
+			Description:         p.Description,
+			OwnerID:             ownerID,
+			Status:              models.PolicyStatus(p.Status),
+			ReviewCadenceMonths: p.ReviewCadenceMonths,
+			NextReviewDate:      p.NextReviewDate,
+			CreatedAt:           p.CreatedAt,
+			UpdatedAt:           p.UpdatedAt,
+		}
+		if err := imp.tx.Create(&policy).Error; err != nil {
+			return fmt.Errorf("failed to import policy %s: %w", p.ReferenceCode, err)
+		}
+		imp.policies[p.ID] = policy.ID
+		imp.result.Imported["policies"]++
+		if p.CurrentVersionID != nil {
+			currentVersions[policy.ID] = *p.CurrentVersionID
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = imp.each("policy_versions.json", func() interface{} { return &orgexport.PolicyVersionRecord{} }, func(record interface{}) error {
+		v := record.(*orgexport.PolicyVersionRecord)
+		policyID, ok := imp.policies[v.PolicyID]
+		if !ok {
+			return fmt.Errorf("policy version %s references policy %s, which is not in the export", v.ID, v.PolicyID)
+		}
+		uploadedByID, err := imp.user(v.UploadedByID, "policy version "+v.ID.String())
+		if err != nil {
+			return err
+		}
+		version := models.PolicyVersion{
+			ID:             uuid.New(),
+			PolicyID:       policyID,
+			VersionNumber:  v.VersionNumber,
+			FileURL:        v.FileURL,
+			FileName:       v.FileName,
+			Changelog:      v.Changelog,
+			UploadedByID:   uploadedByID,
+			ApprovalStatus: models.ApprovalStatus(v.ApprovalStatus),
+			ApproverID:     imp.optionalUser(v.ApproverID),
+			DecidedAt:      v.DecidedAt,
+			CreatedAt:      v.CreatedAt,
+			UpdatedAt:      v.UpdatedAt,
+		}
+		if err := imp.tx.Create(&version).Error; err != nil {
+			return fmt.Errorf("failed to import policy version %s: %w", v.ID, err)
+		}
+		imp.versions[v.ID] = version.ID
+		imp.result.Imported["policy_versions"]++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for policyID, oldVersionID := range currentVersions {
+		versionID, ok := imp.versions[oldVersionID]
+		if !ok {
+			imp.result.warn("policy %s: current version %s is not in the export", policyID, oldVersionID)
+			continue
+		}
+		if err := imp.tx.Model(&models.Policy{}).Where("id = ?", policyID).UpdateColumn("current_version_id", versionID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *importer) importRisks() error {
+	return imp.each("risks.json", func() interface{} { return &orgexport.RiskRecord{} }, func(record interface{}) error {
+		r := record.(*orgexport.RiskRecord)
+		ownerID, err := imp.user(r.OwnerID, "risk "+r.ReferenceCode)
+		if err != nil {
+			return err
+		}
+		if r.StatusCode != nil || r.OwnerTeamID != nil {
+			imp.result.warn("risk %s: custom status and owner team are not imported", r.ReferenceCode)
+		}
+		risk := models.Risk{
+			ID:             uuid.New(),
+			OrganizationID: imp.orgID,
+			ReferenceCode:  r.ReferenceCode,
+			Title:          r.Title,
+			Description:    r.Description,
+			Category:       models.RiskCategory(r.Category),
+			Impact:         models.RiskImpact(r.Impact),
+			Probability:    models.RiskProbability(r.Probability),
+			RiskLevel:      r.RiskLevel,
+			Status:         models.RiskStatus(r.Status),
+			OwnerID:        ownerID,
+			CreatedAt:      r.CreatedAt,
+			UpdatedAt:      r.UpdatedAt,
+		}
+		if err := imp.tx.Create(&risk).Error; err != nil {
+			return fmt.Errorf("failed to import risk %s: %w", r.ReferenceCode, err)
+		}
+		imp.result.Imported["risks"]++
+		return nil
+	})
+}
+
+// control encontra o controle de mesmo identificador no framework semeado de mesmo nome desta instância.
+func (imp *importer) control(frameworkName, controlID string) (*uuid.UUID, error) {
+	key := frameworkName + "|" + controlID
+	if id, ok := imp.controls[key]; ok {
+		return id, nil
+	}
+	var controls []models.AuditControl
+	err := imp.tx.Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Where("audit_frameworks.name = ? AND audit_frameworks.organization_id IS NULL AND audit_controls.control_id = ?", frameworkName, controlID).
+		Limit(1).Find(&controls).Error
+	if err != nil {
+		return nil, err
+	}
+	var id *uuid.UUID
+	if len(controls) > 0 {
+		id = &controls[0].ID
+	}
+	imp.controls[key] = id
+	return id, nil
+}
+
+func (imp *importer) importAssessments() error {
+	skipped := 0
+	err := imp.each("assessments.json", func() interface{} { return &orgexport.AssessmentRecord{} }, func(record interface{}) error {
+		a := record.(*orgexport.AssessmentRecord)
+		if a.AuditCampaignID != nil {
+			skipped++
+			return nil
+		}
+		controlID, err := imp.control(a.FrameworkName, a.ControlID)
+		if err != nil {
+			return err
+		}
+		if controlID == nil {
+			imp.result.warn("assessment %s: control %s of framework %q not found in this instance", a.ID, a.ControlID, a.FrameworkName)
+			skipped++
+			return nil
+		}
+		assessment := models.AuditAssessment{
+			ID:                      uuid.New(),
+			OrganizationID:          imp.orgID,
+			AuditControlID:          *controlID,
+			Status:                  models.AuditControlStatus(a.Status),
+			EvidenceURL:             a.EvidenceURL,
+			Score:                   a.Score,
+			AssessmentDate:          a.AssessmentDate,
+			Comments:                a.Comments,
+			ImplementationNarrative: a.ImplementationNarrative,
+			AssignedToID:            imp.optionalUser(a.AssignedToID),
+			DueDate:                 a.DueDate,
+			CreatedAt:               a.CreatedAt,
+			UpdatedAt:               a.UpdatedAt,
+		}
+		if a.PolicyID != nil {
+			if policyID, ok := imp.policies[*a.PolicyID]; ok {
+				assessment.PolicyID = &policyID
+			}
+		}
+		if err := imp.tx.Create(&assessment).Error; err != nil {
+			return fmt.Errorf("failed to import assessment %s: %w", a.ID, err)
+		}
+		imp.result.Imported["assessments"]++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if skipped > 0 {
+		imp.result.warn("%d assessments were not imported (audit campaigns are not part of the export, or the control was not found)", skipped)
+	}
+	return nil
+}
+
+// advanceSequences posiciona as sequências dos códigos de referência após os maiores códigos importados, para
+// que os próximos riscos e políticas não repitam códigos.
+func (imp *importer) advanceSequences() error {
+	tables := map[models.ReferenceEntityType]string{models.ReferenceRisk: "risks", models.ReferencePolicy: "policies"}
+	for entityType, table := range tables {
+		var codes []string
+		if err := imp.tx.Table(table).Where("organization_id = ?", imp.orgID).Pluck("reference_code", &codes).Error; err != nil {
+			return err
+		}
+		var last int64
+		for _, code := range codes {
+			parsedType, normalized, ok := models.ParseReferenceCode(code)
+			if !ok || parsedType != entityType {
+				continue
+			}
+			n, _ := strconv.ParseInt(normalized[strings.LastIndex(normalized, "-")+1:], 10, 64)
+			if n > last {
+				last = n
+			}
+		}
+		if last == 0 {
+			continue
+		}
+		err := imp.tx.Exec("INSERT INTO org_sequences (organization_id, entity_type, last_value, updated_at) VALUES (?, ?, ?, NOW()) "+
+			"ON CONFLICT (organization_id, entity_type) DO UPDATE SET last_value = GREATEST(org_sequences.last_value, EXCLUDED.last_value), updated_at = NOW()",
+			imp.orgID, entityType, last).Error
+		if err != nil {
+			return fmt.Errorf("failed to advance %s reference codes: %w", entityType, err)
+		}
+	}
+	return nil
+}
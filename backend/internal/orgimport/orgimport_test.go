@@ -0,0 +1,70 @@
+package orgimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"phoenixgrc/backend/internal/orgexport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildArchive monta um ZIP com os arquivos informados e o manifesto; checksums sobrescreve o SHA-256 listado.
+func buildArchive(t *testing.T, version int, contents map[string]string, checksums map[string]string) *bytes.Reader {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	manifest := orgexport.Manifest{FormatVersion: version, OrganizationName: "Origem"}
+	for name, content := range contents {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		sum := sha256.Sum256([]byte(content))
+		checksum := hex.EncodeToString(sum[:])
+		if override, ok := checksums[name]; ok {
+			checksum = override
+		}
+		manifest.Files = append(manifest.Files, orgexport.File{Name: name, SHA256: checksum})
+	}
+	w, err := archive.Create(orgexport.ManifestFileName)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(w).Encode(manifest))
+	require.NoError(t, archive.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func emptyDatasets() map[string]string {
+	contents := map[string]string{}
+	for _, name := range requiredFiles {
+		contents[name] = "[]\n"
+	}
+	return contents
+}
+
+// Os erros de validação acontecem antes de qualquer acesso ao banco de dados.
+func TestImportRejectsInvalidArchives(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := Import(ctx, nil, bytes.NewReader([]byte("not a zip")), 9, Options{})
+	assert.ErrorContains(t, err, "invalid export file")
+
+	r := buildArchive(t, orgexport.FormatVersion+1, emptyDatasets(), nil)
+	_, err = Import(ctx, nil, r, r.Size(), Options{})
+	assert.ErrorContains(t, err, "unsupported export format version")
+
+	r = buildArchive(t, orgexport.FormatVersion, emptyDatasets(), map[string]string{"risks.json": "00"})
+	_, err = Import(ctx, nil, r, r.Size(), Options{})
+	assert.ErrorContains(t, err, "checksum mismatch for risks.json")
+
+	contents := emptyDatasets()
+	delete(contents, "users.json")
+	r = buildArchive(t, orgexport.FormatVersion, contents, nil)
+	_, err = Import(ctx, nil, r, r.Size(), Options{})
+	assert.ErrorContains(t, err, "users.json not found")
+}
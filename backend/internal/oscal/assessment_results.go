@@ -0,0 +1,206 @@
+package oscal
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AssessmentResultsDocument é o documento JSON de resultados de avaliação OSCAL.
+type AssessmentResultsDocument struct {
+	AssessmentResults AssessmentResults `json:"assessment-results"`
+}
+
+// AssessmentResults contém um resultado por exportação.
+type AssessmentResults struct {
+	UUID     string   `json:"uuid"`
+	Metadata Metadata `json:"metadata"`
+	ImportAP ImportAP `json:"import-ap"`
+	Results  []Result `json:"results"`
+}
+
+// ImportAP referencia o plano de avaliação; o Phoenix GRC não exporta planos, então aponta para o próprio documento.
+type ImportAP struct {
+	Href string `json:"href"`
+}
+
+// Result é o resultado da avaliação de um framework.
+type Result struct {
+	UUID             string           `json:"uuid"`
+	Title            string           `json:"title"`
+	Description      string           `json:"description"`
+	Start            string           `json:"start"`
+	End              string           `json:"end,omitempty"`
+	ReviewedControls ReviewedControls `json:"reviewed-controls"`
+	Observations     []Observation    `json:"observations,omitempty"`
+	Findings         []Finding        `json:"findings,omitempty"`
+}
+
+// ReviewedControls lista os controles considerados na avaliação.
+type ReviewedControls struct {
+	ControlSelections []ControlSelection `json:"control-selections"`
+}
+
+// ControlSelection inclui e exclui controles da avaliação.
+type ControlSelection struct {
+	IncludeControls []SelectedControl `json:"include-controls,omitempty"`
+	ExcludeControls []SelectedControl `json:"exclude-controls,omitempty"`
+}
+
+// SelectedControl referencia um controle pelo id OSCAL.
+type SelectedControl struct {
+	ControlID string `json:"control-id"`
+}
+
+// Observation é o que foi examinado para avaliar o controle (incluindo a evidência).
+type Observation struct {
+	UUID             string             `json:"uuid"`
+	Title            string             `json:"title,omitempty"`
+	Description      string             `json:"description"`
+	Methods          []string           `json:"methods"`
+	Collected        string             `json:"collected"`
+	RelevantEvidence []RelevantEvidence `json:"relevant-evidence,omitempty"`
+}
+
+// RelevantEvidence aponta para a evidência do controle.
+type RelevantEvidence struct {
+	Href        string `json:"href,omitempty"`
+	Description string `json:"description"`
+}
+
+// Finding é a conclusão sobre um controle.
+type Finding struct {
+	UUID                string               `json:"uuid"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Props               []Prop               `json:"props,omitempty"`
+	Target              FindingTarget        `json:"target"`
+	RelatedObservations []RelatedObservation `json:"related-observations,omitempty"`
+}
+
+// FindingTarget é o objetivo do controle avaliado e seu estado.
+type FindingTarget struct {
+	Type     string       `json:"type"`
+	TargetID string       `json:"target-id"`
+	Status   TargetStatus `json:"status"`
+}
+
+// TargetStatus é o estado do objetivo: satisfied ou not-satisfied, com o motivo (pass, fail ou other).
+type TargetStatus struct {
+	State   string `json:"state"`
+	Reason  string `json:"reason,omitempty"`
+	Remarks string `json:"remarks,omitempty"`
+}
+
+// RelatedObservation liga um finding à observação que o sustenta.
+type RelatedObservation struct {
+	ObservationUUID string `json:"observation-uuid"`
+}
+
+// AssessedControl é a avaliação de um controle a exportar.
+type AssessedControl struct {
+	AssessmentID uuid.UUID
+	ControlID    string
+	Description  string
+	Status       models.AuditControlStatus
+	Score        *int
+	AssessedAt   time.Time
+	EvidenceURL  string
+}
+
+// AssessmentResultsInput são os dados da exportação de um framework para uma organização.
+type AssessmentResultsInput struct {
+	OrganizationID   uuid.UUID
+	OrganizationName string
+	FrameworkName    string
+	GeneratedAt      time.Time
+	Controls         []AssessedControl
+}
+
+// targetStatus traduz o status da avaliação para o estado OSCAL do objetivo.
+func targetStatus(status models.AuditControlStatus) TargetStatus {
+	switch status {
+	case models.ControlStatusConformant:
+		return TargetStatus{State: "satisfied", Reason: "pass"}
+	case models.ControlStatusPartiallyConformant:
+		return TargetStatus{State: "not-satisfied", Reason: "other", Remarks: "Parcialmente conforme"}
+	case models.ControlStatusWaived:
+		return TargetStatus{State: "not-satisfied", Reason: "other", Remarks: "Dispensado por exceção aprovada"}
+	}
+	return TargetStatus{State: "not-satisfied", Reason: "fail"}
+}
+
+// BuildAssessmentResults monta os resultados de avaliação OSCAL: cada controle avaliado gera uma observação
+// (com a evidência, se houver) e um finding; controles não aplicáveis entram como excluídos da avaliação.
+func BuildAssessmentResults(input AssessmentResultsInput) AssessmentResultsDocument {
+	generatedAt := input.GeneratedAt.UTC().Format(time.RFC3339)
+	result := Result{
+		UUID:        uuid.NewString(),
+		Title:       fmt.Sprintf("Avaliação %s", input.FrameworkName),
+		Description: fmt.Sprintf("Resultados da avaliação do framework %s pela organização %s.", input.FrameworkName, input.OrganizationName),
+		Start:       generatedAt,
+		End:         generatedAt,
+	}
+	var selection ControlSelection
+	var earliest time.Time
+	for _, ctrl := range input.Controls {
+		id := ControlID(ctrl.ControlID)
+		if ctrl.Status == models.ControlStatusNotApplicable {
+			selection.ExcludeControls = append(selection.ExcludeControls, SelectedControl{ControlID: id})
+			continue
+		}
+		selection.IncludeControls = append(selection.IncludeControls, SelectedControl{ControlID: id})
+		if earliest.IsZero() || ctrl.AssessedAt.Before(earliest) {
+			earliest = ctrl.AssessedAt
+		}
+
+		observation := Observation{
+			UUID:        ctrl.AssessmentID.String(),
+			Title:       ctrl.ControlID,
+			Description: fmt.Sprintf("Avaliação do controle %s: %s", ctrl.ControlID, ctrl.Status),
+			Methods:     []string{"EXAMINE"},
+			Collected:   ctrl.AssessedAt.UTC().Format(time.RFC3339),
+		}
+		if ctrl.EvidenceURL != "" {
+			observation.RelevantEvidence = []RelevantEvidence{{Href: ctrl.EvidenceURL, Description: "Evidência registrada na avaliação"}}
+		}
+		finding := Finding{
+			UUID:        uuid.NewSHA1(ctrl.AssessmentID, []byte("finding")).String(),
+			Title:       ctrl.ControlID,
+			Description: ctrl.Description,
+			Props:       []Prop{{Name: "assessment-status", NS: Namespace, Value: string(ctrl.Status)}},
+			Target: FindingTarget{
+				Type:     "objective-id",
+				TargetID: id + "_obj",
+				Status:   targetStatus(ctrl.Status),
+			},
+			RelatedObservations: []RelatedObservation{{ObservationUUID: observation.UUID}},
+		}
+		if ctrl.Score != nil {
+			finding.Props = append(finding.Props, Prop{Name: "score", NS: Namespace, Value: strconv.Itoa(*ctrl.Score)})
+		}
+		result.Observations = append(result.Observations, observation)
+		result.Findings = append(result.Findings, finding)
+	}
+	if !earliest.IsZero() {
+		result.Start = earliest.UTC().Format(time.RFC3339)
+	}
+	result.ReviewedControls.ControlSelections = []ControlSelection{selection}
+
+	return AssessmentResultsDocument{AssessmentResults: AssessmentResults{
+		UUID: uuid.NewString(),
+		Metadata: Metadata{
+			Title:        fmt.Sprintf("%s - Resultados de Avaliação (%s)", input.OrganizationName, input.FrameworkName),
+			LastModified: generatedAt,
+			Version:      input.GeneratedAt.UTC().Format("2006-01-02"),
+			OSCALVersion: Version,
+			Parties:      []Party{{UUID: input.OrganizationID.String(), Type: "organization", Name: input.OrganizationName}},
+		},
+		ImportAP: ImportAP{Href: "#"},
+		Results:  []Result{result},
+	}}
+}
@@ -0,0 +1,90 @@
+package oscal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CatalogDocument é o documento JSON de um catálogo OSCAL.
+type CatalogDocument struct {
+	Catalog *Catalog `json:"catalog"`
+}
+
+// Catalog é um catálogo de controles (um framework).
+type Catalog struct {
+	UUID     string    `json:"uuid"`
+	Metadata Metadata  `json:"metadata"`
+	Groups   []Group   `json:"groups,omitempty"`
+	Controls []Control `json:"controls,omitempty"`
+}
+
+// Group agrupa controles (uma família).
+type Group struct {
+	ID       string    `json:"id,omitempty"`
+	Title    string    `json:"title"`
+	Groups   []Group   `json:"groups,omitempty"`
+	Controls []Control `json:"controls,omitempty"`
+}
+
+// Control é um controle do catálogo; Controls contém as melhorias (enhancements).
+type Control struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Props    []Prop    `json:"props,omitempty"`
+	Parts    []Part    `json:"parts,omitempty"`
+	Controls []Control `json:"controls,omitempty"`
+}
+
+// Part é uma parte textual do controle (ex.: "statement").
+type Part struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Prose string `json:"prose,omitempty"`
+	Parts []Part `json:"parts,omitempty"`
+}
+
+// CatalogEntry é um controle a exportar, na ordem oficial do framework.
+type CatalogEntry struct {
+	ControlID   string
+	Description string
+	Family      string
+}
+
+// BuildCatalog monta o catálogo OSCAL de um framework: cada família vira um grupo (na ordem em que
+// aparece) e controles sem família ficam no nível do catálogo. O ControlID original vai na prop "label".
+func BuildCatalog(title, version, remarks string, entries []CatalogEntry, lastModified time.Time) CatalogDocument {
+	catalog := &Catalog{
+		UUID: uuid.NewString(),
+		Metadata: Metadata{
+			Title:        title,
+			LastModified: lastModified.UTC().Format(time.RFC3339),
+			Version:      version,
+			OSCALVersion: Version,
+			Remarks:      remarks,
+		},
+	}
+	if catalog.Metadata.Version == "" {
+		catalog.Metadata.Version = "1.0"
+	}
+	groupIndex := make(map[string]int)
+	for _, entry := range entries {
+		control := Control{
+			ID:    ControlID(entry.ControlID),
+			Title: entry.Description,
+			Props: []Prop{{Name: "label", Value: entry.ControlID}},
+		}
+		if entry.Family == "" {
+			catalog.Controls = append(catalog.Controls, control)
+			continue
+		}
+		i, ok := groupIndex[entry.Family]
+		if !ok {
+			i = len(catalog.Groups)
+			groupIndex[entry.Family] = i
+			catalog.Groups = append(catalog.Groups, Group{Title: entry.Family})
+		}
+		catalog.Groups[i].Controls = append(catalog.Groups[i].Controls, control)
+	}
+	return CatalogDocument{Catalog: catalog}
+}
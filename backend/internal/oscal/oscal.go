@@ -0,0 +1,61 @@
+// Package oscal contém o subconjunto do modelo NIST OSCAL (https://pages.nist.gov/OSCAL/) usado pelo
+// Phoenix GRC: catálogos (frameworks) e resultados de avaliação (Assessment Results), em JSON.
+package oscal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Version é a versão do OSCAL declarada nos documentos exportados.
+const Version = "1.1.2"
+
+// Namespace identifica as props específicas do Phoenix GRC nos documentos exportados.
+const Namespace = "urn:phoenixgrc:oscal"
+
+// Metadata é o bloco de metadados comum aos modelos OSCAL.
+type Metadata struct {
+	Title        string  `json:"title"`
+	LastModified string  `json:"last-modified,omitempty"`
+	Version      string  `json:"version,omitempty"`
+	OSCALVersion string  `json:"oscal-version,omitempty"`
+	Remarks      string  `json:"remarks,omitempty"`
+	Parties      []Party `json:"parties,omitempty"`
+}
+
+// Party é uma organização ou pessoa referenciada no documento.
+type Party struct {
+	UUID string `json:"uuid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// Prop é uma propriedade nome/valor; props fora do vocabulário OSCAL usam Namespace.
+type Prop struct {
+	Name  string `json:"name"`
+	NS    string `json:"ns,omitempty"`
+	Value string `json:"value"`
+}
+
+// ControlID converte um identificador de controle (ex.: "A.5.15", "GV.OC-1") no token OSCAL
+// equivalente: minúsculas, com caracteres fora de letras, dígitos, '.', '-' e '_' trocados por '_',
+// começando por letra ou '_'.
+func ControlID(controlID string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(controlID)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	id := b.String()
+	if id == "" {
+		return "_"
+	}
+	if first := []rune(id)[0]; !unicode.IsLetter(first) && first != '_' {
+		id = "_" + id
+	}
+	return id
+}
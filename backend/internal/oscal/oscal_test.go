@@ -0,0 +1,67 @@
+package oscal
+
+import (
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlID(t *testing.T) {
+	assert.Equal(t, "a.5.15", ControlID("A.5.15"))
+	assert.Equal(t, "gv.oc-1", ControlID(" GV.OC-1 "))
+	assert.Equal(t, "_1.1", ControlID("1.1"))
+	assert.Equal(t, "cis_control_1", ControlID("CIS Control/1"))
+}
+
+func TestBuildCatalogGroupsByFamilyInOrder(t *testing.T) {
+	doc := BuildCatalog("Interno", "", "", []CatalogEntry{
+		{ControlID: "B-1", Description: "Primeiro", Family: "Beta"},
+		{ControlID: "A-1", Description: "Segundo", Family: "Alfa"},
+		{ControlID: "B-2", Description: "Terceiro", Family: "Beta"},
+		{ControlID: "X-1", Description: "Sem família"},
+	}, time.Now())
+	require.NotNil(t, doc.Catalog)
+	assert.Equal(t, "1.0", doc.Catalog.Metadata.Version)
+	require.Len(t, doc.Catalog.Groups, 2)
+	assert.Equal(t, "Beta", doc.Catalog.Groups[0].Title)
+	require.Len(t, doc.Catalog.Groups[0].Controls, 2)
+	assert.Equal(t, "b-2", doc.Catalog.Groups[0].Controls[1].ID)
+	assert.Equal(t, []Prop{{Name: "label", Value: "B-2"}}, doc.Catalog.Groups[0].Controls[1].Props)
+	require.Len(t, doc.Catalog.Controls, 1)
+}
+
+func TestBuildAssessmentResults(t *testing.T) {
+	assessedAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	score := 50
+	conformantID, partialID := uuid.New(), uuid.New()
+	doc := BuildAssessmentResults(AssessmentResultsInput{
+		OrganizationID:   uuid.New(),
+		OrganizationName: "Acme",
+		FrameworkName:    "ISO",
+		GeneratedAt:      assessedAt.Add(48 * time.Hour),
+		Controls: []AssessedControl{
+			{AssessmentID: conformantID, ControlID: "A.5.1", Status: models.ControlStatusConformant, AssessedAt: assessedAt, EvidenceURL: "https://evidencias/a51.pdf"},
+			{AssessmentID: partialID, ControlID: "A.5.2", Status: models.ControlStatusPartiallyConformant, Score: &score, AssessedAt: assessedAt.Add(time.Hour)},
+			{AssessmentID: uuid.New(), ControlID: "A.6.3", Status: models.ControlStatusNotApplicable, AssessedAt: assessedAt},
+		},
+	})
+	require.Len(t, doc.AssessmentResults.Results, 1)
+	result := doc.AssessmentResults.Results[0]
+	assert.Equal(t, "2026-03-10T12:00:00Z", result.Start)
+	selection := result.ReviewedControls.ControlSelections[0]
+	assert.Len(t, selection.IncludeControls, 2)
+	assert.Equal(t, []SelectedControl{{ControlID: "a.6.3"}}, selection.ExcludeControls)
+
+	require.Len(t, result.Findings, 2)
+	assert.Equal(t, TargetStatus{State: "satisfied", Reason: "pass"}, result.Findings[0].Target.Status)
+	assert.Equal(t, "not-satisfied", result.Findings[1].Target.Status.State)
+	assert.Contains(t, result.Findings[1].Props, Prop{Name: "score", NS: Namespace, Value: "50"})
+	assert.Equal(t, conformantID.String(), result.Findings[0].RelatedObservations[0].ObservationUUID)
+	require.Len(t, result.Observations[0].RelevantEvidence, 1)
+	assert.Empty(t, result.Observations[1].RelevantEvidence)
+}
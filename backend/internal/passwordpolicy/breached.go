@@ -0,0 +1,78 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PwnedPasswordsURL é o endereço da API de faixas do Pwned Passwords (Have I Been Pwned).
+const PwnedPasswordsURL = "https://api.pwnedpasswords.com/range/"
+
+// BreachChecker informa quantas vezes uma senha apareceu em vazamentos conhecidos.
+type BreachChecker interface {
+	BreachCount(ctx context.Context, password string) (int, error)
+}
+
+// DefaultBreachChecker é usado por Check nas políticas com check_breached. Nil desativa a consulta.
+var DefaultBreachChecker BreachChecker = NewPwnedPasswordsChecker(PwnedPasswordsURL)
+
+// PwnedPasswordsChecker consulta a API Pwned Passwords por k-anonimato: só os 5 primeiros caracteres do
+// SHA-1 da senha são enviados, e a comparação com os sufixos retornados é feita localmente.
+type PwnedPasswordsChecker struct {
+	RangeURL string // Prefixo da URL; os 5 caracteres do hash são acrescentados ao final
+	client   *http.Client
+}
+
+// NewPwnedPasswordsChecker cria um PwnedPasswordsChecker com timeout padrão.
+func NewPwnedPasswordsChecker(rangeURL string) *PwnedPasswordsChecker {
+	return &PwnedPasswordsChecker{RangeURL: rangeURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// BreachCount retorna o número de ocorrências da senha nos vazamentos conhecidos (0 se não aparece).
+func (p *PwnedPasswordsChecker) BreachCount(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.RangeURL+prefix, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create breached password request: %w", err)
+	}
+	// Respostas com preenchimento têm sempre o mesmo tamanho e não revelam o prefixo consultado
+	req.Header.Set("Add-Padding", "true")
+	req.Header.Set("User-Agent", "PhoenixGRC")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("breached password request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("breached password API returned status %d", resp.StatusCode)
+	}
+
+	// Cada linha é SUFIXO:OCORRÊNCIAS; as linhas de preenchimento têm 0 ocorrências
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lineSuffix, countStr, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !found || !strings.EqualFold(lineSuffix, suffix) {
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if err != nil {
+			return 0, fmt.Errorf("invalid breached password API line for suffix: %w", err)
+		}
+		return count, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read breached password API response: %w", err)
+	}
+	return 0, nil
+}
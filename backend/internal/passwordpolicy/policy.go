@@ -0,0 +1,149 @@
+// Package passwordpolicy aplica a política de senhas da organização (models.PasswordPolicy): tamanho mínimo,
+// complexidade, verificação em bases de senhas vazadas (Pwned Passwords, por k-anonimato) e validade.
+package passwordpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode"
+
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// MinLengthFloor é o menor tamanho mínimo aceito em uma política.
+	MinLengthFloor = 8
+	// MinLengthCeiling é o maior tamanho mínimo aceito em uma política.
+	MinLengthCeiling = 64
+	// MaxBytes é o limite do bcrypt: bytes além dele seriam ignorados no hash.
+	MaxBytes = 72
+	// MaxAgeDaysCeiling é a maior validade de senha aceita em uma política.
+	MaxAgeDaysCeiling = 3650
+)
+
+// Códigos das violações, estáveis para o frontend traduzir as mensagens.
+const (
+	ViolationTooShort  = "too_short"
+	ViolationTooLong   = "too_long"
+	ViolationUppercase = "missing_uppercase"
+	ViolationLowercase = "missing_lowercase"
+	ViolationDigit     = "missing_digit"
+	ViolationSymbol    = "missing_symbol"
+	ViolationBreached  = "breached"
+)
+
+// Violation é uma regra da política que a senha não atende.
+type Violation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Default retorna a política das organizações que não configuraram a sua, equivalente à regra anterior
+// (mínimo de 8 caracteres).
+func Default() models.PasswordPolicy {
+	return models.PasswordPolicy{MinLength: MinLengthFloor}
+}
+
+// CheckSettings valida os limites de uma política antes de salvá-la.
+func CheckSettings(policy models.PasswordPolicy) error {
+	if policy.MinLength < MinLengthFloor || policy.MinLength > MinLengthCeiling {
+		return fmt.Errorf("min_length must be between %d and %d", MinLengthFloor, MinLengthCeiling)
+	}
+	if policy.MaxAgeDays < 0 || policy.MaxAgeDays > MaxAgeDaysCeiling {
+		return fmt.Errorf("max_age_days must be between 0 and %d", MaxAgeDaysCeiling)
+	}
+	return nil
+}
+
+// Load retorna a política da organização, ou Default se ela não configurou uma.
+func Load(db *gorm.DB, organizationID uuid.UUID) (models.PasswordPolicy, error) {
+	var policy models.PasswordPolicy
+	err := db.Where("organization_id = ?", organizationID).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		policy = Default()
+		policy.OrganizationID = organizationID
+		return policy, nil
+	}
+	return policy, err
+}
+
+// Validate verifica as regras locais da política (tamanho e complexidade), sem consultar serviços externos.
+func Validate(policy models.PasswordPolicy, password string) []Violation {
+	var violations []Violation
+	length := len([]rune(password))
+	if length < policy.MinLength {
+		violations = append(violations, Violation{ViolationTooShort, fmt.Sprintf("Password must be at least %d characters long", policy.MinLength)})
+	}
+	if len(password) > MaxBytes {
+		violations = append(violations, Violation{ViolationTooLong, fmt.Sprintf("Password must be at most %d bytes long", MaxBytes)})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if policy.RequireUppercase && !hasUpper {
+		violations = append(violations, Violation{ViolationUppercase, "Password must contain an uppercase letter"})
+	}
+	if policy.RequireLowercase && !hasLower {
+		violations = append(violations, Violation{ViolationLowercase, "Password must contain a lowercase letter"})
+	}
+	if policy.RequireDigit && !hasDigit {
+		violations = append(violations, Violation{ViolationDigit, "Password must contain a digit"})
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		violations = append(violations, Violation{ViolationSymbol, "Password must contain a symbol"})
+	}
+	return violations
+}
+
+// Check aplica a política completa: as regras locais e, se a política pedir, a consulta de senhas vazadas.
+// A consulta só é feita quando as regras locais passam e, se o serviço estiver indisponível, a senha é
+// aceita (fail open) e a falha fica no log.
+func Check(ctx context.Context, policy models.PasswordPolicy, password string) []Violation {
+	violations := Validate(policy, password)
+	if len(violations) > 0 || !policy.CheckBreached || DefaultBreachChecker == nil {
+		return violations
+	}
+	count, err := DefaultBreachChecker.BreachCount(ctx, password)
+	if err != nil {
+		phxlog.L.Warn("Breached password check unavailable; accepting password", zap.Error(err))
+		return nil
+	}
+	if count > 0 {
+		violations = append(violations, Violation{ViolationBreached, "Password has appeared in a known data breach; choose a different one"})
+	}
+	return violations
+}
+
+// Expired indica se a senha definida em changedAt já passou da validade da política no instante informado.
+func Expired(policy models.PasswordPolicy, changedAt, now time.Time) bool {
+	if policy.MaxAgeDays <= 0 {
+		return false
+	}
+	return now.After(changedAt.AddDate(0, 0, policy.MaxAgeDays))
+}
+
+// ChangedAt retorna quando a senha do usuário foi definida; contas anteriores ao registro usam a criação.
+func ChangedAt(user *models.User) time.Time {
+	if user.PasswordChangedAt != nil {
+		return *user.PasswordChangedAt
+	}
+	return user.CreatedAt
+}
@@ -0,0 +1,122 @@
+package passwordpolicy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func codes(violations []Violation) []string {
+	var result []string
+	for _, v := range violations {
+		result = append(result, v.Code)
+	}
+	return result
+}
+
+func TestValidate(t *testing.T) {
+	strict := models.PasswordPolicy{MinLength: 12, RequireUppercase: true, RequireLowercase: true, RequireDigit: true, RequireSymbol: true}
+
+	assert.Empty(t, Validate(Default(), "senhasimples"))
+	assert.Equal(t, []string{ViolationTooShort}, codes(Validate(Default(), "curta")))
+	assert.Equal(t, []string{ViolationTooLong}, codes(Validate(Default(), strings.Repeat("a", MaxBytes+1))))
+	assert.Empty(t, Validate(strict, "Senha-Forte-2024"))
+	assert.Equal(t, []string{ViolationTooShort, ViolationUppercase, ViolationDigit, ViolationSymbol}, codes(Validate(strict, "minuscula")))
+	assert.Equal(t, []string{ViolationLowercase}, codes(Validate(strict, "SENHA-FORTE-2024")))
+	// Caracteres acentuados contam como letras, e o tamanho é em caracteres
+	assert.Empty(t, Validate(models.PasswordPolicy{MinLength: 8, RequireUppercase: true}, "Ação ção"))
+}
+
+func TestCheckSettings(t *testing.T) {
+	assert.NoError(t, CheckSettings(Default()))
+	assert.Error(t, CheckSettings(models.PasswordPolicy{MinLength: 6}))
+	assert.Error(t, CheckSettings(models.PasswordPolicy{MinLength: 8, MaxAgeDays: -1}))
+	assert.Error(t, CheckSettings(models.PasswordPolicy{MinLength: 8, MaxAgeDays: MaxAgeDaysCeiling + 1}))
+}
+
+func TestExpired(t *testing.T) {
+	changedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := models.PasswordPolicy{MaxAgeDays: 90}
+
+	assert.False(t, Expired(Default(), changedAt, changedAt.AddDate(10, 0, 0)))
+	assert.False(t, Expired(policy, changedAt, changedAt.AddDate(0, 0, 89)))
+	assert.True(t, Expired(policy, changedAt, changedAt.AddDate(0, 0, 91)))
+
+	user := &models.User{CreatedAt: changedAt}
+	assert.Equal(t, changedAt, ChangedAt(user))
+	later := changedAt.AddDate(0, 1, 0)
+	user.PasswordChangedAt = &later
+	assert.Equal(t, later, ChangedAt(user))
+}
+
+func TestPwnedPasswordsChecker(t *testing.T) {
+	// SHA-1 de "password": 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	var gotPath, gotPadding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotPadding = r.URL.Path, r.Header.Get("Add-Padding")
+		w.Write([]byte("0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:3730471\r\nFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:0\r\n"))
+	}))
+	defer server.Close()
+	checker := NewPwnedPasswordsChecker(server.URL + "/range/")
+
+	count, err := checker.BreachCount(context.Background(), "password")
+	require.NoError(t, err)
+	assert.Equal(t, 3730471, count)
+	assert.Equal(t, "/range/5BAA6", gotPath, "only the hash prefix leaves the server")
+	assert.Equal(t, "true", gotPadding)
+
+	count, err = checker.BreachCount(context.Background(), "uma senha que nunca vazou")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestPwnedPasswordsCheckerStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewPwnedPasswordsChecker(server.URL+"/").BreachCount(context.Background(), "password")
+	assert.Error(t, err)
+}
+
+type fakeBreachChecker struct {
+	count int
+	err   error
+	calls int
+}
+
+func (f *fakeBreachChecker) BreachCount(ctx context.Context, password string) (int, error) {
+	f.calls++
+	return f.count, f.err
+}
+
+func TestCheck(t *testing.T) {
+	phxlog.L = zap.NewNop()
+	previous := DefaultBreachChecker
+	t.Cleanup(func() { DefaultBreachChecker = previous })
+	policy := models.PasswordPolicy{MinLength: 8, CheckBreached: true}
+
+	breached := &fakeBreachChecker{count: 10}
+	DefaultBreachChecker = breached
+	assert.Equal(t, []string{ViolationBreached}, codes(Check(context.Background(), policy, "password123")))
+	// Sem check_breached, ou com as regras locais violadas, o serviço não é consultado
+	assert.Empty(t, Check(context.Background(), Default(), "password123"))
+	assert.Equal(t, []string{ViolationTooShort}, codes(Check(context.Background(), policy, "curta")))
+	assert.Equal(t, 1, breached.calls)
+
+	// Serviço indisponível: a senha é aceita
+	DefaultBreachChecker = &fakeBreachChecker{err: errors.New("timeout")}
+	assert.Empty(t, Check(context.Background(), policy, "password123"))
+}
@@ -0,0 +1,114 @@
+package pdfdoc
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color é uma cor RGB com componentes de 0 a 1.
+type Color struct {
+	R, G, B float64
+}
+
+// Cores usadas nos gráficos.
+var (
+	Black     = Color{0, 0, 0}
+	White     = Color{1, 1, 1}
+	Gray      = Color{0.6, 0.6, 0.6}
+	LightGray = Color{0.9, 0.9, 0.9}
+)
+
+// ParseHexColor converte #RRGGBB em Color.
+func ParseHexColor(hex string) (Color, bool) {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(hex) != 6 {
+		return Color{}, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, false
+	}
+	return Color{float64(v>>16&0xff) / 255, float64(v>>8&0xff) / 255, float64(v&0xff) / 255}, true
+}
+
+// Mix retorna a cor misturada com other na proporção t (0 = c, 1 = other); útil para tons mais claros.
+func (c Color) Mix(other Color, t float64) Color {
+	return Color{c.R + (other.R-c.R)*t, c.G + (other.G-c.G)*t, c.B + (other.B-c.B)*t}
+}
+
+func (c Color) fill() string   { return fmt.Sprintf("%.3f %.3f %.3f rg", c.R, c.G, c.B) }
+func (c Color) stroke() string { return fmt.Sprintf("%.3f %.3f %.3f RG", c.R, c.G, c.B) }
+
+// Canvas desenha em uma área reservada da página. As coordenadas são em pontos, relativas ao canto superior
+// esquerdo da área (x a partir da margem esquerda, y crescendo para baixo).
+type Canvas struct {
+	page *bytes.Buffer
+	top  float64 // Distância do topo da página até a área
+}
+
+func (cv *Canvas) px(x float64) float64 { return Margin + x }
+func (cv *Canvas) py(y float64) float64 { return PageHeight - cv.top - y }
+
+// Block reserva height pontos na página (quebrando a página se preciso) e chama draw para desenhar na área.
+func (d *Document) Block(height float64, draw func(cv *Canvas)) {
+	page := d.ensure(height)
+	draw(&Canvas{page: page, top: d.y})
+	d.y += height
+}
+
+// Rect preenche um retângulo.
+func (cv *Canvas) Rect(x, y, w, h float64, fill Color) {
+	fmt.Fprintf(cv.page, "q %s %.2f %.2f %.2f %.2f re f Q\n", fill.fill(), cv.px(x), cv.py(y+h), w, h)
+}
+
+// StrokeRect desenha o contorno de um retângulo.
+func (cv *Canvas) StrokeRect(x, y, w, h, width float64, color Color) {
+	fmt.Fprintf(cv.page, "q %.2f w %s %.2f %.2f %.2f %.2f re S Q\n", width, color.stroke(), cv.px(x), cv.py(y+h), w, h)
+}
+
+// Polyline desenha uma linha ligando os pontos (x, y) em sequência.
+func (cv *Canvas) Polyline(points [][2]float64, width float64, color Color) {
+	if len(points) < 2 {
+		return
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "q %.2f w 1 J 1 j %s %.2f %.2f m", width, color.stroke(), cv.px(points[0][0]), cv.py(points[0][1]))
+	for _, p := range points[1:] {
+		fmt.Fprintf(&sb, " %.2f %.2f l", cv.px(p[0]), cv.py(p[1]))
+	}
+	sb.WriteString(" S Q\n")
+	cv.page.WriteString(sb.String())
+}
+
+// Line desenha um segmento de reta.
+func (cv *Canvas) Line(x1, y1, x2, y2, width float64, color Color) {
+	cv.Polyline([][2]float64{{x1, y1}, {x2, y2}}, width, color)
+}
+
+// Arc desenha um arco de circunferência com centro (cx, cy) e raio r, de from a to graus (0 = direita,
+// sentido anti-horário, como no círculo trigonométrico).
+func (cv *Canvas) Arc(cx, cy, r, from, to, width float64, color Color) {
+	steps := int(math.Ceil(math.Abs(to-from)/5)) + 1
+	points := make([][2]float64, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		angle := (from + (to-from)*float64(i)/float64(steps)) * math.Pi / 180
+		points = append(points, [2]float64{cx + r*math.Cos(angle), cy - r*math.Sin(angle)})
+	}
+	cv.Polyline(points, width, color)
+}
+
+// Text escreve uma linha de texto com o topo em y. align é "left", "center" (x é o centro) ou "right"
+// (x é a borda direita).
+func (cv *Canvas) Text(x, y float64, text string, font Font, size float64, color Color, align string) {
+	switch align {
+	case "center":
+		x -= TextWidth(text, font, size) / 2
+	case "right":
+		x -= TextWidth(text, font, size)
+	}
+	fmt.Fprintf(cv.page, "q %s BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET Q\n", color.fill(), font.resourceName(), size,
+		cv.px(x), cv.py(y+size*0.8), escape(encode(text)))
+}
@@ -0,0 +1,356 @@
+// Package pdfdoc gera documentos PDF simples (títulos, parágrafos, campos, tabelas, linhas, imagens e
+// gráficos vetoriais) sem dependências externas. Usa as fontes padrão Helvetica com codificação WinAnsi,
+// suficiente para textos em português e espanhol, e embute imagens como JPEG (DCTDecode). O layout é em
+// fluxo: cada chamada acrescenta conteúdo abaixo do anterior e quebra a página quando necessário.
+package pdfdoc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"strings"
+	"time"
+)
+
+// Dimensões da página A4 e margens, em pontos.
+const (
+	PageWidth    = 595.28
+	PageHeight   = 841.89
+	Margin       = 50.0
+	footerHeight = 30.0
+)
+
+// ContentWidth é a largura útil entre as margens.
+const ContentWidth = PageWidth - 2*Margin
+
+// Font é uma das fontes padrão disponíveis no documento.
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+func (f Font) resourceName() string {
+	if f == Bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+type embeddedImage struct {
+	data          []byte
+	width, height int
+	gray          bool
+}
+
+// Document é um PDF em construção.
+type Document struct {
+	Title  string // Metadado /Title
+	Footer string // Texto do rodapé de todas as páginas; o número da página é acrescentado
+
+	pages  []*bytes.Buffer
+	images []embeddedImage
+	y      float64 // Distância do topo da página até a próxima linha
+}
+
+// New cria um documento vazio com o título informado.
+func New(title string) *Document {
+	return &Document{Title: title}
+}
+
+// NewPage inicia uma nova página.
+func (d *Document) NewPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = Margin
+}
+
+// ensure garante espaço vertical para height na página corrente, quebrando a página se preciso.
+func (d *Document) ensure(height float64) *bytes.Buffer {
+	if !d.fits(height) {
+		d.NewPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// fits indica se height cabe na página corrente.
+func (d *Document) fits(height float64) bool {
+	return len(d.pages) > 0 && d.y+height <= PageHeight-Margin-footerHeight
+}
+
+// Spacer avança verticalmente height pontos.
+func (d *Document) Spacer(height float64) {
+	d.ensure(0)
+	d.y += height
+}
+
+// Text escreve o texto na fonte e tamanho informados, quebrando linhas para caber em width a partir de x
+// (relativo à margem esquerda). Quebras de linha no texto são respeitadas.
+func (d *Document) Text(text string, font Font, size, x, width float64) {
+	lineHeight := size * 1.35
+	for _, line := range Wrap(text, font, size, width) {
+		page := d.ensure(lineHeight)
+		baseline := PageHeight - d.y - size
+		fmt.Fprintf(page, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font.resourceName(), size, Margin+x, baseline, escape(encode(line)))
+		d.y += lineHeight
+	}
+}
+
+// Heading escreve o título principal do documento.
+func (d *Document) Heading(text string) {
+	d.Text(text, Bold, 18, 0, ContentWidth)
+	d.Spacer(6)
+}
+
+// Section escreve o título de uma seção, sublinhado. Evita deixar o título sozinho no fim da página.
+func (d *Document) Section(text string) {
+	d.ensure(60)
+	d.Spacer(8)
+	d.Text(text, Bold, 13, 0, ContentWidth)
+	d.Rule()
+	d.Spacer(4)
+}
+
+// Paragraph escreve um parágrafo em fonte regular de 10 pontos.
+func (d *Document) Paragraph(text string) {
+	d.Text(text, Regular, 10, 0, ContentWidth)
+	d.Spacer(4)
+}
+
+// Field escreve um par rótulo/valor em duas colunas; o valor quebra linhas na coluna da direita.
+func (d *Document) Field(label, value string) {
+	const labelWidth = 140.0
+	if strings.TrimSpace(value) == "" {
+		value = "-"
+	}
+	d.ensure(14)
+	startPage, startY := len(d.pages), d.y
+	d.Text(label, Bold, 10, 0, labelWidth-8)
+	labelPage, labelY := len(d.pages), d.y
+	if labelPage == startPage {
+		d.y = startY // O valor começa na mesma linha do rótulo
+	}
+	d.Text(value, Regular, 10, labelWidth, ContentWidth-labelWidth)
+	if len(d.pages) == labelPage && labelY > d.y {
+		d.y = labelY
+	}
+}
+
+// Table escreve uma tabela com cabeçalho em negrito. widths são as larguras relativas das colunas (vazias =
+// colunas iguais), distribuídas em ContentWidth. As células quebram linhas na sua coluna; uma linha não é
+// dividida entre páginas e o cabeçalho é repetido no topo de cada página da tabela.
+func (d *Document) Table(header []string, widths []float64, rows [][]string) {
+	const size, padding = 9.0, 6.0
+	lineHeight := size * 1.35
+	columns := make([]float64, len(header))
+	total := 0.0
+	for i := range columns {
+		columns[i] = 1
+		if i < len(widths) && widths[i] > 0 {
+			columns[i] = widths[i]
+		}
+		total += columns[i]
+	}
+	for i := range columns {
+		columns[i] = columns[i] / total * ContentWidth
+	}
+
+	// measure quebra as células da linha e retorna a altura ocupada.
+	measure := func(cells []string, font Font) ([][]string, float64) {
+		wrapped := make([][]string, len(columns))
+		lines := 1
+		for i := range columns {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			wrapped[i] = Wrap(cell, font, size, columns[i]-padding)
+			if len(wrapped[i]) > lines {
+				lines = len(wrapped[i])
+			}
+		}
+		return wrapped, float64(lines)*lineHeight + 3
+	}
+	draw := func(wrapped [][]string, font Font, height float64) {
+		page := d.ensure(height)
+		x := 0.0
+		for i, cellLines := range wrapped {
+			for j, line := range cellLines {
+				baseline := PageHeight - d.y - size - float64(j)*lineHeight
+				fmt.Fprintf(page, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font.resourceName(), size, Margin+x, baseline, escape(encode(line)))
+			}
+			x += columns[i]
+		}
+		d.y += height
+	}
+	headerCells, headerHeight := measure(header, Bold)
+	drawHeader := func() {
+		draw(headerCells, Bold, headerHeight)
+		d.Rule()
+	}
+
+	d.ensure(headerHeight + 6 + lineHeight)
+	drawHeader()
+	for _, cells := range rows {
+		wrapped, height := measure(cells, Regular)
+		if !d.fits(height) {
+			d.NewPage()
+			drawHeader()
+		}
+		draw(wrapped, Regular, height)
+	}
+	d.Spacer(4)
+}
+
+// Rule desenha uma linha horizontal entre as margens.
+func (d *Document) Rule() {
+	page := d.ensure(6)
+	y := PageHeight - d.y - 2
+	fmt.Fprintf(page, "0.6 w 0.6 G %.2f %.2f m %.2f %.2f l S 0 G\n", Margin, y, PageWidth-Margin, y)
+	d.y += 6
+}
+
+// Image desenha a imagem reduzida para caber em maxWidth x maxHeight pontos (mantendo a proporção) a partir
+// de x, com a legenda opcional ao lado. A imagem é reamostrada para no máximo o dobro do tamanho exibido.
+func (d *Document) Image(img image.Image, x, maxWidth, maxHeight float64, caption string) error {
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return fmt.Errorf("empty image")
+	}
+	scale := maxWidth / float64(bounds.Dx())
+	if s := maxHeight / float64(bounds.Dy()); s < scale {
+		scale = s
+	}
+	if scale > 1 {
+		scale = 1
+	}
+	w, h := float64(bounds.Dx())*scale, float64(bounds.Dy())*scale
+
+	resized := Resize(img, int(w*2)+1, int(h*2)+1)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 80}); err != nil {
+		return fmt.Errorf("failed to encode image: %w", err)
+	}
+	_, gray := resized.(*image.Gray)
+	rb := resized.Bounds()
+	d.images = append(d.images, embeddedImage{data: buf.Bytes(), width: rb.Dx(), height: rb.Dy(), gray: gray})
+
+	page := d.ensure(h + 6)
+	top := d.y
+	fmt.Fprintf(page, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", w, h, Margin+x, PageHeight-top-h, len(d.images))
+	if caption != "" {
+		d.Text(caption, Regular, 9, x+w+10, ContentWidth-x-w-10)
+	}
+	if d.y < top+h+6 {
+		d.y = top + h + 6
+	}
+	return nil
+}
+
+// Resize reduz a imagem (vizinho mais próximo) para caber em maxW x maxH pixels. Imagens menores são
+// retornadas sem alteração.
+func Resize(img image.Image, maxW, maxH int) image.Image {
+	b := img.Bounds()
+	if b.Dx() <= maxW && b.Dy() <= maxH {
+		return img
+	}
+	scale := float64(maxW) / float64(b.Dx())
+	if s := float64(maxH) / float64(b.Dy()); s < scale {
+		scale = s
+	}
+	w, h := int(float64(b.Dx())*scale), int(float64(b.Dy())*scale)
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		sy := b.Min.Y + y*b.Dy()/h
+		for x := 0; x < w; x++ {
+			dst.Set(x, y, img.At(b.Min.X+x*b.Dx()/w, sy))
+		}
+	}
+	return dst
+}
+
+// Bytes finaliza o documento (rodapés e numeração de páginas) e retorna o PDF.
+func (d *Document) Bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		d.NewPage()
+	}
+	var out bytes.Buffer
+	var offsets []int
+	startObject := func() int {
+		offsets = append(offsets, out.Len())
+		return len(offsets)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Numeração fixa: 1 catálogo, 2 árvore de páginas, 3 e 4 fontes, 5 metadados, depois imagens e páginas.
+	const firstImage = 6
+	firstPage := firstImage + len(d.images)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	startObject()
+	out.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	startObject()
+	fmt.Fprintf(&out, "2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(d.pages))
+	startObject()
+	out.WriteString("3 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\nendobj\n")
+	startObject()
+	out.WriteString("4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\nendobj\n")
+	startObject()
+	fmt.Fprintf(&out, "5 0 obj\n<< /Title (%s) /Producer (Phoenix GRC) /CreationDate (D:%s) >>\nendobj\n",
+		escape(encode(d.Title)), time.Now().UTC().Format("20060102150405Z"))
+
+	var xobjects strings.Builder
+	for i, img := range d.images {
+		n := startObject()
+		colorSpace := "/DeviceRGB"
+		if img.gray {
+			colorSpace = "/DeviceGray"
+		}
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>\nstream\n",
+			n, img.width, img.height, colorSpace, len(img.data))
+		out.Write(img.data)
+		out.WriteString("\nendstream\nendobj\n")
+		fmt.Fprintf(&xobjects, " /Im%d %d 0 R", i+1, n)
+	}
+	resources := "<< /Font << /F1 3 0 R /F2 4 0 R >>"
+	if xobjects.Len() > 0 {
+		resources += " /XObject <<" + xobjects.String() + " >>"
+	}
+	resources += " >>"
+
+	for i, page := range d.pages {
+		content := page.Bytes()
+		footer := fmt.Sprintf("Página %d de %d", i+1, len(d.pages))
+		if d.Footer != "" {
+			footer = d.Footer + " - " + footer
+		}
+		content = append(content, fmt.Sprintf("0.4 g BT /F1 8 Tf %.2f %.2f Td (%s) Tj ET 0 g\n", Margin, Margin/2, escape(encode(footer)))...)
+
+		n := startObject()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources %s /Contents %d 0 R >>\nendobj\n",
+			n, PageWidth, PageHeight, resources, n+1)
+		startObject()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Length %d >>\nstream\n", n+1, len(content))
+		out.Write(content)
+		out.WriteString("endstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes(), nil
+}
@@ -0,0 +1,115 @@
+package pdfdoc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	lines := Wrap("O controle de acesso é revisado trimestralmente pela equipe de segurança", Regular, 10, 150)
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, TextWidth(line, Regular, 10), 150.0, line)
+	}
+	assert.Equal(t, "O controle de acesso é revisado trimestralmente pela equipe de segurança", strings.Join(lines, " "))
+
+	assert.Equal(t, []string{"linha 1", "", "linha 3"}, Wrap("linha 1\n\nlinha 3", Regular, 10, 500))
+
+	long := strings.Repeat("x", 200)
+	broken := Wrap(long, Bold, 10, 100)
+	assert.Greater(t, len(broken), 1)
+	assert.Equal(t, long, strings.Join(broken, ""))
+}
+
+func TestEncodeAndEscape(t *testing.T) {
+	assert.Equal(t, []byte{'A', 0xE7, 0xE3, 'o', ' ', 0x96, ' ', '?'}, encode("Ação – 漢"))
+	assert.Equal(t, `\(a\\b\)`, escape([]byte(`(a\b)`)))
+}
+
+// TestDocumentStructure verifica que a tabela xref aponta para os objetos e que o conteúdo é paginado.
+func TestDocumentStructure(t *testing.T) {
+	doc := New("Dossiê (teste)")
+	doc.Footer = "Confidencial"
+	doc.Heading("Dossiê do Controle AC-1")
+	doc.Section("Avaliação")
+	doc.Field("Status", "Conforme")
+	for i := 0; i < 80; i++ {
+		doc.Paragraph(fmt.Sprintf("Parágrafo %d com texto suficiente para ocupar uma linha inteira do documento gerado.", i))
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for x := 0; x < 400; x++ {
+		img.Set(x, 100, color.RGBA{R: 255, A: 255})
+	}
+	require.NoError(t, doc.Image(img, 0, 120, 90, "evidencia.png"))
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xrefOffset, _ := strconv.Atoi(string(startxref[1]))
+	require.True(t, bytes.HasPrefix(out[xrefOffset:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xrefOffset:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d offset", i+1)
+	}
+
+	count := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(out)
+	pages, _ := strconv.Atoi(string(count[1]))
+	assert.Greater(t, pages, 1, "long content breaks into pages")
+	assert.Contains(t, string(out), fmt.Sprintf("(Confidencial - P\xe1gina %d de %d)", pages, pages))
+	assert.Contains(t, string(out), "/Title (Dossi\xea \\(teste\\))")
+	assert.Contains(t, string(out), "/Filter /DCTDecode")
+}
+
+func TestTableRepeatsHeaderOnEachPage(t *testing.T) {
+	doc := New("Tabela")
+	rows := make([][]string, 120)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("RISK-%04d", i+1), "Título do risco com texto longo o bastante para quebrar a linha na coluna", "Alto"}
+	}
+	doc.Table([]string{"Código", "Título", "Nível"}, []float64{1, 4, 1}, rows)
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	count := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(out)
+	pages, _ := strconv.Atoi(string(count[1]))
+	require.Greater(t, pages, 1)
+	assert.Equal(t, pages, strings.Count(string(out), "(C\xf3digo) Tj"), "um cabeçalho por página")
+	assert.Contains(t, string(out), "(RISK-0120) Tj")
+}
+
+func TestParseHexColorAndCanvas(t *testing.T) {
+	c, ok := ParseHexColor("#FF8000")
+	require.True(t, ok)
+	assert.Equal(t, Color{1, 128.0 / 255, 0}, c)
+	_, ok = ParseHexColor("#F80")
+	assert.False(t, ok)
+	assert.Equal(t, Color{0.5, 0.5, 0.5}, Black.Mix(White, 0.5))
+
+	doc := New("Gráficos")
+	doc.Block(100, func(cv *Canvas) {
+		cv.Rect(0, 0, 50, 20, c)
+		cv.Arc(100, 50, 30, 180, 0, 8, Gray)
+		cv.Text(10, 30, "Centro", Bold, 10, Black, "center")
+	})
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+	// Topo da área em y = Margin: o retângulo de 20 pontos começa 70 pontos abaixo do topo da página
+	assert.Contains(t, string(out), fmt.Sprintf("1.000 0.502 0.000 rg %.2f %.2f 50.00 20.00 re f", Margin, PageHeight-Margin-20))
+	assert.Regexp(t, `\d+\.\d+ \d+\.\d+ l S Q`, string(out))
+}
@@ -0,0 +1,131 @@
+package pdfdoc
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// helveticaWidths são as larguras (em milésimos do tamanho da fonte) dos caracteres ASCII 32-126 da
+// Helvetica, conforme as métricas AFM padrão.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // espaço a /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0 a ?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @ a O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P a _
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // ` a o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p a ~
+}
+
+// winAnsiExtras mapeia os caracteres da faixa 0x80-0x9F do WinAnsi (cp1252) que diferem do Latin-1.
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encode converte o texto para WinAnsi; caracteres sem representação viram '?'.
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r < 0x20:
+			continue
+		case r < 0x7F || (r >= 0xA0 && r <= 0xFF):
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsiExtras[r]; ok {
+				out = append(out, b)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+// escape escapa os delimitadores de strings literais do PDF.
+func escape(b []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for _, c := range b {
+		switch c {
+		case '\\', '(', ')':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// TextWidth é a largura do texto em pontos. Caracteres acentuados usam a largura média das letras, e o
+// negrito é estimado 8% mais largo que o regular (suficiente para a quebra de linhas).
+func TextWidth(s string, font Font, size float64) float64 {
+	total := 0
+	for _, c := range encode(s) {
+		if c >= 32 && c <= 126 {
+			total += helveticaWidths[c-32]
+		} else {
+			total += 600
+		}
+	}
+	width := float64(total) * size / 1000
+	if font == Bold {
+		width *= 1.08
+	}
+	return width
+}
+
+// Wrap quebra o texto em linhas que cabem em width, respeitando as quebras de linha existentes.
+// Palavras maiores que a linha são quebradas por caractere.
+func Wrap(text string, font Font, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		current := ""
+		for _, word := range words {
+			candidate := word
+			if current != "" {
+				candidate = current + " " + word
+			}
+			if TextWidth(candidate, font, size) <= width {
+				current = candidate
+				continue
+			}
+			if current != "" {
+				lines = append(lines, current)
+			}
+			current = word
+			for TextWidth(current, font, size) > width && utf8.RuneCountInString(current) > 1 {
+				split := fitRunes(current, font, size, width)
+				lines = append(lines, current[:split])
+				current = current[split:]
+			}
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// fitRunes retorna o índice (em bytes) do maior prefixo do texto que cabe em width, com ao menos um caractere.
+func fitRunes(s string, font Font, size, width float64) int {
+	end := 0
+	for i, r := range s {
+		next := i + utf8.RuneLen(r)
+		if end > 0 && TextWidth(s[:next], font, size) > width {
+			break
+		}
+		end = next
+	}
+	return end
+}
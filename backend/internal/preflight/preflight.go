@@ -0,0 +1,150 @@
+// Package preflight estima o impacto de operações em lote (registros afetados, volume de notificações
+// e consumo da cota diária) e emite o token de confirmação exigido para operações acima do limite.
+package preflight
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenTTL é a validade do token de confirmação.
+const TokenTTL = 15 * time.Minute
+
+// QuotaWarnPercent é o percentual da cota diária a partir do qual a estimativa inclui um aviso.
+const QuotaWarnPercent = 80
+
+var (
+	ErrTokenMissing = errors.New("confirmation token is required")
+	ErrTokenInvalid = errors.New("confirmation token is invalid for this operation")
+	ErrTokenExpired = errors.New("confirmation token has expired")
+)
+
+// Scope vincula o token à organização, ao usuário, à operação e ao conteúdo exato da requisição.
+type Scope struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Operation      string
+	Fingerprint    string
+}
+
+// QuotaImpact é o consumo da cota diária de notificações antes e depois da operação.
+type QuotaImpact struct {
+	DailyLimit     int64   `json:"daily_limit"`
+	UsedLast24h    int64   `json:"used_last_24h"`
+	AfterOperation int64   `json:"after_operation"`
+	PercentAfter   float64 `json:"percent_after"`
+	Exceeded       bool    `json:"exceeded"`
+}
+
+// NewQuotaImpact calcula o impacto na cota; retorna nil quando não há cota configurada (limit <= 0).
+func NewQuotaImpact(limit, used, notifications int64) *QuotaImpact {
+	if limit <= 0 {
+		return nil
+	}
+	after := used + notifications
+	return &QuotaImpact{
+		DailyLimit:     limit,
+		UsedLast24h:    used,
+		AfterOperation: after,
+		PercentAfter:   float64(after) * 100 / float64(limit),
+		Exceeded:       after > limit,
+	}
+}
+
+// Estimate é o resultado da verificação prévia de uma operação em lote.
+type Estimate struct {
+	Operation             string         `json:"operation"`
+	AffectedRecords       int            `json:"affected_records"`
+	Notifications         int            `json:"notifications"`
+	Details               map[string]int `json:"details,omitempty"`
+	Quota                 *QuotaImpact   `json:"quota,omitempty"`
+	Warnings              []string       `json:"warnings"`
+	ConfirmationThreshold int            `json:"confirmation_threshold"`
+	RequiresConfirmation  bool           `json:"requires_confirmation"`
+	ConfirmToken          string         `json:"confirm_token,omitempty"`
+	ConfirmTokenExpiresAt *time.Time     `json:"confirm_token_expires_at,omitempty"`
+}
+
+// Evaluate define se a operação exige confirmação e preenche os avisos. A confirmação é exigida quando
+// os registros afetados ou as notificações atingem o limite (threshold <= 0 desativa) ou quando a cota
+// seria excedida. A cota é flexível: excedê-la gera aviso e confirmação, nunca bloqueio.
+func (e *Estimate) Evaluate(threshold int, quota *QuotaImpact) {
+	e.ConfirmationThreshold = threshold
+	e.Quota = quota
+	e.Warnings = []string{}
+	if threshold > 0 {
+		if e.AffectedRecords >= threshold {
+			e.RequiresConfirmation = true
+			e.Warnings = append(e.Warnings, fmt.Sprintf("Operation affects %d records (confirmation threshold: %d)", e.AffectedRecords, threshold))
+		}
+		if e.Notifications >= threshold {
+			e.RequiresConfirmation = true
+			e.Warnings = append(e.Warnings, fmt.Sprintf("Operation sends about %d notifications (confirmation threshold: %d)", e.Notifications, threshold))
+		}
+	}
+	if quota != nil && e.Notifications > 0 {
+		switch {
+		case quota.Exceeded:
+			e.RequiresConfirmation = true
+			e.Warnings = append(e.Warnings, fmt.Sprintf("Daily notification quota would be exceeded (%d of %d)", quota.AfterOperation, quota.DailyLimit))
+		case quota.PercentAfter >= QuotaWarnPercent:
+			e.Warnings = append(e.Warnings, fmt.Sprintf("Daily notification quota would reach %.0f%% (%d of %d)", quota.PercentAfter, quota.AfterOperation, quota.DailyLimit))
+		}
+	}
+}
+
+// Fingerprint resume o conteúdo da requisição (arquivo, parâmetros, payload) para vincular o token a ele.
+func Fingerprint(parts ...[]byte) string {
+	h := sha256.New()
+	var size [8]byte
+	for _, part := range parts {
+		binary.BigEndian.PutUint64(size[:], uint64(len(part)))
+		h.Write(size[:])
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IssueToken emite o token de confirmação ("<expiração unix>.<assinatura>") para o escopo.
+func IssueToken(key []byte, scope Scope, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(TokenTTL).Truncate(time.Second)
+	expires := expiresAt.Unix()
+	return strconv.FormatInt(expires, 10) + "." + sign(key, scope, expires), expiresAt
+}
+
+// VerifyToken valida o token para o escopo.
+func VerifyToken(key []byte, scope Scope, token string, now time.Time) error {
+	if token == "" {
+		return ErrTokenMissing
+	}
+	expiresStr, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrTokenInvalid
+	}
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil {
+		return ErrTokenInvalid
+	}
+	if !hmac.Equal([]byte(sign(key, scope, expires)), []byte(signature)) {
+		return ErrTokenInvalid
+	}
+	if now.Unix() > expires {
+		return ErrTokenExpired
+	}
+	return nil
+}
+
+func sign(key []byte, scope Scope, expires int64) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "bulk-confirm\n%s\n%s\n%s\n%s\n%d", scope.OrganizationID, scope.UserID, scope.Operation, scope.Fingerprint, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
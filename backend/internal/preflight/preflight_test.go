@@ -0,0 +1,71 @@
+package preflight
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScope() Scope {
+	return Scope{OrganizationID: uuid.New(), UserID: uuid.New(), Operation: "risk_csv_upload", Fingerprint: Fingerprint([]byte("title\nA\n"))}
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	key := []byte("secret")
+	scope := testScope()
+	now := time.Now()
+	token, expiresAt := IssueToken(key, scope, now)
+	assert.WithinDuration(t, now.Add(TokenTTL), expiresAt, time.Second)
+	require.NoError(t, VerifyToken(key, scope, token, now))
+
+	assert.ErrorIs(t, VerifyToken(key, scope, "", now), ErrTokenMissing)
+	assert.ErrorIs(t, VerifyToken(key, scope, token, now.Add(TokenTTL+time.Minute)), ErrTokenExpired)
+	assert.ErrorIs(t, VerifyToken([]byte("other"), scope, token, now), ErrTokenInvalid)
+	assert.ErrorIs(t, VerifyToken(key, scope, "garbage", now), ErrTokenInvalid)
+
+	other := scope
+	other.Fingerprint = Fingerprint([]byte("title\nB\n"))
+	assert.ErrorIs(t, VerifyToken(key, other, token, now), ErrTokenInvalid, "token must be bound to the payload")
+	other = scope
+	other.UserID = uuid.New()
+	assert.ErrorIs(t, VerifyToken(key, other, token, now), ErrTokenInvalid, "token must be bound to the user")
+}
+
+func TestFingerprintSeparatesParts(t *testing.T) {
+	assert.NotEqual(t, Fingerprint([]byte("ab"), []byte("c")), Fingerprint([]byte("a"), []byte("bc")))
+	assert.Equal(t, Fingerprint([]byte("a")), Fingerprint([]byte("a")))
+}
+
+func TestEvaluate(t *testing.T) {
+	small := Estimate{AffectedRecords: 10, Notifications: 2}
+	small.Evaluate(100, nil)
+	assert.False(t, small.RequiresConfirmation)
+	assert.Empty(t, small.Warnings)
+
+	large := Estimate{AffectedRecords: 150}
+	large.Evaluate(100, nil)
+	assert.True(t, large.RequiresConfirmation)
+	assert.Len(t, large.Warnings, 1)
+
+	disabled := Estimate{AffectedRecords: 5000, Notifications: 5000}
+	disabled.Evaluate(0, nil)
+	assert.False(t, disabled.RequiresConfirmation)
+
+	nearQuota := Estimate{Notifications: 30}
+	nearQuota.Evaluate(100, NewQuotaImpact(100, 55, 30))
+	assert.False(t, nearQuota.RequiresConfirmation)
+	assert.Len(t, nearQuota.Warnings, 1, "quota above the warning percentage only warns")
+
+	overQuota := Estimate{Notifications: 30}
+	overQuota.Evaluate(100, NewQuotaImpact(100, 80, 30))
+	assert.True(t, overQuota.RequiresConfirmation)
+	assert.True(t, overQuota.Quota.Exceeded)
+	assert.Equal(t, int64(110), overQuota.Quota.AfterOperation)
+}
+
+func TestNewQuotaImpactDisabled(t *testing.T) {
+	assert.Nil(t, NewQuotaImpact(0, 10, 10))
+}
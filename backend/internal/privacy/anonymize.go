@@ -0,0 +1,98 @@
+// Package privacy trata os dados pessoais dos usuários a pedido dos titulares e das regras de retenção
+// (LGPD/GDPR): a anonimização remove os dados que identificam a pessoa e mantém o registro, para que riscos,
+// comentários e a trilha de auditoria continuem apontando para o mesmo usuário.
+package privacy
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AnonymizedName é o nome exibido no lugar do nome dos usuários anonimizados.
+const AnonymizedName = "Usuário anonimizado"
+
+// AnonymizedEmail é o e-mail, único e não entregável, que substitui o do usuário anonimizado.
+func AnonymizedEmail(user *models.User) string {
+	return fmt.Sprintf("anonymized-%s@anonymized.invalid", user.ID)
+}
+
+// AnonymizeUser remove os dados pessoais do usuário dentro da transação tx: nome, e-mail, credenciais (senha,
+// MFA, SSO, chaves de API, sessões) e notificações. O usuário fica desativado e marcado em AnonymizedAt; o nome e o
+// e-mail também são substituídos nos resumos e detalhes da trilha de auditoria, que continuam apontando para ele.
+// Usuários já anonimizados não são alterados.
+func AnonymizeUser(tx *gorm.DB, user *models.User, now time.Time) error {
+	if user.AnonymizedAt != nil {
+		return nil
+	}
+	previousName, previousEmail := user.Name, user.Email
+	anonymizedEmail := AnonymizedEmail(user)
+
+	if _, err := auth.RevokeUserSessions(tx, user.ID, models.SessionRevokedAnonymized, nil); err != nil {
+		return err
+	}
+	for _, model := range []interface{}{&models.AuthSession{}, &models.WebAuthnCredential{}, &models.APIKey{},
+		&models.Notification{}, &models.IdempotencyKey{}, &models.EditLock{}} {
+		if err := tx.Where("user_id = ?", user.ID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.UserInvitation{}).Where("accepted_user_id = ? OR email = ?", user.ID, previousEmail).
+		Updates(map[string]interface{}{"email": anonymizedEmail, "name": ""}).Error; err != nil {
+		return err
+	}
+
+	err := tx.Model(user).Updates(map[string]interface{}{
+		"name":              AnonymizedName,
+		"email":             anonymizedEmail,
+		"password_hash":     "",
+		"sso_provider":      "",
+		"social_login_id":   "",
+		"is_active":         false,
+		"totp_secret":       "",
+		"is_totp_enabled":   false,
+		"totp_backup_codes": "",
+		"anonymized_at":     now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return scrubAuditTrail(tx, user, previousName, previousEmail, anonymizedEmail)
+}
+
+// scrubAuditTrail substitui o e-mail e o nome do usuário na trilha de auditoria da organização. O e-mail é
+// trocado em qualquer posição; o nome, só onde for o valor inteiro de um campo dos detalhes, para não alterar
+// palavras que por acaso o contenham.
+func scrubAuditTrail(tx *gorm.DB, user *models.User, previousName, previousEmail, anonymizedEmail string) error {
+	if !user.OrganizationID.Valid {
+		return nil
+	}
+	entries := tx.Model(&models.AuditTrailEntry{}).Where("organization_id = ?", user.OrganizationID.UUID)
+	if previousEmail != "" {
+		if err := entries.Session(&gorm.Session{}).
+			Where("summary LIKE ? OR details::text LIKE ?", "%"+previousEmail+"%", "%"+previousEmail+"%").
+			Updates(map[string]interface{}{
+				"summary": gorm.Expr("replace(summary, ?, ?)", previousEmail, anonymizedEmail),
+				"details": gorm.Expr("replace(details::text, ?, ?)::jsonb", previousEmail, anonymizedEmail),
+			}).Error; err != nil {
+			return err
+		}
+	}
+	if previousName != "" {
+		quotedName, _ := json.Marshal(previousName)
+		quotedPlaceholder, _ := json.Marshal(AnonymizedName)
+		if err := entries.Session(&gorm.Session{}).Where("details::text LIKE ?", "%"+string(quotedName)+"%").
+			Update("details", gorm.Expr("replace(details::text, ?, ?)::jsonb", string(quotedName), string(quotedPlaceholder))).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
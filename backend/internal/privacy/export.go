@@ -0,0 +1,90 @@
+package privacy
+
+import (
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExportProfile são os dados cadastrais do usuário, sem credenciais.
+type ExportProfile struct {
+	ID                uuid.UUID       `json:"id"`
+	OrganizationID    *uuid.UUID      `json:"organization_id,omitempty"`
+	Name              string          `json:"name"`
+	Email             string          `json:"email"`
+	Role              models.UserRole `json:"role"`
+	CustomRoleID      *uuid.UUID      `json:"custom_role_id,omitempty"`
+	IsActive          bool            `json:"is_active"`
+	IsTOTPEnabled     bool            `json:"is_totp_enabled"`
+	SSOProvider       string          `json:"sso_provider,omitempty"`
+	PasswordChangedAt *time.Time      `json:"password_changed_at,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+// ExportComment é um comentário escrito pelo usuário numa avaliação de controle.
+type ExportComment struct {
+	ID             uuid.UUID `json:"id"`
+	AuditControlID uuid.UUID `json:"audit_control_id"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ExportRisk são os metadados de um risco do qual o usuário é o responsável.
+type ExportRisk struct {
+	ID            uuid.UUID           `json:"id"`
+	ReferenceCode string              `json:"reference_code"`
+	Title         string              `json:"title"`
+	Category      models.RiskCategory `json:"category"`
+	Status        models.RiskStatus   `json:"status"`
+	RiskLevel     string              `json:"risk_level"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// DataExport é o pacote de dados pessoais do usuário entregue no pedido de acesso do titular.
+type DataExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Profile    ExportProfile   `json:"profile"`
+	Comments   []ExportComment `json:"comments"`
+	OwnedRisks []ExportRisk    `json:"owned_risks"`
+}
+
+// ExportUser monta o pacote de dados pessoais do usuário: perfil, comentários e metadados dos riscos dos quais
+// é o responsável (sem a descrição, que pertence à organização).
+func ExportUser(db *gorm.DB, user *models.User, now time.Time) (*DataExport, error) {
+	export := &DataExport{
+		ExportedAt: now,
+		Profile: ExportProfile{
+			ID:                user.ID,
+			Name:              user.Name,
+			Email:             user.Email,
+			Role:              user.Role,
+			CustomRoleID:      user.CustomRoleID,
+			IsActive:          user.IsActive,
+			IsTOTPEnabled:     user.IsTOTPEnabled,
+			SSOProvider:       user.SSOProvider,
+			PasswordChangedAt: user.PasswordChangedAt,
+			CreatedAt:         user.CreatedAt,
+			UpdatedAt:         user.UpdatedAt,
+		},
+		Comments:   []ExportComment{},
+		OwnedRisks: []ExportRisk{},
+	}
+	if user.OrganizationID.Valid {
+		export.Profile.OrganizationID = &user.OrganizationID.UUID
+	}
+	if err := db.Model(&models.AssessmentComment{}).Where("author_id = ?", user.ID).
+		Order("created_at").Find(&export.Comments).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.Risk{}).Where("owner_id = ?", user.ID).
+		Order("created_at").Find(&export.OwnedRisks).Error; err != nil {
+		return nil, err
+	}
+	return export, nil
+}
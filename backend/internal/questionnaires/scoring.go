@@ -0,0 +1,124 @@
+// Package questionnaires valida a estrutura dos questionários de segurança de fornecedores
+// e calcula a pontuação das respostas.
+package questionnaires
+
+import (
+	"fmt"
+	"strings"
+
+	"phoenixgrc/backend/internal/models"
+)
+
+// ValidateQuestion verifica a consistência de uma pergunta montada pelo administrador.
+func ValidateQuestion(q models.QuestionnaireQuestion) error {
+	if strings.TrimSpace(q.Text) == "" {
+		return fmt.Errorf("question text is required")
+	}
+	if q.Weight < 0 {
+		return fmt.Errorf("weight must not be negative")
+	}
+	switch q.Type {
+	case models.QuestionYesNo, models.QuestionText:
+		if len(q.Options) > 0 {
+			return fmt.Errorf("%s questions do not accept options", q.Type)
+		}
+	case models.QuestionSingleChoice, models.QuestionMultipleChoice:
+		if len(q.Options) < 2 {
+			return fmt.Errorf("%s questions require at least 2 options", q.Type)
+		}
+		seen := make(map[string]bool)
+		for _, opt := range q.Options {
+			if opt.Value == "" || strings.Contains(opt.Value, ",") {
+				return fmt.Errorf("option values must be non-empty and must not contain commas")
+			}
+			if seen[opt.Value] {
+				return fmt.Errorf("duplicate option value '%s'", opt.Value)
+			}
+			seen[opt.Value] = true
+			if opt.Score < 0 || opt.Score > 1 {
+				return fmt.Errorf("option '%s' score must be between 0 and 1", opt.Value)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown question type '%s'", q.Type)
+	}
+	return nil
+}
+
+// MaxScore retorna a pontuação máxima da pergunta (0 para perguntas de texto).
+func MaxScore(q models.QuestionnaireQuestion) float64 {
+	if q.Type == models.QuestionText {
+		return 0
+	}
+	return q.Weight
+}
+
+// ScoreAnswer valida os valores respondidos e retorna a pontuação obtida.
+// Uma resposta sem valores pontua zero.
+func ScoreAnswer(q models.QuestionnaireQuestion, values []string) (float64, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+	switch q.Type {
+	case models.QuestionText:
+		return 0, nil
+	case models.QuestionYesNo:
+		if len(values) != 1 {
+			return 0, fmt.Errorf("yes_no questions accept a single value")
+		}
+		switch values[0] {
+		case "yes":
+			return q.Weight, nil
+		case "no":
+			return 0, nil
+		}
+		return 0, fmt.Errorf("yes_no answers must be 'yes' or 'no'")
+	case models.QuestionSingleChoice, models.QuestionMultipleChoice:
+		if q.Type == models.QuestionSingleChoice && len(values) != 1 {
+			return 0, fmt.Errorf("single_choice questions accept a single value")
+		}
+		scores := make(map[string]float64, len(q.Options))
+		for _, opt := range q.Options {
+			scores[opt.Value] = opt.Score
+		}
+		fraction := 0.0
+		seen := make(map[string]bool)
+		for _, v := range values {
+			score, ok := scores[v]
+			if !ok {
+				return 0, fmt.Errorf("invalid option '%s'", v)
+			}
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			fraction += score
+		}
+		if fraction > 1 {
+			fraction = 1
+		}
+		return fraction * q.Weight, nil
+	}
+	return 0, fmt.Errorf("unknown question type '%s'", q.Type)
+}
+
+// EncodeValues serializa os valores de uma resposta para armazenamento.
+func EncodeValues(values []string) string {
+	return strings.Join(values, ",")
+}
+
+// DecodeValues é o inverso de EncodeValues.
+func DecodeValues(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// Percent converte a pontuação em percentual (0-100). Questionários sem perguntas pontuadas retornam 0.
+func Percent(score, maxScore float64) float64 {
+	if maxScore <= 0 {
+		return 0
+	}
+	return score * 100 / maxScore
+}
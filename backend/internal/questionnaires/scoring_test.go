@@ -0,0 +1,91 @@
+package questionnaires
+
+import (
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func choiceQuestion(t models.QuestionType) models.QuestionnaireQuestion {
+	return models.QuestionnaireQuestion{
+		Text:   "Quais controles de acesso são aplicados?",
+		Type:   t,
+		Weight: 4,
+		Options: []models.QuestionnaireOption{
+			{Value: "mfa", Label: "MFA", Score: 0.6},
+			{Value: "sso", Label: "SSO", Score: 0.6},
+			{Value: "none", Label: "Nenhum", Score: 0},
+		},
+	}
+}
+
+func TestValidateQuestion(t *testing.T) {
+	assert.NoError(t, ValidateQuestion(models.QuestionnaireQuestion{Text: "Possui ISO 27001?", Type: models.QuestionYesNo, Weight: 1}))
+	assert.NoError(t, ValidateQuestion(choiceQuestion(models.QuestionMultipleChoice)))
+
+	assert.Error(t, ValidateQuestion(models.QuestionnaireQuestion{Text: " ", Type: models.QuestionYesNo}))
+	assert.Error(t, ValidateQuestion(models.QuestionnaireQuestion{Text: "x", Type: "rating"}))
+	assert.Error(t, ValidateQuestion(models.QuestionnaireQuestion{Text: "x", Type: models.QuestionSingleChoice,
+		Options: []models.QuestionnaireOption{{Value: "a", Score: 1}}}), "choice needs 2 options")
+
+	q := choiceQuestion(models.QuestionSingleChoice)
+	q.Options[1].Value = "mfa"
+	assert.Error(t, ValidateQuestion(q), "duplicate option values")
+
+	q = choiceQuestion(models.QuestionSingleChoice)
+	q.Options[0].Score = 1.5
+	assert.Error(t, ValidateQuestion(q), "score above 1")
+
+	q = choiceQuestion(models.QuestionSingleChoice)
+	q.Options[0].Value = "a,b"
+	assert.Error(t, ValidateQuestion(q), "commas are reserved for encoding")
+}
+
+func TestScoreAnswer(t *testing.T) {
+	yesNo := models.QuestionnaireQuestion{Text: "x", Type: models.QuestionYesNo, Weight: 2}
+	score, err := ScoreAnswer(yesNo, []string{"yes"})
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, score)
+	score, err = ScoreAnswer(yesNo, []string{"no"})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, score)
+	_, err = ScoreAnswer(yesNo, []string{"maybe"})
+	assert.Error(t, err)
+
+	single := choiceQuestion(models.QuestionSingleChoice)
+	score, err = ScoreAnswer(single, []string{"mfa"})
+	require.NoError(t, err)
+	assert.InDelta(t, 2.4, score, 1e-9)
+	_, err = ScoreAnswer(single, []string{"mfa", "sso"})
+	assert.Error(t, err)
+	_, err = ScoreAnswer(single, []string{"unknown"})
+	assert.Error(t, err)
+
+	multi := choiceQuestion(models.QuestionMultipleChoice)
+	score, err = ScoreAnswer(multi, []string{"mfa", "sso", "mfa"})
+	require.NoError(t, err)
+	assert.InDelta(t, 4.0, score, 1e-9, "fraction is capped at 1 and duplicates ignored")
+
+	text := models.QuestionnaireQuestion{Text: "Descreva", Type: models.QuestionText, Weight: 5}
+	score, err = ScoreAnswer(text, []string{"livre"})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, score)
+	assert.Equal(t, 0.0, MaxScore(text))
+
+	score, err = ScoreAnswer(single, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, score)
+}
+
+func TestEncodeDecodeValues(t *testing.T) {
+	assert.Equal(t, []string{"mfa", "sso"}, DecodeValues(EncodeValues([]string{"mfa", "sso"})))
+	assert.Nil(t, DecodeValues(""))
+}
+
+func TestPercent(t *testing.T) {
+	assert.Equal(t, 75.0, Percent(3, 4))
+	assert.Equal(t, 0.0, Percent(0, 0))
+}
@@ -0,0 +1,217 @@
+// Package ratelimit limita quantas vezes uma ação é feita por chave (ex: o IP do cliente ou a conta) com um
+// balde de fichas (token bucket): cada chave tem até Limit fichas, repostas continuamente ao longo de Period.
+// Os baldes ficam em memória, por instância da API, ou no Redis, compartilhados entre as instâncias.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Policy define o tamanho do balde (Limit) e o tempo para reenchê-lo do zero (Period). Limit 0 desativa a política.
+type Policy struct {
+	Limit  int
+	Period time.Duration
+}
+
+// Enabled indica se a política limita alguma coisa.
+func (p Policy) Enabled() bool {
+	return p.Limit > 0 && p.Period > 0
+}
+
+// String formata a política como em ParsePolicy (ex: "30/15m0s").
+func (p Policy) String() string {
+	if !p.Enabled() {
+		return "off"
+	}
+	return fmt.Sprintf("%d/%s", p.Limit, p.Period)
+}
+
+// ParsePolicy lê uma política no formato "<limite>/<período>" (ex: "30/15m", "600/1m"). "0" e "off" a desativam.
+func ParsePolicy(spec string) (Policy, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "0" || strings.EqualFold(spec, "off") {
+		return Policy{}, nil
+	}
+	limitStr, periodStr, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q: use <limit>/<period>, e.g. 30/15m", spec)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+	if err != nil || limit < 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit %q: must be a non-negative integer", limitStr)
+	}
+	period, err := time.ParseDuration(strings.TrimSpace(periodStr))
+	if err != nil || period <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit period %q: use a duration such as 15m", periodStr)
+	}
+	return Policy{Limit: limit, Period: period}, nil
+}
+
+// Result é o estado do balde depois de uma tentativa, usado nos headers RateLimit-* e Retry-After.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int           // Fichas inteiras que sobraram
+	Reset      time.Duration // Até o balde encher de novo
+	RetryAfter time.Duration // Até a próxima ficha, quando a tentativa foi negada
+}
+
+// Store guarda os baldes. Take consome uma ficha da chave, se houver, e retorna o estado resultante.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error)
+}
+
+// newResult calcula o Result a partir das fichas que sobraram no balde.
+func newResult(policy Policy, tokens float64, allowed bool) Result {
+	perToken := policy.Period / time.Duration(policy.Limit)
+	result := Result{
+		Allowed:   allowed,
+		Limit:     policy.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(policy.Limit) - tokens) * float64(perToken)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+	return result
+}
+
+// refill retorna as fichas do balde depois de elapsed, sem passar de Limit.
+func refill(policy Policy, tokens float64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return tokens
+	}
+	return math.Min(float64(policy.Limit), tokens+elapsed.Seconds()*float64(policy.Limit)/policy.Period.Seconds())
+}
+
+// Limiter aplica uma política nomeada às chaves. O nome identifica os baldes no Store e a variável de ambiente
+// que substitui a política padrão (ver Configure).
+type Limiter struct {
+	name          string
+	defaultPolicy Policy // Política do código, restaurada quando a configuração deixa de substituí-la
+
+	mu     sync.RWMutex
+	policy Policy
+	now    func() time.Time
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Limiter{}
+	store      = Store(NewMemoryStore())
+	enabled    = true
+)
+
+// New cria (e registra, para Configure) o limitador name com a política padrão de limit ações por chave a cada period.
+func New(name string, limit int, period time.Duration) *Limiter {
+	policy := Policy{Limit: limit, Period: period}
+	l := &Limiter{name: name, defaultPolicy: policy, policy: policy, now: time.Now}
+	registryMu.Lock()
+	registry[name] = l
+	registryMu.Unlock()
+	return l
+}
+
+// Name retorna o nome da política do limitador.
+func (l *Limiter) Name() string {
+	return l.name
+}
+
+// Policy retorna a política em vigor.
+func (l *Limiter) Policy() Policy {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.policy
+}
+
+// SetPolicy substitui a política do limitador.
+func (l *Limiter) SetPolicy(policy Policy) {
+	l.mu.Lock()
+	l.policy = policy
+	l.mu.Unlock()
+}
+
+// Take consome uma ficha da chave. Com a limitação desativada (globalmente ou na política), tudo é permitido e
+// Result.Limit fica 0. Erros do Store são retornados junto com um Result permitido, para que o chamador decida
+// se deixa a requisição passar.
+func (l *Limiter) Take(ctx context.Context, key string) (Result, error) {
+	policy := l.Policy()
+	registryMu.RLock()
+	active, s := enabled, store
+	registryMu.RUnlock()
+	if !active || !policy.Enabled() {
+		return Result{Allowed: true}, nil
+	}
+	result, err := s.Take(ctx, l.name+":"+key, policy, l.now())
+	if err != nil {
+		return Result{Allowed: true}, err
+	}
+	return result, nil
+}
+
+// Options configura a limitação ao iniciar a API (ver router.SetupRouter).
+type Options struct {
+	Enabled bool
+	Store   Store
+	// Políticas por nome de limitador, no formato de ParsePolicy; nomes desconhecidos são um erro
+	Policies map[string]string
+}
+
+// Configure liga ou desliga a limitação, troca o Store (nil mantém o atual) e substitui as políticas informadas;
+// os limitadores fora de Policies voltam à política padrão.
+func Configure(opts Options) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	// Valida todas as políticas antes de aplicar qualquer uma
+	policies := make(map[*Limiter]Policy, len(opts.Policies))
+	for name, spec := range opts.Policies {
+		l, ok := registry[name]
+		if !ok {
+			return fmt.Errorf("unknown rate limit policy %q (known: %s)", name, strings.Join(policyNames(), ", "))
+		}
+		policy, err := ParsePolicy(spec)
+		if err != nil {
+			return fmt.Errorf("rate limit policy %q: %w", name, err)
+		}
+		policies[l] = policy
+	}
+	for _, l := range registry {
+		if policy, ok := policies[l]; ok {
+			l.SetPolicy(policy)
+		} else {
+			l.SetPolicy(l.defaultPolicy)
+		}
+	}
+	enabled = opts.Enabled
+	if opts.Store != nil {
+		store = opts.Store
+	}
+	return nil
+}
+
+// Policies retorna as políticas em vigor por nome de limitador.
+func Policies() map[string]Policy {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	policies := make(map[string]Policy, len(registry))
+	for name, l := range registry {
+		policies[name] = l.Policy()
+	}
+	return policies
+}
+
+func policyNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
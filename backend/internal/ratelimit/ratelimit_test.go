@@ -0,0 +1,182 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStoreTokenBucket confere o balde de fichas de um Store: 2 fichas por minuto, uma reposta a cada 30s.
+func testStoreTokenBucket(t *testing.T, s Store) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := Policy{Limit: 2, Period: time.Minute}
+
+	result, err := s.Take(ctx, "a", policy, now)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Limit)
+	assert.Equal(t, 1, result.Remaining)
+	assert.Equal(t, 30*time.Second, result.Reset)
+
+	result, err = s.Take(ctx, "a", policy, now)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = s.Take(ctx, "a", policy, now)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 30*time.Second, result.RetryAfter)
+	assert.Equal(t, time.Minute, result.Reset)
+
+	result, err = s.Take(ctx, "b", policy, now)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "keys are limited independently")
+
+	// Em 15s volta meia ficha: ainda não basta
+	result, err = s.Take(ctx, "a", policy, now.Add(15*time.Second))
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 15*time.Second, result.RetryAfter)
+
+	result, err = s.Take(ctx, "a", policy, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "a token is refilled every period/limit")
+	assert.Equal(t, 0, result.Remaining)
+
+	// O balde não passa do limite, por mais tempo que passe
+	result, err = s.Take(ctx, "a", policy, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	testStoreTokenBucket(t, NewMemoryStore())
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := Policy{Limit: 1, Period: time.Minute}
+	s := NewMemoryStore()
+
+	s.Take(ctx, "a", policy, now)
+	s.Take(ctx, "b", policy, now)
+	s.Take(ctx, "c", policy, now.Add(2*time.Minute))
+	assert.Len(t, s.buckets, 1)
+}
+
+func TestRedisStoreTokenBucket(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	testStoreTokenBucket(t, NewRedisStore(client, "test:"))
+	assert.True(t, mr.Exists("test:a"), "buckets are stored under the prefix")
+	assert.Greater(t, mr.TTL("test:a"), time.Duration(0), "buckets expire")
+}
+
+func TestRedisStoreUnavailable(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	mr.Close()
+
+	_, err := NewRedisStore(client, "test:").Take(context.Background(), "a", Policy{Limit: 1, Period: time.Minute}, time.Now())
+	assert.Error(t, err)
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy(" 30/15m ")
+	require.NoError(t, err)
+	assert.Equal(t, Policy{Limit: 30, Period: 15 * time.Minute}, policy)
+
+	for _, off := range []string{"0", "off", "OFF", "0/1m"} {
+		policy, err = ParsePolicy(off)
+		require.NoError(t, err, off)
+		assert.False(t, policy.Enabled(), off)
+	}
+
+	for _, invalid := range []string{"", "30", "x/1m", "-1/1m", "30/abc", "30/0s", "30/-1m"} {
+		_, err = ParsePolicy(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// restoreGlobals desfaz, ao fim do teste, as alterações feitas por Configure.
+func restoreGlobals(t *testing.T) {
+	registryMu.RLock()
+	prevStore, prevEnabled := store, enabled
+	registryMu.RUnlock()
+	t.Cleanup(func() {
+		registryMu.Lock()
+		store, enabled = prevStore, prevEnabled
+		registryMu.Unlock()
+	})
+}
+
+func TestLimiterTake(t *testing.T) {
+	restoreGlobals(t)
+	require.NoError(t, Configure(Options{Enabled: true, Store: NewMemoryStore()}))
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := New("test_take", 1, time.Minute)
+	l.now = func() time.Time { return now }
+
+	result, err := l.Take(context.Background(), "a")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	result, _ = l.Take(context.Background(), "a")
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Minute, result.RetryAfter)
+
+	// Limitadores diferentes não compartilham os baldes da mesma chave
+	other := New("test_take_other", 1, time.Minute)
+	result, _ = other.Take(context.Background(), "a")
+	assert.True(t, result.Allowed)
+
+	require.NoError(t, Configure(Options{Enabled: false}))
+	result, err = l.Take(context.Background(), "a")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "disabled rate limiting allows everything")
+	assert.Equal(t, 0, result.Limit)
+}
+
+func TestConfigurePolicies(t *testing.T) {
+	restoreGlobals(t)
+	l := New("test_configure", 30, 15*time.Minute)
+
+	require.NoError(t, Configure(Options{Enabled: true, Policies: map[string]string{"test_configure": "5/1m"}}))
+	assert.Equal(t, Policy{Limit: 5, Period: time.Minute}, l.Policy())
+	assert.Equal(t, Policy{Limit: 5, Period: time.Minute}, Policies()["test_configure"])
+
+	err := Configure(Options{Enabled: true, Policies: map[string]string{"test_configure": "10/1m", "unknown": "1/1m"}})
+	assert.ErrorContains(t, err, "unknown")
+	assert.Equal(t, Policy{Limit: 5, Period: time.Minute}, l.Policy(), "an invalid configuration changes nothing")
+
+	err = Configure(Options{Enabled: true, Policies: map[string]string{"test_configure": "abc"}})
+	assert.Error(t, err)
+
+	require.NoError(t, Configure(Options{Enabled: true, Policies: map[string]string{"test_configure": "off"}}))
+	result, err := l.Take(context.Background(), "a")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Limit, "a disabled policy sends no limit")
+}
+
+func TestConfigureRestoresDefaultPolicies(t *testing.T) {
+	restoreGlobals(t)
+	l := New("test_configure_default", 30, 15*time.Minute)
+
+	require.NoError(t, Configure(Options{Enabled: true, Policies: map[string]string{"test_configure_default": "5/1m"}}))
+	assert.Equal(t, Policy{Limit: 5, Period: time.Minute}, l.Policy())
+
+	require.NoError(t, Configure(Options{Enabled: true}))
+	assert.Equal(t, Policy{Limit: 30, Period: 15 * time.Minute}, l.Policy(), "a removed override restores the default")
+}
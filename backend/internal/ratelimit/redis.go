@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTokenBucket consome uma ficha do balde (hash com as fichas e o instante da última atualização, em ms) de
+// forma atômica. O balde expira quando estaria cheio de novo, e recriá-lo dá o mesmo resultado.
+var redisTokenBucket = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = limit
+elseif now > ts then
+	tokens = math.min(limit, tokens + (now - ts) * limit / period)
+end
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(math.max(now, ts or now)))
+redis.call("PEXPIRE", KEYS[1], math.ceil((limit - tokens) * period / limit) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore guarda os baldes no Redis, compartilhados entre as instâncias da API.
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisStore cria um Store no Redis com as chaves sob prefix (ex: "phoenixgrc:ratelimit:").
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// NewRedisStoreFromURL conecta ao Redis de url (ex: redis://:senha@localhost:6379/0) e confere a conexão.
+func NewRedisStoreFromURL(ctx context.Context, url, prefix string) (*RedisStore, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error connecting to Redis: %w", err)
+	}
+	return NewRedisStore(client, prefix), nil
+}
+
+// Take consome uma ficha do balde da chave.
+func (s *RedisStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	reply, err := redisTokenBucket.Run(ctx, s.client, []string{s.prefix + key},
+		policy.Limit, policy.Period.Milliseconds(), now.UnixMilli()).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("error updating rate limit bucket: %w", err)
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit script reply: %v", reply)
+	}
+	allowed, _ := reply[0].(int64)
+	tokensStr, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected rate limit script reply: %v", reply)
+	}
+	return newResult(policy, tokens, allowed == 1), nil
+}
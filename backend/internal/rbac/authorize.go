@@ -0,0 +1,105 @@
+package rbac
+
+import (
+	"net/http"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// customPermissionsKey guarda no contexto da requisição as permissões do papel personalizado do usuário,
+// carregadas na primeira verificação que o papel base não cobre.
+const customPermissionsKey = "rbacCustomPermissions"
+
+// CustomPermissions carrega as permissões do papel personalizado do usuário (vazio se ele não tiver um).
+func CustomPermissions(c *gin.Context) ([]Permission, error) {
+	if cached, ok := c.Get(customPermissionsKey); ok {
+		return cached.([]Permission), nil
+	}
+	permissions := []Permission{}
+	userID, ok := c.Get("userID")
+	if !ok {
+		return permissions, nil
+	}
+	var roles []models.CustomRole
+	err := database.GetDB().Model(&models.CustomRole{}).
+		Joins("JOIN users ON users.custom_role_id = custom_roles.id AND users.organization_id = custom_roles.organization_id").
+		Where("users.id = ?", userID.(uuid.UUID)).
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		for _, p := range role.PermissionList() {
+			permissions = append(permissions, Permission(p))
+		}
+	}
+	c.Set(customPermissionsKey, permissions)
+	return permissions, nil
+}
+
+// Can indica se o usuário autenticado tem a permissão, pelo papel base ou pelo papel personalizado. Falhas ao
+// carregar o papel personalizado negam a permissão.
+func Can(c *gin.Context, permission Permission) bool {
+	role, _ := c.Get("userRole")
+	if userRole, ok := role.(models.UserRole); ok && RoleHas(userRole, permission) {
+		return true
+	}
+	custom, err := CustomPermissions(c)
+	if err != nil {
+		return false
+	}
+	for _, p := range custom {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// UserCan indica se outro usuário, fora da sua requisição, tem a permissão pelo papel base ou pelo papel
+// personalizado (ex: o aprovador designado de uma ação).
+func UserCan(db *gorm.DB, user models.User, permission Permission) (bool, error) {
+	if RoleHas(user.Role, permission) {
+		return true, nil
+	}
+	if user.CustomRoleID == nil || !user.OrganizationID.Valid {
+		return false, nil
+	}
+	var role models.CustomRole
+	err := db.Where("id = ? AND organization_id = ?", *user.CustomRoleID, user.OrganizationID.UUID).First(&role).Error
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, p := range role.PermissionList() {
+		if Permission(p) == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Require verifica a permissão e, sem ela, responde 403 com a permissão exigida.
+func Require(c *gin.Context, permission Permission) bool {
+	if Can(c, permission) {
+		return true
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions", "required_permission": permission})
+	return false
+}
+
+// RequirePermission é o middleware equivalente a Require, para grupos de rotas.
+func RequirePermission(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if Require(c, permission) {
+			c.Next()
+		}
+	}
+}
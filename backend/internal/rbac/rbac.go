@@ -0,0 +1,225 @@
+// Package rbac centraliza a autorização por permissões (recurso:ação). Cada papel base (admin, manager, user)
+// tem um conjunto predefinido de permissões, que reproduz as regras anteriores às permissões, e a organização
+// pode criar papéis personalizados (models.CustomRole) com permissões adicionais para os seus usuários.
+package rbac
+
+import (
+	"strings"
+
+	"phoenixgrc/backend/internal/models"
+)
+
+// Permission é uma permissão no formato recurso:ação.
+type Permission string
+
+const (
+	RisksUpdate             Permission = "risks:update"              // Editar qualquer risco (o dono sempre edita o seu)
+	RisksDelete             Permission = "risks:delete"              // Excluir qualquer risco (o dono sempre exclui o seu)
+	RisksSubmitAcceptance   Permission = "risks:submit_acceptance"   // Submeter riscos para aceite
+	RisksManageStakeholders Permission = "risks:manage_stakeholders" // Gerenciar stakeholders de qualquer risco
+	RisksConfigure          Permission = "risks:configure"           // Configurar as categorias e a matriz de pontuação de riscos
+
+	AuditManageFrameworks Permission = "audit:manage_frameworks" // Gerenciar frameworks, coordenadores, ordem, mapeamentos e rubricas
+	AuditAssign           Permission = "audit:assign"            // Atribuir avaliações e acompanhar as de outros avaliadores
+	AuditManageCampaigns  Permission = "audit:manage_campaigns"  // Criar e editar campanhas de auditoria e avaliar em qualquer uma
+	AuditSubmitAny        Permission = "audit:submit_any"        // Avaliar controles de frameworks com coordenadores
+	AuditModerateComments Permission = "audit:moderate_comments" // Excluir comentários de outros usuários
+	AuditImportHistory    Permission = "audit:import_history"    // Importar avaliações históricas (snapshots retroativos)
+
+	AccessReviewsManage Permission = "access_reviews:manage" // Criar, preencher e encerrar campanhas de revisão de acessos
+
+	PoliciesDelete  Permission = "policies:delete"  // Mover políticas para a lixeira
+	PoliciesRestore Permission = "policies:restore" // Listar e restaurar as políticas da lixeira
+	PoliciesManage  Permission = "policies:manage"  // Criar políticas e gerenciar qualquer uma (o dono sempre gerencia a sua)
+
+	ControlTestsManage     Permission = "control_tests:manage"     // Gerenciar os testes recorrentes de controles e a capacidade dos testadores
+	ControlWaiversManage   Permission = "control_waivers:manage"   // Aprovar e revogar exceções de controles
+	EvidenceRequestsManage Permission = "evidence_requests:manage" // Solicitar e revisar evidências e gerenciar os links do portal
+	EvidenceManageAccess   Permission = "evidence:manage_access"   // Configurar a política de acesso às evidências
+
+	AssetsManage            Permission = "assets:manage"             // Gerenciar o inventário de ativos e os seus vínculos
+	BusinessProcessesManage Permission = "business_processes:manage" // Gerenciar os processos de negócio e as suas dependências
+	VendorsManage           Permission = "vendors:manage"            // Gerenciar fornecedores e os seus riscos
+	QuestionnairesManage    Permission = "questionnaires:manage"     // Gerenciar questionários e enviá-los a fornecedores
+	VulnerabilitiesManage   Permission = "vulnerabilities:manage"    // Editar, excluir e importar vulnerabilidades
+	TagsManage              Permission = "tags:manage"               // Criar, editar e excluir tags
+	SavedViewsShare         Permission = "saved_views:share"         // Compartilhar visões salvas e alterar as compartilhadas
+	EditLocksReleaseAny     Permission = "edit_locks:release_any"    // Liberar bloqueios de edição de outros usuários
+
+	IdentityProvidersManage Permission = "identity_providers:manage" // Configurar, importar e exportar provedores de identidade
+
+	UsersManage      Permission = "users:manage"       // Listar usuários e alterar papel e status
+	UsersAssignAdmin Permission = "users:assign_admin" // Conceder ou revogar o papel de admin
+	UsersAnonymize   Permission = "users:anonymize"    // Remover de forma irreversível os dados pessoais de um usuário
+	RolesManage      Permission = "roles:manage"       // Criar papéis personalizados e atribuí-los
+	TeamsManage      Permission = "teams:manage"       // Criar equipes e gerenciar os seus membros
+
+	ReportsViewRollup      Permission = "reports:view_rollup"      // Ver os relatórios consolidados das organizações filhas
+	ReportsManageExecutive Permission = "reports:manage_executive" // Gerar o relatório executivo e editar o seu modelo e logo
+	BrandingManage         Permission = "branding:manage"          // Personalizar a marca, a página de login e os e-mails da organização
+	TrustCenterManage      Permission = "trust_center:manage"      // Editar e despublicar o trust center público
+	TrustCenterPublish     Permission = "trust_center:publish"     // Aprovar e publicar o trust center público
+	ReportsManageSchedules Permission = "reports:manage_schedules" // Agendar relatórios enviados por e-mail
+	ReportsDownload        Permission = "reports:download"         // Gerar relatórios sob demanda
+
+	NotificationsManage   Permission = "notifications:manage"    // Configurar o roteamento e os filtros de notificações
+	WebhooksManage        Permission = "webhooks:manage"         // Configurar os webhooks da organização
+	SecretsManage         Permission = "secrets:manage"          // Gerenciar o cofre de credenciais e a sua política
+	RetentionManage       Permission = "retention:manage"        // Configurar a retenção de dados
+	AuditTrailView        Permission = "audit_trail:view"        // Consultar a trilha de auditoria e verificar manifestos
+	AuditTrailExport      Permission = "audit_trail:export"      // Exportar a trilha de auditoria
+	ConfigChangesView     Permission = "config_changes:view"     // Consultar o histórico de configurações
+	ConfigChangesRollback Permission = "config_changes:rollback" // Reverter alterações de configuração
+	APIUsageView          Permission = "api_usage:view"          // Consultar o uso da API da organização
+	APIKeysManage         Permission = "api_keys:manage"         // Listar e revogar as chaves de API de todos os usuários
+	FeatureFlagsInspect   Permission = "feature_flags:inspect"   // Avaliar feature flags para outros usuários
+	AdminActionsView      Permission = "admin_actions:view"      // Acompanhar as ações que exigem segunda aprovação
+	AdminActionsApprove   Permission = "admin_actions:approve"   // Aprovar ações como segundo admin
+
+	SecurityManage Permission = "security:manage" // Configurar as políticas de segurança da organização (ex: senhas)
+	OrgExport      Permission = "org:export"      // Exportar todos os dados da organização (backup e offboarding)
+)
+
+// PermissionInfo descreve uma permissão do catálogo.
+type PermissionInfo struct {
+	Permission  Permission `json:"permission"`
+	Resource    string     `json:"resource"`
+	Action      string     `json:"action"`
+	Description string     `json:"description"`
+}
+
+// Catalog lista as permissões existentes, na ordem de exibição.
+var Catalog = []PermissionInfo{
+	info(RisksUpdate, "Edit any risk (owners can always edit their own)"),
+	info(RisksDelete, "Delete any risk (owners can always delete their own)"),
+	info(RisksSubmitAcceptance, "Submit risks for acceptance"),
+	info(RisksManageStakeholders, "Manage the stakeholders of any risk"),
+	info(RisksConfigure, "Configure risk categories and the risk scoring matrix"),
+	info(AuditManageFrameworks, "Create, edit and import frameworks, designate coordinators and customize control order, mappings and rubrics"),
+	info(AuditAssign, "Assign assessments and follow other assessors' assignments"),
+	info(AuditManageCampaigns, "Create and edit audit campaigns and assess in any of them"),
+	info(AuditSubmitAny, "Assess controls of frameworks that have coordinators"),
+	info(AuditModerateComments, "Delete other users' assessment comments"),
+	info(AuditImportHistory, "Import historical assessments as backdated snapshots"),
+	info(AccessReviewsManage, "Create, populate and close access review campaigns"),
+	info(PoliciesDelete, "Move policies to the trash"),
+	info(PoliciesRestore, "List and restore policies in the trash"),
+	info(PoliciesManage, "Create policies and manage any policy (owners can always manage their own)"),
+	info(ControlTestsManage, "Manage recurring control tests and testers' capacity"),
+	info(ControlWaiversManage, "Approve and revoke control waivers"),
+	info(EvidenceRequestsManage, "Request and review evidence and manage evidence portal links"),
+	info(EvidenceManageAccess, "Configure the evidence access policy"),
+	info(AssetsManage, "Manage the asset inventory and its links"),
+	info(BusinessProcessesManage, "Manage business processes and their dependencies"),
+	info(VendorsManage, "Manage vendors and their risks"),
+	info(QuestionnairesManage, "Manage questionnaires and send them to vendors"),
+	info(VulnerabilitiesManage, "Edit, delete and import vulnerabilities"),
+	info(TagsManage, "Create, edit and delete tags"),
+	info(SavedViewsShare, "Share saved views and edit shared views"),
+	info(EditLocksReleaseAny, "Release other users' edit locks"),
+	info(IdentityProvidersManage, "Configure, import and export identity providers"),
+	info(UsersManage, "List users and change their role and status"),
+	info(UsersAssignAdmin, "Grant or revoke the admin role"),
+	info(UsersAnonymize, "Irreversibly remove a user's personal data"),
+	info(RolesManage, "Create custom roles and assign them to users"),
+	info(TeamsManage, "Create teams and manage their members"),
+	info(ReportsViewRollup, "View the consolidated reports of child organizations"),
+	info(ReportsManageExecutive, "Generate the executive report and edit its template and logo"),
+	info(BrandingManage, "Customize the organization's branding, login page and e-mails"),
+	info(TrustCenterManage, "Edit and unpublish the public trust center"),
+	info(TrustCenterPublish, "Approve and publish the public trust center"),
+	info(ReportsManageSchedules, "Schedule reports emailed to distribution lists"),
+	info(ReportsDownload, "Generate reports on demand"),
+	info(NotificationsManage, "Configure notification routing and filters"),
+	info(WebhooksManage, "Configure the organization's webhooks"),
+	info(SecretsManage, "Manage the credential vault and its policy"),
+	info(RetentionManage, "Configure data retention"),
+	info(AuditTrailView, "View the audit trail and verify export manifests"),
+	info(AuditTrailExport, "Export the audit trail"),
+	info(ConfigChangesView, "View the configuration history"),
+	info(ConfigChangesRollback, "Roll back configuration changes"),
+	info(APIUsageView, "View the organization's API usage"),
+	info(APIKeysManage, "List and revoke every user's API keys"),
+	info(FeatureFlagsInspect, "Evaluate feature flags for other users"),
+	info(AdminActionsView, "Follow the actions that require a second approval"),
+	info(AdminActionsApprove, "Approve actions as the second admin"),
+	info(SecurityManage, "Configure the organization's security policies, such as the password policy"),
+	info(OrgExport, "Export all of the organization's data for backup or offboarding"),
+}
+
+func info(permission Permission, description string) PermissionInfo {
+	resource, action, _ := strings.Cut(string(permission), ":")
+	return PermissionInfo{Permission: permission, Resource: resource, Action: action, Description: description}
+}
+
+// managerExcluded são as permissões do admin que o manager não tem.
+var managerExcluded = map[Permission]bool{
+	AuditSubmitAny:        true,
+	AuditModerateComments: true,
+	UsersAssignAdmin:      true,
+	UsersAnonymize:        true,
+	RolesManage:           true,
+	TrustCenterPublish:    true,
+	SecurityManage:        true,
+	OrgExport:             true,
+	APIKeysManage:         true,
+	AdminActionsApprove:   true,
+}
+
+// bundles são as permissões de cada papel base. O system admin administra a plataforma (/admin) e não
+// recebe permissões nas organizações, como antes das permissões.
+var bundles = func() map[models.UserRole]map[Permission]bool {
+	admin, manager := map[Permission]bool{}, map[Permission]bool{}
+	for _, p := range Catalog {
+		admin[p.Permission] = true
+		if !managerExcluded[p.Permission] {
+			manager[p.Permission] = true
+		}
+	}
+	return map[models.UserRole]map[Permission]bool{
+		models.RoleAdmin:   admin,
+		models.RoleManager: manager,
+		models.RoleUser:    {},
+	}
+}()
+
+// Valid indica se a permissão existe no catálogo.
+func Valid(permission Permission) bool {
+	for _, p := range Catalog {
+		if p.Permission == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleHas indica se o papel base concede a permissão.
+func RoleHas(role models.UserRole, permission Permission) bool {
+	return bundles[role][permission]
+}
+
+// RolePermissions retorna as permissões do papel base, na ordem do catálogo.
+func RolePermissions(role models.UserRole) []Permission {
+	permissions := []Permission{}
+	for _, p := range Catalog {
+		if bundles[role][p.Permission] {
+			permissions = append(permissions, p.Permission)
+		}
+	}
+	return permissions
+}
+
+// Effective retorna as permissões do papel base somadas às do papel personalizado, na ordem do catálogo.
+func Effective(role models.UserRole, custom []Permission) []Permission {
+	extra := make(map[Permission]bool, len(custom))
+	for _, p := range custom {
+		extra[p] = true
+	}
+	permissions := []Permission{}
+	for _, p := range Catalog {
+		if bundles[role][p.Permission] || extra[p.Permission] {
+			permissions = append(permissions, p.Permission)
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,118 @@
+package rbac
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRoleBundlesKeepThePreviousRules(t *testing.T) {
+	for _, p := range Catalog {
+		assert.True(t, RoleHas(models.RoleAdmin, p.Permission), "admin has %s", p.Permission)
+		assert.False(t, RoleHas(models.RoleUser, p.Permission), "user has no %s", p.Permission)
+		assert.False(t, RoleHas(models.RoleSystemAdmin, p.Permission), "system admin has no %s", p.Permission)
+	}
+	assert.True(t, RoleHas(models.RoleManager, RisksSubmitAcceptance))
+	assert.True(t, RoleHas(models.RoleManager, UsersManage))
+	assert.False(t, RoleHas(models.RoleManager, UsersAssignAdmin), "managers cannot grant admin")
+	assert.False(t, RoleHas(models.RoleManager, UsersAnonymize), "only admins anonymize users")
+	assert.False(t, RoleHas(models.RoleManager, OrgExport), "only admins export the organization")
+	assert.False(t, RoleHas(models.RoleManager, AuditSubmitAny), "only admins bypass framework coordinators")
+	assert.False(t, RoleHas(models.RoleManager, RolesManage))
+	assert.True(t, RoleHas(models.RoleManager, TrustCenterManage))
+	assert.False(t, RoleHas(models.RoleManager, TrustCenterPublish), "only admins approve the public trust center")
+	assert.True(t, RoleHas(models.RoleManager, PoliciesRestore))
+	assert.True(t, RoleHas(models.RoleManager, SecretsManage))
+	assert.False(t, RoleHas(models.RoleManager, APIKeysManage), "only admins manage every user's API keys")
+	assert.False(t, RoleHas(models.RoleManager, AdminActionsApprove), "only admins are second approvers")
+}
+
+func TestCatalogIsWellFormed(t *testing.T) {
+	seen := map[Permission]bool{}
+	for _, p := range Catalog {
+		assert.False(t, seen[p.Permission], "duplicate %s", p.Permission)
+		seen[p.Permission] = true
+		assert.Equal(t, string(p.Permission), p.Resource+":"+p.Action)
+		assert.NotEmpty(t, p.Description)
+	}
+	assert.True(t, Valid(RisksUpdate))
+	assert.False(t, Valid("risks:fly"))
+}
+
+func TestEffectiveMergesCustomPermissionsInCatalogOrder(t *testing.T) {
+	effective := Effective(models.RoleUser, []Permission{AuditAssign, RisksUpdate, RisksUpdate})
+	assert.Equal(t, []Permission{RisksUpdate, AuditAssign}, effective)
+	assert.Equal(t, RolePermissions(models.RoleAdmin), Effective(models.RoleAdmin, []Permission{RolesManage}))
+}
+
+func newContext(t *testing.T, role models.UserRole) (*gin.Context, *httptest.ResponseRecorder, sqlmock.Sqlmock) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Set("userID", uuid.New())
+	c.Set("userRole", role)
+	return c, rec, mock
+}
+
+func TestCanUsesTheBaseRoleWithoutQueryingCustomRoles(t *testing.T) {
+	c, _, mock := newContext(t, models.RoleManager)
+	assert.True(t, Can(c, RisksUpdate))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCanFallsBackToTheCustomRoleOncePerRequest(t *testing.T) {
+	c, rec, mock := newContext(t, models.RoleUser)
+	mock.ExpectQuery(`SELECT "custom_roles"\."id".* FROM "custom_roles" JOIN users ON users\.custom_role_id = custom_roles\.id`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "permissions"}).AddRow(uuid.New(), `["risks:update","audit:assign"]`))
+
+	assert.True(t, Can(c, RisksUpdate))
+	assert.True(t, Can(c, AuditAssign))
+	assert.False(t, Require(c, RisksDelete))
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"error":"Insufficient permissions","required_permission":"risks:delete"}`, rec.Body.String())
+}
+
+func TestUserCanChecksAnotherUsersCustomRole(t *testing.T) {
+	_, _, mock := newContext(t, models.RoleUser)
+	orgID, roleID := uuid.New(), uuid.New()
+	approver := models.User{ID: uuid.New(), OrganizationID: uuid.NullUUID{UUID: orgID, Valid: true}, Role: models.RoleUser, CustomRoleID: &roleID}
+
+	can, err := UserCan(database.DB, models.User{Role: models.RoleAdmin}, AdminActionsApprove)
+	require.NoError(t, err)
+	assert.True(t, can)
+
+	mock.ExpectQuery(`SELECT \* FROM "custom_roles" WHERE id = \$1 AND organization_id = \$2`).
+		WithArgs(roleID, orgID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "permissions"}).AddRow(roleID, `["control_waivers:manage"]`))
+	can, err = UserCan(database.DB, approver, ControlWaiversManage)
+	require.NoError(t, err)
+	assert.True(t, can)
+
+	approver.CustomRoleID = nil
+	can, err = UserCan(database.DB, approver, ControlWaiversManage)
+	require.NoError(t, err)
+	assert.False(t, can)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,318 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TopRisksLimit é o número de riscos abertos listados no registro de riscos.
+const TopRisksLimit = 25
+
+// Titles são os títulos dos relatórios.
+var Titles = map[models.ReportType]string{
+	models.ReportRiskRegister:    "Resumo do Registro de Riscos",
+	models.ReportComplianceDelta: "Variação da Conformidade",
+	models.ReportOverdueActions:  "Ações Vencidas",
+}
+
+// openRiskStatuses são os status de um risco ainda não tratado.
+var openRiskStatuses = []models.RiskStatus{models.StatusOpen, models.StatusInProgress}
+
+// riskLevelOrder ordena os riscos do nível mais grave ao menos grave.
+var riskLevelOrder = fmt.Sprintf("CASE risks.risk_level WHEN '%s' THEN 0 WHEN '%s' THEN 1 WHEN '%s' THEN 2 WHEN '%s' THEN 3 ELSE 4 END",
+	models.RiskLevelExtreme, models.RiskLevelHigh, models.RiskLevelModerate, models.RiskLevelLow)
+
+// Build gera o relatório da organização para o período [from, to). Ações vencidas consideram a situação em to.
+func Build(ctx context.Context, db *gorm.DB, organizationID uuid.UUID, reportType models.ReportType, from, to time.Time) (*Report, error) {
+	title, ok := Titles[reportType]
+	if !ok {
+		return nil, fmt.Errorf("unknown report type %q", reportType)
+	}
+	db = db.WithContext(ctx)
+	var org models.Organization
+	if err := db.Select("id", "name").Where("id = ?", organizationID).Take(&org).Error; err != nil {
+		return nil, fmt.Errorf("failed to load organization: %w", err)
+	}
+	r := &Report{Type: reportType, Title: title, Organization: org.Name, From: from, To: to, GeneratedAt: time.Now()}
+	var err error
+	switch reportType {
+	case models.ReportRiskRegister:
+		err = buildRiskRegister(db, organizationID, r)
+	case models.ReportComplianceDelta:
+		err = buildComplianceDelta(db, organizationID, r)
+	case models.ReportOverdueActions:
+		err = buildOverdueActions(db, organizationID, r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s report: %w", reportType, err)
+	}
+	return r, nil
+}
+
+// buildRiskRegister resume os riscos por nível e status e lista os principais riscos abertos, lidos do
+// modelo de leitura da listagem (risk_list_items).
+func buildRiskRegister(db *gorm.DB, organizationID uuid.UUID, r *Report) error {
+	var total, open, created, pendingAcceptance int64
+	counts := []struct {
+		query *gorm.DB
+		count *int64
+	}{
+		{db.Model(&models.Risk{}).Where("organization_id = ?", organizationID), &total},
+		{db.Model(&models.Risk{}).Where("organization_id = ? AND status IN ?", organizationID, openRiskStatuses), &open},
+		{db.Model(&models.Risk{}).Where("organization_id = ? AND created_at >= ? AND created_at < ?", organizationID, r.From, r.To), &created},
+		{db.Model(&models.ApprovalWorkflow{}).Where("organization_id = ? AND risk_id IS NOT NULL AND status = ?", organizationID, models.ApprovalPending), &pendingAcceptance},
+	}
+	for _, c := range counts {
+		if err := c.query.Count(c.count).Error; err != nil {
+			return err
+		}
+	}
+	r.Summary = []Field{
+		{"Riscos cadastrados", total},
+		{"Riscos abertos ou em andamento", open},
+		{"Novos riscos no período", created},
+		{"Aceites de risco pendentes", pendingAcceptance},
+	}
+
+	var byLevel []struct {
+		RiskLevel string
+		Count     int64
+	}
+	if err := db.Model(&models.Risk{}).Select("risk_level, COUNT(*) AS count").
+		Where("organization_id = ? AND status IN ?", organizationID, openRiskStatuses).
+		Group("risk_level").Scan(&byLevel).Error; err != nil {
+		return err
+	}
+	sort.Slice(byLevel, func(i, j int) bool {
+		if ri, rj := riskLevelRank(byLevel[i].RiskLevel), riskLevelRank(byLevel[j].RiskLevel); ri != rj {
+			return ri < rj
+		}
+		return byLevel[i].RiskLevel < byLevel[j].RiskLevel
+	})
+	levels := Table{Title: "Riscos abertos por nível", Columns: []string{"Nível", "Riscos"}, Empty: "Nenhum risco aberto."}
+	for _, l := range byLevel {
+		levels.add(l.RiskLevel, l.Count)
+	}
+
+	var byStatus []struct {
+		Status models.RiskStatus
+		Count  int64
+	}
+	if err := db.Model(&models.Risk{}).Select("status, COUNT(*) AS count").
+		Where("organization_id = ?", organizationID).Group("status").Order("status").Scan(&byStatus).Error; err != nil {
+		return err
+	}
+	statuses := Table{Title: "Riscos por status", Columns: []string{"Status", "Riscos"}, Empty: "Nenhum risco cadastrado."}
+	for _, s := range byStatus {
+		statuses.add(riskStatusLabel(s.Status), s.Count)
+	}
+
+	var top []models.RiskListItem
+	if err := db.Table("risk_list_items AS risks").
+		Where("risks.organization_id = ? AND risks.status IN ?", organizationID, openRiskStatuses).
+		Order(riskLevelOrder).Order("risks.created_at desc, risks.id").Limit(TopRisksLimit).Find(&top).Error; err != nil {
+		return err
+	}
+	topRisks := Table{
+		Title:   "Principais riscos abertos",
+		Columns: []string{"Código", "Título", "Nível", "Status", "Responsável", "Aceite", "Tratamento"},
+		Widths:  []float64{1.2, 3.5, 1, 1.2, 1.8, 1, 1},
+		Empty:   "Nenhum risco aberto.",
+	}
+	for _, risk := range top {
+		var approval, treatment interface{}
+		if risk.ApprovalStatus != nil {
+			approval = string(*risk.ApprovalStatus)
+		}
+		if risk.TreatmentProgress != nil {
+			treatment = fmt.Sprintf("%d%%", *risk.TreatmentProgress)
+		}
+		topRisks.add(risk.ReferenceCode, risk.Title, risk.RiskLevel, riskStatusLabel(risk.Status), risk.OwnerName, approval, treatment)
+	}
+	r.Tables = []Table{levels, statuses, topRisks}
+	return nil
+}
+
+// complianceSnapshot é o snapshot mais recente de um framework até uma data.
+type complianceSnapshot struct {
+	FrameworkID        uuid.UUID
+	SnapshotDate       time.Time
+	ComplianceScore    float64
+	ConformantControls int
+	NonConformant      int `gorm:"column:non_conformant_controls"`
+}
+
+// latestSnapshots retorna, por framework, o snapshot mais recente até a data (inclusive).
+func latestSnapshots(db *gorm.DB, organizationID uuid.UUID, until time.Time) (map[uuid.UUID]complianceSnapshot, error) {
+	var rows []complianceSnapshot
+	err := db.Raw(`SELECT DISTINCT ON (framework_id) framework_id, snapshot_date, compliance_score, conformant_controls, non_conformant_controls
+		FROM compliance_score_snapshots WHERE organization_id = ? AND snapshot_date <= ?
+		ORDER BY framework_id, snapshot_date DESC`, organizationID, until.UTC().Format("2006-01-02")).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uuid.UUID]complianceSnapshot, len(rows))
+	for _, row := range rows {
+		result[row.FrameworkID] = row
+	}
+	return result, nil
+}
+
+// buildComplianceDelta compara, por framework, o score de conformidade no início e no fim do período a partir
+// dos snapshots diários (ver pacote compliancescore).
+func buildComplianceDelta(db *gorm.DB, organizationID uuid.UUID, r *Report) error {
+	start, err := latestSnapshots(db, organizationID, r.From)
+	if err != nil {
+		return err
+	}
+	end, err := latestSnapshots(db, organizationID, r.To)
+	if err != nil {
+		return err
+	}
+	frameworkIDs := make([]uuid.UUID, 0, len(end))
+	for id := range end {
+		frameworkIDs = append(frameworkIDs, id)
+	}
+	var frameworks []models.AuditFramework
+	if len(frameworkIDs) > 0 {
+		if err := db.Select("id", "name").Where("id IN ?", frameworkIDs).Order("name").Find(&frameworks).Error; err != nil {
+			return err
+		}
+	}
+
+	table := Table{
+		Title:   "Score por framework",
+		Columns: []string{"Framework", "Score inicial", "Score final", "Variação", "Conformes (início)", "Conformes (fim)", "Não conformes (início)", "Não conformes (fim)"},
+		Widths:  []float64{2.5, 1, 1, 1, 1, 1, 1, 1},
+		Empty:   "Nenhum framework avaliado até o fim do período.",
+	}
+	var improved, worsened int
+	for _, fw := range frameworks {
+		e := end[fw.ID]
+		var startScore, delta, startConformant, startNonConformant interface{}
+		if s, ok := start[fw.ID]; ok {
+			startScore, startConformant, startNonConformant = s.ComplianceScore, s.ConformantControls, s.NonConformant
+			d := e.ComplianceScore - s.ComplianceScore
+			delta = d
+			if d > 0 {
+				improved++
+			} else if d < 0 {
+				worsened++
+			}
+		}
+		table.add(fw.Name, startScore, e.ComplianceScore, delta, startConformant, e.ConformantControls, startNonConformant, e.NonConformant)
+	}
+	r.Summary = []Field{
+		{"Frameworks avaliados", len(frameworks)},
+		{"Frameworks com melhora", improved},
+		{"Frameworks com piora", worsened},
+	}
+	r.Tables = []Table{table}
+	return nil
+}
+
+// daysOverdue retorna os dias inteiros entre o prazo e now.
+func daysOverdue(due, now time.Time) int {
+	return int(now.Sub(due).Hours() / 24)
+}
+
+// buildOverdueActions lista as ações vencidas em r.To, com os mesmos critérios do painel da organização.
+func buildOverdueActions(db *gorm.DB, organizationID uuid.UUID, r *Report) error {
+	now := r.To
+	var assessments []struct {
+		ControlID     string
+		FrameworkName string
+		AssigneeName  string
+		DueDate       time.Time
+	}
+	if err := db.Table("audit_assessments").
+		Select("audit_controls.control_id, audit_frameworks.name AS framework_name, users.name AS assignee_name, audit_assessments.due_date").
+		Joins("JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id").
+		Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Joins("LEFT JOIN users ON users.id = audit_assessments.assigned_to_id").
+		Where("audit_assessments.organization_id = ? AND audit_assessments.assigned_to_id IS NOT NULL AND audit_assessments.assignment_completed_at IS NULL AND audit_assessments.due_date < ?",
+			organizationID, now.Format("2006-01-02")).
+		Order("audit_assessments.due_date, audit_controls.control_id").Scan(&assessments).Error; err != nil {
+		return err
+	}
+	assessmentTable := Table{
+		Title:   "Avaliações vencidas",
+		Columns: []string{"Controle", "Framework", "Avaliador", "Prazo", "Dias em atraso"},
+		Widths:  []float64{1.2, 2, 2, 1, 1},
+		Empty:   "Nenhuma avaliação vencida.",
+	}
+	for _, a := range assessments {
+		assessmentTable.add(a.ControlID, a.FrameworkName, a.AssigneeName, a.DueDate.Format("02/01/2006"), daysOverdue(a.DueDate, now))
+	}
+
+	var requests []models.EvidenceRequest
+	if err := db.Where("organization_id = ? AND status IN ? AND due_date < ?", organizationID,
+		[]models.EvidenceRequestStatus{models.EvidenceRequestPending, models.EvidenceRequestRejected}, now).
+		Order("due_date").Find(&requests).Error; err != nil {
+		return err
+	}
+	requestTable := Table{
+		Title:   "Solicitações de evidência vencidas",
+		Columns: []string{"Solicitação", "Responsável", "Status", "Prazo", "Dias em atraso"},
+		Widths:  []float64{2.5, 2, 1, 1, 1},
+		Empty:   "Nenhuma solicitação de evidência vencida.",
+	}
+	for _, req := range requests {
+		requestTable.add(req.Title, req.AssigneeEmail, string(req.Status), req.DueDate.Format("02/01/2006"), daysOverdue(req.DueDate, now))
+	}
+
+	var reviews []models.AccessReviewCampaign
+	if err := db.Where("organization_id = ? AND status = ? AND due_date < ?", organizationID, models.AccessReviewStatusOpen, now).
+		Order("due_date").Find(&reviews).Error; err != nil {
+		return err
+	}
+	reviewTable := Table{
+		Title:   "Revisões de acesso vencidas",
+		Columns: []string{"Revisão", "Prazo", "Dias em atraso"},
+		Widths:  []float64{4, 1, 1},
+		Empty:   "Nenhuma revisão de acesso vencida.",
+	}
+	for _, review := range reviews {
+		reviewTable.add(review.Name, review.DueDate.Format("02/01/2006"), daysOverdue(review.DueDate, now))
+	}
+
+	var policies []struct {
+		ReferenceCode  string
+		Title          string
+		OwnerName      string
+		NextReviewDate time.Time
+	}
+	if err := db.Table("policies").
+		Select("policies.reference_code, policies.title, users.name AS owner_name, policies.next_review_date").
+		Joins("LEFT JOIN users ON users.id = policies.owner_id").
+		Where("policies.organization_id = ? AND policies.deleted_at IS NULL AND policies.status = ? AND policies.next_review_date <= ?", organizationID, models.PolicyStatusApproved, now).
+		Order("policies.next_review_date").Scan(&policies).Error; err != nil {
+		return err
+	}
+	policyTable := Table{
+		Title:   "Políticas com revisão vencida",
+		Columns: []string{"Código", "Política", "Dono", "Revisão prevista", "Dias em atraso"},
+		Widths:  []float64{1, 3, 2, 1, 1},
+		Empty:   "Nenhuma política com revisão vencida.",
+	}
+	for _, p := range policies {
+		policyTable.add(p.ReferenceCode, p.Title, p.OwnerName, p.NextReviewDate.Format("02/01/2006"), daysOverdue(p.NextReviewDate, now))
+	}
+
+	r.Summary = []Field{
+		{"Total de ações vencidas", len(assessments) + len(requests) + len(reviews) + len(policies)},
+		{"Avaliações", len(assessments)},
+		{"Solicitações de evidência", len(requests)},
+		{"Revisões de acesso", len(reviews)},
+		{"Revisões de políticas", len(policies)},
+	}
+	r.Tables = []Table{assessmentTable, requestTable, reviewTable, policyTable}
+	return nil
+}
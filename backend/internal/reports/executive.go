@@ -0,0 +1,353 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	_ "image/gif"  // Decodificador de logos GIF
+	_ "image/jpeg" // Decodificador de logos JPEG
+	_ "image/png"  // Decodificador de logos PNG
+	"sort"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/pdfdoc"
+	"phoenixgrc/backend/internal/riskutils"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Limites do relatório executivo.
+const (
+	ExecutiveTopRisks      = 10
+	ExecutiveMaxFrameworks = 8 // Medidores de conformidade; os frameworks com mais controles avaliados
+	ExecutiveTrendSeries   = 5 // Linhas no gráfico de evolução da conformidade
+	DefaultExecutiveMonths = 12
+	MaxExecutiveMonths     = 24
+	maxLogoBytes           = 2 * 1024 * 1024
+	maxLogoPixels          = 16_000_000
+)
+
+// DefaultExecutiveColor é a cor do relatório quando nem o modelo nem a organização definem uma.
+var DefaultExecutiveColor = pdfdoc.Color{R: 0.12, G: 0.29, B: 0.49}
+
+// Branding é a identidade visual do relatório executivo, resolvida a partir do modelo da organização.
+type Branding struct {
+	Title    string
+	Subtitle string
+	Footer   string
+	Color    pdfdoc.Color
+	Logo     image.Image // nil = sem logo
+}
+
+// Heatmap é a matriz impacto x probabilidade dos riscos abertos.
+type Heatmap struct {
+	Size              int
+	ProbabilityLabels []string   // Linhas, da probabilidade mais baixa à mais alta
+	ImpactLabels      []string   // Colunas, do impacto mais baixo ao mais alto
+	Levels            [][]string // Nível de cada célula segundo a matriz de pontuação
+	Counts            [][]int
+	Unrated           int // Riscos sem impacto ou probabilidade válidos
+}
+
+// FrameworkScore é o score de conformidade corrente de um framework.
+type FrameworkScore struct {
+	FrameworkID   uuid.UUID
+	Name          string
+	Score         float64
+	Evaluated     int
+	TotalControls int
+}
+
+// TrendSeries é a evolução mensal de um indicador; Values[i] é nil quando não há dado no mês.
+type TrendSeries struct {
+	Name   string
+	Values []*float64
+}
+
+// Executive reúne os dados do relatório executivo.
+type Executive struct {
+	Organization    string
+	GeneratedAt     time.Time
+	Months          []time.Time // Primeiro dia de cada mês da janela de tendência, em ordem
+	OpenRisks       int64
+	CriticalRisks   int64 // Riscos abertos de nível Alto ou Extremo
+	PendingApproval int64
+	AverageScore    *float64
+	Heatmap         Heatmap
+	TopRisks        []models.RiskListItem
+	Frameworks      []FrameworkScore
+	ComplianceTrend []TrendSeries
+	NewRisks        []int // Riscos criados em cada mês
+	NewCritical     []int // Dos quais de nível Alto ou Extremo
+}
+
+// LoadBranding resolve a identidade visual do relatório executivo: o modelo da organização, com o logo e a cor
+// primária da organização quando o modelo não define os seus. Um logo que não pode ser lido é omitido.
+func LoadBranding(ctx context.Context, db *gorm.DB, organizationID uuid.UUID) (Branding, error) {
+	var org models.Organization
+	if err := db.Select("id", "name", "logo_url", "primary_color").Take(&org, "id = ?", organizationID).Error; err != nil {
+		return Branding{}, err
+	}
+	var template models.ExecutiveReportTemplate
+	if err := db.Where("organization_id = ?", organizationID).Take(&template).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return Branding{}, err
+	}
+
+	b := Branding{Title: template.Title, Subtitle: template.Subtitle, Footer: template.FooterText, Color: DefaultExecutiveColor}
+	if b.Title == "" {
+		b.Title = models.DefaultExecutiveReportTitle
+	}
+	if b.Footer == "" {
+		b.Footer = org.Name
+	}
+	if color, ok := pdfdoc.ParseHexColor(template.PrimaryColor); ok {
+		b.Color = color
+	} else if color, ok := pdfdoc.ParseHexColor(org.PrimaryColor); ok {
+		b.Color = color
+	}
+	logo := template.LogoURL
+	if logo == "" {
+		logo = org.LogoURL
+	}
+	b.Logo = loadLogo(ctx, logo)
+	return b, nil
+}
+
+// loadLogo lê o logo do armazenamento da aplicação. URLs externas não são baixadas.
+func loadLogo(ctx context.Context, objectName string) image.Image {
+	if objectName == "" || strings.HasPrefix(objectName, "http://") || strings.HasPrefix(objectName, "https://") {
+		return nil
+	}
+	data, err := filestorage.ReadObject(ctx, filestorage.DefaultFileStorageProvider, objectName, maxLogoBytes)
+	if err != nil {
+		phxlog.L.Warn("Failed to read logo for executive report", zap.String("objectName", objectName), zap.Error(err))
+		return nil
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > maxLogoPixels {
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return img
+}
+
+// monthStart retorna o primeiro instante do mês de t (UTC).
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// BuildExecutive reúne os dados do relatório executivo da organização em now, com a tendência dos últimos
+// months meses (incluindo o corrente). matrix é a matriz de pontuação de riscos da organização.
+func BuildExecutive(ctx context.Context, db *gorm.DB, organizationID uuid.UUID, matrix riskutils.ScoringMatrix, months int, now time.Time) (*Executive, error) {
+	db = db.WithContext(ctx)
+	var org models.Organization
+	if err := db.Select("id", "name").Take(&org, "id = ?", organizationID).Error; err != nil {
+		return nil, err
+	}
+	e := &Executive{Organization: org.Name, GeneratedAt: now}
+	first := monthStart(now).AddDate(0, -(months - 1), 0)
+	for i := 0; i < months; i++ {
+		e.Months = append(e.Months, first.AddDate(0, i, 0))
+	}
+
+	if err := buildExecutiveRisks(db, organizationID, matrix, e); err != nil {
+		return nil, err
+	}
+	if err := buildExecutiveCompliance(db, organizationID, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func buildExecutiveRisks(db *gorm.DB, organizationID uuid.UUID, matrix riskutils.ScoringMatrix, e *Executive) error {
+	critical := []string{models.RiskLevelHigh, models.RiskLevelExtreme}
+	open := db.Model(&models.Risk{}).Where("organization_id = ? AND status IN ?", organizationID, openRiskStatuses)
+	if err := open.Session(&gorm.Session{}).Count(&e.OpenRisks).Error; err != nil {
+		return err
+	}
+	if err := open.Session(&gorm.Session{}).Where("risk_level IN ?", critical).Count(&e.CriticalRisks).Error; err != nil {
+		return err
+	}
+	if err := db.Model(&models.ApprovalWorkflow{}).
+		Where("organization_id = ? AND risk_id IS NOT NULL AND status = ?", organizationID, models.ApprovalPending).
+		Count(&e.PendingApproval).Error; err != nil {
+		return err
+	}
+
+	// Matriz de calor dos riscos abertos
+	size := matrix.Size
+	impactLevels := make([]string, len(riskutils.ImpactLevels))
+	for i, level := range riskutils.ImpactLevels {
+		impactLevels[i] = string(level)
+	}
+	probabilityLevels := make([]string, len(riskutils.ProbabilityLevels))
+	for i, level := range riskutils.ProbabilityLevels {
+		probabilityLevels[i] = string(level)
+	}
+	e.Heatmap = Heatmap{
+		Size:              size,
+		ProbabilityLabels: riskutils.MatrixLabels(probabilityLevels, size),
+		ImpactLabels:      riskutils.MatrixLabels(impactLevels, size),
+		Levels:            matrix.Cells,
+		Counts:            make([][]int, size),
+	}
+	for i := range e.Heatmap.Counts {
+		e.Heatmap.Counts[i] = make([]int, size)
+	}
+	var cells []struct {
+		Impact      models.RiskImpact
+		Probability models.RiskProbability
+		Count       int
+	}
+	if err := open.Session(&gorm.Session{}).Select("impact, probability, COUNT(*) AS count").
+		Group("impact, probability").Scan(&cells).Error; err != nil {
+		return err
+	}
+	for _, cell := range cells {
+		row, col, ok := riskutils.MatrixCell(cell.Impact, cell.Probability, size)
+		if !ok {
+			e.Heatmap.Unrated += cell.Count
+			continue
+		}
+		e.Heatmap.Counts[row][col] += cell.Count
+	}
+
+	if err := db.Table("risk_list_items AS risks").
+		Where("risks.organization_id = ? AND risks.status IN ?", organizationID, openRiskStatuses).
+		Order(riskLevelOrder).Order("risks.created_at desc, risks.id").
+		Limit(ExecutiveTopRisks).Find(&e.TopRisks).Error; err != nil {
+		return err
+	}
+
+	// Novos riscos por mês
+	var created []struct {
+		Month    time.Time
+		Total    int
+		Critical int
+	}
+	if err := db.Model(&models.Risk{}).
+		Select("date_trunc('month', created_at AT TIME ZONE 'UTC') AS month, COUNT(*) AS total, "+
+			"COUNT(*) FILTER (WHERE risk_level IN ?) AS critical", critical).
+		Where("organization_id = ? AND created_at >= ?", organizationID, e.Months[0]).
+		Group("month").Scan(&created).Error; err != nil {
+		return err
+	}
+	e.NewRisks = make([]int, len(e.Months))
+	e.NewCritical = make([]int, len(e.Months))
+	for _, row := range created {
+		if i := monthIndex(e.Months, row.Month); i >= 0 {
+			e.NewRisks[i], e.NewCritical[i] = row.Total, row.Critical
+		}
+	}
+	return nil
+}
+
+// monthIndex retorna a posição do mês de t em months, ou -1.
+func monthIndex(months []time.Time, t time.Time) int {
+	m := monthStart(t)
+	for i, month := range months {
+		if month.Equal(m) {
+			return i
+		}
+	}
+	return -1
+}
+
+func buildExecutiveCompliance(db *gorm.DB, organizationID uuid.UUID, e *Executive) error {
+	var frameworkIDs []uuid.UUID
+	if err := db.Raw(`SELECT DISTINCT audit_controls.framework_id
+		FROM audit_assessments
+		JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id
+		WHERE audit_assessments.organization_id = ? AND audit_assessments.audit_campaign_id IS NULL AND audit_assessments.status <> ''`,
+		organizationID).Scan(&frameworkIDs).Error; err != nil {
+		return err
+	}
+	if len(frameworkIDs) == 0 {
+		return nil
+	}
+	var frameworks []models.AuditFramework
+	if err := db.Select("id", "name").Where("id IN ?", frameworkIDs).Find(&frameworks).Error; err != nil {
+		return err
+	}
+	var counts []struct {
+		FrameworkID uuid.UUID
+		Total       int
+	}
+	if err := db.Model(&models.AuditControl{}).Select("framework_id, COUNT(*) AS total").
+		Where("framework_id IN ?", frameworkIDs).Group("framework_id").Scan(&counts).Error; err != nil {
+		return err
+	}
+	totals := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		totals[count.FrameworkID] = count.Total
+	}
+	for _, fw := range frameworks {
+		tally, err := compliancescore.Load(db, organizationID, fw.ID)
+		if err != nil {
+			return err
+		}
+		summary := compliancescore.Summarize(tally)
+		e.Frameworks = append(e.Frameworks, FrameworkScore{
+			FrameworkID:   fw.ID,
+			Name:          fw.Name,
+			Score:         summary.ComplianceScore,
+			Evaluated:     summary.EvaluatedControls,
+			TotalControls: totals[fw.ID],
+		})
+	}
+	sort.SliceStable(e.Frameworks, func(i, j int) bool {
+		if e.Frameworks[i].Evaluated != e.Frameworks[j].Evaluated {
+			return e.Frameworks[i].Evaluated > e.Frameworks[j].Evaluated
+		}
+		return e.Frameworks[i].Name < e.Frameworks[j].Name
+	})
+	if len(e.Frameworks) > ExecutiveMaxFrameworks {
+		e.Frameworks = e.Frameworks[:ExecutiveMaxFrameworks]
+	}
+	var sum float64
+	for _, fw := range e.Frameworks {
+		sum += fw.Score
+	}
+	average := sum / float64(len(e.Frameworks))
+	e.AverageScore = &average
+
+	// Evolução mensal: o último snapshot de cada mês
+	series := e.Frameworks
+	if len(series) > ExecutiveTrendSeries {
+		series = series[:ExecutiveTrendSeries]
+	}
+	ids := make([]uuid.UUID, len(series))
+	for i, fw := range series {
+		ids[i] = fw.FrameworkID
+	}
+	var snapshots []models.ComplianceScoreSnapshot
+	if err := db.Select("framework_id", "snapshot_date", "compliance_score").
+		Where("organization_id = ? AND framework_id IN ? AND snapshot_date >= ?", organizationID, ids, e.Months[0].Format("2006-01-02")).
+		Order("snapshot_date").Find(&snapshots).Error; err != nil {
+		return err
+	}
+	index := make(map[uuid.UUID]int, len(series))
+	for i, fw := range series {
+		index[fw.FrameworkID] = i
+		e.ComplianceTrend = append(e.ComplianceTrend, TrendSeries{Name: fw.Name, Values: make([]*float64, len(e.Months))})
+	}
+	for _, s := range snapshots {
+		if m := monthIndex(e.Months, s.SnapshotDate); m >= 0 {
+			score := s.ComplianceScore
+			e.ComplianceTrend[index[s.FrameworkID]].Values[m] = &score // Ordenados por data: fica o último do mês
+		}
+	}
+	return nil
+}
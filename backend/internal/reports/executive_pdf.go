@@ -0,0 +1,326 @@
+package reports
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/pdfdoc"
+)
+
+// Cores dos níveis de risco e das faixas de conformidade.
+var (
+	levelColors = map[string]pdfdoc.Color{
+		models.RiskLevelLow:      {R: 0.30, G: 0.69, B: 0.31},
+		models.RiskLevelModerate: {R: 1.00, G: 0.76, B: 0.03},
+		models.RiskLevelHigh:     {R: 0.96, G: 0.49, B: 0.00},
+		models.RiskLevelExtreme:  {R: 0.83, G: 0.18, B: 0.18},
+	}
+	seriesColors = []pdfdoc.Color{
+		{R: 0.83, G: 0.18, B: 0.18},
+		{R: 0.30, G: 0.69, B: 0.31},
+		{R: 0.96, G: 0.49, B: 0.00},
+		{R: 0.48, G: 0.31, B: 0.65},
+	}
+)
+
+func levelColor(level string) pdfdoc.Color {
+	if color, ok := levelColors[level]; ok {
+		return color
+	}
+	return pdfdoc.Gray
+}
+
+// scoreColor é a cor do medidor: vermelho abaixo de 50, amarelo abaixo de 80 e verde a partir de 80.
+func scoreColor(score float64) pdfdoc.Color {
+	switch {
+	case score < 50:
+		return levelColors[models.RiskLevelExtreme]
+	case score < 80:
+		return levelColors[models.RiskLevelModerate]
+	}
+	return levelColors[models.RiskLevelLow]
+}
+
+// fitText corta o texto com reticências para caber em width.
+func fitText(text string, font pdfdoc.Font, size, width float64) string {
+	if pdfdoc.TextWidth(text, font, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdfdoc.TextWidth(string(runes)+"...", font, size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// monthLabel formata o mês como MM/AA.
+func monthLabel(e *Executive, i int) string {
+	return e.Months[i].Format("01/06")
+}
+
+// RenderExecutive gera o PDF do relatório executivo com a identidade visual informada.
+func RenderExecutive(e *Executive, b Branding) ([]byte, error) {
+	doc := pdfdoc.New(fmt.Sprintf("%s - %s", b.Title, e.Organization))
+	doc.Footer = fmt.Sprintf("%s - gerado em %s", b.Footer, e.GeneratedAt.Format("02/01/2006 15:04"))
+
+	doc.Block(8, func(cv *pdfdoc.Canvas) {
+		cv.Rect(0, 0, pdfdoc.ContentWidth, 4, b.Color)
+	})
+	if b.Logo != nil {
+		if err := doc.Image(b.Logo, 0, 160, 50, ""); err != nil {
+			return nil, err
+		}
+	}
+	doc.Heading(b.Title)
+	if b.Subtitle != "" {
+		doc.Text(b.Subtitle, pdfdoc.Regular, 12, 0, pdfdoc.ContentWidth)
+	}
+	doc.Text(fmt.Sprintf("%s - %s", e.Organization, e.GeneratedAt.Format("02/01/2006")), pdfdoc.Regular, 10, 0, pdfdoc.ContentWidth)
+	doc.Spacer(10)
+
+	drawKPIs(doc, e, b)
+
+	doc.Section("Mapa de calor dos riscos abertos")
+	drawHeatmap(doc, e.Heatmap)
+
+	doc.Section(fmt.Sprintf("Os %d principais riscos abertos", ExecutiveTopRisks))
+	if len(e.TopRisks) == 0 {
+		doc.Paragraph("Nenhum risco aberto.")
+	} else {
+		rows := make([][]string, len(e.TopRisks))
+		for i, risk := range e.TopRisks {
+			treatment := "-"
+			if risk.TreatmentProgress != nil {
+				treatment = fmt.Sprintf("%d%%", *risk.TreatmentProgress)
+			}
+			rows[i] = []string{risk.ReferenceCode, risk.Title, risk.RiskLevel, riskStatusLabel(risk.Status), risk.OwnerName, treatment}
+		}
+		doc.Table([]string{"Código", "Risco", "Nível", "Status", "Responsável", "Tratamento"}, []float64{1.1, 4, 1, 1.2, 1.8, 1}, rows)
+	}
+
+	doc.NewPage()
+	doc.Section("Conformidade por framework")
+	if len(e.Frameworks) == 0 {
+		doc.Paragraph("Nenhum framework avaliado.")
+	} else {
+		drawGauges(doc, e.Frameworks)
+		doc.Section("Evolução da conformidade")
+		drawLineChart(doc, e, e.ComplianceTrend, b.Color)
+	}
+
+	doc.Section("Novos riscos por mês")
+	drawBarChart(doc, e, b.Color)
+
+	return doc.Bytes()
+}
+
+func drawKPIs(doc *pdfdoc.Document, e *Executive, b Branding) {
+	average := "-"
+	if e.AverageScore != nil {
+		average = strconv.FormatFloat(*e.AverageScore, 'f', 1, 64) + "%"
+	}
+	cards := []struct {
+		value, label string
+		color        pdfdoc.Color
+	}{
+		{strconv.FormatInt(e.OpenRisks, 10), "Riscos abertos", b.Color},
+		{strconv.FormatInt(e.CriticalRisks, 10), "Riscos altos ou extremos", levelColors[models.RiskLevelExtreme]},
+		{strconv.FormatInt(e.PendingApproval, 10), "Aceites pendentes", levelColors[models.RiskLevelHigh]},
+		{average, "Conformidade média", b.Color},
+	}
+	const gap = 8.0
+	width := (pdfdoc.ContentWidth - gap*float64(len(cards)-1)) / float64(len(cards))
+	doc.Block(62, func(cv *pdfdoc.Canvas) {
+		for i, card := range cards {
+			x := float64(i) * (width + gap)
+			cv.Rect(x, 0, width, 54, card.color.Mix(pdfdoc.White, 0.88))
+			cv.Rect(x, 0, 3, 54, card.color)
+			cv.Text(x+12, 8, card.value, pdfdoc.Bold, 20, card.color, "left")
+			cv.Text(x+12, 36, fitText(card.label, pdfdoc.Regular, 9, width-16), pdfdoc.Regular, 9, pdfdoc.Black, "left")
+		}
+	})
+}
+
+func drawHeatmap(doc *pdfdoc.Document, h Heatmap) {
+	const labelWidth, cell, top = 95.0, 46.0, 14.0
+	height := top + float64(h.Size)*cell + 34
+	doc.Block(height, func(cv *pdfdoc.Canvas) {
+		cv.Text(0, 0, "Probabilidade", pdfdoc.Bold, 8, pdfdoc.Black, "left")
+		for row := 0; row < h.Size; row++ {
+			y := top + float64(h.Size-1-row)*cell // Probabilidade mais alta no topo
+			cv.Text(labelWidth-6, y+cell/2-4, fitText(h.ProbabilityLabels[row], pdfdoc.Regular, 8, labelWidth-8), pdfdoc.Regular, 8, pdfdoc.Black, "right")
+			for col := 0; col < h.Size; col++ {
+				x := labelWidth + float64(col)*cell
+				level := ""
+				if row < len(h.Levels) && col < len(h.Levels[row]) {
+					level = h.Levels[row][col]
+				}
+				fill := levelColor(level)
+				count := h.Counts[row][col]
+				if count == 0 {
+					fill = fill.Mix(pdfdoc.White, 0.6)
+				}
+				cv.Rect(x, y, cell, cell, fill)
+				cv.StrokeRect(x, y, cell, cell, 1, pdfdoc.White)
+				if count > 0 {
+					cv.Text(x+cell/2, y+cell/2-7, strconv.Itoa(count), pdfdoc.Bold, 14, pdfdoc.White, "center")
+				}
+			}
+		}
+		bottom := top + float64(h.Size)*cell
+		for col := 0; col < h.Size; col++ {
+			x := labelWidth + float64(col)*cell + cell/2
+			cv.Text(x, bottom+4, fitText(h.ImpactLabels[col], pdfdoc.Regular, 8, cell-2), pdfdoc.Regular, 8, pdfdoc.Black, "center")
+		}
+		cv.Text(labelWidth+float64(h.Size)*cell/2, bottom+16, "Impacto", pdfdoc.Bold, 8, pdfdoc.Black, "center")
+
+		// Legenda à direita da matriz
+		legendX := labelWidth + float64(h.Size)*cell + 30
+		for i, level := range []string{models.RiskLevelExtreme, models.RiskLevelHigh, models.RiskLevelModerate, models.RiskLevelLow} {
+			y := top + float64(i)*16
+			cv.Rect(legendX, y, 10, 10, levelColor(level))
+			cv.Text(legendX+16, y+1, level, pdfdoc.Regular, 9, pdfdoc.Black, "left")
+		}
+		if h.Unrated > 0 {
+			cv.Text(legendX, top+72, fmt.Sprintf("%d risco(s) sem impacto ou probabilidade", h.Unrated), pdfdoc.Regular, 8, pdfdoc.Gray, "left")
+		}
+	})
+}
+
+func drawGauges(doc *pdfdoc.Document, frameworks []FrameworkScore) {
+	const perRow, rowHeight, radius = 4, 112.0, 38.0
+	width := pdfdoc.ContentWidth / perRow
+	for start := 0; start < len(frameworks); start += perRow {
+		end := start + perRow
+		if end > len(frameworks) {
+			end = len(frameworks)
+		}
+		doc.Block(rowHeight, func(cv *pdfdoc.Canvas) {
+			for i, fw := range frameworks[start:end] {
+				cx, cy := float64(i)*width+width/2, 8+radius
+				cv.Arc(cx, cy, radius, 180, 0, 10, pdfdoc.LightGray)
+				score := math.Max(0, math.Min(100, fw.Score))
+				if score > 0 {
+					cv.Arc(cx, cy, radius, 180, 180-180*score/100, 10, scoreColor(score))
+				}
+				cv.Text(cx, cy-20, strconv.FormatFloat(fw.Score, 'f', 1, 64)+"%", pdfdoc.Bold, 14, pdfdoc.Black, "center")
+				cv.Text(cx, cy+12, fitText(fw.Name, pdfdoc.Bold, 9, width-10), pdfdoc.Bold, 9, pdfdoc.Black, "center")
+				cv.Text(cx, cy+25, fmt.Sprintf("%d de %d controles avaliados", fw.Evaluated, fw.TotalControls), pdfdoc.Regular, 7.5, pdfdoc.Gray, "center")
+			}
+		})
+	}
+}
+
+// chartArea desenha os eixos de um gráfico com a escala de 0 a max em y e os meses em x, retornando as funções
+// que convertem o índice do mês e o valor em coordenadas.
+func chartArea(cv *pdfdoc.Canvas, e *Executive, left, top, width, height, max float64, suffix string) (func(int) float64, func(float64) float64) {
+	for i := 0; i <= 4; i++ {
+		v := max * float64(i) / 4
+		y := top + height - height*float64(i)/4
+		cv.Line(left, y, left+width, y, 0.4, pdfdoc.LightGray)
+		cv.Text(left-4, y-3, strconv.FormatFloat(v, 'f', 0, 64)+suffix, pdfdoc.Regular, 7, pdfdoc.Gray, "right")
+	}
+	n := len(e.Months)
+	step := width / float64(n)
+	xOf := func(i int) float64 { return left + step*(float64(i)+0.5) }
+	every := 1
+	if n > 12 {
+		every = 2
+	}
+	for i := 0; i < n; i += every {
+		cv.Text(xOf(i), top+height+4, monthLabel(e, i), pdfdoc.Regular, 7, pdfdoc.Gray, "center")
+	}
+	yOf := func(v float64) float64 { return top + height - height*v/max }
+	return xOf, yOf
+}
+
+func drawLineChart(doc *pdfdoc.Document, e *Executive, series []TrendSeries, primary pdfdoc.Color) {
+	const left, top, height = 34.0, 6.0, 140.0
+	legendRows := (len(series) + 2) / 3
+	doc.Block(top+height+22+float64(legendRows)*14, func(cv *pdfdoc.Canvas) {
+		xOf, yOf := chartArea(cv, e, left, top, pdfdoc.ContentWidth-left, height, 100, "%")
+		for s, line := range series {
+			color := primary
+			if s > 0 {
+				color = seriesColors[(s-1)%len(seriesColors)]
+			}
+			// Meses sem snapshot interrompem a linha
+			var segment [][2]float64
+			flush := func() {
+				if len(segment) == 1 {
+					p := segment[0]
+					cv.Line(p[0]-1.5, p[1], p[0]+1.5, p[1], 3, color)
+				}
+				cv.Polyline(segment, 1.8, color)
+				segment = nil
+			}
+			for i, v := range line.Values {
+				if v == nil {
+					flush()
+					continue
+				}
+				segment = append(segment, [2]float64{xOf(i), yOf(math.Max(0, math.Min(100, *v)))})
+			}
+			flush()
+
+			legendWidth := pdfdoc.ContentWidth / 3
+			lx, ly := float64(s%3)*legendWidth, top+height+20+float64(s/3)*14
+			cv.Rect(lx, ly+2, 12, 3, color)
+			cv.Text(lx+16, ly, fitText(line.Name, pdfdoc.Regular, 8, legendWidth-20), pdfdoc.Regular, 8, pdfdoc.Black, "left")
+		}
+	})
+	if len(series) > 0 && allMissing(series) {
+		doc.Paragraph("Ainda não há snapshots do score de conformidade na janela do relatório.")
+	}
+}
+
+func allMissing(series []TrendSeries) bool {
+	for _, s := range series {
+		for _, v := range s.Values {
+			if v != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func drawBarChart(doc *pdfdoc.Document, e *Executive, primary pdfdoc.Color) {
+	const left, top, height = 34.0, 12.0, 120.0
+	max := 0
+	for _, v := range e.NewRisks {
+		if v > max {
+			max = v
+		}
+	}
+	// Escala em múltiplos de 4 para que as linhas de grade caiam em números inteiros
+	scale := float64((max + 3) / 4 * 4)
+	if scale == 0 {
+		scale = 4
+	}
+	critical := levelColors[models.RiskLevelExtreme]
+	doc.Block(top+height+36, func(cv *pdfdoc.Canvas) {
+		width := pdfdoc.ContentWidth - left
+		xOf, yOf := chartArea(cv, e, left, top, width, height, scale, "")
+		barWidth := width / float64(len(e.Months)) * 0.6
+		base := yOf(0)
+		for i, total := range e.NewRisks {
+			if total == 0 {
+				continue
+			}
+			x := xOf(i) - barWidth/2
+			cv.Rect(x, yOf(float64(total)), barWidth, base-yOf(float64(total)), primary.Mix(pdfdoc.White, 0.35))
+			if c := e.NewCritical[i]; c > 0 {
+				cv.Rect(x, yOf(float64(c)), barWidth, base-yOf(float64(c)), critical)
+			}
+			cv.Text(xOf(i), yOf(float64(total))-10, strconv.Itoa(total), pdfdoc.Bold, 7, pdfdoc.Black, "center")
+		}
+		ly := top + height + 22
+		cv.Rect(0, ly, 10, 8, primary.Mix(pdfdoc.White, 0.35))
+		cv.Text(14, ly, "Novos riscos", pdfdoc.Regular, 8, pdfdoc.Black, "left")
+		cv.Rect(100, ly, 10, 8, critical)
+		cv.Text(114, ly, "Dos quais altos ou extremos", pdfdoc.Regular, 8, pdfdoc.Black, "left")
+	})
+}
@@ -0,0 +1,76 @@
+package reports
+
+import (
+	"image"
+	"image/color"
+	"regexp"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/pdfdoc"
+	"phoenixgrc/backend/internal/riskutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleExecutive() *Executive {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	e := &Executive{Organization: "Acme", GeneratedAt: now, OpenRisks: 12, CriticalRisks: 3, PendingApproval: 1}
+	first := monthStart(now).AddDate(0, -11, 0)
+	for i := 0; i < 12; i++ {
+		e.Months = append(e.Months, first.AddDate(0, i, 0))
+	}
+	matrix := riskutils.DefaultScoringMatrix()
+	e.Heatmap = Heatmap{Size: 4, ProbabilityLabels: []string{"Baixo", "Médio", "Alto", "Crítico"},
+		ImpactLabels: []string{"Baixo", "Médio", "Alto", "Crítico"}, Levels: matrix.Cells, Counts: [][]int{{1, 0, 0, 0}, {0, 2, 0, 0}, {0, 0, 5, 0}, {0, 0, 0, 4}}}
+	e.TopRisks = []models.RiskListItem{{Risk: models.Risk{ReferenceCode: "RISK-0007", Title: "Vazamento de dados", RiskLevel: models.RiskLevelExtreme, Status: models.StatusOpen}, OwnerName: "Ana"}}
+	e.Frameworks = []FrameworkScore{{Name: "ISO 27001", Score: 82.5, Evaluated: 80, TotalControls: 93}, {Name: "NIST CSF", Score: 41, Evaluated: 20, TotalControls: 108}}
+	average := 61.75
+	e.AverageScore = &average
+	score := 70.0
+	e.ComplianceTrend = []TrendSeries{{Name: "ISO 27001", Values: make([]*float64, 12)}, {Name: "NIST CSF", Values: make([]*float64, 12)}}
+	e.ComplianceTrend[0].Values[10], e.ComplianceTrend[0].Values[11] = &score, &e.Frameworks[0].Score
+	e.NewRisks, e.NewCritical = make([]int, 12), make([]int, 12)
+	e.NewRisks[11], e.NewCritical[11] = 6, 2
+	return e
+}
+
+func TestMonthIndex(t *testing.T) {
+	e := sampleExecutive()
+	assert.Equal(t, 0, monthIndex(e.Months, time.Date(2025, 11, 30, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 11, monthIndex(e.Months, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, -1, monthIndex(e.Months, time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestFitText(t *testing.T) {
+	assert.Equal(t, "ISO 27001", fitText("ISO 27001", pdfdoc.Regular, 9, 200))
+	cut := fitText("Framework de segurança cibernética com um nome bem comprido", pdfdoc.Regular, 9, 80)
+	assert.Regexp(t, `\.\.\.$`, cut)
+	assert.LessOrEqual(t, pdfdoc.TextWidth(cut, pdfdoc.Regular, 9), 80.0)
+}
+
+func TestRenderExecutive(t *testing.T) {
+	logo := image.NewRGBA(image.Rect(0, 0, 200, 60))
+	logo.Set(10, 10, color.RGBA{B: 255, A: 255})
+	b := Branding{Title: "Relatório ao Conselho", Subtitle: "Outubro de 2026", Footer: "Confidencial", Color: DefaultExecutiveColor, Logo: logo}
+
+	out, err := RenderExecutive(sampleExecutive(), b)
+	require.NoError(t, err)
+	content := string(out)
+	assert.Contains(t, content, "/Title (Relat\xf3rio ao Conselho - Acme)")
+	assert.Contains(t, content, "(Confidencial - gerado em 16/10/2026 12:00 - P\xe1gina 1 de 2)")
+	assert.Contains(t, content, "/Filter /DCTDecode", "logo embutido")
+	assert.Contains(t, content, "(RISK-0007) Tj")
+	assert.Contains(t, content, "(82.5%) Tj", "medidor do framework")
+	assert.Contains(t, content, "(4) Tj", "contagem na célula da matriz")
+	assert.Regexp(t, regexp.MustCompile(`0\.830 0\.180 0\.180 rg [\d.]+ [\d.]+ [\d.]+ [\d.]+ re f`), content, "célula Extremo preenchida")
+
+	empty := sampleExecutive()
+	empty.Frameworks, empty.ComplianceTrend, empty.AverageScore, empty.TopRisks = nil, nil, nil, nil
+	out, err = RenderExecutive(empty, Branding{Title: models.DefaultExecutiveReportTitle, Footer: "Acme", Color: DefaultExecutiveColor})
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "(Nenhum framework avaliado.) Tj")
+	assert.Contains(t, string(out), "(Nenhum risco aberto.) Tj")
+}
@@ -0,0 +1,51 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// JobScheduledReport é o tipo de job da geração e envio de um relatório agendado.
+const JobScheduledReport = "report.scheduled_delivery"
+
+// scheduledReportJob é o payload de JobScheduledReport. O agendamento é recarregado na execução.
+type scheduledReportJob struct {
+	ScheduleID uuid.UUID `json:"schedule_id"`
+	RunAt      time.Time `json:"run_at"`
+}
+
+// RegisterJobHandlers registra os handlers dos jobs de relatórios. Deve ser chamado pelos processos que
+// executam a fila de jobs.
+func RegisterJobHandlers() {
+	jobs.Register(JobScheduledReport, func(ctx context.Context, job *models.BackgroundJob) error {
+		var p scheduledReportJob
+		if err := jobs.DecodePayload(job, &p); err != nil {
+			return err
+		}
+		db := database.GetDB()
+		var schedule models.ReportSchedule
+		if err := db.WithContext(ctx).First(&schedule, "id = ?", p.ScheduleID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				phxlog.L.Info("Report schedule removed before its run", zap.String("scheduleID", p.ScheduleID.String()))
+				return nil
+			}
+			return err
+		}
+		// Uma nova tentativa reenviaria o relatório aos destinatários que já o receberam; a falha fica em
+		// LastError e o envio é repetido no próximo horário do agendamento
+		if err := Deliver(ctx, db, &schedule, p.RunAt); err != nil {
+			return jobs.Permanent(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,199 @@
+// Package reports gera os relatórios periódicos da organização (registro de riscos, variação da
+// conformidade e ações vencidas) em PDF ou XLSX e os envia por e-mail às listas de distribuição dos
+// agendamentos (models.ReportSchedule).
+package reports
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/pdfdoc"
+	"phoenixgrc/backend/internal/xlsxdoc"
+)
+
+// MaxTableRows limita as linhas de cada tabela; as demais são resumidas em uma nota.
+const MaxTableRows = 500
+
+// Field é um indicador do resumo do relatório.
+type Field struct {
+	Label string
+	Value interface{} // string ou número
+}
+
+// Table é uma seção tabular do relatório. As células são strings, números (int, int64, float64) ou nil.
+type Table struct {
+	Title     string
+	Columns   []string
+	Widths    []float64 // Larguras relativas das colunas no PDF
+	Rows      [][]interface{}
+	Empty     string // Texto exibido quando não há linhas
+	Truncated int    // Linhas omitidas por MaxTableRows
+}
+
+// add acrescenta uma linha respeitando MaxTableRows.
+func (t *Table) add(cells ...interface{}) {
+	if len(t.Rows) >= MaxTableRows {
+		t.Truncated++
+		return
+	}
+	t.Rows = append(t.Rows, cells)
+}
+
+// Report é um relatório pronto para ser renderizado.
+type Report struct {
+	Type         models.ReportType
+	Title        string
+	Organization string
+	From, To     time.Time
+	GeneratedAt  time.Time
+	Summary      []Field
+	Tables       []Table
+}
+
+// ContentType retorna o tipo MIME do formato.
+func ContentType(format models.ReportFormat) string {
+	if format == models.ReportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/pdf"
+}
+
+// Filename retorna o nome do arquivo do relatório, ex: risk_register-2026-10-19.pdf.
+func Filename(r *Report, format models.ReportFormat) string {
+	return fmt.Sprintf("%s-%s.%s", r.Type, r.To.UTC().Format("2006-01-02"), format)
+}
+
+// Render gera o arquivo no formato informado.
+func Render(r *Report, format models.ReportFormat) ([]byte, error) {
+	switch format {
+	case models.ReportFormatPDF:
+		return RenderPDF(r)
+	case models.ReportFormatXLSX:
+		return RenderXLSX(r)
+	}
+	return nil, fmt.Errorf("unsupported report format %q", format)
+}
+
+// cellText formata uma célula para exibição; números decimais com uma casa.
+func cellText(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', 1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func (r *Report) periodText() string {
+	return fmt.Sprintf("%s a %s", r.From.UTC().Format("02/01/2006"), r.To.UTC().Format("02/01/2006"))
+}
+
+// RenderPDF gera o relatório em PDF: cabeçalho, resumo e uma tabela por seção.
+func RenderPDF(r *Report) ([]byte, error) {
+	doc := pdfdoc.New(r.Title)
+	doc.Footer = fmt.Sprintf("%s - %s - gerado em %s UTC", r.Organization, r.Title, r.GeneratedAt.UTC().Format("02/01/2006 15:04"))
+	doc.Heading(r.Title)
+	doc.Field("Organização", r.Organization)
+	doc.Field("Período", r.periodText())
+
+	doc.Section("Resumo")
+	for _, f := range r.Summary {
+		doc.Field(f.Label, cellText(f.Value))
+	}
+	for _, t := range r.Tables {
+		doc.Section(t.Title)
+		if len(t.Rows) == 0 {
+			doc.Paragraph(t.Empty)
+			continue
+		}
+		rows := make([][]string, len(t.Rows))
+		for i, cells := range t.Rows {
+			rows[i] = make([]string, len(cells))
+			for j, cell := range cells {
+				rows[i][j] = cellText(cell)
+			}
+		}
+		doc.Table(t.Columns, t.Widths, rows)
+		if t.Truncated > 0 {
+			doc.Paragraph(fmt.Sprintf("E mais %d itens não listados.", t.Truncated))
+		}
+	}
+	return doc.Bytes()
+}
+
+// RenderXLSX gera o relatório em XLSX: uma aba de resumo e uma aba por seção, com os números como valores.
+func RenderXLSX(r *Report) ([]byte, error) {
+	wb := xlsxdoc.New()
+	summary := wb.AddSheet("Resumo")
+	summary.Widths = []float64{32, 48}
+	summary.Row(r.Title)
+	summary.Row("Organização", r.Organization)
+	summary.Row("Período", r.periodText())
+	summary.Row("Gerado em (UTC)", r.GeneratedAt.UTC().Format("02/01/2006 15:04"))
+	summary.Row()
+	summary.Header("Indicador", "Valor")
+	for _, f := range r.Summary {
+		summary.Row(f.Label, f.Value)
+	}
+
+	for _, t := range r.Tables {
+		sheet := wb.AddSheet(t.Title)
+		sheet.Widths = make([]float64, len(t.Columns))
+		for i, column := range t.Columns {
+			sheet.Widths[i] = float64(len([]rune(column)) + 4)
+			if i < len(t.Widths) && t.Widths[i] > 1 {
+				sheet.Widths[i] = 14 * t.Widths[i]
+			}
+		}
+		sheet.Header(t.Columns...)
+		for _, cells := range t.Rows {
+			sheet.Row(cells...)
+		}
+		if len(t.Rows) == 0 {
+			sheet.Row(t.Empty)
+		}
+		if t.Truncated > 0 {
+			sheet.Row(fmt.Sprintf("E mais %d itens não listados.", t.Truncated))
+		}
+	}
+	return wb.Bytes()
+}
+
+// riskStatusLabels são os rótulos dos status de risco nos relatórios.
+var riskStatusLabels = map[models.RiskStatus]string{
+	models.StatusOpen:       "Aberto",
+	models.StatusInProgress: "Em andamento",
+	models.StatusMitigated:  "Mitigado",
+	models.StatusAccepted:   "Aceito",
+}
+
+func riskStatusLabel(status models.RiskStatus) string {
+	if label, ok := riskStatusLabels[status]; ok {
+		return label
+	}
+	return strings.TrimSpace(string(status))
+}
+
+// riskLevelRank ordena os níveis de risco do mais grave ao menos grave; níveis personalizados vêm depois.
+func riskLevelRank(level string) int {
+	switch level {
+	case models.RiskLevelExtreme:
+		return 0
+	case models.RiskLevelHigh:
+		return 1
+	case models.RiskLevelModerate:
+		return 2
+	case models.RiskLevelLow:
+		return 3
+	case models.RiskLevelUndefined:
+		return 5
+	}
+	return 4
+}
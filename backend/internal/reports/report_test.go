@@ -0,0 +1,84 @@
+package reports
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleReport() *Report {
+	r := &Report{
+		Type:         models.ReportRiskRegister,
+		Title:        Titles[models.ReportRiskRegister],
+		Organization: "Acme",
+		From:         time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC),
+		To:           time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+		GeneratedAt:  time.Date(2026, 10, 19, 8, 0, 5, 0, time.UTC),
+		Summary:      []Field{{"Riscos cadastrados", int64(42)}, {"Score médio", 87.25}},
+	}
+	top := Table{Title: "Principais riscos abertos", Columns: []string{"Código", "Título", "Aceite"}, Empty: "Nenhum risco aberto."}
+	for i := 0; i < MaxTableRows+3; i++ {
+		top.add("RISK-0001", "Vazamento de dados", nil)
+	}
+	r.Tables = []Table{top, {Title: "Riscos por status", Columns: []string{"Status", "Riscos"}, Empty: "Nenhum risco cadastrado."}}
+	return r
+}
+
+func TestTableAddTruncates(t *testing.T) {
+	r := sampleReport()
+	assert.Len(t, r.Tables[0].Rows, MaxTableRows)
+	assert.Equal(t, 3, r.Tables[0].Truncated)
+}
+
+func TestCellTextAndFilename(t *testing.T) {
+	assert.Equal(t, "-", cellText(nil))
+	assert.Equal(t, "87.3", cellText(87.26))
+	assert.Equal(t, "42", cellText(int64(42)))
+	assert.Equal(t, "risk_register-2026-10-19.xlsx", Filename(sampleReport(), models.ReportFormatXLSX))
+}
+
+func TestRiskLevelRank(t *testing.T) {
+	levels := []string{"Baixo", "Indefinido", "Crítico personalizado", "Extremo", "Moderado", "Alto"}
+	sort.SliceStable(levels, func(i, j int) bool { return riskLevelRank(levels[i]) < riskLevelRank(levels[j]) })
+	assert.Equal(t, []string{"Extremo", "Alto", "Moderado", "Baixo", "Crítico personalizado", "Indefinido"}, levels)
+}
+
+func TestRenderPDF(t *testing.T) {
+	out, err := Render(sampleReport(), models.ReportFormatPDF)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.Contains(t, string(out), "(E mais 3 itens n\xe3o listados.) Tj")
+	assert.Contains(t, string(out), "(Nenhum risco cadastrado.) Tj")
+}
+
+func TestRenderXLSX(t *testing.T) {
+	out, err := Render(sampleReport(), models.ReportFormatXLSX)
+	require.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+	sheets := map[string]string{}
+	for _, f := range zr.File {
+		if strings.HasPrefix(f.Name, "xl/worksheets/") {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, _ := io.ReadAll(rc)
+			rc.Close()
+			sheets[f.Name] = string(content)
+		}
+	}
+	require.Len(t, sheets, 3, "resumo e uma aba por tabela")
+	assert.Contains(t, sheets["xl/worksheets/sheet1.xml"], "<v>42</v>", "números como valores")
+	assert.Contains(t, sheets["xl/worksheets/sheet2.xml"], "E mais 3 itens não listados.")
+
+	_, err = Render(sampleReport(), "csv")
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/jobs"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/notifications"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// claimBatchSize é o número máximo de agendamentos executados por ciclo do agendador.
+const claimBatchSize = 20
+
+// claimDueSchedules reserva os agendamentos vencidos em now, avançando o próximo envio e enfileirando um
+// JobScheduledReport para cada um na mesma transação. SKIP LOCKED evita que duas instâncias executem o mesmo
+// agendamento.
+func claimDueSchedules(ctx context.Context, db *gorm.DB, now time.Time) ([]models.ReportSchedule, error) {
+	var schedules []models.ReportSchedule
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("enabled = ? AND next_run_at <= ?", true, now).
+			Order("next_run_at").Limit(claimBatchSize).Find(&schedules).Error; err != nil {
+			return err
+		}
+		for i := range schedules {
+			next := schedules[i].NextRunAfter(now)
+			if err := tx.Model(&models.ReportSchedule{}).Where("id = ?", schedules[i].ID).
+				Update("next_run_at", next).Error; err != nil {
+				return err
+			}
+			schedules[i].NextRunAt = next
+			payload := scheduledReportJob{ScheduleID: schedules[i].ID, RunAt: now}
+			if _, err := jobs.Enqueue(ctx, tx, JobScheduledReport, payload, jobs.WithOrganization(schedules[i].OrganizationID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return schedules, err
+}
+
+// RunDueSchedules enfileira o envio dos relatórios dos agendamentos vencidos em now; a geração e o envio
+// ocorrem nos workers da fila de jobs. Um envio que falha não é repetido até o próximo horário do
+// agendamento; o erro fica em LastError.
+func RunDueSchedules(ctx context.Context, db *gorm.DB, now time.Time) (int, error) {
+	schedules, err := claimDueSchedules(ctx, db, now)
+	if err != nil {
+		return 0, err
+	}
+	return len(schedules), nil
+}
+
+// Deliver gera o relatório do agendamento para o período que termina em runAt, envia o arquivo a cada
+// destinatário e registra o resultado no agendamento.
+func Deliver(ctx context.Context, db *gorm.DB, schedule *models.ReportSchedule, runAt time.Time) error {
+	err := deliver(ctx, db, schedule, runAt)
+	schedule.LastRunAt, schedule.LastStatus, schedule.LastError = &runAt, models.ReportRunSucceeded, ""
+	if err != nil {
+		schedule.LastStatus, schedule.LastError = models.ReportRunFailed, err.Error()
+	}
+	if errUpdate := db.WithContext(ctx).Model(&models.ReportSchedule{}).Where("id = ?", schedule.ID).
+		Updates(map[string]interface{}{"last_run_at": runAt, "last_status": schedule.LastStatus, "last_error": schedule.LastError}).Error; errUpdate != nil {
+		phxlog.L.Error("Failed to record report schedule run", zap.String("scheduleID", schedule.ID.String()), zap.Error(errUpdate))
+	}
+	return err
+}
+
+func deliver(ctx context.Context, db *gorm.DB, schedule *models.ReportSchedule, runAt time.Time) error {
+	from, to := schedule.Period(runAt)
+	report, err := Build(ctx, db, schedule.OrganizationID, schedule.ReportType, from, to)
+	if err != nil {
+		return err
+	}
+	data, err := Render(report, schedule.Format)
+	if err != nil {
+		return err
+	}
+	attachment := notifications.Attachment{Filename: Filename(report, schedule.Format), ContentType: ContentType(schedule.Format), Data: data}
+
+	frontendBaseURL := config.Cfg.FrontendBaseURL
+	if frontendBaseURL == "" {
+		frontendBaseURL = "http://localhost:3000" // Fallback
+	}
+	subject := fmt.Sprintf("%s - %s (%s)", schedule.Name, report.Organization, report.periodText())
+	body := fmt.Sprintf("Olá,\n\nSegue em anexo o relatório \"%s\" (%s) da organização %s, referente ao período de %s.\n\n"+
+		"Este e-mail é enviado automaticamente. Para alterar a lista de distribuição, acesse: %s/settings/reports",
+		schedule.Name, report.Title, report.Organization, report.periodText(), strings.TrimSuffix(frontendBaseURL, "/"))
+
+	var failed []string
+	recipients := schedule.RecipientList()
+	for _, recipient := range recipients {
+		if err := notifications.SendEmailWithAttachments(ctx, recipient, subject, body, []notifications.Attachment{attachment}); err != nil {
+			phxlog.L.Error("Failed to email scheduled report",
+				zap.String("scheduleID", schedule.ID.String()),
+				zap.String("to", recipient),
+				zap.Error(err))
+			failed = append(failed, recipient)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to deliver report to %d of %d recipients: %s", len(failed), len(recipients), strings.Join(failed, ", "))
+	}
+	phxlog.L.Info("Scheduled report delivered",
+		zap.String("scheduleID", schedule.ID.String()),
+		zap.String("reportType", string(schedule.ReportType)),
+		zap.Int("recipients", len(recipients)))
+	return nil
+}
+
+// StartScheduler enfileira os relatórios agendados periodicamente até o contexto ser cancelado.
+func StartScheduler(ctx context.Context, every time.Duration) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				count, err := RunDueSchedules(ctx, database.GetDB(), now)
+				if err != nil {
+					phxlog.L.Error("Scheduled report run failed", zap.Error(err))
+				}
+				if count > 0 {
+					phxlog.L.Debug("Scheduled reports enqueued", zap.Int("count", count))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,248 @@
+// Package retention aplica as regras de retenção de dados das organizações (limitação da conservação,
+// LGPD/GDPR): os registros de cada categoria mais antigos que o prazo configurado são expurgados ou, no caso
+// dos usuários, anonimizados. O relatório (Evaluate) mostra o que a próxima execução trataria sem alterar nada.
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/privacy"
+	"phoenixgrc/backend/internal/trash"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Prazos aceitos numa regra de retenção, em dias.
+const (
+	MinRetentionDays = 30
+	MaxRetentionDays = 36500
+)
+
+// Action é o tratamento dado aos registros vencidos de uma categoria.
+type Action string
+
+const (
+	ActionPurge     Action = "purge"     // Os registros são removidos definitivamente
+	ActionAnonymize Action = "anonymize" // Os dados pessoais são removidos e o registro é mantido
+)
+
+// CategoryAction retorna o tratamento da categoria e se ela existe.
+func CategoryAction(category models.RetentionCategory) (Action, bool) {
+	switch category {
+	case models.RetentionClosedRisks, models.RetentionClosedVulnerabilities, models.RetentionAuditTrail, models.RetentionNotifications:
+		return ActionPurge, true
+	case models.RetentionInactiveUsers:
+		return ActionAnonymize, true
+	}
+	return "", false
+}
+
+// Item é o resultado de uma regra: quantos registros estão vencidos (relatório) ou foram tratados (execução).
+type Item struct {
+	Category      models.RetentionCategory `json:"category"`
+	Action        Action                   `json:"action"`
+	RetentionDays int                      `json:"retention_days"`
+	Cutoff        time.Time                `json:"cutoff"` // Registros anteriores a este instante estão vencidos
+	Records       int64                    `json:"records"`
+}
+
+// Report reúne os resultados das regras da organização.
+type Report struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	GeneratedAt    time.Time `json:"generated_at"`
+	DryRun         bool      `json:"dry_run"`
+	Items          []Item    `json:"items"`
+	TotalRecords   int64     `json:"total_records"`
+}
+
+// LoadRules retorna as regras de retenção da organização, na ordem de models.RetentionCategories.
+func LoadRules(db *gorm.DB, organizationID uuid.UUID) ([]models.DataRetentionRule, error) {
+	var stored []models.DataRetentionRule
+	if err := db.Where("organization_id = ?", organizationID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	byCategory := map[models.RetentionCategory]models.DataRetentionRule{}
+	for _, rule := range stored {
+		byCategory[rule.Category] = rule
+	}
+	rules := []models.DataRetentionRule{}
+	for _, category := range models.RetentionCategories {
+		if rule, ok := byCategory[category]; ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// Cutoff é o instante antes do qual os registros da regra estão vencidos.
+func Cutoff(rule models.DataRetentionRule, now time.Time) time.Time {
+	return now.AddDate(0, 0, -rule.RetentionDays)
+}
+
+// expired seleciona os registros da organização vencidos em cutoff na categoria.
+func expired(db *gorm.DB, organizationID uuid.UUID, category models.RetentionCategory, cutoff time.Time) *gorm.DB {
+	switch category {
+	case models.RetentionClosedRisks:
+		// Inclui os riscos da lixeira: o prazo da organização prevalece sobre o da lixeira
+		return db.Unscoped().Model(&models.Risk{}).Where("organization_id = ? AND status IN ? AND updated_at < ?",
+			organizationID, []models.RiskStatus{models.StatusMitigated, models.StatusAccepted}, cutoff)
+	case models.RetentionClosedVulnerabilities:
+		return db.Model(&models.Vulnerability{}).Where("organization_id = ? AND status = ? AND updated_at < ?",
+			organizationID, models.VStatusRemediated, cutoff)
+	case models.RetentionAuditTrail:
+		return db.Model(&models.AuditTrailEntry{}).Where("organization_id = ? AND created_at < ?", organizationID, cutoff)
+	case models.RetentionNotifications:
+		users := db.Model(&models.User{}).Select("id").Where("organization_id = ?", organizationID)
+		return db.Model(&models.Notification{}).Where("user_id IN (?) AND created_at < ?", users, cutoff)
+	case models.RetentionInactiveUsers:
+		return db.Model(&models.User{}).Where("organization_id = ? AND is_active = ? AND anonymized_at IS NULL AND updated_at < ?",
+			organizationID, false, cutoff)
+	}
+	return nil
+}
+
+// Evaluate conta, sem alterar nada, os registros que a aplicação das regras da organização trataria em now.
+func Evaluate(db *gorm.DB, organizationID uuid.UUID, now time.Time) (*Report, error) {
+	rules, err := LoadRules(db, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{OrganizationID: organizationID, GeneratedAt: now, DryRun: true, Items: []Item{}}
+	for _, rule := range rules {
+		item := newItem(rule, now)
+		if err := expired(db, organizationID, rule.Category, item.Cutoff).Count(&item.Records).Error; err != nil {
+			return nil, fmt.Errorf("error counting %s: %w", rule.Category, err)
+		}
+		report.Items = append(report.Items, item)
+		report.TotalRecords += item.Records
+	}
+	return report, nil
+}
+
+// Apply expurga ou anonimiza os registros vencidos em now segundo as regras da organização, uma categoria por
+// transação, e registra o resultado na trilha de auditoria quando algo foi tratado.
+func Apply(db *gorm.DB, organizationID uuid.UUID, now time.Time) (*Report, error) {
+	rules, err := LoadRules(db, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{OrganizationID: organizationID, GeneratedAt: now, Items: []Item{}}
+	for _, rule := range rules {
+		item := newItem(rule, now)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			item.Records, err = applyRule(tx, organizationID, rule.Category, item.Cutoff, now)
+			return err
+		})
+		if err != nil {
+			return report, fmt.Errorf("error applying %s retention: %w", rule.Category, err)
+		}
+		report.Items = append(report.Items, item)
+		report.TotalRecords += item.Records
+	}
+	if report.TotalRecords == 0 {
+		return report, nil
+	}
+
+	details, err := json.Marshal(map[string]interface{}{"items": report.Items})
+	if err != nil {
+		return report, err
+	}
+	detailsJSON := string(details)
+	entry := models.AuditTrailEntry{
+		OrganizationID: organizationID,
+		Action:         models.AuditTrailRetentionApplied,
+		EntityType:     "retention_policy",
+		Summary:        fmt.Sprintf("Data retention applied: %d records purged or anonymized", report.TotalRecords),
+		Details:        &detailsJSON,
+	}
+	return report, db.Create(&entry).Error
+}
+
+func newItem(rule models.DataRetentionRule, now time.Time) Item {
+	action, _ := CategoryAction(rule.Category)
+	return Item{Category: rule.Category, Action: action, RetentionDays: rule.RetentionDays, Cutoff: Cutoff(rule, now)}
+}
+
+// applyRule trata os registros vencidos da categoria na transação tx e retorna quantos tratou.
+func applyRule(tx *gorm.DB, organizationID uuid.UUID, category models.RetentionCategory, cutoff, now time.Time) (int64, error) {
+	switch category {
+	case models.RetentionClosedRisks:
+		return trash.DeleteRisks(tx, "organization_id = ? AND status IN ? AND updated_at < ?",
+			organizationID, []models.RiskStatus{models.StatusMitigated, models.StatusAccepted}, cutoff)
+	case models.RetentionInactiveUsers:
+		var users []models.User
+		if err := expired(tx, organizationID, category, cutoff).Find(&users).Error; err != nil {
+			return 0, err
+		}
+		for i := range users {
+			if err := privacy.AnonymizeUser(tx, &users[i], now); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(users)), nil
+	}
+	var model interface{}
+	switch category {
+	case models.RetentionClosedVulnerabilities:
+		model = &models.Vulnerability{}
+	case models.RetentionAuditTrail:
+		model = &models.AuditTrailEntry{}
+	case models.RetentionNotifications:
+		model = &models.Notification{}
+	default:
+		return 0, fmt.Errorf("unknown retention category %q", category)
+	}
+	result := expired(tx, organizationID, category, cutoff).Delete(model)
+	return result.RowsAffected, result.Error
+}
+
+// Run aplica as regras de todas as organizações que têm alguma e retorna quantos registros foram tratados.
+// A falha numa organização não impede as demais.
+func Run(ctx context.Context, db *gorm.DB, now time.Time) int64 {
+	var organizationIDs []uuid.UUID
+	if err := db.Model(&models.DataRetentionRule{}).Distinct("organization_id").Pluck("organization_id", &organizationIDs).Error; err != nil {
+		phxlog.L.Error("Failed to list organizations with retention rules", zap.Error(err))
+		return 0
+	}
+	var total int64
+	for _, organizationID := range organizationIDs {
+		if ctx.Err() != nil {
+			break
+		}
+		report, err := Apply(db, organizationID, now)
+		if report != nil {
+			total += report.TotalRecords
+		}
+		if err != nil {
+			phxlog.L.Error("Data retention failed", zap.String("organizationID", organizationID.String()), zap.Error(err))
+		}
+	}
+	return total
+}
+
+// StartScheduler aplica as regras de retenção a cada intervalo até o contexto ser cancelado.
+func StartScheduler(ctx context.Context, every time.Duration) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if count := Run(ctx, database.GetDB().WithContext(ctx), now); count > 0 {
+					phxlog.L.Info("Expired records purged or anonymized by retention rules", zap.Int64("count", count))
+				}
+			}
+		}
+	}()
+}
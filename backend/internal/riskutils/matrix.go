@@ -0,0 +1,130 @@
+package riskutils
+
+import (
+	"fmt"
+
+	"phoenixgrc/backend/internal/models"
+)
+
+// Dimensões da matriz de calor (heatmap). A escala de impacto e de probabilidade tem quatro níveis; em
+// matrizes menores, níveis vizinhos são agrupados na mesma faixa.
+const (
+	MatrixLevels  = 4
+	MinMatrixSize = 2
+)
+
+// ImpactLevels e ProbabilityLevels são os níveis em ordem crescente.
+var (
+	ImpactLevels      = []models.RiskImpact{models.ImpactLow, models.ImpactMedium, models.ImpactHigh, models.ImpactCritical}
+	ProbabilityLevels = []models.RiskProbability{models.ProbabilityLow, models.ProbabilityMedium, models.ProbabilityHigh, models.ProbabilityCritical}
+)
+
+// MatrixIndex retorna a faixa (0 a size-1) de um nível da escala (1 a MatrixLevels) em uma matriz
+// size x size, ou -1 se o nível for inválido.
+func MatrixIndex(value, size int) int {
+	if value < 1 || value > MatrixLevels || size < MinMatrixSize || size > MatrixLevels {
+		return -1
+	}
+	return (value - 1) * size / MatrixLevels
+}
+
+// MatrixCell retorna a linha (probabilidade) e a coluna (impacto) do risco na matriz size x size; ok é
+// false se o impacto ou a probabilidade não estiverem na escala.
+func MatrixCell(impact models.RiskImpact, probability models.RiskProbability, size int) (row, col int, ok bool) {
+	row = MatrixIndex(mapProbabilityToValue(probability), size)
+	col = MatrixIndex(mapImpactToValue(impact), size)
+	return row, col, row >= 0 && col >= 0
+}
+
+// MatrixLabels retorna o rótulo de cada faixa a partir dos níveis em ordem crescente, ex: "Baixo" na
+// matriz 4x4 e "Baixo-Médio" na 2x2.
+func MatrixLabels(levels []string, size int) []string {
+	labels := make([]string, size)
+	last := make([]string, size)
+	for i, level := range levels {
+		index := MatrixIndex(i+1, size)
+		if index < 0 {
+			continue
+		}
+		if labels[index] == "" {
+			labels[index] = level
+		}
+		last[index] = level
+	}
+	for i := range labels {
+		if last[i] != labels[i] {
+			labels[i] += "-" + last[i]
+		}
+	}
+	return labels
+}
+
+// RiskLevels são os níveis de risco em ordem crescente de gravidade.
+var RiskLevels = []string{models.RiskLevelLow, models.RiskLevelModerate, models.RiskLevelHigh, models.RiskLevelExtreme}
+
+// LevelRank retorna a posição do nível em RiskLevels (1 = Baixo, 4 = Extremo), ou 0 se desconhecido.
+func LevelRank(level string) int {
+	for i, l := range RiskLevels {
+		if l == level {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ScoringMatrix é a matriz de pontuação de riscos: o nível de cada célula de uma matriz Size x Size, com
+// Cells[linha][coluna], a linha pela probabilidade e a coluna pelo impacto, do mais baixo ao mais alto.
+type ScoringMatrix struct {
+	Size  int        `json:"size"`
+	Cells [][]string `json:"cells"`
+}
+
+// DefaultScoringMatrix é a matriz 4x4 padrão, usada pelas organizações sem matriz configurada.
+func DefaultScoringMatrix() ScoringMatrix {
+	m := ScoringMatrix{Size: MatrixLevels, Cells: make([][]string, MatrixLevels)}
+	for row, probability := range ProbabilityLevels {
+		m.Cells[row] = make([]string, MatrixLevels)
+		for col, impact := range ImpactLevels {
+			m.Cells[row][col] = CalculateRiskLevel(impact, probability)
+		}
+	}
+	return m
+}
+
+// Validate confere as dimensões e os níveis da matriz. O nível não pode diminuir quando o impacto ou a
+// probabilidade aumentam.
+func (m ScoringMatrix) Validate() error {
+	if m.Size < MinMatrixSize || m.Size > MatrixLevels {
+		return fmt.Errorf("size must be between %d and %d", MinMatrixSize, MatrixLevels)
+	}
+	if len(m.Cells) != m.Size {
+		return fmt.Errorf("cells must have %d rows", m.Size)
+	}
+	for row, cells := range m.Cells {
+		if len(cells) != m.Size {
+			return fmt.Errorf("row %d must have %d cells", row, m.Size)
+		}
+		for col, level := range cells {
+			rank := LevelRank(level)
+			if rank == 0 {
+				return fmt.Errorf("invalid level %q at row %d, column %d", level, row, col)
+			}
+			if col > 0 && rank < LevelRank(cells[col-1]) {
+				return fmt.Errorf("level at row %d, column %d is lower than at column %d", row, col, col-1)
+			}
+			if row > 0 && rank < LevelRank(m.Cells[row-1][col]) {
+				return fmt.Errorf("level at row %d, column %d is lower than at row %d", row, col, row-1)
+			}
+		}
+	}
+	return nil
+}
+
+// Level retorna o nível de risco do impacto e da probabilidade, ou Indefinido se algum estiver fora da escala.
+func (m ScoringMatrix) Level(impact models.RiskImpact, probability models.RiskProbability) string {
+	row, col, ok := MatrixCell(impact, probability, m.Size)
+	if !ok || row >= len(m.Cells) || col >= len(m.Cells[row]) {
+		return models.RiskLevelUndefined
+	}
+	return m.Cells[row][col]
+}
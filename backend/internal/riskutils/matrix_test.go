@@ -0,0 +1,71 @@
+package riskutils
+
+import (
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixCell(t *testing.T) {
+	row, col, ok := MatrixCell(models.ImpactCritical, models.ProbabilityLow, 4)
+	assert.True(t, ok)
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 3, col)
+
+	// 2x2: Baixo e Médio na primeira faixa, Alto e Crítico na segunda
+	row, col, ok = MatrixCell(models.ImpactMedium, models.ProbabilityHigh, 2)
+	assert.True(t, ok)
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 0, col)
+
+	_, _, ok = MatrixCell("", models.ProbabilityHigh, 4)
+	assert.False(t, ok)
+	_, _, ok = MatrixCell(models.ImpactLow, models.ProbabilityLow, 5)
+	assert.False(t, ok)
+}
+
+func TestMatrixLabels(t *testing.T) {
+	levels := []string{"Baixo", "Médio", "Alto", "Crítico"}
+	assert.Equal(t, levels, MatrixLabels(levels, 4))
+	assert.Equal(t, []string{"Baixo-Médio", "Alto", "Crítico"}, MatrixLabels(levels, 3))
+	assert.Equal(t, []string{"Baixo-Médio", "Alto-Crítico"}, MatrixLabels(levels, 2))
+}
+
+func TestDefaultScoringMatrixMatchesCalculateRiskLevel(t *testing.T) {
+	m := DefaultScoringMatrix()
+	assert.NoError(t, m.Validate())
+	for _, impact := range ImpactLevels {
+		for _, probability := range ProbabilityLevels {
+			assert.Equal(t, CalculateRiskLevel(impact, probability), m.Level(impact, probability), "%s x %s", impact, probability)
+		}
+	}
+	assert.Equal(t, models.RiskLevelUndefined, m.Level("", models.ProbabilityLow))
+}
+
+func TestScoringMatrixValidate(t *testing.T) {
+	m := ScoringMatrix{Size: 2, Cells: [][]string{
+		{models.RiskLevelLow, models.RiskLevelHigh},
+		{models.RiskLevelModerate, models.RiskLevelExtreme},
+	}}
+	assert.NoError(t, m.Validate())
+	assert.Equal(t, models.RiskLevelHigh, m.Level(models.ImpactCritical, models.ProbabilityMedium))
+
+	assert.Error(t, ScoringMatrix{Size: 5}.Validate())
+	assert.Error(t, ScoringMatrix{Size: 2, Cells: [][]string{{models.RiskLevelLow, models.RiskLevelLow}}}.Validate())
+	assert.Error(t, ScoringMatrix{Size: 2, Cells: [][]string{
+		{models.RiskLevelLow, "Crítico"},
+		{models.RiskLevelLow, models.RiskLevelLow},
+	}}.Validate())
+	// Nível diminui com o impacto
+	assert.Error(t, ScoringMatrix{Size: 2, Cells: [][]string{
+		{models.RiskLevelHigh, models.RiskLevelLow},
+		{models.RiskLevelHigh, models.RiskLevelHigh},
+	}}.Validate())
+	// Nível diminui com a probabilidade
+	assert.Error(t, ScoringMatrix{Size: 2, Cells: [][]string{
+		{models.RiskLevelHigh, models.RiskLevelHigh},
+		{models.RiskLevelLow, models.RiskLevelHigh},
+	}}.Validate())
+}
@@ -1,16 +1,21 @@
 package router
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"phoenixgrc/backend/internal/auth"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/handlers"
+	"phoenixgrc/backend/internal/health"
+	"phoenixgrc/backend/internal/i18n"
 	phxmiddleware "phoenixgrc/backend/internal/middleware"
 	"phoenixgrc/backend/internal/models"
 	"phoenixgrc/backend/internal/oauth2auth"
+	"phoenixgrc/backend/internal/ratelimit"
 	"phoenixgrc/backend/internal/samlauth"
+	"phoenixgrc/backend/pkg/config"
 	phxlog "phoenixgrc/backend/pkg/log"
 
 	"github.com/gin-gonic/gin"
@@ -18,13 +23,34 @@ import (
 	"go.uber.org/zap"
 )
 
+// apiRateLimiter limita as requisições autenticadas de cada usuário na API v1.
+var apiRateLimiter = ratelimit.New("api", 1200, time.Minute)
+
+// rateLimitRedisPrefix agrupa no Redis as chaves dos baldes de limitação.
+const rateLimitRedisPrefix = "phoenixgrc:ratelimit:"
+
 // SetupRouter configura e retorna uma instância do Gin Engine.
 func SetupRouter(log *zap.Logger) *gin.Engine {
 	router := gin.New()
 
+	configureRateLimit(log)
+
+	// IP do cliente (c.ClientIP) usado na auditoria, na política de acesso às evidências e nos limites por IP.
+	// Só os proxies de TRUSTED_PROXIES podem informá-lo via X-Forwarded-For; sem eles, vale o IP da conexão
+	if err := router.SetTrustedProxies(config.Cfg.TrustedProxies); err != nil {
+		log.Error("Invalid TRUSTED_PROXIES, X-Forwarded-For will be ignored", zap.Error(err))
+		_ = router.SetTrustedProxies(nil)
+	}
+
+	// Erros de validação do binding com nomes de campo json, traduzíveis pelo middleware Localize
+	i18n.InstallValidator()
+
 	// Adicionar middlewares globais
+	router.Use(phxmiddleware.RequestID())
+	router.Use(phxmiddleware.Tracing())
 	router.Use(phxmiddleware.Metrics())
 	router.Use(phxmiddleware.GinZap(log, time.RFC3339, true))
+	router.Use(phxmiddleware.Localize())
 	router.Use(phxmiddleware.GinRecovery(log, time.RFC3339, true, true))
 
 	// Endpoint para métricas Prometheus
@@ -32,6 +58,8 @@ func SetupRouter(log *zap.Logger) *gin.Engine {
 
 	// Rotas de Saúde
 	router.GET("/health", healthCheckHandler)
+	router.GET("/healthz", livenessHandler)
+	router.GET("/readyz", readinessHandler)
 
 	// Rotas Públicas (sem autenticação JWT)
 	setupPublicRoutes(router)
@@ -45,6 +73,29 @@ func SetupRouter(log *zap.Logger) *gin.Engine {
 	return router
 }
 
+// configureRateLimit aplica RATE_LIMIT_* aos limitadores. Sem o Redis, a limitação continua em memória, por
+// instância; uma política inválida mantém as padrão.
+func configureRateLimit(log *zap.Logger) {
+	opts := ratelimit.Options{Enabled: config.Cfg.RateLimitEnabled, Policies: config.Cfg.RateLimitPolicies}
+	if config.Cfg.RateLimitEnabled && config.Cfg.RateLimitStore == "redis" {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		store, err := ratelimit.NewRedisStoreFromURL(ctx, config.Cfg.RedisURL, rateLimitRedisPrefix)
+		cancel()
+		if err != nil {
+			log.Error("Rate limit Redis store unavailable, using in-memory limits", zap.Error(err))
+		} else {
+			opts.Store = store
+		}
+	} else if config.Cfg.RateLimitStore != "memory" {
+		log.Warn("Unknown RATE_LIMIT_STORE, using in-memory limits", zap.String("store", config.Cfg.RateLimitStore))
+	}
+	if err := ratelimit.Configure(opts); err != nil {
+		log.Error("Invalid rate limit policy, using the defaults", zap.Error(err))
+		opts.Policies = nil
+		_ = ratelimit.Configure(opts)
+	}
+}
+
 func healthCheckHandler(c *gin.Context) {
 	// Obter a instância do banco de dados SQL do GORM
 	sqlDB, err := database.DB.DB()
@@ -68,12 +119,47 @@ func healthCheckHandler(c *gin.Context) {
 	})
 }
 
+// livenessHandler responde 200 enquanto o processo atende requisições, sem verificar dependências: uma falha
+// no banco não deve fazer o orquestrador reiniciar a API.
+func livenessHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// readinessHandler informa se a API pode receber tráfego, com a situação de cada componente: banco de dados,
+// migrações, armazenamento de arquivos, e-mail e os subsistemas inicializados na subida. Responde 503 se um
+// componente crítico estiver indisponível. Componentes opcionais indisponíveis (ex: SAML com certificado
+// vencido, e-mail não configurado) aparecem como "degraded", com 200, para não retirar a API de operação.
+func readinessHandler(c *gin.Context) {
+	report := health.Default.Evaluate(c.Request.Context(), health.ServiceChecks(), health.DefaultCheckTimeout)
+	c.JSON(report.HTTPStatus(), report)
+}
+
 func setupPublicRoutes(r *gin.Engine) {
 	publicApi := r.Group("/api/public")
 	{
 		publicApi.GET("/social-identity-providers", handlers.ListGlobalSocialIdentityProvidersHandler)
 		publicApi.GET("/saml-identity-providers", handlers.ListGlobalSAMLIdentityProvidersHandler)
 		publicApi.GET("/setup-status", handlers.GetSetupStatusHandler)
+		// Política padrão, para a configuração inicial
+		publicApi.GET("/password-policy", handlers.GetDefaultPasswordPolicyHandler)
+		publicApi.GET("/files/local", handlers.ServeLocalFileHandler) // URLs assinadas do provider de armazenamento local
+		publicApi.GET("/trust-center/:slug", handlers.GetPublicTrustCenterHandler)
+		publicApi.GET("/login-page", handlers.GetPublicLoginPageHandler) // ?slug= ou ?domain=
+
+		// Portal de questionários para respondentes externos (autenticado pelo token do link)
+		questionnairePortal := publicApi.Group("/questionnaire-portal/:token", handlers.QuestionnairePortalAuthMiddleware())
+		{
+			questionnairePortal.GET("", handlers.GetQuestionnairePortalHandler)
+			questionnairePortal.PUT("/answers", handlers.SaveQuestionnairePortalAnswersHandler)
+			questionnairePortal.POST("/submit", handlers.SubmitQuestionnairePortalHandler)
+		}
+
+		// Portal de evidências para colaboradores (autenticado pelo token do link)
+		evidencePortal := publicApi.Group("/evidence-portal/:token", handlers.EvidencePortalAuthMiddleware())
+		{
+			evidencePortal.GET("", handlers.GetEvidencePortalHandler)
+			evidencePortal.POST("/requests/:requestId/upload", handlers.UploadEvidencePortalHandler)
+		}
 	}
 }
 
@@ -81,7 +167,10 @@ func setupAuthRoutes(r *gin.Engine) {
 	authRoutes := r.Group("/auth")
 	{
 		authRoutes.POST("/setup", handlers.PerformSetupHandler)
-		authRoutes.POST("/login", handlers.LoginHandler)
+		authRoutes.POST("/login", phxmiddleware.RateLimitByIP(handlers.LoginRateLimiter),
+			phxmiddleware.RateLimit(handlers.LoginAccountRateLimiter, phxmiddleware.RateLimitKeyJSONField("email")), handlers.LoginHandler)
+		authRoutes.POST("/refresh", handlers.RefreshTokenHandler)
+		authRoutes.GET("/sso/discover", phxmiddleware.RateLimitByIP(handlers.SSODiscoveryRateLimiter), handlers.DiscoverSSOHandler)
 
 		samlIdPGroup := authRoutes.Group("/saml/:idpId")
 		{
@@ -102,16 +191,30 @@ func setupAuthRoutes(r *gin.Engine) {
 			oauth2GithubGroup.GET("/callback", oauth2auth.GithubCallbackHandler)
 		}
 
-		authRoutes.POST("/login/2fa/verify", handlers.LoginVerifyTOTPHandler)
-		authRoutes.POST("/login/2fa/backup-code/verify", handlers.LoginVerifyBackupCodeHandler)
-		authRoutes.POST("/forgot-password", handlers.ForgotPasswordHandler)
-		authRoutes.POST("/reset-password", handlers.ResetPasswordHandler)
+		// Os três métodos de segundo fator compartilham os limites, por IP e por usuário
+		mfaVerifyRateLimit := []gin.HandlerFunc{phxmiddleware.RateLimitByIP(handlers.MFAVerifyRateLimiter),
+			phxmiddleware.RateLimit(handlers.MFAVerifyAccountRateLimiter, phxmiddleware.RateLimitKeyJSONField("user_id"))}
+		authRoutes.POST("/login/2fa/verify", append(mfaVerifyRateLimit, handlers.LoginVerifyTOTPHandler)...)
+		authRoutes.POST("/login/2fa/backup-code/verify", append(mfaVerifyRateLimit, handlers.LoginVerifyBackupCodeHandler)...)
+		authRoutes.POST("/login/2fa/webauthn/verify", append(mfaVerifyRateLimit, handlers.LoginVerifyWebAuthnHandler)...)
+		authRoutes.POST("/forgot-password", phxmiddleware.RateLimitByIP(handlers.ForgotPasswordRateLimiter), handlers.ForgotPasswordHandler)
+		resetPasswordRateLimit := phxmiddleware.RateLimitByIP(handlers.ResetPasswordRateLimiter)
+		authRoutes.POST("/reset-password/verify", resetPasswordRateLimit, handlers.VerifyResetTokenHandler)
+		authRoutes.POST("/reset-password", resetPasswordRateLimit, handlers.ResetPasswordHandler)
+		authRoutes.POST("/change-password", phxmiddleware.RateLimitByIP(handlers.ChangePasswordRateLimiter),
+			phxmiddleware.RateLimit(handlers.ChangePasswordAccountRateLimiter, phxmiddleware.RateLimitKeyJSONField("email")), handlers.ChangePasswordHandler)
+		authRoutes.GET("/invitations/:token", handlers.GetPublicInvitationHandler)
+		authRoutes.POST("/invitations/accept", handlers.AcceptInvitationHandler)
 	}
 }
 
 func setupV1Routes(r *gin.Engine) {
 	apiV1 := r.Group("/api/v1")
 	apiV1.Use(auth.AuthMiddleware())
+	apiV1.Use(phxmiddleware.RateLimit(apiRateLimiter, phxmiddleware.RateLimitKeyUser))
+	apiV1.Use(phxmiddleware.APIUsage())
+	// Criações e importações que o cliente pode repetir com segurança usando o header Idempotency-Key
+	idempotent := phxmiddleware.Idempotency()
 	{
 		apiV1.GET("/me", func(c *gin.Context) {
 			userID, _ := c.Get("userID")
@@ -130,12 +233,15 @@ func setupV1Routes(r *gin.Engine) {
 		// Risk Routes
 		riskRoutes := apiV1.Group("/risks")
 		{
-			riskRoutes.POST("", handlers.CreateRiskHandler)
+			riskRoutes.POST("", idempotent, handlers.CreateRiskHandler)
 			riskRoutes.GET("", handlers.ListRisksHandler)
+			riskRoutes.GET("/trash", handlers.ListDeletedRisksHandler)
 			riskRoutes.GET("/:riskId", handlers.GetRiskHandler)
 			riskRoutes.PUT("/:riskId", handlers.UpdateRiskHandler)
 			riskRoutes.DELETE("/:riskId", handlers.DeleteRiskHandler)
-			riskRoutes.POST("/bulk-upload-csv", handlers.BulkUploadRisksCSVHandler)
+			riskRoutes.POST("/:riskId/restore", handlers.RestoreRiskHandler)
+			riskRoutes.POST("/bulk-upload-csv", idempotent, handlers.BulkUploadRisksCSVHandler)
+			riskRoutes.POST("/bulk-upload-csv/preflight", handlers.PreflightRiskCSVUploadHandler)
 			riskRoutes.POST("/:riskId/submit-acceptance", handlers.SubmitRiskForAcceptanceHandler)
 			riskRoutes.GET("/:riskId/approval-history", handlers.GetRiskApprovalHistoryHandler)
 			riskRoutes.POST("/:riskId/approval/:approvalId/decide", handlers.ApproveOrRejectRiskAcceptanceHandler)
@@ -146,11 +252,30 @@ func setupV1Routes(r *gin.Engine) {
 				stakeholderRoutes.GET("", handlers.ListRiskStakeholdersHandler)
 				stakeholderRoutes.DELETE("/:userId", handlers.RemoveRiskStakeholderHandler)
 			}
+			riskRoutes.GET("/:riskId/assets", handlers.ListRiskAssetsHandler)
+			riskRoutes.GET("/:riskId/controls", handlers.ListRiskControlsHandler)
+			riskRoutes.POST("/:riskId/controls", handlers.AddRiskControlsHandler)
+			riskRoutes.DELETE("/:riskId/controls/:controlId", handlers.RemoveRiskControlHandler)
 		}
 
 		// Organization Routes
 		orgRoutes := apiV1.Group("/organizations/:orgId")
 		{
+			orgRoutes.GET("/dashboard", handlers.GetOrganizationDashboardHandler)
+			orgRoutes.GET("/search", handlers.SearchHandler)
+			orgRoutes.GET("/references/:code", handlers.LookupReferenceHandler)
+			orgRoutes.GET("/risks/matrix", handlers.GetRiskHeatmapHandler)
+			orgRoutes.POST("/risks/bulk", handlers.BulkRiskOperationsHandler)
+			orgRoutes.GET("/rollup/risks", handlers.GetRiskRollupHandler)
+			orgRoutes.GET("/rollup/compliance-score", handlers.GetComplianceScoreRollupHandler)
+			orgRoutes.GET("/risk-matrix", handlers.GetRiskScoringMatrixHandler)
+			orgRoutes.PUT("/risk-matrix", handlers.SetRiskScoringMatrixHandler)
+			orgRoutes.DELETE("/risk-matrix", handlers.DeleteRiskScoringMatrixHandler)
+			orgRoutes.GET("/risk-categories", handlers.ListRiskCategoriesHandler)
+			orgRoutes.POST("/risk-categories", handlers.CreateRiskCategoryHandler)
+			orgRoutes.PUT("/risk-categories/:categoryId", handlers.UpdateRiskCategoryHandler)
+			orgRoutes.DELETE("/risk-categories/:categoryId", handlers.DeleteRiskCategoryHandler)
+
 			idpRoutes := orgRoutes.Group("/identity-providers")
 			{
 				idpRoutes.POST("", handlers.CreateIdentityProviderHandler)
@@ -158,6 +283,23 @@ func setupV1Routes(r *gin.Engine) {
 				idpRoutes.GET("/:idpId", handlers.GetIdentityProviderHandler)
 				idpRoutes.PUT("/:idpId", handlers.UpdateIdentityProviderHandler)
 				idpRoutes.DELETE("/:idpId", handlers.DeleteIdentityProviderHandler)
+				idpRoutes.POST("/import", handlers.ImportIdentityProvidersHandler)
+				idpRoutes.GET("/export", handlers.ExportIdentityProvidersHandler)
+				idpRoutes.POST("/saml-metadata", handlers.ParseSAMLMetadataHandler)
+				idpRoutes.POST("/oidc-discovery", handlers.DiscoverOIDCHandler)
+				idpRoutes.GET("/:idpId/saml-certificates", handlers.ListSAMLCertificatesHandler)
+				idpRoutes.POST("/:idpId/saml-certificates", handlers.AddSAMLCertificateHandler)
+				idpRoutes.POST("/:idpId/saml-certificates/:certId/activate", handlers.ActivateSAMLCertificateHandler)
+				idpRoutes.DELETE("/:idpId/saml-certificates/:certId", handlers.DeleteSAMLCertificateHandler)
+			}
+			// Domínios de email verificados por DNS, para a descoberta do IdP no login
+			verifiedDomainRoutes := orgRoutes.Group("/verified-domains")
+			{
+				verifiedDomainRoutes.GET("", handlers.ListVerifiedDomainsHandler)
+				verifiedDomainRoutes.POST("", handlers.AddVerifiedDomainHandler)
+				verifiedDomainRoutes.PUT("/:domainId", handlers.UpdateVerifiedDomainHandler)
+				verifiedDomainRoutes.POST("/:domainId/verify", handlers.VerifyDomainHandler)
+				verifiedDomainRoutes.DELETE("/:domainId", handlers.DeleteVerifiedDomainHandler)
 			}
 			webhookRoutes := orgRoutes.Group("/webhooks")
 			{
@@ -174,9 +316,323 @@ func setupV1Routes(r *gin.Engine) {
 				userManagementRoutes.GET("/:userId", handlers.GetOrganizationUserHandler)
 				userManagementRoutes.PUT("/:userId/role", handlers.UpdateOrganizationUserRoleHandler)
 				userManagementRoutes.PUT("/:userId/status", handlers.UpdateOrganizationUserStatusHandler)
+				userManagementRoutes.POST("/bulk-role", handlers.BulkAssignOrganizationUserRoleHandler)
+				userManagementRoutes.PUT("/:userId/custom-role", handlers.AssignUserCustomRoleHandler)
+				userManagementRoutes.POST("/:userId/unlock", handlers.UnlockOrganizationUserHandler)
+				userManagementRoutes.POST("/:userId/anonymize", handlers.AnonymizeOrganizationUserHandler)
+			}
+			invitationRoutes := orgRoutes.Group("/invitations")
+			{
+				invitationRoutes.GET("", handlers.ListInvitationsHandler)
+				invitationRoutes.POST("", handlers.CreateInvitationHandler)
+				invitationRoutes.POST("/:invitationId/resend", handlers.ResendInvitationHandler)
+				invitationRoutes.DELETE("/:invitationId", handlers.RevokeInvitationHandler)
+			}
+			orgRoutes.GET("/permissions", handlers.ListPermissionsHandler)
+			customRoleRoutes := orgRoutes.Group("/roles")
+			{
+				customRoleRoutes.GET("", handlers.ListCustomRolesHandler)
+				customRoleRoutes.POST("", handlers.CreateCustomRoleHandler)
+				customRoleRoutes.PUT("/:roleId", handlers.UpdateCustomRoleHandler)
+				customRoleRoutes.DELETE("/:roleId", handlers.DeleteCustomRoleHandler)
 			}
 			orgRoutes.PUT("/branding", handlers.UpdateOrganizationBrandingHandler)
 			orgRoutes.GET("/branding", handlers.GetOrganizationBrandingHandler)
+			orgRoutes.GET("/login-page", handlers.GetLoginPageSettingsHandler)
+			orgRoutes.PUT("/login-page", handlers.UpsertLoginPageSettingsHandler)
+			orgRoutes.GET("/password-policy", handlers.GetPasswordPolicyHandler)
+			orgRoutes.PUT("/password-policy", handlers.UpsertPasswordPolicyHandler)
+			orgRoutes.GET("/mfa-policy", handlers.GetMFAPolicyHandler)
+			orgRoutes.PUT("/mfa-policy", handlers.UpsertMFAPolicyHandler)
+			orgRoutes.GET("/reports/executive", handlers.GetExecutiveReportHandler)
+			orgRoutes.GET("/reports/executive/template", handlers.GetExecutiveReportTemplateHandler)
+			orgRoutes.PUT("/reports/executive/template", handlers.UpsertExecutiveReportTemplateHandler)
+			orgRoutes.GET("/email-branding", handlers.GetEmailBrandingHandler)
+			orgRoutes.PUT("/email-branding", handlers.UpsertEmailBrandingHandler)
+			orgRoutes.GET("/email-templates", handlers.ListEmailTemplatesHandler)
+			orgRoutes.GET("/email-templates/:name/preview", handlers.PreviewEmailTemplateHandler)
+			orgRoutes.POST("/reports/executive/template/logo", handlers.UploadExecutiveReportLogoHandler)
+			orgRoutes.DELETE("/reports/executive/template/logo", handlers.DeleteExecutiveReportLogoHandler)
+			trustCenterRoutes := orgRoutes.Group("/trust-center")
+			{
+				trustCenterRoutes.GET("", handlers.GetTrustCenterProfileHandler)
+				trustCenterRoutes.PUT("", handlers.UpsertTrustCenterProfileHandler)
+				trustCenterRoutes.POST("/publish", handlers.PublishTrustCenterHandler)
+				trustCenterRoutes.POST("/unpublish", handlers.UnpublishTrustCenterHandler)
+			}
+		}
+
+		// Access Review Routes
+		accessReviewRoutes := apiV1.Group("/access-reviews")
+		{
+			accessReviewRoutes.POST("", handlers.CreateAccessReviewCampaignHandler)
+			accessReviewRoutes.GET("", handlers.ListAccessReviewCampaignsHandler)
+			accessReviewRoutes.GET("/:campaignId", handlers.GetAccessReviewCampaignHandler)
+			accessReviewRoutes.POST("/:campaignId/items/import-csv", handlers.ImportAccessReviewItemsCSVHandler)
+			accessReviewRoutes.PUT("/:campaignId/reviewers", handlers.AssignAccessReviewersHandler)
+			accessReviewRoutes.POST("/:campaignId/items/:itemId/decision", handlers.DecideAccessReviewItemHandler)
+			accessReviewRoutes.POST("/:campaignId/close", handlers.CloseAccessReviewCampaignHandler)
+			accessReviewRoutes.GET("/:campaignId/export", handlers.ExportAccessReviewCampaignHandler)
+			accessReviewRoutes.POST("/:campaignId/attach-evidence", handlers.AttachAccessReviewEvidenceHandler)
+		}
+
+		// Policy Routes
+		policyRoutes := apiV1.Group("/policies")
+		{
+			policyRoutes.POST("", handlers.CreatePolicyHandler)
+			policyRoutes.GET("", handlers.ListPoliciesHandler)
+			policyRoutes.GET("/trash", handlers.ListDeletedPoliciesHandler)
+			policyRoutes.GET("/:policyId", handlers.GetPolicyHandler)
+			policyRoutes.PUT("/:policyId", handlers.UpdatePolicyHandler)
+			policyRoutes.DELETE("/:policyId", handlers.DeletePolicyHandler)
+			policyRoutes.POST("/:policyId/restore", handlers.RestorePolicyHandler)
+			policyRoutes.POST("/:policyId/retire", handlers.RetirePolicyHandler)
+			policyRoutes.PUT("/:policyId/controls", handlers.SetPolicyControlsHandler)
+			policyRoutes.POST("/:policyId/versions", handlers.UploadPolicyVersionHandler)
+			policyRoutes.GET("/:policyId/versions/:versionId/download-url", handlers.GetPolicyVersionDownloadURLHandler)
+			policyRoutes.POST("/:policyId/versions/:versionId/submit", handlers.SubmitPolicyVersionForApprovalHandler)
+			policyRoutes.POST("/:policyId/versions/:versionId/decision", handlers.DecidePolicyVersionHandler)
+			policyRoutes.POST("/:policyId/acknowledge", handlers.AcknowledgePolicyHandler)
+			policyRoutes.GET("/:policyId/acknowledgments", handlers.ListPolicyAcknowledgmentsHandler)
+			policyRoutes.POST("/:policyId/ack-campaigns", handlers.CreatePolicyAckCampaignHandler)
+			policyRoutes.POST("/:policyId/ack-campaigns/preflight", handlers.PreflightPolicyAckCampaignHandler)
+		}
+
+		// Policy Acknowledgment Campaign Routes
+		policyAckCampaignRoutes := apiV1.Group("/policy-ack-campaigns")
+		{
+			policyAckCampaignRoutes.GET("", handlers.ListPolicyAckCampaignsHandler)
+			policyAckCampaignRoutes.GET("/:campaignId/report", handlers.GetPolicyAckCampaignReportHandler)
+			policyAckCampaignRoutes.POST("/:campaignId/remind", handlers.SendPolicyAckCampaignRemindersHandler)
+			policyAckCampaignRoutes.POST("/:campaignId/remind/preflight", handlers.PreflightPolicyAckRemindersHandler)
+			policyAckCampaignRoutes.POST("/:campaignId/close", handlers.ClosePolicyAckCampaignHandler)
+		}
+
+		// Control Testing Planner Routes
+		controlTestingRoutes := apiV1.Group("/control-testing")
+		{
+			controlTestingRoutes.POST("/plans", handlers.CreateControlTestPlanHandler)
+			controlTestingRoutes.GET("/plans", handlers.ListControlTestPlansHandler)
+			controlTestingRoutes.PUT("/plans/:planId", handlers.UpdateControlTestPlanHandler)
+			controlTestingRoutes.DELETE("/plans/:planId", handlers.DeleteControlTestPlanHandler)
+			controlTestingRoutes.GET("/capacities", handlers.ListControlTestCapacitiesHandler)
+			controlTestingRoutes.PUT("/capacities/:userId", handlers.SetControlTestCapacityHandler)
+			controlTestingRoutes.GET("/schedule", handlers.GetControlTestScheduleHandler)
+			controlTestingRoutes.GET("/schedule/ics", handlers.ExportControlTestScheduleICSHandler)
+		}
+
+		// Saved Views (filtros nomeados das listagens de riscos e avaliações)
+		savedViewRoutes := apiV1.Group("/saved-views")
+		{
+			savedViewRoutes.GET("", handlers.ListSavedViewsHandler)
+			savedViewRoutes.POST("", handlers.CreateSavedViewHandler)
+			savedViewRoutes.GET("/:viewId", handlers.GetSavedViewHandler)
+			savedViewRoutes.PUT("/:viewId", handlers.UpdateSavedViewHandler)
+			savedViewRoutes.DELETE("/:viewId", handlers.DeleteSavedViewHandler)
+		}
+
+		// Relatórios agendados (PDF/XLSX enviados por e-mail a uma lista de distribuição)
+		reportScheduleRoutes := apiV1.Group("/report-schedules")
+		{
+			reportScheduleRoutes.GET("", handlers.ListReportSchedulesHandler)
+			reportScheduleRoutes.POST("", handlers.CreateReportScheduleHandler)
+			reportScheduleRoutes.GET("/:scheduleId", handlers.GetReportScheduleHandler)
+			reportScheduleRoutes.PUT("/:scheduleId", handlers.UpdateReportScheduleHandler)
+			reportScheduleRoutes.DELETE("/:scheduleId", handlers.DeleteReportScheduleHandler)
+			reportScheduleRoutes.POST("/:scheduleId/run", handlers.RunReportScheduleHandler)
+		}
+		apiV1.GET("/reports/:reportType", handlers.DownloadReportHandler)
+
+		// Organization Secrets Vault Routes (credenciais de integração; leituras sempre mascaradas)
+		secretRoutes := apiV1.Group("/secrets")
+		{
+			secretRoutes.POST("", handlers.CreateOrgSecretHandler)
+			secretRoutes.GET("", handlers.ListOrgSecretsHandler)
+			secretRoutes.GET("/inventory", handlers.ListOrgSecretInventoryHandler)
+			secretRoutes.POST("/bulk-revoke", handlers.BulkRevokeOrgSecretsHandler)
+			secretRoutes.GET("/policy", handlers.GetOrgSecretPolicyHandler)
+			secretRoutes.PUT("/policy", handlers.SetOrgSecretPolicyHandler)
+			secretRoutes.DELETE("/policy", handlers.DeleteOrgSecretPolicyHandler)
+			secretRoutes.GET("/:secretId", handlers.GetOrgSecretHandler)
+			secretRoutes.PUT("/:secretId", handlers.UpdateOrgSecretHandler)
+			secretRoutes.POST("/:secretId/rotate", handlers.RotateOrgSecretHandler)
+			secretRoutes.DELETE("/:secretId", handlers.DeleteOrgSecretHandler)
+		}
+
+		// Chaves de API (tokens de acesso pessoal) para integrações; as próprias chaves não acessam estas rotas
+		apiKeyRoutes := apiV1.Group("/api-keys")
+		{
+			apiKeyRoutes.GET("/scopes", handlers.ListAPIKeyScopesHandler)
+			apiKeyRoutes.POST("", handlers.CreateAPIKeyHandler)
+			apiKeyRoutes.GET("", handlers.ListAPIKeysHandler)
+			apiKeyRoutes.DELETE("/:keyId", handlers.RevokeAPIKeyHandler)
+		}
+
+		// Admin Action Requests (regra das duas pessoas para ações destrutivas)
+		adminActionRoutes := apiV1.Group("/admin-actions")
+		{
+			adminActionRoutes.GET("", handlers.ListAdminActionRequestsHandler)
+			adminActionRoutes.GET("/:requestId", handlers.GetAdminActionRequestHandler)
+			adminActionRoutes.POST("/:requestId/decide", handlers.DecideAdminActionRequestHandler)
+			adminActionRoutes.POST("/:requestId/cancel", handlers.CancelAdminActionRequestHandler)
+		}
+
+		// Tag Routes (tags de riscos, controles e fornecedores)
+		tagRoutes := apiV1.Group("/tags")
+		{
+			tagRoutes.GET("", handlers.ListTagsHandler)
+			tagRoutes.POST("", handlers.CreateTagHandler)
+			tagRoutes.PUT("/:tagId", handlers.UpdateTagHandler)
+			tagRoutes.DELETE("/:tagId", handlers.DeleteTagHandler)
+			tagRoutes.GET("/:tagId/entities", handlers.ListTagEntitiesHandler)
+			tagRoutes.POST("/:tagId/entities", handlers.TagEntitiesHandler)
+			tagRoutes.DELETE("/:tagId/entities/:entityType/:entityId", handlers.UntagEntityHandler)
+		}
+
+		// Team Routes (equipes donas de riscos, avaliações e testes de controle)
+		teamRoutes := apiV1.Group("/teams")
+		{
+			teamRoutes.GET("", handlers.ListTeamsHandler)
+			teamRoutes.POST("", handlers.CreateTeamHandler)
+			teamRoutes.GET("/:teamId", handlers.GetTeamHandler)
+			teamRoutes.PUT("/:teamId", handlers.UpdateTeamHandler)
+			teamRoutes.DELETE("/:teamId", handlers.DeleteTeamHandler)
+			teamRoutes.POST("/:teamId/members", handlers.AddTeamMembersHandler)
+			teamRoutes.DELETE("/:teamId/members/:userId", handlers.RemoveTeamMemberHandler)
+		}
+
+		// Vendor Routes
+		vendorRoutes := apiV1.Group("/vendors")
+		{
+			vendorRoutes.POST("", handlers.CreateVendorHandler)
+			vendorRoutes.GET("", handlers.ListVendorsHandler)
+			vendorRoutes.GET("/:vendorId", handlers.GetVendorHandler)
+			vendorRoutes.PUT("/:vendorId", handlers.UpdateVendorHandler)
+			vendorRoutes.DELETE("/:vendorId", handlers.DeleteVendorHandler)
+			vendorRoutes.GET("/:vendorId/risks", handlers.ListVendorRisksHandler)
+			vendorRoutes.PUT("/:vendorId/risks", handlers.SetVendorRisksHandler)
+		}
+
+		// Security Questionnaire Routes
+		questionnaireRoutes := apiV1.Group("/questionnaires")
+		{
+			questionnaireRoutes.POST("", handlers.CreateQuestionnaireHandler)
+			questionnaireRoutes.GET("", handlers.ListQuestionnairesHandler)
+			questionnaireRoutes.GET("/:questionnaireId", handlers.GetQuestionnaireHandler)
+			questionnaireRoutes.PUT("/:questionnaireId", handlers.UpdateQuestionnaireHandler)
+			questionnaireRoutes.DELETE("/:questionnaireId", handlers.DeleteQuestionnaireHandler)
+			questionnaireRoutes.POST("/:questionnaireId/publish", handlers.PublishQuestionnaireHandler)
+			questionnaireRoutes.POST("/:questionnaireId/archive", handlers.ArchiveQuestionnaireHandler)
+			questionnaireRoutes.POST("/:questionnaireId/invitations", handlers.CreateQuestionnaireInvitationHandler)
+		}
+		questionnaireInvitationRoutes := apiV1.Group("/questionnaire-invitations")
+		{
+			questionnaireInvitationRoutes.GET("", handlers.ListQuestionnaireInvitationsHandler)
+			questionnaireInvitationRoutes.GET("/:invitationId", handlers.GetQuestionnaireInvitationHandler)
+			questionnaireInvitationRoutes.POST("/:invitationId/revoke", handlers.RevokeQuestionnaireInvitationHandler)
+		}
+
+		// Asset Inventory Routes
+		assetRoutes := apiV1.Group("/assets")
+		{
+			assetRoutes.POST("", handlers.CreateAssetHandler)
+			assetRoutes.GET("", handlers.ListAssetsHandler)
+			assetRoutes.POST("/import-csv", idempotent, handlers.ImportAssetsCSVHandler)
+			assetRoutes.GET("/:assetId", handlers.GetAssetHandler)
+			assetRoutes.PUT("/:assetId", handlers.UpdateAssetHandler)
+			assetRoutes.DELETE("/:assetId", handlers.DeleteAssetHandler)
+			assetRoutes.PUT("/:assetId/risks", handlers.SetAssetRisksHandler)
+			assetRoutes.PUT("/:assetId/controls", handlers.SetAssetControlsHandler)
+		}
+
+		// Business Continuity / BIA Routes
+		businessProcessRoutes := apiV1.Group("/business-processes")
+		{
+			businessProcessRoutes.POST("", handlers.CreateBusinessProcessHandler)
+			businessProcessRoutes.GET("", handlers.ListBusinessProcessesHandler)
+			businessProcessRoutes.GET("/bia-report", handlers.GetBIAReportHandler)
+			businessProcessRoutes.GET("/:processId", handlers.GetBusinessProcessHandler)
+			businessProcessRoutes.PUT("/:processId", handlers.UpdateBusinessProcessHandler)
+			businessProcessRoutes.DELETE("/:processId", handlers.DeleteBusinessProcessHandler)
+		}
+
+		// Evidence Request Routes
+		evidenceRequestRoutes := apiV1.Group("/evidence-requests")
+		{
+			evidenceRequestRoutes.POST("", handlers.CreateEvidenceRequestHandler)
+			evidenceRequestRoutes.GET("", handlers.ListEvidenceRequestsHandler)
+			evidenceRequestRoutes.GET("/:requestId", handlers.GetEvidenceRequestHandler)
+			evidenceRequestRoutes.PUT("/:requestId", handlers.UpdateEvidenceRequestHandler)
+			evidenceRequestRoutes.DELETE("/:requestId", handlers.DeleteEvidenceRequestHandler)
+			evidenceRequestRoutes.POST("/:requestId/review", handlers.ReviewEvidenceRequestHandler)
+		}
+		evidencePortalLinkRoutes := apiV1.Group("/evidence-portal-links")
+		{
+			evidencePortalLinkRoutes.POST("", handlers.CreateEvidencePortalLinkHandler)
+			evidencePortalLinkRoutes.GET("", handlers.ListEvidencePortalLinksHandler)
+			evidencePortalLinkRoutes.POST("/:linkId/revoke", handlers.RevokeEvidencePortalLinkHandler)
+		}
+
+		// API Usage Analytics Routes
+		apiV1.GET("/api-usage", handlers.GetAPIUsageHandler)
+
+		// Notification Channel & Routing Routes
+		apiV1.GET("/notification-channels", handlers.ListNotificationChannelsHandler)
+		notificationRouteRoutes := apiV1.Group("/notification-routes")
+		{
+			notificationRouteRoutes.POST("", handlers.CreateNotificationRouteHandler)
+			notificationRouteRoutes.GET("", handlers.ListNotificationRoutesHandler)
+			notificationRouteRoutes.PUT("/:routeId", handlers.UpdateNotificationRouteHandler)
+			notificationRouteRoutes.DELETE("/:routeId", handlers.DeleteNotificationRouteHandler)
+			notificationRouteRoutes.POST("/:routeId/test", handlers.TestNotificationRouteHandler)
+		}
+
+		// Risk Notification Filter Routes
+		riskNotificationFilterRoutes := apiV1.Group("/risk-notification-filters")
+		{
+			riskNotificationFilterRoutes.POST("", handlers.CreateRiskNotificationFilterHandler)
+			riskNotificationFilterRoutes.GET("", handlers.ListRiskNotificationFiltersHandler)
+			riskNotificationFilterRoutes.POST("/evaluate", handlers.EvaluateRiskNotificationFiltersHandler)
+			riskNotificationFilterRoutes.GET("/:filterId", handlers.GetRiskNotificationFilterHandler)
+			riskNotificationFilterRoutes.PUT("/:filterId", handlers.UpdateRiskNotificationFilterHandler)
+			riskNotificationFilterRoutes.DELETE("/:filterId", handlers.DeleteRiskNotificationFilterHandler)
+		}
+
+		// Configuration Change History Routes (versões e rollback de configurações da organização)
+		configChangeRoutes := apiV1.Group("/config-changes")
+		{
+			configChangeRoutes.GET("", handlers.ListConfigChangesHandler)
+			configChangeRoutes.GET("/:changeId", handlers.GetConfigChangeHandler)
+			configChangeRoutes.POST("/:changeId/rollback", handlers.RollbackConfigChangeHandler)
+		}
+
+		// Audit Trail Routes (trilha de auditoria da organização)
+		apiV1.GET("/audit-trail", handlers.ListAuditTrailHandler)
+		auditTrailExportRoutes := apiV1.Group("/audit-trail/exports")
+		{
+			auditTrailExportRoutes.POST("", handlers.CreateAuditTrailExportHandler)
+			auditTrailExportRoutes.GET("", handlers.ListAuditTrailExportsHandler)
+			auditTrailExportRoutes.POST("/verify", handlers.VerifyAuditTrailExportManifestHandler)
+			auditTrailExportRoutes.GET("/:exportId", handlers.GetAuditTrailExportHandler)
+			auditTrailExportRoutes.GET("/:exportId/manifest", handlers.GetAuditTrailExportManifestHandler)
+			auditTrailExportRoutes.GET("/:exportId/parts/:part", handlers.GetAuditTrailExportPartHandler)
+		}
+
+		// Feature Flag Routes (diagnóstico da segmentação)
+		featureFlagRoutes := apiV1.Group("/feature-flags")
+		{
+			featureFlagRoutes.GET("/:key/evaluation", handlers.EvaluateFeatureFlagHandler)
+		}
+
+		// Event Stream (SSE) para atualização de dashboards em tempo real
+		apiV1.GET("/events/stream", handlers.StreamEventsHandler)
+
+		// Edit Lock Routes (presença/lock de edição em riscos e avaliações)
+		editLockRoutes := apiV1.Group("/edit-locks")
+		{
+			editLockRoutes.GET("/:resourceType/:resourceId", handlers.GetEditLockHandler)
+			editLockRoutes.POST("/:resourceType/:resourceId", handlers.AcquireEditLockHandler)
+			editLockRoutes.DELETE("/:resourceType/:resourceId", handlers.ReleaseEditLockHandler)
 		}
 
 		// Vulnerability Routes
@@ -184,7 +640,9 @@ func setupV1Routes(r *gin.Engine) {
 		{
 			vulnerabilityRoutes.POST("", handlers.CreateVulnerabilityHandler)
 			vulnerabilityRoutes.GET("", handlers.ListVulnerabilitiesHandler)
-			vulnerabilityRoutes.POST("/import-csv", handlers.ImportVulnerabilitiesCSVHandler)
+			vulnerabilityRoutes.POST("/import-csv", idempotent, handlers.ImportVulnerabilitiesCSVHandler)
+			vulnerabilityRoutes.POST("/import", idempotent, handlers.ImportVulnerabilityScanHandler)
+			vulnerabilityRoutes.POST("/import/preflight", handlers.PreflightVulnerabilityScanImportHandler)
 			vulnerabilityRoutes.GET("/:vulnId", handlers.GetVulnerabilityHandler)
 			vulnerabilityRoutes.PUT("/:vulnId", handlers.UpdateVulnerabilityHandler)
 			vulnerabilityRoutes.DELETE("/:vulnId", handlers.DeleteVulnerabilityHandler)
@@ -194,14 +652,67 @@ func setupV1Routes(r *gin.Engine) {
 		auditRoutes := apiV1.Group("/audit")
 		{
 			auditRoutes.GET("/frameworks", handlers.ListFrameworksHandler)
+			auditRoutes.POST("/frameworks", handlers.CreateFrameworkHandler)
+			auditRoutes.POST("/frameworks/import", handlers.ImportFrameworkHandler)
+			auditRoutes.PUT("/frameworks/:frameworkId", handlers.UpdateFrameworkHandler)
+			auditRoutes.DELETE("/frameworks/:frameworkId", handlers.DeleteFrameworkHandler)
+			auditRoutes.POST("/frameworks/:frameworkId/controls", handlers.CreateFrameworkControlHandler)
+			auditRoutes.PUT("/frameworks/:frameworkId/controls/:controlId", handlers.UpdateFrameworkControlHandler)
+			auditRoutes.DELETE("/frameworks/:frameworkId/controls/:controlId", handlers.DeleteFrameworkControlHandler)
+			auditRoutes.PUT("/frameworks/:frameworkId/control-families", handlers.RenameControlFamilyHandler)
+			auditRoutes.DELETE("/frameworks/:frameworkId/control-families", handlers.DeleteControlFamilyHandler)
 			auditRoutes.GET("/frameworks/:frameworkId/controls", handlers.GetFrameworkControlsHandler)
 			auditRoutes.GET("/frameworks/:frameworkId/control-families", handlers.GetControlFamiliesForFrameworkHandler)
-			auditRoutes.POST("/assessments", handlers.CreateOrUpdateAssessmentHandler)
+			auditRoutes.GET("/frameworks/:frameworkId/control-order", handlers.GetControlOrderHandler)
+			auditRoutes.PUT("/frameworks/:frameworkId/control-order", handlers.SetControlOrderHandler)
+			auditRoutes.DELETE("/frameworks/:frameworkId/control-order", handlers.ResetControlOrderHandler)
+			auditRoutes.GET("/frameworks/:frameworkId/coverage-projection", handlers.GetCoverageProjectionHandler)
+			auditRoutes.GET("/frameworks/:frameworkId/oscal-catalog", handlers.ExportFrameworkOSCALCatalogHandler)
+			auditRoutes.GET("/frameworks/:frameworkId/coordinators", handlers.ListFrameworkCoordinatorsHandler)
+			auditRoutes.POST("/frameworks/:frameworkId/coordinators", handlers.AddFrameworkCoordinatorHandler)
+			auditRoutes.DELETE("/frameworks/:frameworkId/coordinators/:userId", handlers.RemoveFrameworkCoordinatorHandler)
+			auditRoutes.GET("/frameworks/:frameworkId/assessment-permission", handlers.GetMyAssessmentPermissionHandler)
+			auditRoutes.GET("/control-mappings", handlers.ListControlMappingsHandler)
+			auditRoutes.GET("/score-rubric", handlers.GetScoreRubricHandler)
+			auditRoutes.PUT("/score-rubric", handlers.SetScoreRubricHandler)
+			auditRoutes.DELETE("/score-rubric", handlers.DeleteScoreRubricHandler)
+			auditRoutes.GET("/score-rubrics", handlers.ListScoreRubricsHandler)
+			auditRoutes.POST("/control-mappings", handlers.CreateControlMappingHandler)
+			auditRoutes.DELETE("/control-mappings/:mappingId", handlers.DeleteControlMappingHandler)
+			auditRoutes.GET("/controls/:controlId/risks", handlers.ListControlRisksHandler)
+			auditRoutes.GET("/controls/:controlId/dossier", handlers.GetControlDossierHandler)
+			auditRoutes.GET("/controls/:controlId/comments", handlers.ListAssessmentCommentsHandler)
+			auditRoutes.POST("/controls/:controlId/comments", handlers.CreateAssessmentCommentHandler)
+			auditRoutes.DELETE("/controls/:controlId/comments/:commentId", handlers.DeleteAssessmentCommentHandler)
+			auditRoutes.GET("/controls/:controlId/evidence-drafts", handlers.ListAssessmentEvidenceDraftsHandler)
+			auditRoutes.POST("/controls/:controlId/evidence-drafts", handlers.UploadAssessmentEvidenceDraftHandler)
+			auditRoutes.DELETE("/controls/:controlId/evidence-drafts/:draftId", handlers.DeleteAssessmentEvidenceDraftHandler)
+			auditRoutes.POST("/campaigns", handlers.CreateAuditCampaignHandler)
+			auditRoutes.GET("/campaigns", handlers.ListAuditCampaignsHandler)
+			auditRoutes.GET("/campaigns/:campaignId", handlers.GetAuditCampaignHandler)
+			auditRoutes.PUT("/campaigns/:campaignId", handlers.UpdateAuditCampaignHandler)
+			auditRoutes.GET("/campaigns/:campaignId/controls", handlers.ListAuditCampaignControlsHandler)
+			auditRoutes.POST("/waivers", handlers.CreateControlWaiverHandler)
+			auditRoutes.GET("/waivers", handlers.ListControlWaiversHandler)
+			auditRoutes.GET("/waivers/:waiverId", handlers.GetControlWaiverHandler)
+			auditRoutes.POST("/waivers/:waiverId/approval/:approvalId/decide", handlers.DecideControlWaiverHandler)
+			auditRoutes.POST("/waivers/:waiverId/revoke", handlers.RevokeControlWaiverHandler)
+			auditRoutes.POST("/assessments", idempotent, handlers.CreateOrUpdateAssessmentHandler)
+			auditRoutes.POST("/assessments/assignments", handlers.AssignAssessmentsHandler)
+			auditRoutes.GET("/assessments/assigned-to-me", handlers.ListMyAssignedAssessmentsHandler)
+			auditRoutes.POST("/assessments/:assessmentId/reminder-ack", handlers.AcknowledgeAssessmentReminderHandler)
+			auditRoutes.GET("/assessments/:assessmentId/reminder-acks", handlers.ListAssessmentReminderAcksHandler)
 			auditRoutes.GET("/assessments/control/:controlId", handlers.GetAssessmentForControlHandler)
 			auditRoutes.DELETE("/assessments/:assessmentId/evidence", handlers.DeleteAssessmentEvidenceHandler)
+			auditRoutes.GET("/assessments/:assessmentId/c2m2-practices", handlers.ListAssessmentC2M2PracticesHandler)
 			auditRoutes.GET("/organizations/:orgId/frameworks/:frameworkId/assessments", handlers.ListOrgAssessmentsByFrameworkHandler)
 			auditRoutes.GET("/organizations/:orgId/frameworks/:frameworkId/compliance-score", handlers.GetComplianceScoreHandler)
+			auditRoutes.GET("/organizations/:orgId/frameworks/:frameworkId/compliance-score/history", handlers.ListComplianceScoreHistoryHandler)
+			auditRoutes.POST("/organizations/:orgId/frameworks/:frameworkId/historical-assessments/import", idempotent, handlers.ImportHistoricalAssessmentsHandler)
 			auditRoutes.GET("/organizations/:orgId/frameworks/:frameworkId/c2m2-maturity-summary", handlers.GetC2M2MaturitySummaryHandler)
+			auditRoutes.GET("/organizations/:orgId/frameworks/:frameworkId/c2m2-practice-summary", handlers.GetC2M2PracticeRollupHandler)
+			auditRoutes.GET("/organizations/:orgId/frameworks/:frameworkId/oscal-assessment-results", handlers.ExportOSCALAssessmentResultsHandler)
+			auditRoutes.GET("/organizations/:orgId/frameworks/:frameworkId/jsonl-export", handlers.ExportAssessmentsJSONLHandler)
 		}
 
 		// C2M2 Routes
@@ -224,10 +735,35 @@ func setupV1Routes(r *gin.Engine) {
 			{
 				backupCodeRoutes.POST("/generate", handlers.GenerateBackupCodesHandler)
 			}
+			// Passkeys e chaves de segurança (WebAuthn)
+			webAuthnRoutes := mfaRoutes.Group("/webauthn")
+			{
+				webAuthnRoutes.POST("/register/options", handlers.BeginWebAuthnRegistrationHandler)
+				webAuthnRoutes.POST("/register/verify", handlers.FinishWebAuthnRegistrationHandler)
+				webAuthnRoutes.GET("/credentials", handlers.ListWebAuthnCredentialsHandler)
+				webAuthnRoutes.DELETE("/credentials/:credentialId", handlers.DeleteWebAuthnCredentialHandler)
+			}
 		}
 
 		// User-specific routes
 		apiV1.GET("/me/dashboard/summary", handlers.GetUserDashboardSummaryHandler)
+		apiV1.GET("/me/permissions", handlers.GetMyPermissionsHandler)
+		apiV1.GET("/me/data-export", handlers.ExportMyDataHandler)
+		// Central de notificações in-app do usuário
+		apiV1.GET("/me/notifications", handlers.ListMyNotificationsHandler)
+		apiV1.GET("/me/notifications/unread-count", handlers.GetMyUnreadNotificationCountHandler)
+		apiV1.PATCH("/me/notifications", handlers.MarkNotificationsHandler)
+		apiV1.POST("/me/notifications/read-all", handlers.MarkAllNotificationsReadHandler)
+		apiV1.POST("/me/notifications/snooze", handlers.SnoozeNotificationsHandler)
+		apiV1.POST("/me/notifications/dismiss", handlers.DismissNotificationsHandler)
+		apiV1.PATCH("/me/notifications/:notificationId", handlers.MarkNotificationHandler)
+
+		// Sessões de login do usuário autenticado
+		apiV1.POST("/auth/logout", handlers.LogoutHandler)
+		apiV1.POST("/auth/logout-all", handlers.LogoutAllHandler)
+		apiV1.GET("/me/sessions", handlers.ListMySessionsHandler)
+		apiV1.DELETE("/me/sessions/:sessionId", handlers.RevokeMySessionHandler)
+
 		apiV1.GET("/users/organization-lookup", handlers.OrganizationUserLookupHandler)
 
 		// File Access Routes
@@ -236,6 +772,34 @@ func setupV1Routes(r *gin.Engine) {
 			fileAccessRoutes.GET("/signed-url", handlers.GetSignedURLForObjectHandler)
 		}
 
+		// Evidence Access Policy Routes (redes e horário permitidos para download de evidências)
+		evidenceAccessRoutes := apiV1.Group("/evidence-access-policy")
+		{
+			evidenceAccessRoutes.GET("", handlers.GetEvidenceAccessPolicyHandler)
+			evidenceAccessRoutes.PUT("", handlers.SetEvidenceAccessPolicyHandler)
+			evidenceAccessRoutes.DELETE("", handlers.DeleteEvidenceAccessPolicyHandler)
+		}
+
+		// Data Retention Policy Routes (prazos de retenção por categoria e relatório do expurgo)
+		retentionRoutes := apiV1.Group("/retention-policy")
+		{
+			retentionRoutes.GET("", handlers.GetRetentionPolicyHandler)
+			retentionRoutes.PUT("", handlers.SetRetentionPolicyHandler)
+			retentionRoutes.GET("/report", handlers.GetRetentionReportHandler)
+		}
+
+		// Exportação completa dos dados da organização (backup e offboarding), apenas administradores
+		orgExportRoutes := apiV1.Group("/organization-exports")
+		{
+			orgExportRoutes.POST("", handlers.CreateOrganizationExportHandler)
+			orgExportRoutes.GET("", handlers.ListOrganizationExportsHandler)
+			orgExportRoutes.GET("/:exportId", handlers.GetOrganizationExportHandler)
+			orgExportRoutes.GET("/:exportId/download", handlers.DownloadOrganizationExportHandler)
+		}
+
+		// Status de riscos e avaliações (nativos e definidos pelo administrador) para os seletores
+		apiV1.GET("/status-definitions", handlers.ListStatusDefinitionsHandler)
+
 		// System Admin Routes
 		adminRoutes := apiV1.Group("/admin")
 		adminRoutes.Use(auth.RoleAuthMiddleware(models.RoleSystemAdmin))
@@ -246,6 +810,35 @@ func setupV1Routes(r *gin.Engine) {
 				settingsRoutes.PUT("", handlers.UpdateSystemSettingsHandler)
 				settingsRoutes.POST("/test-email", handlers.SendTestEmailHandler)
 			}
+			adminRoutes.POST("/self-test", handlers.RunSelfTestHandler)
+			adminRoutes.POST("/config/reload", handlers.ReloadConfigHandler)
+			adminRoutes.GET("/organizations", handlers.ListOrganizationHierarchyHandler)
+			adminRoutes.PUT("/organizations/:orgId/parent", handlers.SetOrganizationParentHandler)
+			jobRoutes := adminRoutes.Group("/jobs")
+			{
+				jobRoutes.GET("", handlers.ListBackgroundJobsHandler)
+				jobRoutes.GET("/stats", handlers.GetBackgroundJobStatsHandler)
+				jobRoutes.GET("/:jobId", handlers.GetBackgroundJobHandler)
+				jobRoutes.POST("/:jobId/retry", handlers.RetryBackgroundJobHandler)
+				jobRoutes.POST("/:jobId/cancel", handlers.CancelBackgroundJobHandler)
+			}
+			statusRoutes := adminRoutes.Group("/status-definitions")
+			{
+				statusRoutes.GET("", handlers.ListStatusDefinitionsHandler)
+				statusRoutes.POST("", handlers.CreateStatusDefinitionHandler)
+				statusRoutes.PUT("/:entity/:code", handlers.UpdateStatusDefinitionHandler)
+			}
+			adminRoutes.PUT("/status-transitions/:entity", handlers.ReplaceStatusTransitionsHandler)
+			adminFlagRoutes := adminRoutes.Group("/feature-flags")
+			{
+				adminFlagRoutes.GET("", handlers.ListFeatureFlagsHandler)
+				adminFlagRoutes.POST("", handlers.CreateFeatureFlagHandler)
+				adminFlagRoutes.GET("/:key", handlers.GetFeatureFlagHandler)
+				adminFlagRoutes.PUT("/:key", handlers.UpdateFeatureFlagHandler)
+				adminFlagRoutes.DELETE("/:key", handlers.DeleteFeatureFlagHandler)
+				adminFlagRoutes.PUT("/:key/organizations/:orgId", handlers.SetFeatureFlagOrganizationOverrideHandler)
+				adminFlagRoutes.DELETE("/:key/organizations/:orgId", handlers.DeleteFeatureFlagOrganizationOverrideHandler)
+			}
 		}
 
 		// Dashboard Routes
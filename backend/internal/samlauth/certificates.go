@@ -0,0 +1,172 @@
+package samlauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultCertificateValidity é a validade dos certificados do SP gerados pelo Phoenix.
+	DefaultCertificateValidity = 2 * 365 * 24 * time.Hour
+	certificateKeyBits         = 2048
+)
+
+// ErrNoSigningCertificate indica um IdP sem certificado ativo guardado quando o par global do SP não foi configurado.
+var ErrNoSigningCertificate = errors.New("no SAML SP signing certificate: add one to the identity provider or set SAML_SP_KEY_PEM and SAML_SP_CERT_PEM")
+
+// parseKeyPairPEM lê a chave privada RSA (PKCS#8 ou PKCS#1) e o certificado do SP, em PEM.
+func parseKeyPairPEM(certPEM, keyPEM string) (*rsa.PrivateKey, *x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, nil, fmt.Errorf("failed to decode SP private key PEM")
+	}
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsedKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse SP private key: %w", err)
+		}
+	}
+	key, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("SP key is not RSA private key")
+	}
+	certificate, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	if publicKey, ok := certificate.PublicKey.(*rsa.PublicKey); !ok || !publicKey.Equal(&key.PublicKey) {
+		return nil, nil, fmt.Errorf("SP certificate does not match the private key")
+	}
+	return key, certificate, nil
+}
+
+func parseCertificatePEM(certPEM string) (*x509.Certificate, error) {
+	certBlock, _ := pem.Decode([]byte(certPEM))
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("failed to decode SP certificate PEM")
+	}
+	certificate, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SP certificate: %w", err)
+	}
+	return certificate, nil
+}
+
+// GenerateCertificate gera uma chave RSA e um certificado autoassinado para o SP, em PEM. Os IdPs SAML confiam no
+// certificado publicado nos metadados, então ele não precisa ser emitido por uma CA.
+func GenerateCertificate(commonName string, validity time.Duration) (certPEM, keyPEM string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, certificateKeyBits)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating SP key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", fmt.Errorf("error generating certificate serial: %w", err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", fmt.Errorf("error creating SP certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", "", fmt.Errorf("error encoding SP key: %w", err)
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM, nil
+}
+
+// NewCertificate valida um par chave/certificado do SP e o prepara para ser guardado como certificado de reserva
+// do IdP, com a chave cifrada. Certificados vencidos são recusados.
+func NewCertificate(identityProviderID uuid.UUID, certPEM, keyPEM string) (*models.SAMLCertificate, error) {
+	_, certificate, err := parseKeyPairPEM(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(certificate.NotAfter) {
+		return nil, fmt.Errorf("SP certificate expired at %s", certificate.NotAfter.UTC().Format(time.RFC3339))
+	}
+	encryptedKey, err := utils.Encrypt(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting SP key: %w", err)
+	}
+	fingerprint := sha256.Sum256(certificate.Raw)
+	return &models.SAMLCertificate{
+		IdentityProviderID:  identityProviderID,
+		Status:              models.SAMLCertificateStandby,
+		CertificatePEM:      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})),
+		PrivateKeyEncrypted: encryptedKey,
+		FingerprintSHA256:   hex.EncodeToString(fingerprint[:]),
+		Subject:             truncate(certificate.Subject.String(), 255),
+		NotBefore:           certificate.NotBefore,
+		NotAfter:            certificate.NotAfter,
+	}, nil
+}
+
+// certificateKeyPair decifra a chave de um certificado guardado.
+func certificateKeyPair(stored *models.SAMLCertificate) (*rsa.PrivateKey, *x509.Certificate, error) {
+	keyPEM, err := utils.Decrypt(stored.PrivateKeyEncrypted)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decrypting SP key of certificate %s: %w", stored.ID, err)
+	}
+	return parseKeyPairPEM(stored.CertificatePEM, keyPEM)
+}
+
+// signingKeyPair retorna o par usado pelo SP com o IdP: o certificado ativo guardado para ele ou, sem nenhum, o par
+// global. ErrNoSigningCertificate se não houver nenhum dos dois.
+func signingKeyPair(db *gorm.DB, identityProviderID uuid.UUID) (*rsa.PrivateKey, *x509.Certificate, error) {
+	var active models.SAMLCertificate
+	err := db.Where("identity_provider_id = ? AND status = ?", identityProviderID, models.SAMLCertificateActive).Take(&active).Error
+	if err == nil {
+		return certificateKeyPair(&active)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, fmt.Errorf("error loading SP certificate: %w", err)
+	}
+	spMu.RLock()
+	key, certificate := spKey, spCertificate
+	spMu.RUnlock()
+	if key == nil || certificate == nil {
+		return nil, nil, ErrNoSigningCertificate
+	}
+	return key, certificate, nil
+}
+
+// HasGlobalCertificate indica se o par global do SP (SAML_SP_KEY_PEM / SAML_SP_CERT_PEM) foi configurado.
+func HasGlobalCertificate() bool {
+	spMu.RLock()
+	defer spMu.RUnlock()
+	return spKey != nil && spCertificate != nil
+}
+
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
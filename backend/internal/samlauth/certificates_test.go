@@ -0,0 +1,159 @@
+package samlauth
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crewjam/saml"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	return db, mock
+}
+
+// setGlobalSP troca a configuração global do SP durante o teste.
+func setGlobalSP(t *testing.T, rootURL, certPEM, keyPEM string) {
+	t.Helper()
+	previousConfig := config.Cfg
+	config.Cfg.AppRootURL, config.Cfg.SAMLSPCertPEM, config.Cfg.SAMLSPKeyPEM = rootURL, certPEM, keyPEM
+	spMu.RLock()
+	previousRoot, previousKey, previousCert := spRootURL, spKey, spCertificate
+	spMu.RUnlock()
+	t.Cleanup(func() {
+		config.Cfg = previousConfig
+		spMu.Lock()
+		spRootURL, spKey, spCertificate = previousRoot, previousKey, previousCert
+		spMu.Unlock()
+	})
+	require.NoError(t, InitializeSAMLSPGlobalConfig())
+}
+
+func TestGenerateAndValidateCertificate(t *testing.T) {
+	certPEM, keyPEM, err := GenerateCertificate("phoenix-grc-sp-test", 24*time.Hour)
+	require.NoError(t, err)
+	idpID := uuid.New()
+
+	cert, err := NewCertificate(idpID, certPEM, keyPEM)
+	require.NoError(t, err)
+	assert.Equal(t, models.SAMLCertificateStandby, cert.Status)
+	assert.Equal(t, idpID, cert.IdentityProviderID)
+	assert.Len(t, cert.FingerprintSHA256, 64)
+	assert.Equal(t, "CN=phoenix-grc-sp-test", cert.Subject)
+	assert.NotContains(t, cert.PrivateKeyEncrypted, "PRIVATE KEY")
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), cert.NotAfter, time.Minute)
+
+	key, parsed, err := certificateKeyPair(cert)
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(parsed.PublicKey))
+
+	otherCertPEM, _, err := GenerateCertificate("other", time.Hour)
+	require.NoError(t, err)
+	_, err = NewCertificate(idpID, otherCertPEM, keyPEM)
+	assert.ErrorContains(t, err, "does not match")
+	_, err = NewCertificate(idpID, "not a certificate", keyPEM)
+	assert.Error(t, err)
+
+	expiredPEM, expiredKeyPEM, err := GenerateCertificate("expired", -time.Hour)
+	require.NoError(t, err)
+	_, err = NewCertificate(idpID, expiredPEM, expiredKeyPEM)
+	assert.ErrorContains(t, err, "expired")
+}
+
+func TestInitializeSAMLSPGlobalConfigKeyPairIsOptional(t *testing.T) {
+	setGlobalSP(t, "https://grc.example.test", "", "")
+	assert.False(t, HasGlobalCertificate())
+
+	certPEM, keyPEM, err := GenerateCertificate("global", time.Hour)
+	require.NoError(t, err)
+	setGlobalSP(t, "https://grc.example.test", certPEM, keyPEM)
+	assert.True(t, HasGlobalCertificate())
+
+	config.Cfg.SAMLSPKeyPEM = ""
+	assert.ErrorContains(t, InitializeSAMLSPGlobalConfig(), "must be set together")
+}
+
+func TestServiceProviderMetadataPublishesStandbyCertificates(t *testing.T) {
+	globalPEM, globalKeyPEM, err := GenerateCertificate("global", time.Hour)
+	require.NoError(t, err)
+	setGlobalSP(t, "https://grc.example.test/", globalPEM, globalKeyPEM)
+	idp := &models.IdentityProvider{ID: uuid.New(), ProviderType: models.IDPTypeSAML, ConfigJSON: `{"sign_request":true}`}
+	derBase64 := func(certPEM string) string {
+		block, _ := pem.Decode([]byte(certPEM))
+		return base64.StdEncoding.EncodeToString(block.Bytes)
+	}
+	keyDescriptors := func(metadata *saml.EntityDescriptor, use string) []string {
+		var certs []string
+		for _, descriptor := range metadata.SPSSODescriptors[0].KeyDescriptors {
+			if descriptor.Use == use {
+				certs = append(certs, descriptor.KeyInfo.X509Data.X509Certificates[0].Data)
+			}
+		}
+		return certs
+	}
+	columns := []string{"id", "identity_provider_id", "status", "certificate_pem", "created_at"}
+
+	// Só com o par global, ele é o único certificado publicado
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(`SELECT \* FROM "saml_certificates" WHERE identity_provider_id = \$1`).
+		WithArgs(idp.ID).WillReturnRows(sqlmock.NewRows(columns))
+	metadata, err := ServiceProviderMetadata(db, idp)
+	require.NoError(t, err)
+	assert.Equal(t, "https://grc.example.test/auth/saml/"+idp.ID.String()+"/metadata", metadata.EntityID)
+	descriptor := metadata.SPSSODescriptors[0]
+	assert.Equal(t, []string{derBase64(globalPEM)}, keyDescriptors(metadata, "signing"))
+	assert.Equal(t, []string{derBase64(globalPEM)}, keyDescriptors(metadata, "encryption"))
+	require.Len(t, descriptor.AssertionConsumerServices, 1)
+	assert.Equal(t, saml.HTTPPostBinding, descriptor.AssertionConsumerServices[0].Binding)
+	assert.Equal(t, "https://grc.example.test/auth/saml/"+idp.ID.String()+"/acs", descriptor.AssertionConsumerServices[0].Location)
+	assert.True(t, *descriptor.AuthnRequestsSigned)
+
+	// Com um certificado de reserva guardado, o par global continua ativo e os dois são publicados para assinatura
+	standbyPEM, _, err := GenerateCertificate("standby", time.Hour)
+	require.NoError(t, err)
+	mock.ExpectQuery(`SELECT \* FROM "saml_certificates"`).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(uuid.New(), idp.ID, models.SAMLCertificateStandby, standbyPEM, time.Now()))
+	metadata, err = ServiceProviderMetadata(db, idp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{derBase64(globalPEM), derBase64(standbyPEM)}, keyDescriptors(metadata, "signing"))
+	assert.Equal(t, []string{derBase64(globalPEM)}, keyDescriptors(metadata, "encryption"))
+
+	// Depois da troca, o certificado ativo guardado substitui o global e o anterior segue publicado
+	activePEM, _, err := GenerateCertificate("active", time.Hour)
+	require.NoError(t, err)
+	mock.ExpectQuery(`SELECT \* FROM "saml_certificates"`).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(uuid.New(), idp.ID, models.SAMLCertificateActive, activePEM, time.Now()).
+		AddRow(uuid.New(), idp.ID, models.SAMLCertificateStandby, standbyPEM, time.Now().Add(-time.Hour)))
+	metadata, err = ServiceProviderMetadata(db, idp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{derBase64(activePEM), derBase64(standbyPEM)}, keyDescriptors(metadata, "signing"))
+	assert.Equal(t, []string{derBase64(activePEM)}, keyDescriptors(metadata, "encryption"))
+	_, err = xml.Marshal(metadata)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestServiceProviderMetadataWithoutCertificate(t *testing.T) {
+	setGlobalSP(t, "https://grc.example.test", "", "")
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(`SELECT \* FROM "saml_certificates"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err := ServiceProviderMetadata(db, &models.IdentityProvider{ID: uuid.New(), ProviderType: models.IDPTypeSAML})
+	assert.ErrorIs(t, err, ErrNoSigningCertificate)
+}
@@ -4,13 +4,16 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
 	"net/url"
-	"os"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/crewjam/saml/samlsp"
+	"gorm.io/gorm"
 )
 
 type SAMLIdPConfig struct {
@@ -18,57 +21,75 @@ type SAMLIdPConfig struct {
 	SignRequest bool   `json:"sign_request"`
 }
 
+// Configuração global do SP, protegida por spMu: pode ser refeita em segundo plano (ver health.InitOptional)
+// enquanto os logins SAML a leem.
 var (
+	spMu          sync.RWMutex
 	spRootURL     string
 	spKey         *rsa.PrivateKey
 	spCertificate *x509.Certificate
 )
 
+// InitializeSAMLSPGlobalConfig carrega a URL pública e o par chave/certificado global do SP. O par é opcional
+// (os IdPs podem ter certificados próprios, ver certificates.go), mas se configurado precisa estar completo. Um
+// certificado global vencido é tratado como falha: o SAML fica indisponível até a troca do certificado.
 func InitializeSAMLSPGlobalConfig() error {
-	spRootURL = os.Getenv("APP_ROOT_URL")
-	if spRootURL == "" {
+	rootURL := config.Cfg.AppRootURL
+	if rootURL == "" {
 		return fmt.Errorf("APP_ROOT_URL environment variable not set")
 	}
-	spKeyPEM := os.Getenv("SAML_SP_KEY_PEM")
-	spCertPEM := os.Getenv("SAML_SP_CERT_PEM")
-	if spKeyPEM == "" || spCertPEM == "" {
-		return fmt.Errorf("SAML_SP_KEY_PEM and SAML_SP_CERT_PEM must be set")
-	}
-	block, _ := pem.Decode([]byte(spKeyPEM))
-	if block == nil {
-		return fmt.Errorf("failed to decode SP private key PEM")
+	spKeyPEM, spCertPEM := config.Cfg.SAMLSPKeyPEM, config.Cfg.SAMLSPCertPEM
+	if (spKeyPEM == "") != (spCertPEM == "") {
+		return fmt.Errorf("SAML_SP_KEY_PEM and SAML_SP_CERT_PEM must be set together")
 	}
-	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
-	if err != nil {
-		parsedKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	var key *rsa.PrivateKey
+	var certificate *x509.Certificate
+	if spKeyPEM != "" {
+		var err error
+		key, certificate, err = parseKeyPairPEM(spCertPEM, spKeyPEM)
 		if err != nil {
-			return fmt.Errorf("failed to parse SP private key: %w", err)
+			return err
+		}
+		if time.Now().After(certificate.NotAfter) {
+			return fmt.Errorf("SP certificate expired at %s", certificate.NotAfter.UTC().Format(time.RFC3339))
 		}
 	}
-	var ok bool
-	spKey, ok = parsedKey.(*rsa.PrivateKey)
-	if !ok {
-		return fmt.Errorf("SP key is not RSA private key")
-	}
-	certBlock, _ := pem.Decode([]byte(spCertPEM))
-	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
-		return fmt.Errorf("failed to decode SP certificate PEM")
-	}
-	spCertificate, err = x509.ParseCertificate(certBlock.Bytes)
-	if err != nil {
-		return fmt.Errorf("failed to parse SP certificate: %w", err)
-	}
+
+	spMu.Lock()
+	defer spMu.Unlock()
+	spRootURL, spKey, spCertificate = rootURL, key, certificate
 	return nil
 }
 
-func GetSAMLServiceProviderOptions(idpModel *models.IdentityProvider) (*samlsp.Options, error) {
-	if spKey == nil || spCertificate == nil || spRootURL == "" {
-		return nil, fmt.Errorf("SAML SP global config not initialized")
-	}
+// idpConfig lê a configuração SAML do IdP. Um config_json inválido ou vazio resulta nos valores padrão.
+func idpConfig(idpModel *models.IdentityProvider) SAMLIdPConfig {
 	var cfg SAMLIdPConfig
-	// Ignorando erro de Unmarshal aqui, pois cfg será zero-value se ConfigJSON for inválido ou vazio,
-	// o que é tratado por defaults abaixo. Em um cenário de produção robusto, o erro seria logado.
 	_ = json.Unmarshal([]byte(idpModel.ConfigJSON), &cfg)
+	return cfg
+}
+
+// spEntityID é o EntityID do SP para o IdP: sp_entity_id da configuração ou, por padrão, a URL dos metadados do SP.
+func spEntityID(rootURL string, idpModel *models.IdentityProvider) string {
+	if cfg := idpConfig(idpModel); cfg.SpEntityID != "" {
+		return cfg.SpEntityID
+	}
+	return fmt.Sprintf("%s/auth/saml/%s/metadata", strings.TrimRight(rootURL, "/"), idpModel.ID.String())
+}
+
+// GetSAMLServiceProviderOptions monta as opções do SP para o IdP, com o certificado ativo guardado para ele (ou o
+// par global).
+func GetSAMLServiceProviderOptions(db *gorm.DB, idpModel *models.IdentityProvider) (*samlsp.Options, error) {
+	spMu.RLock()
+	spRootURL := spRootURL
+	spMu.RUnlock()
+	if spRootURL == "" {
+		return nil, fmt.Errorf("SAML SP global config not initialized")
+	}
+	cfg := idpConfig(idpModel)
+	spKey, spCertificate, err := signingKeyPair(db, idpModel.ID)
+	if err != nil {
+		return nil, err
+	}
 
 	parsedSpRootURL, err := url.Parse(spRootURL)
 	if err != nil {
@@ -76,23 +97,8 @@ func GetSAMLServiceProviderOptions(idpModel *models.IdentityProvider) (*samlsp.O
 	}
 
 	// ACS URL é construída dinamicamente pela biblioteca samlsp, geralmente como `opts.URL.String() + "/acs"`.
-	// A URL de metadados também é dinâmica.
-
-	// Metadata URL também deve ser dinâmica.
-	// Ex: https://app.example.com/auth/saml/uuid-do-idp/metadata
-	metadataURLString := fmt.Sprintf("%s/auth/saml/%s/metadata", spRootURL, idpModel.ID.String())
-	// parsedMetadataURL, err := url.Parse(metadataURLString) // Não usado diretamente em samlsp.Options, mas bom para consistência
-	// if err != nil {
-	// 	return nil, fmt.Errorf("failed to parse Metadata URL '%s': %w", metadataURLString, err)
-	// }
-
-
-	// SpEntityID pode vir da configuração do IdP no banco, ou default para a URL de metadados do SP.
-	spEntityID := cfg.SpEntityID
-	if spEntityID == "" {
-		spEntityID = metadataURLString // Default EntityID para a URL de metadados do SP
-	}
-
+	// A URL de metadados (e EntityID padrão) é https://app.example.com/auth/saml/uuid-do-idp/metadata
+	spEntityID := spEntityID(spRootURL, idpModel)
 
 	opts := samlsp.Options{
 		URL:         *parsedSpRootURL, // URL base da aplicação (Service Provider)
@@ -2,12 +2,14 @@ package samlauth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"phoenixgrc/backend/internal/auth"
 	"phoenixgrc/backend/internal/database"
 	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/internal/ssomapping"
 	"phoenixgrc/backend/pkg/config"
 	phxlog "phoenixgrc/backend/pkg/log"
 	"strings"
@@ -44,7 +46,7 @@ func getSAMLServiceProvider(c *gin.Context, idpID uuid.UUID) (*samlsp.Middleware
 
 	// ConfigJSON já deve estar preenchido pelo GORM a partir do DB.
 
-	opts, err := GetSAMLServiceProviderOptions(&idpModel)
+	opts, err := GetSAMLServiceProviderOptions(db, &idpModel)
 	if err != nil {
 		return nil, &idpModel, fmt.Errorf("failed to get SAML SP options for IdP %s (Name: %s): %w", idpID, idpModel.Name, err)
 	}
@@ -59,26 +61,6 @@ func getSAMLServiceProvider(c *gin.Context, idpID uuid.UUID) (*samlsp.Middleware
 	return spMiddleware, &idpModel, nil
 }
 
-func MetadataHandler(c *gin.Context) {
-	idpIDStr := c.Param("idpId")
-	idpID, err := uuid.Parse(idpIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid IdP ID format"})
-		return
-	}
-
-	middleware, _, err := getSAMLServiceProvider(c, idpID)
-	if err != nil {
-		phxlog.L.Error("Error getting SAML SP for metadata",
-			zap.String("idpID", idpIDStr),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to configure SAML service provider for metadata."})
-		return
-	}
-	middleware.ServeMetadata(c.Writer, c.Request)
-}
-
-
 // ACSAttributeMapping define a estrutura esperada para o AttributeMappingJSON
 type ACSAttributeMapping struct {
 	Email     string `json:"email"`
@@ -142,14 +124,13 @@ func ACSHandler(c *gin.Context) {
 	}
 	attrs := samlSession.GetAttributes()
 
-	// A asserção não é mais um campo público. Acessamos via método.
-	assertion := samlSession.GetAssertion()
-	if assertion == nil {
-		phxlog.L.Error("SAML session assertion is nil after GetAssertion()", zap.String("idpID", idpIDStr))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "SAML assertion not found after successful login."})
+	// A sessão JWT não guarda a asserção: o NameID fica no Subject das claims (ver samlsp.JWTSessionCodec.New)
+	nameID := strings.TrimSpace(samlSession.Subject)
+	if nameID == "" {
+		phxlog.L.Error("SAML session has no NameID (subject)", zap.String("idpID", idpIDStr))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "SAML assertion subject (NameID) not found after successful login."})
 		return
 	}
-	nameID := assertion.Subject.NameID.Value // NameID da asserção
 
 	// Parsear o mapeamento de atributos do IdP
 	var attrMapping ACSAttributeMapping
@@ -160,11 +141,16 @@ func ACSHandler(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error processing IdP attribute mapping."})
 			return
 		}
-	} else {
-		// Mapeamentos padrão se não configurado (ajustar conforme necessidade)
+	}
+	// Mapeamentos padrão para os campos não configurados (o JSON pode ter só o mapeamento de papéis e equipes)
+	if attrMapping.Email == "" {
 		attrMapping.Email = "email" // Ou "mail", "EmailAddress", etc.
+	}
+	if attrMapping.FirstName == "" {
 		attrMapping.FirstName = "firstName" // Ou "givenName"
-		attrMapping.LastName = "lastName"   // Ou "sn", "surname"
+	}
+	if attrMapping.LastName == "" {
+		attrMapping.LastName = "lastName" // Ou "sn", "surname"
 	}
 
 	email := strings.ToLower(strings.TrimSpace(attrs.Get(attrMapping.Email)))
@@ -187,22 +173,65 @@ func ACSHandler(c *gin.Context) {
 	db := database.GetDB()
 	var user models.User
 	err = db.Where("email = ? AND organization_id = ?", email, idpModel.OrganizationID).First(&user).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		phxlog.L.Error("Database error fetching user for SAML login",
+			zap.String("email", email), zap.String("idpName", idpModel.Name), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error during SAML login."})
+		return
+	}
 
-	if err == gorm.ErrRecordNotFound { // Usuário não existe, provisionar
-		var allowUserCreation string
-		allowUserCreation, err = models.GetSystemSetting(db, "ALLOW_SAML_USER_CREATION")
-		if err != nil {
-			// Se a configuração não existir, assuma um padrão seguro (não permitir criação)
-			allowUserCreation = "false"
+	// Grupos do IdP: definem o acesso (allowed_groups) e, mais abaixo, papel e equipes (ver ssomapping)
+	groupsMapping, mapErr := ssomapping.Parse(idpModel.AttributeMappingJSON)
+	if mapErr != nil {
+		phxlog.L.Error("Invalid SAML attribute mapping",
+			zap.String("idpName", idpModel.Name), zap.Error(mapErr))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply identity provider role mapping."})
+		return
+	}
+	groups := append([]string{}, attrs[groupsMapping.GroupsAttribute]...)
+	var existingUser *models.User
+	if err == nil {
+		existingUser = &user
+	}
+	if accessErr := ssomapping.Authorize(db, idpModel, existingUser, groups); accessErr != nil {
+		if errors.Is(accessErr, ssomapping.ErrAccessDenied) {
+			phxlog.L.Warn("SAML login denied: no allowed group asserted",
+				zap.String("email", email), zap.String("idpName", idpModel.Name), zap.Bool("existingUser", existingUser != nil))
+			c.JSON(http.StatusForbidden, gin.H{"error": "Your identity provider account is not in a group allowed to access this organization."})
+			return
 		}
+		phxlog.L.Error("Failed to check SAML group access",
+			zap.String("email", email), zap.String("idpName", idpModel.Name), zap.Error(accessErr))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply identity provider role mapping."})
+		return
+	}
 
-		if allowUserCreation != "true" {
-			phxlog.L.Warn("SAML user creation disabled, user not provisioned",
-				zap.String("email", email), zap.String("idpName", idpModel.Name))
-			c.JSON(http.StatusForbidden, gin.H{"error": "New user registration via this SAML provider is disabled."})
+	if err == gorm.ErrRecordNotFound { // Usuário não existe, provisionar
+		// Um convite pendente da organização para o e-mail autoriza a criação e define o papel
+		invitation, invErr := models.FindPendingInvitation(db, idpModel.OrganizationID, email)
+		if invErr != nil {
+			phxlog.L.Error("Failed to look up user invitation for SAML login",
+				zap.String("email", email), zap.String("idpName", idpModel.Name), zap.Error(invErr))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error during SAML login."})
 			return
 		}
 
+		if invitation == nil {
+			var allowUserCreation string
+			allowUserCreation, err = models.GetSystemSetting(db, "ALLOW_SAML_USER_CREATION")
+			if err != nil {
+				// Se a configuração não existir, assuma um padrão seguro (não permitir criação)
+				allowUserCreation = "false"
+			}
+
+			if allowUserCreation != "true" {
+				phxlog.L.Warn("SAML user creation disabled, user not provisioned",
+					zap.String("email", email), zap.String("idpName", idpModel.Name))
+				c.JSON(http.StatusForbidden, gin.H{"error": "New user registration via this SAML provider is disabled."})
+				return
+			}
+		}
+
 		user = models.User{
 			OrganizationID: uuid.NullUUID{UUID: idpModel.OrganizationID, Valid: true},
 			Name:           fullName,
@@ -213,7 +242,21 @@ func ACSHandler(c *gin.Context) {
 			Role:           models.RoleUser, // Ou buscar de um atributo SAML mapeado para role
 			IsActive:       true,
 		}
-		if createErr := db.Create(&user).Error; createErr != nil {
+		if invitation != nil {
+			invitation.Apply(&user)
+		}
+		createErr := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&user).Error; err != nil {
+				return err
+			}
+			if invitation != nil {
+				if _, err := invitation.MarkAccepted(tx, user.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if createErr != nil {
 			phxlog.L.Error("Failed to create new SAML user",
 				zap.String("email", email), zap.String("idpName", idpModel.Name), zap.Error(createErr))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to provision SAML user."})
@@ -221,11 +264,6 @@ func ACSHandler(c *gin.Context) {
 		}
 		phxlog.L.Info("New SAML user provisioned",
 			zap.String("userID", user.ID.String()), zap.String("email", email), zap.String("idpName", idpModel.Name))
-	} else if err != nil { // Outro erro de DB
-		phxlog.L.Error("Database error fetching user for SAML login",
-			zap.String("email", email), zap.String("idpName", idpModel.Name), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error during SAML login."})
-		return
 	} else { // Usuário existe
 		user.SSOProvider = idpModel.Name
 		user.SocialLoginID = nameID
@@ -244,8 +282,22 @@ func ACSHandler(c *gin.Context) {
 			zap.String("userID", user.ID.String()), zap.String("email", email))
 	}
 
+	// Papel e equipes pelos grupos do IdP, a cada login (ver ssomapping)
+	mapped, err := ssomapping.Apply(db, idpModel, &user, groups)
+	if err == nil && mapped.Changed() {
+		phxlog.L.Info("SAML group mapping applied",
+			zap.String("userID", user.ID.String()), zap.String("role", string(mapped.Role)),
+			zap.Strings("teamsAdded", mapped.TeamsAdded), zap.Strings("teamsRemoved", mapped.TeamsRemoved))
+	}
+	if err != nil {
+		phxlog.L.Error("Failed to apply SAML group mapping",
+			zap.String("userID", user.ID.String()), zap.String("idpName", idpModel.Name), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply identity provider role mapping."})
+		return
+	}
+
 	// Gerar token JWT da aplicação
-	appToken, err := auth.GenerateToken(&user, user.OrganizationID)
+	session, err := auth.StartSession(db, &user, user.OrganizationID.UUID, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		phxlog.L.Error("Failed to generate application token after SAML login",
 			zap.String("userID", user.ID.String()), zap.Error(err))
@@ -256,9 +308,10 @@ func ACSHandler(c *gin.Context) {
 	// Redirecionar para o frontend com o token
 	// O frontend precisa ter uma rota /saml/callback para processar este token
 	frontendSAMLCallbackURL := strings.TrimSuffix(config.Cfg.FrontendBaseURL, "/") + "/saml/callback"
-	targetURL := fmt.Sprintf("%s?token=%s&sso_success=true&provider=saml&idp_name=%s",
+	targetURL := fmt.Sprintf("%s?token=%s&refresh_token=%s&sso_success=true&provider=saml&idp_name=%s",
 		frontendSAMLCallbackURL,
-		url.QueryEscape(appToken),
+		url.QueryEscape(session.AccessToken),
+		url.QueryEscape(session.RefreshToken),
 		url.QueryEscape(idpModel.Name),
 	)
 	phxlog.L.Info("SAML login successful, redirecting to frontend",
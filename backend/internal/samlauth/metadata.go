@@ -0,0 +1,131 @@
+package samlauth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+)
+
+// maxMetadataSize limita o tamanho dos metadados aceitos (upload ou URL).
+const maxMetadataSize = 1 << 20
+
+var metadataHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// IdPMetadataConfig contém os campos do config_json de um IdP SAML extraídos dos metadados.
+// As chaves JSON são as mesmas esperadas em IdentityProvider.ConfigJSON.
+type IdPMetadataConfig struct {
+	EntityID     string   `json:"idp_entity_id"`
+	SSOURL       string   `json:"idp_sso_url"`
+	X509Cert     string   `json:"idp_x509_cert"`            // Primeiro certificado de assinatura, em PEM
+	X509Certs    []string `json:"idp_x509_certs,omitempty"` // Todos os certificados de assinatura (rotação)
+	MetadataURL  string   `json:"idp_metadata_url,omitempty"`
+	CertNotAfter string   `json:"idp_cert_not_after,omitempty"` // Validade do primeiro certificado (RFC3339)
+}
+
+// ParseIdPMetadata extrai entity ID, URL de SSO e certificados de assinatura do XML de metadados do IdP.
+// Aceita EntityDescriptor ou EntitiesDescriptor (usa o primeiro IdP encontrado).
+func ParseIdPMetadata(data []byte) (*IdPMetadataConfig, error) {
+	if len(data) > maxMetadataSize {
+		return nil, fmt.Errorf("metadata exceeds %d bytes", maxMetadataSize)
+	}
+	entity, err := samlsp.ParseMetadata(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAML metadata: %w", err)
+	}
+	if entity.EntityID == "" {
+		return nil, fmt.Errorf("metadata has no entityID")
+	}
+	if len(entity.IDPSSODescriptors) == 0 {
+		return nil, fmt.Errorf("metadata has no IDPSSODescriptor")
+	}
+	idp := entity.IDPSSODescriptors[0]
+
+	cfg := &IdPMetadataConfig{EntityID: entity.EntityID, SSOURL: pickSSOURL(idp.SingleSignOnServices)}
+	if cfg.SSOURL == "" {
+		return nil, fmt.Errorf("metadata has no SingleSignOnService with HTTP-Redirect or HTTP-POST binding")
+	}
+	for _, key := range idp.KeyDescriptors {
+		if key.Use != "" && key.Use != "signing" {
+			continue
+		}
+		for _, cert := range key.KeyInfo.X509Data.X509Certificates {
+			certPEM, notAfter, err := certificateToPEM(cert.Data)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.X509Cert == "" {
+				cfg.X509Cert = certPEM
+				cfg.CertNotAfter = notAfter.UTC().Format(time.RFC3339)
+			}
+			cfg.X509Certs = append(cfg.X509Certs, certPEM)
+		}
+	}
+	if cfg.X509Cert == "" {
+		return nil, fmt.Errorf("metadata has no signing certificate")
+	}
+	return cfg, nil
+}
+
+// FetchIdPMetadata baixa e interpreta os metadados publicados pelo IdP.
+func FetchIdPMetadata(ctx context.Context, metadataURL string) (*IdPMetadataConfig, error) {
+	parsed, err := url.Parse(metadataURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid metadata URL: %s", metadataURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := metadataHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metadata URL returned status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+	cfg, err := ParseIdPMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MetadataURL = parsed.String()
+	return cfg, nil
+}
+
+// pickSSOURL prefere o binding HTTP-Redirect, usado pelo SP para enviar o AuthnRequest.
+func pickSSOURL(endpoints []saml.Endpoint) string {
+	for _, binding := range []string{saml.HTTPRedirectBinding, saml.HTTPPostBinding} {
+		for _, endpoint := range endpoints {
+			if endpoint.Binding == binding && endpoint.Location != "" {
+				return endpoint.Location
+			}
+		}
+	}
+	return ""
+}
+
+func certificateToPEM(data string) (string, time.Time, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data), ""))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid certificate encoding in metadata: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid certificate in metadata: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), cert.NotAfter, nil
+}
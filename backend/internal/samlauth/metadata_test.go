@@ -0,0 +1,90 @@
+package samlauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCertificateBase64(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func testMetadataXML(cert string) string {
+	return fmt.Sprintf(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/saml">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="encryption">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>not-a-signing-cert</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+%s
+      </ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, cert)
+}
+
+func TestParseIdPMetadataExtractsConfig(t *testing.T) {
+	cfg, err := ParseIdPMetadata([]byte(testMetadataXML(testCertificateBase64(t))))
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://idp.example.com/saml", cfg.EntityID)
+	assert.Equal(t, "https://idp.example.com/sso/redirect", cfg.SSOURL, "HTTP-Redirect binding is preferred")
+	assert.True(t, strings.HasPrefix(cfg.X509Cert, "-----BEGIN CERTIFICATE-----"))
+	assert.Len(t, cfg.X509Certs, 1, "encryption-only keys are ignored")
+	assert.NotEmpty(t, cfg.CertNotAfter)
+}
+
+func TestParseIdPMetadataRejectsInvalidInput(t *testing.T) {
+	_, err := ParseIdPMetadata([]byte("<not-metadata/>"))
+	assert.Error(t, err)
+
+	noSSO := strings.Replace(testMetadataXML(testCertificateBase64(t)), "SingleSignOnService", "ArtifactResolutionService", -1)
+	_, err = ParseIdPMetadata([]byte(noSSO))
+	assert.Error(t, err)
+}
+
+func TestFetchIdPMetadataRecordsURL(t *testing.T) {
+	metadata := testMetadataXML(testCertificateBase64(t))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/samlmetadata+xml")
+		_, _ = w.Write([]byte(metadata))
+	}))
+	defer server.Close()
+
+	cfg, err := FetchIdPMetadata(context.Background(), server.URL+"/metadata")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/metadata", cfg.MetadataURL)
+	assert.Equal(t, "https://idp.example.com/saml", cfg.EntityID)
+
+	_, err = FetchIdPMetadata(context.Background(), "ftp://idp.example.com/metadata")
+	assert.Error(t, err)
+}
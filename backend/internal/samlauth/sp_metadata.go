@@ -0,0 +1,143 @@
+package samlauth
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+	"strings"
+
+	"github.com/crewjam/saml"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// publishedCertificates retorna os certificados do SP a anunciar para o IdP, o ativo primeiro e depois os de
+// reserva. Sem certificado ativo guardado, o par global faz o papel do ativo.
+func publishedCertificates(db *gorm.DB, identityProviderID uuid.UUID) (active *x509.Certificate, all []*x509.Certificate, err error) {
+	var stored []models.SAMLCertificate
+	err = db.Where("identity_provider_id = ?", identityProviderID).Order("created_at DESC").Find(&stored).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading SP certificates: %w", err)
+	}
+	var standby []*x509.Certificate
+	for _, cert := range stored {
+		parsed, err := parseCertificatePEM(cert.CertificatePEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("certificate %s: %w", cert.ID, err)
+		}
+		if cert.Status == models.SAMLCertificateActive {
+			active = parsed
+		} else {
+			standby = append(standby, parsed)
+		}
+	}
+	if active == nil {
+		spMu.RLock()
+		active = spCertificate
+		spMu.RUnlock()
+	}
+	if active == nil {
+		return nil, nil, ErrNoSigningCertificate
+	}
+	return active, append([]*x509.Certificate{active}, standby...), nil
+}
+
+// ServiceProviderMetadata gera os metadados do SP para o IdP a partir da configuração guardada. Todos os certificados
+// (ativo e de reserva) são publicados para assinatura, para que o IdP já confie no próximo antes da troca; só o ativo
+// é publicado para cifragem, pois é o único com que as asserções podem ser decifradas.
+func ServiceProviderMetadata(db *gorm.DB, idpModel *models.IdentityProvider) (*saml.EntityDescriptor, error) {
+	spMu.RLock()
+	rootURL := strings.TrimRight(spRootURL, "/")
+	spMu.RUnlock()
+	if rootURL == "" {
+		return nil, fmt.Errorf("SAML SP global config not initialized")
+	}
+	active, certificates, err := publishedCertificates(db, idpModel.ID)
+	if err != nil {
+		return nil, err
+	}
+	base := fmt.Sprintf("%s/auth/saml/%s", rootURL, idpModel.ID.String())
+	metadataURL, err := url.Parse(base + "/metadata")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SP metadata URL: %w", err)
+	}
+	acsURL, err := url.Parse(base + "/acs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SP ACS URL: %w", err)
+	}
+
+	sp := saml.ServiceProvider{
+		EntityID:          spEntityID(rootURL, idpModel),
+		Certificate:       active,
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		AuthnNameIDFormat: saml.UnspecifiedNameIDFormat,
+	}
+	metadata := sp.Metadata()
+	descriptor := &metadata.SPSSODescriptors[0]
+	for _, certificate := range certificates {
+		descriptor.KeyDescriptors = append(descriptor.KeyDescriptors, saml.KeyDescriptor{
+			Use: "signing",
+			KeyInfo: saml.KeyInfo{X509Data: saml.X509Data{X509Certificates: []saml.X509Certificate{
+				{Data: base64.StdEncoding.EncodeToString(certificate.Raw)},
+			}}},
+		})
+	}
+	signRequests := idpConfig(idpModel).SignRequest
+	descriptor.AuthnRequestsSigned = &signRequests
+	// O ACS só aceita o binding HTTP-POST
+	descriptor.AssertionConsumerServices = descriptor.AssertionConsumerServices[:1]
+	return metadata, nil
+}
+
+// MetadataHandler serves the SP metadata XML for a SAML identity provider. Public, so the IdP can fetch it;
+// inactive providers are served too, since the IdP side is usually set up before the provider is enabled.
+func MetadataHandler(c *gin.Context) {
+	idpIDStr := c.Param("idpId")
+	idpID, err := uuid.Parse(idpIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid IdP ID format"})
+		return
+	}
+
+	db := database.GetDB()
+	var idpModel models.IdentityProvider
+	if err := db.First(&idpModel, "id = ?", idpID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "SAML identity provider not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch identity provider: " + err.Error()})
+		return
+	}
+	if idpModel.ProviderType != models.IDPTypeSAML {
+		c.JSON(http.StatusNotFound, gin.H{"error": "SAML identity provider not found"})
+		return
+	}
+
+	metadata, err := ServiceProviderMetadata(db, &idpModel)
+	if err != nil {
+		phxlog.L.Error("Error building SAML SP metadata", zap.String("idpID", idpIDStr), zap.Error(err))
+		if errors.Is(err, ErrNoSigningCertificate) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No SAML service provider certificate is configured for this identity provider"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to configure SAML service provider for metadata."})
+		return
+	}
+	body, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to configure SAML service provider for metadata."})
+		return
+	}
+	c.Data(http.StatusOK, "application/samlmetadata+xml", body)
+}
@@ -0,0 +1,244 @@
+// Package search implementa a busca textual da organização sobre riscos, controles, comentários de
+// avaliações, políticas e fornecedores, com o full-text search do Postgres. Cada fonte indexa um documento
+// (Source.Document); a migração 000041 cria os índices GIN com as mesmas expressões, que devem ser mantidas
+// iguais para que os índices sejam usados.
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Config é a configuração de idioma do full-text search (stemming e stopwords).
+const Config = "portuguese"
+
+// Limites do texto da busca.
+const (
+	MinQueryLength = 2
+	MaxQueryLength = 200
+)
+
+// headlineOptions marca os termos encontrados no trecho com ** (o conteúdo não é HTML).
+const headlineOptions = "MaxFragments=1, MaxWords=30, MinWords=10, StartSel=**, StopSel=**"
+
+// EntityType é o tipo de resultado da busca.
+type EntityType string
+
+const (
+	EntityRisk       EntityType = "risk"
+	EntityControl    EntityType = "control"
+	EntityAssessment EntityType = "assessment"
+	EntityPolicy     EntityType = "policy"
+	EntityVendor     EntityType = "vendor"
+)
+
+var (
+	ErrQueryTooShort = fmt.Errorf("search query must have at least %d characters", MinQueryLength)
+	ErrQueryTooLong  = fmt.Errorf("search query must have at most %d characters", MaxQueryLength)
+	ErrInvalidType   = errors.New("invalid search type")
+)
+
+// Source descreve uma entidade pesquisável. Document é a expressão indexada, com as colunas qualificadas
+// pela tabela Table; OrgFilter recebe o ID da organização como único parâmetro. Reference é a coluna do código
+// de referência sequencial (RISK-0042), vazia se a entidade não tiver um.
+type Source struct {
+	Type      EntityType
+	Table     string
+	From      string
+	OrgFilter string
+	ID        string
+	Title     string
+	Subtitle  string // Contexto do resultado: categoria, framework, situação ou criticidade
+	UpdatedAt string
+	Document  string
+	Reference string
+}
+
+// Sources são as fontes da busca, na ordem de desempate dos resultados.
+var Sources = []Source{
+	{
+		Type:      EntityRisk,
+		Table:     "risks",
+		From:      "risks",
+		OrgFilter: "risks.organization_id = ? AND risks.deleted_at IS NULL",
+		ID:        "risks.id",
+		Title:     "risks.title",
+		Subtitle:  "risks.category",
+		UpdatedAt: "risks.updated_at",
+		Document:  "coalesce(risks.title, '') || ' ' || coalesce(risks.description, '')",
+		Reference: "risks.reference_code",
+	},
+	{
+		Type:      EntityControl,
+		Table:     "audit_controls",
+		From:      "audit_controls JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id",
+		OrgFilter: "(audit_frameworks.organization_id IS NULL OR audit_frameworks.organization_id = ?)",
+		ID:        "audit_controls.id",
+		Title:     "audit_controls.control_id",
+		Subtitle:  "audit_frameworks.name",
+		UpdatedAt: "audit_controls.updated_at",
+		Document:  "coalesce(audit_controls.control_id, '') || ' ' || coalesce(audit_controls.family, '') || ' ' || coalesce(audit_controls.description, '')",
+	},
+	{
+		Type:  EntityAssessment,
+		Table: "audit_assessments",
+		From: "audit_assessments JOIN audit_controls ON audit_controls.id = audit_assessments.audit_control_id " +
+			"JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id",
+		OrgFilter: "audit_assessments.organization_id = ?",
+		ID:        "audit_assessments.id",
+		Title:     "audit_controls.control_id",
+		Subtitle:  "audit_frameworks.name",
+		UpdatedAt: "audit_assessments.updated_at",
+		Document:  "coalesce(audit_assessments.comments, '') || ' ' || coalesce(audit_assessments.c2m2_comments, '')",
+	},
+	{
+		Type:      EntityPolicy,
+		Table:     "policies",
+		From:      "policies",
+		OrgFilter: "policies.organization_id = ? AND policies.deleted_at IS NULL",
+		ID:        "policies.id",
+		Title:     "policies.title",
+		Subtitle:  "policies.status",
+		UpdatedAt: "policies.updated_at",
+		Document:  "coalesce(policies.title, '') || ' ' || coalesce(policies.description, '')",
+		Reference: "policies.reference_code",
+	},
+	{
+		Type:      EntityVendor,
+		Table:     "vendors",
+		From:      "vendors",
+		OrgFilter: "vendors.organization_id = ?",
+		ID:        "vendors.id",
+		Title:     "vendors.name",
+		Subtitle:  "vendors.criticality",
+		UpdatedAt: "vendors.updated_at",
+		Document:  "coalesce(vendors.name, '') || ' ' || coalesce(vendors.contact_name, '') || ' ' || coalesce(vendors.notes, '')",
+	},
+}
+
+// Result é um resultado da busca. Snippet é o trecho do documento com os termos entre **.
+type Result struct {
+	EntityType    EntityType `json:"entity_type"`
+	EntityID      uuid.UUID  `json:"entity_id"`
+	ReferenceCode string     `json:"reference_code,omitempty"`
+	Title         string     `json:"title"`
+	Subtitle      string     `json:"subtitle,omitempty"`
+	Snippet       string     `json:"snippet"`
+	Rank          float64    `json:"rank"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NormalizeQuery remove espaços extras e valida o tamanho do texto da busca.
+func NormalizeQuery(q string) (string, error) {
+	q = strings.Join(strings.Fields(q), " ")
+	if len([]rune(q)) < MinQueryLength {
+		return "", ErrQueryTooShort
+	}
+	if len([]rune(q)) > MaxQueryLength {
+		return "", ErrQueryTooLong
+	}
+	return q, nil
+}
+
+// ParseTypes interpreta o filtro de tipos separado por vírgulas; vazio retorna todas as fontes.
+func ParseTypes(raw string) ([]Source, error) {
+	if strings.TrimSpace(raw) == "" {
+		return Sources, nil
+	}
+	wanted := make(map[EntityType]bool)
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		found := false
+		for _, source := range Sources {
+			if string(source.Type) == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidType, t)
+		}
+		wanted[EntityType(t)] = true
+	}
+	var sources []Source
+	for _, source := range Sources {
+		if wanted[source.Type] {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) == 0 {
+		return Sources, nil
+	}
+	return sources, nil
+}
+
+// vector é o tsvector do documento da fonte, igual à expressão do índice.
+func (s Source) vector() string {
+	return fmt.Sprintf("to_tsvector('%s', %s)", Config, s.Document)
+}
+
+// referenceRank é o rank de um resultado cujo código de referência é igual ao texto da busca; fica acima
+// de qualquer ts_rank, que não passa de 1.
+const referenceRank = 1000
+
+// unionQuery monta a consulta com os resultados de todas as fontes; cada fonte usa dois parâmetros, o
+// texto da busca e o ID da organização. Se o texto for um código de referência (ex: risk-42), as fontes com
+// código também trazem o registro de código igual, mais dois parâmetros, e ele fica à frente dos demais.
+func unionQuery(sources []Source, q string, organizationID uuid.UUID) (string, []interface{}) {
+	_, code, isCode := models.ParseReferenceCode(q)
+	parts := make([]string, len(sources))
+	args := make([]interface{}, 0, 4*len(sources))
+	for i, s := range sources {
+		reference, rank, match := "''", fmt.Sprintf("ts_rank(%s, query)", s.vector()), s.vector()+" @@ query"
+		if s.Reference != "" {
+			reference = s.Reference
+		}
+		if s.Reference != "" && isCode {
+			rank = fmt.Sprintf("CASE WHEN %s = ? THEN %d ELSE %s END", s.Reference, referenceRank, rank)
+			match = fmt.Sprintf("(%s OR %s = ?)", match, s.Reference)
+		}
+		parts[i] = fmt.Sprintf("SELECT '%s' AS entity_type, %s AS entity_id, CAST(%s AS text) AS reference_code, "+
+			"%s AS title, CAST(%s AS text) AS subtitle, %s AS body, %s AS rank, %s AS updated_at "+
+			"FROM %s, websearch_to_tsquery('%s', ?) AS query WHERE %s AND %s",
+			s.Type, s.ID, reference, s.Title, s.Subtitle, s.Document, rank, s.UpdatedAt,
+			s.From, Config, s.OrgFilter, match)
+		if s.Reference != "" && isCode {
+			args = append(args, code, q, organizationID, code)
+		} else {
+			args = append(args, q, organizationID)
+		}
+	}
+	return strings.Join(parts, " UNION ALL "), args
+}
+
+// Search executa a busca nas fontes e retorna a página de resultados, do mais relevante ao menos
+// relevante (e do mais recente ao mais antigo no empate), com o total de resultados.
+func Search(db *gorm.DB, organizationID uuid.UUID, q string, sources []Source, limit, offset int) ([]Result, int64, error) {
+	union, args := unionQuery(sources, q, organizationID)
+
+	var total int64
+	if err := db.Raw("SELECT COUNT(*) FROM ("+union+") AS results", args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	results := []Result{}
+	if total == 0 {
+		return results, 0, nil
+	}
+	pageArgs := append([]interface{}{q}, args...)
+	pageArgs = append(pageArgs, limit, offset)
+	err := db.Raw(fmt.Sprintf("SELECT entity_type, entity_id, reference_code, title, subtitle, "+
+		"ts_headline('%s', body, websearch_to_tsquery('%s', ?), '%s') AS snippet, rank, updated_at "+
+		"FROM (%s) AS results ORDER BY rank DESC, updated_at DESC, entity_id LIMIT ? OFFSET ?",
+		Config, Config, headlineOptions, union), pageArgs...).Scan(&results).Error
+	return results, total, err
+}
@@ -0,0 +1,68 @@
+package search
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	q, err := NormalizeQuery("  risco   de\tbackup ")
+	require.NoError(t, err)
+	assert.Equal(t, "risco de backup", q)
+
+	_, err = NormalizeQuery(" a ")
+	assert.ErrorIs(t, err, ErrQueryTooShort)
+	_, err = NormalizeQuery(strings.Repeat("x", MaxQueryLength+1))
+	assert.ErrorIs(t, err, ErrQueryTooLong)
+}
+
+func TestParseTypes(t *testing.T) {
+	all, err := ParseTypes("")
+	require.NoError(t, err)
+	assert.Len(t, all, len(Sources))
+
+	sources, err := ParseTypes("vendor, risk")
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, EntityRisk, sources[0].Type, "mantém a ordem de Sources")
+	assert.Equal(t, EntityVendor, sources[1].Type)
+
+	_, err = ParseTypes("risk,asset")
+	assert.ErrorIs(t, err, ErrInvalidType)
+}
+
+func TestUnionQueryUsesTwoArgumentsPerSource(t *testing.T) {
+	orgID := uuid.New()
+	sources, err := ParseTypes("risk,policy")
+	require.NoError(t, err)
+	sql, args := unionQuery(sources, "backup", orgID)
+	assert.Equal(t, 2, strings.Count(sql, "UNION ALL")+1)
+	assert.Equal(t, len(args), strings.Count(sql, "?"))
+	assert.Equal(t, []interface{}{"backup", orgID, "backup", orgID}, args)
+}
+
+func TestUnionQueryMatchesReferenceCodes(t *testing.T) {
+	orgID := uuid.New()
+	sources, err := ParseTypes("risk,vendor")
+	require.NoError(t, err)
+	sql, args := unionQuery(sources, "risk-42", orgID)
+	assert.Equal(t, len(args), strings.Count(sql, "?"))
+	assert.Equal(t, []interface{}{"RISK-0042", "risk-42", orgID, "RISK-0042", "risk-42", orgID}, args,
+		"só a fonte com código recebe os parâmetros extras")
+	assert.Contains(t, sql, "OR risks.reference_code = ?")
+}
+
+// As expressões indexadas na migração devem ser iguais aos documentos das fontes, sem a tabela.
+func TestMigrationIndexesMatchSourceDocuments(t *testing.T) {
+	migration, err := os.ReadFile("../database/migrations/000041_add_search_indexes.up.sql")
+	require.NoError(t, err)
+	for _, source := range Sources {
+		expr := strings.ReplaceAll(source.vector(), source.Table+".", "")
+		assert.Contains(t, string(migration), "ON "+source.Table+" USING GIN ("+expr+")", "source %s", source.Type)
+	}
+}
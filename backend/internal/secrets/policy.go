@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"errors"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrSecretMaxAgeExceeded é retornado por Resolve quando a credencial ultrapassou a idade máxima da política
+// da organização e a política está em modo block.
+var ErrSecretMaxAgeExceeded = errors.New("secret exceeded the organization's maximum credential age")
+
+// Situação de uma credencial no inventário.
+const (
+	StatusOK             = "ok"
+	StatusRotationDue    = "rotation_due"     // Prazo de rotação do segredo vencido
+	StatusMaxAgeExceeded = "max_age_exceeded" // Mais antiga que a idade máxima da política
+)
+
+// Assessment é a avaliação de uma credencial em relação ao seu ciclo de rotação e à política da organização.
+type Assessment struct {
+	AgeDays    int        `json:"age_days"`               // Dias desde a última rotação
+	MaxAgeDays int        `json:"max_age_days,omitempty"` // 0 = sem idade máxima
+	MaxAgeAt   *time.Time `json:"max_age_at,omitempty"`   // Quando a credencial ultrapassa a idade máxima
+	Status     string     `json:"status"`
+	Blocked    bool       `json:"blocked"`             // Integrações não conseguem ler o valor
+	IdleDays   *int       `json:"idle_days,omitempty"` // Dias desde o último uso; nulo se nunca usada
+}
+
+// LoadPolicy retorna a política de credenciais da organização, ou nil se ela não configurou uma.
+func LoadPolicy(db *gorm.DB, orgID uuid.UUID) (*models.OrgSecretPolicy, error) {
+	var policy models.OrgSecretPolicy
+	if err := db.Where("organization_id = ?", orgID).Take(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Evaluate avalia a credencial no instante now. policy pode ser nil.
+func Evaluate(secret *models.OrgSecret, policy *models.OrgSecretPolicy, now time.Time) Assessment {
+	a := Assessment{AgeDays: int(now.Sub(secret.LastRotatedAt).Hours() / 24), Status: StatusOK}
+	if secret.LastUsedAt != nil {
+		idle := int(now.Sub(*secret.LastUsedAt).Hours() / 24)
+		a.IdleDays = &idle
+	}
+	if secret.RotationDueAt != nil && !now.Before(*secret.RotationDueAt) {
+		a.Status = StatusRotationDue
+	}
+	if policy != nil && policy.MaxAgeDays > 0 {
+		a.MaxAgeDays = policy.MaxAgeDays
+		limit := secret.LastRotatedAt.AddDate(0, 0, policy.MaxAgeDays)
+		a.MaxAgeAt = &limit
+		if !now.Before(limit) {
+			a.Status = StatusMaxAgeExceeded
+			a.Blocked = policy.Enforcement == models.OrgSecretEnforcementBlock
+		}
+	}
+	return a
+}
@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateWithoutPolicy(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	secret := &models.OrgSecret{LastRotatedAt: now.AddDate(0, 0, -400)}
+
+	a := Evaluate(secret, nil, now)
+	assert.Equal(t, 400, a.AgeDays)
+	assert.Equal(t, StatusOK, a.Status)
+	assert.Nil(t, a.MaxAgeAt)
+	assert.Nil(t, a.IdleDays, "nunca usada")
+	assert.False(t, a.Blocked)
+
+	secret.RotationIntervalDays = 90
+	secret.ScheduleRotation()
+	assert.Equal(t, StatusRotationDue, Evaluate(secret, nil, now).Status)
+}
+
+func TestEvaluateMaxAge(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastUsed := now.AddDate(0, 0, -3)
+	secret := &models.OrgSecret{LastRotatedAt: now.AddDate(0, 0, -89), LastUsedAt: &lastUsed}
+	policy := &models.OrgSecretPolicy{MaxAgeDays: 90, Enforcement: models.OrgSecretEnforcementWarn}
+
+	a := Evaluate(secret, policy, now)
+	assert.Equal(t, StatusOK, a.Status)
+	require.NotNil(t, a.MaxAgeAt)
+	assert.Equal(t, now.AddDate(0, 0, 1), *a.MaxAgeAt)
+	require.NotNil(t, a.IdleDays)
+	assert.Equal(t, 3, *a.IdleDays)
+
+	later := now.AddDate(0, 0, 1)
+	a = Evaluate(secret, policy, later)
+	assert.Equal(t, StatusMaxAgeExceeded, a.Status)
+	assert.False(t, a.Blocked, "warn apenas sinaliza")
+
+	policy.Enforcement = models.OrgSecretEnforcementBlock
+	assert.True(t, Evaluate(secret, policy, later).Blocked)
+	assert.False(t, Evaluate(secret, policy, now).Blocked)
+}
@@ -0,0 +1,56 @@
+// Package secrets é o ponto único de acesso das integrações às credenciais guardadas no cofre da organização
+// (models.OrgSecret). Toda leitura do valor em texto claro passa por Resolve, que registra o uso e aplica a
+// idade máxima da política de credenciais da organização (models.OrgSecretPolicy).
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrSecretNotFound é retornado quando a organização não possui um segredo com o nome informado.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Resolve retorna o valor em texto claro do segredo `name` da organização e registra o uso por `consumer`
+// (ex: "jira", "slack_notifier"). Deve ser chamado somente no servidor; o valor nunca deve ser devolvido pela API.
+func Resolve(ctx context.Context, orgID uuid.UUID, name, consumer string) (string, error) {
+	db := database.GetDB().WithContext(ctx)
+	var secret models.OrgSecret
+	if err := db.Where("organization_id = ? AND name = ?", orgID, name).First(&secret).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+
+	policy, err := LoadPolicy(db, orgID)
+	if err != nil {
+		return "", err
+	}
+	if Evaluate(&secret, policy, time.Now()).Blocked {
+		return "", fmt.Errorf("%w: %q", ErrSecretMaxAgeExceeded, name)
+	}
+
+	value, err := secret.DecryptValue()
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret %q: %w", name, err)
+	}
+
+	err = db.Model(&models.OrgSecret{}).Where("id = ?", secret.ID).Updates(map[string]interface{}{
+		"usage_count":  gorm.Expr("usage_count + 1"),
+		"last_used_at": time.Now(),
+		"last_used_by": consumer,
+	}).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to record secret usage: %w", err)
+	}
+	return value, nil
+}
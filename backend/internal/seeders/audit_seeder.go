@@ -90,11 +90,12 @@ func getFrameworksData() []FrameworkData {
 // SeedAuditFrameworksAndControls popula o banco de dados com frameworks e controles de auditoria.
 func SeedAuditFrameworksAndControls(db *gorm.DB) error {
 	frameworksData := getFrameworksData()
+	guidanceData := getControlGuidanceData()
 
 	for _, fd := range frameworksData {
 		// Tenta encontrar o framework pelo nome para evitar duplicatas
 		var existingFramework models.AuditFramework
-		err := db.Where("name = ?", fd.Name).First(&existingFramework).Error
+		err := db.Where("name = ? AND organization_id IS NULL", fd.Name).First(&existingFramework).Error
 
 		if err != nil && err != gorm.ErrRecordNotFound {
 			return fmt.Errorf("erro ao verificar framework existente %s: %w", fd.Name, err)
@@ -113,7 +114,9 @@ func SeedAuditFrameworksAndControls(db *gorm.DB) error {
 		}
 
 		// Semear controles para este framework
-		for _, cd := range fd.Controls {
+		for i, cd := range fd.Controls {
+			position := i + 1 // Sequência oficial = ordem da lista do framework
+			guidance := seededControlGuidance(guidanceData, fd.Name, cd.ControlID)
 			var existingControl models.AuditControl
 			// Verifica se o controle já existe para este framework e ControlID
 			errCtrl := db.Where("framework_id = ? AND control_id = ?", frameworkToSeed.ID, cd.ControlID).First(&existingControl).Error
@@ -129,10 +132,27 @@ func SeedAuditFrameworksAndControls(db *gorm.DB) error {
 					ControlID:   cd.ControlID,
 					Description: cd.Description,
 					Family:      cd.Family,
+					Position:    &position,
+				}
+				if err := controlToSeed.SetGuidance(guidance); err != nil {
+					return fmt.Errorf("erro ao preparar orientação do controle %s para framework %s: %w", cd.ControlID, fd.Name, err)
 				}
 				if result := db.Create(&controlToSeed); result.Error != nil {
 					return fmt.Errorf("erro ao semear controle %s para framework %s: %w", cd.ControlID, fd.Name, result.Error)
 				}
+			} else if existingControl.Position == nil || *existingControl.Position != position || existingControl.SortKey == "" ||
+				(existingControl.Guidance == nil && guidance != nil) {
+				// Controles semeados antes da ordenação oficial ou da orientação ao avaliador: preenche posição,
+				// chave natural e orientação.
+				existingControl.Position = &position
+				if existingControl.Guidance == nil {
+					if err := existingControl.SetGuidance(guidance); err != nil {
+						return fmt.Errorf("erro ao preparar orientação do controle %s para framework %s: %w", cd.ControlID, fd.Name, err)
+					}
+				}
+				if result := db.Save(&existingControl); result.Error != nil {
+					return fmt.Errorf("erro ao atualizar o controle %s para framework %s: %w", cd.ControlID, fd.Name, result.Error)
+				}
 			} else {
 				log.Printf("Controle %s para framework %s já existe, pulando.", cd.ControlID, fd.Name)
 			}
@@ -0,0 +1,308 @@
+package seeders
+
+import "phoenixgrc/backend/internal/models"
+
+// Referências gerais dos frameworks semeados, anexadas à orientação de todos os seus controles.
+var (
+	linkNISTCSF  = models.ControlGuidanceLink{Title: "NIST Cybersecurity Framework 2.0", URL: "https://www.nist.gov/cyberframework"}
+	linkCISv8    = models.ControlGuidanceLink{Title: "CIS Critical Security Controls v8", URL: "https://www.cisecurity.org/controls/v8"}
+	linkISO27001 = models.ControlGuidanceLink{Title: "ISO/IEC 27001:2022", URL: "https://www.iso.org/standard/27001"}
+	linkISO27002 = models.ControlGuidanceLink{Title: "ISO/IEC 27002:2022 (diretrizes de implementação)", URL: "https://www.iso.org/standard/75652.html"}
+)
+
+// frameworkGuidanceLinks são as referências de cada framework semeado.
+var frameworkGuidanceLinks = map[string][]models.ControlGuidanceLink{
+	frameworkNIST: {linkNISTCSF},
+	frameworkCIS:  {linkCISv8},
+	frameworkISO:  {linkISO27001, linkISO27002},
+}
+
+// controlGuidanceData define a orientação semeada de um controle; as referências do framework são
+// acrescentadas por seededControlGuidance.
+type controlGuidanceData struct {
+	Text             string
+	EvidenceExamples []string
+}
+
+// getControlGuidanceData retorna a orientação dos controles semeados, por framework e ControlID.
+// Assim como os controles, o conteúdo é exemplificativo e deve ser revisado para uso em produção.
+func getControlGuidanceData() map[string]map[string]controlGuidanceData {
+	return map[string]map[string]controlGuidanceData{
+		frameworkNIST: {
+			"GV.OC-1": {
+				Text: "Conforme quando os papéis de cibersegurança (ex: CISO, donos de ativos, resposta a incidentes) estão definidos por escrito, têm responsáveis nomeados e foram comunicados às áreas envolvidas. Papéis apenas implícitos ou desatualizados não atendem o controle.",
+				EvidenceExamples: []string{
+					"Matriz RACI de segurança da informação aprovada pela diretoria",
+					"Descrições de cargo com responsabilidades de cibersegurança",
+					"Comunicado interno ou ata de reunião divulgando os papéis",
+				},
+			},
+			"GV.OC-2": {
+				Text: "Conforme quando existe uma estratégia de cibersegurança formal, com o apetite a risco declarado, aprovada pela alta direção e revisada periodicamente (ao menos anualmente).",
+				EvidenceExamples: []string{
+					"Documento de estratégia de cibersegurança com aprovação da diretoria",
+					"Declaração de apetite a risco",
+					"Ata do comitê que aprovou ou revisou a estratégia",
+				},
+			},
+			"GV.RM-1": {
+				Text: "Conforme quando os riscos de cibersegurança são identificados, avaliados e tratados pelo mesmo processo de gestão de riscos corporativo, com critérios de impacto e probabilidade definidos e registro dos riscos.",
+				EvidenceExamples: []string{
+					"Metodologia de gestão de riscos aprovada",
+					"Registro de riscos atualizado com responsáveis e planos de tratamento",
+					"Relatório periódico de riscos apresentado à direção",
+				},
+			},
+			"GV.SC-1": {
+				Text: "Conforme quando os requisitos de segurança para fornecedores estão definidos em contrato ou política, os fornecedores críticos são avaliados antes da contratação e monitorados durante o relacionamento.",
+				EvidenceExamples: []string{
+					"Cláusulas de segurança da informação em contratos de fornecedores",
+					"Questionários de due diligence respondidos por fornecedores críticos",
+					"Relatórios de avaliação periódica de fornecedores",
+				},
+			},
+			"ID.AM-1": {
+				Text: "Conforme quando há um inventário de hardware completo e atualizado (servidores, estações, dispositivos de rede e móveis), com responsável por ativo e processo definido de atualização, preferencialmente automatizado.",
+				EvidenceExamples: []string{
+					"Exportação do inventário de ativos (CMDB ou ferramenta de descoberta)",
+					"Procedimento de inclusão e baixa de ativos",
+					"Evidência de conciliação periódica do inventário",
+				},
+			},
+			"ID.AM-2": {
+				Text: "Conforme quando o software e os serviços em uso (incluindo SaaS) estão inventariados, com versão, responsável e situação de suporte, e o inventário é revisado periodicamente.",
+				EvidenceExamples: []string{
+					"Inventário de software e serviços em nuvem",
+					"Relatório da ferramenta de gestão de software ou de descoberta de SaaS",
+					"Registro da última revisão do inventário",
+				},
+			},
+			"ID.RA-1": {
+				Text: "Conforme quando os ativos são verificados regularmente quanto a vulnerabilidades (varreduras, avisos de fabricantes), e as vulnerabilidades encontradas são registradas com criticidade e responsável pelo tratamento.",
+				EvidenceExamples: []string{
+					"Relatórios de varredura de vulnerabilidades dos últimos meses",
+					"Registro de vulnerabilidades com status de tratamento",
+					"Procedimento de gestão de vulnerabilidades com prazos por criticidade",
+				},
+			},
+			"PR.AA-1": {
+				Text: "Conforme quando o acesso físico a instalações e áreas sensíveis é restrito a pessoas autorizadas, com controle de entrada registrado e revisão periódica das autorizações.",
+				EvidenceExamples: []string{
+					"Relatório do sistema de controle de acesso físico",
+					"Lista de pessoas autorizadas por área, com data da última revisão",
+					"Registro de visitantes",
+				},
+			},
+			"PR.AA-2": {
+				Text: "Conforme quando as contas de usuário são concedidas por solicitação aprovada, seguem o menor privilégio, são revogadas no desligamento e os acessos são revisados periodicamente.",
+				EvidenceExamples: []string{
+					"Procedimento de concessão e revogação de acessos",
+					"Evidência da última revisão de acessos (campanha concluída)",
+					"Amostra de chamados de concessão com aprovação do gestor",
+				},
+			},
+			"PR.AT-1": {
+				Text: "Conforme quando todos os colaboradores recebem treinamento de conscientização em segurança na admissão e periodicamente, com registro de conclusão e acompanhamento de pendências.",
+				EvidenceExamples: []string{
+					"Relatório de conclusão dos treinamentos por colaborador",
+					"Conteúdo ou plano anual de conscientização",
+					"Resultados de simulações de phishing",
+				},
+			},
+			"PR.DS-1": {
+				Text: "Conforme quando os dados sensíveis em repouso (bancos de dados, discos, backups, armazenamento em nuvem) são criptografados com algoritmos atuais e as chaves são gerenciadas com acesso restrito.",
+				EvidenceExamples: []string{
+					"Configuração de criptografia de discos e bancos de dados",
+					"Política ou padrão de criptografia",
+					"Evidência da gestão de chaves (KMS, rotação)",
+				},
+			},
+			"DE.CM-1": {
+				Text: "Conforme quando o tráfego e os eventos de rede são coletados e analisados continuamente (IDS/IPS, SIEM), com alertas tratados por uma equipe definida.",
+				EvidenceExamples: []string{
+					"Painel ou relatório do SIEM com as fontes de log de rede",
+					"Regras de alerta configuradas",
+					"Amostra de alertas investigados e encerrados",
+				},
+			},
+			"RS.RP-1": {
+				Text: "Conforme quando existe um plano de resposta a incidentes aprovado, com papéis e etapas definidos, e há registro de sua execução em incidentes reais ou em exercícios.",
+				EvidenceExamples: []string{
+					"Plano de resposta a incidentes aprovado",
+					"Relatório de incidente com linha do tempo e lições aprendidas",
+					"Registro de exercício simulado (tabletop)",
+				},
+			},
+			"RC.RP-1": {
+				Text: "Conforme quando os planos de recuperação (incluindo restauração de backups) estão documentados, têm objetivos de recuperação definidos e foram testados com sucesso.",
+				EvidenceExamples: []string{
+					"Plano de recuperação de desastres com RTO e RPO",
+					"Relatório do último teste de restauração",
+					"Registro de recuperação após um evento real",
+				},
+			},
+		},
+		frameworkCIS: {
+			"CIS-1.1": {
+				Text: "Conforme quando há um inventário preciso de todos os ativos corporativos que armazenam ou processam dados, incluindo dispositivos remotos e em nuvem, revisado ao menos semestralmente.",
+				EvidenceExamples: []string{
+					"Exportação do inventário com data de atualização",
+					"Relatório da ferramenta de descoberta ativa ou passiva",
+					"Registro das revisões semestrais",
+				},
+			},
+			"CIS-1.2": {
+				Text: "Conforme quando ativos não autorizados são detectados e tratados (removidos, isolados ou incluídos no inventário) com frequência ao menos semanal.",
+				EvidenceExamples: []string{
+					"Procedimento de tratamento de ativos não autorizados",
+					"Relatório de dispositivos desconhecidos detectados e ação tomada",
+					"Configuração de controle de acesso à rede (NAC)",
+				},
+			},
+			"CIS-2.1": {
+				Text: "Conforme quando o software instalado está inventariado com nome, fabricante, versão e justificativa de negócio, e o inventário é revisado ao menos semestralmente.",
+				EvidenceExamples: []string{
+					"Inventário de software",
+					"Relatório da ferramenta de gestão de endpoints",
+					"Registro da revisão do inventário",
+				},
+			},
+			"CIS-2.2": {
+				Text: "Conforme quando apenas software suportado e autorizado está em uso, e softwares não autorizados são removidos ou documentados como exceção, com verificação ao menos mensal.",
+				EvidenceExamples: []string{
+					"Lista de software autorizado",
+					"Relatório de softwares não autorizados encontrados e removidos",
+					"Exceções aprovadas com prazo",
+				},
+			},
+			"CIS-3.1": {
+				Text: "Conforme quando existe um processo documentado de gestão de dados e de configuração segura, com responsáveis, revisado anualmente.",
+				EvidenceExamples: []string{
+					"Processo de gestão de dados aprovado",
+					"Padrões de configuração segura (baselines)",
+					"Registro da última revisão anual",
+				},
+			},
+			"CIS-3.3": {
+				Text: "Conforme quando as listas de controle de acesso restringem o acesso a dados e sistemas conforme a necessidade de cada usuário ou serviço, e são revisadas periodicamente.",
+				EvidenceExamples: []string{
+					"Exportação das ACLs ou regras de firewall",
+					"Evidência de revisão das regras",
+					"Solicitações de alteração aprovadas",
+				},
+			},
+			"CIS-4.1": {
+				Text: "Conforme quando firewalls, roteadores e demais dispositivos de rede seguem um padrão de configuração segura documentado, e os desvios são detectados e corrigidos.",
+				EvidenceExamples: []string{
+					"Padrão de configuração de dispositivos de rede",
+					"Relatório de conformidade de configuração",
+					"Backup das configurações com controle de versão",
+				},
+			},
+			"CIS-7.1": {
+				Text: "Conforme quando existe um processo documentado de gestão de vulnerabilidades, com frequência de varredura, prazos de correção por criticidade e revisão anual.",
+				EvidenceExamples: []string{
+					"Procedimento de gestão de vulnerabilidades",
+					"Relatórios de varredura recentes",
+					"Indicadores de tempo de correção por criticidade",
+				},
+			},
+		},
+		frameworkISO: {
+			"A.5.1": {
+				Text: "Conforme quando a política de segurança da informação e as políticas específicas estão aprovadas pela direção, publicadas, comunicadas aos colaboradores e partes interessadas e revisadas em intervalos planejados.",
+				EvidenceExamples: []string{
+					"Política de segurança da informação aprovada e versionada",
+					"Registro de aceite das políticas pelos colaboradores",
+					"Ata da última revisão das políticas",
+				},
+			},
+			"A.5.2": {
+				Text: "Conforme quando as responsabilidades de segurança da informação estão definidas e atribuídas conforme as necessidades da organização, incluindo os donos de ativos e processos.",
+				EvidenceExamples: []string{
+					"Matriz de responsabilidades de segurança",
+					"Nomeação formal do responsável pela segurança da informação",
+					"Organograma com as funções de segurança",
+				},
+			},
+			"A.5.15": {
+				Text: "Conforme quando existem regras de controle de acesso físico e lógico baseadas nos requisitos de negócio e de segurança, aplicadas na concessão, alteração e revogação de acessos.",
+				EvidenceExamples: []string{
+					"Política de controle de acesso",
+					"Evidência de revisão periódica de acessos",
+					"Amostra de solicitações de acesso aprovadas",
+				},
+			},
+			"A.5.23": {
+				Text: "Conforme quando os processos de aquisição, uso, gestão e saída de serviços em nuvem seguem requisitos de segurança definidos, incluindo a divisão de responsabilidades com o provedor.",
+				EvidenceExamples: []string{
+					"Política ou padrão de uso de serviços em nuvem",
+					"Matriz de responsabilidade compartilhada por provedor",
+					"Avaliação de segurança dos provedores em nuvem",
+				},
+			},
+			"A.6.3": {
+				Text: "Conforme quando os contratos de trabalho e de prestadores incluem as responsabilidades de segurança da informação do colaborador e da organização, aceitas antes do início das atividades.",
+				EvidenceExamples: []string{
+					"Modelo de contrato com cláusulas de segurança e confidencialidade",
+					"Termo de confidencialidade assinado (amostra)",
+					"Registro de aceite das políticas na admissão",
+				},
+			},
+			"A.7.4": {
+				Text: "Conforme quando as instalações são monitoradas continuamente contra acesso físico não autorizado (câmeras, alarmes, vigilância), com registros preservados e alertas tratados.",
+				EvidenceExamples: []string{
+					"Relatório do sistema de CFTV com período de retenção",
+					"Registros de alarmes e das respostas",
+					"Procedimento de monitoramento físico",
+				},
+			},
+			"A.8.1": {
+				Text: "Conforme quando as informações em dispositivos de usuários (notebooks, celulares) são protegidas por configuração padrão: criptografia, bloqueio de tela, antimalware e gestão remota.",
+				EvidenceExamples: []string{
+					"Relatório de conformidade da ferramenta de MDM ou de gestão de endpoints",
+					"Política de uso de dispositivos",
+					"Evidência de criptografia de disco habilitada",
+				},
+			},
+			"A.8.2": {
+				Text: "Conforme quando os acessos privilegiados são restritos, concedidos por aprovação, usam contas separadas e autenticação forte, e são revisados com frequência maior que os acessos comuns.",
+				EvidenceExamples: []string{
+					"Lista de contas privilegiadas com justificativa",
+					"Evidência de revisão periódica dos acessos privilegiados",
+					"Configuração de MFA ou de cofre de senhas privilegiadas (PAM)",
+				},
+			},
+			"A.8.9": {
+				Text: "Conforme quando as configurações de segurança de hardware, software, serviços e redes são definidas em padrões documentados, aplicadas, monitoradas e revisadas.",
+				EvidenceExamples: []string{
+					"Padrões de configuração (hardening) aprovados",
+					"Relatório de conformidade de configuração",
+					"Registro de mudanças de configuração aprovadas",
+				},
+			},
+			"A.8.16": {
+				Text: "Conforme quando redes, sistemas e aplicações são monitorados quanto a comportamento anômalo, com ações definidas para avaliar e responder a possíveis incidentes.",
+				EvidenceExamples: []string{
+					"Fontes de log integradas ao SIEM",
+					"Regras de detecção e casos de uso documentados",
+					"Amostra de alertas analisados",
+				},
+			},
+		},
+	}
+}
+
+// seededControlGuidance retorna a orientação semeada do controle com as referências do framework, ou nil se
+// o controle não tem orientação semeada.
+func seededControlGuidance(guidance map[string]map[string]controlGuidanceData, frameworkName, controlID string) *models.ControlGuidance {
+	data, ok := guidance[frameworkName][controlID]
+	if !ok {
+		return nil
+	}
+	return &models.ControlGuidance{
+		Text:             data.Text,
+		EvidenceExamples: data.EvidenceExamples,
+		Links:            frameworkGuidanceLinks[frameworkName],
+	}
+}
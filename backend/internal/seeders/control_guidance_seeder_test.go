@@ -0,0 +1,30 @@
+package seeders
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEverySeededControlHasGuidance(t *testing.T) {
+	guidance := getControlGuidanceData()
+	seeded := make(map[string]map[string]bool)
+	for _, fd := range getFrameworksData() {
+		seeded[fd.Name] = make(map[string]bool)
+		for _, cd := range fd.Controls {
+			seeded[fd.Name][cd.ControlID] = true
+			g := seededControlGuidance(guidance, fd.Name, cd.ControlID)
+			require.NotNil(t, g, "%s %s sem orientação", fd.Name, cd.ControlID)
+			assert.NoError(t, g.Validate(), "%s %s", fd.Name, cd.ControlID)
+			assert.NotEmpty(t, g.EvidenceExamples, "%s %s", fd.Name, cd.ControlID)
+			assert.NotEmpty(t, g.Links, "%s %s", fd.Name, cd.ControlID)
+		}
+	}
+	// Orientações sem controle semeado indicam um ControlID digitado errado
+	for framework, controls := range guidance {
+		for controlID := range controls {
+			assert.True(t, seeded[framework][controlID], "orientação de %s %s sem controle semeado", framework, controlID)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package seeders
+
+import (
+	"fmt"
+	"log"
+	"phoenixgrc/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	frameworkNIST = "NIST Cybersecurity Framework 2.0"
+	frameworkCIS  = "CIS Critical Security Controls v8"
+	frameworkISO  = "ISO/IEC 27001:2022 (Anexo A)"
+)
+
+// ControlMappingData define um mapeamento padrão entre controles de frameworks semeados.
+type ControlMappingData struct {
+	SourceFramework string
+	SourceControlID string
+	TargetFramework string
+	TargetControlID string
+	Relationship    models.ControlMappingRelationship
+}
+
+// getControlMappingsData retorna os mapeamentos padrão entre os controles semeados.
+// Assim como os controles, são exemplificativos e devem ser revisados para uso em produção.
+func getControlMappingsData() []ControlMappingData {
+	equivalent, partial := models.ControlMappingEquivalent, models.ControlMappingPartial
+	return []ControlMappingData{
+		// ISO 27001 ↔ NIST CSF
+		{frameworkISO, "A.5.1", frameworkNIST, "GV.OC-2", partial},
+		{frameworkISO, "A.5.2", frameworkNIST, "GV.OC-1", equivalent},
+		{frameworkISO, "A.5.15", frameworkNIST, "PR.AA-2", equivalent},
+		{frameworkISO, "A.5.23", frameworkNIST, "GV.SC-1", partial},
+		{frameworkISO, "A.6.3", frameworkNIST, "PR.AT-1", partial},
+		{frameworkISO, "A.7.4", frameworkNIST, "PR.AA-1", partial},
+		{frameworkISO, "A.8.1", frameworkNIST, "ID.AM-1", partial},
+		{frameworkISO, "A.8.2", frameworkNIST, "PR.AA-2", partial},
+		{frameworkISO, "A.8.16", frameworkNIST, "DE.CM-1", equivalent},
+		// ISO 27001 ↔ CIS Controls
+		{frameworkISO, "A.8.1", frameworkCIS, "CIS-1.1", partial},
+		{frameworkISO, "A.8.9", frameworkCIS, "CIS-3.1", equivalent},
+		{frameworkISO, "A.8.9", frameworkCIS, "CIS-4.1", partial},
+		// NIST CSF ↔ CIS Controls
+		{frameworkNIST, "ID.AM-1", frameworkCIS, "CIS-1.1", equivalent},
+		{frameworkNIST, "ID.AM-2", frameworkCIS, "CIS-2.1", equivalent},
+		{frameworkNIST, "ID.RA-1", frameworkCIS, "CIS-7.1", equivalent},
+		{frameworkNIST, "PR.AA-2", frameworkCIS, "CIS-3.3", partial},
+	}
+}
+
+// findSeededControl busca um controle pelo nome do framework e ControlID.
+func findSeededControl(db *gorm.DB, frameworkName, controlID string) (models.AuditControl, error) {
+	var control models.AuditControl
+	err := db.Joins("JOIN audit_frameworks ON audit_frameworks.id = audit_controls.framework_id").
+		Where("audit_frameworks.name = ? AND audit_frameworks.organization_id IS NULL AND audit_controls.control_id = ?", frameworkName, controlID).
+		First(&control).Error
+	return control, err
+}
+
+// SeedControlMappings popula os mapeamentos padrão entre frameworks (sem organização).
+func SeedControlMappings(db *gorm.DB) error {
+	for _, md := range getControlMappingsData() {
+		source, err := findSeededControl(db, md.SourceFramework, md.SourceControlID)
+		if err != nil {
+			return fmt.Errorf("erro ao buscar controle %s (%s) para mapeamento: %w", md.SourceControlID, md.SourceFramework, err)
+		}
+		target, err := findSeededControl(db, md.TargetFramework, md.TargetControlID)
+		if err != nil {
+			return fmt.Errorf("erro ao buscar controle %s (%s) para mapeamento: %w", md.TargetControlID, md.TargetFramework, err)
+		}
+
+		var count int64
+		db.Model(&models.ControlMapping{}).
+			Where("organization_id IS NULL AND ((source_control_id = ? AND target_control_id = ?) OR (source_control_id = ? AND target_control_id = ?))",
+				source.ID, target.ID, target.ID, source.ID).
+			Count(&count)
+		if count > 0 {
+			continue
+		}
+		mapping := models.ControlMapping{SourceControlID: source.ID, TargetControlID: target.ID, Relationship: md.Relationship}
+		if err := db.Create(&mapping).Error; err != nil {
+			return fmt.Errorf("erro ao semear mapeamento %s ↔ %s: %w", md.SourceControlID, md.TargetControlID, err)
+		}
+		log.Printf("Semeando mapeamento de controles: %s ↔ %s (%s)", md.SourceControlID, md.TargetControlID, md.Relationship)
+	}
+	return nil
+}
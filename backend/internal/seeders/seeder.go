@@ -30,6 +30,93 @@ func RunMigrations(db *gorm.DB) error {
 		&models.C2M2Practice{},
 		&models.SystemSetting{},
 		&models.PasswordResetToken{},
+		&models.AccessReviewCampaign{},
+		&models.AccessReviewItem{},
+		&models.TrustCenterProfile{},
+		&models.EditLock{},
+		&models.AuditAssessmentHistory{},
+		&models.ComplianceScoreSnapshot{},
+		&models.Policy{},
+		&models.PolicyVersion{},
+		&models.PolicyControlMapping{},
+		&models.PolicyAcknowledgment{},
+		&models.ControlTestPlan{},
+		&models.ControlTestCapacity{},
+		&models.PolicyAckCampaign{},
+		&models.PolicyAckCampaignTarget{},
+		&models.OrgSecret{},
+		&models.NotificationRoute{},
+		&models.Vendor{},
+		&models.Questionnaire{},
+		&models.QuestionnaireSection{},
+		&models.QuestionnaireQuestion{},
+		&models.QuestionnaireInvitation{},
+		&models.QuestionnaireAnswer{},
+		&models.Asset{},
+		&models.AssetRisk{},
+		&models.AssetControl{},
+		&models.APIUsageCounter{},
+		&models.RiskNotificationFilter{},
+		&models.VendorRisk{},
+		&models.BusinessProcess{},
+		&models.BusinessProcessAsset{},
+		&models.BusinessProcessVendor{},
+		&models.EvidenceRequest{},
+		&models.EvidenceSubmission{},
+		&models.EvidencePortalLink{},
+		&models.RiskControl{},
+		&models.OrgControlOrder{},
+		&models.ControlMapping{},
+		&models.AssessmentScoreRubric{},
+		&models.ConfigChange{},
+		&models.BulkOperationLog{},
+		&models.FeatureFlag{},
+		&models.FeatureFlagRule{},
+		&models.ComplianceScoreTally{},
+		&models.AuditCampaign{},
+		&models.AuditCampaignScopeFamily{},
+		&models.AuditCampaignScopeAsset{},
+		&models.AuditCampaignParticipant{},
+		&models.OrganizationLoginPage{},
+		&models.AuditTrailEntry{},
+		&models.ControlWaiver{},
+		&models.ControlWaiverCompensatingControl{},
+		&models.RiskScoringMatrix{},
+		&models.AdminActionRequest{},
+		&models.RiskCategoryDefinition{},
+		&models.AssessmentReminderAck{},
+		&models.AuditTrailExport{},
+		&models.Tag{},
+		&models.EntityTag{},
+		&models.OrgSecretPolicy{},
+		&models.SavedView{},
+		&models.OrgSequence{},
+		&models.ReportSchedule{},
+		&models.ExecutiveReportTemplate{},
+		&models.EvidenceAccessPolicy{},
+		&models.BackgroundJob{},
+		&models.FrameworkCoordinator{},
+		&models.AssessmentEvidenceDraft{},
+		&models.AssessmentComment{},
+		&models.StatusDefinition{},
+		&models.StatusTransition{},
+		&models.EmailBranding{},
+		&models.Notification{},
+		&models.AuthSession{},
+		&models.APIKey{},
+		&models.CustomRole{},
+		&models.Team{},
+		&models.TeamMember{},
+		&models.UserInvitation{},
+		&models.PasswordPolicy{},
+		&models.WebAuthnCredential{},
+		&models.WebAuthnChallenge{},
+		&models.MFAPolicy{},
+		&models.SAMLCertificate{},
+		&models.VerifiedDomain{},
+		&models.IdempotencyKey{},
+		&models.DataRetentionRule{},
+		&models.OrganizationExport{},
 	)
 
 	if err != nil {
@@ -52,6 +139,11 @@ func SeedInitialData(db *gorm.DB) error {
 		return err
 	}
 
+	if err := SeedControlMappings(db); err != nil {
+		log.Error("Failed to seed control mappings", zap.Error(err))
+		return err
+	}
+
 	if err := SeedC2M2Data(db); err != nil {
 		log.Error("Failed to seed C2M2 data", zap.Error(err))
 		return err
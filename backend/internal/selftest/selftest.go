@@ -0,0 +1,223 @@
+// Package selftest executa o autoteste de falhas de dependências (chaos): injeta falhas de armazenamento,
+// e-mail (SMTP/SES) e webhook em dublês e verifica que os caminhos reais de degradação tratam o erro sem
+// pânico e que a falha chega às métricas de alerta. As dependências configuradas (provider de arquivos,
+// notifier de e-mail, canais registrados) nunca são substituídas, então o autoteste não afeta o tráfego real.
+package selftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/notifications"
+)
+
+// Cenários disponíveis.
+const (
+	ScenarioStorage = "storage"
+	ScenarioEmail   = "email"
+	ScenarioWebhook = "webhook"
+)
+
+// Scenarios lista os cenários na ordem de execução.
+var Scenarios = []string{ScenarioStorage, ScenarioEmail, ScenarioWebhook}
+
+// scenarioTimeout limita cada cenário.
+const scenarioTimeout = 15 * time.Second
+
+// webhookProbeRetryDelay encurta o intervalo entre as tentativas de webhook durante o autoteste.
+const webhookProbeRetryDelay = 10 * time.Millisecond
+
+// ErrInjected é a falha injetada nos dublês.
+var ErrInjected = errors.New("selftest: injected failure")
+
+// Check é uma verificação de um cenário.
+type Check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Result é o resultado de um cenário.
+type Result struct {
+	Scenario   string  `json:"scenario"`
+	Injected   string  `json:"injected"` // Falha simulada
+	Passed     bool    `json:"passed"`
+	Checks     []Check `json:"checks"`
+	DurationMS int64   `json:"duration_ms"`
+}
+
+// Report é o resultado do autoteste.
+type Report struct {
+	Passed  bool     `json:"passed"`
+	Results []Result `json:"results"`
+}
+
+// Known indica se o cenário existe.
+func Known(scenario string) bool {
+	for _, s := range Scenarios {
+		if s == scenario {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executa os cenários informados (todos, se vazio), em ordem.
+func Run(ctx context.Context, scenarios []string) Report {
+	if len(scenarios) == 0 {
+		scenarios = Scenarios
+	}
+	report := Report{Passed: true, Results: make([]Result, 0, len(scenarios))}
+	for _, scenario := range scenarios {
+		result := runScenario(ctx, scenario)
+		report.Passed = report.Passed && result.Passed
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+func runScenario(ctx context.Context, scenario string) Result {
+	ctx, cancel := context.WithTimeout(ctx, scenarioTimeout)
+	defer cancel()
+	start := time.Now()
+	var result Result
+	switch scenario {
+	case ScenarioStorage:
+		result = storageScenario(ctx)
+	case ScenarioEmail:
+		result = emailScenario(ctx)
+	case ScenarioWebhook:
+		result = webhookScenario(ctx)
+	default:
+		result = Result{Checks: []Check{{Name: "known_scenario", Detail: "unknown scenario"}}}
+	}
+	result.Scenario = scenario
+	result.DurationMS = time.Since(start).Milliseconds()
+	result.Passed = len(result.Checks) > 0
+	for _, check := range result.Checks {
+		result.Passed = result.Passed && check.Passed
+	}
+	return result
+}
+
+// guard executa fn convertendo um pânico em falha: um pânico significa que o caminho de degradação não
+// tratou o erro.
+func guard(fn func() error) (err error, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, panicked = fmt.Errorf("panic: %v", r), true
+		}
+	}()
+	return fn(), false
+}
+
+// expectError verifica que fn retornou erro sem pânico.
+func expectError(name string, fn func() error) Check {
+	err, panicked := guard(fn)
+	switch {
+	case panicked:
+		return Check{Name: name, Detail: err.Error()}
+	case err == nil:
+		return Check{Name: name, Detail: "the failure was swallowed: no error returned"}
+	default:
+		return Check{Name: name, Passed: true, Detail: err.Error()}
+	}
+}
+
+// expectAlerted verifica que a falha foi contada na métrica de falhas de entrega do canal.
+func expectAlerted(channel string, before float64) Check {
+	after := notifications.DeliveryFailureCount(channel)
+	check := Check{Name: "failure_metric_incremented", Detail: fmt.Sprintf("phoenixgrc_notification_delivery_failures_total{channel=%q}: %.0f -> %.0f", channel, before, after)}
+	check.Passed = after > before
+	return check
+}
+
+// failingStorage é um provider de arquivos indisponível.
+type failingStorage struct{}
+
+func (failingStorage) UploadFile(ctx context.Context, organizationID, objectName string, fileContent io.Reader) (string, error) {
+	return "", ErrInjected
+}
+
+func (failingStorage) DeleteFile(ctx context.Context, objectName string) error {
+	return ErrInjected
+}
+
+func (failingStorage) GetSignedURL(ctx context.Context, objectName string, durationMinutes int) (string, error) {
+	return "", ErrInjected
+}
+
+// storageScenario simula o armazenamento indisponível e o não configurado na leitura de objetos (usada,
+// por exemplo, nas miniaturas do dossiê de evidências do controle).
+func storageScenario(ctx context.Context) Result {
+	return Result{
+		Injected: "storage provider returns errors; storage provider not configured",
+		Checks: []Check{
+			expectError("read_from_failing_provider", func() error {
+				_, err := filestorage.ReadObject(ctx, failingStorage{}, "selftest/probe.txt", 1024)
+				return err
+			}),
+			expectError("read_without_provider", func() error {
+				_, err := filestorage.ReadObject(ctx, nil, "selftest/probe.txt", 1024)
+				return err
+			}),
+		},
+	}
+}
+
+// failingNotifier simula o servidor de e-mail (SMTP/SES) rejeitando o envio.
+type failingNotifier struct{}
+
+func (failingNotifier) Send(ctx context.Context, to, subject, body string) error {
+	return fmt.Errorf("%w: 421 service not available", ErrInjected)
+}
+
+// emailScenario entrega uma mensagem pelo canal de e-mail com o servidor recusando o envio.
+func emailScenario(ctx context.Context) Result {
+	before := notifications.DeliveryFailureCount(notifications.ChannelEmail)
+	msg := notifications.Message{EventType: "selftest.email", Subject: "Phoenix GRC self-test", Body: "Injected email failure."}
+	return Result{
+		Injected: "email server rejects the message",
+		Checks: []Check{
+			expectError("delivery_error_returned", func() error {
+				return notifications.DeliverVia(ctx, notifications.NewEmailChannel(failingNotifier{}), "selftest@example.invalid", msg)
+			}),
+			expectAlerted(notifications.ChannelEmail, before),
+		},
+	}
+}
+
+// webhookScenario entrega uma mensagem a um endpoint local que sempre responde 503 e verifica as novas
+// tentativas, o erro e a métrica.
+func webhookScenario(ctx context.Context) Result {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		http.Error(w, "injected failure", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	before := notifications.DeliveryFailureCount(notifications.ChannelWebhook)
+	msg := notifications.Message{EventType: "selftest.webhook", Subject: "Phoenix GRC self-test"}
+	result := Result{
+		Injected: "webhook endpoint responds 503 Service Unavailable",
+		Checks: []Check{
+			expectError("delivery_error_returned", func() error {
+				return notifications.DeliverVia(ctx, notifications.NewWebhookChannel(webhookProbeRetryDelay), server.URL, msg)
+			}),
+		},
+	}
+	got := atomic.LoadInt32(&attempts)
+	result.Checks = append(result.Checks,
+		Check{Name: "retried", Passed: got > 1, Detail: fmt.Sprintf("%d attempts", got)},
+		expectAlerted(notifications.ChannelWebhook, before),
+	)
+	return result
+}
@@ -0,0 +1,32 @@
+package selftest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunAllScenariosPass(t *testing.T) {
+	report := Run(context.Background(), nil)
+	assert.True(t, report.Passed, "%+v", report)
+	if assert.Len(t, report.Results, len(Scenarios)) {
+		for i, result := range report.Results {
+			assert.Equal(t, Scenarios[i], result.Scenario)
+			assert.NotEmpty(t, result.Checks)
+		}
+	}
+}
+
+func TestUnknownScenarioFails(t *testing.T) {
+	report := Run(context.Background(), []string{"dns"})
+	assert.False(t, report.Passed)
+	assert.False(t, Known("dns"))
+	assert.True(t, Known(ScenarioWebhook))
+}
+
+func TestExpectErrorDetectsSwallowedFailuresAndPanics(t *testing.T) {
+	assert.False(t, expectError("swallowed", func() error { return nil }).Passed)
+	assert.False(t, expectError("panic", func() error { panic("boom") }).Passed)
+	assert.True(t, expectError("returned", func() error { return ErrInjected }).Passed)
+}
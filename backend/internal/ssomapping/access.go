@@ -0,0 +1,91 @@
+package ssomapping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"phoenixgrc/backend/internal/auth"
+	"phoenixgrc/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrAccessDenied indica que o usuário não tem nenhum dos grupos permitidos (allowed_groups) do IdP.
+	ErrAccessDenied = errors.New("identity provider does not assert an allowed group for this user")
+	// ErrGroupsUnavailable indica que os grupos do usuário não puderam ser obtidos no IdP para conferir o acesso.
+	ErrGroupsUnavailable = errors.New("could not verify the user's groups with the identity provider")
+)
+
+// Authorize confere, antes de provisionar ou atualizar o usuário no login, se os grupos recebidos dão acesso
+// pelo IdP (allowed_groups). user é o usuário já provisionado, ou nil no primeiro login.
+//
+// Quem não tem um grupo permitido recebe ErrAccessDenied e, com jit_deprovisioning, é desativado e tem todas as
+// sessões revogadas, para que quem saiu da empresa (ou do grupo) não continue usando a conta provisionada.
+// groups nil (grupos indisponíveis) nega o login com ErrGroupsUnavailable sem desativar ninguém, para que uma
+// falha do provedor não tire o acesso de toda a organização. system_admin é barrado, mas nunca desativado pelo IdP.
+func Authorize(db *gorm.DB, idp *models.IdentityProvider, user *models.User, groups []string) error {
+	m, err := Parse(idp.AttributeMappingJSON)
+	if err != nil {
+		return err
+	}
+	if !m.Restricted() {
+		return nil
+	}
+	if groups == nil {
+		return ErrGroupsUnavailable
+	}
+	if m.Allows(groups) {
+		return nil
+	}
+	if m.JITDeprovisioning && user != nil && user.Role != models.RoleSystemAdmin {
+		if err := deprovision(db, idp, user, groups); err != nil {
+			return err
+		}
+	}
+	return ErrAccessDenied
+}
+
+// deprovision desativa o usuário e revoga as suas sessões, registrando na trilha de auditoria da organização
+// (user.sso_deprovisioned) quando algo mudou.
+func deprovision(db *gorm.DB, idp *models.IdentityProvider, user *models.User, groups []string) error {
+	wasActive := user.IsActive
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if wasActive {
+			if err := tx.Model(&models.User{}).Where("id = ?", user.ID).Update("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+		revoked, err := auth.RevokeUserSessions(tx, user.ID, models.SessionRevokedDeprovisioned, nil)
+		if err != nil {
+			return err
+		}
+		if (!wasActive && revoked == 0) || !user.OrganizationID.Valid {
+			return nil
+		}
+		details, err := json.Marshal(map[string]interface{}{
+			"identity_provider_id": idp.ID,
+			"identity_provider":    idp.Name,
+			"groups":               groups,
+			"deactivated":          wasActive,
+			"sessions_revoked":     revoked,
+		})
+		if err != nil {
+			return err
+		}
+		detailsJSON := string(details)
+		return tx.Create(&models.AuditTrailEntry{
+			OrganizationID: user.OrganizationID.UUID,
+			Action:         models.AuditTrailSSODeprovisioned,
+			EntityType:     "user",
+			EntityID:       &user.ID,
+			Summary:        fmt.Sprintf("%s deprovisioned: identity provider %s no longer asserts an allowed group", user.Email, idp.Name),
+			Details:        &detailsJSON,
+		}).Error
+	})
+	if err == nil {
+		user.IsActive = false
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package ssomapping
+
+import (
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeWithoutDeprovisioning(t *testing.T) {
+	open := &models.IdentityProvider{AttributeMappingJSON: `{"groups": "memberOf"}`}
+	restricted := &models.IdentityProvider{AttributeMappingJSON: `{"groups": "memberOf", "allowed_groups": ["GRC-Users"]}`}
+	user := &models.User{Role: models.RoleUser, IsActive: true}
+
+	// Sem jit_deprovisioning nada é gravado, então o banco não é usado
+	assert.NoError(t, Authorize(nil, open, user, nil))
+	assert.NoError(t, Authorize(nil, restricted, user, []string{"grc-users"}))
+	assert.ErrorIs(t, Authorize(nil, restricted, user, []string{"outro"}), ErrAccessDenied)
+	assert.ErrorIs(t, Authorize(nil, restricted, nil, []string{}), ErrAccessDenied)
+	assert.ErrorIs(t, Authorize(nil, restricted, user, nil), ErrGroupsUnavailable)
+	assert.True(t, user.IsActive)
+
+	// system_admin é barrado, mas nunca desativado pelo IdP
+	deprovisioning := &models.IdentityProvider{AttributeMappingJSON: `{"allowed_groups": ["GRC-Users"], "jit_deprovisioning": true}`}
+	admin := &models.User{Role: models.RoleSystemAdmin, IsActive: true}
+	assert.ErrorIs(t, Authorize(nil, deprovisioning, admin, []string{"outro"}), ErrAccessDenied)
+	assert.True(t, admin.IsActive)
+}
@@ -0,0 +1,117 @@
+package ssomapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"phoenixgrc/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Result descreve o que o mapeamento alterou no usuário.
+type Result struct {
+	PreviousRole models.UserRole `json:"previous_role"`
+	Role         models.UserRole `json:"role"`
+	TeamsAdded   []string        `json:"teams_added,omitempty"`
+	TeamsRemoved []string        `json:"teams_removed,omitempty"`
+}
+
+// Changed indica se o papel ou as equipes do usuário mudaram.
+func (r Result) Changed() bool {
+	return r.PreviousRole != r.Role || len(r.TeamsAdded) > 0 || len(r.TeamsRemoved) > 0
+}
+
+// Apply aplica o mapeamento do IdP ao usuário (já gravado) com os grupos recebidos no login: atualiza o papel e
+// a participação nas equipes mapeadas, sem mexer nas demais equipes. groups nil significa que os grupos não
+// puderam ser obtidos; nesse caso nada é alterado, para que uma falha do provedor não rebaixe ninguém.
+// Alterações entram na trilha de auditoria da organização (user.sso_mapping_applied).
+func Apply(db *gorm.DB, idp *models.IdentityProvider, user *models.User, groups []string) (Result, error) {
+	result := Result{PreviousRole: user.Role, Role: user.Role}
+	m, err := Parse(idp.AttributeMappingJSON)
+	if err != nil {
+		return result, err
+	}
+	if !m.Enabled() || groups == nil || !user.OrganizationID.Valid {
+		return result, nil
+	}
+	organizationID := user.OrganizationID.UUID
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		result.Role = m.ResolveRole(user.Role, groups)
+		if result.Role != result.PreviousRole {
+			if err := tx.Model(&models.User{}).Where("id = ?", user.ID).Update("role", result.Role).Error; err != nil {
+				return err
+			}
+		}
+
+		if wanted := m.ResolveTeams(groups); len(wanted) > 0 {
+			names := make([]string, 0, len(wanted))
+			for name := range wanted {
+				names = append(names, name)
+			}
+			var teams []models.Team
+			if err := tx.Select("id, name").Where("organization_id = ? AND LOWER(name) IN ?", organizationID, names).
+				Find(&teams).Error; err != nil {
+				return err
+			}
+			var memberOf []models.TeamMember
+			if err := tx.Where("user_id = ? AND team_id IN (?)", user.ID,
+				tx.Model(&models.Team{}).Select("id").Where("organization_id = ?", organizationID)).
+				Find(&memberOf).Error; err != nil {
+				return err
+			}
+			isMember := make(map[string]bool, len(memberOf))
+			for _, membership := range memberOf {
+				isMember[membership.TeamID.String()] = true
+			}
+			for _, team := range teams {
+				switch want, has := wanted[strings.ToLower(team.Name)], isMember[team.ID.String()]; {
+				case want && !has:
+					if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+						Create(&models.TeamMember{TeamID: team.ID, UserID: user.ID}).Error; err != nil {
+						return err
+					}
+					result.TeamsAdded = append(result.TeamsAdded, team.Name)
+				case !want && has:
+					if err := tx.Where("team_id = ? AND user_id = ?", team.ID, user.ID).Delete(&models.TeamMember{}).Error; err != nil {
+						return err
+					}
+					result.TeamsRemoved = append(result.TeamsRemoved, team.Name)
+				}
+			}
+			sort.Strings(result.TeamsAdded)
+			sort.Strings(result.TeamsRemoved)
+		}
+
+		if !result.Changed() {
+			return nil
+		}
+		details, err := json.Marshal(map[string]interface{}{
+			"identity_provider_id": idp.ID,
+			"identity_provider":    idp.Name,
+			"groups":               groups,
+			"result":               result,
+		})
+		if err != nil {
+			return err
+		}
+		detailsJSON := string(details)
+		return tx.Create(&models.AuditTrailEntry{
+			OrganizationID: organizationID,
+			Action:         models.AuditTrailSSOMappingApplied,
+			EntityType:     "user",
+			EntityID:       &user.ID,
+			Summary:        fmt.Sprintf("Role and teams of %s updated from identity provider %s", user.Email, idp.Name),
+			Details:        &detailsJSON,
+		}).Error
+	})
+	if err != nil {
+		return Result{PreviousRole: result.PreviousRole, Role: result.PreviousRole}, err
+	}
+	user.Role = result.Role
+	return result, nil
+}
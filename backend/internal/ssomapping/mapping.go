@@ -0,0 +1,198 @@
+// Package ssomapping aplica o mapeamento de grupos do provedor de identidade (atributos SAML, organizações e
+// equipes do GitHub, domínio do Google Workspace) para papéis e equipes do Phoenix, a cada login por SSO.
+package ssomapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoleMapping concede o papel a quem pertence ao grupo.
+type RoleMapping struct {
+	Group string          `json:"group"`
+	Role  models.UserRole `json:"role"`
+}
+
+// TeamMapping mantém na equipe (pelo nome) quem pertence ao grupo.
+type TeamMapping struct {
+	Group string `json:"group"`
+	Team  string `json:"team"`
+}
+
+// Mapping é a parte do attribute_mapping_json do IdP que define papéis, equipes e quem tem acesso. Os demais
+// campos (email, firstName, lastName) são lidos por cada fluxo de login.
+type Mapping struct {
+	// Atributo SAML com os grupos do usuário (ex: "memberOf"). Nos provedores OAuth2 os grupos são fixos
+	// por provedor e este campo é ignorado.
+	GroupsAttribute string          `json:"groups"`
+	RoleMappings    []RoleMapping   `json:"role_mappings"`
+	DefaultRole     models.UserRole `json:"default_role"`
+	TeamMappings    []TeamMapping   `json:"team_mappings"`
+	// Grupos que dão acesso à organização por este IdP; vazio libera qualquer usuário autenticado pelo IdP.
+	AllowedGroups []string `json:"allowed_groups"`
+	// Desativa no login (e encerra as sessões de) quem já foi provisionado e não tem mais um grupo permitido.
+	JITDeprovisioning bool `json:"jit_deprovisioning"`
+}
+
+// roleRank ordena os papéis que o mapeamento pode conceder, do menos ao mais privilegiado. system_admin não
+// entra: ele nunca é concedido nem retirado por um IdP.
+var roleRank = map[models.UserRole]int{
+	models.RoleUser:    1,
+	models.RoleManager: 2,
+	models.RoleAdmin:   3,
+}
+
+// Parse lê o mapeamento do attribute_mapping_json do IdP (vazio resulta em mapeamento nenhum) e o valida.
+func Parse(attributeMappingJSON string) (Mapping, error) {
+	var m Mapping
+	if strings.TrimSpace(attributeMappingJSON) == "" {
+		return m, nil
+	}
+	if err := json.Unmarshal([]byte(attributeMappingJSON), &m); err != nil {
+		return m, err
+	}
+	return m, m.Validate()
+}
+
+// Validate confere os papéis e as regras do mapeamento.
+func (m Mapping) Validate() error {
+	if m.DefaultRole != "" {
+		if _, ok := roleRank[m.DefaultRole]; !ok {
+			return fmt.Errorf("default_role must be one of admin, manager, user")
+		}
+	}
+	for i, rule := range m.RoleMappings {
+		if strings.TrimSpace(rule.Group) == "" {
+			return fmt.Errorf("role_mappings[%d]: group is required", i)
+		}
+		if _, ok := roleRank[rule.Role]; !ok {
+			return fmt.Errorf("role_mappings[%d]: role must be one of admin, manager, user", i)
+		}
+	}
+	for i, rule := range m.TeamMappings {
+		if strings.TrimSpace(rule.Group) == "" || strings.TrimSpace(rule.Team) == "" {
+			return fmt.Errorf("team_mappings[%d]: group and team are required", i)
+		}
+	}
+	for i, group := range m.AllowedGroups {
+		if strings.TrimSpace(group) == "" {
+			return fmt.Errorf("allowed_groups[%d]: group must not be empty", i)
+		}
+	}
+	if m.JITDeprovisioning && len(m.AllowedGroups) == 0 {
+		return fmt.Errorf("jit_deprovisioning requires allowed_groups")
+	}
+	return nil
+}
+
+// Enabled indica se o mapeamento altera papéis ou equipes.
+func (m Mapping) Enabled() bool {
+	return m.DefaultRole != "" || len(m.RoleMappings) > 0 || len(m.TeamMappings) > 0
+}
+
+// Restricted indica se o acesso pelo IdP depende dos grupos do usuário (allowed_groups).
+func (m Mapping) Restricted() bool {
+	return len(m.AllowedGroups) > 0
+}
+
+// NeedsGroups indica se o login precisa obter os grupos do usuário no IdP.
+func (m Mapping) NeedsGroups() bool {
+	return m.Enabled() || m.Restricted()
+}
+
+// Allows indica se os grupos dão acesso pelo IdP: sem allowed_groups qualquer usuário passa; com eles, basta
+// pertencer a um dos grupos.
+func (m Mapping) Allows(groups []string) bool {
+	if !m.Restricted() {
+		return true
+	}
+	set := groupSet(groups)
+	for _, group := range m.AllowedGroups {
+		if hasGroup(set, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func groupSet(groups []string) map[string]bool {
+	set := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		if group = strings.ToLower(strings.TrimSpace(group)); group != "" {
+			set[group] = true
+		}
+	}
+	return set
+}
+
+func hasGroup(set map[string]bool, group string) bool {
+	return set[strings.ToLower(strings.TrimSpace(group))]
+}
+
+// ResolveRole define o papel do usuário a partir dos grupos, nesta ordem de precedência:
+//  1. system_admin nunca é alterado;
+//  2. entre as regras de role_mappings cujos grupos o usuário tem, vale o papel mais privilegiado, qualquer
+//     que seja a ordem das regras;
+//  3. sem regra correspondente, vale default_role;
+//  4. sem default_role, o papel atual é mantido (o definido por convite ou por um administrador).
+func (m Mapping) ResolveRole(current models.UserRole, groups []string) models.UserRole {
+	if current == models.RoleSystemAdmin {
+		return current
+	}
+	set := groupSet(groups)
+	resolved := models.UserRole("")
+	for _, rule := range m.RoleMappings {
+		if hasGroup(set, rule.Group) && roleRank[rule.Role] > roleRank[resolved] {
+			resolved = rule.Role
+		}
+	}
+	if resolved != "" {
+		return resolved
+	}
+	if m.DefaultRole != "" {
+		return m.DefaultRole
+	}
+	return current
+}
+
+// ResolveTeams retorna as equipes mapeadas (nomes em minúsculas) e, para cada uma, se o usuário deve ser membro.
+// Uma equipe em várias regras fica com o usuário se qualquer um dos grupos corresponder.
+func (m Mapping) ResolveTeams(groups []string) map[string]bool {
+	set := groupSet(groups)
+	teams := make(map[string]bool, len(m.TeamMappings))
+	for _, rule := range m.TeamMappings {
+		team := strings.ToLower(strings.TrimSpace(rule.Team))
+		teams[team] = teams[team] || hasGroup(set, rule.Group)
+	}
+	return teams
+}
+
+// MissingTeams lista as equipes do mapeamento que não existem na organização.
+func (m Mapping) MissingTeams(db *gorm.DB, organizationID uuid.UUID) ([]string, error) {
+	if len(m.TeamMappings) == 0 {
+		return nil, nil
+	}
+	var existing []string
+	if err := db.Model(&models.Team{}).Where("organization_id = ?", organizationID).Pluck("LOWER(name)", &existing).Error; err != nil {
+		return nil, err
+	}
+	known := groupSet(existing)
+	var missing []string
+	seen := map[string]bool{}
+	for _, rule := range m.TeamMappings {
+		team := strings.TrimSpace(rule.Team)
+		if !hasGroup(known, team) && !seen[strings.ToLower(team)] {
+			seen[strings.ToLower(team)] = true
+			missing = append(missing, team)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
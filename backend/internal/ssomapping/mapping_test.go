@@ -0,0 +1,94 @@
+package ssomapping
+
+import (
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidatesMapping(t *testing.T) {
+	m, err := Parse("")
+	require.NoError(t, err)
+	assert.False(t, m.Enabled())
+
+	m, err = Parse(`{"email": "mail", "groups": "memberOf", "role_mappings": [{"group": "GRC-Admins", "role": "admin"}], "team_mappings": [{"group": "Sec", "team": "Segurança"}]}`)
+	require.NoError(t, err)
+	assert.True(t, m.Enabled())
+	assert.Equal(t, "memberOf", m.GroupsAttribute)
+
+	for name, raw := range map[string]string{
+		"system_admin não é mapeável": `{"role_mappings": [{"group": "root", "role": "system_admin"}]}`,
+		"papel desconhecido":          `{"role_mappings": [{"group": "x", "role": "auditor"}]}`,
+		"grupo vazio":                 `{"role_mappings": [{"group": " ", "role": "user"}]}`,
+		"default_role inválido":       `{"default_role": "owner"}`,
+		"equipe vazia":                `{"team_mappings": [{"group": "x", "team": ""}]}`,
+		"JSON inválido":               `{"role_mappings": "admin"}`,
+		"grupo permitido vazio":       `{"allowed_groups": [""]}`,
+		"desprovisionar sem grupos":   `{"jit_deprovisioning": true}`,
+	} {
+		_, err := Parse(raw)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestResolveRolePrecedence(t *testing.T) {
+	m := Mapping{
+		RoleMappings: []RoleMapping{
+			{Group: "grc-users", Role: models.RoleUser},
+			{Group: "GRC-Admins", Role: models.RoleAdmin},
+			{Group: "grc-managers", Role: models.RoleManager},
+		},
+	}
+	withDefault := m
+	withDefault.DefaultRole = models.RoleUser
+
+	cases := []struct {
+		name    string
+		mapping Mapping
+		current models.UserRole
+		groups  []string
+		want    models.UserRole
+	}{
+		{"o papel mais privilegiado vence, qualquer que seja a ordem das regras", m, models.RoleUser, []string{"grc-managers", "grc-admins", "grc-users"}, models.RoleAdmin},
+		{"a comparação de grupos ignora maiúsculas e espaços", m, models.RoleUser, []string{" GRC-MANAGERS "}, models.RoleManager},
+		{"o mapeamento rebaixa quem perdeu o grupo", m, models.RoleAdmin, []string{"grc-users"}, models.RoleUser},
+		{"sem regra correspondente vale default_role", withDefault, models.RoleManager, []string{"outro"}, models.RoleUser},
+		{"sem regra nem default_role o papel atual é mantido", m, models.RoleManager, []string{"outro"}, models.RoleManager},
+		{"sem grupos nem default_role o papel atual é mantido", m, models.RoleManager, nil, models.RoleManager},
+		{"system_admin nunca é alterado", withDefault, models.RoleSystemAdmin, []string{"grc-users"}, models.RoleSystemAdmin},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, tc.mapping.ResolveRole(tc.current, tc.groups), tc.name)
+	}
+}
+
+func TestResolveTeams(t *testing.T) {
+	m := Mapping{TeamMappings: []TeamMapping{
+		{Group: "sec-analysts", Team: "Segurança"},
+		{Group: "sec-leads", Team: "segurança"},
+		{Group: "finance", Team: "Financeiro"},
+	}}
+
+	assert.Equal(t, map[string]bool{"segurança": true, "financeiro": false}, m.ResolveTeams([]string{"SEC-LEADS"}))
+	assert.Equal(t, map[string]bool{"segurança": false, "financeiro": false}, m.ResolveTeams(nil))
+}
+
+func TestResultChanged(t *testing.T) {
+	assert.False(t, Result{PreviousRole: models.RoleUser, Role: models.RoleUser}.Changed())
+	assert.True(t, Result{PreviousRole: models.RoleUser, Role: models.RoleAdmin}.Changed())
+	assert.True(t, Result{PreviousRole: models.RoleUser, Role: models.RoleUser, TeamsRemoved: []string{"Financeiro"}}.Changed())
+}
+
+func TestAllows(t *testing.T) {
+	assert.True(t, Mapping{}.Allows(nil), "sem allowed_groups qualquer usuário passa")
+
+	m := Mapping{AllowedGroups: []string{"GRC-Users", "grc-admins"}}
+	assert.True(t, m.Restricted())
+	assert.True(t, m.NeedsGroups())
+	assert.True(t, m.Allows([]string{"outro", " grc-users "}))
+	assert.False(t, m.Allows([]string{"outro"}))
+	assert.False(t, m.Allows([]string{}))
+}
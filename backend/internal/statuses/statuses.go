@@ -0,0 +1,230 @@
+// Package statuses resolve os status de riscos e avaliações: os nativos (aberto, conforme...) e os
+// cadastrados em StatusDefinition, com as regras de transição de StatusTransition. As definições ficam em
+// cache por CacheTTL; a alteração feita na própria instância invalida o cache imediatamente.
+package statuses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// CacheTTL é o tempo de cache das definições; alterações feitas em outra instância valem após esse tempo.
+const CacheTTL = 30 * time.Second
+
+var (
+	// ErrUnknownStatus indica um código de status não definido para a entidade.
+	ErrUnknownStatus = errors.New("unknown status")
+	// ErrDeprecatedStatus indica um status descontinuado, que não pode ser atribuído.
+	ErrDeprecatedStatus = errors.New("status is deprecated")
+	// ErrTransitionNotAllowed indica uma mudança de status não permitida pelas transições cadastradas.
+	ErrTransitionNotAllowed = errors.New("status transition not allowed")
+)
+
+// CodePattern é o formato dos códigos de status cadastrados.
+var CodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,29}$`)
+
+// builtins são os status nativos, na ordem de exibição. dispensado (exceção de controle) não é atribuível
+// e fica fora da lista.
+var builtins = map[models.StatusEntity][]models.StatusDefinition{
+	models.StatusEntityRisk: {
+		{Code: string(models.StatusOpen), Label: "Aberto"},
+		{Code: string(models.StatusInProgress), Label: "Em andamento"},
+		{Code: string(models.StatusMitigated), Label: "Mitigado"},
+		{Code: string(models.StatusAccepted), Label: "Aceito"},
+	},
+	models.StatusEntityAssessment: {
+		{Code: string(models.ControlStatusConformant), Label: "Conforme"},
+		{Code: string(models.ControlStatusNonConformant), Label: "Não conforme"},
+		{Code: string(models.ControlStatusPartiallyConformant), Label: "Parcialmente conforme"},
+		{Code: string(models.ControlStatusNotApplicable), Label: "Não aplicável"},
+	},
+}
+
+// ValidEntity indica se a entidade tem status definíveis.
+func ValidEntity(entity models.StatusEntity) bool {
+	_, ok := builtins[entity]
+	return ok
+}
+
+// IsBuiltin indica se code é um status nativo da entidade.
+func IsBuiltin(entity models.StatusEntity, code string) bool {
+	for _, def := range builtins[entity] {
+		if def.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Set é um conjunto imutável de definições e transições carregado do banco.
+type Set struct {
+	definitions map[models.StatusEntity]map[string]models.StatusDefinition
+	transitions map[models.StatusEntity]map[string]map[string]bool
+}
+
+// NewSet combina os status nativos com as definições cadastradas (que podem ajustar um nativo) e as transições.
+func NewSet(definitions []models.StatusDefinition, transitions []models.StatusTransition) *Set {
+	set := &Set{
+		definitions: map[models.StatusEntity]map[string]models.StatusDefinition{},
+		transitions: map[models.StatusEntity]map[string]map[string]bool{},
+	}
+	for entity, defs := range builtins {
+		set.definitions[entity] = map[string]models.StatusDefinition{}
+		for i, def := range defs {
+			def.Entity, def.BaseStatus, def.SortOrder, def.Builtin = entity, def.Code, (i+1)*10, true
+			set.definitions[entity][def.Code] = def
+		}
+	}
+	for _, def := range definitions {
+		byCode, ok := set.definitions[def.Entity]
+		if !ok {
+			continue
+		}
+		if builtin, ok := byCode[def.Code]; ok {
+			// Um nativo continua sendo a própria base
+			def.BaseStatus, def.Builtin = builtin.BaseStatus, true
+		}
+		byCode[def.Code] = def
+	}
+	for _, t := range transitions {
+		if set.transitions[t.Entity] == nil {
+			set.transitions[t.Entity] = map[string]map[string]bool{}
+		}
+		if set.transitions[t.Entity][t.FromCode] == nil {
+			set.transitions[t.Entity][t.FromCode] = map[string]bool{}
+		}
+		set.transitions[t.Entity][t.FromCode][t.ToCode] = true
+	}
+	return set
+}
+
+// Definitions retorna os status da entidade por SortOrder e código.
+func (s *Set) Definitions(entity models.StatusEntity) []models.StatusDefinition {
+	defs := make([]models.StatusDefinition, 0, len(s.definitions[entity]))
+	for _, def := range s.definitions[entity] {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].SortOrder != defs[j].SortOrder {
+			return defs[i].SortOrder < defs[j].SortOrder
+		}
+		return defs[i].Code < defs[j].Code
+	})
+	return defs
+}
+
+// Transitions retorna os destinos permitidos por status de origem; origens ausentes não têm restrição.
+func (s *Set) Transitions(entity models.StatusEntity) map[string][]string {
+	result := map[string][]string{}
+	for from, targets := range s.transitions[entity] {
+		for to := range targets {
+			result[from] = append(result[from], to)
+		}
+		sort.Strings(result[from])
+	}
+	return result
+}
+
+// Lookup retorna a definição do status, inclusive descontinuado.
+func (s *Set) Lookup(entity models.StatusEntity, code string) (models.StatusDefinition, bool) {
+	def, ok := s.definitions[entity][code]
+	return def, ok
+}
+
+// Resolve valida code para ser atribuído: o status deve existir e não estar descontinuado.
+func (s *Set) Resolve(entity models.StatusEntity, code string) (models.StatusDefinition, error) {
+	def, ok := s.Lookup(entity, code)
+	if !ok {
+		return def, fmt.Errorf("%w: %q", ErrUnknownStatus, code)
+	}
+	if def.Deprecated {
+		if def.ReplacedBy != "" {
+			return def, fmt.Errorf("%w: %q (use %q)", ErrDeprecatedStatus, code, def.ReplacedBy)
+		}
+		return def, fmt.Errorf("%w: %q", ErrDeprecatedStatus, code)
+	}
+	return def, nil
+}
+
+// CheckTransition valida a mudança from -> to. Manter o status é sempre permitido.
+func (s *Set) CheckTransition(entity models.StatusEntity, from, to string) error {
+	if from == "" || from == to {
+		return nil
+	}
+	targets, restricted := s.transitions[entity][from]
+	if restricted && !targets[to] {
+		return fmt.Errorf("%w: %q -> %q", ErrTransitionNotAllowed, from, to)
+	}
+	return nil
+}
+
+// Codes retorna os códigos (nativo e refinamentos) cuja base é base, para filtros por status nativo.
+func (s *Set) Codes(entity models.StatusEntity, base string) []string {
+	var codes []string
+	for _, def := range s.Definitions(entity) {
+		if def.BaseStatus == base {
+			codes = append(codes, def.Code)
+		}
+	}
+	return codes
+}
+
+// Load carrega as definições e transições cadastradas.
+func Load(ctx context.Context, db *gorm.DB) (*Set, error) {
+	var definitions []models.StatusDefinition
+	if err := db.WithContext(ctx).Find(&definitions).Error; err != nil {
+		return nil, err
+	}
+	var transitions []models.StatusTransition
+	if err := db.WithContext(ctx).Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return NewSet(definitions, transitions), nil
+}
+
+var (
+	cacheMu  sync.Mutex
+	cached   *Set
+	loadedAt time.Time
+)
+
+// Current retorna as definições em cache, recarregando-as quando expiradas. Se a recarga falhar, usa o
+// cache expirado ou, sem cache, apenas os status nativos.
+func Current(ctx context.Context, db *gorm.DB) *Set {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if cached != nil && time.Since(loadedAt) < CacheTTL {
+		return cached
+	}
+	if db == nil {
+		return NewSet(nil, nil)
+	}
+	set, err := Load(ctx, db)
+	if err != nil {
+		phxlog.L.Warn("Failed to load status definitions; using cached or built-in statuses", zap.Error(err))
+		if cached != nil {
+			return cached
+		}
+		return NewSet(nil, nil)
+	}
+	cached, loadedAt = set, time.Now()
+	return cached
+}
+
+// Invalidate descarta o cache; a próxima consulta recarrega as definições.
+func Invalidate() {
+	cacheMu.Lock()
+	cached = nil
+	cacheMu.Unlock()
+}
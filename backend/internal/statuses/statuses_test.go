@@ -0,0 +1,96 @@
+package statuses
+
+import (
+	"errors"
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSet() *Set {
+	return NewSet([]models.StatusDefinition{
+		{Entity: models.StatusEntityRisk, Code: "em_revisao", Label: "Em revisão", BaseStatus: "em_andamento", SortOrder: 15},
+		{Entity: models.StatusEntityRisk, Code: "transferido", Label: "Transferido", BaseStatus: "aceito", Deprecated: true, ReplacedBy: "aceito"},
+		// Ajuste de um nativo: a base não pode ser trocada
+		{Entity: models.StatusEntityRisk, Code: "mitigado", Label: "Tratado", BaseStatus: "aberto", SortOrder: 30},
+	}, []models.StatusTransition{
+		{Entity: models.StatusEntityRisk, FromCode: "em_revisao", ToCode: "mitigado"},
+		{Entity: models.StatusEntityRisk, FromCode: "em_revisao", ToCode: "em_andamento"},
+	})
+}
+
+func TestBuiltinsWithoutDefinitions(t *testing.T) {
+	set := NewSet(nil, nil)
+	def, err := set.Resolve(models.StatusEntityAssessment, "parcialmente_conforme")
+	require.NoError(t, err)
+	assert.Equal(t, "parcialmente_conforme", def.BaseStatus)
+	assert.True(t, def.Builtin)
+
+	_, err = set.Resolve(models.StatusEntityAssessment, "dispensado")
+	assert.True(t, errors.Is(err, ErrUnknownStatus), "dispensado is set only by control waivers")
+	assert.Len(t, set.Definitions(models.StatusEntityRisk), 4)
+}
+
+func TestResolveCustomAndDeprecated(t *testing.T) {
+	set := testSet()
+	def, err := set.Resolve(models.StatusEntityRisk, "em_revisao")
+	require.NoError(t, err)
+	assert.Equal(t, "em_andamento", def.BaseStatus)
+	assert.False(t, def.Builtin)
+
+	_, err = set.Resolve(models.StatusEntityRisk, "transferido")
+	assert.True(t, errors.Is(err, ErrDeprecatedStatus))
+	assert.Contains(t, err.Error(), `use "aceito"`)
+	_, ok := set.Lookup(models.StatusEntityRisk, "transferido")
+	assert.True(t, ok, "deprecated statuses stay readable")
+
+	_, err = set.Resolve(models.StatusEntityAssessment, "em_revisao")
+	assert.True(t, errors.Is(err, ErrUnknownStatus), "definitions are per entity")
+}
+
+func TestBuiltinOverrideKeepsBase(t *testing.T) {
+	def, ok := testSet().Lookup(models.StatusEntityRisk, "mitigado")
+	require.True(t, ok)
+	assert.Equal(t, "Tratado", def.Label)
+	assert.Equal(t, "mitigado", def.BaseStatus)
+	assert.True(t, def.Builtin)
+}
+
+func TestDefinitionsOrder(t *testing.T) {
+	var codes []string
+	for _, def := range testSet().Definitions(models.StatusEntityRisk) {
+		codes = append(codes, def.Code)
+	}
+	assert.Equal(t, []string{"transferido", "aberto", "em_revisao", "em_andamento", "mitigado", "aceito"}, codes)
+}
+
+func TestCheckTransition(t *testing.T) {
+	set := testSet()
+	assert.NoError(t, set.CheckTransition(models.StatusEntityRisk, "em_revisao", "mitigado"))
+	assert.NoError(t, set.CheckTransition(models.StatusEntityRisk, "em_revisao", "em_revisao"))
+	assert.True(t, errors.Is(set.CheckTransition(models.StatusEntityRisk, "em_revisao", "aceito"), ErrTransitionNotAllowed))
+	// Origens sem transições cadastradas não têm restrição
+	assert.NoError(t, set.CheckTransition(models.StatusEntityRisk, "aberto", "aceito"))
+	assert.NoError(t, set.CheckTransition(models.StatusEntityRisk, "", "aceito"))
+	assert.Equal(t, map[string][]string{"em_revisao": {"em_andamento", "mitigado"}}, set.Transitions(models.StatusEntityRisk))
+}
+
+func TestCodes(t *testing.T) {
+	set := testSet()
+	assert.Equal(t, []string{"em_revisao", "em_andamento"}, set.Codes(models.StatusEntityRisk, "em_andamento"))
+	assert.Equal(t, []string{"transferido", "aceito"}, set.Codes(models.StatusEntityRisk, "aceito"))
+}
+
+func TestStoredStatusCode(t *testing.T) {
+	var risk models.Risk
+	risk.SetStatus(models.StatusInProgress, "em_revisao")
+	assert.Equal(t, models.StatusInProgress, risk.Status)
+	assert.Equal(t, "em_revisao", risk.EffectiveStatus())
+
+	risk.SetStatus(models.StatusMitigated, "mitigado")
+	assert.Nil(t, risk.StatusCode)
+	assert.Equal(t, "mitigado", risk.EffectiveStatus())
+}
@@ -0,0 +1,56 @@
+package testplanner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ICSEvent é um evento de dia inteiro exportado para calendário (RFC 5545).
+type ICSEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time // Data inicial (inclusiva)
+	End         time.Time // Data final (exclusiva)
+}
+
+// icsEscape escapa texto conforme a RFC 5545 (seção 3.3.11).
+func icsEscape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// WriteICS escreve um VCALENDAR com os eventos informados.
+func WriteICS(w io.Writer, calendarName string, events []ICSEvent) error {
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Phoenix GRC//Control Testing Planner//PT",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:" + icsEscape(calendarName),
+	}
+	for _, ev := range events {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+ev.UID,
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+ev.Start.Format("20060102"),
+			"DTEND;VALUE=DATE:"+ev.End.Format("20060102"),
+			"SUMMARY:"+icsEscape(ev.Summary),
+			"DESCRIPTION:"+icsEscape(ev.Description),
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := fmt.Fprint(w, line+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Package testplanner distribui testes recorrentes de controle ao longo do ano,
+// respeitando a frequência de cada teste e a capacidade mensal de cada responsável.
+package testplanner
+
+import (
+	"sort"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMonthlyCapacityHours é a capacidade usada para responsáveis sem capacidade configurada.
+const DefaultMonthlyCapacityHours = 40.0
+
+// PlanInput é um teste recorrente a ser agendado.
+type PlanInput struct {
+	PlanID         uuid.UUID
+	OwnerID        uuid.UUID
+	Frequency      models.ControlTestFrequency
+	EstimatedHours float64
+}
+
+// ScheduledTest é uma execução de um teste em um mês.
+type ScheduledTest struct {
+	PlanID         uuid.UUID `json:"plan_id"`
+	OwnerID        uuid.UUID `json:"owner_id"`
+	Month          int       `json:"month"`      // 1-12
+	Occurrence     int       `json:"occurrence"` // 1..N dentro do ano
+	EstimatedHours float64   `json:"estimated_hours"`
+}
+
+// Overbooking indica um mês em que a carga de um responsável excede sua capacidade.
+type Overbooking struct {
+	OwnerID        uuid.UUID `json:"owner_id"`
+	Month          int       `json:"month"`
+	ScheduledHours float64   `json:"scheduled_hours"`
+	CapacityHours  float64   `json:"capacity_hours"`
+}
+
+// Schedule é o resultado do planejamento anual.
+type Schedule struct {
+	Tests      []ScheduledTest           `json:"tests"`
+	OwnerLoad  map[uuid.UUID][12]float64 `json:"-"`
+	Overbooked []Overbooking             `json:"overbooked"`
+}
+
+// OccurrencesPerYear retorna quantas execuções por ano a frequência exige (0 se inválida).
+func OccurrencesPerYear(frequency models.ControlTestFrequency) int {
+	switch frequency {
+	case models.ControlTestMonthly:
+		return 12
+	case models.ControlTestQuarterly:
+		return 4
+	case models.ControlTestSemiannual:
+		return 2
+	case models.ControlTestAnnual:
+		return 1
+	}
+	return 0
+}
+
+// Plan distribui os testes. Cada execução deve cair dentro da sua janela (ex: trimestre, para testes
+// trimestrais); dentro da janela, escolhe o mês com menor carga do responsável. Testes maiores são
+// alocados primeiro para melhorar o balanceamento. O resultado é determinístico para a mesma entrada.
+func Plan(plans []PlanInput, capacity map[uuid.UUID]float64) Schedule {
+	ordered := make([]PlanInput, len(plans))
+	copy(ordered, plans)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].EstimatedHours != ordered[j].EstimatedHours {
+			return ordered[i].EstimatedHours > ordered[j].EstimatedHours
+		}
+		return ordered[i].PlanID.String() < ordered[j].PlanID.String()
+	})
+
+	schedule := Schedule{OwnerLoad: make(map[uuid.UUID][12]float64)}
+	for _, plan := range ordered {
+		occurrences := OccurrencesPerYear(plan.Frequency)
+		if occurrences == 0 {
+			continue
+		}
+		windowSize := 12 / occurrences
+		load := schedule.OwnerLoad[plan.OwnerID]
+		for occ := 0; occ < occurrences; occ++ {
+			start := occ * windowSize
+			best := start
+			for m := start + 1; m < start+windowSize; m++ {
+				if load[m] < load[best] {
+					best = m
+				}
+			}
+			load[best] += plan.EstimatedHours
+			schedule.Tests = append(schedule.Tests, ScheduledTest{
+				PlanID:         plan.PlanID,
+				OwnerID:        plan.OwnerID,
+				Month:          best + 1,
+				Occurrence:     occ + 1,
+				EstimatedHours: plan.EstimatedHours,
+			})
+		}
+		schedule.OwnerLoad[plan.OwnerID] = load
+	}
+
+	owners := make([]uuid.UUID, 0, len(schedule.OwnerLoad))
+	for owner := range schedule.OwnerLoad {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool { return owners[i].String() < owners[j].String() })
+	for _, owner := range owners {
+		limit, ok := capacity[owner]
+		if !ok {
+			limit = DefaultMonthlyCapacityHours
+		}
+		for m, hours := range schedule.OwnerLoad[owner] {
+			if hours > limit {
+				schedule.Overbooked = append(schedule.Overbooked, Overbooking{
+					OwnerID: owner, Month: m + 1, ScheduledHours: hours, CapacityHours: limit,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(schedule.Tests, func(i, j int) bool { return schedule.Tests[i].Month < schedule.Tests[j].Month })
+	return schedule
+}
@@ -0,0 +1,85 @@
+package testplanner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanRespectsFrequencyWindows(t *testing.T) {
+	owner := uuid.New()
+	quarterly := PlanInput{PlanID: uuid.New(), OwnerID: owner, Frequency: models.ControlTestQuarterly, EstimatedHours: 4}
+
+	schedule := Plan([]PlanInput{quarterly}, nil)
+
+	assert.Len(t, schedule.Tests, 4)
+	for i, test := range schedule.Tests {
+		windowStart := i*3 + 1
+		assert.GreaterOrEqual(t, test.Month, windowStart)
+		assert.Less(t, test.Month, windowStart+3)
+		assert.Equal(t, i+1, test.Occurrence)
+	}
+	assert.Empty(t, schedule.Overbooked)
+}
+
+func TestPlanBalancesOwnerLoad(t *testing.T) {
+	owner := uuid.New()
+	var plans []PlanInput
+	for i := 0; i < 12; i++ {
+		plans = append(plans, PlanInput{PlanID: uuid.New(), OwnerID: owner, Frequency: models.ControlTestAnnual, EstimatedHours: 8})
+	}
+
+	schedule := Plan(plans, map[uuid.UUID]float64{owner: 10})
+
+	load := schedule.OwnerLoad[owner]
+	for m := 0; m < 12; m++ {
+		assert.Equal(t, 8.0, load[m], "month %d should have exactly one annual test", m+1)
+	}
+	assert.Empty(t, schedule.Overbooked)
+}
+
+func TestPlanReportsOverbooking(t *testing.T) {
+	owner := uuid.New()
+	plans := []PlanInput{
+		{PlanID: uuid.New(), OwnerID: owner, Frequency: models.ControlTestMonthly, EstimatedHours: 30},
+		{PlanID: uuid.New(), OwnerID: owner, Frequency: models.ControlTestMonthly, EstimatedHours: 20},
+	}
+
+	schedule := Plan(plans, nil)
+
+	assert.Len(t, schedule.Tests, 24)
+	assert.Len(t, schedule.Overbooked, 12)
+	assert.Equal(t, 50.0, schedule.Overbooked[0].ScheduledHours)
+	assert.Equal(t, DefaultMonthlyCapacityHours, schedule.Overbooked[0].CapacityHours)
+}
+
+func TestPlanSkipsUnknownFrequency(t *testing.T) {
+	schedule := Plan([]PlanInput{{PlanID: uuid.New(), OwnerID: uuid.New(), Frequency: "weekly", EstimatedHours: 1}}, nil)
+	assert.Empty(t, schedule.Tests)
+}
+
+func TestWriteICS(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteICS(&buf, "Testes 2026", []ICSEvent{{
+		UID:         "abc@phoenixgrc",
+		Summary:     "Teste: AC-1, revisão; trimestral",
+		Description: "linha 1\nlinha 2",
+		Start:       time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	}})
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n"))
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20260301\r\n")
+	assert.Contains(t, out, "DTEND;VALUE=DATE:20260401\r\n")
+	assert.Contains(t, out, `SUMMARY:Teste: AC-1\, revisão\; trimestral`)
+	assert.Contains(t, out, `DESCRIPTION:linha 1\nlinha 2`)
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+}
@@ -0,0 +1,77 @@
+// Package tracing configura o rastreamento distribuído com OpenTelemetry: os spans das requisições HTTP
+// (middleware.Tracing), das consultas ao banco (database.TracingPlugin), do armazenamento de arquivos e das
+// chamadas HTTP externas (HTTPClient) são exportados via OTLP/HTTP.
+//
+// O destino e as opções do exportador seguem as variáveis padrão do OpenTelemetry
+// (OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS, OTEL_RESOURCE_ATTRIBUTES...). Com
+// TRACING_ENABLED=false (padrão), nada é exportado e os spans criados pelo código são descartados.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"phoenixgrc/backend/pkg/config"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifica os spans criados pelo backend.
+const InstrumentationName = "phoenixgrc/backend"
+
+// Tracer retorna o tracer do backend. Antes de Init (ou com o rastreamento desativado), os spans são descartados.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Enabled indica se o rastreamento está ligado na configuração.
+func Enabled() bool {
+	return config.Cfg.TracingEnabled
+}
+
+// Init registra o provedor de traces global com o exportador OTLP/HTTP e a propagação W3C (traceparent e
+// baggage). Retorna a função que envia os spans pendentes no encerramento; com o rastreamento desativado, não
+// faz nada.
+func Init(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithAttributes(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", config.Cfg.AppVersion),
+			attribute.String("deployment.environment", config.Cfg.Environment),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Cfg.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// HTTPClient retorna uma cópia do client cujas requisições geram spans de cliente e propagam o trace ao
+// serviço chamado (ex: troca de tokens OAuth2).
+func HTTPClient(client *http.Client) *http.Client {
+	traced := *client
+	traced.Transport = otelhttp.NewTransport(client.Transport)
+	return &traced
+}
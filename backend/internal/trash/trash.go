@@ -0,0 +1,123 @@
+// Package trash expurga os riscos e as políticas que ficaram na lixeira (exclusão lógica, deleted_at) além do
+// prazo de retenção. Até lá, os registros podem ser restaurados pelos endpoints de lixeira.
+package trash
+
+import (
+	"context"
+	"time"
+
+	"phoenixgrc/backend/internal/database"
+	"phoenixgrc/backend/internal/filestorage"
+	"phoenixgrc/backend/internal/models"
+	"phoenixgrc/backend/pkg/config"
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PurgeAt é quando um registro excluído em deletedAt será expurgado.
+func PurgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(config.Cfg.TrashRetention)
+}
+
+// Purge remove definitivamente os riscos e as políticas excluídos antes de cutoff, retornando quantos removeu.
+func Purge(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error) {
+	risks, err := purgeRisks(db, cutoff)
+	if err != nil {
+		return risks, err
+	}
+	var policies []models.Policy
+	if err := db.Unscoped().Where("deleted_at < ?", cutoff).Find(&policies).Error; err != nil {
+		return risks, err
+	}
+	for i := range policies {
+		if err := PurgePolicy(ctx, db, &policies[i]); err != nil {
+			return risks + int64(i), err
+		}
+	}
+	return risks + int64(len(policies)), nil
+}
+
+// purgeRisks remove os riscos da lixeira excluídos antes de cutoff.
+func purgeRisks(db *gorm.DB, cutoff time.Time) (int64, error) {
+	var count int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		count, err = DeleteRisks(tx, "deleted_at < ?", cutoff)
+		return err
+	})
+	return count, err
+}
+
+// DeleteRisks remove definitivamente, na transação tx, os riscos que atendem à condição (estejam ou não na
+// lixeira) com as suas tags; os vínculos (stakeholders, controles, aceites) são removidos pelas FKs em cascata.
+func DeleteRisks(tx *gorm.DB, query interface{}, args ...interface{}) (int64, error) {
+	selected := tx.Unscoped().Model(&models.Risk{}).Select("id").Where(query, args...)
+	if err := tx.Where("entity_type = ? AND entity_id IN (?)", models.TagEntityRisk, selected).Delete(&models.EntityTag{}).Error; err != nil {
+		return 0, err
+	}
+	result := tx.Unscoped().Where(query, args...).Delete(&models.Risk{})
+	return result.RowsAffected, result.Error
+}
+
+// PurgePolicy remove definitivamente a política com as versões, os aceites e os mapeamentos de controles, e apaga
+// os arquivos das versões no armazenamento. As avaliações que citavam a política perdem o vínculo.
+func PurgePolicy(ctx context.Context, db *gorm.DB, policy *models.Policy) error {
+	var versions []models.PolicyVersion
+	if err := db.Where("policy_id = ?", policy.ID).Find(&versions).Error; err != nil {
+		return err
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("policy_id = ?", policy.ID).Delete(&models.PolicyAcknowledgment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("policy_id = ?", policy.ID).Delete(&models.PolicyControlMapping{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("policy_id = ?", policy.ID).Delete(&models.PolicyVersion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.AuditAssessment{}).Where("policy_id = ?", policy.ID).Update("policy_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(policy).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if filestorage.DefaultFileStorageProvider != nil {
+		for _, v := range versions {
+			if errDel := filestorage.DefaultFileStorageProvider.DeleteFile(ctx, v.FileURL); errDel != nil {
+				phxlog.L.Warn("Failed to delete policy version file from storage",
+					zap.String("policyID", policy.ID.String()),
+					zap.String("objectName", v.FileURL),
+					zap.Error(errDel))
+			}
+		}
+	}
+	return nil
+}
+
+// StartPurgeScheduler expurga a lixeira a cada intervalo até o contexto ser cancelado.
+func StartPurgeScheduler(ctx context.Context, every time.Duration) {
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				count, err := Purge(ctx, database.GetDB().WithContext(ctx), now.Add(-config.Cfg.TrashRetention))
+				if err != nil {
+					phxlog.L.Error("Trash purge failed", zap.Error(err))
+				}
+				if count > 0 {
+					phxlog.L.Info("Expired records removed from the trash", zap.Int64("count", count))
+				}
+			}
+		}
+	}()
+}
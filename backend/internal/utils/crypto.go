@@ -7,13 +7,14 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
-	"os"
+
+	"phoenixgrc/backend/pkg/config"
 )
 
 var encryptionKey []byte
 
 func init() {
-	hexKey := os.Getenv("ENCRYPTION_KEY_HEX")
+	hexKey := config.Cfg.EncryptionKeyHex // O pacote config é inicializado antes deste
 	if hexKey == "" {
 		// Em um ambiente de produção real, isso deveria causar um erro fatal.
 		// Para desenvolvimento, podemos usar uma chave padrão, mas isso é INSEGURO.
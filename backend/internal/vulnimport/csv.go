@@ -0,0 +1,189 @@
+package vulnimport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"phoenixgrc/backend/internal/models"
+)
+
+// csvTable lê um CSV de scanner, localizando a linha de cabeçalho (relatórios Qualys trazem linhas de resumo antes dela).
+type csvTable struct {
+	reader     *csv.Reader
+	headers    map[string]int
+	headerLine int
+}
+
+func newCSVTable(r io.Reader, requiredHeaders ...string) (*csvTable, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	line := 0
+	for {
+		record, err := reader.Read()
+		line++
+		if err == io.EOF {
+			return nil, fmt.Errorf("header row with columns %s not found", strings.Join(requiredHeaders, ", "))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		headers := make(map[string]int, len(record))
+		for i, h := range record {
+			headers[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+		}
+		found := true
+		for _, required := range requiredHeaders {
+			if _, ok := headers[required]; !ok {
+				found = false
+				break
+			}
+		}
+		if found {
+			return &csvTable{reader: reader, headers: headers, headerLine: line}, nil
+		}
+	}
+}
+
+func (t *csvTable) field(record []string, names ...string) string {
+	for _, name := range names {
+		if idx, ok := t.headers[name]; ok && idx < len(record) {
+			if v := strings.TrimSpace(record[idx]); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// each percorre as linhas de dados; fn retorna uma mensagem de erro quando a linha é inválida.
+func (t *csvTable) each(fn func(record []string) string) []RowError {
+	var errs []RowError
+	line := t.headerLine
+	for {
+		record, err := t.reader.Read()
+		line++
+		if err == io.EOF {
+			return errs
+		}
+		if err != nil {
+			errs = append(errs, RowError{Line: line, Message: "failed to parse CSV row: " + err.Error()})
+			continue
+		}
+		if msg := fn(record); msg != "" {
+			errs = append(errs, RowError{Line: line, Message: msg})
+		}
+	}
+}
+
+func parseScore(value string) float64 {
+	score, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || score < 0 || score > 10 {
+		return 0
+	}
+	return score
+}
+
+// ParseNessusCSV lê a exportação CSV do Nessus/Tenable. Linhas com Risk "None" (informativas) são ignoradas.
+func ParseNessusCSV(r io.Reader) ([]Finding, []RowError, error) {
+	table, err := newCSVTable(r, "name", "host", "risk")
+	if err != nil {
+		return nil, nil, err
+	}
+	var findings []Finding
+	rowErrs := table.each(func(record []string) string {
+		risk := strings.ToLower(table.field(record, "risk"))
+		if risk == "" || risk == "none" {
+			return ""
+		}
+		title := table.field(record, "name")
+		asset := table.field(record, "host", "ip address", "dns name")
+		if title == "" || asset == "" {
+			return "name and host are required"
+		}
+		finding := Finding{
+			Title:       title,
+			Description: firstNonEmpty(table.field(record, "synopsis"), table.field(record, "description")),
+			CVSS:        parseScore(table.field(record, "cvss v3.0 base score", "cvss v3 base score", "cvss v2.0 base score", "cvss")),
+			Asset:       asset,
+			Source:      "nessus",
+		}
+		if finding.CVSS > 0 {
+			finding.Severity = SeverityFromCVSS(finding.CVSS)
+		} else {
+			finding.Severity = nessusRiskSeverity(risk)
+		}
+		findings = append(findings, splitByCVE(finding, table.field(record, "cve"))...)
+		return ""
+	})
+	return findings, rowErrs, nil
+}
+
+func nessusRiskSeverity(risk string) models.VulnerabilitySeverity {
+	switch risk {
+	case "critical":
+		return models.SeverityCritical
+	case "high":
+		return models.SeverityHigh
+	case "medium":
+		return models.SeverityMedium
+	default:
+		return models.SeverityLow
+	}
+}
+
+// ParseQualysCSV lê o relatório de varredura CSV do Qualys (colunas IP/DNS, QID, Title, Severity, CVE ID, CVSS).
+func ParseQualysCSV(r io.Reader) ([]Finding, []RowError, error) {
+	table, err := newCSVTable(r, "qid", "title", "severity")
+	if err != nil {
+		return nil, nil, err
+	}
+	var findings []Finding
+	rowErrs := table.each(func(record []string) string {
+		title := table.field(record, "title")
+		asset := table.field(record, "dns", "fqdn", "netbios", "ip")
+		if title == "" || asset == "" {
+			return "title and ip/dns are required"
+		}
+		finding := Finding{
+			Title:       title,
+			Description: firstNonEmpty(table.field(record, "threat"), table.field(record, "impact")),
+			CVSS:        parseScore(table.field(record, "cvss3.1 base", "cvss3 base", "cvss base")),
+			Asset:       asset,
+			Source:      "qualys",
+		}
+		if finding.CVSS > 0 {
+			finding.Severity = SeverityFromCVSS(finding.CVSS)
+		} else {
+			finding.Severity = qualysSeverity(table.field(record, "severity"))
+		}
+		findings = append(findings, splitByCVE(finding, table.field(record, "cve id", "cve"))...)
+		return ""
+	})
+	return findings, rowErrs, nil
+}
+
+func qualysSeverity(level string) models.VulnerabilitySeverity {
+	switch strings.TrimSpace(level) {
+	case "5":
+		return models.SeverityCritical
+	case "4":
+		return models.SeverityHigh
+	case "3":
+		return models.SeverityMedium
+	default:
+		return models.SeverityLow
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
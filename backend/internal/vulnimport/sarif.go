@@ -0,0 +1,128 @@
+package vulnimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"phoenixgrc/backend/internal/models"
+)
+
+// Subconjunto do SARIF 2.1.0 usado na importação.
+type sarifLog struct {
+	Runs []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver struct {
+		Name  string      `json:"name"`
+		Rules []sarifRule `json:"rules"`
+	} `json:"driver"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	ShortDescription sarifMessage           `json:"shortDescription"`
+	FullDescription  sarifMessage           `json:"fullDescription"`
+	Properties       map[string]interface{} `json:"properties"`
+}
+
+type sarifResult struct {
+	RuleID    string       `json:"ruleId"`
+	RuleIndex *int         `json:"ruleIndex"`
+	Level     string       `json:"level"`
+	Message   sarifMessage `json:"message"`
+	Locations []struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+		} `json:"physicalLocation"`
+	} `json:"locations"`
+}
+
+// ParseSARIF lê um log SARIF 2.1.0 (scanners de dependências/containers e SAST).
+// O ativo é o artefato da primeira localização do resultado; a nota vem de properties["security-severity"] da regra.
+func ParseSARIF(r io.Reader) ([]Finding, []RowError, error) {
+	var log sarifLog
+	if err := json.NewDecoder(r).Decode(&log); err != nil {
+		return nil, nil, fmt.Errorf("invalid SARIF document: %w", err)
+	}
+	var findings []Finding
+	var rowErrs []RowError
+	position := 0
+	for _, run := range log.Runs {
+		rules := make(map[string]sarifRule, len(run.Tool.Driver.Rules))
+		for _, rule := range run.Tool.Driver.Rules {
+			rules[rule.ID] = rule
+		}
+		source := strings.ToLower(firstNonEmpty(run.Tool.Driver.Name, "sarif"))
+		for _, result := range run.Results {
+			position++
+			rule, ok := rules[result.RuleID]
+			if !ok && result.RuleIndex != nil && *result.RuleIndex >= 0 && *result.RuleIndex < len(run.Tool.Driver.Rules) {
+				rule = run.Tool.Driver.Rules[*result.RuleIndex]
+			}
+			asset := ""
+			if len(result.Locations) > 0 {
+				asset = result.Locations[0].PhysicalLocation.ArtifactLocation.URI
+			}
+			title := firstNonEmpty(rule.ShortDescription.Text, rule.Name, result.RuleID, rule.ID)
+			if title == "" || asset == "" {
+				rowErrs = append(rowErrs, RowError{Line: position, Message: "result without rule or location"})
+				continue
+			}
+			finding := Finding{
+				Title:       title,
+				Description: firstNonEmpty(result.Message.Text, rule.FullDescription.Text),
+				CVSS:        sarifSecuritySeverity(rule.Properties),
+				Asset:       asset,
+				Source:      source,
+			}
+			if finding.CVSS > 0 {
+				finding.Severity = SeverityFromCVSS(finding.CVSS)
+			} else {
+				finding.Severity = sarifLevelSeverity(result.Level)
+			}
+			cveText := strings.Join([]string{result.RuleID, rule.ID, rule.Name, fmt.Sprint(rule.Properties["tags"])}, " ")
+			if cves := ExtractCVEs(cveText); len(cves) > 0 {
+				finding.CVEID = cves[0]
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings, rowErrs, nil
+}
+
+func sarifSecuritySeverity(properties map[string]interface{}) float64 {
+	switch v := properties["security-severity"].(type) {
+	case string:
+		return parseScore(v)
+	case float64:
+		return parseScore(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return 0
+}
+
+func sarifLevelSeverity(level string) models.VulnerabilitySeverity {
+	switch level {
+	case "error":
+		return models.SeverityHigh
+	case "warning", "":
+		return models.SeverityMedium
+	default:
+		return models.SeverityLow
+	}
+}
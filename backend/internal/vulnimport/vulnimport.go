@@ -0,0 +1,124 @@
+// Package vulnimport converte exportações de scanners de vulnerabilidade (Nessus CSV, Qualys CSV e SARIF)
+// em achados normalizados, deduplicados por CVE (ou título, quando não há CVE) + ativo.
+package vulnimport
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"phoenixgrc/backend/internal/models"
+)
+
+// Format é o formato de exportação do scanner.
+type Format string
+
+const (
+	FormatNessus Format = "nessus"
+	FormatQualys Format = "qualys"
+	FormatSARIF  Format = "sarif"
+)
+
+// Finding é um achado normalizado de scanner.
+type Finding struct {
+	Title       string
+	Description string
+	CVEID       string
+	CVSS        float64 // 0 quando o scanner não informa
+	Severity    models.VulnerabilitySeverity
+	Asset       string
+	Source      string // Nome do scanner/ferramenta
+}
+
+// DedupKey identifica o achado para deduplicação: CVE + ativo, ou título + ativo quando não há CVE.
+func (f Finding) DedupKey() string {
+	id := f.CVEID
+	if id == "" {
+		id = "title:" + strings.ToLower(f.Title)
+	}
+	return strings.ToUpper(id) + "|" + strings.ToLower(f.Asset)
+}
+
+// RowError é uma linha/resultado da exportação que não pôde ser convertido.
+type RowError struct {
+	Line    int
+	Message string
+}
+
+var cvePattern = regexp.MustCompile(`(?i)CVE-\d{4}-\d{4,}`)
+
+// ExtractCVEs retorna os CVEs distintos encontrados no texto, em maiúsculas.
+func ExtractCVEs(text string) []string {
+	seen := make(map[string]bool)
+	var cves []string
+	for _, match := range cvePattern.FindAllString(text, -1) {
+		cve := strings.ToUpper(match)
+		if !seen[cve] {
+			seen[cve] = true
+			cves = append(cves, cve)
+		}
+	}
+	return cves
+}
+
+// SeverityFromCVSS converte a nota CVSS na severidade usada pelo Phoenix GRC.
+func SeverityFromCVSS(score float64) models.VulnerabilitySeverity {
+	switch {
+	case score >= 9.0:
+		return models.SeverityCritical
+	case score >= 7.0:
+		return models.SeverityHigh
+	case score >= 4.0:
+		return models.SeverityMedium
+	default:
+		return models.SeverityLow
+	}
+}
+
+// Parse lê a exportação no formato informado.
+func Parse(format Format, r io.Reader) ([]Finding, []RowError, error) {
+	switch format {
+	case FormatNessus:
+		return ParseNessusCSV(r)
+	case FormatQualys:
+		return ParseQualysCSV(r)
+	case FormatSARIF:
+		return ParseSARIF(r)
+	default:
+		return nil, nil, fmt.Errorf("unsupported format '%s' (expected nessus, qualys or sarif)", format)
+	}
+}
+
+// Dedupe mantém um achado por chave de deduplicação, preservando a ordem e ficando com a maior nota CVSS.
+func Dedupe(findings []Finding) []Finding {
+	index := make(map[string]int, len(findings))
+	result := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		key := f.DedupKey()
+		if i, ok := index[key]; ok {
+			if f.CVSS > result[i].CVSS {
+				result[i] = f
+			}
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, f)
+	}
+	return result
+}
+
+// splitByCVE gera um achado por CVE quando a linha lista vários; sem CVE, retorna o achado original.
+func splitByCVE(f Finding, cveField string) []Finding {
+	cves := ExtractCVEs(cveField)
+	if len(cves) == 0 {
+		return []Finding{f}
+	}
+	findings := make([]Finding, 0, len(cves))
+	for _, cve := range cves {
+		perCVE := f
+		perCVE.CVEID = cve
+		findings = append(findings, perCVE)
+	}
+	return findings
+}
@@ -0,0 +1,96 @@
+package vulnimport
+
+import (
+	"strings"
+	"testing"
+
+	"phoenixgrc/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNessusCSVSplitsCVEsAndSkipsInformational(t *testing.T) {
+	data := `Plugin ID,CVE,CVSS v3.0 Base Score,Risk,Host,Protocol,Port,Name,Synopsis
+10001,"CVE-2021-44228, CVE-2021-45046",10.0,Critical,10.0.0.5,tcp,443,Apache Log4j RCE,Remote code execution
+10002,,,None,10.0.0.5,tcp,0,OS Identification,Info only
+10003,,,Medium,web01,tcp,443,TLS Weak Cipher,Weak ciphers supported
+10004,,,High,,tcp,22,Missing host,No host
+`
+	findings, rowErrs, err := ParseNessusCSV(strings.NewReader(data))
+	require.NoError(t, err)
+
+	require.Len(t, findings, 3)
+	assert.Equal(t, "CVE-2021-44228", findings[0].CVEID)
+	assert.Equal(t, "CVE-2021-45046", findings[1].CVEID)
+	assert.Equal(t, models.SeverityCritical, findings[0].Severity)
+	assert.Equal(t, 10.0, findings[0].CVSS)
+	assert.Equal(t, "", findings[2].CVEID)
+	assert.Equal(t, models.SeverityMedium, findings[2].Severity, "severity falls back to the Risk column without CVSS")
+
+	require.Len(t, rowErrs, 1)
+	assert.Equal(t, 5, rowErrs[0].Line)
+}
+
+func TestParseQualysCSVSkipsPreamble(t *testing.T) {
+	data := `"Scan Results","scan/1234"
+"Launch Date","2024-01-01"
+
+"IP","DNS","QID","Title","Severity","CVE ID","CVSS3.1 Base","Threat"
+"10.0.0.7","db01.corp","38739","OpenSSH Vulnerability","4","CVE-2023-38408","9.8","Agent forwarding RCE"
+"10.0.0.8","","11827","HTTP Security Header Not Detected","2","","","Missing header"
+`
+	findings, rowErrs, err := ParseQualysCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	assert.Empty(t, rowErrs)
+
+	require.Len(t, findings, 2)
+	assert.Equal(t, "db01.corp", findings[0].Asset, "DNS is preferred over IP")
+	assert.Equal(t, models.SeverityCritical, findings[0].Severity, "CVSS takes precedence over the Qualys level")
+	assert.Equal(t, "10.0.0.8", findings[1].Asset)
+	assert.Equal(t, models.SeverityLow, findings[1].Severity)
+	assert.Equal(t, "qualys", findings[1].Source)
+}
+
+func TestParseSARIF(t *testing.T) {
+	data := `{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"Trivy","rules":[
+		{"id":"CVE-2022-0778","shortDescription":{"text":"openssl: infinite loop"},"properties":{"security-severity":"7.5"}},
+		{"id":"go/sql-injection","name":"SqlInjection","properties":{}}
+	]}},"results":[
+		{"ruleId":"CVE-2022-0778","level":"error","message":{"text":"Package libssl1.1"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"library/nginx:1.21"}}}]},
+		{"ruleId":"go/sql-injection","level":"warning","message":{"text":"Query built from user input"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"internal/db.go"}}}]},
+		{"ruleId":"go/sql-injection","message":{"text":"No location"}}
+	]}]}`
+	findings, rowErrs, err := ParseSARIF(strings.NewReader(data))
+	require.NoError(t, err)
+
+	require.Len(t, findings, 2)
+	assert.Equal(t, "CVE-2022-0778", findings[0].CVEID)
+	assert.Equal(t, 7.5, findings[0].CVSS)
+	assert.Equal(t, models.SeverityHigh, findings[0].Severity)
+	assert.Equal(t, "trivy", findings[0].Source)
+	assert.Equal(t, "SqlInjection", findings[1].Title)
+	assert.Equal(t, models.SeverityMedium, findings[1].Severity)
+	require.Len(t, rowErrs, 1)
+
+	_, _, err = ParseSARIF(strings.NewReader("not json"))
+	assert.Error(t, err)
+}
+
+func TestDedupeKeepsHighestCVSSPerCVEAndAsset(t *testing.T) {
+	findings := Dedupe([]Finding{
+		{Title: "A", CVEID: "CVE-2024-0001", Asset: "web01", CVSS: 5.0},
+		{Title: "A (plugin 2)", CVEID: "cve-2024-0001", Asset: "WEB01", CVSS: 8.1},
+		{Title: "A", CVEID: "CVE-2024-0001", Asset: "web02", CVSS: 5.0},
+		{Title: "No CVE", Asset: "web01"},
+		{Title: "no cve", Asset: "web01"},
+	})
+	require.Len(t, findings, 3)
+	assert.Equal(t, 8.1, findings[0].CVSS)
+	assert.Equal(t, "web02", findings[1].Asset)
+}
+
+func TestParseRejectsUnknownFormat(t *testing.T) {
+	_, _, err := Parse("openvas", strings.NewReader(""))
+	assert.Error(t, err)
+}
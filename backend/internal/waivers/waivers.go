@@ -0,0 +1,155 @@
+// Package waivers aplica as exceções (waivers) de controles à avaliação contínua da organização: a aprovação
+// marca o controle como dispensado e o vencimento ou a revogação o devolve a não conforme. As transições
+// passam por compliancescore.RecordAssessmentWrite, então o score de conformidade acompanha a mudança.
+package waivers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"phoenixgrc/backend/internal/compliancescore"
+	"phoenixgrc/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrNotPending indica que a exceção já foi decidida (ou revogada) por outra requisição.
+	ErrNotPending = errors.New("waiver is no longer pending")
+	// ErrNotActive indica que a exceção já venceu ou foi revogada por outra requisição.
+	ErrNotActive = errors.New("waiver is no longer active")
+)
+
+// Lapsed é uma exceção encerrada pelo vencimento, com o agregado do framework atualizado.
+type Lapsed struct {
+	Waiver models.ControlWaiver
+	Tally  *models.ComplianceScoreTally
+}
+
+// rubricFor retorna a rubrica efetiva do framework do controle da exceção.
+func rubricFor(db *gorm.DB, waiver *models.ControlWaiver) (models.AssessmentScoreRubric, error) {
+	var control models.AuditControl
+	if err := db.Select("id", "framework_id").Take(&control, "id = ?", waiver.AuditControlID).Error; err != nil {
+		return models.AssessmentScoreRubric{}, err
+	}
+	rubric, _, err := models.ResolveScoreRubric(db, waiver.OrganizationID, control.FrameworkID)
+	return rubric, err
+}
+
+// Activate ativa a exceção pendente: grava o status dispensado na avaliação contínua do controle (criando-a
+// se necessário) e guarda o status anterior. inTx (opcional) roda na mesma transação, ex: a decisão do
+// ApprovalWorkflow. Retorna ErrNotPending se a exceção não estiver mais pendente.
+func Activate(db *gorm.DB, waiver *models.ControlWaiver, now time.Time, inTx func(tx *gorm.DB) error) (*models.ComplianceScoreTally, error) {
+	rubric, err := rubricFor(db, waiver)
+	if err != nil {
+		return nil, err
+	}
+	return compliancescore.RecordAssessmentWrite(db, waiver.OrganizationID, waiver.AuditControlID, func(tx *gorm.DB) error {
+		var previous models.AuditAssessment
+		err := tx.Scopes(models.OrgWideAssessments).Select("status").
+			Where("organization_id = ? AND audit_control_id = ?", waiver.OrganizationID, waiver.AuditControlID).
+			Take(&previous).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		result := tx.Model(&models.ControlWaiver{}).
+			Where("id = ? AND status = ?", waiver.ID, models.ControlWaiverPending).
+			Updates(map[string]interface{}{
+				"status":                     models.ControlWaiverActive,
+				"approved_at":                now,
+				"previous_assessment_status": previous.Status,
+				"updated_at":                 now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotPending
+		}
+
+		score := rubric.ScoreFor(models.ControlStatusWaived)
+		assessment := models.AuditAssessment{
+			OrganizationID: waiver.OrganizationID,
+			AuditControlID: waiver.AuditControlID,
+			Status:         models.ControlStatusWaived,
+			Score:          &score,
+			AssessmentDate: &now,
+		}
+		if err := tx.Clauses(models.OrgWideAssessmentConflict(clause.AssignmentColumns([]string{"status", "status_code", "score", "assessment_date", "updated_at"}))).
+			Create(&assessment).Error; err != nil {
+			return err
+		}
+
+		waiver.Status = models.ControlWaiverActive
+		waiver.ApprovedAt = &now
+		waiver.PreviousAssessmentStatus = previous.Status
+		if inTx != nil {
+			return inTx(tx)
+		}
+		return nil
+	})
+}
+
+// Lapse encerra a exceção ativa com status (expired ou revoked) e devolve o controle a não conforme. A
+// avaliação só é alterada se ainda estiver dispensada: uma reavaliação feita durante a exceção é mantida.
+// Retorna ErrNotActive se a exceção não estiver mais ativa.
+func Lapse(db *gorm.DB, waiver *models.ControlWaiver, status models.ControlWaiverStatus, now time.Time) (*models.ComplianceScoreTally, error) {
+	rubric, err := rubricFor(db, waiver)
+	if err != nil {
+		return nil, err
+	}
+	return compliancescore.RecordAssessmentWrite(db, waiver.OrganizationID, waiver.AuditControlID, func(tx *gorm.DB) error {
+		result := tx.Model(&models.ControlWaiver{}).
+			Where("id = ? AND status = ?", waiver.ID, models.ControlWaiverActive).
+			Updates(map[string]interface{}{"status": status, "closed_at": now, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotActive
+		}
+
+		score := rubric.ScoreFor(models.ControlStatusNonConformant)
+		if err := tx.Model(&models.AuditAssessment{}).Scopes(models.OrgWideAssessments).
+			Where("organization_id = ? AND audit_control_id = ? AND status = ?", waiver.OrganizationID, waiver.AuditControlID, models.ControlStatusWaived).
+			Updates(map[string]interface{}{
+				"status":          models.ControlStatusNonConformant,
+				"score":           score,
+				"assessment_date": now,
+				"updated_at":      now,
+			}).Error; err != nil {
+			return err
+		}
+		waiver.Status = status
+		waiver.ClosedAt = &now
+		return nil
+	})
+}
+
+// ExpireDue encerra as exceções ativas cujo último dia de validade (ExpiresAt) já passou. Falhas em uma
+// exceção não interrompem as demais e são retornadas junto com as exceções encerradas.
+func ExpireDue(ctx context.Context, db *gorm.DB, now time.Time) ([]Lapsed, error) {
+	var due []models.ControlWaiver
+	if err := db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", models.ControlWaiverActive, now.Format("2006-01-02")).
+		Order("expires_at ASC").Find(&due).Error; err != nil {
+		return nil, err
+	}
+	var lapsed []Lapsed
+	var errs []error
+	for i := range due {
+		tally, err := Lapse(db.WithContext(ctx), &due[i], models.ControlWaiverExpired, now)
+		if errors.Is(err, ErrNotActive) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		lapsed = append(lapsed, Lapsed{Waiver: due[i], Tally: tally})
+	}
+	return lapsed, errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+package webauthn
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"math/big"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+// Algoritmos COSE aceitos (IANA "COSE Algorithms"). São os que os navegadores e autenticadores usam na prática.
+const (
+	AlgES256 = -7   // ECDSA P-256 com SHA-256
+	AlgEdDSA = -8   // Ed25519
+	AlgRS256 = -257 // RSASSA-PKCS1-v1_5 com SHA-256 (Windows Hello)
+)
+
+// SupportedAlgorithms é a ordem de preferência enviada em pubKeyCredParams.
+var SupportedAlgorithms = []int{AlgES256, AlgEdDSA, AlgRS256}
+
+const minRSABits = 2048
+
+// credentialParameters são os algoritmos aceitos no formato da biblioteca, que recusa chaves fora da lista.
+func credentialParameters() []protocol.CredentialParameter {
+	params := make([]protocol.CredentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, protocol.CredentialParameter{Type: protocol.PublicKeyCredentialType, Algorithm: webauthncose.COSEAlgorithmIdentifier(alg)})
+	}
+	return params
+}
+
+// checkPublicKey confere a chave COSE atestada no registro e retorna o seu algoritmo. A chave é guardada no
+// formato COSE, que é o que a verificação das asserções recebe.
+func checkPublicKey(coseKey []byte) (int, error) {
+	parsed, err := webauthncose.ParsePublicKey(coseKey)
+	if err != nil {
+		return 0, errors.New("credential public key is not a supported COSE key")
+	}
+	switch key := parsed.(type) {
+	case webauthncose.EC2PublicKeyData:
+		if key.Algorithm == AlgES256 {
+			ecKey, err := key.ToECDSA()
+			if err == nil {
+				_, err = ecKey.ECDH()
+			}
+			if err != nil || len(key.XCoord) != 32 || len(key.YCoord) != 32 {
+				return 0, errors.New("ES256 public key is not on the P-256 curve")
+			}
+			return AlgES256, nil
+		}
+	case webauthncose.OKPPublicKeyData:
+		if key.Algorithm == AlgEdDSA {
+			if len(key.XCoord) != ed25519.PublicKeySize {
+				return 0, errors.New("invalid EdDSA public key")
+			}
+			return AlgEdDSA, nil
+		}
+	case webauthncose.RSAPublicKeyData:
+		if key.Algorithm == AlgRS256 {
+			if new(big.Int).SetBytes(key.Modulus).BitLen() < minRSABits {
+				return 0, errors.New("RS256 public key is too weak")
+			}
+			return AlgRS256, nil
+		}
+	}
+	return 0, errors.New("unsupported credential public key")
+}
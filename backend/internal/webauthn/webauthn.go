@@ -0,0 +1,382 @@
+// Package webauthn implementa o lado do servidor (relying party) das cerimônias WebAuthn usadas como segundo
+// fator: registro de passkeys e chaves de segurança e verificação das asserções no login.
+//
+// A decodificação CBOR/COSE e a verificação das cerimônias ficam com o go-webauthn (pacote protocol); aqui
+// ficam as regras do segundo fator: atestação "none" pedida ao navegador (o servidor não confia no fabricante
+// do autenticador, só na chave registrada), algoritmos ES256, EdDSA e RS256, recusa de cerimônias cross-origin
+// e do contador de assinaturas que não avança. As mensagens seguem o formato JSON de
+// PublicKeyCredential.toJSON(), com os campos binários em base64url.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/google/uuid"
+)
+
+// ChallengeTTL é a validade de um desafio; também é o timeout sugerido ao navegador.
+const ChallengeTTL = 5 * time.Minute
+
+const (
+	challengeBytes       = 32
+	maxCredentialIDBytes = 1023
+)
+
+// ErrVerification é a causa de toda resposta de autenticador recusada; os detalhes vêm na mensagem.
+var ErrVerification = errors.New("webauthn verification failed")
+
+func verificationError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrVerification, fmt.Sprintf(format, args...))
+}
+
+// RelyingParty identifica a aplicação perante os autenticadores.
+type RelyingParty struct {
+	ID      string   // Domínio ao qual as credenciais ficam vinculadas (rp.id)
+	Name    string   // Nome exibido pelo navegador
+	Origins []string // Origens (esquema://host[:porta]) de onde as cerimônias podem vir
+}
+
+// FromConfig monta a relying party a partir de WEBAUTHN_RP_ID, WEBAUTHN_RP_NAME e WEBAUTHN_ORIGINS. Sem elas,
+// o domínio e a origem são os de FRONTEND_BASE_URL.
+func FromConfig() RelyingParty {
+	rp := RelyingParty{ID: config.Cfg.WebAuthnRPID, Name: config.Cfg.WebAuthnRPName, Origins: config.Cfg.WebAuthnOrigins}
+	if frontend, err := url.Parse(config.Cfg.FrontendBaseURL); err == nil && frontend.Host != "" {
+		if rp.ID == "" {
+			rp.ID = frontend.Hostname()
+		}
+		if len(rp.Origins) == 0 {
+			rp.Origins = []string{frontend.Scheme + "://" + frontend.Host}
+		}
+	}
+	if rp.Name == "" {
+		rp.Name = "Phoenix GRC"
+	}
+	return rp
+}
+
+// NewChallenge gera um desafio aleatório em base64url.
+func NewChallenge() (string, error) {
+	challenge := make([]byte, challengeBytes)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(challenge), nil
+}
+
+// CredentialDescriptor identifica uma credencial nas opções enviadas ao navegador.
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// NewCredentialDescriptor descreve uma credencial registrada.
+func NewCredentialDescriptor(credentialID []byte, transports []string) CredentialDescriptor {
+	return CredentialDescriptor{Type: "public-key", ID: base64.RawURLEncoding.EncodeToString(credentialID), Transports: transports}
+}
+
+// User é a conta para a qual a credencial é criada.
+type User struct {
+	ID          []byte
+	Name        string
+	DisplayName string
+}
+
+type relyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type userEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type credentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type authenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions são as opções de navigator.credentials.create (PublicKeyCredential.parseCreationOptionsFromJSON).
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     relyingPartyEntity     `json:"rp"`
+	User                   userEntity             `json:"user"`
+	PubKeyCredParams       []credentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection authenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions são as opções de navigator.credentials.get (PublicKeyCredential.parseRequestOptionsFromJSON).
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// CreationOptions monta as opções de registro. As credenciais já registradas vão em excludeCredentials para o
+// autenticador não registrar a mesma chave duas vezes.
+func (rp RelyingParty) CreationOptions(challenge string, user User, exclude []CredentialDescriptor) CreationOptions {
+	params := make([]credentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, credentialParameter{Type: "public-key", Alg: alg})
+	}
+	if exclude == nil {
+		exclude = []CredentialDescriptor{}
+	}
+	return CreationOptions{
+		Challenge:              challenge,
+		RP:                     relyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User:                   userEntity{ID: base64.RawURLEncoding.EncodeToString(user.ID), Name: user.Name, DisplayName: user.DisplayName},
+		PubKeyCredParams:       params,
+		Timeout:                ChallengeTTL.Milliseconds(),
+		ExcludeCredentials:     exclude,
+		AuthenticatorSelection: authenticatorSelection{ResidentKey: "preferred", UserVerification: "preferred"},
+		Attestation:            "none",
+	}
+}
+
+// RequestOptions monta as opções de asserção, restritas às credenciais do usuário.
+func (rp RelyingParty) RequestOptions(challenge string, allow []CredentialDescriptor) RequestOptions {
+	if allow == nil {
+		allow = []CredentialDescriptor{}
+	}
+	return RequestOptions{
+		Challenge:        challenge,
+		Timeout:          ChallengeTTL.Milliseconds(),
+		RPID:             rp.ID,
+		AllowCredentials: allow,
+		UserVerification: "preferred",
+	}
+}
+
+// RegistrationResponse é o resultado de navigator.credentials.create em JSON.
+type RegistrationResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string   `json:"clientDataJSON"`
+		AttestationObject string   `json:"attestationObject"`
+		Transports        []string `json:"transports,omitempty"`
+	} `json:"response"`
+}
+
+// AssertionResponse é o resultado de navigator.credentials.get em JSON.
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle,omitempty"`
+	} `json:"response"`
+}
+
+// Credential é a credencial aceita no registro, pronta para ser guardada.
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte // Chave pública no formato COSE, como atestada pelo autenticador
+	Algorithm      int
+	SignCount      uint32
+	AAGUID         uuid.UUID // Modelo do autenticador; zero quando o autenticador não informa
+	Transports     []string
+	UserVerified   bool
+	BackupEligible bool // Passkey sincronizável entre dispositivos
+	BackupState    bool
+}
+
+// Assertion é o resultado de uma asserção válida.
+type Assertion struct {
+	SignCount    uint32
+	UserVerified bool
+	BackupState  bool
+}
+
+// Challenge retorna o desafio contido na resposta, para localizar o desafio emitido pelo servidor.
+func (r RegistrationResponse) Challenge() (string, error) {
+	data, err := parseClientData(r.Response.ClientDataJSON)
+	if err != nil {
+		return "", err
+	}
+	return data.Challenge, nil
+}
+
+// Challenge retorna o desafio contido na resposta, para localizar o desafio emitido pelo servidor.
+func (r AssertionResponse) Challenge() (string, error) {
+	data, err := parseClientData(r.Response.ClientDataJSON)
+	if err != nil {
+		return "", err
+	}
+	return data.Challenge, nil
+}
+
+// CredentialID retorna o identificador da credencial usada na asserção.
+func (r AssertionResponse) CredentialID() ([]byte, error) {
+	return credentialID(r.ID, r.RawID, r.Type)
+}
+
+// VerifyRegistration confere a resposta de registro contra o desafio emitido e extrai a credencial. A
+// verificação do clientDataJSON, do authenticatorData e da atestação é feita pelo go-webauthn.
+func (rp RelyingParty) VerifyRegistration(resp RegistrationResponse, challenge string) (*Credential, error) {
+	id, err := credentialID(resp.ID, resp.RawID, resp.Type)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return nil, verificationError("%s", err)
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(raw)
+	if err != nil {
+		return nil, protocolError(err)
+	}
+	if parsed.Response.CollectedClientData.CrossOrigin {
+		return nil, verificationError("cross-origin ceremonies are not allowed")
+	}
+	// Pedimos atestação "none"; outros formatos (packed, tpm...) são verificados pela biblioteca, sem metadados
+	if _, err := parsed.Verify(challenge, false, true, rp.ID, rp.Origins, nil, protocol.TopOriginIgnoreVerificationMode, nil, credentialParameters()); err != nil {
+		return nil, protocolError(err)
+	}
+
+	authData := parsed.Response.AttestationObject.AuthData
+	if err := verifyBackupFlags(authData.Flags); err != nil {
+		return nil, err
+	}
+	if !authData.Flags.HasAttestedCredentialData() {
+		return nil, verificationError("authenticator data has no attested credential")
+	}
+	if !bytes.Equal(authData.AttData.CredentialID, id) {
+		return nil, verificationError("credential ID does not match the attested credential")
+	}
+	algorithm, err := checkPublicKey(authData.AttData.CredentialPublicKey)
+	if err != nil {
+		return nil, verificationError("%s", err)
+	}
+
+	aaguid, _ := uuid.FromBytes(authData.AttData.AAGUID)
+	return &Credential{
+		ID:             id,
+		PublicKey:      authData.AttData.CredentialPublicKey,
+		Algorithm:      algorithm,
+		SignCount:      authData.Counter,
+		AAGUID:         aaguid,
+		Transports:     resp.Response.Transports,
+		UserVerified:   authData.Flags.HasUserVerified(),
+		BackupEligible: authData.Flags.HasBackupEligible(),
+		BackupState:    authData.Flags.HasBackupState(),
+	}, nil
+}
+
+// VerifyAssertion confere a asserção contra o desafio emitido e a chave COSE registrada. Um contador de
+// assinaturas que não avança indica um autenticador clonado e a asserção é recusada.
+func (rp RelyingParty) VerifyAssertion(resp AssertionResponse, challenge string, publicKey []byte, storedSignCount uint32) (*Assertion, error) {
+	if _, err := credentialID(resp.ID, resp.RawID, resp.Type); err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return nil, verificationError("%s", err)
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(raw)
+	if err != nil {
+		return nil, protocolError(err)
+	}
+	if parsed.Response.CollectedClientData.CrossOrigin {
+		return nil, verificationError("cross-origin ceremonies are not allowed")
+	}
+	if err := parsed.Verify(challenge, rp.ID, rp.Origins, nil, protocol.TopOriginIgnoreVerificationMode, "", false, true, publicKey); err != nil {
+		return nil, protocolError(err)
+	}
+
+	authData := parsed.Response.AuthenticatorData
+	if err := verifyBackupFlags(authData.Flags); err != nil {
+		return nil, err
+	}
+	if authData.Flags.HasAttestedCredentialData() {
+		return nil, verificationError("assertion must not carry attested credential data")
+	}
+	if (authData.Counter != 0 || storedSignCount != 0) && authData.Counter <= storedSignCount {
+		return nil, verificationError("signature counter did not increase (stored %d, received %d); the authenticator may have been cloned",
+			storedSignCount, authData.Counter)
+	}
+	return &Assertion{
+		SignCount:    authData.Counter,
+		UserVerified: authData.Flags.HasUserVerified(),
+		BackupState:  authData.Flags.HasBackupState(),
+	}, nil
+}
+
+// protocolError converte os erros do go-webauthn, cuja mensagem é genérica, mantendo o detalhe de depuração.
+func protocolError(err error) error {
+	var protocolErr *protocol.Error
+	if errors.As(err, &protocolErr) && protocolErr.DevInfo != "" {
+		return verificationError("%s: %s", protocolErr.Details, protocolErr.DevInfo)
+	}
+	return verificationError("%s", err)
+}
+
+func verifyBackupFlags(flags protocol.AuthenticatorFlags) error {
+	if flags.HasBackupState() && !flags.HasBackupEligible() {
+		return verificationError("backup state set on a credential that is not backup eligible")
+	}
+	return nil
+}
+
+type clientData struct {
+	Challenge string `json:"challenge"`
+}
+
+func parseClientData(encoded string) (clientData, error) {
+	var data clientData
+	raw, err := decodeBase64URL(encoded)
+	if err != nil {
+		return data, verificationError("clientDataJSON is not valid base64url")
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return data, verificationError("clientDataJSON is not valid JSON")
+	}
+	return data, nil
+}
+
+func credentialID(id, rawID, credentialType string) ([]byte, error) {
+	if credentialType != "public-key" {
+		return nil, verificationError("unexpected credential type %q", credentialType)
+	}
+	decoded, err := decodeBase64URL(id)
+	if err != nil || len(decoded) == 0 || len(decoded) > maxCredentialIDBytes {
+		return nil, verificationError("credential ID is not valid base64url")
+	}
+	if rawID != "" && strings.TrimRight(rawID, "=") != strings.TrimRight(id, "=") {
+		return nil, verificationError("id and rawId differ")
+	}
+	return decoded, nil
+}
+
+// decodeBase64URL aceita base64url com ou sem preenchimento, como os navegadores e bibliotecas enviam.
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
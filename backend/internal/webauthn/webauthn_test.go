@@ -0,0 +1,228 @@
+package webauthn_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"phoenixgrc/backend/internal/webauthn"
+	"phoenixgrc/backend/internal/webauthn/webauthntest"
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rp = webauthn.RelyingParty{ID: "grc.example.com", Name: "Phoenix GRC", Origins: []string{"https://grc.example.com"}}
+
+func newChallenge(t *testing.T) string {
+	t.Helper()
+	challenge, err := webauthn.NewChallenge()
+	require.NoError(t, err)
+	return challenge
+}
+
+func register(t *testing.T, authenticator *webauthntest.Authenticator) *webauthn.Credential {
+	t.Helper()
+	challenge := newChallenge(t)
+	credential, err := rp.VerifyRegistration(authenticator.Register(challenge), challenge)
+	require.NoError(t, err)
+	return credential
+}
+
+func TestRegistrationAndAssertion(t *testing.T) {
+	authenticator := webauthntest.New(rp.ID, rp.Origins[0])
+	credential := register(t, authenticator)
+	assert.Equal(t, authenticator.CredentialID, credential.ID)
+	assert.Equal(t, webauthn.AlgES256, credential.Algorithm)
+	assert.Equal(t, []string{"usb"}, credential.Transports)
+	assert.Zero(t, credential.SignCount)
+
+	challenge := newChallenge(t)
+	resp := authenticator.Assert(challenge)
+	id, err := resp.CredentialID()
+	require.NoError(t, err)
+	assert.Equal(t, credential.ID, id)
+	received, err := resp.Challenge()
+	require.NoError(t, err)
+	assert.Equal(t, challenge, received)
+
+	assertion, err := rp.VerifyAssertion(resp, challenge, credential.PublicKey, credential.SignCount)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, assertion.SignCount)
+	assert.False(t, assertion.UserVerified)
+}
+
+func TestCeremoniesPerAlgorithmAndFormat(t *testing.T) {
+	for _, algorithm := range webauthn.SupportedAlgorithms {
+		for _, format := range []string{"none", "packed"} {
+			t.Run(fmt.Sprintf("%d/%s", algorithm, format), func(t *testing.T) {
+				authenticator := webauthntest.NewWithAlgorithm(rp.ID, rp.Origins[0], algorithm)
+				authenticator.Format = format
+				authenticator.UserVerified = true
+				credential := register(t, authenticator)
+				assert.Equal(t, algorithm, credential.Algorithm)
+				assert.True(t, credential.UserVerified)
+
+				for i := 0; i < 2; i++ {
+					challenge := newChallenge(t)
+					assertion, err := rp.VerifyAssertion(authenticator.Assert(challenge), challenge, credential.PublicKey, credential.SignCount)
+					require.NoError(t, err)
+					assert.Equal(t, authenticator.SignCount, assertion.SignCount)
+					credential.SignCount = assertion.SignCount
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyRegistrationRejects(t *testing.T) {
+	cases := map[string]func(*webauthntest.Authenticator, string) (webauthn.RegistrationResponse, string){
+		"wrong challenge": func(a *webauthntest.Authenticator, challenge string) (webauthn.RegistrationResponse, string) {
+			return a.Register(challenge), base64.RawURLEncoding.EncodeToString([]byte("another challenge"))
+		},
+		"foreign origin": func(a *webauthntest.Authenticator, challenge string) (webauthn.RegistrationResponse, string) {
+			a.Origin = "https://grc.example.com.evil.test"
+			return a.Register(challenge), challenge
+		},
+		"foreign rp id": func(a *webauthntest.Authenticator, challenge string) (webauthn.RegistrationResponse, string) {
+			a.RPID = "evil.test"
+			return a.Register(challenge), challenge
+		},
+		"assertion replayed as registration": func(a *webauthntest.Authenticator, challenge string) (webauthn.RegistrationResponse, string) {
+			resp := a.Register(challenge)
+			resp.Response.ClientDataJSON = a.Assert(challenge).Response.ClientDataJSON
+			return resp, challenge
+		},
+		"credential id mismatch": func(a *webauthntest.Authenticator, challenge string) (webauthn.RegistrationResponse, string) {
+			resp := a.Register(challenge)
+			resp.ID = base64.RawURLEncoding.EncodeToString([]byte("other-credential"))
+			resp.RawID = resp.ID
+			return resp, challenge
+		},
+		"packed statement over other client data": func(a *webauthntest.Authenticator, challenge string) (webauthn.RegistrationResponse, string) {
+			a.Format = "packed"
+			resp := a.Register(challenge)
+			// Mesmo conteúdo com outra serialização: só a assinatura da atestação deixa de conferir
+			raw, _ := base64.RawURLEncoding.DecodeString(resp.Response.ClientDataJSON)
+			var data map[string]interface{}
+			_ = json.Unmarshal(raw, &data)
+			reencoded, _ := json.MarshalIndent(data, "", "  ")
+			resp.Response.ClientDataJSON = base64.RawURLEncoding.EncodeToString(reencoded)
+			return resp, challenge
+		},
+		"truncated attestation": func(a *webauthntest.Authenticator, challenge string) (webauthn.RegistrationResponse, string) {
+			resp := a.Register(challenge)
+			raw, _ := base64.RawURLEncoding.DecodeString(resp.Response.AttestationObject)
+			resp.Response.AttestationObject = base64.RawURLEncoding.EncodeToString(raw[:len(raw)-10])
+			return resp, challenge
+		},
+	}
+	for name, build := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp, challenge := build(webauthntest.New(rp.ID, rp.Origins[0]), newChallenge(t))
+			_, err := rp.VerifyRegistration(resp, challenge)
+			assert.True(t, errors.Is(err, webauthn.ErrVerification), "got %v", err)
+		})
+	}
+}
+
+func TestVerifyAssertionRejects(t *testing.T) {
+	authenticator := webauthntest.New(rp.ID, rp.Origins[0])
+	credential := register(t, authenticator)
+
+	t.Run("signature from another key", func(t *testing.T) {
+		impostor := webauthntest.New(rp.ID, rp.Origins[0])
+		impostor.CredentialID = authenticator.CredentialID
+		challenge := newChallenge(t)
+		_, err := rp.VerifyAssertion(impostor.Assert(challenge), challenge, credential.PublicKey, 0)
+		assert.ErrorIs(t, err, webauthn.ErrVerification)
+	})
+	t.Run("tampered authenticator data", func(t *testing.T) {
+		challenge := newChallenge(t)
+		resp := authenticator.Assert(challenge)
+		raw, _ := base64.RawURLEncoding.DecodeString(resp.Response.AuthenticatorData)
+		raw[32] |= 0x04 // Marca UV depois da assinatura
+		resp.Response.AuthenticatorData = base64.RawURLEncoding.EncodeToString(raw)
+		_, err := rp.VerifyAssertion(resp, challenge, credential.PublicKey, 0)
+		assert.ErrorIs(t, err, webauthn.ErrVerification)
+	})
+	t.Run("sign count regression", func(t *testing.T) {
+		challenge := newChallenge(t)
+		resp := authenticator.Assert(challenge)
+		_, err := rp.VerifyAssertion(resp, challenge, credential.PublicKey, authenticator.SignCount)
+		assert.ErrorIs(t, err, webauthn.ErrVerification)
+	})
+	t.Run("foreign rp id", func(t *testing.T) {
+		challenge := newChallenge(t)
+		authenticator.RPID = "evil.test"
+		resp := authenticator.Assert(challenge)
+		authenticator.RPID = rp.ID
+		_, err := rp.VerifyAssertion(resp, challenge, credential.PublicKey, 0)
+		assert.ErrorIs(t, err, webauthn.ErrVerification)
+	})
+	t.Run("registration replayed as assertion", func(t *testing.T) {
+		challenge := newChallenge(t)
+		resp := authenticator.Assert(challenge)
+		resp.Response.ClientDataJSON = authenticator.Register(challenge).Response.ClientDataJSON
+		_, err := rp.VerifyAssertion(resp, challenge, credential.PublicKey, 0)
+		assert.ErrorIs(t, err, webauthn.ErrVerification)
+	})
+}
+
+func TestOptions(t *testing.T) {
+	descriptor := webauthn.NewCredentialDescriptor([]byte{1, 2, 3}, []string{"usb"})
+	assert.Equal(t, "AQID", descriptor.ID)
+
+	creation := rp.CreationOptions("abc", webauthn.User{ID: []byte{0xff}, Name: "ana@example.com", DisplayName: "Ana"}, nil)
+	assert.Equal(t, "_w", creation.User.ID)
+	assert.Equal(t, "none", creation.Attestation)
+	assert.NotNil(t, creation.ExcludeCredentials)
+	assert.Len(t, creation.PubKeyCredParams, len(webauthn.SupportedAlgorithms))
+
+	request := rp.RequestOptions("abc", []webauthn.CredentialDescriptor{descriptor})
+	assert.Equal(t, rp.ID, request.RPID)
+	assert.Equal(t, webauthn.ChallengeTTL.Milliseconds(), request.Timeout)
+}
+
+func TestFromConfigDefaultsToFrontend(t *testing.T) {
+	previous := config.Cfg
+	t.Cleanup(func() { config.Cfg = previous })
+	config.Cfg.FrontendBaseURL = "https://grc.example.com:8443/app"
+	config.Cfg.WebAuthnRPID, config.Cfg.WebAuthnRPName, config.Cfg.WebAuthnOrigins = "", "", nil
+
+	configured := webauthn.FromConfig()
+	assert.Equal(t, "grc.example.com", configured.ID)
+	assert.Equal(t, []string{"https://grc.example.com:8443"}, configured.Origins)
+	assert.Equal(t, "Phoenix GRC", configured.Name)
+
+	config.Cfg.WebAuthnRPID, config.Cfg.WebAuthnOrigins = "example.com", []string{"https://a.example.com"}
+	configured = webauthn.FromConfig()
+	assert.Equal(t, "example.com", configured.ID)
+	assert.Equal(t, []string{"https://a.example.com"}, configured.Origins)
+}
+
+// FuzzVerifyRegistration alimenta o decodificador CBOR/COSE com attestationObjects arbitrários: toda entrada
+// malformada precisa ser recusada com ErrVerification, sem pânico.
+func FuzzVerifyRegistration(f *testing.F) {
+	authenticator := webauthntest.New(rp.ID, rp.Origins[0])
+	challenge := base64.RawURLEncoding.EncodeToString([]byte("fuzz challenge"))
+	resp := authenticator.Register(challenge)
+	seed, _ := base64.RawURLEncoding.DecodeString(resp.Response.AttestationObject)
+	f.Add(seed)
+	f.Add(seed[:len(seed)/2])
+	f.Add([]byte{0xa3, 0x63, 'f', 'm', 't', 0x64, 'n', 'o', 'n', 'e'})
+
+	f.Fuzz(func(t *testing.T, attestationObject []byte) {
+		mutated := resp
+		mutated.Response.AttestationObject = base64.RawURLEncoding.EncodeToString(attestationObject)
+		credential, err := rp.VerifyRegistration(mutated, challenge)
+		if err != nil {
+			assert.ErrorIs(t, err, webauthn.ErrVerification)
+			return
+		}
+		assert.Equal(t, authenticator.CredentialID, credential.ID)
+	})
+}
@@ -0,0 +1,172 @@
+// Package webauthntest oferece um autenticador WebAuthn em software para testes, no espírito de httptest:
+// gera registros (atestação "none" ou "packed" autoatestada) e asserções ES256, EdDSA ou RS256 como um
+// navegador com uma chave de segurança faria.
+package webauthntest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+
+	"phoenixgrc/backend/internal/webauthn"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+)
+
+// Authenticator é uma chave de segurança vinculada a um rp.id.
+type Authenticator struct {
+	RPID         string
+	Origin       string
+	CredentialID []byte
+	SignCount    uint32
+	// UserVerified marca as respostas com o flag UV, como um autenticador com PIN ou biometria
+	UserVerified bool
+	// Format é o formato de atestação do registro: "none" (padrão) ou "packed", assinada com a própria chave
+	Format string
+
+	algorithm int
+	key       crypto.Signer
+}
+
+// New cria um autenticador ES256 com chave e identificador de credencial aleatórios.
+func New(rpID, origin string) *Authenticator {
+	return NewWithAlgorithm(rpID, origin, webauthn.AlgES256)
+}
+
+// NewWithAlgorithm cria um autenticador com chave do algoritmo COSE informado (ES256, EdDSA ou RS256).
+func NewWithAlgorithm(rpID, origin string, algorithm int) *Authenticator {
+	var (
+		key crypto.Signer
+		err error
+	)
+	switch algorithm {
+	case webauthn.AlgES256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case webauthn.AlgEdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case webauthn.AlgRS256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		panic("webauthntest: unsupported algorithm")
+	}
+	if err != nil {
+		panic(err)
+	}
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		panic(err)
+	}
+	return &Authenticator{RPID: rpID, Origin: origin, CredentialID: credentialID, algorithm: algorithm, key: key}
+}
+
+// Register responde ao desafio de registro.
+func (a *Authenticator) Register(challenge string) webauthn.RegistrationResponse {
+	coseKey := mustMarshal(a.coseKey())
+	attested := make([]byte, 16, 18+len(a.CredentialID)+len(coseKey)) // AAGUID zerado, como na atestação "none"
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.CredentialID)))
+	attested = append(append(attested, a.CredentialID...), coseKey...)
+	authData := a.authenticatorData(0x40, attested)
+	clientData := a.clientData("webauthn.create", challenge)
+
+	format, statement := "none", map[string]interface{}{}
+	if a.Format == "packed" {
+		format = "packed"
+		statement = map[string]interface{}{"alg": int64(a.algorithm), "sig": a.sign(authData, clientData)}
+	}
+
+	var resp webauthn.RegistrationResponse
+	resp.ID = base64.RawURLEncoding.EncodeToString(a.CredentialID)
+	resp.RawID = resp.ID
+	resp.Type = "public-key"
+	resp.Response.ClientDataJSON = clientData
+	resp.Response.AttestationObject = base64.RawURLEncoding.EncodeToString(mustMarshal(map[string]interface{}{
+		"fmt": format, "attStmt": statement, "authData": authData,
+	}))
+	resp.Response.Transports = []string{"usb"}
+	return resp
+}
+
+// Assert responde ao desafio de login, avançando o contador de assinaturas.
+func (a *Authenticator) Assert(challenge string) webauthn.AssertionResponse {
+	a.SignCount++
+	authData := a.authenticatorData(0, nil)
+	clientData := a.clientData("webauthn.get", challenge)
+
+	var resp webauthn.AssertionResponse
+	resp.ID = base64.RawURLEncoding.EncodeToString(a.CredentialID)
+	resp.RawID = resp.ID
+	resp.Type = "public-key"
+	resp.Response.ClientDataJSON = clientData
+	resp.Response.AuthenticatorData = base64.RawURLEncoding.EncodeToString(authData)
+	resp.Response.Signature = base64.RawURLEncoding.EncodeToString(a.sign(authData, clientData))
+	return resp
+}
+
+// sign assina authenticatorData || SHA-256(clientDataJSON), como nas asserções e na atestação "packed".
+func (a *Authenticator) sign(authData []byte, clientData string) []byte {
+	rawClientData, _ := base64.RawURLEncoding.DecodeString(clientData)
+	clientDataHash := sha256.Sum256(rawClientData)
+	signed := append(append([]byte{}, authData...), clientDataHash[:]...)
+
+	var (
+		signature []byte
+		err       error
+	)
+	if a.algorithm == webauthn.AlgEdDSA {
+		signature, err = a.key.Sign(rand.Reader, signed, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(signed)
+		signature, err = a.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return signature
+}
+
+// coseKey é a chave pública no formato COSE_Key (RFC 9053), com os rótulos inteiros da especificação.
+func (a *Authenticator) coseKey() map[int64]interface{} {
+	switch key := a.key.Public().(type) {
+	case *ecdsa.PublicKey:
+		return map[int64]interface{}{1: int64(2), 3: int64(a.algorithm), -1: int64(1), -2: key.X.FillBytes(make([]byte, 32)), -3: key.Y.FillBytes(make([]byte, 32))}
+	case ed25519.PublicKey:
+		return map[int64]interface{}{1: int64(1), 3: int64(a.algorithm), -1: int64(6), -2: []byte(key)}
+	case *rsa.PublicKey:
+		return map[int64]interface{}{1: int64(3), 3: int64(a.algorithm), -1: key.N.Bytes(), -2: big.NewInt(int64(key.E)).Bytes()}
+	default:
+		panic("webauthntest: unsupported key")
+	}
+}
+
+func (a *Authenticator) authenticatorData(flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	flags |= 0x01
+	if a.UserVerified {
+		flags |= 0x04
+	}
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.SignCount)
+	return append(data, attested...)
+}
+
+func (a *Authenticator) clientData(ceremony, challenge string) string {
+	raw, _ := json.Marshal(map[string]interface{}{"type": ceremony, "challenge": challenge, "origin": a.Origin, "crossOrigin": false})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// mustMarshal codifica em CBOR canônico (CTAP2), como os autenticadores.
+func mustMarshal(value interface{}) []byte {
+	encoded, err := webauthncbor.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return encoded
+}
@@ -0,0 +1,75 @@
+package xlsxdoc
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", ColumnName(0))
+	assert.Equal(t, "Z", ColumnName(25))
+	assert.Equal(t, "AA", ColumnName(26))
+	assert.Equal(t, "AZ", ColumnName(51))
+	assert.Equal(t, "BA", ColumnName(52))
+}
+
+func TestAddSheetSanitizesNames(t *testing.T) {
+	w := New()
+	assert.Equal(t, "Riscos-Resumo", w.AddSheet("Riscos/Resumo").Name)
+	long := w.AddSheet(strings.Repeat("x", 40))
+	assert.Len(t, long.Name, MaxSheetNameLength)
+	duplicate := w.AddSheet(strings.Repeat("x", 40))
+	assert.Len(t, duplicate.Name, MaxSheetNameLength)
+	assert.True(t, strings.HasSuffix(duplicate.Name, " (2)"))
+	assert.Equal(t, "Planilha4", w.AddSheet(" ").Name)
+}
+
+func TestWorkbookBytes(t *testing.T) {
+	w := New()
+	sheet := w.AddSheet("Riscos")
+	sheet.Widths = []float64{12, 40}
+	sheet.Header("Código", "Título", "Score")
+	sheet.Row("RISK-0001", "Vazamento <dados> & \"senhas\"", 87.5)
+	sheet.Row("RISK-0002", nil, 3)
+
+	out, err := w.Bytes()
+	require.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+		if strings.HasSuffix(f.Name, ".xml") || strings.HasSuffix(f.Name, ".rels") {
+			assert.NoError(t, xml.Unmarshal(content, new(interface{})), "%s é XML válido", f.Name)
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		assert.Contains(t, files, name)
+	}
+	sheetXML := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheetXML, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Código</t></is></c>`)
+	assert.Contains(t, sheetXML, `Vazamento &lt;dados&gt; &amp; &#34;senhas&#34;`)
+	assert.Contains(t, sheetXML, `<c r="C2"><v>87.5</v></c>`)
+	assert.NotContains(t, sheetXML, `r="B3"`, "nil deixa a célula vazia")
+	assert.Contains(t, sheetXML, `<c r="C3"><v>3</v></c>`)
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Riscos" sheetId="1" r:id="rId1"/>`)
+}
+
+func TestUnsupportedCellType(t *testing.T) {
+	w := New()
+	w.AddSheet("x").Row(true)
+	_, err := w.Bytes()
+	assert.Error(t, err)
+}
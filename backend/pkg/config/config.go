@@ -1,13 +1,11 @@
 package config
 
 import (
-	"log" // Manter log padrão para mensagens de bootstrap antes do logger zap ser configurado
-	"os"
 	"strconv"
 	"strings" // Adicionado para HasPrefix e TrimPrefix
+	"sync/atomic"
 	"time"
 
-	"github.com/joho/godotenv"
 	// phxlog "phoenixgrc/backend/pkg/log" // Não importar aqui para evitar ciclo de importação
 	// "go.uber.org/zap"                 // Não importar aqui
 )
@@ -17,6 +15,17 @@ type AppConfig struct {
 	Port                string
 	JWTSecret           string
 	JWTTokenLifespan    time.Duration
+	JWTRefreshTokenLifespan time.Duration // Validade dos refresh tokens (JWT_REFRESH_TOKEN_LIFESPAN_HOURS)
+	JWTIssuer           string        // iss dos tokens emitidos; vazio usa o padrão do pacote auth
+	JWTAudience         string        // aud gravado nos tokens emitidos e exigido na validação; vazio não exige
+	JWTClockSkew        time.Duration // Tolerância de relógio na validação de exp, nbf e iat
+	JWTGatewayIssuer    string        // Issuer dos tokens de um gateway de autenticação externo; vazio desativa
+	JWTGatewayAudience  string
+	JWTGatewayEmailClaim string // Claim com o e-mail do usuário nos tokens do gateway; vazio usa "email"
+	JWTGatewayPublicKey string // Chave pública PEM do gateway (ou JWT_GATEWAY_PUBLIC_KEY_FILE, ou JWT_GATEWAY_SECRET)
+	JWTGatewayPublicKeyFile string
+	JWTGatewaySecret    string
+	EncryptionKeyHex    string // Chave AES-256 (64 caracteres hexadecimais) dos segredos gravados no banco
 	DBHost              string
 	DBPort              string
 	DBUser              string
@@ -24,18 +33,64 @@ type AppConfig struct {
 	DBName              string
 	DBSchema            string
 	EnableDBSSL         bool
+	DBSSLMode           string // sslmode da conexão com o PostgreSQL (POSTGRES_SSLMODE)
 	Environment         string // "development", "staging", "production" (carregado de APP_ENV)
 	AppVersion          string // Versão da aplicação (carregado de APP_VERSION)
+	LogLevel            string // Nível do log (LOG_LEVEL): debug, info, warn ou error
+	FrontendOAuth2CallbackURL string // Página do frontend que recebe o token após o login OAuth2; vazio usa APP_ROOT_URL
 	GCSProjectID        string
 	GCSBucketName       string
 	AWSRegion           string
 	AWSSESEmailSender   string
 	TOTPIssuerName      string
 	AWSS3Bucket         string // Novo para S3
-	FileStorageProvider string // "gcs" ou "s3"
+	S3Endpoint          string // Endpoint S3-compatível (ex: MinIO). Vazio usa o endpoint padrão da AWS.
+	S3UsePathStyle      bool   // Necessário para a maioria das instalações MinIO
+	S3AccessKeyID       string // Credenciais estáticas opcionais; se vazias, usa a cadeia padrão do SDK
+	S3SecretAccessKey   string
+	FileStorageProvider string // "gcs", "s3", "minio" ou "local"
+	LocalStoragePath    string // Diretório raiz para o provider "local"
+	LocalStorageSigningKey string // Chave HMAC das URLs assinadas do provider "local"; vazia gera uma chave aleatória por processo
+	AppRootURL          string // URL base do backend, usada para montar URLs assinadas do provider local
+	AVScanner           string // "clamav", "webhook" ou vazio (sem verificação antivírus)
+	ClamAVAddress       string // host:port do clamd
+	AVWebhookURL        string
+	AVWebhookToken      string
+	AVScanFailOpen      bool // Se true, aceita uploads quando o scanner está indisponível
 	FrontendBaseURL     string // Adicionado para links em emails/notificações
+	SMSGatewayURL       string // Gateway HTTP para o canal de notificação SMS; vazio apenas registra em log
+	SMSGatewayToken     string
+	APIUsageHourlyLimit int64 // Limite de referência de chamadas por organização por hora; 0 desativa os alertas
+	APIUsageAlertPercent int  // Percentual do limite horário que dispara o alerta de uso
+	VulnAutoRiskCVSSThreshold float64 // Nota CVSS mínima para criar riscos automaticamente na importação de vulnerabilidades
+	BulkConfirmThreshold int // Registros afetados ou notificações a partir dos quais operações em lote exigem token de confirmação; 0 desativa
+	NotificationDailyQuota int64 // Cota flexível de notificações enviadas por operações em lote, por organização, em 24h; 0 desativa
+	FeatureFlagCacheTTLSeconds int // Tempo de cache das feature flags cadastradas no banco
+	DBSlowQueryThreshold time.Duration // Consultas a partir desta duração são registradas como lentas; 0 desativa
+	TracingEnabled bool // Exporta traces OpenTelemetry via OTLP/HTTP (destino em OTEL_EXPORTER_OTLP_ENDPOINT)
+	TracingSampleRatio float64 // Fração dos traces iniciados neste serviço que são amostrados (0 a 1)
+	TrustedProxies []string // Proxies (IPs/CIDRs) cujo X-Forwarded-For é aceito para o IP do cliente; vazio não confia em nenhum
+	JobsWorkerConcurrency int // Workers da fila de jobs iniciados pelo servidor; 0 deixa a fila para um processo worker dedicado
+	JobsPollInterval time.Duration // Intervalo entre as consultas à fila quando ela está vazia
+	SchedulersEnabled bool // Executa os agendadores periódicos (lembretes, relatórios, snapshots) no servidor; false os deixa para o worker
+	WorkerHTTPPort string // Porta do /health e do /metrics do processo worker (cmd/worker)
+	ShutdownTimeout time.Duration // Espera máxima pelas requisições e jobs em andamento no encerramento do servidor (SIGTERM)
+	ShutdownDrainDelay time.Duration // Espera entre sair do /readyz e parar de aceitar conexões, para o balanceador tirar a instância
+	LoginLockoutThreshold int // Falhas de login seguidas (senha ou segundo fator) que bloqueiam a conta; 0 desativa o bloqueio
+	LoginLockoutDuration time.Duration // Duração do bloqueio e janela de contagem das falhas
+	WebAuthnRPID string // Domínio das passkeys (rp.id); vazio usa o host de FRONTEND_BASE_URL
+	WebAuthnRPName string // Nome exibido pelo navegador ao registrar uma passkey
+	WebAuthnOrigins []string // Origens aceitas nas cerimônias WebAuthn; vazio usa a origem de FRONTEND_BASE_URL
+	RateLimitEnabled bool // Liga a limitação de requisições (ver internal/ratelimit)
+	RateLimitStore string // "memory" (por instância) ou "redis" (compartilhado entre as instâncias)
+	RedisURL string // Conexão do Redis, ex: redis://:senha@redis:6379/0
+	RateLimitPolicies map[string]string // Políticas que substituem as padrão, por nome de limitador (RATE_LIMIT_POLICY_<NOME>=30/15m)
+	IdempotencyKeyTTL time.Duration // Por quanto tempo a resposta de uma requisição com Idempotency-Key é reenviada nas repetições
+	TrashRetention time.Duration // Por quanto tempo riscos e políticas excluídos ficam na lixeira antes do expurgo
 	DefaultOrganizationIDForGlobalSSO string `mapstructure:"DEFAULT_ORGANIZATION_ID_FOR_GLOBAL_SSO"`
 	AllowSAMLUserCreation             bool   `mapstructure:"ALLOW_SAML_USER_CREATION"` // Nova config para SAML
+	SAMLSPKeyPEM                      string // Par chave/certificado global do SP SAML; opcional, mas os dois juntos
+	SAMLSPCertPEM                     string
 	GithubClientID                    string `mapstructure:"GITHUB_CLIENT_ID"`
 	GithubClientSecret                string `mapstructure:"GITHUB_CLIENT_SECRET"`
 	GoogleClientID                    string `mapstructure:"GOOGLE_CLIENT_ID"`
@@ -45,105 +100,224 @@ type AppConfig struct {
 	// Adicionar outras configurações aqui
 }
 
+// Cfg é a configuração carregada na inicialização do processo. Reload nunca a altera: as configurações
+// recarregáveis (ver reloadableKeys) devem ser lidas por Get.
 var Cfg AppConfig
 
-// LoadConfig carrega a configuração da aplicação de variáveis de ambiente.
+// current é a configuração em vigor. Cada recarga publica uma cópia nova, nunca alterada depois.
+var current atomic.Pointer[AppConfig]
+
+// Get retorna a configuração em vigor, com as configurações recarregáveis já atualizadas por Reload. O valor
+// retornado não deve ser alterado.
+func Get() *AppConfig {
+	return current.Load()
+}
+
+// LoadConfig carrega a configuração da aplicação das variáveis de ambiente e do arquivo de configuração
+// (CONFIG_FILE, ou .env no diretório atual). Os problemas encontrados ficam disponíveis em Validate.
 func LoadConfig() {
-	// Carregar .env para desenvolvimento local, ignorar erro se não existir (para produção)
-	if err := godotenv.Load(); err != nil {
-		// Usar phxlog aqui pode ser problemático se o logger ainda não estiver 100% configurado
-		// ou se houver dependência cíclica. O log padrão do Go é seguro para esta fase inicial.
-		log.Println("Warning: .env file not found or error loading it:", err)
+	mu.Lock()
+	defer mu.Unlock()
+	file, fileErr := readConfigFile()
+	l := newLoader(file, false)
+	if fileErr != nil {
+		l.problemf("CONFIG_FILE", "%v", fileErr)
 	}
+	cfg := l.load()
+	l.validate(&cfg)
+	Cfg = cfg
+	current.Store(&Cfg) // Até a primeira recarga, a configuração em vigor é a da inicialização
+	loadProblems = l.problems
+	currentValues = l.values
+}
 
-	Cfg.Port = getEnv("SERVER_PORT", "8080")
-	Cfg.JWTSecret = getEnv("JWT_SECRET_KEY", "a_very_secure_secret_key_please_change_me_32_chars_long")
-	jwtLifespanHoursStr := getEnv("JWT_TOKEN_LIFESPAN_HOURS", "24")
-	jwtLifespanHours, err := strconv.Atoi(jwtLifespanHoursStr)
-	if err != nil {
-		log.Printf("Warning: Invalid JWT_TOKEN_LIFESPAN_HOURS ('%s'), using default 24h. Error: %v", jwtLifespanHoursStr, err)
-		jwtLifespanHours = 24
-	}
-	Cfg.JWTTokenLifespan = time.Duration(jwtLifespanHours) * time.Hour
+func (l *loader) load() AppConfig {
+	var cfg AppConfig
+	cfg.Port = l.getEnv("SERVER_PORT", "8080")
+	cfg.JWTSecret = l.getEnv("JWT_SECRET_KEY", "a_very_secure_secret_key_please_change_me_32_chars_long")
+	cfg.JWTTokenLifespan = time.Duration(l.getEnvAsInt("JWT_TOKEN_LIFESPAN_HOURS", 24)) * time.Hour
+	cfg.JWTRefreshTokenLifespan = time.Duration(l.getEnvAsInt("JWT_REFRESH_TOKEN_LIFESPAN_HOURS", 30*24)) * time.Hour
+	cfg.JWTIssuer = strings.TrimSpace(l.getEnv("JWT_ISSUER", ""))
+	cfg.JWTAudience = strings.TrimSpace(l.getEnv("JWT_AUDIENCE", ""))
+	cfg.JWTClockSkew = time.Duration(l.getEnvAsInt("JWT_CLOCK_SKEW_SECONDS", 0)) * time.Second
+	cfg.JWTGatewayIssuer = strings.TrimSpace(l.getEnv("JWT_GATEWAY_ISSUER", ""))
+	cfg.JWTGatewayAudience = strings.TrimSpace(l.getEnv("JWT_GATEWAY_AUDIENCE", ""))
+	cfg.JWTGatewayEmailClaim = strings.TrimSpace(l.getEnv("JWT_GATEWAY_EMAIL_CLAIM", ""))
+	cfg.JWTGatewayPublicKey = l.getEnv("JWT_GATEWAY_PUBLIC_KEY", "")
+	cfg.JWTGatewayPublicKeyFile = l.getEnv("JWT_GATEWAY_PUBLIC_KEY_FILE", "")
+	cfg.JWTGatewaySecret = l.getEnv("JWT_GATEWAY_SECRET", "")
+	cfg.EncryptionKeyHex = l.getEnv("ENCRYPTION_KEY_HEX", "")
 
-	Cfg.DBHost = getEnv("POSTGRES_HOST", "db")
-	Cfg.DBPort = getEnv("POSTGRES_PORT", "5432")
-	Cfg.DBUser = getEnv("POSTGRES_USER", "admin")
-	Cfg.DBPassword = getEnv("POSTGRES_PASSWORD", "password123")
-	Cfg.DBName = getEnv("POSTGRES_DB", "phoenix_grc_dev")
-	Cfg.DBSchema = getEnv("DB_SCHEMA", "phoenix_grc")
-	Cfg.EnableDBSSL = getEnvAsBool("POSTGRES_SSLMODE_ENABLE", false)
+	cfg.DBHost = l.getEnv("POSTGRES_HOST", "db")
+	cfg.DBPort = l.getEnv("POSTGRES_PORT", "5432")
+	cfg.DBUser = l.getEnv("POSTGRES_USER", "admin")
+	cfg.DBPassword = l.getEnv("POSTGRES_PASSWORD", "password123")
+	cfg.DBName = l.getEnv("POSTGRES_DB", "phoenix_grc_dev")
+	cfg.DBSchema = l.getEnv("DB_SCHEMA", "phoenix_grc")
+	cfg.EnableDBSSL = l.getEnvAsBool("POSTGRES_SSLMODE_ENABLE", false)
+	cfg.DBSSLMode = l.getEnv("POSTGRES_SSLMODE", "disable")
 
 	// Padronizar para APP_ENV. GIN_MODE ainda pode ser usado pelo Gin, mas nossa config usa APP_ENV.
-	Cfg.Environment = strings.ToLower(getEnv("APP_ENV", "development"))
-	if Cfg.Environment != "development" && Cfg.Environment != "staging" && Cfg.Environment != "production" {
-		log.Printf("Warning: Invalid APP_ENV value '%s'. Defaulting to 'development'. Allowed: development, staging, production.", Cfg.Environment)
-		Cfg.Environment = "development"
+	cfg.Environment = strings.ToLower(l.getEnv("APP_ENV", "development"))
+	if cfg.Environment != "development" && cfg.Environment != "staging" && cfg.Environment != "production" {
+		l.problemf("APP_ENV", "invalid value %q (allowed: development, staging, production)", cfg.Environment)
+		cfg.Environment = "development"
 	}
-	Cfg.AppVersion = getEnv("APP_VERSION", "0.0.0-dev") // Default version
-
-	Cfg.GCSProjectID = getEnv("GCS_PROJECT_ID", "")
-	Cfg.GCSBucketName = getEnv("GCS_BUCKET_NAME", "")
-
-	Cfg.AWSRegion = getEnv("AWS_REGION", "")
-	Cfg.AWSSESEmailSender = getEnv("AWS_SES_EMAIL_SENDER", "")
-	Cfg.TOTPIssuerName = getEnv("TOTP_ISSUER_NAME", "PhoenixGRC")
-	Cfg.AWSS3Bucket = getEnv("AWS_S3_BUCKET", "")
-	Cfg.FileStorageProvider = strings.ToLower(getEnv("FILE_STORAGE_PROVIDER", "gcs")) // Default para GCS
-	Cfg.FrontendBaseURL = getEnv("FRONTEND_BASE_URL", "http://localhost:3000")
-	Cfg.DefaultOrganizationIDForGlobalSSO = getEnv("DEFAULT_ORGANIZATION_ID_FOR_GLOBAL_SSO", "")
-	Cfg.AllowSAMLUserCreation = getEnvAsBool("ALLOW_SAML_USER_CREATION", false) // Default false
-	Cfg.GithubClientID = getEnv("GITHUB_CLIENT_ID", "")
-	Cfg.GithubClientSecret = getEnv("GITHUB_CLIENT_SECRET", "")
-	Cfg.GoogleClientID = getEnv("GOOGLE_CLIENT_ID", "")
-	Cfg.GoogleClientSecret = getEnv("GOOGLE_CLIENT_SECRET", "")
-	Cfg.AllowGlobalSSOUserCreation = getEnvAsBool("ALLOW_GLOBAL_SSO_USER_CREATION", false)
+	cfg.AppVersion = l.getEnv("APP_VERSION", "0.0.0-dev") // Default version
+	cfg.LogLevel = l.getEnv("LOG_LEVEL", "")
+	cfg.FrontendOAuth2CallbackURL = l.getEnv("FRONTEND_OAUTH2_CALLBACK_URL", "")
+
+	cfg.GCSProjectID = l.getEnv("GCS_PROJECT_ID", "")
+	cfg.GCSBucketName = l.getEnv("GCS_BUCKET_NAME", "")
+
+	cfg.AWSRegion = l.getEnv("AWS_REGION", "")
+	cfg.AWSSESEmailSender = l.getEnv("AWS_SES_EMAIL_SENDER", "")
+	cfg.TOTPIssuerName = l.getEnv("TOTP_ISSUER_NAME", "PhoenixGRC")
+	cfg.AWSS3Bucket = l.getEnv("AWS_S3_BUCKET", "")
+	cfg.S3Endpoint = l.getEnv("S3_ENDPOINT", "")
+	cfg.S3UsePathStyle = l.getEnvAsBool("S3_USE_PATH_STYLE", false)
+	cfg.S3AccessKeyID = l.getEnv("S3_ACCESS_KEY_ID", "")
+	cfg.S3SecretAccessKey = l.getEnv("S3_SECRET_ACCESS_KEY", "")
+	// FILE_STORAGE_BACKEND tem precedência; FILE_STORAGE_PROVIDER é mantido por compatibilidade.
+	cfg.FileStorageProvider = strings.ToLower(l.getEnv("FILE_STORAGE_BACKEND", l.getEnv("FILE_STORAGE_PROVIDER", "gcs"))) // Default para GCS
+	cfg.LocalStoragePath = l.getEnv("LOCAL_STORAGE_PATH", "./data/uploads")
+	cfg.LocalStorageSigningKey = l.getEnv("LOCAL_STORAGE_SIGNING_KEY", "")
+	cfg.AppRootURL = l.getEnv("APP_ROOT_URL", "http://localhost:8080")
+	cfg.AVScanner = l.getEnv("AV_SCANNER", "")
+	cfg.ClamAVAddress = l.getEnv("CLAMAV_ADDRESS", "")
+	cfg.AVWebhookURL = l.getEnv("AV_WEBHOOK_URL", "")
+	cfg.AVWebhookToken = l.getEnv("AV_WEBHOOK_TOKEN", "")
+	cfg.AVScanFailOpen = l.getEnvAsBool("AV_SCAN_FAIL_OPEN", false)
+	cfg.FrontendBaseURL = l.getEnv("FRONTEND_BASE_URL", "http://localhost:3000")
+	cfg.SMSGatewayURL = l.getEnv("SMS_GATEWAY_URL", "")
+	cfg.SMSGatewayToken = l.getEnv("SMS_GATEWAY_TOKEN", "")
+	cfg.APIUsageHourlyLimit = int64(l.getEnvAsInt("API_USAGE_HOURLY_LIMIT", 0))
+	cfg.APIUsageAlertPercent = l.getEnvAsInt("API_USAGE_ALERT_THRESHOLD_PERCENT", 80)
+	cfg.VulnAutoRiskCVSSThreshold = l.getEnvAsFloat("VULN_AUTO_RISK_CVSS_THRESHOLD", 7.0)
+	cfg.BulkConfirmThreshold = l.getEnvAsInt("BULK_CONFIRM_THRESHOLD", 100)
+	cfg.NotificationDailyQuota = int64(l.getEnvAsInt("NOTIFICATION_DAILY_QUOTA", 0))
+	// Não usa o prefixo FEATURE_, reservado aos toggles abaixo
+	cfg.FeatureFlagCacheTTLSeconds = l.getEnvAsInt("FLAGS_CACHE_TTL_SECONDS", 30)
+	cfg.DBSlowQueryThreshold = time.Duration(l.getEnvAsInt("DB_SLOW_QUERY_THRESHOLD_MS", 500)) * time.Millisecond
+	cfg.TracingEnabled = l.getEnvAsBool("TRACING_ENABLED", false)
+	cfg.TracingSampleRatio = l.getEnvAsFloat("TRACING_SAMPLE_RATIO", 1.0)
+	cfg.JobsWorkerConcurrency = l.getEnvAsInt("JOBS_WORKER_CONCURRENCY", 4)
+	cfg.JobsPollInterval = time.Duration(l.getEnvAsInt("JOBS_POLL_INTERVAL_SECONDS", 5)) * time.Second
+	cfg.SchedulersEnabled = l.getEnvAsBool("SCHEDULERS_ENABLED", true)
+	cfg.WorkerHTTPPort = l.getEnv("WORKER_HTTP_PORT", "8081")
+	cfg.ShutdownTimeout = time.Duration(l.getEnvAsInt("SHUTDOWN_TIMEOUT_SECONDS", 30)) * time.Second
+	cfg.ShutdownDrainDelay = time.Duration(l.getEnvAsInt("SHUTDOWN_DRAIN_DELAY_SECONDS", 5)) * time.Second
+	cfg.LoginLockoutThreshold = l.getEnvAsInt("LOGIN_LOCKOUT_THRESHOLD", 5)
+	cfg.LoginLockoutDuration = time.Duration(l.getEnvAsInt("LOGIN_LOCKOUT_MINUTES", 15)) * time.Minute
+	cfg.WebAuthnRPID = l.getEnv("WEBAUTHN_RP_ID", "")
+	cfg.WebAuthnRPName = l.getEnv("WEBAUTHN_RP_NAME", "Phoenix GRC")
+	for _, origin := range strings.Split(l.getEnv("WEBAUTHN_ORIGINS", ""), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			cfg.WebAuthnOrigins = append(cfg.WebAuthnOrigins, origin)
+		}
+	}
+	cfg.IdempotencyKeyTTL = time.Duration(l.getEnvAsInt("IDEMPOTENCY_KEY_TTL_HOURS", 24)) * time.Hour
+	cfg.TrashRetention = time.Duration(l.getEnvAsInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour
+	cfg.RateLimitEnabled = l.getEnvAsBool("RATE_LIMIT_ENABLED", true)
+	cfg.RateLimitStore = strings.ToLower(l.getEnv("RATE_LIMIT_STORE", "memory"))
+	cfg.RedisURL = l.getEnv("REDIS_URL", "")
+	cfg.RateLimitPolicies = make(map[string]string)
+	const rateLimitPolicyPrefix = "RATE_LIMIT_POLICY_"
+	for name, value := range l.values {
+		if strings.HasPrefix(name, rateLimitPolicyPrefix) {
+			cfg.RateLimitPolicies[strings.ToLower(strings.TrimPrefix(name, rateLimitPolicyPrefix))] = value
+		}
+	}
+	// Sem a variável (ou com "none"), o X-Forwarded-For é ignorado: o IP do cliente é o da conexão
+	if proxies := strings.TrimSpace(l.getEnv("TRUSTED_PROXIES", "")); proxies != "" && !strings.EqualFold(proxies, "none") {
+		for _, proxy := range strings.Split(proxies, ",") {
+			if proxy = strings.TrimSpace(proxy); proxy != "" {
+				cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+			}
+		}
+	}
+	cfg.DefaultOrganizationIDForGlobalSSO = l.getEnv("DEFAULT_ORGANIZATION_ID_FOR_GLOBAL_SSO", "")
+	cfg.AllowSAMLUserCreation = l.getEnvAsBool("ALLOW_SAML_USER_CREATION", false) // Default false
+	cfg.SAMLSPKeyPEM = l.getEnv("SAML_SP_KEY_PEM", "")
+	cfg.SAMLSPCertPEM = l.getEnv("SAML_SP_CERT_PEM", "")
+	cfg.GithubClientID = l.getEnv("GITHUB_CLIENT_ID", "")
+	cfg.GithubClientSecret = l.getEnv("GITHUB_CLIENT_SECRET", "")
+	cfg.GoogleClientID = l.getEnv("GOOGLE_CLIENT_ID", "")
+	cfg.GoogleClientSecret = l.getEnv("GOOGLE_CLIENT_SECRET", "")
+	cfg.AllowGlobalSSOUserCreation = l.getEnvAsBool("ALLOW_GLOBAL_SSO_USER_CREATION", false)
 
 	// Carregar Feature Toggles
-	Cfg.FeatureToggles = make(map[string]bool)
+	cfg.FeatureToggles = make(map[string]bool)
 	const featurePrefix = "FEATURE_"
-	for _, e := range os.Environ() {
-		if strings.HasPrefix(e, featurePrefix) {
-			parts := strings.SplitN(e, "=", 2)
-			if len(parts) == 2 {
-				featureName := strings.TrimPrefix(parts[0], featurePrefix)
-				featureValue, err := strconv.ParseBool(parts[1])
-				if err == nil {
-					Cfg.FeatureToggles[featureName] = featureValue
-					log.Printf("Feature Toggle carregado: %s = %t", featureName, featureValue)
-				} else {
-					log.Printf("Aviso: Valor inválido para Feature Toggle %s: %s (esperado true/false)", featureName, parts[1])
-				}
-			}
+	for name, value := range l.values {
+		if !strings.HasPrefix(name, featurePrefix) {
+			continue
+		}
+		featureName := strings.TrimPrefix(name, featurePrefix)
+		featureValue, err := strconv.ParseBool(value)
+		if err != nil {
+			l.problemf(name, "invalid value %q (expected true or false)", value)
+			continue
 		}
+		cfg.FeatureToggles[featureName] = featureValue
+		l.logf("Feature Toggle carregado: %s = %t", featureName, featureValue)
 	}
 
-	log.Printf("Configuração carregada para o ambiente: %s", Cfg.Environment)
+	l.logf("Configuração carregada para o ambiente: %s", cfg.Environment)
+	return cfg
 }
 
-// getEnv retorna o valor de uma variável de ambiente ou um valor default.
-func getEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
+// getEnv retorna o valor de uma variável (do ambiente ou do arquivo de configuração) ou um valor default.
+func (l *loader) getEnv(key, defaultValue string) string {
+	if value, exists := l.values[key]; exists {
 		return value
 	}
-	log.Printf("Variável de ambiente '%s' não definida, usando default: '%s'", key, defaultValue)
+	l.logf("Variável de ambiente '%s' não definida, usando default: '%s'", key, defaultValue)
 	return defaultValue
 }
 
-// getEnvAsBool retorna o valor booleano de uma variável de ambiente ou um valor default.
-func getEnvAsBool(key string, defaultValue bool) bool {
-	valStr := getEnv(key, "")
+// getEnvAsBool retorna o valor booleano de uma variável ou um valor default.
+func (l *loader) getEnvAsBool(key string, defaultValue bool) bool {
+	valStr := l.getEnv(key, "")
 	if valStr == "" {
 		return defaultValue
 	}
 	valBool, err := strconv.ParseBool(valStr)
 	if err != nil {
-		log.Printf("Aviso: Variável de ambiente booleana '%s' com valor inválido '%s', usando default: %t. Erro: %v", key, valStr, defaultValue, err)
+		l.problemf(key, "invalid value %q (expected true or false)", valStr)
 		return defaultValue
 	}
 	return valBool
 }
 
+// getEnvAsInt retorna o valor inteiro de uma variável ou um valor default.
+func (l *loader) getEnvAsInt(key string, defaultValue int) int {
+	valStr := l.getEnv(key, "")
+	if valStr == "" {
+		return defaultValue
+	}
+	valInt, err := strconv.Atoi(valStr)
+	if err != nil {
+		l.problemf(key, "invalid value %q (expected an integer)", valStr)
+		return defaultValue
+	}
+	return valInt
+}
+
+// getEnvAsFloat retorna o valor decimal de uma variável ou um valor default.
+func (l *loader) getEnvAsFloat(key string, defaultValue float64) float64 {
+	valStr := l.getEnv(key, "")
+	if valStr == "" {
+		return defaultValue
+	}
+	valFloat, err := strconv.ParseFloat(valStr, 64)
+	if err != nil {
+		l.problemf(key, "invalid value %q (expected a number)", valStr)
+		return defaultValue
+	}
+	return valFloat
+}
+
 func init() {
 	LoadConfig() // Carregar config automaticamente na inicialização do pacote
 }
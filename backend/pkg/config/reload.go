@@ -0,0 +1,223 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	mu            sync.Mutex
+	loadProblems  []string
+	currentValues map[string]string
+	reloadHooks   []func(*ReloadResult) error
+)
+
+// reloadableKeys são as configurações aplicadas por Reload sem reiniciar o processo; as terminadas em "*"
+// são prefixos. As demais exigem reinício.
+var reloadableKeys = []string{
+	"AWS_REGION", "AWS_SES_EMAIL_SENDER", // E-mail
+	"FEATURE_*", "FLAGS_CACHE_TTL_SECONDS", // Feature flags
+	"RATE_LIMIT_ENABLED", "RATE_LIMIT_POLICY_*", // Limitação de requisições
+}
+
+// applyReloadable copia para dst os campos das configurações recarregáveis. dst é sempre uma cópia nova, e os
+// mapas são substituídos, nunca alterados, para quem já leu a configuração anterior continuar com ela inteira.
+func applyReloadable(dst *AppConfig, src AppConfig) {
+	dst.AWSRegion = src.AWSRegion
+	dst.AWSSESEmailSender = src.AWSSESEmailSender
+	dst.FeatureToggles = src.FeatureToggles
+	dst.FeatureFlagCacheTTLSeconds = src.FeatureFlagCacheTTLSeconds
+	dst.RateLimitEnabled = src.RateLimitEnabled
+	dst.RateLimitPolicies = src.RateLimitPolicies
+}
+
+// ReloadResult descreve o efeito de uma recarga. Apenas os nomes das variáveis são informados, nunca os valores.
+type ReloadResult struct {
+	Changed         []string `json:"changed"`          // Alteradas e aplicadas
+	RestartRequired []string `json:"restart_required"` // Alteradas, mas só aplicadas ao reiniciar o processo
+	Errors          []string `json:"errors"`           // Falhas ao aplicar as alterações (ex: política de limite inválida)
+}
+
+// Affects indica se alguma das variáveis aplicadas corresponde a uma das chaves (as terminadas em "*" são prefixos).
+func (r *ReloadResult) Affects(keys ...string) bool {
+	for _, changed := range r.Changed {
+		if matchesAny(changed, keys) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// OnReload registra uma função chamada após cada recarga que altere alguma configuração recarregável, para o
+// pacote reaplicar o que depende dela (ex: recriar o cliente de e-mail). Um erro é informado no resultado.
+func OnReload(hook func(*ReloadResult) error) {
+	mu.Lock()
+	defer mu.Unlock()
+	reloadHooks = append(reloadHooks, hook)
+}
+
+// Validate retorna os problemas encontrados ao carregar a configuração, para o processo encerrar na
+// inicialização com uma mensagem útil em vez de seguir com valores default inesperados.
+func Validate() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(loadProblems) == 0 {
+		return nil
+	}
+	return problemsError(loadProblems)
+}
+
+func problemsError(problems []string) error {
+	return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+}
+
+// Reload lê de novo o ambiente e o arquivo de configuração (ex: ConfigMap atualizado) e publica em Get uma
+// nova configuração com as recarregáveis aplicadas: e-mail, feature flags e limitação de requisições. Cfg não
+// muda. Uma configuração inválida não altera nada e retorna os problemas encontrados.
+func Reload() (*ReloadResult, error) {
+	mu.Lock()
+	file, err := readConfigFile()
+	if err != nil {
+		mu.Unlock()
+		return nil, err
+	}
+	l := newLoader(file, true)
+	next := l.load()
+	l.validate(&next)
+	if len(l.problems) > 0 {
+		mu.Unlock()
+		return nil, problemsError(l.problems)
+	}
+	result := &ReloadResult{Changed: []string{}, RestartRequired: []string{}, Errors: []string{}}
+	for _, key := range changedKeys(currentValues, l.values) {
+		if matchesAny(key, reloadableKeys) {
+			result.Changed = append(result.Changed, key)
+		} else {
+			result.RestartRequired = append(result.RestartRequired, key)
+		}
+	}
+	snapshot := *Get()
+	applyReloadable(&snapshot, next)
+	current.Store(&snapshot)
+	currentValues = l.values
+	hooks := append([]func(*ReloadResult) error{}, reloadHooks...)
+	mu.Unlock()
+
+	if len(result.Changed) > 0 {
+		for _, hook := range hooks {
+			if err := hook(result); err != nil {
+				result.Errors = append(result.Errors, err.Error())
+			}
+		}
+	}
+	return result, nil
+}
+
+// changedKeys retorna, em ordem alfabética, as variáveis adicionadas, removidas ou alteradas.
+func changedKeys(before, after map[string]string) []string {
+	var keys []string
+	for key, value := range after {
+		if previous, ok := before[key]; !ok || previous != value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validate verifica as combinações e os formatos que o carregamento sozinho não detecta.
+func (l *loader) validate(cfg *AppConfig) {
+	switch cfg.FileStorageProvider {
+	case "gcs", "s3", "minio", "local":
+	default:
+		l.problemf("FILE_STORAGE_BACKEND", "unsupported provider %q (allowed: gcs, s3, minio, local)", cfg.FileStorageProvider)
+	}
+	if cfg.LocalStorageSigningKey != "" && len(cfg.LocalStorageSigningKey) < 32 {
+		l.problemf("LOCAL_STORAGE_SIGNING_KEY", "must be at least 32 characters long")
+	} else if cfg.LocalStorageSigningKey == "" && cfg.FileStorageProvider == "local" && cfg.Environment == "production" {
+		l.problemf("LOCAL_STORAGE_SIGNING_KEY", "required in production when FILE_STORAGE_BACKEND=local")
+	}
+	switch cfg.RateLimitStore {
+	case "memory":
+	case "redis":
+		if cfg.RedisURL == "" {
+			l.problemf("REDIS_URL", "required when RATE_LIMIT_STORE=redis")
+		}
+	default:
+		l.problemf("RATE_LIMIT_STORE", "unsupported store %q (allowed: memory, redis)", cfg.RateLimitStore)
+	}
+	if cfg.AWSSESEmailSender != "" {
+		if _, err := mail.ParseAddress(cfg.AWSSESEmailSender); err != nil {
+			l.problemf("AWS_SES_EMAIL_SENDER", "invalid email address %q", cfg.AWSSESEmailSender)
+		}
+		if cfg.AWSRegion == "" {
+			l.problemf("AWS_REGION", "required to send email when AWS_SES_EMAIL_SENDER is set")
+		}
+	}
+	for key, value := range map[string]string{"APP_ROOT_URL": cfg.AppRootURL, "FRONTEND_BASE_URL": cfg.FrontendBaseURL} {
+		if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			l.problemf(key, "invalid URL %q (expected an absolute http or https URL)", value)
+		}
+	}
+	for key, value := range map[string]string{"SERVER_PORT": cfg.Port, "WORKER_HTTP_PORT": cfg.WorkerHTTPPort} {
+		if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+			l.problemf(key, "invalid port %q", value)
+		}
+	}
+	for _, proxy := range cfg.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				l.problemf("TRUSTED_PROXIES", "invalid IP or CIDR %q", proxy)
+			}
+		}
+	}
+	if cfg.TracingSampleRatio < 0 || cfg.TracingSampleRatio > 1 {
+		l.problemf("TRACING_SAMPLE_RATIO", "must be between 0 and 1, got %g", cfg.TracingSampleRatio)
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		l.problemf("SHUTDOWN_TIMEOUT_SECONDS", "must be greater than zero")
+	}
+	if cfg.ShutdownDrainDelay < 0 {
+		l.problemf("SHUTDOWN_DRAIN_DELAY_SECONDS", "must not be negative")
+	}
+	if cfg.JWTClockSkew < 0 {
+		l.problemf("JWT_CLOCK_SKEW_SECONDS", "must not be negative")
+	}
+	if cfg.JWTRefreshTokenLifespan <= 0 {
+		l.problemf("JWT_REFRESH_TOKEN_LIFESPAN_HOURS", "must be greater than zero")
+	}
+	for key, value := range map[string]int{
+		"JOBS_WORKER_CONCURRENCY": cfg.JobsWorkerConcurrency,
+		"FLAGS_CACHE_TTL_SECONDS": cfg.FeatureFlagCacheTTLSeconds,
+		"LOGIN_LOCKOUT_THRESHOLD": cfg.LoginLockoutThreshold,
+	} {
+		if value < 0 {
+			l.problemf(key, "must not be negative, got %d", value)
+		}
+	}
+	sort.Strings(l.problems)
+}
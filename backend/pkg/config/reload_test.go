@@ -0,0 +1,123 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withConfigFile aponta CONFIG_FILE para um arquivo temporário com o conteúdo informado e restaura o
+// estado do pacote ao fim do teste.
+func withConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "phoenix.env")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(ConfigFileEnv, path)
+
+	previous := Cfg
+	t.Cleanup(func() {
+		mu.Lock()
+		reloadHooks = nil
+		mu.Unlock()
+		Cfg = previous
+		current.Store(&Cfg)
+	})
+	return path
+}
+
+func TestLoadConfigReadsFileAndEnvOverrides(t *testing.T) {
+	withConfigFile(t, "AWS_REGION=us-east-1\nAPP_VERSION=1.2.3\n")
+	t.Setenv("APP_VERSION", "9.9.9")
+
+	LoadConfig()
+
+	require.NoError(t, Validate())
+	assert.Equal(t, "us-east-1", Cfg.AWSRegion)
+	assert.Equal(t, "9.9.9", Cfg.AppVersion, "the process environment wins over the file")
+	assert.Empty(t, os.Getenv("AWS_REGION"), "file values are not exported to the process environment")
+	assert.Same(t, &Cfg, Get())
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	withConfigFile(t, "RATE_LIMIT_STORE=redis\nTRACING_SAMPLE_RATIO=2\nSERVER_PORT=http\n")
+
+	LoadConfig()
+
+	err := Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "REDIS_URL: required when RATE_LIMIT_STORE=redis")
+	assert.Contains(t, err.Error(), "TRACING_SAMPLE_RATIO: must be between 0 and 1")
+	assert.Contains(t, err.Error(), `SERVER_PORT: invalid port "http"`)
+}
+
+func TestValidateMissingConfigFile(t *testing.T) {
+	withConfigFile(t, "")
+	t.Setenv(ConfigFileEnv, filepath.Join(t.TempDir(), "missing.env"))
+
+	LoadConfig()
+
+	err := Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CONFIG_FILE: failed to read configuration file")
+}
+
+func TestReloadAppliesOnlyReloadableSettings(t *testing.T) {
+	path := withConfigFile(t, "FLAGS_CACHE_TTL_SECONDS=30\nSERVER_PORT=8080\n")
+	LoadConfig()
+	require.NoError(t, Validate())
+
+	var hookCalls []*ReloadResult
+	OnReload(func(result *ReloadResult) error {
+		hookCalls = append(hookCalls, result)
+		return nil
+	})
+
+	require.NoError(t, os.WriteFile(path, []byte("FLAGS_CACHE_TTL_SECONDS=5\nSERVER_PORT=9090\nFEATURE_NEW_UI=true\n"), 0o600))
+	result, err := Reload()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"FEATURE_NEW_UI", "FLAGS_CACHE_TTL_SECONDS"}, result.Changed)
+	assert.Equal(t, []string{"SERVER_PORT"}, result.RestartRequired)
+	assert.True(t, result.Affects("FEATURE_*"))
+	assert.False(t, result.Affects("RATE_LIMIT_*"))
+	assert.Equal(t, 5, Get().FeatureFlagCacheTTLSeconds)
+	assert.True(t, Get().FeatureToggles["NEW_UI"])
+	assert.Equal(t, "8080", Get().Port, "settings that require a restart keep their value")
+	assert.Equal(t, 30, Cfg.FeatureFlagCacheTTLSeconds, "the startup snapshot is never changed")
+	require.Len(t, hookCalls, 1)
+
+	// Sem alterações, os hooks não são chamados
+	result, err = Reload()
+	require.NoError(t, err)
+	assert.Empty(t, result.Changed)
+	assert.Len(t, hookCalls, 1)
+}
+
+func TestReloadRejectsInvalidConfiguration(t *testing.T) {
+	path := withConfigFile(t, "FLAGS_CACHE_TTL_SECONDS=30\n")
+	LoadConfig()
+
+	require.NoError(t, os.WriteFile(path, []byte("FLAGS_CACHE_TTL_SECONDS=-1\n"), 0o600))
+	_, err := Reload()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FLAGS_CACHE_TTL_SECONDS: must not be negative")
+	assert.Equal(t, 30, Get().FeatureFlagCacheTTLSeconds, "an invalid configuration changes nothing")
+}
+
+func TestReloadKeepsRestartRequiredSettings(t *testing.T) {
+	path := withConfigFile(t, "JWT_TOKEN_LIFESPAN_HOURS=1\n")
+	LoadConfig()
+	require.NoError(t, Validate())
+
+	require.NoError(t, os.WriteFile(path, []byte("JWT_TOKEN_LIFESPAN_HOURS=48\n"), 0o600))
+	result, err := Reload()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"JWT_TOKEN_LIFESPAN_HOURS"}, result.RestartRequired)
+	assert.Equal(t, time.Hour, Get().JWTTokenLifespan)
+	assert.Empty(t, os.Getenv("JWT_TOKEN_LIFESPAN_HOURS"))
+}
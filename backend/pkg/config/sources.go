@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log" // Log padrão: o logger zap ainda não foi configurado quando a configuração é carregada
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// ConfigFileEnv é a variável com o caminho do arquivo de configuração, no formato KEY=VALUE do .env. Sem
+// ela, o .env do diretório atual é lido, se existir.
+const ConfigFileEnv = "CONFIG_FILE"
+
+const defaultConfigFile = ".env"
+
+// loader lê as variáveis de uma fonte já combinada e acumula os problemas encontrados.
+type loader struct {
+	values   map[string]string
+	quiet    bool // Na recarga, não repete no log os defaults usados
+	problems []string
+}
+
+// newLoader combina as variáveis do ambiente do processo com as do arquivo de configuração. As do ambiente
+// prevalecem. O arquivo nunca é exportado para o ambiente: o código do Phoenix lê apenas Cfg e Get.
+func newLoader(file map[string]string, quiet bool) *loader {
+	values := make(map[string]string, len(file))
+	for key, value := range file {
+		values[key] = value
+	}
+	for _, entry := range os.Environ() {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			values[key] = value
+		}
+	}
+	return &loader{values: values, quiet: quiet}
+}
+
+func (l *loader) logf(format string, args ...interface{}) {
+	if !l.quiet {
+		log.Printf(format, args...)
+	}
+}
+
+// problemf registra um problema de configuração da variável key.
+func (l *loader) problemf(key, format string, args ...interface{}) {
+	problem := key + ": " + fmt.Sprintf(format, args...)
+	l.problems = append(l.problems, problem)
+	log.Printf("Aviso: configuração inválida: %s", problem)
+}
+
+// readConfigFile lê o arquivo de configuração. O .env padrão é opcional; um CONFIG_FILE informado e
+// inexistente é um erro.
+func readConfigFile() (map[string]string, error) {
+	path, explicit := os.LookupEnv(ConfigFileEnv)
+	if !explicit || path == "" {
+		path = defaultConfigFile
+	}
+	values, err := godotenv.Read(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return map[string]string{}, fmt.Errorf("failed to read configuration file %q: %v", path, err)
+	}
+	return values, nil
+}
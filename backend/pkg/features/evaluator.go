@@ -0,0 +1,152 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	phxlog "phoenixgrc/backend/pkg/log"
+
+	"go.uber.org/zap"
+)
+
+// Source carrega as flags cadastradas (ex.: do banco de dados).
+type Source interface {
+	LoadFlags(ctx context.Context) ([]Flag, error)
+}
+
+// Evaluator avalia flags com segmentação, mantendo as flags em cache por ttl.
+type Evaluator struct {
+	source   Source
+	ttl      time.Duration
+	now      func() time.Time
+	mu       sync.RWMutex
+	flags    map[string]Flag
+	loadedAt time.Time
+}
+
+// NewEvaluator cria um avaliador que recarrega as flags da fonte a cada ttl.
+func NewEvaluator(source Source, ttl time.Duration) *Evaluator {
+	return &Evaluator{source: source, ttl: ttl, now: time.Now}
+}
+
+// Invalidate descarta o cache; a próxima avaliação recarrega as flags.
+func (e *Evaluator) Invalidate() {
+	e.mu.Lock()
+	e.flags = nil
+	e.mu.Unlock()
+}
+
+// SetTTL altera o tempo de cache das flags e descarta o cache atual.
+func (e *Evaluator) SetTTL(ttl time.Duration) {
+	e.mu.Lock()
+	e.ttl = ttl
+	e.flags = nil
+	e.mu.Unlock()
+}
+
+// lookup retorna a flag pelo cache, recarregando-o quando expirado. Se a recarga falhar,
+// as flags em cache (mesmo expiradas) continuam valendo e o erro é retornado.
+func (e *Evaluator) lookup(ctx context.Context, key string) (Flag, bool, error) {
+	e.mu.RLock()
+	flags, fresh := e.flags, e.flags != nil && e.now().Sub(e.loadedAt) < e.ttl
+	e.mu.RUnlock()
+	if fresh {
+		flag, ok := flags[key]
+		return flag, ok, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.flags != nil && e.now().Sub(e.loadedAt) < e.ttl {
+		flag, ok := e.flags[key]
+		return flag, ok, nil
+	}
+	loaded, err := e.source.LoadFlags(ctx)
+	if err != nil {
+		flag, ok := e.flags[key]
+		return flag, ok, fmt.Errorf("failed to load feature flags: %w", err)
+	}
+	e.flags = make(map[string]Flag, len(loaded))
+	for _, flag := range loaded {
+		e.flags[flag.Key] = flag
+	}
+	e.loadedAt = e.now()
+	flag, ok := e.flags[key]
+	return flag, ok, nil
+}
+
+// envOverride avalia a variável de ambiente FEATURE_<KEY>, quando definida.
+func envOverride(key string) (Evaluation, bool) {
+	enabled, exists := GetFeatureToggleState(key)
+	if !exists {
+		return Evaluation{}, false
+	}
+	return Evaluation{
+		Key:     key,
+		Enabled: enabled,
+		Reason:  ReasonEnvOverride,
+		Detail:  fmt.Sprintf("environment variable FEATURE_%s=%t overrides the flag", key, enabled),
+	}, true
+}
+
+func notFound(key string) Evaluation {
+	return Evaluation{Key: key, Reason: ReasonFlagNotFound, Detail: "flag is not defined"}
+}
+
+// Evaluate avalia a flag para o sujeito. A variável de ambiente FEATURE_<KEY>, quando definida,
+// tem precedência sobre o cadastro (útil para desligar uma feature em emergência).
+func (e *Evaluator) Evaluate(ctx context.Context, key string, subject Subject) (Evaluation, error) {
+	if evaluation, ok := envOverride(key); ok {
+		return evaluation, nil
+	}
+	flag, ok, err := e.lookup(ctx, key)
+	if !ok {
+		return notFound(key), err
+	}
+	return flag.Evaluate(subject), err
+}
+
+var defaultEvaluator *Evaluator
+
+// Init configura o avaliador usado por IsEnabledFor e Explain.
+func Init(source Source, ttl time.Duration) {
+	defaultEvaluator = NewEvaluator(source, ttl)
+}
+
+// Invalidate descarta o cache do avaliador padrão.
+func Invalidate() {
+	if defaultEvaluator != nil {
+		defaultEvaluator.Invalidate()
+	}
+}
+
+// SetCacheTTL altera o tempo de cache do avaliador padrão (recarga de FLAGS_CACHE_TTL_SECONDS).
+func SetCacheTTL(ttl time.Duration) {
+	if defaultEvaluator != nil {
+		defaultEvaluator.SetTTL(ttl)
+	}
+}
+
+// Explain avalia a flag para o sujeito e retorna o motivo do resultado. Sem avaliador configurado
+// (Init), apenas as variáveis FEATURE_<KEY> são consideradas.
+func Explain(ctx context.Context, key string, subject Subject) (Evaluation, error) {
+	if defaultEvaluator == nil {
+		if evaluation, ok := envOverride(key); ok {
+			return evaluation, nil
+		}
+		return notFound(key), nil
+	}
+	return defaultEvaluator.Evaluate(ctx, key, subject)
+}
+
+// IsEnabledFor verifica se a flag está habilitada para o sujeito, considerando a segmentação
+// (organização, papel e rollout percentual). Erros de carga são registrados em log.
+func IsEnabledFor(ctx context.Context, key string, subject Subject) bool {
+	evaluation, err := Explain(ctx, key, subject)
+	if err != nil {
+		phxlog.L.Warn("Falha ao avaliar feature flag; usando cache anterior", zap.String("flag", key), zap.Error(err))
+	}
+	return evaluation.Enabled
+}
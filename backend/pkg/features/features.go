@@ -9,7 +9,8 @@ import (
 // Os nomes das features são case-insensitive para a verificação,
 // mas são armazenados conforme definidos nas variáveis de ambiente (sem o prefixo FEATURE_).
 func IsEnabled(featureName string) bool {
-	if config.Cfg.FeatureToggles == nil {
+	toggles := config.Get().FeatureToggles // Configuração em vigor, atualizada por config.Reload
+	if toggles == nil {
 		return false // Se o mapa não for inicializado, nenhuma feature está habilitada.
 	}
 
@@ -24,7 +25,7 @@ func IsEnabled(featureName string) bool {
 	// para corresponder a como as variáveis de ambiente são frequentemente definidas.
 	// Ex: featureName = strings.ToUpper(featureName)
 
-	enabled, exists := config.Cfg.FeatureToggles[featureName]
+	enabled, exists := toggles[featureName]
 	if !exists {
 		return false // Feature não definida é considerada desabilitada.
 	}
@@ -35,10 +36,11 @@ func IsEnabled(featureName string) bool {
 // Isso pode ser útil se você precisa distinguir entre uma feature explicitamente desabilitada
 // e uma feature não configurada.
 func GetFeatureToggleState(featureName string) (enabled bool, exists bool) {
-	if config.Cfg.FeatureToggles == nil {
+	toggles := config.Get().FeatureToggles
+	if toggles == nil {
 		return false, false
 	}
 	// featureName = strings.ToUpper(featureName) // Se normalizar
-	enabled, exists = config.Cfg.FeatureToggles[featureName]
+	enabled, exists = toggles[featureName]
 	return enabled, exists
 }
@@ -0,0 +1,123 @@
+package features
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/google/uuid"
+)
+
+// Reason explica o resultado da avaliação de uma flag.
+type Reason string
+
+const (
+	ReasonEnvOverride     Reason = "env_override"     // Variável FEATURE_<KEY> definida
+	ReasonFlagNotFound    Reason = "flag_not_found"   // Flag inexistente: desligada
+	ReasonFlagDisabled    Reason = "flag_disabled"    // Chave geral desligada
+	ReasonRuleMatch       Reason = "rule_match"       // Uma regra de segmentação decidiu
+	ReasonRolloutIncluded Reason = "rollout_included" // Usuário dentro do percentual de rollout
+	ReasonRolloutExcluded Reason = "rollout_excluded" // Usuário fora do percentual de rollout
+)
+
+// Rule é uma regra de segmentação: quando a organização e o papel coincidem (campos vazios
+// valem para todos), o resultado da flag é Enabled.
+type Rule struct {
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	Role           string     `json:"role,omitempty"`
+	Enabled        bool       `json:"enabled"`
+}
+
+// Matches indica se a regra vale para o sujeito.
+func (r Rule) Matches(subject Subject) bool {
+	if r.OrganizationID != nil && *r.OrganizationID != subject.OrganizationID {
+		return false
+	}
+	return r.Role == "" || r.Role == subject.Role
+}
+
+// Flag é uma feature flag com regras de segmentação e rollout percentual.
+type Flag struct {
+	Key               string
+	Enabled           bool   // Chave geral: desligada, a flag fica desligada para todos
+	RolloutPercentage int    // 0-100, aplicado quando nenhuma regra coincide
+	Rules             []Rule // Avaliadas em ordem; a primeira que coincide decide
+}
+
+// Subject é quem está sendo avaliado.
+type Subject struct {
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+	Role           string
+}
+
+// Evaluation é o resultado da avaliação de uma flag para um sujeito, com o motivo.
+type Evaluation struct {
+	Key               string `json:"key"`
+	Enabled           bool   `json:"enabled"`
+	Reason            Reason `json:"reason"`
+	Detail            string `json:"detail"`
+	MatchedRule       *Rule  `json:"matched_rule,omitempty"`
+	MatchedRuleIndex  *int   `json:"matched_rule_index,omitempty"`
+	RolloutPercentage int    `json:"rollout_percentage"`
+	Bucket            *int   `json:"bucket,omitempty"` // 0-99, comparado ao percentual de rollout
+}
+
+// Bucket distribui os sujeitos de forma estável entre 0 e 99 para o rollout da flag. Usa o usuário
+// (ou a organização, quando não há usuário), então o mesmo usuário sempre cai no mesmo grupo.
+func Bucket(key string, subject Subject) int {
+	id := subject.UserID
+	if id == uuid.Nil {
+		id = subject.OrganizationID
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key + ":" + id.String()))
+	return int(h.Sum32() % 100)
+}
+
+// Evaluate avalia a flag para o sujeito: chave geral, regras na ordem e, por fim, rollout percentual.
+func (f Flag) Evaluate(subject Subject) Evaluation {
+	result := Evaluation{Key: f.Key, RolloutPercentage: f.RolloutPercentage}
+	if !f.Enabled {
+		result.Reason = ReasonFlagDisabled
+		result.Detail = "flag is disabled for everyone"
+		return result
+	}
+	for i, rule := range f.Rules {
+		if !rule.Matches(subject) {
+			continue
+		}
+		index, matched := i, rule
+		result.Enabled = rule.Enabled
+		result.Reason = ReasonRuleMatch
+		result.MatchedRule = &matched
+		result.MatchedRuleIndex = &index
+		result.Detail = fmt.Sprintf("targeting rule #%d matched (%s)", i+1, describeRule(rule))
+		return result
+	}
+	bucket := Bucket(f.Key, subject)
+	result.Bucket = &bucket
+	result.Enabled = bucket < f.RolloutPercentage
+	if result.Enabled {
+		result.Reason = ReasonRolloutIncluded
+		result.Detail = fmt.Sprintf("no rule matched; bucket %d is within the %d%% rollout", bucket, f.RolloutPercentage)
+	} else {
+		result.Reason = ReasonRolloutExcluded
+		result.Detail = fmt.Sprintf("no rule matched; bucket %d is outside the %d%% rollout", bucket, f.RolloutPercentage)
+	}
+	return result
+}
+
+func describeRule(rule Rule) string {
+	organization, role := "any organization", "any role"
+	if rule.OrganizationID != nil {
+		organization = "organization " + rule.OrganizationID.String()
+	}
+	if rule.Role != "" {
+		role = "role " + rule.Role
+	}
+	state := "off"
+	if rule.Enabled {
+		state = "on"
+	}
+	return fmt.Sprintf("%s, %s → %s", organization, role, state)
+}
@@ -0,0 +1,133 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"phoenixgrc/backend/pkg/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagEvaluate(t *testing.T) {
+	orgA, orgB := uuid.New(), uuid.New()
+	flag := Flag{
+		Key:               "NEW_MODULE",
+		Enabled:           true,
+		RolloutPercentage: 0,
+		Rules: []Rule{
+			{OrganizationID: &orgA, Role: "user", Enabled: false},
+			{OrganizationID: &orgA, Enabled: true},
+			{Role: "admin", Enabled: true},
+		},
+	}
+
+	userA := Subject{UserID: uuid.New(), OrganizationID: orgA, Role: "user"}
+	result := flag.Evaluate(userA)
+	assert.False(t, result.Enabled)
+	assert.Equal(t, ReasonRuleMatch, result.Reason)
+	require.NotNil(t, result.MatchedRuleIndex)
+	assert.Equal(t, 0, *result.MatchedRuleIndex, "first matching rule wins")
+
+	managerA := Subject{UserID: uuid.New(), OrganizationID: orgA, Role: "manager"}
+	result = flag.Evaluate(managerA)
+	assert.True(t, result.Enabled)
+	assert.Equal(t, 1, *result.MatchedRuleIndex)
+
+	adminB := Subject{UserID: uuid.New(), OrganizationID: orgB, Role: "admin"}
+	assert.True(t, flag.Evaluate(adminB).Enabled)
+
+	userB := Subject{UserID: uuid.New(), OrganizationID: orgB, Role: "user"}
+	result = flag.Evaluate(userB)
+	assert.False(t, result.Enabled)
+	assert.Equal(t, ReasonRolloutExcluded, result.Reason)
+	require.NotNil(t, result.Bucket)
+
+	flag.Enabled = false
+	result = flag.Evaluate(adminB)
+	assert.False(t, result.Enabled)
+	assert.Equal(t, ReasonFlagDisabled, result.Reason)
+}
+
+func TestRolloutIsStableAndProportional(t *testing.T) {
+	flag := Flag{Key: "GRADUAL", Enabled: true, RolloutPercentage: 30}
+	subject := Subject{UserID: uuid.New()}
+	first := flag.Evaluate(subject)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, first.Enabled, flag.Evaluate(subject).Enabled, "same user always gets the same result")
+	}
+
+	enabled := 0
+	const users = 2000
+	for i := 0; i < users; i++ {
+		if flag.Evaluate(Subject{UserID: uuid.New()}).Enabled {
+			enabled++
+		}
+	}
+	assert.InDelta(t, 0.30, float64(enabled)/users, 0.05)
+
+	flag.RolloutPercentage = 100
+	assert.Equal(t, ReasonRolloutIncluded, flag.Evaluate(subject).Reason)
+}
+
+type fakeSource struct {
+	flags []Flag
+	err   error
+	loads int
+}
+
+func (s *fakeSource) LoadFlags(ctx context.Context) ([]Flag, error) {
+	s.loads++
+	return s.flags, s.err
+}
+
+func TestEvaluatorCachesFlags(t *testing.T) {
+	source := &fakeSource{flags: []Flag{{Key: "CACHED", Enabled: true, RolloutPercentage: 100}}}
+	evaluator := NewEvaluator(source, time.Minute)
+	now := time.Now()
+	evaluator.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	result, err := evaluator.Evaluate(ctx, "CACHED", Subject{})
+	require.NoError(t, err)
+	assert.True(t, result.Enabled)
+	_, _ = evaluator.Evaluate(ctx, "CACHED", Subject{})
+	assert.Equal(t, 1, source.loads, "served from cache within the TTL")
+
+	result, err = evaluator.Evaluate(ctx, "MISSING", Subject{})
+	require.NoError(t, err)
+	assert.Equal(t, ReasonFlagNotFound, result.Reason)
+
+	// Expirado com falha na recarga: usa o cache anterior e retorna o erro
+	now = now.Add(2 * time.Minute)
+	source.err = errors.New("db down")
+	result, err = evaluator.Evaluate(ctx, "CACHED", Subject{})
+	assert.Error(t, err)
+	assert.True(t, result.Enabled)
+	assert.Equal(t, 2, source.loads)
+
+	source.err = nil
+	source.flags = []Flag{{Key: "CACHED", Enabled: false}}
+	evaluator.Invalidate()
+	result, err = evaluator.Evaluate(ctx, "CACHED", Subject{})
+	require.NoError(t, err)
+	assert.False(t, result.Enabled)
+	assert.Equal(t, 3, source.loads)
+}
+
+func TestEvaluatorEnvOverride(t *testing.T) {
+	previous := config.Cfg.FeatureToggles
+	config.Cfg.FeatureToggles = map[string]bool{"KILL_SWITCH": false}
+	defer func() { config.Cfg.FeatureToggles = previous }()
+
+	source := &fakeSource{flags: []Flag{{Key: "KILL_SWITCH", Enabled: true, RolloutPercentage: 100}}}
+	result, err := NewEvaluator(source, time.Minute).Evaluate(context.Background(), "KILL_SWITCH", Subject{})
+	require.NoError(t, err)
+	assert.False(t, result.Enabled)
+	assert.Equal(t, ReasonEnvOverride, result.Reason)
+	assert.Equal(t, 0, source.loads)
+}
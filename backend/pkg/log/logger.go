@@ -2,9 +2,10 @@ package log
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
+	"phoenixgrc/backend/pkg/config"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -64,14 +65,14 @@ func Init(logLevel string, env string) {
 // init é chamado quando o pacote é importado pela primeira vez.
 // Configura um logger padrão inicial. Pode ser re-inicializado explicitamente em main.go.
 func init() {
-	// Valores padrão podem vir de variáveis de ambiente
-	logLevel := os.Getenv("LOG_LEVEL")
+	// Valores padrão vêm da configuração, carregada na inicialização do pacote config
+	logLevel := config.Cfg.LogLevel
 	if logLevel == "" {
 		logLevel = "info" // Default log level
 	}
 	// Usar APP_ENV como a fonte primária para o ambiente da aplicação.
 	// GIN_MODE pode ser usado separadamente pelo Gin, mas para logging, APP_ENV é o padrão.
-	appEnv := strings.ToLower(os.Getenv("APP_ENV"))
+	appEnv := config.Cfg.Environment
 	if appEnv != "production" && appEnv != "staging" {
 		appEnv = "development" // Default para development se não for production/staging
 	}